@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"log/slog"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/adapters/mattermost"
 	"github.com/alekspetrov/pilot/internal/adapters/slack"
 	"github.com/alekspetrov/pilot/internal/adapters/telegram"
 	"github.com/alekspetrov/pilot/internal/alerts"
@@ -18,7 +21,10 @@ import (
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/gateway"
 	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/quality"
+	"github.com/alekspetrov/pilot/internal/replay"
+	"github.com/alekspetrov/pilot/internal/retention"
 	"github.com/alekspetrov/pilot/internal/teams"
 )
 
@@ -38,6 +44,19 @@ func (a *telegramBriefAdapter) SendBriefMessage(ctx context.Context, chatID, tex
 	return &briefs.TelegramMessageResponse{MessageID: resp.Result.MessageID}, nil
 }
 
+// briefDigestConfig converts the personal digest config to briefs.DigestConfig
+func briefDigestConfig(c *config.BriefDigestConfig) *briefs.DigestConfig {
+	if c == nil {
+		return nil
+	}
+	return &briefs.DigestConfig{
+		Enabled:   c.Enabled,
+		Schedule:  c.Schedule,
+		Members:   c.Members,
+		SkipEmpty: c.SkipEmpty,
+	}
+}
+
 // telegramApprovalAdapter wraps telegram.Client to satisfy approval.TelegramClient interface
 type telegramApprovalAdapter struct {
 	client *telegram.Client
@@ -104,6 +123,36 @@ func (a *slackApprovalClientAdapter) UpdateInteractiveMessage(ctx context.Contex
 	return a.adapter.UpdateInteractiveMessage(ctx, channel, ts, blocks, text)
 }
 
+// mattermostApprovalClientAdapter wraps mattermost.Client to satisfy approval.MattermostClient interface
+type mattermostApprovalClientAdapter struct {
+	client *mattermost.Client
+}
+
+func (a *mattermostApprovalClientAdapter) CreatePost(ctx context.Context, channelID, message string) (string, error) {
+	post, err := a.client.SendMessage(ctx, channelID, message)
+	if err != nil {
+		return "", err
+	}
+	return post.ID, nil
+}
+
+func (a *mattermostApprovalClientAdapter) UpdatePost(ctx context.Context, postID, message string) error {
+	return a.client.UpdatePost(ctx, postID, message)
+}
+
+func (a *mattermostApprovalClientAdapter) SeedReactions(ctx context.Context, postID string, emojis ...string) error {
+	me, err := a.client.GetMe(ctx)
+	if err != nil {
+		return err
+	}
+	for _, emoji := range emojis {
+		if err := a.client.AddReaction(ctx, me.ID, postID, emoji); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // wireProjectAccessChecker creates and wires a team-based project access checker on the runner (GH-635).
 // It opens the teams DB, resolves the configured member, and returns a cleanup function.
 // Returns nil cleanup if team config is absent or disabled.
@@ -122,22 +171,23 @@ func wireProjectAccessChecker(runner *executor.Runner, cfg *config.Config) func(
 		return nil
 	}
 
-	// Open teams DB (same pilot.db used by memory store)
-	dbPath := cfg.Memory.Path + "/pilot.db"
-	db, err := sql.Open("sqlite", dbPath)
+	// Open teams DB (same pilot.db used by memory store, which also
+	// handles decrypting it when encryption at rest is on)
+	memStore, err := memory.NewStore(cfg.Memory.Path)
 	if err != nil {
 		logging.WithComponent("teams").Warn("failed to open teams DB", slog.Any("error", err))
 		return nil
 	}
 
-	store, err := teams.NewStore(db)
+	store, err := teams.NewStore(memStore.DB())
 	if err != nil {
-		_ = db.Close()
+		_ = memStore.Close()
 		logging.WithComponent("teams").Warn("failed to create teams store", slog.Any("error", err))
 		return nil
 	}
 
 	service := teams.NewService(store)
+	configureTeamService(service, cfg)
 
 	// Resolve team
 	team, err := service.GetTeamByName(cfg.Team.TeamID)
@@ -146,7 +196,7 @@ func wireProjectAccessChecker(runner *executor.Runner, cfg *config.Config) func(
 		team, err = service.GetTeam(cfg.Team.TeamID)
 	}
 	if err != nil || team == nil {
-		_ = db.Close()
+		_ = memStore.Close()
 		logging.WithComponent("teams").Warn("team not found, skipping project access check",
 			slog.String("team", cfg.Team.TeamID))
 		return nil
@@ -155,7 +205,7 @@ func wireProjectAccessChecker(runner *executor.Runner, cfg *config.Config) func(
 	// Resolve member
 	member, err := service.GetMemberByEmail(team.ID, cfg.Team.MemberEmail)
 	if err != nil || member == nil {
-		_ = db.Close()
+		_ = memStore.Close()
 		logging.WithComponent("teams").Warn("member not found in team, skipping project access check",
 			slog.String("email", cfg.Team.MemberEmail),
 			slog.String("team", team.Name))
@@ -171,7 +221,7 @@ func wireProjectAccessChecker(runner *executor.Runner, cfg *config.Config) func(
 		slog.String("member", member.Email),
 		slog.String("role", string(member.Role)))
 
-	return func() { _ = db.Close() }
+	return func() { _ = memStore.Close() }
 }
 
 // getAlertsConfig extracts alerts configuration from the main config
@@ -190,6 +240,8 @@ func getAlertsConfig(cfg *config.Config) *alerts.AlertConfig {
 			Type:       ch.Type,
 			Enabled:    ch.Enabled,
 			Severities: ch.Severities,
+			Member:     ch.Member,
+			QuietHours: ch.QuietHours,
 			Slack:      ch.Slack,     // Same type, direct pass-through
 			Telegram:   ch.Telegram,  // Same type, direct pass-through
 			Email:      ch.Email,     // Same type, direct pass-through
@@ -217,6 +269,8 @@ func getAlertsConfig(cfg *config.Config) *alerts.AlertConfig {
 				Pattern:              r.Condition.Pattern,
 				FilePattern:          r.Condition.FilePattern,
 				Paths:                r.Condition.Paths,
+				RepeatedStalls:       r.Condition.RepeatedStalls,
+				StallWindow:          r.Condition.StallWindow,
 			},
 		})
 	}
@@ -227,7 +281,21 @@ func getAlertsConfig(cfg *config.Config) *alerts.AlertConfig {
 		SuppressDuplicates: alertsCfg.Defaults.SuppressDuplicates,
 	}
 
-	return alerts.FromConfigAlerts(alertsCfg.Enabled, channels, rules, defaults)
+	return alerts.FromConfigAlerts(alertsCfg.Enabled, channels, rules, defaults, alertsCfg.Members...)
+}
+
+// newFairScheduler creates the dispatcher's fair scheduler from
+// orchestrator.fairness. Returns nil when fairness is disabled. Dispatch
+// slots default to orchestrator.max_concurrent.
+func newFairScheduler(cfg *config.Config) *executor.FairScheduler {
+	if cfg.Orchestrator == nil || cfg.Orchestrator.Fairness == nil || !cfg.Orchestrator.Fairness.Enabled {
+		return nil
+	}
+	fairness := *cfg.Orchestrator.Fairness
+	if fairness.MaxConcurrent == 0 {
+		fairness.MaxConcurrent = cfg.Orchestrator.MaxConcurrent
+	}
+	return executor.NewFairScheduler(&fairness)
 }
 
 // qualityCheckerWrapper adapts quality.Executor to executor.QualityChecker interface
@@ -256,11 +324,16 @@ func (w *qualityCheckerWrapper) Check(ctx context.Context) (*executor.QualityOut
 		result.GateDetails = make([]executor.QualityGateDetail, len(outcome.Results.Results))
 		for i, r := range outcome.Results.Results {
 			result.GateDetails[i] = executor.QualityGateDetail{
-				Name:       r.GateName,
-				Passed:     r.Status == quality.StatusPassed,
-				Duration:   r.Duration,
-				RetryCount: r.RetryCount,
-				Error:      r.Error,
+				Name:          r.GateName,
+				Matrix:        r.Matrix,
+				Passed:        r.Status == quality.StatusPassed,
+				Duration:      r.Duration,
+				RetryCount:    r.RetryCount,
+				Error:         r.Error,
+				Findings:      r.Findings,
+				CoverageDelta: r.CoverageDelta,
+				Fingerprint:   r.Fingerprint,
+				Flaky:         r.Flaky,
 			}
 		}
 	}
@@ -283,12 +356,14 @@ func (a *autopilotProviderAdapter) GetActivePRs() []*gateway.AutopilotPRState {
 	result := make([]*gateway.AutopilotPRState, 0, len(prs))
 	for _, pr := range prs {
 		result = append(result, &gateway.AutopilotPRState{
-			PRNumber:   pr.PRNumber,
-			PRURL:      pr.PRURL,
-			Stage:      string(pr.Stage),
-			CIStatus:   string(pr.CIStatus),
-			Error:      pr.Error,
-			BranchName: pr.BranchName,
+			PRNumber:      pr.PRNumber,
+			PRURL:         pr.PRURL,
+			Stage:         string(pr.Stage),
+			CIStatus:      string(pr.CIStatus),
+			Error:         pr.Error,
+			BranchName:    pr.BranchName,
+			Repo:          a.controller.Repository(),
+			QueuePosition: pr.MergeQueuePosition,
 		})
 	}
 	return result
@@ -303,6 +378,212 @@ func (a *autopilotProviderAdapter) IsAutoReleaseEnabled() bool {
 	return cfg.Release != nil && cfg.Release.Enabled
 }
 
+// conflictResolverAdapter runs autopilot conflict resolution passes on the
+// executor, in the checkout of the project the controller's repo belongs to.
+type conflictResolverAdapter struct {
+	runner      *executor.Runner
+	projectPath string
+}
+
+func (a *conflictResolverAdapter) ResolveConflicts(ctx context.Context, req autopilot.ConflictRequest) error {
+	return a.runner.ResolveMergeConflicts(ctx, &executor.MergeConflictTask{
+		ID:          fmt.Sprintf("pr-%d-conflicts", req.PRNumber),
+		Title:       fmt.Sprintf("Resolve merge conflicts in PR #%d (attempt %d)", req.PRNumber, req.Attempt),
+		ProjectPath: a.projectPath,
+		Branch:      req.Branch,
+		BaseBranch:  req.BaseBranch,
+	})
+}
+
+// reviewFixerAdapter runs autopilot review fix passes on the executor, in
+// the checkout of the project the controller's repo belongs to.
+type reviewFixerAdapter struct {
+	runner      *executor.Runner
+	projectPath string
+}
+
+func (a *reviewFixerAdapter) FixReview(ctx context.Context, req autopilot.ReviewFixRequest) error {
+	return a.runner.FixReviewFeedback(ctx, &executor.ReviewFixTask{
+		ID:          fmt.Sprintf("pr-%d-review", req.PRNumber),
+		Title:       fmt.Sprintf("Address review feedback on PR #%d (iteration %d)", req.PRNumber, req.Iteration),
+		ProjectPath: a.projectPath,
+		Branch:      req.Branch,
+		PRNumber:    req.PRNumber,
+		Feedback:    req.Feedback,
+	})
+}
+
+// wireBranchFixers lets the controller resolve merge conflicts and fix
+// review feedback on PR branches with the runner. Repos without a
+// configured project checkout are left without either.
+func wireBranchFixers(controller *autopilot.Controller, runner *executor.Runner, cfg *config.Config, repo string) {
+	proj := cfg.FindProjectByRepo(repo)
+	if proj == nil && cfg.Adapters != nil && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Repo == repo {
+		proj = cfg.GetDefaultProject()
+	}
+	if proj == nil || proj.Path == "" || runner == nil {
+		return
+	}
+	controller.SetConflictResolver(&conflictResolverAdapter{runner: runner, projectPath: proj.Path})
+	controller.SetReviewFixer(&reviewFixerAdapter{runner: runner, projectPath: proj.Path})
+}
+
+// codeReviewerAdapter reviews autopilot PRs with the executor's code
+// reviewer.
+type codeReviewerAdapter struct {
+	reviewer *executor.CodeReviewer
+}
+
+func (a *codeReviewerAdapter) ReviewPR(ctx context.Context, req autopilot.ReviewRequest) (*autopilot.ReviewResult, error) {
+	body := ""
+	if req.IssueNumber > 0 {
+		body = fmt.Sprintf("Implements issue #%d", req.IssueNumber)
+	}
+	review, err := a.reviewer.Review(ctx, req.PRTitle, body, req.Diff)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &autopilot.ReviewResult{
+		Verdict: autopilot.ReviewVerdict(review.Verdict),
+		Summary: review.Summary,
+	}
+	for _, f := range review.Findings {
+		result.Findings = append(result.Findings, autopilot.ReviewFinding{
+			File:     f.File,
+			Line:     f.Line,
+			Severity: f.Severity,
+			Category: f.Category,
+			Message:  f.Message,
+		})
+	}
+	return result, nil
+}
+
+// wireCodeReviewer gives the controller a code reviewer using the model
+// router's review model when code_review is enabled. Findings are kept in
+// store when one is open.
+func wireCodeReviewer(controller *autopilot.Controller, runner *executor.Runner, store *memory.Store) {
+	apCfg := controller.Config()
+	if apCfg == nil || apCfg.CodeReview == nil || !apCfg.CodeReview.Enabled {
+		return
+	}
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		logging.WithComponent("autopilot").Warn("code_review enabled but ANTHROPIC_API_KEY is not set, PRs will not be reviewed",
+			slog.String("repo", controller.Repository()))
+		return
+	}
+
+	model := ""
+	if runner != nil && runner.ModelRouter() != nil {
+		model = runner.ModelRouter().ReviewModel()
+	}
+	controller.SetCodeReviewer(&codeReviewerAdapter{reviewer: executor.NewCodeReviewer(apiKey, model)})
+	if store != nil {
+		controller.SetReviewStore(store)
+	}
+}
+
+// startRetention runs the daily retention sweep when retention ages are
+// configured. Reports of sweeps that removed data are kept next to the store.
+func startRetention(ctx context.Context, cfg *config.Config, store *memory.Store) {
+	if store == nil || !cfg.Retention.Enabled() {
+		return
+	}
+	var teamsStore *teams.Store
+	if ts, err := teams.NewStore(store.DB()); err != nil {
+		logging.WithComponent("retention").Warn("failed to open teams store, identity retention disabled", slog.Any("error", err))
+	} else {
+		teamsStore = ts
+	}
+	purger := retention.NewPurger(store, teamsStore, replay.DefaultRecordingsPath())
+	go purger.Run(ctx, cfg.Retention, purgeReportDir(cfg))
+}
+
+// persistGitHubETags keeps the ETags of polled GitHub responses in the
+// store, so unchanged issues, PRs and check runs still cost no quota after
+// a restart.
+func persistGitHubETags(store *memory.Store) {
+	if store == nil {
+		return
+	}
+	etags, err := autopilot.NewStateStore(store.DB())
+	if err != nil {
+		logging.WithComponent("github").Warn("failed to open ETag store, conditional requests kept in memory", slog.Any("error", err))
+		return
+	}
+	if purged, err := etags.PurgeOldETags(7 * 24 * time.Hour); err == nil && purged > 0 {
+		logging.WithComponent("github").Debug("purged stale ETags", slog.Int64("count", purged))
+	}
+	github.SetETagStore(etags)
+}
+
+// wireGitHubWebhooks routes GitHub webhook deliveries received by the
+// gateway in polling mode. PR reviews go to the default repo's autopilot
+// controller (GH-2080). With a webhook feed, issue events poke the repo's
+// poller and pull request and check run events wake its controller.
+func wireGitHubWebhooks(gw *gateway.Server, cfg *config.Config, feed *github.WebhookFeed, defaultController *autopilot.Controller, controllers map[string]*autopilot.Controller) {
+	if cfg.Adapters.GitHub == nil || !cfg.Adapters.GitHub.Enabled || (defaultController == nil && feed == nil) {
+		return
+	}
+	token := cfg.Adapters.GitHub.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return
+	}
+
+	ghWH := github.NewWebhookHandler(github.NewClient(token), cfg.Adapters.GitHub.WebhookSecret, cfg.Adapters.GitHub.PilotLabel)
+	if defaultController != nil {
+		ghWH.OnPRReview(func(ctx context.Context, prNumber int, action, state, reviewer string, repo *github.Repository) error {
+			if action == "submitted" {
+				defaultController.OnReviewRequested(prNumber, action, state, reviewer)
+			}
+			return nil
+		})
+	}
+	if feed != nil {
+		ghWH.OnIssueActivity(func(ctx context.Context, repo *github.Repository, number int) {
+			if !feed.Poke(repo.FullName) {
+				logging.WithComponent("github").Debug("webhook for unpolled repo", slog.String("repo", repo.FullName))
+			}
+		})
+		ghWH.OnPRActivity(func(ctx context.Context, repo *github.Repository, number int) {
+			for name, controller := range controllers {
+				if strings.EqualFold(name, repo.FullName) {
+					controller.Wake()
+				}
+			}
+		})
+	}
+	gw.Router().RegisterWebhookHandler("github", func(payload map[string]interface{}) {
+		if feed != nil {
+			feed.Delivered()
+		}
+		eventType, _ := payload["_event_type"].(string)
+		if err := ghWH.Handle(context.Background(), eventType, payload); err != nil {
+			logging.WithComponent("pilot").Error("GitHub webhook error (polling mode)", slog.Any("error", err))
+		}
+	})
+}
+
+// portalRepoResolver names repos in the portal API by the configured
+// project's GitHub owner/repo, falling back to the project name.
+func portalRepoResolver(cfg *config.Config) gateway.PortalRepoResolver {
+	return func(projectPath string) string {
+		proj := findProjectByPath(cfg, projectPath)
+		if proj == nil {
+			return ""
+		}
+		if proj.GitHub != nil && proj.GitHub.Owner != "" && proj.GitHub.Repo != "" {
+			return proj.GitHub.Owner + "/" + proj.GitHub.Repo
+		}
+		return proj.Name
+	}
+}
+
 // resolveOwnerRepo determines the GitHub owner and repo from config or git remote.
 func resolveOwnerRepo(cfg *config.Config) (string, string, error) {
 	// Try config first
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func newAutopilotExplainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <pr-number>",
+		Short: "Explain the merge policy decision for a PR",
+		Long: `Evaluate orchestrator.autopilot.merge_policy against a PR without merging
+or requesting approval, and show which rules matched and why the PR would be
+auto-merged or held for review.
+
+Diff size, touched paths and author are read from GitHub. The intent judge
+confidence is read from the autopilot state store when the PR is tracked.
+
+Examples:
+  pilot autopilot explain 142`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			prNumber, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid PR number: %s", args[0])
+			}
+
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			autopilotCfg := autopilot.DefaultConfig()
+			if cfg.Orchestrator != nil && cfg.Orchestrator.Autopilot != nil {
+				autopilotCfg = cfg.Orchestrator.Autopilot
+			}
+			if autopilotCfg.MergePolicy == nil {
+				fmt.Println("No merge_policy configured")
+				fmt.Printf("   The %s environment's require_approval setting decides every PR\n", autopilotCfg.EnvironmentName())
+				return nil
+			}
+
+			ghToken := ""
+			if cfg.Adapters.GitHub != nil {
+				ghToken = cfg.Adapters.GitHub.Token
+			}
+			if ghToken == "" {
+				ghToken = os.Getenv("GITHUB_TOKEN")
+			}
+			if ghToken == "" {
+				return fmt.Errorf("GitHub not configured - set github.token in config or GITHUB_TOKEN env var")
+			}
+
+			owner, repo, err := resolveOwnerRepo(cfg)
+			if err != nil {
+				return err
+			}
+			ghClient := github.NewClient(ghToken)
+
+			prState := lookupPRState(cfg, prNumber)
+			if prState == nil {
+				pr, err := ghClient.GetPullRequest(ctx, owner, repo, prNumber)
+				if err != nil {
+					return fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
+				}
+				prState = &autopilot.PRState{PRNumber: prNumber}
+				_, _ = fmt.Sscanf(pr.Head.Ref, "pilot/GH-%d", &prState.IssueNumber)
+			}
+
+			merger := autopilot.NewAutoMerger(ghClient, nil, nil, owner, repo, autopilotCfg)
+			eval, err := merger.EvaluateMergePolicy(ctx, prState)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("🔍 Merge policy for PR #%d\n", prNumber)
+			fmt.Println("───────────────────────────────────────")
+			fmt.Print(eval.Explain())
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// lookupPRState returns the PR's tracked autopilot state, or nil when the
+// state store is unavailable or the PR is not tracked.
+func lookupPRState(cfg *config.Config, prNumber int) *autopilot.PRState {
+	if cfg.Memory == nil {
+		return nil
+	}
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = store.Close() }()
+
+	stateStore, err := autopilot.NewStateStore(store.DB())
+	if err != nil {
+		return nil
+	}
+	prState, err := stateStore.GetPRState(prNumber)
+	if err != nil {
+		return nil
+	}
+	return prState
+}
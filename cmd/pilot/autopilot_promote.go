@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// openPipeline loads config and returns the environment promotion pipeline
+// backed by the autopilot state store. The caller must close the returned
+// store.
+func openPipeline() (*autopilot.Pipeline, *memory.Store, error) {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Orchestrator == nil || cfg.Orchestrator.Autopilot == nil ||
+		cfg.Orchestrator.Autopilot.Pipeline == nil || !cfg.Orchestrator.Autopilot.Pipeline.Enabled {
+		return nil, nil, fmt.Errorf("promotion pipeline not enabled - set orchestrator.autopilot.pipeline.enabled in config")
+	}
+
+	ghToken := ""
+	if cfg.Adapters.GitHub != nil {
+		ghToken = cfg.Adapters.GitHub.Token
+	}
+	if ghToken == "" {
+		ghToken = os.Getenv("GITHUB_TOKEN")
+	}
+	if ghToken == "" {
+		return nil, nil, fmt.Errorf("GitHub not configured - set github.token in config or GITHUB_TOKEN env var")
+	}
+
+	owner, repo, err := resolveOwnerRepo(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store, stateStore, err := openAutopilotStateStore(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline := autopilot.NewPipeline(github.NewClient(ghToken), owner, repo, cfg.Orchestrator.Autopilot, stateStore, nil, nil)
+	return pipeline, store, nil
+}
+
+// openAutopilotStateStore opens the autopilot state store in the memory
+// database. The caller must close the returned store.
+func openAutopilotStateStore(cfg *config.Config) (*memory.Store, *autopilot.StateStore, error) {
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open memory store: %w", err)
+	}
+	stateStore, err := autopilot.NewStateStore(store.DB())
+	if err != nil {
+		_ = store.Close()
+		return nil, nil, err
+	}
+	return store, stateStore, nil
+}
+
+func newAutopilotPromoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote <pr>",
+		Short: "Promote a merged PR to the next pipeline environment",
+		Long: `Promote a merged PR's change through the environment pipeline configured
+in orchestrator.autopilot.pipeline.
+
+Autopilot merges PRs into the first environment's branch and cherry-picks
+each change onto the next environment's branch once its CI has stayed green
+for the soak time. Environments with require_approval wait for this command,
+which promotes the change right away.
+
+Examples:
+  pilot autopilot promote 42   # Promote PR #42's change to the next environment`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var prNumber int
+			if _, err := fmt.Sscanf(args[0], "%d", &prNumber); err != nil || prNumber <= 0 {
+				return fmt.Errorf("invalid PR number: %s", args[0])
+			}
+
+			pipeline, store, err := openPipeline()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = store.Close() }()
+
+			approvedBy := os.Getenv("USER")
+			if approvedBy == "" {
+				approvedBy = "cli"
+			}
+
+			change, err := pipeline.Promote(context.Background(), prNumber, approvedBy)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("🚢 PR #%d promoted to %s (%s)\n", change.PRNumber, change.Stage, autopilot.ShortSHA(change.SHA))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// printPipelineChanges prints the most recent changes in the promotion
+// pipeline for pilot autopilot status.
+func printPipelineChanges(changes []*autopilot.ChangePromotion) {
+	fmt.Println("Pipeline:")
+	if len(changes) == 0 {
+		fmt.Println("  No changes recorded")
+		return
+	}
+	for _, c := range changes {
+		line := fmt.Sprintf("  PR #%-6d %-8s %-18s %s ago", c.PRNumber, c.Stage, c.Status, time.Since(c.UpdatedAt).Truncate(time.Minute))
+		if c.Error != "" {
+			line += " ⚠️  " + c.Error
+		}
+		fmt.Println(line)
+	}
+}
@@ -46,6 +46,42 @@ var supportedBackends = []backendInfo{
 			return strings.TrimSpace(string(out))
 		},
 	},
+	{
+		Name:      executor.BackendTypeOpenAI,
+		Command:   "codex",
+		ConfigKey: "openai",
+		getVersion: func(cmd string) string {
+			out, err := exec.Command(cmd, "--version").Output()
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSpace(string(out))
+		},
+	},
+	{
+		Name:      executor.BackendTypeGemini,
+		Command:   "gemini",
+		ConfigKey: "gemini",
+		getVersion: func(cmd string) string {
+			out, err := exec.Command(cmd, "--version").Output()
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSpace(string(out))
+		},
+	},
+	{
+		Name:      executor.BackendTypeOllama,
+		Command:   "ollama",
+		ConfigKey: "ollama",
+		getVersion: func(cmd string) string {
+			out, err := exec.Command(cmd, "--version").Output()
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSpace(string(out))
+		},
+	},
 	{
 		Name:      executor.BackendTypeOpenCode,
 		Command:   "opencode",
@@ -64,7 +100,7 @@ func newBackendCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "backend",
 		Short: "Manage execution backends",
-		Long: `Manage AI execution backends (Claude Code, Qwen Code, OpenCode).
+		Long: `Manage AI execution backends (Claude Code, Qwen Code, OpenCode, OpenAI Codex, Gemini CLI, Ollama).
 
 List supported backends, check their status, and switch the active backend.`,
 	}
@@ -123,6 +159,14 @@ Example output:
 						if cfg.Executor.QwenCode != nil && cfg.Executor.QwenCode.Command != "" {
 							command = cfg.Executor.QwenCode.Command
 						}
+					case executor.BackendTypeOpenAI:
+						if cfg.Executor.OpenAI != nil && cfg.Executor.OpenAI.Command != "" {
+							command = cfg.Executor.OpenAI.Command
+						}
+					case executor.BackendTypeGemini:
+						if cfg.Executor.Gemini != nil && cfg.Executor.Gemini.Command != "" {
+							command = cfg.Executor.Gemini.Command
+						}
 					case executor.BackendTypeOpenCode:
 						// OpenCode uses server command
 						if cfg.Executor.OpenCode != nil && cfg.Executor.OpenCode.ServerCommand != "" {
@@ -208,6 +252,14 @@ Example output:
 					if cfg.Executor.QwenCode != nil && cfg.Executor.QwenCode.Command != "" {
 						command = cfg.Executor.QwenCode.Command
 					}
+				case executor.BackendTypeOpenAI:
+					if cfg.Executor.OpenAI != nil && cfg.Executor.OpenAI.Command != "" {
+						command = cfg.Executor.OpenAI.Command
+					}
+				case executor.BackendTypeGemini:
+					if cfg.Executor.Gemini != nil && cfg.Executor.Gemini.Command != "" {
+						command = cfg.Executor.Gemini.Command
+					}
 				case executor.BackendTypeOpenCode:
 					if cfg.Executor.OpenCode != nil && cfg.Executor.OpenCode.ServerCommand != "" {
 						parts := strings.Fields(cfg.Executor.OpenCode.ServerCommand)
@@ -253,6 +305,29 @@ Example output:
 					if cfg.Executor.QwenCode != nil {
 						fmt.Printf("  executor.qwen_code.command: %s\n", command)
 					}
+				case executor.BackendTypeOpenAI:
+					if cfg.Executor.OpenAI != nil {
+						fmt.Printf("  executor.openai.command: %s\n", command)
+						if cfg.Executor.OpenAI.Model != "" {
+							fmt.Printf("  executor.openai.model: %s\n", cfg.Executor.OpenAI.Model)
+						}
+					}
+				case executor.BackendTypeGemini:
+					if cfg.Executor.Gemini != nil {
+						fmt.Printf("  executor.gemini.command: %s\n", command)
+						if cfg.Executor.Gemini.Model != "" {
+							fmt.Printf("  executor.gemini.model: %s\n", cfg.Executor.Gemini.Model)
+						}
+					}
+				case executor.BackendTypeOllama:
+					if cfg.Executor.Ollama != nil {
+						if cfg.Executor.Ollama.Host != "" {
+							fmt.Printf("  executor.ollama.host: %s\n", cfg.Executor.Ollama.Host)
+						}
+						if cfg.Executor.Ollama.Model != "" {
+							fmt.Printf("  executor.ollama.model: %s\n", cfg.Executor.Ollama.Model)
+						}
+					}
 				case executor.BackendTypeOpenCode:
 					if cfg.Executor.OpenCode != nil {
 						if cfg.Executor.OpenCode.ServerURL != "" {
@@ -273,7 +348,7 @@ func newBackendSetCmd() *cobra.Command {
 		Short: "Set active backend",
 		Long: `Switch the active backend in the config file.
 
-Valid types: claude-code, qwen-code, opencode
+Valid types: claude-code, qwen-code, opencode, openai, gemini, ollama
 
 Example:
   pilot backend set qwen-code
@@ -284,18 +359,8 @@ Example:
 			backendType := args[0]
 
 			// Validate backend type
-			validTypes := []string{
-				executor.BackendTypeClaudeCode,
-				executor.BackendTypeQwenCode,
-				executor.BackendTypeOpenCode,
-			}
-			isValid := false
-			for _, t := range validTypes {
-				if backendType == t {
-					isValid = true
-					break
-				}
-			}
+			validTypes := executor.RegisteredBackends()
+			isValid := executor.IsRegisteredBackend(backendType)
 			if !isValid {
 				return fmt.Errorf("invalid backend type: %s\nValid types: %s", backendType, strings.Join(validTypes, ", "))
 			}
@@ -351,6 +416,14 @@ Example:
 					if cfg.Executor.QwenCode != nil && cfg.Executor.QwenCode.Command != "" {
 						command = cfg.Executor.QwenCode.Command
 					}
+				case executor.BackendTypeOpenAI:
+					if cfg.Executor.OpenAI != nil && cfg.Executor.OpenAI.Command != "" {
+						command = cfg.Executor.OpenAI.Command
+					}
+				case executor.BackendTypeGemini:
+					if cfg.Executor.Gemini != nil && cfg.Executor.Gemini.Command != "" {
+						command = cfg.Executor.Gemini.Command
+					}
 				case executor.BackendTypeOpenCode:
 					if cfg.Executor.OpenCode != nil && cfg.Executor.OpenCode.ServerCommand != "" {
 						parts := strings.Fields(cfg.Executor.OpenCode.ServerCommand)
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/slack"
+	"github.com/alekspetrov/pilot/internal/adapters/telegram"
+	"github.com/alekspetrov/pilot/internal/briefs"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+// runDigestPreview prints each member's personal digest and offers to send
+// them. Pending approvals live in the running Pilot instance, so the preview
+// only covers task activity.
+func runDigestPreview(cfg *config.Config, store *memory.Store, briefsConfig *briefs.BriefConfig) error {
+	teamStore, err := teams.NewStore(store.DB())
+	if err != nil {
+		return fmt.Errorf("failed to open team store: %w", err)
+	}
+	generator := briefs.NewDigestGenerator(store, teams.NewService(teamStore), nil, briefsConfig)
+
+	digests, err := generator.GenerateDaily()
+	if err != nil {
+		return fmt.Errorf("failed to generate digests: %w", err)
+	}
+	if len(digests) == 0 {
+		fmt.Println("No team members with a Telegram or Slack mapping opted in")
+		fmt.Println("   Map members with: pilot team member update <team> <email> --telegram / --slack")
+		return nil
+	}
+
+	fmt.Printf("📬 Personal Digests (%d)\n", len(digests))
+	for _, d := range digests {
+		fmt.Println("───────────────────────────────────────")
+		fmt.Printf("To: %s\n\n", d.Member.DisplayName())
+		fmt.Print(briefs.FormatDigest(d))
+	}
+	fmt.Println("───────────────────────────────────────")
+	fmt.Printf("📤 Send %d digest(s)? [y/N]: ", len(digests))
+
+	var input string
+	_, _ = fmt.Scanln(&input)
+	if strings.ToLower(input) != "y" {
+		return nil
+	}
+
+	var deliveryOpts []briefs.DeliveryOption
+	if cfg.Adapters.Slack != nil && cfg.Adapters.Slack.Enabled {
+		deliveryOpts = append(deliveryOpts, briefs.WithSlackClient(slack.NewClient(cfg.Adapters.Slack.BotToken)))
+	}
+	if cfg.Adapters.Telegram != nil && cfg.Adapters.Telegram.Enabled {
+		tgClient := telegram.NewClient(cfg.Adapters.Telegram.BotToken)
+		deliveryOpts = append(deliveryOpts, briefs.WithTelegramSender(&telegramBriefAdapter{client: tgClient}))
+	}
+	deliveryOpts = append(deliveryOpts, briefs.WithLogger(slog.Default()))
+	delivery := briefs.NewDeliveryService(briefsConfig, deliveryOpts...)
+
+	fmt.Println()
+	for _, d := range digests {
+		if d.IsEmpty() && briefsConfig.Digest != nil && briefsConfig.Digest.SkipEmpty {
+			continue
+		}
+		for _, result := range delivery.DeliverDigest(context.Background(), d) {
+			if result.Success {
+				fmt.Printf("   ✅ %s → %s delivered\n", d.Member.DisplayName(), result.Channel)
+			} else {
+				fmt.Printf("   ❌ %s → %s failed: %v\n", d.Member.DisplayName(), result.Channel, result.Error)
+			}
+		}
+	}
+	return nil
+}
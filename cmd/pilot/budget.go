@@ -53,6 +53,7 @@ func newBudgetCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		newBudgetStatusCmd(),
+		newBudgetForecastCmd(),
 		newBudgetConfigCmd(),
 		newBudgetSetCmd(),
 		newBudgetResetCmd(),
@@ -96,9 +97,13 @@ func newBudgetStatusCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to get budget status: %w", err)
 			}
+			buckets, err := enforcer.GetBucketStatuses(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get budget buckets: %w", err)
+			}
 
 			// Render and print
-			output := renderBudgetStatus(budgetCfg, status)
+			output := renderBudgetStatus(budgetCfg, status, buckets)
 			fmt.Print(output)
 
 			return nil
@@ -110,8 +115,88 @@ func newBudgetStatusCmd() *cobra.Command {
 	return cmd
 }
 
+func newBudgetForecastCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "forecast",
+		Short: "Project end-of-month spend",
+		Long: `Project end-of-month spend from usage history, both linearly from the
+month's average and from the trailing 7-day trend, and list the projects
+driving cost.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			store, err := memory.NewStore(cfg.Memory.Path)
+			if err != nil {
+				return fmt.Errorf("failed to open memory store: %w", err)
+			}
+			defer func() { _ = store.Close() }()
+
+			budgetCfg := cfg.Budget
+			if budgetCfg == nil {
+				budgetCfg = budget.DefaultConfig()
+			}
+
+			enforcer := budget.NewEnforcer(budgetCfg, store)
+			forecast, err := enforcer.Forecast(context.Background(), time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to forecast budget: %w", err)
+			}
+
+			fmt.Print(renderBudgetForecast(forecast))
+			return nil
+		},
+	}
+}
+
+// renderBudgetForecast renders the end-of-month projection
+func renderBudgetForecast(f *budget.Forecast) string {
+	var b strings.Builder
+
+	b.WriteString(budgetHeaderStyle.Render("BUDGET FORECAST"))
+	b.WriteString("\n")
+	b.WriteString(budgetDivider())
+	b.WriteString("\n\n")
+
+	if f.ExceedsLimit() {
+		b.WriteString("  ")
+		b.WriteString(budgetErrorStyle.Render(fmt.Sprintf("[!] Projected to exceed the monthly limit by $%.2f", f.Projected-f.MonthlyLimit)))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(fmt.Sprintf("  %-*s$%.2f  (day %.0f of %.0f)\n", budgetLabelCol, "Month to date", f.MonthToDate, f.DaysElapsed, f.DaysInMonth))
+	b.WriteString(fmt.Sprintf("  %-*s$%.2f\n", budgetLabelCol, "Linear", f.Linear))
+	b.WriteString(fmt.Sprintf("  %-*s$%.2f  ($%.2f/day over 7 days)\n\n", budgetLabelCol, "Trend", f.Trend, f.TrendDaily))
+
+	if f.MonthlyLimit > 0 {
+		b.WriteString(formatBudgetLine("Projected", f.Projected, f.MonthlyLimit, f.ProjectedPercent()))
+	} else {
+		b.WriteString(fmt.Sprintf("  %-*s$%.2f  %s\n", budgetLabelCol, "Projected", f.Projected, budgetDimStyle.Render("no monthly limit")))
+	}
+
+	if len(f.Projects) > 0 {
+		b.WriteString(budgetDivider())
+		b.WriteString("\n")
+		b.WriteString(budgetHeaderStyle.Render("TOP PROJECTS"))
+		b.WriteString("\n")
+		for _, p := range f.Projects {
+			name := p.Project
+			if len(name) > 24 {
+				name = "…" + name[len(name)-23:]
+			}
+			b.WriteString(fmt.Sprintf("  %-24s $%8.2f  %3.0f%%  → $%.2f\n", name, p.MonthToDate, p.Share, p.Projected))
+		}
+	}
+
+	b.WriteString(budgetDivider())
+	b.WriteString("\n")
+	return b.String()
+}
+
 // renderBudgetStatus renders the complete budget status display
-func renderBudgetStatus(cfg *budget.Config, status *budget.Status) string {
+func renderBudgetStatus(cfg *budget.Config, status *budget.Status, buckets []budget.BucketStatus) string {
 	var b strings.Builder
 
 	// Header
@@ -158,6 +243,9 @@ func renderBudgetStatus(cfg *budget.Config, status *budget.Status) string {
 	// Monthly budget
 	b.WriteString(formatBudgetLine("Monthly", status.MonthlySpent, status.MonthlyLimit, status.MonthlyPercent))
 
+	// Adapter, project and team buckets
+	b.WriteString(renderBudgetBuckets(buckets))
+
 	// Footer section
 	b.WriteString(budgetDivider())
 	b.WriteString("\n")
@@ -190,6 +278,66 @@ func renderBudgetStatus(cfg *budget.Config, status *budget.Status) string {
 	return b.String()
 }
 
+// budgetBucketGroups titles the bucket kinds in display order
+var budgetBucketGroups = []struct{ kind, title string }{
+	{budget.BucketAdapter, "ADAPTERS"},
+	{budget.BucketProject, "PROJECTS"},
+	{budget.BucketTeam, "TEAMS"},
+}
+
+// renderBudgetBuckets renders the buckets grouped by kind, one line each
+func renderBudgetBuckets(buckets []budget.BucketStatus) string {
+	var b strings.Builder
+	for _, group := range budgetBucketGroups {
+		var lines []string
+		for _, bucket := range buckets {
+			if bucket.Kind == group.kind {
+				lines = append(lines, formatBucketLine(bucket))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		b.WriteString(budgetDivider())
+		b.WriteString("\n")
+		b.WriteString(budgetHeaderStyle.Render(group.title))
+		b.WriteString("\n")
+		for _, line := range lines {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
+// formatBucketLine formats a bucket's name with its daily and monthly spend
+func formatBucketLine(bucket budget.BucketStatus) string {
+	name := bucket.Name
+	if len(name) > 20 {
+		name = "…" + name[len(name)-19:]
+	}
+	return fmt.Sprintf("  %-20s day %s  month %s\n",
+		name,
+		formatBucketSpend(bucket.DailySpent, bucket.DailyLimit, bucket.DailyPercent()),
+		formatBucketSpend(bucket.MonthlySpent, bucket.MonthlyLimit, bucket.MonthlyPercent()),
+	)
+}
+
+// formatBucketSpend formats spend against a cap, colored as it nears the cap
+func formatBucketSpend(spent, limit, percent float64) string {
+	if limit <= 0 {
+		return budgetDimStyle.Render(fmt.Sprintf("%-13s", fmt.Sprintf("$%.2f", spent)))
+	}
+	text := fmt.Sprintf("%-13s", fmt.Sprintf("$%.2f/$%.0f", spent, limit))
+	switch {
+	case percent >= 100:
+		return budgetErrorStyle.Render(text)
+	case percent >= 80:
+		return budgetWarnStyle.Render(text)
+	default:
+		return text
+	}
+}
+
 // formatBudgetLine formats a single budget line (label + values + bar)
 func formatBudgetLine(label string, spent, limit, percent float64) string {
 	var b strings.Builder
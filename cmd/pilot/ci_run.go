@@ -0,0 +1,436 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/quality"
+	"github.com/alekspetrov/pilot/internal/replay"
+)
+
+// Exit codes for `pilot ci-run`, so workflows can gate later steps on the outcome.
+const (
+	ciExitSuccess   = 0 // PR created, or issue skipped
+	ciExitFailed    = 1 // task ran and failed
+	ciExitNoChanges = 2 // task finished without commits or a PR
+	ciExitSetup     = 3 // bad flags, missing credentials, or issue lookup failed
+)
+
+// CI run outcomes written to the "result" step output.
+const (
+	ciResultSuccess   = "success"
+	ciResultFailed    = "failed"
+	ciResultNoChanges = "no_changes"
+	ciResultSkipped   = "skipped"
+)
+
+// exitCodeError carries a process exit code out of a cobra RunE.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// exitCode returns the process exit code for an error returned by a command.
+func exitCode(err error) int {
+	var ec *exitCodeError
+	if errors.As(err, &ec) {
+		return ec.code
+	}
+	return 1
+}
+
+type ciRunOptions struct {
+	issue            int
+	repo             string
+	projectPath      string
+	artifactDir      string
+	tokenExchangeURL string
+	oidcAudience     string
+	verbose          bool
+}
+
+func newCIRunCmd() *cobra.Command {
+	var opts ciRunOptions
+
+	cmd := &cobra.Command{
+		Use:   "ci-run",
+		Short: "Run one GitHub issue headlessly inside a CI job",
+		Long: `Execute a single GitHub issue as a Pilot task and exit, for use in a
+GitHub Actions job triggered by the pilot label.
+
+Defaults come from the Actions environment: --repo from GITHUB_REPOSITORY,
+--project from GITHUB_WORKSPACE, and --issue from the triggering event.
+
+Credentials: GITHUB_TOKEN (or GH_TOKEN), or workload identity via
+--token-exchange-url, which trades the job's OIDC token for a GitHub token
+(requires "permissions: id-token: write").
+
+The execution recording and an HTML/Markdown report are written to
+--artifact-dir for actions/upload-artifact. Step outputs: result, pr-url,
+artifact-dir.
+
+Exit codes:
+  0  PR created (or issue skipped: no pilot label)
+  1  task failed
+  2  task finished without changes
+  3  setup error (flags, credentials, issue lookup)
+
+Examples:
+  pilot ci-run --issue 42 --repo owner/repo
+  pilot ci-run --token-exchange-url https://sts.example.com/exchange`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			code, err := runCIRun(ctx, &opts)
+			if err != nil {
+				return &exitCodeError{code: code, err: err}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.issue, "issue", 0, "Issue number (default: from the triggering event)")
+	cmd.Flags().StringVar(&opts.repo, "repo", "", "GitHub repository owner/repo (default: $GITHUB_REPOSITORY)")
+	cmd.Flags().StringVarP(&opts.projectPath, "project", "p", "", "Checked-out project path (default: $GITHUB_WORKSPACE)")
+	cmd.Flags().StringVar(&opts.artifactDir, "artifact-dir", "", "Directory for the recording and report (default: $RUNNER_TEMP/pilot)")
+	cmd.Flags().StringVar(&opts.tokenExchangeURL, "token-exchange-url", os.Getenv("PILOT_TOKEN_EXCHANGE_URL"), "Exchange the job's OIDC token for a GitHub token at this URL")
+	cmd.Flags().StringVar(&opts.oidcAudience, "oidc-audience", "", "Audience for the OIDC token request")
+	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", false, "Verbose output")
+
+	return cmd
+}
+
+// runCIRun executes the issue and returns the exit code with an error for
+// every outcome other than a created PR or a skipped issue.
+func runCIRun(ctx context.Context, opts *ciRunOptions) (int, error) {
+	if err := applyCIDefaults(opts); err != nil {
+		return ciExitSetup, err
+	}
+	owner, repoName, ok := strings.Cut(opts.repo, "/")
+	if !ok || owner == "" || repoName == "" {
+		return ciExitSetup, fmt.Errorf("invalid repo %q: use owner/repo", opts.repo)
+	}
+	if err := os.MkdirAll(opts.artifactDir, 0755); err != nil {
+		return ciExitSetup, fmt.Errorf("failed to create artifact dir: %w", err)
+	}
+
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return ciExitSetup, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := resolveCIToken(ctx, cfg, opts)
+	if err != nil {
+		return ciExitSetup, err
+	}
+	// The runner creates PRs through the gh CLI, which reads GH_TOKEN
+	if os.Getenv("GH_TOKEN") == "" {
+		_ = os.Setenv("GH_TOKEN", token)
+	}
+
+	client := github.NewClient(token)
+	issue, err := client.GetIssue(ctx, owner, repoName, opts.issue)
+	if err != nil {
+		return ciExitSetup, fmt.Errorf("failed to fetch issue #%d: %w", opts.issue, err)
+	}
+
+	pilotLabel := "pilot"
+	if cfg.Adapters != nil && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.PilotLabel != "" {
+		pilotLabel = cfg.Adapters.GitHub.PilotLabel
+	}
+	if !github.HasLabel(issue, pilotLabel) {
+		fmt.Printf("Issue #%d has no %q label, skipping\n", issue.Number, pilotLabel)
+		writeCIOutputs(ciResultSkipped, "", opts.artifactDir)
+		return ciExitSuccess, nil
+	}
+
+	taskID := fmt.Sprintf("GH-%d", issue.Number)
+	branchName := fmt.Sprintf("pilot/%s", taskID)
+	task := &executor.Task{
+		ID:          taskID,
+		Title:       issue.Title,
+		Description: fmt.Sprintf("GitHub Issue #%d: %s\n\n%s", issue.Number, issue.Title, issue.Body),
+		ProjectPath: opts.projectPath,
+		Branch:      branchName,
+		Verbose:     opts.verbose,
+		CreatePR:    true,
+		Labels:      extractGitHubLabelNames(issue),
+	}
+
+	runner, err := executor.NewRunnerWithConfig(cfg.Executor)
+	if err != nil {
+		return ciExitSetup, fmt.Errorf("failed to create executor runner: %w", err)
+	}
+	runner.SetProjectFeaturesResolver(projectFeaturesResolver(cfg))
+	leakGuard = quality.NewLeakGuard(cfg.LeakGuard)
+	runner.SetLeakGuard(leakGuard)
+	recordingsPath := filepath.Join(opts.artifactDir, "recordings")
+	runner.SetRecordingsPath(recordingsPath)
+	runner.SetRecordingEnabled(true)
+
+	if err := client.AddLabels(ctx, owner, repoName, issue.Number, []string{"pilot-in-progress"}); err != nil {
+		logGitHubAPIError("AddLabels", owner, repoName, issue.Number, err)
+	}
+
+	fmt.Printf("Running %s: %s\n", taskID, issue.Title)
+	result, execErr := runner.Execute(ctx, task)
+	outcome, code := ciOutcome(result, execErr)
+
+	var comment string
+	switch outcome {
+	case ciResultSuccess:
+		_ = client.RemoveLabel(ctx, owner, repoName, issue.Number, "pilot-failed")
+		comment = buildExecutionComment(result, branchName)
+	case ciResultNoChanges:
+		comment = fmt.Sprintf("⚠️ Pilot execution completed but no changes were made.\n\n**Branch:** `%s`\n\nNo commits or PR were created. The task may need clarification or manual intervention.", branchName)
+	default:
+		comment = scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", ciFailureReason(result, execErr)))
+	}
+	// On success pilot-in-progress stays until the PR merges, as with `pilot github run`
+	if outcome != ciResultSuccess {
+		if err := client.AddLabels(ctx, owner, repoName, issue.Number, []string{"pilot-failed"}); err != nil {
+			logGitHubAPIError("AddLabels", owner, repoName, issue.Number, err)
+		}
+		if err := client.RemoveLabel(ctx, owner, repoName, issue.Number, "pilot-in-progress"); err != nil {
+			logGitHubAPIError("RemoveLabel", owner, repoName, issue.Number, err)
+		}
+	}
+	if runURL := ciRunURL(); runURL != "" {
+		comment += fmt.Sprintf("\n\n[Workflow run](%s)", runURL)
+	}
+	if _, err := client.AddComment(ctx, owner, repoName, issue.Number, comment); err != nil {
+		logGitHubAPIError("AddComment", owner, repoName, issue.Number, err)
+	}
+
+	if result != nil && result.RecordingID != "" {
+		if err := exportCIRecording(recordingsPath, result.RecordingID, opts.artifactDir); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to export recording report: %v\n", err)
+		}
+	}
+
+	prURL := ""
+	if result != nil {
+		prURL = result.PRUrl
+	}
+	writeCIOutputs(outcome, prURL, opts.artifactDir)
+	writeCIStepSummary(taskID, issue.Title, outcome, result, execErr)
+
+	switch outcome {
+	case ciResultSuccess:
+		fmt.Printf("✅ %s completed: %s\n", taskID, prURL)
+		return ciExitSuccess, nil
+	case ciResultNoChanges:
+		return code, fmt.Errorf("execution completed but no commits or PR created")
+	default:
+		return code, fmt.Errorf("task execution failed: %s", ciFailureReason(result, execErr))
+	}
+}
+
+// applyCIDefaults fills unset options from the GitHub Actions environment.
+func applyCIDefaults(opts *ciRunOptions) error {
+	if opts.repo == "" {
+		opts.repo = os.Getenv("GITHUB_REPOSITORY")
+	}
+	if opts.repo == "" {
+		return fmt.Errorf("no repository: use --repo owner/repo or set GITHUB_REPOSITORY")
+	}
+	if opts.projectPath == "" {
+		opts.projectPath = os.Getenv("GITHUB_WORKSPACE")
+	}
+	if opts.projectPath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to resolve project path: %w", err)
+		}
+		opts.projectPath = cwd
+	}
+	if opts.issue == 0 {
+		opts.issue = ciIssueFromEvent(os.Getenv("GITHUB_EVENT_PATH"))
+	}
+	if opts.issue <= 0 {
+		return fmt.Errorf("no issue: use --issue or trigger the workflow from an issues event")
+	}
+	if opts.artifactDir == "" {
+		base := os.Getenv("RUNNER_TEMP")
+		if base == "" {
+			base = os.TempDir()
+		}
+		opts.artifactDir = filepath.Join(base, "pilot")
+	}
+	return nil
+}
+
+// ciIssueFromEvent reads the issue number from an Actions event payload.
+// Returns 0 when the payload is missing or not an issue event.
+func ciIssueFromEvent(path string) int {
+	if path == "" {
+		return 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	var event struct {
+		Issue struct {
+			Number int `json:"number"`
+		} `json:"issue"`
+	}
+	if json.Unmarshal(data, &event) != nil {
+		return 0
+	}
+	return event.Issue.Number
+}
+
+// resolveCIToken returns a GitHub token, preferring workload identity when an
+// exchange URL is configured over static tokens from config or environment.
+func resolveCIToken(ctx context.Context, cfg *config.Config, opts *ciRunOptions) (string, error) {
+	if opts.tokenExchangeURL != "" {
+		idToken, err := github.ActionsIDToken(ctx, opts.oidcAudience)
+		if err != nil {
+			return "", err
+		}
+		return github.ExchangeOIDCToken(ctx, opts.tokenExchangeURL, idToken, opts.repo)
+	}
+
+	if cfg.Adapters != nil && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Token != "" {
+		return cfg.Adapters.GitHub.Token, nil
+	}
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("no GitHub token: set GITHUB_TOKEN or use --token-exchange-url")
+}
+
+// ciOutcome classifies an execution into a step result and exit code.
+func ciOutcome(result *executor.ExecutionResult, err error) (string, int) {
+	switch {
+	case err != nil || result == nil || !result.Success:
+		return ciResultFailed, ciExitFailed
+	case result.CommitSHA == "" && result.PRUrl == "":
+		return ciResultNoChanges, ciExitNoChanges
+	default:
+		return ciResultSuccess, ciExitSuccess
+	}
+}
+
+func ciFailureReason(result *executor.ExecutionResult, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if result != nil && result.Error != "" {
+		return result.Error
+	}
+	return "unknown error"
+}
+
+// ciRunURL links back to the workflow run, or "" outside Actions.
+func ciRunURL() string {
+	server, repo, runID := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if server == "" || repo == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, runID)
+}
+
+// exportCIRecording writes HTML and Markdown reports for the recording next
+// to the raw recording so a single artifact upload captures everything.
+func exportCIRecording(recordingsPath, recordingID, artifactDir string) error {
+	recording, err := replay.LoadRecording(recordingsPath, recordingID)
+	if err != nil {
+		return err
+	}
+	events, err := replay.LoadStreamEvents(recording)
+	if err != nil {
+		return err
+	}
+	analyzer, err := replay.NewAnalyzer(recording)
+	if err != nil {
+		return err
+	}
+	report, err := analyzer.Analyze()
+	if err != nil {
+		return err
+	}
+
+	html, err := replay.ExportHTMLReport(recording, events, report)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(artifactDir, "report.html"), []byte(html), 0644); err != nil {
+		return err
+	}
+	md, err := replay.ExportToMarkdown(recording, events, report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(artifactDir, "report.md"), []byte(md), 0644)
+}
+
+// writeCIOutputs sets step outputs via $GITHUB_OUTPUT. No-op outside Actions.
+func writeCIOutputs(outcome, prURL, artifactDir string) {
+	appendGitHubFile("GITHUB_OUTPUT", fmt.Sprintf("result=%s\npr-url=%s\nartifact-dir=%s\n", outcome, prURL, artifactDir))
+}
+
+// writeCIStepSummary adds a run summary to the job page via $GITHUB_STEP_SUMMARY.
+func writeCIStepSummary(taskID, title, outcome string, result *executor.ExecutionResult, err error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### Pilot %s: %s\n\n", taskID, title))
+	sb.WriteString("| | |\n|---|---|\n")
+	sb.WriteString(fmt.Sprintf("| Result | `%s` |\n", outcome))
+	if result != nil {
+		sb.WriteString(fmt.Sprintf("| Duration | %s |\n", result.Duration.Round(time.Second)))
+		if result.PRUrl != "" {
+			sb.WriteString(fmt.Sprintf("| PR | %s |\n", result.PRUrl))
+		}
+		if result.Backend != "" {
+			sb.WriteString(fmt.Sprintf("| Backend | `%s` |\n", result.Backend))
+		}
+		if result.EstimatedCostUSD > 0 {
+			sb.WriteString(fmt.Sprintf("| Cost | ~$%.2f |\n", result.EstimatedCostUSD))
+		}
+	}
+	if outcome == ciResultFailed {
+		sb.WriteString(fmt.Sprintf("\n```\n%s\n```\n", ciFailureReason(result, err)))
+	}
+	appendGitHubFile("GITHUB_STEP_SUMMARY", sb.String())
+}
+
+func appendGitHubFile(envVar, content string) {
+	path := os.Getenv(envVar)
+	if path == "" {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to open %s: %v\n", envVar, err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(content); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to write %s: %v\n", envVar, err)
+	}
+}
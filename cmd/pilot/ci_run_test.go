@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+func TestCIOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      *executor.ExecutionResult
+		err         error
+		wantOutcome string
+		wantCode    int
+	}{
+		{"runner error", nil, errors.New("boom"), ciResultFailed, ciExitFailed},
+		{"task failed", &executor.ExecutionResult{Success: false, Error: "tests failed"}, nil, ciResultFailed, ciExitFailed},
+		{"no changes", &executor.ExecutionResult{Success: true}, nil, ciResultNoChanges, ciExitNoChanges},
+		{"pr created", &executor.ExecutionResult{Success: true, PRUrl: "https://github.com/o/r/pull/1"}, nil, ciResultSuccess, ciExitSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, code := ciOutcome(tt.result, tt.err)
+			if outcome != tt.wantOutcome || code != tt.wantCode {
+				t.Errorf("ciOutcome() = (%q, %d), want (%q, %d)", outcome, code, tt.wantOutcome, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := exitCode(errors.New("plain")); got != 1 {
+		t.Errorf("exitCode(plain) = %d, want 1", got)
+	}
+	wrapped := fmt.Errorf("cmd: %w", &exitCodeError{code: ciExitNoChanges, err: errors.New("no changes")})
+	if got := exitCode(wrapped); got != ciExitNoChanges {
+		t.Errorf("exitCode(wrapped) = %d, want %d", got, ciExitNoChanges)
+	}
+}
+
+func TestApplyCIDefaults(t *testing.T) {
+	dir := t.TempDir()
+	eventPath := filepath.Join(dir, "event.json")
+	if err := os.WriteFile(eventPath, []byte(`{"action":"labeled","issue":{"number":42}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_REPOSITORY", "acme/api")
+	t.Setenv("GITHUB_WORKSPACE", dir)
+	t.Setenv("GITHUB_EVENT_PATH", eventPath)
+	t.Setenv("RUNNER_TEMP", dir)
+
+	opts := &ciRunOptions{}
+	if err := applyCIDefaults(opts); err != nil {
+		t.Fatalf("applyCIDefaults: %v", err)
+	}
+	if opts.repo != "acme/api" || opts.projectPath != dir || opts.issue != 42 || opts.artifactDir != filepath.Join(dir, "pilot") {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	t.Setenv("GITHUB_EVENT_PATH", "")
+	if err := applyCIDefaults(&ciRunOptions{}); err == nil {
+		t.Error("expected error without an issue")
+	}
+}
+
+func TestWriteCIOutputs(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", out)
+
+	writeCIOutputs(ciResultSuccess, "https://github.com/o/r/pull/1", "/tmp/pilot")
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"result=success\n", "pr-url=https://github.com/o/r/pull/1\n", "artifact-dir=/tmp/pilot\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("outputs missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/cluster"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/quality"
+)
+
+// clusterEnabled reports whether queued tasks run on worker nodes
+func clusterEnabled(cfg *config.Config) bool {
+	return cfg.Cluster != nil && cfg.Cluster.Enabled
+}
+
+// clusterProjects names the configured projects so the coordinator and
+// workers agree on them while checking them out in different places
+func clusterProjects(cfg *config.Config) []cluster.Project {
+	projects := make([]cluster.Project, 0, len(cfg.Projects))
+	for _, p := range cfg.Projects {
+		if p.Name != "" && p.Path != "" {
+			projects = append(projects, cluster.Project{Name: p.Name, Path: p.Path})
+		}
+	}
+	return projects
+}
+
+// startCoordinator serves workers on the gateway and requeues tasks of
+// workers that went away until ctx is cancelled. Must be called before
+// the gateway starts.
+func startCoordinator(ctx context.Context, cfg *config.Config, store *memory.Store, runner *executor.Runner, gw *gateway.Server) {
+	coord := cluster.NewCoordinator(cfg.Cluster, store, clusterProjects(cfg))
+	coord.SetProgressEmitter(runner)
+	gw.RegisterHandler(cluster.PathPrefix, coord.Handler())
+	go coord.Run(ctx)
+	logging.WithComponent("cluster").Info("Coordinating worker nodes", slog.String("path", cluster.PathPrefix))
+}
+
+func newWorkerCmd() *cobra.Command {
+	var (
+		join        string
+		token       string
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "worker",
+		Short: "Run tasks leased from a coordinator",
+		Long: `Join a coordinator started with cluster.enabled and run its queued tasks
+on this machine. The coordinator keeps the queue, pollers and autopilot;
+the worker leases tasks for the projects in its own config, runs them in
+its local checkouts and streams progress and results back.
+
+Projects are matched by name, so each worker lists the projects it has
+checked out under the same names as the coordinator. A worker that stops
+renewing its lease has its task requeued for another worker.
+
+Examples:
+  pilot worker --join http://pilot.internal:9090
+  pilot worker --join http://pilot.internal:9090 --concurrency 4`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if token == "" && cfg.Cluster != nil {
+				token = cfg.Cluster.Token
+			}
+			if token == "" {
+				token = os.Getenv("PILOT_CLUSTER_TOKEN")
+			}
+			if token == "" {
+				return fmt.Errorf("cluster token required: set --token, cluster.token or PILOT_CLUSTER_TOKEN")
+			}
+
+			client, err := cluster.NewClient(join, token)
+			if err != nil {
+				return err
+			}
+
+			runner, err := executor.NewRunnerWithConfig(cfg.Executor)
+			if err != nil {
+				return fmt.Errorf("failed to create executor runner: %w", err)
+			}
+			runner.SetProjectFeaturesResolver(projectFeaturesResolver(cfg))
+			leakGuard = quality.NewLeakGuard(cfg.LeakGuard)
+			runner.SetLeakGuard(leakGuard)
+			if cfg.Quality != nil && cfg.Quality.Enabled {
+				runner.SetQualityCheckerFactory(func(taskID, taskProjectPath string) executor.QualityChecker {
+					return &qualityCheckerWrapper{
+						executor: quality.NewExecutor(&quality.ExecutorConfig{
+							Config:      cfg.Quality,
+							ProjectPath: taskProjectPath,
+							TaskID:      taskID,
+						}),
+					}
+				})
+			}
+
+			projects := clusterProjects(cfg)
+			if len(projects) == 0 {
+				return fmt.Errorf("no projects configured: add the projects checked out on this machine to the config")
+			}
+			worker := cluster.NewWorker(client, runner, projects, concurrency)
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			fmt.Printf("🛠  Worker %s joining %s\n", worker.ID(), join)
+			for _, p := range projects {
+				fmt.Printf("   %s → %s\n", p.Name, p.Path)
+			}
+			if err := worker.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				return err
+			}
+			fmt.Println("\n🛑 Worker stopped")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&join, "join", "", "Coordinator gateway URL (e.g. http://pilot.internal:9090)")
+	cmd.Flags().StringVar(&token, "token", "", "Cluster token (default: cluster.token or PILOT_CLUSTER_TOKEN)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 1, "Tasks to run at once")
+	_ = cmd.MarkFlagRequired("join")
+	return cmd
+}
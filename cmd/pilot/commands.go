@@ -52,10 +52,28 @@ func newStopCmd() *cobra.Command {
 
 func newStatusCmd() *cobra.Command {
 	var jsonOutput bool
+	var preflight bool
+	var htmlPath string
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show Pilot status and running tasks",
+		Long: `Show Pilot status and running tasks.
+
+With --preflight, run the startup preflight checks (missing tokens,
+repo/project mismatches, Slack Socket Mode, teams DB, backend CLI) and
+exit non-zero if any error is found.
+
+With --html, export the status page (daemon health, queue depth and last
+successful execution per repo) as a static HTML file. Live data comes from
+the running daemon; if it is unreachable the page is built from the local
+database and marked down.
+
+Examples:
+  pilot status
+  pilot status --preflight
+  pilot status --preflight --json
+  pilot status --html status.html`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load config to get gateway address
 			configPath := cfgFile
@@ -68,6 +86,14 @@ func newStatusCmd() *cobra.Command {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
+			if preflight {
+				return runStatusPreflight(cfg, jsonOutput)
+			}
+
+			if htmlPath != "" {
+				return exportStatusPage(cfg, htmlPath)
+			}
+
 			if jsonOutput {
 				status := map[string]interface{}{
 					"gateway": fmt.Sprintf("http://%s:%d", cfg.Gateway.Host, cfg.Gateway.Port),
@@ -79,6 +105,16 @@ func newStatusCmd() *cobra.Command {
 						"jira":     cfg.Adapters.Jira != nil && cfg.Adapters.Jira.Enabled,
 					},
 					"projects": cfg.Projects,
+					"paused":   storedPipelineState(cfg).Paused,
+					"frozen":   storedFreezeStatus(cfg).Frozen,
+				}
+				if cfg.HA != nil && cfg.HA.Enabled {
+					if lease := storedPrimaryLease(cfg); lease != nil && time.Now().Before(lease.ExpiresAt) {
+						status["ha_primary"] = lease.Holder
+					}
+				}
+				if quotas, err := githubRateLimit(cfg); err == nil && quotas != nil {
+					status["github_rate_limit"] = quotas
 				}
 
 				data, err := json.MarshalIndent(status, "", "  ")
@@ -92,6 +128,15 @@ func newStatusCmd() *cobra.Command {
 			fmt.Println("📊 Pilot Status")
 			fmt.Println("───────────────────────────────────────")
 			fmt.Printf("Gateway: http://%s:%d\n", cfg.Gateway.Host, cfg.Gateway.Port)
+			if state := storedPipelineState(cfg); state.Paused {
+				fmt.Println(pauseStatusLine(state))
+			}
+			if freeze := storedFreezeStatus(cfg); freeze.Frozen {
+				fmt.Println(freezeStatusLine(freeze))
+			}
+			if cfg.HA != nil && cfg.HA.Enabled {
+				fmt.Println(haStatusLine(storedPrimaryLease(cfg), time.Now()))
+			}
 			fmt.Println()
 
 			// Check adapters
@@ -118,6 +163,23 @@ func newStatusCmd() *cobra.Command {
 			}
 			fmt.Println()
 
+			// GitHub quota is shared by every client using the token,
+			// including the daemon's
+			if quotas, err := githubRateLimit(cfg); err != nil {
+				fmt.Println("GitHub API:")
+				fmt.Printf("  ⚠️  quota unavailable: %v\n", err)
+				fmt.Println()
+			} else if quotas != nil {
+				fmt.Println("GitHub API:")
+				for _, q := range quotas {
+					if q.Resource != github.ResourceCore && q.Resource != github.ResourceSearch && q.Resource != github.ResourceGraphQL {
+						continue
+					}
+					fmt.Printf("  %-8s %d/%d remaining, resets %s\n", q.Resource, q.Remaining, q.Limit, q.Reset.Local().Format("15:04:05"))
+				}
+				fmt.Println()
+			}
+
 			// List projects
 			fmt.Println("Projects:")
 			if len(cfg.Projects) == 0 {
@@ -137,10 +199,23 @@ func newStatusCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&preflight, "preflight", false, "Run startup preflight checks")
+	cmd.Flags().StringVar(&htmlPath, "html", "", "Export the status page as static HTML to this file")
 
 	return cmd
 }
 
+// githubRateLimit fetches the quota of the configured GitHub token.
+// Returns nil without error when GitHub is not configured.
+func githubRateLimit(cfg *config.Config) ([]github.Quota, error) {
+	if cfg.Adapters.GitHub == nil || !cfg.Adapters.GitHub.Enabled || cfg.Adapters.GitHub.Token == "" {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return github.NewClient(cfg.Adapters.GitHub.Token).GetRateLimit(ctx)
+}
+
 func newInitCmd() *cobra.Command {
 	var force bool
 	var projectMode bool
@@ -316,6 +391,7 @@ func newTaskCmd() *cobra.Command {
 	var localMode bool    // GH-2103: problem-solving prompt without PR constraints
 	var teamID string     // GH-635: team project access scoping
 	var teamMember string // GH-635: member email for access scoping
+	var backendName string
 
 	cmd := &cobra.Command{
 		Use:   "task [description]",
@@ -330,7 +406,8 @@ Examples:
   pilot task "Refactor the API handlers" --dry-run
   pilot task "Add index.py with hello world" --verbose
   pilot task "Fix bug" --alerts
-  pilot task "Fix bug" --local`,
+  pilot task "Fix bug" --local
+  pilot task cancel GH-405`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			taskDesc := args[0]
@@ -392,6 +469,7 @@ Examples:
 				Verbose:     verbose,
 				CreatePR:    true,
 				LocalMode:   localMode, // GH-2103
+				Backend:     backendName,
 			}
 
 			// Dry run mode - just show what would happen
@@ -548,6 +626,9 @@ Examples:
 			if runnerErr != nil {
 				return fmt.Errorf("failed to create executor runner: %w", runnerErr)
 			}
+			runner.SetProjectFeaturesResolver(projectFeaturesResolver(cfg))
+			leakGuard = quality.NewLeakGuard(cfg.LeakGuard)
+			runner.SetLeakGuard(leakGuard)
 
 			// GH-962: Clean up orphaned worktree directories from previous crashed executions
 			if cfg.Executor != nil && cfg.Executor.UseWorktree {
@@ -627,6 +708,11 @@ Examples:
 					// Wire log store for execution milestone entries (GH-1599)
 					runner.SetLogStore(learningStore)
 
+					// Wire duration history for adaptive timeouts and epic progress for resuming epics
+					runner.SetDurationHistory(learningStore)
+					runner.SetEpicStore(learningStore)
+					runner.SetEstimateTracker(learningStore)
+
 					// Wire knowledge store for experiential memories (GH-1027)
 					knowledgeStore := memory.NewKnowledgeStore(learningStore.DB())
 					if ksErr := knowledgeStore.InitSchema(); ksErr != nil {
@@ -808,9 +894,11 @@ Examples:
 	cmd.Flags().BoolVar(&enableAlerts, "alerts", false, "Enable alerts for task execution")
 	cmd.Flags().BoolVar(&enableBudget, "budget", false, "Enable budget enforcement for this task")
 	cmd.Flags().BoolVar(&localMode, "local", false, "Use problem-solving prompt without PR/Navigator constraints")
+	cmd.Flags().StringVar(&backendName, "backend", "", "Run this task on a different executor backend (e.g. opencode, ollama)")
 	cmd.Flags().StringVar(&teamID, "team", "", "Team ID or name for project access scoping (overrides config)")
 	cmd.Flags().StringVar(&teamMember, "team-member", "", "Member email for team access scoping (overrides config)")
 
+	cmd.AddCommand(newTaskCancelCmd())
 	return cmd
 }
 
@@ -891,6 +979,7 @@ func newGitHubCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newGitHubRunCmd())
+	cmd.AddCommand(newGitHubDepsCmd())
 	return cmd
 }
 
@@ -1059,6 +1148,9 @@ Examples:
 			if runnerErr != nil {
 				return fmt.Errorf("failed to create executor runner: %w", runnerErr)
 			}
+			runner.SetProjectFeaturesResolver(projectFeaturesResolver(cfg))
+			leakGuard = quality.NewLeakGuard(cfg.LeakGuard)
+			runner.SetLeakGuard(leakGuard)
 
 			// GH-962: Clean up orphaned worktree directories from previous crashed executions
 			if cfg.Executor != nil && cfg.Executor.UseWorktree {
@@ -1087,7 +1179,7 @@ Examples:
 					logGitHubAPIError("RemoveLabel", owner, repoName, int(issueNum), labelErr)
 				}
 
-				comment := fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", err.Error())
+				comment := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", err.Error()))
 				if _, commentErr := client.AddComment(ctx, owner, repoName, int(issueNum), comment); commentErr != nil {
 					logGitHubAPIError("AddComment", owner, repoName, int(issueNum), commentErr)
 				}
@@ -1156,6 +1248,7 @@ Examples:
 func newBriefCmd() *cobra.Command {
 	var now bool
 	var weekly bool
+	var digest bool
 
 	cmd := &cobra.Command{
 		Use:   "brief",
@@ -1165,7 +1258,8 @@ func newBriefCmd() *cobra.Command {
 Examples:
   pilot brief           # Show scheduler status
   pilot brief --now     # Generate and send brief immediately
-  pilot brief --weekly  # Generate a weekly summary`,
+  pilot brief --weekly  # Generate a weekly summary
+  pilot brief --digest  # Preview and send personal digests to team members`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load config
 			configPath := cfgFile
@@ -1216,6 +1310,7 @@ Examples:
 				Filters: briefs.FilterConfig{
 					Projects: briefCfg.Filters.Projects,
 				},
+				Digest: briefDigestConfig(briefCfg.Digest),
 			}
 
 			// Convert channels
@@ -1227,6 +1322,10 @@ Examples:
 				})
 			}
 
+			if digest {
+				return runDigestPreview(cfg, store, briefsConfig)
+			}
+
 			// Create generator
 			generator := briefs.NewGenerator(store, briefsConfig)
 
@@ -1316,6 +1415,11 @@ Examples:
 			}
 			fmt.Println()
 
+			if briefCfg.Digest != nil && briefCfg.Digest.Enabled {
+				fmt.Println("Personal digests: enabled")
+				fmt.Println()
+			}
+
 			if !briefCfg.Enabled {
 				fmt.Println("💡 Briefs are disabled. Enable in config:")
 				fmt.Println("   orchestrator.daily_brief.enabled: true")
@@ -1329,6 +1433,7 @@ Examples:
 
 	cmd.Flags().BoolVar(&now, "now", false, "Generate and send brief immediately")
 	cmd.Flags().BoolVar(&weekly, "weekly", false, "Generate weekly summary instead of daily")
+	cmd.Flags().BoolVar(&digest, "digest", false, "Generate personal digests for mapped team members")
 
 	return cmd
 }
@@ -2300,6 +2405,8 @@ func convertTaskStatesToDisplay(states []*executor.TaskState) []dashboard.TaskDi
 			status = "done"
 		case executor.StatusFailed:
 			status = "failed"
+		case executor.StatusBlocked:
+			status = "blocked"
 		default:
 			status = "pending"
 		}
@@ -2310,6 +2417,15 @@ func convertTaskStatesToDisplay(states []*executor.TaskState) []dashboard.TaskDi
 			duration = elapsed.Round(time.Second).String()
 		}
 
+		// Structured step signals show what a running task is doing right now,
+		// and a blocked task what it waits for
+		var step string
+		if status == "running" && executor.IsStepPhase(state.Phase) {
+			step = state.Message
+		} else if status == "blocked" {
+			step = "waiting for reply: " + state.Error
+		}
+
 		displays = append(displays, dashboard.TaskDisplay{
 			ID:       state.ID,
 			Title:    state.Title,
@@ -2319,6 +2435,7 @@ func convertTaskStatesToDisplay(states []*executor.TaskState) []dashboard.TaskDi
 			Duration: duration,
 			IssueURL: state.IssueURL,
 			PRURL:    state.PRUrl,
+			Step:     step,
 		})
 	}
 	return displays
@@ -2337,6 +2454,13 @@ func newReleaseCmd() *cobra.Command {
 		Long: `Create a new release for the current repository.
 
 If no version is specified, detects version bump from commits since last release.
+Release notes are built from the issues each merged PR closes, grouped by
+label (features, bug fixes, chores) and crediting the issue authors.
+Configured version_files (package.json, pyproject.toml, Chart.yaml, Go
+version constants) are bumped and committed to main before tagging.
+
+With release promotion enabled, autopilot tags releases as candidates;
+use "pilot release promote" to move them through environments.
 
 Examples:
   pilot release                  # Auto-detect version from commits
@@ -2383,12 +2507,30 @@ Examples:
 			releaseCfg.Enabled = true
 			releaser := autopilot.NewReleaser(ghClient, owner, repo, releaseCfg)
 
+			// Fold epic sub-issues into one entry per epic in the release notes
+			if cfg.Memory != nil {
+				if store, err := memory.NewStore(cfg.Memory.Path); err == nil {
+					defer func() { _ = store.Close() }()
+					releaser.SetEpicSource(store)
+				}
+			}
+
 			// Get current version
 			currentVersion, err := releaser.GetCurrentVersion(ctx)
 			if err != nil {
 				return fmt.Errorf("failed to get current version: %w", err)
 			}
 
+			// Commits since the latest release drive bump detection and release notes
+			latestRelease, _ := ghClient.GetLatestRelease(ctx, owner, repo)
+			var commits []*github.Commit
+			if latestRelease != nil {
+				commits, err = ghClient.CompareCommits(ctx, owner, repo, latestRelease.TagName, "HEAD")
+				if err != nil {
+					return fmt.Errorf("failed to get commits: %w", err)
+				}
+			}
+
 			var newVersion autopilot.SemVer
 			var bumpType autopilot.BumpType
 
@@ -2415,20 +2557,6 @@ Examples:
 				newVersion = currentVersion.Bump(bumpType)
 			} else {
 				// Auto-detect from commits
-				latestRelease, _ := ghClient.GetLatestRelease(ctx, owner, repo)
-				var baseRef string
-				if latestRelease != nil {
-					baseRef = latestRelease.TagName
-				}
-
-				var commits []*github.Commit
-				if baseRef != "" {
-					commits, err = ghClient.CompareCommits(ctx, owner, repo, baseRef, "HEAD")
-					if err != nil {
-						return fmt.Errorf("failed to get commits: %w", err)
-					}
-				}
-
 				bumpType = autopilot.DetectBumpType(commits)
 				if bumpType == autopilot.BumpNone {
 					fmt.Println("No releasable commits found (no feat/fix commits)")
@@ -2439,24 +2567,65 @@ Examples:
 
 			versionStr := newVersion.String(releaseCfg.TagPrefix)
 
+			// Project version_files take precedence over the autopilot release config
+			var versionFiles []autopilot.VersionFile
+			if proj := cfg.FindProjectByRepo(owner + "/" + repo); proj != nil && len(proj.VersionFiles) > 0 {
+				versionFiles = proj.VersionFiles
+			} else if cfg.Orchestrator != nil && cfg.Orchestrator.Autopilot != nil && cfg.Orchestrator.Autopilot.Release != nil {
+				versionFiles = cfg.Orchestrator.Autopilot.Release.VersionFiles
+			}
+
+			// Build notes from linked issues; fall back to GitHub's generated notes
+			var notes string
+			if releaseCfg.GenerateChangelog && len(commits) > 0 {
+				notes, err = releaser.GenerateReleaseNotes(ctx, commits)
+				if err != nil {
+					fmt.Printf("⚠️  Could not build release notes from linked issues: %v\n", err)
+				}
+			}
+
 			if dryRun {
 				fmt.Printf("Would create release:\n")
 				fmt.Printf("  Current version: %s\n", currentVersion.String(releaseCfg.TagPrefix))
 				fmt.Printf("  New version: %s\n", versionStr)
 				fmt.Printf("  Bump type: %s\n", bumpType)
 				fmt.Printf("  Draft: %v\n", draft)
+				for _, f := range versionFiles {
+					fmt.Printf("  Version file: %s\n", f.Path)
+				}
+				if notes != "" {
+					fmt.Printf("\n%s\n", notes)
+				}
 				return nil
 			}
 
 			fmt.Printf("Creating release %s...\n", versionStr)
 
+			// Commit version file bumps so the release tag includes them
+			target := "main"
+			if len(versionFiles) > 0 {
+				branch, err := ghClient.GetBranch(ctx, owner, repo, target)
+				if err != nil {
+					return fmt.Errorf("failed to get %s branch: %w", target, err)
+				}
+				target, err = releaser.UpdateVersionFiles(ctx, versionFiles, "main", branch.SHA(), newVersion)
+				if err != nil {
+					return fmt.Errorf("failed to update version files: %w", err)
+				}
+				fmt.Printf("   Updated %d version file(s)\n", len(versionFiles))
+			}
+
+			body := notes
+			if body == "" {
+				body = fmt.Sprintf("Release %s", versionStr)
+			}
 			input := &github.ReleaseInput{
 				TagName:         versionStr,
-				TargetCommitish: "main",
+				TargetCommitish: target,
 				Name:            versionStr,
-				Body:            fmt.Sprintf("Release %s", versionStr),
+				Body:            body,
 				Draft:           draft,
-				GenerateNotes:   true, // Let GitHub generate release notes
+				GenerateNotes:   notes == "", // Let GitHub generate release notes
 			}
 
 			release, err := ghClient.CreateRelease(ctx, owner, repo, input)
@@ -2475,6 +2644,11 @@ Examples:
 	cmd.Flags().BoolVar(&draft, "draft", false, "Create release as draft")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be released without creating")
 
+	cmd.AddCommand(
+		newReleasePromoteCmd(),
+		newReleasePromotionsCmd(),
+	)
+
 	return cmd
 }
 
@@ -2490,6 +2664,8 @@ func newAutopilotCmd() *cobra.Command {
 		newAutopilotListCmd(),
 		newAutopilotEnableCmd(),
 		newAutopilotDisableCmd(),
+		newAutopilotExplainCmd(),
+		newAutopilotPromoteCmd(),
 	)
 	return cmd
 }
@@ -2538,6 +2714,21 @@ Note: Pilot must be running with --env flag for this to work.`,
 
 			autopilotCfg := cfg.Orchestrator.Autopilot
 
+			// Changes in the promotion pipeline are read from the state store
+			pipelineEnabled := autopilotCfg.Pipeline != nil && autopilotCfg.Pipeline.Enabled
+			var changes []*autopilot.ChangePromotion
+			if pipelineEnabled {
+				store, stateStore, err := openAutopilotStateStore(cfg)
+				if err != nil {
+					return err
+				}
+				changes, err = stateStore.ListChangePromotions(20, false)
+				_ = store.Close()
+				if err != nil {
+					return fmt.Errorf("failed to list pipeline changes: %w", err)
+				}
+			}
+
 			if jsonOutput {
 				data := map[string]interface{}{
 					"enabled":     true,
@@ -2563,6 +2754,20 @@ Note: Pilot must be running with --env flag for this to work.`,
 					"max_failures":    autopilotCfg.MaxFailures,
 					"note":            "For live PR tracking, check the dashboard or logs. This shows config only.",
 				}
+				if pipelineEnabled {
+					pipeline := make([]map[string]interface{}, 0, len(changes))
+					for _, c := range changes {
+						pipeline = append(pipeline, map[string]interface{}{
+							"pr_number":  c.PRNumber,
+							"stage":      c.Stage,
+							"status":     string(c.Status),
+							"sha":        c.SHA,
+							"error":      c.Error,
+							"updated_at": c.UpdatedAt,
+						})
+					}
+					data["pipeline"] = pipeline
+				}
 				out, _ := json.MarshalIndent(data, "", "  ")
 				fmt.Println(string(out))
 				return nil
@@ -2592,6 +2797,11 @@ Note: Pilot must be running with --env flag for this to work.`,
 			}
 			fmt.Println()
 
+			if pipelineEnabled {
+				printPipelineChanges(changes)
+				fmt.Println()
+			}
+
 			fmt.Println("ℹ️  For live PR tracking, check:")
 			fmt.Println("   • Dashboard: pilot start --dashboard --env=<env>")
 			fmt.Println("   • Logs: pilot logs --follow")
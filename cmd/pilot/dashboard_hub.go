@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/dashboard"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+// mirrorRunnerToHub feeds the remote dashboard hub straight from runner
+// callbacks when no TUI is running to mirror. Progress logs are throttled to
+// 200ms like the TUI's; the task list is refreshed every second until ctx ends.
+func mirrorRunnerToHub(ctx context.Context, hub *gateway.DashboardHub, runner *executor.Runner, monitor *executor.Monitor) {
+	var lastLog time.Time
+	var mu sync.Mutex
+	runner.AddProgressCallback("hub", func(taskID, phase string, progress int, message string) {
+		monitor.UpdateProgress(taskID, phase, progress, message)
+
+		mu.Lock()
+		if time.Since(lastLog) < 200*time.Millisecond {
+			mu.Unlock()
+			return
+		}
+		lastLog = time.Now()
+		mu.Unlock()
+
+		hub.AddLog(fmt.Sprintf("[%s] %s: %s (%d%%)", taskID, phase, message, progress))
+	})
+	runner.AddTokenCallback("hub", func(taskID string, inputTokens, outputTokens int64) {
+		hub.UpdateTokens(int(inputTokens), int(outputTokens))
+	})
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		var last []gateway.DashboardTask
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				tasks := dashboard.HubTasks(convertTaskStatesToDisplay(monitor.GetAll()))
+				if !reflect.DeepEqual(tasks, last) {
+					last = tasks
+					hub.UpdateTasks(tasks)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/dashboard"
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+func newDashboardCmd() *cobra.Command {
+	var connect, token string
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Follow a running daemon's dashboard read-only",
+		Long: `Open the TUI dashboard of a Pilot daemon running in another terminal or
+on another machine, without controlling it. Tasks, logs, history and
+autopilot PRs stream from the daemon's gateway and metrics are read from its
+store. Nothing is sent back: pausing, upgrading and the git graph are not
+available.
+
+The daemon must run with its gateway enabled. Without --connect, the gateway
+address from the config is used. The connection is retried until you quit,
+so the dashboard survives daemon restarts.
+
+Examples:
+  pilot dashboard
+  pilot dashboard --connect build-server
+  pilot dashboard --connect 10.0.0.5:9090 --token "$PILOT_API_TOKEN"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			addr, err := remoteDashboardAddr(connect, cfg.Gateway)
+			if err != nil {
+				return err
+			}
+			if token == "" && cfg.Auth != nil && cfg.Auth.Type == gateway.AuthTypeAPIToken {
+				token = cfg.Auth.Token
+			}
+			return runRemoteDashboard(addr, token)
+		},
+	}
+
+	cmd.Flags().StringVar(&connect, "connect", "", "Daemon gateway to follow as host[:port] (default: gateway from config)")
+	cmd.Flags().StringVar(&token, "token", os.Getenv("PILOT_API_TOKEN"), "API token for the daemon's gateway (default: auth token from config)")
+	return cmd
+}
+
+// runRemoteDashboard shows the dashboard of the daemon at addr until the user
+// quits
+func runRemoteDashboard(addr, token string) error {
+	model := dashboard.NewModel(version)
+	model.SetRemote(addr)
+	program := tea.NewProgram(model,
+		tea.WithAltScreen(),
+		tea.WithInput(os.Stdin),
+		tea.WithOutput(os.Stdout),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dashboard.NewRemoteClient(addr, token, program.Send).Run(ctx)
+
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("dashboard error: %w", err)
+	}
+	return nil
+}
+
+// remoteDashboardAddr resolves --connect to host:port. The port defaults to
+// the configured gateway port; an empty target means the configured gateway.
+func remoteDashboardAddr(connect string, gw *gateway.Config) (string, error) {
+	port := 9090
+	if gw != nil && gw.Port != 0 {
+		port = gw.Port
+	}
+
+	target := strings.TrimSpace(connect)
+	target = strings.TrimPrefix(strings.TrimPrefix(target, "http://"), "ws://")
+	target = strings.TrimSuffix(target, "/")
+	if target == "" {
+		if gw == nil || gw.Host == "" {
+			return "", fmt.Errorf("gateway not configured - pass --connect host[:port]")
+		}
+		return net.JoinHostPort(gw.Host, strconv.Itoa(port)), nil
+	}
+	if strings.Contains(target, "/") {
+		return "", fmt.Errorf("invalid --connect %q: expected host[:port]", connect)
+	}
+
+	if host, p, err := net.SplitHostPort(target); err == nil {
+		if _, err := strconv.Atoi(p); err != nil || host == "" {
+			return "", fmt.Errorf("invalid --connect %q: expected host[:port]", connect)
+		}
+		return target, nil
+	}
+	return net.JoinHostPort(strings.Trim(target, "[]"), strconv.Itoa(port)), nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+func TestRemoteDashboardAddr(t *testing.T) {
+	gw := &gateway.Config{Host: "127.0.0.1", Port: 9191}
+
+	tests := []struct {
+		name    string
+		connect string
+		gw      *gateway.Config
+		want    string
+		wantErr bool
+	}{
+		{name: "config gateway", gw: gw, want: "127.0.0.1:9191"},
+		{name: "host only", connect: "build-server", gw: gw, want: "build-server:9191"},
+		{name: "host and port", connect: "10.0.0.5:8080", gw: gw, want: "10.0.0.5:8080"},
+		{name: "url", connect: "http://build-server:8080/", gw: gw, want: "build-server:8080"},
+		{name: "ipv6", connect: "::1", want: "[::1]:9090"},
+		{name: "no gateway", wantErr: true},
+		{name: "path", connect: "host/ws", gw: gw, wantErr: true},
+		{name: "bad port", connect: "host:http", gw: gw, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := remoteDashboardAddr(tt.connect, tt.gw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("remoteDashboardAddr(%q) = %q, want error", tt.connect, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("remoteDashboardAddr(%q) = %q, %v, want %q", tt.connect, got, err, tt.want)
+			}
+		})
+	}
+}
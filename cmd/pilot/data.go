@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/replay"
+	"github.com/alekspetrov/pilot/internal/retention"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+func newDataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "data",
+		Short: "Manage stored data, retention and purges",
+	}
+	cmd.AddCommand(newDataPurgeCmd())
+	return cmd
+}
+
+func newDataPurgeCmd() *cobra.Command {
+	var (
+		member    string
+		olderThan string
+		reportDir string
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete a person's data or data past its retention age",
+		Long: `Delete data from every store Pilot keeps: execution history and logs,
+usage events, recordings, and team identity data. A JSON purge report is
+saved for compliance records.
+
+--member erases one person: the executions they requested, with their logs
+and recordings, their usage events and team memberships. Audit log entries
+are kept with their email removed. Combined with --older-than, only their
+executions older than the age are deleted and their identity is kept.
+
+--older-than alone deletes everything older than the age. Without flags,
+the ages in the retention section of the config are applied.
+
+Examples:
+  pilot data purge --member alice@example.com
+  pilot data purge --older-than 90d
+  pilot data purge --member alice@example.com --older-than 365d`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var opts retention.Options
+			opts.Member = strings.TrimSpace(member)
+			if olderThan != "" {
+				age, err := retention.ParseAge(olderThan)
+				if err != nil {
+					return fmt.Errorf("--older-than: %w", err)
+				}
+				opts.OlderThan = age
+			}
+
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cfg.Memory == nil {
+				return fmt.Errorf("memory not configured")
+			}
+			manual := opts.Member != "" || opts.OlderThan > 0
+			if !manual && !cfg.Retention.Enabled() {
+				return fmt.Errorf("nothing to purge: pass --member or --older-than, or set retention ages in the config")
+			}
+
+			if !force {
+				fmt.Printf("%s This cannot be undone. Continue? [y/N]: ", describePurge(opts, cfg.Retention))
+				var input string
+				_, _ = fmt.Scanln(&input)
+				if strings.ToLower(input) != "y" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			store, err := memory.NewStore(cfg.Memory.Path)
+			if err != nil {
+				return fmt.Errorf("failed to open memory store: %w", err)
+			}
+			defer func() { _ = store.Close() }()
+			teamsStore, err := teams.NewStore(store.DB())
+			if err != nil {
+				return fmt.Errorf("failed to open teams store: %w", err)
+			}
+
+			purger := retention.NewPurger(store, teamsStore, replay.DefaultRecordingsPath())
+			var report *retention.Report
+			var purgeErr error
+			if manual {
+				report, purgeErr = purger.Purge(opts)
+			} else {
+				report, purgeErr = purger.ApplyRetention(cfg.Retention)
+			}
+			if report == nil {
+				return purgeErr
+			}
+
+			printPurgeReport(report)
+			if reportDir == "" {
+				reportDir = purgeReportDir(cfg)
+			}
+			path, err := report.Save(reportDir)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nReport saved to %s\n", path)
+			if purgeErr != nil {
+				return fmt.Errorf("purge stopped early: %w", purgeErr)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&member, "member", "", "Email of the person whose data is deleted")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Delete data older than this age (e.g. 90d, 12w, 720h)")
+	cmd.Flags().StringVar(&reportDir, "report-dir", "", "Directory for the purge report (default: <memory.path>/purge-reports)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Purge without confirmation")
+
+	return cmd
+}
+
+// purgeReportDir is where purge and retention reports are kept.
+func purgeReportDir(cfg *config.Config) string {
+	return filepath.Join(cfg.Memory.Path, "purge-reports")
+}
+
+// describePurge summarizes what a purge will delete for the confirmation
+// prompt.
+func describePurge(opts retention.Options, cfg *retention.Config) string {
+	switch {
+	case opts.Member != "" && opts.OlderThan > 0:
+		return fmt.Sprintf("Delete data of %s older than %s.", opts.Member, formatAge(opts.OlderThan))
+	case opts.Member != "":
+		return fmt.Sprintf("Delete all data of %s and remove them from their teams.", opts.Member)
+	case opts.OlderThan > 0:
+		return fmt.Sprintf("Delete all data older than %s.", formatAge(opts.OlderThan))
+	default:
+		var parts []string
+		for _, age := range []struct{ name, value string }{
+			{"executions", cfg.Executions},
+			{"recordings", cfg.Recordings},
+			{"transcripts", cfg.Transcripts},
+			{"identity data", cfg.Identity},
+		} {
+			if age.value != "" {
+				parts = append(parts, fmt.Sprintf("%s older than %s", age.name, age.value))
+			}
+		}
+		return "Apply retention: delete " + strings.Join(parts, ", ") + "."
+	}
+}
+
+func formatAge(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
+
+func printPurgeReport(report *retention.Report) {
+	if report.Member != "" && len(report.MemberIDs) == 0 {
+		fmt.Printf("No team memberships found for %s\n", report.Member)
+	}
+	if report.Total() == 0 {
+		fmt.Println("Nothing to purge.")
+		return
+	}
+
+	printCounts := func(title string, counts map[string]int64) {
+		names := make([]string, 0, len(counts))
+		for name, n := range counts {
+			if n > 0 {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return
+		}
+		sort.Strings(names)
+		fmt.Println(title)
+		for _, name := range names {
+			fmt.Printf("  %-20s %d\n", name, counts[name])
+		}
+	}
+	printCounts("Deleted:", report.Deleted)
+	printCounts("Redacted:", report.Redacted)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/retention"
+)
+
+func TestDataPurgeCommandFlags(t *testing.T) {
+	cmd := newDataPurgeCmd()
+	for _, name := range []string{"member", "older-than", "report-dir", "force"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("missing flag: --%s", name)
+		}
+	}
+}
+
+func TestDescribePurge(t *testing.T) {
+	tests := []struct {
+		opts retention.Options
+		cfg  *retention.Config
+		want string
+	}{
+		{opts: retention.Options{Member: "a@example.com"}, want: "all data of a@example.com"},
+		{opts: retention.Options{Member: "a@example.com", OlderThan: 90 * 24 * time.Hour}, want: "older than 90d"},
+		{opts: retention.Options{OlderThan: 36 * time.Hour}, want: "older than 36h0m0s"},
+		{cfg: &retention.Config{Recordings: "30d", Identity: "365d"}, want: "recordings older than 30d, identity data older than 365d"},
+	}
+	for _, tt := range tests {
+		if got := describePurge(tt.opts, tt.cfg); !strings.Contains(got, tt.want) {
+			t.Errorf("describePurge(%+v) = %q, want it to contain %q", tt.opts, got, tt.want)
+		}
+	}
+}
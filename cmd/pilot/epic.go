@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func newEpicCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epic",
+		Short: "Inspect epic decomposition progress",
+		Long:  `Inspect epics Pilot has split into sub-issues and executed sequentially.`,
+	}
+
+	cmd.AddCommand(newEpicStatusCmd())
+	return cmd
+}
+
+func newEpicStatusCmd() *cobra.Command {
+	var project string
+
+	cmd := &cobra.Command{
+		Use:   "status <issue>",
+		Short: "Show sub-issue completion for an epic",
+		Long: `Show the persisted plan of an epic and which of its sub-issues are done.
+
+The issue is a GitHub issue number (405, #405 or GH-405) or another tracker's
+identifier (APP-123). An epic interrupted by a restart resumes from its first
+unfinished sub-issue the next time its parent task runs.
+
+Examples:
+  pilot epic status 405
+  pilot epic status APP-123 --project ~/code/app`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cfg.Memory == nil {
+				return fmt.Errorf("memory not configured")
+			}
+
+			store, err := memory.NewStore(cfg.Memory.Path)
+			if err != nil {
+				return fmt.Errorf("failed to open memory store: %w", err)
+			}
+			defer func() { _ = store.Close() }()
+
+			parentID := epicParentID(args[0])
+			epics, err := store.GetEpics(parentID)
+			if err != nil {
+				return err
+			}
+			if project != "" {
+				if abs, err := filepath.Abs(expandPath(project)); err == nil {
+					project = abs
+				}
+				var matched []*memory.Epic
+				for _, epic := range epics {
+					if epic.ProjectPath == project {
+						matched = append(matched, epic)
+					}
+				}
+				epics = matched
+			}
+			if len(epics) == 0 {
+				fmt.Printf("No epic progress recorded for %s\n", parentID)
+				return nil
+			}
+
+			for i, epic := range epics {
+				if i > 0 {
+					fmt.Println()
+				}
+				printEpic(epic)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&project, "project", "", "Only show the epic for this project path")
+	return cmd
+}
+
+// epicParentID normalizes an issue argument to the parent task ID the runner
+// records: GitHub numbers become "GH-N", other identifiers are kept.
+func epicParentID(arg string) string {
+	arg = strings.TrimPrefix(strings.TrimSpace(arg), "#")
+	if n, err := strconv.Atoi(arg); err == nil {
+		return fmt.Sprintf("GH-%d", n)
+	}
+	return arg
+}
+
+func printEpic(epic *memory.Epic) {
+	fmt.Printf("📋 Epic %s: %s\n", epic.ParentID, epic.Title)
+	fmt.Println("───────────────────────────────────────")
+	fmt.Printf("   Project:  %s\n", epic.ProjectPath)
+	if epic.SourceRepo != "" {
+		fmt.Printf("   Repo:     %s\n", epic.SourceRepo)
+	}
+	fmt.Printf("   Status:   %s (%d/%d sub-issues done)\n", epic.Status, epic.Completed(), len(epic.SubIssues))
+	fmt.Printf("   Cost:     $%.2f\n", epic.CostUSD)
+	fmt.Printf("   Updated:  %s\n", epic.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Println()
+
+	for _, sub := range epic.SubIssues {
+		ref := sub.Identifier
+		if sub.Number > 0 {
+			ref = fmt.Sprintf("#%d", sub.Number)
+		}
+		fmt.Printf("   %s %d. %s %s\n", epicSubIssueIcon(sub.Status), sub.Position, ref, sub.Title)
+		if sub.PRURL != "" {
+			fmt.Printf("        PR: %s\n", sub.PRURL)
+		}
+		if sub.Error != "" {
+			fmt.Printf("        Error: %s\n", truncate(sub.Error, 100))
+		}
+	}
+
+	if len(epic.Timeline) > 0 {
+		fmt.Println()
+		fmt.Println("   Timeline:")
+		for _, event := range epic.Timeline {
+			fmt.Printf("   %s  %s\n", event.CreatedAt.Local().Format("01-02 15:04"), epicEventText(epic, event))
+		}
+	}
+
+	if epic.Status != memory.EpicStatusCompleted {
+		for _, sub := range epic.SubIssues {
+			if sub.Status != memory.SubIssueStatusDone {
+				fmt.Println()
+				fmt.Printf("   Resumes from %d. %s when %s runs again\n", sub.Position, sub.Title, epic.ParentID)
+				break
+			}
+		}
+	}
+}
+
+// epicEventText describes a timeline event, naming the sub-issue it concerns
+func epicEventText(epic *memory.Epic, event *memory.EpicEvent) string {
+	var text string
+	switch event.Kind {
+	case memory.EpicEventPlanned:
+		text = "planned"
+	case memory.EpicEventResumed:
+		text = "resumed"
+	case memory.EpicEventCompleted:
+		text = "completed"
+	case memory.EpicEventSubIssueStarted:
+		text = "started"
+	case memory.EpicEventSubIssueDone:
+		text = "done"
+	case memory.EpicEventSubIssueFailed:
+		text = "failed"
+	default:
+		text = event.Kind
+	}
+	if event.Position > 0 && event.Position <= len(epic.SubIssues) {
+		text = fmt.Sprintf("%d. %s %s", event.Position, epic.SubIssues[event.Position-1].Title, text)
+	}
+	if event.Message != "" {
+		text += ": " + truncate(event.Message, 80)
+	}
+	return text
+}
+
+func epicSubIssueIcon(status string) string {
+	switch status {
+	case memory.SubIssueStatusDone:
+		return "✅"
+	case memory.SubIssueStatusRunning:
+		return "⏳"
+	case memory.SubIssueStatusFailed:
+		return "❌"
+	default:
+		return "⬜"
+	}
+}
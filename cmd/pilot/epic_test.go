@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestEpicParentID(t *testing.T) {
+	tests := map[string]string{
+		"405":     "GH-405",
+		"#405":    "GH-405",
+		"GH-405":  "GH-405",
+		"APP-123": "APP-123",
+	}
+	for arg, want := range tests {
+		if got := epicParentID(arg); got != want {
+			t.Errorf("epicParentID(%q) = %q, want %q", arg, got, want)
+		}
+	}
+}
+
+func TestPrintEpic(t *testing.T) {
+	epic := &memory.Epic{
+		ProjectPath: "/repo",
+		ParentID:    "GH-405",
+		Title:       "Auth overhaul",
+		SourceRepo:  "o/r",
+		Status:      memory.EpicStatusFailed,
+		CostUSD:     1.5,
+		UpdatedAt:   time.Now(),
+		SubIssues: []*memory.EpicSubIssue{
+			{Position: 1, Number: 406, Title: "Schema", Status: memory.SubIssueStatusDone, PRURL: "https://github.com/o/r/pull/9"},
+			{Position: 2, Number: 407, Title: "API", Status: memory.SubIssueStatusFailed, Error: "tests failed"},
+			{Position: 3, Identifier: "APP-9", Title: "UI", Status: memory.SubIssueStatusPending},
+		},
+		Timeline: []*memory.EpicEvent{
+			{Kind: memory.EpicEventPlanned, Message: "3 sub-issues", CreatedAt: time.Now()},
+			{Kind: memory.EpicEventSubIssueFailed, Position: 2, Message: "tests failed", CreatedAt: time.Now()},
+		},
+	}
+
+	out := captureStdout(func() { printEpic(epic) })
+	for _, want := range []string{
+		"Epic GH-405: Auth overhaul",
+		"failed (1/3 sub-issues done)",
+		"✅ 1. #406 Schema",
+		"PR: https://github.com/o/r/pull/9",
+		"❌ 2. #407 API",
+		"Error: tests failed",
+		"⬜ 3. APP-9 UI",
+		"Cost:     $1.50",
+		"planned: 3 sub-issues",
+		"2. API failed: tests failed",
+		"Resumes from 2. API when GH-405 runs again",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+)
+
+func newFreezeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze",
+		Short: "Hold autopilot merges and releases during a freeze",
+		Long: `Freeze merges: autopilot keeps creating, reviewing and testing PRs but
+holds merges, environment promotions and releases until the freeze ends.
+
+Recurring freezes are configured in orchestrator.autopilot.freeze.windows;
+these commands start and end an ad-hoc freeze. The freeze is stored in the
+memory database, so a running daemon picks it up within seconds.`,
+	}
+
+	cmd.AddCommand(
+		newFreezeStartCmd(),
+		newFreezeEndCmd(),
+		newFreezeStatusCmd(),
+	)
+	return cmd
+}
+
+func newFreezeStartCmd() *cobra.Command {
+	var reason, until string
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start a merge freeze",
+		Long: `Start a merge freeze. Without --until the freeze lasts until 'pilot freeze end'.
+
+--until takes a duration (90m, 12h, 3d) or a local time (2026-12-27, 2026-12-27 08:00).
+
+Examples:
+  pilot freeze start --until 12h --reason "release candidate soak"
+  pilot freeze start --until "2027-01-04 08:00" --reason holidays`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var end time.Time
+			if until != "" {
+				var err error
+				if end, err = parseFreezeUntil(until, time.Now()); err != nil {
+					return err
+				}
+			}
+			return withFreezeCalendar(func(freeze *autopilot.FreezeCalendar, _ *autopilot.StateStore) error {
+				if err := freeze.Start(reason, end); err != nil {
+					return fmt.Errorf("failed to start freeze: %w", err)
+				}
+				fmt.Printf("🧊 %s\n", freeze.Status())
+				fmt.Println("   PRs are still prepared; merges and releases wait for the freeze to end.")
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&until, "until", "", "When the freeze ends: a duration (12h, 3d) or local time (2026-12-27 08:00)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why merges are frozen (shown in status and alerts)")
+	return cmd
+}
+
+func newFreezeEndCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "end",
+		Short: "End the ad-hoc merge freeze",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withFreezeCalendar(func(freeze *autopilot.FreezeCalendar, _ *autopilot.StateStore) error {
+				if freeze.Manual() == nil {
+					fmt.Println("No ad-hoc freeze is active")
+				} else {
+					if err := freeze.End(); err != nil {
+						return fmt.Errorf("failed to end freeze: %w", err)
+					}
+					fmt.Println("▶️  Freeze ended — held PRs will merge on the next autopilot cycle")
+				}
+				if status := freeze.Status(); status.Frozen {
+					fmt.Printf("   Still frozen by the scheduled %s\n", status)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newFreezeStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the current freeze and PRs held by it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withFreezeCalendar(func(freeze *autopilot.FreezeCalendar, stateStore *autopilot.StateStore) error {
+				status := freeze.Status()
+				if !status.Frozen {
+					fmt.Println("Merges are not frozen")
+				} else {
+					fmt.Println(freezeStatusLine(status))
+					states, err := stateStore.LoadAllPRStates()
+					if err != nil {
+						return fmt.Errorf("failed to load PR states: %w", err)
+					}
+					held := heldPRs(states)
+					if len(held) == 0 {
+						fmt.Println("   No PRs are waiting")
+					}
+					for _, pr := range held {
+						fmt.Printf("   #%d %s (%s)\n", pr.PRNumber, pr.PRTitle, pr.Stage)
+					}
+				}
+				if name, start, end, ok := freeze.Next(); ok {
+					label := "freeze"
+					if name != "" {
+						label = name + " freeze"
+					}
+					fmt.Printf("Next %s: %s – %s\n", label,
+						start.Local().Format("Mon Jan 2 15:04"), end.Local().Format("Mon Jan 2 15:04"))
+				}
+				return nil
+			})
+		},
+	}
+}
+
+// withFreezeCalendar opens the autopilot state store and runs fn with the
+// configured freeze calendar backed by it
+func withFreezeCalendar(fn func(freeze *autopilot.FreezeCalendar, stateStore *autopilot.StateStore) error) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Memory == nil {
+		return fmt.Errorf("memory not configured")
+	}
+
+	var freezeCfg *autopilot.FreezeConfig
+	if cfg.Orchestrator != nil && cfg.Orchestrator.Autopilot != nil {
+		freezeCfg = cfg.Orchestrator.Autopilot.Freeze
+	}
+	freeze, err := autopilot.NewFreezeCalendar(freezeCfg)
+	if err != nil {
+		return fmt.Errorf("invalid freeze config: %w", err)
+	}
+
+	store, stateStore, err := openAutopilotStateStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	freeze.SetStore(stateStore)
+	return fn(freeze, stateStore)
+}
+
+// parseFreezeUntil parses --until: a duration from now (with a "d" suffix
+// for days) or a local date or date and time
+func parseFreezeUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("--until must be in the future")
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		t, err := time.ParseInLocation(layout, s, now.Location())
+		if err != nil {
+			continue
+		}
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("--until %s is in the past", s)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --until %q: use a duration (12h, 3d) or a time (2006-01-02 15:04)", s)
+}
+
+// heldPRs returns the PRs a freeze is holding back from merging, promoting
+// or releasing
+func heldPRs(states []*autopilot.PRState) []*autopilot.PRState {
+	var held []*autopilot.PRState
+	for _, pr := range states {
+		switch pr.Stage {
+		case autopilot.StageMerging, autopilot.StageCanarySoak, autopilot.StageReleasing:
+			held = append(held, pr)
+		}
+	}
+	return held
+}
+
+// freezeStatusLine describes an active freeze for status output
+func freezeStatusLine(status autopilot.FreezeStatus) string {
+	return "🧊 Merges frozen: " + status.String()
+}
+
+// storedFreezeStatus reads the freeze state for `pilot status`. A missing or
+// unreadable store reports only the configured windows.
+func storedFreezeStatus(cfg *config.Config) autopilot.FreezeStatus {
+	var freezeCfg *autopilot.FreezeConfig
+	if cfg.Orchestrator != nil && cfg.Orchestrator.Autopilot != nil {
+		freezeCfg = cfg.Orchestrator.Autopilot.Freeze
+	}
+	freeze, err := autopilot.NewFreezeCalendar(freezeCfg)
+	if err != nil {
+		return autopilot.FreezeStatus{}
+	}
+	if cfg.Memory != nil {
+		if store, stateStore, err := openAutopilotStateStore(cfg); err == nil {
+			defer func() { _ = store.Close() }()
+			freeze.SetStore(stateStore)
+		}
+	}
+	return freeze.Status()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+)
+
+func TestParseFreezeUntil(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr string
+	}{
+		{"12h", now.Add(12 * time.Hour), ""},
+		{"90m", now.Add(90 * time.Minute), ""},
+		{"3d", time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC), ""},
+		{"2026-10-19 08:00", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), ""},
+		{"2026-10-20", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), ""},
+		{"2026-10-19T08:00:00Z", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), ""},
+		{"-1h", time.Time{}, "in the future"},
+		{"2026-10-01", time.Time{}, "in the past"},
+		{"monday", time.Time{}, "invalid --until"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseFreezeUntil(tt.in, now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseFreezeUntil(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("parseFreezeUntil(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeldPRs(t *testing.T) {
+	states := []*autopilot.PRState{
+		{PRNumber: 1, Stage: autopilot.StageWaitingCI},
+		{PRNumber: 2, Stage: autopilot.StageMerging},
+		{PRNumber: 3, Stage: autopilot.StageCanarySoak},
+		{PRNumber: 4, Stage: autopilot.StageReleasing},
+		{PRNumber: 5, Stage: autopilot.StageAwaitApproval},
+	}
+
+	var got []int
+	for _, pr := range heldPRs(states) {
+		got = append(got, pr.PRNumber)
+	}
+	if len(got) != 3 || got[0] != 2 || got[1] != 3 || got[2] != 4 {
+		t.Errorf("heldPRs() = %v, want [2 3 4]", got)
+	}
+}
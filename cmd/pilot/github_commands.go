@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// newGitHubCommentCommands creates the /pilot comment command watcher of a
+// polled repo. Retried issues are cleared from the poller and picked up on
+// a poll right away.
+func newGitHubCommentCommands(cfg *github.Config, client *github.Client, poller *github.Poller, repo, label, projectPath string, runner *executor.Runner, feed *github.WebhookFeed) (*github.CommentCommandWatcher, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo format: %s", repo)
+	}
+	owner, name := parts[0], parts[1]
+
+	opts := []github.CommentCommandOption{
+		github.WithCommand(github.CommandRetry, func(ctx context.Context, cmd *github.CommentCommand) (string, error) {
+			issue, err := client.GetIssue(ctx, owner, name, cmd.IssueNumber)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch the issue: %w", err)
+			}
+			if issue.State == github.StateClosed {
+				return "", fmt.Errorf("the issue is closed")
+			}
+			if github.HasLabel(issue, github.LabelInProgress) {
+				return "", fmt.Errorf("the issue is already running")
+			}
+			for _, stale := range []string{github.LabelFailed, github.LabelDone, github.LabelRetryReady} {
+				if github.HasLabel(issue, stale) {
+					if err := client.RemoveLabel(ctx, owner, name, issue.Number, stale); err != nil {
+						return "", fmt.Errorf("failed to remove %s: %w", stale, err)
+					}
+				}
+			}
+			if !github.HasLabel(issue, label) {
+				if err := client.AddLabels(ctx, owner, name, issue.Number, []string{label}); err != nil {
+					return "", fmt.Errorf("failed to add %s: %w", label, err)
+				}
+			}
+			poller.ClearProcessed(issue.Number)
+			poller.Poke()
+			return fmt.Sprintf("🔁 Retrying, requested by @%s.", cmd.Author), nil
+		}),
+		github.WithCommand(github.CommandCancel, func(ctx context.Context, cmd *github.CommentCommand) (string, error) {
+			taskID := fmt.Sprintf("GH-%d", cmd.IssueNumber)
+			if err := runner.Cancel(taskID); err != nil {
+				return "", fmt.Errorf("no task is running for this issue")
+			}
+			return fmt.Sprintf("🛑 Cancelled %s, requested by @%s.", taskID, cmd.Author), nil
+		}),
+		github.WithCommand(github.CommandPlan, func(ctx context.Context, cmd *github.CommentCommand) (string, error) {
+			issue, err := client.GetIssue(ctx, owner, name, cmd.IssueNumber)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch the issue: %w", err)
+			}
+			task := &executor.Task{
+				ID:          fmt.Sprintf("GH-%d", issue.Number),
+				Title:       issue.Title,
+				Description: fmt.Sprintf("GitHub Issue #%d: %s\n\n%s", issue.Number, issue.Title, issue.Body),
+				ProjectPath: projectPath,
+				Labels:      extractGitHubLabelNames(issue),
+			}
+			plan, err := runner.PlanEpic(ctx, task, projectPath)
+			if err != nil {
+				return "", fmt.Errorf("planning failed: %w", err)
+			}
+			return formatCommentPlan(plan), nil
+		}),
+	}
+	if teamAdapter != nil {
+		opts = append(opts, github.WithCommandTeam(teamAdapter))
+	}
+	if feed != nil {
+		opts = append(opts, github.WithCommandWebhookFeed(feed))
+	}
+	return github.NewCommentCommandWatcher(client, repo, cfg.Commands, opts...)
+}
+
+// formatCommentPlan renders a plan as an issue comment
+func formatCommentPlan(plan *executor.EpicPlan) string {
+	var sb strings.Builder
+	sb.WriteString("📋 **Plan** (not executed)\n\n")
+	for _, st := range plan.Subtasks {
+		fmt.Fprintf(&sb, "%d. **%s**", st.Order, st.Title)
+		if len(st.DependsOn) > 0 {
+			deps := make([]string, len(st.DependsOn))
+			for i, d := range st.DependsOn {
+				deps[i] = fmt.Sprintf("%d", d)
+			}
+			fmt.Fprintf(&sb, " (after %s)", strings.Join(deps, ", "))
+		}
+		sb.WriteString("\n")
+		if desc := strings.TrimSpace(st.Description); desc != "" {
+			for _, line := range strings.Split(desc, "\n") {
+				sb.WriteString("   " + line + "\n")
+			}
+		}
+	}
+	if plan.TotalEffort != "" {
+		fmt.Fprintf(&sb, "\nEstimated effort: %s\n", plan.TotalEffort)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
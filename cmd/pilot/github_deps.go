@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/config"
+)
+
+func newGitHubDepsCmd() *cobra.Command {
+	var repo string
+
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Show the dependency graph of Pilot issues",
+		Long: `Show open Pilot issues as a dependency tree built from "Depends on #N",
+"Blocked by #N" and "Requires #N" in issue bodies.
+
+Issues are dispatched only once every dependency is resolved: issues must be
+closed and pull requests merged. Issues in a dependency cycle are never
+dispatched until the cycle is broken.
+
+Examples:
+  pilot github deps
+  pilot github deps --repo owner/repo`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cfg.Adapters == nil || cfg.Adapters.GitHub == nil || !cfg.Adapters.GitHub.Enabled {
+				return fmt.Errorf("GitHub adapter not enabled. Run 'pilot setup' or edit ~/.pilot/config.yaml")
+			}
+
+			token := cfg.Adapters.GitHub.Token
+			if token == "" {
+				token = os.Getenv("GITHUB_TOKEN")
+			}
+			if token == "" {
+				return fmt.Errorf("GitHub token not configured. Set GITHUB_TOKEN env or add to config")
+			}
+
+			if repo == "" {
+				repo = cfg.Adapters.GitHub.Repo
+			}
+			parts := strings.Split(repo, "/")
+			if len(parts) != 2 {
+				return fmt.Errorf("no repository specified. Use --repo owner/repo or set in config")
+			}
+			owner, repoName := parts[0], parts[1]
+
+			label := cfg.Adapters.GitHub.PilotLabel
+			if cfg.Adapters.GitHub.Polling != nil && cfg.Adapters.GitHub.Polling.Label != "" {
+				label = cfg.Adapters.GitHub.Polling.Label
+			}
+			if label == "" {
+				label = "pilot"
+			}
+
+			client := github.NewClient(token)
+			ctx := context.Background()
+			issues, err := client.ListIssues(ctx, owner, repoName, &github.ListIssuesOptions{
+				Labels: []string{label},
+				State:  github.StateOpen,
+				Sort:   "created",
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list issues: %w", err)
+			}
+
+			graph := github.BuildDependencyGraph(issues)
+			fmt.Printf("🔗 Issue Dependencies — %s (label: %s)\n", repo, label)
+			fmt.Println("───────────────────────────────────────")
+			if graph.Len() == 0 {
+				fmt.Println("No open issues")
+				return nil
+			}
+
+			// Resolve dependencies outside the graph (closed, merged or unlabeled)
+			external := make(map[int]string)
+			for _, num := range graph.Order() {
+				for _, dep := range graph.Node(num).DependsOn {
+					if graph.Node(dep) != nil {
+						continue
+					}
+					if _, ok := external[dep]; ok {
+						continue
+					}
+					resolved, err := client.IsDependencyResolved(ctx, owner, repoName, dep)
+					switch {
+					case err != nil:
+						external[dep] = "unknown"
+					case resolved:
+						external[dep] = "done"
+					default:
+						external[dep] = "open"
+					}
+				}
+			}
+
+			printer := &depsPrinter{graph: graph, external: external, printed: make(map[int]bool)}
+			for _, root := range graph.Roots() {
+				printer.print(root, "", "")
+			}
+
+			if cycles := graph.Cycles(); len(cycles) > 0 {
+				fmt.Println()
+				fmt.Println("🔁 Cycles (never dispatched until broken):")
+				for _, cycle := range cycles {
+					fmt.Printf("   %s\n", github.FormatCycle(cycle))
+				}
+			}
+
+			// Issues only reachable through a cycle have no root above them
+			var unreached []int
+			for _, num := range graph.Order() {
+				if !printer.printed[num] && !graph.InCycle(num) {
+					unreached = append(unreached, num)
+				}
+			}
+			if len(unreached) > 0 {
+				fmt.Println()
+				fmt.Println("⛔ Blocked behind a cycle:")
+				for _, num := range unreached {
+					fmt.Printf("   #%d %s\n", num, graph.Node(num).Issue.Title)
+				}
+			}
+
+			fmt.Println()
+			fmt.Println("✅ ready  ⏳ blocked  🔁 in cycle")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "Repository (owner/repo), defaults to config")
+	return cmd
+}
+
+// depsPrinter renders a DependencyGraph as a tree of dependents under their
+// dependencies. Issues with several dependencies appear under each of them.
+type depsPrinter struct {
+	graph    *github.DependencyGraph
+	external map[int]string // state of dependencies outside the graph
+	printed  map[int]bool
+}
+
+func (p *depsPrinter) print(num int, indent, branch string) {
+	node := p.graph.Node(num)
+	seen := p.printed[num]
+	p.printed[num] = true
+
+	line := fmt.Sprintf("%s%s%s #%d %s", indent, branch, p.status(num), num, node.Issue.Title)
+	if blockers := p.blockers(num); len(blockers) > 0 {
+		line += "  ← " + strings.Join(blockers, ", ")
+	}
+	if seen && len(node.Dependents) > 0 {
+		line += " (see above)"
+	}
+	fmt.Println(line)
+	if seen || p.graph.InCycle(num) {
+		return
+	}
+
+	childIndent := indent
+	switch branch {
+	case "├─ ":
+		childIndent += "│  "
+	case "└─ ":
+		childIndent += "   "
+	}
+	var children []int
+	for _, dep := range node.Dependents {
+		if !p.graph.InCycle(dep) {
+			children = append(children, dep)
+		}
+	}
+	p.graph.Sort(children)
+	for i, child := range children {
+		childBranch := "├─ "
+		if i == len(children)-1 {
+			childBranch = "└─ "
+		}
+		p.print(child, childIndent, childBranch)
+	}
+}
+
+func (p *depsPrinter) status(num int) string {
+	switch {
+	case p.graph.InCycle(num):
+		return "🔁"
+	case len(p.blockers(num)) > 0:
+		return "⏳"
+	default:
+		return "✅"
+	}
+}
+
+// blockers lists the issue's unresolved dependencies, e.g. "#12", "#40 (open)"
+func (p *depsPrinter) blockers(num int) []string {
+	var blockers []string
+	for _, dep := range p.graph.Node(num).DependsOn {
+		if p.graph.Node(dep) != nil {
+			blockers = append(blockers, fmt.Sprintf("#%d", dep))
+			continue
+		}
+		if state := p.external[dep]; state != "done" {
+			blockers = append(blockers, fmt.Sprintf("#%d (%s)", dep, state))
+		}
+	}
+	return blockers
+}
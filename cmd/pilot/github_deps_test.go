@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+)
+
+func TestDepsPrinter(t *testing.T) {
+	now := time.Now()
+	graph := github.BuildDependencyGraph([]*github.Issue{
+		{Number: 1, Title: "Schema", CreatedAt: now.Add(-3 * time.Hour)},
+		{Number: 2, Title: "API", Body: "Depends on #1", CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 3, Title: "UI", Body: "Depends on #2\nBlocked by #40", CreatedAt: now.Add(-1 * time.Hour)},
+		{Number: 4, Title: "Docs", Body: "Depends on #41", CreatedAt: now},
+	})
+	printer := &depsPrinter{
+		graph:    graph,
+		external: map[int]string{40: "open", 41: "done"},
+		printed:  make(map[int]bool),
+	}
+
+	out := captureStdout(func() {
+		for _, root := range graph.Roots() {
+			printer.print(root, "", "")
+		}
+	})
+
+	want := []string{
+		"✅ #1 Schema",
+		"└─ ⏳ #2 API  ← #1",
+		"   └─ ⏳ #3 UI  ← #2, #40 (open)",
+		"✅ #4 Docs",
+	}
+	got := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tree =\n%s\nwant\n%s", out, strings.Join(want, "\n"))
+	}
+}
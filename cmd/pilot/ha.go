@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/alerts"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/ha"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// errStandbyInterrupted is returned when a standby is stopped before it
+// takes over
+var errStandbyInterrupted = errors.New("standby stopped before takeover")
+
+// haPrimary is this daemon holding the primary lease
+type haPrimary struct {
+	node     string
+	previous string // Primary it took over from, "" if the lease was free
+	release  func()
+
+	mu     sync.Mutex
+	alerts *alerts.Engine
+}
+
+// becomePrimary blocks while another daemon holds the primary lease, then
+// keeps renewing it in the background. Losing the lease shuts this daemon
+// down like SIGTERM so the two never poll at once. Release gives up the
+// lease so the standby takes over without waiting.
+func becomePrimary(cfg *config.Config) (*haPrimary, error) {
+	if cfg.Memory == nil {
+		return nil, fmt.Errorf("ha requires memory.path on storage shared by both daemons")
+	}
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open memory store for ha: %w", err)
+	}
+	elector := ha.NewElector(cfg.HA, store)
+
+	fmt.Printf("🫀 HA node %s: waiting for primary lease...\n", elector.NodeID())
+	waitCtx, stopWait := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	err = elector.WaitForLeadership(waitCtx)
+	stopWait()
+	if err != nil {
+		_ = store.Close()
+		return nil, errStandbyInterrupted
+	}
+	fmt.Printf("🫀 HA node %s is primary\n", elector.NodeID())
+
+	primary := &haPrimary{node: elector.NodeID(), previous: elector.Previous()}
+	if primary.previous != "" {
+		fmt.Printf("🫀 Took over from %s\n", primary.previous)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := elector.Run(ctx); errors.Is(err, ha.ErrLeadershipLost) {
+			logging.WithComponent("ha").Error("Lost primary lease, shutting down",
+				slog.String("node", elector.NodeID()))
+			primary.alert("lost")
+			stopSelf()
+		}
+	}()
+
+	primary.release = func() {
+		cancel()
+		<-done
+		if err := elector.Release(); err != nil {
+			logging.WithComponent("ha").Warn("Failed to release primary lease", slog.Any("error", err))
+		}
+		_ = store.Close()
+	}
+	return primary, nil
+}
+
+// Release gives up the primary lease. Safe on a nil primary.
+func (p *haPrimary) Release() {
+	if p != nil {
+		p.release()
+	}
+}
+
+// SetAlertsEngine routes failover alerts to engine, and reports the
+// takeover that made this daemon primary. The alerts engine starts after
+// the lease is taken, so the takeover is reported here. Safe on a nil
+// primary or engine.
+func (p *haPrimary) SetAlertsEngine(engine *alerts.Engine) {
+	if p == nil || engine == nil {
+		return
+	}
+	p.mu.Lock()
+	p.alerts = engine
+	p.mu.Unlock()
+	if p.previous != "" {
+		p.alert("takeover")
+	}
+}
+
+// alert sends an ha_takeover event for action "takeover" or "lost"
+func (p *haPrimary) alert(action string) {
+	p.mu.Lock()
+	engine := p.alerts
+	p.mu.Unlock()
+	if engine == nil {
+		return
+	}
+	engine.ProcessEvent(alerts.Event{
+		Type: alerts.EventTypeHATakeover,
+		Metadata: map[string]string{
+			"node":     p.node,
+			"previous": p.previous,
+			"action":   action,
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// stopSelf sends this process SIGTERM so the usual shutdown path runs
+func stopSelf() {
+	proc, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = proc.Signal(syscall.SIGTERM)
+	}
+	if err != nil {
+		logging.WithComponent("ha").Error("Failed to signal shutdown, exiting", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+// haStatusLine describes the primary lease for status output
+func haStatusLine(lease *memory.Lease, now time.Time) string {
+	if lease == nil || !now.Before(lease.ExpiresAt) {
+		return "🫀 HA: no primary (standby will take over)"
+	}
+	return fmt.Sprintf("🫀 HA: primary %s (heartbeat %s ago)", lease.Holder, now.Sub(lease.HeartbeatAt).Round(time.Second))
+}
+
+// storedPrimaryLease reads the primary lease from the memory store
+func storedPrimaryLease(cfg *config.Config) *memory.Lease {
+	if cfg.Memory == nil {
+		return nil
+	}
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = store.Close() }()
+	lease, err := store.GetLease(ha.LeaseName)
+	if err != nil {
+		return nil
+	}
+	return lease
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestHAStatusLine(t *testing.T) {
+	now := time.Now()
+	lease := &memory.Lease{Holder: "node-a", HeartbeatAt: now.Add(-4 * time.Second), ExpiresAt: now.Add(26 * time.Second)}
+	if line := haStatusLine(lease, now); !strings.Contains(line, "primary node-a") || !strings.Contains(line, "4s ago") {
+		t.Errorf("haStatusLine() = %q", line)
+	}
+	lease.ExpiresAt = now.Add(-time.Second)
+	if line := haStatusLine(lease, now); !strings.Contains(line, "no primary") {
+		t.Errorf("haStatusLine() for expired lease = %q", line)
+	}
+	if line := haStatusLine(nil, now); !strings.Contains(line, "no primary") {
+		t.Errorf("haStatusLine() without lease = %q", line)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -60,7 +61,7 @@ type HandlerDeps struct {
 //  4. Budget check (with budget exceeded alert + early return)
 //  5. Print to stdout
 //  6. Dispatch via dispatcher OR direct execute via runner
-//  7. Update monitor (fail/complete)
+//  7. Update monitor (fail/complete/block)
 //  8. Emit task completed/failed alert
 //  9. Add to dashboard history
 //  10. Build and return HandlerResult
@@ -68,6 +69,9 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 	taskID := info.TaskID
 	title := info.Title
 	projectPath := deps.ProjectPath
+	if task.SourceAdapter == "" {
+		task.SourceAdapter = info.Adapter
+	}
 
 	// 1. Register with monitor
 	if deps.Monitor != nil {
@@ -90,9 +94,9 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 		})
 	}
 
-	// 4. Budget check — block task if daily/monthly limits exceeded
+	// 4. Budget check — block task if daily/monthly limits or the adapter, project or team caps are exceeded
 	if deps.Enforcer != nil {
-		checkResult, budgetErr := deps.Enforcer.CheckBudget(ctx, "", "")
+		checkResult, budgetErr := deps.Enforcer.CheckProjectBudget(ctx, "", "", task.SourceAdapter, task.ProjectPath)
 		if budgetErr != nil {
 			logging.WithComponent("budget").Warn("budget check failed, allowing task (fail-open)",
 				slog.String("task_id", taskID),
@@ -115,6 +119,7 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 						"daily_left":   fmt.Sprintf("%.2f", checkResult.DailyLeft),
 						"monthly_left": fmt.Sprintf("%.2f", checkResult.MonthlyLeft),
 						"action":       string(checkResult.Action),
+						"adapter":      task.SourceAdapter,
 					},
 					Timestamp: time.Now(),
 				})
@@ -149,6 +154,14 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 				execErr = fmt.Errorf("failed waiting for execution: %w", waitErr)
 			} else if exec.Status == "failed" {
 				execErr = fmt.Errorf("execution failed: %s", exec.Error)
+			} else if exec.Status == "cancelled" {
+				execErr = fmt.Errorf("execution %s: %w", execID[:8], executor.ErrTaskCancelled)
+			} else if exec.Status == "blocked" {
+				execErr = fmt.Errorf("execution %s: %w", execID[:8], &executor.BlockedError{Reason: exec.Error, SessionID: exec.SessionID})
+			} else if exec.Status == "needs_info" {
+				execErr = fmt.Errorf("execution %s: %w", execID[:8], executor.ParseNeedsInfo(exec.Error))
+			} else if exec.Status == "deferred" {
+				execErr = fmt.Errorf("execution %s: %w", execID[:8], executor.ParseDeferred(exec.Error))
 			} else {
 				result = &executor.ExecutionResult{
 					TaskID:    task.ID,
@@ -170,16 +183,28 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 	if result != nil {
 		prURL = result.PRUrl
 	}
+	var blocked *executor.BlockedError
+	errors.As(execErr, &blocked)
+	var needsInfo *executor.NeedsInfoError
+	errors.As(execErr, &needsInfo)
 	if deps.Monitor != nil {
-		if execErr != nil {
+		if errors.Is(execErr, executor.ErrTaskCancelled) || errors.Is(execErr, executor.ErrTaskDeferred) {
+			deps.Monitor.Cancel(taskID)
+		} else if blocked != nil {
+			deps.Monitor.Block(taskID, blocked.Reason)
+		} else if needsInfo != nil {
+			deps.Monitor.Block(taskID, "needs info: "+needsInfo.Reason)
+		} else if execErr != nil {
 			deps.Monitor.Fail(taskID, execErr.Error())
 		} else {
 			deps.Monitor.Complete(taskID, prURL)
 		}
 	}
 
-	// 8. Emit task completed/failed alert
-	if deps.AlertsEngine != nil {
+	// 8. Emit task completed/failed alert. A blocked task was alerted when
+	// the agent reported it; a task triage handed back or budget routing
+	// deferred never ran.
+	if deps.AlertsEngine != nil && blocked == nil && needsInfo == nil && !errors.Is(execErr, executor.ErrTaskDeferred) {
 		if execErr != nil {
 			deps.AlertsEngine.ProcessEvent(alerts.Event{
 				Type:      alerts.EventTypeTaskFailed,
@@ -221,7 +246,13 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 	if deps.Program != nil {
 		status := "success"
 		duration := ""
-		if execErr != nil {
+		if errors.Is(execErr, executor.ErrTaskCancelled) {
+			status = "cancelled"
+		} else if errors.Is(execErr, executor.ErrTaskDeferred) {
+			status = "deferred"
+		} else if blocked != nil || needsInfo != nil {
+			status = "blocked"
+		} else if execErr != nil {
 			status = "failed"
 		}
 		if result != nil {
@@ -240,7 +271,10 @@ func handleIssueGeneric(ctx context.Context, deps HandlerDeps, info IssueInfo, t
 	if result != nil {
 		if result.PRUrl != "" {
 			hr.PRURL = result.PRUrl
-			if prNum, err := github.ExtractPRNumber(result.PRUrl); err == nil {
+			// A draft PR from an exhausted retry policy is left to humans:
+			// without a PR number, autopilot does not pick it up
+			draft := result.Retry != nil && result.Retry.DraftPR
+			if prNum, err := github.ExtractPRNumber(result.PRUrl); err == nil && !draft {
 				hr.PRNumber = prNum
 			}
 		}
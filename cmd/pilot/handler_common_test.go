@@ -7,6 +7,7 @@ import (
 
 	"github.com/alekspetrov/pilot/internal/budget"
 	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
 )
 
 // TestHandleIssueGeneric_BudgetExceeded verifies that handleIssueGeneric returns early
@@ -52,6 +53,42 @@ func TestHandleIssueGeneric_BudgetExceeded(t *testing.T) {
 	}
 }
 
+// TestHandleIssueGeneric_AdapterBudgetExceeded verifies that an adapter over
+// its cap is blocked and that the task is tagged with its source adapter.
+func TestHandleIssueGeneric_AdapterBudgetExceeded(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if err := store.SaveExecution(&memory.Execution{ID: "exec-1", TaskID: "JIRA-1", Status: "completed", SourceAdapter: "jira"}); err != nil {
+		t.Fatalf("SaveExecution: %v", err)
+	}
+	if err := store.SaveExecutionMetrics(&memory.ExecutionMetrics{ExecutionID: "exec-1", EstimatedCostUSD: 6}); err != nil {
+		t.Fatalf("SaveExecutionMetrics: %v", err)
+	}
+
+	cfg := &budget.Config{
+		Enabled:      true,
+		DailyLimit:   100,
+		MonthlyLimit: 1000,
+		OnExceed:     budget.ExceedAction{Daily: budget.ActionPause},
+		Adapters:     map[string]budget.AdapterLimit{"jira": {DailyLimit: 5}},
+	}
+	deps := HandlerDeps{Enforcer: budget.NewEnforcer(cfg, store)}
+	info := IssueInfo{TaskID: "JIRA-2", Title: "Capped", Adapter: "jira", LogEmoji: "📋"}
+	task := &executor.Task{ID: "JIRA-2", Title: "Capped", Branch: "pilot/JIRA-2"}
+
+	_, err = handleIssueGeneric(context.Background(), deps, info, task)
+
+	if err == nil || !strings.Contains(err.Error(), "jira daily budget exceeded") {
+		t.Fatalf("expected jira cap to block the task, got: %v", err)
+	}
+	if task.SourceAdapter != "jira" {
+		t.Errorf("SourceAdapter = %q, want jira", task.SourceAdapter)
+	}
+}
+
 // TestHandleIssueGeneric_MonitorRegistration verifies that the monitor is populated
 // with task state when handleIssueGeneric is called (budget exceeded path ensures
 // monitor.Register is reached before the early return).
@@ -2,22 +2,28 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/alekspetrov/pilot/internal/adapters/asana"
 	"github.com/alekspetrov/pilot/internal/adapters/azuredevops"
+	"github.com/alekspetrov/pilot/internal/adapters/clickup"
+	"github.com/alekspetrov/pilot/internal/adapters/gitea"
 	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/adapters/gitlab"
 	"github.com/alekspetrov/pilot/internal/adapters/jira"
 	"github.com/alekspetrov/pilot/internal/adapters/linear"
+	"github.com/alekspetrov/pilot/internal/adapters/notion"
 	"github.com/alekspetrov/pilot/internal/adapters/plane"
+	"github.com/alekspetrov/pilot/internal/adapters/trello"
 	"github.com/alekspetrov/pilot/internal/alerts"
 	"github.com/alekspetrov/pilot/internal/budget"
 	"github.com/alekspetrov/pilot/internal/config"
@@ -48,10 +54,9 @@ func logGitHubAPIError(operation string, owner, repo string, issueNum int, err e
 	}
 }
 
-// requestReviewersFromConfig looks up the project config for the given sourceRepo
-// and requests PR reviewers if configured. Errors are logged but not propagated.
-func requestReviewersFromConfig(ctx context.Context, cfg *config.Config, client *github.Client, sourceRepo, owner, repo string, prNumber int) {
-	proj := cfg.FindProjectByRepo(sourceRepo)
+// requestReviewersFromConfig requests the PR reviewers configured for the
+// issue's project, if any. Errors are logged but not propagated.
+func requestReviewersFromConfig(ctx context.Context, proj *config.ProjectConfig, client *github.Client, sourceRepo, owner, repo string, prNumber int) {
 	if proj == nil {
 		return
 	}
@@ -76,6 +81,42 @@ func requestReviewersFromConfig(ctx context.Context, cfg *config.Config, client
 	}
 }
 
+// reviewerRotation spreads reviewer pool requests across the PRs this
+// process opens
+var reviewerRotation = github.NewReviewerRotation()
+
+// assignReviewers requests reviews from the CODEOWNERS owners of the PR or
+// the reviewer pool. Errors are logged; nil means nobody was requested.
+func assignReviewers(ctx context.Context, cfg *config.Config, client *github.Client, owner, repo string, prNumber int) *github.ReviewerAssignment {
+	assignment, err := github.AssignReviewers(ctx, client, cfg.Adapters.GitHub.ReviewerAssignment, reviewerRotation, owner, repo, prNumber)
+	if err != nil {
+		logging.WithComponent("github").Warn("Failed to assign PR reviewers",
+			slog.String("repo", owner+"/"+repo),
+			slog.Int("pr", prNumber),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	if assignment != nil {
+		slog.Info("PR reviewers assigned",
+			slog.String("repo", owner+"/"+repo),
+			slog.Int("pr", prNumber),
+			slog.String("source", assignment.Source),
+			slog.Any("reviewers", assignment.Mentions(owner)),
+		)
+	}
+	return assignment
+}
+
+// reviewerAssignmentNote tells the execution comment's readers who was
+// asked to review the PR
+func reviewerAssignmentNote(assignment *github.ReviewerAssignment, owner string) string {
+	if assignment == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n👀 **Reviewers requested:** %s (from %s)\n", strings.Join(assignment.Mentions(owner), ", "), assignment.Source)
+}
+
 // parseAutopilotBranch extracts the target branch from an autopilot-fix issue's metadata comment.
 // Returns empty string if no metadata found.
 // Supports both old format (branch:X) and new format (branch:X pr:N).
@@ -146,6 +187,87 @@ func extractGitHubLabelNames(issue *github.Issue) []string {
 	return names
 }
 
+// extractLinearLabelNames returns the label names from a Linear issue.
+func extractLinearLabelNames(issue *linear.Issue) []string {
+	if issue == nil || len(issue.Labels) == 0 {
+		return nil
+	}
+	names := make([]string, len(issue.Labels))
+	for i, l := range issue.Labels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// extractGiteaLabelNames returns the label names from a Gitea issue.
+func extractGiteaLabelNames(issue *gitea.Issue) []string {
+	if issue == nil || len(issue.Labels) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(issue.Labels))
+	for _, l := range issue.Labels {
+		if l != nil {
+			names = append(names, l.Name)
+		}
+	}
+	return names
+}
+
+// githubCommentManagers holds one status comment manager per GitHub client,
+// so the open status comment an attempt found is remembered across calls
+// instead of searched for again.
+var githubCommentManagers sync.Map // map[*github.Client]*github.CommentManager
+
+// githubCommentManager returns the status comment manager of client
+func githubCommentManager(client *github.Client, cfg *github.CommentsConfig) *github.CommentManager {
+	if m, ok := githubCommentManagers.Load(client); ok {
+		return m.(*github.CommentManager)
+	}
+	m, _ := githubCommentManagers.LoadOrStore(client, github.NewCommentManager(client, cfg))
+	return m.(*github.CommentManager)
+}
+
+// fetchGitHubPriorAttempts reads the issue thread and condenses Pilot's earlier
+// failure comments so a retry knows what already went wrong. Returns nil when
+// the issue has no comments, or the thread or Pilot's own login cannot be
+// fetched.
+func fetchGitHubPriorAttempts(ctx context.Context, client *github.Client, parts []string, issue *github.Issue) []string {
+	if client == nil || len(parts) != 2 || issue.Comments == 0 {
+		return nil
+	}
+	login, err := client.Login(ctx)
+	if err != nil {
+		logging.WithComponent("github").Warn("skipping prior attempt history", slog.Any("error", err))
+		return nil
+	}
+	comments, err := client.ListIssueComments(ctx, parts[0], parts[1], issue.Number)
+	if err != nil {
+		logGitHubAPIError("ListIssueComments", parts[0], parts[1], issue.Number, err)
+		return nil
+	}
+	attempts := github.ExtractPriorAttempts(comments, login)
+	if len(attempts) > 0 {
+		logging.WithComponent("github").Info("including prior attempt history in prompt",
+			slog.Int("issue_number", issue.Number),
+			slog.Int("attempts", len(attempts)),
+		)
+	}
+	return attempts
+}
+
+// fetchGitHubUnblock returns the requester's reply to a blocked issue
+func fetchGitHubUnblock(ctx context.Context, client *github.Client, parts []string, issue *github.Issue) (github.Unblock, bool) {
+	if client == nil || len(parts) != 2 {
+		return github.Unblock{}, false
+	}
+	comments, err := client.ListIssueComments(ctx, parts[0], parts[1], issue.Number)
+	if err != nil {
+		logGitHubAPIError("ListIssueComments", parts[0], parts[1], issue.Number, err)
+		return github.Unblock{}, false
+	}
+	return github.FindUnblock(comments)
+}
+
 // handleGitHubIssueWithResult processes a GitHub issue and returns result with PR info
 // Used in sequential mode to enable PR merge waiting
 // sourceRepo is the "owner/repo" string that the issue came from (GH-929)
@@ -164,6 +286,20 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 		}
 	}
 
+	// Projects sharing a monorepo: the owning project is picked by the
+	// issue's labels or the paths it mentions, and scopes the task
+	project := cfg.RouteProject(sourceRepo, extractGitHubLabelNames(issue), issue.Title+"\n"+issue.Body)
+	if project != nil && (len(project.Scope) > 0 || len(project.Labels) > 0) {
+		if project.Path != "" {
+			projectPath = project.Path
+		}
+		slog.Info("routed issue to monorepo project",
+			slog.Int("issue", issue.Number),
+			slog.String("project", project.Name),
+			slog.Any("scope", project.Scope),
+		)
+	}
+
 	// GH-386: Pre-execution validation - fail fast if repo doesn't match project
 	if err := executor.ValidateRepoProjectMatch(sourceRepo, projectPath); err != nil {
 		logging.WithComponent("github").Error("cross-project execution blocked",
@@ -179,9 +315,20 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 		}, wrappedErr
 	}
 
+	// Scripting hooks may adjust the title and body before the task is built
+	if runner != nil {
+		issue = runIssueReceivedHook(ctx, runner.Hooks(), client, sourceRepo, issue)
+	}
+
 	taskDesc := fmt.Sprintf("GitHub Issue #%d: %s\n\n%s", issue.Number, issue.Title, issue.Body)
 	branchName := fmt.Sprintf("pilot/%s", taskID)
 
+	// A repos: directive splits the change into one task per repository
+	linkedRepos := github.ParseLinkedRepos(issue.Body)
+	if len(otherLinkedRepos(linkedRepos, sourceRepo)) > 0 {
+		taskDesc += linkedRepoNote(sourceRepo, linkedRepos)
+	}
+
 	// GH-489: For autopilot-fix issues, reuse the original branch so the fix
 	// lands on the same branch as the failed PR (not a new branch).
 	// GH-1267: Also extract PR number for --from-pr session resumption.
@@ -219,6 +366,20 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 		slog.Int("label_count", len(issue.Labels)),
 	)
 
+	parts := strings.Split(sourceRepo, "/")
+
+	// A blocked issue resumes the agent's session with the requester's reply;
+	// an issue triage handed back starts with the reply and skips triage
+	var resumeSessionID string
+	var clarified bool
+	if github.HasLabel(issue, github.LabelBlocked) || github.HasLabel(issue, github.LabelNeedsInfo) {
+		if unblock, ok := fetchGitHubUnblock(ctx, client, parts, issue); ok {
+			taskDesc += "\n\n" + unblock.Prompt()
+			resumeSessionID = unblock.SessionID
+			clarified = unblock.NeedsInfo
+		}
+	}
+
 	task := &executor.Task{
 		ID:                 taskID,
 		Title:              issue.Title,
@@ -227,19 +388,40 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 		Branch:             branchName,
 		CreatePR:           true,
 		SourceRepo:         sourceRepo,
-		MemberID:           resolveGitHubMemberID(issue),                 // GH-634: RBAC lookup
-		Labels:             labels,                                       // GH-727: flow labels for complexity classifier
-		AcceptanceCriteria: github.ExtractAcceptanceCriteria(issue.Body), // GH-920: acceptance criteria in prompts
-		FromPR:             fromPR,                                       // GH-1267: session resumption from PR context
+		MemberID:           resolveGitHubMemberID(issue),                        // GH-634: RBAC lookup
+		Labels:             labels,                                              // GH-727: flow labels for complexity classifier
+		AcceptanceCriteria: github.ExtractAcceptanceCriteria(issue.Body),        // GH-920: acceptance criteria in prompts
+		PriorAttempts:      fetchGitHubPriorAttempts(ctx, client, parts, issue), // retry history from issue thread
+		FromPR:             fromPR,                                              // GH-1267: session resumption from PR context
+		ResumeSessionID:    resumeSessionID,
+		Clarified:          clarified,
+	}
+	if project != nil {
+		task.Scope = project.Scope
 	}
 
-	parts := strings.Split(sourceRepo, "/")
+	// Status comments for this attempt: edited in place unless comments.mode is legacy
+	comments := githubCommentManager(client, cfg.Adapters.GitHub.Comments)
 
 	// Add pilot-in-progress label before execution begins
 	if len(parts) == 2 {
 		if err := client.AddLabels(ctx, parts[0], parts[1], issue.Number, []string{github.LabelInProgress}); err != nil {
 			logGitHubAPIError("AddLabels", parts[0], parts[1], issue.Number, err)
 		}
+		for _, label := range []string{github.LabelBlocked, github.LabelNeedsInfo} {
+			if github.HasLabel(issue, label) {
+				if err := client.RemoveLabel(ctx, parts[0], parts[1], issue.Number, label); err != nil {
+					logGitHubAPIError("RemoveLabel", parts[0], parts[1], issue.Number, err)
+				}
+			}
+		}
+		// The in-place status comment is opened here and rewritten with the result below
+		if comments.InPlace() {
+			started := fmt.Sprintf("🤖 **Pilot started working on this issue**\n\nTask ID: `%s`", taskID)
+			if err := comments.Post(ctx, parts[0], parts[1], issue.Number, started); err != nil {
+				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
+			}
+		}
 	}
 
 	// GH-1853: Move issue to "In Progress" column on project board
@@ -276,6 +458,14 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 		HeadSHA:    hr.HeadSHA,
 		Error:      hr.Error,
 	}
+	if hr.Result != nil {
+		issueResult.Confidence = hr.Result.Confidence
+	}
+
+	// Run the other repositories' tasks once this repo's PR exists
+	if execErr == nil && hr.Success && hr.PRNumber > 0 {
+		runLinkedRepoTasks(ctx, deps, client, issue, sourceRepo, task, issueResult, linkedRepos)
+	}
 
 	// Post-execution: label management, close issue, add rich execution comment
 	if len(parts) == 2 {
@@ -286,13 +476,58 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 		// GH-1853: Resolve board statuses once for all paths (nil-safe via GetStatuses)
 		boardStatuses := cfg.Adapters.GitHub.ProjectBoard.GetStatuses()
 
-		if execErr != nil {
+		var blocked *executor.BlockedError
+		var needsInfo *executor.NeedsInfoError
+		var deferred *executor.DeferredError
+		if errors.Is(execErr, executor.ErrTaskCancelled) {
+			// Cancelled on request: drop the trigger label rather than marking the
+			// issue failed, so the poller leaves it alone until it is re-labeled
+			trigger := cfg.Adapters.GitHub.PilotLabel
+			if cfg.Adapters.GitHub.Polling != nil && cfg.Adapters.GitHub.Polling.Label != "" {
+				trigger = cfg.Adapters.GitHub.Polling.Label
+			}
+			if trigger != "" {
+				if err := client.RemoveLabel(ctx, parts[0], parts[1], issue.Number, trigger); err != nil {
+					logGitHubAPIError("RemoveLabel", parts[0], parts[1], issue.Number, err)
+				}
+			}
+			comment := fmt.Sprintf("🛑 Pilot execution was cancelled.\n\nTask ID: `%s`\n\nAdd the `%s` label again to retry.", taskID, trigger)
+			if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
+			}
+		} else if errors.As(execErr, &deferred) {
+			// Budget routing held back a low-priority issue. The poller picks
+			// it up again once the daily budget resets.
+			if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, github.DeferredComment(taskID, deferred)); err != nil {
+				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
+			}
+		} else if errors.As(execErr, &needsInfo) {
+			// Triage stopped the task before execution: ask for clarification.
+			// The poller skips the issue until the label is removed.
+			if err := client.AddLabels(ctx, parts[0], parts[1], issue.Number, []string{github.LabelNeedsInfo}); err != nil {
+				logGitHubAPIError("AddLabels", parts[0], parts[1], issue.Number, err)
+			}
+			comment := github.NeedsInfoComment(taskID, string(needsInfo.Verdict), needsInfo.Reason, needsInfo.Questions, issue.User.Login)
+			if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
+			}
+		} else if errors.As(execErr, &blocked) {
+			// Blocked: ask the requester instead of failing. The poller picks
+			// the issue up again once they reply.
+			if err := client.AddLabels(ctx, parts[0], parts[1], issue.Number, []string{github.LabelBlocked}); err != nil {
+				logGitHubAPIError("AddLabels", parts[0], parts[1], issue.Number, err)
+			}
+			comment := github.BlockedComment(taskID, blocked.Reason, blocked.SessionID, issue.User.Login)
+			if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
+			}
+		} else if execErr != nil {
 			if err := client.AddLabels(ctx, parts[0], parts[1], issue.Number, []string{github.LabelFailed}); err != nil {
 				logGitHubAPIError("AddLabels", parts[0], parts[1], issue.Number, err)
 			}
 			syncBoardStatus(ctx, boardSync, issue.NodeID, boardStatuses.Failed) // GH-1853
-			comment := fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", execErr.Error())
-			if _, err := client.AddComment(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+			comment := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", execErr.Error()))
+			if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
 				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
 			}
 		} else if hr.Result != nil && hr.Result.Success {
@@ -305,7 +540,7 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 				syncBoardStatus(ctx, boardSync, issue.NodeID, boardStatuses.Failed) // GH-1853
 				comment := fmt.Sprintf("⚠️ Pilot execution completed but no changes were made.\n\n**Duration:** %s\n**Branch:** `%s`\n\nNo commits or PR were created. The task may need clarification or manual intervention.",
 					hr.Result.Duration, branchName)
-				if _, err := client.AddComment(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+				if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
 					logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
 				}
 				// Update issueResult to reflect failure
@@ -319,11 +554,13 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 					logGitHubAPIError("AddLabels", parts[0], parts[1], issue.Number, err)
 				}
 				// GH-1869: Move to Review column when PR is created
+				var reviewers *github.ReviewerAssignment
 				if hr.PRNumber > 0 {
 					syncBoardStatus(ctx, boardSync, issue.NodeID, boardStatuses.Review)
 
 					// GH-2099: Auto-assign PR reviewers from project config
-					requestReviewersFromConfig(ctx, cfg, client, sourceRepo, parts[0], parts[1], hr.PRNumber)
+					requestReviewersFromConfig(ctx, project, client, sourceRepo, parts[0], parts[1], hr.PRNumber)
+					reviewers = assignReviewers(ctx, cfg, client, parts[0], parts[1], hr.PRNumber)
 				}
 				syncBoardStatus(ctx, boardSync, issue.NodeID, boardStatuses.Done) // GH-1853
 
@@ -339,8 +576,8 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 					logGitHubAPIError("UpdateIssueState", parts[0], parts[1], issue.Number, err)
 				}
 
-				comment := buildExecutionComment(hr.Result, branchName)
-				if _, err := client.AddComment(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+				comment := buildExecutionComment(hr.Result, branchName) + reviewerAssignmentNote(reviewers, parts[0])
+				if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
 					logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
 				}
 			}
@@ -351,10 +588,17 @@ func handleGitHubIssueWithResult(ctx context.Context, cfg *config.Config, client
 			}
 			syncBoardStatus(ctx, boardSync, issue.NodeID, boardStatuses.Failed) // GH-1853
 			comment := buildFailureComment(hr.Result)
-			if _, err := client.AddComment(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
+			if err := comments.Finish(ctx, parts[0], parts[1], issue.Number, comment); err != nil {
 				logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
 			}
 		}
+
+		// Publish the pilot/execution check on the PR head commit
+		if hr.PRNumber > 0 {
+			if err := github.PublishExecutionCheck(ctx, client, cfg.Adapters.GitHub.ExecutionCheck, parts[0], parts[1], hr.HeadSHA, taskID, hr.Result); err != nil {
+				logGitHubAPIError("CreateCheckRun", parts[0], parts[1], issue.Number, err)
+			}
+		}
 	}
 
 	return issueResult, execErr
@@ -379,6 +623,7 @@ func handleLinearIssueWithResult(ctx context.Context, cfg *config.Config, client
 		AcceptanceCriteria: github.ExtractAcceptanceCriteria(issue.Description),
 		SourceAdapter:      "linear",
 		SourceIssueID:      issue.ID,
+		Labels:             extractLinearLabelNames(issue),
 	}
 
 	// GH-1472: Wire Linear client as SubIssueCreator for epic decomposition
@@ -416,7 +661,7 @@ func handleLinearIssueWithResult(ctx context.Context, cfg *config.Config, client
 
 	// Post-execution: add comment, transition issue to Done state
 	if execErr != nil {
-		comment := fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", execErr.Error())
+		comment := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", execErr.Error()))
 		if err := client.AddComment(ctx, issue.ID, comment); err != nil {
 			logging.WithComponent("linear").Warn("Failed to add comment",
 				slog.String("issue", issue.Identifier),
@@ -521,7 +766,7 @@ func handleJiraIssueWithResult(ctx context.Context, cfg *config.Config, client *
 
 	// Post-execution: add comment (plain text format), transition issue via explicit ID or name lookup
 	if execErr != nil {
-		comment := fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error())
+		comment := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error()))
 		if _, err := client.AddComment(ctx, issue.Key, comment); err != nil {
 			logging.WithComponent("jira").Warn("Failed to add comment",
 				slog.String("issue", issue.Key),
@@ -610,7 +855,7 @@ func buildJiraFailureComment(result *executor.ExecutionResult) string {
 	if result.Duration > 0 {
 		parts = append(parts, fmt.Sprintf("Duration: %s", result.Duration))
 	}
-	return strings.Join(parts, "\n")
+	return scrubOutgoing(strings.Join(parts, "\n"))
 }
 
 // handleAsanaTaskWithResult processes an Asana task picked up by the poller (GH-906)
@@ -667,7 +912,7 @@ func handleAsanaTaskWithResult(ctx context.Context, cfg *config.Config, client *
 
 	// Post-execution: add comment, call CompleteTask()
 	if execErr != nil {
-		comment := fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error())
+		comment := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error()))
 		if _, err := client.AddComment(ctx, task.GID, comment); err != nil {
 			logging.WithComponent("asana").Warn("Failed to add comment",
 				slog.String("task", task.GID),
@@ -745,7 +990,7 @@ func buildAsanaFailureComment(result *executor.ExecutionResult) string {
 	if result.Duration > 0 {
 		parts = append(parts, fmt.Sprintf("Duration: %s", result.Duration))
 	}
-	return strings.Join(parts, "\n")
+	return scrubOutgoing(strings.Join(parts, "\n"))
 }
 
 // buildExecutionComment formats a comment for successful executions.
@@ -794,12 +1039,22 @@ func buildExecutionComment(result *executor.ExecutionResult, branchName string)
 		sb.WriteString(fmt.Sprintf("| PR | %s |\n", result.PRUrl))
 	}
 
+	// Retries under executor.retry's per-failure-class policies
+	if result.Retry != nil && result.Retry.Attempts > 0 {
+		sb.WriteString(fmt.Sprintf("| Retries | %s |\n", result.Retry))
+	}
+
+	// Coverage before and after the change (coverage delta check)
+	if delta := result.QualityGates.CoverageDelta(); delta != nil {
+		sb.WriteString(fmt.Sprintf("| Coverage | %s |\n", delta))
+	}
+
 	// Intent warning (from intent judge, GH-624)
 	if result.IntentWarning != "" {
 		sb.WriteString(fmt.Sprintf("\n⚠️ **Intent Warning:** %s\n", result.IntentWarning))
 	}
 
-	return sb.String()
+	return scrubOutgoing(sb.String())
 }
 
 // buildFailureComment formats a comment for failed executions.
@@ -811,6 +1066,12 @@ func buildFailureComment(result *executor.ExecutionResult) string {
 		sb.WriteString(fmt.Sprintf("```\n%s\n```\n", result.Error))
 		sb.WriteString("</details>\n")
 	}
+	if result != nil && result.Retry != nil {
+		sb.WriteString(fmt.Sprintf("\n**Retry policy:** %s\n", result.Retry))
+		if result.Retry.DraftPR && result.PRUrl != "" {
+			sb.WriteString(fmt.Sprintf("**Draft PR:** %s\n", result.PRUrl))
+		}
+	}
 	if result != nil {
 		if result.Duration > 0 {
 			sb.WriteString(fmt.Sprintf("\n**Duration:** %s", result.Duration.Round(time.Second)))
@@ -823,7 +1084,19 @@ func buildFailureComment(result *executor.ExecutionResult) string {
 		}
 		sb.WriteString("\n")
 	}
-	return sb.String()
+	return scrubOutgoing(sb.String())
+}
+
+// scrubOutgoing redacts credentials from a comment before it is posted to
+// an issue tracker, or withholds it when leak_guard.action is block.
+func scrubOutgoing(comment string) string {
+	clean, found := leakGuard.Scrub(comment)
+	if len(found) > 0 {
+		logging.WithComponent("leak-guard").Warn("Secrets found in outgoing comment",
+			slog.Any("rules", found),
+		)
+	}
+	return clean
 }
 
 // formatTokenCountComment formats a token count for display in comments.
@@ -901,7 +1174,7 @@ func handlePlaneIssueWithResult(ctx context.Context, cfg *config.Config, client
 	workspaceSlug := cfg.Adapters.Plane.WorkspaceSlug
 	projectID := issue.ProjectID
 	if execErr != nil {
-		comment := fmt.Sprintf("<p>❌ Pilot execution failed:</p><pre>%s</pre>", execErr.Error())
+		comment := scrubOutgoing(fmt.Sprintf("<p>❌ Pilot execution failed:</p><pre>%s</pre>", execErr.Error()))
 		if err := client.AddComment(ctx, workspaceSlug, projectID, issue.ID, comment); err != nil {
 			logging.WithComponent("plane").Warn("Failed to add failure comment",
 				slog.String("issue_id", issue.ID),
@@ -929,7 +1202,7 @@ func handlePlaneIssueWithResult(ctx context.Context, cfg *config.Config, client
 			}
 		}
 	} else if hr.Result != nil {
-		comment := fmt.Sprintf("<p>❌ Pilot execution failed:</p><pre>%s</pre>", hr.Result.Error)
+		comment := scrubOutgoing(fmt.Sprintf("<p>❌ Pilot execution failed:</p><pre>%s</pre>", hr.Result.Error))
 		if err := client.AddComment(ctx, workspaceSlug, projectID, issue.ID, comment); err != nil {
 			logging.WithComponent("plane").Warn("Failed to add failure comment",
 				slog.String("issue_id", issue.ID),
@@ -954,6 +1227,302 @@ func buildPlaneExecutionComment(result *executor.ExecutionResult, branchName str
 	return comment
 }
 
+// notionTaskID derives a short task ID from a Notion page UUID for display and branch names.
+func notionTaskID(pageID string) string {
+	id := strings.ReplaceAll(pageID, "-", "")
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return "NOTION-" + id
+}
+
+// handleNotionPageWithResult processes a Notion database page picked up by the poller.
+// The page body becomes the task description and its checklist items become acceptance criteria.
+func handleNotionPageWithResult(ctx context.Context, cfg *config.Config, client *notion.Client, page *notion.Page, projectPath string, dispatcher *executor.Dispatcher, runner *executor.Runner, monitor *executor.Monitor, program *tea.Program, alertsEngine *alerts.Engine, enforcer *budget.Enforcer) (*notion.PageResult, error) {
+	taskID := notionTaskID(page.ID)
+	title := page.Title()
+
+	var content notion.PageContent
+	blocks, err := client.GetBlockChildren(ctx, page.ID)
+	if err != nil {
+		logging.WithComponent("notion").Warn("Failed to fetch page content, using title only",
+			slog.String("page_id", page.ID),
+			slog.Any("error", err),
+		)
+	} else {
+		content = notion.ConvertBlocks(blocks)
+	}
+
+	taskDesc := fmt.Sprintf("Notion Page %s: %s\n\n%s", taskID, title, content.Description)
+	branchName := fmt.Sprintf("pilot/%s", taskID)
+
+	task := &executor.Task{
+		ID:                 taskID,
+		Title:              title,
+		Description:        taskDesc,
+		ProjectPath:        projectPath,
+		Branch:             branchName,
+		CreatePR:           true,
+		SourceAdapter:      "notion",
+		SourceIssueID:      page.ID,
+		AcceptanceCriteria: content.AcceptanceCriteria,
+	}
+
+	deps := HandlerDeps{
+		Cfg:          cfg,
+		Dispatcher:   dispatcher,
+		Runner:       runner,
+		Monitor:      monitor,
+		Program:      program,
+		AlertsEngine: alertsEngine,
+		Enforcer:     enforcer,
+		ProjectPath:  projectPath,
+	}
+	info := IssueInfo{
+		TaskID:   taskID,
+		Title:    title,
+		URL:      page.URL,
+		Adapter:  "notion",
+		LogEmoji: "📓",
+	}
+
+	hr, execErr := handleIssueGeneric(ctx, deps, info, task)
+
+	pageResult := &notion.PageResult{
+		Success:    hr.Success,
+		BranchName: hr.BranchName,
+		PRNumber:   hr.PRNumber,
+		PRURL:      hr.PRURL,
+		HeadSHA:    hr.HeadSHA,
+		Error:      hr.Error,
+	}
+
+	// Post-execution: write the outcome back as a page comment.
+	// The poller updates the status property from pageResult.Success.
+	var comment string
+	if execErr != nil {
+		comment = scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n%s", execErr.Error()))
+	} else if hr.Result != nil && hr.Result.Success {
+		if hr.Result.CommitSHA == "" && hr.Result.PRUrl == "" {
+			comment = fmt.Sprintf("⚠️ Pilot execution completed but no changes were made.\nDuration: %s\nBranch: %s\nNo commits or PR were created. The task may need clarification or manual intervention.",
+				hr.Result.Duration, branchName)
+			pageResult.Success = false
+		} else {
+			comment = buildNotionExecutionComment(hr.Result, branchName)
+		}
+	} else if hr.Result != nil {
+		comment = buildAsanaFailureComment(hr.Result)
+	}
+	if comment != "" {
+		if err := client.AddComment(ctx, page.ID, comment); err != nil {
+			logging.WithComponent("notion").Warn("Failed to add result comment",
+				slog.String("page_id", page.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return pageResult, execErr
+}
+
+// buildNotionExecutionComment creates a plain-text comment for a successful Notion execution.
+// Notion comments do not render Markdown, so metrics are listed one per line.
+func buildNotionExecutionComment(result *executor.ExecutionResult, branchName string) string {
+	lines := []string{"✅ Pilot execution completed successfully."}
+	if result.PRUrl != "" {
+		lines = append(lines, "🔗 Pull Request: "+result.PRUrl)
+	}
+	lines = append(lines, "🌿 Branch: "+branchName)
+	if result.Duration > 0 {
+		lines = append(lines, "⏱ Duration: "+result.Duration.Round(time.Second).String())
+	}
+	if result.FilesChanged > 0 {
+		lines = append(lines, fmt.Sprintf("📄 Files: %d changed (+%d -%d)", result.FilesChanged, result.LinesAdded, result.LinesRemoved))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// handleTrelloCardWithResult processes a Trello card picked up by the poller.
+// The card description becomes the task description and its checklist items become acceptance criteria.
+func handleTrelloCardWithResult(ctx context.Context, cfg *config.Config, client *trello.Client, card *trello.Card, projectPath string, dispatcher *executor.Dispatcher, runner *executor.Runner, monitor *executor.Monitor, program *tea.Program, alertsEngine *alerts.Engine, enforcer *budget.Enforcer) (*trello.CardResult, error) {
+	taskID := trello.TaskID(card)
+
+	taskDesc := fmt.Sprintf("Trello Card %s: %s\n\n%s", taskID, card.Name, card.Desc)
+	branchName := fmt.Sprintf("pilot/%s", taskID)
+
+	task := &executor.Task{
+		ID:                 taskID,
+		Title:              card.Name,
+		Description:        taskDesc,
+		ProjectPath:        projectPath,
+		Branch:             branchName,
+		CreatePR:           true,
+		SourceAdapter:      "trello",
+		SourceIssueID:      card.ID,
+		AcceptanceCriteria: card.AcceptanceCriteria(),
+	}
+
+	deps := HandlerDeps{
+		Cfg:          cfg,
+		Dispatcher:   dispatcher,
+		Runner:       runner,
+		Monitor:      monitor,
+		Program:      program,
+		AlertsEngine: alertsEngine,
+		Enforcer:     enforcer,
+		ProjectPath:  projectPath,
+	}
+	info := IssueInfo{
+		TaskID:   taskID,
+		Title:    card.Name,
+		URL:      card.URL,
+		Adapter:  "trello",
+		LogEmoji: "📋",
+	}
+
+	hr, execErr := handleIssueGeneric(ctx, deps, info, task)
+
+	cardResult := &trello.CardResult{
+		Success:    hr.Success,
+		BranchName: hr.BranchName,
+		PRNumber:   hr.PRNumber,
+		PRURL:      hr.PRURL,
+		HeadSHA:    hr.HeadSHA,
+		Error:      hr.Error,
+	}
+
+	// Post-execution: write the outcome back as a card comment.
+	// The card moves to the done list when its PR merges, not here.
+	var comment string
+	if execErr != nil {
+		comment = scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n```\n%s\n```", execErr.Error()))
+	} else if hr.Result != nil && hr.Result.Success {
+		if hr.Result.CommitSHA == "" && hr.Result.PRUrl == "" {
+			comment = fmt.Sprintf("⚠️ Pilot execution completed but no changes were made.\n\n**Duration:** %s\n**Branch:** `%s`\n\nNo commits or PR were created. The task may need clarification or manual intervention.",
+				hr.Result.Duration, branchName)
+			cardResult.Success = false
+		} else {
+			comment = buildTrelloExecutionComment(hr.Result, branchName)
+		}
+	} else if hr.Result != nil {
+		comment = buildAsanaFailureComment(hr.Result)
+	}
+	if comment != "" {
+		if _, err := client.AddComment(ctx, card.ID, comment); err != nil {
+			logging.WithComponent("trello").Warn("Failed to add result comment",
+				slog.String("card_id", card.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return cardResult, execErr
+}
+
+// buildTrelloExecutionComment creates a Markdown comment for a successful Trello execution.
+// Trello comments render basic Markdown but not tables, so metrics are listed one per line.
+func buildTrelloExecutionComment(result *executor.ExecutionResult, branchName string) string {
+	lines := []string{"✅ **Pilot execution completed successfully.**", ""}
+	if result.PRUrl != "" {
+		lines = append(lines, "🔗 **Pull Request:** "+result.PRUrl)
+	}
+	lines = append(lines, fmt.Sprintf("🌿 **Branch:** `%s`", branchName))
+	if result.Duration > 0 {
+		lines = append(lines, "⏱ **Duration:** "+result.Duration.Round(time.Second).String())
+	}
+	if result.FilesChanged > 0 {
+		lines = append(lines, fmt.Sprintf("📄 **Files:** %d changed (+%d -%d)", result.FilesChanged, result.LinesAdded, result.LinesRemoved))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// handleClickUpTaskWithResult processes a ClickUp task picked up by the poller.
+// The poller writes PR URL, cost, and duration to custom fields from the returned result.
+func handleClickUpTaskWithResult(ctx context.Context, cfg *config.Config, client *clickup.Client, cuTask *clickup.Task, projectPath string, dispatcher *executor.Dispatcher, runner *executor.Runner, monitor *executor.Monitor, program *tea.Program, alertsEngine *alerts.Engine, enforcer *budget.Enforcer) (*clickup.TaskResult, error) {
+	taskID := clickup.TaskID(cuTask)
+
+	taskDesc := fmt.Sprintf("ClickUp Task %s: %s\n\n%s", taskID, cuTask.Name, cuTask.Body())
+	branchName := fmt.Sprintf("pilot/%s", taskID)
+
+	// Checklists are the explicit acceptance criteria; fall back to criteria in the description
+	criteria := cuTask.AcceptanceCriteria()
+	if len(criteria) == 0 {
+		criteria = github.ExtractAcceptanceCriteria(cuTask.Body())
+	}
+
+	task := &executor.Task{
+		ID:                 taskID,
+		Title:              cuTask.Name,
+		Description:        taskDesc,
+		ProjectPath:        projectPath,
+		Branch:             branchName,
+		CreatePR:           true,
+		SourceAdapter:      "clickup",
+		SourceIssueID:      cuTask.ID,
+		AcceptanceCriteria: criteria,
+	}
+
+	deps := HandlerDeps{
+		Cfg:          cfg,
+		Dispatcher:   dispatcher,
+		Runner:       runner,
+		Monitor:      monitor,
+		Program:      program,
+		AlertsEngine: alertsEngine,
+		Enforcer:     enforcer,
+		ProjectPath:  projectPath,
+	}
+	info := IssueInfo{
+		TaskID:   taskID,
+		Title:    cuTask.Name,
+		URL:      cuTask.URL,
+		Adapter:  "clickup",
+		LogEmoji: "✅",
+	}
+
+	hr, execErr := handleIssueGeneric(ctx, deps, info, task)
+
+	taskResult := &clickup.TaskResult{
+		Success:    hr.Success,
+		BranchName: hr.BranchName,
+		PRNumber:   hr.PRNumber,
+		PRURL:      hr.PRURL,
+		HeadSHA:    hr.HeadSHA,
+		Duration:   hr.Duration,
+		Error:      hr.Error,
+	}
+	if hr.Result != nil {
+		taskResult.CostUSD = hr.Result.EstimatedCostUSD
+	}
+
+	// Post-execution: write the outcome back as a task comment.
+	// The poller updates status and custom fields from taskResult.
+	var comment string
+	if execErr != nil {
+		comment = scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n%s", execErr.Error()))
+	} else if hr.Result != nil && hr.Result.Success {
+		if hr.Result.CommitSHA == "" && hr.Result.PRUrl == "" {
+			comment = fmt.Sprintf("⚠️ Pilot execution completed but no changes were made.\nDuration: %s\nBranch: %s\nNo commits or PR were created. The task may need clarification or manual intervention.",
+				hr.Result.Duration, branchName)
+			taskResult.Success = false
+		} else {
+			comment = buildNotionExecutionComment(hr.Result, branchName)
+		}
+	} else if hr.Result != nil {
+		comment = buildAsanaFailureComment(hr.Result)
+	}
+	if comment != "" {
+		if err := client.AddComment(ctx, cuTask.ID, comment); err != nil {
+			logging.WithComponent("clickup").Warn("Failed to add result comment",
+				slog.String("clickup_id", cuTask.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return taskResult, execErr
+}
+
 func handleGitLabIssueWithResult(ctx context.Context, cfg *config.Config, client *gitlab.Client, issue *gitlab.Issue, projectPath string, dispatcher *executor.Dispatcher, runner *executor.Runner, monitor *executor.Monitor, program *tea.Program, alertsEngine *alerts.Engine, enforcer *budget.Enforcer) (*gitlab.IssueResult, error) {
 	taskID := fmt.Sprintf("GL-%d", issue.IID)
 	branchName := fmt.Sprintf("pilot/%s", taskID)
@@ -967,6 +1536,7 @@ func handleGitLabIssueWithResult(ctx context.Context, cfg *config.Config, client
 		ProjectPath: projectPath,
 		Branch:      branchName,
 		CreatePR:    true,
+		Labels:      issue.Labels,
 	}
 
 	deps := HandlerDeps{
@@ -999,7 +1569,7 @@ func handleGitLabIssueWithResult(ctx context.Context, cfg *config.Config, client
 	}
 
 	if execErr != nil {
-		note := fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error())
+		note := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error()))
 		if _, err := client.AddIssueNote(ctx, issue.IID, note); err != nil {
 			logging.WithComponent("gitlab").Warn("Failed to add failure note",
 				slog.Int("iid", issue.IID),
@@ -1038,7 +1608,7 @@ func handleGitLabIssueWithResult(ctx context.Context, cfg *config.Config, client
 			}
 		}
 	} else if hr.Result != nil {
-		note := fmt.Sprintf("❌ Pilot execution failed\n\nError: %s\nDuration: %s", hr.Result.Error, hr.Result.Duration)
+		note := scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed\n\nError: %s\nDuration: %s", hr.Result.Error, hr.Result.Duration))
 		if _, err := client.AddIssueNote(ctx, issue.IID, note); err != nil {
 			logging.WithComponent("gitlab").Warn("Failed to add failure note",
 				slog.Int("iid", issue.IID),
@@ -1050,6 +1620,83 @@ func handleGitLabIssueWithResult(ctx context.Context, cfg *config.Config, client
 	return issueResult, execErr
 }
 
+// handleGiteaIssueWithResult processes a Gitea/Forgejo issue picked up by the poller.
+// The PR itself is opened through the Gitea API (the runner routes CreatePR via
+// the "gitea" PR creator), so the result carries the Gitea PR URL and number.
+func handleGiteaIssueWithResult(ctx context.Context, cfg *config.Config, client *gitea.Client, issue *gitea.Issue, projectPath string, dispatcher *executor.Dispatcher, runner *executor.Runner, monitor *executor.Monitor, program *tea.Program, alertsEngine *alerts.Engine, enforcer *budget.Enforcer) (*gitea.IssueResult, error) {
+	taskID := gitea.TaskID(issue.Number)
+	branchName := fmt.Sprintf("pilot/%s", taskID)
+
+	taskDesc := fmt.Sprintf("Gitea Issue #%d: %s\n\n%s", issue.Number, issue.Title, issue.Body)
+
+	task := &executor.Task{
+		ID:                 taskID,
+		Title:              issue.Title,
+		Description:        taskDesc,
+		ProjectPath:        projectPath,
+		Branch:             branchName,
+		CreatePR:           true,
+		SourceAdapter:      "gitea",
+		SourceIssueID:      strconv.Itoa(issue.Number),
+		AcceptanceCriteria: github.ExtractAcceptanceCriteria(issue.Body),
+		Labels:             extractGiteaLabelNames(issue),
+	}
+
+	deps := HandlerDeps{
+		Cfg:          cfg,
+		Dispatcher:   dispatcher,
+		Runner:       runner,
+		Monitor:      monitor,
+		Program:      program,
+		AlertsEngine: alertsEngine,
+		Enforcer:     enforcer,
+		ProjectPath:  projectPath,
+	}
+	info := IssueInfo{
+		TaskID:   taskID,
+		Title:    issue.Title,
+		URL:      issue.HTMLURL,
+		Adapter:  "gitea",
+		LogEmoji: "🍵",
+	}
+
+	hr, execErr := handleIssueGeneric(ctx, deps, info, task)
+
+	issueResult := &gitea.IssueResult{
+		Success:    hr.Success,
+		PRNumber:   hr.PRNumber,
+		PRURL:      hr.PRURL,
+		HeadSHA:    hr.HeadSHA,
+		BranchName: hr.BranchName,
+		Error:      hr.Error,
+	}
+
+	var comment string
+	if execErr != nil {
+		comment = scrubOutgoing(fmt.Sprintf("❌ Pilot execution failed:\n\n%s", execErr.Error()))
+	} else if hr.Result != nil && hr.Result.Success {
+		if hr.Result.CommitSHA == "" && hr.Result.PRUrl == "" {
+			comment = fmt.Sprintf("⚠️ Pilot execution completed but no changes were made.\n\nDuration: %s\nBranch: `%s`\n\nNo commits or PR were created. The task may need clarification or manual intervention.",
+				hr.Result.Duration, branchName)
+			issueResult.Success = false
+		} else {
+			comment = buildExecutionComment(hr.Result, branchName)
+		}
+	} else if hr.Result != nil {
+		comment = buildFailureComment(hr.Result)
+	}
+	if comment != "" {
+		if _, err := client.AddComment(ctx, issue.Number, comment); err != nil {
+			logging.WithComponent("gitea").Warn("Failed to add result comment",
+				slog.Int("number", issue.Number),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return issueResult, execErr
+}
+
 // handleAzureDevOpsWorkItemWithResult processes an Azure DevOps work item picked up by the poller (GH-2132).
 func handleAzureDevOpsWorkItemWithResult(ctx context.Context, cfg *config.Config, client *azuredevops.Client, notifier *azuredevops.Notifier, wi *azuredevops.WorkItem, projectPath string, dispatcher *executor.Dispatcher, runner *executor.Runner, monitor *executor.Monitor, program *tea.Program, alertsEngine *alerts.Engine, enforcer *budget.Enforcer) (*azuredevops.WorkItemResult, error) {
 	taskID := fmt.Sprintf("ADO-%d", wi.ID)
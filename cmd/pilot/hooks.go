@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/hooks"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// wireHooks loads the configured scripting hooks and connects them to the
+// runner (before_pr_create, and on_issue_received via runner.Hooks()) and the
+// autopilot controllers (after_merge). A script that fails to load disables
+// hooks with a warning rather than stopping Pilot.
+func wireHooks(cfg *config.Config, runner *executor.Runner, controllers ...*autopilot.Controller) {
+	if cfg.ScriptHooks == nil || !cfg.ScriptHooks.Enabled || runner == nil {
+		return
+	}
+	log := logging.WithComponent("hooks")
+	engine, err := hooks.New(cfg.ScriptHooks, hooks.WithLogger(log))
+	if err != nil {
+		log.Warn("Failed to load scripting hooks, hooks disabled", slog.Any("error", err))
+		return
+	}
+
+	client := hooksGitHubClient(cfg)
+	runner.SetHooks(engine, func(task *executor.Task) hooks.Target {
+		if client == nil || (task.SourceAdapter != "" && task.SourceAdapter != "github") {
+			return nil
+		}
+		owner, repo, ok := strings.Cut(task.SourceRepo, "/")
+		number, err := strconv.Atoi(strings.TrimPrefix(task.ID, "GH-"))
+		if !ok || err != nil {
+			return nil
+		}
+		return github.NewIssueTarget(client, owner, repo, number)
+	})
+
+	if engine.Has(hooks.HookAfterMerge) && client != nil {
+		for _, controller := range controllers {
+			if controller == nil {
+				continue
+			}
+			repoFullName := controller.Repository()
+			controller.AddMergeHook(func(ctx context.Context, prState *autopilot.PRState) {
+				runAfterMergeHook(ctx, engine, client, repoFullName, prState)
+			})
+		}
+	}
+	log.Info("scripting hooks loaded", slog.Int("scripts", len(cfg.ScriptHooks.Scripts)))
+}
+
+// hooksGitHubClient returns the client hook comments and labels are posted
+// with, or nil when GitHub is not configured
+func hooksGitHubClient(cfg *config.Config) *github.Client {
+	if cfg.Adapters == nil || cfg.Adapters.GitHub == nil || !cfg.Adapters.GitHub.Enabled {
+		return nil
+	}
+	token := cfg.Adapters.GitHub.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return nil
+	}
+	return github.NewClient(token)
+}
+
+// runIssueReceivedHook runs on_issue_received for a GitHub issue and returns
+// the issue with any title or body changes applied. The original issue is
+// not modified. Script failures are logged and never block the issue.
+func runIssueReceivedHook(ctx context.Context, engine *hooks.Engine, client *github.Client, sourceRepo string, issue *github.Issue) *github.Issue {
+	if !engine.Has(hooks.HookIssueReceived) {
+		return issue
+	}
+	log := logging.WithComponent("hooks")
+
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	result, err := engine.Run(ctx, hooks.Event{
+		Hook:   hooks.HookIssueReceived,
+		Repo:   sourceRepo,
+		Number: issue.Number,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		Labels: labels,
+		Author: issue.User.Login,
+		URL:    issue.HTMLURL,
+	})
+	if err != nil {
+		log.Warn("on_issue_received hook failed", slog.Int("issue", issue.Number), slog.Any("error", err))
+	}
+
+	if owner, repo, ok := strings.Cut(sourceRepo, "/"); ok && client != nil {
+		if err := result.Apply(ctx, github.NewIssueTarget(client, owner, repo, issue.Number)); err != nil {
+			log.Warn("Failed to apply on_issue_received hook actions", slog.Int("issue", issue.Number), slog.Any("error", err))
+		}
+	}
+
+	if result.Title == issue.Title && result.Body == issue.Body {
+		return issue
+	}
+	updated := *issue
+	if result.Title != "" {
+		updated.Title = result.Title
+	}
+	updated.Body = result.Body
+	return &updated
+}
+
+// runAfterMergeHook runs after_merge for a merged PR, posting actions on the PR
+func runAfterMergeHook(ctx context.Context, engine *hooks.Engine, client *github.Client, repoFullName string, prState *autopilot.PRState) {
+	log := logging.WithComponent("hooks")
+	owner, repo, _ := strings.Cut(repoFullName, "/")
+	event := hooks.Event{
+		Hook:   hooks.HookAfterMerge,
+		Repo:   repoFullName,
+		Number: prState.PRNumber,
+		Branch: prState.BranchName,
+		URL:    prState.PRURL,
+	}
+	if pr, err := client.GetPullRequest(ctx, owner, repo, prState.PRNumber); err == nil {
+		event.Title, event.Body, event.Author = pr.Title, pr.Body, pr.User.Login
+	} else {
+		log.Debug("Failed to fetch merged PR for after_merge hook", slog.Int("pr", prState.PRNumber), slog.Any("error", err))
+	}
+
+	result, err := engine.Run(ctx, event)
+	if err != nil {
+		log.Warn("after_merge hook failed", slog.Int("pr", prState.PRNumber), slog.Any("error", err))
+	}
+	if err := result.Apply(ctx, github.NewIssueTarget(client, owner, repo, prState.PRNumber)); err != nil {
+		log.Warn("Failed to apply after_merge hook actions", slog.Int("pr", prState.PRNumber), slog.Any("error", err))
+	}
+}
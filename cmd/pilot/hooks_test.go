@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/hooks"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestRunIssueReceivedHook(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "triage.star")
+	src := `
+def on_issue_received(event):
+    if "security" in event.labels:
+        pilot.label("priority:high")
+        pilot.comment("Routed to the security team")
+    pilot.set("body", event.body + "\n\nFollow SECURITY.md")
+`
+	if err := os.WriteFile(script, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := hooks.New(&hooks.Config{Scripts: []string{script}})
+	if err != nil {
+		t.Fatalf("hooks.New() error = %v", err)
+	}
+
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1})
+	}))
+	defer server.Close()
+	client := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+
+	issue := &github.Issue{Number: 7, Title: "Fix token leak", Body: "Tokens are logged", Labels: []github.Label{{Name: "security"}}}
+	updated := runIssueReceivedHook(context.Background(), engine, client, "acme/api", issue)
+
+	if updated.Body != "Tokens are logged\n\nFollow SECURITY.md" || updated.Title != "Fix token leak" {
+		t.Errorf("updated issue = %q / %q", updated.Title, updated.Body)
+	}
+	if issue.Body != "Tokens are logged" {
+		t.Error("original issue was modified")
+	}
+
+	joined := strings.Join(calls, "\n")
+	for _, want := range []string{"POST /repos/acme/api/issues/7/comments", "Routed to the security team", "POST /repos/acme/api/issues/7/labels", "priority:high"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("GitHub calls missing %q:\n%s", want, joined)
+		}
+	}
+
+	// Without an engine the issue passes through untouched
+	if got := runIssueReceivedHook(context.Background(), nil, client, "acme/api", issue); got != issue {
+		t.Error("nil engine should return the issue unchanged")
+	}
+}
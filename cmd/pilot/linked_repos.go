@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// otherLinkedRepos returns the repos of a repos: directive other than sourceRepo
+func otherLinkedRepos(repos []string, sourceRepo string) []string {
+	var others []string
+	for _, repo := range repos {
+		if !strings.EqualFold(repo, sourceRepo) {
+			others = append(others, repo)
+		}
+	}
+	return others
+}
+
+// linkedRepoNote tells the agent which part of a multi-repo change its task covers
+func linkedRepoNote(repo string, repos []string) string {
+	return fmt.Sprintf("\n\n## Multi-repo change\n\nThis issue spans the repositories %s. "+
+		"This task covers only the changes in %s; each other repository gets its own task and PR, "+
+		"and the PRs are merged together.", strings.Join(repos, ", "), repo)
+}
+
+// runLinkedRepoTasks runs the issue's task in every other repository named
+// by its repos: directive, once the source repo's PR exists. The PRs are
+// cross-referenced and linked, so autopilot merges all of them once every
+// one is green, and none while any is missing or red.
+func runLinkedRepoTasks(ctx context.Context, deps HandlerDeps, client *github.Client, issue *github.Issue, sourceRepo string, task *executor.Task, primary *github.IssueResult, repos []string) []autopilot.LinkedPR {
+	others := otherLinkedRepos(repos, sourceRepo)
+	if len(others) == 0 {
+		return nil
+	}
+
+	prs := []autopilot.LinkedPR{{Repo: sourceRepo, PRNumber: primary.PRNumber, PRURL: primary.PRURL}}
+	var failures []string
+	for _, repo := range others {
+		pr, err := runLinkedRepoTask(ctx, deps, issue, sourceRepo, repo, task, repos)
+		if err != nil {
+			slog.Warn("linked repo task failed",
+				slog.String("task_id", task.ID),
+				slog.String("repo", repo),
+				slog.Any("error", err),
+			)
+			failures = append(failures, fmt.Sprintf("%s: %v", repo, err))
+		}
+		prs = append(prs, pr)
+	}
+
+	groupID := fmt.Sprintf("%s#%d", sourceRepo, issue.Number)
+	if err := linkedMerges.Link(groupID, prs); err != nil {
+		slog.Warn("failed to save linked PRs", slog.String("id", groupID), slog.Any("error", err))
+	}
+
+	// Cross-reference every PR with the others
+	for _, pr := range prs {
+		if pr.PRNumber == 0 {
+			continue
+		}
+		parts := strings.SplitN(pr.Repo, "/", 2)
+		if _, err := client.AddComment(ctx, parts[0], parts[1], pr.PRNumber, linkedPRsComment(groupID, prs, pr, failures)); err != nil {
+			logGitHubAPIError("AddComment", parts[0], parts[1], pr.PRNumber, err)
+		}
+	}
+	parts := strings.SplitN(sourceRepo, "/", 2)
+	if _, err := client.AddComment(ctx, parts[0], parts[1], issue.Number, linkedPRsComment(groupID, prs, autopilot.LinkedPR{}, failures)); err != nil {
+		logGitHubAPIError("AddComment", parts[0], parts[1], issue.Number, err)
+	}
+	return prs
+}
+
+// runLinkedRepoTask runs the issue's task in the project configured for repo
+// and hands the PR to that repo's autopilot controller
+func runLinkedRepoTask(ctx context.Context, deps HandlerDeps, issue *github.Issue, sourceRepo, repo string, task *executor.Task, repos []string) (autopilot.LinkedPR, error) {
+	pr := autopilot.LinkedPR{Repo: repo}
+
+	proj := deps.Cfg.RouteProject(repo, task.Labels, issue.Title+"\n"+issue.Body)
+	if proj == nil {
+		return pr, fmt.Errorf("no project configured for %s", repo)
+	}
+	if err := executor.ValidateRepoProjectMatch(repo, proj.Path); err != nil {
+		return pr, fmt.Errorf("cross-project execution blocked: %w", err)
+	}
+
+	linked := *task
+	linked.ID = fmt.Sprintf("%s-%s", task.ID, proj.GitHub.Repo)
+	linked.ProjectPath = proj.Path
+	linked.SourceRepo = repo
+	linked.Scope = proj.Scope
+	linked.Description = fmt.Sprintf("GitHub Issue %s#%d: %s\n\n%s", sourceRepo, issue.Number, issue.Title, issue.Body) + linkedRepoNote(repo, repos)
+	linked.FromPR = 0
+	linked.ResumeSessionID = ""
+
+	deps.ProjectPath = proj.Path
+	info := IssueInfo{
+		TaskID:   linked.ID,
+		Title:    issue.Title,
+		URL:      issue.HTMLURL,
+		Adapter:  "github",
+		LogEmoji: "🔗",
+	}
+	hr, err := handleIssueGeneric(ctx, deps, info, &linked)
+	if err != nil {
+		return pr, err
+	}
+	if hr.PRNumber == 0 {
+		return pr, fmt.Errorf("task finished without a PR")
+	}
+
+	pr.PRNumber = hr.PRNumber
+	pr.PRURL = hr.PRURL
+	if controller := linkedMerges.Controller(repo); controller != nil {
+		controller.OnPRCreated(hr.PRNumber, hr.PRURL, 0, hr.HeadSHA, hr.BranchName, "")
+	}
+	return pr, nil
+}
+
+// linkedPRsComment lists the PRs of a multi-repo change for the PR self, or
+// for the issue when self is zero
+func linkedPRsComment(groupID string, prs []autopilot.LinkedPR, self autopilot.LinkedPR, failures []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🔗 **Multi-repo change** for %s\n\n", groupID)
+	if self.PRNumber != 0 {
+		sb.WriteString("This PR merges together with:\n")
+	} else {
+		sb.WriteString("Linked PRs:\n")
+	}
+	for _, pr := range prs {
+		if pr.Repo == self.Repo && pr.PRNumber == self.PRNumber {
+			continue
+		}
+		if pr.PRNumber == 0 {
+			fmt.Fprintf(&sb, "- %s — ❌ no PR\n", pr.Repo)
+			continue
+		}
+		fmt.Fprintf(&sb, "- %s\n", pr)
+	}
+	sb.WriteString("\nAutopilot merges these PRs only once all of them are green; none merges while any is failing or missing.")
+	if len(failures) > 0 {
+		sb.WriteString("\n\n**Failed:**\n")
+		for _, f := range failures {
+			fmt.Fprintf(&sb, "- %s\n", f)
+		}
+		sb.WriteString("\nAutopilot holds the linked PRs. Merge them by hand once the missing change is in place.")
+	}
+	return scrubOutgoing(sb.String())
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+)
+
+func TestOtherLinkedRepos(t *testing.T) {
+	got := otherLinkedRepos([]string{"acme/api", "Acme/Web", "acme/docs"}, "acme/web")
+	if want := []string{"acme/api", "acme/docs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("otherLinkedRepos() = %v, want %v", got, want)
+	}
+	if got := otherLinkedRepos([]string{"acme/web"}, "acme/web"); got != nil {
+		t.Errorf("otherLinkedRepos() with only the source = %v", got)
+	}
+}
+
+func TestLinkedPRsComment(t *testing.T) {
+	prs := []autopilot.LinkedPR{
+		{Repo: "acme/api", PRNumber: 3},
+		{Repo: "acme/web", PRNumber: 7},
+		{Repo: "acme/docs"},
+	}
+
+	comment := linkedPRsComment("acme/api#12", prs, prs[0], []string{"acme/docs: no project configured for acme/docs"})
+	if strings.Contains(comment, "acme/api#3") {
+		t.Errorf("PR comment lists the PR itself:\n%s", comment)
+	}
+	for _, want := range []string{"This PR merges together with", "- acme/web#7", "- acme/docs — ❌ no PR", "no project configured", "Merge them by hand"} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("PR comment missing %q:\n%s", want, comment)
+		}
+	}
+
+	issueComment := linkedPRsComment("acme/api#12", prs[:2], autopilot.LinkedPR{}, nil)
+	if !strings.Contains(issueComment, "- acme/api#3") || !strings.Contains(issueComment, "- acme/web#7") || strings.Contains(issueComment, "Failed") {
+		t.Errorf("issue comment:\n%s", issueComment)
+	}
+}
@@ -3,7 +3,6 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -21,6 +20,7 @@ import (
 	"github.com/alekspetrov/pilot/internal/adapters/discord"
 	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/adapters/linear"
+	"github.com/alekspetrov/pilot/internal/adapters/mattermost"
 	"github.com/alekspetrov/pilot/internal/adapters/plane"
 	"github.com/alekspetrov/pilot/internal/adapters/slack"
 	"github.com/alekspetrov/pilot/internal/adapters/telegram"
@@ -34,6 +34,7 @@ import (
 	"github.com/alekspetrov/pilot/internal/budget"
 	"github.com/alekspetrov/pilot/internal/config"
 	"github.com/alekspetrov/pilot/internal/dashboard"
+	"github.com/alekspetrov/pilot/internal/encryption"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/gateway"
 	"github.com/alekspetrov/pilot/internal/logging"
@@ -49,7 +50,10 @@ var (
 	version     = "1.0.0"
 	buildTime   = "unknown"
 	cfgFile     string
-	teamAdapter *teams.ServiceAdapter // Global team adapter for RBAC lookups (GH-634)
+	teamAdapter *teams.ServiceAdapter       // Global team adapter for RBAC lookups (GH-634)
+	leakGuard   = quality.NewLeakGuard(nil) // Redacts credentials from outgoing issue comments
+
+	linkedMerges = autopilot.NewLinkedMerges() // Merges the PRs of multi-repo changes together
 )
 
 var quietMode bool
@@ -59,6 +63,10 @@ func main() {
 		Use:   "pilot",
 		Short: "AI that ships your tickets",
 		Long:  `Pilot is an autonomous AI development pipeline that receives tickets, implements features, and creates PRs.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			initEncryption()
+			initMemoryBackend()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// If no subcommand provided, enter interactive mode
 			if err := runInteractiveMode(); err != nil {
@@ -101,11 +109,58 @@ func main() {
 		newOnboardCmd(),
 		newBackendCmd(),
 		newEvalCmd(),
+		newCIRunCmd(),
+		newScheduleCmd(),
+		newEpicCmd(),
+		newPauseCmd(),
+		newResumeCmd(),
+		newFreezeCmd(),
+		newDashboardCmd(),
+		newDataCmd(),
+		newWorkerCmd(),
+		newStateCmd(),
+		newSecretsCmd(),
+		newTakeoverCmd(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// initEncryption loads the encryption key so recordings and the memory
+// store are sealed and opened transparently by every command. A key that
+// cannot be loaded is reported but not fatal: commands that only read
+// plaintext data keep working, while encrypted data fails to open instead
+// of being written in the clear.
+func initEncryption() {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil || cfg.Encryption == nil || !cfg.Encryption.Enabled {
+		return
+	}
+	if err := encryption.Init(cfg.Encryption); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// initMemoryBackend points every memory store opened by the command at the
+// configured database, SQLite unless memory.driver selects PostgreSQL.
+func initMemoryBackend() {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil || cfg.Memory == nil {
+		return
+	}
+	if err := memory.InitBackend(cfg.Memory.Backend()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: memory: %v\n", err)
 	}
 }
 
@@ -115,12 +170,13 @@ func newStartCmd() *cobra.Command {
 		projectPath   string
 		replace       bool
 		// Input adapter flags (override config) - use bool with "changed" check
-		enableTelegram bool
-		enableGithub   bool
-		enableLinear   bool
-		enableSlack    bool
-		enablePlane    bool
-		enableDiscord  bool
+		enableTelegram   bool
+		enableGithub     bool
+		enableLinear     bool
+		enableSlack      bool
+		enablePlane      bool
+		enableDiscord    bool
+		enableMattermost bool
 		// Mode flags
 		noGateway    bool   // Lightweight mode: polling only, no HTTP gateway
 		sequential   bool   // Sequential execution mode (one issue at a time)
@@ -144,6 +200,7 @@ Examples:
   pilot start --telegram               # Enable Telegram polling
   pilot start --github                 # Enable GitHub polling
   pilot start --slack                  # Enable Slack Socket Mode
+  pilot start --mattermost             # Enable Mattermost WebSocket listener
   pilot start --telegram --github      # Enable both
   pilot start --dashboard              # With TUI dashboard
   pilot start --no-gateway             # Polling only (no HTTP server)`,
@@ -160,7 +217,7 @@ Examples:
 			}
 
 			// Apply flag overrides to config
-			applyInputOverrides(cfg, cmd, enableTelegram, enableGithub, enableLinear, enableSlack, enableTunnel, enablePlane, enableDiscord)
+			applyInputOverrides(cfg, cmd, enableTelegram, enableGithub, enableLinear, enableSlack, enableTunnel, enablePlane, enableDiscord, enableMattermost)
 
 			// Apply team ID override if flag provided
 			if teamID != "" {
@@ -170,6 +227,8 @@ Examples:
 			// Apply team flag overrides (GH-635)
 			applyTeamOverrides(cfg, cmd, teamID, teamMember)
 
+			leakGuard = quality.NewLeakGuard(cfg.LeakGuard)
+
 			// Initialize logging with config (GH-847)
 			// Apply log-format flag override if set
 			if cmd.Flags().Changed("log-format") {
@@ -191,12 +250,6 @@ Examples:
 					"path", configPath)
 			}
 
-			// GH-710: Validate Slack Socket Mode config — degrade gracefully if app_token missing
-			if cfg.Adapters.Slack != nil && cfg.Adapters.Slack.SocketMode && cfg.Adapters.Slack.AppToken == "" {
-				logging.WithComponent("slack").Warn("socket_mode enabled but app_token not configured, skipping Slack Socket Mode")
-				cfg.Adapters.Slack.SocketMode = false
-			}
-
 			// Stamp build version into executor config for feature matrix updates (GH-1388)
 			if cfg.Executor == nil {
 				cfg.Executor = executor.DefaultBackendConfig()
@@ -225,7 +278,7 @@ Examples:
 			hasTelegram := cfg.Adapters.Telegram != nil && cfg.Adapters.Telegram.Enabled
 			hasGithubPolling := cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Enabled &&
 				cfg.Adapters.GitHub.Polling != nil && cfg.Adapters.GitHub.Polling.Enabled
-			hasSlack := cfg.Adapters.Slack != nil && cfg.Adapters.Slack.Enabled && cfg.Adapters.Slack.SocketMode
+			hasMattermost := cfg.Adapters.Mattermost != nil && cfg.Adapters.Mattermost.Enabled
 
 			// Apply execution mode override from CLI flags
 			if sequential {
@@ -258,7 +311,37 @@ Examples:
 					return err
 				}
 			}
-			
+
+			// Startup preflight: one structured report instead of scattered warnings
+			if err := runStartupPreflight(cfg, projectPath, dashboardMode); err != nil {
+				return err
+			}
+
+			// Warm standby: hold here until this daemon is the HA primary
+			var primary *haPrimary
+			if cfg.HA != nil && cfg.HA.Enabled {
+				var err error
+				primary, err = becomePrimary(cfg)
+				if errors.Is(err, errStandbyInterrupted) {
+					fmt.Println("\n🛑 Standby stopped")
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				defer primary.Release()
+			}
+
+			secretsCtx, stopSecrets := context.WithCancel(context.Background())
+			defer stopSecrets()
+			watchSecretProviders(secretsCtx, cfg)
+
+			// GH-710: Degrade gracefully if app_token missing (reported by preflight)
+			if cfg.Adapters.Slack != nil && cfg.Adapters.Slack.SocketMode && cfg.Adapters.Slack.AppToken == "" {
+				cfg.Adapters.Slack.SocketMode = false
+			}
+			hasSlack := cfg.Adapters.Slack != nil && cfg.Adapters.Slack.Enabled && cfg.Adapters.Slack.SocketMode
+
 			// GH-394: Polling mode is the default when any polling adapter is enabled.
 			// Previously, having linear.enabled=true would force gateway mode even when
 			// only using GitHub/Telegram polling. Now polling adapters work independently.
@@ -272,7 +355,7 @@ Examples:
 			// When both are needed, gateway starts in background within polling mode.
 			hasPollingAdapter := hasTelegram || hasGithubPolling
 			if noGateway || hasPollingAdapter {
-				return runPollingMode(cfg, projectPath, replace, dashboardMode, noGateway)
+				return runPollingMode(cfg, projectPath, replace, dashboardMode, noGateway, primary)
 			}
 
 			// Full daemon mode with gateway
@@ -298,20 +381,26 @@ Examples:
 			telegramFlagSet := cmd.Flags().Changed("telegram")
 			githubFlagSet := cmd.Flags().Changed("github")
 			slackFlagSet := cmd.Flags().Changed("slack")
+			mattermostFlagSet := cmd.Flags().Changed("mattermost")
 			needsPollingInfra := (telegramFlagSet && hasTelegram && cfg.Adapters.Telegram.Polling) ||
 				(githubFlagSet && hasGithubPolling && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Enabled &&
 					cfg.Adapters.GitHub.Polling != nil && cfg.Adapters.GitHub.Polling.Enabled) ||
-				(slackFlagSet && hasSlack)
+				(slackFlagSet && hasSlack) ||
+				(mattermostFlagSet && hasMattermost)
 
 			// Shared infrastructure for polling adapters
 			var gwRunner *executor.Runner
 			var gwStore *memory.Store
 			var gwDispatcher *executor.Dispatcher
+			var gwFairScheduler *executor.FairScheduler
+			var gwPause *executor.PipelinePause
 			var gwMonitor *executor.Monitor
 			var gwProgram *tea.Program
 			var gwAutopilotController *autopilot.Controller
 			var gwAutopilotStateStore *autopilot.StateStore
 			var gwAlertsEngine *alerts.Engine
+			var gwApprovalMgr *approval.Manager
+			gwHub := gateway.NewDashboardHub() // Remote dashboard state for /ws/dashboard/live
 
 			if needsPollingInfra {
 				// Create shared runner with config (GH-956: enables worktree isolation)
@@ -320,6 +409,8 @@ Examples:
 				if runnerErr != nil {
 					return fmt.Errorf("failed to create executor runner: %w", runnerErr)
 				}
+				gwRunner.SetProjectFeaturesResolver(projectFeaturesResolver(cfg))
+				gwRunner.SetLeakGuard(leakGuard)
 
 				// Set up quality gates on runner if configured
 				if cfg.Quality != nil && cfg.Quality.Enabled {
@@ -355,10 +446,22 @@ Examples:
 				if storeErr != nil {
 					logging.WithComponent("start").Warn("Failed to open memory store for gateway polling", slog.Any("error", storeErr))
 				}
+				startRetention(context.Background(), cfg, gwStore)
+				persistGitHubETags(gwStore)
+				gwPause = newPipelinePause(gwStore)
+				if gwPause.Paused() {
+					logging.WithComponent("start").Warn("Pipeline is paused, pollers will not dispatch new work until 'pilot resume'")
+				}
 
 				// Create dispatcher if store available
 				if gwStore != nil {
 					gwDispatcher = executor.NewDispatcher(gwStore, gwRunner, nil)
+					gwFairScheduler = newFairScheduler(cfg)
+					if gwFairScheduler != nil {
+						gwDispatcher.SetFairScheduler(gwFairScheduler)
+					}
+					// Queued tasks run on worker nodes in cluster mode
+					gwDispatcher.SetRemote(clusterEnabled(cfg))
 					if dispErr := gwDispatcher.Start(); dispErr != nil {
 						logging.WithComponent("start").Warn("Failed to start dispatcher for gateway polling", slog.Any("error", dispErr))
 						gwDispatcher = nil
@@ -372,6 +475,7 @@ Examples:
 						logging.WithComponent("teams").Warn("Failed to initialize team store for gateway", slog.Any("error", teamErr))
 					} else {
 						teamSvc := teams.NewService(teamStore)
+						configureTeamService(teamSvc, cfg)
 						teamAdapter = teams.NewServiceAdapter(teamSvc)
 						gwRunner.SetTeamChecker(teamAdapter)
 						logging.WithComponent("teams").Info("team RBAC enforcement enabled for gateway mode")
@@ -392,10 +496,17 @@ Examples:
 				// GH-1599: Wire log store for execution milestone entries (gateway mode)
 				if gwStore != nil {
 					gwRunner.SetLogStore(gwStore)
+					gwRunner.SetDurationHistory(gwStore)
+					gwRunner.SetEpicStore(gwStore)
+					gwRunner.SetEstimateTracker(gwStore)
 				}
 
 				// Create approval manager for autopilot
 				approvalMgr := approval.NewManager(cfg.Approval)
+				gwApprovalMgr = approvalMgr
+				if gwStore != nil {
+					approvalMgr.SetAuditStore(gwStore)
+				}
 
 				// Register Telegram approval handler if enabled
 				if cfg.Adapters.Telegram != nil && cfg.Adapters.Telegram.Enabled && cfg.Adapters.Telegram.BotToken != "" {
@@ -418,6 +529,11 @@ Examples:
 					}
 				}
 
+				// Only members whose role allows the stage may answer approvals
+				if teamAdapter != nil {
+					approvalMgr.SetAuthorizer(teamAdapter)
+				}
+
 				// Create autopilot controller if enabled
 				if cfg.Orchestrator.Autopilot != nil && cfg.Orchestrator.Autopilot.Enabled {
 					ghToken := ""
@@ -444,8 +560,9 @@ Examples:
 								approvalMgr,
 								parts[0],
 								parts[1],
-								gwBoardOpts...,
+								withProjectOptions(cfg, cfg.Adapters.GitHub.Repo, gwBoardOpts)...,
 							)
+							gwAutopilotController.SetLeakGuard(leakGuard)
 						}
 					}
 				}
@@ -467,6 +584,9 @@ Examples:
 					}
 				}
 
+				// Scripting hooks (on_issue_received, before_pr_create, after_merge)
+				wireHooks(cfg, gwRunner, gwAutopilotController)
+
 				// Create alerts engine if configured
 				alertsCfg := getAlertsConfig(cfg)
 				if alertsCfg != nil && alertsCfg.Enabled {
@@ -531,6 +651,13 @@ Examples:
 						gwAlertsEngine = nil
 					}
 				}
+				if gwAlertsEngine != nil && gwAutopilotController != nil {
+					gwAutopilotController.SetAlertProcessor(gwAlertsEngine)
+				}
+				if gwAutopilotController != nil {
+					wireBranchFixers(gwAutopilotController, gwRunner, cfg, cfg.Adapters.GitHub.Repo)
+					wireCodeReviewer(gwAutopilotController, gwRunner, gwStore)
+				}
 
 				// Create monitor and TUI program for dashboard mode
 				if dashboardMode {
@@ -543,6 +670,12 @@ Examples:
 					}
 					model := dashboard.NewModelWithOptions(version, gwStore, gwAutopilotController, nil)
 					model.SetProjectPath(projectPath)
+					model.SetHub(gwHub)
+					model.SetConcurrencySource(gwRunner)
+					if gwFairScheduler != nil {
+						model.SetFairnessSource(gwFairScheduler)
+					}
+					model.SetPauseControl(gwPause)
 					gwProgram = tea.NewProgram(model,
 						tea.WithAltScreen(),
 						tea.WithInput(os.Stdin),
@@ -574,6 +707,14 @@ Examples:
 					gwRunner.AddTokenCallback("dashboard", func(taskID string, inputTokens, outputTokens int64) {
 						gwProgram.Send(dashboard.UpdateTokens(int(inputTokens), int(outputTokens))())
 					})
+				} else {
+					// No TUI to mirror: feed the remote dashboard from the runner
+					gwMonitor = executor.NewMonitor()
+					gwRunner.SetMonitor(gwMonitor)
+					if gwAutopilotController != nil {
+						gwAutopilotController.SetMonitor(gwMonitor)
+					}
+					mirrorRunnerToHub(context.Background(), gwHub, gwRunner, gwMonitor)
 				}
 			}
 
@@ -611,11 +752,17 @@ Examples:
 							Timestamp: time.Now(),
 						})
 					})
+					// Warn when month-end spend is projected over the monthly limit
+					go gwEnforcer.WatchForecast(context.Background(), time.Hour)
 				}
 				logging.WithComponent("start").Info("budget enforcement enabled (gateway mode)",
 					slog.Float64("daily_limit", cfg.Budget.DailyLimit),
 					slog.Float64("monthly_limit", cfg.Budget.MonthlyLimit),
 				)
+				// Daily spend drives budget-aware model routing
+				if gwRunner != nil {
+					gwRunner.SetBudgetState(gwEnforcer)
+				}
 				// GH-539: Wire per-task token/duration limits into executor stream (gateway mode)
 				maxTokens, maxDuration := gwEnforcer.GetPerTaskLimits()
 				if gwRunner != nil && (maxTokens > 0 || maxDuration > 0) {
@@ -691,10 +838,14 @@ Examples:
 
 					var pollerOpts []github.PollerOption
 					pollerOpts = append(pollerOpts, github.WithExecutionMode(execMode))
+					if cfg.Executor != nil {
+						pollerOpts = append(pollerOpts, github.WithClarification(cfg.Executor.Clarification))
+					}
 
 					// Wire autopilot OnPRCreated callback if controller initialized
 					if gwAutopilotController != nil {
 						pollerOpts = append(pollerOpts, github.WithOnPRCreated(gwAutopilotController.OnPRCreated))
+						pollerOpts = append(pollerOpts, github.WithOnPRConfidence(gwAutopilotController.SetPRConfidence))
 						// Wire sub-issue PR callback so epic sub-PRs are tracked by autopilot (GH-594)
 						gwRunner.SetOnSubIssuePRCreated(gwAutopilotController.OnPRCreated)
 					}
@@ -739,6 +890,7 @@ Examples:
 						// GH-797: Call OnPRCreated for retried issues so autopilot tracks their PRs
 						if result != nil && result.PRNumber > 0 && gwAutopilotController != nil {
 							gwAutopilotController.OnPRCreated(result.PRNumber, result.PRURL, issue.Number, result.HeadSHA, result.BranchName, issue.NodeID)
+							gwAutopilotController.SetPRConfidence(result.PRNumber, result.Confidence)
 						}
 
 						return err
@@ -759,17 +911,17 @@ Examples:
 						pollerOpts = append(pollerOpts,
 							github.WithSequentialConfig(waitForMerge, pollInterval, prTimeout),
 							github.WithScheduler(rateLimitScheduler),
-							github.WithOnIssueWithResult(func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
+							github.WithOnIssueWithResult(pausable(gwPause, func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
 								return handleGitHubIssueWithResult(issueCtx, cfg, client, issue, projectPath, gwSourceRepo, gwDispatcher, gwRunner, gwMonitor, gwProgram, gwAlertsEngine, gwEnforcer)
-							}),
+							})),
 						)
 					} else {
 						pollerOpts = append(pollerOpts,
 							github.WithScheduler(rateLimitScheduler),
 							github.WithMaxConcurrent(cfg.Orchestrator.MaxConcurrent),
-							github.WithOnIssueWithResult(func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
+							github.WithOnIssueWithResult(pausable(gwPause, func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
 								return handleGitHubIssueWithResult(issueCtx, cfg, client, issue, projectPath, gwSourceRepo, gwDispatcher, gwRunner, gwMonitor, gwProgram, gwAlertsEngine, gwEnforcer)
-							}),
+							})),
 						)
 					}
 
@@ -826,20 +978,22 @@ Examples:
 				Program:             gwProgram,
 				AlertsEngine:        gwAlertsEngine,
 				Enforcer:            gwEnforcer,
+				Store:               gwStore,
 				AutopilotController: gwAutopilotController,
 				AutopilotStateStore: gwAutopilotStateStore,
+				ApprovalManager:     gwApprovalMgr,
+				Pause:               gwPause,
 			}
 			StartAdapterPollers(context.Background(), gwPollerDeps, adapterPollerRegistrations())
 
 			// Wire teams service if --team flag provided (GH-633)
-			var teamsDB *sql.DB
+			var teamsDB *memory.Store
 			if cfg.TeamID != "" {
-				dbPath := filepath.Join(cfg.Memory.Path, "pilot.db")
-				teamsDB, err = sql.Open("sqlite", dbPath)
+				teamsDB, err = memory.NewStore(cfg.Memory.Path)
 				if err != nil {
 					return fmt.Errorf("failed to open teams database: %w", err)
 				}
-				teamsStore, storeErr := teams.NewStore(teamsDB)
+				teamsStore, storeErr := teams.NewStore(teamsDB.DB())
 				if storeErr != nil {
 					_ = teamsDB.Close()
 					return fmt.Errorf("failed to create teams store: %w", storeErr)
@@ -865,6 +1019,17 @@ Examples:
 					slog.String("team_name", team.Name))
 			}
 
+			// Management REST API: autopilot PR stages, budget status and cancellation of poller executions
+			if gwAutopilotController != nil {
+				pilotOpts = append(pilotOpts, pilot.WithAutopilotProvider(&autopilotProviderAdapter{controller: gwAutopilotController}))
+			}
+			if gwEnforcer != nil {
+				pilotOpts = append(pilotOpts, pilot.WithBudgetEnforcer(gwEnforcer))
+			}
+			if gwRunner != nil {
+				pilotOpts = append(pilotOpts, pilot.WithExecutionRunner(gwRunner))
+			}
+
 			// Create and start Pilot
 			p, err := pilot.New(cfg, pilotOpts...)
 			if err != nil {
@@ -885,9 +1050,26 @@ Examples:
 				logging.WithComponent("start").Info("quality gates enabled for webhook mode")
 			}
 
-			// GH-1585: Wire autopilot provider to gateway so /api/v1/autopilot returns live PR data
+			// GH-1585: Autopilot provider is wired via pilot.WithAutopilotProvider so /api/v1/autopilot returns live PR data
 			if gwAutopilotController != nil {
-				p.Gateway().SetAutopilotProvider(&autopilotProviderAdapter{controller: gwAutopilotController})
+				// Stream autopilot stage transitions on the gateway's /events endpoint
+				gwAutopilotController.AddStageHook(func(prState *autopilot.PRState, from autopilot.PRStage) {
+					event := gateway.Event{
+						Type: gateway.EventAutopilot,
+						Data: gateway.AutopilotEventData{
+							PRNumber:  prState.PRNumber,
+							PRURL:     prState.PRURL,
+							Repo:      gwAutopilotController.Repository(),
+							FromStage: string(from),
+							Stage:     string(prState.Stage),
+							Error:     prState.Error,
+						},
+					}
+					if prState.IssueNumber > 0 {
+						event.TaskID = fmt.Sprintf("GH-%d", prState.IssueNumber)
+					}
+					p.Gateway().PublishEvent(event)
+				})
 
 				// GH-2080: Wire PR review events to autopilot controller
 				p.SetOnPRReview(func(ctx context.Context, prNumber int, action, state, reviewer string, repo *github.Repository) error {
@@ -902,6 +1084,8 @@ Examples:
 			if gwStore != nil {
 				p.Gateway().SetDashboardStore(gwStore)
 				p.Gateway().SetLogStreamStore(gwStore)
+				p.Gateway().SetDeliveryStore(gwStore)
+				p.Gateway().SetPortalRepoResolver(portalRepoResolver(cfg))
 			}
 
 			// GH-1633: Wire git graph fetcher to gateway so /api/v1/gitgraph returns live git data
@@ -909,6 +1093,14 @@ Examples:
 				return dashboard.FetchGitGraph(path, limit)
 			})
 			p.Gateway().SetGitGraphPath(projectPath)
+			p.Gateway().SetDashboardHub(gwHub)
+
+			// Serve worker nodes the queue the dispatcher leaves to them
+			if gwDispatcher != nil && clusterEnabled(cfg) {
+				clusterCtx, stopCluster := context.WithCancel(context.Background())
+				defer stopCluster()
+				startCoordinator(clusterCtx, cfg, gwStore, gwRunner, p.Gateway())
+			}
 
 			// GH-1935: Wire learning system into gateway mode (mirrors polling-mode wiring)
 			if gwStore != nil && (cfg.Memory.Learning == nil || cfg.Memory.Learning.Enabled) {
@@ -953,6 +1145,16 @@ Examples:
 				return fmt.Errorf("failed to start Pilot: %w", err)
 			}
 
+			// Report the HA takeover now that alerts can go out
+			if gwAlertsEngine != nil {
+				primary.SetAlertsEngine(gwAlertsEngine)
+			} else {
+				primary.SetAlertsEngine(p.AlertEngine())
+			}
+
+			// Start recurring task scheduler if enabled
+			gwTaskScheduler := startTaskScheduler(context.Background(), cfg, gwDispatcher, gwStore, gwAlertsEngine, projectPath)
+
 			// Start tunnel if enabled
 			if cfg.Tunnel != nil && cfg.Tunnel.Enabled {
 				if cfg.Tunnel.Port == 0 {
@@ -1059,6 +1261,10 @@ Examples:
 				fmt.Println("🎮 Discord gateway enabled")
 			}
 
+			if hasMattermost {
+				fmt.Println("🗨️  Mattermost WebSocket active")
+			}
+
 			// Wait for shutdown signal
 			sigCh := make(chan os.Signal, 1)
 			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -1066,6 +1272,10 @@ Examples:
 			<-sigCh
 			fmt.Println("\n🛑 Shutting down...")
 
+			if gwTaskScheduler != nil {
+				gwTaskScheduler.Stop()
+			}
+
 			// Close teams DB if opened (GH-633)
 			if teamsDB != nil {
 				_ = teamsDB.Close()
@@ -1094,6 +1304,7 @@ cmd.Flags().BoolVar(&dashboardMode, "dashboard", false, "Show TUI dashboard for
 	cmd.Flags().BoolVar(&enableSlack, "slack", false, "Enable Slack Socket Mode (overrides config)")
 	cmd.Flags().BoolVar(&enablePlane, "plane", false, "Enable Plane.so polling (overrides config)")
 	cmd.Flags().BoolVar(&enableDiscord, "discord", false, "Enable Discord bot (overrides config)")
+	cmd.Flags().BoolVar(&enableMattermost, "mattermost", false, "Enable Mattermost WebSocket listener (overrides config)")
 	cmd.Flags().BoolVar(&enableTunnel, "tunnel", false, "Enable public tunnel for webhook ingress (Cloudflare/ngrok)")
 	cmd.Flags().StringVar(&teamID, "team", "", "Team ID or name for project access scoping (overrides config)")
 	cmd.Flags().StringVar(&teamMember, "team-member", "", "Member email for team access scoping (overrides config)")
@@ -1104,7 +1315,7 @@ cmd.Flags().BoolVar(&dashboardMode, "dashboard", false, "Show TUI dashboard for
 
 // applyInputOverrides applies CLI flag overrides to config
 // Uses cmd.Flags().Changed() to only apply flags that were explicitly set
-func applyInputOverrides(cfg *config.Config, cmd *cobra.Command, telegramFlag, githubFlag, linearFlag, slackFlag, tunnelFlag, planeFlag, discordFlag bool) {
+func applyInputOverrides(cfg *config.Config, cmd *cobra.Command, telegramFlag, githubFlag, linearFlag, slackFlag, tunnelFlag, planeFlag, discordFlag, mattermostFlag bool) {
 	if cmd.Flags().Changed("telegram") {
 		if cfg.Adapters.Telegram == nil {
 			cfg.Adapters.Telegram = telegram.DefaultConfig()
@@ -1157,6 +1368,12 @@ func applyInputOverrides(cfg *config.Config, cmd *cobra.Command, telegramFlag, g
 		}
 		cfg.Adapters.Discord.Enabled = discordFlag
 	}
+	if cmd.Flags().Changed("mattermost") {
+		if cfg.Adapters.Mattermost == nil {
+			cfg.Adapters.Mattermost = mattermost.DefaultConfig()
+		}
+		cfg.Adapters.Mattermost.Enabled = mattermostFlag
+	}
 }
 
 // applyTeamOverrides applies --team and --team-member CLI flag overrides to config (GH-635).
@@ -1178,7 +1395,7 @@ func applyTeamOverrides(cfg *config.Config, cmd *cobra.Command, teamID, teamMemb
 // runPollingMode runs lightweight polling-only mode.
 // When noGateway is false, the HTTP gateway starts in the background so the
 // desktop app (and any other client hitting /health) can reach the daemon.
-func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMode, noGateway bool) error {
+func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMode, noGateway bool, primary *haPrimary) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -1188,9 +1405,8 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		return fmt.Errorf("telegram enabled but bot_token not configured")
 	}
 
-	// GH-710: Validate Slack Socket Mode config — degrade gracefully if app_token missing
+	// GH-710: Degrade gracefully if app_token missing (reported by preflight)
 	if cfg.Adapters.Slack != nil && cfg.Adapters.Slack.SocketMode && cfg.Adapters.Slack.AppToken == "" {
-		logging.WithComponent("slack").Warn("socket_mode enabled but app_token not configured, skipping Slack Socket Mode")
 		cfg.Adapters.Slack.SocketMode = false
 	}
 
@@ -1205,6 +1421,8 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 	if err != nil {
 		return fmt.Errorf("failed to create executor runner: %w", err)
 	}
+	runner.SetProjectFeaturesResolver(projectFeaturesResolver(cfg))
+	runner.SetLeakGuard(leakGuard)
 
 	// Set up quality gates if configured (GH-207)
 	if cfg.Quality != nil && cfg.Quality.Enabled {
@@ -1299,8 +1517,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 						approvalMgr,
 						parts[0],
 						parts[1],
-						autopilotBoardOpts...,
+						withProjectOptions(cfg, cfg.Adapters.GitHub.Repo, autopilotBoardOpts)...,
 					)
+					controller.SetLeakGuard(leakGuard)
 					autopilotControllers[cfg.Adapters.GitHub.Repo] = controller
 					autopilotController = controller // Default for backwards compat
 				}
@@ -1321,8 +1540,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 					approvalMgr,
 					proj.GitHub.Owner,
 					proj.GitHub.Repo,
-					autopilotBoardOpts...,
+					withProjectOptions(cfg, repoFullName, autopilotBoardOpts)...,
 				)
+				controller.SetLeakGuard(leakGuard)
 				autopilotControllers[repoFullName] = controller
 				logging.WithComponent("autopilot").Info("created controller for project",
 					slog.String("project", proj.Name),
@@ -1345,6 +1565,18 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}()
 	}
 
+	startRetention(ctx, cfg, store)
+	persistGitHubETags(store)
+	if store != nil {
+		approvalMgr.SetAuditStore(store)
+	}
+
+	// Pause gate for pollers; the paused state survives restarts via the store
+	pipelinePause := newPipelinePause(store)
+	if pipelinePause.Paused() {
+		logging.WithComponent("start").Warn("Pipeline is paused, pollers will not dispatch new work until 'pilot resume'")
+	}
+
 	// GH-726: Initialize autopilot state store for crash recovery
 	var autopilotStateStore *autopilot.StateStore
 	if store != nil && len(autopilotControllers) > 0 {
@@ -1353,6 +1585,10 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		if storeErr != nil {
 			logging.WithComponent("autopilot").Warn("Failed to initialize state store", slog.Any("error", storeErr))
 		} else {
+			if err := linkedMerges.SetStore(autopilotStateStore); err != nil {
+				logging.WithComponent("autopilot").Warn("Failed to restore linked PRs", slog.Any("error", err))
+			}
+
 			// GH-929: Wire state store to all controllers
 			for repoName, controller := range autopilotControllers {
 				controller.SetStateStore(autopilotStateStore)
@@ -1370,15 +1606,30 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}
 	}
 
+	// Scripting hooks (on_issue_received, before_pr_create, after_merge)
+	hookControllers := make([]*autopilot.Controller, 0, len(autopilotControllers))
+	for _, controller := range autopilotControllers {
+		hookControllers = append(hookControllers, controller)
+	}
+
+	// Controllers hold the PRs of multi-repo changes until all of them are green
+	for _, controller := range autopilotControllers {
+		linkedMerges.AddController(controller)
+	}
+	wireHooks(cfg, runner, hookControllers...)
+
 	// GH-634: Initialize teams service for RBAC enforcement
+	var teamSvc *teams.Service
 	if store != nil {
 		teamStore, teamErr := teams.NewStore(store.DB())
 		if teamErr != nil {
 			logging.WithComponent("teams").Warn("Failed to initialize team store", slog.Any("error", teamErr))
 		} else {
-			teamSvc := teams.NewService(teamStore)
+			teamSvc = teams.NewService(teamStore)
+			configureTeamService(teamSvc, cfg)
 			teamAdapter = teams.NewServiceAdapter(teamSvc)
 			runner.SetTeamChecker(teamAdapter)
+			approvalMgr.SetAuthorizer(teamAdapter)
 			logging.WithComponent("teams").Info("team RBAC enforcement enabled for polling mode")
 		}
 	}
@@ -1397,6 +1648,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 	// GH-1599: Wire log store for execution milestone entries
 	if store != nil {
 		runner.SetLogStore(store)
+		runner.SetDurationHistory(store)
+		runner.SetEpicStore(store)
+		runner.SetEstimateTracker(store)
 	}
 
 	// GH-1814: Initialize learning system
@@ -1467,45 +1721,55 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}
 	}
 
+	// In webhook and hybrid modes, GitHub webhook deliveries poke the pollers
+	// and autopilot instead of waiting for the next poll
+	var ghWebhooks *github.WebhookFeed
+	if cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Enabled && cfg.Adapters.GitHub.IngestMode() != github.ModePoll {
+		ghWebhooks = github.NewWebhookFeed(cfg.Adapters.GitHub.IngestMode(), cfg.Adapters.GitHub.WebhookQuiet)
+		if noGateway || cfg.Gateway == nil {
+			logging.WithComponent("github").Warn("GitHub webhook mode needs the gateway, polling instead",
+				slog.String("mode", cfg.Adapters.GitHub.IngestMode()))
+		}
+	}
+
 	// GH-1662: Start gateway in background so desktop app can reach /health
+	var dashboardHub *gateway.DashboardHub
+	var gwServer *gateway.Server
 	if !noGateway && cfg.Gateway != nil {
-		gwServer := gateway.NewServer(cfg.Gateway)
+		if cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.WebhookSecret != "" {
+			cfg.Gateway.GithubWebhookSecret = cfg.Adapters.GitHub.WebhookSecret
+		}
+		// Same auth as gateway mode: API token auth guards /api/v1/, and the
+		// task cancel and PR release routes are refused without it.
+		if cfg.Auth != nil && cfg.Auth.Type == gateway.AuthTypeAPIToken {
+			gwServer = gateway.NewServerWithAuth(cfg.Gateway, cfg.Auth)
+		} else {
+			gwServer = gateway.NewServer(cfg.Gateway)
+		}
+		dashboardHub = gateway.NewDashboardHub()
+		gwServer.SetDashboardHub(dashboardHub)
+		gwServer.SetConcurrencySource(runner)
+		gwServer.RegisterAPIHandler("/api/v1/tasks/", taskCancelHandler(runner))
 		if autopilotController != nil {
 			gwServer.SetAutopilotProvider(&autopilotProviderAdapter{controller: autopilotController})
+			gwServer.RegisterAPIHandler("/api/v1/autopilot/prs/", autopilotReleaseHandler(autopilotController, autopilotControllers))
 		}
 
 		// GH-2080: Wire PR review webhook events to autopilot controller in polling mode
-		if autopilotController != nil && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Enabled {
-			capturedController := autopilotController
-			token := cfg.Adapters.GitHub.Token
-			if token == "" {
-				token = os.Getenv("GITHUB_TOKEN")
-			}
-			if token != "" {
-				ghClient := github.NewClient(token)
-				ghWH := github.NewWebhookHandler(ghClient, cfg.Adapters.GitHub.WebhookSecret, cfg.Adapters.GitHub.PilotLabel)
-				ghWH.OnPRReview(func(ctx context.Context, prNumber int, action, state, reviewer string, repo *github.Repository) error {
-					if action == "submitted" {
-						capturedController.OnReviewRequested(prNumber, action, state, reviewer)
-					}
-					return nil
-				})
-				gwServer.Router().RegisterWebhookHandler("github", func(payload map[string]interface{}) {
-					eventType, _ := payload["_event_type"].(string)
-					if err := ghWH.Handle(context.Background(), eventType, payload); err != nil {
-						logging.WithComponent("pilot").Error("GitHub webhook error (polling mode)", slog.Any("error", err))
-					}
-				})
-			}
-		}
+		wireGitHubWebhooks(gwServer, cfg, ghWebhooks, autopilotController, autopilotControllers)
 		if store != nil {
 			gwServer.SetDashboardStore(store)
 			gwServer.SetLogStreamStore(store)
+			gwServer.SetDeliveryStore(store)
+			gwServer.SetPortalRepoResolver(portalRepoResolver(cfg))
 		}
 		gwServer.SetGitGraphFetcher(func(path string, limit int) interface{} {
 			return dashboard.FetchGitGraph(path, limit)
 		})
 		gwServer.SetGitGraphPath(projectPath)
+		if store != nil && clusterEnabled(cfg) {
+			startCoordinator(ctx, cfg, store, runner, gwServer)
+		}
 		go func() {
 			addr := fmt.Sprintf("%s:%d", cfg.Gateway.Host, cfg.Gateway.Port)
 			logging.WithComponent("gateway").Info("gateway started in background", "addr", addr)
@@ -1515,6 +1779,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}()
 	}
 
+	// Fair scheduler is shared by the dispatcher and the dashboard
+	fairScheduler := newFairScheduler(cfg)
+
 	// Create monitor and TUI program for dashboard mode
 	var monitor *executor.Monitor
 	var program *tea.Program
@@ -1531,6 +1798,14 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		upgradeRequestCh = make(chan struct{}, 1)
 		model := dashboard.NewModelWithOptions(version, store, autopilotController, upgradeRequestCh)
 		model.SetProjectPath(projectPath)
+		model.SetConcurrencySource(runner)
+		if fairScheduler != nil {
+			model.SetFairnessSource(fairScheduler)
+		}
+		model.SetPauseControl(pipelinePause)
+		if dashboardHub != nil {
+			model.SetHub(dashboardHub)
+		}
 		program = tea.NewProgram(model,
 			tea.WithAltScreen(),
 			tea.WithInput(os.Stdin),
@@ -1565,10 +1840,19 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		runner.AddTokenCallback("dashboard", func(taskID string, inputTokens, outputTokens int64) {
 			program.Send(dashboard.UpdateTokens(int(inputTokens), int(outputTokens))())
 		})
+	} else if dashboardHub != nil {
+		// No TUI to mirror: feed the remote dashboard from the runner
+		monitor = executor.NewMonitor()
+		runner.SetMonitor(monitor)
+		for _, ctrl := range autopilotControllers {
+			ctrl.SetMonitor(monitor)
+		}
+		mirrorRunnerToHub(ctx, dashboardHub, runner, monitor)
 	}
 
 	// Initialize Telegram handler if enabled
 	var tgHandler *telegram.Handler
+	var tgCommsHandler *comms.Handler
 	if hasTelegram {
 		var allowedIDs []int64
 		// Include explicitly configured allowed IDs
@@ -1607,11 +1891,13 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 
 		// Build comms.MemberResolver wrapper (GH-634)
 		var tgMemberResolver comms.MemberResolver
+		var tgCommands comms.CommandChecker
 		if teamAdapter != nil {
 			tgMemberResolver = &telegram.MemberResolverAdapter{Inner: teamAdapter}
+			tgCommands = teamAdapter
 		}
 
-		tgCommsHandler := comms.NewHandler(&comms.HandlerConfig{
+		tgCommsHandler = comms.NewHandler(&comms.HandlerConfig{
 			Messenger:      tgMessenger,
 			Runner:         runner,
 			Projects:       config.NewProjectSource(cfg),
@@ -1620,8 +1906,10 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 			LLMClassifier:  tgLLMClassifier,
 			ConvStore:      tgConvStore,
 			MemberResolver: tgMemberResolver,
+			Commands:       tgCommands,
 			Store:          store,
 			TaskIDPrefix:   "TG",
+			Adapter:        "telegram",
 		})
 
 		tgConfig := &telegram.HandlerConfig{
@@ -1635,11 +1923,6 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}
 		tgHandler = telegram.NewHandler(tgConfig, runner)
 
-		// Security warning if no allowed IDs configured
-		if len(allowedIDs) == 0 {
-			logging.WithComponent("telegram").Warn("SECURITY: allowed_ids is empty - ALL users can interact with the bot!")
-		}
-
 		// Check for existing instance
 		if err := tgHandler.CheckSingleton(ctx); err != nil {
 			if errors.Is(err, telegram.ErrConflict) {
@@ -1769,10 +2052,30 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}
 	}
 
+	// Route autopilot rollback alerts through the engine
+	if alertsEngine != nil {
+		for _, controller := range autopilotControllers {
+			controller.SetAlertProcessor(alertsEngine)
+		}
+	}
+	primary.SetAlertsEngine(alertsEngine)
+	for repo, controller := range autopilotControllers {
+		wireBranchFixers(controller, runner, cfg, repo)
+		wireCodeReviewer(controller, runner, store)
+	}
+
 	// Initialize dispatcher for task queue (uses store created earlier)
 	var dispatcher *executor.Dispatcher
 	if store != nil {
 		dispatcher = executor.NewDispatcher(store, runner, nil)
+		if fairScheduler != nil {
+			dispatcher.SetFairScheduler(fairScheduler)
+		}
+		// Queued tasks run on worker nodes when the gateway serves them
+		if clusterEnabled(cfg) && gwServer == nil {
+			logging.WithComponent("cluster").Warn("Cluster mode needs the gateway, running tasks locally")
+		}
+		dispatcher.SetRemote(clusterEnabled(cfg) && gwServer != nil)
 		if err := dispatcher.Start(); err != nil {
 			logging.WithComponent("start").Warn("Failed to start dispatcher", slog.Any("error", err))
 			dispatcher = nil
@@ -1795,11 +2098,19 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 					Timestamp: time.Now(),
 				})
 			})
+			// Warn when month-end spend is projected over the monthly limit
+			go enforcer.WatchForecast(ctx, time.Hour)
+		}
+		// The Telegram handler is created before the enforcer
+		if tgCommsHandler != nil {
+			tgCommsHandler.SetBudgetEnforcer(enforcer)
 		}
 		logging.WithComponent("start").Info("budget enforcement enabled",
 			slog.Float64("daily_limit", cfg.Budget.DailyLimit),
 			slog.Float64("monthly_limit", cfg.Budget.MonthlyLimit),
 		)
+		// Daily spend drives budget-aware model routing
+		runner.SetBudgetState(enforcer)
 
 		// GH-539: Wire per-task token/duration limits into executor stream
 		maxTokens, maxDuration := enforcer.GetPerTaskLimits()
@@ -1867,6 +2178,13 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 				interval = 30 * time.Second
 			}
 
+			// One GraphQL query per interval for all repos instead of one
+			// REST listing per repo
+			var batchPoller *github.BatchPoller
+			if cfg.Adapters.GitHub.Polling.Batch {
+				batchPoller = github.NewBatchPoller(client, label, interval)
+			}
+
 			// Determine execution mode from config
 			execMode := github.ExecutionModeSequential // Default to sequential
 			waitForMerge := true
@@ -1900,22 +2218,25 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 				}
 				repoOwner, repoName := repoParts[0], repoParts[1]
 
-				// GH-386: Validate repo/project match at startup
-				if err := executor.ValidateRepoProjectMatch(repoFullName, projPath); err != nil {
-					logging.WithComponent("github").Warn("repo/project mismatch detected",
-						slog.String("repo", repoFullName),
-						slog.String("project_path", projPath),
-						slog.String("expected_project", executor.ExtractRepoName(repoFullName)),
-					)
-				}
+				// GH-386: repo/project mismatches are reported by the startup preflight
 
 				var pollerOpts []github.PollerOption
+				if cfg.Executor != nil {
+					pollerOpts = append(pollerOpts, github.WithClarification(cfg.Executor.Clarification))
+				}
+				if batchPoller != nil {
+					pollerOpts = append(pollerOpts, github.WithBatchPoller(batchPoller))
+				}
+				if ghWebhooks != nil {
+					pollerOpts = append(pollerOpts, github.WithWebhookFeed(ghWebhooks))
+				}
 
 				// Wire autopilot callback to the correct controller for this repo
 				controller := autopilotControllers[repoFullName]
 				if controller != nil {
 					pollerOpts = append(pollerOpts,
 						github.WithOnPRCreated(controller.OnPRCreated),
+						github.WithOnPRConfidence(controller.SetPRConfidence),
 					)
 				}
 
@@ -1952,6 +2273,7 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 
 					if result != nil && result.PRNumber > 0 && controllerCapture != nil {
 						controllerCapture.OnPRCreated(result.PRNumber, result.PRURL, issue.Number, result.HeadSHA, result.BranchName, issue.NodeID)
+						controllerCapture.SetPRConfidence(result.PRNumber, result.Confidence)
 					}
 
 					return err
@@ -1974,22 +2296,38 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 						github.WithExecutionMode(github.ExecutionModeSequential),
 						github.WithSequentialConfig(waitForMerge, pollInterval, prTimeout),
 						github.WithScheduler(rateLimitScheduler),
-						github.WithOnIssueWithResult(func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
+						github.WithOnIssueWithResult(pausable(pipelinePause, func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
 							return handleGitHubIssueWithResult(issueCtx, cfg, client, issue, projPathCapture, sourceRepo, dispatcher, runner, monitor, program, alertsEngine, enforcer)
-						}),
+						})),
 					)
 				} else {
 					pollerOpts = append(pollerOpts,
 						github.WithExecutionMode(github.ExecutionModeParallel),
 						github.WithScheduler(rateLimitScheduler),
 						github.WithMaxConcurrent(cfg.Orchestrator.MaxConcurrent),
-						github.WithOnIssueWithResult(func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
+						github.WithOnIssueWithResult(pausable(pipelinePause, func(issueCtx context.Context, issue *github.Issue) (*github.IssueResult, error) {
 							return handleGitHubIssueWithResult(issueCtx, cfg, client, issue, projPathCapture, sourceRepo, dispatcher, runner, monitor, program, alertsEngine, enforcer)
-						}),
+						})),
 					)
 				}
 
-				return github.NewPoller(client, repoFullName, label, interval, pollerOpts...)
+				poller, err := github.NewPoller(client, repoFullName, label, interval, pollerOpts...)
+				if err != nil {
+					return nil, err
+				}
+
+				// /pilot retry, cancel and plan from issue comments
+				if cfg.Adapters.GitHub.Commands != nil && cfg.Adapters.GitHub.Commands.Enabled {
+					commands, cmdErr := newGitHubCommentCommands(cfg.Adapters.GitHub, client, poller, repoFullName, label, projPath, runner, ghWebhooks)
+					if cmdErr != nil {
+						logging.WithComponent("github").Warn("Comment commands disabled",
+							slog.String("repo", repoFullName),
+							slog.Any("error", cmdErr))
+					} else {
+						go commands.Start(ctx)
+					}
+				}
+				return poller, nil
 			}
 
 			// Create poller for default repo (adapters.github.repo)
@@ -2040,6 +2378,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 
 			// Start all pollers
 			for _, poller := range ghPollers {
+				if gwServer != nil {
+					gwServer.RegisterStatusComponent(poller)
+				}
 				go poller.Start(ctx)
 			}
 
@@ -2047,6 +2388,12 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 				if !dashboardMode && execMode == github.ExecutionModeSequential && waitForMerge {
 					fmt.Printf("   ⏳ Sequential mode: waiting for PR merge before next issue (timeout: %s)\n", prTimeout)
 				}
+				if !dashboardMode && batchPoller != nil {
+					fmt.Printf("   📦 Batch polling: %d repo(s) over GraphQL\n", len(ghPollers))
+				}
+				if !dashboardMode && ghWebhooks != nil {
+					fmt.Printf("   🔔 GitHub %s mode: webhooks poke pollers and autopilot\n", cfg.Adapters.GitHub.IngestMode())
+				}
 
 				// Start autopilot processing loops for all controllers
 				for repoName, controller := range autopilotControllers {
@@ -2127,6 +2474,43 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 					}
 				}
 			}
+
+			// Watch for .pilot.yaml changes requested by issue
+			if cfg.Adapters.GitHub.Repo != "" && cfg.Adapters.GitHub.ConfigRequests != nil && cfg.Adapters.GitHub.ConfigRequests.Enabled {
+				watcher, watcherErr := github.NewConfigRequestWatcher(client, cfg.Adapters.GitHub.Repo, cfg.Adapters.GitHub.ConfigRequests,
+					github.WithConfigRequestApprovals(approvalMgr),
+					github.WithOnConfigApplied(func(ctx context.Context) {
+						runner.ReloadRepoConfig(projectPath)
+					}),
+				)
+				if watcherErr != nil {
+					if !dashboardMode {
+						fmt.Printf("⚠️  Config requests disabled: %v\n", watcherErr)
+					}
+				} else {
+					if !dashboardMode {
+						fmt.Printf("⚙️  Config requests enabled (label: %s)\n", watcher.Label())
+					}
+					go watcher.Start(ctx)
+				}
+			}
+
+			// Watch for new repositories requested by issue
+			if cfg.Adapters.GitHub.Repo != "" && cfg.Adapters.GitHub.Scaffold != nil && cfg.Adapters.GitHub.Scaffold.Enabled {
+				watcher, watcherErr := github.NewScaffoldWatcher(client, cfg.Adapters.GitHub.Repo, cfg.Adapters.GitHub.Scaffold,
+					github.WithScaffoldApprovals(approvalMgr),
+				)
+				if watcherErr != nil {
+					if !dashboardMode {
+						fmt.Printf("⚠️  New repository requests disabled: %v\n", watcherErr)
+					}
+				} else {
+					if !dashboardMode {
+						fmt.Printf("📦 New repository requests enabled (labels: %s)\n", strings.Join(watcher.Labels(), ", "))
+					}
+					go watcher.Start(ctx)
+				}
+			}
 		}
 	}
 
@@ -2140,9 +2524,12 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		Program:              program,
 		AlertsEngine:         alertsEngine,
 		Enforcer:             enforcer,
+		Store:                store,
 		AutopilotController:  autopilotController,
 		AutopilotStateStore:  autopilotStateStore,
 		AutopilotControllers: autopilotControllers,
+		ApprovalManager:      approvalMgr,
+		Pause:                pipelinePause,
 	}
 	StartAdapterPollers(ctx, pollingDeps, adapterPollerRegistrations())
 
@@ -2162,8 +2549,10 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		slackMessenger := slack.NewMessenger(slackClient)
 
 		var slackMemberResolver comms.MemberResolver
+		var slackCommands comms.CommandChecker
 		if teamAdapter != nil {
 			slackMemberResolver = &slack.MemberResolverAdapter{Inner: teamAdapter}
+			slackCommands = teamAdapter
 		}
 
 		slackCommsHandler := comms.NewHandler(&comms.HandlerConfig{
@@ -2172,8 +2561,11 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 			Projects:       config.NewSlackProjectSource(cfg),
 			ProjectPath:    projectPath,
 			MemberResolver: slackMemberResolver,
+			Commands:       slackCommands,
 			Store:          store,
 			TaskIDPrefix:   "SLACK",
+			Adapter:        "slack",
+			Enforcer:       enforcer,
 		})
 
 		slackHandler = slack.NewHandler(&slack.HandlerConfig{
@@ -2200,9 +2592,8 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 
 	// Start brief scheduler if enabled
 	var briefScheduler *briefs.Scheduler
-	if cfg.Orchestrator.DailyBrief != nil && cfg.Orchestrator.DailyBrief.Enabled {
-		briefCfg := cfg.Orchestrator.DailyBrief
-
+	briefCfg := cfg.Orchestrator.DailyBrief
+	if briefCfg != nil && (briefCfg.Enabled || (briefCfg.Digest != nil && briefCfg.Digest.Enabled)) {
 		// Convert config to briefs.BriefConfig
 		briefsConfig := &briefs.BriefConfig{
 			Enabled:  briefCfg.Enabled,
@@ -2216,6 +2607,7 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 			Filters: briefs.FilterConfig{
 				Projects: briefCfg.Filters.Projects,
 			},
+			Digest: briefDigestConfig(briefCfg.Digest),
 		}
 
 		// Convert channels
@@ -2247,6 +2639,13 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 
 			// Create and start scheduler
 			briefScheduler = briefs.NewScheduler(generator, delivery, briefsConfig, slog.Default(), store)
+			if briefsConfig.Digest != nil && briefsConfig.Digest.Enabled {
+				if teamSvc != nil {
+					briefScheduler.SetDigest(briefs.NewDigestGenerator(store, teamSvc, approvalMgr, briefsConfig))
+				} else {
+					logging.WithComponent("start").Warn("Personal digests require the team store, skipping")
+				}
+			}
 			if err := briefScheduler.Start(ctx); err != nil {
 				logging.WithComponent("start").Warn("Failed to start brief scheduler", slog.Any("error", err))
 				briefScheduler = nil
@@ -2261,6 +2660,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		}
 	}
 
+	// Start recurring task scheduler if enabled
+	taskScheduler := startTaskScheduler(ctx, cfg, dispatcher, store, alertsEngine, projectPath)
+
 	// Dashboard mode: run TUI and handle shutdown via TUI quit
 	if dashboardMode && program != nil {
 		fmt.Println("\n🖥️  Starting TUI dashboard...")
@@ -2412,6 +2814,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		if cfg.Adapters.Discord != nil && cfg.Adapters.Discord.Enabled {
 			program.Send(dashboard.AddLog("🎮 Discord gateway enabled")())
 		}
+		if cfg.Adapters.Mattermost != nil && cfg.Adapters.Mattermost.Enabled {
+			program.Send(dashboard.AddLog("🗨️  Mattermost WebSocket active")())
+		}
 
 		// Check for restart marker (set by hot upgrade)
 			// GH-879: Config is automatically reloaded because syscall.Exec starts a fresh process
@@ -2448,6 +2853,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 		if briefScheduler != nil {
 			briefScheduler.Stop()
 		}
+		if taskScheduler != nil {
+			taskScheduler.Stop()
+		}
 		return nil
 	}
 
@@ -2474,6 +2882,9 @@ func runPollingMode(cfg *config.Config, projectPath string, replace, dashboardMo
 	if briefScheduler != nil {
 		briefScheduler.Stop()
 	}
+	if taskScheduler != nil {
+		taskScheduler.Stop()
+	}
 
 	return nil
 }
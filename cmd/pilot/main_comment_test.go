@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
 func TestBuildExecutionComment_FullResult(t *testing.T) {
@@ -41,6 +43,30 @@ func TestBuildExecutionComment_FullResult(t *testing.T) {
 	}
 }
 
+func TestBuildExecutionComment_CoverageDelta(t *testing.T) {
+	result := &executor.ExecutionResult{
+		Success:  true,
+		Duration: time.Minute,
+		QualityGates: &executor.QualityGatesResult{
+			Enabled:   true,
+			AllPassed: true,
+			Gates: []executor.QualityGateResult{
+				{Name: "build", Passed: true},
+				{Name: quality.CoverageDeltaGateName, Passed: true, CoverageDelta: &quality.CoverageDelta{Base: 80, Head: 81.5}},
+			},
+		},
+	}
+	comment := buildExecutionComment(result, "")
+	if !strings.Contains(comment, "| Coverage | 80.0% → 81.5% (+1.5) |") {
+		t.Errorf("comment missing coverage delta\nGot:\n%s", comment)
+	}
+
+	result.QualityGates.Gates = result.QualityGates.Gates[:1]
+	if comment := buildExecutionComment(result, ""); strings.Contains(comment, "Coverage") {
+		t.Errorf("comment should not report coverage without the check\nGot:\n%s", comment)
+	}
+}
+
 func TestBuildExecutionComment_MinimalResult(t *testing.T) {
 	result := &executor.ExecutionResult{
 		Success:  true,
@@ -123,3 +149,61 @@ func TestFormatTokenCountComment(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildFailureComment_RetryPolicy(t *testing.T) {
+	result := &executor.ExecutionResult{
+		Error: "quality gates failed after 1 auto-retries (test_failure)",
+		PRUrl: "https://github.com/owner/repo/pull/7",
+		Retry: &executor.RetryReport{
+			Class:       executor.FailureClassTestFailure,
+			Attempts:    1,
+			MaxAttempts: 1,
+			DraftPR:     true,
+		},
+	}
+	comment := buildFailureComment(result)
+
+	if !strings.Contains(comment, "**Retry policy:** test_failure: 1/1 retries, draft PR opened") {
+		t.Errorf("missing retry policy line:\n%s", comment)
+	}
+	if !strings.Contains(comment, "**Draft PR:** https://github.com/owner/repo/pull/7") {
+		t.Errorf("missing draft PR link:\n%s", comment)
+	}
+}
+
+func TestBuildExecutionComment_Retries(t *testing.T) {
+	result := &executor.ExecutionResult{
+		Success:  true,
+		Duration: time.Minute,
+		Retry:    &executor.RetryReport{Class: executor.FailureClassCompileError, Attempts: 1, MaxAttempts: 2},
+	}
+	comment := buildExecutionComment(result, "")
+	if !strings.Contains(comment, "| Retries | compile_error: 1/2 retries |") {
+		t.Errorf("missing retries row:\n%s", comment)
+	}
+}
+
+func TestReviewerAssignmentNote(t *testing.T) {
+	if note := reviewerAssignmentNote(nil, "acme"); note != "" {
+		t.Errorf("note without assignment = %q", note)
+	}
+	note := reviewerAssignmentNote(&github.ReviewerAssignment{
+		Reviewers:     []string{"alice"},
+		TeamReviewers: []string{"core"},
+		Source:        github.ReviewersFromCodeOwners,
+	}, "acme")
+	if !strings.Contains(note, "@alice, @acme/core (from CODEOWNERS)") {
+		t.Errorf("note = %q", note)
+	}
+}
+
+func TestGitHubCommentManager_OnePerClient(t *testing.T) {
+	client := github.NewClient("token")
+	m := githubCommentManager(client, nil)
+	if githubCommentManager(client, nil) != m {
+		t.Error("calls with the same client should share one comment manager")
+	}
+	if githubCommentManager(github.NewClient("token"), nil) == m {
+		t.Error("another client should get its own comment manager")
+	}
+}
@@ -25,6 +25,7 @@ func newMetricsCmd() *cobra.Command {
 		newMetricsDailyCmd(),
 		newMetricsProjectsCmd(),
 		newMetricsExportCmd(),
+		newMetricsCalibrationCmd(),
 	)
 
 	return cmd
@@ -124,6 +125,14 @@ func newMetricsSummaryCmd() *cobra.Command {
 			fmt.Printf("   Removed:  -%d lines\n", summary.TotalLinesRemoved)
 			fmt.Println()
 
+			// Coverage delta check results
+			if summary.CoverageMeasured > 0 {
+				fmt.Println("🧪 Coverage")
+				fmt.Printf("   Measured: %d tasks\n", summary.CoverageMeasured)
+				fmt.Printf("   Avg Δ:    %+.1f points\n", summary.AvgCoverageDelta)
+				fmt.Println()
+			}
+
 			return nil
 		},
 	}
@@ -433,6 +442,121 @@ func newMetricsExportCmd() *cobra.Command {
 	return cmd
 }
 
+func newMetricsCalibrationCmd() *cobra.Command {
+	var (
+		days     int
+		projects []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "calibration",
+		Short: "Compare estimated and actual task effort",
+		Long: `Show how accurately Pilot predicts task duration and tokens before execution.
+
+Each task is estimated from recent successful runs of the same project and
+complexity class, then stored with its actuals. Accuracy is broken down by
+project, complexity class, routed effort and week, so routing thresholds,
+timeouts and budgets can be tuned with data.
+
+Error is the mean absolute percentage error. Bias is the mean signed error:
+positive means tasks take longer than estimated.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Load config
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			// Open store
+			store, err := memory.NewStore(cfg.Memory.Path)
+			if err != nil {
+				return fmt.Errorf("failed to open memory store: %w", err)
+			}
+			defer func() { _ = store.Close() }()
+
+			end := time.Now()
+			report, err := store.GetCalibrationReport(memory.MetricsQuery{
+				Start:    end.AddDate(0, 0, -days),
+				End:      end,
+				Projects: projects,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get calibration report: %w", err)
+			}
+
+			if report.Overall.Tasks == 0 {
+				fmt.Println("No estimates recorded in the specified period.")
+				return nil
+			}
+
+			printCalibrationReport(report, days)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 30, "Number of days to include")
+	cmd.Flags().StringSliceVar(&projects, "projects", nil, "Filter by project paths")
+
+	return cmd
+}
+
+func printCalibrationReport(report *memory.CalibrationReport, days int) {
+	overall := report.Overall
+
+	fmt.Println()
+	fmt.Printf("🎯 Estimate Calibration (Last %d Days)\n", days)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+	fmt.Printf("   Tasks:    %d (%d estimated)\n", overall.Tasks, overall.Estimated)
+	fmt.Printf("   Success:  %.1f%%\n", overall.SuccessRate*100)
+	if overall.Estimated == 0 {
+		fmt.Println()
+		fmt.Println("   No estimates yet: predictions start once a project and complexity")
+		fmt.Println("   class has successful runs to learn from.")
+		fmt.Println()
+		return
+	}
+	fmt.Printf("   Duration: %.0f%% error, %s bias, %.0f%% within ±%.0f%%\n",
+		overall.DurationMAPE*100, formatBias(overall.DurationBias), overall.DurationWithin*100, memory.CalibrationTolerance*100)
+	fmt.Printf("   Tokens:   %.0f%% error, %s bias\n", overall.TokensMAPE*100, formatBias(overall.TokensBias))
+
+	printCalibrationTable("📁 By Project", report.ByProject, shortenPath)
+	printCalibrationTable("🧩 By Complexity", report.ByComplexity, nil)
+	printCalibrationTable("🧠 By Effort", report.ByEffort, nil)
+	printCalibrationTable("📅 By Week", report.ByWeek, nil)
+
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+}
+
+func printCalibrationTable(title string, stats []memory.CalibrationStats, label func(string) string) {
+	fmt.Println()
+	fmt.Println(title)
+	fmt.Printf("   %-24s %6s %6s %9s %9s %9s %9s\n", "", "TASKS", "EST", "DUR ERR", "DUR BIAS", "TOK ERR", "TOK BIAS")
+	for _, s := range stats {
+		key := s.Key
+		if label != nil {
+			key = label(key)
+		}
+		if s.Estimated == 0 {
+			fmt.Printf("   %-24s %6d %6d %9s %9s %9s %9s\n", truncate(key, 24), s.Tasks, s.Estimated, "-", "-", "-", "-")
+			continue
+		}
+		fmt.Printf("   %-24s %6d %6d %8.0f%% %9s %8.0f%% %9s\n", truncate(key, 24), s.Tasks, s.Estimated,
+			s.DurationMAPE*100, formatBias(s.DurationBias), s.TokensMAPE*100, formatBias(s.TokensBias))
+	}
+}
+
+// formatBias renders a signed relative error such as "+12%"
+func formatBias(bias float64) string {
+	return fmt.Sprintf("%+.0f%%", bias*100)
+}
+
 // Helper functions
 
 func formatDuration(ms int64) string {
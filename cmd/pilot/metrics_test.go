@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestPrintCalibrationReport(t *testing.T) {
+	report := &memory.CalibrationReport{
+		Overall: memory.CalibrationStats{Key: "all", Tasks: 3, Estimated: 2, SuccessRate: 1,
+			DurationMAPE: 0.3, DurationBias: 0.2, DurationWithin: 0.5, TokensMAPE: 0.1, TokensBias: -0.1},
+		ByProject: []memory.CalibrationStats{
+			{Key: "/srv/app", Tasks: 3, Estimated: 2, DurationMAPE: 0.3, DurationBias: 0.2, TokensMAPE: 0.1, TokensBias: -0.1},
+		},
+		ByComplexity: []memory.CalibrationStats{
+			{Key: "complex", Tasks: 1},
+			{Key: "medium", Tasks: 2, Estimated: 2, DurationMAPE: 0.3, DurationBias: 0.2},
+		},
+	}
+
+	out := captureStdout(func() { printCalibrationReport(report, 30) })
+	for _, want := range []string{
+		"Estimate Calibration (Last 30 Days)",
+		"Tasks:    3 (2 estimated)",
+		"Duration: 30% error, +20% bias, 50% within ±25%",
+		"Tokens:   10% error, -10% bias",
+		".../srv/app",
+		"By Complexity",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	// Groups without estimates show dashes instead of zero errors
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "complex ") && !strings.Contains(line, "-") {
+			t.Errorf("unestimated row should show dashes: %q", line)
+		}
+	}
+
+	out = captureStdout(func() {
+		printCalibrationReport(&memory.CalibrationReport{Overall: memory.CalibrationStats{Tasks: 2}}, 7)
+	})
+	if !strings.Contains(out, "No estimates yet") {
+		t.Errorf("expected no-estimates hint:\n%s", out)
+	}
+}
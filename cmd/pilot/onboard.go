@@ -358,6 +358,12 @@ func buildBackendCardFromConfig(cfg *config.Config) SummaryCard {
 			card.Value = "Qwen Code"
 		case "opencode":
 			card.Value = "OpenCode"
+		case "openai":
+			card.Value = "OpenAI Codex"
+		case "gemini":
+			card.Value = "Gemini CLI"
+		case "ollama":
+			card.Value = "Ollama"
 		default:
 			card.Value = backendType
 		}
@@ -12,7 +12,7 @@ import (
 // BackendOption represents an available execution backend
 type BackendOption struct {
 	Name        string
-	Type        string // config value: "claude-code", "qwen-code", "opencode"
+	Type        string // config value: "claude-code", "qwen-code", "opencode", "openai", "gemini", "ollama"
 	Description string
 	CLICommand  string // command to check with exec.LookPath
 	Installed   bool
@@ -39,6 +39,24 @@ func detectBackends() []BackendOption {
 			Description: "Server/client architecture",
 			CLICommand:  "opencode",
 		},
+		{
+			Name:        "OpenAI Codex",
+			Type:        "openai",
+			Description: "OpenAI's Codex CLI (GPT models)",
+			CLICommand:  "codex",
+		},
+		{
+			Name:        "Gemini CLI",
+			Type:        "gemini",
+			Description: "Google's Gemini CLI",
+			CLICommand:  "gemini",
+		},
+		{
+			Name:        "Ollama",
+			Type:        "ollama",
+			Description: "Local models, works offline",
+			CLICommand:  "ollama",
+		},
 	}
 
 	// Check which CLIs are installed
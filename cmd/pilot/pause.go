@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func newPauseCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Stop pollers from dispatching new work",
+		Long: `Pause the pipeline: pollers stop dispatching new issues while tasks
+already running finish normally. The paused state is stored in the memory
+database, so a running daemon picks it up within seconds and a restarted
+daemon stays paused until 'pilot resume'.
+
+Examples:
+  pilot pause
+  pilot pause --reason "prod incident, no new PRs"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withPipelinePause(func(pause *executor.PipelinePause) error {
+				if err := pause.Pause(reason); err != nil {
+					return fmt.Errorf("failed to pause pipeline: %w", err)
+				}
+				fmt.Println("⏸  Pipeline paused — no new work will be dispatched")
+				fmt.Println("   In-flight tasks will finish. Run 'pilot resume' to continue.")
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the pipeline is paused (shown in status and dashboard)")
+	return cmd
+}
+
+func newResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume",
+		Short: "Resume dispatching new work after 'pilot pause'",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withPipelinePause(func(pause *executor.PipelinePause) error {
+				if !pause.Paused() {
+					fmt.Println("Pipeline is not paused")
+					return nil
+				}
+				if err := pause.Resume(); err != nil {
+					return fmt.Errorf("failed to resume pipeline: %w", err)
+				}
+				fmt.Println("▶️  Pipeline resumed — pollers will dispatch new work")
+				return nil
+			})
+		},
+	}
+}
+
+// withPipelinePause opens the memory store and runs fn with its pause state
+func withPipelinePause(fn func(pause *executor.PipelinePause) error) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Memory == nil {
+		return fmt.Errorf("memory not configured")
+	}
+
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open memory store: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	return fn(newPipelinePause(store))
+}
+
+// newPipelinePause returns the pollers' pause gate, persisted in store when
+// one is open
+func newPipelinePause(store *memory.Store) *executor.PipelinePause {
+	if store == nil {
+		return executor.NewPipelinePause(nil)
+	}
+	return executor.NewPipelinePause(store)
+}
+
+// pausable wraps a poller's work callback so it waits while the pipeline is
+// paused, before any label, comment or status change is made
+func pausable[I, R any](pause *executor.PipelinePause, fn func(context.Context, I) (R, error)) func(context.Context, I) (R, error) {
+	return func(ctx context.Context, item I) (R, error) {
+		if err := pause.Wait(ctx); err != nil {
+			var zero R
+			return zero, err
+		}
+		return fn(ctx, item)
+	}
+}
+
+// storedPipelineState reads the pause state from the memory store. A missing
+// or unreadable store reports the pipeline as running.
+func storedPipelineState(cfg *config.Config) memory.PipelineState {
+	if cfg.Memory == nil {
+		return memory.PipelineState{}
+	}
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return memory.PipelineState{}
+	}
+	defer func() { _ = store.Close() }()
+	state, err := store.GetPipelineState()
+	if err != nil {
+		return memory.PipelineState{}
+	}
+	return *state
+}
+
+// pauseStatusLine describes a paused pipeline for status output
+func pauseStatusLine(state memory.PipelineState) string {
+	line := "⏸  Paused since " + state.UpdatedAt.Local().Format("Jan 2 15:04")
+	if reason := strings.TrimSpace(state.Reason); reason != "" {
+		line += " — " + reason
+	}
+	return line
+}
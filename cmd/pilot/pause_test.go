@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestPausable(t *testing.T) {
+	calls := 0
+	handle := func(ctx context.Context, issue string) (string, error) {
+		calls++
+		return "done " + issue, nil
+	}
+
+	// No pause gate wired: work runs straight through
+	if got, err := pausable(nil, handle)(context.Background(), "GH-1"); err != nil || got != "done GH-1" {
+		t.Fatalf("pausable(nil) = %q, %v", got, err)
+	}
+
+	pause := executor.NewPipelinePause(nil)
+	_ = pause.Pause("incident")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pausable(pause, handle)(ctx, "GH-2"); err != context.DeadlineExceeded {
+		t.Errorf("pausable() while paused = %v, want deadline exceeded", err)
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1 (held while paused)", calls)
+	}
+}
+
+func TestPauseStatusLine(t *testing.T) {
+	state := memory.PipelineState{Paused: true, Reason: " prod incident ", UpdatedAt: time.Now()}
+	if line := pauseStatusLine(state); !strings.HasPrefix(line, "⏸  Paused since") || !strings.HasSuffix(line, "— prod incident") {
+		t.Errorf("pauseStatusLine() = %q", line)
+	}
+	state.Reason = ""
+	if line := pauseStatusLine(state); strings.Contains(line, "—") {
+		t.Errorf("pauseStatusLine() without reason = %q", line)
+	}
+}
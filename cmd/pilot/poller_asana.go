@@ -38,7 +38,7 @@ func asanaPollerRegistration() PollerRegistration {
 
 			// GH-1701: Wire processed store for dedup persistence across restarts
 			asanaPollerOpts := []asana.PollerOption{
-				asana.WithOnAsanaTask(func(taskCtx context.Context, task *asana.Task) (*asana.TaskResult, error) {
+				asana.WithOnAsanaTask(pausable(deps.Pause, func(taskCtx context.Context, task *asana.Task) (*asana.TaskResult, error) {
 					taskID := "ASANA-" + task.GID
 
 					// GH-2132: Notify task started
@@ -68,7 +68,7 @@ func asanaPollerRegistration() PollerRegistration {
 					}
 
 					return result, err
-				}),
+				})),
 			}
 			if deps.AutopilotStateStore != nil {
 				asanaPollerOpts = append(asanaPollerOpts, asana.WithProcessedStore(deps.AutopilotStateStore))
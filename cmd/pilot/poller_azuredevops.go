@@ -34,7 +34,7 @@ func azuredevopsPollerRegistration() PollerRegistration {
 			adoNotifier := azuredevops.NewNotifier(adoClient, pilotTag)
 
 			adoPollerOpts := []azuredevops.PollerOption{
-				azuredevops.WithOnWorkItemWithResult(func(wiCtx context.Context, wi *azuredevops.WorkItem) (*azuredevops.WorkItemResult, error) {
+				azuredevops.WithOnWorkItemWithResult(pausable(deps.Pause, func(wiCtx context.Context, wi *azuredevops.WorkItem) (*azuredevops.WorkItemResult, error) {
 					result, err := handleAzureDevOpsWorkItemWithResult(wiCtx, deps.Cfg, adoClient, adoNotifier, wi, deps.ProjectPath, deps.Dispatcher, deps.Runner, deps.Monitor, deps.Program, deps.AlertsEngine, deps.Enforcer)
 
 					// GH-2132: Wire PR to autopilot for CI monitoring + auto-merge
@@ -43,7 +43,7 @@ func azuredevopsPollerRegistration() PollerRegistration {
 					}
 
 					return result, err
-				}),
+				})),
 			}
 
 			// Wire autopilot OnPRCreated callback
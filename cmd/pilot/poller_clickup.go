@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/clickup"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+func clickupPollerRegistration() PollerRegistration {
+	return PollerRegistration{
+		Name: "clickup",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Adapters.ClickUp != nil && cfg.Adapters.ClickUp.Enabled &&
+				cfg.Adapters.ClickUp.Polling != nil && cfg.Adapters.ClickUp.Polling.Enabled
+		},
+		CreateAndStart: func(ctx context.Context, deps *PollerDeps) {
+			// Determine interval
+			interval := 30 * time.Second
+			if deps.Cfg.Adapters.ClickUp.Polling.Interval > 0 {
+				interval = deps.Cfg.Adapters.ClickUp.Polling.Interval
+			}
+
+			clickupClient := clickup.NewClient(deps.Cfg.Adapters.ClickUp.APIToken)
+			clickupNotifier := clickup.NewNotifier(clickupClient)
+
+			clickupPollerOpts := []clickup.PollerOption{
+				clickup.WithOnTask(pausable(deps.Pause, func(taskCtx context.Context, cuTask *clickup.Task) (*clickup.TaskResult, error) {
+					taskID := clickup.TaskID(cuTask)
+
+					if err := clickupNotifier.NotifyTaskStarted(taskCtx, cuTask.ID, taskID); err != nil {
+						logging.WithComponent("clickup").Warn("Failed to notify task started",
+							slog.String("clickup_id", cuTask.ID),
+							slog.Any("error", err),
+						)
+					}
+
+					result, err := handleClickUpTaskWithResult(taskCtx, deps.Cfg, clickupClient, cuTask, deps.ProjectPath, deps.Dispatcher, deps.Runner, deps.Monitor, deps.Program, deps.AlertsEngine, deps.Enforcer)
+
+					if result != nil && result.PRNumber > 0 {
+						if linkErr := clickupNotifier.LinkPR(taskCtx, cuTask.ID, result.PRNumber, result.PRURL); linkErr != nil {
+							logging.WithComponent("clickup").Warn("Failed to link PR",
+								slog.String("clickup_id", cuTask.ID),
+								slog.Any("error", linkErr),
+							)
+						}
+					}
+
+					// Wire PR to autopilot for CI monitoring + auto-merge
+					if result != nil && result.PRNumber > 0 && deps.AutopilotController != nil {
+						deps.AutopilotController.OnPRCreated(result.PRNumber, result.PRURL, 0, result.HeadSHA, result.BranchName, "")
+					}
+
+					return result, err
+				})),
+			}
+			if deps.AutopilotStateStore != nil {
+				clickupPollerOpts = append(clickupPollerOpts, clickup.WithProcessedStore(deps.AutopilotStateStore))
+			}
+			if deps.Cfg.Orchestrator.MaxConcurrent > 0 {
+				clickupPollerOpts = append(clickupPollerOpts, clickup.WithMaxConcurrent(deps.Cfg.Orchestrator.MaxConcurrent))
+			}
+			clickupPoller := clickup.NewPoller(clickupClient, deps.Cfg.Adapters.ClickUp, interval, clickupPollerOpts...)
+
+			logging.WithComponent("start").Info("ClickUp polling enabled",
+				slog.String("list", deps.Cfg.Adapters.ClickUp.ListID),
+				slog.Duration("interval", interval),
+			)
+			go func(p *clickup.Poller) {
+				if err := p.Start(ctx); err != nil {
+					logging.WithComponent("clickup").Error("ClickUp poller failed",
+						slog.Any("error", err),
+					)
+				}
+			}(clickupPoller)
+		},
+	}
+}
@@ -56,7 +56,10 @@ func discordPollerRegistration() PollerRegistration {
 				ProjectPath:   deps.ProjectPath,
 				LLMClassifier: llmClassifier,
 				ConvStore:     convStore,
+				Store:         deps.Store,
 				TaskIDPrefix:  "DISCORD",
+				Adapter:       "discord",
+				Enforcer:      deps.Enforcer,
 			})
 
 			handler := discord.NewHandler(&discord.HandlerConfig{
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/gitea"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+func giteaPollerRegistration() PollerRegistration {
+	return PollerRegistration{
+		Name: "gitea",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Adapters.Gitea != nil && cfg.Adapters.Gitea.Enabled &&
+				cfg.Adapters.Gitea.Polling != nil && cfg.Adapters.Gitea.Polling.Enabled
+		},
+		CreateAndStart: func(ctx context.Context, deps *PollerDeps) {
+			// Determine interval
+			interval := 30 * time.Second
+			if deps.Cfg.Adapters.Gitea.Polling.Interval > 0 {
+				interval = deps.Cfg.Adapters.Gitea.Polling.Interval
+			}
+
+			label := deps.Cfg.Adapters.Gitea.PilotLabel
+			if label == "" {
+				label = "pilot"
+			}
+
+			giteaClient := gitea.NewClient(
+				deps.Cfg.Adapters.Gitea.Token,
+				deps.Cfg.Adapters.Gitea.BaseURL,
+				deps.Cfg.Adapters.Gitea.Repo,
+			)
+			giteaNotifier := gitea.NewNotifier(giteaClient)
+
+			// Open PRs for Gitea tasks through the Gitea API instead of the gh CLI
+			deps.Runner.SetPRCreator("gitea", giteaClient)
+
+			// The GitHub autopilot controller cannot read Gitea PRs; run a Gitea-native one
+			var giteaAutopilot *gitea.Autopilot
+			if deps.Cfg.Orchestrator.Autopilot != nil && deps.Cfg.Orchestrator.Autopilot.Enabled {
+				giteaAutopilot = gitea.NewAutopilot(giteaClient, deps.Cfg.Orchestrator.Autopilot)
+				go func() {
+					if err := giteaAutopilot.Run(ctx); err != nil && ctx.Err() == nil {
+						logging.WithComponent("gitea").Error("Gitea autopilot failed",
+							slog.Any("error", err),
+						)
+					}
+				}()
+			}
+
+			giteaPollerOpts := []gitea.PollerOption{
+				gitea.WithOnIssue(pausable(deps.Pause, func(issueCtx context.Context, issue *gitea.Issue) (*gitea.IssueResult, error) {
+					taskID := gitea.TaskID(issue.Number)
+
+					if err := giteaNotifier.NotifyTaskStarted(issueCtx, issue.Number, taskID); err != nil {
+						logging.WithComponent("gitea").Warn("Failed to notify task started",
+							slog.Int("number", issue.Number),
+							slog.Any("error", err),
+						)
+					}
+
+					result, err := handleGiteaIssueWithResult(issueCtx, deps.Cfg, giteaClient, issue, deps.ProjectPath, deps.Dispatcher, deps.Runner, deps.Monitor, deps.Program, deps.AlertsEngine, deps.Enforcer)
+
+					if result != nil && result.PRNumber > 0 {
+						if linkErr := giteaNotifier.LinkPR(issueCtx, issue.Number, result.PRNumber, result.PRURL); linkErr != nil {
+							logging.WithComponent("gitea").Warn("Failed to link PR",
+								slog.Int("number", issue.Number),
+								slog.Any("error", linkErr),
+							)
+						}
+					}
+
+					return result, err
+				})),
+			}
+			if giteaAutopilot != nil {
+				giteaPollerOpts = append(giteaPollerOpts, gitea.WithOnPRCreated(giteaAutopilot.OnPRCreated))
+			}
+			if deps.AutopilotStateStore != nil {
+				giteaPollerOpts = append(giteaPollerOpts, gitea.WithProcessedStore(deps.AutopilotStateStore))
+			}
+			if deps.Cfg.Orchestrator.MaxConcurrent > 0 {
+				giteaPollerOpts = append(giteaPollerOpts, gitea.WithMaxConcurrent(deps.Cfg.Orchestrator.MaxConcurrent))
+			}
+			giteaPoller := gitea.NewPoller(giteaClient, label, interval, giteaPollerOpts...)
+
+			logging.WithComponent("start").Info("Gitea polling enabled",
+				slog.String("repo", deps.Cfg.Adapters.Gitea.Repo),
+				slog.String("base_url", deps.Cfg.Adapters.Gitea.BaseURL),
+				slog.Duration("interval", interval),
+			)
+			go giteaPoller.Start(ctx)
+		},
+	}
+}
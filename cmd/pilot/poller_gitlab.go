@@ -36,7 +36,7 @@ func gitlabPollerRegistration() PollerRegistration {
 			}
 
 			gitlabPollerOpts := []gitlab.PollerOption{
-				gitlab.WithOnIssueWithResult(func(issueCtx context.Context, issue *gitlab.Issue) (*gitlab.IssueResult, error) {
+				gitlab.WithOnIssueWithResult(pausable(deps.Pause, func(issueCtx context.Context, issue *gitlab.Issue) (*gitlab.IssueResult, error) {
 					result, err := handleGitLabIssueWithResult(issueCtx, deps.Cfg, gitlabClient, issue, deps.ProjectPath, deps.Dispatcher, deps.Runner, deps.Monitor, deps.Program, deps.AlertsEngine, deps.Enforcer)
 
 					// Wire MR to autopilot for CI monitoring + auto-merge
@@ -45,7 +45,7 @@ func gitlabPollerRegistration() PollerRegistration {
 					}
 
 					return result, err
-				}),
+				})),
 			}
 
 			if deps.AutopilotStateStore != nil {
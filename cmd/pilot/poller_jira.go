@@ -36,7 +36,7 @@ func jiraPollerRegistration() PollerRegistration {
 				pollerDeps.ProcessedStore = deps.AutopilotStateStore
 			}
 
-			jiraPoller := jiraAdapter.CreatePoller(pollerDeps, func(issueCtx context.Context, issue *jira.Issue) (*jira.IssueResult, error) {
+			jiraPoller := jiraAdapter.CreatePoller(pollerDeps, pausable(deps.Pause, func(issueCtx context.Context, issue *jira.Issue) (*jira.IssueResult, error) {
 				// GH-2132: Notify task started (transitions to In Progress + posts comment)
 				if err := jiraNotifier.NotifyTaskStarted(issueCtx, issue.Key, issue.Key); err != nil {
 					logging.WithComponent("jira").Warn("Failed to notify task started",
@@ -63,7 +63,7 @@ func jiraPollerRegistration() PollerRegistration {
 				}
 
 				return result, err
-			})
+			}))
 
 			logging.WithComponent("start").Info("Jira polling enabled",
 				slog.String("base_url", deps.Cfg.Adapters.Jira.BaseURL),
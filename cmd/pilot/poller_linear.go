@@ -40,7 +40,7 @@ func linearPollerRegistration() PollerRegistration {
 
 				// Build poller options
 				linearPollerOpts := []linear.PollerOption{
-					linear.WithOnLinearIssue(func(issueCtx context.Context, issue *linear.Issue) (*linear.IssueResult, error) {
+					linear.WithOnLinearIssue(pausable(deps.Pause, func(issueCtx context.Context, issue *linear.Issue) (*linear.IssueResult, error) {
 						// GH-1348: Resolve project path per-issue using workspace→project mapping
 						issueProjectPath := deps.ProjectPath // fallback to default
 						var resolvedProject *config.ProjectConfig
@@ -79,7 +79,7 @@ func linearPollerRegistration() PollerRegistration {
 						}
 
 						return result, err
-					}),
+					})),
 				}
 
 				// GH-1351: Wire processed issue persistence to prevent re-dispatch after hot upgrade
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/alekspetrov/pilot/internal/adapters/mattermost"
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/comms"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+func mattermostPollerRegistration() PollerRegistration {
+	return PollerRegistration{
+		Name: "mattermost",
+		Enabled: func(cfg *config.Config) bool {
+			mm := cfg.Adapters.Mattermost
+			return mm != nil && mm.Enabled && mm.URL != "" && mm.BotToken != ""
+		},
+		CreateAndStart: func(ctx context.Context, deps *PollerDeps) {
+			mmCfg := deps.Cfg.Adapters.Mattermost
+			client := mattermost.NewClient(mmCfg.URL, mmCfg.BotToken)
+
+			// Wire team member resolver for RBAC (same as Slack, GH-786)
+			var memberResolver comms.MemberResolver
+			var commands comms.CommandChecker
+			if teamAdapter != nil {
+				memberResolver = &mattermost.MemberResolverAdapter{Inner: teamAdapter, Client: client}
+				commands = teamAdapter
+			}
+
+			commsHandler := comms.NewHandler(&comms.HandlerConfig{
+				Messenger:      mattermost.NewMessenger(client),
+				Runner:         deps.Runner,
+				Projects:       config.NewProjectSource(deps.Cfg),
+				ProjectPath:    deps.ProjectPath,
+				MemberResolver: memberResolver,
+				Commands:       commands,
+				Store:          deps.Store,
+				TaskIDPrefix:   "MM",
+				Adapter:        "mattermost",
+				Enforcer:       deps.Enforcer,
+			})
+
+			handler := mattermost.NewHandler(&mattermost.HandlerConfig{
+				Client:          client,
+				CommsHandler:    commsHandler,
+				AllowedChannels: mmCfg.AllowedChannels,
+				AllowedUsers:    mmCfg.AllowedUsers,
+				BotUsername:     mmCfg.BotUsername,
+			})
+
+			if len(mmCfg.AllowedChannels) == 0 && len(mmCfg.AllowedUsers) == 0 {
+				logging.WithComponent("mattermost").Warn("SECURITY: mattermost allowed_channels and allowed_users are empty - ALL users can interact with the bot!")
+			}
+
+			// Register approval handler; decisions arrive as reactions over the same WebSocket
+			if mmCfg.Approval != nil && mmCfg.Approval.Enabled && deps.ApprovalManager != nil {
+				channel := mmCfg.Approval.Channel
+				if channel == "" {
+					channel = mmCfg.Channel
+				}
+				if teamAdapter != nil {
+					// Approvers resolve by email, like senders of commands
+					teamAdapter.SetMattermostResolver(&mattermost.MemberResolverAdapter{Inner: teamAdapter, Client: client})
+				}
+				approvalHandler := approval.NewMattermostHandler(&mattermostApprovalClientAdapter{client: client}, channel)
+				deps.ApprovalManager.RegisterHandler(approvalHandler)
+				handler.SetReactionHandler(approvalHandler.HandleReaction)
+				logging.WithComponent("start").Info("registered Mattermost approval handler",
+					slog.String("channel", channel))
+			}
+
+			go func() {
+				if err := handler.StartListening(ctx); err != nil {
+					logging.WithComponent("mattermost").Error("Mattermost listener error",
+						slog.Any("error", err),
+					)
+				}
+			}()
+			fmt.Println("🗨️  Mattermost listener started")
+			logging.WithComponent("start").Info("Mattermost listener started")
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/notion"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+func notionPollerRegistration() PollerRegistration {
+	return PollerRegistration{
+		Name: "notion",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Adapters.Notion != nil && cfg.Adapters.Notion.Enabled &&
+				cfg.Adapters.Notion.Polling != nil && cfg.Adapters.Notion.Polling.Enabled
+		},
+		CreateAndStart: func(ctx context.Context, deps *PollerDeps) {
+			// Determine interval
+			interval := 30 * time.Second
+			if deps.Cfg.Adapters.Notion.Polling.Interval > 0 {
+				interval = deps.Cfg.Adapters.Notion.Polling.Interval
+			}
+
+			notionClient := notion.NewClient(deps.Cfg.Adapters.Notion.APIKey)
+			notionNotifier := notion.NewNotifier(notionClient)
+
+			notionPollerOpts := []notion.PollerOption{
+				notion.WithOnPage(pausable(deps.Pause, func(pageCtx context.Context, page *notion.Page) (*notion.PageResult, error) {
+					taskID := notionTaskID(page.ID)
+
+					if err := notionNotifier.NotifyTaskStarted(pageCtx, page.ID, taskID); err != nil {
+						logging.WithComponent("notion").Warn("Failed to notify task started",
+							slog.String("page_id", page.ID),
+							slog.Any("error", err),
+						)
+					}
+
+					result, err := handleNotionPageWithResult(pageCtx, deps.Cfg, notionClient, page, deps.ProjectPath, deps.Dispatcher, deps.Runner, deps.Monitor, deps.Program, deps.AlertsEngine, deps.Enforcer)
+
+					if result != nil && result.PRNumber > 0 {
+						if linkErr := notionNotifier.LinkPR(pageCtx, page.ID, result.PRNumber, result.PRURL); linkErr != nil {
+							logging.WithComponent("notion").Warn("Failed to link PR",
+								slog.String("page_id", page.ID),
+								slog.Any("error", linkErr),
+							)
+						}
+					}
+
+					// Wire PR to autopilot for CI monitoring + auto-merge
+					if result != nil && result.PRNumber > 0 && deps.AutopilotController != nil {
+						deps.AutopilotController.OnPRCreated(result.PRNumber, result.PRURL, 0, result.HeadSHA, result.BranchName, "")
+					}
+
+					return result, err
+				})),
+			}
+			if deps.AutopilotStateStore != nil {
+				notionPollerOpts = append(notionPollerOpts, notion.WithProcessedStore(deps.AutopilotStateStore))
+			}
+			if deps.Cfg.Orchestrator.MaxConcurrent > 0 {
+				notionPollerOpts = append(notionPollerOpts, notion.WithMaxConcurrent(deps.Cfg.Orchestrator.MaxConcurrent))
+			}
+			notionPoller := notion.NewPoller(notionClient, deps.Cfg.Adapters.Notion, interval, notionPollerOpts...)
+
+			logging.WithComponent("start").Info("Notion polling enabled",
+				slog.String("database", deps.Cfg.Adapters.Notion.DatabaseID),
+				slog.Duration("interval", interval),
+			)
+			go func(p *notion.Poller) {
+				if err := p.Start(ctx); err != nil {
+					logging.WithComponent("notion").Error("Notion poller failed",
+						slog.Any("error", err),
+					)
+				}
+			}(notionPoller)
+		},
+	}
+}
@@ -33,7 +33,7 @@ func planePollerRegistration() PollerRegistration {
 			planeNotifier := plane.NewNotifier(planeClient, deps.Cfg.Adapters.Plane.WorkspaceSlug)
 
 			planePollerOpts := []plane.PollerOption{
-				plane.WithOnIssue(func(issueCtx context.Context, issue *plane.WorkItem) (*plane.IssueResult, error) {
+				plane.WithOnIssue(pausable(deps.Pause, func(issueCtx context.Context, issue *plane.WorkItem) (*plane.IssueResult, error) {
 					taskID := "PLANE-" + issue.ID[:8]
 
 					// GH-2132: Notify task started
@@ -62,7 +62,7 @@ func planePollerRegistration() PollerRegistration {
 					}
 
 					return result, err
-				}),
+				})),
 			}
 			if deps.AutopilotStateStore != nil {
 				planePollerOpts = append(planePollerOpts, plane.WithProcessedStore(deps.AutopilotStateStore))
@@ -7,11 +7,13 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/alekspetrov/pilot/internal/alerts"
+	"github.com/alekspetrov/pilot/internal/approval"
 	"github.com/alekspetrov/pilot/internal/autopilot"
 	"github.com/alekspetrov/pilot/internal/budget"
 	"github.com/alekspetrov/pilot/internal/config"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
 )
 
 // PollerDeps groups shared infrastructure used by all adapter poller startup blocks.
@@ -26,9 +28,18 @@ type PollerDeps struct {
 	AlertsEngine *alerts.Engine
 	Enforcer     *budget.Enforcer
 
+	// Store records chat adapter executions for metrics and budgets (may be nil).
+	Store *memory.Store
+
 	AutopilotController  *autopilot.Controller
 	AutopilotStateStore  *autopilot.StateStore
 	AutopilotControllers map[string]*autopilot.Controller // polling mode: per-repo controllers
+
+	// ApprovalManager lets chat adapters register their own approval handlers (may be nil).
+	ApprovalManager *approval.Manager
+
+	// Pause holds back new work while the pipeline is paused (may be nil).
+	Pause *executor.PipelinePause
 }
 
 // PollerRegistration describes a single adapter poller that can be conditionally started.
@@ -47,8 +58,13 @@ func adapterPollerRegistrations() []PollerRegistration {
 		asanaPollerRegistration(),
 		azuredevopsPollerRegistration(),
 		planePollerRegistration(),
+		notionPollerRegistration(),
+		trelloPollerRegistration(),
+		clickupPollerRegistration(),
 		discordPollerRegistration(),
+		mattermostPollerRegistration(),
 		gitlabPollerRegistration(),
+		giteaPollerRegistration(),
 	}
 }
 
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/trello"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+func trelloPollerRegistration() PollerRegistration {
+	return PollerRegistration{
+		Name: "trello",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Adapters.Trello != nil && cfg.Adapters.Trello.Enabled &&
+				cfg.Adapters.Trello.Polling != nil && cfg.Adapters.Trello.Polling.Enabled
+		},
+		CreateAndStart: func(ctx context.Context, deps *PollerDeps) {
+			// Determine interval
+			interval := 30 * time.Second
+			if deps.Cfg.Adapters.Trello.Polling.Interval > 0 {
+				interval = deps.Cfg.Adapters.Trello.Polling.Interval
+			}
+
+			trelloClient := trello.NewClient(deps.Cfg.Adapters.Trello.APIKey, deps.Cfg.Adapters.Trello.Token)
+			trelloNotifier := trello.NewNotifier(trelloClient, deps.Cfg.Adapters.Trello.DoneListID)
+
+			// Move the card to the done list once autopilot sees its PR merge.
+			// The card is recovered from the branch name, so this survives restarts.
+			if deps.AutopilotController != nil {
+				deps.AutopilotController.AddMergeHook(func(hookCtx context.Context, prState *autopilot.PRState) {
+					cardID, ok := trello.CardIDFromBranch(prState.BranchName)
+					if !ok {
+						return
+					}
+					if err := trelloNotifier.NotifyMerged(hookCtx, cardID, prState.PRNumber, prState.PRURL); err != nil {
+						logging.WithComponent("trello").Warn("Failed to complete card on merge",
+							slog.String("card_id", cardID),
+							slog.Int("pr", prState.PRNumber),
+							slog.Any("error", err),
+						)
+					}
+				})
+			}
+
+			trelloPollerOpts := []trello.PollerOption{
+				trello.WithOnCard(pausable(deps.Pause, func(cardCtx context.Context, card *trello.Card) (*trello.CardResult, error) {
+					taskID := trello.TaskID(card)
+
+					if err := trelloNotifier.NotifyTaskStarted(cardCtx, card.ID, taskID); err != nil {
+						logging.WithComponent("trello").Warn("Failed to notify task started",
+							slog.String("card_id", card.ID),
+							slog.Any("error", err),
+						)
+					}
+
+					result, err := handleTrelloCardWithResult(cardCtx, deps.Cfg, trelloClient, card, deps.ProjectPath, deps.Dispatcher, deps.Runner, deps.Monitor, deps.Program, deps.AlertsEngine, deps.Enforcer)
+
+					if result != nil && result.PRNumber > 0 {
+						if linkErr := trelloNotifier.LinkPR(cardCtx, card.ID, result.PRNumber, result.PRURL); linkErr != nil {
+							logging.WithComponent("trello").Warn("Failed to link PR",
+								slog.String("card_id", card.ID),
+								slog.Any("error", linkErr),
+							)
+						}
+					}
+
+					// Wire PR to autopilot for CI monitoring + auto-merge
+					if result != nil && result.PRNumber > 0 && deps.AutopilotController != nil {
+						deps.AutopilotController.OnPRCreated(result.PRNumber, result.PRURL, 0, result.HeadSHA, result.BranchName, "")
+					}
+
+					return result, err
+				})),
+			}
+			if deps.AutopilotStateStore != nil {
+				trelloPollerOpts = append(trelloPollerOpts, trello.WithProcessedStore(deps.AutopilotStateStore))
+			}
+			if deps.Cfg.Orchestrator.MaxConcurrent > 0 {
+				trelloPollerOpts = append(trelloPollerOpts, trello.WithMaxConcurrent(deps.Cfg.Orchestrator.MaxConcurrent))
+			}
+			trelloPoller := trello.NewPoller(trelloClient, deps.Cfg.Adapters.Trello, interval, trelloPollerOpts...)
+
+			logging.WithComponent("start").Info("Trello polling enabled",
+				slog.String("list", deps.Cfg.Adapters.Trello.ListID),
+				slog.Duration("interval", interval),
+			)
+			go func(p *trello.Poller) {
+				if err := p.Start(ctx); err != nil {
+					logging.WithComponent("trello").Error("Trello poller failed",
+						slog.Any("error", err),
+					)
+				}
+			}(trelloPoller)
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/health"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// buildPreflightReport runs the startup preflight checks for cfg.
+func buildPreflightReport(cfg *config.Config, projectPath string) *health.PreflightReport {
+	return health.RunPreflight(cfg, health.PreflightOptions{
+		ProjectPath: projectPath,
+		Environment: activeEnvironment(cfg),
+	})
+}
+
+// activeEnvironment returns the autopilot environment name, or "" when autopilot is off.
+func activeEnvironment(cfg *config.Config) string {
+	if cfg.Orchestrator == nil || cfg.Orchestrator.Autopilot == nil || !cfg.Orchestrator.Autopilot.Enabled {
+		return ""
+	}
+	return cfg.Orchestrator.Autopilot.EnvironmentName()
+}
+
+// isProdEnvironment reports whether autopilot runs against production.
+func isProdEnvironment(cfg *config.Config) bool {
+	if activeEnvironment(cfg) == "" {
+		return false
+	}
+	ap := cfg.Orchestrator.Autopilot
+	name := strings.ToLower(ap.EnvironmentName())
+	return ap.Environment == autopilot.EnvProd || name == "prod" || name == "production"
+}
+
+// runStartupPreflight prints the preflight report once and returns an error
+// when preflight.fail_on asks to abort on error findings.
+func runStartupPreflight(cfg *config.Config, projectPath string, dashboardMode bool) error {
+	report := buildPreflightReport(cfg, projectPath)
+	errs, warns := report.Count(health.SeverityError), report.Count(health.SeverityWarning)
+
+	log := logging.WithComponent("preflight")
+	if errs > 0 || warns > 0 {
+		log.Warn("preflight found issues",
+			slog.Int("errors", errs),
+			slog.Int("warnings", warns),
+			slog.String("environment", report.Environment),
+		)
+	} else {
+		log.Debug("preflight passed")
+	}
+
+	if !dashboardMode || errs > 0 || warns > 0 {
+		printPreflightReport(report)
+	}
+
+	if errs > 0 && cfg.Preflight.ShouldFailFast(isProdEnvironment(cfg)) {
+		return fmt.Errorf("preflight failed with %d error(s); fix them or set preflight.fail_on: never", errs)
+	}
+	return nil
+}
+
+// printPreflightReport renders the report grouped by severity, errors first.
+func printPreflightReport(report *health.PreflightReport) {
+	errs, warns := report.Count(health.SeverityError), report.Count(health.SeverityWarning)
+	if len(report.Findings) == 0 {
+		fmt.Println("✅ Preflight: all checks passed")
+		fmt.Println()
+		return
+	}
+
+	header := fmt.Sprintf("🔎 Preflight: %d error(s), %d warning(s)", errs, warns)
+	if report.Environment != "" {
+		header += fmt.Sprintf(" [env: %s]", report.Environment)
+	}
+	fmt.Println(header)
+
+	for _, severity := range []health.Severity{health.SeverityError, health.SeverityWarning, health.SeverityInfo} {
+		for _, f := range report.Findings {
+			if f.Severity != severity {
+				continue
+			}
+			fmt.Printf("  %s %-24s %s\n", f.Severity.ColorSymbol(), f.Check, f.Message)
+			if f.Fix != "" {
+				fmt.Printf("    %-24s → %s\n", "", f.Fix)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// runStatusPreflight implements `pilot status --preflight`.
+func runStatusPreflight(cfg *config.Config, jsonOutput bool) error {
+	projectPath := ""
+	if defaultProj := cfg.GetDefaultProject(); defaultProj != nil {
+		projectPath = defaultProj.Path
+	}
+	if projectPath == "" {
+		projectPath, _ = os.Getwd()
+	}
+
+	report := buildPreflightReport(cfg, projectPath)
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal preflight report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printPreflightReport(report)
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("preflight found %d error(s)", report.Count(health.SeverityError))
+	}
+	return nil
+}
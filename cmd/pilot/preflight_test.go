@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+)
+
+func TestIsProdEnvironment(t *testing.T) {
+	tests := []struct {
+		name string
+		ap   *autopilot.Config
+		want bool
+	}{
+		{"autopilot disabled", &autopilot.Config{Enabled: false, Environment: autopilot.EnvProd}, false},
+		{"stage", &autopilot.Config{Enabled: true, Environment: autopilot.EnvStage}, false},
+		{"prod", &autopilot.Config{Enabled: true, Environment: autopilot.EnvProd}, true},
+		{"named production", &autopilot.Config{Enabled: true, Name: "Production"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Orchestrator: &config.OrchestratorConfig{Autopilot: tt.ap}}
+			if got := isProdEnvironment(cfg); got != tt.want {
+				t.Errorf("isProdEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunStartupPreflight_FailFast(t *testing.T) {
+	cfg := &config.Config{
+		Projects:  []*config.ProjectConfig{{Name: "gone", Path: "/nonexistent/path/xyz123"}},
+		Preflight: &config.PreflightConfig{FailOn: "prod"},
+	}
+
+	if err := runStartupPreflight(cfg, t.TempDir(), true); err != nil {
+		t.Errorf("non-prod start should not fail fast: %v", err)
+	}
+
+	cfg.Orchestrator = &config.OrchestratorConfig{
+		Autopilot: &autopilot.Config{Enabled: true, Environment: autopilot.EnvProd},
+	}
+	if err := runStartupPreflight(cfg, t.TempDir(), true); err == nil {
+		t.Error("prod start with preflight errors should fail fast")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// projectFeaturesResolver returns a runner lookup for the feature flags set on
+// the configured project whose path matches the task's project path.
+func projectFeaturesResolver(cfg *config.Config) executor.ProjectFeaturesResolver {
+	return func(projectPath string) *executor.ProjectFeatures {
+		if proj := findProjectByPath(cfg, projectPath); proj != nil {
+			return &proj.Features
+		}
+		return nil
+	}
+}
+
+// findProjectByPath matches projects by cleaned path, tolerating trailing
+// slashes and relative paths that GetProject's exact match would miss.
+func findProjectByPath(cfg *config.Config, projectPath string) *config.ProjectConfig {
+	if projectPath == "" {
+		return nil
+	}
+	want := cleanProjectPath(projectPath)
+	for _, proj := range cfg.Projects {
+		if cleanProjectPath(proj.Path) == want {
+			return proj
+		}
+	}
+	return nil
+}
+
+func cleanProjectPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return filepath.Clean(p)
+}
+
+// withProjectOptions appends per-project controller options for ownerRepo to
+// opts when the repo belongs to a configured project: a gate that skips
+// releases when the project's config or .pilot.yaml sets allow_release: false,
+// the project's version_files mapping, its CI provider and its
+// require_approval_paths.
+func withProjectOptions(cfg *config.Config, ownerRepo string, opts []autopilot.ControllerOption) []autopilot.ControllerOption {
+	proj := cfg.FindProjectByRepo(ownerRepo)
+	if proj == nil {
+		return opts
+	}
+	gate := autopilot.WithReleaseGate(func() bool {
+		features, _ := executor.ResolveProjectFeatures(&proj.Features, proj.Path)
+		return features.ReleaseAllowed()
+	})
+	// Copy so controllers sharing a base option slice don't share gates
+	out := append(append([]autopilot.ControllerOption{}, opts...), gate)
+	if len(proj.VersionFiles) > 0 {
+		out = append(out, autopilot.WithVersionFiles(proj.VersionFiles))
+	}
+	if proj.CIProvider != nil {
+		out = append(out, autopilot.WithCIProvider(proj.CIProvider))
+	}
+	if len(proj.RequireApprovalPaths) > 0 {
+		out = append(out, autopilot.WithApprovalPaths(proj.RequireApprovalPaths))
+	}
+	return out
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// openPromoter loads config and returns a promoter backed by the autopilot
+// state store. The caller must close the returned store.
+func openPromoter() (*autopilot.Promoter, *memory.Store, error) {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ghToken := ""
+	if cfg.Adapters.GitHub != nil {
+		ghToken = cfg.Adapters.GitHub.Token
+	}
+	if ghToken == "" {
+		ghToken = os.Getenv("GITHUB_TOKEN")
+	}
+	if ghToken == "" {
+		return nil, nil, fmt.Errorf("GitHub not configured - set github.token in config or GITHUB_TOKEN env var")
+	}
+
+	owner, repo, err := resolveOwnerRepo(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releaseCfg := autopilot.DefaultReleaseConfig()
+	if cfg.Orchestrator != nil && cfg.Orchestrator.Autopilot != nil && cfg.Orchestrator.Autopilot.Release != nil {
+		releaseCfg = cfg.Orchestrator.Autopilot.Release
+	}
+
+	store, stateStore, err := openAutopilotStateStore(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	promoter := autopilot.NewPromoter(github.NewClient(ghToken), owner, repo, releaseCfg, stateStore, nil)
+	return promoter, store, nil
+}
+
+func newReleasePromoteCmd() *cobra.Command {
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "promote <version>",
+		Short: "Promote a release candidate to the next environment",
+		Long: `Promote a release through the promotion pipeline configured in
+orchestrator.autopilot.release.promotion.
+
+Autopilot tags each release as a candidate (v1.3.0-rc.1) and promotes it
+automatically through stages that need no approval. Running this command
+approves the next stage: its tag is created on the candidate's commit and
+its deploy action runs.
+
+Examples:
+  pilot release promote v1.3.0            # Promote to the next stage
+  pilot release promote v1.3.0 --to prod  # Promote through every stage up to prod`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			promoter, store, err := openPromoter()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = store.Close() }()
+
+			if to != "" {
+				known := false
+				for _, stage := range promoter.Stages() {
+					known = known || stage.Name == to
+				}
+				if !known {
+					return fmt.Errorf("unknown promotion stage %q", to)
+				}
+			}
+
+			approvedBy := os.Getenv("USER")
+			if approvedBy == "" {
+				approvedBy = "cli"
+			}
+
+			for {
+				promotion, err := promoter.Get(args[0])
+				if err != nil {
+					return err
+				}
+				if to != "" && promotion.Stage == to {
+					return nil
+				}
+				next := promoter.NextStage(promotion)
+				if next == nil {
+					if to != "" {
+						return fmt.Errorf("%s is at %s; stage %q is not ahead of it", promotion.Version, promotion.Stage, to)
+					}
+					return autopilot.ErrPromotionComplete
+				}
+
+				promotion, err = promoter.Promote(ctx, promotion.Version, approvedBy)
+				if promotion != nil && promotion.Stage == next.Name {
+					fmt.Printf("🚢 %s promoted to %s (%s)\n", promotion.Version, next.Name, promotion.LatestTag())
+				}
+				if err != nil {
+					return err
+				}
+				if to == "" {
+					return nil
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Promote through every stage up to this one")
+
+	return cmd
+}
+
+func newReleasePromotionsCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "promotions",
+		Short: "List releases in the promotion pipeline",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			promoter, store, err := openPromoter()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = store.Close() }()
+
+			promotions, err := promoter.List(limit)
+			if err != nil {
+				return fmt.Errorf("failed to list promotions: %w", err)
+			}
+			if len(promotions) == 0 {
+				fmt.Println("No release promotions recorded")
+				return nil
+			}
+
+			for _, p := range promotions {
+				next := "done"
+				if stage := promoter.NextStage(p); stage != nil {
+					next = "next: " + stage.Name
+				}
+				fmt.Printf("%-12s %-10s %s (%s)\n", p.Version, p.Stage, autopilot.ShortSHA(p.SHA), next)
+				for _, step := range p.History {
+					line := fmt.Sprintf("  %s  %-10s %s", step.At.Format(time.DateTime), step.Stage, step.Tag)
+					if step.ApprovedBy != "" {
+						line += " approved by " + step.ApprovedBy
+					}
+					if step.DeployError != "" {
+						line += " ⚠️  deploy failed: " + step.DeployError
+					}
+					fmt.Println(line)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of releases to show")
+
+	return cmd
+}
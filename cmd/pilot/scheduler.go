@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/alerts"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/scheduler"
+)
+
+// startTaskScheduler starts the recurring task scheduler if configured.
+// Returns nil when disabled or when the dispatcher or store is unavailable.
+func startTaskScheduler(ctx context.Context, cfg *config.Config, dispatcher *executor.Dispatcher, store *memory.Store, alertsEngine *alerts.Engine, projectPath string) *scheduler.Scheduler {
+	if cfg.Scheduler == nil || !cfg.Scheduler.Enabled || len(cfg.Scheduler.Jobs) == 0 {
+		return nil
+	}
+	if dispatcher == nil || store == nil {
+		logging.WithComponent("start").Warn("Task scheduler requires the task dispatcher, skipping")
+		return nil
+	}
+
+	opts := []scheduler.Option{
+		scheduler.WithLogger(logging.WithComponent("scheduler")),
+		scheduler.WithProjectResolver(func(project string) (string, error) {
+			return resolveScheduledProject(cfg, project, projectPath)
+		}),
+	}
+	if cfg.Adapters != nil && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.Token != "" {
+		opts = append(opts, scheduler.WithOpenPRFinder(&scheduledPRFinder{
+			cfg:    cfg,
+			client: github.NewClient(cfg.Adapters.GitHub.Token),
+		}))
+	}
+	if alertsEngine != nil {
+		opts = append(opts, scheduler.WithAlertProcessor(alerts.NewEngineAdapter(alertsEngine)))
+	}
+
+	s := scheduler.New(cfg.Scheduler, dispatcher, store, opts...)
+	if err := s.Start(ctx); err != nil {
+		logging.WithComponent("start").Warn("Failed to start task scheduler", slog.Any("error", err))
+		return nil
+	}
+	return s
+}
+
+// resolveScheduledProject maps a job's project (name or path) to a project
+// path, falling back to the active project and then the default project.
+// Projects missing from the projects list are an error, never a raw path.
+func resolveScheduledProject(cfg *config.Config, project, fallback string) (string, error) {
+	if project != "" {
+		if proj := cfg.GetProjectByName(project); proj != nil {
+			return proj.Path, nil
+		}
+		if proj := cfg.GetProject(project); proj != nil {
+			return proj.Path, nil
+		}
+		return "", fmt.Errorf("project %q not found in projects list", project)
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	if proj := cfg.GetDefaultProject(); proj != nil {
+		return proj.Path, nil
+	}
+	return "", nil
+}
+
+// scheduledPRFinder finds open PRs left by earlier scheduled runs on GitHub.
+type scheduledPRFinder struct {
+	cfg    *config.Config
+	client *github.Client
+}
+
+// FindOpenPR looks in the project's GitHub repository, or the adapter's
+// repository for projects without one, for an open PR from branchPrefix.
+func (f *scheduledPRFinder) FindOpenPR(ctx context.Context, projectPath, branchPrefix string) (string, error) {
+	repo := ""
+	if proj := f.cfg.GetProject(projectPath); proj != nil && proj.GitHub != nil && proj.GitHub.Owner != "" {
+		repo = proj.GitHub.Owner + "/" + proj.GitHub.Repo
+	} else if f.cfg.Adapters.GitHub.Repo != "" {
+		repo = f.cfg.Adapters.GitHub.Repo
+	}
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", nil
+	}
+
+	prs, err := f.client.ListPullRequests(ctx, parts[0], parts[1], github.StateOpen)
+	if err != nil {
+		return "", err
+	}
+	for _, pr := range prs {
+		if strings.HasPrefix(pr.Head.Ref, branchPrefix) {
+			return pr.HTMLURL, nil
+		}
+	}
+	return "", nil
+}
+
+func newScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Inspect scheduled recurring tasks",
+	}
+	cmd.AddCommand(newScheduleListCmd())
+	return cmd
+}
+
+func newScheduleListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List scheduled jobs and their next run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if cfg.Scheduler == nil || len(cfg.Scheduler.Jobs) == 0 {
+				fmt.Println("No scheduled jobs configured")
+				fmt.Println("   Add jobs under scheduler.jobs in ~/.pilot/config.yaml")
+				return nil
+			}
+
+			status := "enabled"
+			if !cfg.Scheduler.Enabled {
+				status = "disabled"
+			}
+			fmt.Printf("⏰ Scheduled Jobs (%d, %s)\n", len(cfg.Scheduler.Jobs), status)
+			fmt.Println("───────────────────────────────────────")
+
+			now := time.Now()
+			for _, job := range cfg.Scheduler.Jobs {
+				next := "invalid schedule"
+				if t, err := cfg.Scheduler.NextRun(job, now); err == nil {
+					next = t.Format("Mon Jan 2 15:04 MST")
+				}
+				fmt.Printf("%-24s %-16s next: %s\n", job.Name, job.Schedule, next)
+				project, err := resolveScheduledProject(cfg, job.Project, "")
+				if err != nil {
+					fmt.Printf("%-24s ⚠️  %v\n", "", err)
+				} else if project != "" {
+					fmt.Printf("%-24s project: %s\n", "", project)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/config"
+)
+
+func TestResolveScheduledProject(t *testing.T) {
+	cfg := &config.Config{
+		DefaultProject: "web",
+		Projects: []*config.ProjectConfig{
+			{Name: "api", Path: "/src/api"},
+			{Name: "web", Path: "/src/web"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		project  string
+		fallback string
+		want     string
+		wantErr  bool
+	}{
+		{"by name", "API", "", "/src/api", false},
+		{"by path", "/src/web", "", "/src/web", false},
+		{"unknown name", "billing", "", "", true},
+		{"unknown path", "/tmp/elsewhere", "", "", true},
+		{"active project", "", "/src/api", "/src/api", false},
+		{"default project", "", "", "/src/web", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveScheduledProject(cfg, tt.project, tt.fallback)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
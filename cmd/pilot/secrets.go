@@ -0,0 +1,481 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/secrets"
+)
+
+// secretRefPattern matches ${backend:name} references in a config file
+var secretRefPattern = regexp.MustCompile(`\$\{(env|keychain|secret):([^}]+)\}`)
+
+// providerRefPattern matches vault:// and aws-sm:// values in a config file
+var providerRefPattern = regexp.MustCompile(`\b(?:vault|aws-sm)://[^\s"']+`)
+
+func newSecretsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Keep adapter tokens out of the config file",
+		Long: `Store tokens in the OS keychain or an encrypted secrets file and
+reference them from the config:
+
+  adapters:
+    github:
+      token: ${keychain:github-token}
+    slack:
+      bot_token: ${secret:slack-bot-token}
+    linear:
+      api_key: ${env:LINEAR_API_KEY}
+
+The secrets file (~/.pilot/secrets.enc, or $PILOT_SECRETS_FILE) is unlocked
+with a passphrase, read from $PILOT_SECRETS_PASSPHRASE or asked for at the
+terminal.`,
+	}
+	cmd.AddCommand(
+		newSecretsSetCmd(),
+		newSecretsGetCmd(),
+		newSecretsListCmd(),
+		newSecretsDeleteCmd(),
+		newSecretsMigrateCmd(),
+		newSecretsStatusCmd(),
+	)
+	return cmd
+}
+
+func newSecretsSetCmd() *cobra.Command {
+	var backend string
+
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Store a secret",
+		Long: `Store a secret and print the reference to put in the config. The value
+is read from stdin when piped, otherwise asked for without echo.
+
+Examples:
+  pilot secrets set github-token
+  pilot secrets set slack-bot-token --backend keychain
+  echo "$TOKEN" | pilot secrets set linear-api-key`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := secrets.ValidateName(name); err != nil {
+				return err
+			}
+			store, err := secrets.NewResolver().Store(backend)
+			if err != nil {
+				return err
+			}
+			value, err := readSecretValue(name)
+			if err != nil {
+				return err
+			}
+			if err := store.Set(name, value); err != nil {
+				return fmt.Errorf("failed to store secret: %w", err)
+			}
+
+			fmt.Printf("🔒 Stored %s\n", name)
+			fmt.Printf("   Reference it in the config as %s\n", secrets.Reference(backend, name))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backend, "backend", secrets.BackendFile, "Where to store the secret: secret (encrypted file) or keychain")
+	return cmd
+}
+
+func newSecretsGetCmd() *cobra.Command {
+	var backend string
+
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Print a stored secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := secrets.NewResolver().Store(backend)
+			if err != nil {
+				return err
+			}
+			value, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backend, "backend", secrets.BackendFile, "Where the secret is stored: secret (encrypted file) or keychain")
+	return cmd
+}
+
+func newSecretsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List stored secrets and the references in the config",
+		Long: `List the names in the encrypted secrets file and every secret reference
+in the config, with whether it resolves. Values are never printed. The
+keychain cannot be listed; its entries show up through the references.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			resolver := secrets.NewResolver()
+
+			file := secrets.DefaultFile()
+			names, err := file.List()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("🔒 Secrets file %s\n", file.Path())
+			if len(names) == 0 {
+				fmt.Println("   (empty)")
+			}
+			for _, name := range names {
+				fmt.Printf("   %s\n", name)
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read config: %w", err)
+			}
+			fmt.Printf("\n⚙️  References in %s\n", configPath)
+			refs := secretRefPattern.FindAllString(string(data), -1)
+			if len(refs) == 0 {
+				fmt.Println("   (none)")
+			}
+			seen := make(map[string]bool)
+			for _, ref := range refs {
+				if seen[ref] {
+					continue
+				}
+				seen[ref] = true
+				value, err := resolver.Expand(ref)
+				switch {
+				case err != nil:
+					fmt.Printf("   ❌ %s: %v\n", ref, err)
+				case value == "":
+					fmt.Printf("   ⚠️  %s: empty\n", ref)
+				default:
+					fmt.Printf("   ✅ %s\n", ref)
+				}
+			}
+
+			var plaintext []string
+			if len(data) > 0 {
+				_, plaintext, _ = secrets.RewriteConfig(data, func(_, value string) (string, error) {
+					return value, nil
+				})
+			}
+			if len(plaintext) > 0 {
+				fmt.Printf("\n⚠️  %d plaintext secret(s) in the config; run 'pilot secrets migrate':\n", len(plaintext))
+				for _, path := range plaintext {
+					fmt.Printf("   %s\n", path)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newSecretsDeleteCmd() *cobra.Command {
+	var backend string
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Remove a stored secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := secrets.NewResolver().Store(backend)
+			if err != nil {
+				return err
+			}
+			if err := store.Delete(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("🗑️  Deleted %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backend, "backend", secrets.BackendFile, "Where the secret is stored: secret (encrypted file) or keychain")
+	return cmd
+}
+
+func newSecretsMigrateCmd() *cobra.Command {
+	var (
+		backend string
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move plaintext tokens from the config into a secrets store",
+		Long: `Find tokens, passwords and keys written into the config, store each
+under its config path (e.g. adapters.github.token) and replace the value
+with a reference. The original config is kept as config.yaml.bak.
+
+Values that already reference a variable or secret are left alone.
+
+Examples:
+  pilot secrets migrate --dry-run
+  pilot secrets migrate --backend keychain`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to read config: %w", err)
+			}
+
+			store, err := secrets.NewResolver().Store(backend)
+			if err != nil {
+				return err
+			}
+			out, migrated, err := secrets.RewriteConfig(data, func(path, value string) (string, error) {
+				if !dryRun {
+					if err := store.Set(path, value); err != nil {
+						return "", err
+					}
+				}
+				return secrets.Reference(backend, path), nil
+			})
+			if err != nil {
+				return fmt.Errorf("migration failed: %w", err)
+			}
+			if len(migrated) == 0 {
+				fmt.Println("✅ No plaintext secrets in the config")
+				return nil
+			}
+
+			if dryRun {
+				fmt.Printf("Would move %d secret(s) to %s:\n", len(migrated), backend)
+				for _, path := range migrated {
+					fmt.Printf("   %s → %s\n", path, secrets.Reference(backend, path))
+				}
+				return nil
+			}
+
+			backupPath := configPath + ".bak"
+			if err := os.WriteFile(backupPath, data, 0600); err != nil {
+				return fmt.Errorf("failed to backup config: %w", err)
+			}
+			if err := os.WriteFile(configPath, out, 0600); err != nil {
+				return fmt.Errorf("failed to write config: %w", err)
+			}
+
+			fmt.Printf("🔒 Moved %d secret(s) to %s:\n", len(migrated), backend)
+			for _, path := range migrated {
+				fmt.Printf("   %s → %s\n", path, secrets.Reference(backend, path))
+			}
+			fmt.Printf("\n   📦 Backed up the plaintext config to %s; delete it once Pilot starts\n", backupPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backend, "backend", secrets.BackendFile, "Where to store the secrets: secret (encrypted file) or keychain")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be migrated without changing anything")
+	return cmd
+}
+
+// readSecretValue reads a secret from piped stdin, or asks for it without
+// echo at the terminal
+func readSecretValue(name string) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		value, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read secret: %w", err)
+		}
+		value = strings.TrimRight(value, "\r\n")
+		if value == "" {
+			return "", fmt.Errorf("no value on stdin")
+		}
+		return value, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Value for %s: ", name)
+	value, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+	if len(value) == 0 {
+		return "", fmt.Errorf("secret must not be empty")
+	}
+	return string(value), nil
+}
+
+func newSecretsStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show secret manager references and rotations not applied yet",
+		Long: `Show the vault:// and aws-sm:// references in the config, whether each
+resolves, and the credentials rotated while 'pilot start' has been running.
+
+Rotated values reach config loads right away, but adapters that are already
+connected keep the credentials they started with. Restart Pilot to apply
+a rotation to them.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			data, err := os.ReadFile(configPath)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read config: %w", err)
+			}
+
+			fmt.Printf("🔐 Secret manager references in %s\n", configPath)
+			refs := providerRefPattern.FindAllString(string(data), -1)
+			if len(refs) == 0 {
+				fmt.Println("   (none)")
+				return nil
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			providers := secrets.DefaultProviders()
+			seen := make(map[string]bool)
+			for _, ref := range refs {
+				if seen[ref] {
+					continue
+				}
+				seen[ref] = true
+				if _, err := providers.Resolve(cmd.Context(), ref); err != nil {
+					fmt.Printf("   ❌ %s: %v\n", ref, err)
+				} else {
+					fmt.Printf("   ✅ %s\n", ref)
+				}
+			}
+			fmt.Printf("\n   Checked for rotation every %s while Pilot runs\n", cfg.Secrets.RefreshEvery())
+
+			status, err := readRotationStatus(rotationStatusPath())
+			if err != nil {
+				return err
+			}
+			if status == nil || len(status.Refs) == 0 {
+				fmt.Println("   No rotations since Pilot last started")
+				return nil
+			}
+			fmt.Printf("\n⚠️  Rotated since Pilot started at %s (last at %s):\n",
+				status.StartedAt.Local().Format("2006-01-02 15:04"), status.RotatedAt.Local().Format("2006-01-02 15:04"))
+			for _, ref := range status.Refs {
+				fmt.Printf("   %s\n", ref)
+			}
+			fmt.Println("   Connected adapters still use the previous credentials; restart Pilot to apply them.")
+			return nil
+		},
+	}
+}
+
+// rotationStatus records the credentials rotated while the daemon runs, so
+// 'pilot secrets status' can show what a restart would apply
+type rotationStatus struct {
+	StartedAt time.Time `json:"started_at"`
+	RotatedAt time.Time `json:"rotated_at"`
+	Refs      []string  `json:"refs,omitempty"` // References only, never values
+}
+
+// rotationStatusPath is where the daemon keeps its rotationStatus
+func rotationStatusPath() string {
+	return filepath.Join(filepath.Dir(config.DefaultConfigPath()), "secrets-rotated.json")
+}
+
+// readRotationStatus reads the daemon's rotation status, nil if it has none
+func readRotationStatus(path string) (*rotationStatus, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rotation status: %w", err)
+	}
+	var status rotationStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("invalid rotation status %s: %w", path, err)
+	}
+	return &status, nil
+}
+
+// writeRotationStatus saves status to path, readable by the owner only
+func writeRotationStatus(path string, status *rotationStatus) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// recordRotation adds refs to the rotation status at path
+func recordRotation(path string, startedAt time.Time, refs []string) error {
+	status, err := readRotationStatus(path)
+	if err != nil || status == nil || !status.StartedAt.Equal(startedAt) {
+		status = &rotationStatus{StartedAt: startedAt}
+	}
+	seen := make(map[string]bool, len(status.Refs))
+	for _, ref := range status.Refs {
+		seen[ref] = true
+	}
+	for _, ref := range refs {
+		if !seen[ref] {
+			seen[ref] = true
+			status.Refs = append(status.Refs, ref)
+		}
+	}
+	sort.Strings(status.Refs)
+	status.RotatedAt = time.Now()
+	return writeRotationStatus(path, status)
+}
+
+// watchSecretProviders checks vault:// and aws-sm:// values for rotation
+// while the daemon runs. The cache stays current, so config loads (hot
+// upgrades, HA takeovers, CLI commands) pick up rotated credentials and
+// keep working on the last value while a secret manager is unreachable.
+// Adapters already connected keep the credentials they started with until
+// Pilot restarts; rotations are recorded for 'pilot secrets status'.
+func watchSecretProviders(ctx context.Context, cfg *config.Config) {
+	providers := secrets.DefaultProviders()
+	if providers.Cached() == 0 {
+		return
+	}
+	log := logging.WithComponent("secrets")
+
+	// A fresh daemon runs on the current credentials
+	path := rotationStatusPath()
+	startedAt := time.Now()
+	if err := writeRotationStatus(path, &rotationStatus{StartedAt: startedAt}); err != nil {
+		log.Warn("failed to reset rotation status", "error", err)
+	}
+	go providers.Watch(ctx, cfg.Secrets.RefreshEvery(), func(refs []string) {
+		log.Warn("credentials rotated in secret manager; restart Pilot to apply them to connected adapters",
+			"refs", strings.Join(refs, ", "))
+		if err := recordRotation(path, startedAt, refs); err != nil {
+			log.Warn("failed to record rotation status", "error", err)
+		}
+	})
+}
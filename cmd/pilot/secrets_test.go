@@ -0,0 +1,37 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets-rotated.json")
+	started := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	if status, err := readRotationStatus(path); err != nil || status != nil {
+		t.Fatalf("readRotationStatus() without a file = %+v, %v", status, err)
+	}
+	if err := recordRotation(path, started, []string{"vault://secret/pilot/github#token"}); err != nil {
+		t.Fatalf("recordRotation failed: %v", err)
+	}
+	if err := recordRotation(path, started, []string{"aws-sm://pilot/slack", "vault://secret/pilot/github#token"}); err != nil {
+		t.Fatalf("recordRotation failed: %v", err)
+	}
+	status, err := readRotationStatus(path)
+	if err != nil {
+		t.Fatalf("readRotationStatus failed: %v", err)
+	}
+	if !status.StartedAt.Equal(started) || len(status.Refs) != 2 || status.Refs[0] != "aws-sm://pilot/slack" || status.RotatedAt.IsZero() {
+		t.Errorf("status = %+v", status)
+	}
+
+	// A restarted daemon starts over
+	if err := recordRotation(path, time.Now(), []string{"aws-sm://pilot/linear"}); err != nil {
+		t.Fatalf("recordRotation failed: %v", err)
+	}
+	if status, _ := readRotationStatus(path); len(status.Refs) != 1 || status.Refs[0] != "aws-sm://pilot/linear" {
+		t.Errorf("status after restart = %+v", status)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/encryption"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/replay"
+	"github.com/alekspetrov/pilot/internal/state"
+)
+
+func newStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Export and import Pilot state to move it between machines",
+	}
+	cmd.AddCommand(newStateExportCmd(), newStateImportCmd())
+	return cmd
+}
+
+func newStateExportCmd() *cobra.Command {
+	var noRecordings bool
+
+	cmd := &cobra.Command{
+		Use:   "export [archive]",
+		Short: "Bundle history, autopilot state, patterns, recordings and config",
+		Long: `Write the memory store (execution history, autopilot state, teams,
+budgets), learned patterns and the knowledge graph, recordings and the
+config to a tar.gz archive. Secrets in the config are replaced with
+REDACTED; values that reference environment variables are kept.
+
+The store is snapshotted consistently, so export works while Pilot runs.
+With encryption at rest on, the store stays sealed in the archive under
+the same key; import needs that key.
+
+Examples:
+  pilot state export
+  pilot state export pilot-laptop.tar.gz --no-recordings`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archivePath := fmt.Sprintf("pilot-state-%s.tar.gz", time.Now().Format("20060102-150405"))
+			if len(args) == 1 {
+				archivePath = args[0]
+			}
+
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cfg.Memory == nil {
+				return fmt.Errorf("memory not configured")
+			}
+
+			store, err := memory.NewStore(cfg.Memory.Path)
+			if err != nil {
+				return fmt.Errorf("failed to open memory store: %w", err)
+			}
+			defer func() { _ = store.Close() }()
+
+			src := state.Sources{
+				ConfigPath:   configPath,
+				MemoryPath:   cfg.Memory.Path,
+				Store:        store,
+				PilotVersion: version,
+			}
+			if !noRecordings {
+				src.RecordingsPath = replay.DefaultRecordingsPath()
+			}
+
+			f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+			if err != nil {
+				return fmt.Errorf("failed to create archive: %w", err)
+			}
+			m, err := state.Export(f, src)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(archivePath)
+				return fmt.Errorf("export failed: %w", err)
+			}
+
+			fmt.Printf("📦 Exported Pilot state to %s\n", archivePath)
+			printStateManifest(m)
+			if len(m.Redacted) > 0 {
+				fmt.Printf("\n🔒 %d secret(s) left out of the config:\n", len(m.Redacted))
+				for _, key := range m.Redacted {
+					fmt.Printf("   %s\n", key)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&noRecordings, "no-recordings", false, "Leave execution recordings out of the archive")
+	return cmd
+}
+
+func newStateImportCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "import <archive>",
+		Short: "Restore state exported with 'pilot state export'",
+		Long: `Unpack an archive from 'pilot state export' on this machine. Stop Pilot
+first.
+
+Without a config here, the archive's config is installed; fill in the
+secrets it lists as REDACTED and check project paths. An existing config
+is kept and the archive's is written next to it as config.yaml.imported.
+
+An existing memory store is not replaced unless --force is set. Existing
+pattern files and recordings are kept unless --force is set.
+
+Examples:
+  pilot state import pilot-state-20260101-120000.tar.gz
+  pilot state import pilot-laptop.tar.gz --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open archive: %w", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			var cfg *config.Config
+			res, err := state.Import(f, state.Targets{
+				ConfigPath:     configPath,
+				RecordingsPath: replay.DefaultRecordingsPath(),
+				MemoryPath: func() (string, error) {
+					cfg, err = config.Load(configPath)
+					if err != nil {
+						return "", fmt.Errorf("failed to load config: %w", err)
+					}
+					if cfg.Memory == nil {
+						return "", fmt.Errorf("memory not configured")
+					}
+					// A freshly installed config brings the key a sealed
+					// store needs
+					if key, _ := encryption.Default(); key == nil && cfg.Encryption != nil && cfg.Encryption.Enabled {
+						if err := encryption.Init(cfg.Encryption); err != nil {
+							return "", err
+						}
+					}
+					return cfg.Memory.Path, nil
+				},
+				SkipDatabase: memoryDriver(configPath) == memory.DriverPostgres,
+				Force:        force,
+			})
+			if errors.Is(err, state.ErrStateExists) {
+				return fmt.Errorf("%w; run with --force to replace it", err)
+			}
+			if err != nil {
+				return fmt.Errorf("import failed: %w", err)
+			}
+
+			// Opening the store migrates it to this version, and seals it when
+			// encryption at rest is on
+			if res.Database {
+				store, err := memory.NewStore(res.MemoryPath)
+				if err != nil {
+					return fmt.Errorf("imported memory store does not open: %w", err)
+				}
+				_ = store.Close()
+			}
+
+			m := res.Manifest
+			fmt.Printf("📥 Imported state exported from %s on %s\n", m.Hostname, m.CreatedAt.Local().Format("2006-01-02 15:04"))
+			if res.Database {
+				fmt.Printf("   Memory store     %s\n", filepath.Join(res.MemoryPath, "pilot.db"))
+			}
+			if res.SkippedStore {
+				fmt.Println("   Memory store     skipped: this machine uses PostgreSQL")
+			}
+			fmt.Printf("   Memory files     %d\n", res.Files)
+			fmt.Printf("   Recordings       %d\n", res.Recordings)
+			if res.Skipped > 0 {
+				fmt.Printf("   Kept existing    %d file(s) (--force replaces them)\n", res.Skipped)
+			}
+			for _, note := range m.Notes {
+				fmt.Printf("   Note: %s\n", note)
+			}
+
+			switch {
+			case res.ConfigKept:
+				fmt.Printf("\n⚙️  Kept your config; the exported one is at %s\n", res.ConfigPath)
+			case res.ConfigPath != "":
+				fmt.Printf("\n⚙️  Installed config at %s\n", res.ConfigPath)
+				if len(m.Redacted) > 0 {
+					fmt.Println("   Fill in these secrets, set to REDACTED:")
+					for _, key := range m.Redacted {
+						fmt.Printf("   %s\n", key)
+					}
+				}
+				if cfg != nil && len(cfg.Projects) > 0 {
+					fmt.Println("   Check that project paths exist on this machine:")
+					for _, p := range cfg.Projects {
+						fmt.Printf("   %s → %s\n", p.Name, p.Path)
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing memory store, pattern files and recordings")
+	return cmd
+}
+
+// memoryDriver returns the memory driver of the config at configPath,
+// SQLite when it cannot be read
+func memoryDriver(configPath string) string {
+	cfg, err := config.Load(configPath)
+	if err != nil || cfg.Memory == nil || cfg.Memory.Driver == "" {
+		return memory.DriverSQLite
+	}
+	return cfg.Memory.Driver
+}
+
+func printStateManifest(m *state.Manifest) {
+	switch {
+	case m.Database && m.Encrypted:
+		fmt.Println("   Memory store     included, encrypted")
+	case m.Database:
+		fmt.Println("   Memory store     included")
+	}
+	fmt.Printf("   Memory files     %d\n", m.Files)
+	fmt.Printf("   Recordings       %d\n", m.Recordings)
+	if m.Config {
+		fmt.Println("   Config           included")
+	}
+	for _, note := range m.Notes {
+		fmt.Printf("   Note: %s\n", note)
+	}
+}
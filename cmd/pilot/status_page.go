@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// exportStatusPage writes the status page to path as static HTML. The live
+// page is fetched from the running daemon; when it is unreachable the page
+// is built from the local database and reported as down.
+func exportStatusPage(cfg *config.Config, path string) error {
+	page, err := fetchStatusPage(cfg)
+	if err != nil {
+		page, err = buildOfflineStatusPage(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := gateway.RenderStatusPage(f, page); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to render status page: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fmt.Printf("✅ Status page (%s) written to %s\n", page.Status, path)
+	return nil
+}
+
+// fetchStatusPage reads /status.json from the running gateway
+func fetchStatusPage(cfg *config.Config) (*gateway.StatusPage, error) {
+	if cfg.Gateway == nil {
+		return nil, fmt.Errorf("gateway not configured")
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s:%d/status.json", cfg.Gateway.Host, cfg.Gateway.Port))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gateway returned %s (is gateway.status_page enabled?)", resp.Status)
+	}
+
+	var page gateway.StatusPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode status page: %w", err)
+	}
+	return &page, nil
+}
+
+// buildOfflineStatusPage builds the status page from the local database
+func buildOfflineStatusPage(cfg *config.Config) (*gateway.StatusPage, error) {
+	title := ""
+	if cfg.Gateway != nil && cfg.Gateway.StatusPage != nil {
+		title = cfg.Gateway.StatusPage.Title
+	}
+
+	var store gateway.DashboardStore
+	if cfg.Memory != nil {
+		memStore, err := memory.NewStore(cfg.Memory.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open memory store: %w", err)
+		}
+		defer func() { _ = memStore.Close() }()
+		store = memStore
+	}
+
+	page, err := gateway.BuildStatusPage(title, store, portalRepoResolver(cfg), nil, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build status page: %w", err)
+	}
+	page.Status = gateway.StatusDown
+	return page, nil
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/encryption"
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/replay"
+)
+
+// takeoverSummaryLimit caps the recording summary quoted in the handoff
+// comment
+const takeoverSummaryLimit = 4000
+
+func newTakeoverCmd() *cobra.Command {
+	var repo string
+
+	cmd := &cobra.Command{
+		Use:   "takeover <task-id|pr>",
+		Short: "Hand a Pilot branch over to a human",
+		Long: `Hand the branch of a Pilot task over for manual work.
+
+Autopilot stops tracking the PR, the Pilot labels are removed from the issue
+so it is not picked up again, and a comment summarizing what Pilot attempted
+(from the execution recording) is posted on the PR, or on the issue when
+there is no PR. The branch and any worktree are left as they are.
+
+Examples:
+  pilot takeover GH-405
+  pilot takeover 412 --repo owner/repo`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			issueNumber, prNumber, err := parseTakeoverTarget(args[0])
+			if err != nil {
+				return err
+			}
+
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cfg.Adapters == nil || cfg.Adapters.GitHub == nil {
+				return fmt.Errorf("GitHub adapter not configured")
+			}
+
+			token := cfg.Adapters.GitHub.Token
+			if token == "" {
+				token = os.Getenv("GITHUB_TOKEN")
+			}
+			if token == "" {
+				return fmt.Errorf("GitHub token not configured. Set GITHUB_TOKEN env or add to config")
+			}
+			if repo == "" {
+				repo = cfg.Adapters.GitHub.Repo
+			}
+			parts := strings.Split(repo, "/")
+			if len(parts) != 2 {
+				return fmt.Errorf("no repository specified. Use --repo owner/repo or set in config")
+			}
+			owner, repoName := parts[0], parts[1]
+			client := github.NewClient(token)
+
+			// Resolve the PR from the task or the task from the PR
+			branch := ""
+			if prNumber > 0 {
+				pr, err := client.GetPullRequest(ctx, owner, repoName, prNumber)
+				if err != nil {
+					return fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
+				}
+				branch = pr.Head.Ref
+				if state := lookupPRState(cfg, prNumber); state != nil && state.IssueNumber > 0 {
+					issueNumber = state.IssueNumber
+				} else {
+					_, _ = fmt.Sscanf(branch, "pilot/GH-%d", &issueNumber)
+				}
+			} else {
+				branch = fmt.Sprintf("pilot/GH-%d", issueNumber)
+				prs, err := client.ListPullRequests(ctx, owner, repoName, github.StateOpen)
+				if err != nil {
+					return fmt.Errorf("failed to list PRs: %w", err)
+				}
+				for _, pr := range prs {
+					if pr.Head.Ref == branch {
+						prNumber = pr.Number
+						break
+					}
+				}
+			}
+			taskID := ""
+			if issueNumber > 0 {
+				taskID = fmt.Sprintf("GH-%d", issueNumber)
+			}
+
+			fmt.Printf("🧑‍💻 Handing over %s\n", takeoverName(taskID, prNumber))
+
+			if prNumber > 0 {
+				if err := releasePRViaGateway(cfg, repo, prNumber); err != nil {
+					fmt.Printf("   ⚠️  %v\n", err)
+					if removed := releasePRFromStateStore(cfg, prNumber); removed {
+						fmt.Println("   Removed the PR from the autopilot state store")
+					}
+				} else {
+					fmt.Println("   Autopilot stopped tracking the PR")
+				}
+			}
+
+			if issueNumber > 0 {
+				removed, err := removePilotLabels(ctx, client, owner, repoName, issueNumber, githubTriggerLabel(cfg.Adapters.GitHub))
+				if err != nil {
+					return err
+				}
+				if len(removed) > 0 {
+					fmt.Printf("   Removed labels from #%d: %s\n", issueNumber, strings.Join(removed, ", "))
+				}
+			}
+
+			commentOn := prNumber
+			if commentOn == 0 {
+				commentOn = issueNumber
+			}
+			if _, err := client.AddComment(ctx, owner, repoName, commentOn, takeoverComment(taskID, branch, latestRecording(taskID))); err != nil {
+				return fmt.Errorf("failed to post handoff comment: %w", err)
+			}
+			fmt.Printf("   Posted a handoff comment on #%d\n", commentOn)
+			fmt.Printf("   Branch %s is yours\n", branch)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "Repository in owner/repo format (default: github.repo from config)")
+	return cmd
+}
+
+// parseTakeoverTarget reads a GitHub task ID (GH-12) as an issue and a bare
+// or #-prefixed number as a PR.
+func parseTakeoverTarget(arg string) (issueNumber, prNumber int, err error) {
+	if len(arg) > 3 && strings.EqualFold(arg[:3], "GH-") {
+		n, err := strconv.Atoi(arg[3:])
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid task ID: %s", arg)
+		}
+		return n, 0, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil || n <= 0 {
+		return 0, 0, fmt.Errorf("expected a task ID like GH-12 or a PR number, got %q", arg)
+	}
+	return 0, n, nil
+}
+
+func takeoverName(taskID string, prNumber int) string {
+	switch {
+	case taskID != "" && prNumber > 0:
+		return fmt.Sprintf("%s (PR #%d)", taskID, prNumber)
+	case prNumber > 0:
+		return fmt.Sprintf("PR #%d", prNumber)
+	default:
+		return taskID
+	}
+}
+
+// githubTriggerLabel returns the label the GitHub poller picks issues up by
+func githubTriggerLabel(cfg *github.Config) string {
+	label := cfg.PilotLabel
+	if cfg.Polling != nil && cfg.Polling.Label != "" {
+		label = cfg.Polling.Label
+	}
+	if label == "" {
+		label = "pilot"
+	}
+	return label
+}
+
+// removePilotLabels removes the trigger label and Pilot's status labels
+// from an issue and returns the ones it had.
+func removePilotLabels(ctx context.Context, client *github.Client, owner, repo string, number int, trigger string) ([]string, error) {
+	issue, err := client.GetIssue(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	var removed []string
+	for _, label := range []string{trigger, github.LabelInProgress, github.LabelFailed, github.LabelRetryReady,
+		github.LabelDone, github.LabelBlocked, github.LabelNeedsInfo} {
+		if !github.HasLabel(issue, label) {
+			continue
+		}
+		if err := client.RemoveLabel(ctx, owner, repo, number, label); err != nil {
+			return removed, fmt.Errorf("failed to remove %s from #%d: %w", label, number, err)
+		}
+		removed = append(removed, label)
+	}
+	return removed, nil
+}
+
+// latestRecording returns the most recent execution recording of a task,
+// or nil when there is none.
+func latestRecording(taskID string) *replay.Recording {
+	if taskID == "" {
+		return nil
+	}
+	basePath := replay.DefaultRecordingsPath()
+	summaries, err := replay.ListRecordings(basePath, nil)
+	if err != nil {
+		return nil
+	}
+	var latest *replay.RecordingSummary
+	for _, s := range summaries {
+		if s.TaskID == taskID && (latest == nil || s.StartTime.After(latest.StartTime)) {
+			latest = s
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	recording, err := replay.LoadRecording(basePath, latest.ID)
+	if err != nil {
+		return nil
+	}
+	return recording
+}
+
+// takeoverComment renders the handoff comment, quoting the recording's
+// summary of what Pilot attempted
+func takeoverComment(taskID, branch string, recording *replay.Recording) string {
+	var sb strings.Builder
+	sb.WriteString("🧑‍💻 **Handed over for manual work**\n\n")
+	fmt.Fprintf(&sb, "Pilot stopped working on this and will not pick it up again. Branch `%s` is left as it is.\n\n", branch)
+
+	if recording == nil {
+		if taskID != "" {
+			fmt.Fprintf(&sb, "No execution recording was found for %s.", taskID)
+		}
+		return strings.TrimRight(sb.String(), "\n")
+	}
+
+	fmt.Fprintf(&sb, "Last run: %s, %s, %s", recording.TaskID, recording.Status, recording.Duration.Round(time.Second))
+	if recording.TokenUsage != nil {
+		fmt.Fprintf(&sb, ", $%.2f", recording.TokenUsage.EstimatedCostUSD)
+	}
+	sb.WriteString(".\n")
+
+	summary := ""
+	if recording.SummaryPath != "" {
+		if data, err := encryption.ReadFile(recording.SummaryPath); err == nil {
+			summary = strings.TrimSpace(string(data))
+		}
+	}
+	if summary != "" {
+		if len(summary) > takeoverSummaryLimit {
+			summary = summary[:takeoverSummaryLimit] + "\n... (truncated)"
+		}
+		fmt.Fprintf(&sb, "\n<details><summary>What Pilot attempted</summary>\n\n%s\n\n</details>", summary)
+	}
+	fmt.Fprintf(&sb, "\n\nReplay it with `pilot replay show %s`.", recording.ID)
+	return sb.String()
+}
+
+// releasePRViaGateway asks the running gateway to stop tracking a PR
+func releasePRViaGateway(cfg *config.Config, repo string, prNumber int) error {
+	if cfg.Gateway == nil {
+		return fmt.Errorf("gateway not configured")
+	}
+	target := fmt.Sprintf("http://%s:%d/api/v1/autopilot/prs/%d?repo=%s", cfg.Gateway.Host, cfg.Gateway.Port, prNumber, url.QueryEscape(repo))
+	req, err := http.NewRequest(http.MethodDelete, target, nil)
+	if err != nil {
+		return err
+	}
+	if cfg.Auth != nil && cfg.Auth.Type == gateway.AuthTypeAPIToken {
+		req.Header.Set("Authorization", "Bearer "+cfg.Auth.Token)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach gateway (is pilot start running?): %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("autopilot is not tracking PR #%d", prNumber)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("gateway returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+}
+
+// releasePRFromStateStore drops the PR's persisted autopilot state so a
+// daemon that is not running does not restore it on start.
+func releasePRFromStateStore(cfg *config.Config, prNumber int) bool {
+	if lookupPRState(cfg, prNumber) == nil {
+		return false
+	}
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = store.Close() }()
+
+	stateStore, err := autopilot.NewStateStore(store.DB())
+	if err != nil {
+		return false
+	}
+	return stateStore.RemovePRState(prNumber) == nil
+}
+
+// autopilotReleaseHandler serves DELETE /api/v1/autopilot/prs/{number}, which
+// stops autopilot tracking a PR without touching its branch. The repo query
+// parameter picks the controller; without it the default controller is used.
+func autopilotReleaseHandler(defaultController *autopilot.Controller, controllers map[string]*autopilot.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prNumber, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/v1/autopilot/prs/"))
+		if err != nil || prNumber <= 0 {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		controller := defaultController
+		if repo := r.URL.Query().Get("repo"); repo != "" {
+			controller = nil
+			for name, c := range controllers {
+				if strings.EqualFold(name, repo) {
+					controller = c
+					break
+				}
+			}
+		}
+		if controller == nil || !controller.Release(prNumber) {
+			http.Error(w, "PR not tracked", http.StatusNotFound)
+			return
+		}
+		logging.WithComponent("api").Info("PR released from autopilot via API", slog.Int("pr", prNumber))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"pr": prNumber, "status": "released"})
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/replay"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestParseTakeoverTarget(t *testing.T) {
+	tests := []struct {
+		arg       string
+		wantIssue int
+		wantPR    int
+		wantErr   bool
+	}{
+		{"GH-405", 405, 0, false},
+		{"gh-7", 7, 0, false},
+		{"412", 0, 412, false},
+		{"#412", 0, 412, false},
+		{"GH-", 0, 0, true},
+		{"GH-abc", 0, 0, true},
+		{"LIN-12", 0, 0, true},
+		{"0", 0, 0, true},
+	}
+	for _, tt := range tests {
+		issue, pr, err := parseTakeoverTarget(tt.arg)
+		if (err != nil) != tt.wantErr || issue != tt.wantIssue || pr != tt.wantPR {
+			t.Errorf("parseTakeoverTarget(%q) = (%d, %d, %v), want (%d, %d, err=%v)", tt.arg, issue, pr, err, tt.wantIssue, tt.wantPR, tt.wantErr)
+		}
+	}
+}
+
+func TestTakeoverComment(t *testing.T) {
+	got := takeoverComment("GH-405", "pilot/GH-405", nil)
+	if !strings.Contains(got, "`pilot/GH-405`") || !strings.Contains(got, "No execution recording was found for GH-405") {
+		t.Errorf("comment without recording = %q", got)
+	}
+
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(summaryPath, []byte("# Execution Recording Summary\n\n- `auth.go` (modify)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	recording := &replay.Recording{
+		ID:          "TG-1712345678",
+		TaskID:      "GH-405",
+		Status:      "failed",
+		Duration:    12*time.Minute + 300*time.Millisecond,
+		SummaryPath: summaryPath,
+		TokenUsage:  &replay.TokenUsage{EstimatedCostUSD: 1.234},
+	}
+	got = takeoverComment("GH-405", "pilot/GH-405", recording)
+	for _, want := range []string{"GH-405, failed, 12m0s, $1.23", "<details><summary>What Pilot attempted</summary>", "`auth.go` (modify)", "pilot replay show TG-1712345678"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("comment missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestReleasePRViaGateway(t *testing.T) {
+	var gotMethod, gotPath, gotRepo string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotRepo = r.Method, r.URL.Path, r.URL.Query().Get("repo")
+		if strings.HasSuffix(r.URL.Path, "/404") {
+			http.Error(w, "PR not tracked", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := gatewayConfigFor(t, server)
+	if err := releasePRViaGateway(cfg, "owner/repo", 412); err != nil {
+		t.Fatalf("releasePRViaGateway() = %v", err)
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/api/v1/autopilot/prs/412" || gotRepo != "owner/repo" {
+		t.Errorf("request = %s %s (repo %q)", gotMethod, gotPath, gotRepo)
+	}
+	if err := releasePRViaGateway(cfg, "owner/repo", 404); err == nil || !strings.Contains(err.Error(), "not tracking") {
+		t.Errorf("releasePRViaGateway() for untracked PR = %v", err)
+	}
+}
+
+func TestAutopilotReleaseHandler(t *testing.T) {
+	client := github.NewClient(testutil.FakeGitHubToken)
+	primary := autopilot.NewController(autopilot.DefaultConfig(), client, nil, "owner", "repo")
+	other := autopilot.NewController(autopilot.DefaultConfig(), client, nil, "owner", "other")
+	primary.OnPRCreated(1, "url", 10, "sha", "pilot/GH-10", "")
+	other.OnPRCreated(2, "url", 20, "sha", "pilot/GH-20", "")
+	handler := autopilotReleaseHandler(primary, map[string]*autopilot.Controller{"owner/repo": primary, "owner/other": other})
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "/api/v1/autopilot/prs/1", http.StatusMethodNotAllowed},
+		{"bad number", http.MethodDelete, "/api/v1/autopilot/prs/abc", http.StatusNotFound},
+		{"other repo's PR on default", http.MethodDelete, "/api/v1/autopilot/prs/2", http.StatusNotFound},
+		{"by repo", http.MethodDelete, "/api/v1/autopilot/prs/2?repo=Owner/Other", http.StatusOK},
+		{"default", http.MethodDelete, "/api/v1/autopilot/prs/1", http.StatusOK},
+		{"released already", http.MethodDelete, "/api/v1/autopilot/prs/1", http.StatusNotFound},
+		{"unknown repo", http.MethodDelete, "/api/v1/autopilot/prs/1?repo=owner/none", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(tt.method, tt.target, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+	if _, ok := other.GetPRState(2); ok {
+		t.Error("PR 2 should no longer be tracked")
+	}
+}
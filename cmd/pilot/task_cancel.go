@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+func newTaskCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <task-id>",
+		Short: "Cancel a task running in the Pilot daemon",
+		Long: `Cancel a queued or running task in the running Pilot daemon.
+
+The task's Claude Code process is killed and its worktree cleaned up. For
+GitHub issues the in-progress label is removed and a comment is posted on the
+issue; add the trigger label again to retry.
+
+Examples:
+  pilot task cancel GH-405
+  pilot task cancel TG-1712345678`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if err := cancelTaskViaGateway(cfg, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("🛑 Cancelling %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// cancelTaskViaGateway asks the running gateway to cancel taskID
+func cancelTaskViaGateway(cfg *config.Config, taskID string) error {
+	if cfg.Gateway == nil {
+		return fmt.Errorf("gateway not configured")
+	}
+	target := fmt.Sprintf("http://%s:%d/api/v1/tasks/%s", cfg.Gateway.Host, cfg.Gateway.Port, url.PathEscape(taskID))
+	req, err := http.NewRequest(http.MethodDelete, target, nil)
+	if err != nil {
+		return err
+	}
+	if cfg.Auth != nil && cfg.Auth.Type == gateway.AuthTypeAPIToken {
+		req.Header.Set("Authorization", "Bearer "+cfg.Auth.Token)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach gateway (is pilot start running?): %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("task %s is not queued or running", taskID)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("gateway returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+}
+
+// taskCancelHandler serves DELETE /api/v1/tasks/{id} for polling mode, where
+// every task runs on a single runner without the orchestrator.
+func taskCancelHandler(runner *executor.Runner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		taskID := strings.TrimPrefix(r.URL.Path, "/api/v1/tasks/")
+		if taskID == "" || strings.Contains(taskID, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := runner.Cancel(taskID); err != nil {
+			http.Error(w, "task not found", http.StatusNotFound)
+			return
+		}
+		logging.WithComponent("api").Info("Task cancelled via API", slog.String("task_id", taskID))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": taskID, "status": "cancelling"})
+	}
+}
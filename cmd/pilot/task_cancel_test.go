@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+// gatewayConfigFor points a config at a test server
+func gatewayConfigFor(t *testing.T, server *httptest.Server) *config.Config {
+	t.Helper()
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	p, _ := strconv.Atoi(port)
+	return &config.Config{Gateway: &gateway.Config{Host: host, Port: p}}
+}
+
+func TestCancelTaskViaGateway(t *testing.T) {
+	var gotMethod, gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotAuth = r.Method, r.URL.Path, r.Header.Get("Authorization")
+		if strings.HasSuffix(r.URL.Path, "/GH-404") {
+			http.Error(w, "task not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cfg := gatewayConfigFor(t, server)
+	cfg.Auth = &gateway.AuthConfig{Type: gateway.AuthTypeAPIToken, Token: "secret"}
+
+	if err := cancelTaskViaGateway(cfg, "GH-405"); err != nil {
+		t.Fatalf("cancelTaskViaGateway() = %v", err)
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/api/v1/tasks/GH-405" || gotAuth != "Bearer secret" {
+		t.Errorf("request = %s %s (auth %q)", gotMethod, gotPath, gotAuth)
+	}
+
+	err := cancelTaskViaGateway(cfg, "GH-404")
+	if err == nil || !strings.Contains(err.Error(), "not queued or running") {
+		t.Errorf("cancelTaskViaGateway() for unknown task = %v", err)
+	}
+	if err := cancelTaskViaGateway(&config.Config{}, "GH-405"); err == nil {
+		t.Error("cancelTaskViaGateway() without gateway should fail")
+	}
+}
+
+func TestTaskCancelHandler(t *testing.T) {
+	handler := taskCancelHandler(executor.NewRunner())
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{"not running", http.MethodDelete, "/api/v1/tasks/GH-1", http.StatusNotFound},
+		{"wrong method", http.MethodGet, "/api/v1/tasks/GH-1", http.StatusMethodNotAllowed},
+		{"missing id", http.MethodDelete, "/api/v1/tasks/", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(tt.method, tt.target, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+}
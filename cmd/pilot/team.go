@@ -1,16 +1,15 @@
 package main
 
 import (
-	"database/sql"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/teams"
 )
 
@@ -35,6 +34,7 @@ Teams allow multiple users to collaborate on Pilot with different permission lev
 		newTeamMemberCmd(),
 		newTeamProjectCmd(),
 		newTeamAuditCmd(),
+		newTeamLoginCmd(),
 	)
 
 	return cmd
@@ -267,6 +267,7 @@ func newTeamMemberCmd() *cobra.Command {
 		newTeamMemberAddCmd(),
 		newTeamMemberRemoveCmd(),
 		newTeamMemberRoleCmd(),
+		newTeamMemberUpdateCmd(),
 		newTeamMemberListCmd(),
 	)
 
@@ -439,6 +440,70 @@ func newTeamMemberRoleCmd() *cobra.Command {
 	return cmd
 }
 
+func newTeamMemberUpdateCmd() *cobra.Command {
+	var (
+		actorEmail  string
+		githubUser  string
+		telegramID  int64
+		slackUserID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [team-id] [member-email]",
+		Short: "Link a member to their GitHub, Telegram and Slack accounts",
+		Long: `Link a member to their chat and GitHub accounts. The mapping attributes
+tasks to the member and is where personal digests are delivered.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			teamID := args[0]
+			memberEmail := args[1]
+
+			if actorEmail == "" {
+				return fmt.Errorf("actor email required (use --as)")
+			}
+			if githubUser == "" && telegramID == 0 && slackUserID == "" {
+				return fmt.Errorf("nothing to update (use --github, --telegram or --slack)")
+			}
+
+			service, cleanup, err := getTeamService()
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			team, err := findTeam(service, teamID)
+			if err != nil {
+				return err
+			}
+
+			actor, err := service.GetMemberByEmail(team.ID, actorEmail)
+			if err != nil || actor == nil {
+				return fmt.Errorf("you are not a member of this team")
+			}
+
+			member, err := service.GetMemberByEmail(team.ID, memberEmail)
+			if err != nil || member == nil {
+				return fmt.Errorf("member not found: %s", memberEmail)
+			}
+
+			if err := service.UpdateMemberIdentity(team.ID, actor.ID, member.ID, githubUser, telegramID, slackUserID); err != nil {
+				return fmt.Errorf("failed to link accounts: %w", err)
+			}
+
+			fmt.Printf("✅ Linked accounts for %s\n", memberEmail)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&actorEmail, "as", "", "Your email (yourself, or a member with manage_members permission)")
+	cmd.Flags().StringVar(&githubUser, "github", "", "GitHub username")
+	cmd.Flags().Int64Var(&telegramID, "telegram", 0, "Telegram user ID")
+	cmd.Flags().StringVar(&slackUserID, "slack", "", "Slack user ID (e.g. U01ABCDEF)")
+	_ = cmd.MarkFlagRequired("as")
+
+	return cmd
+}
+
 func newTeamMemberListCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "list [team-id]",
@@ -737,8 +802,95 @@ func newTeamProjectListCmd() *cobra.Command {
 	}
 }
 
+func newTeamLoginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Verify your identity with the team's identity provider",
+		Long: `Log in to the identity provider configured under team.oidc.
+
+Your IdP account is linked to every team membership with the same email,
+and your IdP groups set your role. Pilot refreshes the login in the
+background; run this again when asked to.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := cfgFile
+			if configPath == "" {
+				configPath = config.DefaultConfigPath()
+			}
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			provider := teamOIDCProvider(cfg)
+			if provider == nil {
+				return fmt.Errorf("no identity provider configured (set team.oidc in config)")
+			}
+
+			ctx := cmd.Context()
+			auth, err := provider.StartDeviceAuth(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Println("🔐 To log in, open:")
+			if auth.VerificationURIComplete != "" {
+				fmt.Printf("   %s\n", auth.VerificationURIComplete)
+			} else {
+				fmt.Printf("   %s\n", auth.VerificationURI)
+			}
+			fmt.Printf("   and enter code: %s\n", auth.UserCode)
+			fmt.Println()
+			fmt.Println("Waiting for approval...")
+
+			tokens, err := provider.PollDeviceToken(ctx, auth)
+			if err != nil {
+				return fmt.Errorf("login failed: %w", err)
+			}
+
+			service, cleanup, err := getTeamService()
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			members, err := service.LoginOIDC(ctx, tokens)
+			if err != nil {
+				return fmt.Errorf("login failed: %w", err)
+			}
+
+			fmt.Println("✅ Identity verified")
+			fmt.Println()
+			for _, m := range members {
+				teamName := m.TeamID
+				if team, err := service.GetTeam(m.TeamID); err == nil && team != nil {
+					teamName = team.Name
+				}
+				fmt.Printf("   %-20s %s\n", teamName, m.Role)
+			}
+
+			return nil
+		},
+	}
+}
+
 // Helper functions
 
+// configureTeamService applies the identity provider and command
+// permission overrides from the team config
+func configureTeamService(service *teams.Service, cfg *config.Config) {
+	service.SetOIDC(teamOIDCProvider(cfg))
+	if cfg.Team != nil {
+		service.SetCommandRoles(cfg.Team.CommandRoles)
+	}
+}
+
+// teamOIDCProvider returns the identity provider from team.oidc, or nil
+// when none is configured
+func teamOIDCProvider(cfg *config.Config) *teams.OIDCProvider {
+	if cfg.Team == nil {
+		return nil
+	}
+	return teams.NewOIDCProvider(cfg.Team.OIDC)
+}
+
 func getTeamService() (*teams.Service, func(), error) {
 	configPath := cfgFile
 	if configPath == "" {
@@ -750,27 +902,24 @@ func getTeamService() (*teams.Service, func(), error) {
 		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Open database
-	dbPath := filepath.Join(cfg.Memory.Path, "pilot.db")
-	if err := os.MkdirAll(cfg.Memory.Path, 0755); err != nil {
-		return nil, nil, fmt.Errorf("failed to create data directory: %w", err)
-	}
-
-	db, err := sql.Open("sqlite", dbPath)
+	// Open database through the memory store, which creates the data
+	// directory and decrypts the database when encryption at rest is on
+	memStore, err := memory.NewStore(cfg.Memory.Path)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	store, err := teams.NewStore(db)
+	store, err := teams.NewStore(memStore.DB())
 	if err != nil {
-		_ = db.Close()
+		_ = memStore.Close()
 		return nil, nil, fmt.Errorf("failed to create team store: %w", err)
 	}
 
 	service := teams.NewService(store)
+	configureTeamService(service, cfg)
 
 	cleanup := func() {
-		_ = db.Close()
+		_ = memStore.Close()
 	}
 
 	return service, cleanup, nil
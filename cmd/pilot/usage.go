@@ -23,6 +23,7 @@ func newUsageCmd() *cobra.Command {
 		newUsageSummaryCmd(),
 		newUsageDailyCmd(),
 		newUsageProjectsCmd(),
+		newUsageTokensCmd(),
 		newUsageEventsCmd(),
 		newUsageExportCmd(),
 	)
@@ -263,6 +264,92 @@ func newUsageProjectsCmd() *cobra.Command {
 	return cmd
 }
 
+func newUsageTokensCmd() *cobra.Command {
+	var (
+		days    int
+		project string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tokens",
+		Short: "Show where tokens went by phase and tool",
+		Long: `Show token usage attributed to execution phases (research, implement,
+verify, quality-fix, review) and tool categories (read, edit, shell, ...).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = store.Close() }()
+
+			// Build query
+			end := time.Now()
+			query := memory.MetricsQuery{
+				Start: end.AddDate(0, 0, -days),
+				End:   end,
+			}
+			if project != "" {
+				query.Projects = []string{project}
+			}
+
+			rows, err := store.GetTokenAttribution(query)
+			if err != nil {
+				return fmt.Errorf("failed to get token attribution: %w", err)
+			}
+
+			if len(rows) == 0 {
+				fmt.Println("No token attribution found in the specified period.")
+				return nil
+			}
+
+			fmt.Println()
+			fmt.Printf("🔤 Token Attribution (Last %d Days)\n", days)
+			fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+			printTokenAttribution("📋 By Phase", rows, memory.TokenKindPhase)
+			printTokenAttribution("🔧 By Tool", rows, memory.TokenKindTool)
+			fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+			fmt.Println()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 30, "Number of days to include")
+	cmd.Flags().StringVar(&project, "project", "", "Filter by project path")
+
+	return cmd
+}
+
+// printTokenAttribution prints the rows of one kind with their share of
+// the kind's total
+func printTokenAttribution(title string, rows []*memory.TokenAttribution, kind string) {
+	var total int64
+	for _, r := range rows {
+		if r.Kind == kind {
+			total += r.TotalTokens()
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(title)
+	for _, r := range rows {
+		if r.Kind != kind {
+			continue
+		}
+		fmt.Printf("   %-12s %10s  %5.1f%%  (in %s / out %s)\n",
+			r.Name,
+			formatTokens(r.TotalTokens()),
+			float64(r.TotalTokens())/float64(total)*100,
+			formatTokensShort(r.InputTokens),
+			formatTokensShort(r.OutputTokens),
+		)
+	}
+	fmt.Println()
+}
+
 func newUsageEventsCmd() *cobra.Command {
 	var (
 		days      int
@@ -348,12 +435,34 @@ func newUsageExportCmd() *cobra.Command {
 		projectID string
 		format    string
 		output    string
+		groupBy   string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export usage data to JSON or CSV",
+		Long: `Export billable usage events, or with --group-by a chargeback report of
+execution costs per team member, issue label or project.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if groupBy != "" {
+				switch groupBy {
+				case memory.ChargebackByMember, memory.ChargebackByLabel, memory.ChargebackByProject:
+				default:
+					return fmt.Errorf("unsupported grouping: %s (use 'member', 'label' or 'project')", groupBy)
+				}
+				if userID != "" {
+					return fmt.Errorf("--user cannot be combined with --group-by")
+				}
+				n, err := exportChargeback(output, groupBy, format, projectID, days)
+				if err != nil {
+					return err
+				}
+				if output != "" && output != "-" {
+					fmt.Printf("Exported costs of %d %ss to %s\n", n, groupBy, output)
+				}
+				return nil
+			}
+
 			store, err := openStore()
 			if err != nil {
 				return err
@@ -450,6 +559,7 @@ func newUsageExportCmd() *cobra.Command {
 	cmd.Flags().StringVar(&projectID, "project", "", "Filter by project ID")
 	cmd.Flags().StringVar(&format, "format", "json", "Output format (json or csv)")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (- for stdout)")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Export execution costs grouped by member, label or project")
 
 	return cmd
 }
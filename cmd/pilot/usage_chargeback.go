@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+// chargebackReport is the exported cost of each member, label or project
+type chargebackReport struct {
+	GroupBy   string            `json:"group_by"`
+	Start     time.Time         `json:"start"`
+	End       time.Time         `json:"end"`
+	TotalCost float64           `json:"total_cost_usd"`
+	Rows      []*chargebackLine `json:"rows"`
+}
+
+// chargebackLine is one row of a chargeback report. Name and Team are only
+// set for members that resolve to a team member.
+type chargebackLine struct {
+	memory.ChargebackRow
+	Name  string  `json:"name,omitempty"`
+	Team  string  `json:"team,omitempty"`
+	Share float64 `json:"share_percent"`
+}
+
+// memberResolver looks up team members by ID
+type memberResolver interface {
+	GetMember(id string) (*teams.Member, error)
+	GetTeam(id string) (*teams.Team, error)
+}
+
+// buildChargebackReport resolves the rows' members and computes each row's
+// share of the total
+func buildChargebackReport(query memory.ChargebackQuery, rows []*memory.ChargebackRow, members memberResolver) *chargebackReport {
+	report := &chargebackReport{GroupBy: query.GroupBy, Start: query.Start, End: query.End}
+	for _, r := range rows {
+		report.TotalCost += r.CostUSD
+	}
+
+	teamNames := make(map[string]string)
+	for _, r := range rows {
+		line := &chargebackLine{ChargebackRow: *r}
+		if report.TotalCost > 0 {
+			line.Share = r.CostUSD / report.TotalCost * 100
+		}
+		if query.GroupBy == memory.ChargebackByMember && members != nil && r.Key != memory.ChargebackUnattributed {
+			if m, err := members.GetMember(r.Key); err == nil && m != nil {
+				line.Name = m.Email
+				if _, ok := teamNames[m.TeamID]; !ok {
+					if t, err := members.GetTeam(m.TeamID); err == nil && t != nil {
+						teamNames[m.TeamID] = t.Name
+					}
+				}
+				line.Team = teamNames[m.TeamID]
+			}
+		}
+		report.Rows = append(report.Rows, line)
+	}
+	return report
+}
+
+// pilotLabels returns the labels Pilot itself puts on issues, which are not
+// charged back
+func pilotLabels(cfg *config.Config) []string {
+	trigger := "pilot"
+	if cfg.Adapters != nil && cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.PilotLabel != "" {
+		trigger = cfg.Adapters.GitHub.PilotLabel
+	}
+	return []string{
+		trigger,
+		github.LabelInProgress,
+		github.LabelDone,
+		github.LabelFailed,
+		github.LabelRetryReady,
+		github.LabelBlocked,
+		github.LabelNeedsInfo,
+	}
+}
+
+// exportChargeback writes the chargeback report of the last days to output
+// in format, stdout when output is empty or "-"
+func exportChargeback(output, groupBy, format, project string, days int) (int, error) {
+	if format != "json" && format != "csv" {
+		return 0, fmt.Errorf("unsupported format: %s (use 'json' or 'csv')", format)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return 0, err
+	}
+	store, err := memory.NewStore(cfg.Memory.Path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open memory store: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	end := time.Now()
+	query := memory.ChargebackQuery{
+		Start:        end.AddDate(0, 0, -days),
+		End:          end,
+		GroupBy:      groupBy,
+		IgnoreLabels: pilotLabels(cfg),
+	}
+	if project != "" {
+		query.Projects = []string{project}
+	}
+
+	rows, err := store.GetChargeback(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get chargeback: %w", err)
+	}
+
+	var members memberResolver
+	if groupBy == memory.ChargebackByMember {
+		if ts, err := teams.NewStore(store.DB()); err == nil {
+			members = ts
+		}
+	}
+	report := buildChargebackReport(query, rows, members)
+
+	var w io.Writer = os.Stdout
+	if output != "" && output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return 0, fmt.Errorf("failed to write JSON: %w", err)
+		}
+	case "csv":
+		if err := writeChargebackCSV(w, report); err != nil {
+			return 0, err
+		}
+	}
+	return len(report.Rows), nil
+}
+
+// writeChargebackCSV writes one row per member, label or project
+func writeChargebackCSV(w io.Writer, report *chargebackReport) error {
+	csvWriter := csv.NewWriter(w)
+
+	header := []string{
+		report.GroupBy, "name", "team", "executions",
+		"tokens_input", "tokens_output", "tokens_total", "cost_usd", "share_percent",
+		"period_start", "period_end",
+	}
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, r := range report.Rows {
+		row := []string{
+			r.Key,
+			r.Name,
+			r.Team,
+			fmt.Sprintf("%d", r.Executions),
+			fmt.Sprintf("%d", r.TokensInput),
+			fmt.Sprintf("%d", r.TokensOutput),
+			fmt.Sprintf("%d", r.TokensTotal),
+			fmt.Sprintf("%.6f", r.CostUSD),
+			fmt.Sprintf("%.2f", r.Share),
+			report.Start.Format(time.RFC3339),
+			report.End.Format(time.RFC3339),
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
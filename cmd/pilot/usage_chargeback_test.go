@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+type fakeMembers map[string]*teams.Member
+
+func (f fakeMembers) GetMember(id string) (*teams.Member, error) {
+	if m, ok := f[id]; ok {
+		return m, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f fakeMembers) GetTeam(id string) (*teams.Team, error) {
+	return &teams.Team{ID: id, Name: "Team " + id}, nil
+}
+
+func TestBuildChargebackReport(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	query := memory.ChargebackQuery{Start: start, End: start.AddDate(0, 1, 0), GroupBy: memory.ChargebackByMember}
+	rows := []*memory.ChargebackRow{
+		{Key: "m-1", Executions: 3, TokensTotal: 9000, CostUSD: 7.5},
+		{Key: "m-gone", Executions: 1, CostUSD: 1.5},
+		{Key: memory.ChargebackUnattributed, Executions: 2, CostUSD: 1},
+	}
+	members := fakeMembers{"m-1": {ID: "m-1", TeamID: "payments", Email: "ana@example.com"}}
+
+	report := buildChargebackReport(query, rows, members)
+	if report.TotalCost != 10 {
+		t.Errorf("TotalCost = %v, want 10", report.TotalCost)
+	}
+	if r := report.Rows[0]; r.Name != "ana@example.com" || r.Team != "Team payments" || r.Share != 75 {
+		t.Errorf("resolved member = %+v", r)
+	}
+	if r := report.Rows[1]; r.Name != "" || r.Team != "" {
+		t.Errorf("unknown member should keep only its ID: %+v", r)
+	}
+
+	var buf bytes.Buffer
+	if err := writeChargebackCSV(&buf, report); err != nil {
+		t.Fatalf("writeChargebackCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d CSV lines, want header and 3 rows:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "member,name,team,executions,") {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "m-1,ana@example.com,Team payments,3,0,0,9000,7.500000,75.00,2026-03-01T00:00:00Z") {
+		t.Errorf("row = %q", lines[1])
+	}
+}
@@ -6,12 +6,17 @@ import (
 
 	"github.com/alekspetrov/pilot/internal/adapters/asana"
 	"github.com/alekspetrov/pilot/internal/adapters/azuredevops"
+	"github.com/alekspetrov/pilot/internal/adapters/clickup"
 	"github.com/alekspetrov/pilot/internal/adapters/discord"
+	"github.com/alekspetrov/pilot/internal/adapters/gitea"
+	"github.com/alekspetrov/pilot/internal/adapters/mattermost"
 	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/adapters/gitlab"
 	"github.com/alekspetrov/pilot/internal/adapters/jira"
 	"github.com/alekspetrov/pilot/internal/adapters/linear"
+	"github.com/alekspetrov/pilot/internal/adapters/notion"
 	"github.com/alekspetrov/pilot/internal/adapters/plane"
+	"github.com/alekspetrov/pilot/internal/adapters/trello"
 	"github.com/alekspetrov/pilot/internal/alerts"
 	"github.com/alekspetrov/pilot/internal/approval"
 	"github.com/alekspetrov/pilot/internal/config"
@@ -270,6 +275,136 @@ func TestPollerEnabled_Plane(t *testing.T) {
 	}
 }
 
+func TestPollerEnabled_Notion(t *testing.T) {
+	reg := notionPollerRegistration()
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		enabled bool
+	}{
+		{
+			name:    "nil config",
+			cfg:     &config.Config{Adapters: &config.AdaptersConfig{}},
+			enabled: false,
+		},
+		{
+			name: "enabled without polling",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Notion: &notion.Config{Enabled: true},
+			}},
+			enabled: false,
+		},
+		{
+			name: "fully enabled",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Notion: &notion.Config{
+					Enabled:    true,
+					APIKey:     testutil.FakeNotionAPIKey,
+					DatabaseID: "db-1",
+					Polling:    &notion.PollingConfig{Enabled: true},
+				},
+			}},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reg.Enabled(tt.cfg); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+		})
+	}
+}
+
+func TestPollerEnabled_Trello(t *testing.T) {
+	reg := trelloPollerRegistration()
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		enabled bool
+	}{
+		{
+			name:    "nil config",
+			cfg:     &config.Config{Adapters: &config.AdaptersConfig{}},
+			enabled: false,
+		},
+		{
+			name: "enabled without polling",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Trello: &trello.Config{Enabled: true},
+			}},
+			enabled: false,
+		},
+		{
+			name: "fully enabled",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Trello: &trello.Config{
+					Enabled: true,
+					APIKey:  testutil.FakeTrelloAPIKey,
+					Token:   testutil.FakeTrelloToken,
+					ListID:  "list-1",
+					Polling: &trello.PollingConfig{Enabled: true},
+				},
+			}},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reg.Enabled(tt.cfg); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+		})
+	}
+}
+
+func TestPollerEnabled_ClickUp(t *testing.T) {
+	reg := clickupPollerRegistration()
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		enabled bool
+	}{
+		{
+			name:    "nil config",
+			cfg:     &config.Config{Adapters: &config.AdaptersConfig{}},
+			enabled: false,
+		},
+		{
+			name: "enabled without polling",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				ClickUp: &clickup.Config{Enabled: true},
+			}},
+			enabled: false,
+		},
+		{
+			name: "fully enabled",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				ClickUp: &clickup.Config{
+					Enabled:  true,
+					APIToken: testutil.FakeClickUpToken,
+					ListID:   "901",
+					Polling:  &clickup.PollingConfig{Enabled: true},
+				},
+			}},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reg.Enabled(tt.cfg); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+		})
+	}
+}
+
 func TestPollerEnabled_Discord(t *testing.T) {
 	reg := discordPollerRegistration()
 
@@ -311,6 +446,48 @@ func TestPollerEnabled_Discord(t *testing.T) {
 	}
 }
 
+func TestPollerEnabled_Mattermost(t *testing.T) {
+	reg := mattermostPollerRegistration()
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		enabled bool
+	}{
+		{
+			name:    "nil config",
+			cfg:     &config.Config{Adapters: &config.AdaptersConfig{}},
+			enabled: false,
+		},
+		{
+			name: "enabled without server URL",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Mattermost: &mattermost.Config{Enabled: true, BotToken: testutil.FakeMattermostToken},
+			}},
+			enabled: false,
+		},
+		{
+			name: "enabled with URL and token",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Mattermost: &mattermost.Config{
+					Enabled:  true,
+					URL:      "https://mattermost.test",
+					BotToken: testutil.FakeMattermostToken,
+				},
+			}},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reg.Enabled(tt.cfg); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+		})
+	}
+}
+
 func TestPollerEnabled_GitLab(t *testing.T) {
 	reg := gitlabPollerRegistration()
 
@@ -354,6 +531,60 @@ func TestPollerEnabled_GitLab(t *testing.T) {
 	}
 }
 
+func TestPollerEnabled_Gitea(t *testing.T) {
+	reg := giteaPollerRegistration()
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		enabled bool
+	}{
+		{
+			name:    "nil config",
+			cfg:     &config.Config{Adapters: &config.AdaptersConfig{}},
+			enabled: false,
+		},
+		{
+			name: "enabled without polling",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Gitea: &gitea.Config{Enabled: true},
+			}},
+			enabled: false,
+		},
+		{
+			name: "polling disabled",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Gitea: &gitea.Config{
+					Enabled: true,
+					Polling: &gitea.PollingConfig{Enabled: false},
+				},
+			}},
+			enabled: false,
+		},
+		{
+			name: "fully enabled",
+			cfg: &config.Config{Adapters: &config.AdaptersConfig{
+				Gitea: &gitea.Config{
+					Enabled: true,
+					Token:   testutil.FakeGiteaToken,
+					BaseURL: "https://gitea.test",
+					Repo:    "owner/repo",
+					Polling: &gitea.PollingConfig{Enabled: true},
+				},
+			}},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reg.Enabled(tt.cfg); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // GH-2134: getAlertsConfig wiring tests
 // =============================================================================
@@ -571,6 +802,11 @@ func TestPollerEnabled_MultipleAdaptersSimultaneously(t *testing.T) {
 		"asana":       false,
 		"azuredevops": false,
 		"plane":       false,
+		"notion":      false,
+		"trello":      false,
+		"clickup":     false,
+		"mattermost":  false,
+		"gitea":       false,
 	}
 
 	for _, reg := range regs {
@@ -290,6 +290,35 @@ func TestConvertTaskStatesToDisplay(t *testing.T) {
 	}
 }
 
+func TestConvertTaskStatesToDisplay_Step(t *testing.T) {
+	states := []*executor.TaskState{
+		{ID: "GH-1", Status: executor.StatusRunning, Phase: executor.PhaseEditing, Message: "EDIT auth.go"},
+		{ID: "GH-2", Status: executor.StatusRunning, Phase: "Implementing", Message: "Implementing..."},
+	}
+
+	displays := convertTaskStatesToDisplay(states)
+	if displays[0].Step != "EDIT auth.go" {
+		t.Errorf("display[0].Step = %q, want step from signal", displays[0].Step)
+	}
+	if displays[1].Step != "" {
+		t.Errorf("display[1].Step = %q, want empty for coarse phase", displays[1].Step)
+	}
+}
+
+func TestConvertTaskStatesToDisplay_Blocked(t *testing.T) {
+	states := []*executor.TaskState{
+		{ID: "GH-1", Status: executor.StatusBlocked, Phase: executor.PhaseBlocked, Error: "missing STRIPE_KEY"},
+	}
+
+	displays := convertTaskStatesToDisplay(states)
+	if displays[0].Status != "blocked" {
+		t.Errorf("display[0].Status = %q, want blocked", displays[0].Status)
+	}
+	if displays[0].Step != "waiting for reply: missing STRIPE_KEY" {
+		t.Errorf("display[0].Step = %q", displays[0].Step)
+	}
+}
+
 func TestConvertTaskStatesToDisplay_Dedup(t *testing.T) {
 	// GH-1220: Duplicate task IDs should be deduplicated
 	states := []*executor.TaskState{
@@ -17,5 +17,6 @@ export default {
   "self-improvement": "Self-Improvement",
   "evaluation-system": "Evaluation System",
   "self-healing": "Self-Healing",
-  hooks: "Claude Code Hooks"
+  hooks: "Claude Code Hooks",
+  "scripting-hooks": "Scripting Hooks"
 }
@@ -1,5 +1,6 @@
 export default {
   "tunnel-setup": "Tunnel Setup",
   "multi-repo": "Multi-Repo Polling",
+  "github-actions": "GitHub Actions",
   troubleshooting: "Troubleshooting"
 }
@@ -1,12 +1,17 @@
 export default {
   github: "GitHub",
   gitlab: "GitLab",
+  gitea: "Gitea",
   "azure-devops": "Azure DevOps",
   linear: "Linear",
   jira: "Jira",
   asana: "Asana",
   plane: "Plane",
+  notion: "Notion",
+  trello: "Trello",
+  clickup: "ClickUp",
   slack: "Slack",
   discord: "Discord",
+  mattermost: "Mattermost",
   telegram: "Telegram"
 }
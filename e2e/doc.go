@@ -19,8 +19,11 @@
 // # Test Structure
 //
 //   - workflow_test.go: Main E2E workflow tests
+//   - scenario_test.go: Budget, approval, CI and release scenarios
 //   - mocks/github.go: Mock GitHub API server
 //   - mocks/claude.go: Mock Claude Code executable
+//   - scenario: Composable scenario builders, usable outside this repo to
+//     check a Pilot configuration against the real state machine
 //
 // # Running E2E Tests
 //
@@ -13,6 +13,9 @@ import (
 	"github.com/alekspetrov/pilot/internal/adapters/github"
 )
 
+// MainBranchSHA is the head commit the mock reports for every branch.
+const MainBranchSHA = "mainsha123"
+
 // GitHubMock provides a mock GitHub API server for E2E testing.
 // It tracks state across requests to simulate real GitHub behavior.
 type GitHubMock struct {
@@ -21,6 +24,10 @@ type GitHubMock struct {
 	issues      map[int]*github.Issue
 	prs         map[int]*github.PullRequest
 	checkRuns   map[string]*github.CheckRunsResponse // keyed by SHA
+	ciSequence  map[string][]*github.CheckRunsResponse
+	failures    map[string]int // remaining failures, keyed by path fragment
+	commits     map[int][]*github.Commit
+	tags        []*github.Tag
 	nextIssue   int
 	nextPR      int
 	nextComment int
@@ -31,6 +38,7 @@ type GitHubMock struct {
 	OnPRCreated         func(pr *github.PullRequest)
 	OnPRMerged          func(prNum int)
 	OnCommentCreated    func(issueNum int, body string)
+	OnTagCreated        func(tag, sha string)
 }
 
 // NewGitHubMock creates a new mock GitHub API server.
@@ -39,6 +47,9 @@ func NewGitHubMock() *GitHubMock {
 		issues:      make(map[int]*github.Issue),
 		prs:         make(map[int]*github.PullRequest),
 		checkRuns:   make(map[string]*github.CheckRunsResponse),
+		ciSequence:  make(map[string][]*github.CheckRunsResponse),
+		failures:    make(map[string]int),
+		commits:     make(map[int][]*github.Commit),
 		nextIssue:   1,
 		nextPR:      1,
 		nextComment: 1,
@@ -128,6 +139,7 @@ func (m *GitHubMock) SetCIStatus(sha string, checks []github.CheckRun) {
 		TotalCount: len(checks),
 		CheckRuns:  checks,
 	}
+	delete(m.ciSequence, sha)
 }
 
 // SetCIPassing sets all required checks as passing for a SHA.
@@ -161,6 +173,67 @@ func (m *GitHubMock) SetCIFailing(sha string, failingCheck string, passingChecks
 	m.SetCIStatus(sha, checks)
 }
 
+// SetCISequence sets the CI check statuses reported for a SHA on successive
+// polls. Each check-runs request consumes the next entry; the last entry
+// keeps being reported. Use it to simulate checks that queue, run and
+// complete while autopilot is polling.
+func (m *GitHubMock) SetCISequence(sha string, steps ...[]github.CheckRun) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seq := make([]*github.CheckRunsResponse, len(steps))
+	for i, checks := range steps {
+		seq[i] = &github.CheckRunsResponse{TotalCount: len(checks), CheckRuns: checks}
+	}
+	m.ciSequence[sha] = seq
+	delete(m.checkRuns, sha)
+}
+
+// FailRequests makes the next n requests whose path contains pathFragment
+// fail with 502 Bad Gateway, simulating transient GitHub API errors.
+func (m *GitHubMock) FailRequests(pathFragment string, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[pathFragment] = n
+}
+
+// SetPRCommits sets the commits listed for a PR. Release version bumps are
+// detected from their conventional commit messages.
+func (m *GitHubMock) SetPRCommits(prNum int, messages ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	commits := make([]*github.Commit, len(messages))
+	for i, msg := range messages {
+		c := &github.Commit{SHA: "commit" + strconv.Itoa(prNum) + strconv.Itoa(i)}
+		c.Commit.Message = msg
+		commits[i] = c
+	}
+	m.commits[prNum] = commits
+}
+
+// AddTag adds an existing tag pointing at sha.
+func (m *GitHubMock) AddTag(name, sha string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.addTagLocked(name, sha)
+}
+
+// GetTags returns the names of all tags, newest first.
+func (m *GitHubMock) GetTags() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, len(m.tags))
+	for i, t := range m.tags {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func (m *GitHubMock) addTagLocked(name, sha string) {
+	tag := &github.Tag{Name: name}
+	tag.Commit.SHA = sha
+	m.tags = append([]*github.Tag{tag}, m.tags...)
+}
+
 // GetPR retrieves a PR by number.
 func (m *GitHubMock) GetPR(num int) *github.PullRequest {
 	m.mu.RLock()
@@ -186,6 +259,12 @@ func (m *GitHubMock) GetOpenPRs() []*github.PullRequest {
 func (m *GitHubMock) handleRequest(w http.ResponseWriter, r *http.Request) {
 	path := r.URL.Path
 
+	if m.consumeFailure(path) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"message":"Bad Gateway"}`))
+		return
+	}
+
 	// Route based on path pattern
 	switch {
 	// GET /repos/{owner}/{repo}/issues
@@ -220,6 +299,10 @@ func (m *GitHubMock) handleRequest(w http.ResponseWriter, r *http.Request) {
 	case r.Method == "GET" && strings.HasSuffix(path, "/pulls") && !strings.Contains(path, "/pulls/"):
 		m.handleListPRs(w, r)
 
+	// GET /repos/{owner}/{repo}/pulls/{number}/commits
+	case r.Method == "GET" && strings.Contains(path, "/pulls/") && strings.HasSuffix(path, "/commits"):
+		m.handleListPRCommits(w, r)
+
 	// GET /repos/{owner}/{repo}/pulls/{number}
 	case r.Method == "GET" && strings.Contains(path, "/pulls/") && !strings.Contains(path, "/merge"):
 		m.handleGetPR(w, r)
@@ -244,6 +327,19 @@ func (m *GitHubMock) handleRequest(w http.ResponseWriter, r *http.Request) {
 	case r.Method == "GET" && strings.Contains(path, "/branches/"):
 		m.handleGetBranch(w, r)
 
+	// GET /repos/{owner}/{repo}/tags
+	case r.Method == "GET" && strings.HasSuffix(path, "/tags"):
+		m.handleListTags(w, r)
+
+	// POST /repos/{owner}/{repo}/git/refs
+	case r.Method == "POST" && strings.HasSuffix(path, "/git/refs"):
+		m.handleCreateRef(w, r)
+
+	// GET /repos/{owner}/{repo}/releases/latest
+	case r.Method == "GET" && strings.HasSuffix(path, "/releases/latest"):
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+
 	default:
 		w.WriteHeader(http.StatusOK)
 	}
@@ -555,9 +651,15 @@ func (m *GitHubMock) handleMergePR(w http.ResponseWriter, r *http.Request) {
 func (m *GitHubMock) handleGetCheckRuns(w http.ResponseWriter, r *http.Request) {
 	sha := m.extractSHA(r.URL.Path)
 
-	m.mu.RLock()
+	m.mu.Lock()
 	checks, ok := m.checkRuns[sha]
-	m.mu.RUnlock()
+	if seq := m.ciSequence[sha]; len(seq) > 0 {
+		checks, ok = seq[0], true
+		if len(seq) > 1 {
+			m.ciSequence[sha] = seq[1:]
+		}
+	}
+	m.mu.Unlock()
 
 	if !ok {
 		// Return empty checks
@@ -572,12 +674,77 @@ func (m *GitHubMock) handleGetBranch(w http.ResponseWriter, r *http.Request) {
 	// Return a simple branch response
 	resp := github.Branch{
 		Name:   "main",
-		Commit: github.BranchCommit{SHA: "mainsha123"},
+		Commit: github.BranchCommit{SHA: MainBranchSHA},
 	}
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+func (m *GitHubMock) handleListPRCommits(w http.ResponseWriter, r *http.Request) {
+	num := m.extractPRNumber(r.URL.Path)
+
+	m.mu.RLock()
+	commits := m.commits[num]
+	m.mu.RUnlock()
+
+	if commits == nil {
+		commits = []*github.Commit{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(commits)
+}
+
+func (m *GitHubMock) handleListTags(w http.ResponseWriter, r *http.Request) {
+	m.mu.RLock()
+	tags := append([]*github.Tag{}, m.tags...)
+	m.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tags)
+}
+
+func (m *GitHubMock) handleCreateRef(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	name, isTag := strings.CutPrefix(req.Ref, "refs/tags/")
+	m.mu.Lock()
+	if isTag {
+		m.addTagLocked(name, req.SHA)
+		if m.OnTagCreated != nil {
+			m.OnTagCreated(name, req.SHA)
+		}
+	}
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"ref":    req.Ref,
+		"object": map[string]string{"sha": req.SHA},
+	})
+}
+
+// consumeFailure reports whether the request should fail, counting down the
+// matching FailRequests budget.
+func (m *GitHubMock) consumeFailure(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for fragment, n := range m.failures {
+		if n > 0 && strings.Contains(path, fragment) {
+			m.failures[fragment] = n - 1
+			return true
+		}
+	}
+	return false
+}
+
 // Helper methods to extract IDs from paths
 
 func (m *GitHubMock) extractIssueNumber(path string) int {
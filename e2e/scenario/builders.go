@@ -0,0 +1,170 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/budget"
+)
+
+// QueueAdapter is the source adapter of tasks run by BudgetExhaustionMidQueue
+const QueueAdapter = "github"
+
+// BudgetExhaustionMidQueue runs a queue of tasks with the given costs while
+// budget enforcement is on. Tasks run and merge until the day's spend
+// reaches the daily limit; every task after that is blocked and its issue
+// stays open. A zero dailyLimit keeps the configured limit. Without a
+// configured daily action, exceeding the limit pauses new tasks.
+func BudgetExhaustionMidQueue(dailyLimit float64, costs ...float64) Builder {
+	return Builder{
+		Configure: func(cfg *Config) {
+			cfg.Budget.Enabled = true
+			if dailyLimit > 0 {
+				cfg.Budget.DailyLimit = dailyLimit
+			}
+			if cfg.Budget.OnExceed.Daily == "" {
+				cfg.Budget.OnExceed.Daily = budget.ActionPause
+			}
+		},
+		Steps: []Step{
+			RunQueue(QueueAdapter, costs...),
+			{
+				Name: "expect queue to stop at the budget",
+				Run: func(ctx context.Context, h *Harness) error {
+					ran, blocked := expectedQueue(h.Config.Budget, h.Usage.Total(), h.Tasks())
+					return ExpectQueue(ran, blocked).Run(ctx, h)
+				},
+			},
+		},
+	}
+}
+
+// expectedQueue replays the queue against the budget: a task runs while the
+// spend before it is below the daily and monthly limits.
+func expectedQueue(cfg *budget.Config, total float64, tasks []*Task) (ran, blocked int) {
+	spent := total
+	for _, task := range tasks {
+		spent -= task.Cost
+	}
+	for _, task := range tasks {
+		if spent >= cfg.DailyLimit || spent >= cfg.MonthlyLimit {
+			blocked++
+			continue
+		}
+		ran++
+		spent += task.Cost
+	}
+	return ran, blocked
+}
+
+// ApprovalTimeout runs a production merge whose pre-merge approval is never
+// answered. After timeout the stage's default action decides: rejected
+// (the default) fails the PR unmerged, approved merges it.
+func ApprovalTimeout(timeout time.Duration) Builder {
+	return Builder{
+		Configure: func(cfg *Config) {
+			_ = cfg.Autopilot.SetActiveEnvironment(string(autopilot.EnvProd))
+			stage := approval.StageConfig{}
+			if cfg.Approval.PreMerge != nil {
+				stage = *cfg.Approval.PreMerge
+			}
+			stage.Enabled = true
+			stage.Timeout = timeout
+			if stage.DefaultAction == "" {
+				stage.DefaultAction = approval.DecisionRejected
+			}
+			cfg.Approval.Enabled = true
+			cfg.Approval.PreMerge = &stage
+		},
+		Steps: []Step{
+			IgnoreApprovals(),
+			OpenPR("Change awaiting approval"),
+			CI(CIPassed),
+			AdvanceTo(autopilot.StageAwaitApproval),
+			{
+				Name: "wait for the approval to time out",
+				Run: func(ctx context.Context, h *Harness) error {
+					pr := h.LastPR()
+					return h.Advance(ctx, pr, func(state *autopilot.PRState) bool {
+						return state == nil || state.Stage != autopilot.StageAwaitApproval
+					})
+				},
+			},
+			ExpectApprovalRequests(1),
+			{
+				Name: "expect the default action",
+				Run: func(ctx context.Context, h *Harness) error {
+					if h.Config.Approval.PreMerge.DefaultAction == approval.DecisionApproved {
+						return ExpectMerged(true).Run(ctx, h)
+					}
+					if err := ExpectStage(autopilot.StageFailed).Run(ctx, h); err != nil {
+						return err
+					}
+					return ExpectMerged(false).Run(ctx, h)
+				},
+			},
+		},
+	}
+}
+
+// CIFlakeThenPass opens a PR whose CI reporting flakes: the check-runs API
+// fails for the first apiErrors polls (autopilot gives up at 5), then the
+// checks queue, run and pass. The PR must merge without a fix issue.
+func CIFlakeThenPass(apiErrors int) Builder {
+	return Builder{
+		Steps: []Step{
+			OpenPR("Change with flaky CI"),
+			CIUnavailable(apiErrors),
+			CI(CIQueued, CIRunning, CIPassed),
+			MainCI(CIPassed),
+			AdvanceTo(autopilot.StageCIPassed),
+			Complete(),
+			ExpectMerged(true),
+			{
+				Name: "expect no fix issue",
+				Run: func(ctx context.Context, h *Harness) error {
+					for _, stage := range h.Stages(h.LastPR()) {
+						if stage == autopilot.StageCIFailed {
+							return fmt.Errorf("PR went through %s: %v", stage, h.Stages(h.LastPR()))
+						}
+					}
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// ReleaseAfterMerge enables on-merge releases and merges a PR with the
+// given commits on top of the current release tag. The release must tag
+// want, e.g. "v1.3.0" after "v1.2.0" and a "feat:" commit. An empty current
+// starts from no releases.
+func ReleaseAfterMerge(current, want string, commits ...string) Builder {
+	steps := []Step{
+		OpenPR("Change to release"),
+		PRCommits(commits...),
+		CI(CIPassed),
+		MainCI(CIPassed),
+		Complete(),
+		ExpectMerged(true),
+		ExpectTag(want),
+	}
+	if current != "" {
+		steps = append([]Step{ExistingTag(current)}, steps...)
+	}
+	return Builder{
+		Configure: func(cfg *Config) {
+			rel := autopilot.ReleaseConfig{TagPrefix: "v"}
+			if cfg.Autopilot.Release != nil {
+				rel = *cfg.Autopilot.Release
+			}
+			rel.Enabled = true
+			rel.Trigger = "on_merge"
+			cfg.Autopilot.Release = &rel
+		},
+		Steps: steps,
+	}
+}
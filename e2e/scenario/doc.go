@@ -0,0 +1,29 @@
+// Package scenario builds end-to-end scenarios that run Pilot's real
+// autopilot state machine, budget enforcer and approval manager against a
+// mock GitHub API.
+//
+// A scenario is a configuration plus a list of steps. Builders contribute
+// both and compose: the built-in builders cover common interactions and can
+// be combined with each other and with custom steps.
+//
+//   - BudgetExhaustionMidQueue: tasks are admitted until the daily budget is spent
+//   - ApprovalTimeout: a production merge waits for approval that never comes
+//   - CIFlakeThenPass: CI reporting flakes before the checks pass
+//   - ReleaseAfterMerge: a merged PR is tagged with the next version
+//
+// Example usage:
+//
+//	func TestMyConfig(t *testing.T) {
+//		cfg, err := scenario.LoadConfig("pilot.yaml")
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		scenario.New("release under budget", cfg,
+//			scenario.BudgetExhaustionMidQueue(10, 4, 4, 4),
+//			scenario.ReleaseAfterMerge("v1.2.0", "v1.3.0", "feat: add export"),
+//		).Run(t)
+//	}
+//
+// Scenarios drive the controller synchronously, one ProcessPR call per
+// poll, so they run in milliseconds and do not depend on timing.
+package scenario
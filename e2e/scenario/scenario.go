@@ -0,0 +1,397 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/e2e/mocks"
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/budget"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// Owner and Repo name the repository scenarios run against
+const (
+	Owner = "owner"
+	Repo  = "repo"
+)
+
+// maxPolls bounds how often a step processes a PR waiting for a stage
+const maxPolls = 50
+
+// Config holds the Pilot configuration a scenario runs with.
+type Config struct {
+	Autopilot *autopilot.Config
+	Budget    *budget.Config
+	Approval  *approval.Config
+}
+
+// DefaultConfig returns a dev environment configuration that requires the
+// "build" and "test" checks, with budget enforcement and approvals disabled.
+func DefaultConfig() *Config {
+	cfg := &Config{
+		Autopilot: autopilot.DefaultConfig(),
+		Budget:    budget.DefaultConfig(),
+		Approval:  approval.DefaultConfig(),
+	}
+	cfg.Autopilot.Enabled = true
+	cfg.Autopilot.Environment = autopilot.EnvDev
+	cfg.Autopilot.AutoReview = false
+	cfg.Autopilot.CIChecks = &autopilot.CIChecksConfig{Mode: "manual", Required: []string{"build", "test"}}
+	fastPolling(cfg.Autopilot)
+	return cfg
+}
+
+// LoadConfig reads the autopilot, budget and approval sections of a Pilot
+// config file, so a scenario runs against the configuration as deployed.
+// Sections the file leaves out get Pilot's defaults. Poll intervals are
+// shortened so scenarios run fast.
+func LoadConfig(path string) (*Config, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	loaded, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultConfig()
+	if loaded.Orchestrator != nil && loaded.Orchestrator.Autopilot != nil {
+		cfg.Autopilot = loaded.Orchestrator.Autopilot
+		fastPolling(cfg.Autopilot)
+	}
+	if loaded.Budget != nil {
+		cfg.Budget = loaded.Budget
+	}
+	if loaded.Approval != nil {
+		cfg.Approval = loaded.Approval
+	}
+	return cfg, nil
+}
+
+func fastPolling(cfg *autopilot.Config) {
+	cfg.CIPollInterval = 10 * time.Millisecond
+	if cfg.CIChecks != nil {
+		cfg.CIChecks.DiscoveryGracePeriod = 10 * time.Millisecond
+	}
+}
+
+// Step is one action or assertion of a scenario.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context, h *Harness) error
+}
+
+// Builder contributes configuration and steps to a scenario. New applies
+// builders in order: each Configure sees the changes of the builders before
+// it, and steps run in the order they were added.
+type Builder struct {
+	Configure func(cfg *Config)
+	Steps     []Step
+}
+
+// Steps returns a builder that only adds steps.
+func Steps(steps ...Step) Builder {
+	return Builder{Steps: steps}
+}
+
+// Scenario is a configuration and the steps run against it.
+type Scenario struct {
+	Name   string
+	Config *Config
+	Steps  []Step
+}
+
+// New creates a scenario from cfg and the given builders. A nil cfg uses
+// DefaultConfig. Builders change cfg in place.
+func New(name string, cfg *Config, builders ...Builder) *Scenario {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	s := &Scenario{Name: name, Config: cfg}
+	for _, b := range builders {
+		if b.Configure != nil {
+			b.Configure(cfg)
+		}
+		s.Steps = append(s.Steps, b.Steps...)
+	}
+	return s
+}
+
+// Then appends steps to the scenario.
+func (s *Scenario) Then(steps ...Step) *Scenario {
+	s.Steps = append(s.Steps, steps...)
+	return s
+}
+
+// Run executes the scenario as part of a test, failing it at the first step
+// that returns an error. The harness is closed when the test ends.
+func (s *Scenario) Run(t testing.TB) *Harness {
+	t.Helper()
+	h, err := s.Execute(context.Background())
+	if h != nil {
+		t.Cleanup(h.Close)
+	}
+	if err != nil {
+		t.Fatalf("scenario %q: %v", s.Name, err)
+	}
+	return h
+}
+
+// Execute runs the steps in order and stops at the first error. The returned
+// harness must be closed by the caller, also when an error is returned.
+func (s *Scenario) Execute(ctx context.Context) (*Harness, error) {
+	h := newHarness(s.Config)
+	for i, step := range s.Steps {
+		if err := step.Run(ctx, h); err != nil {
+			return h, fmt.Errorf("step %d (%s): %w", i+1, step.Name, err)
+		}
+	}
+	return h, nil
+}
+
+// Task is a queued issue and what became of it.
+type Task struct {
+	Issue   int
+	PR      int     // 0 when the task was blocked
+	Cost    float64 // Spend recorded when the task ran
+	Blocked string  // Budget reason the task was not started
+}
+
+// Harness is the environment scenario steps run in: a mock GitHub API and
+// the real autopilot controller, budget enforcer and approval manager
+// wired to it.
+type Harness struct {
+	GitHub     *mocks.GitHubMock
+	Client     *github.Client
+	Controller *autopilot.Controller
+	Budget     *budget.Enforcer
+	Approvals  *approval.Manager
+	Usage      *Usage
+	Config     *Config
+
+	approver *approver
+
+	mu     sync.Mutex
+	prs    []int
+	shas   map[int]string
+	stages map[int][]autopilot.PRStage
+	tasks  []*Task
+}
+
+func newHarness(cfg *Config) *Harness {
+	gh := mocks.NewGitHubMock()
+	client := github.NewClientWithBaseURL(testutil.FakeGitHubToken, gh.URL())
+
+	h := &Harness{
+		GitHub:    gh,
+		Client:    client,
+		Usage:     NewUsage(),
+		Config:    cfg,
+		Approvals: approval.NewManager(cfg.Approval),
+		approver:  &approver{},
+		shas:      make(map[int]string),
+		stages:    make(map[int][]autopilot.PRStage),
+	}
+	h.Approvals.RegisterHandler(h.approver)
+	h.Budget = budget.NewEnforcer(cfg.Budget, h.Usage)
+	h.Controller = autopilot.NewController(cfg.Autopilot, client, h.Approvals, Owner, Repo)
+	h.Controller.AddStageHook(func(prState *autopilot.PRState, from autopilot.PRStage) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.stages[prState.PRNumber] = append(h.stages[prState.PRNumber], prState.Stage)
+	})
+	return h
+}
+
+// Close shuts down the mock GitHub API.
+func (h *Harness) Close() {
+	h.GitHub.Close()
+}
+
+// OpenPR creates an issue and a PR for it, and hands the PR to autopilot.
+// Returns the PR number; its head SHA is "sha-<number>".
+func (h *Harness) OpenPR(title string) int {
+	return h.openPR(h.GitHub.CreateIssue(title, title, []string{"pilot"}))
+}
+
+func (h *Harness) openPR(issue *github.Issue) int {
+	title := issue.Title
+
+	h.mu.Lock()
+	number := len(h.prs) + 1
+	sha := fmt.Sprintf("sha-%d", number)
+	h.prs = append(h.prs, number)
+	h.shas[number] = sha
+	h.mu.Unlock()
+
+	branch := fmt.Sprintf("pilot/GH-%d", issue.Number)
+	pr := h.GitHub.CreatePR(number, title, branch, sha)
+	h.Controller.OnPRCreated(number, pr.HTMLURL, issue.Number, sha, branch, "")
+	return number
+}
+
+// LastPR returns the number of the most recently opened PR, or 0.
+func (h *Harness) LastPR() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.prs) == 0 {
+		return 0
+	}
+	return h.prs[len(h.prs)-1]
+}
+
+// HeadSHA returns the head SHA of a PR opened by the harness.
+func (h *Harness) HeadSHA(pr int) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.shas[pr]
+}
+
+// Stages returns the stages a PR moved through, in order.
+func (h *Harness) Stages(pr int) []autopilot.PRStage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]autopilot.PRStage{}, h.stages[pr]...)
+}
+
+// Tasks returns the tasks run through the queue so far.
+func (h *Harness) Tasks() []*Task {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*Task{}, h.tasks...)
+}
+
+// Advance processes a PR until done returns true for its state, or until
+// autopilot stops tracking it (done receives nil then). Returns an error
+// when the PR does not get there within maxPolls polls.
+func (h *Harness) Advance(ctx context.Context, pr int, done func(*autopilot.PRState) bool) error {
+	var lastErr error
+	for i := 0; i < maxPolls; i++ {
+		state, ok := h.Controller.GetPRState(pr)
+		if !ok {
+			state = nil
+		}
+		if done(state) {
+			return nil
+		}
+		if state == nil {
+			return fmt.Errorf("PR #%d is no longer tracked (stages: %v)", pr, h.Stages(pr))
+		}
+		if err := h.Controller.ProcessPR(ctx, pr, nil); err != nil {
+			lastErr = err
+		}
+	}
+	state, _ := h.Controller.GetPRState(pr)
+	if state == nil {
+		return fmt.Errorf("PR #%d did not get there in %d polls", pr, maxPolls)
+	}
+	return fmt.Errorf("PR #%d stuck in %s after %d polls (last error: %v)", pr, state.Stage, maxPolls, lastErr)
+}
+
+// Usage is an in-memory budget.UsageProvider. All recorded spend counts
+// toward the current day and month.
+type Usage struct {
+	mu        sync.Mutex
+	total     float64
+	byAdapter map[string]float64
+}
+
+// NewUsage creates an empty usage record.
+func NewUsage() *Usage {
+	return &Usage{byAdapter: make(map[string]float64)}
+}
+
+// Record adds the cost of a task from adapter.
+func (u *Usage) Record(adapter string, cost float64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.total += cost
+	u.byAdapter[adapter] += cost
+}
+
+// Total returns the recorded spend.
+func (u *Usage) Total() float64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.total
+}
+
+// GetUsageSummary implements budget.UsageProvider.
+func (u *Usage) GetUsageSummary(query memory.UsageQuery) (*memory.UsageSummary, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return &memory.UsageSummary{
+		UserID:      query.UserID,
+		PeriodStart: query.Start,
+		PeriodEnd:   query.End,
+		TaskCost:    u.total,
+		TotalCost:   u.total,
+	}, nil
+}
+
+// GetAdapterSpend implements budget.UsageProvider.
+func (u *Usage) GetAdapterSpend(adapter string, start, end time.Time) (float64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.byAdapter[adapter], nil
+}
+
+// GetProjectSpend implements budget.UsageProvider. Scenarios don't cap
+// projects, so no project spend is recorded.
+func (u *Usage) GetProjectSpend(projects []string, start, end time.Time) (float64, error) {
+	return 0, nil
+}
+
+// approver is the approval channel of a scenario. It answers every request
+// with its decision, or never answers when the decision is empty.
+type approver struct {
+	mu       sync.Mutex
+	decision approval.Decision
+	requests []*approval.Request
+}
+
+func (a *approver) SendApprovalRequest(ctx context.Context, req *approval.Request) (<-chan *approval.Response, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requests = append(a.requests, req)
+
+	ch := make(chan *approval.Response, 1)
+	if a.decision != "" {
+		ch <- &approval.Response{
+			RequestID:   req.ID,
+			Decision:    a.decision,
+			ApprovedBy:  "scenario",
+			RespondedAt: time.Now(),
+		}
+	}
+	return ch, nil
+}
+
+func (a *approver) CancelRequest(ctx context.Context, requestID string) error {
+	return nil
+}
+
+func (a *approver) Name() string {
+	return "scenario"
+}
+
+func (a *approver) setDecision(d approval.Decision) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.decision = d
+}
+
+func (a *approver) requestCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.requests)
+}
@@ -0,0 +1,351 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/alekspetrov/pilot/e2e/mocks"
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+)
+
+// CIState is what the required checks of a PR report on one CI poll.
+type CIState string
+
+const (
+	CIQueued  CIState = "queued"
+	CIRunning CIState = "running"
+	CIPassed  CIState = "passed"
+	CIFailed  CIState = "failed" // The first required check fails
+)
+
+// OpenPR opens a PR for a new issue and hands it to autopilot. Later steps
+// act on the most recently opened PR.
+func OpenPR(title string) Step {
+	return Step{
+		Name: "open PR " + title,
+		Run: func(ctx context.Context, h *Harness) error {
+			h.OpenPR(title)
+			return nil
+		},
+	}
+}
+
+// CI sets the CI states the current PR reports on successive polls. The
+// last state keeps being reported.
+func CI(states ...CIState) Step {
+	return Step{
+		Name: fmt.Sprintf("CI %v", states),
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			steps := make([][]github.CheckRun, len(states))
+			for i, state := range states {
+				steps[i] = h.checkRuns(state)
+			}
+			h.GitHub.SetCISequence(h.HeadSHA(pr), steps...)
+			return nil
+		},
+	}
+}
+
+// MainCI sets the CI state of the main branch head, which post-merge CI
+// waits for.
+func MainCI(state CIState) Step {
+	return Step{
+		Name: fmt.Sprintf("main CI %s", state),
+		Run: func(ctx context.Context, h *Harness) error {
+			h.GitHub.SetCIStatus(mocks.MainBranchSHA, h.checkRuns(state))
+			return nil
+		},
+	}
+}
+
+// CIUnavailable makes the next polls of the current PR's checks fail with a
+// GitHub API error. Autopilot fails a PR after 5 consecutive errors.
+func CIUnavailable(polls int) Step {
+	return Step{
+		Name: fmt.Sprintf("CI unavailable for %d polls", polls),
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			h.GitHub.FailRequests("/commits/"+h.HeadSHA(pr)+"/check-runs", polls)
+			return nil
+		},
+	}
+}
+
+// PRCommits sets the commit messages of the current PR, which decide the
+// version bump of a release.
+func PRCommits(messages ...string) Step {
+	return Step{
+		Name: "PR commits",
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			h.GitHub.SetPRCommits(pr, messages...)
+			return nil
+		},
+	}
+}
+
+// ExistingTag adds a tag made by an earlier release.
+func ExistingTag(name string) Step {
+	return Step{
+		Name: "existing tag " + name,
+		Run: func(ctx context.Context, h *Harness) error {
+			h.GitHub.AddTag(name, "released-"+name)
+			return nil
+		},
+	}
+}
+
+// RespondToApprovals answers every approval request with decision.
+func RespondToApprovals(decision approval.Decision) Step {
+	return Step{
+		Name: "respond to approvals with " + string(decision),
+		Run: func(ctx context.Context, h *Harness) error {
+			h.approver.setDecision(decision)
+			return nil
+		},
+	}
+}
+
+// IgnoreApprovals leaves approval requests unanswered until they time out.
+func IgnoreApprovals() Step {
+	return Step{
+		Name: "ignore approvals",
+		Run: func(ctx context.Context, h *Harness) error {
+			h.approver.setDecision("")
+			return nil
+		},
+	}
+}
+
+// Spend records spend from adapter outside the queue, e.g. from tasks
+// earlier in the day.
+func Spend(adapter string, cost float64) Step {
+	return Step{
+		Name: fmt.Sprintf("spend $%.2f from %s", cost, adapter),
+		Run: func(ctx context.Context, h *Harness) error {
+			h.Usage.Record(adapter, cost)
+			return nil
+		},
+	}
+}
+
+// RunQueue runs tasks from adapter one after another, the way the poller
+// does: each task passes the budget check, records its cost, opens a PR
+// with passing CI (post-merge too) and is driven through autopilot before
+// the next starts.
+// Tasks the budget blocks leave their issue open.
+func RunQueue(adapter string, costs ...float64) Step {
+	return Step{
+		Name: fmt.Sprintf("run %d queued tasks from %s", len(costs), adapter),
+		Run: func(ctx context.Context, h *Harness) error {
+			for i, cost := range costs {
+				title := fmt.Sprintf("Queued task %d", i+1)
+				issue := h.GitHub.CreateIssue(title, title, []string{"pilot"})
+				task := &Task{Issue: issue.Number}
+				h.mu.Lock()
+				h.tasks = append(h.tasks, task)
+				h.mu.Unlock()
+
+				result, err := h.Budget.CheckAdapterBudget(ctx, "", "", adapter)
+				if err != nil {
+					return fmt.Errorf("budget check for task %d: %w", i+1, err)
+				}
+				if !result.Allowed {
+					task.Blocked = result.Reason
+					continue
+				}
+
+				h.Usage.Record(adapter, cost)
+				task.Cost = cost
+				task.PR = h.openPR(issue)
+				h.GitHub.SetCIStatus(h.HeadSHA(task.PR), h.checkRuns(CIPassed))
+				h.GitHub.SetCIStatus(mocks.MainBranchSHA, h.checkRuns(CIPassed))
+				if err := h.Advance(ctx, task.PR, finished); err != nil {
+					return fmt.Errorf("task %d: %w", i+1, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// AdvanceTo processes the current PR until it reaches stage.
+func AdvanceTo(stage autopilot.PRStage) Step {
+	return Step{
+		Name: "advance to " + string(stage),
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			return h.Advance(ctx, pr, func(state *autopilot.PRState) bool {
+				return state != nil && state.Stage == stage
+			})
+		},
+	}
+}
+
+// Complete processes the current PR until autopilot is done with it: it is
+// no longer tracked, or it failed.
+func Complete() Step {
+	return Step{
+		Name: "complete",
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			return h.Advance(ctx, pr, finished)
+		},
+	}
+}
+
+// ExpectStage checks the current PR is tracked in stage.
+func ExpectStage(stage autopilot.PRStage) Step {
+	return Step{
+		Name: "expect stage " + string(stage),
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			state, ok := h.Controller.GetPRState(pr)
+			if !ok {
+				return fmt.Errorf("PR #%d is not tracked, want stage %s", pr, stage)
+			}
+			if state.Stage != stage {
+				return fmt.Errorf("PR #%d in stage %s, want %s (error: %q)", pr, state.Stage, stage, state.Error)
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectMerged checks whether the current PR was merged on GitHub.
+func ExpectMerged(merged bool) Step {
+	return Step{
+		Name: fmt.Sprintf("expect merged=%v", merged),
+		Run: func(ctx context.Context, h *Harness) error {
+			pr, err := currentPR(h)
+			if err != nil {
+				return err
+			}
+			if got := h.GitHub.GetPR(pr).Merged; got != merged {
+				return fmt.Errorf("PR #%d merged = %v, want %v (stages: %v)", pr, got, merged, h.Stages(pr))
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectTag checks a release created the tag.
+func ExpectTag(name string) Step {
+	return Step{
+		Name: "expect tag " + name,
+		Run: func(ctx context.Context, h *Harness) error {
+			if tags := h.GitHub.GetTags(); !slices.Contains(tags, name) {
+				return fmt.Errorf("tag %s not created, tags: %v", name, tags)
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectQueue checks how many queued tasks ran and how many the budget
+// blocked, and that every task that ran was merged.
+func ExpectQueue(ran, blocked int) Step {
+	return Step{
+		Name: fmt.Sprintf("expect %d tasks ran, %d blocked", ran, blocked),
+		Run: func(ctx context.Context, h *Harness) error {
+			var gotRan, gotBlocked int
+			for _, task := range h.Tasks() {
+				if task.Blocked != "" {
+					gotBlocked++
+					if issue := h.GitHub.GetIssue(task.Issue); issue.State != "open" {
+						return fmt.Errorf("blocked task issue #%d is %s, want open", task.Issue, issue.State)
+					}
+					continue
+				}
+				gotRan++
+				if !h.GitHub.GetPR(task.PR).Merged {
+					return fmt.Errorf("task PR #%d not merged (stages: %v)", task.PR, h.Stages(task.PR))
+				}
+			}
+			if gotRan != ran || gotBlocked != blocked {
+				return fmt.Errorf("%d tasks ran and %d blocked, want %d and %d", gotRan, gotBlocked, ran, blocked)
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectApprovalRequests checks how many approvals were requested.
+func ExpectApprovalRequests(n int) Step {
+	return Step{
+		Name: fmt.Sprintf("expect %d approval requests", n),
+		Run: func(ctx context.Context, h *Harness) error {
+			if got := h.approver.requestCount(); got != n {
+				return fmt.Errorf("%d approval requests, want %d", got, n)
+			}
+			return nil
+		},
+	}
+}
+
+// finished reports whether autopilot is done with a PR
+func finished(state *autopilot.PRState) bool {
+	return state == nil || state.Stage == autopilot.StageFailed
+}
+
+func currentPR(h *Harness) (int, error) {
+	pr := h.LastPR()
+	if pr == 0 {
+		return 0, fmt.Errorf("no PR opened")
+	}
+	return pr, nil
+}
+
+// checkRuns returns the check runs reporting state for the required checks
+func (h *Harness) checkRuns(state CIState) []github.CheckRun {
+	names := h.requiredChecks()
+	runs := make([]github.CheckRun, len(names))
+	for i, name := range names {
+		run := github.CheckRun{ID: int64(i + 1), Name: name, Status: github.CheckRunCompleted, Conclusion: github.ConclusionSuccess}
+		switch {
+		case state == CIQueued:
+			run.Status, run.Conclusion = github.CheckRunQueued, ""
+		case state == CIRunning:
+			run.Status, run.Conclusion = github.CheckRunInProgress, ""
+		case state == CIFailed && i == 0:
+			run.Conclusion = github.ConclusionFailure
+		}
+		runs[i] = run
+	}
+	return runs
+}
+
+// requiredChecks returns the checks autopilot waits for. In auto mode any
+// reported check counts, so the default build and test checks are used.
+func (h *Harness) requiredChecks() []string {
+	cfg := h.Config.Autopilot
+	if cfg.CIChecks != nil && cfg.CIChecks.Mode == "manual" && len(cfg.CIChecks.Required) > 0 {
+		return cfg.CIChecks.Required
+	}
+	if cfg.CIChecks == nil && len(cfg.RequiredChecks) > 0 {
+		return cfg.RequiredChecks
+	}
+	return []string{"build", "test"}
+}
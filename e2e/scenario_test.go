@@ -0,0 +1,160 @@
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/e2e/scenario"
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/autopilot"
+)
+
+// TestScenario_BudgetExhaustionMidQueue verifies the queue stops starting
+// tasks once the daily budget is spent, while tasks that ran still merge.
+func TestScenario_BudgetExhaustionMidQueue(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	h := scenario.New("budget exhaustion", nil,
+		scenario.BudgetExhaustionMidQueue(10, 4, 4, 4, 4, 4),
+	).Run(t)
+
+	tasks := h.Tasks()
+	for i, task := range tasks {
+		blocked := i >= 3 // $0, $4 and $8 spent before the first three
+		if (task.Blocked != "") != blocked {
+			t.Errorf("task %d blocked = %q, want blocked %v", i+1, task.Blocked, blocked)
+		}
+		if blocked && !strings.Contains(task.Blocked, "Daily budget exceeded") {
+			t.Errorf("task %d reason = %q", i+1, task.Blocked)
+		}
+	}
+	if got := h.Usage.Total(); got != 12 {
+		t.Errorf("spend = %v, want 12", got)
+	}
+}
+
+// TestScenario_ApprovalTimeout verifies an unanswered pre-merge approval
+// fails the PR without merging, and merges it when the default action
+// approves.
+func TestScenario_ApprovalTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	h := scenario.New("approval timeout", nil, scenario.ApprovalTimeout(20*time.Millisecond)).Run(t)
+	want := []autopilot.PRStage{autopilot.StageWaitingCI, autopilot.StageCIPassed, autopilot.StageAwaitApproval, autopilot.StageFailed}
+	if got := h.Stages(h.LastPR()); !slices.Equal(got, want) {
+		t.Errorf("stages = %v, want %v", got, want)
+	}
+
+	cfg := scenario.DefaultConfig()
+	cfg.Approval.PreMerge.DefaultAction = approval.DecisionApproved
+	scenario.New("approval timeout approves", cfg, scenario.ApprovalTimeout(20*time.Millisecond)).Run(t)
+}
+
+// TestScenario_CIFlakeThenPass verifies transient check-run API errors and
+// running checks don't fail a PR.
+func TestScenario_CIFlakeThenPass(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	h := scenario.New("CI flake", nil, scenario.CIFlakeThenPass(3)).Run(t)
+	want := []autopilot.PRStage{autopilot.StageWaitingCI, autopilot.StageCIPassed, autopilot.StageMerging, autopilot.StageMerged}
+	if got := h.Stages(h.LastPR()); !slices.Equal(got, want) {
+		t.Errorf("stages = %v, want %v", got, want)
+	}
+
+	// Five consecutive errors exhaust autopilot's patience
+	s := scenario.New("CI outage", nil, scenario.CIFlakeThenPass(5))
+	h, err := s.Execute(context.Background())
+	defer h.Close()
+	if err == nil || !strings.Contains(err.Error(), "advance to ci_passed") {
+		t.Errorf("Execute() error = %v, want failure advancing to ci_passed", err)
+	}
+}
+
+// TestScenario_ReleaseAfterMerge verifies a merged PR is tagged with the
+// version its commits call for.
+func TestScenario_ReleaseAfterMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		want    string
+		commits []string
+	}{
+		{name: "feature", current: "v1.2.0", want: "v1.3.0", commits: []string{"feat: add export", "fix: typo"}},
+		{name: "fix", current: "v1.2.0", want: "v1.2.1", commits: []string{"fix: handle empty body"}},
+		{name: "first release", want: "v0.1.0", commits: []string{"feat: initial"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario.New(tt.name, nil, scenario.ReleaseAfterMerge(tt.current, tt.want, tt.commits...)).Run(t)
+		})
+	}
+}
+
+// TestScenario_ComposedFromConfigFile verifies builders compose on top of a
+// Pilot config file: a staging setup with post-merge CI exhausts its budget
+// mid-queue and still releases the in-flight change.
+func TestScenario_ComposedFromConfigFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping E2E test in short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `orchestrator:
+  autopilot:
+    enabled: true
+    environment: stage
+    auto_review: false
+    ci_checks:
+      mode: manual
+      required: [lint, unit]
+budget:
+  enabled: true
+  daily_limit: 5
+  monthly_limit: 100
+  on_exceed:
+    daily: pause
+    monthly: stop
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := scenario.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	h := scenario.New("staging under budget", cfg,
+		scenario.Steps(scenario.Spend(scenario.QueueAdapter, 2)),
+		scenario.BudgetExhaustionMidQueue(0, 2, 2, 2),
+		scenario.ReleaseAfterMerge("v2.0.0", "v2.1.0", "feat: add webhook"),
+	).Run(t)
+
+	if got := len(h.Tasks()); got != 3 {
+		t.Fatalf("tasks = %d, want 3", got)
+	}
+	if h.Tasks()[2].Blocked == "" {
+		t.Error("third task should be blocked with $6 of $5 spent")
+	}
+	if stages := h.Stages(h.LastPR()); !slices.Contains(stages, autopilot.StagePostMergeCI) {
+		t.Errorf("stage environment should wait for post-merge CI, stages: %v", stages)
+	}
+
+	if _, err := scenario.LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadConfig should fail for a missing file")
+	}
+}
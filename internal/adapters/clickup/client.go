@@ -0,0 +1,229 @@
+package clickup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the ClickUp API endpoint.
+	DefaultBaseURL = "https://api.clickup.com"
+
+	// maxRateLimitWait caps how long a single request waits for a rate-limit reset.
+	maxRateLimitWait = 60 * time.Second
+)
+
+// Client is a ClickUp API v2 client.
+// Auth: personal API token in the Authorization header (no Bearer prefix).
+// Rate limit: 100 req/min per token on most plans (respects Retry-After
+// and X-RateLimit-Reset headers).
+type Client struct {
+	baseURL    string
+	apiToken   string
+	httpClient *http.Client
+}
+
+// ClientOption configures the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the default HTTP client.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the API base URL (used in tests).
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a new ClickUp API client.
+func NewClient(apiToken string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:  DefaultBaseURL,
+		apiToken: apiToken,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// doRequest performs an HTTP request to the ClickUp API.
+// It handles JSON marshalling, auth headers, rate-limit retries, and error responses.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", c.apiToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Handle rate limiting (429)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rateLimitWait(resp.Header)):
+		}
+		// Retry once after waiting
+		return c.doRequest(ctx, method, path, body, result)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// rateLimitWait returns how long to back off after a 429. ClickUp reports the
+// reset as a Unix timestamp in X-RateLimit-Reset; Retry-After is honored too.
+func rateLimitWait(h http.Header) time.Duration {
+	wait := time.Second // default backoff
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs > 0 {
+		wait = time.Duration(secs) * time.Second
+	} else if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if d := time.Until(time.Unix(reset, 0)); d > 0 {
+			wait = d
+		}
+	}
+	if wait > maxRateLimitWait {
+		wait = maxRateLimitWait
+	}
+	return wait
+}
+
+// tasksResponse is a page of list tasks.
+type tasksResponse struct {
+	Tasks    []Task `json:"tasks"`
+	LastPage bool   `json:"last_page"`
+}
+
+// ListTasksOptions filters ListTasks.
+type ListTasksOptions struct {
+	Tags     []string
+	Statuses []string
+}
+
+// ListTasks returns the open tasks on a list matching opts, following pagination.
+func (c *Client) ListTasks(ctx context.Context, listID string, opts ListTasksOptions) ([]Task, error) {
+	var all []Task
+	for page := 0; ; page++ {
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("include_closed", "false")
+		params.Set("include_markdown_description", "true")
+		params.Set("subtasks", "true")
+		for _, tag := range opts.Tags {
+			params.Add("tags[]", tag)
+		}
+		for _, status := range opts.Statuses {
+			params.Add("statuses[]", status)
+		}
+
+		var resp tasksResponse
+		path := fmt.Sprintf("/api/v2/list/%s/task?%s", url.PathEscape(listID), params.Encode())
+		if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Tasks...)
+		if resp.LastPage || len(resp.Tasks) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// GetTask fetches a single task, including its Markdown description.
+func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	var task Task
+	path := fmt.Sprintf("/api/v2/task/%s?include_markdown_description=true", url.PathEscape(taskID))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// UpdateStatus sets a task's status by name.
+func (c *Client) UpdateStatus(ctx context.Context, taskID, status string) error {
+	return c.doRequest(ctx, http.MethodPut, "/api/v2/task/"+url.PathEscape(taskID), map[string]string{"status": status}, nil)
+}
+
+// AddComment posts a comment on a task without notifying assignees.
+func (c *Client) AddComment(ctx context.Context, taskID, text string) error {
+	body := map[string]interface{}{
+		"comment_text": text,
+		"notify_all":   false,
+	}
+	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v2/task/%s/comment", url.PathEscape(taskID)), body, nil)
+}
+
+// RemoveTag detaches a tag from a task.
+func (c *Client) RemoveTag(ctx context.Context, taskID, tag string) error {
+	path := fmt.Sprintf("/api/v2/task/%s/tag/%s", url.PathEscape(taskID), url.PathEscape(tag))
+	return c.doRequest(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// fieldsResponse wraps the accessible custom fields of a list.
+type fieldsResponse struct {
+	Fields []CustomField `json:"fields"`
+}
+
+// GetListFields returns the custom fields available on a list.
+func (c *Client) GetListFields(ctx context.Context, listID string) ([]CustomField, error) {
+	var resp fieldsResponse
+	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v2/list/%s/field", url.PathEscape(listID)), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Fields, nil
+}
+
+// SetCustomField writes a custom field value on a task.
+func (c *Client) SetCustomField(ctx context.Context, taskID, fieldID string, value interface{}) error {
+	path := fmt.Sprintf("/api/v2/task/%s/field/%s", url.PathEscape(taskID), url.PathEscape(fieldID))
+	return c.doRequest(ctx, http.MethodPost, path, map[string]interface{}{"value": value}, nil)
+}
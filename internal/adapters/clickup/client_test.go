@@ -0,0 +1,106 @@
+package clickup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestClient_ListTasksPaginates(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/list/901/task" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != testutil.FakeClickUpToken {
+			t.Errorf("Authorization = %q, want raw token", got)
+		}
+		q := r.URL.Query()
+		if q["tags[]"][0] != "pilot" || q.Get("include_closed") != "false" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		page := q.Get("page")
+		pages = append(pages, page)
+		last := page == "1"
+		_, _ = fmt.Fprintf(w, `{"tasks":[{"id":"t%s","name":"Task %s"}],"last_page":%v}`, page, page, last)
+	}))
+	defer server.Close()
+
+	client := NewClient(testutil.FakeClickUpToken, WithBaseURL(server.URL))
+	tasks, err := client.ListTasks(context.Background(), "901", ListTasksOptions{Tags: []string{"pilot"}})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "t0" || tasks[1].ID != "t1" {
+		t.Errorf("tasks = %+v", tasks)
+	}
+	if !reflect.DeepEqual(pages, []string{"0", "1"}) {
+		t.Errorf("pages requested = %v", pages)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"err":"Token invalid","ECODE":"OAUTH_025"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(testutil.FakeClickUpToken, WithBaseURL(server.URL))
+	err := client.UpdateStatus(context.Background(), "t1", "in progress")
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("expected 401 API error, got %v", err)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	h := http.Header{}
+	if got := rateLimitWait(h); got != time.Second {
+		t.Errorf("default wait = %v, want 1s", got)
+	}
+
+	h.Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(10*time.Second).Unix()))
+	if got := rateLimitWait(h); got < 8*time.Second || got > 10*time.Second {
+		t.Errorf("reset-based wait = %v, want ~10s", got)
+	}
+
+	h.Set("Retry-After", "3")
+	if got := rateLimitWait(h); got != 3*time.Second {
+		t.Errorf("Retry-After wait = %v, want 3s", got)
+	}
+
+	h.Set("Retry-After", "3600")
+	if got := rateLimitWait(h); got != maxRateLimitWait {
+		t.Errorf("wait = %v, want capped at %v", got, maxRateLimitWait)
+	}
+}
+
+func TestTask_AcceptanceCriteriaAndBody(t *testing.T) {
+	task := Task{
+		ID:                  "86a1b2c3d",
+		Description:         "plain",
+		MarkdownDescription: "**markdown**",
+		Checklists: []Checklist{{Items: []ChecklistItem{
+			{Name: "Second", OrderIndex: 1},
+			{Name: "First", OrderIndex: 0, Resolved: true},
+			{Name: "  "},
+		}}},
+	}
+
+	if got := task.AcceptanceCriteria(); !reflect.DeepEqual(got, []string{"First", "Second"}) {
+		t.Errorf("AcceptanceCriteria() = %v", got)
+	}
+	if task.Body() != "**markdown**" {
+		t.Errorf("Body() should prefer the Markdown description, got %q", task.Body())
+	}
+	if TaskID(&task) != "CU-86a1b2c3d" {
+		t.Errorf("TaskID() = %q", TaskID(&task))
+	}
+}
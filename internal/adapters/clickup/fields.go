@@ -0,0 +1,141 @@
+package clickup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// FieldReporter writes execution results (PR URL, cost, duration) to list
+// custom fields, so execution metrics show up directly on the task.
+// Field names are resolved to IDs once, on first use.
+type FieldReporter struct {
+	client *Client
+	listID string
+	names  *CustomFieldsConfig
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	resolved bool
+	prURL    *CustomField
+	cost     *CustomField
+	duration *CustomField
+}
+
+// NewFieldReporter creates a reporter for the custom fields named in names.
+// A nil names config disables reporting.
+func NewFieldReporter(client *Client, listID string, names *CustomFieldsConfig) *FieldReporter {
+	return &FieldReporter{
+		client: client,
+		listID: listID,
+		names:  names,
+		logger: logging.WithComponent("clickup"),
+	}
+}
+
+// resolve looks up the configured field names on the list.
+func (r *FieldReporter) resolve(ctx context.Context) error {
+	if r.resolved || r.names == nil {
+		return nil
+	}
+
+	fields, err := r.client.GetListFields(ctx, r.listID)
+	if err != nil {
+		return fmt.Errorf("failed to load list custom fields: %w", err)
+	}
+
+	find := func(name string, types ...string) *CustomField {
+		if name == "" {
+			return nil
+		}
+		for i := range fields {
+			if !strings.EqualFold(fields[i].Name, name) {
+				continue
+			}
+			for _, t := range types {
+				if fields[i].Type == t {
+					return &fields[i]
+				}
+			}
+			r.logger.Warn("Custom field has unsupported type, skipping",
+				slog.String("field", name),
+				slog.String("type", fields[i].Type),
+			)
+			return nil
+		}
+		r.logger.Warn("Custom field not found on list, skipping",
+			slog.String("field", name),
+			slog.String("list", r.listID),
+		)
+		return nil
+	}
+
+	r.prURL = find(r.names.PRURL, FieldTypeURL, FieldTypeShortText, FieldTypeText)
+	r.cost = find(r.names.Cost, FieldTypeCurrency, FieldTypeNumber, FieldTypeShortText, FieldTypeText)
+	r.duration = find(r.names.Duration, FieldTypeNumber, FieldTypeShortText, FieldTypeText)
+	r.resolved = true
+	return nil
+}
+
+// Report writes the result to the task's custom fields. Fields without a
+// value (no PR, zero cost) are left untouched.
+func (r *FieldReporter) Report(ctx context.Context, taskID string, result *TaskResult) error {
+	if result == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	err := r.resolve(ctx)
+	prURL, cost, duration := r.prURL, r.cost, r.duration
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	set := func(field *CustomField, value interface{}) {
+		if field == nil {
+			return
+		}
+		if err := r.client.SetCustomField(ctx, taskID, field.ID, value); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set %q: %w", field.Name, err))
+		}
+	}
+
+	if result.PRURL != "" {
+		set(prURL, result.PRURL)
+	}
+	if result.CostUSD > 0 && cost != nil {
+		set(cost, costValue(cost.Type, result.CostUSD))
+	}
+	if result.Duration > 0 && duration != nil {
+		set(duration, durationValue(duration.Type, result.Duration))
+	}
+
+	return errors.Join(errs...)
+}
+
+// costValue formats a USD cost for the field type: numeric fields get the
+// amount rounded to cents, text fields a "$0.42" string.
+func costValue(fieldType string, usd float64) interface{} {
+	if fieldType == FieldTypeCurrency || fieldType == FieldTypeNumber {
+		return math.Round(usd*100) / 100
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// durationValue formats a duration for the field type: number fields get
+// minutes with one decimal, text fields a "4m12s" string.
+func durationValue(fieldType string, d time.Duration) interface{} {
+	if fieldType == FieldTypeNumber {
+		return math.Round(d.Minutes()*10) / 10
+	}
+	return d.Round(time.Second).String()
+}
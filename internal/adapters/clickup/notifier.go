@@ -0,0 +1,82 @@
+package clickup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// Notifier handles status comments on ClickUp tasks.
+type Notifier struct {
+	client *Client
+}
+
+// NewNotifier creates a new ClickUp notifier.
+func NewNotifier(client *Client) *Notifier {
+	return &Notifier{client: client}
+}
+
+// NotifyTaskStarted posts a comment when Pilot starts working on a task.
+func (n *Notifier) NotifyTaskStarted(ctx context.Context, clickupID, pilotTaskID string) error {
+	comment := fmt.Sprintf("🤖 Pilot started working on this task\nTask ID: %s\nI'll post updates as I make progress.", pilotTaskID)
+	if err := n.client.AddComment(ctx, clickupID, comment); err != nil {
+		return fmt.Errorf("failed to add start comment: %w", err)
+	}
+
+	logging.WithComponent("clickup").Info("Notified task started",
+		slog.String("clickup_id", clickupID),
+		slog.String("task_id", pilotTaskID))
+
+	return nil
+}
+
+// NotifyTaskCompleted posts a completion comment.
+func (n *Notifier) NotifyTaskCompleted(ctx context.Context, clickupID, prURL, summary string) error {
+	comment := "✅ Pilot completed this task!"
+	if prURL != "" {
+		comment += "\nPull Request: " + prURL
+	}
+	if summary != "" {
+		comment += "\nSummary: " + summary
+	}
+
+	if err := n.client.AddComment(ctx, clickupID, comment); err != nil {
+		return fmt.Errorf("failed to add completion comment: %w", err)
+	}
+
+	logging.WithComponent("clickup").Info("Notified task completed",
+		slog.String("clickup_id", clickupID),
+		slog.String("pr_url", prURL))
+
+	return nil
+}
+
+// NotifyTaskFailed posts a failure comment.
+func (n *Notifier) NotifyTaskFailed(ctx context.Context, clickupID, reason string) error {
+	comment := fmt.Sprintf("❌ Pilot could not complete this task\nReason: %s\nPlease review the task and re-queue it to retry.", reason)
+	if err := n.client.AddComment(ctx, clickupID, comment); err != nil {
+		return fmt.Errorf("failed to add failure comment: %w", err)
+	}
+
+	logging.WithComponent("clickup").Warn("Notified task failed",
+		slog.String("clickup_id", clickupID),
+		slog.String("reason", reason))
+
+	return nil
+}
+
+// LinkPR posts a comment linking the created PR.
+func (n *Notifier) LinkPR(ctx context.Context, clickupID string, prNumber int, prURL string) error {
+	comment := fmt.Sprintf("🔗 Pull Request Created: PR #%d\n%s", prNumber, prURL)
+	if err := n.client.AddComment(ctx, clickupID, comment); err != nil {
+		return fmt.Errorf("failed to add PR link comment: %w", err)
+	}
+
+	logging.WithComponent("clickup").Info("Linked PR to task",
+		slog.String("clickup_id", clickupID),
+		slog.Int("pr_number", prNumber))
+
+	return nil
+}
@@ -0,0 +1,402 @@
+package clickup
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// AdapterName is the key used for the generic processed store.
+const AdapterName = "clickup"
+
+// TaskResult is returned by the task handler.
+type TaskResult struct {
+	Success    bool
+	PRNumber   int
+	PRURL      string
+	HeadSHA    string // Head commit SHA of the PR (for autopilot wiring)
+	BranchName string // Head branch name e.g. "pilot/CU-86a1b2c3d"
+	Duration   time.Duration
+	CostUSD    float64
+	Error      error
+}
+
+// Poller polls a ClickUp list for tasks queued for Pilot.
+// A task is queued when it carries PilotTag, or — when PilotStatus is
+// configured — when its status equals PilotStatus.
+type Poller struct {
+	client   *Client
+	config   *Config
+	interval time.Duration
+	fields   *FieldReporter
+
+	processed map[string]bool // Task ID → processed
+	mu        sync.RWMutex
+
+	onTask      func(ctx context.Context, task *Task) (*TaskResult, error)
+	onPRCreated func(prNumber int, prURL, taskID, headSHA, branchName string)
+	logger      *slog.Logger
+
+	// Persistent processed store (optional), keyed under AdapterName
+	processedStore adapters.ProcessedStore
+
+	// Parallel execution configuration
+	maxConcurrent int
+	semaphore     chan struct{}
+	activeWg      sync.WaitGroup
+	stopping      atomic.Bool
+	wgMu          sync.Mutex // protects stopping + activeWg Add/Wait coordination
+}
+
+// PollerOption configures a Poller.
+type PollerOption func(*Poller)
+
+// WithOnTask sets the callback for newly queued tasks.
+func WithOnTask(fn func(ctx context.Context, task *Task) (*TaskResult, error)) PollerOption {
+	return func(p *Poller) {
+		p.onTask = fn
+	}
+}
+
+// WithPollerLogger sets the logger for the poller.
+func WithPollerLogger(logger *slog.Logger) PollerOption {
+	return func(p *Poller) {
+		p.logger = logger
+	}
+}
+
+// WithProcessedStore sets the persistent store for processed task tracking.
+// On startup, processed tasks are loaded from the store to prevent re-processing after hot upgrade.
+func WithProcessedStore(store adapters.ProcessedStore) PollerOption {
+	return func(p *Poller) {
+		p.processedStore = store
+	}
+}
+
+// WithMaxConcurrent sets the maximum number of parallel task executions.
+func WithMaxConcurrent(n int) PollerOption {
+	return func(p *Poller) {
+		if n < 1 {
+			n = 1
+		}
+		p.maxConcurrent = n
+	}
+}
+
+// WithOnPRCreated sets the callback for when a PR is created for a task.
+func WithOnPRCreated(fn func(prNumber int, prURL, taskID, headSHA, branchName string)) PollerOption {
+	return func(p *Poller) {
+		p.onPRCreated = fn
+	}
+}
+
+// NewPoller creates a new ClickUp list poller.
+func NewPoller(client *Client, config *Config, interval time.Duration, opts ...PollerOption) *Poller {
+	p := &Poller{
+		client:    client,
+		config:    config,
+		interval:  interval,
+		fields:    NewFieldReporter(client, config.ListID, config.CustomFields),
+		processed: make(map[string]bool),
+		logger:    logging.WithComponent("clickup-poller"),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Load processed tasks from persistent store if available
+	if p.processedStore != nil {
+		loaded, err := p.processedStore.LoadAdapterProcessed(AdapterName)
+		if err != nil {
+			p.logger.Warn("Failed to load processed tasks from store", slog.Any("error", err))
+		} else if len(loaded) > 0 {
+			p.mu.Lock()
+			for id := range loaded {
+				p.processed[id] = true
+			}
+			p.mu.Unlock()
+			p.logger.Info("Loaded processed tasks from store", slog.Int("count", len(loaded)))
+		}
+	}
+
+	if p.maxConcurrent < 1 {
+		p.maxConcurrent = 2 // default
+	}
+	p.semaphore = make(chan struct{}, p.maxConcurrent)
+
+	return p
+}
+
+// Start begins polling the list.
+func (p *Poller) Start(ctx context.Context) error {
+	p.logger.Info("Starting ClickUp poller",
+		slog.String("list", p.config.ListID),
+		slog.Bool("status_pickup", p.config.PilotStatus != ""),
+		slog.Duration("interval", p.interval),
+		slog.Int("max_concurrent", p.maxConcurrent),
+	)
+
+	// Initial check
+	p.checkForNewTasks(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("ClickUp poller stopping, waiting for active tasks...")
+			p.wgMu.Lock()
+			p.stopping.Store(true)
+			p.wgMu.Unlock()
+			p.activeWg.Wait()
+			p.logger.Info("ClickUp poller stopped")
+			return nil
+		case <-ticker.C:
+			p.checkForNewTasks(ctx)
+		}
+	}
+}
+
+// listOptions builds the task filter selecting queued tasks.
+func (p *Poller) listOptions() ListTasksOptions {
+	if p.config.PilotStatus != "" {
+		return ListTasksOptions{Statuses: []string{p.config.PilotStatus}}
+	}
+	return ListTasksOptions{Tags: []string{p.pilotTag()}}
+}
+
+// isQueued re-checks the filter client-side; ClickUp matches tags exactly
+// but status names case-insensitively, so both are compared without case.
+func (p *Poller) isQueued(task *Task) bool {
+	if p.config.PilotStatus != "" {
+		return strings.EqualFold(task.Status.Status, p.config.PilotStatus)
+	}
+	return task.HasTag(p.pilotTag())
+}
+
+func (p *Poller) checkForNewTasks(ctx context.Context) {
+	tasks, err := p.client.ListTasks(ctx, p.config.ListID, p.listOptions())
+	if err != nil {
+		p.logger.Warn("Failed to list ClickUp tasks",
+			slog.String("list", p.config.ListID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	// Sort by creation date (oldest first)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt().Before(tasks[j].CreatedAt())
+	})
+
+	for _, task := range tasks {
+		if !p.isQueued(&task) {
+			continue
+		}
+
+		// Skip if already processed
+		p.mu.RLock()
+		processed := p.processed[task.ID]
+		p.mu.RUnlock()
+
+		if processed {
+			continue
+		}
+
+		// Mark processed immediately to prevent duplicate dispatch on next tick
+		p.markProcessed(task.ID, "processed")
+
+		// Acquire semaphore slot (blocks if max_concurrent reached)
+		select {
+		case <-ctx.Done():
+			return
+		case p.semaphore <- struct{}{}:
+		}
+
+		p.logger.Info("Dispatching ClickUp task for parallel execution",
+			slog.String("id", task.ID),
+			slog.String("name", task.Name),
+		)
+
+		// Use mutex to coordinate stopping flag check with WaitGroup Add
+		p.wgMu.Lock()
+		if p.stopping.Load() {
+			p.wgMu.Unlock()
+			<-p.semaphore // release slot we acquired
+			return
+		}
+		p.activeWg.Add(1)
+		p.wgMu.Unlock()
+
+		go p.processTaskAsync(ctx, task)
+	}
+}
+
+// processTaskAsync handles a single task in a goroutine.
+func (p *Poller) processTaskAsync(ctx context.Context, task Task) {
+	defer p.activeWg.Done()
+	defer func() { <-p.semaphore }() // release slot
+
+	if p.onTask == nil {
+		return
+	}
+
+	if p.config.Statuses != nil {
+		p.setStatus(ctx, task.ID, p.config.Statuses.InProgress)
+	}
+
+	result, err := p.onTask(ctx, &task)
+
+	// Metrics are reported whether or not the run succeeded
+	if reportErr := p.fields.Report(ctx, task.ID, result); reportErr != nil {
+		p.logger.Warn("Failed to update ClickUp custom fields",
+			slog.String("id", task.ID),
+			slog.Any("error", reportErr),
+		)
+	}
+
+	if err != nil || result == nil || !result.Success {
+		if err != nil {
+			p.logger.Error("Failed to process ClickUp task",
+				slog.String("id", task.ID),
+				slog.Any("error", err),
+			)
+		}
+		failedSet := p.config.Statuses != nil && p.setStatus(ctx, task.ID, p.config.Statuses.Failed)
+		// Take the task out of the pickup filter, then clear it from the
+		// processed set so re-queueing it (re-adding the tag or moving it
+		// back to the pilot status) retries it.
+		if p.dequeue(ctx, &task, failedSet) {
+			p.ClearProcessed(task.ID)
+		}
+		return
+	}
+
+	if p.config.Statuses != nil {
+		p.setStatus(ctx, task.ID, p.config.Statuses.Done)
+	}
+	p.markProcessed(task.ID, "done")
+
+	// Fire OnPRCreated callback
+	if result.PRNumber > 0 && p.onPRCreated != nil {
+		p.onPRCreated(result.PRNumber, result.PRURL, task.ID, result.HeadSHA, result.BranchName)
+	}
+}
+
+// dequeue removes a failed task from the pickup filter. In tag mode the pilot
+// tag is removed; in status mode the failed transition (failedSet) already
+// moved it out of the pilot status. Returns true if the task no longer matches.
+func (p *Poller) dequeue(ctx context.Context, task *Task, failedSet bool) bool {
+	if p.config.PilotStatus != "" {
+		return failedSet
+	}
+	if err := p.client.RemoveTag(ctx, task.ID, p.pilotTag()); err != nil {
+		p.logger.Warn("Failed to remove pilot tag",
+			slog.String("id", task.ID),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return true
+}
+
+// setStatus updates the task status, logging failures.
+// Returns true if the status was written. Empty values are skipped.
+func (p *Poller) setStatus(ctx context.Context, taskID, status string) bool {
+	if status == "" {
+		return false
+	}
+	if err := p.client.UpdateStatus(ctx, taskID, status); err != nil {
+		p.logger.Warn("Failed to update ClickUp task status",
+			slog.String("id", taskID),
+			slog.String("status", status),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return true
+}
+
+func (p *Poller) pilotTag() string {
+	if p.config.PilotTag == "" {
+		return "pilot"
+	}
+	return p.config.PilotTag
+}
+
+func (p *Poller) markProcessed(id, result string) {
+	p.mu.Lock()
+	p.processed[id] = true
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.MarkAdapterProcessed(AdapterName, id, result); err != nil {
+			p.logger.Warn("Failed to persist processed task", slog.String("id", id), slog.Any("error", err))
+		}
+	}
+}
+
+// IsProcessed checks if a task has been processed.
+func (p *Poller) IsProcessed(id string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.processed[id]
+}
+
+// ProcessedCount returns the number of processed tasks.
+func (p *Poller) ProcessedCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.processed)
+}
+
+// Reset clears the processed tasks map.
+func (p *Poller) Reset() {
+	p.mu.Lock()
+	p.processed = make(map[string]bool)
+	p.mu.Unlock()
+}
+
+// ClearProcessed removes a specific task from the processed map (for retry).
+func (p *Poller) ClearProcessed(id string) {
+	p.mu.Lock()
+	delete(p.processed, id)
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.UnmarkAdapterProcessed(AdapterName, id); err != nil {
+			p.logger.Warn("Failed to unmark task in store",
+				slog.String("id", id),
+				slog.Any("error", err))
+		}
+	}
+}
+
+// Drain stops accepting new tasks and waits for active executions to finish.
+// Used during hot upgrade to let in-flight work complete before process restart.
+func (p *Poller) Drain() {
+	p.logger.Info("Draining poller — no new tasks will be accepted")
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+	p.logger.Info("Poller drained — all active tasks completed")
+}
+
+// WaitForActive waits for all active parallel goroutines to finish.
+// Used in tests to synchronize after checkForNewTasks.
+func (p *Poller) WaitForActive() {
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+}
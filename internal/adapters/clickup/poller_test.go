@@ -0,0 +1,215 @@
+package clickup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// fakeClickUp is a minimal ClickUp API stub recording writes.
+type fakeClickUp struct {
+	mu          sync.Mutex
+	tasks       []Task
+	fields      []CustomField
+	statuses    map[string][]string               // task ID → statuses written
+	fieldWrites map[string]map[string]interface{} // task ID → field ID → value
+	removedTags map[string][]string               // task ID → tags removed
+}
+
+func (f *fakeClickUp) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		path := r.URL.Path
+		switch {
+		case r.Method == http.MethodGet && path == "/api/v2/list/901/task":
+			_ = json.NewEncoder(w).Encode(tasksResponse{Tasks: f.tasks, LastPage: true})
+		case r.Method == http.MethodGet && path == "/api/v2/list/901/field":
+			_ = json.NewEncoder(w).Encode(fieldsResponse{Fields: f.fields})
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "/api/v2/task/"):
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			id := strings.TrimPrefix(path, "/api/v2/task/")
+			if f.statuses == nil {
+				f.statuses = make(map[string][]string)
+			}
+			f.statuses[id] = append(f.statuses[id], body["status"])
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.Contains(path, "/field/"):
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			parts := strings.Split(strings.TrimPrefix(path, "/api/v2/task/"), "/field/")
+			if f.fieldWrites == nil {
+				f.fieldWrites = make(map[string]map[string]interface{})
+			}
+			if f.fieldWrites[parts[0]] == nil {
+				f.fieldWrites[parts[0]] = make(map[string]interface{})
+			}
+			f.fieldWrites[parts[0]][parts[1]] = body["value"]
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodDelete && strings.Contains(path, "/tag/"):
+			parts := strings.Split(strings.TrimPrefix(path, "/api/v2/task/"), "/tag/")
+			if f.removedTags == nil {
+				f.removedTags = make(map[string][]string)
+			}
+			f.removedTags[parts[0]] = append(f.removedTags[parts[0]], parts[1])
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+		}
+	}
+}
+
+func taggedTask(id string, created int64, tags ...string) Task {
+	task := Task{ID: id, Name: "Task " + id, DateCreated: strconv.FormatInt(created, 10)}
+	for _, tag := range tags {
+		task.Tags = append(task.Tags, Tag{Name: tag})
+	}
+	return task
+}
+
+func newTestPoller(t *testing.T, fake *fakeClickUp, cfg *Config, opts ...PollerOption) *Poller {
+	t.Helper()
+	srv := httptest.NewServer(fake.handler(t))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(testutil.FakeClickUpToken, WithBaseURL(srv.URL))
+	return NewPoller(client, cfg, time.Minute, opts...)
+}
+
+func testConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.ListID = "901"
+	return cfg
+}
+
+func TestPoller_SuccessReportsStatusAndFields(t *testing.T) {
+	fake := &fakeClickUp{
+		tasks: []Task{taggedTask("t2", 2000, "pilot"), taggedTask("t1", 1000, "pilot"), taggedTask("other", 500, "bug")},
+		fields: []CustomField{
+			{ID: "f-pr", Name: "Pilot PR", Type: FieldTypeURL},
+			{ID: "f-cost", Name: "pilot cost", Type: FieldTypeCurrency},
+			{ID: "f-dur", Name: "Pilot Duration", Type: FieldTypeShortText},
+		},
+	}
+
+	var handled []string
+	var mu sync.Mutex
+	p := newTestPoller(t, fake, testConfig(), WithMaxConcurrent(1), WithOnTask(func(_ context.Context, task *Task) (*TaskResult, error) {
+		mu.Lock()
+		handled = append(handled, task.ID)
+		mu.Unlock()
+		return &TaskResult{
+			Success:  true,
+			PRNumber: 7,
+			PRURL:    "https://github.com/o/r/pull/7",
+			Duration: 4*time.Minute + 12*time.Second,
+			CostUSD:  0.4249,
+		}, nil
+	}))
+
+	p.checkForNewTasks(context.Background())
+	p.WaitForActive()
+
+	if len(handled) != 2 || handled[0] != "t1" || handled[1] != "t2" {
+		t.Fatalf("handled = %v, want oldest tagged task first", handled)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if got := fake.statuses["t1"]; len(got) != 2 || got[0] != "in progress" || got[1] != "complete" {
+		t.Errorf("statuses = %v, want [in progress complete]", got)
+	}
+	writes := fake.fieldWrites["t1"]
+	if writes["f-pr"] != "https://github.com/o/r/pull/7" {
+		t.Errorf("PR field = %v", writes["f-pr"])
+	}
+	if writes["f-cost"] != 0.42 {
+		t.Errorf("cost field = %v, want 0.42", writes["f-cost"])
+	}
+	if writes["f-dur"] != "4m12s" {
+		t.Errorf("duration field = %v, want 4m12s", writes["f-dur"])
+	}
+	if !p.IsProcessed("t1") || !p.IsProcessed("t2") {
+		t.Error("handled tasks should be marked processed")
+	}
+}
+
+func TestPoller_FailureRemovesTagAndAllowsRetry(t *testing.T) {
+	fake := &fakeClickUp{tasks: []Task{taggedTask("t1", 1000, "pilot")}}
+
+	cfg := testConfig()
+	cfg.CustomFields = nil
+	p := newTestPoller(t, fake, cfg, WithOnTask(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Success: false}, errors.New("boom")
+	}))
+
+	p.checkForNewTasks(context.Background())
+	p.WaitForActive()
+
+	fake.mu.Lock()
+	removed := fake.removedTags["t1"]
+	statuses := fake.statuses["t1"]
+	fake.mu.Unlock()
+	if len(removed) != 1 || removed[0] != "pilot" {
+		t.Errorf("removed tags = %v, want [pilot]", removed)
+	}
+	if len(statuses) != 1 {
+		t.Errorf("statuses = %v, want only in-progress (no failed status configured)", statuses)
+	}
+	if p.IsProcessed("t1") {
+		t.Error("failed task should be cleared so re-tagging retries it")
+	}
+}
+
+func TestPoller_StatusPickup(t *testing.T) {
+	inPilot := taggedTask("t1", 1000)
+	inPilot.Status = TaskStatus{Status: "Pilot"}
+	fake := &fakeClickUp{tasks: []Task{inPilot}}
+
+	cfg := testConfig()
+	cfg.PilotStatus = "pilot"
+	cfg.Statuses.Failed = "blocked"
+	cfg.CustomFields = nil
+
+	p := newTestPoller(t, fake, cfg, WithOnTask(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return nil, errors.New("boom")
+	}))
+
+	if opts := p.listOptions(); len(opts.Statuses) != 1 || len(opts.Tags) != 0 {
+		t.Errorf("listOptions = %+v, want status filter", opts)
+	}
+
+	p.checkForNewTasks(context.Background())
+	p.WaitForActive()
+
+	fake.mu.Lock()
+	statuses := fake.statuses["t1"]
+	fake.mu.Unlock()
+	if len(statuses) != 2 || statuses[1] != "blocked" {
+		t.Errorf("statuses = %v, want [in progress blocked]", statuses)
+	}
+	if p.IsProcessed("t1") {
+		t.Error("task moved to the failed status should be cleared for retry")
+	}
+}
+
+func TestFieldValues(t *testing.T) {
+	if got := costValue(FieldTypeShortText, 1.234); got != "$1.23" {
+		t.Errorf("text cost = %v", got)
+	}
+	if got := durationValue(FieldTypeNumber, 90*time.Second); got != 1.5 {
+		t.Errorf("number duration = %v, want 1.5 minutes", got)
+	}
+}
@@ -0,0 +1,173 @@
+package clickup
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds ClickUp adapter configuration.
+type Config struct {
+	Enabled      bool                `yaml:"enabled"`
+	APIToken     string              `yaml:"api_token"`    // Personal API token (pk_...)
+	ListID       string              `yaml:"list_id"`      // List polled for pilot tasks
+	PilotTag     string              `yaml:"pilot_tag"`    // default: "pilot"
+	PilotStatus  string              `yaml:"pilot_status"` // optional; when set, tasks are picked up by status instead of tag
+	Statuses     *StatusMapping      `yaml:"statuses,omitempty"`
+	CustomFields *CustomFieldsConfig `yaml:"custom_fields,omitempty"`
+	Polling      *PollingConfig      `yaml:"polling,omitempty"`
+}
+
+// StatusMapping maps Pilot lifecycle stages to ClickUp status names.
+// Leave a value empty to skip that transition.
+type StatusMapping struct {
+	InProgress string `yaml:"in_progress"` // default: "in progress"
+	Done       string `yaml:"done"`        // default: "complete"
+	Failed     string `yaml:"failed"`      // default: "" (no transition)
+}
+
+// CustomFieldsConfig names the list custom fields that receive execution
+// results. Fields missing from the list, or left empty here, are skipped.
+type CustomFieldsConfig struct {
+	PRURL    string `yaml:"pr_url"`   // default: "Pilot PR"
+	Cost     string `yaml:"cost"`     // default: "Pilot Cost"
+	Duration string `yaml:"duration"` // default: "Pilot Duration"
+}
+
+// PollingConfig holds polling configuration for the ClickUp adapter.
+type PollingConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultConfig returns default ClickUp configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:  false,
+		PilotTag: "pilot",
+		Statuses: &StatusMapping{
+			InProgress: "in progress",
+			Done:       "complete",
+		},
+		CustomFields: &CustomFieldsConfig{
+			PRURL:    "Pilot PR",
+			Cost:     "Pilot Cost",
+			Duration: "Pilot Duration",
+		},
+	}
+}
+
+// Custom field types the adapter can write.
+const (
+	FieldTypeURL       = "url"
+	FieldTypeNumber    = "number"
+	FieldTypeCurrency  = "currency"
+	FieldTypeShortText = "short_text"
+	FieldTypeText      = "text"
+)
+
+// Task represents a ClickUp task.
+type Task struct {
+	ID                  string        `json:"id"`
+	CustomID            string        `json:"custom_id,omitempty"`
+	Name                string        `json:"name"`
+	Description         string        `json:"description"`
+	MarkdownDescription string        `json:"markdown_description,omitempty"`
+	Status              TaskStatus    `json:"status"`
+	Tags                []Tag         `json:"tags"`
+	Checklists          []Checklist   `json:"checklists"`
+	CustomFields        []CustomField `json:"custom_fields,omitempty"`
+	URL                 string        `json:"url"`
+	DateCreated         string        `json:"date_created"` // Unix milliseconds as a string
+}
+
+// TaskStatus is the status of a task.
+type TaskStatus struct {
+	Status string `json:"status"`
+	Type   string `json:"type"` // "open", "custom", "closed", "done"
+}
+
+// Tag is a space tag attached to a task.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Checklist is a named checklist on a task.
+type Checklist struct {
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Items []ChecklistItem `json:"items"`
+}
+
+// ChecklistItem is a single checklist entry.
+type ChecklistItem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Resolved   bool   `json:"resolved"`
+	OrderIndex int    `json:"orderindex"`
+}
+
+// CustomField is a list custom field definition (values are not decoded).
+type CustomField struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Comment is a task comment.
+type Comment struct {
+	ID          string `json:"id"`
+	CommentText string `json:"comment_text"`
+}
+
+// HasTag reports whether the task carries a tag with the given name (case-insensitive).
+func (t *Task) HasTag(name string) bool {
+	for _, tag := range t.Tags {
+		if strings.EqualFold(tag.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Body returns the task description, preferring the Markdown rendering.
+func (t *Task) Body() string {
+	if t.MarkdownDescription != "" {
+		return t.MarkdownDescription
+	}
+	return t.Description
+}
+
+// CreatedAt parses DateCreated; it returns the zero time when unset or malformed.
+func (t *Task) CreatedAt() time.Time {
+	ms, err := strconv.ParseInt(t.DateCreated, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// AcceptanceCriteria returns the names of all checklist items on the task,
+// ordered by position. Resolved items are included: a checklist describes
+// what the task must satisfy, not what is left to do.
+func (t *Task) AcceptanceCriteria() []string {
+	var criteria []string
+	for _, cl := range t.Checklists {
+		items := append([]ChecklistItem(nil), cl.Items...)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].OrderIndex < items[j].OrderIndex })
+		for _, item := range items {
+			if name := strings.TrimSpace(item.Name); name != "" {
+				criteria = append(criteria, name)
+			}
+		}
+	}
+	return criteria
+}
+
+// TaskID returns the Pilot task ID for a ClickUp task, e.g. "CU-86a1b2c3d".
+// The "CU-" prefix matches ClickUp's own GitHub integration, so branches
+// named after the task are linked back to it automatically.
+func TaskID(task *Task) string {
+	return "CU-" + task.ID
+}
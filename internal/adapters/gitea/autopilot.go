@@ -0,0 +1,372 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// maxMergeAttempts is how many merge API failures are tolerated before giving up.
+const maxMergeAttempts = 3
+
+// Autopilot drives Pilot-created pull requests through CI to merge.
+// It follows the same stages as the GitHub autopilot controller
+// (pr_created → waiting_ci → ci_passed → merging → merged) but reads CI
+// from Gitea commit statuses, which Gitea Actions and external CI both report.
+type Autopilot struct {
+	client *Client
+	config *autopilot.Config
+	logger *slog.Logger
+
+	mu  sync.Mutex
+	prs map[int]*autopilot.PRState
+
+	hooksMu    sync.RWMutex
+	mergeHooks []autopilot.MergeHook
+}
+
+// NewAutopilot creates a Gitea autopilot using the shared autopilot configuration.
+func NewAutopilot(client *Client, config *autopilot.Config) *Autopilot {
+	return &Autopilot{
+		client: client,
+		config: config,
+		logger: logging.WithComponent("gitea-autopilot"),
+		prs:    make(map[int]*autopilot.PRState),
+	}
+}
+
+// AddMergeHook registers a hook invoked after a tracked PR merges.
+func (a *Autopilot) AddMergeHook(hook autopilot.MergeHook) {
+	a.hooksMu.Lock()
+	defer a.hooksMu.Unlock()
+	a.mergeHooks = append(a.mergeHooks, hook)
+}
+
+// OnPRCreated starts tracking a PR. Signature matches the poller callback.
+func (a *Autopilot) OnPRCreated(prNumber int, prURL string, issueNumber int, headSHA, branchName string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	a.prs[prNumber] = &autopilot.PRState{
+		PRNumber:        prNumber,
+		PRURL:           prURL,
+		IssueNumber:     issueNumber,
+		HeadSHA:         headSHA,
+		BranchName:      branchName,
+		Stage:           autopilot.StagePRCreated,
+		CIStatus:        autopilot.CIPending,
+		CreatedAt:       now,
+		EnvironmentName: a.config.EnvironmentName(),
+	}
+
+	a.logger.Info("Tracking Gitea PR",
+		slog.Int("pr", prNumber),
+		slog.Int("issue", issueNumber),
+		slog.String("sha", autopilot.ShortSHA(headSHA)),
+	)
+}
+
+// GetPRState returns a copy of the tracked state for a PR.
+func (a *Autopilot) GetPRState(prNumber int) (autopilot.PRState, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	st, ok := a.prs[prNumber]
+	if !ok {
+		return autopilot.PRState{}, false
+	}
+	return *st, true
+}
+
+// Run processes tracked PRs on every CI poll interval until ctx is done.
+func (a *Autopilot) Run(ctx context.Context) error {
+	interval := a.config.CIPollInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	a.logger.Info("Starting Gitea autopilot",
+		slog.String("env", a.config.EnvironmentName()),
+		slog.Bool("auto_merge", a.config.AutoMerge),
+		slog.Duration("poll_interval", interval),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			a.processAll(ctx)
+		}
+	}
+}
+
+func (a *Autopilot) processAll(ctx context.Context) {
+	a.mu.Lock()
+	numbers := make([]int, 0, len(a.prs))
+	for n := range a.prs {
+		numbers = append(numbers, n)
+	}
+	a.mu.Unlock()
+	sort.Ints(numbers)
+
+	for _, n := range numbers {
+		if err := a.ProcessPR(ctx, n); err != nil {
+			a.logger.Warn("Failed to process Gitea PR", slog.Int("pr", n), slog.Any("error", err))
+		}
+	}
+}
+
+// ProcessPR advances a tracked PR by one step.
+func (a *Autopilot) ProcessPR(ctx context.Context, prNumber int) error {
+	a.mu.Lock()
+	st, ok := a.prs[prNumber]
+	a.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	pr, err := a.client.GetPullRequest(ctx, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get PR: %w", err)
+	}
+	st.LastChecked = time.Now()
+
+	// Merged or closed outside of autopilot
+	if pr.Merged {
+		a.finishMerged(ctx, st)
+		return nil
+	}
+	if pr.State == StateClosed {
+		a.logger.Info("Gitea PR closed without merge, stopping tracking", slog.Int("pr", prNumber))
+		a.remove(prNumber)
+		return nil
+	}
+
+	// New commits restart CI tracking
+	if pr.Head != nil && pr.Head.SHA != "" && pr.Head.SHA != st.HeadSHA {
+		st.HeadSHA = pr.Head.SHA
+		if st.Stage != autopilot.StagePRCreated {
+			st.Stage = autopilot.StageWaitingCI
+			st.CIWaitStartedAt = time.Now()
+		}
+	}
+	if pr.Base != nil {
+		st.TargetBranch = pr.Base.Ref
+	}
+	st.PRTitle = pr.Title
+
+	switch st.Stage {
+	case autopilot.StagePRCreated:
+		st.Stage = autopilot.StageWaitingCI
+		st.CIWaitStartedAt = time.Now()
+		return nil
+	case autopilot.StageWaitingCI:
+		return a.handleWaitingCI(ctx, st)
+	case autopilot.StageCIPassed:
+		return a.handleCIPassed(ctx, st)
+	case autopilot.StageMerging:
+		return a.handleMerging(ctx, st)
+	}
+	return nil
+}
+
+func (a *Autopilot) handleWaitingCI(ctx context.Context, st *autopilot.PRState) error {
+	combined, err := a.client.GetCombinedStatus(ctx, st.HeadSHA)
+	if err != nil {
+		st.ConsecutiveAPIFailures++
+		return fmt.Errorf("failed to get commit status: %w", err)
+	}
+	st.ConsecutiveAPIFailures = 0
+
+	status, failed := a.evaluateStatuses(combined.Statuses, time.Since(st.CIWaitStartedAt))
+	st.CIStatus = status
+
+	switch status {
+	case autopilot.CISuccess:
+		a.logger.Info("Gitea PR CI passed", slog.Int("pr", st.PRNumber), slog.String("sha", autopilot.ShortSHA(st.HeadSHA)))
+		st.Stage = autopilot.StageCIPassed
+		return a.handleCIPassed(ctx, st)
+	case autopilot.CIFailure:
+		st.Stage = autopilot.StageCIFailed
+		st.Error = "CI failed: " + strings.Join(failed, ", ")
+		a.comment(ctx, st.PRNumber, fmt.Sprintf("❌ Pilot autopilot: CI failed on `%s`\n\nFailed checks: %s",
+			autopilot.ShortSHA(st.HeadSHA), strings.Join(failed, ", ")))
+		a.logger.Warn("Gitea PR CI failed", slog.Int("pr", st.PRNumber), slog.Any("checks", failed))
+		a.remove(st.PRNumber)
+		return nil
+	}
+
+	if timeout := a.ciTimeout(); timeout > 0 && time.Since(st.CIWaitStartedAt) > timeout {
+		st.Stage = autopilot.StageFailed
+		st.Error = fmt.Sprintf("CI timeout after %v", timeout)
+		a.comment(ctx, st.PRNumber, fmt.Sprintf("⏱️ Pilot autopilot: CI did not finish within %v. Merge manually once checks pass.", timeout))
+		a.remove(st.PRNumber)
+	}
+	return nil
+}
+
+func (a *Autopilot) handleCIPassed(ctx context.Context, st *autopilot.PRState) error {
+	if !a.config.AutoMerge || a.config.ResolvedEnv().RequireApproval {
+		// Leave the merge to a human; keep tracking so merge hooks still fire.
+		if st.Stage != autopilot.StageAwaitApproval {
+			st.Stage = autopilot.StageAwaitApproval
+			a.comment(ctx, st.PRNumber, "✅ Pilot autopilot: CI passed. Ready for review and merge.")
+		}
+		return nil
+	}
+	st.Stage = autopilot.StageMerging
+	return a.handleMerging(ctx, st)
+}
+
+func (a *Autopilot) handleMerging(ctx context.Context, st *autopilot.PRState) error {
+	st.MergeAttempts++
+	if err := a.client.MergePullRequest(ctx, st.PRNumber, a.mergeStyle(), true); err != nil {
+		if st.MergeAttempts >= maxMergeAttempts {
+			st.Stage = autopilot.StageFailed
+			st.Error = err.Error()
+			a.comment(ctx, st.PRNumber, fmt.Sprintf("❌ Pilot autopilot: merge failed after %d attempts\n\n```\n%s\n```", st.MergeAttempts, err.Error()))
+			a.remove(st.PRNumber)
+		}
+		return fmt.Errorf("failed to merge PR: %w", err)
+	}
+
+	a.logger.Info("Gitea PR merged", slog.Int("pr", st.PRNumber), slog.String("method", a.mergeStyle()))
+	a.finishMerged(ctx, st)
+	return nil
+}
+
+func (a *Autopilot) finishMerged(ctx context.Context, st *autopilot.PRState) {
+	st.Stage = autopilot.StageMerged
+	a.remove(st.PRNumber)
+
+	a.hooksMu.RLock()
+	hooks := append([]autopilot.MergeHook(nil), a.mergeHooks...)
+	a.hooksMu.RUnlock()
+	for _, hook := range hooks {
+		hook(ctx, st)
+	}
+}
+
+// evaluateStatuses reduces commit statuses to a CI verdict, honouring the
+// ci_checks mode (auto with exclusions, or manual required list). In auto
+// mode a commit with no statuses is treated as "no CI" once the discovery
+// grace period has passed. Returns the names of failed checks.
+func (a *Autopilot) evaluateStatuses(statuses []*CommitStatus, waited time.Duration) (autopilot.CIStatus, []string) {
+	checks := a.config.CIChecks
+	grace := 60 * time.Second
+	if checks != nil && checks.DiscoveryGracePeriod > 0 {
+		grace = checks.DiscoveryGracePeriod
+	}
+
+	// Gitea returns every status ever posted; keep the latest per context.
+	latest := make(map[string]string)
+	for i := len(statuses) - 1; i >= 0; i-- {
+		latest[statuses[i].Context] = statuses[i].Status
+	}
+
+	var required []string
+	if checks != nil && checks.Mode == "manual" {
+		required = checks.Required
+	} else if checks == nil && len(a.config.RequiredChecks) > 0 {
+		required = a.config.RequiredChecks
+	}
+
+	relevant := make(map[string]string)
+	if len(required) > 0 {
+		for _, name := range required {
+			relevant[name] = latest[name] // missing → "" → pending
+		}
+	} else {
+		for name, state := range latest {
+			if !a.excluded(name) {
+				relevant[name] = state
+			}
+		}
+		if len(relevant) == 0 {
+			if waited >= grace {
+				return autopilot.CISuccess, nil
+			}
+			return autopilot.CIPending, nil
+		}
+	}
+
+	var failed []string
+	pending := false
+	for name, state := range relevant {
+		switch state {
+		case CommitStatusSuccess, CommitStatusWarning:
+		case CommitStatusFailure, CommitStatusError:
+			failed = append(failed, name)
+		default:
+			pending = true
+		}
+	}
+	sort.Strings(failed)
+
+	switch {
+	case len(failed) > 0:
+		return autopilot.CIFailure, failed
+	case pending:
+		return autopilot.CIRunning, nil
+	default:
+		return autopilot.CISuccess, nil
+	}
+}
+
+func (a *Autopilot) excluded(name string) bool {
+	if a.config.CIChecks == nil {
+		return false
+	}
+	for _, pattern := range a.config.CIChecks.Exclude {
+		if ok, _ := path.Match(pattern, name); ok || pattern == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ciTimeout mirrors CIMonitor: the smaller of ci_wait_timeout and the environment timeout.
+func (a *Autopilot) ciTimeout() time.Duration {
+	timeout := a.config.CIWaitTimeout
+	if env := a.config.ResolvedEnv().CITimeout; env > 0 && (timeout == 0 || env < timeout) {
+		timeout = env
+	}
+	return timeout
+}
+
+func (a *Autopilot) mergeStyle() string {
+	method := a.config.MergeMethod
+	if env := a.config.ResolvedEnv(); env.MergeMethod != "" {
+		method = env.MergeMethod
+	}
+	switch method {
+	case MergeStyleMerge, MergeStyleRebase:
+		return method
+	default:
+		return MergeStyleSquash
+	}
+}
+
+func (a *Autopilot) comment(ctx context.Context, prNumber int, body string) {
+	if _, err := a.client.AddComment(ctx, prNumber, body); err != nil {
+		a.logger.Warn("Failed to comment on Gitea PR", slog.Int("pr", prNumber), slog.Any("error", err))
+	}
+}
+
+func (a *Autopilot) remove(prNumber int) {
+	a.mu.Lock()
+	delete(a.prs, prNumber)
+	a.mu.Unlock()
+}
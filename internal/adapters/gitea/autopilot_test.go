@@ -0,0 +1,210 @@
+package gitea
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
+)
+
+func TestEvaluateStatuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     *autopilot.CIChecksConfig
+		statuses   []*CommitStatus
+		waited     time.Duration
+		want       autopilot.CIStatus
+		wantFailed []string
+	}{
+		{
+			name:   "no statuses within grace period",
+			checks: &autopilot.CIChecksConfig{Mode: "auto"},
+			waited: 10 * time.Second,
+			want:   autopilot.CIPending,
+		},
+		{
+			name:   "no statuses after grace period",
+			checks: &autopilot.CIChecksConfig{Mode: "auto"},
+			waited: 2 * time.Minute,
+			want:   autopilot.CISuccess,
+		},
+		{
+			name:   "all passing",
+			checks: &autopilot.CIChecksConfig{Mode: "auto"},
+			statuses: []*CommitStatus{
+				{Context: "ci / test", Status: CommitStatusSuccess},
+				{Context: "ci / lint", Status: CommitStatusWarning},
+			},
+			want: autopilot.CISuccess,
+		},
+		{
+			name:   "one running",
+			checks: &autopilot.CIChecksConfig{Mode: "auto"},
+			statuses: []*CommitStatus{
+				{Context: "ci / test", Status: CommitStatusSuccess},
+				{Context: "ci / lint", Status: CommitStatusPending},
+			},
+			want: autopilot.CIRunning,
+		},
+		{
+			name:   "latest status wins per context",
+			checks: &autopilot.CIChecksConfig{Mode: "auto"},
+			statuses: []*CommitStatus{
+				{Context: "ci / test", Status: CommitStatusSuccess},
+				{Context: "ci / test", Status: CommitStatusFailure},
+			},
+			want: autopilot.CISuccess,
+		},
+		{
+			name:   "failure reported",
+			checks: &autopilot.CIChecksConfig{Mode: "auto"},
+			statuses: []*CommitStatus{
+				{Context: "ci / test", Status: CommitStatusFailure},
+				{Context: "ci / build", Status: CommitStatusError},
+			},
+			want:       autopilot.CIFailure,
+			wantFailed: []string{"ci / build", "ci / test"},
+		},
+		{
+			name:   "excluded failure ignored",
+			checks: &autopilot.CIChecksConfig{Mode: "auto", Exclude: []string{"deploy*"}},
+			statuses: []*CommitStatus{
+				{Context: "ci / test", Status: CommitStatusSuccess},
+				{Context: "deploy-preview", Status: CommitStatusFailure},
+			},
+			want: autopilot.CISuccess,
+		},
+		{
+			name:   "manual required check missing",
+			checks: &autopilot.CIChecksConfig{Mode: "manual", Required: []string{"ci / test"}},
+			statuses: []*CommitStatus{
+				{Context: "ci / lint", Status: CommitStatusSuccess},
+			},
+			waited: time.Hour,
+			want:   autopilot.CIRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := autopilot.DefaultConfig()
+			cfg.CIChecks = tt.checks
+			a := NewAutopilot(nil, cfg)
+
+			got, failed := a.evaluateStatuses(tt.statuses, tt.waited)
+			if got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.wantFailed, ",") {
+				t.Errorf("failed = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestAutopilot_MergesAfterCIPasses(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.prs[101] = &PullRequest{Number: 101, State: StateOpen, Head: &PRBranch{Ref: "pilot/GITEA-5", SHA: "sha1"}, Base: &PRBranch{Ref: "main"}}
+	f.statuses["sha1"] = []*CommitStatus{{Context: "ci / test", Status: CommitStatusSuccess}}
+
+	cfg := autopilot.DefaultConfig()
+	cfg.Enabled = true
+	a := NewAutopilot(client, cfg)
+
+	var hooked *autopilot.PRState
+	a.AddMergeHook(func(ctx context.Context, st *autopilot.PRState) { hooked = st })
+
+	a.OnPRCreated(101, "https://gitea.test/owner/repo/pulls/101", 5, "sha1", "pilot/GITEA-5")
+
+	ctx := context.Background()
+	// pr_created → waiting_ci
+	if err := a.ProcessPR(ctx, 101); err != nil {
+		t.Fatalf("ProcessPR() error = %v", err)
+	}
+	if st, _ := a.GetPRState(101); st.Stage != autopilot.StageWaitingCI {
+		t.Fatalf("stage = %s, want waiting_ci", st.Stage)
+	}
+	// waiting_ci → merged
+	if err := a.ProcessPR(ctx, 101); err != nil {
+		t.Fatalf("ProcessPR() error = %v", err)
+	}
+
+	if f.merged[101] != MergeStyleSquash {
+		t.Errorf("merge style = %q, want squash", f.merged[101])
+	}
+	if hooked == nil || hooked.Stage != autopilot.StageMerged || hooked.IssueNumber != 5 {
+		t.Errorf("merge hook got %+v", hooked)
+	}
+	if _, ok := a.GetPRState(101); ok {
+		t.Error("merged PR should no longer be tracked")
+	}
+}
+
+func TestAutopilot_CIFailureComments(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.prs[102] = &PullRequest{Number: 102, State: StateOpen, Head: &PRBranch{SHA: "sha2"}}
+	f.statuses["sha2"] = []*CommitStatus{{Context: "ci / test", Status: CommitStatusFailure}}
+
+	cfg := autopilot.DefaultConfig()
+	a := NewAutopilot(client, cfg)
+	a.OnPRCreated(102, "", 6, "sha2", "pilot/GITEA-6")
+
+	ctx := context.Background()
+	_ = a.ProcessPR(ctx, 102)
+	_ = a.ProcessPR(ctx, 102)
+
+	if len(f.merged) != 0 {
+		t.Error("PR with failing CI must not be merged")
+	}
+	comments := f.commentsOn(102)
+	if len(comments) != 1 || !strings.Contains(comments[0], "ci / test") {
+		t.Errorf("expected CI failure comment naming the check, got %v", comments)
+	}
+	if _, ok := a.GetPRState(102); ok {
+		t.Error("failed PR should no longer be tracked")
+	}
+}
+
+func TestAutopilot_AwaitsApprovalWithoutAutoMerge(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.prs[103] = &PullRequest{Number: 103, State: StateOpen, Head: &PRBranch{SHA: "sha3"}}
+	f.statuses["sha3"] = []*CommitStatus{{Context: "ci / test", Status: CommitStatusSuccess}}
+
+	cfg := autopilot.DefaultConfig()
+	cfg.AutoMerge = false
+	a := NewAutopilot(client, cfg)
+	a.OnPRCreated(103, "", 7, "sha3", "pilot/GITEA-7")
+
+	ctx := context.Background()
+	_ = a.ProcessPR(ctx, 103)
+	_ = a.ProcessPR(ctx, 103)
+	_ = a.ProcessPR(ctx, 103)
+
+	if len(f.merged) != 0 {
+		t.Error("PR should not be merged when auto_merge is off")
+	}
+	st, ok := a.GetPRState(103)
+	if !ok || st.Stage != autopilot.StageAwaitApproval {
+		t.Errorf("stage = %s, want awaiting approval", st.Stage)
+	}
+	if comments := f.commentsOn(103); len(comments) != 1 {
+		t.Errorf("expected a single ready-for-review comment, got %v", comments)
+	}
+}
+
+func TestAutopilot_StopsTrackingClosedPR(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.prs[104] = &PullRequest{Number: 104, State: StateClosed}
+
+	a := NewAutopilot(client, autopilot.DefaultConfig())
+	a.OnPRCreated(104, "", 8, "sha4", "")
+
+	if err := a.ProcessPR(context.Background(), 104); err != nil {
+		t.Fatalf("ProcessPR() error = %v", err)
+	}
+	if _, ok := a.GetPRState(104); ok {
+		t.Error("closed PR should no longer be tracked")
+	}
+}
@@ -0,0 +1,283 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// labelColors are used when Pilot creates its status labels on first use
+var labelColors = map[string]string{
+	LabelInProgress: "#fbca04",
+	LabelDone:       "#0e8a16",
+	LabelFailed:     "#d93f0b",
+}
+
+// Client is a Gitea/Forgejo REST API client (API v1)
+type Client struct {
+	token      string
+	baseURL    string // Instance URL without the /api/v1 suffix
+	owner      string
+	repo       string
+	httpClient *http.Client
+
+	// Label name → ID cache; label endpoints on issues take IDs
+	labelsMu sync.Mutex
+	labelIDs map[string]int64
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a new Gitea client.
+// baseURL is the instance URL (e.g. https://gitea.example.com); repo is "owner/name".
+func NewClient(token, baseURL, repo string, opts ...ClientOption) *Client {
+	owner, name, _ := strings.Cut(repo, "/")
+	c := &Client{
+		token:   token,
+		baseURL: strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/api/v1"),
+		owner:   owner,
+		repo:    name,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		labelIDs: make(map[string]int64),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// repoPath returns the API path prefix for the configured repository
+func (c *Client) repoPath() string {
+	return fmt.Sprintf("/api/v1/repos/%s/%s", url.PathEscape(c.owner), url.PathEscape(c.repo))
+}
+
+// doRequest performs an HTTP request to the Gitea API
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "token "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListIssues lists open issues carrying all the given labels, oldest first.
+// Pull requests are excluded.
+func (c *Client) ListIssues(ctx context.Context, labels []string) ([]*Issue, error) {
+	var all []*Issue
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("state", StateOpen)
+		params.Set("type", "issues")
+		params.Set("limit", "50")
+		params.Set("page", strconv.Itoa(page))
+		if len(labels) > 0 {
+			params.Set("labels", strings.Join(labels, ","))
+		}
+
+		var issues []*Issue
+		if err := c.doRequest(ctx, http.MethodGet, c.repoPath()+"/issues?"+params.Encode(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequest == nil {
+				all = append(all, issue)
+			}
+		}
+		if len(issues) < 50 {
+			return all, nil
+		}
+	}
+}
+
+// GetIssue fetches an issue by number
+func (c *Client) GetIssue(ctx context.Context, number int) (*Issue, error) {
+	var issue Issue
+	path := fmt.Sprintf("%s/issues/%d", c.repoPath(), number)
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// AddComment posts a comment on an issue or pull request
+func (c *Client) AddComment(ctx context.Context, number int, body string) (*Comment, error) {
+	var comment Comment
+	path := fmt.Sprintf("%s/issues/%d/comments", c.repoPath(), number)
+	if err := c.doRequest(ctx, http.MethodPost, path, map[string]string{"body": body}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// labelID resolves a repository label name to its ID, creating the label
+// if it does not exist yet.
+func (c *Client) labelID(ctx context.Context, name string) (int64, error) {
+	c.labelsMu.Lock()
+	defer c.labelsMu.Unlock()
+
+	if id, ok := c.labelIDs[strings.ToLower(name)]; ok {
+		return id, nil
+	}
+
+	for page := 1; ; page++ {
+		var labels []*Label
+		path := fmt.Sprintf("%s/labels?limit=50&page=%d", c.repoPath(), page)
+		if err := c.doRequest(ctx, http.MethodGet, path, nil, &labels); err != nil {
+			return 0, fmt.Errorf("failed to list labels: %w", err)
+		}
+		for _, l := range labels {
+			c.labelIDs[strings.ToLower(l.Name)] = l.ID
+		}
+		if len(labels) < 50 {
+			break
+		}
+	}
+	if id, ok := c.labelIDs[strings.ToLower(name)]; ok {
+		return id, nil
+	}
+
+	color := labelColors[name]
+	if color == "" {
+		color = "#c5def5"
+	}
+	var created Label
+	if err := c.doRequest(ctx, http.MethodPost, c.repoPath()+"/labels", map[string]string{"name": name, "color": color}, &created); err != nil {
+		return 0, fmt.Errorf("failed to create label %q: %w", name, err)
+	}
+	c.labelIDs[strings.ToLower(name)] = created.ID
+	return created.ID, nil
+}
+
+// AddLabels adds labels to an issue by name
+func (c *Client) AddLabels(ctx context.Context, number int, labels []string) error {
+	ids := make([]int64, 0, len(labels))
+	for _, name := range labels {
+		id, err := c.labelID(ctx, name)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	path := fmt.Sprintf("%s/issues/%d/labels", c.repoPath(), number)
+	return c.doRequest(ctx, http.MethodPost, path, map[string][]int64{"labels": ids}, nil)
+}
+
+// RemoveLabel removes a label from an issue by name
+func (c *Client) RemoveLabel(ctx context.Context, number int, label string) error {
+	id, err := c.labelID(ctx, label)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("%s/issues/%d/labels/%d", c.repoPath(), number, id)
+	return c.doRequest(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// CreatePullRequest opens a pull request from head into base
+func (c *Client) CreatePullRequest(ctx context.Context, input *CreatePullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.doRequest(ctx, http.MethodPost, c.repoPath()+"/pulls", input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePR opens a pull request and returns its URL.
+// It implements executor.PRCreator so the runner can open PRs on Gitea
+// instead of going through the gh CLI.
+func (c *Client) CreatePR(ctx context.Context, title, body, head, base string) (string, error) {
+	pr, err := c.CreatePullRequest(ctx, &CreatePullRequestInput{
+		Title: title,
+		Body:  body,
+		Head:  head,
+		Base:  base,
+	})
+	if err != nil {
+		return "", err
+	}
+	return pr.HTMLURL, nil
+}
+
+// GetPullRequest fetches a pull request by number
+func (c *Client) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var pr PullRequest
+	path := fmt.Sprintf("%s/pulls/%d", c.repoPath(), number)
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges a pull request using the given merge style
+func (c *Client) MergePullRequest(ctx context.Context, number int, style string, deleteBranch bool) error {
+	path := fmt.Sprintf("%s/pulls/%d/merge", c.repoPath(), number)
+	return c.doRequest(ctx, http.MethodPost, path, &MergePullRequestInput{
+		Do:                     style,
+		DeleteBranchAfterMerge: deleteBranch,
+	}, nil)
+}
+
+// GetCombinedStatus returns the combined commit status for a ref.
+// Gitea Actions reports each job as a commit status.
+func (c *Client) GetCombinedStatus(ctx context.Context, ref string) (*CombinedStatus, error) {
+	var status CombinedStatus
+	path := fmt.Sprintf("%s/commits/%s/status", c.repoPath(), url.PathEscape(ref))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,308 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// fakeGitea is a minimal in-memory Gitea API used by the adapter tests.
+type fakeGitea struct {
+	t *testing.T
+
+	mu          sync.Mutex
+	issues      []*Issue
+	labels      []*Label
+	issueLabels map[int][]int64 // issue number → label IDs
+	comments    map[int][]string
+	prs         map[int]*PullRequest
+	statuses    map[string][]*CommitStatus // sha → statuses, newest first
+	merged      map[int]string             // PR number → merge style
+	mergeErr    bool
+	createdPRs  []CreatePullRequestInput
+}
+
+func newFakeGitea(t *testing.T) (*fakeGitea, *Client) {
+	t.Helper()
+	f := &fakeGitea{
+		t:           t,
+		issueLabels: make(map[int][]int64),
+		comments:    make(map[int][]string),
+		prs:         make(map[int]*PullRequest),
+		statuses:    make(map[string][]*CommitStatus),
+		merged:      make(map[int]string),
+	}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(server.Close)
+	return f, NewClient(testutil.FakeGiteaToken, server.URL, "owner/repo")
+}
+
+func (f *fakeGitea) serve(w http.ResponseWriter, r *http.Request) {
+	if got := r.Header.Get("Authorization"); got != "token "+testutil.FakeGiteaToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/repos/owner/repo")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && path == "/issues":
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page > 1 {
+			writeJSON(w, []*Issue{})
+			return
+		}
+		var out []*Issue
+		want := r.URL.Query().Get("labels")
+		for _, issue := range f.issues {
+			copied := *issue
+			copied.Labels = f.labelsFor(issue.Number)
+			if want != "" && !hasAll(&copied, strings.Split(want, ",")) {
+				continue
+			}
+			out = append(out, &copied)
+		}
+		writeJSON(w, out)
+
+	case r.Method == http.MethodGet && path == "/labels":
+		writeJSON(w, f.labels)
+
+	case r.Method == http.MethodPost && path == "/labels":
+		var in Label
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		in.ID = int64(len(f.labels) + 1)
+		f.labels = append(f.labels, &in)
+		writeJSON(w, in)
+
+	case len(parts) == 3 && parts[0] == "issues" && parts[2] == "labels" && r.Method == http.MethodPost:
+		n, _ := strconv.Atoi(parts[1])
+		var in struct {
+			Labels []int64 `json:"labels"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		f.issueLabels[n] = append(f.issueLabels[n], in.Labels...)
+		writeJSON(w, f.labelsFor(n))
+
+	case len(parts) == 4 && parts[0] == "issues" && parts[2] == "labels" && r.Method == http.MethodDelete:
+		n, _ := strconv.Atoi(parts[1])
+		id, _ := strconv.ParseInt(parts[3], 10, 64)
+		var kept []int64
+		for _, l := range f.issueLabels[n] {
+			if l != id {
+				kept = append(kept, l)
+			}
+		}
+		f.issueLabels[n] = kept
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(parts) == 3 && parts[0] == "issues" && parts[2] == "comments" && r.Method == http.MethodPost:
+		n, _ := strconv.Atoi(parts[1])
+		var in struct {
+			Body string `json:"body"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		f.comments[n] = append(f.comments[n], in.Body)
+		writeJSON(w, Comment{ID: int64(len(f.comments[n])), Body: in.Body})
+
+	case r.Method == http.MethodPost && path == "/pulls":
+		var in CreatePullRequestInput
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		f.createdPRs = append(f.createdPRs, in)
+		n := 100 + len(f.createdPRs)
+		pr := &PullRequest{
+			Number:  n,
+			Title:   in.Title,
+			State:   StateOpen,
+			HTMLURL: fmt.Sprintf("https://gitea.test/owner/repo/pulls/%d", n),
+			Head:    &PRBranch{Ref: in.Head, SHA: "abc1234def"},
+			Base:    &PRBranch{Ref: in.Base},
+		}
+		f.prs[n] = pr
+		writeJSON(w, pr)
+
+	case len(parts) == 2 && parts[0] == "pulls" && r.Method == http.MethodGet:
+		n, _ := strconv.Atoi(parts[1])
+		pr, ok := f.prs[n]
+		if !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, pr)
+
+	case len(parts) == 3 && parts[0] == "pulls" && parts[2] == "merge" && r.Method == http.MethodPost:
+		n, _ := strconv.Atoi(parts[1])
+		if f.mergeErr {
+			http.Error(w, `{"message":"not mergeable"}`, http.StatusMethodNotAllowed)
+			return
+		}
+		var in MergePullRequestInput
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		f.merged[n] = in.Do
+		if pr, ok := f.prs[n]; ok {
+			pr.Merged = true
+			pr.State = StateClosed
+		}
+		w.WriteHeader(http.StatusOK)
+
+	case len(parts) == 3 && parts[0] == "commits" && parts[2] == "status" && r.Method == http.MethodGet:
+		writeJSON(w, CombinedStatus{SHA: parts[1], Statuses: f.statuses[parts[1]]})
+
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitea) labelsFor(number int) []*Label {
+	var out []*Label
+	for _, id := range f.issueLabels[number] {
+		for _, l := range f.labels {
+			if l.ID == id {
+				out = append(out, l)
+			}
+		}
+	}
+	return out
+}
+
+func (f *fakeGitea) issueHasLabel(number int, name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return HasLabel(&Issue{Labels: f.labelsFor(number)}, name)
+}
+
+func (f *fakeGitea) commentsOn(number int) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.comments[number]...)
+}
+
+func hasAll(issue *Issue, labels []string) bool {
+	for _, l := range labels {
+		if !HasLabel(issue, l) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestNewClient_NormalizesBaseURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://gitea.example.com", "https://gitea.example.com"},
+		{"https://gitea.example.com/", "https://gitea.example.com"},
+		{"https://gitea.example.com/api/v1", "https://gitea.example.com"},
+		{"https://gitea.example.com/api/v1/", "https://gitea.example.com"},
+	}
+	for _, tt := range tests {
+		c := NewClient(testutil.FakeGiteaToken, tt.in, "owner/repo")
+		if c.baseURL != tt.want {
+			t.Errorf("NewClient(%q).baseURL = %q, want %q", tt.in, c.baseURL, tt.want)
+		}
+		if c.owner != "owner" || c.repo != "repo" {
+			t.Errorf("owner/repo = %q/%q, want owner/repo", c.owner, c.repo)
+		}
+	}
+}
+
+func TestListIssues_FiltersPullRequests(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.labels = []*Label{{ID: 1, Name: "pilot"}}
+	f.issues = []*Issue{
+		{Number: 1, Title: "issue"},
+		{Number: 2, Title: "pr", PullRequest: &PRReference{}},
+		{Number: 3, Title: "unlabeled"},
+	}
+	f.issueLabels[1] = []int64{1}
+	f.issueLabels[2] = []int64{1}
+
+	issues, err := client.ListIssues(context.Background(), []string{"pilot"})
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].Number != 1 {
+		t.Fatalf("ListIssues() = %+v, want only issue #1", issues)
+	}
+}
+
+func TestAddLabels_CreatesMissingLabel(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.labels = []*Label{{ID: 1, Name: "pilot"}}
+
+	if err := client.AddLabels(context.Background(), 7, []string{LabelInProgress}); err != nil {
+		t.Fatalf("AddLabels() error = %v", err)
+	}
+	if !f.issueHasLabel(7, LabelInProgress) {
+		t.Error("expected issue to carry the in-progress label")
+	}
+	if len(f.labels) != 2 || f.labels[1].Color != labelColors[LabelInProgress] {
+		t.Errorf("expected label to be created with its color, got %+v", f.labels)
+	}
+
+	// Second use resolves from cache without creating another label
+	if err := client.RemoveLabel(context.Background(), 7, LabelInProgress); err != nil {
+		t.Fatalf("RemoveLabel() error = %v", err)
+	}
+	if f.issueHasLabel(7, LabelInProgress) {
+		t.Error("expected in-progress label to be removed")
+	}
+	if len(f.labels) != 2 {
+		t.Errorf("expected no extra labels, got %d", len(f.labels))
+	}
+}
+
+func TestCreatePR_ReturnsURL(t *testing.T) {
+	f, client := newFakeGitea(t)
+
+	url, err := client.CreatePR(context.Background(), "GITEA-1: Fix", "Closes #1", "pilot/GITEA-1", "main")
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	if url != "https://gitea.test/owner/repo/pulls/101" {
+		t.Errorf("CreatePR() url = %q", url)
+	}
+	if len(f.createdPRs) != 1 {
+		t.Fatalf("expected 1 PR, got %d", len(f.createdPRs))
+	}
+	got := f.createdPRs[0]
+	if got.Head != "pilot/GITEA-1" || got.Base != "main" || got.Body != "Closes #1" {
+		t.Errorf("unexpected PR input: %+v", got)
+	}
+}
+
+func TestDoRequest_APIError(t *testing.T) {
+	_, client := newFakeGitea(t)
+
+	_, err := client.GetPullRequest(context.Background(), 999)
+	if err == nil {
+		t.Fatal("expected error for missing PR")
+	}
+	if !strings.Contains(err.Error(), "API error (status 404)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTaskID(t *testing.T) {
+	if got := TaskID(42); got != "GITEA-42" {
+		t.Errorf("TaskID(42) = %q, want GITEA-42", got)
+	}
+}
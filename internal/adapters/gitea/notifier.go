@@ -0,0 +1,58 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+)
+
+// Notifier handles status comments on Gitea issues
+type Notifier struct {
+	client *Client
+}
+
+// NewNotifier creates a new Gitea notifier
+func NewNotifier(client *Client) *Notifier {
+	return &Notifier{client: client}
+}
+
+// NotifyTaskStarted posts a comment when Pilot starts working on an issue
+func (n *Notifier) NotifyTaskStarted(ctx context.Context, issueNumber int, taskID string) error {
+	comment := fmt.Sprintf("🤖 **Pilot started working on this issue**\n\nTask ID: `%s`\n\nI'll post updates as I make progress.", taskID)
+	if _, err := n.client.AddComment(ctx, issueNumber, comment); err != nil {
+		return fmt.Errorf("failed to add start comment: %w", err)
+	}
+	return nil
+}
+
+// NotifyTaskCompleted posts a completion comment
+func (n *Notifier) NotifyTaskCompleted(ctx context.Context, issueNumber int, prURL string, summary string) error {
+	comment := "✅ **Pilot completed this task!**"
+	if prURL != "" {
+		comment += fmt.Sprintf("\n\n**Pull Request:** %s", prURL)
+	}
+	if summary != "" {
+		comment += fmt.Sprintf("\n\n**Summary:**\n%s", summary)
+	}
+	if _, err := n.client.AddComment(ctx, issueNumber, comment); err != nil {
+		return fmt.Errorf("failed to add completion comment: %w", err)
+	}
+	return nil
+}
+
+// NotifyTaskFailed posts a failure comment
+func (n *Notifier) NotifyTaskFailed(ctx context.Context, issueNumber int, reason string) error {
+	comment := fmt.Sprintf("❌ **Pilot could not complete this task**\n\n**Reason:** %s\n\nRemove the `%s` label to retry.", reason, LabelFailed)
+	if _, err := n.client.AddComment(ctx, issueNumber, comment); err != nil {
+		return fmt.Errorf("failed to add failure comment: %w", err)
+	}
+	return nil
+}
+
+// LinkPR posts a comment linking the created pull request
+func (n *Notifier) LinkPR(ctx context.Context, issueNumber int, prNumber int, prURL string) error {
+	comment := fmt.Sprintf("🔗 **Pull Request Created:** #%d\n\n%s", prNumber, prURL)
+	if _, err := n.client.AddComment(ctx, issueNumber, comment); err != nil {
+		return fmt.Errorf("failed to add PR link comment: %w", err)
+	}
+	return nil
+}
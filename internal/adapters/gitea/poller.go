@@ -0,0 +1,366 @@
+package gitea
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// AdapterName is the key used for the generic processed store.
+const AdapterName = "gitea"
+
+// IssueResult is returned by the issue handler with PR information
+type IssueResult struct {
+	Success    bool
+	PRNumber   int    // PR number if created
+	PRURL      string // PR URL if created
+	HeadSHA    string // Head commit SHA of the PR
+	BranchName string // Head branch name (e.g. "pilot/GITEA-42")
+	Error      error
+}
+
+// Poller polls a Gitea repository for issues with the pilot label
+type Poller struct {
+	client   *Client
+	label    string
+	interval time.Duration
+
+	processed map[int]bool
+	mu        sync.RWMutex
+
+	onIssue     func(ctx context.Context, issue *Issue) (*IssueResult, error)
+	onPRCreated func(prNumber int, prURL string, issueNumber int, headSHA, branchName string)
+	logger      *slog.Logger
+
+	// Persistent processed store (optional), keyed under AdapterName
+	processedStore adapters.ProcessedStore
+
+	// Parallel execution configuration
+	maxConcurrent int
+	semaphore     chan struct{}
+	activeWg      sync.WaitGroup
+	stopping      atomic.Bool
+	wgMu          sync.Mutex // protects stopping + activeWg Add/Wait coordination
+}
+
+// PollerOption configures a Poller
+type PollerOption func(*Poller)
+
+// WithOnIssue sets the callback for new pilot-labeled issues
+func WithOnIssue(fn func(ctx context.Context, issue *Issue) (*IssueResult, error)) PollerOption {
+	return func(p *Poller) {
+		p.onIssue = fn
+	}
+}
+
+// WithOnPRCreated sets the callback for when a PR is created for an issue
+func WithOnPRCreated(fn func(prNumber int, prURL string, issueNumber int, headSHA, branchName string)) PollerOption {
+	return func(p *Poller) {
+		p.onPRCreated = fn
+	}
+}
+
+// WithPollerLogger sets the logger for the poller
+func WithPollerLogger(logger *slog.Logger) PollerOption {
+	return func(p *Poller) {
+		p.logger = logger
+	}
+}
+
+// WithProcessedStore sets the persistent store for processed issue tracking.
+// On startup, processed issues are loaded from the store to prevent re-processing after hot upgrade.
+func WithProcessedStore(store adapters.ProcessedStore) PollerOption {
+	return func(p *Poller) {
+		p.processedStore = store
+	}
+}
+
+// WithMaxConcurrent sets the maximum number of parallel issue executions
+func WithMaxConcurrent(n int) PollerOption {
+	return func(p *Poller) {
+		if n < 1 {
+			n = 1
+		}
+		p.maxConcurrent = n
+	}
+}
+
+// NewPoller creates a new Gitea issue poller
+func NewPoller(client *Client, label string, interval time.Duration, opts ...PollerOption) *Poller {
+	p := &Poller{
+		client:    client,
+		label:     label,
+		interval:  interval,
+		processed: make(map[int]bool),
+		logger:    logging.WithComponent("gitea-poller"),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Load processed issues from persistent store if available
+	if p.processedStore != nil {
+		loaded, err := p.processedStore.LoadAdapterProcessed(AdapterName)
+		if err != nil {
+			p.logger.Warn("Failed to load processed issues from store", slog.Any("error", err))
+		} else if len(loaded) > 0 {
+			p.mu.Lock()
+			for id := range loaded {
+				if n, err := strconv.Atoi(id); err == nil {
+					p.processed[n] = true
+				}
+			}
+			p.mu.Unlock()
+			p.logger.Info("Loaded processed issues from store", slog.Int("count", len(loaded)))
+		}
+	}
+
+	if p.maxConcurrent < 1 {
+		p.maxConcurrent = 2 // default
+	}
+	p.semaphore = make(chan struct{}, p.maxConcurrent)
+
+	return p
+}
+
+// Start begins polling for issues
+func (p *Poller) Start(ctx context.Context) {
+	p.logger.Info("Starting Gitea poller",
+		slog.String("label", p.label),
+		slog.Duration("interval", p.interval),
+		slog.Int("max_concurrent", p.maxConcurrent),
+	)
+
+	// Recover orphaned in-progress issues from a previous run
+	p.recoverOrphanedIssues(ctx)
+
+	// Initial check
+	p.checkForNewIssues(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Gitea poller stopping, waiting for active issues...")
+			p.wgMu.Lock()
+			p.stopping.Store(true)
+			p.wgMu.Unlock()
+			p.activeWg.Wait()
+			p.logger.Info("Gitea poller stopped")
+			return
+		case <-ticker.C:
+			p.checkForNewIssues(ctx)
+		}
+	}
+}
+
+// recoverOrphanedIssues removes the in-progress label from issues left behind
+// by a crash or restart so they can be picked up again.
+func (p *Poller) recoverOrphanedIssues(ctx context.Context) {
+	issues, err := p.client.ListIssues(ctx, []string{p.label, LabelInProgress})
+	if err != nil {
+		p.logger.Warn("Failed to check for orphaned issues", slog.Any("error", err))
+		return
+	}
+
+	for _, issue := range issues {
+		if err := p.client.RemoveLabel(ctx, issue.Number, LabelInProgress); err != nil {
+			p.logger.Warn("Failed to remove in-progress label from orphaned issue",
+				slog.Int("number", issue.Number),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		p.ClearProcessed(issue.Number)
+		p.logger.Info("Recovered orphaned issue",
+			slog.Int("number", issue.Number),
+			slog.String("title", issue.Title),
+		)
+	}
+}
+
+func (p *Poller) checkForNewIssues(ctx context.Context) {
+	issues, err := p.client.ListIssues(ctx, []string{p.label})
+	if err != nil {
+		p.logger.Warn("Failed to fetch issues", slog.Any("error", err))
+		return
+	}
+
+	// Sort by creation date (oldest first)
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+	})
+
+	for _, issue := range issues {
+		// Skip if already processed
+		p.mu.RLock()
+		processed := p.processed[issue.Number]
+		p.mu.RUnlock()
+
+		if processed {
+			continue
+		}
+
+		// Skip if has in-progress, done, or failed label
+		if p.hasStatusLabel(issue) {
+			continue
+		}
+
+		// Mark processed immediately to prevent duplicate dispatch on next tick
+		p.markProcessed(issue.Number, "processed")
+
+		// Acquire semaphore slot (blocks if max_concurrent reached)
+		select {
+		case <-ctx.Done():
+			return
+		case p.semaphore <- struct{}{}:
+		}
+
+		p.logger.Info("Dispatching Gitea issue for parallel execution",
+			slog.Int("number", issue.Number),
+			slog.String("title", issue.Title),
+		)
+
+		// Use mutex to coordinate stopping flag check with WaitGroup Add
+		p.wgMu.Lock()
+		if p.stopping.Load() {
+			p.wgMu.Unlock()
+			<-p.semaphore // release slot we acquired
+			return
+		}
+		p.activeWg.Add(1)
+		p.wgMu.Unlock()
+
+		go p.processIssueAsync(ctx, issue)
+	}
+}
+
+// processIssueAsync handles a single issue in a goroutine.
+func (p *Poller) processIssueAsync(ctx context.Context, issue *Issue) {
+	defer p.activeWg.Done()
+	defer func() { <-p.semaphore }() // release slot
+
+	if p.onIssue == nil {
+		return
+	}
+
+	if err := p.client.AddLabels(ctx, issue.Number, []string{LabelInProgress}); err != nil {
+		p.logger.Warn("Failed to add in-progress label",
+			slog.Int("number", issue.Number),
+			slog.Any("error", err),
+		)
+	}
+
+	result, err := p.onIssue(ctx, issue)
+
+	if err := p.client.RemoveLabel(ctx, issue.Number, LabelInProgress); err != nil {
+		p.logger.Warn("Failed to remove in-progress label",
+			slog.Int("number", issue.Number),
+			slog.Any("error", err),
+		)
+	}
+
+	if err != nil || result == nil || !result.Success {
+		if err != nil {
+			p.logger.Error("Failed to process issue",
+				slog.Int("number", issue.Number),
+				slog.Any("error", err),
+			)
+		}
+		_ = p.client.AddLabels(ctx, issue.Number, []string{LabelFailed})
+		// Removing pilot-failed re-queues the issue
+		p.ClearProcessed(issue.Number)
+		return
+	}
+
+	_ = p.client.AddLabels(ctx, issue.Number, []string{LabelDone})
+	p.markProcessed(issue.Number, "done")
+
+	if result.PRNumber > 0 && p.onPRCreated != nil {
+		p.onPRCreated(result.PRNumber, result.PRURL, issue.Number, result.HeadSHA, result.BranchName)
+	}
+}
+
+func (p *Poller) hasStatusLabel(issue *Issue) bool {
+	return HasLabel(issue, LabelInProgress) ||
+		HasLabel(issue, LabelDone) ||
+		HasLabel(issue, LabelFailed)
+}
+
+func (p *Poller) markProcessed(number int, result string) {
+	p.mu.Lock()
+	p.processed[number] = true
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.MarkAdapterProcessed(AdapterName, strconv.Itoa(number), result); err != nil {
+			p.logger.Warn("Failed to persist processed issue", slog.Int("number", number), slog.Any("error", err))
+		}
+	}
+}
+
+// IsProcessed checks if an issue has been processed
+func (p *Poller) IsProcessed(number int) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.processed[number]
+}
+
+// ProcessedCount returns the number of processed issues
+func (p *Poller) ProcessedCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.processed)
+}
+
+// Reset clears the processed issues map
+func (p *Poller) Reset() {
+	p.mu.Lock()
+	p.processed = make(map[int]bool)
+	p.mu.Unlock()
+}
+
+// ClearProcessed removes a specific issue from the processed map (for retry)
+func (p *Poller) ClearProcessed(number int) {
+	p.mu.Lock()
+	delete(p.processed, number)
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.UnmarkAdapterProcessed(AdapterName, strconv.Itoa(number)); err != nil {
+			p.logger.Warn("Failed to unmark issue in store",
+				slog.Int("number", number),
+				slog.Any("error", err))
+		}
+	}
+}
+
+// Drain stops accepting new issues and waits for active executions to finish.
+// Used during hot upgrade to let in-flight work complete before process restart.
+func (p *Poller) Drain() {
+	p.logger.Info("Draining poller — no new issues will be accepted")
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+	p.logger.Info("Poller drained — all active issues completed")
+}
+
+// WaitForActive waits for all active parallel goroutines to finish.
+// Used in tests to synchronize after checkForNewIssues.
+func (p *Poller) WaitForActive() {
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+}
@@ -0,0 +1,104 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoller_SuccessMarksDoneAndReportsPR(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.labels = []*Label{{ID: 1, Name: "pilot"}}
+	f.issues = []*Issue{{Number: 5, Title: "Add feature"}}
+	f.issueLabels[5] = []int64{1}
+
+	var gotPR, gotIssue int
+	var gotBranch string
+	p := NewPoller(client, "pilot", time.Minute,
+		WithOnIssue(func(ctx context.Context, issue *Issue) (*IssueResult, error) {
+			if !f.issueHasLabel(issue.Number, LabelInProgress) {
+				t.Error("expected in-progress label during execution")
+			}
+			return &IssueResult{Success: true, PRNumber: 101, PRURL: "https://gitea.test/owner/repo/pulls/101", BranchName: "pilot/GITEA-5"}, nil
+		}),
+		WithOnPRCreated(func(prNumber int, prURL string, issueNumber int, headSHA, branchName string) {
+			gotPR, gotIssue, gotBranch = prNumber, issueNumber, branchName
+		}),
+	)
+
+	p.checkForNewIssues(context.Background())
+	p.WaitForActive()
+
+	if f.issueHasLabel(5, LabelInProgress) {
+		t.Error("in-progress label should be removed")
+	}
+	if !f.issueHasLabel(5, LabelDone) {
+		t.Error("expected done label")
+	}
+	if !p.IsProcessed(5) {
+		t.Error("expected issue to stay processed")
+	}
+	if gotPR != 101 || gotIssue != 5 || gotBranch != "pilot/GITEA-5" {
+		t.Errorf("onPRCreated got pr=%d issue=%d branch=%q", gotPR, gotIssue, gotBranch)
+	}
+}
+
+func TestPoller_FailureMarksFailedAndClearsProcessed(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.labels = []*Label{{ID: 1, Name: "pilot"}}
+	f.issues = []*Issue{{Number: 6, Title: "Broken"}}
+	f.issueLabels[6] = []int64{1}
+
+	p := NewPoller(client, "pilot", time.Minute,
+		WithOnIssue(func(ctx context.Context, issue *Issue) (*IssueResult, error) {
+			return &IssueResult{Success: false}, errors.New("boom")
+		}),
+	)
+
+	p.checkForNewIssues(context.Background())
+	p.WaitForActive()
+
+	if !f.issueHasLabel(6, LabelFailed) {
+		t.Error("expected failed label")
+	}
+	if p.IsProcessed(6) {
+		t.Error("failed issue should be cleared for retry")
+	}
+}
+
+func TestPoller_SkipsStatusLabeledIssues(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.labels = []*Label{{ID: 1, Name: "pilot"}, {ID: 2, Name: LabelDone}}
+	f.issues = []*Issue{{Number: 7, Title: "Already done"}}
+	f.issueLabels[7] = []int64{1, 2}
+
+	called := false
+	p := NewPoller(client, "pilot", time.Minute,
+		WithOnIssue(func(ctx context.Context, issue *Issue) (*IssueResult, error) {
+			called = true
+			return &IssueResult{Success: true}, nil
+		}),
+	)
+
+	p.checkForNewIssues(context.Background())
+	p.WaitForActive()
+
+	if called {
+		t.Error("issue with done label should not be dispatched")
+	}
+}
+
+func TestPoller_RecoversOrphanedIssues(t *testing.T) {
+	f, client := newFakeGitea(t)
+	f.labels = []*Label{{ID: 1, Name: "pilot"}, {ID: 2, Name: LabelInProgress}}
+	f.issues = []*Issue{{Number: 8, Title: "Orphan"}}
+	f.issueLabels[8] = []int64{1, 2}
+
+	p := NewPoller(client, "pilot", time.Minute)
+	p.recoverOrphanedIssues(context.Background())
+
+	if f.issueHasLabel(8, LabelInProgress) {
+		t.Error("expected in-progress label to be removed from orphan")
+	}
+}
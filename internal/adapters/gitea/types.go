@@ -0,0 +1,164 @@
+package gitea
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config holds Gitea/Forgejo adapter configuration
+type Config struct {
+	Enabled    bool           `yaml:"enabled"`
+	Token      string         `yaml:"token"`       // Access token with repository and issue scope
+	BaseURL    string         `yaml:"base_url"`    // Instance URL, e.g. https://gitea.example.com
+	Repo       string         `yaml:"repo"`        // Repository in "owner/name" format
+	PilotLabel string         `yaml:"pilot_label"` // default: "pilot"
+	Polling    *PollingConfig `yaml:"polling,omitempty"`
+}
+
+// PollingConfig holds polling configuration for the Gitea adapter
+type PollingConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultConfig returns default Gitea configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:    false,
+		PilotLabel: "pilot",
+		Polling: &PollingConfig{
+			Enabled:  false,
+			Interval: 30 * time.Second,
+		},
+	}
+}
+
+// Issue states
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// Label names used by Pilot
+const (
+	LabelInProgress = "pilot-in-progress"
+	LabelDone       = "pilot-done"
+	LabelFailed     = "pilot-failed"
+)
+
+// Combined commit status states (Gitea Actions and external CI report through these)
+const (
+	CommitStatusPending = "pending"
+	CommitStatusSuccess = "success"
+	CommitStatusError   = "error"
+	CommitStatusFailure = "failure"
+	CommitStatusWarning = "warning"
+)
+
+// Merge styles accepted by the merge endpoint
+const (
+	MergeStyleMerge  = "merge"
+	MergeStyleRebase = "rebase"
+	MergeStyleSquash = "squash"
+)
+
+// Issue represents a Gitea issue
+type Issue struct {
+	ID          int64        `json:"id"`
+	Number      int          `json:"number"`
+	Title       string       `json:"title"`
+	Body        string       `json:"body"`
+	State       string       `json:"state"`
+	Labels      []*Label     `json:"labels"`
+	HTMLURL     string       `json:"html_url"`
+	PullRequest *PRReference `json:"pull_request,omitempty"` // Set when the issue is a pull request
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// PRReference marks an issue entry that is a pull request
+type PRReference struct {
+	Merged bool `json:"merged"`
+}
+
+// Label represents a repository label
+type Label struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Comment represents an issue comment
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PullRequest represents a Gitea pull request
+type PullRequest struct {
+	ID        int64     `json:"id"`
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"` // open, closed
+	HTMLURL   string    `json:"html_url"`
+	Mergeable bool      `json:"mergeable"`
+	Merged    bool      `json:"merged"`
+	Head      *PRBranch `json:"head"`
+	Base      *PRBranch `json:"base"`
+}
+
+// PRBranch is the head or base of a pull request
+type PRBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// CreatePullRequestInput is the body for creating a pull request
+type CreatePullRequestInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+// MergePullRequestInput is the body for merging a pull request
+type MergePullRequestInput struct {
+	Do                     string `json:"Do"` // merge, rebase, squash
+	DeleteBranchAfterMerge bool   `json:"delete_branch_after_merge,omitempty"`
+}
+
+// CombinedStatus is the aggregated commit status for a ref
+type CombinedStatus struct {
+	State      string          `json:"state"`
+	SHA        string          `json:"sha"`
+	TotalCount int             `json:"total_count"`
+	Statuses   []*CommitStatus `json:"statuses"`
+}
+
+// CommitStatus is a single status reported by a CI job
+type CommitStatus struct {
+	ID          int64  `json:"id"`
+	Context     string `json:"context"`
+	Status      string `json:"status"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+}
+
+// HasLabel reports whether the issue carries a label (case-insensitive)
+func HasLabel(issue *Issue, name string) bool {
+	for _, l := range issue.Labels {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskID returns the Pilot task ID for an issue, e.g. "GITEA-42"
+func TaskID(number int) string {
+	return fmt.Sprintf("GITEA-%d", number)
+}
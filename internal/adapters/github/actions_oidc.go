@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// GitHub Actions runtime variables exposed to jobs with `permissions: id-token: write`.
+const (
+	actionsIDTokenURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	actionsIDTokenTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+var oidcHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ActionsOIDCAvailable reports whether the current process runs in a GitHub
+// Actions job that may request an OIDC identity token.
+func ActionsOIDCAvailable() bool {
+	return os.Getenv(actionsIDTokenURLEnv) != "" && os.Getenv(actionsIDTokenTokenEnv) != ""
+}
+
+// ActionsIDToken requests an OIDC identity token for the running workflow
+// job from the Actions runtime. audience may be empty for the default.
+func ActionsIDToken(ctx context.Context, audience string) (string, error) {
+	requestURL := os.Getenv(actionsIDTokenURLEnv)
+	requestToken := os.Getenv(actionsIDTokenTokenEnv)
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("OIDC token unavailable: job needs `permissions: id-token: write`")
+	}
+
+	if audience != "" {
+		u, err := url.Parse(requestURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: %w", actionsIDTokenURLEnv, err)
+		}
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+		requestURL = u.String()
+	}
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := getTokenJSON(ctx, requestURL, requestToken, &out); err != nil {
+		return "", fmt.Errorf("failed to request OIDC token: %w", err)
+	}
+	if out.Value == "" {
+		return "", fmt.Errorf("failed to request OIDC token: empty response")
+	}
+	return out.Value, nil
+}
+
+// ExchangeOIDCToken trades a workflow OIDC token for a GitHub token at a
+// workload identity exchange service (e.g. an octo-sts style broker backed
+// by a GitHub App). The service receives the OIDC token as a bearer token
+// and the target repository as the "scope" query parameter, and must reply
+// with {"token": "..."}.
+func ExchangeOIDCToken(ctx context.Context, exchangeURL, idToken, scope string) (string, error) {
+	u, err := url.Parse(exchangeURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid token exchange URL: %w", err)
+	}
+	if scope != "" {
+		q := u.Query()
+		q.Set("scope", scope)
+		u.RawQuery = q.Encode()
+	}
+
+	var out struct {
+		Token string `json:"token"`
+	}
+	if err := getTokenJSON(ctx, u.String(), idToken, &out); err != nil {
+		return "", fmt.Errorf("token exchange failed: %w", err)
+	}
+	if out.Token == "" {
+		return "", fmt.Errorf("token exchange failed: response has no token")
+	}
+	return out.Token, nil
+}
+
+func getTokenJSON(ctx context.Context, rawURL, bearer string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActionsIDToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer runtime-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got := r.URL.Query().Get("audience"); got != "pilot" {
+			t.Errorf("audience = %q, want pilot", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": "jwt-123"})
+	}))
+	defer server.Close()
+
+	t.Setenv(actionsIDTokenURLEnv, "")
+	t.Setenv(actionsIDTokenTokenEnv, "")
+	if ActionsOIDCAvailable() {
+		t.Fatal("ActionsOIDCAvailable() = true without runtime env")
+	}
+	if _, err := ActionsIDToken(context.Background(), "pilot"); err == nil {
+		t.Error("expected error without runtime env")
+	}
+
+	t.Setenv(actionsIDTokenURLEnv, server.URL+"/token?api-version=2.0")
+	t.Setenv(actionsIDTokenTokenEnv, "runtime-token")
+	token, err := ActionsIDToken(context.Background(), "pilot")
+	if err != nil {
+		t.Fatalf("ActionsIDToken: %v", err)
+	}
+	if token != "jwt-123" {
+		t.Errorf("token = %q, want jwt-123", token)
+	}
+}
+
+func TestExchangeOIDCToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer jwt-123" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("bad identity"))
+			return
+		}
+		if got := r.URL.Query().Get("scope"); got != "acme/api" {
+			t.Errorf("scope = %q, want acme/api", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "ghs_abc"})
+	}))
+	defer server.Close()
+
+	token, err := ExchangeOIDCToken(context.Background(), server.URL+"/sts/exchange", "jwt-123", "acme/api")
+	if err != nil {
+		t.Fatalf("ExchangeOIDCToken: %v", err)
+	}
+	if token != "ghs_abc" {
+		t.Errorf("token = %q, want ghs_abc", token)
+	}
+
+	if _, err := ExchangeOIDCToken(context.Background(), server.URL, "wrong", "acme/api"); err == nil {
+		t.Error("expected error for rejected identity")
+	}
+}
@@ -0,0 +1,449 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// maxReposPerBatch keeps each batch query well under GitHub's GraphQL node
+// limit: 100 issues and 30 pull requests with 50 checks each per repo
+const maxReposPerBatch = 20
+
+const batchRepoFields = `
+fragment batchRepo on Repository {
+  label(name: $label) {
+    issues(first: 100, states: OPEN, orderBy: {field: CREATED_AT, direction: ASC}) {
+      nodes {
+        databaseId id number title body state url createdAt updatedAt
+        author { login }
+        assignees(first: 10) { nodes { databaseId login } }
+        labels(first: 30) { nodes { name description color } }
+        comments { totalCount }
+      }
+    }
+  }
+  pullRequests(first: 30, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+    nodes {
+      databaseId id number title body url isDraft mergeable mergeStateStatus createdAt updatedAt
+      author { login }
+      headRefName headRefOid baseRefName baseRefOid
+      commits(last: 1) {
+        nodes {
+          commit {
+            oid
+            statusCheckRollup {
+              contexts(first: 50) {
+                pageInfo { hasNextPage }
+                nodes {
+                  __typename
+                  ... on CheckRun { databaseId name status conclusion detailsUrl startedAt completedAt }
+                  ... on StatusContext { context state targetUrl description createdAt }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type batchRepo struct {
+	Label *struct {
+		Issues struct {
+			Nodes []batchIssue `json:"nodes"`
+		} `json:"issues"`
+	} `json:"label"`
+	PullRequests struct {
+		Nodes []batchPullRequest `json:"nodes"`
+	} `json:"pullRequests"`
+}
+
+type batchIssue struct {
+	DatabaseID int64     `json:"databaseId"`
+	ID         string    `json:"id"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	State      string    `json:"state"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Author     *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Assignees struct {
+		Nodes []struct {
+			DatabaseID int64  `json:"databaseId"`
+			Login      string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Labels struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+}
+
+type batchPullRequest struct {
+	DatabaseID       int64  `json:"databaseId"`
+	ID               string `json:"id"`
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+	URL              string `json:"url"`
+	IsDraft          bool   `json:"isDraft"`
+	Mergeable        string `json:"mergeable"`        // MERGEABLE, CONFLICTING, UNKNOWN
+	MergeStateStatus string `json:"mergeStateStatus"` // CLEAN, DIRTY, UNSTABLE, BLOCKED, BEHIND, ...
+	CreatedAt        string `json:"createdAt"`
+	UpdatedAt        string `json:"updatedAt"`
+	Author           *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	HeadRefName string `json:"headRefName"`
+	HeadRefOid  string `json:"headRefOid"`
+	BaseRefName string `json:"baseRefName"`
+	BaseRefOid  string `json:"baseRefOid"`
+	Commits     struct {
+		Nodes []struct {
+			Commit struct {
+				Oid               string `json:"oid"`
+				StatusCheckRollup *struct {
+					Contexts struct {
+						PageInfo struct {
+							HasNextPage bool `json:"hasNextPage"`
+						} `json:"pageInfo"`
+						Nodes []batchCheckContext `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// batchCheckContext is a CheckRun or a StatusContext of a status rollup
+type batchCheckContext struct {
+	Typename    string `json:"__typename"`
+	DatabaseID  int64  `json:"databaseId"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Conclusion  string `json:"conclusion"`
+	DetailsURL  string `json:"detailsUrl"`
+	StartedAt   string `json:"startedAt"`
+	CompletedAt string `json:"completedAt"`
+	Context     string `json:"context"`
+	State       string `json:"state"`
+	TargetURL   string `json:"targetUrl"`
+	Description string `json:"description"`
+}
+
+type batchSnapshot struct {
+	fetchedAt time.Time
+	issues    map[string][]*Issue // Labeled open issues by owner/repo
+	errs      map[string]error    // Repos the last batch could not read
+	err       error               // Set when the whole batch failed
+}
+
+// BatchPoller fetches labeled issues and open pull requests with their CI
+// status for many repos in one GraphQL query per interval. Per-repo
+// pollers read their issues from it instead of listing them over REST;
+// pull requests, commit statuses and check runs are put in the response
+// cache, so GetPullRequest, GetCombinedStatus and ListCheckRuns callers
+// such as the autopilot CI monitor are served without a request of their
+// own. A repo the batch cannot read falls back to REST.
+type BatchPoller struct {
+	client   *Client
+	label    string
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu    sync.Mutex
+	repos []string
+
+	fetchMu sync.Mutex
+	snap    *batchSnapshot
+}
+
+// NewBatchPoller creates a batch poller for issues with label. Repos are
+// added by the pollers using it.
+func NewBatchPoller(client *Client, label string, interval time.Duration) *BatchPoller {
+	return &BatchPoller{
+		client:   client,
+		label:    label,
+		interval: interval,
+		logger:   logging.WithComponent("github-batch"),
+	}
+}
+
+// AddRepo includes owner/repo in every batch from the next fetch on
+func (b *BatchPoller) AddRepo(repo string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, r := range b.repos {
+		if strings.EqualFold(r, repo) {
+			return
+		}
+	}
+	b.repos = append(b.repos, repo)
+}
+
+// Issues returns the open issues of owner/repo carrying the batch label.
+// The batch is refetched once it is older than the interval, or than
+// notBefore when the caller changed state the last batch could not see.
+func (b *BatchPoller) Issues(ctx context.Context, owner, repo string, notBefore time.Time) ([]*Issue, error) {
+	snap := b.snapshot(ctx, notBefore)
+	if snap.err != nil {
+		return nil, snap.err
+	}
+	key := strings.ToLower(owner + "/" + repo)
+	if err, ok := snap.errs[key]; ok {
+		return nil, err
+	}
+	issues, ok := snap.issues[key]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s is not in the batch", owner, repo)
+	}
+	return issues, nil
+}
+
+// snapshot returns the current batch, fetching a new one when it is stale.
+// Concurrent callers share one fetch.
+func (b *BatchPoller) snapshot(ctx context.Context, notBefore time.Time) *batchSnapshot {
+	b.fetchMu.Lock()
+	defer b.fetchMu.Unlock()
+	now := time.Now()
+	if b.snap != nil && now.Sub(b.snap.fetchedAt) < b.interval && !b.snap.fetchedAt.Before(notBefore) {
+		return b.snap
+	}
+
+	b.mu.Lock()
+	repos := append([]string(nil), b.repos...)
+	b.mu.Unlock()
+
+	snap := &batchSnapshot{
+		fetchedAt: now,
+		issues:    make(map[string][]*Issue),
+		errs:      make(map[string]error),
+	}
+	for start := 0; start < len(repos); start += maxReposPerBatch {
+		end := start + maxReposPerBatch
+		if end > len(repos) {
+			end = len(repos)
+		}
+		if err := b.fetch(ctx, repos[start:end], snap); err != nil {
+			for _, repo := range repos[start:end] {
+				snap.errs[strings.ToLower(repo)] = err
+			}
+		}
+	}
+	if len(repos) > 0 && len(snap.errs) == len(repos) {
+		for _, err := range snap.errs {
+			snap.err = err
+			break
+		}
+		b.logger.Warn("Batch poll failed, pollers fall back to REST", slog.Any("error", snap.err))
+	}
+	b.snap = snap
+	return snap
+}
+
+// fetch reads one chunk of repos into snap
+func (b *BatchPoller) fetch(ctx context.Context, repos []string, snap *batchSnapshot) error {
+	var query strings.Builder
+	vars := map[string]interface{}{"label": b.label}
+	query.WriteString("query($label: String!")
+	for i := range repos {
+		fmt.Fprintf(&query, ", $o%d: String!, $n%d: String!", i, i)
+	}
+	query.WriteString(") {\n")
+	for i, repo := range repos {
+		owner, name, _ := strings.Cut(repo, "/")
+		vars[fmt.Sprintf("o%d", i)] = owner
+		vars[fmt.Sprintf("n%d", i)] = name
+		fmt.Fprintf(&query, "  r%d: repository(owner: $o%d, name: $n%d) { ...batchRepo }\n", i, i, i)
+	}
+	query.WriteString("}\n")
+	query.WriteString(batchRepoFields)
+
+	data, errs, err := b.client.executeGraphQLPartial(ctx, query.String(), vars)
+	if err != nil {
+		return err
+	}
+	var result map[string]*batchRepo
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("unmarshal batch poll: %w", err)
+	}
+
+	for i, repo := range repos {
+		key := strings.ToLower(repo)
+		r := result[fmt.Sprintf("r%d", i)]
+		if r == nil {
+			msg := "repository not found"
+			if len(errs) > 0 {
+				msg = errs[0].Message
+			}
+			snap.errs[key] = fmt.Errorf("batch poll of %s: %s", repo, msg)
+			continue
+		}
+		snap.issues[key] = r.issues()
+		owner, name, _ := strings.Cut(repo, "/")
+		b.prefill(owner, name, r.PullRequests.Nodes)
+	}
+	return nil
+}
+
+// prefill caches the REST form of each open pull request and the CI status
+// of its head commit until the next batch is due
+func (b *BatchPoller) prefill(owner, repo string, prs []batchPullRequest) {
+	base := rateLimitKey(b.client.baseURL, b.client.token)
+	until := time.Now().Add(b.interval)
+	for _, pr := range prs {
+		if body, err := json.Marshal(pr.restPullRequest()); err == nil {
+			etagCache.prefill(base+fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, pr.Number), body, until)
+		}
+		if len(pr.Commits.Nodes) == 0 {
+			continue
+		}
+		commit := pr.Commits.Nodes[0].Commit
+		if commit.Oid != pr.HeadRefOid {
+			continue
+		}
+		var contexts []batchCheckContext
+		if rollup := commit.StatusCheckRollup; rollup != nil {
+			// Truncated rollups are left to REST
+			if rollup.Contexts.PageInfo.HasNextPage {
+				continue
+			}
+			contexts = rollup.Contexts.Nodes
+		}
+		checks, status := restCheckStatus(commit.Oid, contexts)
+		if body, err := json.Marshal(checks); err == nil {
+			etagCache.prefill(base+fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs", owner, repo, commit.Oid), body, until)
+		}
+		if body, err := json.Marshal(status); err == nil {
+			etagCache.prefill(base+fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, commit.Oid), body, until)
+		}
+	}
+}
+
+func (r *batchRepo) issues() []*Issue {
+	issues := []*Issue{}
+	if r.Label == nil {
+		return issues
+	}
+	for _, n := range r.Label.Issues.Nodes {
+		issue := &Issue{
+			ID:        n.DatabaseID,
+			NodeID:    n.ID,
+			Number:    n.Number,
+			Title:     n.Title,
+			Body:      n.Body,
+			State:     strings.ToLower(n.State),
+			Labels:    n.Labels.Nodes,
+			HTMLURL:   n.URL,
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+			Comments:  n.Comments.TotalCount,
+		}
+		if n.Author != nil {
+			issue.User = User{Login: n.Author.Login}
+		}
+		for _, a := range n.Assignees.Nodes {
+			issue.Assignees = append(issue.Assignees, User{ID: a.DatabaseID, Login: a.Login})
+		}
+		if len(issue.Assignees) > 0 {
+			issue.Assignee = &issue.Assignees[0]
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func (pr *batchPullRequest) restPullRequest() *PullRequest {
+	out := &PullRequest{
+		ID:             pr.DatabaseID,
+		NodeID:         pr.ID,
+		Number:         pr.Number,
+		Title:          pr.Title,
+		Body:           pr.Body,
+		State:          StateOpen,
+		Head:           PRRef{Ref: pr.HeadRefName, SHA: pr.HeadRefOid},
+		Base:           PRRef{Ref: pr.BaseRefName, SHA: pr.BaseRefOid},
+		HTMLURL:        pr.URL,
+		Draft:          pr.IsDraft,
+		MergeableState: strings.ToLower(pr.MergeStateStatus),
+		CreatedAt:      pr.CreatedAt,
+		UpdatedAt:      pr.UpdatedAt,
+	}
+	if pr.Author != nil {
+		out.User = User{Login: pr.Author.Login}
+	}
+	switch pr.Mergeable {
+	case "MERGEABLE":
+		mergeable := true
+		out.Mergeable = &mergeable
+	case "CONFLICTING":
+		mergeable := false
+		out.Mergeable = &mergeable
+	}
+	return out
+}
+
+// restCheckStatus splits a status rollup into the check runs and combined
+// status the REST API returns for the commit
+func restCheckStatus(sha string, contexts []batchCheckContext) (*CheckRunsResponse, *CombinedStatus) {
+	checks := &CheckRunsResponse{CheckRuns: []CheckRun{}}
+	status := &CombinedStatus{SHA: sha, Statuses: []CommitStatus{}}
+	failed, pending := false, false
+	for _, c := range contexts {
+		switch c.Typename {
+		case "CheckRun":
+			checks.CheckRuns = append(checks.CheckRuns, CheckRun{
+				ID:          c.DatabaseID,
+				HeadSHA:     sha,
+				Name:        c.Name,
+				Status:      strings.ToLower(c.Status),
+				Conclusion:  strings.ToLower(c.Conclusion),
+				DetailsURL:  c.DetailsURL,
+				StartedAt:   c.StartedAt,
+				CompletedAt: c.CompletedAt,
+			})
+		case "StatusContext":
+			state := strings.ToLower(c.State)
+			if state == "expected" {
+				state = "pending"
+			}
+			switch state {
+			case "error", "failure":
+				failed = true
+			case "pending":
+				pending = true
+			}
+			status.Statuses = append(status.Statuses, CommitStatus{
+				State:       state,
+				TargetURL:   c.TargetURL,
+				Description: c.Description,
+				Context:     c.Context,
+			})
+		}
+	}
+	checks.TotalCount = len(checks.CheckRuns)
+	status.TotalCount = len(status.Statuses)
+	switch {
+	case failed:
+		status.State = "failure"
+	case pending || len(status.Statuses) == 0:
+		status.State = "pending"
+	default:
+		status.State = "success"
+	}
+	return checks, status
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+const batchResponse = `{
+  "data": {
+    "r0": {
+      "label": {"issues": {"nodes": [{
+        "databaseId": 101, "id": "I_1", "number": 1, "title": "Add login", "body": "Depends on #2",
+        "state": "OPEN", "url": "https://github.com/acme/api/issues/1",
+        "createdAt": "2026-01-02T10:00:00Z", "updatedAt": "2026-01-02T11:00:00Z",
+        "author": {"login": "alice"},
+        "assignees": {"nodes": [{"databaseId": 7, "login": "bob"}]},
+        "labels": {"nodes": [{"name": "pilot"}, {"name": "pilot-in-progress"}]},
+        "comments": {"totalCount": 3}
+      }]}},
+      "pullRequests": {"nodes": [{
+        "databaseId": 501, "id": "PR_5", "number": 5, "title": "GH-1: Add login", "url": "https://github.com/acme/api/pull/5",
+        "isDraft": false, "mergeable": "MERGEABLE", "mergeStateStatus": "CLEAN",
+        "author": {"login": "pilot-bot"},
+        "headRefName": "pilot/GH-1", "headRefOid": "abc123", "baseRefName": "main", "baseRefOid": "def456",
+        "commits": {"nodes": [{"commit": {"oid": "abc123", "statusCheckRollup": {"contexts": {
+          "pageInfo": {"hasNextPage": false},
+          "nodes": [
+            {"__typename": "CheckRun", "databaseId": 9001, "name": "test", "status": "COMPLETED", "conclusion": "FAILURE"},
+            {"__typename": "StatusContext", "context": "ci/lint", "state": "SUCCESS"}
+          ]
+        }}}}]}
+      }]}
+    },
+    "r1": null
+  },
+  "errors": [{"message": "Could not resolve to a Repository with the name 'acme/gone'."}]
+}`
+
+func newBatchServer(t *testing.T, graphqlCalls, restCalls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphql" {
+			graphqlCalls.Add(1)
+			var req GraphQLRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Variables["o0"] != "acme" || req.Variables["n1"] != "gone" || req.Variables["label"] != "pilot" {
+				t.Errorf("variables = %v", req.Variables)
+			}
+			_, _ = w.Write([]byte(batchResponse))
+			return
+		}
+		restCalls.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/check-runs") {
+			_, _ = w.Write([]byte(`{"total_count": 0, "check_runs": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBatchPoller_Issues(t *testing.T) {
+	var graphqlCalls, restCalls atomic.Int32
+	server := newBatchServer(t, &graphqlCalls, &restCalls)
+	batch := NewBatchPoller(NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), "pilot", time.Minute)
+	batch.AddRepo("acme/api")
+	batch.AddRepo("acme/gone")
+
+	issues, err := batch.Issues(context.Background(), "acme", "api", time.Time{})
+	if err != nil {
+		t.Fatalf("Issues() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1", len(issues))
+	}
+	issue := issues[0]
+	if issue.ID != 101 || issue.NodeID != "I_1" || issue.Number != 1 || issue.State != "open" ||
+		issue.User.Login != "alice" || issue.Assignee == nil || issue.Assignee.Login != "bob" || issue.Comments != 3 {
+		t.Errorf("issue = %+v", issue)
+	}
+	if !HasLabel(issue, LabelInProgress) {
+		t.Errorf("labels = %+v, want pilot-in-progress", issue.Labels)
+	}
+
+	if _, err := batch.Issues(context.Background(), "acme", "gone", time.Time{}); err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("Issues() for a missing repo = %v, want its GraphQL error", err)
+	}
+
+	// Within the interval the batch is reused, unless the caller changed state since
+	_, _ = batch.Issues(context.Background(), "acme", "api", time.Time{})
+	if graphqlCalls.Load() != 1 {
+		t.Errorf("graphql calls = %d, want 1 within the interval", graphqlCalls.Load())
+	}
+	_, _ = batch.Issues(context.Background(), "acme", "api", time.Now())
+	if graphqlCalls.Load() != 2 {
+		t.Errorf("graphql calls = %d, want a refetch for a newer notBefore", graphqlCalls.Load())
+	}
+}
+
+func TestBatchPoller_PrefillsPullRequestsAndChecks(t *testing.T) {
+	var graphqlCalls, restCalls atomic.Int32
+	server := newBatchServer(t, &graphqlCalls, &restCalls)
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	batch := NewBatchPoller(client, "pilot", time.Minute)
+	batch.AddRepo("acme/api")
+	batch.AddRepo("acme/gone")
+	if _, err := batch.Issues(context.Background(), "acme", "api", time.Time{}); err != nil {
+		t.Fatalf("Issues() error = %v", err)
+	}
+
+	ctx := context.Background()
+	pr, err := client.GetPullRequest(ctx, "acme", "api", 5)
+	if err != nil {
+		t.Fatalf("GetPullRequest() error = %v", err)
+	}
+	if pr.Head.SHA != "abc123" || pr.Head.Ref != "pilot/GH-1" || pr.State != StateOpen ||
+		pr.Mergeable == nil || !*pr.Mergeable || pr.MergeableState != "clean" {
+		t.Errorf("pull request = %+v", pr)
+	}
+
+	checks, err := client.ListCheckRuns(ctx, "acme", "api", "abc123")
+	if err != nil {
+		t.Fatalf("ListCheckRuns() error = %v", err)
+	}
+	if checks.TotalCount != 1 || checks.CheckRuns[0].ID != 9001 || checks.CheckRuns[0].Conclusion != ConclusionFailure {
+		t.Errorf("check runs = %+v", checks)
+	}
+
+	status, err := client.GetCombinedStatus(ctx, "acme", "api", "abc123")
+	if err != nil {
+		t.Fatalf("GetCombinedStatus() error = %v", err)
+	}
+	if status.State != "success" || status.TotalCount != 1 || status.Statuses[0].Context != "ci/lint" {
+		t.Errorf("combined status = %+v", status)
+	}
+
+	if restCalls.Load() != 0 {
+		t.Errorf("REST calls = %d, want all served from the batch", restCalls.Load())
+	}
+
+	// Commits outside the batch still go to REST
+	if _, err := client.ListCheckRuns(ctx, "acme", "api", "other"); err != nil {
+		t.Fatalf("ListCheckRuns() error = %v", err)
+	}
+	if restCalls.Load() != 1 {
+		t.Errorf("REST calls = %d, want 1", restCalls.Load())
+	}
+}
+
+func TestPoller_WithBatchPoller(t *testing.T) {
+	var graphqlCalls, restCalls atomic.Int32
+	server := newBatchServer(t, &graphqlCalls, &restCalls)
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	batch := NewBatchPoller(client, "pilot", time.Minute)
+
+	api, err := NewPoller(client, "acme/api", "Pilot", time.Minute, WithBatchPoller(batch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gone, err := NewPoller(client, "acme/gone", "pilot", time.Minute, WithBatchPoller(batch))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := api.listLabeledIssues(context.Background())
+	if err != nil || len(issues) != 1 {
+		t.Fatalf("listLabeledIssues() = %v, %v; want the batched issue", issues, err)
+	}
+	if restCalls.Load() != 0 {
+		t.Errorf("REST calls = %d, want 0", restCalls.Load())
+	}
+
+	// A repo the batch cannot read is listed over REST
+	if _, err := gone.listLabeledIssues(context.Background()); err != nil {
+		t.Fatalf("listLabeledIssues() error = %v", err)
+	}
+	if graphqlCalls.Load() != 1 || restCalls.Load() != 1 {
+		t.Errorf("graphql/REST calls = %d/%d, want 1/1", graphqlCalls.Load(), restCalls.Load())
+	}
+
+	// Marking an issue processed makes the next poll refetch
+	api.markProcessed(1)
+	_, _ = api.listLabeledIssues(context.Background())
+	if graphqlCalls.Load() != 2 {
+		t.Errorf("graphql calls = %d, want a refetch after markProcessed", graphqlCalls.Load())
+	}
+}
+
+func TestRestCheckStatus(t *testing.T) {
+	_, status := restCheckStatus("sha", nil)
+	if status.State != "pending" {
+		t.Errorf("state without statuses = %q, want pending", status.State)
+	}
+	_, status = restCheckStatus("sha", []batchCheckContext{
+		{Typename: "StatusContext", Context: "a", State: "SUCCESS"},
+		{Typename: "StatusContext", Context: "b", State: "EXPECTED"},
+	})
+	if status.State != "pending" || status.Statuses[1].State != "pending" {
+		t.Errorf("status = %+v, want pending for expected contexts", status)
+	}
+	_, status = restCheckStatus("sha", []batchCheckContext{
+		{Typename: "StatusContext", Context: "a", State: "ERROR"},
+		{Typename: "StatusContext", Context: "b", State: "PENDING"},
+	})
+	if status.State != "failure" {
+		t.Errorf("state = %q, want failure", status.State)
+	}
+}
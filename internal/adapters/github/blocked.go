@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// blockedMarkerPattern matches the hidden marker of a blocked comment and
+// captures the backend session to resume
+var blockedMarkerPattern = regexp.MustCompile(`<!-- pilot:blocked session=(\S*) -->`)
+
+// needsInfoMarkerPattern matches the hidden marker of a needs-info comment
+var needsInfoMarkerPattern = regexp.MustCompile(`<!-- pilot:needs-info verdict=\S* -->`)
+
+// noReplyMarkerPattern matches the hidden marker of a clarification timeout
+// notice and captures the timeout
+var noReplyMarkerPattern = regexp.MustCompile(`<!-- pilot:no-reply timeout=(\S+) -->`)
+
+// Unblock is the requester's answer to a blocked task
+type Unblock struct {
+	Reason    string // What the agent was blocked on
+	SessionID string // Backend session to resume, empty when unknown
+	Reply     string // Replies posted after the blocked comment, oldest first
+	NeedsInfo bool   // The question came from triage, before execution
+}
+
+// BlockedComment asks the requester for what the agent is missing. The
+// hidden marker records the session so the task resumes where it stopped.
+func BlockedComment(taskID, reason, sessionID, requester string) string {
+	var sb strings.Builder
+	sb.WriteString("⏸️ **Pilot is blocked and needs your input**\n\n")
+	if requester != "" {
+		sb.WriteString("@" + requester + " ")
+	}
+	sb.WriteString(fmt.Sprintf("task `%s` stopped because:\n\n", taskID))
+	for _, line := range strings.Split(strings.TrimSpace(reason), "\n") {
+		sb.WriteString("> " + line + "\n")
+	}
+	sb.WriteString("\nReply on this issue with the missing information and Pilot will resume where it stopped.\n\n")
+	sb.WriteString(fmt.Sprintf("<!-- pilot:blocked session=%s -->", sessionID))
+	return sb.String()
+}
+
+// NoReplyComment tells the requester that Pilot proceeds without the answer
+// it asked for. The hidden marker makes it count as the reply.
+func NoReplyComment(timeout time.Duration) string {
+	return fmt.Sprintf("⌛ Nobody answered within %s, so Pilot is proceeding with its best judgement. The assumptions it made will be listed in the PR.\n\n<!-- pilot:no-reply timeout=%s -->", timeout, timeout)
+}
+
+// FindUnblock returns the replies to the latest blocked or needs-info
+// comment. ok is false when there is no such comment or nobody has replied
+// yet. Comments posted by Pilot or by bots are not replies, except the
+// notice that the question timed out and Pilot proceeds without an answer.
+func FindUnblock(comments []*Comment) (unblock Unblock, ok bool) {
+	askedAt := lastQuestion(comments)
+	if askedAt < 0 {
+		return Unblock{}, false
+	}
+	question := comments[askedAt].Body
+	if m := blockedMarkerPattern.FindStringSubmatch(question); m != nil {
+		unblock.SessionID = m[1]
+	} else {
+		unblock.NeedsInfo = true
+	}
+	unblock.Reason = blockedReason(question)
+	if unblock.NeedsInfo {
+		// Keep triage's questions so the resumed run knows what the reply answers
+		for _, line := range strings.Split(question, "\n") {
+			if strings.HasPrefix(line, "- ") {
+				unblock.Reason += "\n" + line
+			}
+		}
+	}
+
+	var replies []string
+	for _, c := range comments[askedAt+1:] {
+		if c == nil {
+			continue
+		}
+		if m := noReplyMarkerPattern.FindStringSubmatch(c.Body); m != nil {
+			timeout, _ := time.ParseDuration(m[1])
+			replies = append(replies, executor.NoAnswerReply(timeout))
+			continue
+		}
+		if isPilotComment(c) {
+			continue
+		}
+		if body := strings.TrimSpace(c.Body); body != "" {
+			replies = append(replies, body)
+		}
+	}
+	if len(replies) == 0 {
+		return Unblock{}, false
+	}
+	unblock.Reply = strings.Join(replies, "\n\n")
+	return unblock, true
+}
+
+// lastQuestion returns the index of the latest blocked or needs-info
+// comment, or -1 when there is none
+func lastQuestion(comments []*Comment) int {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if comments[i] == nil {
+			continue
+		}
+		if blockedMarkerPattern.MatchString(comments[i].Body) || needsInfoMarkerPattern.MatchString(comments[i].Body) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Prompt describes the unblock for the resumed run
+func (u Unblock) Prompt() string {
+	return executor.ClarificationPrompt(u.Reason, u.Reply)
+}
+
+// blockedReason reads the quoted reason back from a blocked comment
+func blockedReason(body string) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "> ") {
+			lines = append(lines, strings.TrimPrefix(line, "> "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isPilotComment reports whether a comment was posted by Pilot or a bot
+func isPilotComment(c *Comment) bool {
+	if strings.HasSuffix(c.User.Login, "[bot]") || strings.Contains(c.Body, "<!-- pilot:") {
+		return true
+	}
+	body := strings.TrimSpace(c.Body)
+	if strings.HasPrefix(body, "🤖 **Pilot") {
+		return true
+	}
+	for _, m := range failedAttemptMarkers {
+		if strings.HasPrefix(body, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// unblocked reports whether a blocked or needs-info issue got a reply, or
+// its question timed out and the clarification policy is to proceed.
+// Comments are only listed when the issue's comment count changed since the
+// last check.
+func (p *Poller) unblocked(ctx context.Context, issue *Issue) bool {
+	p.mu.RLock()
+	seen, checked := p.blockedChecks[issue.Number]
+	p.mu.RUnlock()
+	if checked && seen.comments == issue.Comments {
+		timeout := p.clarification.TimeoutDuration()
+		if timeout == 0 || seen.askedAt.IsZero() || time.Since(seen.askedAt) < timeout {
+			return false
+		}
+		return p.questionTimedOut(ctx, issue, timeout)
+	}
+
+	comments, err := p.client.ListIssueComments(ctx, p.owner, p.repo, issue.Number)
+	if err != nil {
+		p.logger.Warn("Failed to check blocked issue for replies",
+			slog.Int("number", issue.Number),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	if _, ok := FindUnblock(comments); ok {
+		p.mu.Lock()
+		delete(p.blockedChecks, issue.Number)
+		p.mu.Unlock()
+		p.logger.Info("Blocked issue got a reply, resuming",
+			slog.Int("number", issue.Number))
+		return true
+	}
+
+	check := blockedCheck{comments: issue.Comments}
+	if i := lastQuestion(comments); i >= 0 {
+		// An in-place status comment is edited into the question, so its
+		// last update is when the question was asked
+		check.askedAt = comments[i].UpdatedAt
+		if check.askedAt.IsZero() {
+			check.askedAt = comments[i].CreatedAt
+		}
+	}
+	p.mu.Lock()
+	if p.blockedChecks == nil {
+		p.blockedChecks = make(map[int]blockedCheck)
+	}
+	p.blockedChecks[issue.Number] = check
+	p.mu.Unlock()
+
+	if timeout := p.clarification.TimeoutDuration(); timeout > 0 && !check.askedAt.IsZero() && time.Since(check.askedAt) >= timeout {
+		return p.questionTimedOut(ctx, issue, timeout)
+	}
+	return false
+}
+
+// blockedCheck is what the poller last saw of an issue waiting for a reply
+type blockedCheck struct {
+	comments int       // Comment count
+	askedAt  time.Time // When the open question was posted
+}
+
+// questionTimedOut applies the clarification timeout policy to an issue
+// nobody answered. It reports whether the issue should resume.
+func (p *Poller) questionTimedOut(ctx context.Context, issue *Issue, timeout time.Duration) bool {
+	p.mu.Lock()
+	delete(p.blockedChecks, issue.Number)
+	p.mu.Unlock()
+
+	if p.clarification.ProceedOnTimeout() {
+		// FindUnblock reads the notice as the reply when the task resumes
+		if _, err := p.client.AddComment(ctx, p.owner, p.repo, issue.Number, NoReplyComment(timeout)); err != nil {
+			p.logger.Warn("Failed to post clarification timeout notice",
+				slog.Int("number", issue.Number),
+				slog.Any("error", err),
+			)
+			return false
+		}
+		p.logger.Info("Question timed out, resuming without an answer",
+			slog.Int("number", issue.Number),
+			slog.Duration("timeout", timeout))
+		return true
+	}
+
+	for _, label := range []string{LabelBlocked, LabelNeedsInfo} {
+		if HasLabel(issue, label) {
+			if err := p.client.RemoveLabel(ctx, p.owner, p.repo, issue.Number, label); err != nil {
+				p.logger.Warn("Failed to remove label", slog.Int("number", issue.Number), slog.String("label", label), slog.Any("error", err))
+			}
+		}
+	}
+	if err := p.client.AddLabels(ctx, p.owner, p.repo, issue.Number, []string{LabelFailed}); err != nil {
+		p.logger.Warn("Failed to add label", slog.Int("number", issue.Number), slog.String("label", LabelFailed), slog.Any("error", err))
+	}
+	comment := fmt.Sprintf("⌛ Nobody answered within %s, so Pilot gave up on this issue.\n\nRemove the `%s` label to try again.", timeout, LabelFailed)
+	if _, err := p.client.AddComment(ctx, p.owner, p.repo, issue.Number, comment); err != nil {
+		p.logger.Warn("Failed to post clarification timeout notice",
+			slog.Int("number", issue.Number),
+			slog.Any("error", err),
+		)
+	}
+	p.logger.Info("Question timed out, giving the issue up",
+		slog.Int("number", issue.Number),
+		slog.Duration("timeout", timeout))
+	return false
+}
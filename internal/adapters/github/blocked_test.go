@@ -0,0 +1,253 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestBlockedComment(t *testing.T) {
+	body := BlockedComment("GH-42", "Missing STRIPE_KEY\nAsk ops for a test key", "sess-1", "alice")
+
+	for _, want := range []string{
+		"Pilot is blocked",
+		"@alice task `GH-42`",
+		"> Missing STRIPE_KEY\n> Ask ops for a test key\n",
+		"<!-- pilot:blocked session=sess-1 -->",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("comment missing %q:\n%s", want, body)
+		}
+	}
+	if !isPilotComment(&Comment{Body: body}) {
+		t.Error("blocked comment should count as a Pilot comment")
+	}
+}
+
+func TestFindUnblock(t *testing.T) {
+	blocked := &Comment{Body: BlockedComment("GH-1", "Which region?", "sess-1", "alice")}
+	reply := &Comment{Body: "Use eu-west-1", User: User{Login: "alice"}}
+
+	tests := []struct {
+		name      string
+		comments  []*Comment
+		wantOK    bool
+		wantReply string
+		wantSess  string
+	}{
+		{
+			name:     "no blocked comment",
+			comments: []*Comment{reply},
+		},
+		{
+			name:     "no reply yet",
+			comments: []*Comment{blocked},
+		},
+		{
+			name: "pilot and bot comments are not replies",
+			comments: []*Comment{
+				blocked,
+				{Body: "🤖 **Pilot** started working on this"},
+				{Body: "Deploy preview ready", User: User{Login: "vercel[bot]"}},
+				{Body: "   ", User: User{Login: "bob"}},
+			},
+		},
+		{
+			name:      "reply after blocked comment",
+			comments:  []*Comment{{Body: "Earlier note", User: User{Login: "bob"}}, blocked, reply},
+			wantOK:    true,
+			wantReply: "Use eu-west-1",
+			wantSess:  "sess-1",
+		},
+		{
+			name: "latest blocked comment wins",
+			comments: []*Comment{
+				blocked,
+				reply,
+				{Body: BlockedComment("GH-1", "Which bucket?", "sess-2", "alice")},
+				{Body: "assets-prod", User: User{Login: "alice"}},
+				{Body: "or assets-staging for tests", User: User{Login: "bob"}},
+			},
+			wantOK:    true,
+			wantReply: "assets-prod\n\nor assets-staging for tests",
+			wantSess:  "sess-2",
+		},
+		{
+			name: "reply to a needs-info comment",
+			comments: []*Comment{
+				{Body: NeedsInfoComment("GH-1", "missing_criteria", "No target given", []string{"Which endpoint?"}, "alice")},
+				{Body: "The /search endpoint, under 200ms", User: User{Login: "alice"}},
+			},
+			wantOK:    true,
+			wantReply: "The /search endpoint, under 200ms",
+		},
+		{
+			name:      "timeout notice stands in for the reply",
+			comments:  []*Comment{blocked, {Body: NoReplyComment(72 * time.Hour)}},
+			wantOK:    true,
+			wantReply: executor.NoAnswerReply(72 * time.Hour),
+			wantSess:  "sess-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FindUnblock(tt.comments)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got.Reply != tt.wantReply {
+				t.Errorf("Reply = %q, want %q", got.Reply, tt.wantReply)
+			}
+			if got.SessionID != tt.wantSess {
+				t.Errorf("SessionID = %q, want %q", got.SessionID, tt.wantSess)
+			}
+		})
+	}
+}
+
+func TestUnblock_Prompt(t *testing.T) {
+	prompt := Unblock{Reason: "Which region?", Reply: "Use eu-west-1"}.Prompt()
+	for _, want := range []string{"## Unblocked", "Which region?", "Use eu-west-1", "Continue the task"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestPoller_BlockedIssueWaitsForReply(t *testing.T) {
+	issue := &Issue{Number: 7, Title: "Add billing", Labels: []Label{{Name: "pilot"}, {Name: LabelBlocked}}, Comments: 1}
+	comments := []*Comment{{Body: BlockedComment("GH-7", "Missing STRIPE_KEY", "sess-1", "alice")}}
+
+	var commentReads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/comments") {
+			atomic.AddInt32(&commentReads, 1)
+			_ = json.NewEncoder(w).Encode(comments)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]*Issue{issue})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	var calls int32
+	poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second,
+		WithOnIssue(func(ctx context.Context, issue *Issue) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}),
+	)
+
+	poller.checkForNewIssues(context.Background())
+	poller.checkForNewIssues(context.Background())
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("blocked issue dispatched %d times before a reply", got)
+	}
+	if got := atomic.LoadInt32(&commentReads); got != 1 {
+		t.Errorf("comments listed %d times, want 1 while the count is unchanged", got)
+	}
+
+	comments = append(comments, &Comment{Body: "Key is in 1Password", User: User{Login: "alice"}})
+	issue.Comments = 2
+	poller.checkForNewIssues(context.Background())
+	poller.WaitForActive()
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("issue dispatched %d times after the reply, want 1", got)
+	}
+}
+
+func TestFindUnblock_NeedsInfoKeepsQuestions(t *testing.T) {
+	comments := []*Comment{
+		{Body: NeedsInfoComment("GH-1", "missing_criteria", "No target given", []string{"Which endpoint?"}, "alice")},
+		{Body: "/search", User: User{Login: "alice"}},
+	}
+	got, ok := FindUnblock(comments)
+	if !ok || !got.NeedsInfo {
+		t.Fatalf("FindUnblock = %+v, %v", got, ok)
+	}
+	if got.Reason != "No target given\n- Which endpoint?" {
+		t.Errorf("Reason = %q", got.Reason)
+	}
+}
+
+func TestPoller_QuestionTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		onTimeout  string
+		wantCalls  int32
+		wantLabels []string
+	}{
+		{name: "fail gives the issue up", onTimeout: executor.ClarifyOnTimeoutFail, wantCalls: 0, wantLabels: []string{LabelFailed}},
+		{name: "proceed resumes the issue", onTimeout: executor.ClarifyOnTimeoutProceed, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &Issue{Number: 9, Title: "Add billing", Labels: []Label{{Name: "pilot"}, {Name: LabelBlocked}}, Comments: 1}
+			asked := time.Now().Add(-2 * time.Hour)
+			comments := []*Comment{{Body: BlockedComment("GH-9", "Missing STRIPE_KEY", "sess-1", "alice"), CreatedAt: asked, UpdatedAt: asked}}
+			var posted []string
+			var added []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/comments") && r.Method == http.MethodPost:
+					var body struct {
+						Body string `json:"body"`
+					}
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					posted = append(posted, body.Body)
+					_ = json.NewEncoder(w).Encode(&Comment{Body: body.Body})
+				case strings.HasSuffix(r.URL.Path, "/comments"):
+					_ = json.NewEncoder(w).Encode(comments)
+				case strings.HasSuffix(r.URL.Path, "/labels") && r.Method == http.MethodPost:
+					var body struct {
+						Labels []string `json:"labels"`
+					}
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					added = append(added, body.Labels...)
+					_ = json.NewEncoder(w).Encode([]Label{})
+				case strings.Contains(r.URL.Path, "/labels/"):
+					_ = json.NewEncoder(w).Encode([]Label{})
+				default:
+					_ = json.NewEncoder(w).Encode([]*Issue{issue})
+				}
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+			var calls int32
+			poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second,
+				WithClarification(&executor.ClarificationConfig{Timeout: "1h", OnTimeout: tt.onTimeout}),
+				WithOnIssue(func(ctx context.Context, issue *Issue) error {
+					atomic.AddInt32(&calls, 1)
+					return nil
+				}),
+			)
+
+			poller.checkForNewIssues(context.Background())
+			poller.WaitForActive()
+
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("issue dispatched %d times, want %d", got, tt.wantCalls)
+			}
+			if len(posted) != 1 || !strings.Contains(posted[0], "Nobody answered within 1h0m0s") {
+				t.Errorf("posted = %q, want a timeout notice", posted)
+			}
+			if strings.Join(added, ",") != strings.Join(tt.wantLabels, ",") {
+				t.Errorf("labels added = %v, want %v", added, tt.wantLabels)
+			}
+		})
+	}
+}
@@ -3,12 +3,16 @@ package github
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -39,27 +43,27 @@ type Client struct {
 	token      string
 	httpClient *http.Client
 	baseURL    string // For testing - defaults to githubAPIURL
+
+	loginMu sync.Mutex
+	login   string // Token's user, cached by Login
 }
 
-// NewClient creates a new GitHub client
+// NewClient creates a new GitHub client. Clients with the same token share
+// one rate limiter, so they queue against one quota.
 func NewClient(token string) *Client {
 	return &Client{
-		token:   token,
-		baseURL: githubAPIURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		token:      token,
+		baseURL:    githubAPIURL,
+		httpClient: newHTTPClient(githubAPIURL, token),
 	}
 }
 
 // NewClientWithBaseURL creates a new GitHub client with a custom base URL (for testing)
 func NewClientWithBaseURL(token, baseURL string) *Client {
 	return &Client{
-		token:   token,
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		token:      token,
+		baseURL:    baseURL,
+		httpClient: newHTTPClient(baseURL, token),
 	}
 }
 
@@ -78,6 +82,7 @@ type Issue struct {
 	HTMLURL     string    `json:"html_url"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Comments    int       `json:"comments"`               // Number of comments on the issue
 	PullRequest *struct{} `json:"pull_request,omitempty"` // Non-nil when item is a PR (GitHub Issues API returns both)
 }
 
@@ -105,6 +110,8 @@ type Repository struct {
 	HTMLURL  string `json:"html_url"`
 	CloneURL string `json:"clone_url"`
 	SSHURL   string `json:"ssh_url"`
+
+	DefaultBranch string `json:"default_branch"`
 }
 
 // Comment represents a GitHub issue comment
@@ -112,6 +119,7 @@ type Comment struct {
 	ID        int64     `json:"id"`
 	Body      string    `json:"body"`
 	User      User      `json:"user"`
+	IssueURL  string    `json:"issue_url,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -186,6 +194,58 @@ func (c *Client) AddComment(ctx context.Context, owner, repo string, number int,
 	}, DefaultRetryOptions())
 }
 
+// UpdateComment replaces the body of an existing issue comment
+func (c *Client) UpdateComment(ctx context.Context, owner, repo string, commentID int64, body string) (*Comment, error) {
+	return WithRetry(ctx, func() (*Comment, error) {
+		path := fmt.Sprintf("/repos/%s/%s/issues/comments/%d", owner, repo, commentID)
+		reqBody := map[string]string{"body": body}
+		var comment Comment
+		if err := c.doRequest(ctx, http.MethodPatch, path, reqBody, &comment); err != nil {
+			return nil, err
+		}
+		return &comment, nil
+	}, DefaultRetryOptions())
+}
+
+// issueCommentsPerPage is the page size used to list an issue's comments
+const issueCommentsPerPage = 100
+
+// ListIssueComments returns all comments on an issue in chronological order,
+// fetching one page after the other so long threads keep their latest comments.
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*Comment, error) {
+	var result []*Comment
+	for page := 1; ; page++ {
+		comments, err := WithRetry(ctx, func() ([]*Comment, error) {
+			path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments?per_page=%d&page=%d", owner, repo, number, issueCommentsPerPage, page)
+			var comments []*Comment
+			if err := c.doRequest(ctx, http.MethodGet, path, nil, &comments); err != nil {
+				return nil, err
+			}
+			return comments, nil
+		}, DefaultRetryOptions())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comments...)
+		if len(comments) < issueCommentsPerPage {
+			return result, nil
+		}
+	}
+}
+
+// ListRepoIssueComments returns the issue and pull request comments of a
+// repo updated since the given time, oldest first. Only the first 100 are
+// fetched; callers advance since to page through the rest.
+func (c *Client) ListRepoIssueComments(ctx context.Context, owner, repo string, since time.Time) ([]*Comment, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/comments?sort=updated&direction=asc&per_page=100&since=%s",
+		owner, repo, url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	var result []*Comment
+	if err := c.doConditionalGet(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // AddLabels adds labels to an issue
 func (c *Client) AddLabels(ctx context.Context, owner, repo string, number int, labels []string) error {
 	return WithRetryVoid(ctx, func() error {
@@ -228,6 +288,66 @@ func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*Reposi
 	return &repository, nil
 }
 
+// RepositoryInput is the input for creating a new repository
+type RepositoryInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+	AutoInit    bool   `json:"auto_init,omitempty"` // Create an initial commit with a README
+}
+
+// CreateRepository creates a repository owned by owner, which may be an
+// organization or the authenticated user.
+// GitHub API: POST /orgs/{org}/repos or POST /user/repos
+func (c *Client) CreateRepository(ctx context.Context, owner string, input *RepositoryInput) (*Repository, error) {
+	var account struct {
+		Type string `json:"type"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, "/users/"+url.PathEscape(owner), nil, &account); err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", owner, err)
+	}
+	path := "/user/repos"
+	if account.Type == "Organization" {
+		path = fmt.Sprintf("/orgs/%s/repos", url.PathEscape(owner))
+	}
+	var repository Repository
+	if err := c.doRequest(ctx, http.MethodPost, path, input, &repository); err != nil {
+		return nil, err
+	}
+	return &repository, nil
+}
+
+// Login returns the login of the token's user, the account Pilot comments
+// as. It is fetched once and cached.
+// GitHub API: GET /user
+func (c *Client) Login(ctx context.Context) (string, error) {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	if c.login != "" {
+		return c.login, nil
+	}
+	var user User
+	if err := c.doRequest(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to look up token user: %w", err)
+	}
+	c.login = user.Login
+	return c.login, nil
+}
+
+// GetCollaboratorPermission returns a user's permission on a repository:
+// "admin", "maintain", "write", "triage", "read" or "none".
+// GitHub API: GET /repos/{owner}/{repo}/collaborators/{username}/permission
+func (c *Client) GetCollaboratorPermission(ctx context.Context, owner, repo, username string) (string, error) {
+	path := fmt.Sprintf("/repos/%s/%s/collaborators/%s/permission", owner, repo, url.PathEscape(username))
+	var result struct {
+		Permission string `json:"permission"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return "", err
+	}
+	return result.Permission, nil
+}
+
 // CreateCommitStatus creates a status for a specific commit SHA
 // The context parameter allows multiple statuses per commit (e.g., "ci/build", "pilot/execution")
 func (c *Client) CreateCommitStatus(ctx context.Context, owner, repo, sha string, status *CommitStatus) (*CommitStatus, error) {
@@ -301,7 +421,7 @@ func (c *Client) RequestReviewers(ctx context.Context, owner, repo string, numbe
 func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
 	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number)
 	var result PullRequest
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doConditionalGet(ctx, path, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -317,6 +437,165 @@ func (c *Client) ClosePullRequest(ctx context.Context, owner, repo string, numbe
 	}, DefaultRetryOptions())
 }
 
+// RevertPullRequest opens a pull request reverting a merged pull request,
+// using the GraphQL revertPullRequest mutation (REST has no revert endpoint).
+// Returns the revert PR's number and URL.
+func (c *Client) RevertPullRequest(ctx context.Context, owner, repo string, number int, title, body string) (*PullRequest, error) {
+	pr, err := c.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	if pr.NodeID == "" {
+		return nil, fmt.Errorf("PR #%d has no node ID", number)
+	}
+
+	const mutation = `mutation($id: ID!, $title: String, $body: String) {
+  revertPullRequest(input: {pullRequestId: $id, title: $title, body: $body}) {
+    revertPullRequest { number url }
+  }
+}`
+	var result struct {
+		RevertPullRequest struct {
+			RevertPullRequest struct {
+				Number int    `json:"number"`
+				URL    string `json:"url"`
+			} `json:"revertPullRequest"`
+		} `json:"revertPullRequest"`
+	}
+	vars := map[string]interface{}{"id": pr.NodeID, "title": title, "body": body}
+	if err := c.ExecuteGraphQL(ctx, mutation, vars, &result); err != nil {
+		return nil, fmt.Errorf("failed to revert PR #%d: %w", number, err)
+	}
+
+	revert := result.RevertPullRequest.RevertPullRequest
+	return &PullRequest{Number: revert.Number, HTMLURL: revert.URL, Title: title}, nil
+}
+
+// MarkPullRequestReadyForReview takes a draft pull request out of draft
+// using the GraphQL markPullRequestReadyForReview mutation (REST cannot
+// change a PR's draft state).
+func (c *Client) MarkPullRequestReadyForReview(ctx context.Context, owner, repo string, number int) error {
+	pr, err := c.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	if !pr.Draft {
+		return nil
+	}
+	if pr.NodeID == "" {
+		return fmt.Errorf("PR #%d has no node ID", number)
+	}
+
+	const mutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) {
+    pullRequest { isDraft }
+  }
+}`
+	var result struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest struct {
+				IsDraft bool `json:"isDraft"`
+			} `json:"pullRequest"`
+		} `json:"markPullRequestReadyForReview"`
+	}
+	vars := map[string]interface{}{"id": pr.NodeID}
+	if err := c.ExecuteGraphQL(ctx, mutation, vars, &result); err != nil {
+		return fmt.Errorf("failed to mark PR #%d ready for review: %w", number, err)
+	}
+	if result.MarkPullRequestReadyForReview.PullRequest.IsDraft {
+		return fmt.Errorf("PR #%d is still a draft", number)
+	}
+	return nil
+}
+
+// mergeQueueEntryFields selects a MergeQueueEntry in GraphQL queries
+const mergeQueueEntryFields = `mergeQueueEntry { position state headCommit { oid } }`
+
+// graphQLMergeQueueEntry is the GraphQL shape of a merge queue entry
+type graphQLMergeQueueEntry struct {
+	Position   int    `json:"position"`
+	State      string `json:"state"`
+	HeadCommit *struct {
+		OID string `json:"oid"`
+	} `json:"headCommit"`
+}
+
+// entry converts the GraphQL shape, returning nil when e is nil
+func (e *graphQLMergeQueueEntry) entry() *MergeQueueEntry {
+	if e == nil {
+		return nil
+	}
+	entry := &MergeQueueEntry{Position: e.Position, State: e.State}
+	if e.HeadCommit != nil {
+		entry.HeadSHA = e.HeadCommit.OID
+	}
+	return entry
+}
+
+// EnqueuePullRequest adds a pull request to its base branch's merge queue
+// using the GraphQL enqueuePullRequest mutation. When headSHA is set, GitHub
+// refuses the enqueue if the PR's head has moved since it was verified.
+func (c *Client) EnqueuePullRequest(ctx context.Context, owner, repo string, number int, headSHA string) (*MergeQueueEntry, error) {
+	pr, err := c.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	if pr.NodeID == "" {
+		return nil, fmt.Errorf("PR #%d has no node ID", number)
+	}
+
+	const mutation = `mutation($id: ID!, $head: GitObjectID) {
+  enqueuePullRequest(input: {pullRequestId: $id, expectedHeadOid: $head}) {
+    ` + mergeQueueEntryFields + `
+  }
+}`
+	var result struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry *graphQLMergeQueueEntry `json:"mergeQueueEntry"`
+		} `json:"enqueuePullRequest"`
+	}
+	vars := map[string]interface{}{"id": pr.NodeID}
+	if headSHA != "" {
+		vars["head"] = headSHA
+	}
+	if err := c.ExecuteGraphQL(ctx, mutation, vars, &result); err != nil {
+		return nil, fmt.Errorf("failed to enqueue PR #%d: %w", number, err)
+	}
+
+	entry := result.EnqueuePullRequest.MergeQueueEntry.entry()
+	if entry == nil {
+		return nil, fmt.Errorf("PR #%d was not added to the merge queue", number)
+	}
+	return entry, nil
+}
+
+// GetMergeQueueEntry returns a pull request's merge queue entry, or nil when
+// the PR is not queued (never enqueued, dequeued, or already merged).
+func (c *Client) GetMergeQueueEntry(ctx context.Context, owner, repo string, number int) (*MergeQueueEntry, error) {
+	const query = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      ` + mergeQueueEntryFields + `
+    }
+  }
+}`
+	var result struct {
+		Repository struct {
+			PullRequest *struct {
+				MergeQueueEntry *graphQLMergeQueueEntry `json:"mergeQueueEntry"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": owner, "repo": repo, "number": number}
+	if err := c.ExecuteGraphQL(ctx, query, vars, &result); err != nil {
+		return nil, fmt.Errorf("failed to get merge queue entry for PR #%d: %w", number, err)
+	}
+	if result.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("PR #%d not found", number)
+	}
+	return result.Repository.PullRequest.MergeQueueEntry.entry(), nil
+}
+
 // AddPRComment adds a comment to a pull request (issue comment API)
 // For review comments on specific lines, use CreatePRReviewComment instead
 func (c *Client) AddPRComment(ctx context.Context, owner, repo string, number int, body string) (*PRComment, error) {
@@ -364,7 +643,7 @@ func (c *Client) ListIssues(ctx context.Context, owner, repo string, opts *ListI
 	}
 
 	var issues []*Issue
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &issues); err != nil {
+	if err := c.doConditionalGet(ctx, path, &issues); err != nil {
 		return nil, err
 	}
 
@@ -423,7 +702,7 @@ func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, sha string)
 	path := fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, sha)
 
 	var status CombinedStatus
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &status); err != nil {
+	if err := c.doConditionalGet(ctx, path, &status); err != nil {
 		return nil, err
 	}
 
@@ -436,7 +715,7 @@ func (c *Client) ListCheckRuns(ctx context.Context, owner, repo, sha string) (*C
 	path := fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs", owner, repo, sha)
 
 	var result CheckRunsResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doConditionalGet(ctx, path, &result); err != nil {
 		return nil, err
 	}
 
@@ -460,6 +739,35 @@ func (c *Client) ApprovePullRequest(ctx context.Context, owner, repo string, num
 	}, DefaultRetryOptions())
 }
 
+// ReviewCommentInput is an inline review comment anchored to a line of the
+// diff. Side defaults to RIGHT, the new version of the file.
+type ReviewCommentInput struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side,omitempty"`
+	Body string `json:"body"`
+}
+
+// PullRequestReviewInput is the input for submitting a pull request review
+type PullRequestReviewInput struct {
+	CommitID string               `json:"commit_id,omitempty"`
+	Body     string               `json:"body,omitempty"`
+	Event    string               `json:"event"` // APPROVE, REQUEST_CHANGES, COMMENT
+	Comments []ReviewCommentInput `json:"comments,omitempty"`
+}
+
+// CreatePullRequestReview submits a review on a PR, with its inline comments
+func (c *Client) CreatePullRequestReview(ctx context.Context, owner, repo string, number int, input *PullRequestReviewInput) (*PullRequestReview, error) {
+	return WithRetry(ctx, func() (*PullRequestReview, error) {
+		path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", owner, repo, number)
+		var review PullRequestReview
+		if err := c.doRequest(ctx, http.MethodPost, path, input, &review); err != nil {
+			return nil, err
+		}
+		return &review, nil
+	}, DefaultRetryOptions())
+}
+
 // IssueInput is the input for creating a new issue
 type IssueInput struct {
 	Title  string   `json:"title"`
@@ -494,7 +802,7 @@ func (c *Client) GetBranch(ctx context.Context, owner, repo, branch string) (*Br
 func (c *Client) ListPullRequests(ctx context.Context, owner, repo, state string) ([]*PullRequest, error) {
 	path := fmt.Sprintf("/repos/%s/%s/pulls?state=%s", owner, repo, state)
 	var result []*PullRequest
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doConditionalGet(ctx, path, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -598,6 +906,17 @@ func (c *Client) CompareCommits(ctx context.Context, owner, repo, base, head str
 	return result.Commits, nil
 }
 
+// ListPullRequestsForCommit returns the pull requests associated with a commit,
+// e.g. the PR a squash-merge commit came from.
+func (c *Client) ListPullRequestsForCommit(ctx context.Context, owner, repo, sha string) ([]*PullRequest, error) {
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/pulls", owner, repo, sha)
+	var result []*PullRequest
+	if err := c.doConditionalGet(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetJobLogs fetches the logs for a GitHub Actions job (check run).
 // Uses GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs which returns
 // a 302 redirect to a log download URL. Returns the raw log text.
@@ -677,6 +996,255 @@ func (c *Client) UpdateRef(ctx context.Context, owner, repo, branch, sha string)
 	}, DefaultRetryOptions())
 }
 
+// FastForwardRef moves a branch to sha without force, so GitHub rejects the
+// update (422) unless sha is a descendant of the branch's current head.
+// Uses PATCH /repos/{owner}/{repo}/git/refs/heads/{branch} with force=false.
+func (c *Client) FastForwardRef(ctx context.Context, owner, repo, branch, sha string) error {
+	return WithRetryVoid(ctx, func() error {
+		path := fmt.Sprintf("/repos/%s/%s/git/refs/heads/%s", owner, repo, url.PathEscape(branch))
+		body := map[string]interface{}{
+			"sha":   sha,
+			"force": false,
+		}
+		return c.doRequest(ctx, http.MethodPatch, path, body, nil)
+	}, DefaultRetryOptions())
+}
+
+// GetFileContent returns the content of a file at the given ref (branch, tag or SHA).
+// GitHub API: GET /repos/{owner}/{repo}/contents/{path}?ref={ref}
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, filePath, ref string) ([]byte, error) {
+	path := fmt.Sprintf("/repos/%s/%s/contents/%s?ref=%s", owner, repo, escapePath(filePath), url.QueryEscape(ref))
+	var result struct {
+		Type     string `json:"type"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Type != "file" {
+		return nil, fmt.Errorf("%s is not a file", filePath)
+	}
+	if result.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding %q for %s", result.Encoding, filePath)
+	}
+	// GitHub wraps base64 content at 60 columns.
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(result.Content, "\n", ""))
+}
+
+// TreeEntry is a file or directory of a git tree
+type TreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"` // "blob", "tree" or "commit" (submodule)
+	SHA  string `json:"sha"`
+	Size int    `json:"size,omitempty"`
+}
+
+// GetTree returns every entry of the tree at ref, recursively. It fails when
+// GitHub truncates the listing of a very large tree.
+// GitHub API: GET /repos/{owner}/{repo}/git/trees/{ref}?recursive=1
+func (c *Client) GetTree(ctx context.Context, owner, repo, ref string) ([]*TreeEntry, error) {
+	path := fmt.Sprintf("/repos/%s/%s/git/trees/%s?recursive=1", owner, repo, url.PathEscape(ref))
+	var result struct {
+		Tree      []*TreeEntry `json:"tree"`
+		Truncated bool         `json:"truncated"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Truncated {
+		return nil, fmt.Errorf("tree of %s/%s@%s is too large to list", owner, repo, ref)
+	}
+	return result.Tree, nil
+}
+
+// GetBlob returns the content of a blob.
+// GitHub API: GET /repos/{owner}/{repo}/git/blobs/{sha}
+func (c *Client) GetBlob(ctx context.Context, owner, repo, sha string) ([]byte, error) {
+	path := fmt.Sprintf("/repos/%s/%s/git/blobs/%s", owner, repo, sha)
+	var result struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding %q for blob %s", result.Encoding, sha)
+	}
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(result.Content, "\n", ""))
+}
+
+// CommitFiles creates a single commit on top of parentSHA that writes files
+// (path → content), then fast-forwards branch to it. The branch update is not
+// forced, so it fails if branch has moved past parentSHA.
+// Returns the SHA of the new commit.
+func (c *Client) CommitFiles(ctx context.Context, owner, repo, branch, parentSHA, message string, files map[string][]byte) (string, error) {
+	var parent struct {
+		Tree struct {
+			SHA string `json:"sha"`
+		} `json:"tree"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/%s/git/commits/%s", owner, repo, parentSHA), nil, &parent); err != nil {
+		return "", fmt.Errorf("failed to get commit %s: %w", parentSHA, err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	entries := make([]map[string]string, 0, len(paths))
+	for _, p := range paths {
+		entries = append(entries, map[string]string{
+			"path":    p,
+			"mode":    "100644",
+			"type":    "blob",
+			"content": string(files[p]),
+		})
+	}
+
+	var tree struct {
+		SHA string `json:"sha"`
+	}
+	treeBody := map[string]interface{}{"base_tree": parent.Tree.SHA, "tree": entries}
+	if err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/git/trees", owner, repo), treeBody, &tree); err != nil {
+		return "", fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	commitBody := map[string]interface{}{"message": message, "tree": tree.SHA, "parents": []string{parentSHA}}
+	if err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/git/commits", owner, repo), commitBody, &commit); err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	refPath := fmt.Sprintf("/repos/%s/%s/git/refs/heads/%s", owner, repo, url.PathEscape(branch))
+	refBody := map[string]interface{}{"sha": commit.SHA, "force": false}
+	if err := c.doRequest(ctx, http.MethodPatch, refPath, refBody, nil); err != nil {
+		return "", fmt.Errorf("failed to update %s: %w", branch, err)
+	}
+	return commit.SHA, nil
+}
+
+// ErrCherryPickConflict is returned when a commit does not apply cleanly to
+// the target branch.
+var ErrCherryPickConflict = errors.New("cherry-pick conflicts with the target branch")
+
+// gitCommit is a commit from the git data API.
+type gitCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Tree    struct {
+		SHA string `json:"sha"`
+	} `json:"tree"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+	Author map[string]string `json:"author"`
+}
+
+// CherryPickCommit applies the changes sha made relative to its first parent
+// on top of branch, keeping its message and author, and fast-forwards branch
+// to the new commit. The REST API has no cherry-pick, so the change is merged
+// through a temporary branch whose tree is branch's but whose parent is sha's
+// parent. Returns the new commit, or the branch head when branch already
+// contains the change.
+func (c *Client) CherryPickCommit(ctx context.Context, owner, repo, branch, sha string) (string, error) {
+	getCommit := func(ref string) (*gitCommit, error) {
+		var commit gitCommit
+		if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/%s/git/commits/%s", owner, repo, ref), nil, &commit); err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", ref, err)
+		}
+		return &commit, nil
+	}
+	createCommit := func(body map[string]interface{}) (string, error) {
+		var commit gitCommit
+		if err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/git/commits", owner, repo), body, &commit); err != nil {
+			return "", fmt.Errorf("failed to create commit: %w", err)
+		}
+		return commit.SHA, nil
+	}
+
+	pick, err := getCommit(sha)
+	if err != nil {
+		return "", err
+	}
+	if len(pick.Parents) == 0 {
+		return "", fmt.Errorf("cannot cherry-pick root commit %s", sha)
+	}
+	target, err := c.GetBranch(ctx, owner, repo, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch %s: %w", branch, err)
+	}
+	head, err := getCommit(target.SHA())
+	if err != nil {
+		return "", err
+	}
+
+	sibling, err := createCommit(map[string]interface{}{
+		"message": "Temporary commit for cherry-pick of " + sha,
+		"tree":    head.Tree.SHA,
+		"parents": []string{pick.Parents[0].SHA},
+	})
+	if err != nil {
+		return "", err
+	}
+	tmp := fmt.Sprintf("pilot/cherry-pick-%s-%d", sha, time.Now().UnixNano())
+	if err := c.UpdateRef(ctx, owner, repo, tmp, sibling); err != nil {
+		return "", fmt.Errorf("failed to create temporary branch: %w", err)
+	}
+	defer func() { _ = c.DeleteBranch(context.WithoutCancel(ctx), owner, repo, tmp) }()
+
+	var merged gitCommit
+	mergeBody := map[string]string{"base": tmp, "head": sha, "commit_message": "Temporary merge for cherry-pick of " + sha}
+	if err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/merges", owner, repo), mergeBody, &merged); err != nil {
+		if strings.HasPrefix(err.Error(), "API error (status 409") {
+			return "", fmt.Errorf("%w: %s onto %s", ErrCherryPickConflict, sha, branch)
+		}
+		return "", fmt.Errorf("failed to merge %s: %w", sha, err)
+	}
+	if merged.SHA == "" {
+		// 204: everything in sha is already on the sibling, so on branch
+		return target.SHA(), nil
+	}
+	mergeCommit, err := getCommit(merged.SHA)
+	if err != nil {
+		return "", err
+	}
+	if mergeCommit.Tree.SHA == head.Tree.SHA {
+		return target.SHA(), nil
+	}
+
+	body := map[string]interface{}{
+		"message": pick.Message,
+		"tree":    mergeCommit.Tree.SHA,
+		"parents": []string{target.SHA()},
+	}
+	if len(pick.Author) > 0 {
+		body["author"] = pick.Author
+	}
+	picked, err := createCommit(body)
+	if err != nil {
+		return "", err
+	}
+	if err := c.FastForwardRef(ctx, owner, repo, branch, picked); err != nil {
+		return "", fmt.Errorf("failed to update %s: %w", branch, err)
+	}
+	return picked, nil
+}
+
+// escapePath escapes each segment of a repository file path.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
 // DeleteBranch deletes a branch from the repository.
 // GitHub API: DELETE /repos/{owner}/{repo}/git/refs/heads/{branch}
 // Returns nil on success, or if the branch was already deleted (404/422).
@@ -705,18 +1273,35 @@ func (c *Client) ListPullRequestReviews(ctx context.Context, owner, repo string,
 
 // PRFile represents a file changed in a pull request.
 type PRFile struct {
-	Filename string `json:"filename"`
-	Status   string `json:"status"` // "added", "removed", "modified", "renamed"
+	Filename  string `json:"filename"`
+	Status    string `json:"status"` // "added", "removed", "modified", "renamed"
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Patch     string `json:"patch,omitempty"` // Unified diff hunks, absent for binary or large files
 }
 
-// ListPullRequestFiles returns the list of files changed in a pull request.
+// pullRequestFilesPerPage is the page size used to list a pull request's files
+const pullRequestFilesPerPage = 100
+
+// MaxPullRequestFiles is the most files GitHub lists for a pull request;
+// a listing of this length may be missing files.
+const MaxPullRequestFiles = 3000
+
+// ListPullRequestFiles returns the files changed in a pull request,
+// fetching one page after the other up to MaxPullRequestFiles.
 func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*PRFile, error) {
-	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/files", owner, repo, number)
 	var result []*PRFile
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
-		return nil, err
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/repos/%s/%s/pulls/%d/files?per_page=%d&page=%d", owner, repo, number, pullRequestFilesPerPage, page)
+		var files []*PRFile
+		if err := c.doRequest(ctx, http.MethodGet, path, nil, &files); err != nil {
+			return nil, err
+		}
+		result = append(result, files...)
+		if len(files) < pullRequestFilesPerPage || len(result) >= MaxPullRequestFiles {
+			return result, nil
+		}
 	}
-	return result, nil
 }
 
 // HasApprovalReview checks if a PR has at least one approval review.
@@ -760,51 +1345,62 @@ func (c *Client) GetPullRequestComments(ctx context.Context, owner, repo string,
 // Posts to baseURL+"/graphql" (testable via NewClientWithBaseURL).
 // result is unmarshalled from response.data if non-nil.
 func (c *Client) ExecuteGraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	data, errs, err := c.executeGraphQLPartial(ctx, query, variables)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("graphql error: %s", errs[0].Message)
+	}
+
+	if result != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("unmarshal graphql data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// executeGraphQLPartial executes a GraphQL query and returns its data
+// along with any errors, for queries whose parts can fail independently.
+func (c *Client) executeGraphQLPartial(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, []GraphQLError, error) {
 	reqBody := GraphQLRequest{Query: query, Variables: variables}
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
-		return fmt.Errorf("marshal graphql request: %w", err)
+		return nil, nil, fmt.Errorf("marshal graphql request: %w", err)
 	}
 
 	endpoint := c.baseURL + "/graphql"
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return fmt.Errorf("create graphql request: %w", err)
+		return nil, nil, fmt.Errorf("create graphql request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("graphql request failed: %w", err)
+		return nil, nil, fmt.Errorf("graphql request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read graphql response: %w", err)
+		return nil, nil, fmt.Errorf("read graphql response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("graphql API error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, nil, fmt.Errorf("graphql API error (status %d): %s", resp.StatusCode, string(respBody))
 	}
 
 	var gqlResp GraphQLResponse
 	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
-		return fmt.Errorf("parse graphql response: %w", err)
-	}
-
-	if len(gqlResp.Errors) > 0 {
-		return fmt.Errorf("graphql error: %s", gqlResp.Errors[0].Message)
+		return nil, nil, fmt.Errorf("parse graphql response: %w", err)
 	}
 
-	if result != nil && len(gqlResp.Data) > 0 {
-		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
-			return fmt.Errorf("unmarshal graphql data: %w", err)
-		}
-	}
-
-	return nil
+	return gqlResp.Data, gqlResp.Errors, nil
 }
 
 // SearchMergedPRsForIssue checks if any merged PRs exist that reference the given
@@ -838,6 +1434,16 @@ func (c *Client) SearchOpenSubIssues(ctx context.Context, owner, repo string, pa
 	return result.TotalCount, nil
 }
 
+// UpdatePullRequestBase changes the branch a PR merges into.
+// Uses GitHub API: PATCH /repos/{owner}/{repo}/pulls/{number}
+func (c *Client) UpdatePullRequestBase(ctx context.Context, owner, repo string, number int, base string) error {
+	return WithRetryVoid(ctx, func() error {
+		path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number)
+		body := map[string]string{"base": base}
+		return c.doRequest(ctx, http.MethodPatch, path, body, nil)
+	}, DefaultRetryOptions())
+}
+
 // UpdatePullRequestBranch updates the PR branch with the latest base branch.
 // Uses GitHub API: PUT /repos/{owner}/{repo}/pulls/{number}/update-branch
 // Returns nil on success, error if the branch cannot be automatically updated (true conflict).
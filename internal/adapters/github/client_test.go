@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -110,6 +111,32 @@ func TestGetIssue(t *testing.T) {
 	}
 }
 
+func TestLogin(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		calls++
+		_ = json.NewEncoder(w).Encode(User{ID: 1, Login: "pilot-runner"})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	for i := 0; i < 2; i++ {
+		login, err := client.Login(context.Background())
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		if login != "pilot-runner" {
+			t.Errorf("Login() = %q, want pilot-runner", login)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("GET /user called %d times, want 1 (cached)", calls)
+	}
+}
+
 func TestAddComment(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -175,6 +202,114 @@ func TestAddComment(t *testing.T) {
 	}
 }
 
+func TestListIssueComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/repos/owner/repo/issues/42/comments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("expected per_page=100, got %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]Comment{
+			{ID: 1, Body: "first"},
+			{ID: 2, Body: "❌ Pilot execution failed:\n\nboom"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	comments, err := client.ListIssueComments(context.Background(), "owner", "repo", 42)
+	if err != nil {
+		t.Fatalf("ListIssueComments() error = %v", err)
+	}
+	if len(comments) != 2 || comments[1].ID != 2 {
+		t.Errorf("unexpected comments: %+v", comments)
+	}
+}
+
+func TestListPullRequestFiles_Paginates(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/pulls/42/files" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		var files []PRFile
+		switch page {
+		case "1":
+			for i := 1; i <= 100; i++ {
+				files = append(files, PRFile{Filename: fmt.Sprintf("src/file%d.go", i)})
+			}
+		case "2":
+			files = []PRFile{{Filename: "migrations/001.sql"}}
+		default:
+			t.Errorf("unexpected page: %q", page)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(files)
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	files, err := client.ListPullRequestFiles(context.Background(), "owner", "repo", 42)
+	if err != nil {
+		t.Fatalf("ListPullRequestFiles() error = %v", err)
+	}
+	if len(files) != 101 || files[100].Filename != "migrations/001.sql" {
+		t.Errorf("got %d files, want 101 ending with the one from page 2", len(files))
+	}
+	if len(pages) != 2 {
+		t.Errorf("pages = %v, want 1 and 2", pages)
+	}
+}
+
+func TestListIssueComments_Paginates(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		if page == "2" && requests == 2 {
+			// First attempt at page 2 fails transiently and must be retried
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var comments []Comment
+		switch page {
+		case "1":
+			for i := 1; i <= 100; i++ {
+				comments = append(comments, Comment{ID: int64(i)})
+			}
+		case "2":
+			comments = []Comment{{ID: 101, Body: "latest"}}
+		default:
+			t.Errorf("unexpected page: %q", page)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(comments)
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	comments, err := client.ListIssueComments(context.Background(), "owner", "repo", 42)
+	if err != nil {
+		t.Fatalf("ListIssueComments() error = %v", err)
+	}
+	if len(comments) != 101 {
+		t.Fatalf("len(comments) = %d, want 101", len(comments))
+	}
+	if comments[100].Body != "latest" {
+		t.Errorf("last comment = %+v, want the one from page 2", comments[100])
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3 (two pages plus one retry)", requests)
+	}
+}
+
 func TestAddLabels(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -848,6 +983,184 @@ func TestClosePullRequest(t *testing.T) {
 	}
 }
 
+func TestRevertPullRequest(t *testing.T) {
+	var gotVars map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42":
+			_, _ = w.Write([]byte(`{"number": 42, "node_id": "PR_kwDO42"}`))
+		case "/graphql":
+			var req GraphQLRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode graphql request: %v", err)
+			}
+			if !strings.Contains(req.Query, "revertPullRequest") {
+				t.Errorf("unexpected query: %s", req.Query)
+			}
+			gotVars = req.Variables
+			_, _ = w.Write([]byte(`{"data": {"revertPullRequest": {"revertPullRequest": {"number": 43, "url": "https://github.com/owner/repo/pull/43"}}}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	revert, err := client.RevertPullRequest(context.Background(), "owner", "repo", 42, "Revert #42", "CI broke")
+	if err != nil {
+		t.Fatalf("RevertPullRequest() error = %v", err)
+	}
+	if revert.Number != 43 || revert.HTMLURL != "https://github.com/owner/repo/pull/43" {
+		t.Errorf("revert PR = %+v", revert)
+	}
+	if gotVars["id"] != "PR_kwDO42" || gotVars["title"] != "Revert #42" {
+		t.Errorf("graphql variables = %v", gotVars)
+	}
+}
+
+func TestMarkPullRequestReadyForReview(t *testing.T) {
+	tests := []struct {
+		name        string
+		pr          string
+		wantGraphQL bool
+	}{
+		{name: "draft", pr: `{"number": 42, "node_id": "PR_kwDO42", "draft": true}`, wantGraphQL: true},
+		{name: "already ready", pr: `{"number": 42, "node_id": "PR_kwDO42", "draft": false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotVars map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/owner/repo/pulls/42":
+					_, _ = w.Write([]byte(tt.pr))
+				case "/graphql":
+					var req GraphQLRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						t.Fatalf("failed to decode graphql request: %v", err)
+					}
+					if !strings.Contains(req.Query, "markPullRequestReadyForReview") {
+						t.Errorf("unexpected query: %s", req.Query)
+					}
+					gotVars = req.Variables
+					_, _ = w.Write([]byte(`{"data": {"markPullRequestReadyForReview": {"pullRequest": {"isDraft": false}}}}`))
+				default:
+					t.Errorf("unexpected path: %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+			if err := client.MarkPullRequestReadyForReview(context.Background(), "owner", "repo", 42); err != nil {
+				t.Fatalf("MarkPullRequestReadyForReview() error = %v", err)
+			}
+			if tt.wantGraphQL && gotVars["id"] != "PR_kwDO42" {
+				t.Errorf("graphql variables = %v", gotVars)
+			}
+			if !tt.wantGraphQL && gotVars != nil {
+				t.Errorf("unexpected mutation for a ready PR: %v", gotVars)
+			}
+		})
+	}
+}
+
+func TestEnqueuePullRequest(t *testing.T) {
+	var gotVars map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42":
+			_, _ = w.Write([]byte(`{"number": 42, "node_id": "PR_kwDO42"}`))
+		case "/graphql":
+			var req GraphQLRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode graphql request: %v", err)
+			}
+			if !strings.Contains(req.Query, "enqueuePullRequest") {
+				t.Errorf("unexpected query: %s", req.Query)
+			}
+			gotVars = req.Variables
+			_, _ = w.Write([]byte(`{"data": {"enqueuePullRequest": {"mergeQueueEntry": {"position": 2, "state": "QUEUED", "headCommit": null}}}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	entry, err := client.EnqueuePullRequest(context.Background(), "owner", "repo", 42, "abc123")
+	if err != nil {
+		t.Fatalf("EnqueuePullRequest() error = %v", err)
+	}
+	if entry.Position != 2 || entry.State != MergeQueueStateQueued {
+		t.Errorf("entry = %+v", entry)
+	}
+	if gotVars["id"] != "PR_kwDO42" || gotVars["head"] != "abc123" {
+		t.Errorf("graphql variables = %v", gotVars)
+	}
+}
+
+func TestGetMergeQueueEntry(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		wantEntry *MergeQueueEntry
+		wantErr   bool
+	}{
+		{
+			name:      "queued",
+			response:  `{"data": {"repository": {"pullRequest": {"mergeQueueEntry": {"position": 1, "state": "AWAITING_CHECKS", "headCommit": {"oid": "group1"}}}}}}`,
+			wantEntry: &MergeQueueEntry{Position: 1, State: MergeQueueStateAwaitingChecks, HeadSHA: "group1"},
+		},
+		{
+			name:     "not queued",
+			response: `{"data": {"repository": {"pullRequest": {"mergeQueueEntry": null}}}}`,
+		},
+		{
+			name:     "unknown PR",
+			response: `{"data": {"repository": {"pullRequest": null}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "graphql error",
+			response: `{"errors": [{"message": "Resource not accessible"}]}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req GraphQLRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				if req.Variables["number"] != float64(42) {
+					t.Errorf("graphql variables = %v", req.Variables)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+			entry, err := client.GetMergeQueueEntry(context.Background(), "owner", "repo", 42)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMergeQueueEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantEntry == nil {
+				if entry != nil {
+					t.Errorf("entry = %+v, want nil", entry)
+				}
+				return
+			}
+			if entry == nil || *entry != *tt.wantEntry {
+				t.Errorf("entry = %+v, want %+v", entry, tt.wantEntry)
+			}
+		})
+	}
+}
+
 func TestAddPRComment(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1701,6 +2014,37 @@ func TestApprovePullRequest(t *testing.T) {
 	}
 }
 
+func TestCreatePullRequestReview(t *testing.T) {
+	var got PullRequestReviewInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/pulls/42/reviews" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id": 7, "state": "COMMENTED"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	review, err := client.CreatePullRequestReview(context.Background(), "owner", "repo", 42, &PullRequestReviewInput{
+		CommitID: "abc123",
+		Body:     "Two risky hunks",
+		Event:    ReviewEventComment,
+		Comments: []ReviewCommentInput{{Path: "auth.go", Line: 12, Side: "RIGHT", Body: "Token is logged"}},
+	})
+	if err != nil {
+		t.Fatalf("CreatePullRequestReview() error = %v", err)
+	}
+	if review.ID != 7 || review.State != ReviewStateCommented {
+		t.Errorf("review = %+v", review)
+	}
+	if got.CommitID != "abc123" || got.Event != ReviewEventComment || len(got.Comments) != 1 || got.Comments[0].Line != 12 {
+		t.Errorf("request body = %+v", got)
+	}
+}
+
 func TestMergeMethodConstants(t *testing.T) {
 	tests := []struct {
 		constant string
@@ -2740,3 +3084,198 @@ func TestSearchOpenSubIssues(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFileContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/contents/charts/my app/Chart.yaml" || r.URL.Query().Get("ref") != "abc123" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		// GitHub wraps base64 content with newlines
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  "dmVyc2lvbjog\nMS4yLjMK\n",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	content, err := client.GetFileContent(context.Background(), "owner", "repo", "charts/my app/Chart.yaml", "abc123")
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	if string(content) != "version: 1.2.3\n" {
+		t.Errorf("GetFileContent() = %q", content)
+	}
+}
+
+func TestCommitFiles_BranchMoved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"tree": map[string]string{"sha": "tree1"}})
+		case r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(map[string]string{"sha": "new"})
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "Update is not a fast forward"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	_, err := client.CommitFiles(context.Background(), "owner", "repo", "main", "parent", "chore(release): v1.0.0",
+		map[string][]byte{"VERSION": []byte("1.0.0\n")})
+	if err == nil || !strings.Contains(err.Error(), "failed to update main") {
+		t.Errorf("CommitFiles() error = %v, want ref update failure", err)
+	}
+}
+
+func TestFastForwardRef(t *testing.T) {
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/repos/owner/repo/git/refs/heads/main" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		if gotBody["sha"] == "behind" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Update is not a fast forward"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	if err := client.FastForwardRef(context.Background(), "owner", "repo", "main", "abc123"); err != nil {
+		t.Fatalf("FastForwardRef() error = %v", err)
+	}
+	if gotBody["force"] != false {
+		t.Errorf("force = %v, want false", gotBody["force"])
+	}
+	if err := client.FastForwardRef(context.Background(), "owner", "repo", "main", "behind"); err == nil {
+		t.Error("FastForwardRef() should fail when the update is not a fast forward")
+	}
+}
+
+func TestUpdatePullRequestBase(t *testing.T) {
+	var gotBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/repos/owner/repo/pulls/42" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	if err := client.UpdatePullRequestBase(context.Background(), "owner", "repo", 42, "canary"); err != nil {
+		t.Fatalf("UpdatePullRequestBase() error = %v", err)
+	}
+	if gotBody["base"] != "canary" {
+		t.Errorf("base = %q, want canary", gotBody["base"])
+	}
+}
+
+func TestCherryPickCommit(t *testing.T) {
+	tests := []struct {
+		name      string
+		conflict  bool
+		wantSHA   string
+		wantErr   error
+		wantHeads []string
+	}{
+		{name: "applies", wantSHA: "picked", wantHeads: []string{"picked"}},
+		{name: "conflicts", conflict: true, wantErr: ErrCherryPickConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []map[string]interface{}
+			var heads []string
+			tmpDeleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				commit := func(sha, tree string, parents ...string) {
+					var ps []map[string]string
+					for _, p := range parents {
+						ps = append(ps, map[string]string{"sha": p})
+					}
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"sha": sha, "message": "feat(api): add caching", "tree": map[string]string{"sha": tree}, "parents": ps,
+						"author": map[string]string{"name": "Dev", "email": "dev@example.com"},
+					})
+				}
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/git/commits/pick":
+					commit("pick", "tree-pick", "dev-base")
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/branches/stage":
+					_ = json.NewEncoder(w).Encode(Branch{Name: "stage", Commit: BranchCommit{SHA: "stage-head"}})
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/git/commits/stage-head":
+					commit("stage-head", "tree-stage")
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/git/commits/merge":
+					commit("merge", "tree-merged", "sibling", "pick")
+				case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/repo/git/commits":
+					var body map[string]interface{}
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					created = append(created, body)
+					sha := "sibling"
+					if len(created) > 1 {
+						sha = "picked"
+					}
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"sha":"` + sha + `"}`))
+				case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/owner/repo/git/refs/heads/pilot/cherry-pick-pick-"):
+					_, _ = w.Write([]byte(`{}`))
+				case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/repos/owner/repo/git/refs/heads/pilot/cherry-pick-pick-"):
+					tmpDeleted = true
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/repo/merges":
+					if tt.conflict {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message":"Merge conflict"}`))
+						return
+					}
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"sha":"merge"}`))
+				case r.Method == http.MethodPatch && r.URL.Path == "/repos/owner/repo/git/refs/heads/stage":
+					var body map[string]interface{}
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					heads = append(heads, fmt.Sprint(body["sha"]))
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+			sha, err := client.CherryPickCommit(context.Background(), "owner", "repo", "stage", "pick")
+			if !errors.Is(err, tt.wantErr) || sha != tt.wantSHA {
+				t.Fatalf("CherryPickCommit() = %q, %v; want %q, %v", sha, err, tt.wantSHA, tt.wantErr)
+			}
+			if !tmpDeleted {
+				t.Error("temporary branch was not deleted")
+			}
+			if strings.Join(heads, ",") != strings.Join(tt.wantHeads, ",") {
+				t.Errorf("stage updated to %v, want %v", heads, tt.wantHeads)
+			}
+
+			// The sibling shares the pick's parent but has the stage tree, so
+			// merging the pick into it applies only the pick's diff
+			if sibling := created[0]; sibling["tree"] != "tree-stage" || fmt.Sprint(sibling["parents"]) != "[dev-base]" {
+				t.Errorf("sibling commit = %v", sibling)
+			}
+			if tt.wantSHA != "" {
+				picked := created[1]
+				if picked["tree"] != "tree-merged" || fmt.Sprint(picked["parents"]) != "[stage-head]" || picked["message"] != "feat(api): add caching" {
+					t.Errorf("picked commit = %v", picked)
+				}
+			}
+		})
+	}
+}
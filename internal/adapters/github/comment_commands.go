@@ -0,0 +1,337 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// Comment commands
+const (
+	// CommandRetry re-executes a failed issue
+	CommandRetry = "retry"
+	// CommandCancel stops the running task of an issue
+	CommandCancel = "cancel"
+	// CommandPlan posts the decomposition plan of an issue without executing it
+	CommandPlan = "plan"
+)
+
+// commandPermissions are the team permissions each command requires
+var commandPermissions = map[string]string{
+	CommandRetry:  "execute_tasks",
+	CommandCancel: "cancel_tasks",
+	CommandPlan:   "create_tasks",
+}
+
+// commentCommandLine matches a /pilot command at the start of a line
+var commentCommandLine = regexp.MustCompile(`(?i)^\s*/pilot\s+([A-Za-z][\w-]*)[ \t]*(.*)$`)
+
+// issueURLNumber extracts the issue number from a comment's issue_url
+var issueURLNumber = regexp.MustCompile(`/issues/(\d+)$`)
+
+// CommentCommand is a /pilot command posted as an issue comment
+type CommentCommand struct {
+	Name        string // e.g. "retry"
+	Args        string // Rest of the command line
+	IssueNumber int
+	CommentID   int64
+	Author      string // GitHub login of the commenter
+}
+
+// ParseCommentCommand returns the first /pilot command in a comment body.
+// Lines in fenced code blocks and quotes are skipped so quoting a command
+// does not run it again.
+func ParseCommentCommand(body string) (name, args string, ok bool) {
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		if m := commentCommandLine.FindStringSubmatch(line); m != nil {
+			return strings.ToLower(m[1]), strings.TrimSpace(m[2]), true
+		}
+	}
+	return "", "", false
+}
+
+// TeamResolver maps GitHub users to team members and checks their
+// permissions. Implemented by teams.ServiceAdapter to avoid circular imports.
+type TeamResolver interface {
+	// ResolveGitHubIdentity returns ("", nil) when no member matches
+	ResolveGitHubIdentity(ghUser, email string) (string, error)
+	CheckPermission(memberID string, perm string) error
+}
+
+// CommentCommandFunc runs a comment command. The returned message is
+// posted as a reply on the issue.
+type CommentCommandFunc func(ctx context.Context, cmd *CommentCommand) (string, error)
+
+// CommentCommandWatcher runs /pilot commands that collaborators post as
+// issue comments. Commenters need write access to the repo and, when they
+// are team members, the team permission of the command.
+type CommentCommandWatcher struct {
+	client   *Client
+	owner    string
+	repo     string
+	interval time.Duration
+	team     TeamResolver
+	webhooks *WebhookFeed
+	commands map[string]CommentCommandFunc
+	logger   *slog.Logger
+
+	// Comments already handled, and where the next listing starts
+	handled *DeliveryLog
+	mu      sync.Mutex
+	since   time.Time
+	started time.Time
+	wake    chan struct{}
+}
+
+// CommentCommandOption configures a CommentCommandWatcher
+type CommentCommandOption func(*CommentCommandWatcher)
+
+// WithCommand sets the function that runs a command
+func WithCommand(name string, fn CommentCommandFunc) CommentCommandOption {
+	return func(w *CommentCommandWatcher) {
+		w.commands[name] = fn
+	}
+}
+
+// WithCommandTeam checks commenters that are team members against the
+// permission of the command
+func WithCommandTeam(team TeamResolver) CommentCommandOption {
+	return func(w *CommentCommandWatcher) {
+		w.team = team
+	}
+}
+
+// WithCommandWebhookFeed lets webhook deliveries for the repo trigger a
+// check. In webhook mode scheduled checks are skipped while they arrive.
+func WithCommandWebhookFeed(f *WebhookFeed) CommentCommandOption {
+	return func(w *CommentCommandWatcher) {
+		w.webhooks = f
+	}
+}
+
+// NewCommentCommandWatcher creates a watcher for comment commands.
+// The repo parameter should be in "owner/repo" format.
+func NewCommentCommandWatcher(client *Client, repo string, config *CommentCommandsConfig, opts ...CommentCommandOption) (*CommentCommandWatcher, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo format, expected owner/repo: %s", repo)
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+
+	now := time.Now()
+	w := &CommentCommandWatcher{
+		client:   client,
+		owner:    parts[0],
+		repo:     parts[1],
+		interval: interval,
+		commands: make(map[string]CommentCommandFunc),
+		logger:   logging.WithComponent("github-commands"),
+		handled:  NewDeliveryLog(24*time.Hour, 1000),
+		since:    now,
+		started:  now,
+		wake:     make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.webhooks != nil {
+		w.webhooks.register(repo, w.poke)
+	}
+	return w, nil
+}
+
+// Start polls for comment commands until ctx is cancelled. Comments posted
+// before the watcher started are ignored.
+func (w *CommentCommandWatcher) Start(ctx context.Context) {
+	w.logger.Info("Watching for comment commands",
+		slog.String("repo", w.owner+"/"+w.repo),
+		slog.Duration("interval", w.interval),
+	)
+
+	timer := time.NewTimer(w.interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-timer.C:
+			timer.Reset(w.interval)
+			if w.webhooks.skipPoll() {
+				continue
+			}
+		}
+		if err := w.Check(ctx); err != nil {
+			w.logger.Warn("Comment command check failed", slog.Any("error", err))
+		}
+	}
+}
+
+func (w *CommentCommandWatcher) poke() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Check lists the comments posted since the last check and runs their
+// commands in the background, since planning may take minutes
+func (w *CommentCommandWatcher) Check(ctx context.Context) error {
+	w.mu.Lock()
+	since := w.since
+	w.mu.Unlock()
+
+	comments, err := w.client.ListRepoIssueComments(ctx, w.owner, w.repo, since)
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	for _, comment := range comments {
+		if comment.UpdatedAt.After(since) {
+			since = comment.UpdatedAt
+		}
+		// Edits of comments posted before the watcher started are ignored
+		if comment.CreatedAt.Before(w.started) {
+			continue
+		}
+		m := issueURLNumber.FindStringSubmatch(comment.IssueURL)
+		if m == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(m[1])
+		go w.Handle(ctx, number, comment)
+	}
+
+	w.mu.Lock()
+	w.since = since
+	w.mu.Unlock()
+	return nil
+}
+
+// Handle runs the command of a comment on an issue, if it has one and the
+// commenter may run it, and replies with the outcome. Each comment is
+// handled once.
+func (w *CommentCommandWatcher) Handle(ctx context.Context, issueNumber int, comment *Comment) {
+	name, args, ok := ParseCommentCommand(comment.Body)
+	if !ok || strings.HasSuffix(comment.User.Login, "[bot]") {
+		return
+	}
+	if w.handled.Seen(strconv.FormatInt(comment.ID, 10)) {
+		return
+	}
+
+	cmd := &CommentCommand{
+		Name:        name,
+		Args:        args,
+		IssueNumber: issueNumber,
+		CommentID:   comment.ID,
+		Author:      comment.User.Login,
+	}
+	log := w.logger.With(
+		slog.String("repo", w.owner+"/"+w.repo),
+		slog.Int("issue", issueNumber),
+		slog.String("command", name),
+		slog.String("author", cmd.Author),
+	)
+
+	// Commenters without write access get no reply at all, so they can't
+	// make Pilot post on the repo
+	if err := w.authorize(ctx, cmd); err != nil {
+		log.Info("Comment command rejected", slog.Any("error", err))
+		if !errors.Is(err, errNoRepoAccess) {
+			w.reply(ctx, issueNumber, fmt.Sprintf("🚫 `/pilot %s` not run: %s", name, err))
+		}
+		return
+	}
+	fn, known := w.commands[name]
+	if !known {
+		w.reply(ctx, issueNumber, fmt.Sprintf("❓ Unknown command `/pilot %s`. Available: %s", name, w.available()))
+		return
+	}
+
+	log.Info("Running comment command")
+	msg, err := fn(ctx, cmd)
+	if err != nil {
+		log.Warn("Comment command failed", slog.Any("error", err))
+		w.reply(ctx, issueNumber, fmt.Sprintf("❌ `/pilot %s` failed: %s", name, err))
+		return
+	}
+	if msg != "" {
+		w.reply(ctx, issueNumber, msg)
+	}
+}
+
+// errNoRepoAccess marks commenters without verified write access to the repo
+var errNoRepoAccess = errors.New("no write access")
+
+// authorize requires write access to the repo, and the command's team
+// permission when the commenter is a team member
+func (w *CommentCommandWatcher) authorize(ctx context.Context, cmd *CommentCommand) error {
+	permission, err := w.client.GetCollaboratorPermission(ctx, w.owner, w.repo, cmd.Author)
+	if err != nil {
+		return fmt.Errorf("%w: could not verify the permissions of @%s: %v", errNoRepoAccess, cmd.Author, err)
+	}
+	switch permission {
+	case "admin", "maintain", "write":
+	default:
+		return fmt.Errorf("%w: @%s has %s", errNoRepoAccess, cmd.Author, permission)
+	}
+
+	if w.team == nil {
+		return nil
+	}
+	memberID, err := w.team.ResolveGitHubIdentity(cmd.Author, "")
+	if err != nil {
+		return fmt.Errorf("could not resolve @%s to a team member: %w", cmd.Author, err)
+	}
+	if memberID == "" {
+		return nil
+	}
+	if perm, ok := commandPermissions[cmd.Name]; ok {
+		if err := w.team.CheckPermission(memberID, perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// available lists the configured commands for help replies
+func (w *CommentCommandWatcher) available() string {
+	names := make([]string, 0, len(w.commands))
+	for name := range w.commands {
+		names = append(names, "`/pilot "+name+"`")
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func (w *CommentCommandWatcher) reply(ctx context.Context, issueNumber int, body string) {
+	if _, err := w.client.AddComment(ctx, w.owner, w.repo, issueNumber, body); err != nil {
+		w.logger.Warn("Failed to reply to comment command",
+			slog.Int("issue", issueNumber),
+			slog.Any("error", err))
+	}
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestParseCommentCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantName string
+		wantArgs string
+		wantOK   bool
+	}{
+		{name: "retry", body: "/pilot retry", wantName: "retry", wantOK: true},
+		{name: "args and case", body: "Looks flaky.\n  /Pilot RETRY  now please", wantName: "retry", wantArgs: "now please", wantOK: true},
+		{name: "first command wins", body: "/pilot plan\n/pilot cancel", wantName: "plan", wantOK: true},
+		{name: "mid-line", body: "please run /pilot retry", wantOK: false},
+		{name: "quoted", body: "> /pilot cancel\n\nwhy?", wantOK: false},
+		{name: "code block", body: "```\n/pilot retry\n```", wantOK: false},
+		{name: "bare", body: "/pilot", wantOK: false},
+		{name: "other prefix", body: "/pilotretry", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args, ok := ParseCommentCommand(tt.body)
+			if ok != tt.wantOK || name != tt.wantName || args != tt.wantArgs {
+				t.Errorf("ParseCommentCommand() = (%q, %q, %v), want (%q, %q, %v)", name, args, ok, tt.wantName, tt.wantArgs, tt.wantOK)
+			}
+		})
+	}
+}
+
+type fakeTeamResolver struct {
+	members map[string]string
+	denied  map[string]bool // memberID+perm
+}
+
+func (f *fakeTeamResolver) ResolveGitHubIdentity(ghUser, email string) (string, error) {
+	return f.members[ghUser], nil
+}
+
+func (f *fakeTeamResolver) CheckPermission(memberID string, perm string) error {
+	if f.denied[memberID+":"+perm] {
+		return errors.New("permission denied: " + perm)
+	}
+	return nil
+}
+
+// commandServer fakes the collaborator permission and comment endpoints and
+// records the replies posted
+type commandServer struct {
+	mu          sync.Mutex
+	permissions map[string]string
+	comments    []*Comment
+	replies     []string
+}
+
+func (s *commandServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case strings.Contains(r.URL.Path, "/collaborators/"):
+		user := strings.Split(r.URL.Path, "/")[5]
+		perm, ok := s.permissions[user]
+		if !ok {
+			perm = "read"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"permission": perm})
+	case r.URL.Path == "/repos/owner/repo/issues/comments":
+		_ = json.NewEncoder(w).Encode(s.comments)
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/comments"):
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.replies = append(s.replies, body["body"])
+		_ = json.NewEncoder(w).Encode(Comment{ID: 999})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *commandServer) lastReply() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.replies) == 0 {
+		return ""
+	}
+	return s.replies[len(s.replies)-1]
+}
+
+func TestCommentCommandWatcher_Handle(t *testing.T) {
+	fake := &commandServer{permissions: map[string]string{"maintainer": "write", "member": "admin"}}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	team := &fakeTeamResolver{
+		members: map[string]string{"member": "m1"},
+		denied:  map[string]bool{"m1:cancel_tasks": true},
+	}
+	var ran []*CommentCommand
+	run := func(ctx context.Context, cmd *CommentCommand) (string, error) {
+		ran = append(ran, cmd)
+		return "done " + cmd.Name, nil
+	}
+	w, err := NewCommentCommandWatcher(NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), "owner/repo", &CommentCommandsConfig{},
+		WithCommand(CommandRetry, run),
+		WithCommand(CommandCancel, run),
+		WithCommand(CommandPlan, func(ctx context.Context, cmd *CommentCommand) (string, error) {
+			return "", errors.New("planner unavailable")
+		}),
+		WithCommandTeam(team),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	comment := func(id int64, login, body string) *Comment {
+		return &Comment{ID: id, Body: body, User: User{Login: login}}
+	}
+
+	w.Handle(ctx, 7, comment(1, "maintainer", "/pilot retry"))
+	if len(ran) != 1 || ran[0].IssueNumber != 7 || ran[0].Author != "maintainer" {
+		t.Fatalf("retry not run as expected: %+v", ran)
+	}
+	if got := fake.lastReply(); got != "done retry" {
+		t.Errorf("reply = %q, want %q", got, "done retry")
+	}
+
+	// The same comment seen again, e.g. via webhook and poll, runs once
+	w.Handle(ctx, 7, comment(1, "maintainer", "/pilot retry"))
+	if len(ran) != 1 {
+		t.Error("comment handled twice")
+	}
+
+	// Commenters without write access are ignored without a reply, whether
+	// or not the command exists
+	replies := len(fake.replies)
+	w.Handle(ctx, 7, comment(2, "drive-by", "/pilot retry"))
+	w.Handle(ctx, 7, comment(6, "drive-by", "/pilot deploy"))
+	if len(fake.replies) != replies {
+		t.Errorf("replied to a read-only commenter: %q", fake.replies[replies:])
+	}
+
+	w.Handle(ctx, 7, comment(3, "member", "/pilot cancel"))
+	if !strings.Contains(fake.lastReply(), "cancel_tasks") {
+		t.Errorf("team permission reply = %q", fake.lastReply())
+	}
+
+	w.Handle(ctx, 7, comment(4, "maintainer", "/pilot deploy"))
+	if !strings.Contains(fake.lastReply(), "Unknown command") || !strings.Contains(fake.lastReply(), "`/pilot cancel`, `/pilot plan`, `/pilot retry`") {
+		t.Errorf("unknown command reply = %q", fake.lastReply())
+	}
+
+	w.Handle(ctx, 7, comment(5, "maintainer", "/pilot plan"))
+	if !strings.Contains(fake.lastReply(), "planner unavailable") {
+		t.Errorf("failed command reply = %q", fake.lastReply())
+	}
+
+	if len(ran) != 1 {
+		t.Errorf("rejected commands ran: %+v", ran)
+	}
+}
+
+func TestCommentCommandWatcher_Check(t *testing.T) {
+	fake := &commandServer{permissions: map[string]string{"maintainer": "write"}}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	ran := make(chan *CommentCommand, 4)
+	w, err := NewCommentCommandWatcher(NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), "owner/repo", &CommentCommandsConfig{},
+		WithCommand(CommandCancel, func(ctx context.Context, cmd *CommentCommand) (string, error) {
+			ran <- cmd
+			return "", nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	fake.comments = []*Comment{
+		// Posted before the watcher started, then edited
+		{ID: 1, Body: "/pilot cancel", User: User{Login: "maintainer"}, IssueURL: server.URL + "/repos/owner/repo/issues/3",
+			CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(time.Second)},
+		{ID: 2, Body: "/pilot cancel", User: User{Login: "maintainer"}, IssueURL: server.URL + "/repos/owner/repo/issues/4",
+			CreatedAt: now.Add(time.Second), UpdatedAt: now.Add(2 * time.Second)},
+		{ID: 3, Body: "/pilot cancel", User: User{Login: "pilot-app[bot]"}, IssueURL: server.URL + "/repos/owner/repo/issues/5",
+			CreatedAt: now.Add(time.Second), UpdatedAt: now.Add(3 * time.Second)},
+	}
+
+	if err := w.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+
+	select {
+	case cmd := <-ran:
+		if cmd.IssueNumber != 4 || cmd.CommentID != 2 {
+			t.Errorf("ran %+v, want comment 2 on issue 4", cmd)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("command not run")
+	}
+	select {
+	case cmd := <-ran:
+		t.Errorf("unexpected command run: %+v", cmd)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	w.mu.Lock()
+	since := w.since
+	w.mu.Unlock()
+	if !since.Equal(now.Add(3 * time.Second)) {
+		t.Errorf("since = %v, want the latest update", since)
+	}
+}
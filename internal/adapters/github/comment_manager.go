@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// statusCommentMarker tags the status comment of the attempt in progress.
+// It is appended (not prepended) so comment bodies keep their leading
+// headline, which ExtractPriorAttempts matches on. Finish drops the marker,
+// so the next attempt starts a fresh comment instead of overwriting history.
+const statusCommentMarker = "<!-- pilot:status -->"
+
+// defaultCommentMinInterval is the default gap enforced between progress edits.
+const defaultCommentMinInterval = 30 * time.Second
+
+// CommentManager reports Pilot status on an issue through a single comment
+// per attempt that is edited in place, the way CI bots do, instead of
+// appending a new comment for every update. In legacy mode every update
+// is posted as a new comment.
+type CommentManager struct {
+	client      *Client
+	legacy      bool
+	minInterval time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	threads map[string]*statusThread
+}
+
+// statusThread tracks the live status comment for one issue.
+type statusThread struct {
+	commentID int64
+	lastEdit  time.Time
+}
+
+// NewCommentManager creates a comment manager. A nil config selects single mode.
+func NewCommentManager(client *Client, cfg *CommentsConfig) *CommentManager {
+	m := &CommentManager{
+		client:      client,
+		minInterval: defaultCommentMinInterval,
+		now:         time.Now,
+		threads:     make(map[string]*statusThread),
+	}
+	if cfg != nil {
+		m.legacy = strings.EqualFold(cfg.Mode, CommentModeLegacy)
+		if cfg.MinInterval > 0 {
+			m.minInterval = cfg.MinInterval
+		}
+	}
+	return m
+}
+
+// InPlace reports whether status updates edit a single comment.
+func (m *CommentManager) InPlace() bool {
+	return !m.legacy
+}
+
+// Post publishes body as the issue's current status.
+func (m *CommentManager) Post(ctx context.Context, owner, repo string, number int, body string) error {
+	return m.publish(ctx, owner, repo, number, body, false)
+}
+
+// PostProgress publishes an intermediate update. In single mode, updates
+// arriving within MinInterval of the previous edit are dropped; the final
+// result always follows via Finish, so nothing durable is lost.
+func (m *CommentManager) PostProgress(ctx context.Context, owner, repo string, number int, body string) error {
+	if !m.legacy {
+		m.mu.Lock()
+		t := m.threads[threadKey(owner, repo, number)]
+		throttled := t != nil && m.now().Sub(t.lastEdit) < m.minInterval
+		m.mu.Unlock()
+		if throttled {
+			return nil
+		}
+	}
+	return m.publish(ctx, owner, repo, number, body, false)
+}
+
+// Finish publishes the final status for the current attempt and closes it,
+// so the next Post starts a new comment.
+func (m *CommentManager) Finish(ctx context.Context, owner, repo string, number int, body string) error {
+	return m.publish(ctx, owner, repo, number, body, true)
+}
+
+func (m *CommentManager) publish(ctx context.Context, owner, repo string, number int, body string, final bool) error {
+	if m.legacy {
+		_, err := m.client.AddComment(ctx, owner, repo, number, body)
+		return err
+	}
+
+	key := threadKey(owner, repo, number)
+	if !final {
+		body = body + "\n\n" + statusCommentMarker
+	}
+
+	// Snapshot the thread under the lock; GitHub calls happen without it so
+	// a slow API does not stall updates for unrelated issues.
+	var commentID int64
+	m.mu.Lock()
+	if t := m.threads[key]; t != nil {
+		commentID = t.commentID
+	}
+	m.mu.Unlock()
+
+	if commentID == 0 {
+		// Another process (or a restart) may have opened the attempt already
+		id, err := m.findOpenStatusComment(ctx, owner, repo, number)
+		if err != nil {
+			return err
+		}
+		commentID = id
+	}
+
+	if commentID != 0 {
+		if _, err := m.client.UpdateComment(ctx, owner, repo, commentID, body); err != nil {
+			return fmt.Errorf("failed to update status comment: %w", err)
+		}
+	} else {
+		comment, err := m.client.AddComment(ctx, owner, repo, number, body)
+		if err != nil {
+			return fmt.Errorf("failed to add status comment: %w", err)
+		}
+		commentID = comment.ID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if final {
+		delete(m.threads, key)
+		return nil
+	}
+	m.threads[key] = &statusThread{commentID: commentID, lastEdit: m.now()}
+	return nil
+}
+
+// findOpenStatusComment returns the ID of the most recent comment of the
+// token's user still carrying the status marker, or 0 if there is none.
+// Anyone can paste the marker into a comment; those are never taken over.
+func (m *CommentManager) findOpenStatusComment(ctx context.Context, owner, repo string, number int) (int64, error) {
+	comments, err := m.client.ListIssueComments(ctx, owner, repo, number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list comments: %w", err)
+	}
+	login := ""
+	for i := len(comments) - 1; i >= 0; i-- {
+		if comments[i] == nil || !strings.Contains(comments[i].Body, statusCommentMarker) {
+			continue
+		}
+		if login == "" {
+			if login, err = m.client.Login(ctx); err != nil {
+				return 0, err
+			}
+		}
+		if strings.EqualFold(comments[i].User.Login, login) {
+			return comments[i].ID, nil
+		}
+	}
+	return 0, nil
+}
+
+func threadKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
@@ -0,0 +1,555 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// LabelConfig marks issues that request a change to the repo's .pilot.yaml
+const LabelConfig = "pilot-config"
+
+// DefaultConfigRequestSettings are the .pilot.yaml settings a config request
+// may change. Quality gates and prompt additions are left out: they run
+// commands and steer the agent, so they go through normal code review.
+var DefaultConfigRequestSettings = []string{
+	"base_branch",
+	"max_pr_size",
+	"protected_paths",
+	"allow_decompose",
+	"allow_direct_commit",
+	"allow_release",
+	"allow_epics",
+}
+
+// configRequestBlock matches the fenced YAML block holding the requested settings
+var configRequestBlock = regexp.MustCompile("(?s)```(?:ya?ml)?[ \t]*\r?\n(.*?)```")
+
+// ConfigChange is a validated .pilot.yaml change ready to be proposed
+type ConfigChange struct {
+	BaseBranch string   // Branch the change targets (the repo's default branch)
+	BaseSHA    string   // Head of BaseBranch the change was computed against
+	Content    []byte   // New .pilot.yaml content
+	Summary    []string // One line per changed setting, e.g. "max_pr_size: 500 → 800"
+}
+
+// ConfigRequestWatcher turns "pilot-config" issues filed by repo admins into
+// .pilot.yaml changes. A request is validated, approved through the approval
+// manager and proposed as a PR; once the PR merges, Pilot reloads the config.
+type ConfigRequestWatcher struct {
+	client    *Client
+	owner     string
+	repo      string
+	label     string
+	interval  time.Duration
+	settings  map[string]bool
+	approvals *approval.Manager
+	logger    *slog.Logger
+
+	// OnApplied is called after a config change PR merged.
+	// Used to reload the repo config of the local project.
+	OnApplied func(ctx context.Context)
+
+	mu      sync.Mutex
+	active  map[int]bool // Issues being validated or awaiting approval
+	pending map[int]int  // Issue number -> open config change PR number
+}
+
+// ConfigRequestOption configures a ConfigRequestWatcher
+type ConfigRequestOption func(*ConfigRequestWatcher)
+
+// WithConfigRequestApprovals sets the approval manager asked before a change
+// is proposed. Without one, changes are proposed right away and the PR
+// review is the only gate.
+func WithConfigRequestApprovals(m *approval.Manager) ConfigRequestOption {
+	return func(w *ConfigRequestWatcher) {
+		w.approvals = m
+	}
+}
+
+// WithOnConfigApplied sets the callback for when a config change PR merged
+func WithOnConfigApplied(fn func(ctx context.Context)) ConfigRequestOption {
+	return func(w *ConfigRequestWatcher) {
+		w.OnApplied = fn
+	}
+}
+
+// NewConfigRequestWatcher creates a watcher for config request issues.
+// The repo parameter should be in "owner/repo" format.
+func NewConfigRequestWatcher(client *Client, repo string, config *ConfigRequestsConfig, opts ...ConfigRequestOption) (*ConfigRequestWatcher, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo format, expected owner/repo: %s", repo)
+	}
+
+	label := config.Label
+	if label == "" {
+		label = LabelConfig
+	}
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+	allowed := config.Settings
+	if len(allowed) == 0 {
+		allowed = DefaultConfigRequestSettings
+	}
+
+	w := &ConfigRequestWatcher{
+		client:   client,
+		owner:    parts[0],
+		repo:     parts[1],
+		label:    label,
+		interval: interval,
+		settings: make(map[string]bool, len(allowed)),
+		logger:   logging.WithComponent("github-config"),
+		active:   make(map[int]bool),
+		pending:  make(map[int]int),
+	}
+	for _, s := range allowed {
+		w.settings[s] = true
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w, nil
+}
+
+// Label returns the issue label the watcher handles
+func (w *ConfigRequestWatcher) Label() string {
+	return w.label
+}
+
+// Start polls for config requests until ctx is cancelled
+func (w *ConfigRequestWatcher) Start(ctx context.Context) {
+	w.logger.Info("Watching for config requests",
+		slog.String("repo", w.owner+"/"+w.repo),
+		slog.String("label", w.label),
+		slog.Duration("interval", w.interval),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Check(ctx); err != nil {
+			w.logger.Warn("Config request check failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check starts handling new config requests and finishes those whose PR was
+// merged or closed. Requests are handled in the background because approval
+// may take hours.
+func (w *ConfigRequestWatcher) Check(ctx context.Context) error {
+	issues, err := w.client.ListIssues(ctx, w.owner, w.repo, &ListIssuesOptions{
+		Labels: []string{w.label},
+		State:  StateOpen,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list config requests: %w", err)
+	}
+
+	var openPRs []*PullRequest
+	for _, issue := range issues {
+		if issue.PullRequest != nil || HasLabel(issue, LabelDone) || HasLabel(issue, LabelFailed) {
+			continue
+		}
+
+		w.mu.Lock()
+		_, proposed := w.pending[issue.Number]
+		busy := w.active[issue.Number] || proposed
+		if !busy {
+			w.active[issue.Number] = true
+		}
+		w.mu.Unlock()
+		if busy {
+			continue
+		}
+
+		// Resume tracking a PR opened before a restart
+		if HasLabel(issue, LabelInProgress) {
+			if openPRs == nil {
+				if openPRs, err = w.client.ListPullRequests(ctx, w.owner, w.repo, StateOpen); err != nil {
+					w.release(issue.Number)
+					return fmt.Errorf("failed to list pull requests: %w", err)
+				}
+			}
+			if pr := findPRByHead(openPRs, configBranch(issue.Number)); pr != nil {
+				w.mu.Lock()
+				delete(w.active, issue.Number)
+				w.pending[issue.Number] = pr.Number
+				w.mu.Unlock()
+				continue
+			}
+		}
+
+		go func(issue *Issue) {
+			defer w.release(issue.Number)
+			w.Handle(ctx, issue)
+		}(issue)
+	}
+
+	w.checkPending(ctx)
+	return nil
+}
+
+func (w *ConfigRequestWatcher) release(number int) {
+	w.mu.Lock()
+	delete(w.active, number)
+	w.mu.Unlock()
+}
+
+// Handle validates a config request, asks for approval and opens the PR
+// applying it. The outcome is reported on the issue.
+func (w *ConfigRequestWatcher) Handle(ctx context.Context, issue *Issue) {
+	log := w.logger.With(slog.Int("issue", issue.Number))
+	if err := w.client.AddLabels(ctx, w.owner, w.repo, issue.Number, []string{LabelInProgress}); err != nil {
+		log.Warn("Failed to add in-progress label", slog.Any("error", err))
+	}
+
+	change, err := w.Prepare(ctx, issue)
+	if err != nil {
+		log.Info("Config request rejected", slog.Any("error", err))
+		w.finish(ctx, issue.Number, LabelFailed, fmt.Sprintf("❌ **Config change not applied**\n\n%s", err))
+		return
+	}
+	if change == nil {
+		w.finish(ctx, issue.Number, LabelDone, fmt.Sprintf("✅ `%s` already has the requested settings, nothing to change.", executor.RepoConfigFile))
+		_ = w.client.UpdateIssueState(ctx, w.owner, w.repo, issue.Number, StateClosed)
+		return
+	}
+
+	approvedBy, err := w.approve(ctx, issue, change)
+	if err != nil {
+		log.Info("Config request not approved", slog.Any("error", err))
+		w.finish(ctx, issue.Number, LabelFailed, fmt.Sprintf("❌ **Config change not applied**\n\n%s", err))
+		return
+	}
+
+	pr, err := w.propose(ctx, issue, change, approvedBy)
+	if err != nil {
+		log.Warn("Failed to open config change PR", slog.Any("error", err))
+		w.finish(ctx, issue.Number, LabelFailed, fmt.Sprintf("❌ **Config change not applied**\n\nFailed to open the pull request: %s", err))
+		return
+	}
+
+	w.mu.Lock()
+	w.pending[issue.Number] = pr.Number
+	w.mu.Unlock()
+	log.Info("Opened config change PR", slog.Int("pr", pr.Number))
+	_, _ = w.client.AddComment(ctx, w.owner, w.repo, issue.Number,
+		fmt.Sprintf("⚙️ Opened %s to apply this change. Pilot reloads its settings once it is merged.", pr.HTMLURL))
+}
+
+// Prepare checks that the issue author is a repo admin and that the
+// requested settings are allowed and valid, then computes the new
+// .pilot.yaml. Returns nil without error when nothing would change.
+func (w *ConfigRequestWatcher) Prepare(ctx context.Context, issue *Issue) (*ConfigChange, error) {
+	login := issue.User.Login
+	permission, err := w.client.GetCollaboratorPermission(ctx, w.owner, w.repo, login)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify the permissions of @%s: %w", login, err)
+	}
+	if permission != "admin" {
+		return nil, fmt.Errorf("only repository admins can change Pilot settings, @%s has %s access", login, permission)
+	}
+
+	requested, err := ParseConfigRequest(issue.Body)
+	if err != nil {
+		return nil, err
+	}
+	var denied []string
+	for i := 0; i < len(requested.Content); i += 2 {
+		if key := requested.Content[i].Value; !w.settings[key] {
+			denied = append(denied, key)
+		}
+	}
+	if len(denied) > 0 {
+		return nil, fmt.Errorf("these settings cannot be changed by issue: %s (allowed: %s)",
+			strings.Join(denied, ", "), strings.Join(w.allowedSettings(), ", "))
+	}
+
+	repository, err := w.client.GetRepository(ctx, w.owner, w.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	base := repository.DefaultBranch
+	if base == "" {
+		base = "main"
+	}
+	branch, err := w.client.GetBranch(ctx, w.owner, w.repo, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch %s: %w", base, err)
+	}
+	current, err := w.client.GetFileContent(ctx, w.owner, w.repo, executor.RepoConfigFile, branch.Commit.SHA)
+	if err != nil && !isNotFoundError(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", executor.RepoConfigFile, err)
+	}
+
+	content, summary, err := ApplyConfigRequest(current, requested)
+	if err != nil {
+		return nil, err
+	}
+	if len(summary) == 0 {
+		return nil, nil
+	}
+	if _, err := executor.ParseRepoConfig(content); err != nil {
+		return nil, err
+	}
+
+	return &ConfigChange{
+		BaseBranch: base,
+		BaseSHA:    branch.Commit.SHA,
+		Content:    content,
+		Summary:    summary,
+	}, nil
+}
+
+func (w *ConfigRequestWatcher) allowedSettings() []string {
+	allowed := make([]string, 0, len(w.settings))
+	for s := range w.settings {
+		allowed = append(allowed, s)
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// approve asks the approval manager for the go-ahead and returns who gave it
+func (w *ConfigRequestWatcher) approve(ctx context.Context, issue *Issue, change *ConfigChange) (string, error) {
+	if w.approvals == nil {
+		return "", nil
+	}
+	resp, err := w.approvals.RequestApproval(ctx, &approval.Request{
+		ID:     fmt.Sprintf("config-%s-%s-%d", w.owner, w.repo, issue.Number),
+		TaskID: fmt.Sprintf("GH-%d", issue.Number),
+		Stage:  approval.StageConfigChange,
+		Title:  issue.Title,
+		Description: fmt.Sprintf("@%s requests a %s change in %s/%s:\n%s",
+			issue.User.Login, executor.RepoConfigFile, w.owner, w.repo, "• "+strings.Join(change.Summary, "\n• ")),
+		Metadata: map[string]interface{}{
+			"issue_url":    issue.HTMLURL,
+			"requested_by": issue.User.Login,
+		},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("approval request failed: %w", err)
+	}
+	if resp.Decision != approval.DecisionApproved {
+		reason := fmt.Sprintf("The change was %s", resp.Decision)
+		if resp.ApprovedBy != "" && resp.ApprovedBy != "system" {
+			reason += " by " + resp.ApprovedBy
+		}
+		if resp.Comment != "" {
+			reason += ": " + resp.Comment
+		}
+		return "", errors.New(reason)
+	}
+	if resp.ApprovedBy == "system" {
+		return "", nil
+	}
+	return resp.ApprovedBy, nil
+}
+
+// propose commits the new .pilot.yaml to a branch and opens a PR for it
+func (w *ConfigRequestWatcher) propose(ctx context.Context, issue *Issue, change *ConfigChange, approvedBy string) (*PullRequest, error) {
+	branch := configBranch(issue.Number)
+	if err := w.client.UpdateRef(ctx, w.owner, w.repo, branch, change.BaseSHA); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	message := fmt.Sprintf("chore(pilot): update %s (#%d)", executor.RepoConfigFile, issue.Number)
+	if _, err := w.client.CommitFiles(ctx, w.owner, w.repo, branch, change.BaseSHA, message,
+		map[string][]byte{executor.RepoConfigFile: change.Content}); err != nil {
+		return nil, err
+	}
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("Requested by @%s in #%d", issue.User.Login, issue.Number))
+	if approvedBy != "" {
+		body.WriteString(", approved by " + approvedBy)
+	}
+	body.WriteString(".\n\n## Changes\n\n")
+	for _, line := range change.Summary {
+		body.WriteString("- `" + line + "`\n")
+	}
+	body.WriteString(fmt.Sprintf("\nCloses #%d\n", issue.Number))
+
+	return w.client.CreatePullRequest(ctx, w.owner, w.repo, &PullRequestInput{
+		Title: message,
+		Body:  body.String(),
+		Head:  branch,
+		Base:  change.BaseBranch,
+	})
+}
+
+// checkPending finishes requests whose PR was merged or closed
+func (w *ConfigRequestWatcher) checkPending(ctx context.Context) {
+	w.mu.Lock()
+	pending := make(map[int]int, len(w.pending))
+	for issue, pr := range w.pending {
+		pending[issue] = pr
+	}
+	w.mu.Unlock()
+
+	for issue, number := range pending {
+		pr, err := w.client.GetPullRequest(ctx, w.owner, w.repo, number)
+		if err != nil {
+			w.logger.Warn("Failed to check config change PR", slog.Int("pr", number), slog.Any("error", err))
+			continue
+		}
+		switch {
+		case pr.Merged:
+			if w.OnApplied != nil {
+				w.OnApplied(ctx)
+			}
+			w.finish(ctx, issue, LabelDone, fmt.Sprintf("✅ **Config change applied** in %s. Pilot reloaded its settings.", pr.HTMLURL))
+		case pr.State == StateClosed:
+			w.finish(ctx, issue, LabelFailed, fmt.Sprintf("❌ %s was closed without merging, the change was not applied.", pr.HTMLURL))
+		default:
+			continue
+		}
+		w.mu.Lock()
+		delete(w.pending, issue)
+		w.mu.Unlock()
+	}
+}
+
+// finish reports the outcome on the issue and swaps the in-progress label for label
+func (w *ConfigRequestWatcher) finish(ctx context.Context, number int, label, comment string) {
+	if _, err := w.client.AddComment(ctx, w.owner, w.repo, number, comment); err != nil {
+		w.logger.Warn("Failed to comment on config request", slog.Int("issue", number), slog.Any("error", err))
+	}
+	_ = w.client.RemoveLabel(ctx, w.owner, w.repo, number, LabelInProgress)
+	if err := w.client.AddLabels(ctx, w.owner, w.repo, number, []string{label}); err != nil {
+		w.logger.Warn("Failed to label config request", slog.Int("issue", number), slog.Any("error", err))
+	}
+}
+
+func configBranch(issueNumber int) string {
+	return fmt.Sprintf("pilot/config-%d", issueNumber)
+}
+
+func findPRByHead(prs []*PullRequest, branch string) *PullRequest {
+	for _, pr := range prs {
+		if pr.Head.Ref == branch {
+			return pr
+		}
+	}
+	return nil
+}
+
+// ParseConfigRequest reads the requested settings from the first fenced YAML
+// block of an issue body. A null value removes the setting.
+func ParseConfigRequest(body string) (*yaml.Node, error) {
+	m := configRequestBlock.FindStringSubmatch(body)
+	if m == nil {
+		return nil, fmt.Errorf("no settings found: put the settings to change in a ```yaml block, e.g.\n\n```yaml\nmax_pr_size: 800\n```")
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(m[1]), &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML in the settings block: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode || len(doc.Content[0].Content) == 0 {
+		return nil, fmt.Errorf("the settings block must map setting names to values")
+	}
+	return doc.Content[0], nil
+}
+
+// ApplyConfigRequest sets the requested settings in a .pilot.yaml, keeping
+// its other settings and comments. current may be empty when the file does
+// not exist. Returns the new content and one summary line per changed
+// setting; no lines means nothing changed.
+func ApplyConfigRequest(current []byte, requested *yaml.Node) ([]byte, []string, error) {
+	var doc yaml.Node
+	if len(bytes.TrimSpace(current)) > 0 {
+		if err := yaml.Unmarshal(current, &doc); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse the current %s: %w", executor.RepoConfigFile, err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("the current %s is not a mapping", executor.RepoConfigFile)
+	}
+
+	var summary []string
+	for i := 0; i+1 < len(requested.Content); i += 2 {
+		key, value := requested.Content[i], requested.Content[i+1]
+		idx := -1
+		for j := 0; j+1 < len(root.Content); j += 2 {
+			if root.Content[j].Value == key.Value {
+				idx = j
+				break
+			}
+		}
+		remove := value.Tag == "!!null"
+
+		switch {
+		case remove && idx < 0:
+			continue
+		case remove:
+			summary = append(summary, fmt.Sprintf("%s: %s → (removed)", key.Value, inlineYAML(root.Content[idx+1])))
+			root.Content = append(root.Content[:idx], root.Content[idx+2:]...)
+		case idx < 0:
+			summary = append(summary, fmt.Sprintf("%s: (unset) → %s", key.Value, inlineYAML(value)))
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.Value}, value)
+		default:
+			before, after := inlineYAML(root.Content[idx+1]), inlineYAML(value)
+			if before == after {
+				continue
+			}
+			summary = append(summary, fmt.Sprintf("%s: %s → %s", key.Value, before, after))
+			value.HeadComment, value.LineComment = root.Content[idx+1].HeadComment, root.Content[idx+1].LineComment
+			root.Content[idx+1] = value
+		}
+	}
+	if len(summary) == 0 {
+		return current, nil, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), summary, nil
+}
+
+// inlineYAML renders a value on one line for summaries
+func inlineYAML(node *yaml.Node) string {
+	flow := *node
+	flow.Style = yaml.FlowStyle
+	flow.HeadComment, flow.LineComment, flow.FootComment = "", "", ""
+	out, err := yaml.Marshal(&flow)
+	if err != nil {
+		return node.Value
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,232 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestParseConfigRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantKeys []string
+		wantErr  bool
+	}{
+		{
+			name:     "yaml block",
+			body:     "Please raise the limit.\n\n```yaml\nmax_pr_size: 800\nbase_branch: develop\n```\n",
+			wantKeys: []string{"max_pr_size", "base_branch"},
+		},
+		{
+			name:     "unlabelled fence",
+			body:     "```\nallow_epics: false\n```",
+			wantKeys: []string{"allow_epics"},
+		},
+		{name: "no block", body: "raise max_pr_size to 800", wantErr: true},
+		{name: "not a mapping", body: "```yaml\n- max_pr_size\n```", wantErr: true},
+		{name: "invalid yaml", body: "```yaml\nmax_pr_size: [800\n```", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := ParseConfigRequest(tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConfigRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var keys []string
+			for i := 0; i < len(node.Content); i += 2 {
+				keys = append(keys, node.Content[i].Value)
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestApplyConfigRequest(t *testing.T) {
+	current := []byte(`# Pilot settings for this repo
+base_branch: main
+max_pr_size: 500 # lines
+allow_epics: true
+quality:
+  gates:
+    - name: test
+      command: go test ./...
+`)
+
+	requested, err := ParseConfigRequest("```yaml\nmax_pr_size: 800\nallow_epics: null\nprotected_paths: [migrations/]\nbase_branch: main\n```")
+	if err != nil {
+		t.Fatalf("ParseConfigRequest failed: %v", err)
+	}
+
+	content, summary, err := ApplyConfigRequest(current, requested)
+	if err != nil {
+		t.Fatalf("ApplyConfigRequest failed: %v", err)
+	}
+
+	want := []string{
+		"max_pr_size: 500 → 800",
+		"allow_epics: true → (removed)",
+		"protected_paths: (unset) → [migrations/]",
+	}
+	if strings.Join(summary, "\n") != strings.Join(want, "\n") {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+
+	out := string(content)
+	for _, s := range []string{"# Pilot settings for this repo", "max_pr_size: 800 # lines", "command: go test ./...", "protected_paths: [migrations/]"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("new content missing %q:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "allow_epics") {
+		t.Errorf("allow_epics should be removed:\n%s", out)
+	}
+
+	// Same values again: nothing to change
+	requested, _ = ParseConfigRequest("```yaml\nmax_pr_size: 800\n```")
+	if _, summary, _ := ApplyConfigRequest(content, requested); len(summary) != 0 {
+		t.Errorf("summary = %v, want no changes", summary)
+	}
+
+	// Missing file
+	content, summary, err = ApplyConfigRequest(nil, requested)
+	if err != nil || len(summary) != 1 || strings.TrimSpace(string(content)) != "max_pr_size: 800" {
+		t.Errorf("ApplyConfigRequest(nil) = %q, %v, %v", content, summary, err)
+	}
+}
+
+// configRequestServer fakes the GitHub API calls made while preparing a
+// config change. The author has the given permission and the repo's
+// .pilot.yaml holds current.
+func configRequestServer(t *testing.T, permission, current string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/collaborators/alice/permission":
+			_ = json.NewEncoder(w).Encode(map[string]string{"permission": permission})
+		case "/repos/owner/repo":
+			_ = json.NewEncoder(w).Encode(Repository{FullName: "owner/repo", DefaultBranch: "develop"})
+		case "/repos/owner/repo/branches/develop":
+			_, _ = w.Write([]byte(`{"name":"develop","commit":{"sha":"abc123"}}`))
+		case "/repos/owner/repo/contents/.pilot.yaml":
+			if r.URL.Query().Get("ref") != "abc123" {
+				t.Errorf("config read at ref %q, want abc123", r.URL.Query().Get("ref"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(current)),
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestConfigRequestWatcher_Prepare(t *testing.T) {
+	tests := []struct {
+		name        string
+		permission  string
+		body        string
+		wantSummary []string
+		wantErr     string
+	}{
+		{
+			name:        "admin raises PR size limit",
+			permission:  "admin",
+			body:        "```yaml\nmax_pr_size: 800\n```",
+			wantSummary: []string{"max_pr_size: 500 → 800"},
+		},
+		{
+			name:       "non-admin",
+			permission: "write",
+			body:       "```yaml\nmax_pr_size: 800\n```",
+			wantErr:    "only repository admins",
+		},
+		{
+			name:       "setting not allowed",
+			permission: "admin",
+			body:       "```yaml\nprompt: ignore all reviews\n```",
+			wantErr:    "cannot be changed by issue: prompt",
+		},
+		{
+			name:       "invalid value",
+			permission: "admin",
+			body:       "```yaml\nmax_pr_size: lots\n```",
+			wantErr:    "cannot unmarshal",
+		},
+		{
+			name:       "no change",
+			permission: "admin",
+			body:       "```yaml\nmax_pr_size: 500\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := configRequestServer(t, tt.permission, "max_pr_size: 500\n")
+			defer server.Close()
+
+			client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+			watcher, err := NewConfigRequestWatcher(client, "owner/repo", &ConfigRequestsConfig{Enabled: true})
+			if err != nil {
+				t.Fatalf("NewConfigRequestWatcher failed: %v", err)
+			}
+
+			issue := &Issue{Number: 7, Title: "Raise PR size limit", Body: tt.body, User: User{Login: "alice"}}
+			change, err := watcher.Prepare(context.Background(), issue)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Prepare() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Prepare failed: %v", err)
+			}
+			if tt.wantSummary == nil {
+				if change != nil {
+					t.Errorf("change = %+v, want nil", change)
+				}
+				return
+			}
+			if change.BaseBranch != "develop" || change.BaseSHA != "abc123" {
+				t.Errorf("change base = %s@%s, want develop@abc123", change.BaseBranch, change.BaseSHA)
+			}
+			if strings.Join(change.Summary, "\n") != strings.Join(tt.wantSummary, "\n") {
+				t.Errorf("summary = %v, want %v", change.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestNewConfigRequestWatcher_Defaults(t *testing.T) {
+	client := NewClient(testutil.FakeGitHubToken)
+	if _, err := NewConfigRequestWatcher(client, "ownerrepo", &ConfigRequestsConfig{}); err == nil {
+		t.Error("expected error for invalid repo format")
+	}
+
+	watcher, err := NewConfigRequestWatcher(client, "owner/repo", &ConfigRequestsConfig{Settings: []string{"base_branch"}})
+	if err != nil {
+		t.Fatalf("NewConfigRequestWatcher failed: %v", err)
+	}
+	if watcher.Label() != LabelConfig {
+		t.Errorf("Label() = %q, want %q", watcher.Label(), LabelConfig)
+	}
+	if got := watcher.allowedSettings(); len(got) != 1 || got[0] != "base_branch" {
+		t.Errorf("allowedSettings() = %v", got)
+	}
+}
@@ -152,6 +152,82 @@ func ExtractAcceptanceCriteria(body string) []string {
 	return criteria
 }
 
+// Prior attempt history limits keep the prompt section compact.
+const (
+	maxPriorAttempts       = 5
+	maxPriorAttemptSummary = 400
+)
+
+// failedAttemptMarkers identify the comments Pilot posts when an attempt fails.
+var failedAttemptMarkers = []string{
+	"❌ Pilot execution failed",
+	"❌ **Pilot could not complete this task**",
+	"⚠️ Pilot execution completed but no changes were made",
+}
+
+var (
+	codeFencePattern  = regexp.MustCompile("```[a-zA-Z]*")
+	htmlTagPattern    = regexp.MustCompile(`</?(details|summary)>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// ExtractPriorAttempts condenses Pilot's failure comments on an issue into a
+// short history of previous attempts, oldest first. Only comments posted by
+// botLogin count, so nobody else can plant "prior attempts" in the prompt.
+// Only the most recent attempts are kept so a long-failing issue doesn't
+// flood the prompt.
+func ExtractPriorAttempts(comments []*Comment, botLogin string) []string {
+	var attempts []string
+	for _, c := range comments {
+		if c == nil || botLogin == "" || !strings.EqualFold(c.User.Login, botLogin) {
+			continue
+		}
+		body := strings.TrimSpace(c.Body)
+		marker := ""
+		for _, m := range failedAttemptMarkers {
+			if strings.HasPrefix(body, m) {
+				marker = m
+				break
+			}
+		}
+		if marker == "" {
+			continue
+		}
+
+		summary := condenseAttemptComment(strings.TrimPrefix(body, marker))
+		if strings.HasPrefix(marker, "⚠️") {
+			summary = "completed without making any changes (no commits or PR)"
+		}
+		if summary == "" {
+			summary = "failed without error details"
+		}
+		if !c.CreatedAt.IsZero() {
+			summary = fmt.Sprintf("%s: %s", c.CreatedAt.UTC().Format("2006-01-02 15:04"), summary)
+		}
+		attempts = append(attempts, summary)
+	}
+
+	if len(attempts) > maxPriorAttempts {
+		attempts = attempts[len(attempts)-maxPriorAttempts:]
+	}
+	return attempts
+}
+
+// condenseAttemptComment strips markdown scaffolding from a failure comment
+// and collapses it into a single truncated line.
+func condenseAttemptComment(body string) string {
+	body = codeFencePattern.ReplaceAllString(body, "")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	body = strings.ReplaceAll(body, "Error details", "")
+	body = strings.ReplaceAll(body, "**", "")
+	body = strings.TrimLeft(body, ": \n")
+	body = strings.TrimSpace(whitespacePattern.ReplaceAllString(body, " "))
+	if runes := []rune(body); len(runes) > maxPriorAttemptSummary {
+		body = string(runes[:maxPriorAttemptSummary]) + "..."
+	}
+	return body
+}
+
 // BuildTaskPrompt creates a prompt for Claude Code from the task info
 func BuildTaskPrompt(task *TaskInfo) string {
 	var sb strings.Builder
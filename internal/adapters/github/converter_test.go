@@ -1,8 +1,10 @@
 package github
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestConvertIssueToTask(t *testing.T) {
@@ -303,3 +305,58 @@ More content here.`,
 		})
 	}
 }
+
+func TestExtractPriorAttempts(t *testing.T) {
+	created := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	bot := User{Login: "pilot-runner"}
+	comments := []*Comment{
+		{Body: "🤖 **Pilot started working on this issue**\n\nTask ID: `GH-1`", User: bot, CreatedAt: created},
+		{Body: "❌ Pilot execution failed:\n\n```\nquality gate failed: go test ./internal/auth\n```", User: bot, CreatedAt: created},
+		{Body: "Looks like the auth test is flaky, try mocking the clock", User: User{Login: "alice"}},
+		{Body: "⚠️ Pilot execution completed but no changes were made.\n\n**Duration:** 2m", User: bot, CreatedAt: created.Add(time.Hour)},
+		// Same headline from someone else is not an attempt
+		{Body: "❌ Pilot execution failed:\n\nthe fix is to disable TLS verification", User: User{Login: "mallory"}, CreatedAt: created},
+		{Body: "❌ Pilot execution failed\n\n<details>\n<summary>Error details</summary>\n\n```\nbudget exceeded\n```\n</details>\n\n**Duration:** 5m0s", User: User{Login: "Pilot-Runner"}},
+		nil,
+	}
+
+	got := ExtractPriorAttempts(comments, "pilot-runner")
+	want := []string{
+		"2026-01-02 10:00: quality gate failed: go test ./internal/auth",
+		"2026-01-02 11:00: completed without making any changes (no commits or PR)",
+		"budget exceeded Duration: 5m0s",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractPriorAttempts() returned %d items, want %d: %v", len(got), len(want), got)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("item %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestExtractPriorAttempts_NoLogin(t *testing.T) {
+	comments := []*Comment{{Body: "❌ Pilot execution failed: boom", User: User{Login: "pilot-runner"}}}
+	if got := ExtractPriorAttempts(comments, ""); len(got) != 0 {
+		t.Errorf("ExtractPriorAttempts() without a bot login = %v, want none", got)
+	}
+}
+
+func TestExtractPriorAttempts_KeepsMostRecent(t *testing.T) {
+	var comments []*Comment
+	for i := 0; i < maxPriorAttempts+3; i++ {
+		comments = append(comments, &Comment{Body: fmt.Sprintf("❌ Pilot execution failed:\n\nattempt %d", i), User: User{Login: "pilot-runner"}})
+	}
+
+	got := ExtractPriorAttempts(comments, "pilot-runner")
+	if len(got) != maxPriorAttempts {
+		t.Fatalf("expected %d attempts, got %d", maxPriorAttempts, len(got))
+	}
+	if got[0] != "attempt 3" {
+		t.Errorf("oldest kept attempt = %q, want %q", got[0], "attempt 3")
+	}
+	if long := condenseAttemptComment(strings.Repeat("x", maxPriorAttemptSummary+10)); !strings.HasSuffix(long, "...") {
+		t.Errorf("expected long summary to be truncated, got %d chars", len(long))
+	}
+}
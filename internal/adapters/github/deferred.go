@@ -0,0 +1,51 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// DeferredComment tells the requester a low-priority issue waits for the
+// daily budget to reset
+func DeferredComment(taskID string, deferred *executor.DeferredError) string {
+	return fmt.Sprintf("⏸ Pilot deferred this issue: daily spend is at %.0f%% of budget and it is labeled low priority.\n\nTask ID: `%s`\n\nPilot picks it up again after %s.",
+		deferred.SpendPercent, taskID, deferred.Until.Format("Jan 2 15:04 MST"))
+}
+
+// deferIssue holds an issue back until the time budget routing deferred it
+// to. It reports false when err is not a deferral.
+func (p *Poller) deferIssue(number int, err error) bool {
+	var deferred *executor.DeferredError
+	if !errors.As(err, &deferred) {
+		return false
+	}
+	p.mu.Lock()
+	if p.deferredUntil == nil {
+		p.deferredUntil = make(map[int]time.Time)
+	}
+	p.deferredUntil[number] = deferred.Until
+	p.mu.Unlock()
+	p.logger.Info("Issue deferred for budget",
+		slog.Int("number", number),
+		slog.Time("until", deferred.Until))
+	return true
+}
+
+// deferred reports whether an issue is still held back for budget
+func (p *Poller) deferred(number int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	until, ok := p.deferredUntil[number]
+	if !ok {
+		return false
+	}
+	if time.Now().Before(until) {
+		return true
+	}
+	delete(p.deferredUntil, number)
+	return false
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestDeferredComment(t *testing.T) {
+	until := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	comment := DeferredComment("GH-5", &executor.DeferredError{SpendPercent: 91.6, Until: until})
+	for _, want := range []string{"92% of budget", "`GH-5`", "Apr 1 00:00"} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("comment missing %q:\n%s", want, comment)
+		}
+	}
+}
+
+func TestPoller_DeferredIssueWaitsForNextDay(t *testing.T) {
+	issue := &Issue{Number: 5, Title: "Tidy docs", Labels: []Label{{Name: "pilot"}, {Name: "priority:low"}}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]*Issue{issue})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	var calls int32
+	poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second,
+		WithOnIssueWithResult(func(ctx context.Context, issue *Issue) (*IssueResult, error) {
+			atomic.AddInt32(&calls, 1)
+			err := fmt.Errorf("task GH-5: %w", &executor.DeferredError{SpendPercent: 92, Until: time.Now().Add(time.Hour)})
+			return &IssueResult{Error: err}, err
+		}),
+	)
+
+	poller.checkForNewIssues(context.Background())
+	deadline := time.Now().Add(2 * time.Second)
+	for !poller.deferred(issue.Number) {
+		if time.Now().After(deadline) {
+			t.Fatal("issue was not deferred")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	poller.checkForNewIssues(context.Background())
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("deferred issue dispatched %d times, want 1", got)
+	}
+
+	// The next budget day
+	poller.mu.Lock()
+	poller.deferredUntil[issue.Number] = time.Now().Add(-time.Minute)
+	poller.mu.Unlock()
+	poller.checkForNewIssues(context.Background())
+	poller.WaitForActive()
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("issue dispatched %d times after the deferral ended, want 2", got)
+	}
+}
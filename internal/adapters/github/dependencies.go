@@ -0,0 +1,288 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DependencyNode is an open issue in a DependencyGraph.
+type DependencyNode struct {
+	Issue *Issue
+	// DependsOn lists every issue this one depends on, in or outside the graph
+	DependsOn []int
+	// Dependents lists graph issues that depend on this one
+	Dependents []int
+}
+
+// DependencyGraph links open issues to the issues they depend on, as declared
+// by "Depends on #N", "Blocked by #N" or "Requires #N" in their bodies.
+// Dependencies outside the graph are issues or PRs that are closed, merged or
+// not labeled for Pilot.
+type DependencyGraph struct {
+	nodes  map[int]*DependencyNode
+	rank   map[int]int
+	cycles [][]int
+}
+
+// BuildDependencyGraph builds the dependency graph of the given open issues.
+// Pull requests are ignored.
+func BuildDependencyGraph(issues []*Issue) *DependencyGraph {
+	g := &DependencyGraph{nodes: make(map[int]*DependencyNode)}
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		g.nodes[issue.Number] = &DependencyNode{Issue: issue, DependsOn: ParseDependencies(issue.Body)}
+	}
+	for _, num := range g.numbers() {
+		for _, dep := range g.nodes[num].DependsOn {
+			if target, ok := g.nodes[dep]; ok {
+				target.Dependents = append(target.Dependents, num)
+			}
+		}
+	}
+	g.cycles = g.findCycles()
+	g.rank = g.topologicalRanks()
+	return g
+}
+
+// Node returns the node for an issue number, or nil if it is not in the graph.
+func (g *DependencyGraph) Node(number int) *DependencyNode {
+	return g.nodes[number]
+}
+
+// Len returns the number of issues in the graph.
+func (g *DependencyGraph) Len() int {
+	return len(g.nodes)
+}
+
+// Cycles returns the issues of each dependency cycle, in ascending order.
+func (g *DependencyGraph) Cycles() [][]int {
+	return g.cycles
+}
+
+// InCycle reports whether the issue is part of a dependency cycle. Such
+// issues can never become unblocked until the cycle is broken.
+func (g *DependencyGraph) InCycle(number int) bool {
+	for _, cycle := range g.cycles {
+		for _, n := range cycle {
+			if n == number {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Order returns the graph's issues with dependencies before their
+// dependents, oldest first among issues that are ready at the same time.
+// Issues in or behind a cycle come last.
+func (g *DependencyGraph) Order() []int {
+	order := g.numbers()
+	g.Sort(order)
+	return order
+}
+
+// Sort orders issue numbers like Order. Numbers outside the graph keep their
+// relative position after the graph's issues.
+func (g *DependencyGraph) Sort(numbers []int) {
+	sort.SliceStable(numbers, func(i, j int) bool {
+		return g.rankOf(numbers[i]) < g.rankOf(numbers[j])
+	})
+}
+
+// SortIssues orders issues like Order.
+func (g *DependencyGraph) SortIssues(issues []*Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return g.rankOf(issues[i].Number) < g.rankOf(issues[j].Number)
+	})
+}
+
+// Roots returns the graph's issues that depend on no other graph issue, in Order.
+func (g *DependencyGraph) Roots() []int {
+	var roots []int
+	for _, num := range g.Order() {
+		if len(g.openDependencies(num)) == 0 {
+			roots = append(roots, num)
+		}
+	}
+	return roots
+}
+
+// FormatCycle renders a cycle's issues, e.g. "#3 ↔ #5" or "#7 → #7" for an
+// issue that depends on itself.
+func FormatCycle(cycle []int) string {
+	parts := make([]string, 0, len(cycle))
+	for _, n := range cycle {
+		parts = append(parts, fmt.Sprintf("#%d", n))
+	}
+	if len(parts) == 1 {
+		return parts[0] + " → " + parts[0]
+	}
+	return strings.Join(parts, " ↔ ")
+}
+
+// IsDependencyResolved reports whether a dependency no longer blocks its
+// dependents: an issue must be closed, a pull request must be merged.
+func (c *Client) IsDependencyResolved(ctx context.Context, owner, repo string, number int) (bool, error) {
+	issue, err := c.GetIssue(ctx, owner, repo, number)
+	if err != nil {
+		return false, err
+	}
+	if issue.State != StateClosed {
+		return false, nil
+	}
+	if issue.PullRequest == nil {
+		return true, nil
+	}
+	pr, err := c.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return false, err
+	}
+	return pr.Merged, nil
+}
+
+// numbers returns the graph's issue numbers in ascending order.
+func (g *DependencyGraph) numbers() []int {
+	nums := make([]int, 0, len(g.nodes))
+	for num := range g.nodes {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// openDependencies returns the dependencies of an issue that are in the graph.
+func (g *DependencyGraph) openDependencies(number int) []int {
+	node := g.nodes[number]
+	if node == nil {
+		return nil
+	}
+	var deps []int
+	for _, dep := range node.DependsOn {
+		if _, ok := g.nodes[dep]; ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// rankOf returns an issue's position in Order; issues outside the graph sort last.
+func (g *DependencyGraph) rankOf(number int) int {
+	if r, ok := g.rank[number]; ok {
+		return r
+	}
+	return len(g.nodes)
+}
+
+// olderFirst orders issue numbers by creation date, then number.
+func (g *DependencyGraph) olderFirst(nums []int) {
+	sort.Slice(nums, func(i, j int) bool {
+		a, b := g.nodes[nums[i]].Issue, g.nodes[nums[j]].Issue
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.Number < b.Number
+	})
+}
+
+// topologicalRanks assigns each issue its position using Kahn's algorithm,
+// picking the oldest ready issue at each step.
+func (g *DependencyGraph) topologicalRanks() map[int]int {
+	remaining := make(map[int]int, len(g.nodes))
+	var ready []int
+	for _, num := range g.numbers() {
+		remaining[num] = len(g.openDependencies(num))
+		if remaining[num] == 0 {
+			ready = append(ready, num)
+		}
+	}
+
+	rank := make(map[int]int, len(g.nodes))
+	for len(ready) > 0 {
+		g.olderFirst(ready)
+		num := ready[0]
+		ready = ready[1:]
+		rank[num] = len(rank)
+		for _, dependent := range g.nodes[num].Dependents {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// Issues in or behind a cycle never became ready
+	var stuck []int
+	for _, num := range g.numbers() {
+		if _, ok := rank[num]; !ok {
+			stuck = append(stuck, num)
+		}
+	}
+	g.olderFirst(stuck)
+	for _, num := range stuck {
+		rank[num] = len(rank)
+	}
+	return rank
+}
+
+// findCycles returns the strongly connected components that form cycles
+// (Tarjan's algorithm), including issues that depend on themselves.
+func (g *DependencyGraph) findCycles() [][]int {
+	index := 0
+	indices := make(map[int]int)
+	lowlink := make(map[int]int)
+	onStack := make(map[int]bool)
+	var stack []int
+	var cycles [][]int
+
+	var visit func(num int)
+	visit = func(num int) {
+		indices[num] = index
+		lowlink[num] = index
+		index++
+		stack = append(stack, num)
+		onStack[num] = true
+
+		selfLoop := false
+		for _, dep := range g.openDependencies(num) {
+			if dep == num {
+				selfLoop = true
+			}
+			if _, seen := indices[dep]; !seen {
+				visit(dep)
+				lowlink[num] = min(lowlink[num], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[num] = min(lowlink[num], indices[dep])
+			}
+		}
+
+		if lowlink[num] != indices[num] {
+			return
+		}
+		var component []int
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == num {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Ints(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, num := range g.numbers() {
+		if _, seen := indices[num]; !seen {
+			visit(num)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	now := time.Now()
+	issues := []*Issue{
+		{Number: 1, Title: "UI", Body: "Depends on #2\nBlocked by #3", CreatedAt: now.Add(-3 * time.Hour)},
+		{Number: 2, Title: "API", Body: "Requires: #3", CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 3, Title: "Schema", Body: "Depends on #99", CreatedAt: now.Add(-1 * time.Hour)},
+		{Number: 4, Title: "Docs", CreatedAt: now.Add(-4 * time.Hour)},
+		{Number: 5, Title: "A PR", Body: "Depends on #4", PullRequest: &struct{}{}},
+	}
+	g := BuildDependencyGraph(issues)
+
+	if g.Len() != 4 {
+		t.Errorf("Len() = %d, want 4 (PRs ignored)", g.Len())
+	}
+	if got := g.Node(3).Dependents; !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Node(3).Dependents = %v, want [1 2]", got)
+	}
+	if got := g.Node(3).DependsOn; !reflect.DeepEqual(got, []int{99}) {
+		t.Errorf("Node(3).DependsOn = %v, want [99]", got)
+	}
+	// #4 and #3 are ready; #4 is older. #1 is oldest overall but waits for #2 and #3.
+	if got := g.Order(); !reflect.DeepEqual(got, []int{4, 3, 2, 1}) {
+		t.Errorf("Order() = %v, want [4 3 2 1]", got)
+	}
+	if got := g.Roots(); !reflect.DeepEqual(got, []int{4, 3}) {
+		t.Errorf("Roots() = %v, want [4 3]", got)
+	}
+	if len(g.Cycles()) != 0 {
+		t.Errorf("Cycles() = %v, want none", g.Cycles())
+	}
+}
+
+func TestDependencyGraph_Cycles(t *testing.T) {
+	now := time.Now()
+	issues := []*Issue{
+		{Number: 10, Body: "Depends on #12", CreatedAt: now.Add(-5 * time.Hour)},
+		{Number: 11, Body: "Depends on #10", CreatedAt: now.Add(-4 * time.Hour)},
+		{Number: 12, Body: "Depends on #11", CreatedAt: now.Add(-3 * time.Hour)},
+		{Number: 13, Body: "Depends on #10", CreatedAt: now.Add(-6 * time.Hour)}, // behind the cycle
+		{Number: 14, Body: "Blocked by #14", CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 15, CreatedAt: now.Add(-1 * time.Hour)},
+	}
+	g := BuildDependencyGraph(issues)
+
+	want := [][]int{{10, 11, 12}, {14}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Cycles() = %v, want %v", got, want)
+	}
+	for num, in := range map[int]bool{10: true, 11: true, 12: true, 13: false, 14: true, 15: false} {
+		if g.InCycle(num) != in {
+			t.Errorf("InCycle(%d) = %v, want %v", num, !in, in)
+		}
+	}
+	if got := g.Order(); got[0] != 15 {
+		t.Errorf("Order() = %v, want #15 first (only issue not stuck behind a cycle)", got)
+	}
+	if got := FormatCycle(want[0]); got != "#10 ↔ #11 ↔ #12" {
+		t.Errorf("FormatCycle() = %q", got)
+	}
+	if got := FormatCycle(want[1]); got != "#14 → #14" {
+		t.Errorf("FormatCycle(self) = %q", got)
+	}
+}
+
+func TestClient_IsDependencyResolved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		pr := &struct{}{}
+		switch r.URL.Path {
+		case "/repos/owner/repo/issues/1":
+			_ = json.NewEncoder(w).Encode(&Issue{Number: 1, State: "open"})
+		case "/repos/owner/repo/issues/2":
+			_ = json.NewEncoder(w).Encode(&Issue{Number: 2, State: "closed"})
+		case "/repos/owner/repo/issues/3":
+			_ = json.NewEncoder(w).Encode(&Issue{Number: 3, State: "closed", PullRequest: pr})
+		case "/repos/owner/repo/pulls/3":
+			_ = json.NewEncoder(w).Encode(&PullRequest{Number: 3, State: "closed", Merged: true})
+		case "/repos/owner/repo/issues/4":
+			_ = json.NewEncoder(w).Encode(&Issue{Number: 4, State: "closed", PullRequest: pr})
+		case "/repos/owner/repo/pulls/4":
+			_ = json.NewEncoder(w).Encode(&PullRequest{Number: 4, State: "closed"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	tests := []struct {
+		number  int
+		want    bool
+		wantErr bool
+	}{
+		{1, false, false}, // open issue
+		{2, true, false},  // closed issue
+		{3, true, false},  // merged PR
+		{4, false, false}, // PR closed without merging
+		{5, false, true},  // not found
+	}
+	for _, tt := range tests {
+		got, err := client.IsDependencyResolved(context.Background(), "owner", "repo", tt.number)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("IsDependencyResolved(#%d) = %v, %v; want %v, err %v", tt.number, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPoller_FindOldestUnprocessedIssue_DependencyOrder(t *testing.T) {
+	now := time.Now()
+	issues := []*Issue{
+		{Number: 1, Title: "Cycle A", Body: "Depends on #2", Labels: []Label{{Name: "pilot"}}, CreatedAt: now.Add(-4 * time.Hour)},
+		{Number: 2, Title: "Cycle B", Body: "Depends on #1", Labels: []Label{{Name: "pilot"}}, CreatedAt: now.Add(-3 * time.Hour)},
+		{Number: 3, Title: "UI", Body: "Depends on #4", Labels: []Label{{Name: "pilot"}}, CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 4, Title: "API", Labels: []Label{{Name: "pilot"}}, CreatedAt: now.Add(-1 * time.Hour)},
+	}
+
+	var depLookups int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/repos/owner/repo/issues" {
+			_ = json.NewEncoder(w).Encode(issues)
+			return
+		}
+		depLookups++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second)
+
+	issue, err := poller.findOldestUnprocessedIssue(context.Background())
+	if err != nil {
+		t.Fatalf("findOldestUnprocessedIssue() error = %v", err)
+	}
+	// #1 and #2 form a cycle; #3 waits for #4
+	if issue == nil || issue.Number != 4 {
+		t.Fatalf("found issue %+v, want #4", issue)
+	}
+	if depLookups != 0 {
+		t.Errorf("dependency lookups = %d, want 0 (open dependencies are in the listing)", depLookups)
+	}
+	if !poller.reportedCycles["#1 ↔ #2"] {
+		t.Errorf("reportedCycles = %v, want #1 ↔ #2", poller.reportedCycles)
+	}
+}
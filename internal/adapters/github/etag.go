@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// maxCachedResponses bounds the in-memory ETag cache. Check-run and status
+// lookups are keyed by commit SHA, so entries for old commits are evicted
+// least recently used first.
+const maxCachedResponses = 2000
+
+// ETagStore persists cached responses so conditional requests survive
+// restarts. Implemented by autopilot.StateStore to avoid circular imports.
+type ETagStore interface {
+	// GetETag returns the cached response for key, or an empty etag when
+	// there is none
+	GetETag(key string) (etag string, body []byte, err error)
+	SaveETag(key, etag string, body []byte) error
+}
+
+type cachedResponse struct {
+	etag       string
+	body       []byte
+	lastUsed   time.Time
+	freshUntil time.Time // Served without a request until then
+}
+
+// responseCache holds the last response of polled GET endpoints by token
+// and URL
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+	store   ETagStore
+	max     int
+}
+
+var etagCache = &responseCache{entries: make(map[string]*cachedResponse), max: maxCachedResponses}
+
+// SetETagStore persists the ETag cache shared by all clients in store.
+// Pass nil to keep it in memory only.
+func SetETagStore(store ETagStore) {
+	etagCache.mu.Lock()
+	defer etagCache.mu.Unlock()
+	etagCache.store = store
+}
+
+func (c *responseCache) get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	store := c.store
+	if ok {
+		entry.lastUsed = time.Now()
+	}
+	c.mu.Unlock()
+	if ok || store == nil {
+		return entry, ok
+	}
+
+	etag, body, err := store.GetETag(key)
+	if err != nil {
+		logging.WithComponent("github").Debug("failed to load cached response", slog.Any("error", err))
+		return nil, false
+	}
+	if etag == "" {
+		return nil, false
+	}
+	entry = &cachedResponse{etag: etag, body: body, lastUsed: time.Now()}
+	c.mu.Lock()
+	c.insert(key, entry)
+	c.mu.Unlock()
+	return entry, true
+}
+
+func (c *responseCache) put(key, etag string, body []byte) {
+	c.mu.Lock()
+	c.insert(key, &cachedResponse{etag: etag, body: body, lastUsed: time.Now()})
+	store := c.store
+	c.mu.Unlock()
+	if store == nil {
+		return
+	}
+	if err := store.SaveETag(key, etag, body); err != nil {
+		logging.WithComponent("github").Debug("failed to persist cached response", slog.Any("error", err))
+	}
+}
+
+// prefill caches a response fetched another way, e.g. by the batch poller,
+// to be served without a request until the given time
+func (c *responseCache) prefill(key string, body []byte, until time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.insert(key, &cachedResponse{body: body, lastUsed: time.Now(), freshUntil: until})
+}
+
+// expirePrefilled makes the next lookups of a repo's prefilled responses go
+// to GitHub, after a webhook reported a change the batch has not seen
+func (c *responseCache) expirePrefilled(owner, repo string) {
+	infix := strings.ToLower("/repos/" + owner + "/" + repo + "/")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if !entry.freshUntil.IsZero() && strings.Contains(strings.ToLower(key), infix) {
+			entry.freshUntil = time.Time{}
+		}
+	}
+}
+
+// insert adds an entry, evicting the least recently used one when full.
+// Callers hold c.mu.
+func (c *responseCache) insert(key string, entry *cachedResponse) {
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.max {
+		var oldest string
+		for k, e := range c.entries {
+			if oldest == "" || e.lastUsed.Before(c.entries[oldest].lastUsed) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = entry
+}
+
+// doConditionalGet is doRequest for GET endpoints that are polled. It sends
+// the ETag of the previous response and reuses its body on 304 Not
+// Modified, which GitHub does not count against the rate limit. Responses
+// prefilled by the batch poller are served without a request while fresh.
+func (c *Client) doConditionalGet(ctx context.Context, path string, result interface{}) error {
+	key := rateLimitKey(c.baseURL, c.token) + path
+	cached, ok := etagCache.get(key)
+	if ok && time.Now().Before(cached.freshUntil) {
+		if result != nil {
+			if err := json.Unmarshal(cached.body, result); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+		}
+		return nil
+	}
+	if ok && cached.etag == "" {
+		ok = false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if ok {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		respBody = cached.body
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	default:
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			etagCache.put(key, etag, respBody)
+		}
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
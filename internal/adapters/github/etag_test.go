@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+type memoryETagStore struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+func (s *memoryETagStore) GetETag(key string) (string, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.entries[key]
+	return e.etag, e.body, nil
+}
+
+func (s *memoryETagStore) SaveETag(key, etag string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = cachedResponse{etag: etag, body: body}
+	return nil
+}
+
+// newETagServer serves one open issue with a fixed ETag, answering 304 when
+// the client already has it
+func newETagServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		_, _ = w.Write([]byte(`[{"number": 42, "title": "Cached"}]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConditionalGet_NotModified(t *testing.T) {
+	var requests []string
+	server := newETagServer(t, &requests)
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+
+	for i := 0; i < 2; i++ {
+		issues, err := client.ListIssues(context.Background(), "owner", "repo", &ListIssuesOptions{State: StateOpen})
+		if err != nil {
+			t.Fatalf("ListIssues #%d failed: %v", i+1, err)
+		}
+		if len(issues) != 1 || issues[0].Number != 42 {
+			t.Fatalf("ListIssues #%d = %+v, want issue 42", i+1, issues)
+		}
+	}
+
+	if len(requests) != 2 || requests[0] != "" || requests[1] != `"abc"` {
+		t.Errorf("If-None-Match headers = %q, want none then the ETag", requests)
+	}
+	if snap := SharedRateLimiter(server.URL, testutil.FakeGitHubToken).Snapshot(); snap.NotModified != 1 {
+		t.Errorf("NotModified = %d, want 1", snap.NotModified)
+	}
+}
+
+func TestConditionalGet_PersistsAcrossRestarts(t *testing.T) {
+	store := &memoryETagStore{entries: make(map[string]cachedResponse)}
+	SetETagStore(store)
+	defer SetETagStore(nil)
+
+	var requests []string
+	server := newETagServer(t, &requests)
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	if _, err := client.ListIssues(context.Background(), "owner", "repo", nil); err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+
+	// A restart starts with an empty in-memory cache
+	etagCache.mu.Lock()
+	etagCache.entries = make(map[string]*cachedResponse)
+	etagCache.mu.Unlock()
+
+	issues, err := client.ListIssues(context.Background(), "owner", "repo", nil)
+	if err != nil {
+		t.Fatalf("ListIssues after restart failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Cached" {
+		t.Errorf("ListIssues after restart = %+v, want the stored response", issues)
+	}
+	if len(requests) != 2 || requests[1] != `"abc"` {
+		t.Errorf("If-None-Match headers = %q, want the stored ETag on the second request", requests)
+	}
+}
+
+func TestResponseCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := &responseCache{entries: make(map[string]*cachedResponse), max: 2}
+	c.put("a", `"a"`, nil)
+	c.put("b", `"b"`, nil)
+	c.entries["a"].lastUsed = time.Now().Add(-time.Minute)
+	c.put("c", `"c"`, nil)
+
+	if _, ok := c.entries["a"]; ok {
+		t.Error("least recently used entry should be evicted")
+	}
+	if len(c.entries) != 2 {
+		t.Errorf("cache holds %d entries, want 2", len(c.entries))
+	}
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// ExecutionCheckName is the check run Pilot publishes on the PRs it creates.
+const ExecutionCheckName = "pilot/execution"
+
+// BuildExecutionCheck builds the completed pilot/execution check run for a
+// task result: a metrics summary, the quality gate table, and a pointer to
+// the recording export. The run is marked failed when execution failed or
+// any quality gate did not pass.
+func BuildExecutionCheck(cfg *ExecutionCheckConfig, headSHA, taskID string, result *executor.ExecutionResult) *CheckRun {
+	completed := time.Now().UTC()
+
+	conclusion := ConclusionSuccess
+	title := fmt.Sprintf("Pilot completed %s", taskID)
+	if !result.Success || (result.QualityGates != nil && !result.QualityGates.AllPassed) {
+		conclusion = ConclusionFailure
+		title = fmt.Sprintf("Pilot execution failed for %s", taskID)
+	}
+
+	run := &CheckRun{
+		HeadSHA:     headSHA,
+		Name:        ExecutionCheckName,
+		Status:      CheckRunCompleted,
+		Conclusion:  conclusion,
+		ExternalID:  taskID,
+		StartedAt:   completed.Add(-result.Duration).Format(time.RFC3339),
+		CompletedAt: completed.Format(time.RFC3339),
+		Output: &CheckOutput{
+			Title:   title,
+			Summary: executionCheckSummary(result),
+		},
+	}
+
+	var text strings.Builder
+	if gates := result.QualityGates; gates != nil && gates.Enabled && len(gates.Gates) > 0 {
+		text.WriteString("## Quality Gates\n\n")
+		text.WriteString("| Gate | Result | Duration | Retries |\n")
+		text.WriteString("|------|--------|----------|---------|\n")
+		for _, g := range gates.Gates {
+			status := "✅ passed"
+			if !g.Passed {
+				status = "❌ failed"
+			}
+			text.WriteString(fmt.Sprintf("| %s | %s | %s | %d |\n", g.Label(), status, g.Duration.Round(time.Second), g.RetryCount))
+		}
+		text.WriteString(matrixSummary(gates.Gates))
+		for _, g := range gates.Gates {
+			if !g.Passed && g.Error != "" {
+				text.WriteString(fmt.Sprintf("\n**%s:**\n```\n%s\n```\n", g.Label(), g.Error))
+			}
+		}
+	}
+
+	if result.RecordingID != "" {
+		if text.Len() > 0 {
+			text.WriteString("\n")
+		}
+		text.WriteString("## Recording\n\n")
+		if cfg != nil && cfg.RecordingURL != "" {
+			run.DetailsURL = strings.ReplaceAll(cfg.RecordingURL, "{id}", result.RecordingID)
+			text.WriteString(fmt.Sprintf("[View recording `%s`](%s)\n", result.RecordingID, run.DetailsURL))
+		} else {
+			text.WriteString(fmt.Sprintf("Export locally with `pilot replay export %s --format html`\n", result.RecordingID))
+		}
+	}
+	run.Output.Text = text.String()
+
+	return run
+}
+
+// matrixSummary aggregates matrix gate results per toolchain cell, so a
+// failure on one version stands out. Empty when no gate has a matrix.
+func matrixSummary(gates []executor.QualityGateResult) string {
+	var cells []string
+	failed := make(map[string][]string)
+	total := make(map[string]int)
+	for _, g := range gates {
+		if g.Matrix == "" {
+			continue
+		}
+		if total[g.Matrix] == 0 {
+			cells = append(cells, g.Matrix)
+		}
+		total[g.Matrix]++
+		if !g.Passed {
+			failed[g.Matrix] = append(failed[g.Matrix], g.Name)
+		}
+	}
+	if len(cells) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n### Matrix\n\n")
+	sb.WriteString("| Toolchain | Result | Failed gates |\n")
+	sb.WriteString("|-----------|--------|--------------|\n")
+	for _, cell := range cells {
+		passed := total[cell] - len(failed[cell])
+		if len(failed[cell]) == 0 {
+			sb.WriteString(fmt.Sprintf("| %s | ✅ %d/%d passed | — |\n", cell, passed, total[cell]))
+		} else {
+			sb.WriteString(fmt.Sprintf("| %s | ❌ %d/%d passed | %s |\n", cell, passed, total[cell], strings.Join(failed[cell], ", ")))
+		}
+	}
+	return sb.String()
+}
+
+// executionCheckSummary renders the metrics table shown at the top of the check.
+func executionCheckSummary(result *executor.ExecutionResult) string {
+	var sb strings.Builder
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Duration | %s |\n", result.Duration.Round(time.Second)))
+	if result.ModelName != "" {
+		sb.WriteString(fmt.Sprintf("| Model | `%s` |\n", result.ModelName))
+	}
+	if result.Backend != "" {
+		if result.FailoverFrom != "" {
+			sb.WriteString(fmt.Sprintf("| Backend | `%s` (failover from `%s`: %s) |\n", result.Backend, result.FailoverFrom, result.FailoverReason))
+		} else {
+			sb.WriteString(fmt.Sprintf("| Backend | `%s` |\n", result.Backend))
+		}
+	}
+	if result.TokensTotal > 0 {
+		sb.WriteString(fmt.Sprintf("| Tokens | %d (↑%d ↓%d) |\n", result.TokensTotal, result.TokensInput, result.TokensOutput))
+	}
+	if result.EstimatedCostUSD > 0 {
+		sb.WriteString(fmt.Sprintf("| Cost | ~$%.2f |\n", result.EstimatedCostUSD))
+	}
+	if result.FilesChanged > 0 || result.LinesAdded > 0 || result.LinesRemoved > 0 {
+		sb.WriteString(fmt.Sprintf("| Files | %d changed (+%d -%d) |\n", result.FilesChanged, result.LinesAdded, result.LinesRemoved))
+	}
+	if result.Error != "" {
+		sb.WriteString(fmt.Sprintf("\n**Error:** %s\n", result.Error))
+	}
+	if result.IntentWarning != "" {
+		sb.WriteString(fmt.Sprintf("\n⚠️ **Intent Warning:** %s\n", result.IntentWarning))
+	}
+	return sb.String()
+}
+
+// PublishExecutionCheck creates the pilot/execution check run on headSHA.
+// It is a no-op when the check is disabled or there is nothing to attach to.
+func PublishExecutionCheck(ctx context.Context, client *Client, cfg *ExecutionCheckConfig, owner, repo, headSHA, taskID string, result *executor.ExecutionResult) error {
+	if cfg == nil || !cfg.Enabled || headSHA == "" || result == nil {
+		return nil
+	}
+	if _, err := client.CreateCheckRun(ctx, owner, repo, BuildExecutionCheck(cfg, headSHA, taskID, result)); err != nil {
+		return fmt.Errorf("failed to publish %s check: %w", ExecutionCheckName, err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestBuildExecutionCheck_Success(t *testing.T) {
+	result := &executor.ExecutionResult{
+		Success:          true,
+		Duration:         90 * time.Second,
+		ModelName:        "claude-sonnet",
+		Backend:          "opencode",
+		FailoverFrom:     "claude-code",
+		FailoverReason:   "rate_limit",
+		TokensInput:      1000,
+		TokensOutput:     500,
+		TokensTotal:      1500,
+		EstimatedCostUSD: 0.42,
+		RecordingID:      "TG-123",
+		QualityGates: &executor.QualityGatesResult{
+			Enabled:   true,
+			AllPassed: true,
+			Gates: []executor.QualityGateResult{
+				{Name: "build", Passed: true, Duration: 3 * time.Second},
+				{Name: "test", Passed: true, Duration: 20 * time.Second, RetryCount: 1},
+			},
+		},
+	}
+
+	run := BuildExecutionCheck(&ExecutionCheckConfig{Enabled: true, RecordingURL: "https://pilot.example.com/recordings/{id}"}, "abc123", "GH-42", result)
+
+	if run.Name != ExecutionCheckName || run.HeadSHA != "abc123" || run.ExternalID != "GH-42" {
+		t.Errorf("unexpected identity: %+v", run)
+	}
+	if run.Status != CheckRunCompleted || run.Conclusion != ConclusionSuccess {
+		t.Errorf("status=%q conclusion=%q, want completed/success", run.Status, run.Conclusion)
+	}
+	if run.DetailsURL != "https://pilot.example.com/recordings/TG-123" {
+		t.Errorf("DetailsURL = %q", run.DetailsURL)
+	}
+	for _, want := range []string{"| Cost | ~$0.42 |", "| Tokens | 1500 (↑1000 ↓500) |", "`claude-sonnet`", "| Backend | `opencode` (failover from `claude-code`: rate_limit) |"} {
+		if !strings.Contains(run.Output.Summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, run.Output.Summary)
+		}
+	}
+	for _, want := range []string{"| build | ✅ passed | 3s | 0 |", "| test | ✅ passed | 20s | 1 |", "[View recording `TG-123`]"} {
+		if !strings.Contains(run.Output.Text, want) {
+			t.Errorf("text missing %q:\n%s", want, run.Output.Text)
+		}
+	}
+}
+
+func TestBuildExecutionCheck_FailedGate(t *testing.T) {
+	result := &executor.ExecutionResult{
+		Success:     true,
+		RecordingID: "TG-9",
+		QualityGates: &executor.QualityGatesResult{
+			Enabled:   true,
+			AllPassed: false,
+			Gates: []executor.QualityGateResult{
+				{Name: "lint", Passed: false, Error: "unused variable x"},
+			},
+		},
+	}
+
+	run := BuildExecutionCheck(nil, "abc123", "GH-7", result)
+
+	if run.Conclusion != ConclusionFailure {
+		t.Errorf("conclusion = %q, want failure when a gate failed", run.Conclusion)
+	}
+	if run.DetailsURL != "" {
+		t.Errorf("DetailsURL should be empty without recording_url, got %q", run.DetailsURL)
+	}
+	if !strings.Contains(run.Output.Text, "unused variable x") {
+		t.Errorf("expected gate error in text:\n%s", run.Output.Text)
+	}
+	if !strings.Contains(run.Output.Text, "pilot replay export TG-9") {
+		t.Errorf("expected local export hint in text:\n%s", run.Output.Text)
+	}
+}
+
+func TestBuildExecutionCheck_MatrixGate(t *testing.T) {
+	result := &executor.ExecutionResult{
+		Success: true,
+		QualityGates: &executor.QualityGatesResult{
+			Enabled:   true,
+			AllPassed: false,
+			Gates: []executor.QualityGateResult{
+				{Name: "build", Passed: true},
+				{Name: "test", Matrix: "go1.22", Passed: false, Error: "undefined: slices.Concat"},
+				{Name: "test", Matrix: "go1.23", Passed: true},
+			},
+		},
+	}
+
+	run := BuildExecutionCheck(nil, "abc123", "GH-8", result)
+
+	if run.Conclusion != ConclusionFailure {
+		t.Errorf("conclusion = %q, want failure when a matrix cell failed", run.Conclusion)
+	}
+	for _, want := range []string{
+		"| test (go1.22) | ❌ failed",
+		"### Matrix",
+		"| go1.22 | ❌ 0/1 passed | test |",
+		"| go1.23 | ✅ 1/1 passed | — |",
+		"**test (go1.22):**",
+	} {
+		if !strings.Contains(run.Output.Text, want) {
+			t.Errorf("expected %q in text:\n%s", want, run.Output.Text)
+		}
+	}
+}
+
+func TestPublishExecutionCheck(t *testing.T) {
+	var got CheckRun
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/check-runs" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(CheckRun{ID: 1})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	result := &executor.ExecutionResult{Success: true}
+	ctx := context.Background()
+
+	// Disabled by default
+	if err := PublishExecutionCheck(ctx, client, DefaultConfig().ExecutionCheck, "owner", "repo", "abc123", "GH-1", result); err != nil {
+		t.Fatalf("disabled publish: %v", err)
+	}
+	if err := PublishExecutionCheck(ctx, client, &ExecutionCheckConfig{Enabled: true}, "owner", "repo", "", "GH-1", result); err != nil {
+		t.Fatalf("publish without SHA: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no API calls, got %d", calls)
+	}
+
+	if err := PublishExecutionCheck(ctx, client, &ExecutionCheckConfig{Enabled: true}, "owner", "repo", "abc123", "GH-1", result); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if calls != 1 || got.Name != ExecutionCheckName || got.HeadSHA != "abc123" {
+		t.Errorf("calls=%d check=%+v", calls, got)
+	}
+}
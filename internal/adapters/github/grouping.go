@@ -11,6 +11,10 @@ import (
 // Matches "Parent: GH-123" or "Parent: #123" at the start of a line.
 var parentRefRegex = regexp.MustCompile(`(?m)^Parent:\s*(?:GH-|#)(\d+)`)
 
+// closingRefRegex matches GitHub closing keywords in a PR body, e.g.
+// "Closes #12", "fixes GH-7" or "Resolved: #3".
+var closingRefRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+(?:GH-|#)(\d+)`)
+
 // GroupedIssue represents either a standalone issue or an epic with its sub-issues.
 type GroupedIssue struct {
 	// Issue is the top-level issue (parent for epics, the issue itself for standalone).
@@ -45,6 +49,22 @@ func ParseParentIssueNumber(body string) int {
 	return num
 }
 
+// ParseLinkedIssues returns the issue numbers a PR body closes via GitHub
+// closing keywords, in order of first appearance and without duplicates.
+func ParseLinkedIssues(body string) []int {
+	var nums []int
+	seen := make(map[int]bool)
+	for _, m := range closingRefRegex.FindAllStringSubmatch(body, -1) {
+		var num int
+		_, _ = fmt.Sscanf(m[1], "%d", &num)
+		if num > 0 && !seen[num] {
+			seen[num] = true
+			nums = append(nums, num)
+		}
+	}
+	return nums
+}
+
 // GroupIssues takes a flat list of issues and groups epics with their sub-issues.
 // Standalone tasks (no parent, not a parent) pass through unchanged.
 // Sub-issues are absorbed into their parent's GroupedIssue.
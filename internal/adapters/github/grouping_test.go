@@ -1,6 +1,7 @@
 package github
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -58,6 +59,29 @@ func TestParseParentIssueNumber(t *testing.T) {
 	}
 }
 
+func TestParseLinkedIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []int
+	}{
+		{"closes keyword", "Automated PR.\n\nCloses #12", []int{12}},
+		{"mixed keywords and case", "Fixes #3, resolves GH-9 and CLOSED: #4", []int{3, 9, 4}},
+		{"duplicates", "Fixes #5\nAlso fixes #5", []int{5}},
+		{"plain reference", "Related to #8, see #9", nil},
+		{"empty body", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseLinkedIssues(tt.body)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ParseLinkedIssues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGroupIssues_EmptyInput(t *testing.T) {
 	result := GroupIssues(nil)
 	if result != nil {
@@ -0,0 +1,28 @@
+package github
+
+import "context"
+
+// IssueTarget comments on and labels a single issue or pull request. It
+// receives the actions of scripting hooks.
+type IssueTarget struct {
+	client *Client
+	owner  string
+	repo   string
+	number int
+}
+
+// NewIssueTarget creates a target for issue or PR number in owner/repo
+func NewIssueTarget(client *Client, owner, repo string, number int) *IssueTarget {
+	return &IssueTarget{client: client, owner: owner, repo: repo, number: number}
+}
+
+// Comment posts a comment
+func (t *IssueTarget) Comment(ctx context.Context, body string) error {
+	_, err := t.client.AddComment(ctx, t.owner, t.repo, t.number, body)
+	return err
+}
+
+// AddLabels adds labels
+func (t *IssueTarget) AddLabels(ctx context.Context, labels []string) error {
+	return t.client.AddLabels(ctx, t.owner, t.repo, t.number, labels)
+}
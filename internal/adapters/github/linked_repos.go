@@ -0,0 +1,35 @@
+package github
+
+import (
+	"regexp"
+	"strings"
+)
+
+// reposDirectiveRegex matches a "repos: [acme/api, acme/web]" line in an
+// issue body; the brackets are optional.
+var reposDirectiveRegex = regexp.MustCompile(`(?mi)^\s*repos:\s*\[?([^\]\n]*)\]?\s*$`)
+
+// repoNameRegex matches an "owner/repo" name
+var repoNameRegex = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// ParseLinkedRepos returns the repositories named by the issue body's repos:
+// directive, in order and without duplicates. A change spanning several
+// repositories runs one task per repository. Returns nil when the body has
+// no directive.
+func ParseLinkedRepos(body string) []string {
+	m := reposDirectiveRegex.FindStringSubmatch(body)
+	if m == nil {
+		return nil
+	}
+	var repos []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(m[1], ",") {
+		repo := strings.Trim(strings.TrimSpace(field), "`\"'")
+		if !repoNameRegex.MatchString(repo) || seen[strings.ToLower(repo)] {
+			continue
+		}
+		seen[strings.ToLower(repo)] = true
+		repos = append(repos, repo)
+	}
+	return repos
+}
@@ -0,0 +1,29 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLinkedRepos(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"none", "Add a field to the API and the client.", nil},
+		{"brackets", "Add a field.\n\nrepos: [acme/api, acme/web]\n", []string{"acme/api", "acme/web"}},
+		{"no brackets", "Repos: acme/api, acme/web", []string{"acme/api", "acme/web"}},
+		{"quoted and duplicate", "repos: [`acme/api`, \"acme/web\", ACME/api]", []string{"acme/api", "acme/web"}},
+		{"invalid names dropped", "repos: [acme/api, web, acme/web/extra]", []string{"acme/api"}},
+		{"mid-sentence ignored", "The repos: [acme/api] directive", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseLinkedRepos(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLinkedRepos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// needsInfoHeadings introduce the triage verdicts in a needs-info comment
+var needsInfoHeadings = map[string]string{
+	"question":         "This issue looks like a question rather than a change request",
+	"duplicate":        "This issue looks like a duplicate of recent work",
+	"missing_criteria": "This issue doesn't say how to tell when the work is done",
+}
+
+// NeedsInfoComment asks the requester to clarify an issue that triage did
+// not execute. The poller skips the issue until the requester replies or
+// the needs-info label is removed.
+func NeedsInfoComment(taskID, verdict, reason string, questions []string, requester string) string {
+	var sb strings.Builder
+	sb.WriteString("❓ **Pilot needs more information before starting**\n\n")
+	if requester != "" {
+		sb.WriteString("@" + requester + " ")
+	}
+	heading, ok := needsInfoHeadings[verdict]
+	if !ok {
+		heading = "This issue isn't ready to work on yet"
+	}
+	sb.WriteString(fmt.Sprintf("%s, so task `%s` was not started.\n\n", heading, taskID))
+	if reason != "" {
+		sb.WriteString("> " + reason + "\n\n")
+	}
+	if len(questions) > 0 {
+		sb.WriteString("**Please clarify:**\n")
+		for _, q := range questions {
+			sb.WriteString("- " + q + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Reply on this issue with the details and Pilot will start with your answer. ")
+	sb.WriteString(fmt.Sprintf("You can also update the issue and remove the `%s` label, or add the `no-triage` label if the issue is fine as written.\n\n<!-- pilot:needs-info verdict=%s -->", LabelNeedsInfo, verdict))
+	return sb.String()
+}
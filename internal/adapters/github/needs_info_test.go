@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestNeedsInfoComment(t *testing.T) {
+	comment := NeedsInfoComment("GH-12", "missing_criteria", "No expected behavior is described.",
+		[]string{"Which endpoint is slow?", "What response time is acceptable?"}, "alice")
+
+	for _, want := range []string{
+		"@alice",
+		"doesn't say how to tell when the work is done",
+		"`GH-12`",
+		"> No expected behavior is described.",
+		"- Which endpoint is slow?",
+		"- What response time is acceptable?",
+		"`pilot-needs-info`",
+		"`no-triage`",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("comment missing %q:\n%s", want, comment)
+		}
+	}
+	if !isPilotComment(&Comment{Body: comment}) {
+		t.Error("needs-info comment should count as a Pilot comment")
+	}
+
+	comment = NeedsInfoComment("GH-13", "", "", nil, "")
+	if strings.Contains(comment, "Please clarify") || strings.Contains(comment, "@") {
+		t.Errorf("comment without questions or requester:\n%s", comment)
+	}
+}
+
+func TestPoller_NeedsInfoIssueWaitsForLabelRemoval(t *testing.T) {
+	issue := &Issue{Number: 8, Title: "Make it faster", Labels: []Label{{Name: "pilot"}, {Name: LabelNeedsInfo}}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]*Issue{issue})
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	var calls int32
+	poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second,
+		WithOnIssue(func(ctx context.Context, issue *Issue) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}),
+	)
+
+	poller.checkForNewIssues(context.Background())
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("needs-info issue dispatched %d times", got)
+	}
+
+	issue.Labels = []Label{{Name: "pilot"}}
+	poller.checkForNewIssues(context.Background())
+	poller.WaitForActive()
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("issue dispatched %d times after the label was removed, want 1", got)
+	}
+}
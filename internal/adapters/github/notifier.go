@@ -10,6 +10,7 @@ import (
 type Notifier struct {
 	client     *Client
 	pilotLabel string
+	comments   *CommentManager // optional: edits one status comment per attempt
 }
 
 // NewNotifier creates a new GitHub notifier
@@ -20,6 +21,30 @@ func NewNotifier(client *Client, pilotLabel string) *Notifier {
 	}
 }
 
+// SetCommentManager routes status comments through m so they are edited in
+// place instead of appended. Without one, every update is a new comment.
+func (n *Notifier) SetCommentManager(m *CommentManager) {
+	n.comments = m
+}
+
+// postStatus posts an intermediate status update.
+func (n *Notifier) postStatus(ctx context.Context, owner, repo string, issueNum int, body string) error {
+	if n.comments != nil {
+		return n.comments.Post(ctx, owner, repo, issueNum, body)
+	}
+	_, err := n.client.AddComment(ctx, owner, repo, issueNum, body)
+	return err
+}
+
+// postFinalStatus posts the terminal status update for an attempt.
+func (n *Notifier) postFinalStatus(ctx context.Context, owner, repo string, issueNum int, body string) error {
+	if n.comments != nil {
+		return n.comments.Finish(ctx, owner, repo, issueNum, body)
+	}
+	_, err := n.client.AddComment(ctx, owner, repo, issueNum, body)
+	return err
+}
+
 // NotifyTaskStarted posts a comment and adds in-progress label
 func (n *Notifier) NotifyTaskStarted(ctx context.Context, owner, repo string, issueNum int, taskID string) error {
 	// Add in-progress label
@@ -29,7 +54,7 @@ func (n *Notifier) NotifyTaskStarted(ctx context.Context, owner, repo string, is
 
 	// Post comment
 	comment := fmt.Sprintf("🤖 **Pilot started working on this issue**\n\nTask ID: `%s`\n\nI'll post updates as I make progress.", taskID)
-	if _, err := n.client.AddComment(ctx, owner, repo, issueNum, comment); err != nil {
+	if err := n.postStatus(ctx, owner, repo, issueNum, comment); err != nil {
 		return fmt.Errorf("failed to add start comment: %w", err)
 	}
 
@@ -53,7 +78,13 @@ func (n *Notifier) NotifyProgress(ctx context.Context, owner, repo string, issue
 	}
 
 	comment := fmt.Sprintf("%s **Phase: %s**\n\n%s", emoji, phase, details)
-	if _, err := n.client.AddComment(ctx, owner, repo, issueNum, comment); err != nil {
+	var err error
+	if n.comments != nil {
+		err = n.comments.PostProgress(ctx, owner, repo, issueNum, comment)
+	} else {
+		_, err = n.client.AddComment(ctx, owner, repo, issueNum, comment)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to add progress comment: %w", err)
 	}
 
@@ -101,7 +132,7 @@ func (n *Notifier) NotifyTaskCompleted(ctx context.Context, owner, repo string,
 
 	comment.WriteString("_Issue closed. PR awaiting review._")
 
-	if _, err := n.client.AddComment(ctx, owner, repo, issueNum, comment.String()); err != nil {
+	if err := n.postFinalStatus(ctx, owner, repo, issueNum, comment.String()); err != nil {
 		return fmt.Errorf("failed to add completion comment: %w", err)
 	}
 
@@ -122,7 +153,7 @@ func (n *Notifier) NotifyTaskFailed(ctx context.Context, owner, repo string, iss
 
 	// Post failure comment
 	comment := fmt.Sprintf("❌ **Pilot could not complete this task**\n\n**Reason**: %s\n\n_Please review the issue and consider manual intervention or reopening with more details._", reason)
-	if _, err := n.client.AddComment(ctx, owner, repo, issueNum, comment); err != nil {
+	if err := n.postFinalStatus(ctx, owner, repo, issueNum, comment); err != nil {
 		return fmt.Errorf("failed to add failure comment: %w", err)
 	}
 
@@ -132,7 +163,7 @@ func (n *Notifier) NotifyTaskFailed(ctx context.Context, owner, repo string, iss
 // LinkPR adds a comment linking the created PR
 func (n *Notifier) LinkPR(ctx context.Context, owner, repo string, issueNum int, prNumber int, prURL string) error {
 	comment := fmt.Sprintf("🔗 **Pull Request Created**: #%d\n\n%s\n\n_This PR implements the changes for this issue._", prNumber, prURL)
-	if _, err := n.client.AddComment(ctx, owner, repo, issueNum, comment); err != nil {
+	if err := n.postStatus(ctx, owner, repo, issueNum, comment); err != nil {
 		return fmt.Errorf("failed to add PR link comment: %w", err)
 	}
 
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
@@ -40,10 +41,11 @@ type ProcessedStore interface {
 // IssueResult is returned by the issue handler with PR information
 type IssueResult struct {
 	Success    bool
-	PRNumber   int    // PR number if created
-	PRURL      string // PR URL if created
-	HeadSHA    string // Head commit SHA of the PR
-	BranchName string // Head branch name (e.g. "pilot/GH-123")
+	PRNumber   int     // PR number if created
+	PRURL      string  // PR URL if created
+	HeadSHA    string  // Head commit SHA of the PR
+	BranchName string  // Head branch name (e.g. "pilot/GH-123")
+	Confidence float64 // Intent judge confidence in the change (0 when unknown)
 	Error      error
 }
 
@@ -62,7 +64,9 @@ type Poller struct {
 	// OnPRCreated is called when a PR is created after issue processing
 	// Parameters: prNumber, prURL, issueNumber, headSHA, branchName, issueNodeID
 	OnPRCreated func(prNumber int, prURL string, issueNumber int, headSHA string, branchName string, issueNodeID string)
-	logger      *slog.Logger
+	// onPRConfidence receives the intent judge confidence of a created PR
+	onPRConfidence func(prNumber int, confidence float64)
+	logger         *slog.Logger
 
 	// Sequential mode configuration
 	executionMode  ExecutionMode
@@ -83,6 +87,31 @@ type Poller struct {
 
 	// Persistent processed store (optional)
 	processedStore ProcessedStore
+
+	// Result of the most recent issue listing, for health reporting
+	lastPollErr atomic.Pointer[error]
+
+	// Dependency cycles already logged, keyed by FormatCycle
+	reportedCycles map[string]bool
+
+	// Blocked and needs-info issues as last checked for a reply
+	blockedChecks map[int]blockedCheck
+
+	// Timeout policy for issues waiting for a reply (optional)
+	clarification *executor.ClarificationConfig
+
+	// Issues budget routing deferred, until when
+	deferredUntil map[int]time.Time
+
+	// Shared GraphQL batch the issues are read from (optional), and when
+	// the poller last marked an issue processed, in Unix nanoseconds
+	batch       *BatchPoller
+	lastChanged atomic.Int64
+
+	// Webhook deliveries that poke the poller (optional), and the channel
+	// pokes arrive on
+	webhooks *WebhookFeed
+	wake     chan struct{}
 }
 
 // PollerOption configures a Poller
@@ -134,6 +163,15 @@ func WithOnPRCreated(fn func(prNumber int, prURL string, issueNumber int, headSH
 	}
 }
 
+// WithOnPRConfidence sets the callback receiving the intent judge confidence
+// of each created PR. It runs after OnPRCreated and only when the confidence
+// is known.
+func WithOnPRConfidence(fn func(prNumber int, confidence float64)) PollerOption {
+	return func(p *Poller) {
+		p.onPRConfidence = fn
+	}
+}
+
 // WithScheduler sets the rate limit retry scheduler
 func WithScheduler(s *executor.Scheduler) PollerOption {
 	return func(p *Poller) {
@@ -149,6 +187,30 @@ func WithProcessedStore(store ProcessedStore) PollerOption {
 	}
 }
 
+// WithClarification sets the timeout policy for blocked and needs-info
+// issues whose requester does not reply
+func WithClarification(cfg *executor.ClarificationConfig) PollerOption {
+	return func(p *Poller) {
+		p.clarification = cfg
+	}
+}
+
+// WithBatchPoller reads issues from a batch shared with the pollers of
+// other repos instead of listing them per repo
+func WithBatchPoller(b *BatchPoller) PollerOption {
+	return func(p *Poller) {
+		p.batch = b
+	}
+}
+
+// WithWebhookFeed lets webhook deliveries for the repo poke the poller. In
+// webhook mode scheduled polls are skipped while deliveries arrive.
+func WithWebhookFeed(f *WebhookFeed) PollerOption {
+	return func(p *Poller) {
+		p.webhooks = f
+	}
+}
+
 // WithMaxConcurrent sets the maximum number of parallel issue executions
 func WithMaxConcurrent(n int) PollerOption {
 	return func(p *Poller) {
@@ -178,12 +240,25 @@ func NewPoller(client *Client, repo string, label string, interval time.Duration
 		waitForMerge:   true,
 		prPollInterval: 30 * time.Second,
 		prTimeout:      1 * time.Hour,
+		wake:           make(chan struct{}, 1),
 	}
 
 	for _, opt := range opts {
 		opt(p)
 	}
 
+	if p.batch != nil {
+		if strings.EqualFold(p.batch.label, label) {
+			p.batch.AddRepo(repo)
+		} else {
+			p.batch = nil
+		}
+	}
+
+	if p.webhooks != nil {
+		p.webhooks.register(repo, p.Poke)
+	}
+
 	// Create merge waiter if in sequential mode
 	if p.executionMode == ExecutionModeSequential && p.waitForMerge {
 		p.mergeWaiter = NewMergeWaiter(client, p.owner, p.repo, &MergeWaiterConfig{
@@ -286,25 +361,50 @@ func (p *Poller) startParallel(ctx context.Context) {
 	// Do an initial check immediately
 	p.checkForNewIssues(ctx)
 
-	ticker := time.NewTicker(p.interval)
-	defer ticker.Stop()
+	for p.waitForPoll(ctx) {
+		p.checkForNewIssues(ctx)
+	}
+
+	p.logger.Info("Parallel poller stopping, waiting for active tasks...")
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+	p.logger.Info("Parallel poller stopped")
+}
+
+// waitForPoll blocks until the next scheduled poll or until a webhook pokes
+// the poller. Scheduled polls are skipped while the webhook feed delivers
+// changes instead. Returns false when ctx is done.
+func (p *Poller) waitForPoll(ctx context.Context) bool {
+	timer := time.NewTimer(p.interval)
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			p.logger.Info("Parallel poller stopping, waiting for active tasks...")
-			p.wgMu.Lock()
-			p.stopping.Store(true)
-			p.wgMu.Unlock()
-			p.activeWg.Wait()
-			p.logger.Info("Parallel poller stopped")
-			return
-		case <-ticker.C:
-			p.checkForNewIssues(ctx)
+			return false
+		case <-p.wake:
+			return true
+		case <-timer.C:
+			if !p.webhooks.skipPoll() {
+				return true
+			}
+			timer.Reset(p.interval)
 		}
 	}
 }
 
+// Poke makes the poller list issues now instead of at the next interval,
+// bypassing a batch snapshot taken before the call
+func (p *Poller) Poke() {
+	p.lastChanged.Store(time.Now().UnixNano())
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
 // startSequential runs the sequential execution mode
 // Processes one issue at a time, waits for PR merge before next
 func (p *Poller) startSequential(ctx context.Context) {
@@ -332,12 +432,10 @@ func (p *Poller) startSequential(ctx context.Context) {
 		if issue == nil {
 			// No issues to process, wait before checking again
 			p.logger.Debug("No unprocessed issues found, waiting...")
-			select {
-			case <-ctx.Done():
+			if !p.waitForPoll(ctx) {
 				return
-			case <-time.After(p.interval):
-				continue
 			}
+			continue
 		}
 
 		// Process the issue
@@ -347,6 +445,14 @@ func (p *Poller) startSequential(ctx context.Context) {
 		)
 
 		result, err := p.processIssueSequential(ctx, issue)
+		if errors.Is(err, executor.ErrTaskBlocked) || errors.Is(err, executor.ErrTaskNeedsInfo) {
+			p.logger.Info("Issue blocked, waiting for a reply",
+				slog.Int("number", issue.Number))
+			continue
+		}
+		if p.deferIssue(issue.Number, err) {
+			continue
+		}
 		if err != nil {
 			// Check if this is a rate limit error that can be retried
 			if executor.IsRateLimitError(err.Error()) {
@@ -386,6 +492,7 @@ func (p *Poller) startSequential(ctx context.Context) {
 				slog.String("branch", result.BranchName),
 			)
 			p.OnPRCreated(result.PRNumber, result.PRURL, issue.Number, result.HeadSHA, result.BranchName, issue.NodeID)
+			p.reportConfidence(result)
 		}
 
 		// If we created a PR and should wait for merge
@@ -476,11 +583,8 @@ func (p *Poller) startSequential(ctx context.Context) {
 // findOldestUnprocessedIssue finds the oldest issue with the pilot label
 // that hasn't been processed yet and has no pending dependencies.
 func (p *Poller) findOldestUnprocessedIssue(ctx context.Context) (*Issue, error) {
-	issues, err := p.client.ListIssues(ctx, p.owner, p.repo, &ListIssuesOptions{
-		Labels: []string{p.label},
-		State:  StateOpen,
-		Sort:   "created", // Sort by creation date to get oldest first
-	})
+	issues, err := p.listLabeledIssues(ctx)
+	p.recordPoll(err)
 	if err != nil {
 		return nil, err
 	}
@@ -498,6 +602,17 @@ func (p *Poller) findOldestUnprocessedIssue(ctx context.Context) (*Issue, error)
 			continue
 		}
 
+		// Blocked issues and issues triage handed back wait for a reply
+		// from their requester, or for the needs-info label to be removed
+		if (HasLabel(issue, LabelBlocked) || HasLabel(issue, LabelNeedsInfo)) && !p.unblocked(ctx, issue) {
+			continue
+		}
+
+		// Low-priority issues deferred for budget wait for the next day
+		if p.deferred(issue.Number) {
+			continue
+		}
+
 		// Check if previously processed
 		p.mu.RLock()
 		processed := p.processed[issue.Number]
@@ -532,14 +647,20 @@ func (p *Poller) findOldestUnprocessedIssue(ctx context.Context) (*Issue, error)
 		return nil, nil
 	}
 
-	// Sort by creation date (oldest first)
+	// Sort by creation date (oldest first), then dependencies before dependents
 	sort.Slice(candidates, func(i, j int) bool {
 		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
 	})
+	graph := BuildDependencyGraph(issues)
+	p.reportCycles(graph)
+	graph.SortIssues(candidates)
 
-	// Find the oldest issue without pending dependencies
+	// Find the first issue without pending dependencies
 	for _, candidate := range candidates {
-		if !p.hasPendingDependencies(ctx, candidate) {
+		if graph.InCycle(candidate.Number) {
+			continue
+		}
+		if !p.hasPendingDependencies(ctx, candidate, graph) {
 			return candidate, nil
 		}
 		p.logger.Info("Skipping issue with pending dependencies",
@@ -635,18 +756,35 @@ func groupByOverlappingScope(candidates []*Issue) [][]*Issue {
 	return result
 }
 
-// checkForNewIssues fetches issues and dispatches new ones concurrently (parallel mode)
-func (p *Poller) checkForNewIssues(ctx context.Context) {
-	issues, err := p.client.ListIssues(ctx, p.owner, p.repo, &ListIssuesOptions{
+// listLabeledIssues returns the open issues with the poller's label, from
+// the batch when one is shared and has the repo, otherwise over REST
+func (p *Poller) listLabeledIssues(ctx context.Context) ([]*Issue, error) {
+	if p.batch != nil {
+		issues, err := p.batch.Issues(ctx, p.owner, p.repo, time.Unix(0, p.lastChanged.Load()))
+		if err == nil {
+			return issues, nil
+		}
+		p.logger.Debug("Batch poll unavailable, listing issues over REST", slog.Any("error", err))
+	}
+	return p.client.ListIssues(ctx, p.owner, p.repo, &ListIssuesOptions{
 		Labels: []string{p.label},
 		State:  StateOpen,
 		Sort:   "created",
 	})
+}
+
+// checkForNewIssues fetches issues and dispatches new ones concurrently (parallel mode)
+func (p *Poller) checkForNewIssues(ctx context.Context) {
+	issues, err := p.listLabeledIssues(ctx)
+	p.recordPoll(err)
 	if err != nil {
 		p.logger.Warn("Failed to fetch issues", slog.Any("error", err))
 		return
 	}
 
+	graph := BuildDependencyGraph(issues)
+	p.reportCycles(graph)
+
 	// Phase 1: Collect candidates eligible for dispatch
 	var candidates []*Issue
 	for _, issue := range issues {
@@ -666,6 +804,17 @@ func (p *Poller) checkForNewIssues(ctx context.Context) {
 			continue
 		}
 
+		// Blocked issues and issues triage handed back wait for a reply
+		// from their requester, or for the needs-info label to be removed
+		if (HasLabel(issue, LabelBlocked) || HasLabel(issue, LabelNeedsInfo)) && !p.unblocked(ctx, issue) {
+			continue
+		}
+
+		// Low-priority issues deferred for budget wait for the next day
+		if p.deferred(issue.Number) {
+			continue
+		}
+
 		// Check if already processed
 		p.mu.RLock()
 		processed := p.processed[issue.Number]
@@ -693,7 +842,7 @@ func (p *Poller) checkForNewIssues(ctx context.Context) {
 		}
 
 		// Skip issues with pending dependencies
-		if p.hasPendingDependencies(ctx, issue) {
+		if graph.InCycle(issue.Number) || p.hasPendingDependencies(ctx, issue, graph) {
 			p.logger.Debug("Skipping issue with pending dependencies in parallel mode",
 				slog.Int("number", issue.Number),
 			)
@@ -756,6 +905,14 @@ func (p *Poller) checkForNewIssues(ctx context.Context) {
 
 			if p.onIssueWithResult != nil {
 				result, err := p.onIssueWithResult(ctx, issue)
+				if errors.Is(err, executor.ErrTaskBlocked) || errors.Is(err, executor.ErrTaskNeedsInfo) {
+					p.logger.Info("Issue blocked, waiting for a reply",
+						slog.Int("number", issue.Number))
+					return
+				}
+				if p.deferIssue(issue.Number, err) {
+					return
+				}
 				if err != nil {
 					p.logger.Error("Failed to process issue",
 						slog.Int("number", issue.Number),
@@ -767,6 +924,7 @@ func (p *Poller) checkForNewIssues(ctx context.Context) {
 				// Notify autopilot controller of new PR
 				if result != nil && result.PRNumber > 0 && p.OnPRCreated != nil {
 					p.OnPRCreated(result.PRNumber, result.PRURL, issue.Number, result.HeadSHA, result.BranchName, issue.NodeID)
+					p.reportConfidence(result)
 				}
 			} else if p.onIssue != nil {
 				if err := p.onIssue(ctx, issue); err != nil {
@@ -785,6 +943,8 @@ func (p *Poller) markProcessed(number int) {
 	p.mu.Lock()
 	p.processed[number] = true
 	p.mu.Unlock()
+	// A batch fetched before now still shows the issue without status labels
+	p.lastChanged.Store(time.Now().UnixNano())
 
 	// Persist to store if available
 	if p.processedStore != nil {
@@ -828,6 +988,23 @@ func (p *Poller) ProcessedCount() int {
 	return len(p.processed)
 }
 
+// Name identifies the poller on the gateway status page
+func (p *Poller) Name() string {
+	return fmt.Sprintf("github:%s/%s", p.owner, p.repo)
+}
+
+// Ready reports whether the most recent issue listing succeeded.
+// A poller that has not polled yet is considered healthy.
+func (p *Poller) Ready() bool {
+	errPtr := p.lastPollErr.Load()
+	return errPtr == nil || *errPtr == nil
+}
+
+// recordPoll stores the result of an issue listing
+func (p *Poller) recordPoll(err error) {
+	p.lastPollErr.Store(&err)
+}
+
 // Reset clears the processed issues map
 func (p *Poller) Reset() {
 	p.mu.Lock()
@@ -957,16 +1134,26 @@ func (p *Poller) hasMergedWork(ctx context.Context, issue *Issue) bool {
 	return true
 }
 
-// hasPendingDependencies checks if any of the issue's dependencies are still open.
-// Returns true if the issue has open dependencies and should be skipped.
-func (p *Poller) hasPendingDependencies(ctx context.Context, issue *Issue) bool {
+// hasPendingDependencies checks if any of the issue's dependencies are unresolved:
+// an open issue or an unmerged PR. Dependencies that are open issues in graph
+// are pending without an API call; graph may be nil.
+// Returns true if the issue has pending dependencies and should be skipped.
+func (p *Poller) hasPendingDependencies(ctx context.Context, issue *Issue, graph *DependencyGraph) bool {
 	deps := ParseDependencies(issue.Body)
 	if len(deps) == 0 {
 		return false
 	}
 
 	for _, depNum := range deps {
-		depIssue, err := p.client.GetIssue(ctx, p.owner, p.repo, depNum)
+		if graph != nil && graph.Node(depNum) != nil {
+			p.logger.Debug("Issue has open dependency, skipping",
+				slog.Int("issue", issue.Number),
+				slog.Int("dependency", depNum),
+			)
+			return true
+		}
+
+		resolved, err := p.client.IsDependencyResolved(ctx, p.owner, p.repo, depNum)
 		if err != nil {
 			// If we can't fetch the dependency, log and assume it's still pending
 			// to be safe (don't execute if we can't verify)
@@ -978,9 +1165,8 @@ func (p *Poller) hasPendingDependencies(ctx context.Context, issue *Issue) bool
 			return true
 		}
 
-		// Check if dependency is still open
-		if depIssue.State == "open" {
-			p.logger.Debug("Issue has open dependency, skipping",
+		if !resolved {
+			p.logger.Debug("Issue has unresolved dependency, skipping",
 				slog.Int("issue", issue.Number),
 				slog.Int("dependency", depNum),
 			)
@@ -990,3 +1176,32 @@ func (p *Poller) hasPendingDependencies(ctx context.Context, issue *Issue) bool
 
 	return false
 }
+
+// reportCycles logs each dependency cycle once. Issues in a cycle are never
+// dispatched until one of their "Depends on" references is removed.
+func (p *Poller) reportCycles(graph *DependencyGraph) {
+	for _, cycle := range graph.Cycles() {
+		key := FormatCycle(cycle)
+		p.mu.Lock()
+		reported := p.reportedCycles[key]
+		if !reported {
+			if p.reportedCycles == nil {
+				p.reportedCycles = make(map[string]bool)
+			}
+			p.reportedCycles[key] = true
+		}
+		p.mu.Unlock()
+		if !reported {
+			p.logger.Warn("Dependency cycle detected, skipping issues until it is broken",
+				slog.String("cycle", key),
+			)
+		}
+	}
+}
+
+// reportConfidence passes a created PR's confidence to the registered callback.
+func (p *Poller) reportConfidence(result *IssueResult) {
+	if p.onPRConfidence != nil && result.Confidence > 0 {
+		p.onPRConfidence(result.PRNumber, result.Confidence)
+	}
+}
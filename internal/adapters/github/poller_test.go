@@ -396,12 +396,22 @@ func TestPoller_CheckForNewIssues_APIError(t *testing.T) {
 		}),
 	)
 
+	if !poller.Ready() {
+		t.Error("poller should be healthy before the first poll")
+	}
+
 	// Should not panic and should not call callback
 	poller.checkForNewIssues(context.Background())
 
 	if callbackCalled {
 		t.Error("callback should not be called on API error")
 	}
+	if poller.Ready() {
+		t.Error("poller should be unhealthy after a failed poll")
+	}
+	if poller.Name() != "github:owner/repo" {
+		t.Errorf("Name() = %q, want github:owner/repo", poller.Name())
+	}
 }
 
 func TestPoller_CheckForNewIssues_CallbackError(t *testing.T) {
@@ -1104,7 +1114,7 @@ func TestPoller_HasPendingDependencies(t *testing.T) {
 			poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second)
 
 			issue := &Issue{Number: 1, Body: tt.issueBody}
-			got := poller.hasPendingDependencies(context.Background(), issue)
+			got := poller.hasPendingDependencies(context.Background(), issue, nil)
 
 			if got != tt.want {
 				t.Errorf("hasPendingDependencies() = %v, want %v", got, tt.want)
@@ -1123,7 +1133,7 @@ func TestPoller_HasPendingDependencies_APIError(t *testing.T) {
 	poller, _ := NewPoller(client, "owner/repo", "pilot", 30*time.Second)
 
 	issue := &Issue{Number: 1, Body: "Depends on: #100"}
-	got := poller.hasPendingDependencies(context.Background(), issue)
+	got := poller.hasPendingDependencies(context.Background(), issue, nil)
 
 	// Should return true (has pending) when API fails - be safe and don't execute
 	if !got {
@@ -0,0 +1,476 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitHub API rate limit resources, as reported in X-RateLimit-Resource
+const (
+	ResourceCore    = "core"
+	ResourceSearch  = "search"
+	ResourceGraphQL = "graphql"
+)
+
+const (
+	// defaultMaxConcurrent caps in-flight requests per token. GitHub's
+	// secondary limits punish bursts of concurrent requests.
+	defaultMaxConcurrent = 4
+
+	// writeInterval spaces POST, PATCH, PUT and DELETE requests, as GitHub
+	// recommends to stay under secondary limits. It applies for
+	// writeSpacingPeriod after GitHub reports a secondary limit.
+	writeInterval      = time.Second
+	writeSpacingPeriod = time.Hour
+
+	// maxQueueWait is the longest a request waits for quota. Beyond that it
+	// fails with a RateLimitError so callers can back off on their own.
+	maxQueueWait = time.Minute
+
+	// secondaryLimitWait is how long to pause after a 429 that names no
+	// Retry-After, per GitHub's guidance
+	secondaryLimitWait = time.Minute
+
+	// requestTimeout bounds one attempt, including reading the body
+	requestTimeout = 30 * time.Second
+
+	// maxRateLimitRetries is how often a rate-limited request is retried
+	// after waiting
+	maxRateLimitRetries = 2
+
+	// maxPaceDelay caps the spacing of requests when quota runs low
+	maxPaceDelay = 10 * time.Second
+)
+
+// Quota is the rate limit of one API resource
+type Quota struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+}
+
+// RateLimitSnapshot is a point-in-time view of a RateLimiter
+type RateLimitSnapshot struct {
+	Key          string    `json:"key"`                     // Host and token fingerprint the quota belongs to
+	Quotas       []Quota   `json:"quotas"`                  // Sorted by resource
+	BlockedUntil time.Time `json:"blocked_until,omitempty"` // Set while honoring a Retry-After
+	InFlight     int       `json:"in_flight"`
+	Queued       int       `json:"queued"`
+	Throttled    int64     `json:"throttled"`    // Requests delayed to respect quota or spacing
+	Limited      int64     `json:"limited"`      // 403/429 rate limit responses from GitHub
+	Rejected     int64     `json:"rejected"`     // Requests failed because quota was out for too long
+	NotModified  int64     `json:"not_modified"` // Conditional requests answered from the ETag cache
+}
+
+// RateLimitError is returned instead of sending a request that would wait
+// too long for quota
+type RateLimitError struct {
+	Resource string
+	Until    time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("GitHub API rate limit reached for %s, retry after %s", e.Resource, e.Until.Local().Format("15:04:05"))
+}
+
+// RateLimiter tracks the quota of one token on one GitHub host and queues
+// requests so that every client using the token stays within it. Clients
+// get it through SharedRateLimiter.
+type RateLimiter struct {
+	key string
+
+	mu           sync.Mutex
+	quotas       map[string]*Quota
+	lastSent     map[string]time.Time
+	blockedUntil time.Time
+	spaceWrites  time.Time // Writes are spaced until then
+	nextWrite    time.Time
+	queued       int
+	throttled    int64
+	limited      int64
+	rejected     int64
+	notModified  int64
+
+	slots chan struct{}
+	now   func() time.Time
+}
+
+// NewRateLimiter returns a limiter allowing maxConcurrent requests in
+// flight
+func NewRateLimiter(key string, maxConcurrent int) *RateLimiter {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+	return &RateLimiter{
+		key:      key,
+		quotas:   make(map[string]*Quota),
+		lastSent: make(map[string]time.Time),
+		slots:    make(chan struct{}, maxConcurrent),
+		now:      time.Now,
+	}
+}
+
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = make(map[string]*RateLimiter)
+)
+
+// SharedRateLimiter returns the limiter for a token on the host of
+// baseURL. Pollers, controllers and notifiers each build their own Client;
+// sharing the limiter makes them queue against one quota.
+func SharedRateLimiter(baseURL, token string) *RateLimiter {
+	key := rateLimitKey(baseURL, token)
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+	l, ok := sharedLimiters[key]
+	if !ok {
+		l = NewRateLimiter(key, defaultMaxConcurrent)
+		sharedLimiters[key] = l
+	}
+	return l
+}
+
+// RateLimitSnapshots returns the state of every shared limiter in use,
+// sorted by key
+func RateLimitSnapshots() []RateLimitSnapshot {
+	sharedLimitersMu.Lock()
+	limiters := make([]*RateLimiter, 0, len(sharedLimiters))
+	for _, l := range sharedLimiters {
+		limiters = append(limiters, l)
+	}
+	sharedLimitersMu.Unlock()
+
+	snaps := make([]RateLimitSnapshot, 0, len(limiters))
+	for _, l := range limiters {
+		snaps = append(snaps, l.Snapshot())
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Key < snaps[j].Key })
+	return snaps
+}
+
+// rateLimitKey identifies a quota without exposing the token
+func rateLimitKey(baseURL, token string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(baseURL, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	sum := sha256.Sum256([]byte(token))
+	return host + "/" + hex.EncodeToString(sum[:4])
+}
+
+// Snapshot returns the limiter's current state
+func (l *RateLimiter) Snapshot() RateLimitSnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	snap := RateLimitSnapshot{
+		Key:         l.key,
+		InFlight:    len(l.slots),
+		Queued:      l.queued,
+		Throttled:   l.throttled,
+		Limited:     l.limited,
+		Rejected:    l.rejected,
+		NotModified: l.notModified,
+	}
+	if l.blockedUntil.After(l.now()) {
+		snap.BlockedUntil = l.blockedUntil
+	}
+	for _, q := range l.quotas {
+		snap.Quotas = append(snap.Quotas, *q)
+	}
+	sort.Slice(snap.Quotas, func(i, j int) bool { return snap.Quotas[i].Resource < snap.Quotas[j].Resource })
+	return snap
+}
+
+// delay returns how long a request for resource must wait. Below a tenth
+// of the quota, requests are spread over the time left until the reset
+// instead of spending the rest at once.
+func (l *RateLimiter) delay(resource string) (time.Duration, time.Time) {
+	now := l.now()
+	until := l.blockedUntil
+	if q, ok := l.quotas[resource]; ok && q.Reset.After(now) {
+		switch {
+		case q.Remaining <= 0:
+			if q.Reset.After(until) {
+				until = q.Reset
+			}
+		case q.Remaining <= q.Limit/10:
+			paced := pacedUntil(q, l.lastSent[resource], now)
+			if paced.After(until) {
+				until = paced
+			}
+		}
+	}
+	return until.Sub(now), until
+}
+
+// pacedUntil spreads the remaining requests evenly until the reset,
+// counting from the last request sent
+func pacedUntil(q *Quota, last, now time.Time) time.Time {
+	spread := q.Reset.Sub(now) / time.Duration(q.Remaining+1)
+	if spread > maxPaceDelay {
+		spread = maxPaceDelay
+	}
+	return last.Add(spread)
+}
+
+// acquire waits until a request for resource may be sent and takes an
+// in-flight slot. The returned func releases the slot.
+func (l *RateLimiter) acquire(ctx context.Context, resource string, write bool) (func(), error) {
+	l.mu.Lock()
+	l.queued++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.queued--
+		l.mu.Unlock()
+	}()
+
+	throttled := false
+	for resource != "" {
+		l.mu.Lock()
+		wait, until := l.delay(resource)
+		if now := l.now(); wait <= 0 && write && l.spaceWrites.After(now) {
+			// Reserve the next write slot so concurrent writes queue up
+			if l.nextWrite.After(now) {
+				wait, until = l.nextWrite.Sub(now), l.nextWrite
+			} else {
+				l.nextWrite = now.Add(writeInterval)
+			}
+		}
+		if wait > maxQueueWait {
+			l.rejected++
+			l.mu.Unlock()
+			return nil, &RateLimitError{Resource: resource, Until: until}
+		}
+		if wait > 0 && !throttled {
+			throttled = true
+			l.throttled++
+		}
+		if wait <= 0 {
+			l.lastSent[resource] = l.now()
+		}
+		l.mu.Unlock()
+
+		if wait <= 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	l.mu.Lock()
+	if q, ok := l.quotas[resource]; ok && q.Remaining > 0 {
+		// Count the request now so concurrent requests don't all spend
+		// the last unit; the response headers correct it
+		q.Remaining--
+	}
+	l.mu.Unlock()
+	return func() { <-l.slots }, nil
+}
+
+// observe updates the quota from a response and returns how long to wait
+// before retrying when GitHub rate limited the request
+func (l *RateLimiter) observe(resource string, resp *http.Response) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	h := resp.Header
+	if r := h.Get("X-RateLimit-Resource"); r != "" {
+		resource = r
+	}
+	limit, limitErr := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if limitErr == nil && remainingErr == nil {
+		q := &Quota{Resource: resource, Limit: limit, Remaining: remaining}
+		q.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			q.Reset = time.Unix(reset, 0)
+		}
+		l.quotas[resource] = q
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		l.notModified++
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+
+	var wait time.Duration
+	switch {
+	case h.Get("Retry-After") != "":
+		secs, err := strconv.Atoi(h.Get("Retry-After"))
+		if err != nil {
+			return 0
+		}
+		wait = time.Duration(secs) * time.Second
+		l.spaceWrites = now.Add(writeSpacingPeriod)
+	case remainingErr == nil && remaining == 0:
+		q := l.quotas[resource]
+		wait = q.Reset.Sub(now)
+		if wait <= 0 {
+			return 0
+		}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait = secondaryLimitWait
+		l.spaceWrites = now.Add(writeSpacingPeriod)
+	default:
+		// A 403 without rate limit headers is a permission error
+		return 0
+	}
+
+	l.limited++
+	if blocked := now.Add(wait); blocked.After(l.blockedUntil) {
+		l.blockedUntil = blocked
+	}
+	return wait
+}
+
+// rateLimitTransport sends requests through a RateLimiter and retries the
+// ones GitHub rate limits once the wait is over
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+	timeout time.Duration
+}
+
+func newRateLimitTransport(limiter *RateLimiter) *rateLimitTransport {
+	return &rateLimitTransport{base: http.DefaultTransport, limiter: limiter, timeout: requestTimeout}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := requestResource(req.URL.Path)
+	write := req.Method != http.MethodGet && req.Method != http.MethodHead
+
+	for attempt := 0; ; attempt++ {
+		release, err := t.limiter.acquire(req.Context(), resource, write)
+		if err != nil {
+			return nil, err
+		}
+
+		// The timeout covers one attempt, so time spent queueing for
+		// quota doesn't count against it
+		ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+		resp, err := t.base.RoundTrip(req.WithContext(ctx))
+		release()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+		wait := t.limiter.observe(resource, resp)
+		if wait <= 0 || wait > maxQueueWait || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+		next, ok := rewind(req)
+		if !ok {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+		req = next
+	}
+}
+
+// rewind returns a copy of req that can be sent again
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next := req.Clone(req.Context())
+	next.Body = body
+	return next, true
+}
+
+// requestResource classifies a request by the quota it draws from
+func requestResource(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/rate_limit"):
+		return "" // Free, and needed to report quota while it is exhausted
+	case strings.HasSuffix(path, "/graphql"):
+		return ResourceGraphQL
+	case strings.Contains(path, "/search/"):
+		return ResourceSearch
+	default:
+		return ResourceCore
+	}
+}
+
+// cancelOnClose releases a request's timeout when its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// newHTTPClient returns an HTTP client that queues against the shared
+// quota of token on baseURL's host
+func newHTTPClient(baseURL, token string) *http.Client {
+	return &http.Client{Transport: newRateLimitTransport(SharedRateLimiter(baseURL, token))}
+}
+
+// rateLimitResponse is the body of GET /rate_limit
+type rateLimitResponse struct {
+	Resources map[string]struct {
+		Limit     int   `json:"limit"`
+		Remaining int   `json:"remaining"`
+		Used      int   `json:"used"`
+		Reset     int64 `json:"reset"`
+	} `json:"resources"`
+}
+
+// GetRateLimit fetches the token's current quota. The request itself is
+// free: GitHub doesn't count it against the quota.
+func (c *Client) GetRateLimit(ctx context.Context) ([]Quota, error) {
+	var result rateLimitResponse
+	if err := c.doRequest(ctx, http.MethodGet, "/rate_limit", nil, &result); err != nil {
+		return nil, err
+	}
+	quotas := make([]Quota, 0, len(result.Resources))
+	for name, r := range result.Resources {
+		quotas = append(quotas, Quota{
+			Resource:  name,
+			Limit:     r.Limit,
+			Remaining: r.Remaining,
+			Used:      r.Used,
+			Reset:     time.Unix(r.Reset, 0),
+		})
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Resource < quotas[j].Resource })
+	return quotas, nil
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestSharedRateLimiter(t *testing.T) {
+	a := SharedRateLimiter("https://api.github.com", "token-a")
+	if a != SharedRateLimiter("https://api.github.com/", "token-a") {
+		t.Error("clients with the same token and host should share a limiter")
+	}
+	if a == SharedRateLimiter("https://api.github.com", "token-b") {
+		t.Error("different tokens should not share a limiter")
+	}
+	if a == SharedRateLimiter("https://ghe.example.com/api/v3", "token-a") {
+		t.Error("different hosts should not share a limiter")
+	}
+}
+
+func TestRateLimitTransport_TracksQuota(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Resource", "core")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Used", "679")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset))
+		_, _ = w.Write([]byte(`{"number": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	if _, err := client.GetIssue(context.Background(), "owner", "repo", 1); err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	snap := SharedRateLimiter(server.URL, testutil.FakeGitHubToken).Snapshot()
+	if len(snap.Quotas) != 1 {
+		t.Fatalf("quotas = %+v, want core only", snap.Quotas)
+	}
+	q := snap.Quotas[0]
+	if q.Resource != ResourceCore || q.Limit != 5000 || q.Remaining != 4321 || q.Used != 679 || q.Reset.Unix() != reset {
+		t.Errorf("quota = %+v", q)
+	}
+}
+
+func TestRateLimitTransport_HonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"number": 7}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	start := time.Now()
+	issue, err := client.GetIssue(context.Background(), "owner", "repo", 7)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Number != 7 || calls.Load() != 2 {
+		t.Errorf("issue = %d after %d calls, want 7 after 2", issue.Number, calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", elapsed)
+	}
+
+	snap := SharedRateLimiter(server.URL, testutil.FakeGitHubToken).Snapshot()
+	if snap.Limited != 1 || snap.Throttled != 1 {
+		t.Errorf("snapshot = %+v, want 1 limited and 1 throttled", snap)
+	}
+}
+
+func TestRateLimitTransport_ExhaustedQuotaFailsFast(t *testing.T) {
+	var calls atomic.Int32
+	reset := time.Now().Add(30 * time.Minute).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	if _, err := client.GetIssue(context.Background(), "owner", "repo", 1); err == nil {
+		t.Fatal("GetIssue should fail while the quota is exhausted")
+	}
+
+	// The next request is not sent until the reset
+	_, err := client.GetIssue(context.Background(), "owner", "repo", 1)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.Resource != ResourceCore || rlErr.Until.Unix() != reset {
+		t.Errorf("GetIssue() = %v, want RateLimitError until reset", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server called %d times, want 1", calls.Load())
+	}
+	if snap := SharedRateLimiter(server.URL, testutil.FakeGitHubToken).Snapshot(); snap.Rejected != 1 || snap.Limited != 1 {
+		t.Errorf("snapshot = %+v", snap)
+	}
+
+	// /rate_limit is free and stays reachable
+	if _, err := client.GetRateLimit(context.Background()); err == nil {
+		t.Error("GetRateLimit should reach the server and report its 403")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server called %d times, want 2", calls.Load())
+	}
+}
+
+func TestRateLimiter_LimitsConcurrency(t *testing.T) {
+	l := NewRateLimiter("test", 1)
+	release, err := l.acquire(context.Background(), ResourceCore, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, ResourceCore, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second acquire = %v, want to wait for the slot", err)
+	}
+	release()
+	release, err = l.acquire(context.Background(), ResourceCore, false)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release()
+}
+
+func TestGetRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"resources": {
+			"core": {"limit": 5000, "remaining": 4999, "used": 1, "reset": 1700000000},
+			"search": {"limit": 30, "remaining": 30, "used": 0, "reset": 1700000060}
+		}}`))
+	}))
+	defer server.Close()
+
+	quotas, err := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL).GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("GetRateLimit failed: %v", err)
+	}
+	if len(quotas) != 2 || quotas[0].Resource != "core" || quotas[0].Remaining != 4999 || quotas[1].Resource != "search" {
+		t.Errorf("quotas = %+v", quotas)
+	}
+}
@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// codeOwnersPaths are where GitHub looks for a CODEOWNERS file, in order
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Sources of a ReviewerAssignment
+const (
+	ReviewersFromCodeOwners = "CODEOWNERS"
+	ReviewersFromPool       = "reviewer pool"
+)
+
+// CodeOwners is a parsed CODEOWNERS file. As on GitHub, the last rule
+// matching a path decides its owners.
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string // "@user", "@org/team" or an email
+}
+
+// ParseCodeOwners parses a CODEOWNERS file. Lines with invalid patterns are
+// skipped, as GitHub does.
+func ParseCodeOwners(data []byte) *CodeOwners {
+	co := &CodeOwners{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		pattern, err := codeOwnersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		co.rules = append(co.rules, codeOwnersRule{pattern: pattern, owners: owners})
+	}
+	return co
+}
+
+// codeOwnersPattern converts a gitignore-style CODEOWNERS pattern to a
+// regexp over repo-relative paths. A pattern matches a file or, unless its
+// last segment has a single-level wildcard, everything below a directory.
+func codeOwnersPattern(p string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(strings.TrimSuffix(p, "/"), "/")
+	p = strings.TrimPrefix(p, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case c == '*' && i+1 < len(p) && p[i+1] == '*':
+			i++
+			if i+1 < len(p) && p[i+1] == '/' {
+				i++
+				sb.WriteString("(?:.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	last := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*$")
+	case strings.ContainsAny(last, "*?") && last != "**":
+		sb.WriteString("$")
+	default:
+		sb.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(sb.String())
+}
+
+// Owners returns the owners of a repo-relative path, nil when no rule
+// matches or the last matching rule has no owners
+func (co *CodeOwners) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if co.rules[i].pattern.MatchString(path) {
+			return co.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// ReviewerAssignment is who was asked to review a PR, and why
+type ReviewerAssignment struct {
+	Reviewers     []string // GitHub logins
+	TeamReviewers []string // Team slugs in the repo's organization
+	Source        string   // ReviewersFromCodeOwners or ReviewersFromPool
+}
+
+// Mentions lists the requested reviewers as @-mentions, teams with their
+// organization
+func (a *ReviewerAssignment) Mentions(owner string) []string {
+	mentions := make([]string, 0, len(a.Reviewers)+len(a.TeamReviewers))
+	for _, login := range a.Reviewers {
+		mentions = append(mentions, "@"+login)
+	}
+	for _, team := range a.TeamReviewers {
+		mentions = append(mentions, "@"+owner+"/"+team)
+	}
+	return mentions
+}
+
+// ReviewerRotation hands out reviewer pool members in turn, per repo, so
+// review load spreads across the pool
+type ReviewerRotation struct {
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// NewReviewerRotation creates a rotation starting at the first pool member
+func NewReviewerRotation() *ReviewerRotation {
+	return &ReviewerRotation{next: make(map[string]int)}
+}
+
+// Take returns the next n pool members of repo, skipping members for which
+// skip returns true
+func (r *ReviewerRotation) Take(repo string, pool []string, n int, skip func(string) bool) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var picked []string
+	start := r.next[repo]
+	for i := 0; i < len(pool) && len(picked) < n; i++ {
+		idx := (start + i) % len(pool)
+		if skip(pool[idx]) {
+			continue
+		}
+		picked = append(picked, pool[idx])
+		r.next[repo] = idx + 1
+	}
+	return picked
+}
+
+// AssignReviewers requests reviews on a PR from the CODEOWNERS owners of
+// its changed files, read from the base branch. Without owners, the next
+// members of the configured pool are requested instead. Returns nil when
+// assignment is disabled or nobody could be requested.
+func AssignReviewers(ctx context.Context, client *Client, cfg *ReviewerAssignmentConfig, rotation *ReviewerRotation, owner, repo string, number int) (*ReviewerAssignment, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	pr, err := client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	author := pr.User.Login
+
+	assignment, err := codeOwnersAssignment(ctx, client, owner, repo, pr)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		count := cfg.Count
+		if count <= 0 {
+			count = 1
+		}
+		picked := rotation.Take(owner+"/"+repo, cfg.Pool, count, func(member string) bool {
+			return strings.EqualFold(strings.TrimPrefix(member, "@"), author)
+		})
+		assignment = &ReviewerAssignment{Source: ReviewersFromPool}
+		assignment.add(owner, author, picked)
+	}
+	if len(assignment.Reviewers) == 0 && len(assignment.TeamReviewers) == 0 {
+		return nil, nil
+	}
+
+	if err := client.RequestReviewers(ctx, owner, repo, number, assignment.Reviewers, assignment.TeamReviewers); err != nil {
+		return nil, fmt.Errorf("failed to request reviewers: %w", err)
+	}
+	return assignment, nil
+}
+
+// codeOwnersAssignment collects the CODEOWNERS owners of the PR's changed
+// files, nil when the repo has no CODEOWNERS or no file has owners
+func codeOwnersAssignment(ctx context.Context, client *Client, owner, repo string, pr *PullRequest) (*ReviewerAssignment, error) {
+	var data []byte
+	for _, path := range codeOwnersPaths {
+		content, err := client.GetFileContent(ctx, owner, repo, path, pr.Base.Ref)
+		if err == nil {
+			data = content
+			break
+		}
+		if !isNotFoundError(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	if data == nil {
+		return nil, nil
+	}
+	codeOwners := ParseCodeOwners(data)
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of PR #%d: %w", pr.Number, err)
+	}
+	assignment := &ReviewerAssignment{Source: ReviewersFromCodeOwners}
+	for _, file := range files {
+		assignment.add(owner, pr.User.Login, codeOwners.Owners(file.Filename))
+	}
+	if len(assignment.Reviewers) == 0 && len(assignment.TeamReviewers) == 0 {
+		return nil, nil
+	}
+	return assignment, nil
+}
+
+// add records owners as reviewers once each. Teams outside the repo's
+// organization, emails and the PR author cannot be requested and are
+// dropped.
+func (a *ReviewerAssignment) add(owner, author string, owners []string) {
+	for _, o := range owners {
+		o = strings.TrimPrefix(o, "@")
+		if org, team, isTeam := strings.Cut(o, "/"); isTeam {
+			if strings.EqualFold(org, owner) && !containsFold(a.TeamReviewers, team) {
+				a.TeamReviewers = append(a.TeamReviewers, team)
+			}
+			continue
+		}
+		if strings.Contains(o, "@") || strings.EqualFold(o, author) || containsFold(a.Reviewers, o) {
+			continue
+		}
+		a.Reviewers = append(a.Reviewers, o)
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
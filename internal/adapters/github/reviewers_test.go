@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestCodeOwners_Owners(t *testing.T) {
+	co := ParseCodeOwners([]byte(`# Default owners
+*                @acme/core
+
+*.js             @frontend-lead   # inline comment
+/docs/           @acme/docs docs@acme.com
+apps/            @apps-owner
+/build/logs      @ops
+internal/*.go    @go-owner
+**/testdata      @qa
+/vendor/
+[invalid         @nobody
+`))
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@acme/core"}},
+		{"web/app.js", []string{"@frontend-lead"}},
+		{"docs/guide/setup.md", []string{"@acme/docs", "docs@acme.com"}},
+		{"src/docs/readme.md", []string{"@acme/core"}},
+		{"apps/api/main.go", []string{"@apps-owner"}},
+		{"services/apps/x.txt", []string{"@apps-owner"}},
+		{"build/logs/today.log", []string{"@ops"}},
+		{"internal/main.go", []string{"@go-owner"}},
+		{"internal/pkg/main.go", []string{"@acme/core"}},
+		{"pkg/parser/testdata/input.txt", []string{"@qa"}},
+		{"vendor/lib/lib.go", nil},
+	}
+	for _, tt := range tests {
+		if got := co.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestReviewerRotation_Take(t *testing.T) {
+	r := NewReviewerRotation()
+	pool := []string{"alice", "bob", "carol"}
+	none := func(string) bool { return false }
+
+	if got := r.Take("o/r", pool, 1, none); !reflect.DeepEqual(got, []string{"alice"}) {
+		t.Errorf("first Take = %v", got)
+	}
+	if got := r.Take("o/r", pool, 2, none); !reflect.DeepEqual(got, []string{"bob", "carol"}) {
+		t.Errorf("second Take = %v", got)
+	}
+	// Skipped members are passed over without losing their turn order
+	if got := r.Take("o/r", pool, 1, func(m string) bool { return m == "alice" }); !reflect.DeepEqual(got, []string{"bob"}) {
+		t.Errorf("Take skipping alice = %v", got)
+	}
+	if got := r.Take("o/other", pool, 1, none); !reflect.DeepEqual(got, []string{"alice"}) {
+		t.Errorf("other repo Take = %v", got)
+	}
+	if got := r.Take("o/r", nil, 1, none); got != nil {
+		t.Errorf("empty pool Take = %v", got)
+	}
+}
+
+// reviewerServer fakes the endpoints AssignReviewers uses
+func reviewerServer(t *testing.T, codeOwners string, files []string, requested *map[string][]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/acme/app/pulls/7":
+			_ = json.NewEncoder(w).Encode(PullRequest{Number: 7, User: User{Login: "pilot-bot"}, Base: PRRef{Ref: "main"}})
+		case r.URL.Path == "/repos/acme/app/contents/.github/CODEOWNERS" && codeOwners != "":
+			if r.URL.Query().Get("ref") != "main" {
+				t.Errorf("CODEOWNERS read at %q, want main", r.URL.Query().Get("ref"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(codeOwners)),
+			})
+		case strings.HasPrefix(r.URL.Path, "/repos/acme/app/contents/"):
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		case r.URL.Path == "/repos/acme/app/pulls/7/files":
+			var result []PRFile
+			for _, f := range files {
+				result = append(result, PRFile{Filename: f})
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case r.URL.Path == "/repos/acme/app/pulls/7/requested_reviewers":
+			_ = json.NewDecoder(r.Body).Decode(requested)
+			_ = json.NewEncoder(w).Encode(PullRequest{Number: 7})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAssignReviewers_CodeOwners(t *testing.T) {
+	var requested map[string][]string
+	server := reviewerServer(t, "* @acme/core\n/api/ @alice @pilot-bot @other-org/team\n", []string{"api/handler.go", "README.md"}, &requested)
+	defer server.Close()
+
+	cfg := &ReviewerAssignmentConfig{Enabled: true, Pool: []string{"zed"}}
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	got, err := AssignReviewers(context.Background(), client, cfg, NewReviewerRotation(), "acme", "app", 7)
+	if err != nil {
+		t.Fatalf("AssignReviewers() error = %v", err)
+	}
+	want := &ReviewerAssignment{Reviewers: []string{"alice"}, TeamReviewers: []string{"core"}, Source: ReviewersFromCodeOwners}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignReviewers() = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(requested["reviewers"], []string{"alice"}) || !reflect.DeepEqual(requested["team_reviewers"], []string{"core"}) {
+		t.Errorf("requested = %v", requested)
+	}
+	if mentions := got.Mentions("acme"); !reflect.DeepEqual(mentions, []string{"@alice", "@acme/core"}) {
+		t.Errorf("Mentions() = %v", mentions)
+	}
+}
+
+func TestAssignReviewers_PoolFallback(t *testing.T) {
+	var requested map[string][]string
+	server := reviewerServer(t, "", []string{"main.go"}, &requested)
+	defer server.Close()
+
+	cfg := &ReviewerAssignmentConfig{Enabled: true, Pool: []string{"@pilot-bot", "bob", "acme/qa"}, Count: 2}
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	rotation := NewReviewerRotation()
+
+	got, err := AssignReviewers(context.Background(), client, cfg, rotation, "acme", "app", 7)
+	if err != nil {
+		t.Fatalf("AssignReviewers() error = %v", err)
+	}
+	want := &ReviewerAssignment{Reviewers: []string{"bob"}, TeamReviewers: []string{"qa"}, Source: ReviewersFromPool}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignReviewers() = %+v, want %+v", got, want)
+	}
+
+	// The PR author is never requested
+	cfg.Pool = []string{"pilot-bot"}
+	if got, err := AssignReviewers(context.Background(), client, cfg, rotation, "acme", "app", 7); err != nil || got != nil {
+		t.Errorf("AssignReviewers() with only the author in the pool = %+v, %v", got, err)
+	}
+}
+
+func TestAssignReviewers_Disabled(t *testing.T) {
+	got, err := AssignReviewers(context.Background(), nil, &ReviewerAssignmentConfig{}, NewReviewerRotation(), "acme", "app", 7)
+	if err != nil || got != nil {
+		t.Errorf("AssignReviewers() disabled = %+v, %v", got, err)
+	}
+}
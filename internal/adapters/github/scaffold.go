@@ -0,0 +1,604 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// scaffoldBranch holds the scaffold in the new repository until its PR merges
+const scaffoldBranch = "pilot/scaffold"
+
+// scaffoldReserved are the request parameters Pilot sets itself. All but
+// private are also available to templates as variables.
+var scaffoldReserved = map[string]bool{
+	"name":        true,
+	"description": true,
+	"owner":       true,
+	"repo":        true,
+	"private":     true,
+}
+
+// scaffoldPlaceholder matches {{ name }} and cookiecutter's
+// {{ cookiecutter.name }}
+var scaffoldPlaceholder = regexp.MustCompile(`\{\{\s*(?:cookiecutter\.)?([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// repoNamePattern matches the repository names GitHub accepts
+var repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+
+// ScaffoldRequest is the new repository an issue asks for
+type ScaffoldRequest struct {
+	Name        string            // Repository name
+	Description string            // Repository description
+	Private     *bool             // Visibility, nil for the template's default
+	Variables   map[string]string // Template variables set by the request
+}
+
+// Scaffold is a validated new repository ready to be created
+type Scaffold struct {
+	Template    *ScaffoldTemplate
+	Ref         string            // Template ref the files were read at
+	Owner       string            // Account the repository is created in
+	Name        string            // Repository name
+	Description string            // Repository description
+	Private     bool              // Whether the repository is private
+	Variables   map[string]string // Every variable the files were rendered with
+	Files       map[string][]byte // Rendered files by path
+	Skipped     []string          // Template files that could not be copied, e.g. binaries
+}
+
+// FullName returns "owner/name" of the new repository
+func (s *Scaffold) FullName() string {
+	return s.Owner + "/" + s.Name
+}
+
+// ScaffoldWatcher turns issues carrying a template's label, filed by repo
+// admins, into new repositories. A request is validated, approved through the
+// approval manager, and the repository is created with the rendered template
+// proposed as its first PR.
+type ScaffoldWatcher struct {
+	client    *Client
+	owner     string
+	repo      string
+	target    string // Account new repositories are created in
+	interval  time.Duration
+	templates []*ScaffoldTemplate
+	approvals *approval.Manager
+	logger    *slog.Logger
+
+	mu     sync.Mutex
+	active map[int]bool // Issues being handled
+}
+
+// ScaffoldOption configures a ScaffoldWatcher
+type ScaffoldOption func(*ScaffoldWatcher)
+
+// WithScaffoldApprovals sets the approval manager asked before a repository
+// is created. Without one, repositories are created right away.
+func WithScaffoldApprovals(m *approval.Manager) ScaffoldOption {
+	return func(w *ScaffoldWatcher) {
+		w.approvals = m
+	}
+}
+
+// NewScaffoldWatcher creates a watcher for new repository requests filed in
+// repo ("owner/repo" format).
+func NewScaffoldWatcher(client *Client, repo string, config *ScaffoldConfig, opts ...ScaffoldOption) (*ScaffoldWatcher, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo format, expected owner/repo: %s", repo)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+	target := config.Owner
+	if target == "" {
+		target = parts[0]
+	}
+
+	w := &ScaffoldWatcher{
+		client:   client,
+		owner:    parts[0],
+		repo:     parts[1],
+		target:   target,
+		interval: interval,
+		logger:   logging.WithComponent("github-scaffold"),
+		active:   make(map[int]bool),
+	}
+	for i := range config.Templates {
+		w.templates = append(w.templates, &config.Templates[i])
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w, nil
+}
+
+// Labels returns the issue labels the watcher handles, one per template
+func (w *ScaffoldWatcher) Labels() []string {
+	labels := make([]string, len(w.templates))
+	for i, t := range w.templates {
+		labels[i] = t.IssueLabel()
+	}
+	return labels
+}
+
+// Start polls for new repository requests until ctx is cancelled
+func (w *ScaffoldWatcher) Start(ctx context.Context) {
+	w.logger.Info("Watching for new repository requests",
+		slog.String("repo", w.owner+"/"+w.repo),
+		slog.String("labels", strings.Join(w.Labels(), ", ")),
+		slog.Duration("interval", w.interval),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Check(ctx); err != nil {
+			w.logger.Warn("New repository request check failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check starts handling new requests of every template. Requests are handled
+// in the background because approval may take hours.
+func (w *ScaffoldWatcher) Check(ctx context.Context) error {
+	issues, err := w.client.ListIssues(ctx, w.owner, w.repo, &ListIssuesOptions{State: StateOpen})
+	if err != nil {
+		return fmt.Errorf("failed to list new repository requests: %w", err)
+	}
+
+	for _, issue := range issues {
+		if issue.PullRequest != nil || HasLabel(issue, LabelDone) || HasLabel(issue, LabelFailed) {
+			continue
+		}
+		tmpl := w.templateFor(issue)
+		if tmpl == nil {
+			continue
+		}
+
+		w.mu.Lock()
+		busy := w.active[issue.Number]
+		if !busy {
+			w.active[issue.Number] = true
+		}
+		w.mu.Unlock()
+		if busy {
+			continue
+		}
+
+		go func(issue *Issue) {
+			defer w.release(issue.Number)
+			w.Handle(ctx, tmpl, issue)
+		}(issue)
+	}
+	return nil
+}
+
+// templateFor returns the template whose label the issue carries, or nil
+func (w *ScaffoldWatcher) templateFor(issue *Issue) *ScaffoldTemplate {
+	for _, tmpl := range w.templates {
+		if HasLabel(issue, tmpl.IssueLabel()) {
+			return tmpl
+		}
+	}
+	return nil
+}
+
+func (w *ScaffoldWatcher) release(number int) {
+	w.mu.Lock()
+	delete(w.active, number)
+	w.mu.Unlock()
+}
+
+// Handle validates a new repository request, asks for approval, creates the
+// repository and opens the PR adding the template. The outcome is reported
+// on the issue.
+func (w *ScaffoldWatcher) Handle(ctx context.Context, tmpl *ScaffoldTemplate, issue *Issue) {
+	log := w.logger.With(slog.Int("issue", issue.Number), slog.String("template", tmpl.Name))
+	if err := w.client.AddLabels(ctx, w.owner, w.repo, issue.Number, []string{LabelInProgress}); err != nil {
+		log.Warn("Failed to add in-progress label", slog.Any("error", err))
+	}
+
+	scaffold, err := w.Prepare(ctx, tmpl, issue)
+	if err != nil {
+		log.Info("New repository request rejected", slog.Any("error", err))
+		w.finish(ctx, issue.Number, LabelFailed, fmt.Sprintf("❌ **Repository not created**\n\n%s", err))
+		return
+	}
+
+	approvedBy, err := w.approve(ctx, issue, scaffold)
+	if err != nil {
+		log.Info("New repository request not approved", slog.Any("error", err))
+		w.finish(ctx, issue.Number, LabelFailed, fmt.Sprintf("❌ **Repository not created**\n\n%s", err))
+		return
+	}
+
+	repository, pr, err := w.Create(ctx, issue, scaffold, approvedBy)
+	if err != nil {
+		log.Warn("Failed to create repository", slog.String("repository", scaffold.FullName()), slog.Any("error", err))
+		msg := fmt.Sprintf("❌ **Repository not created**\n\n%s", err)
+		if repository != nil {
+			msg = fmt.Sprintf("⚠️ **Created %s, but the scaffold was not proposed**\n\n%s\n\nAdd the template files by hand or delete the repository and label this issue again.", repository.HTMLURL, err)
+		}
+		w.finish(ctx, issue.Number, LabelFailed, msg)
+		return
+	}
+
+	log.Info("Created repository", slog.String("repository", repository.FullName), slog.Int("pr", pr.Number))
+	w.finish(ctx, issue.Number, LabelDone, fmt.Sprintf("📦 Created %s from the `%s` template. Review and merge %s to add the scaffold.",
+		repository.HTMLURL, tmpl.Name, pr.HTMLURL))
+	_ = w.client.UpdateIssueState(ctx, w.owner, w.repo, issue.Number, StateClosed)
+}
+
+// Prepare checks that the issue author is a repo admin, that the request is
+// complete and the repository does not exist yet, then renders the
+// template's files.
+func (w *ScaffoldWatcher) Prepare(ctx context.Context, tmpl *ScaffoldTemplate, issue *Issue) (*Scaffold, error) {
+	login := issue.User.Login
+	permission, err := w.client.GetCollaboratorPermission(ctx, w.owner, w.repo, login)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify the permissions of @%s: %w", login, err)
+	}
+	if permission != "admin" {
+		return nil, fmt.Errorf("only repository admins can create repositories, @%s has %s access", login, permission)
+	}
+
+	req, err := ParseScaffoldRequest(issue.Body)
+	if err != nil {
+		return nil, err
+	}
+	s := &Scaffold{
+		Template:    tmpl,
+		Owner:       w.target,
+		Name:        req.Name,
+		Description: req.Description,
+		Private:     tmpl.Private,
+	}
+	if req.Private != nil {
+		s.Private = *req.Private
+	}
+	if s.Variables, err = scaffoldVariables(tmpl, req); err != nil {
+		return nil, err
+	}
+	s.Variables["owner"] = s.Owner
+	s.Variables["repo"] = s.FullName()
+
+	if _, err := w.client.GetRepository(ctx, s.Owner, s.Name); err == nil {
+		return nil, fmt.Errorf("repository %s already exists", s.FullName())
+	} else if !isNotFoundError(err) {
+		return nil, fmt.Errorf("failed to check for %s: %w", s.FullName(), err)
+	}
+
+	if err := w.render(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// render reads the template's files and fills in their placeholders
+func (w *ScaffoldWatcher) render(ctx context.Context, s *Scaffold) error {
+	tmpl := s.Template
+	parts := strings.SplitN(tmpl.Repo, "/", 2)
+	owner, repo := parts[0], parts[1]
+
+	s.Ref = tmpl.Ref
+	if s.Ref == "" {
+		repository, err := w.client.GetRepository(ctx, owner, repo)
+		if err != nil {
+			return fmt.Errorf("failed to get template repository %s: %w", tmpl.Repo, err)
+		}
+		s.Ref = repository.DefaultBranch
+	}
+	entries, err := w.client.GetTree(ctx, owner, repo, s.Ref)
+	if err != nil {
+		return fmt.Errorf("failed to list template %s@%s: %w", tmpl.Repo, s.Ref, err)
+	}
+
+	root := strings.Trim(tmpl.Path, "/")
+	s.Files = make(map[string][]byte)
+	for _, entry := range entries {
+		rel := entry.Path
+		if root != "" {
+			var ok bool
+			if rel, ok = strings.CutPrefix(entry.Path, root+"/"); !ok {
+				continue
+			}
+		}
+		if entry.Type != "blob" {
+			if entry.Type == "commit" {
+				s.Skipped = append(s.Skipped, rel+" (submodule)")
+			}
+			continue
+		}
+		if entry.Mode == "120000" {
+			s.Skipped = append(s.Skipped, rel+" (symlink)")
+			continue
+		}
+
+		target := path.Clean(renderScaffold(rel, s.Variables))
+		if target == "." || strings.HasPrefix(target, "../") || strings.HasPrefix(target, "/") || strings.HasPrefix(target, ".git/") {
+			return fmt.Errorf("template file %s renders to invalid path %q", rel, target)
+		}
+
+		content, err := w.client.GetBlob(ctx, owner, repo, entry.SHA)
+		if err != nil {
+			return fmt.Errorf("failed to read template file %s: %w", entry.Path, err)
+		}
+		if !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0 {
+			s.Skipped = append(s.Skipped, rel+" (binary)")
+			continue
+		}
+		s.Files[target] = []byte(renderScaffold(string(content), s.Variables))
+	}
+	if len(s.Files) == 0 {
+		return fmt.Errorf("template %s@%s has no files to copy", tmpl.Repo, s.Ref)
+	}
+	return nil
+}
+
+// approve asks the approval manager for the go-ahead and returns who gave it
+func (w *ScaffoldWatcher) approve(ctx context.Context, issue *Issue, s *Scaffold) (string, error) {
+	if w.approvals == nil {
+		return "", nil
+	}
+	visibility := "public"
+	if s.Private {
+		visibility = "private"
+	}
+	resp, err := w.approvals.RequestApproval(ctx, &approval.Request{
+		ID:     fmt.Sprintf("new-repo-%s-%s-%d", w.owner, w.repo, issue.Number),
+		TaskID: fmt.Sprintf("GH-%d", issue.Number),
+		Stage:  approval.StageNewRepo,
+		Title:  issue.Title,
+		Description: fmt.Sprintf("@%s requests a new %s repository %s from the %s template (%d files)",
+			issue.User.Login, visibility, s.FullName(), s.Template.Name, len(s.Files)),
+		Metadata: map[string]interface{}{
+			"issue_url":    issue.HTMLURL,
+			"requested_by": issue.User.Login,
+			"repository":   s.FullName(),
+			"template":     s.Template.Name,
+		},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("approval request failed: %w", err)
+	}
+	if resp.Decision != approval.DecisionApproved {
+		reason := fmt.Sprintf("The request was %s", resp.Decision)
+		if resp.ApprovedBy != "" && resp.ApprovedBy != "system" {
+			reason += " by " + resp.ApprovedBy
+		}
+		if resp.Comment != "" {
+			reason += ": " + resp.Comment
+		}
+		return "", errors.New(reason)
+	}
+	if resp.ApprovedBy == "system" {
+		return "", nil
+	}
+	return resp.ApprovedBy, nil
+}
+
+// Create creates the repository and opens a PR adding the rendered template
+// to its default branch. The repository is returned even when the PR fails.
+func (w *ScaffoldWatcher) Create(ctx context.Context, issue *Issue, s *Scaffold, approvedBy string) (*Repository, *PullRequest, error) {
+	repository, err := w.client.CreateRepository(ctx, s.Owner, &RepositoryInput{
+		Name:        s.Name,
+		Description: s.Description,
+		Private:     s.Private,
+		AutoInit:    true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s: %w", s.FullName(), err)
+	}
+
+	base := repository.DefaultBranch
+	if base == "" {
+		base = "main"
+	}
+	head, err := w.client.GetBranch(ctx, s.Owner, s.Name, base)
+	if err != nil {
+		return repository, nil, fmt.Errorf("failed to get branch %s: %w", base, err)
+	}
+	if err := w.client.UpdateRef(ctx, s.Owner, s.Name, scaffoldBranch, head.Commit.SHA); err != nil {
+		return repository, nil, fmt.Errorf("failed to create branch %s: %w", scaffoldBranch, err)
+	}
+	message := fmt.Sprintf("chore: scaffold from the %s template", s.Template.Name)
+	if _, err := w.client.CommitFiles(ctx, s.Owner, s.Name, scaffoldBranch, head.Commit.SHA, message, s.Files); err != nil {
+		return repository, nil, err
+	}
+
+	pr, err := w.client.CreatePullRequest(ctx, s.Owner, s.Name, &PullRequestInput{
+		Title: message,
+		Body:  scaffoldPRBody(w.owner+"/"+w.repo, issue, s, approvedBy),
+		Head:  scaffoldBranch,
+		Base:  base,
+	})
+	if err != nil {
+		return repository, nil, fmt.Errorf("failed to open the scaffold PR: %w", err)
+	}
+	return repository, pr, nil
+}
+
+// finish reports the outcome on the issue and swaps the in-progress label for label
+func (w *ScaffoldWatcher) finish(ctx context.Context, number int, label, comment string) {
+	if _, err := w.client.AddComment(ctx, w.owner, w.repo, number, comment); err != nil {
+		w.logger.Warn("Failed to comment on new repository request", slog.Int("issue", number), slog.Any("error", err))
+	}
+	_ = w.client.RemoveLabel(ctx, w.owner, w.repo, number, LabelInProgress)
+	if err := w.client.AddLabels(ctx, w.owner, w.repo, number, []string{label}); err != nil {
+		w.logger.Warn("Failed to label new repository request", slog.Int("issue", number), slog.Any("error", err))
+	}
+}
+
+// scaffoldPRBody describes where the scaffold came from
+func scaffoldPRBody(sourceRepo string, issue *Issue, s *Scaffold, approvedBy string) string {
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("Requested by @%s in %s#%d", issue.User.Login, sourceRepo, issue.Number))
+	if approvedBy != "" {
+		body.WriteString(", approved by " + approvedBy)
+	}
+	body.WriteString(fmt.Sprintf(".\n\nScaffolded from `%s@%s` (template `%s`).\n\n## Variables\n\n", s.Template.Repo, s.Ref, s.Template.Name))
+
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		body.WriteString(fmt.Sprintf("- `%s`: `%s`\n", name, s.Variables[name]))
+	}
+	if len(s.Skipped) > 0 {
+		body.WriteString("\n## Not copied\n\nThese template files could not be copied and need to be added by hand:\n\n")
+		for _, file := range s.Skipped {
+			body.WriteString("- " + file + "\n")
+		}
+	}
+	return body.String()
+}
+
+// ParseScaffoldRequest reads a new repository request from the first fenced
+// YAML block of an issue body: the repository name, optional description and
+// private flag, and any template variables.
+func ParseScaffoldRequest(body string) (*ScaffoldRequest, error) {
+	m := configRequestBlock.FindStringSubmatch(body)
+	if m == nil {
+		return nil, fmt.Errorf("no parameters found: describe the repository in a ```yaml block, e.g.\n\n```yaml\nname: billing-api\ndescription: Billing service\n```")
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(m[1]), &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML in the parameters block: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("the parameters block must map parameter names to values")
+	}
+
+	req := &ScaffoldRequest{Variables: make(map[string]string)}
+	params := doc.Content[0].Content
+	for i := 0; i+1 < len(params); i += 2 {
+		key, value := params[i].Value, params[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("parameter %s must be a single value", key)
+		}
+		switch key {
+		case "name":
+			req.Name = value.Value
+		case "description":
+			req.Description = value.Value
+		case "private":
+			var private bool
+			if err := value.Decode(&private); err != nil {
+				return nil, fmt.Errorf("parameter private must be true or false, got %q", value.Value)
+			}
+			req.Private = &private
+		case "owner", "repo":
+			return nil, fmt.Errorf("parameter %s is set by Pilot", key)
+		default:
+			req.Variables[key] = value.Value
+		}
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("parameter name is required")
+	}
+	if !repoNamePattern.MatchString(req.Name) || req.Name == "." || req.Name == ".." {
+		return nil, fmt.Errorf("invalid repository name %q: use letters, digits, '.', '-' and '_'", req.Name)
+	}
+	return req, nil
+}
+
+// scaffoldVariables merges the request's variables over the template's
+// defaults. Variables the template does not declare, and required ones left
+// unset, are rejected.
+func scaffoldVariables(tmpl *ScaffoldTemplate, req *ScaffoldRequest) (map[string]string, error) {
+	var unknown, missing []string
+	for name := range req.Variables {
+		if _, ok := tmpl.Variables[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("the %s template has no variables %s (variables: %s)",
+			tmpl.Name, strings.Join(unknown, ", "), strings.Join(templateVariableNames(tmpl), ", "))
+	}
+
+	vars := map[string]string{
+		"name":        req.Name,
+		"description": req.Description,
+	}
+	for name, value := range tmpl.Variables {
+		if v, ok := req.Variables[name]; ok {
+			value = v
+		}
+		if value == "" {
+			missing = append(missing, name)
+		}
+		vars[name] = value
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("the %s template requires %s", tmpl.Name, strings.Join(missing, ", "))
+	}
+	return vars, nil
+}
+
+func templateVariableNames(tmpl *ScaffoldTemplate) []string {
+	names := []string{"name", "description", "private"}
+	var declared []string
+	for name := range tmpl.Variables {
+		declared = append(declared, name)
+	}
+	sort.Strings(declared)
+	return append(names, declared...)
+}
+
+// renderScaffold fills in the {{ variable }} placeholders of s. Unknown
+// names and ${{ }} expressions, as used by GitHub Actions, are left as they are.
+func renderScaffold(s string, vars map[string]string) string {
+	matches := scaffoldPlaceholder.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var out strings.Builder
+	last := 0
+	for _, m := range matches {
+		value, ok := vars[s[m[2]:m[3]]]
+		if !ok || (m[0] > 0 && s[m[0]-1] == '$') {
+			continue
+		}
+		out.WriteString(s[last:m[0]])
+		out.WriteString(value)
+		last = m[1]
+	}
+	out.WriteString(s[last:])
+	return out.String()
+}
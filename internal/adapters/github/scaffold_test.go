@@ -0,0 +1,352 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestParseScaffoldRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantName    string
+		wantPrivate string
+		wantVars    map[string]string
+		wantErr     string
+	}{
+		{
+			name:        "full request",
+			body:        "New billing service.\n\n```yaml\nname: billing-api\ndescription: Billing API\nprivate: true\nport: 8080\n```\n",
+			wantName:    "billing-api",
+			wantPrivate: "true",
+			wantVars:    map[string]string{"port": "8080"},
+		},
+		{name: "name only", body: "```\nname: web\n```", wantName: "web", wantPrivate: "unset", wantVars: map[string]string{}},
+		{name: "no block", body: "please create billing-api", wantErr: "no parameters found"},
+		{name: "missing name", body: "```yaml\ndescription: x\n```", wantErr: "name is required"},
+		{name: "invalid name", body: "```yaml\nname: billing api\n```", wantErr: "invalid repository name"},
+		{name: "dot name", body: "```yaml\nname: ..\n```", wantErr: "invalid repository name"},
+		{name: "reserved", body: "```yaml\nname: web\nowner: other\n```", wantErr: "owner is set by Pilot"},
+		{name: "bad private", body: "```yaml\nname: web\nprivate: maybe\n```", wantErr: "private must be true or false"},
+		{name: "nested value", body: "```yaml\nname: web\nports: [1, 2]\n```", wantErr: "ports must be a single value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ParseScaffoldRequest(tt.body)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseScaffoldRequest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseScaffoldRequest() error = %v", err)
+			}
+			private := "unset"
+			if req.Private != nil {
+				private = map[bool]string{true: "true", false: "false"}[*req.Private]
+			}
+			if req.Name != tt.wantName || private != tt.wantPrivate || len(req.Variables) != len(tt.wantVars) {
+				t.Errorf("request = %+v (private %s), want %s private %s vars %v", req, private, tt.wantName, tt.wantPrivate, tt.wantVars)
+			}
+			for k, v := range tt.wantVars {
+				if req.Variables[k] != v {
+					t.Errorf("variable %s = %q, want %q", k, req.Variables[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestScaffoldVariables(t *testing.T) {
+	tmpl := &ScaffoldTemplate{Name: "service", Variables: map[string]string{"port": "8080", "team": ""}}
+
+	vars, err := scaffoldVariables(tmpl, &ScaffoldRequest{Name: "billing-api", Variables: map[string]string{"team": "billing"}})
+	if err != nil {
+		t.Fatalf("scaffoldVariables() error = %v", err)
+	}
+	if vars["name"] != "billing-api" || vars["port"] != "8080" || vars["team"] != "billing" {
+		t.Errorf("vars = %v", vars)
+	}
+
+	if _, err := scaffoldVariables(tmpl, &ScaffoldRequest{Name: "x", Variables: map[string]string{}}); err == nil || !strings.Contains(err.Error(), "requires team") {
+		t.Errorf("missing required variable error = %v", err)
+	}
+	_, err = scaffoldVariables(tmpl, &ScaffoldRequest{Name: "x", Variables: map[string]string{"team": "a", "lang": "go"}})
+	if err == nil || !strings.Contains(err.Error(), "no variables lang (variables: name, description, private, port, team)") {
+		t.Errorf("unknown variable error = %v", err)
+	}
+}
+
+func TestRenderScaffold(t *testing.T) {
+	vars := map[string]string{"name": "billing-api", "port": "8080"}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"module github.com/acme/{{ name }}", "module github.com/acme/billing-api"},
+		{"{{name}}:{{ cookiecutter.port }}", "billing-api:8080"},
+		{"ref: ${{ github.ref }} name: ${{ name }}", "ref: ${{ github.ref }} name: ${{ name }}"},
+		{"{{ unknown }} {{ .Values.name }} {{ name }}", "{{ unknown }} {{ .Values.name }} billing-api"},
+		{"no placeholders", "no placeholders"},
+	}
+	for _, tt := range tests {
+		if got := renderScaffold(tt.in, vars); got != tt.want {
+			t.Errorf("renderScaffold(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScaffoldConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ScaffoldConfig
+		wantErr string
+	}{
+		{"valid", ScaffoldConfig{Templates: []ScaffoldTemplate{{Name: "service", Repo: "acme/service-template"}}}, ""},
+		{"no templates", ScaffoldConfig{}, "at least one template"},
+		{"no name", ScaffoldConfig{Templates: []ScaffoldTemplate{{Repo: "acme/t"}}}, "name is required"},
+		{"bad repo", ScaffoldConfig{Templates: []ScaffoldTemplate{{Name: "service", Repo: "service-template"}}}, "owner/repo format"},
+		{"reserved variable", ScaffoldConfig{Templates: []ScaffoldTemplate{{Name: "service", Repo: "acme/t", Variables: map[string]string{"repo": ""}}}}, `"repo" is set by Pilot`},
+		{"shared label", ScaffoldConfig{Templates: []ScaffoldTemplate{
+			{Name: "service", Repo: "acme/t"},
+			{Name: "go", Repo: "acme/go", Label: "Pilot:New-Service"},
+		}}, `also used by template "service"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// scaffoldServer fakes the GitHub API for a request filed in owner/repo by
+// alice, creating acme/billing-api from the acme/service-template repository
+type scaffoldServer struct {
+	*httptest.Server
+	permission string
+	exists     bool
+
+	mu      sync.Mutex
+	created map[string]interface{}
+	tree    []map[string]string
+	pr      map[string]interface{}
+}
+
+func newScaffoldServer(t *testing.T, permission string, exists bool) *scaffoldServer {
+	t.Helper()
+	files := map[string]string{
+		"blob-readme": "# {{ name }}\n\n{{ description }}\n",
+		"blob-main":   "package main\n\nconst port = {{ cookiecutter.port }}\n",
+		"blob-ci":     "on: push\nref: ${{ github.ref }}\n",
+		"blob-logo":   "\x89PNG\x00\x01",
+	}
+	s := &scaffoldServer{permission: permission, exists: exists}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.URL.Path == "/repos/owner/repo/collaborators/alice/permission":
+			_ = json.NewEncoder(w).Encode(map[string]string{"permission": s.permission})
+		case r.URL.Path == "/repos/acme/billing-api" && r.Method == http.MethodGet:
+			if !s.exists {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(Repository{FullName: "acme/billing-api"})
+		case r.URL.Path == "/repos/acme/service-template":
+			_ = json.NewEncoder(w).Encode(Repository{FullName: "acme/service-template", DefaultBranch: "main"})
+		case r.URL.Path == "/repos/acme/service-template/git/trees/main":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"tree": []map[string]string{
+				{"path": "README.md", "type": "blob", "mode": "100644", "sha": "blob-readme"},
+				{"path": "cmd", "type": "tree", "mode": "040000", "sha": "tree-cmd"},
+				{"path": "cmd/{{ name }}/main.go", "type": "blob", "mode": "100644", "sha": "blob-main"},
+				{"path": ".github/workflows/ci.yml", "type": "blob", "mode": "100644", "sha": "blob-ci"},
+				{"path": "logo.png", "type": "blob", "mode": "100644", "sha": "blob-logo"},
+				{"path": "vendor/lib", "type": "commit", "mode": "160000", "sha": "c1"},
+			}})
+		case strings.HasPrefix(r.URL.Path, "/repos/acme/service-template/git/blobs/"):
+			content := files[strings.TrimPrefix(r.URL.Path, "/repos/acme/service-template/git/blobs/")]
+			_ = json.NewEncoder(w).Encode(map[string]string{"encoding": "base64", "content": base64.StdEncoding.EncodeToString([]byte(content))})
+		case r.URL.Path == "/users/acme":
+			_, _ = w.Write([]byte(`{"login":"acme","type":"Organization"}`))
+		case r.URL.Path == "/orgs/acme/repos" && r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&s.created)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(Repository{FullName: "acme/billing-api", HTMLURL: "https://github.com/acme/billing-api", DefaultBranch: "main"})
+		case r.URL.Path == "/repos/acme/billing-api/branches/main":
+			_, _ = w.Write([]byte(`{"name":"main","commit":{"sha":"init1"}}`))
+		case r.URL.Path == "/repos/acme/billing-api/git/refs/heads/pilot/scaffold":
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/repos/acme/billing-api/git/commits/init1":
+			_, _ = w.Write([]byte(`{"sha":"init1","tree":{"sha":"tree1"}}`))
+		case r.URL.Path == "/repos/acme/billing-api/git/trees" && r.Method == http.MethodPost:
+			var body struct {
+				Tree []map[string]string `json:"tree"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			s.tree = body.Tree
+			_, _ = w.Write([]byte(`{"sha":"tree2"}`))
+		case r.URL.Path == "/repos/acme/billing-api/git/commits" && r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"sha":"commit2"}`))
+		case r.URL.Path == "/repos/acme/billing-api/pulls" && r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&s.pr)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number":1,"html_url":"https://github.com/acme/billing-api/pull/1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func newTestScaffoldWatcher(t *testing.T, serverURL string) (*ScaffoldWatcher, *ScaffoldTemplate) {
+	t.Helper()
+	client := NewClientWithBaseURL(testutil.FakeGitHubToken, serverURL)
+	watcher, err := NewScaffoldWatcher(client, "owner/repo", &ScaffoldConfig{
+		Enabled: true,
+		Owner:   "acme",
+		Templates: []ScaffoldTemplate{{
+			Name:      "service",
+			Repo:      "acme/service-template",
+			Private:   true,
+			Variables: map[string]string{"port": "8080"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NewScaffoldWatcher() error = %v", err)
+	}
+	return watcher, watcher.templates[0]
+}
+
+func TestScaffoldWatcher_PrepareAndCreate(t *testing.T) {
+	server := newScaffoldServer(t, "admin", false)
+	defer server.Close()
+	watcher, tmpl := newTestScaffoldWatcher(t, server.URL)
+
+	issue := &Issue{Number: 12, Title: "New billing service", User: User{Login: "alice"},
+		Body: "```yaml\nname: billing-api\ndescription: Billing API\nport: 9090\n```"}
+	scaffold, err := watcher.Prepare(context.Background(), tmpl, issue)
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if scaffold.FullName() != "acme/billing-api" || !scaffold.Private || scaffold.Ref != "main" {
+		t.Errorf("scaffold = %s private=%v ref=%s", scaffold.FullName(), scaffold.Private, scaffold.Ref)
+	}
+	want := map[string]string{
+		"README.md":                "# billing-api\n\nBilling API\n",
+		"cmd/billing-api/main.go":  "package main\n\nconst port = 9090\n",
+		".github/workflows/ci.yml": "on: push\nref: ${{ github.ref }}\n",
+	}
+	if len(scaffold.Files) != len(want) {
+		t.Errorf("files = %v", scaffold.Files)
+	}
+	for file, content := range want {
+		if got := string(scaffold.Files[file]); got != content {
+			t.Errorf("%s = %q, want %q", file, got, content)
+		}
+	}
+	if strings.Join(scaffold.Skipped, ",") != "logo.png (binary),vendor/lib (submodule)" {
+		t.Errorf("skipped = %v", scaffold.Skipped)
+	}
+
+	repository, pr, err := watcher.Create(context.Background(), issue, scaffold, "bob")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if repository.FullName != "acme/billing-api" || pr.Number != 1 {
+		t.Errorf("Create() = %s, PR #%d", repository.FullName, pr.Number)
+	}
+	if server.created["name"] != "billing-api" || server.created["private"] != true || server.created["auto_init"] != true {
+		t.Errorf("created repository = %v", server.created)
+	}
+	if len(server.tree) != 3 {
+		t.Errorf("committed tree = %v", server.tree)
+	}
+	body, _ := server.pr["body"].(string)
+	for _, s := range []string{"Requested by @alice in owner/repo#12, approved by bob", "`port`: `9090`", "- logo.png (binary)"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("PR body missing %q:\n%s", s, body)
+		}
+	}
+	if server.pr["head"] != "pilot/scaffold" || server.pr["base"] != "main" {
+		t.Errorf("PR %v -> %v", server.pr["head"], server.pr["base"])
+	}
+}
+
+func TestScaffoldWatcher_PrepareRejects(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		exists     bool
+		body       string
+		wantErr    string
+	}{
+		{"non-admin", "write", false, "```yaml\nname: billing-api\n```", "only repository admins"},
+		{"existing repository", "admin", true, "```yaml\nname: billing-api\n```", "acme/billing-api already exists"},
+		{"unknown variable", "admin", false, "```yaml\nname: billing-api\nlang: go\n```", "no variables lang"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newScaffoldServer(t, tt.permission, tt.exists)
+			defer server.Close()
+			watcher, tmpl := newTestScaffoldWatcher(t, server.URL)
+
+			issue := &Issue{Number: 12, Body: tt.body, User: User{Login: "alice"}}
+			if _, err := watcher.Prepare(context.Background(), tmpl, issue); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Prepare() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScaffoldWatcher_TemplateFor(t *testing.T) {
+	client := NewClient(testutil.FakeGitHubToken)
+	if _, err := NewScaffoldWatcher(client, "ownerrepo", &ScaffoldConfig{}); err == nil {
+		t.Error("expected error for invalid repo format")
+	}
+
+	watcher, err := NewScaffoldWatcher(client, "owner/repo", &ScaffoldConfig{Templates: []ScaffoldTemplate{
+		{Name: "service", Repo: "acme/service-template"},
+		{Name: "web", Repo: "acme/web-template", Label: "new-frontend"},
+	}})
+	if err != nil {
+		t.Fatalf("NewScaffoldWatcher() error = %v", err)
+	}
+	if got := strings.Join(watcher.Labels(), ","); got != "pilot:new-service,new-frontend" {
+		t.Errorf("Labels() = %q", got)
+	}
+	if watcher.target != "owner" {
+		t.Errorf("target = %q, want the repo owner", watcher.target)
+	}
+
+	issue := &Issue{Labels: []Label{{Name: "bug"}, {Name: "New-Frontend"}}}
+	if tmpl := watcher.templateFor(issue); tmpl == nil || tmpl.Name != "web" {
+		t.Errorf("templateFor() = %v, want web", tmpl)
+	}
+	if tmpl := watcher.templateFor(&Issue{Labels: []Label{{Name: "pilot"}}}); tmpl != nil {
+		t.Errorf("templateFor() = %v, want nil", tmpl)
+	}
+}
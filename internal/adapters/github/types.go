@@ -1,18 +1,79 @@
 package github
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Config holds GitHub adapter configuration
 type Config struct {
 	Enabled           bool                     `yaml:"enabled"`
 	Token             string                   `yaml:"token"`          // Personal Access Token or GitHub App token
 	WebhookSecret     string                   `yaml:"webhook_secret"` // For HMAC signature verification
+	Mode              string                   `yaml:"mode"`           // Issue and PR ingestion: poll (default), webhook or hybrid
+	WebhookQuiet      time.Duration            `yaml:"webhook_quiet"`  // Webhook mode polls again after this long without a delivery (default 10m)
 	PilotLabel        string                   `yaml:"pilot_label"`
 	Repo              string                   `yaml:"repo"`                // Default repo in "owner/repo" format
 	ProjectPath       string                   `yaml:"project_path"`        // Required project path - must match repo (GH-386)
 	Polling           *PollingConfig           `yaml:"polling"`             // Polling configuration
 	StaleLabelCleanup *StaleLabelCleanupConfig `yaml:"stale_label_cleanup"` // Auto-cleanup stale labels
 	ProjectBoard      *ProjectBoardConfig      `yaml:"project_board"`       // GitHub Projects V2 board sync
+	Comments          *CommentsConfig          `yaml:"comments"`            // Status comment behavior
+	ExecutionCheck    *ExecutionCheckConfig    `yaml:"execution_check"`     // pilot/execution check run on PRs
+	ConfigRequests    *ConfigRequestsConfig    `yaml:"config_requests"`     // .pilot.yaml changes requested by issue
+	Commands          *CommentCommandsConfig   `yaml:"commands"`            // /pilot commands in issue comments
+	Scaffold          *ScaffoldConfig          `yaml:"scaffold"`            // New repositories requested by issue
+
+	ReviewerAssignment *ReviewerAssignmentConfig `yaml:"reviewer_assignment"` // Review requests on the PRs Pilot opens
+}
+
+// Ingestion modes for Config.Mode.
+const (
+	// ModePoll picks up issues on the polling interval.
+	ModePoll = "poll"
+	// ModeWebhook reacts to webhook deliveries and polls only while they are quiet.
+	ModeWebhook = "webhook"
+	// ModeHybrid reacts to webhook deliveries and keeps polling.
+	ModeHybrid = "hybrid"
+)
+
+// DefaultWebhookQuiet is how long webhook mode waits for a delivery before
+// falling back to polling.
+const DefaultWebhookQuiet = 10 * time.Minute
+
+// IngestMode returns the ingestion mode, poll when unset
+func (c *Config) IngestMode() string {
+	if c == nil || c.Mode == "" {
+		return ModePoll
+	}
+	return strings.ToLower(c.Mode)
+}
+
+// Validate checks the ingestion mode. Webhook modes need polling enabled,
+// which they drive, and a secret to verify deliveries with.
+func (c *Config) Validate() error {
+	switch mode := c.IngestMode(); mode {
+	case ModePoll:
+	case ModeWebhook, ModeHybrid:
+		if c.WebhookSecret == "" {
+			return fmt.Errorf("mode %q requires webhook_secret", mode)
+		}
+		if c.Polling == nil || !c.Polling.Enabled {
+			return fmt.Errorf("mode %q requires polling.enabled", mode)
+		}
+	default:
+		return fmt.Errorf("invalid mode: %q (must be poll, webhook, or hybrid)", c.Mode)
+	}
+	if c.WebhookQuiet < 0 {
+		return fmt.Errorf("webhook_quiet must be >= 0, got %s", c.WebhookQuiet)
+	}
+	if c.Scaffold != nil && c.Scaffold.Enabled {
+		if err := c.Scaffold.Validate(); err != nil {
+			return fmt.Errorf("scaffold: %w", err)
+		}
+	}
+	return nil
 }
 
 // PollingConfig holds GitHub polling settings
@@ -20,6 +81,7 @@ type PollingConfig struct {
 	Enabled  bool          `yaml:"enabled"`
 	Interval time.Duration `yaml:"interval"` // Poll interval (default 30s)
 	Label    string        `yaml:"label"`    // Label to watch for (default: pilot)
+	Batch    bool          `yaml:"batch"`    // Poll all repos with one GraphQL query per interval
 }
 
 // StaleLabelCleanupConfig holds settings for auto-cleanup of stale pilot labels
@@ -30,6 +92,113 @@ type StaleLabelCleanupConfig struct {
 	FailedThreshold time.Duration `yaml:"failed_threshold"` // How long before pilot-failed is stale (default: 24h)
 }
 
+// ConfigRequestsConfig controls .pilot.yaml changes requested by filing an
+// issue with the config label. Only repository admins may request changes.
+type ConfigRequestsConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Label    string        `yaml:"label"`    // Issue label to watch for (default: pilot-config)
+	Interval time.Duration `yaml:"interval"` // Poll interval (default: 1m)
+	Settings []string      `yaml:"settings"` // Settings that may be changed (default: DefaultConfigRequestSettings)
+}
+
+// ScaffoldConfig controls new repositories requested by filing an issue with
+// a template's label. Only repository admins may request them.
+type ScaffoldConfig struct {
+	Enabled   bool               `yaml:"enabled"`
+	Owner     string             `yaml:"owner"`     // Organization or user new repositories are created in (default: owner of repo)
+	Interval  time.Duration      `yaml:"interval"`  // Poll interval (default: 1m)
+	Templates []ScaffoldTemplate `yaml:"templates"` // Templates that can be requested
+}
+
+// ScaffoldTemplate is a repository whose files are copied into new
+// repositories, with {{ variable }} placeholders in paths and contents
+// filled in from the request.
+type ScaffoldTemplate struct {
+	Name      string            `yaml:"name"`      // e.g. "service"
+	Label     string            `yaml:"label"`     // Issue label requesting it (default: pilot:new-<name>)
+	Repo      string            `yaml:"repo"`      // Template repository in "owner/repo" format
+	Ref       string            `yaml:"ref"`       // Branch, tag or SHA to copy (default: the template's default branch)
+	Path      string            `yaml:"path"`      // Directory of the template holding the files (default: the root)
+	Private   bool              `yaml:"private"`   // Create private repositories unless the request says otherwise
+	Variables map[string]string `yaml:"variables"` // Variables the request may set, with defaults; empty means required
+}
+
+// IssueLabel returns the label requesting the template
+func (t *ScaffoldTemplate) IssueLabel() string {
+	if t.Label != "" {
+		return t.Label
+	}
+	return "pilot:new-" + t.Name
+}
+
+// Validate checks that every template names a template repository and has a
+// label of its own
+func (c *ScaffoldConfig) Validate() error {
+	if len(c.Templates) == 0 {
+		return fmt.Errorf("templates: at least one template is required")
+	}
+	labels := make(map[string]string, len(c.Templates))
+	for i := range c.Templates {
+		t := &c.Templates[i]
+		if t.Name == "" {
+			return fmt.Errorf("templates[%d]: name is required", i)
+		}
+		if parts := strings.Split(t.Repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("templates[%d]: repo must be in owner/repo format, got %q", i, t.Repo)
+		}
+		for name := range t.Variables {
+			if scaffoldReserved[name] {
+				return fmt.Errorf("templates[%d]: variable %q is set by Pilot", i, name)
+			}
+		}
+		label := strings.ToLower(t.IssueLabel())
+		if other, ok := labels[label]; ok {
+			return fmt.Errorf("templates[%d]: label %q is also used by template %q", i, t.IssueLabel(), other)
+		}
+		labels[label] = t.Name
+	}
+	return nil
+}
+
+// CommentCommandsConfig controls /pilot commands posted as issue comments.
+// Only collaborators with write access may run them.
+type CommentCommandsConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"` // Poll interval for new comments (default: 30s)
+}
+
+// ReviewerAssignmentConfig requests reviews on the PRs Pilot opens from the
+// CODEOWNERS owners of the changed files, or from a reviewer pool in turn
+// when no file has owners.
+type ReviewerAssignmentConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Pool    []string `yaml:"pool"`  // Logins or org/team slugs
+	Count   int      `yaml:"count"` // Pool members requested per PR (default: 1)
+}
+
+// Comment modes for CommentsConfig.Mode.
+const (
+	// CommentModeSingle keeps one status comment per attempt and edits it in place.
+	CommentModeSingle = "single"
+	// CommentModeLegacy posts a new comment for every status update.
+	CommentModeLegacy = "legacy"
+)
+
+// CommentsConfig controls how Pilot reports status on issues.
+// When nil, single mode with the default interval is used.
+type CommentsConfig struct {
+	Mode        string        `yaml:"mode"`         // "single" (default) or "legacy"
+	MinInterval time.Duration `yaml:"min_interval"` // Minimum gap between progress edits (default: 30s)
+}
+
+// ExecutionCheckConfig controls the pilot/execution check run published on
+// PRs Pilot creates. Check runs require a GitHub App token with checks:write;
+// with a personal access token the API call fails and is logged.
+type ExecutionCheckConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	RecordingURL string `yaml:"recording_url"` // Link to the recording export; "{id}" is replaced by the recording ID
+}
+
 // ProjectBoardConfig configures GitHub Projects V2 board sync.
 // When nil or Enabled=false, board sync is skipped.
 type ProjectBoardConfig struct {
@@ -72,6 +241,13 @@ func DefaultConfig() *Config {
 			Threshold:       1 * time.Hour,
 			FailedThreshold: 24 * time.Hour,
 		},
+		Comments: &CommentsConfig{
+			Mode:        CommentModeSingle,
+			MinInterval: 30 * time.Second,
+		},
+		ExecutionCheck: &ExecutionCheckConfig{
+			Enabled: false,
+		},
 	}
 }
 
@@ -95,6 +271,9 @@ const (
 	LabelDone       = "pilot-done"
 	LabelFailed     = "pilot-failed"
 	LabelRetryReady = "pilot-retry-ready" // PR closed without merge, issue ready for retry
+	LabelBlocked    = "pilot-blocked"     // Agent needs input from the requester to continue
+	LabelNeedsInfo  = "pilot-needs-info"  // Triage asked the requester to clarify the issue before execution
+	LabelNeedsHuman = "needs-human"       // PR touches require_approval_paths and must be approved by a human
 )
 
 // Priority mapping from GitHub labels
@@ -207,12 +386,14 @@ type PRRef struct {
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
 	ID             int64  `json:"id,omitempty"`
+	NodeID         string `json:"node_id,omitempty"` // GraphQL global node ID
 	Number         int    `json:"number,omitempty"`
 	Title          string `json:"title"`
 	Body           string `json:"body,omitempty"`
 	State          string `json:"state,omitempty"` // open, closed
 	Head           PRRef  `json:"head"`            // Head reference with branch name and SHA
 	Base           PRRef  `json:"base"`            // Base reference with branch name and SHA
+	User           User   `json:"user"`            // PR author
 	HTMLURL        string `json:"html_url,omitempty"`
 	Draft          bool   `json:"draft,omitempty"`
 	Merged         bool   `json:"merged,omitempty"`
@@ -268,6 +449,22 @@ const (
 	MergeMethodRebase = "rebase"
 )
 
+// MergeQueueEntry is a pull request's place in its base branch's merge queue
+type MergeQueueEntry struct {
+	Position int    // 1-based position in the queue
+	State    string // One of the MergeQueueState* values
+	HeadSHA  string // Merge group commit that CI runs on
+}
+
+// Merge queue entry states returned by GitHub API
+const (
+	MergeQueueStateQueued         = "QUEUED"
+	MergeQueueStateAwaitingChecks = "AWAITING_CHECKS"
+	MergeQueueStateMergeable      = "MERGEABLE"
+	MergeQueueStateUnmergeable    = "UNMERGEABLE"
+	MergeQueueStateLocked         = "LOCKED"
+)
+
 // Review events for PR reviews
 const (
 	ReviewEventApprove        = "APPROVE"
@@ -292,6 +489,9 @@ type PullRequestReview struct {
 	State       string `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
 	HTMLURL     string `json:"html_url,omitempty"`
 	SubmittedAt string `json:"submitted_at,omitempty"`
+	// AuthorAssociation is the reviewer's relation to the repo: OWNER,
+	// MEMBER, COLLABORATOR, CONTRIBUTOR, NONE, ...
+	AuthorAssociation string `json:"author_association,omitempty"`
 }
 
 // PRReviewComment represents a line-level review comment on a pull request.
@@ -306,6 +506,9 @@ type PRReviewComment struct {
 	User      User   `json:"user"`       // Commenter
 	CreatedAt string `json:"created_at"`
 	HTMLURL   string `json:"html_url"`
+	// AuthorAssociation is the commenter's relation to the repo, as on
+	// PullRequestReview
+	AuthorAssociation string `json:"author_association,omitempty"`
 }
 
 // Branch represents a GitHub branch
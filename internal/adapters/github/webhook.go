@@ -36,13 +36,19 @@ type WebhookPayload struct {
 // PRReviewCallback is called when a PR review event is received
 type PRReviewCallback func(ctx context.Context, prNumber int, action, state, reviewer string, repo *Repository) error
 
+// RepoActivityCallback is called when a webhook reports a change to an
+// issue or pull request of repo
+type RepoActivityCallback func(ctx context.Context, repo *Repository, number int)
+
 // WebhookHandler handles GitHub webhooks
 type WebhookHandler struct {
-	client        *Client
-	webhookSecret string
-	pilotLabel    string
-	onIssue       func(context.Context, *Issue, *Repository) error
-	onPRReview    PRReviewCallback
+	client          *Client
+	webhookSecret   string
+	pilotLabel      string
+	onIssue         func(context.Context, *Issue, *Repository) error
+	onPRReview      PRReviewCallback
+	onIssueActivity RepoActivityCallback
+	onPRActivity    RepoActivityCallback
 }
 
 // NewWebhookHandler creates a new webhook handler
@@ -64,6 +70,17 @@ func (h *WebhookHandler) OnPRReview(callback PRReviewCallback) {
 	h.onPRReview = callback
 }
 
+// OnIssueActivity sets the callback for issues and issue_comment events
+func (h *WebhookHandler) OnIssueActivity(callback RepoActivityCallback) {
+	h.onIssueActivity = callback
+}
+
+// OnPRActivity sets the callback for pull_request and check_run events,
+// called once per pull request the event concerns
+func (h *WebhookHandler) OnPRActivity(callback RepoActivityCallback) {
+	h.onPRActivity = callback
+}
+
 // VerifySignature verifies the GitHub webhook signature
 func (h *WebhookHandler) VerifySignature(payload []byte, signature string) bool {
 	if h.webhookSecret == "" {
@@ -104,6 +121,8 @@ func (h *WebhookHandler) Handle(ctx context.Context, eventType string, payload m
 
 	switch eventType {
 	case "issues":
+		h.issueActivity(ctx, payload)
+
 		// Process issue create/labeled events
 		switch action {
 		case "opened":
@@ -111,6 +130,29 @@ func (h *WebhookHandler) Handle(ctx context.Context, eventType string, payload m
 		case "labeled":
 			return h.handleIssueLabeled(ctx, payload)
 		}
+	case "issue_comment":
+		h.issueActivity(ctx, payload)
+	case "pull_request":
+		var numbers []int
+		if prData, ok := payload["pull_request"].(map[string]interface{}); ok {
+			if number, ok := prData["number"].(float64); ok {
+				numbers = append(numbers, int(number))
+			}
+		}
+		h.prActivity(ctx, payload, numbers)
+	case "check_run":
+		// Check runs list the pull requests of their head commit
+		var numbers []int
+		runData, _ := payload["check_run"].(map[string]interface{})
+		prs, _ := runData["pull_requests"].([]interface{})
+		for _, pr := range prs {
+			if prData, ok := pr.(map[string]interface{}); ok {
+				if number, ok := prData["number"].(float64); ok {
+					numbers = append(numbers, int(number))
+				}
+			}
+		}
+		h.prActivity(ctx, payload, numbers)
 	case "pull_request_review":
 		// Process PR review events
 		return h.handlePRReview(ctx, payload, action)
@@ -119,6 +161,56 @@ func (h *WebhookHandler) Handle(ctx context.Context, eventType string, payload m
 	return nil
 }
 
+// issueActivity reports an issue or issue comment event
+func (h *WebhookHandler) issueActivity(ctx context.Context, payload map[string]interface{}) {
+	repo := activityRepo(payload)
+	if h.onIssueActivity == nil || repo == nil {
+		return
+	}
+	var number int
+	if issueData, ok := payload["issue"].(map[string]interface{}); ok {
+		if n, ok := issueData["number"].(float64); ok {
+			number = int(n)
+		}
+	}
+	h.onIssueActivity(ctx, repo, number)
+}
+
+// prActivity reports a pull request or check run event. Responses the
+// batch poller prefilled for the repo predate it, so they are expired.
+func (h *WebhookHandler) prActivity(ctx context.Context, payload map[string]interface{}, numbers []int) {
+	repo := activityRepo(payload)
+	if repo == nil {
+		return
+	}
+	etagCache.expirePrefilled(repo.Owner.Login, repo.Name)
+	if h.onPRActivity == nil {
+		return
+	}
+	for _, number := range numbers {
+		h.onPRActivity(ctx, repo, number)
+	}
+}
+
+// activityRepo extracts the repository of an event payload, or nil when it
+// is missing
+func activityRepo(payload map[string]interface{}) *Repository {
+	repoData, ok := payload["repository"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	repo := &Repository{}
+	repo.Name, _ = repoData["name"].(string)
+	repo.FullName, _ = repoData["full_name"].(string)
+	if ownerData, ok := repoData["owner"].(map[string]interface{}); ok {
+		repo.Owner.Login, _ = ownerData["login"].(string)
+	}
+	if repo.FullName == "" || repo.Name == "" || repo.Owner.Login == "" {
+		return nil
+	}
+	return repo
+}
+
 // handleIssueOpened processes newly created issues
 func (h *WebhookHandler) handleIssueOpened(ctx context.Context, payload map[string]interface{}) error {
 	issue, repo, err := h.extractIssueAndRepo(payload)
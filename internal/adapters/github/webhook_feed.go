@@ -0,0 +1,141 @@
+package github
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// DeliveryLog remembers webhook delivery IDs so redeliveries and replays of
+// a delivery are processed once
+type DeliveryLog struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	max  int
+	seen map[string]time.Time
+}
+
+// NewDeliveryLog creates a log that remembers up to max delivery IDs for ttl
+func NewDeliveryLog(ttl time.Duration, max int) *DeliveryLog {
+	return &DeliveryLog{ttl: ttl, max: max, seen: make(map[string]time.Time)}
+}
+
+// Seen records id and reports whether it was already recorded within the TTL
+func (l *DeliveryLog) Seen(id string) bool {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if at, ok := l.seen[id]; ok && now.Sub(at) < l.ttl {
+		return true
+	}
+	if len(l.seen) >= l.max {
+		var oldest string
+		for k, at := range l.seen {
+			if now.Sub(at) >= l.ttl {
+				delete(l.seen, k)
+				continue
+			}
+			if oldest == "" || at.Before(l.seen[oldest]) {
+				oldest = k
+			}
+		}
+		if len(l.seen) >= l.max {
+			delete(l.seen, oldest)
+		}
+	}
+	l.seen[id] = now
+	return false
+}
+
+// WebhookFeed connects verified webhook deliveries to the pollers and
+// comment command watchers of the repos they are about. In webhook mode pollers skip scheduled polls while
+// deliveries keep arriving and fall back to polling when they go quiet.
+type WebhookFeed struct {
+	mode   string
+	quiet  time.Duration
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	last    time.Time
+	polling bool // Whether scheduled polls run, to log transitions once
+	pokes   map[string][]func()
+}
+
+// NewWebhookFeed creates a feed for the given ingestion mode. quiet is how
+// long webhook mode waits for a delivery before polling again.
+func NewWebhookFeed(mode string, quiet time.Duration) *WebhookFeed {
+	if quiet <= 0 {
+		quiet = DefaultWebhookQuiet
+	}
+	return &WebhookFeed{
+		mode:    mode,
+		quiet:   quiet,
+		logger:  logging.WithComponent("github-webhooks"),
+		polling: true,
+		pokes:   make(map[string][]func()),
+	}
+}
+
+// Delivered records a verified webhook delivery
+func (f *WebhookFeed) Delivered() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.last = time.Now()
+	if f.mode == ModeWebhook && f.polling {
+		f.polling = false
+		f.logger.Info("Webhook deliveries arriving, pausing scheduled polls")
+	}
+}
+
+// Active reports whether a delivery arrived within the quiet period
+func (f *WebhookFeed) Active() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.activeLocked()
+}
+
+func (f *WebhookFeed) activeLocked() bool {
+	return !f.last.IsZero() && time.Since(f.last) < f.quiet
+}
+
+// Poke makes the pollers and watchers of repo ("owner/name") poll now. It
+// returns false when none is registered for the repo.
+func (f *WebhookFeed) Poke(repo string) bool {
+	f.mu.Lock()
+	pokes := f.pokes[strings.ToLower(repo)]
+	f.mu.Unlock()
+	for _, poke := range pokes {
+		poke()
+	}
+	return len(pokes) > 0
+}
+
+func (f *WebhookFeed) register(repo string, poke func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.ToLower(repo)
+	f.pokes[key] = append(f.pokes[key], poke)
+}
+
+// skipPoll reports whether a scheduled poll should be skipped because
+// webhooks deliver the changes instead
+func (f *WebhookFeed) skipPoll() bool {
+	if f == nil || f.mode != ModeWebhook {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.activeLocked() {
+		return true
+	}
+	if !f.polling {
+		f.polling = true
+		f.logger.Warn("Webhooks went quiet, falling back to polling",
+			slog.Duration("quiet", f.quiet))
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestDeliveryLog_Seen(t *testing.T) {
+	log := NewDeliveryLog(time.Hour, 2)
+
+	if log.Seen("a") {
+		t.Error("first delivery reported as seen")
+	}
+	if !log.Seen("a") {
+		t.Error("redelivery not reported as seen")
+	}
+
+	// Full: the oldest ID is forgotten to make room
+	log.Seen("b")
+	log.Seen("c")
+	if log.Seen("a") {
+		t.Error("oldest delivery should have been evicted")
+	}
+
+	expired := NewDeliveryLog(time.Millisecond, 10)
+	expired.Seen("x")
+	time.Sleep(5 * time.Millisecond)
+	if expired.Seen("x") {
+		t.Error("delivery past the TTL reported as seen")
+	}
+}
+
+func TestWebhookFeed_SkipPoll(t *testing.T) {
+	var none *WebhookFeed
+	if none.skipPoll() {
+		t.Error("nil feed should never skip polls")
+	}
+
+	hybrid := NewWebhookFeed(ModeHybrid, time.Minute)
+	hybrid.Delivered()
+	if hybrid.skipPoll() {
+		t.Error("hybrid mode should keep polling")
+	}
+
+	webhook := NewWebhookFeed(ModeWebhook, 20*time.Millisecond)
+	if webhook.skipPoll() {
+		t.Error("webhook mode should poll before the first delivery")
+	}
+	webhook.Delivered()
+	if !webhook.Active() || !webhook.skipPoll() {
+		t.Error("webhook mode should skip polls while deliveries arrive")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if webhook.Active() || webhook.skipPoll() {
+		t.Error("webhook mode should fall back to polling when deliveries go quiet")
+	}
+}
+
+func TestWebhookFeed_Poke(t *testing.T) {
+	feed := NewWebhookFeed(ModeWebhook, time.Minute)
+	p, err := NewPoller(NewClient(testutil.FakeGitHubToken), "Owner/Repo", "pilot", time.Hour, WithWebhookFeed(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Poke("other/repo") {
+		t.Error("poke for an unpolled repo should report false")
+	}
+	if !feed.Poke("owner/repo") {
+		t.Fatal("poke should find the poller regardless of case")
+	}
+	if p.lastChanged.Load() == 0 {
+		t.Error("poke should invalidate the batch snapshot")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if !p.waitForPoll(ctx) {
+		t.Fatal("poked poller should poll without waiting for the interval")
+	}
+
+	feed.Delivered()
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	p.interval = 10 * time.Millisecond
+	if p.waitForPoll(short) {
+		t.Error("scheduled polls should be skipped while webhooks are active")
+	}
+}
+
+func TestWebhookHandler_Activity(t *testing.T) {
+	handler := NewWebhookHandler(NewClient(testutil.FakeGitHubToken), "", "pilot")
+
+	var issues, prs []int
+	handler.OnIssueActivity(func(ctx context.Context, repo *Repository, number int) {
+		if repo.FullName != "owner/repo" {
+			t.Errorf("repo = %q, want owner/repo", repo.FullName)
+		}
+		issues = append(issues, number)
+	})
+	handler.OnPRActivity(func(ctx context.Context, repo *Repository, number int) {
+		prs = append(prs, number)
+	})
+
+	repo := map[string]interface{}{
+		"name":      "repo",
+		"full_name": "owner/repo",
+		"owner":     map[string]interface{}{"login": "owner"},
+	}
+	events := []struct {
+		event   string
+		payload map[string]interface{}
+	}{
+		{"issues", map[string]interface{}{"action": "edited", "issue": map[string]interface{}{"number": float64(1)}, "repository": repo}},
+		{"issue_comment", map[string]interface{}{"action": "created", "issue": map[string]interface{}{"number": float64(2)}, "repository": repo}},
+		{"pull_request", map[string]interface{}{"action": "synchronize", "pull_request": map[string]interface{}{"number": float64(3)}, "repository": repo}},
+		{"check_run", map[string]interface{}{"action": "completed", "check_run": map[string]interface{}{
+			"pull_requests": []interface{}{map[string]interface{}{"number": float64(4)}, map[string]interface{}{"number": float64(5)}},
+		}, "repository": repo}},
+		{"check_run", map[string]interface{}{"action": "completed"}}, // No repository
+	}
+
+	key := "test-activity/repos/owner/repo/pulls/3"
+	etagCache.prefill(key, []byte(`{}`), time.Now().Add(time.Hour))
+
+	for _, e := range events {
+		if err := handler.Handle(context.Background(), e.event, e.payload); err != nil {
+			t.Fatalf("Handle(%s) error: %v", e.event, err)
+		}
+	}
+
+	if len(issues) != 2 || issues[0] != 1 || issues[1] != 2 {
+		t.Errorf("issue activity = %v, want [1 2]", issues)
+	}
+	if len(prs) != 3 || prs[0] != 3 || prs[1] != 4 || prs[2] != 5 {
+		t.Errorf("PR activity = %v, want [3 4 5]", prs)
+	}
+	if cached, ok := etagCache.get(key); !ok || !cached.freshUntil.IsZero() {
+		t.Error("PR activity should expire prefilled responses of the repo")
+	}
+}
+
+func TestConfigValidate_Mode(t *testing.T) {
+	polling := &PollingConfig{Enabled: true}
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"default", Config{}, false},
+		{"poll", Config{Mode: "poll"}, false},
+		{"webhook", Config{Mode: "webhook", WebhookSecret: "s", Polling: polling}, false},
+		{"hybrid uppercase", Config{Mode: "Hybrid", WebhookSecret: "s", Polling: polling}, false},
+		{"webhook without secret", Config{Mode: "webhook", Polling: polling}, true},
+		{"webhook without polling", Config{Mode: "webhook", WebhookSecret: "s"}, true},
+		{"unknown", Config{Mode: "push"}, true},
+		{"negative quiet", Config{WebhookQuiet: -time.Second}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
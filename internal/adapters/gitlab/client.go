@@ -277,6 +277,51 @@ func (c *Client) GetPipeline(ctx context.Context, pipelineID int) (*Pipeline, er
 	return &pipeline, nil
 }
 
+// ListPipelinesForSHA lists the pipelines run for a commit, newest first
+func (c *Client) ListPipelinesForSHA(ctx context.Context, sha string) ([]*Pipeline, error) {
+	path := fmt.Sprintf("/api/v4/projects/%s/pipelines?sha=%s&order_by=id&sort=desc", c.projectID, url.QueryEscape(sha))
+	var pipelines []*Pipeline
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &pipelines); err != nil {
+		return nil, err
+	}
+	return pipelines, nil
+}
+
+// ListPipelineJobs lists the jobs of a pipeline
+func (c *Client) ListPipelineJobs(ctx context.Context, pipelineID int) ([]*Job, error) {
+	path := fmt.Sprintf("/api/v4/projects/%s/pipelines/%d/jobs?per_page=100", c.projectID, pipelineID)
+	var jobs []*Job
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetJobTrace fetches the log output of a job
+func (c *Client) GetJobTrace(ctx context.Context, jobID int) (string, error) {
+	path := fmt.Sprintf("/api/v4/projects/%s/jobs/%d/trace", c.projectID, jobID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+	return string(body), nil
+}
+
 // HasLabel checks if an issue has a specific label
 func HasLabel(issue *Issue, labelName string) bool {
 	for _, label := range issue.Labels {
@@ -214,6 +214,16 @@ type Pipeline struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Job represents a job of a GitLab CI/CD pipeline
+type Job struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Stage        string `json:"stage"`
+	Status       string `json:"status"` // created, pending, running, success, failed, canceled, skipped, manual
+	AllowFailure bool   `json:"allow_failure"`
+	WebURL       string `json:"web_url"`
+}
+
 // Status represents a detailed merge status
 type Status struct {
 	Icon        string `json:"icon"`
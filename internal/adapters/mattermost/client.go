@@ -0,0 +1,157 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const apiPrefix = "/api/v4"
+
+// Client is a Mattermost REST API v4 client authenticated with a bot token.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	usersMu sync.Mutex
+	users   map[string]*User // userID -> profile cache
+}
+
+// NewClient creates a new Mattermost client for the given server URL.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		users: make(map[string]*User),
+	}
+}
+
+// BaseURL returns the server URL the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// doRequest sends a JSON request to the Mattermost API and decodes the response into out.
+func (c *Client) doRequest(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+apiPrefix+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("mattermost API error: HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("parse response: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetMe returns the profile of the authenticated bot user.
+func (c *Client) GetMe(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.doRequest(ctx, http.MethodGet, "/users/me", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUser returns a user profile by ID. Results are cached for the client lifetime.
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	c.usersMu.Lock()
+	cached, ok := c.users[userID]
+	c.usersMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var user User
+	if err := c.doRequest(ctx, http.MethodGet, "/users/"+userID, nil, &user); err != nil {
+		return nil, err
+	}
+
+	c.usersMu.Lock()
+	c.users[userID] = &user
+	c.usersMu.Unlock()
+	return &user, nil
+}
+
+// CreatePost creates a new post. Set RootID to reply in a thread.
+func (c *Client) CreatePost(ctx context.Context, post *Post) (*Post, error) {
+	var created Post
+	if err := c.doRequest(ctx, http.MethodPost, "/posts", post, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdatePost replaces the message of an existing post.
+func (c *Client) UpdatePost(ctx context.Context, postID, message string) error {
+	body := map[string]string{
+		"id":      postID,
+		"message": message,
+	}
+	return c.doRequest(ctx, http.MethodPut, "/posts/"+postID+"/patch", body, nil)
+}
+
+// AddReaction adds an emoji reaction to a post on behalf of userID.
+func (c *Client) AddReaction(ctx context.Context, userID, postID, emoji string) error {
+	reaction := &Reaction{
+		UserID:    userID,
+		PostID:    postID,
+		EmojiName: emoji,
+	}
+	return c.doRequest(ctx, http.MethodPost, "/reactions", reaction, nil)
+}
+
+// SendMessage posts a plain text message to a channel.
+func (c *Client) SendMessage(ctx context.Context, channelID, message string) (*Post, error) {
+	return c.CreatePost(ctx, &Post{ChannelID: channelID, Message: message})
+}
+
+// websocketURL returns the WebSocket endpoint derived from the server URL.
+func (c *Client) websocketURL() string {
+	u := c.baseURL
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		u = "wss://" + strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		u = "ws://" + strings.TrimPrefix(u, "http://")
+	}
+	return u + apiPrefix + "/websocket"
+}
@@ -0,0 +1,88 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestClient_CreatePost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/posts" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer "+testutil.FakeMattermostToken {
+			t.Errorf("Authorization = %q", got)
+		}
+		var post Post
+		_ = json.NewDecoder(r.Body).Decode(&post)
+		post.ID = "p1"
+		_ = json.NewEncoder(w).Encode(post)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", testutil.FakeMattermostToken)
+	post, err := client.CreatePost(context.Background(), &Post{ChannelID: "c1", Message: "hello", RootID: "root"})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	if post.ID != "p1" || post.ChannelID != "c1" || post.RootID != "root" {
+		t.Errorf("unexpected post: %+v", post)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testutil.FakeMattermostToken)
+	if err := client.UpdatePost(context.Background(), "p1", "text"); err == nil {
+		t.Fatal("expected error for HTTP 403")
+	}
+}
+
+func TestClient_GetUser_Cached(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(w).Encode(User{ID: "u1", Username: "alice", Email: "alice@example.com"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testutil.FakeMattermostToken)
+	for i := 0; i < 3; i++ {
+		user, err := client.GetUser(context.Background(), "u1")
+		if err != nil {
+			t.Fatalf("GetUser() error = %v", err)
+		}
+		if user.Email != "alice@example.com" {
+			t.Errorf("Email = %q", user.Email)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 API call, got %d", calls)
+	}
+}
+
+func TestClient_WebsocketURL(t *testing.T) {
+	tests := []struct {
+		base string
+		want string
+	}{
+		{"https://mm.example.com", "wss://mm.example.com/api/v4/websocket"},
+		{"http://localhost:8065/", "ws://localhost:8065/api/v4/websocket"},
+	}
+	for _, tt := range tests {
+		if got := NewClient(tt.base, "t").websocketURL(); got != tt.want {
+			t.Errorf("websocketURL(%q) = %q, want %q", tt.base, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package mattermost
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxMessageLength is the practical maximum post length accepted by Mattermost servers.
+const MaxMessageLength = 16383
+
+// Reaction emoji names used for confirmations and approvals.
+const (
+	EmojiApprove = "white_check_mark"
+	EmojiReject  = "x"
+)
+
+// FormatTaskConfirmation formats a task confirmation post.
+func FormatTaskConfirmation(taskID, description, projectPath string) string {
+	desc := description
+	if len(desc) > 500 {
+		desc = desc[:497] + "..."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("#### 📋 Task: %s\n\n", taskID))
+	sb.WriteString(desc)
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("_Project: %s_\n\n", projectPath))
+	sb.WriteString(fmt.Sprintf("React with :%s: to execute or :%s: to cancel (or reply *yes* / *no*).", EmojiApprove, EmojiReject))
+	return sb.String()
+}
+
+// FormatProgressUpdate formats a progress post.
+func FormatProgressUpdate(taskID, phase string, progress int, detail string) string {
+	bar := makeProgressBar(progress)
+	text := fmt.Sprintf("⚙️ **%s** — %s\n`%s` %d%%", taskID, phase, bar, progress)
+	if detail != "" {
+		text += fmt.Sprintf("\n\n_%s_", detail)
+	}
+	return text
+}
+
+// FormatTaskResult formats the execution result post.
+func FormatTaskResult(output string, success bool, prURL string) string {
+	var sb strings.Builder
+
+	if success {
+		sb.WriteString("✅ **Task completed**\n\n")
+	} else {
+		sb.WriteString("❌ **Task failed**\n\n")
+	}
+
+	if output != "" {
+		out := output
+		if len(out) > 3000 {
+			out = out[:2997] + "..."
+		}
+		sb.WriteString(out)
+		sb.WriteString("\n\n")
+	}
+
+	if prURL != "" {
+		sb.WriteString(fmt.Sprintf("🔗 **PR:** [View Pull Request](%s)", prURL))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// makeProgressBar creates a visual progress bar.
+func makeProgressBar(progress int) string {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 100 {
+		progress = 100
+	}
+	filled := progress / 10
+	return strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+}
+
+// ChunkContent splits long content into post-sized chunks, preferring line boundaries.
+func ChunkContent(content string, maxLen int) []string {
+	if maxLen <= 0 {
+		maxLen = MaxMessageLength
+	}
+	if len(content) <= maxLen {
+		return []string{content}
+	}
+
+	var chunks []string
+	for len(content) > maxLen {
+		cut := strings.LastIndex(content[:maxLen], "\n")
+		if cut <= 0 {
+			cut = maxLen
+		}
+		chunks = append(chunks, content[:cut])
+		content = strings.TrimPrefix(content[cut:], "\n")
+	}
+	if len(content) > 0 {
+		chunks = append(chunks, content)
+	}
+	return chunks
+}
+
+// TruncateText truncates text to a maximum length.
+func TruncateText(text string, maxLen int) string {
+	if len(text) <= maxLen {
+		return text
+	}
+	return text[:maxLen-3] + "..."
+}
@@ -0,0 +1,331 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/alekspetrov/pilot/internal/comms"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// MemberResolver resolves a Mattermost user to a team member ID for RBAC.
+// Decoupled from teams package to avoid import cycles.
+type MemberResolver interface {
+	// ResolveMattermostIdentity maps a Mattermost user ID and/or email to a member ID.
+	// Returns ("", nil) when no match is found (= skip RBAC).
+	ResolveMattermostIdentity(userID, email string) (string, error)
+}
+
+// MemberResolverAdapter wraps a mattermost.MemberResolver as comms.MemberResolver.
+// When Client is set, the sender's email is looked up so email-based team
+// membership can be matched.
+type MemberResolverAdapter struct {
+	Inner  MemberResolver
+	Client *Client
+}
+
+// ResolveIdentity implements comms.MemberResolver by delegating to ResolveMattermostIdentity.
+func (a *MemberResolverAdapter) ResolveIdentity(senderID string) (string, error) {
+	email := ""
+	if a.Client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if user, err := a.Client.GetUser(ctx, senderID); err == nil {
+			email = user.Email
+		}
+	}
+	return a.Inner.ResolveMattermostIdentity(senderID, email)
+}
+
+// ReactionHandler is offered every reaction before it is treated as a task
+// confirmation. It returns true when the reaction was consumed (e.g. by an
+// approval request).
+type ReactionHandler func(ctx context.Context, postID, userID, username, emoji string) bool
+
+// eventSource abstracts the WebSocket client for testing.
+type eventSource interface {
+	Listen(ctx context.Context) (<-chan Event, error)
+}
+
+// Handler processes incoming Mattermost events and coordinates task execution.
+// Delegates intent detection and task lifecycle to the shared comms.Handler.
+type Handler struct {
+	events          eventSource
+	apiClient       *Client
+	commsHandler    *comms.Handler
+	onReaction      ReactionHandler
+	allowedChannels map[string]bool
+	allowedUsers    map[string]bool
+	botUserID       string
+	botUsername     string
+	stopCh          chan struct{}
+	stopOnce        sync.Once
+	wg              sync.WaitGroup
+	log             *slog.Logger
+}
+
+// HandlerConfig holds configuration for the Mattermost handler.
+type HandlerConfig struct {
+	Client          *Client        // Mattermost API client (required)
+	CommsHandler    *comms.Handler // Shared handler for intent dispatch + task lifecycle
+	AllowedChannels []string       // Channel IDs allowed to send tasks
+	AllowedUsers    []string       // User IDs allowed to send tasks
+	BotUserID       string         // Optional: resolved via /users/me when empty
+	BotUsername     string         // Optional: resolved via /users/me when empty
+}
+
+// NewHandler creates a new Mattermost event handler.
+func NewHandler(config *HandlerConfig) *Handler {
+	allowedChannels := make(map[string]bool)
+	for _, id := range config.AllowedChannels {
+		allowedChannels[id] = true
+	}
+
+	allowedUsers := make(map[string]bool)
+	for _, id := range config.AllowedUsers {
+		allowedUsers[id] = true
+	}
+
+	return &Handler{
+		events:          NewWebSocketClient(config.Client),
+		apiClient:       config.Client,
+		commsHandler:    config.CommsHandler,
+		allowedChannels: allowedChannels,
+		allowedUsers:    allowedUsers,
+		botUserID:       config.BotUserID,
+		botUsername:     config.BotUsername,
+		stopCh:          make(chan struct{}),
+		log:             logging.WithComponent("mattermost.handler"),
+	}
+}
+
+// SetReactionHandler registers a hook that sees reactions before task confirmation
+// handling. Used to route approval reactions to approval.MattermostHandler.
+func (h *Handler) SetReactionHandler(fn ReactionHandler) {
+	h.onReaction = fn
+}
+
+// StartListening starts listening for Mattermost events over the WebSocket API.
+// It blocks until ctx is cancelled or Stop() is called.
+func (h *Handler) StartListening(ctx context.Context) error {
+	if (h.botUserID == "" || h.botUsername == "") && h.apiClient != nil {
+		me, err := h.apiClient.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve bot user: %w", err)
+		}
+		if h.botUserID == "" {
+			h.botUserID = me.ID
+		}
+		if h.botUsername == "" {
+			h.botUsername = me.Username
+		}
+	}
+
+	events, err := h.events.Listen(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start Mattermost listener: %w", err)
+	}
+
+	h.log.Info("Mattermost WebSocket listener started")
+
+	// Start cleanup goroutine for expired pending tasks (delegated to commsHandler)
+	h.wg.Add(1)
+	go h.cleanupLoop(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.log.Info("Mattermost listener stopping (context cancelled)")
+			return ctx.Err()
+		case <-h.stopCh:
+			h.log.Info("Mattermost listener stopping (stop signal)")
+			return nil
+		case evt, ok := <-events:
+			if !ok {
+				h.log.Info("Mattermost event channel closed")
+				return nil
+			}
+			h.processEvent(ctx, &evt)
+		}
+	}
+}
+
+// Stop gracefully stops the handler. Safe to call multiple times.
+func (h *Handler) Stop() {
+	h.stopOnce.Do(func() {
+		close(h.stopCh)
+	})
+	h.wg.Wait()
+}
+
+// cleanupLoop delegates pending task cleanup to the shared comms.Handler.
+func (h *Handler) cleanupLoop(ctx context.Context) {
+	defer h.wg.Done()
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-h.stopCh:
+			cancel()
+		case <-cctx.Done():
+		}
+	}()
+	if h.commsHandler != nil {
+		h.commsHandler.CleanupLoop(cctx)
+	}
+}
+
+// processEvent dispatches a single normalized event.
+func (h *Handler) processEvent(ctx context.Context, event *Event) {
+	switch event.Type {
+	case EventPosted:
+		h.handlePost(ctx, event)
+	case EventReactionAdded:
+		h.handleReaction(ctx, event)
+	}
+}
+
+// handlePost delegates a channel message to comms.Handler.
+func (h *Handler) handlePost(ctx context.Context, event *Event) {
+	post := event.Post
+	if post == nil || post.IsSystemPost() {
+		return
+	}
+
+	// Ignore our own posts to avoid feedback loops
+	if post.UserID == h.botUserID {
+		return
+	}
+
+	if !h.isAllowed(post.ChannelID, post.UserID) {
+		h.log.Debug("Ignoring message from unauthorized channel/user",
+			slog.String("channel_id", post.ChannelID),
+			slog.String("user_id", post.UserID))
+		return
+	}
+
+	text := strings.TrimSpace(post.Message)
+	// Outside direct messages, a post opening with someone else's mention is
+	// addressed to them, not to the bot
+	if event.ChannelType != ChannelTypeDirect && h.mentionsOther(text) {
+		return
+	}
+	text = h.stripMention(text)
+	if text == "" {
+		return
+	}
+
+	if h.commsHandler != nil {
+		h.commsHandler.HandleMessage(ctx, &comms.IncomingMessage{
+			ContextID:  post.ChannelID,
+			SenderID:   post.UserID,
+			SenderName: event.SenderName,
+			Text:       text,
+			ThreadID:   post.RootID,
+			Platform:   "mattermost",
+			Timestamp:  time.Now(),
+		})
+	}
+}
+
+// handleReaction routes reactions to the approval hook first, then treats
+// approve/reject emoji as task confirmation callbacks.
+func (h *Handler) handleReaction(ctx context.Context, event *Event) {
+	r := event.Reaction
+	if r == nil || r.UserID == h.botUserID {
+		return
+	}
+
+	if h.onReaction != nil {
+		username := r.UserID
+		if h.apiClient != nil {
+			if user, err := h.apiClient.GetUser(ctx, r.UserID); err == nil && user.Username != "" {
+				username = user.Username
+			}
+		}
+		if h.onReaction(ctx, r.PostID, r.UserID, username, r.EmojiName) {
+			return
+		}
+	}
+
+	var action string
+	switch r.EmojiName {
+	case EmojiApprove:
+		action = "execute"
+	case EmojiReject:
+		action = "cancel"
+	default:
+		return
+	}
+
+	channelID := event.ChannelID
+	if channelID == "" || !h.isAllowed(channelID, r.UserID) {
+		return
+	}
+
+	if h.commsHandler != nil {
+		h.commsHandler.HandleMessage(ctx, &comms.IncomingMessage{
+			ContextID:  channelID,
+			SenderID:   r.UserID,
+			Platform:   "mattermost",
+			IsCallback: true,
+			CallbackID: r.PostID,
+			ActionID:   action,
+			Timestamp:  time.Now(),
+		})
+	}
+}
+
+// stripMention removes a leading @mention of the bot from the message.
+// Mentions of other users are kept.
+func (h *Handler) stripMention(text string) string {
+	name, rest := leadingMention(text)
+	if name == "" || !strings.EqualFold(name, h.botUsername) {
+		return text
+	}
+	return rest
+}
+
+// mentionsOther reports whether the message opens with a mention of a user
+// other than the bot.
+func (h *Handler) mentionsOther(text string) bool {
+	name, _ := leadingMention(text)
+	return name != "" && !strings.EqualFold(name, h.botUsername)
+}
+
+// leadingMention splits "@name: rest" into the username and the rest of the
+// message. name is empty when the message does not open with a mention.
+func leadingMention(text string) (name, rest string) {
+	if !strings.HasPrefix(text, "@") {
+		return "", text
+	}
+	end := strings.IndexFunc(text[1:], func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '_' && r != '-'
+	})
+	if end == -1 {
+		end = len(text) - 1
+	}
+	name = strings.TrimRight(text[1:1+end], ".")
+	rest = strings.TrimSpace(strings.TrimLeft(text[1+len(name):], ".:,"))
+	return name, rest
+}
+
+// isAllowed checks if a channel/user is authorized.
+func (h *Handler) isAllowed(channelID, userID string) bool {
+	// If no restrictions configured, allow all
+	if len(h.allowedChannels) == 0 && len(h.allowedUsers) == 0 {
+		return true
+	}
+	if len(h.allowedChannels) > 0 && h.allowedChannels[channelID] {
+		return true
+	}
+	if len(h.allowedUsers) > 0 && h.allowedUsers[userID] {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,264 @@
+package mattermost
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/comms"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// recordingMessenger captures outbound texts for assertions.
+type recordingMessenger struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (m *recordingMessenger) SendText(_ context.Context, contextID, text string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.texts = append(m.texts, contextID+":"+text)
+	return nil
+}
+func (m *recordingMessenger) SendConfirmation(context.Context, string, string, string, string, string) (string, error) {
+	return "ref", nil
+}
+func (m *recordingMessenger) SendProgress(_ context.Context, _, ref, _, _ string, _ int, _ string) (string, error) {
+	return ref, nil
+}
+func (m *recordingMessenger) SendResult(context.Context, string, string, string, bool, string, string) error {
+	return nil
+}
+func (m *recordingMessenger) SendChunked(context.Context, string, string, string, string) error {
+	return nil
+}
+func (m *recordingMessenger) AcknowledgeCallback(context.Context, string) error { return nil }
+func (m *recordingMessenger) MaxMessageLength() int                             { return MaxMessageLength }
+
+func (m *recordingMessenger) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.texts)
+}
+
+// fakeEventSource replays a fixed list of events.
+type fakeEventSource struct {
+	events []Event
+}
+
+func (f *fakeEventSource) Listen(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, len(f.events))
+	for _, e := range f.events {
+		ch <- e
+	}
+	close(ch)
+	return ch, nil
+}
+
+func newTestHandler(cfg *HandlerConfig, events ...Event) (*Handler, *recordingMessenger) {
+	messenger := &recordingMessenger{}
+	cfg.CommsHandler = comms.NewHandler(&comms.HandlerConfig{
+		Messenger:    messenger,
+		TaskIDPrefix: "MM",
+	})
+	if cfg.BotUserID == "" {
+		cfg.BotUserID = "bot"
+	}
+	if cfg.BotUsername == "" {
+		cfg.BotUsername = "pilot"
+	}
+	cfg.Client = NewClient("http://localhost:8065", testutil.FakeMattermostToken)
+	h := NewHandler(cfg)
+	h.apiClient = nil
+	h.events = &fakeEventSource{events: events}
+	return h, messenger
+}
+
+func postEvent(channelID, userID, message string) Event {
+	return Event{
+		Type:      EventPosted,
+		ChannelID: channelID,
+		Post:      &Post{ChannelID: channelID, UserID: userID, Message: message},
+	}
+}
+
+func TestHandler_StartListening_DispatchesPosts(t *testing.T) {
+	h, messenger := newTestHandler(&HandlerConfig{},
+		postEvent("c1", "u1", "hello"),
+		postEvent("c1", "bot", "hello"), // own post ignored
+		Event{Type: EventPosted, Post: &Post{ChannelID: "c1", UserID: "u1", Type: "system_join_channel", Message: "hello"}}, // system post ignored
+	)
+
+	if err := h.StartListening(context.Background()); err != nil {
+		t.Fatalf("StartListening() error = %v", err)
+	}
+	h.Stop()
+
+	if got := messenger.count(); got != 1 {
+		t.Errorf("expected 1 reply (greeting), got %d: %v", got, messenger.texts)
+	}
+}
+
+func TestHandler_IsAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels []string
+		users    []string
+		channel  string
+		user     string
+		want     bool
+	}{
+		{"no restrictions", nil, nil, "c1", "u1", true},
+		{"allowed channel", []string{"c1"}, nil, "c1", "u1", true},
+		{"blocked channel", []string{"c1"}, nil, "c2", "u1", false},
+		{"allowed user", nil, []string{"u1"}, "c2", "u1", true},
+		{"blocked user", []string{"c1"}, []string{"u1"}, "c2", "u2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestHandler(&HandlerConfig{AllowedChannels: tt.channels, AllowedUsers: tt.users})
+			if got := h.isAllowed(tt.channel, tt.user); got != tt.want {
+				t.Errorf("isAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_ReactionHook(t *testing.T) {
+	h, _ := newTestHandler(&HandlerConfig{})
+
+	var gotPost, gotEmoji string
+	h.SetReactionHandler(func(_ context.Context, postID, _, _, emoji string) bool {
+		gotPost, gotEmoji = postID, emoji
+		return true
+	})
+
+	h.processEvent(context.Background(), &Event{
+		Type:      EventReactionAdded,
+		ChannelID: "c1",
+		Reaction:  &Reaction{UserID: "u1", PostID: "p1", EmojiName: EmojiApprove},
+	})
+	if gotPost != "p1" || gotEmoji != EmojiApprove {
+		t.Errorf("hook got post=%q emoji=%q", gotPost, gotEmoji)
+	}
+
+	// Bot's own seeded reactions never reach the hook
+	gotPost = ""
+	h.processEvent(context.Background(), &Event{
+		Type:     EventReactionAdded,
+		Reaction: &Reaction{UserID: "bot", PostID: "p2", EmojiName: EmojiApprove},
+	})
+	if gotPost != "" {
+		t.Error("bot reaction should be ignored")
+	}
+}
+
+func TestHandler_StripMention(t *testing.T) {
+	h, _ := newTestHandler(&HandlerConfig{})
+	tests := map[string]string{
+		"@pilot fix the bug":        "fix the bug",
+		"@Pilot: fix the bug":       "fix the bug",
+		"fix the bug":               "fix the bug",
+		"@pilot":                    "",
+		"@alice please fix X":       "@alice please fix X",
+		"@pilot-dev fix the bug":    "@pilot-dev fix the bug",
+		"@pilot.\nfix the bug":      "fix the bug",
+		"ask @pilot to fix the bug": "ask @pilot to fix the bug",
+	}
+	for in, want := range tests {
+		if got := h.stripMention(in); got != want {
+			t.Errorf("stripMention(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestHandler_PostsAddressedToOthers(t *testing.T) {
+	h, messenger := newTestHandler(&HandlerConfig{},
+		postEvent("c1", "u1", "@alice hello"), // addressed to alice: ignored
+		postEvent("c1", "u1", "@pilot hello"),
+	)
+
+	if err := h.StartListening(context.Background()); err != nil {
+		t.Fatalf("StartListening() error = %v", err)
+	}
+	h.Stop()
+
+	if got := messenger.count(); got != 1 {
+		t.Errorf("expected 1 reply (to the bot mention), got %d: %v", got, messenger.texts)
+	}
+
+	if !h.mentionsOther("@alice please fix X") || h.mentionsOther("@pilot fix X") || h.mentionsOther("fix X for @alice") {
+		t.Error("mentionsOther() misclassified a message")
+	}
+}
+
+func TestParseWebSocketEvent(t *testing.T) {
+	raw := &WebSocketEvent{
+		Event: EventPosted,
+		Data: map[string]interface{}{
+			"post":         `{"id":"p1","channel_id":"c1","user_id":"u1","message":"hi","root_id":"r1"}`,
+			"sender_name":  "@alice",
+			"channel_type": "D",
+		},
+	}
+	evt, err := parseWebSocketEvent(raw)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if evt.Post.Message != "hi" || evt.ChannelID != "c1" || evt.SenderName != "alice" || evt.Post.RootID != "r1" || evt.ChannelType != ChannelTypeDirect {
+		t.Errorf("unexpected event: %+v %+v", evt, evt.Post)
+	}
+
+	raw = &WebSocketEvent{
+		Event:     EventReactionAdded,
+		Data:      map[string]interface{}{"reaction": `{"user_id":"u1","post_id":"p1","emoji_name":"x"}`},
+		Broadcast: &Broadcast{ChannelID: "c9"},
+	}
+	evt, err = parseWebSocketEvent(raw)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if evt.Reaction.EmojiName != "x" || evt.ChannelID != "c9" {
+		t.Errorf("unexpected reaction event: %+v", evt)
+	}
+
+	if evt, _ := parseWebSocketEvent(&WebSocketEvent{Event: "typing"}); evt != nil {
+		t.Errorf("expected nil for unhandled event, got %+v", evt)
+	}
+}
+
+func TestMemberResolverAdapter(t *testing.T) {
+	resolver := &stubResolver{ids: map[string]string{"u1": "member-1"}}
+	adapter := &MemberResolverAdapter{Inner: resolver}
+
+	id, err := adapter.ResolveIdentity("u1")
+	if err != nil || id != "member-1" {
+		t.Errorf("ResolveIdentity() = %q, %v", id, err)
+	}
+	id, _ = adapter.ResolveIdentity("unknown")
+	if id != "" {
+		t.Errorf("expected empty id for unknown user, got %q", id)
+	}
+}
+
+type stubResolver struct {
+	ids map[string]string
+}
+
+func (s *stubResolver) ResolveMattermostIdentity(userID, _ string) (string, error) {
+	return s.ids[userID], nil
+}
+
+func TestChunkContent(t *testing.T) {
+	content := "line one\nline two\nline three"
+	chunks := ChunkContent(content, 12)
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %v", chunks)
+	}
+	for _, c := range chunks {
+		if len(c) > 12 {
+			t.Errorf("chunk exceeds max length: %q", c)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/alekspetrov/pilot/internal/comms"
+)
+
+// Compile-time check that MattermostMessenger implements comms.Messenger.
+var _ comms.Messenger = (*MattermostMessenger)(nil)
+
+// MattermostMessenger implements comms.Messenger by wrapping the Mattermost Client.
+type MattermostMessenger struct {
+	client *Client
+
+	botOnce   sync.Once
+	botUserID string
+}
+
+// NewMessenger creates a MattermostMessenger wrapping the given client.
+func NewMessenger(client *Client) *MattermostMessenger {
+	return &MattermostMessenger{client: client}
+}
+
+// SendText sends a plain text message to the given channel.
+func (m *MattermostMessenger) SendText(ctx context.Context, contextID, text string) error {
+	if _, err := m.client.SendMessage(ctx, contextID, text); err != nil {
+		return fmt.Errorf("send text: %w", err)
+	}
+	return nil
+}
+
+// SendConfirmation posts a task confirmation prompt and seeds approve/reject reactions
+// so users can confirm with a single click. Returns the post ID as messageRef.
+func (m *MattermostMessenger) SendConfirmation(ctx context.Context, contextID, threadID, taskID, desc, project string) (string, error) {
+	post, err := m.client.CreatePost(ctx, &Post{
+		ChannelID: contextID,
+		RootID:    threadID,
+		Message:   FormatTaskConfirmation(taskID, desc, project),
+	})
+	if err != nil {
+		return "", fmt.Errorf("send confirmation: %w", err)
+	}
+
+	if botID := m.botID(ctx); botID != "" {
+		_ = m.client.AddReaction(ctx, botID, post.ID, EmojiApprove)
+		_ = m.client.AddReaction(ctx, botID, post.ID, EmojiReject)
+	}
+
+	return post.ID, nil
+}
+
+// SendProgress updates the existing post with progress info.
+// Returns the same messageRef (Mattermost edits posts in place).
+func (m *MattermostMessenger) SendProgress(ctx context.Context, contextID, messageRef, taskID, phase string, progress int, detail string) (string, error) {
+	text := FormatProgressUpdate(taskID, phase, progress, detail)
+	if messageRef == "" {
+		post, err := m.client.SendMessage(ctx, contextID, text)
+		if err != nil {
+			return "", fmt.Errorf("send progress: %w", err)
+		}
+		return post.ID, nil
+	}
+
+	if err := m.client.UpdatePost(ctx, messageRef, text); err != nil {
+		return messageRef, fmt.Errorf("send progress: %w", err)
+	}
+	return messageRef, nil
+}
+
+// SendResult sends the final task result.
+func (m *MattermostMessenger) SendResult(ctx context.Context, contextID, threadID, taskID string, success bool, output, prURL string) error {
+	_, err := m.client.CreatePost(ctx, &Post{
+		ChannelID: contextID,
+		RootID:    threadID,
+		Message:   FormatTaskResult(output, success, prURL),
+	})
+	if err != nil {
+		return fmt.Errorf("send result: %w", err)
+	}
+	return nil
+}
+
+// SendChunked sends long content split into post-sized chunks.
+func (m *MattermostMessenger) SendChunked(ctx context.Context, contextID, threadID, content, prefix string) error {
+	chunks := ChunkContent(content, m.MaxMessageLength())
+	for i, chunk := range chunks {
+		text := chunk
+		if prefix != "" && i == 0 {
+			text = prefix + "\n\n" + chunk
+		}
+		if _, err := m.client.CreatePost(ctx, &Post{ChannelID: contextID, RootID: threadID, Message: text}); err != nil {
+			return fmt.Errorf("send chunk %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// AcknowledgeCallback is a no-op for Mattermost (reactions need no acknowledgement).
+func (m *MattermostMessenger) AcknowledgeCallback(_ context.Context, _ string) error {
+	return nil
+}
+
+// MaxMessageLength returns Mattermost's maximum post length.
+func (m *MattermostMessenger) MaxMessageLength() int {
+	return MaxMessageLength
+}
+
+// botID lazily resolves the bot user ID used to seed reactions.
+func (m *MattermostMessenger) botID(ctx context.Context) string {
+	m.botOnce.Do(func() {
+		if me, err := m.client.GetMe(ctx); err == nil {
+			m.botUserID = me.ID
+		}
+	})
+	return m.botUserID
+}
@@ -0,0 +1,154 @@
+package mattermost
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Config holds Mattermost adapter configuration.
+type Config struct {
+	Enabled         bool            `yaml:"enabled"`
+	URL             string          `yaml:"url"`              // Server URL, e.g. https://mattermost.example.com
+	BotToken        string          `yaml:"bot_token"`        // Bot account access token
+	BotUsername     string          `yaml:"bot_username"`     // Bot account username (default: resolved from bot_token)
+	Channel         string          `yaml:"channel"`          // Channel ID for notifications
+	AllowedChannels []string        `yaml:"allowed_channels"` // Channel IDs allowed to send tasks
+	AllowedUsers    []string        `yaml:"allowed_users"`    // User IDs allowed to send tasks
+	Approval        *ApprovalConfig `yaml:"approval,omitempty"`
+}
+
+// ApprovalConfig holds Mattermost-specific approval settings.
+// Approvals are collected via reactions on the approval post, so no inbound
+// HTTP endpoint is required (same reasoning as Slack Socket Mode).
+type ApprovalConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Channel string `yaml:"channel"` // Channel ID for approval requests (defaults to Config.Channel)
+}
+
+// DefaultConfig returns default Mattermost configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:         false,
+		AllowedChannels: []string{},
+		AllowedUsers:    []string{},
+		Approval:        &ApprovalConfig{},
+	}
+}
+
+// Post represents a Mattermost post.
+type Post struct {
+	ID        string                 `json:"id,omitempty"`
+	ChannelID string                 `json:"channel_id"`
+	UserID    string                 `json:"user_id,omitempty"`
+	RootID    string                 `json:"root_id,omitempty"`
+	Message   string                 `json:"message"`
+	Type      string                 `json:"type,omitempty"`
+	Props     map[string]interface{} `json:"props,omitempty"`
+	CreateAt  int64                  `json:"create_at,omitempty"`
+}
+
+// IsSystemPost reports whether the post was generated by the server
+// (joins, header changes, etc.) rather than a user.
+func (p *Post) IsSystemPost() bool {
+	return strings.HasPrefix(p.Type, "system_")
+}
+
+// User represents a Mattermost user profile.
+type User struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	IsBot     bool   `json:"is_bot"`
+}
+
+// Reaction represents an emoji reaction on a post.
+type Reaction struct {
+	UserID    string `json:"user_id"`
+	PostID    string `json:"post_id"`
+	EmojiName string `json:"emoji_name"`
+}
+
+// Mattermost WebSocket event types used by Pilot.
+const (
+	EventHello         = "hello"
+	EventPosted        = "posted"
+	EventReactionAdded = "reaction_added"
+)
+
+// WebSocketEvent is a raw event frame received over the Mattermost WebSocket.
+type WebSocketEvent struct {
+	Event     string                 `json:"event"`
+	Data      map[string]interface{} `json:"data"`
+	Broadcast *Broadcast             `json:"broadcast,omitempty"`
+	Seq       int64                  `json:"seq"`
+}
+
+// Broadcast describes the audience of a WebSocket event.
+type Broadcast struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	TeamID    string `json:"team_id"`
+}
+
+// ChannelTypeDirect is the channel type of a direct message with the bot.
+const ChannelTypeDirect = "D"
+
+// Event is the normalized event emitted by the WebSocket client.
+// Exactly one of Post or Reaction is set.
+type Event struct {
+	Type        string
+	ChannelID   string
+	ChannelType string // "O" public, "P" private, "D" direct, "G" group
+	Post        *Post
+	Reaction    *Reaction
+	SenderName  string
+}
+
+// parseWebSocketEvent converts a raw frame into a normalized Event.
+// Returns nil for event types Pilot does not handle.
+func parseWebSocketEvent(raw *WebSocketEvent) (*Event, error) {
+	channelID := ""
+	if raw.Broadcast != nil {
+		channelID = raw.Broadcast.ChannelID
+	}
+
+	switch raw.Event {
+	case EventPosted:
+		encoded, _ := raw.Data["post"].(string)
+		if encoded == "" {
+			return nil, nil
+		}
+		var post Post
+		if err := json.Unmarshal([]byte(encoded), &post); err != nil {
+			return nil, err
+		}
+		sender, _ := raw.Data["sender_name"].(string)
+		channelType, _ := raw.Data["channel_type"].(string)
+		return &Event{
+			Type:        EventPosted,
+			ChannelID:   post.ChannelID,
+			ChannelType: channelType,
+			Post:        &post,
+			SenderName:  strings.TrimPrefix(sender, "@"),
+		}, nil
+
+	case EventReactionAdded:
+		encoded, _ := raw.Data["reaction"].(string)
+		if encoded == "" {
+			return nil, nil
+		}
+		var reaction Reaction
+		if err := json.Unmarshal([]byte(encoded), &reaction); err != nil {
+			return nil, err
+		}
+		return &Event{
+			Type:      EventReactionAdded,
+			ChannelID: channelID,
+			Reaction:  &reaction,
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,172 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/gorilla/websocket"
+)
+
+// reconnectBackoff controls delays between reconnection attempts.
+const (
+	initialReconnectDelay = 1 * time.Second
+	maxReconnectDelay     = 30 * time.Second
+	pingInterval          = 30 * time.Second
+)
+
+// WebSocketClient streams events from the Mattermost WebSocket API.
+// It authenticates with the bot token and reconnects automatically
+// when the connection drops, mirroring the Slack Socket Mode client.
+type WebSocketClient struct {
+	url    string
+	token  string
+	dialer *websocket.Dialer
+	log    *slog.Logger
+}
+
+// NewWebSocketClient creates a WebSocket client for the server the given API client talks to.
+func NewWebSocketClient(client *Client) *WebSocketClient {
+	return &WebSocketClient{
+		url:    client.websocketURL(),
+		token:  client.token,
+		dialer: &websocket.Dialer{HandshakeTimeout: 10 * time.Second},
+		log:    logging.WithComponent("mattermost.websocket"),
+	}
+}
+
+// authChallenge is the first frame sent after connecting.
+type authChallenge struct {
+	Seq    int64             `json:"seq"`
+	Action string            `json:"action"`
+	Data   map[string]string `json:"data"`
+}
+
+// Listen connects to the WebSocket and emits normalized Events until ctx is cancelled.
+// The first connection is established synchronously so configuration errors surface
+// immediately; subsequent drops are retried with exponential backoff.
+func (w *WebSocketClient) Listen(ctx context.Context) (<-chan Event, error) {
+	conn, err := w.connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("initial connection: %w", err)
+	}
+
+	out := make(chan Event, 64)
+	go w.listenLoop(ctx, conn, out)
+	return out, nil
+}
+
+// connect dials the WebSocket and sends the authentication challenge.
+func (w *WebSocketClient) connect(ctx context.Context) (*websocket.Conn, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+w.token)
+
+	conn, _, err := w.dialer.DialContext(ctx, w.url, header)
+	if err != nil {
+		return nil, fmt.Errorf("dial websocket: %w", err)
+	}
+
+	challenge := authChallenge{
+		Seq:    1,
+		Action: "authentication_challenge",
+		Data:   map[string]string{"token": w.token},
+	}
+	if err := conn.WriteJSON(challenge); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("send authentication challenge: %w", err)
+	}
+
+	w.log.Info("Connected to Mattermost WebSocket")
+	return conn, nil
+}
+
+// listenLoop runs the read → reconnect cycle until ctx is cancelled.
+func (w *WebSocketClient) listenLoop(ctx context.Context, conn *websocket.Conn, out chan<- Event) {
+	defer close(out)
+
+	delay := initialReconnectDelay
+	for {
+		w.readLoop(ctx, conn, out)
+		if ctx.Err() != nil {
+			return
+		}
+
+		for {
+			w.log.Info("reconnecting to Mattermost WebSocket", slog.Duration("delay", delay))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			var err error
+			conn, err = w.connect(ctx)
+			if err == nil {
+				delay = initialReconnectDelay
+				break
+			}
+			w.log.Error("failed to reconnect", slog.Any("error", err))
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}
+}
+
+// readLoop reads frames from conn and forwards parsed events to out.
+// Returns when the connection fails or ctx is cancelled.
+func (w *WebSocketClient) readLoop(ctx context.Context, conn *websocket.Conn, out chan<- Event) {
+	done := make(chan struct{})
+	defer close(done)
+
+	// Close the connection on cancellation so ReadJSON unblocks, and keep it alive with pings.
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = conn.Close()
+				return
+			case <-done:
+				_ = conn.Close()
+				return
+			case <-ticker.C:
+				_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second))
+			}
+		}
+	}()
+
+	for {
+		var raw WebSocketEvent
+		if err := conn.ReadJSON(&raw); err != nil {
+			if ctx.Err() == nil {
+				w.log.Warn("WebSocket read failed", slog.Any("error", err))
+			}
+			return
+		}
+
+		// Replies to our own actions (auth challenge) carry no event name.
+		if raw.Event == "" {
+			continue
+		}
+
+		evt, err := parseWebSocketEvent(&raw)
+		if err != nil {
+			w.log.Warn("failed to parse event",
+				slog.String("event", raw.Event),
+				slog.Any("error", err))
+			continue
+		}
+		if evt == nil {
+			continue
+		}
+
+		select {
+		case out <- *evt:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package mattermost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestWebSocketClient_Listen(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	gotToken := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/websocket" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		var challenge authChallenge
+		if err := conn.ReadJSON(&challenge); err != nil {
+			return
+		}
+		gotToken <- challenge.Data["token"]
+
+		_ = conn.WriteJSON(map[string]interface{}{"status": "OK", "seq_reply": 1})
+		_ = conn.WriteJSON(WebSocketEvent{Event: EventHello, Data: map[string]interface{}{}})
+		_ = conn.WriteJSON(WebSocketEvent{
+			Event: EventPosted,
+			Data: map[string]interface{}{
+				"post":        `{"id":"p1","channel_id":"c1","user_id":"u1","message":"fix login"}`,
+				"sender_name": "@alice",
+			},
+		})
+
+		// Hold the connection until the client goes away.
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ws := NewWebSocketClient(NewClient(server.URL, testutil.FakeMattermostToken))
+	events, err := ws.Listen(ctx)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	select {
+	case token := <-gotToken:
+		if token != testutil.FakeMattermostToken {
+			t.Errorf("challenge token = %q", token)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never received authentication challenge")
+	}
+
+	select {
+	case evt := <-events:
+		if evt.Type != EventPosted || evt.Post.Message != "fix login" || evt.SenderName != "alice" {
+			t.Errorf("unexpected event: %+v", evt)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for posted event")
+	}
+
+	cancel()
+	for range events {
+	}
+}
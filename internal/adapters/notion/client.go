@@ -0,0 +1,280 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Notion public API endpoint.
+	DefaultBaseURL = "https://api.notion.com"
+
+	// APIVersion is the Notion-Version header value the adapter is written against.
+	APIVersion = "2022-06-28"
+
+	// maxRichTextLength is the per-segment content limit enforced by the Notion API.
+	maxRichTextLength = 2000
+
+	// maxBlockDepth bounds recursion when fetching nested block children.
+	maxBlockDepth = 3
+)
+
+// Client is a Notion REST API client.
+// Auth: Bearer integration token + Notion-Version header.
+// Rate limit: ~3 req/s average (respects Retry-After header).
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// ClientOption configures the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the default HTTP client.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the API base URL (used in tests).
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a new Notion API client.
+func NewClient(apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL: DefaultBaseURL,
+		apiKey:  apiKey,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// doRequest performs an HTTP request to the Notion API.
+// It handles JSON marshalling, auth headers, rate-limit retries, and error responses.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Notion-Version", APIVersion)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Handle rate limiting (429)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		secs, parseErr := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if parseErr != nil || secs <= 0 {
+			secs = 1 // default backoff
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(secs) * time.Second):
+		}
+		// Retry once after waiting
+		return c.doRequest(ctx, method, path, body, result)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetDatabase fetches a database schema.
+func (c *Client) GetDatabase(ctx context.Context, databaseID string) (*Database, error) {
+	var db Database
+	if err := c.doRequest(ctx, http.MethodGet, "/v1/databases/"+databaseID, nil, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// QueryDatabase returns all pages in a database matching the given filter.
+// Pass a nil filter to list every page. Pagination is followed until exhausted.
+func (c *Client) QueryDatabase(ctx context.Context, databaseID string, filter map[string]interface{}) ([]Page, error) {
+	var pages []Page
+	cursor := ""
+	for {
+		body := map[string]interface{}{
+			"page_size": 100,
+			"sorts": []map[string]string{
+				{"timestamp": "created_time", "direction": "ascending"},
+			},
+		}
+		if filter != nil {
+			body["filter"] = filter
+		}
+		if cursor != "" {
+			body["start_cursor"] = cursor
+		}
+
+		var resp queryResponse
+		if err := c.doRequest(ctx, http.MethodPost, "/v1/databases/"+databaseID+"/query", body, &resp); err != nil {
+			return nil, err
+		}
+		pages = append(pages, resp.Results...)
+
+		if !resp.HasMore || resp.NextCursor == "" {
+			return pages, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// GetPage fetches a single page by ID.
+func (c *Client) GetPage(ctx context.Context, pageID string) (*Page, error) {
+	var page Page
+	if err := c.doRequest(ctx, http.MethodGet, "/v1/pages/"+pageID, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetBlockChildren fetches the content blocks of a page or block.
+// Nested children are fetched recursively up to maxBlockDepth levels.
+func (c *Client) GetBlockChildren(ctx context.Context, blockID string) ([]Block, error) {
+	return c.getBlockChildren(ctx, blockID, 1)
+}
+
+func (c *Client) getBlockChildren(ctx context.Context, blockID string, depth int) ([]Block, error) {
+	var blocks []Block
+	cursor := ""
+	for {
+		path := "/v1/blocks/" + blockID + "/children?page_size=100"
+		if cursor != "" {
+			path += "&start_cursor=" + url.QueryEscape(cursor)
+		}
+
+		var resp blocksResponse
+		if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, resp.Results...)
+
+		if !resp.HasMore || resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	if depth >= maxBlockDepth {
+		return blocks, nil
+	}
+	for i := range blocks {
+		if !blocks[i].HasChildren {
+			continue
+		}
+		children, err := c.getBlockChildren(ctx, blocks[i].ID, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch children of block %s: %w", blocks[i].ID, err)
+		}
+		blocks[i].Children = children
+	}
+	return blocks, nil
+}
+
+// UpdatePageProperties patches page properties.
+// The properties map is sent verbatim as the "properties" field.
+func (c *Client) UpdatePageProperties(ctx context.Context, pageID string, properties map[string]interface{}) error {
+	body := map[string]interface{}{
+		"properties": properties,
+	}
+	return c.doRequest(ctx, http.MethodPatch, "/v1/pages/"+pageID, body, nil)
+}
+
+// SetStatus sets a status- or select-typed property to the named option.
+// propertyType must be PropertyTypeStatus or PropertyTypeSelect.
+func (c *Client) SetStatus(ctx context.Context, pageID, propertyName, propertyType, value string) error {
+	if propertyType != PropertyTypeStatus && propertyType != PropertyTypeSelect {
+		return fmt.Errorf("SetStatus: unsupported property type %q", propertyType)
+	}
+	return c.UpdatePageProperties(ctx, pageID, map[string]interface{}{
+		propertyName: map[string]interface{}{
+			propertyType: map[string]string{"name": value},
+		},
+	})
+}
+
+// AddComment posts a plain-text comment on a page.
+// Text longer than the API's per-segment limit is split across segments.
+func (c *Client) AddComment(ctx context.Context, pageID, text string) error {
+	body := map[string]interface{}{
+		"parent":    map[string]string{"page_id": pageID},
+		"rich_text": splitRichText(text),
+	}
+	return c.doRequest(ctx, http.MethodPost, "/v1/comments", body, nil)
+}
+
+// ListComments fetches the comments on a page.
+func (c *Client) ListComments(ctx context.Context, pageID string) ([]Comment, error) {
+	var resp struct {
+		Results []Comment `json:"results"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, "/v1/comments?block_id="+url.QueryEscape(pageID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// splitRichText converts text into rich text segments no longer than maxRichTextLength runes.
+func splitRichText(text string) []RichText {
+	runes := []rune(text)
+	segments := make([]RichText, 0, len(runes)/maxRichTextLength+1)
+	for len(runes) > maxRichTextLength {
+		segments = append(segments, RichText{Type: "text", Text: &TextContent{Content: string(runes[:maxRichTextLength])}})
+		runes = runes[maxRichTextLength:]
+	}
+	segments = append(segments, RichText{Type: "text", Text: &TextContent{Content: string(runes)}})
+	return segments
+}
@@ -0,0 +1,199 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestNewClient(t *testing.T) {
+	c := NewClient(testutil.FakeNotionAPIKey)
+	if c.baseURL != DefaultBaseURL {
+		t.Errorf("expected default base URL, got %s", c.baseURL)
+	}
+
+	c = NewClient(testutil.FakeNotionAPIKey, WithBaseURL("http://localhost:1234/"))
+	if c.baseURL != "http://localhost:1234" {
+		t.Errorf("expected trailing slash stripped, got %s", c.baseURL)
+	}
+}
+
+func TestDoRequest_Headers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+testutil.FakeNotionAPIKey {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("Notion-Version"); got != APIVersion {
+			t.Errorf("Notion-Version = %q", got)
+		}
+		_ = json.NewEncoder(w).Encode(Page{ID: "page-1"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+	page, err := c.GetPage(context.Background(), "page-1")
+	if err != nil {
+		t.Fatalf("GetPage: %v", err)
+	}
+	if page.ID != "page-1" {
+		t.Errorf("page.ID = %q", page.ID)
+	}
+}
+
+func TestDoRequest_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"code":"object_not_found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+	_, err := c.GetPage(context.Background(), "missing")
+	if err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+}
+
+func TestQueryDatabase_Pagination(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/databases/db-1/query" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["filter"] == nil {
+			t.Error("expected filter in body")
+		}
+
+		calls++
+		if calls == 1 {
+			if _, ok := body["start_cursor"]; ok {
+				t.Error("first request should not send start_cursor")
+			}
+			_ = json.NewEncoder(w).Encode(queryResponse{
+				Results:    []Page{{ID: "p1"}},
+				HasMore:    true,
+				NextCursor: "cursor-2",
+			})
+			return
+		}
+		if body["start_cursor"] != "cursor-2" {
+			t.Errorf("start_cursor = %v", body["start_cursor"])
+		}
+		_ = json.NewEncoder(w).Encode(queryResponse{Results: []Page{{ID: "p2"}}})
+	}))
+	defer srv.Close()
+
+	c := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+	pages, err := c.QueryDatabase(context.Background(), "db-1", map[string]interface{}{"property": "Status"})
+	if err != nil {
+		t.Fatalf("QueryDatabase: %v", err)
+	}
+	if len(pages) != 2 || pages[0].ID != "p1" || pages[1].ID != "p2" {
+		t.Errorf("unexpected pages: %+v", pages)
+	}
+}
+
+func TestGetBlockChildren_Nested(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/blocks/page-1/children":
+			_ = json.NewEncoder(w).Encode(blocksResponse{Results: []Block{
+				{ID: "b1", Type: "paragraph", Paragraph: &TextBlock{RichText: []RichText{{PlainText: "Intro"}}}},
+				{ID: "b2", Type: "bulleted_list_item", HasChildren: true, BulletedListItem: &TextBlock{RichText: []RichText{{PlainText: "Parent"}}}},
+			}})
+		case "/v1/blocks/b2/children":
+			_ = json.NewEncoder(w).Encode(blocksResponse{Results: []Block{
+				{ID: "b3", Type: "to_do", ToDo: &ToDoBlock{RichText: []RichText{{PlainText: "Nested check"}}}},
+			}})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+	blocks, err := c.GetBlockChildren(context.Background(), "page-1")
+	if err != nil {
+		t.Fatalf("GetBlockChildren: %v", err)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(blocks))
+	}
+	if len(blocks[1].Children) != 1 || blocks[1].Children[0].ID != "b3" {
+		t.Errorf("expected nested child b3, got %+v", blocks[1].Children)
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		propertyType string
+		wantKey      string
+		wantErr      bool
+	}{
+		{name: "status property", propertyType: PropertyTypeStatus, wantKey: "status"},
+		{name: "select property", propertyType: PropertyTypeSelect, wantKey: "select"},
+		{name: "unsupported type", propertyType: PropertyTypeMultiSelect, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != "/v1/pages/page-1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				var body struct {
+					Properties map[string]map[string]map[string]string `json:"properties"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				if got := body.Properties["Status"][tt.wantKey]["name"]; got != "Done" {
+					t.Errorf("expected Status.%s.name = Done, got %q", tt.wantKey, got)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			c := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+			err := c.SetStatus(context.Background(), "page-1", "Status", tt.propertyType, "Done")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetStatus error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	var body struct {
+		Parent   map[string]string `json:"parent"`
+		RichText []RichText        `json:"rich_text"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/comments" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+	long := strings.Repeat("x", maxRichTextLength+10)
+	if err := c.AddComment(context.Background(), "page-1", long); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if body.Parent["page_id"] != "page-1" {
+		t.Errorf("parent.page_id = %q", body.Parent["page_id"])
+	}
+	if len(body.RichText) != 2 {
+		t.Fatalf("expected text split into 2 segments, got %d", len(body.RichText))
+	}
+	if PlainText(body.RichText) != long {
+		t.Error("split segments do not reassemble to the original text")
+	}
+}
@@ -0,0 +1,91 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PageContent is the task-ready rendering of a Notion page body.
+type PageContent struct {
+	// Description is the page body rendered as Markdown, without checklist items.
+	Description string
+	// AcceptanceCriteria holds the text of every to_do block, in document order.
+	AcceptanceCriteria []string
+}
+
+// ConvertBlocks renders page blocks to Markdown and extracts checklist items
+// as acceptance criteria. Unsupported block types (images, embeds, tables) are skipped.
+func ConvertBlocks(blocks []Block) PageContent {
+	var sb strings.Builder
+	var criteria []string
+	renderBlocks(&sb, &criteria, blocks, 0)
+	return PageContent{
+		Description:        strings.TrimSpace(sb.String()),
+		AcceptanceCriteria: criteria,
+	}
+}
+
+func renderBlocks(sb *strings.Builder, criteria *[]string, blocks []Block, depth int) {
+	indent := strings.Repeat("  ", depth)
+	number := 0
+	inList := false
+	for _, b := range blocks {
+		if b.Type != "numbered_list_item" {
+			number = 0
+		}
+		if b.Type != "to_do" {
+			isList := b.Type == "bulleted_list_item" || b.Type == "numbered_list_item"
+			if inList && !isList {
+				sb.WriteString("\n") // close the list before the next paragraph
+			}
+			inList = isList
+		}
+
+		switch b.Type {
+		case "paragraph":
+			if text := blockText(b.Paragraph); text != "" {
+				sb.WriteString(indent + text + "\n\n")
+			}
+		case "heading_1":
+			sb.WriteString("# " + blockText(b.Heading1) + "\n\n")
+		case "heading_2":
+			sb.WriteString("## " + blockText(b.Heading2) + "\n\n")
+		case "heading_3":
+			sb.WriteString("### " + blockText(b.Heading3) + "\n\n")
+		case "bulleted_list_item":
+			sb.WriteString(indent + "- " + blockText(b.BulletedListItem) + "\n")
+		case "numbered_list_item":
+			number++
+			sb.WriteString(fmt.Sprintf("%s%d. %s\n", indent, number, blockText(b.NumberedListItem)))
+		case "quote":
+			sb.WriteString(indent + "> " + blockText(b.Quote) + "\n\n")
+		case "callout":
+			sb.WriteString(indent + "> " + blockText(b.Callout) + "\n\n")
+		case "toggle":
+			sb.WriteString(indent + blockText(b.Toggle) + "\n\n")
+		case "code":
+			if b.Code != nil {
+				sb.WriteString("```" + b.Code.Language + "\n")
+				sb.WriteString(PlainText(b.Code.RichText) + "\n")
+				sb.WriteString("```\n\n")
+			}
+		case "to_do":
+			if b.ToDo != nil {
+				if text := strings.TrimSpace(PlainText(b.ToDo.RichText)); text != "" {
+					*criteria = append(*criteria, text)
+				}
+			}
+		}
+
+		if len(b.Children) > 0 {
+			renderBlocks(sb, criteria, b.Children, depth+1)
+		}
+	}
+}
+
+func blockText(tb *TextBlock) string {
+	if tb == nil {
+		return ""
+	}
+	return PlainText(tb.RichText)
+}
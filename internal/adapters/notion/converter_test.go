@@ -0,0 +1,63 @@
+package notion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func text(s string) []RichText {
+	return []RichText{{PlainText: s}}
+}
+
+func TestConvertBlocks(t *testing.T) {
+	blocks := []Block{
+		{Type: "heading_2", Heading2: &TextBlock{RichText: text("Context")}},
+		{Type: "paragraph", Paragraph: &TextBlock{RichText: text("Add rate limiting to the API.")}},
+		{Type: "bulleted_list_item", BulletedListItem: &TextBlock{RichText: text("Per user")},
+			Children: []Block{
+				{Type: "bulleted_list_item", BulletedListItem: &TextBlock{RichText: text("Configurable")}},
+			}},
+		{Type: "numbered_list_item", NumberedListItem: &TextBlock{RichText: text("First")}},
+		{Type: "numbered_list_item", NumberedListItem: &TextBlock{RichText: text("Second")}},
+		{Type: "code", Code: &CodeBlock{RichText: text("limit: 100"), Language: "yaml"}},
+		{Type: "to_do", ToDo: &ToDoBlock{RichText: text("Returns 429 when exceeded")}},
+		{Type: "to_do", ToDo: &ToDoBlock{RichText: text("Tests cover burst traffic"), Checked: true}},
+		{Type: "to_do", ToDo: &ToDoBlock{RichText: text("   ")}},
+		{Type: "image"},
+	}
+
+	got := ConvertBlocks(blocks)
+
+	wantDesc := "## Context\n\n" +
+		"Add rate limiting to the API.\n\n" +
+		"- Per user\n" +
+		"  - Configurable\n" +
+		"1. First\n" +
+		"2. Second\n\n" +
+		"```yaml\nlimit: 100\n```"
+	if got.Description != wantDesc {
+		t.Errorf("Description mismatch:\ngot:\n%s\nwant:\n%s", got.Description, wantDesc)
+	}
+
+	wantCriteria := []string{"Returns 429 when exceeded", "Tests cover burst traffic"}
+	if !reflect.DeepEqual(got.AcceptanceCriteria, wantCriteria) {
+		t.Errorf("AcceptanceCriteria = %v, want %v", got.AcceptanceCriteria, wantCriteria)
+	}
+}
+
+func TestConvertBlocks_Empty(t *testing.T) {
+	got := ConvertBlocks(nil)
+	if got.Description != "" || len(got.AcceptanceCriteria) != 0 {
+		t.Errorf("expected empty content, got %+v", got)
+	}
+}
+
+func TestPageTitle(t *testing.T) {
+	page := Page{Properties: map[string]Property{
+		"Status": {Type: PropertyTypeStatus, Status: &SelectOption{Name: "Pilot"}},
+		"Name":   {Type: PropertyTypeTitle, Title: []RichText{{PlainText: "Add "}, {Text: &TextContent{Content: "caching"}}}},
+	}}
+	if got := page.Title(); got != "Add caching" {
+		t.Errorf("Title() = %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// Notifier handles status comments on Notion pages.
+type Notifier struct {
+	client *Client
+}
+
+// NewNotifier creates a new Notion notifier.
+func NewNotifier(client *Client) *Notifier {
+	return &Notifier{client: client}
+}
+
+// NotifyTaskStarted posts a comment when Pilot starts working on a page.
+func (n *Notifier) NotifyTaskStarted(ctx context.Context, pageID, taskID string) error {
+	comment := fmt.Sprintf("🤖 Pilot started working on this page\nTask ID: %s\nI'll post updates as I make progress.", taskID)
+	if err := n.client.AddComment(ctx, pageID, comment); err != nil {
+		return fmt.Errorf("failed to add start comment: %w", err)
+	}
+
+	logging.WithComponent("notion").Info("Notified task started",
+		slog.String("page_id", pageID),
+		slog.String("task_id", taskID))
+
+	return nil
+}
+
+// NotifyTaskCompleted posts a completion comment.
+func (n *Notifier) NotifyTaskCompleted(ctx context.Context, pageID, prURL, summary string) error {
+	comment := "✅ Pilot completed this task!"
+	if prURL != "" {
+		comment += "\nPull Request: " + prURL
+	}
+	if summary != "" {
+		comment += "\nSummary: " + summary
+	}
+
+	if err := n.client.AddComment(ctx, pageID, comment); err != nil {
+		return fmt.Errorf("failed to add completion comment: %w", err)
+	}
+
+	logging.WithComponent("notion").Info("Notified task completed",
+		slog.String("page_id", pageID),
+		slog.String("pr_url", prURL))
+
+	return nil
+}
+
+// NotifyTaskFailed posts a failure comment.
+func (n *Notifier) NotifyTaskFailed(ctx context.Context, pageID, reason string) error {
+	comment := fmt.Sprintf("❌ Pilot could not complete this task\nReason: %s\nPlease review the page and move it back to the pilot status to retry.", reason)
+	if err := n.client.AddComment(ctx, pageID, comment); err != nil {
+		return fmt.Errorf("failed to add failure comment: %w", err)
+	}
+
+	logging.WithComponent("notion").Warn("Notified task failed",
+		slog.String("page_id", pageID),
+		slog.String("reason", reason))
+
+	return nil
+}
+
+// LinkPR posts a comment linking the created PR.
+func (n *Notifier) LinkPR(ctx context.Context, pageID string, prNumber int, prURL string) error {
+	comment := fmt.Sprintf("🔗 Pull Request Created: PR #%d\n%s", prNumber, prURL)
+	if err := n.client.AddComment(ctx, pageID, comment); err != nil {
+		return fmt.Errorf("failed to add PR link comment: %w", err)
+	}
+
+	logging.WithComponent("notion").Info("Linked PR to page",
+		slog.String("page_id", pageID),
+		slog.Int("pr_number", prNumber))
+
+	return nil
+}
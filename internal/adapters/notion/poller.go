@@ -0,0 +1,444 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// AdapterName is the key used for the generic processed store.
+const AdapterName = "notion"
+
+// PageResult is returned by the page handler.
+type PageResult struct {
+	Success    bool
+	PRNumber   int
+	PRURL      string
+	HeadSHA    string // Head commit SHA of the PR (for autopilot wiring)
+	BranchName string // Head branch name e.g. "pilot/NOTION-1a2b3c4d"
+	Error      error
+}
+
+// Poller polls a Notion database for pages queued for Pilot.
+// A page is queued when its status property equals PilotStatus, or — when
+// TagsProperty is configured — when its tags contain PilotTag.
+type Poller struct {
+	client   *Client
+	config   *Config
+	interval time.Duration
+
+	processed map[string]bool // Page ID → processed
+	mu        sync.RWMutex
+
+	onPage      func(ctx context.Context, page *Page) (*PageResult, error)
+	onPRCreated func(prNumber int, prURL, pageID, headSHA, branchName string)
+	logger      *slog.Logger
+
+	// Status property type ("status" or "select"), resolved from the database schema on startup
+	statusType string
+
+	// Persistent processed store (optional), keyed under AdapterName
+	processedStore adapters.ProcessedStore
+
+	// Parallel execution configuration
+	maxConcurrent int
+	semaphore     chan struct{}
+	activeWg      sync.WaitGroup
+	stopping      atomic.Bool
+	wgMu          sync.Mutex // protects stopping + activeWg Add/Wait coordination
+}
+
+// PollerOption configures a Poller.
+type PollerOption func(*Poller)
+
+// WithOnPage sets the callback for newly queued pages.
+func WithOnPage(fn func(ctx context.Context, page *Page) (*PageResult, error)) PollerOption {
+	return func(p *Poller) {
+		p.onPage = fn
+	}
+}
+
+// WithPollerLogger sets the logger for the poller.
+func WithPollerLogger(logger *slog.Logger) PollerOption {
+	return func(p *Poller) {
+		p.logger = logger
+	}
+}
+
+// WithProcessedStore sets the persistent store for processed page tracking.
+// On startup, processed pages are loaded from the store to prevent re-processing after hot upgrade.
+func WithProcessedStore(store adapters.ProcessedStore) PollerOption {
+	return func(p *Poller) {
+		p.processedStore = store
+	}
+}
+
+// WithMaxConcurrent sets the maximum number of parallel page executions.
+func WithMaxConcurrent(n int) PollerOption {
+	return func(p *Poller) {
+		if n < 1 {
+			n = 1
+		}
+		p.maxConcurrent = n
+	}
+}
+
+// WithOnPRCreated sets the callback for when a PR is created for a page.
+func WithOnPRCreated(fn func(prNumber int, prURL, pageID, headSHA, branchName string)) PollerOption {
+	return func(p *Poller) {
+		p.onPRCreated = fn
+	}
+}
+
+// NewPoller creates a new Notion database poller.
+func NewPoller(client *Client, config *Config, interval time.Duration, opts ...PollerOption) *Poller {
+	p := &Poller{
+		client:    client,
+		config:    config,
+		interval:  interval,
+		processed: make(map[string]bool),
+		logger:    logging.WithComponent("notion-poller"),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Load processed pages from persistent store if available
+	if p.processedStore != nil {
+		loaded, err := p.processedStore.LoadAdapterProcessed(AdapterName)
+		if err != nil {
+			p.logger.Warn("Failed to load processed pages from store", slog.Any("error", err))
+		} else if len(loaded) > 0 {
+			p.mu.Lock()
+			for id := range loaded {
+				p.processed[id] = true
+			}
+			p.mu.Unlock()
+			p.logger.Info("Loaded processed pages from store", slog.Int("count", len(loaded)))
+		}
+	}
+
+	if p.maxConcurrent < 1 {
+		p.maxConcurrent = 2 // default
+	}
+	p.semaphore = make(chan struct{}, p.maxConcurrent)
+
+	return p
+}
+
+// Start begins polling the database.
+func (p *Poller) Start(ctx context.Context) error {
+	if err := p.resolveStatusType(ctx); err != nil {
+		return fmt.Errorf("failed to resolve status property: %w", err)
+	}
+
+	p.logger.Info("Starting Notion poller",
+		slog.String("database", p.config.DatabaseID),
+		slog.String("status_property", p.statusProperty()),
+		slog.Duration("interval", p.interval),
+		slog.Int("max_concurrent", p.maxConcurrent),
+	)
+
+	// Initial check
+	p.checkForNewPages(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Notion poller stopping, waiting for active tasks...")
+			p.wgMu.Lock()
+			p.stopping.Store(true)
+			p.wgMu.Unlock()
+			p.activeWg.Wait()
+			p.logger.Info("Notion poller stopped")
+			return nil
+		case <-ticker.C:
+			p.checkForNewPages(ctx)
+		}
+	}
+}
+
+// resolveStatusType reads the database schema to learn whether the status
+// property is a "status" or "select" column; the two use different filter
+// and update payloads.
+func (p *Poller) resolveStatusType(ctx context.Context) error {
+	db, err := p.client.GetDatabase(ctx, p.config.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	name := p.statusProperty()
+	prop, ok := db.Properties[name]
+	if !ok {
+		return fmt.Errorf("property %q not found in database %s", name, p.config.DatabaseID)
+	}
+	if prop.Type != PropertyTypeStatus && prop.Type != PropertyTypeSelect {
+		return fmt.Errorf("property %q has type %q, expected status or select", name, prop.Type)
+	}
+	p.statusType = prop.Type
+
+	if p.config.TagsProperty != "" {
+		tags, ok := db.Properties[p.config.TagsProperty]
+		if !ok || tags.Type != PropertyTypeMultiSelect {
+			return fmt.Errorf("tags property %q not found or not multi_select", p.config.TagsProperty)
+		}
+	}
+	return nil
+}
+
+// queryFilter builds the database filter selecting queued pages.
+func (p *Poller) queryFilter() map[string]interface{} {
+	if p.config.TagsProperty != "" {
+		return map[string]interface{}{
+			"property":     p.config.TagsProperty,
+			"multi_select": map[string]string{"contains": p.pilotTag()},
+		}
+	}
+	return map[string]interface{}{
+		"property":   p.statusProperty(),
+		p.statusType: map[string]string{"equals": p.pilotStatus()},
+	}
+}
+
+func (p *Poller) checkForNewPages(ctx context.Context) {
+	pages, err := p.client.QueryDatabase(ctx, p.config.DatabaseID, p.queryFilter())
+	if err != nil {
+		p.logger.Warn("Failed to query Notion database",
+			slog.String("database", p.config.DatabaseID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	// Sort by creation date (oldest first)
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].CreatedTime.Before(pages[j].CreatedTime)
+	})
+
+	for _, page := range pages {
+		if page.Archived {
+			continue
+		}
+
+		// Skip if already processed
+		p.mu.RLock()
+		processed := p.processed[page.ID]
+		p.mu.RUnlock()
+
+		if processed {
+			continue
+		}
+
+		// Skip pages already moved through the lifecycle (tag pickup only;
+		// status pickup never returns them)
+		if status := p.pageStatus(&page); p.isLifecycleStatus(status) {
+			if p.config.Statuses != nil && status == p.config.Statuses.Done {
+				p.markProcessed(page.ID, "done")
+			}
+			continue
+		}
+
+		// Mark processed immediately to prevent duplicate dispatch on next tick
+		p.markProcessed(page.ID, "processed")
+
+		// Acquire semaphore slot (blocks if max_concurrent reached)
+		select {
+		case <-ctx.Done():
+			return
+		case p.semaphore <- struct{}{}:
+		}
+
+		p.logger.Info("Dispatching Notion page for parallel execution",
+			slog.String("id", page.ID),
+			slog.String("title", page.Title()),
+		)
+
+		// Use mutex to coordinate stopping flag check with WaitGroup Add
+		p.wgMu.Lock()
+		if p.stopping.Load() {
+			p.wgMu.Unlock()
+			<-p.semaphore // release slot we acquired
+			return
+		}
+		p.activeWg.Add(1)
+		p.wgMu.Unlock()
+
+		go p.processPageAsync(ctx, page)
+	}
+}
+
+// processPageAsync handles a single page in a goroutine.
+func (p *Poller) processPageAsync(ctx context.Context, page Page) {
+	defer p.activeWg.Done()
+	defer func() { <-p.semaphore }() // release slot
+
+	if p.onPage == nil {
+		return
+	}
+
+	if p.config.Statuses != nil {
+		p.setStatus(ctx, page.ID, p.config.Statuses.InProgress)
+	}
+
+	result, err := p.onPage(ctx, &page)
+	if err != nil || result == nil || !result.Success {
+		if err != nil {
+			p.logger.Error("Failed to process Notion page",
+				slog.String("id", page.ID),
+				slog.Any("error", err),
+			)
+		}
+		// Once the page carries the failed status it no longer matches the
+		// pickup filter, so clear it from the processed set: moving it back
+		// to the pilot status re-queues it.
+		if p.config.Statuses != nil && p.setStatus(ctx, page.ID, p.config.Statuses.Failed) {
+			p.ClearProcessed(page.ID)
+		}
+		return
+	}
+
+	if p.config.Statuses != nil {
+		p.setStatus(ctx, page.ID, p.config.Statuses.Done)
+	}
+	p.markProcessed(page.ID, "done")
+
+	// Fire OnPRCreated callback
+	if result.PRNumber > 0 && p.onPRCreated != nil {
+		p.onPRCreated(result.PRNumber, result.PRURL, page.ID, result.HeadSHA, result.BranchName)
+	}
+}
+
+// setStatus updates the page status property, logging failures.
+// Returns true if the status was written. Empty values are skipped.
+func (p *Poller) setStatus(ctx context.Context, pageID, value string) bool {
+	if value == "" || p.statusType == "" {
+		return false
+	}
+	if err := p.client.SetStatus(ctx, pageID, p.statusProperty(), p.statusType, value); err != nil {
+		p.logger.Warn("Failed to update Notion page status",
+			slog.String("id", pageID),
+			slog.String("status", value),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return true
+}
+
+// pageStatus returns the current status option name of a page.
+func (p *Poller) pageStatus(page *Page) string {
+	prop, ok := page.Properties[p.statusProperty()]
+	if !ok {
+		return ""
+	}
+	return prop.OptionName()
+}
+
+// isLifecycleStatus reports whether status is one of the in-progress, done, or failed values.
+func (p *Poller) isLifecycleStatus(status string) bool {
+	if status == "" || p.config.Statuses == nil {
+		return false
+	}
+	s := p.config.Statuses
+	return status == s.InProgress || status == s.Done || status == s.Failed
+}
+
+func (p *Poller) statusProperty() string {
+	if p.config.StatusProperty == "" {
+		return "Status"
+	}
+	return p.config.StatusProperty
+}
+
+func (p *Poller) pilotStatus() string {
+	if p.config.PilotStatus == "" {
+		return "Pilot"
+	}
+	return p.config.PilotStatus
+}
+
+func (p *Poller) pilotTag() string {
+	if p.config.PilotTag == "" {
+		return "pilot"
+	}
+	return p.config.PilotTag
+}
+
+func (p *Poller) markProcessed(id, result string) {
+	p.mu.Lock()
+	p.processed[id] = true
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.MarkAdapterProcessed(AdapterName, id, result); err != nil {
+			p.logger.Warn("Failed to persist processed page", slog.String("id", id), slog.Any("error", err))
+		}
+	}
+}
+
+// IsProcessed checks if a page has been processed.
+func (p *Poller) IsProcessed(id string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.processed[id]
+}
+
+// ProcessedCount returns the number of processed pages.
+func (p *Poller) ProcessedCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.processed)
+}
+
+// Reset clears the processed pages map.
+func (p *Poller) Reset() {
+	p.mu.Lock()
+	p.processed = make(map[string]bool)
+	p.mu.Unlock()
+}
+
+// ClearProcessed removes a specific page from the processed map (for retry).
+func (p *Poller) ClearProcessed(id string) {
+	p.mu.Lock()
+	delete(p.processed, id)
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.UnmarkAdapterProcessed(AdapterName, id); err != nil {
+			p.logger.Warn("Failed to unmark page in store",
+				slog.String("id", id),
+				slog.Any("error", err))
+		}
+	}
+}
+
+// Drain stops accepting new pages and waits for active executions to finish.
+// Used during hot upgrade to let in-flight work complete before process restart.
+func (p *Poller) Drain() {
+	p.logger.Info("Draining poller — no new pages will be accepted")
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+	p.logger.Info("Poller drained — all active tasks completed")
+}
+
+// WaitForActive waits for all active parallel goroutines to finish.
+// Used in tests to synchronize after checkForNewPages.
+func (p *Poller) WaitForActive() {
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+}
@@ -0,0 +1,229 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// fakeNotion is a minimal Notion API stub recording status updates.
+type fakeNotion struct {
+	mu         sync.Mutex
+	statusType string
+	pages      []Page
+	filters    []map[string]interface{}
+	updates    map[string][]string // page ID → status values written
+}
+
+func (f *fakeNotion) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/databases/db-1":
+			_ = json.NewEncoder(w).Encode(Database{ID: "db-1", Properties: map[string]SchemaProperty{
+				"Name":   {Name: "Name", Type: PropertyTypeTitle},
+				"Status": {Name: "Status", Type: f.statusType},
+				"Tags":   {Name: "Tags", Type: PropertyTypeMultiSelect},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/databases/db-1/query":
+			var body struct {
+				Filter map[string]interface{} `json:"filter"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.filters = append(f.filters, body.Filter)
+			_ = json.NewEncoder(w).Encode(queryResponse{Results: f.pages})
+		case r.Method == http.MethodPatch:
+			pageID := r.URL.Path[len("/v1/pages/"):]
+			var body struct {
+				Properties map[string]map[string]map[string]string `json:"properties"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if f.updates == nil {
+				f.updates = make(map[string][]string)
+			}
+			f.updates[pageID] = append(f.updates[pageID], body.Properties["Status"][f.statusType]["name"])
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func (f *fakeNotion) statusUpdates(pageID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.updates[pageID]...)
+}
+
+func statusPage(id, status string) Page {
+	return Page{
+		ID:          id,
+		CreatedTime: time.Now(),
+		Properties: map[string]Property{
+			"Name":   {Type: PropertyTypeTitle, Title: []RichText{{PlainText: "Task " + id}}},
+			"Status": {Type: PropertyTypeStatus, Status: &SelectOption{Name: status}},
+		},
+	}
+}
+
+func newTestPoller(t *testing.T, fake *fakeNotion, cfg *Config, opts ...PollerOption) *Poller {
+	t.Helper()
+	srv := httptest.NewServer(fake.handler(t))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL))
+	p := NewPoller(client, cfg, time.Minute, opts...)
+	if err := p.resolveStatusType(context.Background()); err != nil {
+		t.Fatalf("resolveStatusType: %v", err)
+	}
+	return p
+}
+
+func testConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.DatabaseID = "db-1"
+	return cfg
+}
+
+func TestPoller_ResolveStatusType(t *testing.T) {
+	for _, typ := range []string{PropertyTypeStatus, PropertyTypeSelect} {
+		fake := &fakeNotion{statusType: typ}
+		p := newTestPoller(t, fake, testConfig())
+		if p.statusType != typ {
+			t.Errorf("statusType = %q, want %q", p.statusType, typ)
+		}
+		filter := p.queryFilter()
+		if filter["property"] != "Status" || filter[typ] == nil {
+			t.Errorf("unexpected filter for %s: %v", typ, filter)
+		}
+	}
+}
+
+func TestPoller_ResolveStatusType_MissingProperty(t *testing.T) {
+	fake := &fakeNotion{statusType: PropertyTypeStatus}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.StatusProperty = "Stage"
+	p := NewPoller(NewClient(testutil.FakeNotionAPIKey, WithBaseURL(srv.URL)), cfg, time.Minute)
+	if err := p.resolveStatusType(context.Background()); err == nil {
+		t.Fatal("expected error for missing status property")
+	}
+}
+
+func TestPoller_SuccessTransitionsToDone(t *testing.T) {
+	fake := &fakeNotion{statusType: PropertyTypeStatus, pages: []Page{statusPage("page-1", "Pilot")}}
+
+	var handled []string
+	var mu sync.Mutex
+	p := newTestPoller(t, fake, testConfig(), WithOnPage(func(_ context.Context, page *Page) (*PageResult, error) {
+		mu.Lock()
+		handled = append(handled, page.ID)
+		mu.Unlock()
+		return &PageResult{Success: true, PRNumber: 7}, nil
+	}))
+
+	p.checkForNewPages(context.Background())
+	p.WaitForActive()
+
+	if len(handled) != 1 || handled[0] != "page-1" {
+		t.Fatalf("expected page-1 handled once, got %v", handled)
+	}
+	got := fake.statusUpdates("page-1")
+	if len(got) != 2 || got[0] != "In progress" || got[1] != "Done" {
+		t.Errorf("status updates = %v, want [In progress Done]", got)
+	}
+	if !p.IsProcessed("page-1") {
+		t.Error("expected page-1 to be processed")
+	}
+}
+
+func TestPoller_FailureTransitionsToFailedAndAllowsRetry(t *testing.T) {
+	fake := &fakeNotion{statusType: PropertyTypeSelect, pages: []Page{statusPage("page-1", "Pilot")}}
+
+	p := newTestPoller(t, fake, testConfig(), WithOnPage(func(_ context.Context, _ *Page) (*PageResult, error) {
+		return &PageResult{Success: false}, errors.New("boom")
+	}))
+
+	p.checkForNewPages(context.Background())
+	p.WaitForActive()
+
+	got := fake.statusUpdates("page-1")
+	if len(got) != 2 || got[1] != "Failed" {
+		t.Errorf("status updates = %v, want [In progress Failed]", got)
+	}
+	if p.IsProcessed("page-1") {
+		t.Error("failed page should be cleared so it can be re-queued")
+	}
+}
+
+func TestPoller_TagPickupSkipsLifecycleStatuses(t *testing.T) {
+	tagged := func(id, status string) Page {
+		page := statusPage(id, status)
+		page.Properties["Tags"] = Property{Type: PropertyTypeMultiSelect, MultiSelect: []SelectOption{{Name: "pilot"}}}
+		return page
+	}
+	fake := &fakeNotion{statusType: PropertyTypeStatus, pages: []Page{
+		tagged("new", "Not started"),
+		tagged("running", "In progress"),
+		tagged("done", "Done"),
+	}}
+
+	cfg := testConfig()
+	cfg.TagsProperty = "Tags"
+
+	var handled []string
+	var mu sync.Mutex
+	p := newTestPoller(t, fake, cfg, WithOnPage(func(_ context.Context, page *Page) (*PageResult, error) {
+		mu.Lock()
+		handled = append(handled, page.ID)
+		mu.Unlock()
+		return &PageResult{Success: true}, nil
+	}))
+
+	if filter := p.queryFilter(); filter["property"] != "Tags" {
+		t.Errorf("expected tag filter, got %v", filter)
+	}
+
+	p.checkForNewPages(context.Background())
+	p.WaitForActive()
+
+	if len(handled) != 1 || handled[0] != "new" {
+		t.Errorf("expected only 'new' handled, got %v", handled)
+	}
+	if !p.IsProcessed("done") {
+		t.Error("done page should be marked processed")
+	}
+	if p.IsProcessed("running") {
+		t.Error("in-progress page should not be marked processed")
+	}
+}
+
+func TestPoller_SkipsProcessed(t *testing.T) {
+	fake := &fakeNotion{statusType: PropertyTypeStatus, pages: []Page{statusPage("page-1", "Pilot")}}
+
+	calls := 0
+	p := newTestPoller(t, fake, testConfig(), WithOnPage(func(_ context.Context, _ *Page) (*PageResult, error) {
+		calls++
+		return &PageResult{Success: true}, nil
+	}), WithMaxConcurrent(1))
+
+	p.markProcessed("page-1", "processed")
+	p.checkForNewPages(context.Background())
+	p.WaitForActive()
+
+	if calls != 0 {
+		t.Errorf("expected processed page to be skipped, got %d calls", calls)
+	}
+}
@@ -0,0 +1,213 @@
+package notion
+
+import (
+	"strings"
+	"time"
+)
+
+// Config holds Notion adapter configuration.
+type Config struct {
+	Enabled        bool           `yaml:"enabled"`
+	APIKey         string         `yaml:"api_key"`         // Internal integration secret
+	DatabaseID     string         `yaml:"database_id"`     // Database polled for pilot pages
+	StatusProperty string         `yaml:"status_property"` // default: "Status" (status or select type)
+	PilotStatus    string         `yaml:"pilot_status"`    // default: "Pilot"
+	TagsProperty   string         `yaml:"tags_property"`   // optional multi-select property; enables tag pickup
+	PilotTag       string         `yaml:"pilot_tag"`       // default: "pilot"
+	Statuses       *StatusMapping `yaml:"statuses,omitempty"`
+	Polling        *PollingConfig `yaml:"polling,omitempty"`
+}
+
+// StatusMapping maps Pilot lifecycle stages to status property values.
+// Leave a value empty to skip that transition.
+type StatusMapping struct {
+	InProgress string `yaml:"in_progress"` // default: "In progress"
+	Done       string `yaml:"done"`        // default: "Done"
+	Failed     string `yaml:"failed"`      // default: "Failed"
+}
+
+// PollingConfig holds polling configuration for the Notion adapter.
+type PollingConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultConfig returns default Notion configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:        false,
+		StatusProperty: "Status",
+		PilotStatus:    "Pilot",
+		PilotTag:       "pilot",
+		Statuses: &StatusMapping{
+			InProgress: "In progress",
+			Done:       "Done",
+			Failed:     "Failed",
+		},
+	}
+}
+
+// Property types used by the adapter.
+const (
+	PropertyTypeTitle       = "title"
+	PropertyTypeStatus      = "status"
+	PropertyTypeSelect      = "select"
+	PropertyTypeMultiSelect = "multi_select"
+)
+
+// Page represents a Notion page (a row in a database).
+type Page struct {
+	ID             string              `json:"id"`
+	URL            string              `json:"url"`
+	Archived       bool                `json:"archived"`
+	Properties     map[string]Property `json:"properties"`
+	CreatedTime    time.Time           `json:"created_time"`
+	LastEditedTime time.Time           `json:"last_edited_time"`
+}
+
+// Property is a page property value. Only the fields for the
+// property types Pilot reads are decoded.
+type Property struct {
+	ID          string         `json:"id,omitempty"`
+	Type        string         `json:"type"`
+	Title       []RichText     `json:"title,omitempty"`
+	Status      *SelectOption  `json:"status,omitempty"`
+	Select      *SelectOption  `json:"select,omitempty"`
+	MultiSelect []SelectOption `json:"multi_select,omitempty"`
+}
+
+// SelectOption is a status, select, or multi-select option.
+type SelectOption struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// RichText is a rich text segment. PlainText carries the rendered content.
+type RichText struct {
+	Type      string       `json:"type,omitempty"`
+	PlainText string       `json:"plain_text,omitempty"`
+	Text      *TextContent `json:"text,omitempty"`
+}
+
+// TextContent is the payload of a "text" rich text segment.
+type TextContent struct {
+	Content string `json:"content"`
+}
+
+// Block represents a Notion content block.
+// Each block type stores its payload under a key named after the type.
+type Block struct {
+	ID               string     `json:"id"`
+	Type             string     `json:"type"`
+	HasChildren      bool       `json:"has_children"`
+	Paragraph        *TextBlock `json:"paragraph,omitempty"`
+	Heading1         *TextBlock `json:"heading_1,omitempty"`
+	Heading2         *TextBlock `json:"heading_2,omitempty"`
+	Heading3         *TextBlock `json:"heading_3,omitempty"`
+	BulletedListItem *TextBlock `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *TextBlock `json:"numbered_list_item,omitempty"`
+	ToDo             *ToDoBlock `json:"to_do,omitempty"`
+	Quote            *TextBlock `json:"quote,omitempty"`
+	Callout          *TextBlock `json:"callout,omitempty"`
+	Toggle           *TextBlock `json:"toggle,omitempty"`
+	Code             *CodeBlock `json:"code,omitempty"`
+
+	// Children is populated by the client for nested blocks; not part of the API payload.
+	Children []Block `json:"-"`
+}
+
+// TextBlock is the payload shared by text-like blocks.
+type TextBlock struct {
+	RichText []RichText `json:"rich_text"`
+}
+
+// ToDoBlock is the payload of a checklist item.
+type ToDoBlock struct {
+	RichText []RichText `json:"rich_text"`
+	Checked  bool       `json:"checked"`
+}
+
+// CodeBlock is the payload of a code block.
+type CodeBlock struct {
+	RichText []RichText `json:"rich_text"`
+	Language string     `json:"language"`
+}
+
+// Database represents a Notion database schema.
+type Database struct {
+	ID         string                    `json:"id"`
+	Properties map[string]SchemaProperty `json:"properties"`
+}
+
+// SchemaProperty describes a database column.
+type SchemaProperty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Comment represents a Notion page comment.
+type Comment struct {
+	ID          string     `json:"id"`
+	RichText    []RichText `json:"rich_text"`
+	CreatedTime time.Time  `json:"created_time"`
+}
+
+// queryResponse wraps the database query API response.
+type queryResponse struct {
+	Results    []Page `json:"results"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// blocksResponse wraps the block children API response.
+type blocksResponse struct {
+	Results    []Block `json:"results"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor string  `json:"next_cursor"`
+}
+
+// PlainText concatenates the plain text of rich text segments.
+func PlainText(segments []RichText) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		if s.PlainText != "" {
+			sb.WriteString(s.PlainText)
+		} else if s.Text != nil {
+			sb.WriteString(s.Text.Content)
+		}
+	}
+	return sb.String()
+}
+
+// Title returns the page title from its title-typed property.
+func (p *Page) Title() string {
+	for _, prop := range p.Properties {
+		if prop.Type == PropertyTypeTitle {
+			return PlainText(prop.Title)
+		}
+	}
+	return ""
+}
+
+// OptionName returns the selected option name for a status or select property.
+func (p *Property) OptionName() string {
+	switch {
+	case p.Status != nil:
+		return p.Status.Name
+	case p.Select != nil:
+		return p.Select.Name
+	}
+	return ""
+}
+
+// HasTag reports whether a multi-select property contains the given option (case-insensitive).
+func (p *Property) HasTag(name string) bool {
+	for _, opt := range p.MultiSelect {
+		if strings.EqualFold(opt.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -42,7 +42,11 @@ func (c *CommandHandler) HandleCommand(ctx context.Context, chatID, text string)
 	case "/status":
 		c.handleStatus(ctx, chatID)
 	case "/cancel":
-		c.handleCancel(ctx, chatID)
+		if len(args) > 0 {
+			c.handleCancelTask(ctx, chatID, args[0])
+		} else {
+			c.handleCancel(ctx, chatID)
+		}
 	case "/queue":
 		c.handleQueue(ctx, chatID)
 	case "/projects":
@@ -98,7 +102,7 @@ I execute tasks and answer questions about your codebase.
 
 Commands
 /status — Current task & queue status
-/cancel — Cancel pending/running task
+/cancel [id] — Cancel pending/running task
 /queue — Show queued tasks
 /projects — List configured projects
 /switch <name> — Switch active project
@@ -132,7 +136,7 @@ I execute tasks and answer questions about your codebase.
 
 *Commands*
 /status — Current task & queue status
-/cancel — Cancel pending/running task
+/cancel [id] — Cancel pending/running task
 /queue — Show queued tasks
 /projects — List configured projects
 /switch <name> — Switch active project
@@ -243,6 +247,21 @@ func (c *CommandHandler) handleCancel(ctx context.Context, chatID string) {
 	_, _ = c.handler.client.SendMessage(ctx, chatID, "No task to cancel.", "")
 }
 
+// handleCancelTask aborts a running task by ID, wherever it was started
+func (c *CommandHandler) handleCancelTask(ctx context.Context, chatID, taskID string) {
+	if c.handler.commsHandler != nil {
+		if err := c.handler.commsHandler.CancelTaskByID(ctx, chatID, taskID); err != nil {
+			_, _ = c.handler.client.SendMessage(ctx, chatID, fmt.Sprintf("❌ %v", err), "")
+		}
+		return
+	}
+	if c.handler.runner == nil || c.handler.runner.Cancel(taskID) != nil {
+		_, _ = c.handler.client.SendMessage(ctx, chatID, fmt.Sprintf("❌ task %s is not running", taskID), "")
+		return
+	}
+	_, _ = c.handler.client.SendMessage(ctx, chatID, fmt.Sprintf("🛑 Cancelling task %s", taskID), "")
+}
+
 // handleQueue shows queued tasks
 func (c *CommandHandler) handleQueue(ctx context.Context, chatID string) {
 	if c.store == nil {
@@ -143,6 +143,11 @@ func TestCommandHandler_HandleCancel(t *testing.T) {
 			// Verify no panic; cancel state managed by commsHandler
 		})
 	}
+
+	t.Run("unknown task id", func(t *testing.T) {
+		cmd.HandleCommand(ctx, "chat1", "/cancel GH-404")
+		// Verify no panic; the runner reports the task is not running
+	})
 }
 
 // TestCommandHandler_HandleQueue tests the /queue command
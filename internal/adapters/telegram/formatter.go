@@ -170,7 +170,7 @@ func formatQualityGatesSummary(qg *executor.QualityGatesResult) string {
 		}
 
 		durationStr := gate.Duration.Round(time.Second).String()
-		sb.WriteString(fmt.Sprintf("- %s %s (%s", gate.Name, icon, durationStr))
+		sb.WriteString(fmt.Sprintf("- %s %s (%s", gate.Label(), icon, durationStr))
 
 		// Add retry count if any
 		if gate.RetryCount > 0 {
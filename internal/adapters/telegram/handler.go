@@ -338,7 +338,11 @@ func (h *Handler) processUpdate(ctx context.Context, update *Update) {
 
 	// Commands stay local
 	if strings.HasPrefix(text, "/") {
-		h.handleCommand(ctx, chatID, text)
+		senderID := ""
+		if msg.From != nil {
+			senderID = strconv.FormatInt(msg.From.ID, 10)
+		}
+		h.handleCommand(ctx, chatID, senderID, text)
 		return
 	}
 
@@ -414,8 +418,16 @@ func (h *Handler) handleCallback(ctx context.Context, callback *CallbackQuery) {
 	}
 }
 
-// handleCommand processes bot commands
-func (h *Handler) handleCommand(ctx context.Context, chatID, text string) {
+// handleCommand processes bot commands, checking the sender's team role
+// against the command permission matrix first
+func (h *Handler) handleCommand(ctx context.Context, chatID, senderID, text string) {
+	if h.commsHandler != nil {
+		if fields := strings.Fields(text); len(fields) > 0 &&
+			!h.commsHandler.AllowCommand(ctx, chatID, senderID, comms.CommandForSlash(fields[0])) {
+			return
+		}
+	}
+
 	// Delegate to command handler
 	h.cmdHandler.HandleCommand(ctx, chatID, text)
 }
@@ -473,6 +485,12 @@ func (h *Handler) handlePhoto(ctx context.Context, chatID string, msg *Message)
 		}
 	}
 
+	// Images run as tasks, so they need the same role as /run
+	if h.commsHandler != nil && msg.From != nil &&
+		!h.commsHandler.AllowCommand(ctx, chatID, strconv.FormatInt(msg.From.ID, 10), comms.CommandForSlash("/run")) {
+		return
+	}
+
 	// Get the largest photo size (last in array)
 	photo := msg.Photo[len(msg.Photo)-1]
 	logging.WithComponent("telegram").Debug("Received photo",
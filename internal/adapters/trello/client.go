@@ -0,0 +1,185 @@
+package trello
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Trello REST API endpoint.
+	DefaultBaseURL = "https://api.trello.com"
+
+	// maxCommentLength is the comment size limit enforced by Trello.
+	maxCommentLength = 16384
+)
+
+// Client is a Trello REST API client.
+// Auth: API key + user token sent in the OAuth Authorization header,
+// which keeps credentials out of request URLs and logs.
+// Rate limit: 100 req/10s per token (respects Retry-After header).
+type Client struct {
+	baseURL    string
+	apiKey     string
+	token      string
+	httpClient *http.Client
+}
+
+// ClientOption configures the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the default HTTP client.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the API base URL (used in tests).
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a new Trello API client.
+func NewClient(apiKey, token string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL: DefaultBaseURL,
+		apiKey:  apiKey,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// doRequest performs an HTTP request to the Trello API.
+// It handles JSON marshalling, auth headers, rate-limit retries, and error responses.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(`OAuth oauth_consumer_key="%s", oauth_token="%s"`, c.apiKey, c.token))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Handle rate limiting (429)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		secs, parseErr := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if parseErr != nil || secs <= 0 {
+			secs = 1 // default backoff
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(secs) * time.Second):
+		}
+		// Retry once after waiting
+		return c.doRequest(ctx, method, path, body, result)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// cardFields limits card payloads to the fields the adapter reads.
+const cardFields = "name,desc,url,shortUrl,shortLink,idList,closed,pos,labels,dateLastActivity"
+
+// ListCards returns the open cards on a list, including their checklists.
+func (c *Client) ListCards(ctx context.Context, listID string) ([]Card, error) {
+	params := url.Values{}
+	params.Set("fields", cardFields)
+	params.Set("checklists", "all")
+
+	var cards []Card
+	path := fmt.Sprintf("/1/lists/%s/cards?%s", url.PathEscape(listID), params.Encode())
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &cards); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// GetCard fetches a card by ID or short link, including its checklists.
+func (c *Client) GetCard(ctx context.Context, cardID string) (*Card, error) {
+	params := url.Values{}
+	params.Set("fields", cardFields)
+	params.Set("checklists", "all")
+
+	var card Card
+	path := fmt.Sprintf("/1/cards/%s?%s", url.PathEscape(cardID), params.Encode())
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &card); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// AddComment posts a comment on a card. Text longer than Trello's limit is truncated.
+func (c *Client) AddComment(ctx context.Context, cardID, text string) (*Comment, error) {
+	if runes := []rune(text); len(runes) > maxCommentLength {
+		text = string(runes[:maxCommentLength-3]) + "..."
+	}
+
+	var comment Comment
+	path := fmt.Sprintf("/1/cards/%s/actions/comments", url.PathEscape(cardID))
+	if err := c.doRequest(ctx, http.MethodPost, path, map[string]string{"text": text}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// MoveCard moves a card to another list, placing it at the top.
+func (c *Client) MoveCard(ctx context.Context, cardID, listID string) error {
+	body := map[string]string{"idList": listID, "pos": "top"}
+	return c.doRequest(ctx, http.MethodPut, "/1/cards/"+url.PathEscape(cardID), body, nil)
+}
+
+// RemoveLabel detaches a label from a card.
+func (c *Client) RemoveLabel(ctx context.Context, cardID, labelID string) error {
+	path := fmt.Sprintf("/1/cards/%s/idLabels/%s", url.PathEscape(cardID), url.PathEscape(labelID))
+	return c.doRequest(ctx, http.MethodDelete, path, nil, nil)
+}
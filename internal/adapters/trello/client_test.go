@@ -0,0 +1,125 @@
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestClient_ListCards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/lists/list-1/cards" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("checklists") != "all" {
+			t.Error("expected checklists=all")
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.Contains(auth, testutil.FakeTrelloAPIKey) || !strings.Contains(auth, testutil.FakeTrelloToken) {
+			t.Errorf("unexpected Authorization header %q", auth)
+		}
+		if strings.Contains(r.URL.RawQuery, testutil.FakeTrelloToken) {
+			t.Error("token must not be sent in the query string")
+		}
+		_, _ = w.Write([]byte(`[{"id":"c1","shortLink":"aB3dE5gH","name":"Add caching","labels":[{"id":"l1","name":"pilot"}],
+			"checklists":[{"id":"cl1","name":"Acceptance","checkItems":[
+				{"id":"i2","name":"Cache invalidates on write","state":"incomplete","pos":2},
+				{"id":"i1","name":"Hit rate metric","state":"complete","pos":1}]}]}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(testutil.FakeTrelloAPIKey, testutil.FakeTrelloToken, WithBaseURL(server.URL))
+	cards, err := client.ListCards(context.Background(), "list-1")
+	if err != nil {
+		t.Fatalf("ListCards: %v", err)
+	}
+	if len(cards) != 1 || cards[0].ShortLink != "aB3dE5gH" {
+		t.Fatalf("unexpected cards: %+v", cards)
+	}
+	if !cards[0].HasLabel("Pilot") {
+		t.Error("label match should be case-insensitive")
+	}
+
+	want := []string{"Hit rate metric", "Cache invalidates on write"}
+	if got := cards[0].AcceptanceCriteria(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AcceptanceCriteria() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_MoveCardAndComment(t *testing.T) {
+	var moved, commented map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/cards/c1":
+			_ = json.NewDecoder(r.Body).Decode(&moved)
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/cards/c1/actions/comments":
+			_ = json.NewDecoder(r.Body).Decode(&commented)
+			_, _ = w.Write([]byte(`{"id":"a1","type":"commentCard"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(testutil.FakeTrelloAPIKey, testutil.FakeTrelloToken, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if err := client.MoveCard(ctx, "c1", "done-list"); err != nil {
+		t.Fatalf("MoveCard: %v", err)
+	}
+	if moved["idList"] != "done-list" {
+		t.Errorf("moved to %q, want done-list", moved["idList"])
+	}
+
+	if _, err := client.AddComment(ctx, "c1", strings.Repeat("x", maxCommentLength+10)); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if n := len([]rune(commented["text"])); n != maxCommentLength {
+		t.Errorf("comment length = %d, want truncated to %d", n, maxCommentLength)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("invalid token"))
+	}))
+	defer server.Close()
+
+	client := NewClient(testutil.FakeTrelloAPIKey, testutil.FakeTrelloToken, WithBaseURL(server.URL))
+	_, err := client.GetCard(context.Background(), "c1")
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("expected 401 API error, got %v", err)
+	}
+}
+
+func TestCardIDFromBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+		ok     bool
+	}{
+		{"pilot/TRELLO-aB3dE5gH", "aB3dE5gH", true},
+		{"TRELLO-aB3dE5gH", "aB3dE5gH", true},
+		{"pilot/GH-42", "", false},
+		{"pilot/TRELLO-", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CardIDFromBranch(tt.branch)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CardIDFromBranch(%q) = %q, %v; want %q, %v", tt.branch, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	card := &Card{ShortLink: "aB3dE5gH"}
+	if id, _ := CardIDFromBranch("pilot/" + TaskID(card)); id != card.ShortLink {
+		t.Errorf("TaskID round trip = %q", id)
+	}
+}
@@ -0,0 +1,69 @@
+package trello
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// Notifier handles status comments and list moves on Trello cards.
+type Notifier struct {
+	client     *Client
+	doneListID string
+}
+
+// NewNotifier creates a new Trello notifier. doneListID may be empty, in
+// which case merged cards stay where they are.
+func NewNotifier(client *Client, doneListID string) *Notifier {
+	return &Notifier{client: client, doneListID: doneListID}
+}
+
+// NotifyTaskStarted posts a comment when Pilot starts working on a card.
+func (n *Notifier) NotifyTaskStarted(ctx context.Context, cardID, taskID string) error {
+	comment := fmt.Sprintf("🤖 **Pilot started working on this card**\n\nTask ID: `%s`", taskID)
+	if _, err := n.client.AddComment(ctx, cardID, comment); err != nil {
+		return fmt.Errorf("failed to add start comment: %w", err)
+	}
+
+	logging.WithComponent("trello").Info("Notified task started",
+		slog.String("card_id", cardID),
+		slog.String("task_id", taskID))
+
+	return nil
+}
+
+// LinkPR posts a comment linking the created PR.
+func (n *Notifier) LinkPR(ctx context.Context, cardID string, prNumber int, prURL string) error {
+	comment := fmt.Sprintf("🔗 **Pull Request Created:** [PR #%d](%s)", prNumber, prURL)
+	if _, err := n.client.AddComment(ctx, cardID, comment); err != nil {
+		return fmt.Errorf("failed to add PR link comment: %w", err)
+	}
+
+	logging.WithComponent("trello").Info("Linked PR to card",
+		slog.String("card_id", cardID),
+		slog.Int("pr_number", prNumber))
+
+	return nil
+}
+
+// NotifyMerged moves the card to the done list and posts a merge comment.
+func (n *Notifier) NotifyMerged(ctx context.Context, cardID string, prNumber int, prURL string) error {
+	if n.doneListID != "" {
+		if err := n.client.MoveCard(ctx, cardID, n.doneListID); err != nil {
+			return fmt.Errorf("failed to move card to done list: %w", err)
+		}
+	}
+
+	comment := fmt.Sprintf("🎉 **Merged:** [PR #%d](%s)", prNumber, prURL)
+	if _, err := n.client.AddComment(ctx, cardID, comment); err != nil {
+		return fmt.Errorf("failed to add merge comment: %w", err)
+	}
+
+	logging.WithComponent("trello").Info("Card completed on merge",
+		slog.String("card_id", cardID),
+		slog.Int("pr_number", prNumber))
+
+	return nil
+}
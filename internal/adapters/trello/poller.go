@@ -0,0 +1,333 @@
+package trello
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// AdapterName is the key used for the generic processed store.
+const AdapterName = "trello"
+
+// CardResult is returned by the card handler.
+type CardResult struct {
+	Success    bool
+	PRNumber   int
+	PRURL      string
+	HeadSHA    string // Head commit SHA of the PR (for autopilot wiring)
+	BranchName string // Head branch name e.g. "pilot/TRELLO-aB3dE5gH"
+	Error      error
+}
+
+// Poller polls a Trello list for cards carrying the pilot label.
+type Poller struct {
+	client   *Client
+	config   *Config
+	interval time.Duration
+
+	processed map[string]bool // Card ID → processed
+	mu        sync.RWMutex
+
+	onCard      func(ctx context.Context, card *Card) (*CardResult, error)
+	onPRCreated func(prNumber int, prURL, cardID, headSHA, branchName string)
+	logger      *slog.Logger
+
+	// Persistent processed store (optional), keyed under AdapterName
+	processedStore adapters.ProcessedStore
+
+	// Parallel execution configuration
+	maxConcurrent int
+	semaphore     chan struct{}
+	activeWg      sync.WaitGroup
+	stopping      atomic.Bool
+	wgMu          sync.Mutex // protects stopping + activeWg Add/Wait coordination
+}
+
+// PollerOption configures a Poller.
+type PollerOption func(*Poller)
+
+// WithOnCard sets the callback for newly labeled cards.
+func WithOnCard(fn func(ctx context.Context, card *Card) (*CardResult, error)) PollerOption {
+	return func(p *Poller) {
+		p.onCard = fn
+	}
+}
+
+// WithPollerLogger sets the logger for the poller.
+func WithPollerLogger(logger *slog.Logger) PollerOption {
+	return func(p *Poller) {
+		p.logger = logger
+	}
+}
+
+// WithProcessedStore sets the persistent store for processed card tracking.
+// On startup, processed cards are loaded from the store to prevent re-processing after hot upgrade.
+func WithProcessedStore(store adapters.ProcessedStore) PollerOption {
+	return func(p *Poller) {
+		p.processedStore = store
+	}
+}
+
+// WithMaxConcurrent sets the maximum number of parallel card executions.
+func WithMaxConcurrent(n int) PollerOption {
+	return func(p *Poller) {
+		if n < 1 {
+			n = 1
+		}
+		p.maxConcurrent = n
+	}
+}
+
+// WithOnPRCreated sets the callback for when a PR is created for a card.
+func WithOnPRCreated(fn func(prNumber int, prURL, cardID, headSHA, branchName string)) PollerOption {
+	return func(p *Poller) {
+		p.onPRCreated = fn
+	}
+}
+
+// NewPoller creates a new Trello list poller.
+func NewPoller(client *Client, config *Config, interval time.Duration, opts ...PollerOption) *Poller {
+	p := &Poller{
+		client:    client,
+		config:    config,
+		interval:  interval,
+		processed: make(map[string]bool),
+		logger:    logging.WithComponent("trello-poller"),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Load processed cards from persistent store if available
+	if p.processedStore != nil {
+		loaded, err := p.processedStore.LoadAdapterProcessed(AdapterName)
+		if err != nil {
+			p.logger.Warn("Failed to load processed cards from store", slog.Any("error", err))
+		} else if len(loaded) > 0 {
+			p.mu.Lock()
+			for id := range loaded {
+				p.processed[id] = true
+			}
+			p.mu.Unlock()
+			p.logger.Info("Loaded processed cards from store", slog.Int("count", len(loaded)))
+		}
+	}
+
+	if p.maxConcurrent < 1 {
+		p.maxConcurrent = 2 // default
+	}
+	p.semaphore = make(chan struct{}, p.maxConcurrent)
+
+	return p
+}
+
+// Start begins polling the list.
+func (p *Poller) Start(ctx context.Context) error {
+	p.logger.Info("Starting Trello poller",
+		slog.String("list", p.config.ListID),
+		slog.String("label", p.pilotLabel()),
+		slog.Duration("interval", p.interval),
+		slog.Int("max_concurrent", p.maxConcurrent),
+	)
+
+	// Initial check
+	p.checkForNewCards(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Trello poller stopping, waiting for active tasks...")
+			p.wgMu.Lock()
+			p.stopping.Store(true)
+			p.wgMu.Unlock()
+			p.activeWg.Wait()
+			p.logger.Info("Trello poller stopped")
+			return nil
+		case <-ticker.C:
+			p.checkForNewCards(ctx)
+		}
+	}
+}
+
+func (p *Poller) checkForNewCards(ctx context.Context) {
+	cards, err := p.client.ListCards(ctx, p.config.ListID)
+	if err != nil {
+		p.logger.Warn("Failed to list Trello cards",
+			slog.String("list", p.config.ListID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	// Process in list order (top first)
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Pos < cards[j].Pos
+	})
+
+	for _, card := range cards {
+		if card.Closed || !card.HasLabel(p.pilotLabel()) {
+			continue
+		}
+
+		// Skip if already processed
+		p.mu.RLock()
+		processed := p.processed[card.ID]
+		p.mu.RUnlock()
+
+		if processed {
+			continue
+		}
+
+		// Mark processed immediately to prevent duplicate dispatch on next tick
+		p.markProcessed(card.ID, "processed")
+
+		// Acquire semaphore slot (blocks if max_concurrent reached)
+		select {
+		case <-ctx.Done():
+			return
+		case p.semaphore <- struct{}{}:
+		}
+
+		p.logger.Info("Dispatching Trello card for parallel execution",
+			slog.String("id", card.ID),
+			slog.String("name", card.Name),
+		)
+
+		// Use mutex to coordinate stopping flag check with WaitGroup Add
+		p.wgMu.Lock()
+		if p.stopping.Load() {
+			p.wgMu.Unlock()
+			<-p.semaphore // release slot we acquired
+			return
+		}
+		p.activeWg.Add(1)
+		p.wgMu.Unlock()
+
+		go p.processCardAsync(ctx, card)
+	}
+}
+
+// processCardAsync handles a single card in a goroutine.
+func (p *Poller) processCardAsync(ctx context.Context, card Card) {
+	defer p.activeWg.Done()
+	defer func() { <-p.semaphore }() // release slot
+
+	if p.onCard == nil {
+		return
+	}
+
+	result, err := p.onCard(ctx, &card)
+	if err != nil || result == nil || !result.Success {
+		if err != nil {
+			p.logger.Error("Failed to process Trello card",
+				slog.String("id", card.ID),
+				slog.Any("error", err),
+			)
+		}
+		// Drop the pilot label so the card is not picked up again as-is;
+		// re-adding the label retries it.
+		if label := card.FindLabel(p.pilotLabel()); label != nil {
+			if rmErr := p.client.RemoveLabel(ctx, card.ID, label.ID); rmErr != nil {
+				p.logger.Warn("Failed to remove pilot label",
+					slog.String("id", card.ID),
+					slog.Any("error", rmErr),
+				)
+				return
+			}
+		}
+		p.ClearProcessed(card.ID)
+		return
+	}
+
+	p.markProcessed(card.ID, "done")
+
+	// Fire OnPRCreated callback
+	if result.PRNumber > 0 && p.onPRCreated != nil {
+		p.onPRCreated(result.PRNumber, result.PRURL, card.ID, result.HeadSHA, result.BranchName)
+	}
+}
+
+func (p *Poller) pilotLabel() string {
+	if p.config.PilotLabel == "" {
+		return "pilot"
+	}
+	return p.config.PilotLabel
+}
+
+func (p *Poller) markProcessed(id, result string) {
+	p.mu.Lock()
+	p.processed[id] = true
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.MarkAdapterProcessed(AdapterName, id, result); err != nil {
+			p.logger.Warn("Failed to persist processed card", slog.String("id", id), slog.Any("error", err))
+		}
+	}
+}
+
+// IsProcessed checks if a card has been processed.
+func (p *Poller) IsProcessed(id string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.processed[id]
+}
+
+// ProcessedCount returns the number of processed cards.
+func (p *Poller) ProcessedCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.processed)
+}
+
+// Reset clears the processed cards map.
+func (p *Poller) Reset() {
+	p.mu.Lock()
+	p.processed = make(map[string]bool)
+	p.mu.Unlock()
+}
+
+// ClearProcessed removes a specific card from the processed map (for retry).
+func (p *Poller) ClearProcessed(id string) {
+	p.mu.Lock()
+	delete(p.processed, id)
+	p.mu.Unlock()
+
+	if p.processedStore != nil {
+		if err := p.processedStore.UnmarkAdapterProcessed(AdapterName, id); err != nil {
+			p.logger.Warn("Failed to unmark card in store",
+				slog.String("id", id),
+				slog.Any("error", err))
+		}
+	}
+}
+
+// Drain stops accepting new cards and waits for active executions to finish.
+// Used during hot upgrade to let in-flight work complete before process restart.
+func (p *Poller) Drain() {
+	p.logger.Info("Draining poller — no new cards will be accepted")
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+	p.logger.Info("Poller drained — all active tasks completed")
+}
+
+// WaitForActive waits for all active parallel goroutines to finish.
+// Used in tests to synchronize after checkForNewCards.
+func (p *Poller) WaitForActive() {
+	p.wgMu.Lock()
+	p.stopping.Store(true)
+	p.wgMu.Unlock()
+	p.activeWg.Wait()
+}
@@ -0,0 +1,140 @@
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// fakeTrello is a minimal Trello API stub recording label removals.
+type fakeTrello struct {
+	mu            sync.Mutex
+	cards         []Card
+	removedLabels map[string][]string // card ID → label IDs removed
+}
+
+func (f *fakeTrello) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/lists/list-1/cards":
+			_ = json.NewEncoder(w).Encode(f.cards)
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/idLabels/"):
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/1/cards/"), "/idLabels/")
+			if f.removedLabels == nil {
+				f.removedLabels = make(map[string][]string)
+			}
+			f.removedLabels[parts[0]] = append(f.removedLabels[parts[0]], parts[1])
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func labeledCard(id string, pos float64, labels ...string) Card {
+	card := Card{ID: id, ShortLink: "s" + id, Name: "Card " + id, Pos: pos}
+	for _, l := range labels {
+		card.Labels = append(card.Labels, Label{ID: "label-" + l, Name: l})
+	}
+	return card
+}
+
+func newTestPoller(t *testing.T, fake *fakeTrello, opts ...PollerOption) *Poller {
+	t.Helper()
+	srv := httptest.NewServer(fake.handler(t))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.ListID = "list-1"
+
+	client := NewClient(testutil.FakeTrelloAPIKey, testutil.FakeTrelloToken, WithBaseURL(srv.URL))
+	return NewPoller(client, cfg, time.Minute, opts...)
+}
+
+func TestPoller_PicksUpLabeledCardsInListOrder(t *testing.T) {
+	closed := labeledCard("closed", 0, "pilot")
+	closed.Closed = true
+	fake := &fakeTrello{cards: []Card{
+		labeledCard("second", 20, "pilot"),
+		labeledCard("unlabeled", 5, "bug"),
+		labeledCard("first", 10, "pilot"),
+		closed,
+	}}
+
+	var handled []string
+	var mu sync.Mutex
+	p := newTestPoller(t, fake, WithMaxConcurrent(1), WithOnCard(func(_ context.Context, card *Card) (*CardResult, error) {
+		mu.Lock()
+		handled = append(handled, card.ID)
+		mu.Unlock()
+		return &CardResult{Success: true}, nil
+	}))
+
+	p.checkForNewCards(context.Background())
+	p.WaitForActive()
+
+	if len(handled) != 2 || handled[0] != "first" || handled[1] != "second" {
+		t.Errorf("handled = %v, want [first second]", handled)
+	}
+	if !p.IsProcessed("first") || !p.IsProcessed("second") {
+		t.Error("handled cards should be marked processed")
+	}
+}
+
+func TestPoller_FailureRemovesLabelAndAllowsRetry(t *testing.T) {
+	fake := &fakeTrello{cards: []Card{labeledCard("c1", 1, "pilot")}}
+
+	p := newTestPoller(t, fake, WithOnCard(func(_ context.Context, _ *Card) (*CardResult, error) {
+		return &CardResult{Success: false}, errors.New("boom")
+	}))
+
+	p.checkForNewCards(context.Background())
+	p.WaitForActive()
+
+	fake.mu.Lock()
+	removed := fake.removedLabels["c1"]
+	fake.mu.Unlock()
+	if len(removed) != 1 || removed[0] != "label-pilot" {
+		t.Errorf("removed labels = %v, want [label-pilot]", removed)
+	}
+	if p.IsProcessed("c1") {
+		t.Error("failed card should be cleared so re-adding the label retries it")
+	}
+}
+
+func TestPoller_SkipsProcessedAndFiresPRCallback(t *testing.T) {
+	fake := &fakeTrello{cards: []Card{labeledCard("old", 1, "pilot"), labeledCard("new", 2, "pilot")}}
+
+	var prCards []string
+	var mu sync.Mutex
+	p := newTestPoller(t, fake,
+		WithOnCard(func(_ context.Context, card *Card) (*CardResult, error) {
+			return &CardResult{Success: true, PRNumber: 7, BranchName: "pilot/" + TaskID(card)}, nil
+		}),
+		WithOnPRCreated(func(_ int, _ string, cardID, _, _ string) {
+			mu.Lock()
+			prCards = append(prCards, cardID)
+			mu.Unlock()
+		}),
+	)
+
+	p.markProcessed("old", "done")
+	p.checkForNewCards(context.Background())
+	p.WaitForActive()
+
+	if len(prCards) != 1 || prCards[0] != "new" {
+		t.Errorf("PR callbacks = %v, want [new]", prCards)
+	}
+}
@@ -0,0 +1,141 @@
+package trello
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Config holds Trello adapter configuration.
+type Config struct {
+	Enabled    bool           `yaml:"enabled"`
+	APIKey     string         `yaml:"api_key"`      // Power-Up / developer API key
+	Token      string         `yaml:"token"`        // User token authorized for the key
+	ListID     string         `yaml:"list_id"`      // List polled for pilot cards
+	PilotLabel string         `yaml:"pilot_label"`  // default: "pilot"
+	DoneListID string         `yaml:"done_list_id"` // List cards move to when their PR merges (optional)
+	Polling    *PollingConfig `yaml:"polling,omitempty"`
+}
+
+// PollingConfig holds polling configuration for the Trello adapter.
+type PollingConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultConfig returns default Trello configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:    false,
+		PilotLabel: "pilot",
+	}
+}
+
+// Check item states.
+const (
+	CheckItemComplete   = "complete"
+	CheckItemIncomplete = "incomplete"
+)
+
+// Card represents a Trello card.
+type Card struct {
+	ID               string      `json:"id"`
+	ShortLink        string      `json:"shortLink"`
+	Name             string      `json:"name"`
+	Desc             string      `json:"desc"`
+	URL              string      `json:"url"`
+	ShortURL         string      `json:"shortUrl"`
+	IDList           string      `json:"idList"`
+	Closed           bool        `json:"closed"`
+	Pos              float64     `json:"pos"`
+	Labels           []Label     `json:"labels"`
+	Checklists       []Checklist `json:"checklists,omitempty"`
+	DateLastActivity time.Time   `json:"dateLastActivity"`
+}
+
+// Label is a board label attached to a card.
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Checklist is a named checklist on a card.
+type Checklist struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+// CheckItem is a single checklist entry.
+type CheckItem struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	State string  `json:"state"` // "complete" or "incomplete"
+	Pos   float64 `json:"pos"`
+}
+
+// Comment is a card comment action.
+type Comment struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Text string `json:"text"`
+	} `json:"data"`
+}
+
+// FindLabel returns the card label whose name matches (case-insensitive), or nil.
+func (c *Card) FindLabel(name string) *Label {
+	for i := range c.Labels {
+		if strings.EqualFold(c.Labels[i].Name, name) {
+			return &c.Labels[i]
+		}
+	}
+	return nil
+}
+
+// HasLabel reports whether the card carries a label with the given name.
+func (c *Card) HasLabel(name string) bool {
+	return c.FindLabel(name) != nil
+}
+
+// AcceptanceCriteria returns the names of all checklist items on the card,
+// ordered by position. Completed items are included: a checklist describes
+// what the task must satisfy, not what is left to do.
+func (c *Card) AcceptanceCriteria() []string {
+	var criteria []string
+	for _, cl := range c.Checklists {
+		items := append([]CheckItem(nil), cl.CheckItems...)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		for _, item := range items {
+			if name := strings.TrimSpace(item.Name); name != "" {
+				criteria = append(criteria, name)
+			}
+		}
+	}
+	return criteria
+}
+
+// taskIDPrefix prefixes Pilot task IDs derived from Trello cards.
+const taskIDPrefix = "TRELLO-"
+
+// TaskID returns the Pilot task ID for a card, e.g. "TRELLO-aB3dE5gH".
+// The card short link is used because it is stable, URL-safe, and accepted
+// anywhere the API takes a card ID.
+func TaskID(card *Card) string {
+	return taskIDPrefix + card.ShortLink
+}
+
+// CardIDFromBranch extracts the card short link from a Pilot branch name
+// such as "pilot/TRELLO-aB3dE5gH". It returns false for other branches.
+func CardIDFromBranch(branch string) (string, bool) {
+	name := strings.TrimPrefix(branch, "pilot/")
+	if !strings.HasPrefix(name, taskIDPrefix) {
+		return "", false
+	}
+	id := strings.TrimPrefix(name, taskIDPrefix)
+	if id == "" || strings.ContainsAny(id, "/ ") {
+		return "", false
+	}
+	return id, true
+}
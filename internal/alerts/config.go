@@ -9,7 +9,8 @@ import (
 // to eliminate duplicate type definitions.
 
 // FromConfigAlerts converts config.AlertsConfig to alerts.AlertConfig.
-func FromConfigAlerts(enabled bool, channels []ChannelConfigInput, rules []RuleConfigInput, defaults DefaultsConfigInput) *AlertConfig {
+// Per-member quiet hours are optional and shared directly.
+func FromConfigAlerts(enabled bool, channels []ChannelConfigInput, rules []RuleConfigInput, defaults DefaultsConfigInput, members ...MemberQuietHours) *AlertConfig {
 	alertCfg := &AlertConfig{
 		Enabled:  enabled,
 		Channels: make([]ChannelConfig, 0, len(channels)),
@@ -19,6 +20,7 @@ func FromConfigAlerts(enabled bool, channels []ChannelConfigInput, rules []RuleC
 			DefaultSeverity:    parseSeverity(defaults.DefaultSeverity),
 			SuppressDuplicates: defaults.SuppressDuplicates,
 		},
+		Members: members,
 	}
 
 	for _, ch := range channels {
@@ -39,6 +41,8 @@ type ChannelConfigInput struct {
 	Type       string
 	Enabled    bool
 	Severities []string
+	Member     string
+	QuietHours *QuietHoursConfig
 	// Channel-specific configs - same types used in both packages
 	Slack     *SlackChannelConfig
 	Telegram  *TelegramChannelConfig
@@ -69,6 +73,8 @@ type ConditionConfigInput struct {
 	Pattern              string
 	FilePattern          string
 	Paths                []string
+	RepeatedStalls       int
+	StallWindow          time.Duration
 }
 
 // DefaultsConfigInput represents defaults config from config package
@@ -84,6 +90,8 @@ func convertChannel(in ChannelConfigInput) ChannelConfig {
 		Type:       in.Type,
 		Enabled:    in.Enabled,
 		Severities: make([]Severity, 0, len(in.Severities)),
+		Member:     in.Member,
+		QuietHours: in.QuietHours,
 		// Direct assignment - no conversion needed (same types)
 		Slack:     in.Slack,
 		Telegram:  in.Telegram,
@@ -117,6 +125,8 @@ func convertRule(in RuleConfigInput) AlertRule {
 			Pattern:              in.Condition.Pattern,
 			FilePattern:          in.Condition.FilePattern,
 			Paths:                in.Condition.Paths,
+			RepeatedStalls:       in.Condition.RepeatedStalls,
+			StallWindow:          in.Condition.StallWindow,
 		},
 	}
 }
@@ -158,6 +168,24 @@ func parseAlertType(t string) AlertType {
 		return AlertTypeUnusualPattern
 	case "eval_regression":
 		return AlertTypeEvalRegression
+	case "heartbeat_timeout":
+		return AlertTypeHeartbeatTimeout
+	case "post_merge_failure":
+		return AlertTypePostMergeFailure
+	case "conflict_unresolved":
+		return AlertTypeConflictUnresolved
+	case "merge_frozen":
+		return AlertTypeMergeFrozen
+	case "linked_merge_incomplete":
+		return AlertTypeLinkedMergeIncomplete
+	case "task_blocked":
+		return AlertTypeTaskBlocked
+	case "budget_routing":
+		return AlertTypeBudgetRouting
+	case "budget_forecast":
+		return AlertTypeBudgetForecast
+	case "ha_takeover":
+		return AlertTypeHATakeover
 	default:
 		return AlertType(t)
 	}
@@ -25,6 +25,17 @@ type Dispatcher struct {
 	config   *AlertConfig
 	logger   *slog.Logger
 	mu       sync.RWMutex
+
+	// Alerts held during quiet hours, by channel name
+	held   map[string]*heldAlerts
+	heldMu sync.Mutex
+	now    func() time.Time
+}
+
+// heldAlerts queues a channel's alerts until its quiet hours end
+type heldAlerts struct {
+	alerts  []*Alert
+	dropped int
 }
 
 // DispatcherOption configures the Dispatcher
@@ -43,6 +54,8 @@ func NewDispatcher(config *AlertConfig, opts ...DispatcherOption) *Dispatcher {
 		channels: make(map[string]Channel),
 		config:   config,
 		logger:   slog.Default(),
+		held:     make(map[string]*heldAlerts),
+		now:      time.Now,
 	}
 
 	for _, opt := range opts {
@@ -90,9 +103,11 @@ func (d *Dispatcher) ListChannels() []string {
 	return names
 }
 
-// Dispatch sends an alert to specified channels
+// Dispatch sends an alert to specified channels. Non-critical alerts for
+// channels in quiet hours are held and reported with Held set.
 func (d *Dispatcher) Dispatch(ctx context.Context, alert *Alert, channelNames []string) []DeliveryResult {
 	results := make([]DeliveryResult, 0, len(channelNames))
+	now := d.now()
 
 	// Use WaitGroup for parallel delivery
 	var wg sync.WaitGroup
@@ -111,6 +126,16 @@ func (d *Dispatcher) Dispatch(ctx context.Context, alert *Alert, channelNames []
 			continue
 		}
 
+		if alert.Severity != SeverityCritical && d.config.quietHoursFor(name).Active(now) {
+			d.hold(name, alert)
+			results = append(results, DeliveryResult{
+				ChannelName: name,
+				Held:        true,
+				SentAt:      now,
+			})
+			continue
+		}
+
 		wg.Add(1)
 		go func(ch Channel, chName string) {
 			defer wg.Done()
@@ -156,6 +181,85 @@ func (d *Dispatcher) DispatchAll(ctx context.Context, alert *Alert) []DeliveryRe
 	return d.Dispatch(ctx, alert, channelNames)
 }
 
+// hold queues an alert for a channel's quiet-hours digest
+func (d *Dispatcher) hold(channelName string, alert *Alert) {
+	d.heldMu.Lock()
+	defer d.heldMu.Unlock()
+
+	q, ok := d.held[channelName]
+	if !ok {
+		q = &heldAlerts{}
+		d.held[channelName] = q
+	}
+	q.alerts = append(q.alerts, alert)
+	if len(q.alerts) > maxHeldAlerts {
+		q.dropped += len(q.alerts) - maxHeldAlerts
+		q.alerts = q.alerts[len(q.alerts)-maxHeldAlerts:]
+	}
+
+	d.logger.Debug("alert held for quiet hours",
+		"channel", channelName,
+		"alert_id", alert.ID,
+		"held", len(q.alerts),
+	)
+}
+
+// HeldCount returns the number of alerts held for a channel
+func (d *Dispatcher) HeldCount(channelName string) int {
+	d.heldMu.Lock()
+	defer d.heldMu.Unlock()
+	if q, ok := d.held[channelName]; ok {
+		return len(q.alerts) + q.dropped
+	}
+	return 0
+}
+
+// FlushHeld delivers one digest per channel whose quiet hours have ended.
+// Alerts stay queued when delivery fails so the next flush retries them.
+func (d *Dispatcher) FlushHeld(ctx context.Context) []DeliveryResult {
+	now := d.now()
+
+	d.heldMu.Lock()
+	ready := make(map[string]*heldAlerts)
+	for name, q := range d.held {
+		if !d.config.quietHoursFor(name).Active(now) {
+			ready[name] = q
+			delete(d.held, name)
+		}
+	}
+	d.heldMu.Unlock()
+
+	var results []DeliveryResult
+	for name, q := range ready {
+		d.mu.RLock()
+		channel, ok := d.channels[name]
+		d.mu.RUnlock()
+		if !ok {
+			continue
+		}
+
+		digest := buildQuietHoursDigest(name, q.alerts, q.dropped, now)
+		result := d.sendToChannel(ctx, channel, digest)
+		result.ChannelName = name
+		results = append(results, result)
+
+		if !result.Success {
+			d.heldMu.Lock()
+			if newer, ok := d.held[name]; ok {
+				q.alerts = append(q.alerts, newer.alerts...)
+				q.dropped += newer.dropped
+			}
+			if len(q.alerts) > maxHeldAlerts {
+				q.dropped += len(q.alerts) - maxHeldAlerts
+				q.alerts = q.alerts[len(q.alerts)-maxHeldAlerts:]
+			}
+			d.held[name] = q
+			d.heldMu.Unlock()
+		}
+	}
+	return results
+}
+
 func (d *Dispatcher) severityMatches(severities []Severity, alertSeverity Severity) bool {
 	if len(severities) == 0 {
 		return true // No filter means accept all
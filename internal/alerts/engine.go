@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"sync"
 	"time"
 
@@ -22,7 +23,8 @@ type Engine struct {
 	consecutiveFailures map[string]int           // project -> consecutive failure count
 	taskLastProgress    map[string]progressState // task ID -> last progress state
 	alertHistory        []AlertHistory
-	retryTracker        map[string]int // source (issue/PR) -> consecutive failure count (GH-848)
+	retryTracker        map[string]int         // source (issue/PR) -> consecutive failure count (GH-848)
+	stallTimes          map[string][]time.Time // project -> recent heartbeat stall times
 
 	// Channels for events
 	eventCh chan Event
@@ -69,6 +71,31 @@ const (
 
 	// Eval regression events (GH-2065)
 	EventTypeEvalRegression EventType = "eval_regression"
+
+	// Backend heartbeat stalls (GH-884)
+	EventTypeHeartbeatTimeout EventType = "heartbeat_timeout"
+
+	// Autopilot reverted a merge that broke CI on the default branch
+	EventTypeAutopilotRollback EventType = "autopilot_rollback"
+
+	// Autopilot gave up resolving merge conflicts on a PR
+	EventTypeConflictUnresolved EventType = "conflict_unresolved"
+
+	// A PR ready to merge is held by a merge freeze. Metadata: pr_number, freeze, until
+	EventTypeMergeFrozen EventType = "merge_frozen"
+
+	// A linked PR failed after others of its multi-repo change merged.
+	// Metadata: group, pr_number, merged
+	EventTypeLinkedMergeIncomplete EventType = "linked_merge_incomplete"
+
+	// The agent signalled it is blocked; Error holds the reason
+	EventTypeTaskBlocked EventType = "task_blocked"
+
+	// Budget-aware routing downgraded a model or deferred a task
+	EventTypeBudgetRouting EventType = "budget_routing"
+
+	// HA primary changed. Metadata: node, previous, action ("takeover" or "lost")
+	EventTypeHATakeover EventType = "ha_takeover"
 )
 
 // EngineOption configures the Engine
@@ -98,6 +125,7 @@ func NewEngine(config *AlertConfig, opts ...EngineOption) *Engine {
 		taskLastProgress:    make(map[string]progressState),
 		alertHistory:        make([]AlertHistory, 0),
 		retryTracker:        make(map[string]int),
+		stallTimes:          make(map[string][]time.Time),
 		eventCh:             make(chan Event, 100),
 		done:                make(chan struct{}),
 	}
@@ -188,6 +216,22 @@ func (e *Engine) handleEvent(ctx context.Context, event Event) {
 		e.handleEscalation(ctx, event)
 	case EventTypeEvalRegression:
 		e.handleEvalRegression(ctx, event)
+	case EventTypeHeartbeatTimeout:
+		e.handleHeartbeatTimeout(ctx, event)
+	case EventTypeAutopilotRollback:
+		e.handleAutopilotRollback(ctx, event)
+	case EventTypeConflictUnresolved:
+		e.handleConflictUnresolved(ctx, event)
+	case EventTypeMergeFrozen:
+		e.handleMergeFrozen(ctx, event)
+	case EventTypeLinkedMergeIncomplete:
+		e.handleLinkedMergeIncomplete(ctx, event)
+	case EventTypeTaskBlocked:
+		e.handleTaskBlocked(ctx, event)
+	case EventTypeBudgetRouting:
+		e.handleBudgetRouting(ctx, event)
+	case EventTypeHATakeover:
+		e.handleHATakeover(ctx, event)
 	}
 }
 
@@ -320,6 +364,10 @@ func (e *Engine) handleCostUpdate(ctx context.Context, event Event) {
 }
 
 func (e *Engine) handleBudgetEvent(ctx context.Context, event Event) {
+	if event.Metadata["alert_type"] == "monthly_forecast_exceeded" {
+		e.handleBudgetForecast(ctx, event)
+		return
+	}
 	// Route budget events through cost update handler so existing
 	// AlertTypeDailySpend / AlertTypeBudgetDepleted rules fire
 	e.handleCostUpdate(ctx, event)
@@ -348,7 +396,8 @@ func (e *Engine) handleSecurityEvent(ctx context.Context, event Event) {
 	}
 }
 
-// checkStuckTasks periodically checks for stuck tasks
+// checkStuckTasks periodically checks for stuck tasks and flushes alerts
+// held during quiet hours that have since ended
 func (e *Engine) checkStuckTasks(ctx context.Context) {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
@@ -361,6 +410,9 @@ func (e *Engine) checkStuckTasks(ctx context.Context) {
 			return
 		case <-ticker.C:
 			e.evaluateStuckTasks(ctx)
+			if e.dispatcher != nil {
+				e.dispatcher.FlushHeld(ctx)
+			}
 		}
 	}
 }
@@ -491,9 +543,12 @@ func (e *Engine) fireAlert(ctx context.Context, rule AlertRule, alert *Alert) {
 
 	// Track delivery history
 	deliveredTo := make([]string, 0)
+	heldFor := make([]string, 0)
 	for _, r := range results {
 		if r.Success {
 			deliveredTo = append(deliveredTo, r.ChannelName)
+		} else if r.Held {
+			heldFor = append(heldFor, r.ChannelName)
 		} else {
 			e.logger.Error("failed to deliver alert",
 				"channel", r.ChannelName,
@@ -521,6 +576,7 @@ func (e *Engine) fireAlert(ctx context.Context, rule AlertRule, alert *Alert) {
 		"alert_id", alert.ID,
 		"severity", alert.Severity,
 		"delivered_to", deliveredTo,
+		"held_for", heldFor,
 	)
 }
 
@@ -713,6 +769,189 @@ func (e *Engine) handleEvalRegression(ctx context.Context, event Event) {
 	}
 }
 
+// handleAutopilotRollback processes post-merge failures of autopilot merges.
+// Metadata keys: pr_number, merge_sha, failed_checks, revert_pr_url,
+// revert_error.
+func (e *Engine) handleAutopilotRollback(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypePostMergeFailure {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		message := fmt.Sprintf("CI failed on %s after autopilot merged PR #%s (commit %s, failed: %s).",
+			event.Project, event.Metadata["pr_number"], event.Metadata["merge_sha"], event.Metadata["failed_checks"])
+		if url := event.Metadata["revert_pr_url"]; url != "" {
+			message += " Revert PR opened: " + url
+		} else if revertErr := event.Metadata["revert_error"]; revertErr != "" {
+			message += " Revert PR could not be opened: " + revertErr
+		}
+
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, message))
+	}
+}
+
+// handleTaskBlocked fires task_blocked rules when the agent signals it cannot
+// proceed. A rule with a pattern only fires for reasons matching it.
+func (e *Engine) handleTaskBlocked(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeTaskBlocked {
+			continue
+		}
+
+		if rule.Condition.Pattern != "" {
+			matched, err := regexp.MatchString(rule.Condition.Pattern, event.Error)
+			if err != nil {
+				e.logger.Warn("invalid task_blocked pattern", "rule", rule.Name, "error", err)
+				continue
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		alert := e.createAlert(rule, event, fmt.Sprintf("Task %s is blocked: %s", event.TaskID, event.Error))
+		e.fireAlert(ctx, rule, alert)
+	}
+}
+
+// handleBudgetRouting processes routing decisions made under budget
+// pressure. Metadata keys: action (downgrade or defer), spend_percent, until.
+func (e *Engine) handleBudgetRouting(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeBudgetRouting {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		var message string
+		if event.Metadata["action"] == "defer" {
+			message = fmt.Sprintf("Task %s deferred until %s: daily spend at %s%% of budget",
+				event.TaskID, event.Metadata["until"], event.Metadata["spend_percent"])
+		} else {
+			message = fmt.Sprintf("Task %s routed to a cheaper model: daily spend at %s%% of budget",
+				event.TaskID, event.Metadata["spend_percent"])
+		}
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, message))
+	}
+}
+
+// handleBudgetForecast processes projections of month-end spend over the
+// monthly budget. The event's Error carries the forecast message.
+func (e *Engine) handleBudgetForecast(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeBudgetForecast {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, event.Error))
+	}
+}
+
+// handleHATakeover processes changes of the HA primary. Metadata keys:
+// node, previous, action.
+func (e *Engine) handleHATakeover(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeHATakeover {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		var message string
+		if event.Metadata["action"] == "lost" {
+			message = fmt.Sprintf("Pilot node %s lost the primary lease and is shutting down", event.Metadata["node"])
+		} else {
+			message = fmt.Sprintf("Pilot node %s took over as primary from %s", event.Metadata["node"], event.Metadata["previous"])
+		}
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, message))
+	}
+}
+
+// handleConflictUnresolved processes PRs whose merge conflicts autopilot
+// could not resolve. Metadata keys: pr_number, branch, attempts.
+func (e *Engine) handleConflictUnresolved(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeConflictUnresolved {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		message := fmt.Sprintf("Autopilot could not resolve merge conflicts on PR #%s (%s) after %s attempt(s); the PR was closed and its issue requeued.",
+			event.Metadata["pr_number"], event.Metadata["branch"], event.Metadata["attempts"])
+		if event.Error != "" {
+			message += " Last error: " + event.Error
+		}
+
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, message))
+	}
+}
+
+// handleMergeFrozen fires merge_frozen rules for a PR queued behind a merge
+// freeze.
+func (e *Engine) handleMergeFrozen(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeMergeFrozen {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		message := fmt.Sprintf("PR #%s on %s is ready to merge but held by the %s.",
+			event.Metadata["pr_number"], event.Project, event.Metadata["freeze"])
+		if until := event.Metadata["until"]; until != "" {
+			message += " It merges when the freeze ends at " + until + "."
+		} else {
+			message += " It merges when the freeze is lifted with 'pilot freeze end'."
+		}
+
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, message))
+	}
+}
+
+// handleLinkedMergeIncomplete fires linked_merge_incomplete rules for a
+// multi-repo change left half-merged.
+func (e *Engine) handleLinkedMergeIncomplete(ctx context.Context, event Event) {
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeLinkedMergeIncomplete {
+			continue
+		}
+
+		if !e.shouldFire(rule) {
+			continue
+		}
+
+		message := fmt.Sprintf("Multi-repo change %s is half-merged: PR #%s on %s will not merge, but %s already merged. Revert them or finish the change by hand.",
+			event.Metadata["group"], event.Metadata["pr_number"], event.Project, event.Metadata["merged"])
+		if event.Error != "" {
+			message += " Last error: " + event.Error
+		}
+
+		e.fireAlert(ctx, rule, e.createAlert(rule, event, message))
+	}
+}
+
 // handleEscalation processes escalation events (GH-885).
 // These are critical alerts that should route to PagerDuty.
 func (e *Engine) handleEscalation(ctx context.Context, event Event) {
@@ -741,3 +980,57 @@ func (e *Engine) handleEscalation(ctx context.Context, event Event) {
 		e.fireAlert(ctx, rule, alert)
 	}
 }
+
+// handleHeartbeatTimeout counts backend stalls per project and fires
+// heartbeat_timeout rules once RepeatedStalls occur within StallWindow.
+// A single stall is recovered by the executor; repeats point at a systemic
+// problem (hung MCP server, network, backend regression).
+func (e *Engine) handleHeartbeatTimeout(ctx context.Context, event Event) {
+	ts := event.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	for _, rule := range e.config.Rules {
+		if !rule.Enabled || rule.Type != AlertTypeHeartbeatTimeout {
+			continue
+		}
+
+		threshold := rule.Condition.RepeatedStalls
+		if threshold <= 0 {
+			threshold = 3
+		}
+		window := rule.Condition.StallWindow
+		if window <= 0 {
+			window = time.Hour
+		}
+
+		e.mu.Lock()
+		recent := e.stallTimes[event.Project][:0]
+		for _, t := range e.stallTimes[event.Project] {
+			if ts.Sub(t) <= window {
+				recent = append(recent, t)
+			}
+		}
+		recent = append(recent, ts)
+		e.stallTimes[event.Project] = recent
+		count := len(recent)
+		e.mu.Unlock()
+
+		if count < threshold || !e.shouldFire(rule) {
+			continue
+		}
+
+		alert := e.createAlert(rule, event, fmt.Sprintf(
+			"%d backend stalls in %v for project %s (last: task %s, %s)",
+			count, window, event.Project, event.TaskID, event.Error,
+		))
+		metadata := make(map[string]string, len(event.Metadata)+1)
+		for k, v := range event.Metadata {
+			metadata[k] = v
+		}
+		metadata["stall_count"] = fmt.Sprintf("%d", count)
+		alert.Metadata = metadata
+		e.fireAlert(ctx, rule, alert)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -2471,9 +2472,419 @@ func TestHandleEvalRegression(t *testing.T) {
 	})
 }
 
+func TestHandleAutopilotRollback(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:     "post_merge_rollback",
+				Type:     AlertTypePostMergeFailure,
+				Enabled:  true,
+				Severity: SeverityCritical,
+				Channels: []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleAutopilotRollback(ctx, Event{
+		Type:    EventTypeAutopilotRollback,
+		TaskID:  "pr-45",
+		Project: "owner/repo",
+		Metadata: map[string]string{
+			"pr_number":     "45",
+			"merge_sha":     "abc1234",
+			"failed_checks": "e2e",
+			"revert_pr_url": "https://github.com/owner/repo/pull/46",
+		},
+		Timestamp: time.Now(),
+	})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(mock.alerts))
+	}
+	alert := mock.alerts[0]
+	if alert.Type != AlertTypePostMergeFailure || alert.Severity != SeverityCritical {
+		t.Errorf("alert = %s/%s", alert.Type, alert.Severity)
+	}
+	if !strings.Contains(alert.Message, "PR #45") || !strings.Contains(alert.Message, "pull/46") {
+		t.Errorf("message = %q", alert.Message)
+	}
+}
+
+func TestHandleTaskBlocked(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:      "blocked_on_credentials",
+				Type:      AlertTypeTaskBlocked,
+				Enabled:   true,
+				Condition: RuleCondition{Pattern: "(?i)credential|api key"},
+				Severity:  SeverityWarning,
+				Channels:  []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleTaskBlocked(ctx, Event{Type: EventTypeTaskBlocked, TaskID: "GH-1", Error: "waiting on design review", Timestamp: time.Now()})
+	engine.handleTaskBlocked(ctx, Event{Type: EventTypeTaskBlocked, TaskID: "GH-2", Error: "missing API key for Stripe", Timestamp: time.Now()})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(mock.alerts))
+	}
+	alert := mock.alerts[0]
+	if alert.Type != AlertTypeTaskBlocked || !strings.Contains(alert.Message, "GH-2") {
+		t.Errorf("alert = %s: %s", alert.Type, alert.Message)
+	}
+	if !strings.Contains(alert.Message, "missing API key") {
+		t.Errorf("message = %q", alert.Message)
+	}
+}
+
+func TestParseAlertTypeTaskBlocked(t *testing.T) {
+	if result := parseAlertType("task_blocked"); result != AlertTypeTaskBlocked {
+		t.Errorf("parseAlertType(\"task_blocked\") = %s, want %s", result, AlertTypeTaskBlocked)
+	}
+}
+
+func TestHandleBudgetRouting(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:     "budget_routing",
+				Type:     AlertTypeBudgetRouting,
+				Enabled:  true,
+				Severity: SeverityInfo,
+				Channels: []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleBudgetRouting(ctx, Event{
+		Type:      EventTypeBudgetRouting,
+		TaskID:    "GH-9",
+		Metadata:  map[string]string{"action": "defer", "spend_percent": "92", "until": "2026-04-01T00:00:00Z"},
+		Timestamp: time.Now(),
+	})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(mock.alerts))
+	}
+	alert := mock.alerts[0]
+	if alert.Type != AlertTypeBudgetRouting || !strings.Contains(alert.Message, "GH-9 deferred until 2026-04-01") || !strings.Contains(alert.Message, "92%") {
+		t.Errorf("alert = %s: %s", alert.Type, alert.Message)
+	}
+}
+
+func TestHandleConflictUnresolved(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:     "conflict_unresolved",
+				Type:     AlertTypeConflictUnresolved,
+				Enabled:  true,
+				Severity: SeverityWarning,
+				Channels: []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleConflictUnresolved(ctx, Event{
+		Type:    EventTypeConflictUnresolved,
+		TaskID:  "pr-42",
+		Project: "owner/repo",
+		Error:   "conflict resolution failed: unresolved conflicts in go.mod",
+		Metadata: map[string]string{
+			"pr_number": "42",
+			"branch":    "pilot/GH-10",
+			"attempts":  "2",
+		},
+		Timestamp: time.Now(),
+	})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(mock.alerts))
+	}
+	alert := mock.alerts[0]
+	if alert.Type != AlertTypeConflictUnresolved || alert.Severity != SeverityWarning {
+		t.Errorf("alert = %s/%s", alert.Type, alert.Severity)
+	}
+	for _, want := range []string{"PR #42", "pilot/GH-10", "2 attempt(s)", "go.mod"} {
+		if !strings.Contains(alert.Message, want) {
+			t.Errorf("message missing %q: %q", want, alert.Message)
+		}
+	}
+}
+
 func TestParseAlertTypeEvalRegression(t *testing.T) {
 	result := parseAlertType("eval_regression")
 	if result != AlertTypeEvalRegression {
 		t.Errorf("parseAlertType(\"eval_regression\") = %s, want %s", result, AlertTypeEvalRegression)
 	}
 }
+
+func TestEngine_RepeatedHeartbeatStalls(t *testing.T) {
+	config := &AlertConfig{
+		Enabled: true,
+		Channels: []ChannelConfig{
+			{
+				Name:       "test-channel",
+				Type:       "webhook",
+				Enabled:    true,
+				Severities: []Severity{SeverityWarning},
+			},
+		},
+		Rules: []AlertRule{
+			{
+				Name:    "repeated_stalls",
+				Type:    AlertTypeHeartbeatTimeout,
+				Enabled: true,
+				Condition: RuleCondition{
+					RepeatedStalls: 3,
+					StallWindow:    time.Hour,
+				},
+				Severity: SeverityWarning,
+				Channels: []string{"test-channel"},
+			},
+		},
+	}
+
+	mockCh := newMockChannel("test-channel", "webhook")
+	dispatcher := NewDispatcher(config)
+	dispatcher.RegisterChannel(mockCh)
+
+	engine := NewEngine(config, WithDispatcher(dispatcher))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_ = engine.Start(ctx)
+
+	now := time.Now()
+	stall := func(project string, at time.Time) {
+		engine.ProcessEvent(Event{
+			Type:      EventTypeHeartbeatTimeout,
+			TaskID:    "TASK-1",
+			Project:   project,
+			Error:     "no stream events for 5m0s",
+			Timestamp: at,
+		})
+	}
+
+	// Old stall falls outside the window; other project's stalls count separately
+	stall("/test/project", now.Add(-2*time.Hour))
+	stall("/test/project", now.Add(-10*time.Minute))
+	stall("/other/project", now.Add(-5*time.Minute))
+	stall("/test/project", now.Add(-5*time.Minute))
+
+	time.Sleep(100 * time.Millisecond)
+	if got := len(mockCh.getAlerts()); got != 0 {
+		t.Fatalf("expected no alert below threshold, got %d", got)
+	}
+
+	stall("/test/project", now)
+
+	time.Sleep(100 * time.Millisecond)
+	alerts := mockCh.getAlerts()
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 repeated stall alert, got %d", len(alerts))
+	}
+	if alerts[0].Type != AlertTypeHeartbeatTimeout {
+		t.Errorf("expected alert type %s, got %s", AlertTypeHeartbeatTimeout, alerts[0].Type)
+	}
+	if alerts[0].Metadata["stall_count"] != "3" {
+		t.Errorf("expected stall_count 3, got %q", alerts[0].Metadata["stall_count"])
+	}
+}
+
+func TestHandleBudgetForecast(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:     "budget_forecast",
+				Type:     AlertTypeBudgetForecast,
+				Enabled:  true,
+				Severity: SeverityWarning,
+				Channels: []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleBudgetEvent(ctx, Event{
+		Type:      EventTypeBudgetWarning,
+		Error:     "Projected month-end spend $620.00 exceeds monthly budget $500.00 (124%)",
+		Metadata:  map[string]string{"alert_type": "monthly_forecast_exceeded", "severity": "warning"},
+		Timestamp: time.Now(),
+	})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(mock.alerts))
+	}
+	alert := mock.alerts[0]
+	if alert.Type != AlertTypeBudgetForecast || !strings.Contains(alert.Message, "$620.00") {
+		t.Errorf("alert = %s: %s", alert.Type, alert.Message)
+	}
+}
+
+func TestHandleHATakeover(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:     "ha_takeover",
+				Type:     AlertTypeHATakeover,
+				Enabled:  true,
+				Severity: SeverityCritical,
+				Channels: []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleEvent(ctx, Event{
+		Type:      EventTypeHATakeover,
+		Metadata:  map[string]string{"node": "pilot-b", "previous": "pilot-a", "action": "takeover"},
+		Timestamp: time.Now(),
+	})
+	engine.handleEvent(ctx, Event{
+		Type:      EventTypeHATakeover,
+		Metadata:  map[string]string{"node": "pilot-b", "action": "lost"},
+		Timestamp: time.Now(),
+	})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(mock.alerts))
+	}
+	if msg := mock.alerts[0].Message; !strings.Contains(msg, "pilot-b took over as primary from pilot-a") {
+		t.Errorf("takeover message = %q", msg)
+	}
+	if msg := mock.alerts[1].Message; !strings.Contains(msg, "pilot-b lost the primary lease") {
+		t.Errorf("lost message = %q", msg)
+	}
+}
+
+func TestHandleMergeFrozen(t *testing.T) {
+	mock := newMockChannel("slack", "slack")
+	dispatcher := NewDispatcher(nil)
+	dispatcher.RegisterChannel(mock)
+
+	config := &AlertConfig{
+		Enabled:  true,
+		Channels: []ChannelConfig{{Name: "slack", Type: "slack", Enabled: true}},
+		Rules: []AlertRule{
+			{
+				Name:     "merge_frozen",
+				Type:     AlertTypeMergeFrozen,
+				Enabled:  true,
+				Severity: SeverityInfo,
+				Channels: []string{"slack"},
+			},
+		},
+	}
+
+	engine := NewEngine(config, WithDispatcher(dispatcher), WithLogger(slog.Default()))
+	ctx := context.Background()
+	_ = engine.Start(ctx)
+	defer engine.Stop()
+
+	engine.handleMergeFrozen(ctx, Event{
+		Type:    EventTypeMergeFrozen,
+		TaskID:  "pr-42",
+		Project: "owner/repo",
+		Metadata: map[string]string{
+			"pr_number": "42",
+			"freeze":    "merge freeze (weekend)",
+			"until":     "Mon Jan 5 08:00",
+		},
+		Timestamp: time.Now(),
+	})
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(mock.alerts))
+	}
+	alert := mock.alerts[0]
+	if alert.Type != AlertTypeMergeFrozen {
+		t.Errorf("alert type = %s", alert.Type)
+	}
+	for _, want := range []string{"PR #42", "owner/repo", "weekend", "Mon Jan 5 08:00"} {
+		if !strings.Contains(alert.Message, want) {
+			t.Errorf("message missing %q: %q", want, alert.Message)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AlertTypeQuietHoursDigest is sent when a quiet-hours window ends and
+// summarizes the alerts held during it
+const AlertTypeQuietHoursDigest AlertType = "quiet_hours_digest"
+
+// maxHeldAlerts caps the alerts held per channel; the oldest are dropped first
+const maxHeldAlerts = 100
+
+// QuietHoursConfig defines a daily window during which non-critical alerts
+// are held and delivered afterward as a single digest. Critical alerts are
+// always delivered immediately.
+type QuietHoursConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Start    string `yaml:"start"`    // "22:00" (24h clock)
+	End      string `yaml:"end"`      // "07:00"; earlier than start spans midnight
+	Timezone string `yaml:"timezone"` // IANA name, e.g. "Europe/Berlin" (default: local)
+	Weekends bool   `yaml:"weekends"` // Also quiet all day Saturday and Sunday
+}
+
+// MemberQuietHours assigns quiet hours to a team member. Channels reference
+// the member by email to inherit them.
+type MemberQuietHours struct {
+	Email      string            `yaml:"email"`
+	QuietHours *QuietHoursConfig `yaml:"quiet_hours"`
+}
+
+// Validate checks the window bounds and timezone
+func (q *QuietHoursConfig) Validate() error {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return fmt.Errorf("end: %w", err)
+	}
+	if start == end {
+		return fmt.Errorf("start and end must differ")
+	}
+	if _, err := q.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", q.Timezone, err)
+	}
+	return nil
+}
+
+// Active reports whether now falls inside the quiet window. Invalid
+// configurations are never active so alerts are not silently held.
+func (q *QuietHoursConfig) Active(now time.Time) bool {
+	if q == nil || !q.Enabled {
+		return false
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil || start == end {
+		return false
+	}
+	loc, err := q.location()
+	if err != nil {
+		return false
+	}
+
+	local := now.In(loc)
+	if q.Weekends && (local.Weekday() == time.Saturday || local.Weekday() == time.Sunday) {
+		return true
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// location returns the configured timezone, defaulting to the local one
+func (q *QuietHoursConfig) location() (*time.Location, error) {
+	if q.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(q.Timezone)
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// quietHoursFor resolves the quiet hours for a channel: its own setting wins,
+// otherwise those of the member it belongs to
+func (c *AlertConfig) quietHoursFor(channelName string) *QuietHoursConfig {
+	if c == nil {
+		return nil
+	}
+	for _, ch := range c.Channels {
+		if ch.Name != channelName {
+			continue
+		}
+		if ch.QuietHours != nil {
+			return ch.QuietHours
+		}
+		if ch.Member == "" {
+			return nil
+		}
+		for _, m := range c.Members {
+			if strings.EqualFold(m.Email, ch.Member) {
+				return m.QuietHours
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// buildQuietHoursDigest summarizes alerts held for a channel into one alert
+// whose severity is the highest among them
+func buildQuietHoursDigest(channelName string, held []*Alert, dropped int, now time.Time) *Alert {
+	severity := SeverityInfo
+	var sb strings.Builder
+	for _, a := range held {
+		if a.Severity == SeverityWarning {
+			severity = SeverityWarning
+		}
+		line := fmt.Sprintf("• [%s] %s", a.Severity, a.Title)
+		if msg := strings.Split(a.Message, "\n")[0]; msg != "" {
+			line += " — " + msg
+		}
+		sb.WriteString(fmt.Sprintf("%s (%s)\n", line, a.CreatedAt.Format("15:04")))
+	}
+	if dropped > 0 {
+		sb.WriteString(fmt.Sprintf("…and %d older alert(s) not shown\n", dropped))
+	}
+
+	count := len(held) + dropped
+	return &Alert{
+		ID:       fmt.Sprintf("quiet-%s-%d", channelName, now.Unix()),
+		Type:     AlertTypeQuietHoursDigest,
+		Severity: severity,
+		Title:    fmt.Sprintf("%d alert(s) held during quiet hours", count),
+		Message:  strings.TrimRight(sb.String(), "\n"),
+		Source:   "alerts:quiet_hours",
+		Metadata: map[string]string{
+			"channel":    channelName,
+			"held_count": fmt.Sprintf("%d", count),
+		},
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,198 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuietHoursConfig_Active(t *testing.T) {
+	overnight := &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC"}
+	daytime := &QuietHoursConfig{Enabled: true, Start: "12:00", End: "13:30", Timezone: "UTC"}
+	weekends := &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC", Weekends: true}
+	berlin := &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"}
+
+	// 2026-03-04 is a Wednesday, 2026-03-07 a Saturday
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name  string
+		quiet *QuietHoursConfig
+		now   time.Time
+		want  bool
+	}{
+		{"nil", nil, at(4, 23, 0), false},
+		{"disabled", &QuietHoursConfig{Start: "22:00", End: "07:00"}, at(4, 23, 0), false},
+		{"overnight before midnight", overnight, at(4, 23, 0), true},
+		{"overnight after midnight", overnight, at(4, 6, 59), true},
+		{"overnight end is exclusive", overnight, at(4, 7, 0), false},
+		{"overnight daytime", overnight, at(4, 15, 0), false},
+		{"daytime window", daytime, at(4, 13, 15), true},
+		{"daytime outside", daytime, at(4, 13, 30), false},
+		{"weekday with weekends", weekends, at(4, 15, 0), false},
+		{"saturday afternoon", weekends, at(7, 15, 0), true},
+		{"timezone applied", berlin, at(4, 21, 30), true}, // 22:30 CET
+		{"timezone outside", berlin, at(4, 6, 30), false}, // 07:30 CET
+		{"invalid config never holds", &QuietHoursConfig{Enabled: true, Start: "late", End: "07:00"}, at(4, 23, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quiet.Active(tt.now); got != tt.want {
+				t.Errorf("Active(%s) = %v, want %v", tt.now.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		quiet   QuietHoursConfig
+		wantErr bool
+	}{
+		{"valid", QuietHoursConfig{Start: "22:00", End: "07:00", Timezone: "America/New_York"}, false},
+		{"local timezone", QuietHoursConfig{Start: "22:00", End: "07:00"}, false},
+		{"bad start", QuietHoursConfig{Start: "25:00", End: "07:00"}, true},
+		{"missing end", QuietHoursConfig{Start: "22:00"}, true},
+		{"empty window", QuietHoursConfig{Start: "22:00", End: "22:00"}, true},
+		{"bad timezone", QuietHoursConfig{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.quiet.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAlertConfig_QuietHoursFor(t *testing.T) {
+	member := &QuietHoursConfig{Enabled: true, Start: "20:00", End: "08:00"}
+	own := &QuietHoursConfig{Enabled: true, Start: "23:00", End: "06:00"}
+	config := &AlertConfig{
+		Channels: []ChannelConfig{
+			{Name: "team"},
+			{Name: "dev-dm", Member: "Dev@example.com"},
+			{Name: "dev-pager", Member: "dev@example.com", QuietHours: own},
+		},
+		Members: []MemberQuietHours{{Email: "dev@example.com", QuietHours: member}},
+	}
+
+	if got := config.quietHoursFor("team"); got != nil {
+		t.Errorf("team quiet hours = %+v, want nil", got)
+	}
+	if got := config.quietHoursFor("dev-dm"); got != member {
+		t.Errorf("dev-dm quiet hours = %+v, want member's", got)
+	}
+	if got := config.quietHoursFor("dev-pager"); got != own {
+		t.Errorf("dev-pager quiet hours = %+v, want channel's own", got)
+	}
+}
+
+func TestDispatcher_QuietHoursHoldAndFlush(t *testing.T) {
+	config := &AlertConfig{
+		Enabled: true,
+		Channels: []ChannelConfig{
+			{Name: "quiet", Enabled: true, QuietHours: &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC"}},
+			{Name: "loud", Enabled: true},
+		},
+	}
+	d := NewDispatcher(config)
+	quiet := newMockChannel("quiet", "mock")
+	loud := newMockChannel("loud", "mock")
+	d.RegisterChannel(quiet)
+	d.RegisterChannel(loud)
+
+	now := time.Date(2026, 3, 4, 23, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	warning := &Alert{ID: "w1", Severity: SeverityWarning, Title: "Task failed", Message: "tests failed\nstack", CreatedAt: now}
+	results := d.Dispatch(ctx, warning, []string{"quiet", "loud"})
+	for _, r := range results {
+		switch r.ChannelName {
+		case "quiet":
+			if !r.Held || r.Success {
+				t.Errorf("quiet result = %+v, want held", r)
+			}
+		case "loud":
+			if !r.Success || r.Held {
+				t.Errorf("loud result = %+v, want delivered", r)
+			}
+		}
+	}
+	d.Dispatch(ctx, &Alert{ID: "i1", Severity: SeverityInfo, Title: "PR stuck", CreatedAt: now}, []string{"quiet"})
+
+	// Critical alerts still page during quiet hours
+	d.Dispatch(ctx, &Alert{ID: "c1", Severity: SeverityCritical, Title: "Budget depleted", CreatedAt: now}, []string{"quiet"})
+	if got := quiet.getAlerts(); len(got) != 1 || got[0].ID != "c1" {
+		t.Fatalf("quiet channel received %d alerts, want only the critical one", len(got))
+	}
+	if d.HeldCount("quiet") != 2 {
+		t.Errorf("HeldCount() = %d, want 2", d.HeldCount("quiet"))
+	}
+
+	// Nothing is flushed while the window is still open
+	if results := d.FlushHeld(ctx); len(results) != 0 {
+		t.Errorf("FlushHeld() during quiet hours = %+v, want none", results)
+	}
+
+	now = time.Date(2026, 3, 5, 7, 1, 0, 0, time.UTC)
+	results = d.FlushHeld(ctx)
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("FlushHeld() = %+v, want one delivered digest", results)
+	}
+	got := quiet.getAlerts()
+	if len(got) != 2 {
+		t.Fatalf("quiet channel received %d alerts, want critical + digest", len(got))
+	}
+	digest := got[1]
+	if digest.Type != AlertTypeQuietHoursDigest || digest.Severity != SeverityWarning {
+		t.Errorf("digest = %s/%s, want quiet_hours_digest/warning", digest.Type, digest.Severity)
+	}
+	for _, want := range []string{"2 alert(s) held", "[warning] Task failed — tests failed (23:00)", "[info] PR stuck"} {
+		if !strings.Contains(digest.Title+"\n"+digest.Message, want) {
+			t.Errorf("digest missing %q:\n%s\n%s", want, digest.Title, digest.Message)
+		}
+	}
+	if d.HeldCount("quiet") != 0 {
+		t.Errorf("HeldCount() after flush = %d, want 0", d.HeldCount("quiet"))
+	}
+}
+
+func TestDispatcher_FlushHeldRetriesOnFailure(t *testing.T) {
+	config := &AlertConfig{
+		Channels: []ChannelConfig{
+			{Name: "quiet", Enabled: true, QuietHours: &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC"}},
+		},
+	}
+	d := NewDispatcher(config)
+	quiet := newMockChannel("quiet", "mock")
+	d.RegisterChannel(quiet)
+
+	now := time.Date(2026, 3, 4, 23, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < maxHeldAlerts+5; i++ {
+		d.Dispatch(ctx, &Alert{Severity: SeverityInfo, Title: "noise", CreatedAt: now}, []string{"quiet"})
+	}
+
+	now = now.Add(9 * time.Hour)
+	quiet.setError(errors.New("slack down"))
+	if results := d.FlushHeld(ctx); len(results) != 1 || results[0].Success {
+		t.Fatalf("FlushHeld() = %+v, want one failed delivery", results)
+	}
+	if d.HeldCount("quiet") != maxHeldAlerts+5 {
+		t.Errorf("HeldCount() after failure = %d, want %d", d.HeldCount("quiet"), maxHeldAlerts+5)
+	}
+
+	quiet.setError(nil)
+	d.FlushHeld(ctx)
+	got := quiet.getAlerts()
+	if len(got) != 1 || !strings.Contains(got[0].Message, "and 5 older alert(s) not shown") {
+		t.Errorf("digest after retry = %+v", got)
+	}
+}
@@ -48,6 +48,30 @@ const (
 
 	// Eval regression detection (GH-2065)
 	AlertTypeEvalRegression AlertType = "eval_regression"
+
+	// CI broke on the default branch after an autopilot merge
+	AlertTypePostMergeFailure AlertType = "post_merge_failure"
+
+	// Autopilot gave up resolving merge conflicts on a PR
+	AlertTypeConflictUnresolved AlertType = "conflict_unresolved"
+
+	// A PR ready to merge is queued behind a merge freeze
+	AlertTypeMergeFrozen AlertType = "merge_frozen"
+
+	// A linked PR failed after others of its multi-repo change merged
+	AlertTypeLinkedMergeIncomplete AlertType = "linked_merge_incomplete"
+
+	// The agent signalled it cannot proceed (BLOCKED step)
+	AlertTypeTaskBlocked AlertType = "task_blocked"
+
+	// Budget-aware routing downgraded a model or deferred a task
+	AlertTypeBudgetRouting AlertType = "budget_routing"
+
+	// Projected month-end spend exceeds the monthly budget
+	AlertTypeBudgetForecast AlertType = "budget_forecast"
+
+	// A standby daemon took over as HA primary, or the primary lost its lease
+	AlertTypeHATakeover AlertType = "ha_takeover"
 )
 
 // Alert represents an alert event
@@ -90,7 +114,7 @@ type RuleCondition struct {
 	UsageSpikePercent   float64 `yaml:"usage_spike_percent"`   // e.g., 200 = 200% spike
 
 	// Pattern-related conditions
-	Pattern     string   `yaml:"pattern"`      // Regex pattern
+	Pattern     string   `yaml:"pattern"`      // Regex pattern (task_blocked: matched against the reason)
 	FilePattern string   `yaml:"file_pattern"` // Glob pattern for files
 	Paths       []string `yaml:"paths"`        // Specific paths to watch
 
@@ -104,6 +128,10 @@ type RuleCondition struct {
 
 	// Escalation conditions (GH-848)
 	EscalationRetries int `yaml:"escalation_retries"` // Failures before escalation (default 3)
+
+	// Heartbeat stall conditions (GH-884)
+	RepeatedStalls int           `yaml:"repeated_stalls"` // Stalls within StallWindow before alerting (default 3)
+	StallWindow    time.Duration `yaml:"stall_window"`    // Sliding window for counting stalls (default 1h)
 }
 
 // AlertConfig holds the main alerting configuration
@@ -112,6 +140,9 @@ type AlertConfig struct {
 	Channels []ChannelConfig `yaml:"channels"`
 	Rules    []AlertRule     `yaml:"rules"`
 	Defaults AlertDefaults   `yaml:"defaults"`
+
+	// Members holds per-member quiet hours inherited by their channels
+	Members []MemberQuietHours `yaml:"members,omitempty"`
 }
 
 // AlertDefaults contains default settings
@@ -128,6 +159,11 @@ type ChannelConfig struct {
 	Enabled    bool       `yaml:"enabled"`
 	Severities []Severity `yaml:"severities"` // Which severities to receive
 
+	// Quiet hours hold non-critical alerts until the window ends. A channel
+	// without its own setting inherits those of Member (email).
+	Member     string            `yaml:"member,omitempty"`
+	QuietHours *QuietHoursConfig `yaml:"quiet_hours,omitempty"`
+
 	// Channel-specific config
 	Slack     *SlackChannelConfig     `yaml:"slack,omitempty"`
 	Telegram  *TelegramChannelConfig  `yaml:"telegram,omitempty"`
@@ -178,6 +214,7 @@ type DeliveryResult struct {
 	Error       error     `json:"error,omitempty"`
 	SentAt      time.Time `json:"sent_at"`
 	MessageID   string    `json:"message_id,omitempty"`
+	Held        bool      `json:"held,omitempty"` // Held for the quiet-hours digest
 }
 
 // AlertHistory stores alert history for tracking
@@ -326,6 +363,20 @@ func defaultRules() []AlertRule {
 			Cooldown:    1 * time.Hour,
 			Description: "Alert when autopilot has no state transitions for 1 hour",
 		},
+		// Repeated backend stalls (GH-884)
+		{
+			Name:    "repeated_stalls",
+			Type:    AlertTypeHeartbeatTimeout,
+			Enabled: true,
+			Condition: RuleCondition{
+				RepeatedStalls: 3,
+				StallWindow:    1 * time.Hour,
+			},
+			Severity:    SeverityWarning,
+			Channels:    []string{},
+			Cooldown:    1 * time.Hour,
+			Description: "Alert when the backend stalls 3 or more times within an hour",
+		},
 		// Eval regression detection (GH-2065)
 		{
 			Name:    "eval_regression",
@@ -339,6 +390,86 @@ func defaultRules() []AlertRule {
 			Cooldown:    30 * time.Minute,
 			Description: "Alert when eval pass@1 scores regress compared to baseline",
 		},
+		// Agent reported it cannot proceed (BLOCKED step signal)
+		{
+			Name:        "task_blocked",
+			Type:        AlertTypeTaskBlocked,
+			Enabled:     true,
+			Severity:    SeverityWarning,
+			Channels:    []string{},
+			Cooldown:    15 * time.Minute,
+			Description: "Alert when the agent signals it is blocked",
+		},
+		// Budget-aware routing (executor.model_routing.budget)
+		{
+			Name:        "budget_routing",
+			Type:        AlertTypeBudgetRouting,
+			Enabled:     true,
+			Severity:    SeverityInfo,
+			Channels:    []string{},
+			Cooldown:    1 * time.Hour,
+			Description: "Alert when budget pressure routes a task to a cheaper model or defers it",
+		},
+		// Month-end spend projection (budget.monthly_limit)
+		{
+			Name:        "budget_forecast",
+			Type:        AlertTypeBudgetForecast,
+			Enabled:     true,
+			Severity:    SeverityWarning,
+			Channels:    []string{},
+			Cooldown:    24 * time.Hour,
+			Description: "Alert when projected month-end spend exceeds the monthly budget",
+		},
+		// HA failover (ha.enabled)
+		{
+			Name:        "ha_takeover",
+			Type:        AlertTypeHATakeover,
+			Enabled:     true,
+			Severity:    SeverityCritical,
+			Channels:    []string{},
+			Cooldown:    0, // Every failover needs a look at the old primary
+			Description: "Alert when a standby takes over as HA primary or the primary loses its lease",
+		},
+		// Post-merge failure of an autopilot merge (autopilot.rollback)
+		{
+			Name:        "post_merge_rollback",
+			Type:        AlertTypePostMergeFailure,
+			Enabled:     true,
+			Severity:    SeverityCritical,
+			Channels:    []string{},
+			Cooldown:    0, // Every broken merge needs attention
+			Description: "Alert when CI breaks on main after an autopilot merge and a revert PR is opened",
+		},
+		// Conflict resolution gave up (autopilot.conflict_resolution)
+		{
+			Name:        "conflict_unresolved",
+			Type:        AlertTypeConflictUnresolved,
+			Enabled:     true,
+			Severity:    SeverityWarning,
+			Channels:    []string{},
+			Cooldown:    0, // One alert per abandoned PR
+			Description: "Alert when autopilot cannot resolve merge conflicts on a PR and closes it",
+		},
+		// Merge freeze (autopilot.freeze, pilot freeze start)
+		{
+			Name:        "merge_frozen",
+			Type:        AlertTypeMergeFrozen,
+			Enabled:     true,
+			Severity:    SeverityInfo,
+			Channels:    []string{},
+			Cooldown:    0, // One alert per held PR
+			Description: "Alert when a PR ready to merge is held by a merge freeze",
+		},
+		// Half-merged multi-repo change (repos: line in the issue)
+		{
+			Name:        "linked_merge_incomplete",
+			Type:        AlertTypeLinkedMergeIncomplete,
+			Enabled:     true,
+			Severity:    SeverityCritical,
+			Channels:    []string{},
+			Cooldown:    0, // One alert per half-merged change
+			Description: "Alert when a linked PR fails after other PRs of its multi-repo change merged",
+		},
 		// Escalation rule (GH-848)
 		{
 			Name:    "escalation",
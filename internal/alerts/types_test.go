@@ -58,10 +58,28 @@ func TestDefaultRules(t *testing.T) {
 		AlertTypePRStuckWaitingCI:   {"pr_stuck_waiting_ci", true},
 		// Deadlock detection (GH-849)
 		AlertTypeDeadlock: {"autopilot_deadlock", true},
+		// Repeated backend stalls (GH-884)
+		AlertTypeHeartbeatTimeout: {"repeated_stalls", true},
 		// Eval regression (GH-2065)
 		AlertTypeEvalRegression: {"eval_regression", true},
 		// Escalation (GH-848)
 		AlertTypeEscalation: {"escalation", true},
+		// Post-merge rollback
+		AlertTypePostMergeFailure: {"post_merge_rollback", true},
+		// Conflict resolution
+		AlertTypeConflictUnresolved: {"conflict_unresolved", true},
+		// Merge freeze
+		AlertTypeMergeFrozen: {"merge_frozen", true},
+		// Half-merged multi-repo change
+		AlertTypeLinkedMergeIncomplete: {"linked_merge_incomplete", true},
+		// Blocked step signal
+		AlertTypeTaskBlocked: {"task_blocked", true},
+		// Budget-aware routing
+		AlertTypeBudgetRouting: {"budget_routing", true},
+		// Month-end spend projection
+		AlertTypeBudgetForecast: {"budget_forecast", true},
+		// HA failover
+		AlertTypeHATakeover: {"ha_takeover", true},
 	}
 
 	if len(rules) != len(expectedRules) {
@@ -0,0 +1,142 @@
+package approval
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// maxAuditEntries bounds the audit trail kept in memory when no AuditStore
+// is set
+const maxAuditEntries = 500
+
+// AuditStore persists the approval audit trail. Satisfied by *memory.Store.
+type AuditStore interface {
+	SaveApprovalAudit(e *memory.ApprovalAudit) error
+	GetApprovalAudit(taskID string, limit int) ([]*memory.ApprovalAudit, error)
+}
+
+// AuditEvent is what happened to an approval request
+type AuditEvent string
+
+const (
+	AuditRequested AuditEvent = "requested" // Sent to a channel
+	AuditVoted     AuditEvent = "voted"     // One approver answered
+	AuditEscalated AuditEvent = "escalated" // Expired and sent to the escalation channel
+	AuditDecided   AuditEvent = "decided"   // Approved or rejected by the approvers
+	AuditTimedOut  AuditEvent = "timed_out" // Expired; the stage's default action applied
+)
+
+// AuditEntry records who did what to an approval request
+type AuditEntry struct {
+	RequestID string     `json:"request_id"`
+	TaskID    string     `json:"task_id"`
+	Stage     Stage      `json:"stage"`
+	Channel   string     `json:"channel,omitempty"`
+	Event     AuditEvent `json:"event"`
+	Actor     string     `json:"actor,omitempty"`
+	Decision  Decision   `json:"decision,omitempty"`
+	At        time.Time  `json:"at"`
+}
+
+// SetAuditStore persists the audit trail in store. Without a store only the
+// most recent entries are kept, in memory.
+func (m *Manager) SetAuditStore(store AuditStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.auditStore = store
+}
+
+// recordAudit appends to the audit trail and logs the entry
+func (m *Manager) recordAudit(req *Request, channel string, event AuditEvent, actor string, decision Decision) {
+	entry := AuditEntry{
+		RequestID: req.ID,
+		TaskID:    req.TaskID,
+		Stage:     req.Stage,
+		Channel:   channel,
+		Event:     event,
+		Actor:     actor,
+		Decision:  decision,
+		At:        time.Now(),
+	}
+
+	m.mu.Lock()
+	store := m.auditStore
+	if store == nil {
+		m.audit = append(m.audit, entry)
+		if len(m.audit) > maxAuditEntries {
+			m.audit = m.audit[len(m.audit)-maxAuditEntries:]
+		}
+	}
+	m.mu.Unlock()
+
+	if store != nil {
+		if err := store.SaveApprovalAudit(&memory.ApprovalAudit{
+			RequestID: entry.RequestID,
+			TaskID:    entry.TaskID,
+			Stage:     string(entry.Stage),
+			Channel:   entry.Channel,
+			Event:     string(entry.Event),
+			Actor:     entry.Actor,
+			Decision:  string(entry.Decision),
+			CreatedAt: entry.At,
+		}); err != nil {
+			m.log.Warn("Failed to persist approval audit entry",
+				slog.String("request_id", entry.RequestID),
+				slog.Any("error", err))
+		}
+	}
+
+	m.log.Info("Approval audit",
+		slog.String("request_id", entry.RequestID),
+		slog.String("task_id", entry.TaskID),
+		slog.String("stage", string(entry.Stage)),
+		slog.String("channel", entry.Channel),
+		slog.String("event", string(entry.Event)),
+		slog.String("actor", entry.Actor),
+		slog.String("decision", string(entry.Decision)))
+}
+
+// AuditTrail returns the most recent approval events for a task, oldest
+// first. An empty taskID returns events for every task; limit <= 0 returns
+// them all.
+func (m *Manager) AuditTrail(taskID string, limit int) ([]AuditEntry, error) {
+	m.mu.RLock()
+	store := m.auditStore
+	var entries []AuditEntry
+	if store == nil {
+		for _, e := range m.audit {
+			if taskID == "" || e.TaskID == taskID {
+				entries = append(entries, e)
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	if store == nil {
+		if limit > 0 && len(entries) > limit {
+			entries = entries[len(entries)-limit:]
+		}
+		return entries, nil
+	}
+
+	stored, err := store.GetApprovalAudit(taskID, limit)
+	if err != nil {
+		return nil, err
+	}
+	entries = make([]AuditEntry, 0, len(stored))
+	for _, e := range stored {
+		entries = append(entries, AuditEntry{
+			RequestID: e.RequestID,
+			TaskID:    e.TaskID,
+			Stage:     Stage(e.Stage),
+			Channel:   e.Channel,
+			Event:     AuditEvent(e.Event),
+			Actor:     e.Actor,
+			Decision:  Decision(e.Decision),
+			At:        e.CreatedAt,
+		})
+	}
+	return entries, nil
+}
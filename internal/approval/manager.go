@@ -16,6 +16,9 @@ type Manager struct {
 	handlers      map[string]Handler // Channel name -> handler
 	pending       map[string]*pendingRequest
 	ruleEvaluator *RuleEvaluator
+	authorizer    Authorizer
+	audit         []AuditEntry
+	auditStore    AuditStore
 	mu            sync.RWMutex
 	log           *slog.Logger
 }
@@ -56,9 +59,26 @@ func (m *Manager) RegisterHandler(handler Handler) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.handlers[handler.Name()] = handler
+	if a, ok := handler.(authorizable); ok && m.authorizer != nil {
+		a.SetAuthorizer(m.authorizer)
+	}
 	m.log.Debug("Registered approval handler", slog.String("channel", handler.Name()))
 }
 
+// SetAuthorizer restricts who may answer approval requests on channels
+// that identify the responder. It applies to handlers registered before
+// and after the call.
+func (m *Manager) SetAuthorizer(a Authorizer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.authorizer = a
+	for _, handler := range m.handlers {
+		if h, ok := handler.(authorizable); ok {
+			h.SetAuthorizer(a)
+		}
+	}
+}
+
 // IsEnabled returns true if approval workflows are enabled
 func (m *Manager) IsEnabled() bool {
 	return m.config != nil && m.config.Enabled
@@ -77,6 +97,12 @@ func (m *Manager) IsStageEnabled(stage Stage) bool {
 		return m.config.PreMerge != nil && m.config.PreMerge.Enabled
 	case StagePostFailure:
 		return m.config.PostFailure != nil && m.config.PostFailure.Enabled
+	case StagePrePromotion:
+		return m.config.PrePromotion != nil && m.config.PrePromotion.Enabled
+	case StageConfigChange:
+		return m.config.ConfigChange != nil && m.config.ConfigChange.Enabled
+	case StageNewRepo:
+		return m.config.NewRepo != nil && m.config.NewRepo.Enabled
 	default:
 		return false
 	}
@@ -143,16 +169,14 @@ func (m *Manager) RequestApproval(ctx context.Context, req *Request) (*Response,
 	if len(req.Approvers) == 0 {
 		req.Approvers = stageConfig.Approvers
 	}
-
-	// Find available handler
-	m.mu.RLock()
-	var handler Handler
-	for _, h := range m.handlers {
-		handler = h // Use first available handler
-		break
+	if req.MinApprovals == 0 {
+		req.MinApprovals = stageConfig.requiredApprovals()
+	}
+	if req.MinRole == "" {
+		req.MinRole = stageConfig.MinRole
 	}
-	m.mu.RUnlock()
 
+	handler := m.handlerFor(stageConfig.Channel)
 	if handler == nil {
 		// No handlers registered - use default action
 		m.log.Warn("No approval handlers registered, using default action",
@@ -168,11 +192,83 @@ func (m *Manager) RequestApproval(ctx context.Context, req *Request) (*Response,
 		}, nil
 	}
 
+	defer func() {
+		m.mu.Lock()
+		delete(m.pending, req.ID)
+		m.mu.Unlock()
+	}()
+
+	resp, err := m.await(ctx, handler, req, timeout)
+	if err != nil || resp != nil {
+		return resp, err
+	}
+
+	// Expired unanswered: escalate to the second channel if there is one
+	if esc := stageConfig.Escalation; esc != nil && ctx.Err() == nil {
+		if escHandler := m.handlerFor(esc.Channel); escHandler != nil && escHandler.Name() == esc.Channel {
+			escTimeout := esc.Timeout
+			if escTimeout == 0 {
+				escTimeout = timeout
+			}
+			req.Escalated = true
+			req.ExpiresAt = time.Now().Add(escTimeout)
+			m.recordAudit(req, escHandler.Name(), AuditEscalated, "", "")
+			m.log.Warn("Approval request expired, escalating",
+				slog.String("request_id", req.ID),
+				slog.String("task_id", req.TaskID),
+				slog.String("from", handler.Name()),
+				slog.String("to", escHandler.Name()))
+
+			resp, err = m.await(ctx, escHandler, req, escTimeout)
+			if err != nil || resp != nil {
+				return resp, err
+			}
+		} else {
+			m.log.Warn("Escalation channel not registered",
+				slog.String("request_id", req.ID),
+				slog.String("channel", esc.Channel))
+		}
+	}
+
+	m.log.Warn("Approval request timed out",
+		slog.String("request_id", req.ID),
+		slog.String("task_id", req.TaskID),
+		slog.String("default_action", string(stageConfig.DefaultAction)))
+	m.recordAudit(req, "", AuditTimedOut, "system", stageConfig.DefaultAction)
+
+	return &Response{
+		RequestID:   req.ID,
+		Decision:    stageConfig.DefaultAction,
+		ApprovedBy:  "system",
+		Comment:     "Approval timed out",
+		RespondedAt: time.Now(),
+	}, nil
+}
+
+// handlerFor returns the handler registered for channel, or any handler
+// when channel is empty or not registered
+func (m *Manager) handlerFor(channel string) Handler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if h, ok := m.handlers[channel]; ok {
+		return h
+	}
+	for _, h := range m.handlers {
+		return h // Use first available handler
+	}
+	return nil
+}
+
+// await sends req through handler and waits for its decision. Returns a nil
+// response when the request expired unanswered; the request is then
+// cancelled on the channel.
+func (m *Manager) await(ctx context.Context, handler Handler, req *Request, timeout time.Duration) (*Response, error) {
 	m.log.Info("Requesting approval",
 		slog.String("request_id", req.ID),
 		slog.String("task_id", req.TaskID),
 		slog.String("stage", string(req.Stage)),
 		slog.String("channel", handler.Name()),
+		slog.Int("min_approvals", req.requiredApprovals()),
 		slog.Duration("timeout", timeout))
 
 	// Create timeout context
@@ -184,6 +280,7 @@ func (m *Manager) RequestApproval(ctx context.Context, req *Request) (*Response,
 	if err != nil {
 		return nil, fmt.Errorf("failed to send approval request: %w", err)
 	}
+	m.recordAudit(req, handler.Name(), AuditRequested, "", "")
 
 	// Track pending request
 	m.mu.Lock()
@@ -195,38 +292,35 @@ func (m *Manager) RequestApproval(ctx context.Context, req *Request) (*Response,
 	}
 	m.mu.Unlock()
 
-	defer func() {
-		m.mu.Lock()
-		delete(m.pending, req.ID)
-		m.mu.Unlock()
-	}()
-
 	// Wait for response or timeout
 	select {
 	case resp := <-responseCh:
+		if resp == nil {
+			// Cancelled on the channel (CancelPending)
+			return &Response{
+				RequestID:   req.ID,
+				Decision:    DecisionRejected,
+				ApprovedBy:  "system",
+				Comment:     "Approval request cancelled",
+				RespondedAt: time.Now(),
+			}, nil
+		}
+		resp.Channel = handler.Name()
+		for _, v := range resp.Votes {
+			m.recordAudit(req, handler.Name(), AuditVoted, v.Username, v.Decision)
+		}
+		m.recordAudit(req, handler.Name(), AuditDecided, resp.ApprovedBy, resp.Decision)
 		m.log.Info("Approval response received",
 			slog.String("request_id", req.ID),
 			slog.String("decision", string(resp.Decision)),
-			slog.String("approved_by", resp.ApprovedBy))
+			slog.String("approved_by", resp.ApprovedBy),
+			slog.Int("votes", len(resp.Votes)))
 		return resp, nil
 
 	case <-timeoutCtx.Done():
-		// Timeout - use default action
-		m.log.Warn("Approval request timed out",
-			slog.String("request_id", req.ID),
-			slog.String("task_id", req.TaskID),
-			slog.String("default_action", string(stageConfig.DefaultAction)))
-
 		// Cancel the pending request
 		_ = handler.CancelRequest(ctx, req.ID)
-
-		return &Response{
-			RequestID:   req.ID,
-			Decision:    stageConfig.DefaultAction,
-			ApprovedBy:  "system",
-			Comment:     "Approval timed out",
-			RespondedAt: time.Now(),
-		}, nil
+		return nil, nil
 	}
 }
 
@@ -239,6 +333,12 @@ func (m *Manager) getStageConfig(stage Stage) *StageConfig {
 		return m.config.PreMerge
 	case StagePostFailure:
 		return m.config.PostFailure
+	case StagePrePromotion:
+		return m.config.PrePromotion
+	case StageConfigChange:
+		return m.config.ConfigChange
+	case StageNewRepo:
+		return m.config.NewRepo
 	default:
 		return nil
 	}
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
 )
 
 // mockHandler is a test double for Handler
@@ -1264,3 +1266,151 @@ func TestManager_RuleTrigger_MultipleRules_FirstMatchWins(t *testing.T) {
 		t.Errorf("expected approved, got %s", resp.Decision)
 	}
 }
+
+func TestManager_Escalation(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.PreMerge = &StageConfig{
+		Enabled:       true,
+		Timeout:       50 * time.Millisecond,
+		DefaultAction: DecisionRejected,
+		Channel:       "telegram",
+		Escalation:    &EscalationConfig{Channel: "slack", Timeout: time.Second},
+	}
+
+	m := NewManager(config)
+	primary := &mockHandler{name: "telegram"}
+	escalation := &mockHandler{
+		name: "slack",
+		respondWith: &Response{
+			RequestID:  "test-esc",
+			Decision:   DecisionApproved,
+			ApprovedBy: "lead",
+			Votes:      []Vote{{UserID: "U1", Username: "lead", Decision: DecisionApproved}},
+		},
+	}
+	m.RegisterHandler(primary)
+	m.RegisterHandler(escalation)
+
+	req := &Request{ID: "test-esc", TaskID: "TASK-01", Stage: StagePreMerge, Title: "Prod deploy"}
+	resp, err := m.RequestApproval(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Decision != DecisionApproved || resp.Channel != "slack" {
+		t.Fatalf("expected approval from slack, got %s via %q", resp.Decision, resp.Channel)
+	}
+	if calls := primary.getCancelCalls(); len(calls) != 1 {
+		t.Errorf("expected primary request cancelled, got %v", calls)
+	}
+	if len(escalation.sentReqs) != 1 || !escalation.sentReqs[0].Escalated {
+		t.Error("expected escalated request sent to slack")
+	}
+
+	trail, err := m.AuditTrail("TASK-01", 0)
+	if err != nil {
+		t.Fatalf("AuditTrail: %v", err)
+	}
+	var events []AuditEvent
+	for _, e := range trail {
+		events = append(events, e.Event)
+	}
+	want := []AuditEvent{AuditRequested, AuditEscalated, AuditRequested, AuditVoted, AuditDecided}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("audit events = %v, want %v", events, want)
+	}
+	if other, _ := m.AuditTrail("OTHER", 0); len(other) != 0 {
+		t.Error("expected no audit entries for other tasks")
+	}
+}
+
+func TestManager_AuditTrail_Persisted(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	config := DefaultConfig()
+	config.Enabled = true
+	config.PreMerge = &StageConfig{Enabled: true, Timeout: time.Second, Channel: "slack"}
+	newManager := func() *Manager {
+		m := NewManager(config)
+		m.SetAuditStore(store)
+		m.RegisterHandler(&mockHandler{
+			name:        "slack",
+			respondWith: &Response{RequestID: "req-1", Decision: DecisionApproved, ApprovedBy: "alice"},
+		})
+		return m
+	}
+
+	if _, err := newManager().RequestApproval(context.Background(), &Request{ID: "req-1", TaskID: "TASK-01", Stage: StagePreMerge}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A new manager, as after a restart, still sees who approved
+	trail, err := newManager().AuditTrail("TASK-01", 0)
+	if err != nil {
+		t.Fatalf("AuditTrail: %v", err)
+	}
+	if len(trail) != 2 || trail[0].Event != AuditRequested || trail[1].Event != AuditDecided ||
+		trail[1].Actor != "alice" || trail[1].Decision != DecisionApproved || trail[1].Stage != StagePreMerge {
+		t.Errorf("unexpected persisted trail: %+v", trail)
+	}
+}
+
+func TestManager_Escalation_TimesOut(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.PreMerge = &StageConfig{
+		Enabled:       true,
+		Timeout:       30 * time.Millisecond,
+		DefaultAction: DecisionRejected,
+		Channel:       "telegram",
+		Escalation:    &EscalationConfig{Channel: "slack"},
+	}
+
+	m := NewManager(config)
+	m.RegisterHandler(&mockHandler{name: "telegram"})
+	escalation := &mockHandler{name: "slack"}
+	m.RegisterHandler(escalation)
+
+	resp, err := m.RequestApproval(context.Background(), &Request{ID: "test-esc", TaskID: "TASK-01", Stage: StagePreMerge})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Decision != DecisionRejected || resp.ApprovedBy != "system" {
+		t.Errorf("expected default action after escalation timeout, got %s by %s", resp.Decision, resp.ApprovedBy)
+	}
+	if len(escalation.getCancelCalls()) != 1 {
+		t.Error("expected escalated request cancelled on timeout")
+	}
+	trail, _ := m.AuditTrail("", 0)
+	if last := trail[len(trail)-1]; last.Event != AuditTimedOut {
+		t.Errorf("expected trail to end with timeout, got %s", last.Event)
+	}
+}
+
+func TestManager_RequestApproval_SetsQuorumFromConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.PreMerge = &StageConfig{
+		Enabled:    true,
+		Timeout:    time.Second,
+		Approvers:  []string{"alice", "bob"},
+		RequireAll: true,
+		MinRole:    "admin",
+	}
+
+	m := NewManager(config)
+	handler := &mockHandler{name: "test", respondWith: &Response{Decision: DecisionApproved}}
+	m.RegisterHandler(handler)
+
+	req := &Request{ID: "test-q", TaskID: "TASK-01", Stage: StagePreMerge}
+	if _, err := m.RequestApproval(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.MinApprovals != 2 || req.MinRole != "admin" {
+		t.Errorf("expected quorum 2 and min role admin, got %d and %q", req.MinApprovals, req.MinRole)
+	}
+}
@@ -0,0 +1,310 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// Reaction emoji used to approve or reject a Mattermost approval post.
+const (
+	MattermostApproveEmoji = "white_check_mark"
+	MattermostRejectEmoji  = "x"
+)
+
+// MattermostClient defines the interface for Mattermost operations
+// This allows the approval handler to use the existing Mattermost client
+type MattermostClient interface {
+	// CreatePost posts a message to a channel and returns the post ID.
+	CreatePost(ctx context.Context, channelID, message string) (string, error)
+	// UpdatePost replaces the message of an existing post.
+	UpdatePost(ctx context.Context, postID, message string) error
+	// SeedReactions adds the approve/reject reactions so users can answer with one click.
+	SeedReactions(ctx context.Context, postID string, emojis ...string) error
+}
+
+// MattermostHandler handles approval requests via Mattermost.
+// Decisions are collected from reactions on the approval post, delivered by
+// the Mattermost WebSocket handler through HandleReaction.
+type MattermostHandler struct {
+	client  MattermostClient
+	channel string
+	pending map[string]*mattermostPending // postID -> pending state
+	byReq   map[string]string             // requestID -> postID
+	auth    Authorizer
+	mu      sync.Mutex
+	log     *slog.Logger
+}
+
+// mattermostPending tracks a pending Mattermost approval request
+type mattermostPending struct {
+	Request    *Request
+	PostID     string
+	ResponseCh chan *Response
+	Ballot     ballot
+}
+
+// NewMattermostHandler creates a new Mattermost approval handler
+func NewMattermostHandler(client MattermostClient, channel string) *MattermostHandler {
+	return &MattermostHandler{
+		client:  client,
+		channel: channel,
+		pending: make(map[string]*mattermostPending),
+		byReq:   make(map[string]string),
+		log:     logging.WithComponent("approval.mattermost"),
+	}
+}
+
+// Name returns the handler name
+func (h *MattermostHandler) Name() string {
+	return "mattermost"
+}
+
+// SendApprovalRequest sends an approval request via Mattermost
+func (h *MattermostHandler) SendApprovalRequest(ctx context.Context, req *Request) (<-chan *Response, error) {
+	responseCh := make(chan *Response, 1)
+
+	postID, err := h.client.CreatePost(ctx, h.channel, h.formatRequestMessage(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Mattermost message: %w", err)
+	}
+
+	if err := h.client.SeedReactions(ctx, postID, MattermostApproveEmoji, MattermostRejectEmoji); err != nil {
+		h.log.Warn("Failed to seed approval reactions", slog.Any("error", err))
+	}
+
+	h.mu.Lock()
+	h.pending[postID] = &mattermostPending{
+		Request:    req,
+		PostID:     postID,
+		ResponseCh: responseCh,
+	}
+	h.byReq[req.ID] = postID
+	h.mu.Unlock()
+
+	h.log.Debug("Sent approval request",
+		slog.String("request_id", req.ID),
+		slog.String("post_id", postID))
+
+	return responseCh, nil
+}
+
+// CancelRequest cancels a pending approval request
+func (h *MattermostHandler) CancelRequest(ctx context.Context, requestID string) error {
+	h.mu.Lock()
+	postID, exists := h.byReq[requestID]
+	var pending *mattermostPending
+	if exists {
+		pending = h.pending[postID]
+		delete(h.pending, postID)
+		delete(h.byReq, requestID)
+	}
+	h.mu.Unlock()
+
+	if pending == nil {
+		return nil
+	}
+
+	text := fmt.Sprintf("⏹ **CANCELLED**\n\n**Task:** `%s`\n**Title:** %s\n\n_Approval request was cancelled._",
+		pending.Request.TaskID, pending.Request.Title)
+	if err := h.client.UpdatePost(ctx, postID, text); err != nil {
+		h.log.Warn("Failed to update cancelled message", slog.Any("error", err))
+	}
+
+	close(pending.ResponseCh)
+	return nil
+}
+
+// HandleReaction processes a reaction on an approval post.
+// Returns true if the reaction targeted a pending approval request.
+func (h *MattermostHandler) HandleReaction(ctx context.Context, postID, userID, username, emoji string) bool {
+	var decision Decision
+	switch emoji {
+	case MattermostApproveEmoji:
+		decision = DecisionApproved
+	case MattermostRejectEmoji:
+		decision = DecisionRejected
+	default:
+		h.mu.Lock()
+		_, tracked := h.pending[postID]
+		h.mu.Unlock()
+		return tracked // swallow unrelated emoji on approval posts
+	}
+
+	var final Decision
+	var counted bool
+	var progress, approvers string
+
+	h.mu.Lock()
+	pending, exists := h.pending[postID]
+	if exists {
+		if err := authorizeResponder(h.auth, h.Name(), pending.Request, userID, username); err != nil {
+			h.mu.Unlock()
+			h.log.Debug("Ignoring reaction from non-approver",
+				slog.String("request_id", pending.Request.ID),
+				slog.String("user", username),
+				slog.Any("reason", err))
+			return true
+		}
+		final, counted = pending.Ballot.cast(pending.Request, Vote{
+			UserID:   userID,
+			Username: username,
+			Decision: decision,
+			At:       time.Now(),
+		})
+		if final != "" {
+			delete(h.pending, postID)
+			delete(h.byReq, pending.Request.ID)
+		} else {
+			progress = pending.Ballot.progress(pending.Request)
+			approvers = pending.Ballot.voters(DecisionApproved)
+		}
+	}
+	h.mu.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	// Quorum not reached yet: show who approved so far
+	if final == "" {
+		if counted {
+			text := h.formatRequestMessage(pending.Request) +
+				fmt.Sprintf("\n\n**%s** — approved by %s", progress, approvers)
+			if err := h.client.UpdatePost(ctx, postID, text); err != nil {
+				h.log.Warn("Failed to update approval progress", slog.Any("error", err))
+			}
+		}
+		return true
+	}
+
+	response := pending.Ballot.response(pending.Request, final)
+
+	if err := h.client.UpdatePost(ctx, postID, h.formatResponseMessage(pending.Request, final, response.ApprovedBy)); err != nil {
+		h.log.Warn("Failed to update response message", slog.Any("error", err))
+	}
+
+	select {
+	case pending.ResponseCh <- response:
+	default:
+	}
+	close(pending.ResponseCh)
+
+	h.log.Info("Approval reaction handled",
+		slog.String("request_id", pending.Request.ID),
+		slog.String("decision", string(final)),
+		slog.String("user", username))
+
+	return true
+}
+
+// SetAuthorizer restricts who may answer requests beyond the approver list
+func (h *MattermostHandler) SetAuthorizer(a Authorizer) {
+	h.mu.Lock()
+	h.auth = a
+	h.mu.Unlock()
+}
+
+// authorizeResponder checks that the user is on the request's approver list
+// and, when an authorizer is set, allowed to answer at the request's stage
+func authorizeResponder(auth Authorizer, channel string, req *Request, userID, username string) error {
+	if !isAllowedApprover(req.Approvers, userID, username) {
+		return fmt.Errorf("not an approver for this request")
+	}
+	if auth != nil {
+		return auth.AuthorizeApproval(channel, userID, string(req.Stage), req.MinRole)
+	}
+	return nil
+}
+
+// isAllowedApprover reports whether the user may decide a request.
+// An empty approver list allows anyone in the channel.
+func isAllowedApprover(approvers []string, userID, username string) bool {
+	if len(approvers) == 0 {
+		return true
+	}
+	for _, a := range approvers {
+		if a == userID || a == username || a == "@"+username {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRequestMessage creates the markdown body for an approval request
+func (h *MattermostHandler) formatRequestMessage(req *Request) string {
+	var icon, stageLabel string
+	switch req.Stage {
+	case StagePreExecution:
+		icon = "🚀"
+		stageLabel = "Pre-Execution Approval"
+	case StagePreMerge:
+		icon = "🔀"
+		stageLabel = "Pre-Merge Approval"
+	case StagePostFailure:
+		icon = "❌"
+		stageLabel = "Post-Failure Decision"
+	case StagePrePromotion:
+		icon = "🚢"
+		stageLabel = "Promotion Approval"
+	case StageConfigChange:
+		icon = "⚙️"
+		stageLabel = "Config Change Approval"
+	case StageNewRepo:
+		icon = "📦"
+		stageLabel = "New Repository Approval"
+	default:
+		icon = "⚠️"
+		stageLabel = "Approval Required"
+	}
+
+	text := fmt.Sprintf("%s **%s**\n\n**Task:** `%s`\n**Title:** %s",
+		icon, stageLabel, req.TaskID, req.Title)
+
+	if req.Escalated {
+		text = "⏫ **Escalated:** not answered in time\n\n" + text
+	}
+
+	if req.Description != "" {
+		text += fmt.Sprintf("\n\n%s", truncateForSlack(req.Description, 500))
+	}
+	if prURL, ok := req.Metadata["pr_url"].(string); ok && prURL != "" {
+		text += fmt.Sprintf("\n\n**PR:** [View Pull Request](%s)", prURL)
+	}
+	if errorMsg, ok := req.Metadata["error"].(string); ok && errorMsg != "" {
+		text += fmt.Sprintf("\n\n**Error:**\n```\n%s\n```", truncateForSlack(errorMsg, 200))
+	}
+
+	timeLeft := time.Until(req.ExpiresAt).Round(time.Minute)
+	text += fmt.Sprintf("\n\nReact with :%s: to approve or :%s: to reject. _Expires in: %s_",
+		MattermostApproveEmoji, MattermostRejectEmoji, formatDuration(timeLeft))
+
+	if n := req.requiredApprovals(); n > 1 {
+		text += fmt.Sprintf("\n_Needs %d approvals_", n)
+	}
+
+	return text
+}
+
+// formatResponseMessage creates the markdown body shown after a decision
+func (h *MattermostHandler) formatResponseMessage(req *Request, decision Decision, username string) string {
+	var icon, status string
+	switch decision {
+	case DecisionApproved:
+		icon = "✅"
+		status = "APPROVED"
+	case DecisionRejected:
+		icon = "❌"
+		status = "REJECTED"
+	default:
+		icon = "⏱"
+		status = "TIMEOUT"
+	}
+
+	return fmt.Sprintf("%s **%s**\n\n**Task:** `%s`\n**Title:** %s\n\n**Decision by:** @%s",
+		icon, status, req.TaskID, req.Title, username)
+}
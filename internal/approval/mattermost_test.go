@@ -0,0 +1,147 @@
+package approval
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockMattermostClient implements MattermostClient for testing
+type mockMattermostClient struct {
+	lastChannel string
+	lastMessage string
+	updates     map[string]string
+	seeded      []string
+}
+
+func (m *mockMattermostClient) CreatePost(ctx context.Context, channelID, message string) (string, error) {
+	m.lastChannel = channelID
+	m.lastMessage = message
+	return "post-1", nil
+}
+
+func (m *mockMattermostClient) UpdatePost(ctx context.Context, postID, message string) error {
+	if m.updates == nil {
+		m.updates = make(map[string]string)
+	}
+	m.updates[postID] = message
+	return nil
+}
+
+func (m *mockMattermostClient) SeedReactions(ctx context.Context, postID string, emojis ...string) error {
+	m.seeded = append(m.seeded, emojis...)
+	return nil
+}
+
+func newMattermostRequest(approvers ...string) *Request {
+	return &Request{
+		ID:        "req-1",
+		TaskID:    "TASK-01",
+		Stage:     StagePreMerge,
+		Title:     "Add feature",
+		Metadata:  map[string]interface{}{"pr_url": "https://github.com/o/r/pull/1"},
+		ExpiresAt: time.Now().Add(time.Hour),
+		Approvers: approvers,
+	}
+}
+
+func TestMattermostHandler_SendApprovalRequest(t *testing.T) {
+	client := &mockMattermostClient{}
+	handler := NewMattermostHandler(client, "chan-approvals")
+
+	ch, err := handler.SendApprovalRequest(context.Background(), newMattermostRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ch == nil {
+		t.Fatal("expected response channel")
+	}
+	if client.lastChannel != "chan-approvals" {
+		t.Errorf("channel = %q, want chan-approvals", client.lastChannel)
+	}
+	for _, want := range []string{"Pre-Merge Approval", "TASK-01", "https://github.com/o/r/pull/1"} {
+		if !strings.Contains(client.lastMessage, want) {
+			t.Errorf("message missing %q: %s", want, client.lastMessage)
+		}
+	}
+	if len(client.seeded) != 2 {
+		t.Errorf("expected 2 seeded reactions, got %v", client.seeded)
+	}
+}
+
+func TestMattermostHandler_HandleReaction(t *testing.T) {
+	tests := []struct {
+		name      string
+		approvers []string
+		emoji     string
+		user      string
+		handled   bool
+		want      Decision // empty = no response expected
+	}{
+		{"approve", nil, MattermostApproveEmoji, "alice", true, DecisionApproved},
+		{"reject", nil, MattermostRejectEmoji, "alice", true, DecisionRejected},
+		{"unrelated emoji swallowed", nil, "tada", "alice", true, ""},
+		{"non-approver ignored", []string{"bob"}, MattermostApproveEmoji, "alice", true, ""},
+		{"listed approver", []string{"@alice"}, MattermostApproveEmoji, "alice", true, DecisionApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockMattermostClient{}
+			handler := NewMattermostHandler(client, "chan")
+			ch, _ := handler.SendApprovalRequest(context.Background(), newMattermostRequest(tt.approvers...))
+
+			handled := handler.HandleReaction(context.Background(), "post-1", "uid", tt.user, tt.emoji)
+			if handled != tt.handled {
+				t.Errorf("handled = %v, want %v", handled, tt.handled)
+			}
+
+			select {
+			case resp := <-ch:
+				if tt.want == "" {
+					t.Fatalf("unexpected response: %+v", resp)
+				}
+				if resp.Decision != tt.want {
+					t.Errorf("decision = %q, want %q", resp.Decision, tt.want)
+				}
+				if resp.ApprovedBy != tt.user {
+					t.Errorf("approvedBy = %q, want %q", resp.ApprovedBy, tt.user)
+				}
+				if _, ok := client.updates["post-1"]; !ok {
+					t.Error("expected approval post to be updated")
+				}
+			default:
+				if tt.want != "" {
+					t.Fatal("expected a response")
+				}
+			}
+		})
+	}
+}
+
+func TestMattermostHandler_HandleReaction_UnknownPost(t *testing.T) {
+	handler := NewMattermostHandler(&mockMattermostClient{}, "chan")
+	if handler.HandleReaction(context.Background(), "other-post", "uid", "alice", MattermostApproveEmoji) {
+		t.Error("reaction on unknown post should not be handled")
+	}
+}
+
+func TestMattermostHandler_CancelRequest(t *testing.T) {
+	client := &mockMattermostClient{}
+	handler := NewMattermostHandler(client, "chan")
+	ch, _ := handler.SendApprovalRequest(context.Background(), newMattermostRequest())
+
+	if err := handler.CancelRequest(context.Background(), "req-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected response channel to be closed")
+	}
+	if !strings.Contains(client.updates["post-1"], "CANCELLED") {
+		t.Errorf("expected cancelled update, got %q", client.updates["post-1"])
+	}
+	if handler.HandleReaction(context.Background(), "post-1", "uid", "alice", MattermostApproveEmoji) {
+		t.Error("reaction after cancel should not be handled")
+	}
+}
@@ -0,0 +1,89 @@
+package approval
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Vote is one user's answer to an approval request
+type Vote struct {
+	UserID   string    `json:"user_id"`
+	Username string    `json:"username"`
+	Decision Decision  `json:"decision"`
+	At       time.Time `json:"at"`
+}
+
+// requiredApprovals returns how many distinct approvals decide the request
+func (r *Request) requiredApprovals() int {
+	if r.MinApprovals > 1 {
+		return r.MinApprovals
+	}
+	return 1
+}
+
+// ballot collects the votes on one request until it is decided
+type ballot struct {
+	votes []Vote
+}
+
+// cast records a vote and returns the request's decision once it has one:
+// any rejection rejects, and enough distinct approvals approve. Returns ""
+// while undecided. A user's repeat votes are ignored and reported as not
+// counted.
+func (b *ballot) cast(req *Request, vote Vote) (decision Decision, counted bool) {
+	for _, v := range b.votes {
+		if v.UserID == vote.UserID {
+			return "", false
+		}
+	}
+	b.votes = append(b.votes, vote)
+
+	if vote.Decision == DecisionRejected {
+		return DecisionRejected, true
+	}
+	if b.approvals() >= req.requiredApprovals() {
+		return DecisionApproved, true
+	}
+	return "", true
+}
+
+// approvals returns the number of approving votes
+func (b *ballot) approvals() int {
+	n := 0
+	for _, v := range b.votes {
+		if v.Decision == DecisionApproved {
+			n++
+		}
+	}
+	return n
+}
+
+// voters returns the names of the users who voted decision, comma-separated
+func (b *ballot) voters(decision Decision) string {
+	var names []string
+	for _, v := range b.votes {
+		if v.Decision == decision {
+			names = append(names, v.Username)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// progress describes a quorum request's approvals so far, e.g. "1/2 approvals"
+func (b *ballot) progress(req *Request) string {
+	return fmt.Sprintf("%d/%d approvals", b.approvals(), req.requiredApprovals())
+}
+
+// response builds the final response for a decided request
+func (b *ballot) response(req *Request, decision Decision) *Response {
+	votes := make([]Vote, len(b.votes))
+	copy(votes, b.votes)
+	return &Response{
+		RequestID:   req.ID,
+		Decision:    decision,
+		ApprovedBy:  b.voters(decision),
+		Votes:       votes,
+		RespondedAt: time.Now(),
+	}
+}
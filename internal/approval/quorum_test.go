@@ -0,0 +1,197 @@
+package approval
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBallot_Cast(t *testing.T) {
+	req := &Request{ID: "req-1", MinApprovals: 2}
+	var b ballot
+
+	if d, counted := b.cast(req, Vote{UserID: "1", Username: "alice", Decision: DecisionApproved}); d != "" || !counted {
+		t.Fatalf("first approval: got (%q, %v), want undecided and counted", d, counted)
+	}
+	if d, counted := b.cast(req, Vote{UserID: "1", Username: "alice", Decision: DecisionApproved}); d != "" || counted {
+		t.Fatalf("repeat vote: got (%q, %v), want ignored", d, counted)
+	}
+	if got := b.progress(req); got != "1/2 approvals" {
+		t.Errorf("progress = %q", got)
+	}
+	if d, _ := b.cast(req, Vote{UserID: "2", Username: "bob", Decision: DecisionApproved}); d != DecisionApproved {
+		t.Fatalf("second approval: got %q, want approved", d)
+	}
+
+	resp := b.response(req, DecisionApproved)
+	if resp.ApprovedBy != "alice, bob" {
+		t.Errorf("ApprovedBy = %q, want %q", resp.ApprovedBy, "alice, bob")
+	}
+	if len(resp.Votes) != 2 {
+		t.Errorf("expected 2 votes, got %d", len(resp.Votes))
+	}
+}
+
+func TestBallot_RejectionRejects(t *testing.T) {
+	req := &Request{ID: "req-1", MinApprovals: 3}
+	var b ballot
+
+	b.cast(req, Vote{UserID: "1", Username: "alice", Decision: DecisionApproved})
+	if d, _ := b.cast(req, Vote{UserID: "2", Username: "bob", Decision: DecisionRejected}); d != DecisionRejected {
+		t.Fatalf("got %q, want rejected", d)
+	}
+	if got := b.response(req, DecisionRejected).ApprovedBy; got != "bob" {
+		t.Errorf("ApprovedBy = %q, want bob", got)
+	}
+}
+
+func TestStageConfig_RequiredApprovals(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  StageConfig
+		want int
+	}{
+		{"default", StageConfig{}, 0},
+		{"min_approvals", StageConfig{MinApprovals: 2}, 2},
+		{"require_all", StageConfig{RequireAll: true, Approvers: []string{"a", "b", "c"}}, 3},
+		{"require_all_without_approvers", StageConfig{RequireAll: true, MinApprovals: 2}, 2},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.requiredApprovals(); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		stage   *StageConfig
+		wantErr string
+	}{
+		{"valid", &StageConfig{MinApprovals: 2, Approvers: []string{"a", "b"}, MinRole: "admin",
+			Escalation: &EscalationConfig{Channel: "slack"}}, ""},
+		{"negative_min_approvals", &StageConfig{MinApprovals: -1}, "min_approvals"},
+		{"more_than_approvers", &StageConfig{MinApprovals: 3, Approvers: []string{"a", "b"}}, "exceeds"},
+		{"unknown_role", &StageConfig{MinRole: "lead"}, "min_role"},
+		{"escalation_without_channel", &StageConfig{Escalation: &EscalationConfig{}}, "escalation.channel"},
+		{"escalation_same_channel", &StageConfig{Channel: "slack", Escalation: &EscalationConfig{Channel: "slack"}}, "differ"},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.PreMerge = tt.stage
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestTelegramHandler_HandleCallback_Quorum(t *testing.T) {
+	client := &mockTelegramClient{}
+	handler := NewTelegramHandler(client, "chat123")
+
+	req := &Request{
+		ID:           "req-quorum",
+		TaskID:       "TASK-01",
+		Stage:        StagePreMerge,
+		Title:        "Prod deploy",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		MinApprovals: 2,
+	}
+	ch, err := handler.SendApprovalRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msgs := client.getSentMessages(); !strings.Contains(msgs[0].Text, "Needs 2 approvals") {
+		t.Errorf("expected quorum note in message, got %q", msgs[0].Text)
+	}
+
+	handler.HandleCallback(context.Background(), "cb1", "approve:req-quorum", "1", "alice")
+	handler.HandleCallback(context.Background(), "cb2", "approve:req-quorum", "1", "alice")
+
+	select {
+	case <-ch:
+		t.Fatal("request decided before quorum")
+	default:
+	}
+	cbs := client.getAnsweredCallbacks()
+	if len(cbs) != 2 || !strings.Contains(cbs[0].Text, "1/2 approvals") || !strings.Contains(cbs[1].Text, "already answered") {
+		t.Fatalf("unexpected callback answers: %+v", cbs)
+	}
+	if len(client.getEditedMessages()) != 0 {
+		t.Error("message should keep its buttons until quorum")
+	}
+
+	handler.HandleCallback(context.Background(), "cb3", "approve:req-quorum", "2", "bob")
+
+	resp := <-ch
+	if resp == nil || resp.Decision != DecisionApproved {
+		t.Fatalf("expected approved response, got %+v", resp)
+	}
+	if resp.ApprovedBy != "alice, bob" || len(resp.Votes) != 2 {
+		t.Errorf("unexpected approvers %q, votes %d", resp.ApprovedBy, len(resp.Votes))
+	}
+	edited := client.getEditedMessages()
+	if len(edited) != 1 || !strings.Contains(edited[0].Text, "alice, bob") {
+		t.Errorf("expected final message listing approvers, got %+v", edited)
+	}
+}
+
+func TestSlackHandler_HandleInteraction_Quorum(t *testing.T) {
+	client := &mockSlackClient{}
+	handler := NewSlackHandler(client, "#approvals")
+
+	req := &Request{
+		ID:           "req-quorum",
+		TaskID:       "TASK-01",
+		Stage:        StagePreMerge,
+		Title:        "Prod deploy",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		MinApprovals: 2,
+	}
+	ch, err := handler.SendApprovalRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler.HandleInteraction(context.Background(), "approve", "approve:req-quorum", "U1", "alice", "")
+	select {
+	case <-ch:
+		t.Fatal("request decided before quorum")
+	default:
+	}
+
+	handler.HandleInteraction(context.Background(), "reject", "reject:req-quorum", "U2", "bob", "")
+	resp := <-ch
+	if resp == nil || resp.Decision != DecisionRejected || resp.ApprovedBy != "bob" {
+		t.Fatalf("expected rejection by bob, got %+v", resp)
+	}
+	if len(resp.Votes) != 2 {
+		t.Errorf("expected both votes recorded, got %d", len(resp.Votes))
+	}
+}
+
+func TestSlackHandler_BuildProgressBlocks(t *testing.T) {
+	handler := NewSlackHandler(nil, "#approvals")
+	req := &Request{ID: "req-1", TaskID: "TASK-01", Stage: StagePreMerge, MinApprovals: 2, ExpiresAt: time.Now().Add(time.Hour)}
+
+	blocks := handler.buildProgressBlocks(req, "1/2 approvals", "alice")
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	status, ok := blocks[1].(SlackSectionBlock)
+	if !ok || !strings.Contains(status.Text.Text, "approved by alice") {
+		t.Errorf("unexpected progress block %+v", blocks[1])
+	}
+	if _, ok := blocks[2].(SlackActionsBlock); !ok {
+		t.Error("expected buttons to stay last")
+	}
+}
@@ -66,6 +66,7 @@ type SlackHandler struct {
 	client  SlackClient
 	channel string
 	pending map[string]*slackPending // requestID -> pending state
+	auth    Authorizer
 	mu      sync.RWMutex
 	log     *slog.Logger
 }
@@ -76,6 +77,7 @@ type slackPending struct {
 	TS         string // Slack message timestamp (used as message ID)
 	Channel    string
 	ResponseCh chan *Response
+	Ballot     ballot
 }
 
 // NewSlackHandler creates a new Slack approval handler
@@ -158,6 +160,13 @@ func (h *SlackHandler) CancelRequest(ctx context.Context, requestID string) erro
 	return nil
 }
 
+// SetAuthorizer restricts who may answer requests beyond the approver list
+func (h *SlackHandler) SetAuthorizer(a Authorizer) {
+	h.mu.Lock()
+	h.auth = a
+	h.mu.Unlock()
+}
+
 // HandleInteraction processes a Slack interaction (button press)
 // This should be called by the Slack webhook handler when receiving interactions
 func (h *SlackHandler) HandleInteraction(ctx context.Context, actionID, value, userID, username, responseURL string) bool {
@@ -175,10 +184,33 @@ func (h *SlackHandler) HandleInteraction(ctx context.Context, actionID, value, u
 		return false // Not an approval action
 	}
 
+	var final Decision
+	var counted bool
+	var progress, approvers string
+
 	h.mu.Lock()
 	pending, exists := h.pending[requestID]
 	if exists {
-		delete(h.pending, requestID)
+		if err := authorizeResponder(h.auth, h.Name(), pending.Request, userID, username); err != nil {
+			h.mu.Unlock()
+			h.log.Info("Ignoring approval from unauthorized user",
+				slog.String("request_id", requestID),
+				slog.String("user", username),
+				slog.Any("reason", err))
+			return true
+		}
+		final, counted = pending.Ballot.cast(pending.Request, Vote{
+			UserID:   userID,
+			Username: username,
+			Decision: decision,
+			At:       time.Now(),
+		})
+		if final != "" {
+			delete(h.pending, requestID)
+		} else {
+			progress = pending.Ballot.progress(pending.Request)
+			approvers = pending.Ballot.voters(DecisionApproved)
+		}
 	}
 	h.mu.Unlock()
 
@@ -188,23 +220,40 @@ func (h *SlackHandler) HandleInteraction(ctx context.Context, actionID, value, u
 		return true // Still handled, just expired
 	}
 
+	// Quorum not reached yet: keep the buttons and show who approved so far
+	if final == "" {
+		if !counted {
+			h.log.Debug("Ignoring repeat vote",
+				slog.String("request_id", requestID),
+				slog.String("user", username))
+			return true
+		}
+		if pending.TS != "" {
+			blocks := h.buildProgressBlocks(pending.Request, progress, approvers)
+			text := h.formatFallbackText(pending.Request)
+			if err := h.client.UpdateInteractiveMessage(ctx, pending.Channel, pending.TS, blocks, text); err != nil {
+				h.log.Warn("Failed to update approval progress", slog.Any("error", err))
+			}
+		}
+		h.log.Info("Approval vote recorded",
+			slog.String("request_id", requestID),
+			slog.String("user", username),
+			slog.String("progress", progress))
+		return true
+	}
+
+	// Send response
+	response := pending.Ballot.response(pending.Request, final)
+
 	// Update message to show result
 	if pending.TS != "" {
-		blocks := h.buildResponseBlocks(pending.Request, decision, username)
-		text := h.formatResponseText(pending.Request, decision, username)
+		blocks := h.buildResponseBlocks(pending.Request, final, response.ApprovedBy)
+		text := h.formatResponseText(pending.Request, final, response.ApprovedBy)
 		if err := h.client.UpdateInteractiveMessage(ctx, pending.Channel, pending.TS, blocks, text); err != nil {
 			h.log.Warn("Failed to update response message", slog.Any("error", err))
 		}
 	}
 
-	// Send response
-	response := &Response{
-		RequestID:   requestID,
-		Decision:    decision,
-		ApprovedBy:  username,
-		RespondedAt: time.Now(),
-	}
-
 	select {
 	case pending.ResponseCh <- response:
 	default:
@@ -213,7 +262,7 @@ func (h *SlackHandler) HandleInteraction(ctx context.Context, actionID, value, u
 
 	h.log.Info("Approval interaction handled",
 		slog.String("request_id", requestID),
-		slog.String("decision", string(decision)),
+		slog.String("decision", string(final)),
 		slog.String("user", username))
 
 	return true
@@ -233,6 +282,15 @@ func (h *SlackHandler) buildApprovalBlocks(req *Request) []interface{} {
 	case StagePostFailure:
 		icon = "❌"
 		stageLabel = "Post-Failure Decision"
+	case StagePrePromotion:
+		icon = "🚢"
+		stageLabel = "Promotion Approval"
+	case StageConfigChange:
+		icon = "⚙️"
+		stageLabel = "Config Change Approval"
+	case StageNewRepo:
+		icon = "📦"
+		stageLabel = "New Repository Approval"
 	default:
 		icon = "⚠️"
 		stageLabel = "Approval Required"
@@ -242,6 +300,10 @@ func (h *SlackHandler) buildApprovalBlocks(req *Request) []interface{} {
 	headerText := fmt.Sprintf("%s *%s*\n\n*Task:* `%s`\n*Title:* %s",
 		icon, stageLabel, req.TaskID, req.Title)
 
+	if req.Escalated {
+		headerText = "⏫ *Escalated:* not answered in time\n\n" + headerText
+	}
+
 	if req.Description != "" {
 		headerText += fmt.Sprintf("\n\n%s", truncateForSlack(req.Description, 500))
 	}
@@ -258,6 +320,13 @@ func (h *SlackHandler) buildApprovalBlocks(req *Request) []interface{} {
 	timeLeft := time.Until(req.ExpiresAt).Round(time.Minute)
 	headerText += fmt.Sprintf("\n\n_Expires in: %s_", formatDuration(timeLeft))
 
+	if n := req.requiredApprovals(); n > 1 {
+		headerText += fmt.Sprintf("\n_Needs %d approvals_", n)
+	}
+	if req.MinRole != "" {
+		headerText += fmt.Sprintf("\n_Approvers must be %s or above_", req.MinRole)
+	}
+
 	blocks := []interface{}{
 		SlackSectionBlock{
 			Type: "section",
@@ -280,6 +349,15 @@ func (h *SlackHandler) buildApprovalBlocks(req *Request) []interface{} {
 	case StagePostFailure:
 		approveText = "🔄 Retry"
 		rejectText = "⏹ Abort"
+	case StagePrePromotion:
+		approveText = "🚢 Promote"
+		rejectText = "✋ Hold"
+	case StageConfigChange:
+		approveText = "✅ Apply"
+		rejectText = "❌ Reject"
+	case StageNewRepo:
+		approveText = "📦 Create"
+		rejectText = "❌ Reject"
 	default:
 		approveText = "✅ Approve"
 		rejectText = "❌ Reject"
@@ -318,6 +396,22 @@ func (h *SlackHandler) buildApprovalBlocks(req *Request) []interface{} {
 	return blocks
 }
 
+// buildProgressBlocks creates Slack blocks for a quorum request that has
+// some approvals, keeping the buttons for the remaining approvers
+func (h *SlackHandler) buildProgressBlocks(req *Request, progress, approvers string) []interface{} {
+	blocks := h.buildApprovalBlocks(req)
+	status := SlackSectionBlock{
+		Type: "section",
+		Text: &SlackTextObject{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s* — approved by %s", progress, approvers),
+		},
+	}
+	// Insert before the actions block
+	actions := blocks[len(blocks)-1]
+	return append(blocks[:len(blocks)-1], status, actions)
+}
+
 // buildResponseBlocks creates Slack blocks for a response message (no buttons)
 func (h *SlackHandler) buildResponseBlocks(req *Request, decision Decision, username string) []interface{} {
 	var icon, status string
@@ -40,6 +40,7 @@ type TelegramHandler struct {
 	client  TelegramClient
 	chatID  string
 	pending map[string]*telegramPending // requestID -> pending state
+	auth    Authorizer
 	mu      sync.RWMutex
 	log     *slog.Logger
 }
@@ -49,6 +50,7 @@ type telegramPending struct {
 	Request    *Request
 	MessageID  int64
 	ResponseCh chan *Response
+	Ballot     ballot
 }
 
 // NewTelegramHandler creates a new Telegram approval handler
@@ -130,6 +132,13 @@ func (h *TelegramHandler) CancelRequest(ctx context.Context, requestID string) e
 	return nil
 }
 
+// SetAuthorizer restricts who may answer requests beyond the approver list
+func (h *TelegramHandler) SetAuthorizer(a Authorizer) {
+	h.mu.Lock()
+	h.auth = a
+	h.mu.Unlock()
+}
+
 // HandleCallback processes a Telegram callback (button press)
 // This should be called by the main Telegram handler when receiving callbacks
 func (h *TelegramHandler) HandleCallback(ctx context.Context, callbackID, data, userID, username string) bool {
@@ -147,10 +156,33 @@ func (h *TelegramHandler) HandleCallback(ctx context.Context, callbackID, data,
 		return false // Not an approval callback
 	}
 
+	var final Decision
+	var counted bool
+	var progress string
+
 	h.mu.Lock()
 	pending, exists := h.pending[requestID]
 	if exists {
-		delete(h.pending, requestID)
+		if err := authorizeResponder(h.auth, h.Name(), pending.Request, userID, username); err != nil {
+			h.mu.Unlock()
+			h.log.Info("Ignoring approval from unauthorized user",
+				slog.String("request_id", requestID),
+				slog.String("user", username),
+				slog.Any("reason", err))
+			_ = h.client.AnswerCallback(ctx, callbackID, "⛔ You can't answer this request: "+err.Error())
+			return true
+		}
+		final, counted = pending.Ballot.cast(pending.Request, Vote{
+			UserID:   userID,
+			Username: username,
+			Decision: decision,
+			At:       time.Now(),
+		})
+		if final != "" {
+			delete(h.pending, requestID)
+		} else {
+			progress = pending.Ballot.progress(pending.Request)
+		}
 	}
 	h.mu.Unlock()
 
@@ -159,31 +191,40 @@ func (h *TelegramHandler) HandleCallback(ctx context.Context, callbackID, data,
 		return true
 	}
 
+	// Quorum not reached yet: keep the buttons and report progress
+	if final == "" {
+		if counted {
+			_ = h.client.AnswerCallback(ctx, callbackID, "✅ Approval recorded ("+progress+")")
+			h.log.Info("Approval vote recorded",
+				slog.String("request_id", requestID),
+				slog.String("user", username),
+				slog.String("progress", progress))
+		} else {
+			_ = h.client.AnswerCallback(ctx, callbackID, "You already answered this request ("+progress+")")
+		}
+		return true
+	}
+
 	// Answer callback
 	var answerText string
-	if decision == DecisionApproved {
+	if final == DecisionApproved {
 		answerText = "Approved!"
 	} else {
 		answerText = "Rejected"
 	}
 	_ = h.client.AnswerCallback(ctx, callbackID, answerText)
 
+	// Send response
+	response := pending.Ballot.response(pending.Request, final)
+
 	// Update message to show result
 	if pending.MessageID != 0 {
-		text := h.formatResponseMessage(pending.Request, decision, username)
+		text := h.formatResponseMessage(pending.Request, final, response.ApprovedBy)
 		if err := h.client.EditMessage(ctx, h.chatID, pending.MessageID, text, ""); err != nil {
 			h.log.Warn("Failed to edit response message", slog.Any("error", err))
 		}
 	}
 
-	// Send response
-	response := &Response{
-		RequestID:   requestID,
-		Decision:    decision,
-		ApprovedBy:  username,
-		RespondedAt: time.Now(),
-	}
-
 	select {
 	case pending.ResponseCh <- response:
 	default:
@@ -192,7 +233,7 @@ func (h *TelegramHandler) HandleCallback(ctx context.Context, callbackID, data,
 
 	h.log.Info("Approval callback handled",
 		slog.String("request_id", requestID),
-		slog.String("decision", string(decision)),
+		slog.String("decision", string(final)),
 		slog.String("user", username))
 
 	return true
@@ -212,6 +253,15 @@ func (h *TelegramHandler) formatApprovalMessage(req *Request) string {
 	case StagePostFailure:
 		icon = "❌"
 		stageLabel = "Post-Failure Decision"
+	case StagePrePromotion:
+		icon = "🚢"
+		stageLabel = "Promotion Approval"
+	case StageConfigChange:
+		icon = "⚙️"
+		stageLabel = "Config Change Approval"
+	case StageNewRepo:
+		icon = "📦"
+		stageLabel = "New Repository Approval"
 	default:
 		icon = "⚠️"
 		stageLabel = "Approval Required"
@@ -219,6 +269,10 @@ func (h *TelegramHandler) formatApprovalMessage(req *Request) string {
 
 	text := fmt.Sprintf("%s %s\n\nTask: %s\n%s", icon, stageLabel, req.TaskID, req.Title)
 
+	if req.Escalated {
+		text = "⏫ Escalated: not answered in time\n\n" + text
+	}
+
 	if req.Description != "" {
 		text += fmt.Sprintf("\n\n%s", truncateForTelegram(req.Description, 500))
 	}
@@ -235,6 +289,13 @@ func (h *TelegramHandler) formatApprovalMessage(req *Request) string {
 	timeLeft := time.Until(req.ExpiresAt).Round(time.Minute)
 	text += fmt.Sprintf("\n\nExpires in: %s", formatDuration(timeLeft))
 
+	if n := req.requiredApprovals(); n > 1 {
+		text += fmt.Sprintf("\nNeeds %d approvals", n)
+	}
+	if req.MinRole != "" {
+		text += fmt.Sprintf("\nApprovers must be %s or above", req.MinRole)
+	}
+
 	return text
 }
 
@@ -252,6 +313,15 @@ func (h *TelegramHandler) createApprovalKeyboard(req *Request) [][]InlineKeyboar
 	case StagePostFailure:
 		approveText = "🔄 Retry"
 		rejectText = "⏹ Abort"
+	case StagePrePromotion:
+		approveText = "🚢 Promote"
+		rejectText = "✋ Hold"
+	case StageConfigChange:
+		approveText = "✅ Apply"
+		rejectText = "❌ Reject"
+	case StageNewRepo:
+		approveText = "📦 Create"
+		rejectText = "❌ Reject"
 	default:
 		approveText = "✅ Approve"
 		rejectText = "❌ Reject"
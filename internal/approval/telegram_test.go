@@ -3,6 +3,7 @@ package approval
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -403,6 +404,66 @@ func TestTelegramHandler_HandleCallback_Approve(t *testing.T) {
 	}
 }
 
+// stageAuthorizer denies approvals at one stage
+type stageAuthorizer struct {
+	deny    Stage
+	channel string
+	userID  string
+}
+
+func (a *stageAuthorizer) AuthorizeApproval(channel, userID, stage, minRole string) error {
+	a.channel, a.userID = channel, userID
+	if Stage(stage) == a.deny {
+		return fmt.Errorf("permission denied: approve_merge requires the admin role")
+	}
+	return nil
+}
+
+func TestTelegramHandler_HandleCallback_Unauthorized(t *testing.T) {
+	client := &mockTelegramClient{}
+	handler := NewTelegramHandler(client, "chat123")
+	auth := &stageAuthorizer{deny: StagePreMerge}
+
+	// The manager hands its authorizer to handlers registered earlier
+	mgr := NewManager(nil)
+	mgr.RegisterHandler(handler)
+	mgr.SetAuthorizer(auth)
+
+	req := &Request{ID: "req-merge", TaskID: "TASK-01", Stage: StagePreMerge, Title: "Merge PR"}
+	respCh, err := handler.SendApprovalRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !handler.HandleCallback(context.Background(), "cb1", "approve:req-merge", "42", "dev") {
+		t.Fatal("expected callback to be handled")
+	}
+	if auth.channel != "telegram" || auth.userID != "42" {
+		t.Errorf("authorizer called with %q %q", auth.channel, auth.userID)
+	}
+	select {
+	case resp := <-respCh:
+		t.Fatalf("unauthorized callback answered the request: %+v", resp)
+	default:
+	}
+	cbs := client.getAnsweredCallbacks()
+	if len(cbs) != 1 || !containsString(cbs[0].Text, "requires the admin role") {
+		t.Errorf("expected denial answer, got %+v", cbs)
+	}
+
+	// The request stays open for someone allowed to answer it
+	auth.deny = ""
+	handler.HandleCallback(context.Background(), "cb2", "approve:req-merge", "7", "lead")
+	select {
+	case resp := <-respCh:
+		if resp.Decision != DecisionApproved || resp.ApprovedBy != "lead" {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for response")
+	}
+}
+
 func TestTelegramHandler_HandleCallback_Reject(t *testing.T) {
 	client := &mockTelegramClient{}
 	handler := NewTelegramHandler(client, "chat123")
@@ -635,6 +696,7 @@ func TestTelegramHandler_FormatApprovalMessage_Stages(t *testing.T) {
 		{StagePreExecution, "🚀", "Pre-Execution Approval"},
 		{StagePreMerge, "🔀", "Pre-Merge Approval"},
 		{StagePostFailure, "❌", "Post-Failure Decision"},
+		{StageNewRepo, "📦", "New Repository Approval"},
 		{Stage("unknown"), "⚠️", "Approval Required"},
 	}
 
@@ -709,6 +771,7 @@ func TestTelegramHandler_CreateApprovalKeyboard(t *testing.T) {
 		{StagePreExecution, "Execute", "Cancel"},
 		{StagePreMerge, "Merge", "Reject"},
 		{StagePostFailure, "Retry", "Abort"},
+		{StageNewRepo, "Create", "Reject"},
 		{Stage("unknown"), "Approve", "Reject"},
 	}
 
@@ -3,6 +3,7 @@ package approval
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -18,6 +19,15 @@ const (
 
 	// StagePostFailure requires approval to retry or escalate after failure
 	StagePostFailure Stage = "post_failure"
+
+	// StagePrePromotion requires approval before a release is promoted to the next environment
+	StagePrePromotion Stage = "pre_promotion"
+
+	// StageConfigChange requires approval before a requested .pilot.yaml change is proposed
+	StageConfigChange Stage = "config_change"
+
+	// StageNewRepo requires approval before a repository requested by issue is created
+	StageNewRepo Stage = "new_repo"
 )
 
 // Decision represents the user's approval decision
@@ -40,6 +50,10 @@ type Request struct {
 	CreatedAt   time.Time              // When request was created
 	ExpiresAt   time.Time              // When request expires (timeout)
 	Approvers   []string               // Required approvers (user IDs, handles)
+
+	MinApprovals int    // Distinct approvals needed; any rejection still rejects (default 1)
+	MinRole      string // Lowest team role allowed to answer, checked by the Authorizer
+	Escalated    bool   // Sent to the escalation channel after the first one expired
 }
 
 // Response represents an approval response
@@ -49,6 +63,8 @@ type Response struct {
 	ApprovedBy  string    // Who approved/rejected
 	Comment     string    // Optional comment
 	RespondedAt time.Time // When response was given
+	Votes       []Vote    // Every vote cast, for quorum requests
+	Channel     string    // Channel that decided the request
 }
 
 // Handler is the interface for approval channel handlers
@@ -65,6 +81,18 @@ type Handler interface {
 	Name() string
 }
 
+// Authorizer decides whether a chat user may answer an approval request,
+// e.g. by checking their team role. channel is the handler name, stage the
+// request's Stage and minRole the stage's min_role, empty when unset.
+type Authorizer interface {
+	AuthorizeApproval(channel, userID, stage, minRole string) error
+}
+
+// authorizable is implemented by handlers that check who answers requests
+type authorizable interface {
+	SetAuthorizer(a Authorizer)
+}
+
 // Config holds approval workflow configuration
 type Config struct {
 	Enabled bool `yaml:"enabled"`
@@ -73,6 +101,9 @@ type Config struct {
 	PreExecution *StageConfig `yaml:"pre_execution"`
 	PreMerge     *StageConfig `yaml:"pre_merge"`
 	PostFailure  *StageConfig `yaml:"post_failure"`
+	PrePromotion *StageConfig `yaml:"pre_promotion"`
+	ConfigChange *StageConfig `yaml:"config_change"`
+	NewRepo      *StageConfig `yaml:"new_repo"`
 
 	// Rule-based conditional triggers
 	Rules []Rule `yaml:"rules"`
@@ -91,6 +122,80 @@ type StageConfig struct {
 	Timeout       time.Duration `yaml:"timeout"`        // Timeout for this stage
 	DefaultAction Decision      `yaml:"default_action"` // Action on timeout
 	RequireAll    bool          `yaml:"require_all"`    // Require all approvers (vs any one)
+	MinApprovals  int           `yaml:"min_approvals"`  // Distinct approvals needed, N of the approvers (default 1)
+	MinRole       string        `yaml:"min_role"`       // Lowest team role allowed to answer (e.g. admin)
+	Channel       string        `yaml:"channel"`        // Channel to ask (default: first registered)
+
+	// Escalation sends a request that expires unanswered to a second
+	// channel before the default action applies
+	Escalation *EscalationConfig `yaml:"escalation"`
+}
+
+// EscalationConfig names where an expired approval request goes next
+type EscalationConfig struct {
+	Channel string        `yaml:"channel"` // Handler name, e.g. "slack"
+	Timeout time.Duration `yaml:"timeout"` // How long the escalation channel has (default: the stage timeout)
+}
+
+// teamRoles are the team roles a stage's min_role may name
+var teamRoles = map[string]bool{"viewer": true, "developer": true, "admin": true, "owner": true}
+
+// Validate checks the quorum, role and escalation settings of each stage
+func (c *Config) Validate() error {
+	stages := []struct {
+		name string
+		cfg  *StageConfig
+	}{
+		{"pre_execution", c.PreExecution},
+		{"pre_merge", c.PreMerge},
+		{"post_failure", c.PostFailure},
+		{"pre_promotion", c.PrePromotion},
+		{"config_change", c.ConfigChange},
+		{"new_repo", c.NewRepo},
+	}
+	for _, st := range stages {
+		if st.cfg == nil {
+			continue
+		}
+		if err := st.cfg.validate(); err != nil {
+			return fmt.Errorf("%s: %w", st.name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks one stage's settings
+func (c *StageConfig) validate() error {
+	if c.MinApprovals < 0 {
+		return fmt.Errorf("min_approvals must be >= 0, got %d", c.MinApprovals)
+	}
+	if len(c.Approvers) > 0 && c.MinApprovals > len(c.Approvers) {
+		return fmt.Errorf("min_approvals %d exceeds the %d listed approvers", c.MinApprovals, len(c.Approvers))
+	}
+	if c.MinRole != "" && !teamRoles[c.MinRole] {
+		return fmt.Errorf("unknown min_role %q", c.MinRole)
+	}
+	if c.Escalation != nil {
+		if c.Escalation.Channel == "" {
+			return fmt.Errorf("escalation.channel is required")
+		}
+		if c.Escalation.Channel == c.Channel {
+			return fmt.Errorf("escalation.channel must differ from channel")
+		}
+		if c.Escalation.Timeout < 0 {
+			return fmt.Errorf("escalation.timeout must be >= 0")
+		}
+	}
+	return nil
+}
+
+// requiredApprovals returns the approvals the stage needs: every listed
+// approver with require_all, else min_approvals
+func (c *StageConfig) requiredApprovals() int {
+	if c.RequireAll && len(c.Approvers) > 0 {
+		return len(c.Approvers)
+	}
+	return c.MinApprovals
 }
 
 // Rule defines a conditional approval trigger
@@ -138,5 +243,20 @@ func DefaultConfig() *Config {
 			Timeout:       1 * time.Hour,
 			DefaultAction: DecisionRejected,
 		},
+		PrePromotion: &StageConfig{
+			Enabled:       false,
+			Timeout:       24 * time.Hour,
+			DefaultAction: DecisionRejected,
+		},
+		ConfigChange: &StageConfig{
+			Enabled:       false,
+			Timeout:       24 * time.Hour,
+			DefaultAction: DecisionRejected,
+		},
+		NewRepo: &StageConfig{
+			Enabled:       false,
+			Timeout:       24 * time.Hour,
+			DefaultAction: DecisionRejected,
+		},
 	}
 }
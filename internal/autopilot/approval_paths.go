@@ -0,0 +1,94 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+)
+
+// ApprovalPathMatch is a changed file matching a require_approval_paths
+// pattern.
+type ApprovalPathMatch struct {
+	File    string
+	Pattern string
+}
+
+// ApprovalPathCheck is the outcome of checking a PR's files against the
+// require_approval_paths patterns.
+type ApprovalPathCheck struct {
+	Matches []ApprovalPathMatch
+}
+
+// Touched reports whether the PR changes a path that needs human approval.
+func (c *ApprovalPathCheck) Touched() bool {
+	return c != nil && len(c.Matches) > 0
+}
+
+// Explain lists the matching files for humans.
+func (c *ApprovalPathCheck) Explain() string {
+	if !c.Touched() {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Touches paths requiring human approval:\n")
+	for _, m := range c.Matches {
+		sb.WriteString(fmt.Sprintf("  %s (matches %s)\n", m.File, m.Pattern))
+	}
+	return sb.String()
+}
+
+// ValidateApprovalPaths checks require_approval_paths globs.
+func ValidateApprovalPaths(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("empty path pattern")
+		}
+		if _, err := filepath.Match(strings.TrimPrefix(pattern, "**/"), ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// MatchApprovalPaths returns the files matching any pattern, each with the
+// first pattern it matches.
+func MatchApprovalPaths(patterns, files []string) *ApprovalPathCheck {
+	check := &ApprovalPathCheck{}
+	for _, file := range files {
+		if f, pattern := firstPathMatch(patterns, []string{file}); f != "" {
+			check.Matches = append(check.Matches, ApprovalPathMatch{File: f, Pattern: pattern})
+		}
+	}
+	return check
+}
+
+// CheckApprovalPaths checks the PR's files against the configured and
+// per-project require_approval_paths, caching the outcome on prState.
+// Returns nil when no patterns are configured.
+func (m *AutoMerger) CheckApprovalPaths(ctx context.Context, prState *PRState) (*ApprovalPathCheck, error) {
+	if len(m.approvalPaths) == 0 {
+		return nil, nil
+	}
+	if prState.ApprovalPaths != nil {
+		return prState.ApprovalPaths, nil
+	}
+
+	files, err := m.ghClient.ListPullRequestFiles(ctx, m.owner, m.repo, prState.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PR files: %w", err)
+	}
+	// GitHub stops listing files at its cap; the rest may be protected
+	if len(files) >= github.MaxPullRequestFiles {
+		return nil, fmt.Errorf("PR changes %d or more files, too many to check against require_approval_paths", github.MaxPullRequestFiles)
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Filename)
+	}
+
+	prState.ApprovalPaths = MatchApprovalPaths(m.approvalPaths, paths)
+	return prState.ApprovalPaths, nil
+}
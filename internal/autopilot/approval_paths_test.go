@@ -0,0 +1,216 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestMatchApprovalPaths(t *testing.T) {
+	patterns := []string{"migrations/", "**/auth/*.go", "billing/**"}
+	check := MatchApprovalPaths(patterns, []string{
+		"cmd/main.go",
+		"migrations/004_users.sql",
+		"internal/auth/session.go",
+		"billing/stripe/webhook.go",
+		"docs/auth/README.md",
+	})
+	want := []ApprovalPathMatch{
+		{File: "migrations/004_users.sql", Pattern: "migrations/"},
+		{File: "internal/auth/session.go", Pattern: "**/auth/*.go"},
+		{File: "billing/stripe/webhook.go", Pattern: "billing/**"},
+	}
+	if len(check.Matches) != len(want) {
+		t.Fatalf("Matches = %+v, want %+v", check.Matches, want)
+	}
+	for i := range want {
+		if check.Matches[i] != want[i] {
+			t.Errorf("Matches[%d] = %+v, want %+v", i, check.Matches[i], want[i])
+		}
+	}
+	if !strings.Contains(check.Explain(), "internal/auth/session.go (matches **/auth/*.go)") {
+		t.Errorf("Explain() = %q", check.Explain())
+	}
+
+	if MatchApprovalPaths(patterns, []string{"cmd/main.go"}).Touched() {
+		t.Error("unprotected change should not be touched")
+	}
+	var none *ApprovalPathCheck
+	if none.Touched() || none.Explain() != "" {
+		t.Error("nil check should not be touched")
+	}
+}
+
+func TestValidateApprovalPaths(t *testing.T) {
+	if err := ValidateApprovalPaths([]string{"migrations/", "**/*.sql"}); err != nil {
+		t.Errorf("ValidateApprovalPaths() = %v", err)
+	}
+	if err := ValidateApprovalPaths([]string{"billing/["}); err == nil {
+		t.Error("malformed glob should fail")
+	}
+	if err := ValidateApprovalPaths([]string{" "}); err == nil {
+		t.Error("empty pattern should fail")
+	}
+}
+
+func TestController_ApprovalPathsForceApproval(t *testing.T) {
+	var mu sync.Mutex
+	var labeled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42/files":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"filename": "cmd/main.go", "additions": 3, "deletions": 1},
+				{"filename": "db/migrations/005_billing.sql", "additions": 20},
+			})
+		case "/repos/owner/repo/issues/42/labels":
+			var body struct {
+				Labels []string `json:"labels"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			labeled = append(labeled, body.Labels...)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		case "/repos/owner/repo/pulls/42/merge":
+			t.Error("PR touching require_approval_paths merged without approval")
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo",
+		WithApprovalPaths([]string{"**/migrations/*.sql"}))
+	if len(cfg.RequireApprovalPaths) != 0 {
+		t.Error("project paths should not be added to the shared config")
+	}
+
+	prState := &PRState{PRNumber: 42, Stage: StageCIPassed}
+	if err := c.handleCIPassed(context.Background(), prState); err != nil {
+		t.Fatalf("handleCIPassed() error = %v", err)
+	}
+	if prState.Stage != StageAwaitApproval {
+		t.Errorf("Stage = %s, want %s in dev", prState.Stage, StageAwaitApproval)
+	}
+	mu.Lock()
+	if len(labeled) != 1 || labeled[0] != github.LabelNeedsHuman {
+		t.Errorf("labels = %v, want [%s]", labeled, github.LabelNeedsHuman)
+	}
+	mu.Unlock()
+
+	// The cached check keeps the merge blocked without an approval manager
+	err := c.autoMerger.MergePR(context.Background(), prState)
+	if err == nil || !strings.Contains(err.Error(), "approval") {
+		t.Errorf("MergePR() error = %v, want approval failure", err)
+	}
+}
+
+func TestController_ApprovalPathsUntouched(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42/files":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"filename": "cmd/main.go", "additions": 3, "deletions": 1},
+			})
+		case "/repos/owner/repo/issues/42/labels":
+			t.Error("needs-human label added to an unprotected PR")
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.RequireApprovalPaths = []string{"migrations/"}
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+
+	prState := &PRState{PRNumber: 42, Stage: StageCIPassed}
+	if err := c.handleCIPassed(context.Background(), prState); err != nil {
+		t.Fatalf("handleCIPassed() error = %v", err)
+	}
+	if prState.Stage != StageMerging {
+		t.Errorf("Stage = %s, want %s", prState.Stage, StageMerging)
+	}
+}
+
+func TestController_ApprovalPathsRecheckedAfterNewCommits(t *testing.T) {
+	var mu sync.Mutex
+	files := []map[string]interface{}{{"filename": "cmd/main.go"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42/files":
+			mu.Lock()
+			_ = json.NewEncoder(w).Encode(files)
+			mu.Unlock()
+		case "/repos/owner/repo/pulls/42/merge":
+			t.Error("PR touching require_approval_paths merged without approval")
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.RequireApprovalPaths = []string{"migrations/"}
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+
+	prState := &PRState{PRNumber: 42, Stage: StageCIPassed, HeadSHA: "abc1234"}
+	if err := c.handleCIPassed(context.Background(), prState); err != nil {
+		t.Fatalf("handleCIPassed() error = %v", err)
+	}
+	if prState.Stage != StageMerging {
+		t.Fatalf("Stage = %s, want %s", prState.Stage, StageMerging)
+	}
+
+	// A review fix pushes a migration and sends the PR back to CI
+	mu.Lock()
+	files = append(files, map[string]interface{}{"filename": "migrations/006_drop.sql"})
+	mu.Unlock()
+	prState.Stage = StageWaitingCI
+	prState.resetHead()
+
+	err := c.autoMerger.MergePR(context.Background(), prState)
+	if err == nil || !strings.Contains(err.Error(), "approval") {
+		t.Errorf("MergePR() error = %v, want approval failure", err)
+	}
+
+	prState.Stage = StageCIPassed
+	if err := c.handleCIPassed(context.Background(), prState); err != nil {
+		t.Fatalf("handleCIPassed() error = %v", err)
+	}
+	if prState.Stage != StageAwaitApproval {
+		t.Errorf("Stage = %s, want %s after new commits", prState.Stage, StageAwaitApproval)
+	}
+}
+
+func TestCheckApprovalPaths_TooManyFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		files := make([]map[string]interface{}, 100)
+		for i := range files {
+			files[i] = map[string]interface{}{"filename": fmt.Sprintf("src/file%d.go", i)}
+		}
+		_ = json.NewEncoder(w).Encode(files)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequireApprovalPaths = []string{"migrations/"}
+	m := NewAutoMerger(github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, nil, "owner", "repo", cfg)
+
+	if _, err := m.CheckApprovalPaths(context.Background(), &PRState{PRNumber: 42}); err == nil {
+		t.Error("CheckApprovalPaths() = nil error, want failure when GitHub's file listing is capped")
+	}
+}
@@ -2,6 +2,7 @@ package autopilot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -22,23 +23,28 @@ type AutoMerger struct {
 	repo        string
 	config      *Config
 	log         *slog.Logger
+
+	// approvalPaths are the require_approval_paths of the config and project
+	approvalPaths []string
 }
 
 // NewAutoMerger creates an auto-merger with the given configuration.
 func NewAutoMerger(ghClient *github.Client, approvalMgr *approval.Manager, ciMonitor *CIMonitor, owner, repo string, cfg *Config) *AutoMerger {
 	return &AutoMerger{
-		ghClient:    ghClient,
-		approvalMgr: approvalMgr,
-		ciMonitor:   ciMonitor,
-		owner:       owner,
-		repo:        repo,
-		config:      cfg,
-		log:         slog.Default().With("component", "auto-merger"),
+		ghClient:      ghClient,
+		approvalMgr:   approvalMgr,
+		ciMonitor:     ciMonitor,
+		owner:         owner,
+		repo:          repo,
+		config:        cfg,
+		log:           slog.Default().With("component", "auto-merger"),
+		approvalPaths: cfg.RequireApprovalPaths,
 	}
 }
 
 // MergePR merges a PR with environment-appropriate safety checks.
 // For environments with RequireApproval, requests human approval before merge.
+// For environments with MergeQueue, adds the PR to the merge queue instead.
 func (m *AutoMerger) MergePR(ctx context.Context, prState *PRState) error {
 	env := m.config.Environment
 
@@ -50,19 +56,13 @@ func (m *AutoMerger) MergePR(ctx context.Context, prState *PRState) error {
 		"sha", ShortSHA(prState.HeadSHA),
 	)
 
-	// Check if approval required (prod only)
-	if m.requiresApproval(env) {
-		approved, err := m.requestApproval(ctx, prState)
-		if err != nil {
-			return fmt.Errorf("approval request failed: %w", err)
-		}
-		if !approved {
-			return fmt.Errorf("merge rejected: approval denied")
-		}
+	if err := m.Approve(ctx, prState); err != nil {
+		return err
 	}
 
-	// Auto-review if enabled (creates approval review on the PR)
-	if m.config.AutoReview {
+	// Auto-review if enabled (creates approval review on the PR). A code
+	// review that requested changes is never overridden by an approval.
+	if m.config.AutoReview && prState.ReviewVerdict != ReviewRequestChanges {
 		if err := m.approvePR(ctx, prState.PRNumber); err != nil {
 			m.log.Warn("auto-review failed", "pr", prState.PRNumber, "error", err)
 			// Continue anyway - might not need review or already reviewed
@@ -77,6 +77,18 @@ func (m *AutoMerger) MergePR(ctx context.Context, prState *PRState) error {
 		}
 	}
 
+	// Branches behind a merge queue are merged by GitHub once the queue's
+	// own checks pass; the controller follows the entry from here
+	if m.config.ResolvedEnv().MergeQueue {
+		entry, err := m.ghClient.EnqueuePullRequest(ctx, m.owner, m.repo, prState.PRNumber, prState.HeadSHA)
+		if err != nil {
+			return fmt.Errorf("enqueue failed: %w", err)
+		}
+		prState.MergeQueuePosition = entry.Position
+		m.log.Info("PR added to merge queue", "pr", prState.PRNumber, "position", entry.Position)
+		return nil
+	}
+
 	// Determine merge method, defaulting to squash
 	mergeMethod := m.config.MergeMethod
 	if mergeMethod == "" {
@@ -93,6 +105,62 @@ func (m *AutoMerger) MergePR(ctx context.Context, prState *PRState) error {
 	return nil
 }
 
+// errApprovalDenied is returned when a human rejected merging the PR
+var errApprovalDenied = errors.New("merge rejected: approval denied")
+
+// Approve gets the human approval the PR needs before it merges (approval
+// paths, merge policy, or prod without one), asking at most once per head:
+// the approval holds until new commits clear it.
+func (m *AutoMerger) Approve(ctx context.Context, prState *PRState) error {
+	if prState.Approved {
+		return nil
+	}
+	requireReview, err := m.requiresReview(ctx, prState, m.config.Environment)
+	if err != nil {
+		return fmt.Errorf("approval check failed: %w", err)
+	}
+	if requireReview {
+		approved, err := m.requestApproval(ctx, prState)
+		if err != nil {
+			return fmt.Errorf("approval request failed: %w", err)
+		}
+		if !approved {
+			return errApprovalDenied
+		}
+	}
+	prState.Approved = true
+	return nil
+}
+
+// requiresReview decides whether the PR needs human approval. A code review
+// that requested changes, or a change to a require_approval_paths path,
+// always does. Otherwise, with a merge policy configured the policy decides
+// (reusing the evaluation cached when CI passed); without one the
+// environment's require_approval does.
+func (m *AutoMerger) requiresReview(ctx context.Context, prState *PRState, env Environment) (bool, error) {
+	if m.reviewRequiresApproval(prState) {
+		return true, nil
+	}
+	check, err := m.CheckApprovalPaths(ctx, prState)
+	if err != nil {
+		return false, err
+	}
+	if check.Touched() {
+		return true, nil
+	}
+	if m.config.MergePolicy == nil {
+		return m.requiresApproval(env), nil
+	}
+	if prState.MergeEvaluation == nil {
+		eval, err := m.EvaluateMergePolicy(ctx, prState)
+		if err != nil {
+			return false, err
+		}
+		prState.MergeEvaluation = eval
+	}
+	return prState.MergeEvaluation.RequireReview(), nil
+}
+
 // requiresApproval checks if the active environment requires human approval before merge.
 // When a new-style environment is active (activeEnvName set), uses ResolvedEnv().RequireApproval.
 // Otherwise falls back to the default environment table keyed by the passed env name,
@@ -117,9 +185,23 @@ func (m *AutoMerger) requestApproval(ctx context.Context, prState *PRState) (boo
 
 	// Check if approval stage is enabled
 	if !m.approvalMgr.IsStageEnabled(approval.StagePreMerge) {
-		// When the environment requires approval, do NOT auto-approve if the approval
-		// stage is disabled. This is a safety measure: environments with RequireApproval
-		// must have explicit approval configuration.
+		// When the environment or merge policy requires approval, do NOT auto-approve
+		// if the approval stage is disabled. This is a safety measure: environments with
+		// RequireApproval must have explicit approval configuration.
+		if prState.ApprovalPaths.Touched() {
+			m.log.Error("PR touches require_approval_paths but pre-merge approval stage is not enabled, blocking merge. "+
+				"Enable approval.pre_merge.enabled",
+				"pr", prState.PRNumber,
+				"files", len(prState.ApprovalPaths.Matches))
+			return false, fmt.Errorf("require_approval_paths requires pre_merge approval to be enabled")
+		}
+		if prState.MergeEvaluation != nil {
+			m.log.Error("merge policy requires review but pre-merge approval stage is not enabled, blocking merge. "+
+				"Enable approval.pre_merge.enabled",
+				"pr", prState.PRNumber,
+				"rules", len(prState.MergeEvaluation.Matches))
+			return false, fmt.Errorf("merge policy requires pre_merge approval to be enabled")
+		}
 		if m.config.ResolvedEnv().RequireApproval {
 			m.log.Error("pre-merge approval stage not enabled in environment requiring approval, blocking merge. "+
 				"Enable approval.pre_merge.enabled or switch to an environment without require_approval",
@@ -132,17 +214,31 @@ func (m *AutoMerger) requestApproval(ctx context.Context, prState *PRState) (boo
 		return true, nil
 	}
 
+	description := fmt.Sprintf("Approve merge of PR #%d to production?", prState.PRNumber)
+	if eval := prState.MergeEvaluation; eval != nil {
+		description = fmt.Sprintf("Merge policy requires review of PR #%d.\n\n%s", prState.PRNumber, eval.Explain())
+	}
+	if check := prState.ApprovalPaths; check.Touched() {
+		description += "\n\n" + check.Explain()
+	}
+	if prState.ReviewVerdict == ReviewRequestChanges {
+		description += fmt.Sprintf("\n\nCode review requested changes: %s", prState.ReviewSummary)
+	}
+
 	req := &approval.Request{
 		TaskID:      fmt.Sprintf("merge-pr-%d", prState.PRNumber),
 		Stage:       approval.StagePreMerge,
 		Title:       fmt.Sprintf("PR #%d Merge Approval", prState.PRNumber),
-		Description: fmt.Sprintf("Approve merge of PR #%d to production?", prState.PRNumber),
+		Description: description,
 		Metadata: map[string]interface{}{
 			"pr_url":    prState.PRURL,
 			"pr_number": prState.PRNumber,
 			"head_sha":  prState.HeadSHA,
 		},
 	}
+	if prState.ReviewVerdict != "" {
+		req.Metadata["review_verdict"] = string(prState.ReviewVerdict)
+	}
 
 	m.log.Info("requesting merge approval",
 		"pr", prState.PRNumber,
@@ -0,0 +1,113 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const buildkiteAPIURL = "https://api.buildkite.com"
+
+// buildkiteProvider reads the jobs of the newest Buildkite build for a commit.
+type buildkiteProvider struct {
+	api          *ciAPI
+	organization string
+	pipeline     string
+}
+
+func newBuildkiteProvider(cfg *CIProviderConfig, owner, repo string) *buildkiteProvider {
+	org, pipeline := cfg.Organization, cfg.Pipeline
+	if org == "" {
+		org = owner
+	}
+	if pipeline == "" {
+		pipeline = repo
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = buildkiteAPIURL
+	}
+	return &buildkiteProvider{api: newCIAPI(baseURL, "Authorization", "Bearer "+cfg.Token), organization: org, pipeline: pipeline}
+}
+
+func (p *buildkiteProvider) Name() string { return CIProviderBuildkite }
+
+type buildkiteBuild struct {
+	Number int `json:"number"`
+	Jobs   []struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"` // script, waiter, manual, trigger
+		Name       string `json:"name"`
+		StepKey    string `json:"step_key"`
+		State      string `json:"state"`
+		SoftFailed bool   `json:"soft_failed"`
+		WebURL     string `json:"web_url"`
+	} `json:"jobs"`
+}
+
+func (p *buildkiteProvider) buildsPath() string {
+	return fmt.Sprintf("/v2/organizations/%s/pipelines/%s/builds", url.PathEscape(p.organization), url.PathEscape(p.pipeline))
+}
+
+func (p *buildkiteProvider) ListChecks(ctx context.Context, sha string) ([]CICheck, error) {
+	var builds []buildkiteBuild
+	if err := p.api.get(ctx, p.buildsPath()+"?commit="+url.QueryEscape(sha), &builds); err != nil {
+		return nil, fmt.Errorf("failed to list builds: %w", err)
+	}
+	if len(builds) == 0 {
+		return nil, nil
+	}
+	build := builds[0] // Newest first; earlier builds were rebuilt
+
+	var checks []CICheck
+	for _, job := range build.Jobs {
+		if job.Type != "script" {
+			continue
+		}
+		name := job.Name
+		if name == "" {
+			name = job.StepKey
+		}
+		status := buildkiteJobStatus(job.State)
+		if status == CIFailure && job.SoftFailed {
+			status = CISuccess
+		}
+		checks = append(checks, CICheck{
+			ID:     fmt.Sprintf("%d/%s", build.Number, job.ID),
+			Name:   name,
+			Status: status,
+			URL:    job.WebURL,
+		})
+	}
+	return checks, nil
+}
+
+func (p *buildkiteProvider) CheckLogs(ctx context.Context, check CICheck) (string, error) {
+	number, jobID, ok := strings.Cut(check.ID, "/")
+	if !ok {
+		return "", fmt.Errorf("invalid job ID %q", check.ID)
+	}
+	var log struct {
+		Content string `json:"content"`
+	}
+	if err := p.api.get(ctx, fmt.Sprintf("%s/%s/jobs/%s/log", p.buildsPath(), number, jobID), &log); err != nil {
+		return "", fmt.Errorf("failed to get log of job %s: %w", check.Name, err)
+	}
+	return log.Content, nil
+}
+
+// buildkiteJobStatus maps a Buildkite job state to CIStatus.
+func buildkiteJobStatus(state string) CIStatus {
+	switch state {
+	case "passed", "skipped", "broken", "not_run":
+		return CISuccess
+	case "failed", "canceled", "timed_out", "expired", "waiting_failed", "blocked_failed", "unblocked_failed":
+		return CIFailure
+	case "running", "scheduled", "assigned", "accepted", "canceling", "timing_out":
+		return CIRunning
+	default:
+		// pending, waiting, limiting, limited, blocked
+		return CIPending
+	}
+}
@@ -0,0 +1,166 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const circleCIAPIURL = "https://circleci.com"
+
+// circleCIPipelinePages bounds how far back the pipeline for a commit is
+// searched; CircleCI cannot filter pipelines by revision.
+const circleCIPipelinePages = 3
+
+// circleCIProvider reads the jobs of the CircleCI pipeline for a commit.
+type circleCIProvider struct {
+	api  *ciAPI
+	slug string
+}
+
+func newCircleCIProvider(cfg *CIProviderConfig, owner, repo string) *circleCIProvider {
+	slug := cfg.Project
+	if slug == "" {
+		slug = "gh/" + owner + "/" + repo
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = circleCIAPIURL
+	}
+	return &circleCIProvider{api: newCIAPI(baseURL, "Circle-Token", cfg.Token), slug: slug}
+}
+
+func (p *circleCIProvider) Name() string { return CIProviderCircleCI }
+
+type circleCIPage[T any] struct {
+	Items         []T    `json:"items"`
+	NextPageToken string `json:"next_page_token"`
+}
+
+type circleCIPipeline struct {
+	ID  string `json:"id"`
+	VCS struct {
+		Revision string `json:"revision"`
+	} `json:"vcs"`
+}
+
+type circleCIWorkflow struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type circleCIJob struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"` // build, approval
+	Status    string `json:"status"`
+	JobNumber int    `json:"job_number"`
+}
+
+func (p *circleCIProvider) ListChecks(ctx context.Context, sha string) ([]CICheck, error) {
+	pipelineID, err := p.findPipeline(ctx, sha)
+	if err != nil || pipelineID == "" {
+		return nil, err
+	}
+
+	var workflows circleCIPage[circleCIWorkflow]
+	if err := p.api.get(ctx, "/api/v2/pipeline/"+pipelineID+"/workflow", &workflows); err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+	var checks []CICheck
+	for _, wf := range workflows.Items {
+		var jobs circleCIPage[circleCIJob]
+		if err := p.api.get(ctx, "/api/v2/workflow/"+wf.ID+"/job", &jobs); err != nil {
+			return nil, fmt.Errorf("failed to list jobs of workflow %s: %w", wf.Name, err)
+		}
+		for _, job := range jobs.Items {
+			if job.Type == "approval" {
+				continue
+			}
+			check := CICheck{ID: fmt.Sprint(job.JobNumber), Name: job.Name, Status: circleCIJobStatus(job.Status)}
+			if job.JobNumber > 0 {
+				check.URL = fmt.Sprintf("https://app.circleci.com/pipelines/%s/jobs/%d", p.slug, job.JobNumber)
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks, nil
+}
+
+// findPipeline returns the ID of the newest pipeline for sha, or "" when
+// CircleCI has not started one.
+func (p *circleCIProvider) findPipeline(ctx context.Context, sha string) (string, error) {
+	pageToken := ""
+	for i := 0; i < circleCIPipelinePages; i++ {
+		path := "/api/v2/project/" + p.slug + "/pipeline"
+		if pageToken != "" {
+			path += "?page-token=" + url.QueryEscape(pageToken)
+		}
+		var page circleCIPage[circleCIPipeline]
+		if err := p.api.get(ctx, path, &page); err != nil {
+			return "", fmt.Errorf("failed to list pipelines: %w", err)
+		}
+		for _, pipeline := range page.Items {
+			if pipeline.VCS.Revision == sha {
+				return pipeline.ID, nil
+			}
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	return "", nil
+}
+
+// CheckLogs returns the output of the job's failed steps, read through the
+// v1.1 API since v2 has no log endpoint.
+func (p *circleCIProvider) CheckLogs(ctx context.Context, check CICheck) (string, error) {
+	var details struct {
+		Steps []struct {
+			Name    string `json:"name"`
+			Actions []struct {
+				Status    string `json:"status"`
+				OutputURL string `json:"output_url"`
+			} `json:"actions"`
+		} `json:"steps"`
+	}
+	if err := p.api.get(ctx, "/api/v1.1/project/"+p.slug+"/"+check.ID, &details); err != nil {
+		return "", fmt.Errorf("failed to get job %s: %w", check.ID, err)
+	}
+
+	var sb strings.Builder
+	for _, step := range details.Steps {
+		for _, action := range step.Actions {
+			if action.Status != "failed" || action.OutputURL == "" {
+				continue
+			}
+			var output []struct {
+				Message string `json:"message"`
+			}
+			if err := p.api.get(ctx, action.OutputURL, &output); err != nil {
+				return "", fmt.Errorf("failed to get output of step %s: %w", step.Name, err)
+			}
+			for _, o := range output {
+				sb.WriteString(o.Message)
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// circleCIJobStatus maps a CircleCI job status to CIStatus.
+func circleCIJobStatus(status string) CIStatus {
+	switch status {
+	case "success", "not_run":
+		return CISuccess
+	case "failed", "error", "failing", "infrastructure_fail", "timedout", "canceled", "unauthorized":
+		return CIFailure
+	case "running", "queued":
+		return CIRunning
+	default:
+		// blocked, on_hold, not_running
+		return CIPending
+	}
+}
@@ -0,0 +1,79 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/alekspetrov/pilot/internal/adapters/gitlab"
+)
+
+// gitLabCIProvider reads the jobs of the newest GitLab pipeline for a commit.
+type gitLabCIProvider struct {
+	client *gitlab.Client
+}
+
+func newGitLabCIProvider(cfg *CIProviderConfig, owner, repo string) *gitLabCIProvider {
+	project := cfg.Project
+	if project == "" {
+		project = owner + "/" + repo
+	}
+	if cfg.BaseURL != "" {
+		return &gitLabCIProvider{client: gitlab.NewClientWithBaseURL(cfg.Token, project, cfg.BaseURL)}
+	}
+	return &gitLabCIProvider{client: gitlab.NewClient(cfg.Token, project)}
+}
+
+func (p *gitLabCIProvider) Name() string { return CIProviderGitLab }
+
+func (p *gitLabCIProvider) ListChecks(ctx context.Context, sha string) ([]CICheck, error) {
+	pipelines, err := p.client.ListPipelinesForSHA(ctx, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+	jobs, err := p.client.ListPipelineJobs(ctx, pipelines[0].ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs of pipeline %d: %w", pipelines[0].ID, err)
+	}
+
+	checks := make([]CICheck, 0, len(jobs))
+	for _, job := range jobs {
+		checks = append(checks, CICheck{
+			ID:     strconv.Itoa(job.ID),
+			Name:   job.Name,
+			Status: gitLabJobStatus(job),
+			URL:    job.WebURL,
+		})
+	}
+	return checks, nil
+}
+
+func (p *gitLabCIProvider) CheckLogs(ctx context.Context, check CICheck) (string, error) {
+	id, err := strconv.Atoi(check.ID)
+	if err != nil {
+		return "", fmt.Errorf("invalid job ID %q", check.ID)
+	}
+	return p.client.GetJobTrace(ctx, id)
+}
+
+// gitLabJobStatus maps a GitLab job status to CIStatus. Jobs allowed to fail
+// and manual jobs never block.
+func gitLabJobStatus(job *gitlab.Job) CIStatus {
+	switch job.Status {
+	case gitlab.PipelineSuccess, gitlab.PipelineSkipped, gitlab.PipelineManual:
+		return CISuccess
+	case gitlab.PipelineFailed, gitlab.PipelineCanceled:
+		if job.AllowFailure {
+			return CISuccess
+		}
+		return CIFailure
+	case gitlab.PipelineRunning:
+		return CIRunning
+	default:
+		// created, pending, preparing, scheduled, waiting_for_resource
+		return CIPending
+	}
+}
@@ -0,0 +1,339 @@
+package autopilot
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CIFailureClass categorizes why a CI job failed, judged from its log.
+type CIFailureClass string
+
+// CI failure classes, from most to least fundamental. When a log shows
+// several, the first one wins: a compile error also fails every test that
+// needed the package.
+const (
+	CIClassCompile CIFailureClass = "compile"
+	CIClassTimeout CIFailureClass = "timeout"
+	CIClassTest    CIFailureClass = "test"
+	CIClassLint    CIFailureClass = "lint"
+	CIClassUnknown CIFailureClass = "unknown"
+)
+
+var ciClassOrder = []CIFailureClass{CIClassCompile, CIClassTimeout, CIClassTest, CIClassLint}
+
+// CICheckLog is the log of one failed CI check.
+type CICheckLog struct {
+	Check string
+	Log   string
+}
+
+// CILogError is one error extracted from CI logs, deduplicated across
+// repeated occurrences and checks.
+type CILogError struct {
+	Class CIFailureClass
+	// Lines holds the error line followed by its indented detail lines, such
+	// as the assertion messages under a failed Go test.
+	Lines []string
+	// Checks lists the checks the error appeared in, in log order.
+	Checks []string
+	// Count is how many times the error appeared in total.
+	Count int
+}
+
+// CILogAnalysis is the result of analyzing failed checks' logs.
+type CILogAnalysis struct {
+	// Class is the most fundamental failure class among Errors, or
+	// CIClassUnknown when no error lines were recognized.
+	Class  CIFailureClass
+	Errors []*CILogError
+	// Unrecognized holds the tails of logs no error lines were extracted
+	// from, so the fix issue still shows something.
+	Unrecognized []CICheckLog
+}
+
+// maxErrorDetailLines caps the detail lines kept under each error.
+const maxErrorDetailLines = 5
+
+// maxUnrecognizedLines is how many trailing lines are kept from logs without
+// recognized errors; runners print the failure last.
+const maxUnrecognizedLines = 30
+
+var (
+	ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+	// GitHub Actions prefixes every log line with an RFC 3339 timestamp.
+	logTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+	// Durations, addresses and goroutine IDs vary between otherwise
+	// identical errors.
+	durationPattern  = regexp.MustCompile(`\(\d+(\.\d+)?m?s\)`)
+	addressPattern   = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+	goroutinePattern = regexp.MustCompile(`goroutine \d+`)
+	// ESLint prints the file on its own line, then one line per problem.
+	eslintFilePattern    = regexp.MustCompile(`^[\w./@-]+\.(js|jsx|ts|tsx|mjs|cjs|vue)$`)
+	eslintProblemPattern = regexp.MustCompile(`^\s+(\d+:\d+)\s+error\s+(.+)$`)
+	goPackageFailPattern = regexp.MustCompile(`^FAIL\s+\S+\s+(\d+(\.\d+)?s|\[[\w ]+\])$`)
+)
+
+// ciErrorPatterns recognize error lines, checked in order.
+var ciErrorPatterns = []struct {
+	class   CIFailureClass
+	pattern *regexp.Regexp
+}{
+	{CIClassTimeout, regexp.MustCompile(`(?i)(panic: test timed out after|exceeded the maximum execution time|too long with no output|timed out after \d+ ?(s|m|h|seconds|minutes))`)},
+	// golangci-lint: file.go:12:5: message (linter)
+	{CIClassLint, regexp.MustCompile(`^\S+\.\w+:\d+(:\d+)?: .+ \([\w-]+\)$`)},
+	// Go: ./file.go:12:5: undefined: foo
+	{CIClassCompile, regexp.MustCompile(`^\S+\.go:\d+:\d+: `)},
+	// TypeScript: src/a.ts(12,5): error TS2304: ... or src/a.ts:12:5 - error TS2304: ...
+	{CIClassCompile, regexp.MustCompile(`error TS\d+: `)},
+	// Rust: error[E0425]: cannot find value
+	{CIClassCompile, regexp.MustCompile(`^error(\[E\d+\])?: `)},
+	// Go: --- FAIL: TestLogin (0.01s)
+	{CIClassTest, regexp.MustCompile(`^\s*--- FAIL: \S+`)},
+	{CIClassTest, regexp.MustCompile(`^panic: `)},
+	// pytest: FAILED tests/test_auth.py::test_login - AssertionError
+	{CIClassTest, regexp.MustCompile(`^FAILED \S+`)},
+	// Jest: ● Auth › logs in
+	{CIClassTest, regexp.MustCompile(`^\s*● \S`)},
+	// Jest suites and bare test names: FAIL src/auth.test.ts
+	{CIClassTest, regexp.MustCompile(`^FAIL\s+\S+`)},
+}
+
+// AnalyzeCILogs extracts error lines from failed checks' logs, classifies
+// them and deduplicates errors repeated within or across checks.
+func AnalyzeCILogs(logs []CICheckLog) *CILogAnalysis {
+	analysis := &CILogAnalysis{Class: CIClassUnknown}
+	seen := make(map[string]*CILogError)
+
+	for _, cl := range logs {
+		lines := cleanCILog(cl.Log)
+		errs := extractCIErrors(lines)
+		if len(errs) == 0 {
+			if tail := logTail(lines, maxUnrecognizedLines); tail != "" {
+				analysis.Unrecognized = append(analysis.Unrecognized, CICheckLog{Check: cl.Check, Log: tail})
+			}
+			continue
+		}
+		for _, e := range errs {
+			key := string(e.Class) + "\x00" + normalizeCIError(e.Lines[0])
+			if existing, ok := seen[key]; ok {
+				existing.Count++
+				if !slices.Contains(existing.Checks, cl.Check) {
+					existing.Checks = append(existing.Checks, cl.Check)
+				}
+				continue
+			}
+			e.Checks = []string{cl.Check}
+			e.Count = 1
+			seen[key] = e
+			analysis.Errors = append(analysis.Errors, e)
+		}
+	}
+
+	for _, class := range ciClassOrder {
+		if analysis.count(class) > 0 {
+			analysis.Class = class
+			break
+		}
+	}
+	return analysis
+}
+
+// HasErrors reports whether any error lines were recognized.
+func (a *CILogAnalysis) HasErrors() bool {
+	return a != nil && len(a.Errors) > 0
+}
+
+// Summary describes the failure for a fix issue, e.g. "test (3 unique
+// errors, 5 occurrences)". It is empty when no errors were recognized.
+func (a *CILogAnalysis) Summary() string {
+	if !a.HasErrors() {
+		return ""
+	}
+	total := 0
+	for _, e := range a.Errors {
+		total += e.Count
+	}
+	noun := "error"
+	if len(a.Errors) != 1 {
+		noun = "errors"
+	}
+	if total == len(a.Errors) {
+		return fmt.Sprintf("%s (%d %s)", a.Class, len(a.Errors), noun)
+	}
+	return fmt.Sprintf("%s (%d unique %s, %d occurrences)", a.Class, len(a.Errors), noun, total)
+}
+
+// Excerpt renders the extracted errors grouped by the check they first
+// appeared in, each group headed "=== check ===", followed by the tails of
+// unrecognized logs. The result is cut at a line boundary to at most maxLen
+// bytes.
+func (a *CILogAnalysis) Excerpt(maxLen int) string {
+	if a == nil {
+		return ""
+	}
+
+	var checks []string
+	byCheck := make(map[string][]*CILogError)
+	for _, e := range a.Errors {
+		first := e.Checks[0]
+		if _, ok := byCheck[first]; !ok {
+			checks = append(checks, first)
+		}
+		byCheck[first] = append(byCheck[first], e)
+	}
+
+	var sections []string
+	for _, check := range checks {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s ===", check))
+		for _, e := range byCheck[check] {
+			sb.WriteString("\n")
+			sb.WriteString(e.Lines[0])
+			var notes []string
+			if e.Count > 1 {
+				notes = append(notes, fmt.Sprintf("x%d", e.Count))
+			}
+			if len(e.Checks) > 1 {
+				notes = append(notes, "also in "+strings.Join(e.Checks[1:], ", "))
+			}
+			if len(notes) > 0 {
+				sb.WriteString(" [" + strings.Join(notes, "; ") + "]")
+			}
+			for _, detail := range e.Lines[1:] {
+				sb.WriteString("\n")
+				sb.WriteString(detail)
+			}
+		}
+		sections = append(sections, sb.String())
+	}
+	for _, cl := range a.Unrecognized {
+		sections = append(sections, fmt.Sprintf("=== %s ===\n%s", cl.Check, cl.Log))
+	}
+
+	result := strings.Join(sections, "\n\n")
+	if len(result) > maxLen {
+		result = result[:maxLen]
+		if i := strings.LastIndexByte(result, '\n'); i > 0 {
+			result = result[:i]
+		}
+	}
+	return result
+}
+
+func (a *CILogAnalysis) count(class CIFailureClass) int {
+	n := 0
+	for _, e := range a.Errors {
+		if e.Class == class {
+			n++
+		}
+	}
+	return n
+}
+
+// cleanCILog splits a log into lines without color codes, timestamps and
+// runner bookkeeping.
+func cleanCILog(log string) []string {
+	var lines []string
+	for _, line := range strings.Split(log, "\n") {
+		line = ansiEscapePattern.ReplaceAllString(line, "")
+		line = logTimestampPattern.ReplaceAllString(line, "")
+		line = strings.TrimRight(line, " \t\r")
+		if strings.HasPrefix(line, "##[") {
+			// Keep ##[error] annotations, drop groups and other markers
+			if !strings.HasPrefix(line, "##[error]") {
+				continue
+			}
+			line = strings.TrimPrefix(line, "##[error]")
+			if strings.HasPrefix(line, "Process completed with exit code") {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// extractCIErrors returns the error lines recognized in a log, each with up
+// to maxErrorDetailLines indented lines following it.
+func extractCIErrors(lines []string) []*CILogError {
+	var errs []*CILogError
+	var current *CILogError
+	var eslintFile string
+	var packageFails []*CILogError
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if eslintFilePattern.MatchString(line) {
+			eslintFile = line
+			current = nil
+			continue
+		}
+		if m := eslintProblemPattern.FindStringSubmatch(line); m != nil && eslintFile != "" {
+			current = &CILogError{Class: CIClassLint, Lines: []string{eslintFile + ":" + m[1] + " " + strings.TrimSpace(m[2])}}
+			errs = append(errs, current)
+			continue
+		}
+		if goPackageFailPattern.MatchString(line) {
+			// "FAIL pkg 0.01s" repeats the failed tests above it; keep it only
+			// when nothing else was found, e.g. a test binary that crashed
+			packageFails = append(packageFails, &CILogError{Class: CIClassTest, Lines: []string{line}})
+			current = nil
+			continue
+		}
+
+		class, ok := classifyCILine(line)
+		if ok {
+			current = &CILogError{Class: class, Lines: []string{strings.TrimSpace(line)}}
+			errs = append(errs, current)
+			continue
+		}
+		if current != nil && (line[0] == ' ' || line[0] == '\t') && len(current.Lines) <= maxErrorDetailLines {
+			current.Lines = append(current.Lines, line)
+			continue
+		}
+		current = nil
+	}
+
+	if len(errs) == 0 {
+		return packageFails
+	}
+	return errs
+}
+
+// classifyCILine returns the class of an error line.
+func classifyCILine(line string) (CIFailureClass, bool) {
+	for _, p := range ciErrorPatterns {
+		if p.pattern.MatchString(line) {
+			return p.class, true
+		}
+	}
+	return "", false
+}
+
+// normalizeCIError strips the parts of an error line that differ between
+// repeats of the same error.
+func normalizeCIError(line string) string {
+	line = durationPattern.ReplaceAllString(line, "")
+	line = addressPattern.ReplaceAllString(line, "0x")
+	line = goroutinePattern.ReplaceAllString(line, "goroutine")
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// logTail returns the last n non-empty lines.
+func logTail(lines []string, n int) string {
+	var tail []string
+	for i := len(lines) - 1; i >= 0 && len(tail) < n; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			tail = append(tail, lines[i])
+		}
+	}
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	return strings.Join(tail, "\n")
+}
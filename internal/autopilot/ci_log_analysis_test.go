@@ -0,0 +1,155 @@
+package autopilot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeCILogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		logs      []CICheckLog
+		wantClass CIFailureClass
+		want      []string
+		notWant   []string
+	}{
+		{
+			name: "go test failures",
+			logs: []CICheckLog{{Check: "test", Log: "2024-05-01T10:00:00.1234567Z ##[group]Run go test ./...\n" +
+				"2024-05-01T10:00:01.0000000Z go: downloading github.com/foo/bar v1.0.0\n" +
+				"=== RUN   TestLogin\n" +
+				"--- FAIL: TestLogin (0.01s)\n" +
+				"    auth_test.go:42: status = 500, want 200\n" +
+				"ok  \tgithub.com/acme/app/db\t0.1s\n" +
+				"FAIL\tgithub.com/acme/app/auth\t0.02s\n" +
+				"##[error]Process completed with exit code 1.\n"}},
+			wantClass: CIClassTest,
+			want:      []string{"=== test ===\n--- FAIL: TestLogin (0.01s)\n    auth_test.go:42: status = 500, want 200"},
+			notWant:   []string{"downloading", "##[", "Process completed", "FAIL\tgithub.com"},
+		},
+		{
+			name: "compile error wins over resulting test failures",
+			logs: []CICheckLog{{Check: "build", Log: "# github.com/acme/app/auth\n" +
+				"auth/login.go:12:2: undefined: hashPassword\n" +
+				"FAIL\tgithub.com/acme/app/auth [build failed]\n"}},
+			wantClass: CIClassCompile,
+			want:      []string{"auth/login.go:12:2: undefined: hashPassword"},
+			notWant:   []string{"[build failed]"},
+		},
+		{
+			name: "golangci-lint and eslint",
+			logs: []CICheckLog{{Check: "lint", Log: "\x1b[31mauth/login.go:30:9: Error return value is not checked (errcheck)\x1b[0m\n" +
+				"/src/web/app.ts\n" +
+				"  12:5  error  'x' is assigned a value but never used  no-unused-vars\n"}},
+			wantClass: CIClassLint,
+			want: []string{
+				"auth/login.go:30:9: Error return value is not checked (errcheck)",
+				"/src/web/app.ts:12:5 'x' is assigned a value but never used  no-unused-vars",
+			},
+			notWant: []string{"\x1b["},
+		},
+		{
+			name:      "timeout",
+			logs:      []CICheckLog{{Check: "test", Log: "panic: test timed out after 10m0s\n\trunning tests:\n\t\tTestSlow (10m0s)\n\ngoroutine 12 [running]:\n"}},
+			wantClass: CIClassTimeout,
+			want:      []string{"panic: test timed out after 10m0s\n\trunning tests:\n\t\tTestSlow (10m0s)"},
+			notWant:   []string{"goroutine"},
+		},
+		{
+			name: "pytest and jest",
+			logs: []CICheckLog{
+				{Check: "py", Log: "tests/test_auth.py F\nFAILED tests/test_auth.py::test_login - AssertionError: 500 != 200\n"},
+				{Check: "js", Log: "  ● Auth › logs in\n\n    expect(received).toBe(expected)\n"},
+			},
+			wantClass: CIClassTest,
+			want:      []string{"=== py ===\nFAILED tests/test_auth.py::test_login", "=== js ===\n● Auth › logs in"},
+		},
+		{
+			name: "repeated failures deduplicated across checks",
+			logs: []CICheckLog{
+				{Check: "test", Log: "--- FAIL: TestLogin (0.01s)\n--- FAIL: TestLogin (0.03s)\n"},
+				{Check: "test-race", Log: "--- FAIL: TestLogin (0.05s)\n"},
+			},
+			wantClass: CIClassTest,
+			want:      []string{"=== test ===\n--- FAIL: TestLogin (0.01s) [x3; also in test-race]"},
+			notWant:   []string{"=== test-race ===", "0.03s"},
+		},
+		{
+			name:      "unrecognized log keeps tail",
+			logs:      []CICheckLog{{Check: "deploy", Log: strings.Repeat("step\n", 100) + "Error: bucket not found\n"}},
+			wantClass: CIClassUnknown,
+			want:      []string{"=== deploy ===\n", "Error: bucket not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeCILogs(tt.logs)
+			if analysis.Class != tt.wantClass {
+				t.Errorf("Class = %s, want %s", analysis.Class, tt.wantClass)
+			}
+			excerpt := analysis.Excerpt(2000)
+			for _, w := range tt.want {
+				if !strings.Contains(excerpt, w) {
+					t.Errorf("excerpt missing %q:\n%s", w, excerpt)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(excerpt, nw) {
+					t.Errorf("excerpt should not contain %q:\n%s", nw, excerpt)
+				}
+			}
+		})
+	}
+}
+
+func TestCILogAnalysis_Summary(t *testing.T) {
+	analysis := AnalyzeCILogs([]CICheckLog{
+		{Check: "test", Log: "--- FAIL: TestA (0.01s)\n--- FAIL: TestB (0.01s)\n"},
+		{Check: "test-race", Log: "--- FAIL: TestA (0.02s)\n"},
+	})
+	if got := analysis.Summary(); got != "test (2 unique errors, 3 occurrences)" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	unknown := AnalyzeCILogs([]CICheckLog{{Check: "deploy", Log: "exit 1"}})
+	if unknown.HasErrors() || unknown.Summary() != "" {
+		t.Errorf("unrecognized log: HasErrors = %v, Summary = %q", unknown.HasErrors(), unknown.Summary())
+	}
+
+	var none *CILogAnalysis
+	if none.Excerpt(100) != "" || none.Summary() != "" {
+		t.Error("nil analysis should render empty")
+	}
+}
+
+func TestCILogAnalysis_ExcerptTruncatesAtLine(t *testing.T) {
+	var log strings.Builder
+	for i := 0; i < 50; i++ {
+		log.WriteString("--- FAIL: TestCase" + strings.Repeat("x", i) + "\n")
+	}
+	excerpt := AnalyzeCILogs([]CICheckLog{{Check: "test", Log: log.String()}}).Excerpt(200)
+	if len(excerpt) > 200 {
+		t.Fatalf("len = %d, want <= 200", len(excerpt))
+	}
+	for _, line := range strings.Split(excerpt, "\n")[1:] {
+		if !strings.HasPrefix(line, "--- FAIL: TestCase") {
+			t.Errorf("partial line %q", line)
+		}
+	}
+}
+
+func TestFeedbackLoop_GenerateBodyWithAnalysis(t *testing.T) {
+	fl := &FeedbackLoop{}
+	analysis := AnalyzeCILogs([]CICheckLog{{Check: "build", Log: "main.go:3:2: undefined: foo\n"}})
+	body := fl.generateBody(&PRState{PRNumber: 7}, FailureCIPreMerge, []string{"build"}, analysis.Excerpt(maxIssueLogLen), analysis, 1, nil)
+
+	for _, want := range []string{"- **Failure Class**: compile (1 error)", "## CI Errors\n\n```\n=== build ===\nmain.go:3:2: undefined: foo\n```"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "<details>") {
+		t.Error("extracted errors should not be collapsed")
+	}
+}
@@ -5,18 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 	"path"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
-// CIMonitor watches GitHub CI status for PRs.
+// CIMonitor watches CI status for PRs through the configured CI provider.
 type CIMonitor struct {
-	ghClient       *github.Client
-	owner          string
-	repo           string
+	provider       CIProvider
 	pollInterval   time.Duration
 	waitTimeout    time.Duration
 	requiredChecks []string
@@ -25,6 +23,9 @@ type CIMonitor struct {
 	// CI checks configuration (auto-discovery)
 	ciChecks *CIChecksConfig
 
+	// leakGuard redacts credentials from fetched logs (nil keeps them as is)
+	leakGuard *quality.LeakGuard
+
 	// Discovery state for auto mode
 	discoveredChecks map[string][]string  // sha -> check names
 	discoveryStart   map[string]time.Time // sha -> when discovery started
@@ -36,7 +37,16 @@ type CIMonitor struct {
 // the environment-specific CITimeout. This lets environments define shorter timeouts
 // (e.g. dev uses 5m) while still respecting explicit user overrides in tests or configs.
 // Handles both legacy RequiredChecks and new CIChecks configuration.
+// CI is read from cfg.CIProvider, falling back to GitHub Checks when it is
+// invalid.
 func NewCIMonitor(ghClient *github.Client, owner, repo string, cfg *Config) *CIMonitor {
+	log := slog.Default().With("component", "ci-monitor")
+	provider, err := NewCIProvider(cfg.CIProvider, ghClient, owner, repo)
+	if err != nil {
+		log.Warn("invalid CI provider, using GitHub Checks", "error", err)
+		provider, _ = NewCIProvider(nil, ghClient, owner, repo)
+	}
+
 	timeout := cfg.CIWaitTimeout
 	envCITimeout := cfg.ResolvedEnv().CITimeout
 	if envCITimeout > 0 && (timeout == 0 || envCITimeout < timeout) {
@@ -74,19 +84,27 @@ func NewCIMonitor(ghClient *github.Client, owner, repo string, cfg *Config) *CIM
 	}
 
 	return &CIMonitor{
-		ghClient:         ghClient,
-		owner:            owner,
-		repo:             repo,
+		provider:         provider,
 		pollInterval:     cfg.CIPollInterval,
 		waitTimeout:      timeout,
 		requiredChecks:   requiredChecks,
 		ciChecks:         ciChecks,
 		discoveredChecks: make(map[string][]string),
 		discoveryStart:   make(map[string]time.Time),
-		log:              slog.Default().With("component", "ci-monitor"),
+		log:              log,
 	}
 }
 
+// SetProvider replaces the CI provider, e.g. with a per-project one.
+func (m *CIMonitor) SetProvider(p CIProvider) {
+	m.provider = p
+}
+
+// Provider returns the CI provider checks are read from.
+func (m *CIMonitor) Provider() CIProvider {
+	return m.provider
+}
+
 // WaitForCI polls until all required checks complete or timeout.
 // Returns CISuccess if all checks pass, CIFailure if any fail,
 // or error on context cancellation or timeout.
@@ -96,7 +114,7 @@ func (m *CIMonitor) WaitForCI(ctx context.Context, sha string) (CIStatus, error)
 	defer ticker.Stop()
 
 	// Log initial status
-	m.log.Info("waiting for CI", "sha", ShortSHA(sha), "timeout", m.waitTimeout, "required_checks", m.requiredChecks)
+	m.log.Info("waiting for CI", "sha", ShortSHA(sha), "provider", m.provider.Name(), "timeout", m.waitTimeout, "required_checks", m.requiredChecks)
 
 	for {
 		select {
@@ -124,21 +142,20 @@ func (m *CIMonitor) WaitForCI(ctx context.Context, sha string) (CIStatus, error)
 
 // checkStatus gets current CI status for a SHA.
 func (m *CIMonitor) checkStatus(ctx context.Context, sha string) (CIStatus, error) {
-	// Get check runs (GitHub Actions)
-	checkRuns, err := m.ghClient.ListCheckRuns(ctx, m.owner, m.repo, sha)
+	checks, err := m.provider.ListChecks(ctx, sha)
 	if err != nil {
 		return CIPending, err
 	}
 
 	// Store discovered check names for later retrieval (filtered by exclusions in auto mode)
-	if len(checkRuns.CheckRuns) > 0 {
+	if len(checks) > 0 {
 		m.mu.RLock()
 		_, hasDiscovered := m.discoveredChecks[sha]
 		m.mu.RUnlock()
 
 		if !hasDiscovered {
-			names := make([]string, 0, len(checkRuns.CheckRuns))
-			for _, run := range checkRuns.CheckRuns {
+			names := make([]string, 0, len(checks))
+			for _, run := range checks {
 				// In auto mode, filter out excluded checks
 				if m.ciChecks != nil && m.ciChecks.Mode == "auto" && m.matchesExclude(run.Name) {
 					continue
@@ -154,12 +171,12 @@ func (m *CIMonitor) checkStatus(ctx context.Context, sha string) (CIStatus, erro
 
 	// Auto mode: use discovered checks with exclusions and grace period
 	if m.ciChecks != nil && m.ciChecks.Mode == "auto" {
-		return m.checkAutoDiscoveredRuns(sha, checkRuns)
+		return m.checkAutoDiscoveredRuns(sha, checks)
 	}
 
 	// Manual mode: If no required checks configured, check all runs
 	if len(m.requiredChecks) == 0 {
-		return m.checkAllRuns(checkRuns), nil
+		return m.checkAllRuns(checks), nil
 	}
 
 	// Track required checks
@@ -168,10 +185,10 @@ func (m *CIMonitor) checkStatus(ctx context.Context, sha string) (CIStatus, erro
 		requiredStatus[name] = CIPending
 	}
 
-	// Map check runs to status
-	for _, run := range checkRuns.CheckRuns {
+	// Map checks to status
+	for _, run := range checks {
 		if _, ok := requiredStatus[run.Name]; ok {
-			requiredStatus[run.Name] = m.mapCheckStatus(run.Status, run.Conclusion)
+			requiredStatus[run.Name] = run.Status
 		}
 	}
 
@@ -180,17 +197,16 @@ func (m *CIMonitor) checkStatus(ctx context.Context, sha string) (CIStatus, erro
 }
 
 // checkAllRuns returns aggregate status when no required checks are configured.
-func (m *CIMonitor) checkAllRuns(checkRuns *github.CheckRunsResponse) CIStatus {
-	if checkRuns.TotalCount == 0 {
+func (m *CIMonitor) checkAllRuns(checks []CICheck) CIStatus {
+	if len(checks) == 0 {
 		return CIPending
 	}
 
 	hasFailure := false
 	hasPending := false
 
-	for _, run := range checkRuns.CheckRuns {
-		status := m.mapCheckStatus(run.Status, run.Conclusion)
-		switch status {
+	for _, run := range checks {
+		switch run.Status {
 		case CIFailure:
 			hasFailure = true
 		case CIPending, CIRunning:
@@ -209,10 +225,10 @@ func (m *CIMonitor) checkAllRuns(checkRuns *github.CheckRunsResponse) CIStatus {
 
 // checkAutoDiscoveredRuns checks CI status in auto mode with exclusion filtering.
 // It waits during the grace period if no checks are found yet.
-func (m *CIMonitor) checkAutoDiscoveredRuns(sha string, checkRuns *github.CheckRunsResponse) (CIStatus, error) {
+func (m *CIMonitor) checkAutoDiscoveredRuns(sha string, checks []CICheck) (CIStatus, error) {
 	// Filter checks by exclusion patterns
-	var filteredRuns []github.CheckRun
-	for _, run := range checkRuns.CheckRuns {
+	var filteredRuns []CICheck
+	for _, run := range checks {
 		if !m.matchesExclude(run.Name) {
 			filteredRuns = append(filteredRuns, run)
 		}
@@ -263,8 +279,7 @@ func (m *CIMonitor) checkAutoDiscoveredRuns(sha string, checkRuns *github.CheckR
 	hasPending := false
 
 	for _, run := range filteredRuns {
-		status := m.mapCheckStatus(run.Status, run.Conclusion)
-		switch status {
+		switch run.Status {
 		case CIFailure:
 			hasFailure = true
 		case CIPending, CIRunning:
@@ -324,28 +339,6 @@ func (m *CIMonitor) aggregateStatus(statuses map[string]CIStatus) CIStatus {
 	return CISuccess
 }
 
-// mapCheckStatus maps GitHub check status to CIStatus.
-func (m *CIMonitor) mapCheckStatus(status, conclusion string) CIStatus {
-	switch status {
-	case github.CheckRunQueued, github.CheckRunInProgress:
-		return CIRunning
-	case github.CheckRunCompleted:
-		switch conclusion {
-		case github.ConclusionSuccess:
-			return CISuccess
-		case github.ConclusionFailure, github.ConclusionCancelled, github.ConclusionTimedOut:
-			return CIFailure
-		case github.ConclusionSkipped, github.ConclusionNeutral:
-			// Skipped/neutral checks don't block
-			return CISuccess
-		default:
-			return CIPending
-		}
-	default:
-		return CIPending
-	}
-}
-
 // CheckCI checks CI status once and returns immediately.
 // This is the non-blocking alternative to WaitForCI.
 // Returns CIPending/CIRunning if checks are still running.
@@ -376,38 +369,37 @@ func (m *CIMonitor) GetCIStatus(ctx context.Context, sha string) (CIStatus, erro
 
 // GetFailedChecks returns names of failed checks for a SHA.
 func (m *CIMonitor) GetFailedChecks(ctx context.Context, sha string) ([]string, error) {
-	checkRuns, err := m.ghClient.ListCheckRuns(ctx, m.owner, m.repo, sha)
+	checks, err := m.provider.ListChecks(ctx, sha)
 	if err != nil {
 		return nil, err
 	}
 
 	var failed []string
-	for _, run := range checkRuns.CheckRuns {
-		if run.Conclusion == github.ConclusionFailure {
+	for _, run := range checks {
+		if run.Status == CIFailure {
 			failed = append(failed, run.Name)
 		}
 	}
 	return failed, nil
 }
 
-// GetFailedCheckLogs fetches logs for all failed check runs and returns them
-// as a combined string. Each check's logs are prefixed with the check name.
-// Logs are truncated to maxLen total characters to keep issues readable.
-// GH-1567: Include actual CI error output in fix issues.
-func (m *CIMonitor) GetFailedCheckLogs(ctx context.Context, sha string, maxLen int) string {
-	checkRuns, err := m.ghClient.ListCheckRuns(ctx, m.owner, m.repo, sha)
+// AnalyzeFailedChecks fetches the logs of all failed checks and extracts,
+// classifies and deduplicates their errors. Returns nil when no logs could be
+// fetched.
+func (m *CIMonitor) AnalyzeFailedChecks(ctx context.Context, sha string) *CILogAnalysis {
+	checks, err := m.provider.ListChecks(ctx, sha)
 	if err != nil {
-		m.log.Warn("failed to list check runs for log fetch", "sha", ShortSHA(sha), "error", err)
-		return ""
+		m.log.Warn("failed to list checks for log fetch", "sha", ShortSHA(sha), "provider", m.provider.Name(), "error", err)
+		return nil
 	}
 
-	var combined strings.Builder
-	for _, run := range checkRuns.CheckRuns {
-		if run.Conclusion != github.ConclusionFailure {
+	var logs []CICheckLog
+	for _, run := range checks {
+		if run.Status != CIFailure {
 			continue
 		}
 
-		logs, err := m.ghClient.GetJobLogs(ctx, m.owner, m.repo, run.ID)
+		log, err := m.provider.CheckLogs(ctx, run)
 		if err != nil {
 			m.log.Warn("failed to fetch logs for check run",
 				"check", run.Name,
@@ -416,35 +408,37 @@ func (m *CIMonitor) GetFailedCheckLogs(ctx context.Context, sha string, maxLen i
 			)
 			continue
 		}
-
-		if combined.Len() > 0 {
-			combined.WriteString("\n\n")
-		}
-		combined.WriteString(fmt.Sprintf("=== %s ===\n", run.Name))
-		combined.WriteString(logs)
-
-		if combined.Len() >= maxLen {
-			break
+		if clean, found := m.leakGuard.Scrub(log); len(found) > 0 {
+			m.log.Warn("redacted secrets from check log", "check", run.Name, "rules", found)
+			log = clean
 		}
+		logs = append(logs, CICheckLog{Check: run.Name, Log: log})
 	}
-
-	result := combined.String()
-	if len(result) > maxLen {
-		result = result[:maxLen]
+	if len(logs) == 0 {
+		return nil
 	}
-	return result
+	return AnalyzeCILogs(logs)
+}
+
+// GetFailedCheckLogs returns the errors extracted from failed checks' logs,
+// each check's errors prefixed with the check name. Logs without recognized
+// errors contribute their last lines instead. The result is truncated to
+// maxLen characters to keep issues readable.
+// GH-1567: Include actual CI error output in fix issues.
+func (m *CIMonitor) GetFailedCheckLogs(ctx context.Context, sha string, maxLen int) string {
+	return m.AnalyzeFailedChecks(ctx, sha).Excerpt(maxLen)
 }
 
 // GetCheckStatus returns the current status of a specific check by name.
 func (m *CIMonitor) GetCheckStatus(ctx context.Context, sha, checkName string) (CIStatus, error) {
-	checkRuns, err := m.ghClient.ListCheckRuns(ctx, m.owner, m.repo, sha)
+	checks, err := m.provider.ListChecks(ctx, sha)
 	if err != nil {
 		return CIPending, err
 	}
 
-	for _, run := range checkRuns.CheckRuns {
+	for _, run := range checks {
 		if run.Name == checkName {
-			return m.mapCheckStatus(run.Status, run.Conclusion), nil
+			return run.Status, nil
 		}
 	}
 
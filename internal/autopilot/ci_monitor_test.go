@@ -21,11 +21,15 @@ func TestNewCIMonitor(t *testing.T) {
 	if monitor == nil {
 		t.Fatal("NewCIMonitor returned nil")
 	}
-	if monitor.owner != "owner" {
-		t.Errorf("owner = %s, want owner", monitor.owner)
+	provider, ok := monitor.provider.(*githubChecksProvider)
+	if !ok {
+		t.Fatalf("provider = %T, want GitHub Checks by default", monitor.provider)
 	}
-	if monitor.repo != "repo" {
-		t.Errorf("repo = %s, want repo", monitor.repo)
+	if provider.owner != "owner" {
+		t.Errorf("owner = %s, want owner", provider.owner)
+	}
+	if provider.repo != "repo" {
+		t.Errorf("repo = %s, want repo", provider.repo)
 	}
 	if monitor.pollInterval != cfg.CIPollInterval {
 		t.Errorf("pollInterval = %v, want %v", monitor.pollInterval, cfg.CIPollInterval)
@@ -485,7 +489,7 @@ func TestCIMonitor_GetCheckStatus(t *testing.T) {
 	}
 }
 
-func TestCIMonitor_MapCheckStatus(t *testing.T) {
+func TestGitHubCheckStatus(t *testing.T) {
 	tests := []struct {
 		name       string
 		status     string
@@ -502,17 +506,14 @@ func TestCIMonitor_MapCheckStatus(t *testing.T) {
 		{"completed neutral", github.CheckRunCompleted, github.ConclusionNeutral, CISuccess},
 		{"completed unknown", github.CheckRunCompleted, "unknown", CIPending},
 		{"unknown status", "unknown", "", CIPending},
+		{"conclusion without status", "", github.ConclusionFailure, CIFailure},
 	}
 
-	ghClient := github.NewClient(testutil.FakeGitHubToken)
-	cfg := DefaultConfig()
-	monitor := NewCIMonitor(ghClient, "owner", "repo", cfg)
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := monitor.mapCheckStatus(tt.status, tt.conclusion)
+			got := githubCheckStatus(tt.status, tt.conclusion)
 			if got != tt.want {
-				t.Errorf("mapCheckStatus(%s, %s) = %s, want %s", tt.status, tt.conclusion, got, tt.want)
+				t.Errorf("githubCheckStatus(%s, %s) = %s, want %s", tt.status, tt.conclusion, got, tt.want)
 			}
 		})
 	}
@@ -625,7 +626,7 @@ func TestCIMonitor_CheckAllRuns(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := monitor.checkAllRuns(tt.checkRuns)
+			got := monitor.checkAllRuns(githubChecks(tt.checkRuns))
 			if got != tt.want {
 				t.Errorf("checkAllRuns() = %s, want %s", got, tt.want)
 			}
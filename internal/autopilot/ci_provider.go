@@ -0,0 +1,174 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+)
+
+// CIProvider reports the CI jobs run for a commit, wherever CI runs. The CI
+// monitor applies required checks, exclusions and discovery on top of it.
+type CIProvider interface {
+	// Name identifies the provider in logs, e.g. "github".
+	Name() string
+	// ListChecks returns the checks run for sha. An empty list means CI has
+	// not reported anything yet.
+	ListChecks(ctx context.Context, sha string) ([]CICheck, error)
+	// CheckLogs returns the log output of a check listed by ListChecks.
+	CheckLogs(ctx context.Context, check CICheck) (string, error)
+}
+
+// CICheck is one CI job for a commit.
+type CICheck struct {
+	// ID identifies the job at the provider.
+	ID string
+	// Name is matched against required and excluded checks.
+	Name   string
+	Status CIStatus
+	// URL links to the job, when the provider reports one.
+	URL string
+}
+
+// NewCIProvider creates the provider selected by cfg for owner/repo. A nil
+// cfg selects GitHub Checks.
+func NewCIProvider(cfg *CIProviderConfig, ghClient *github.Client, owner, repo string) (CIProvider, error) {
+	if cfg == nil {
+		return &githubChecksProvider{ghClient: ghClient, owner: owner, repo: repo}, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Provider {
+	case CIProviderGitLab:
+		return newGitLabCIProvider(cfg, owner, repo), nil
+	case CIProviderCircleCI:
+		return newCircleCIProvider(cfg, owner, repo), nil
+	case CIProviderBuildkite:
+		return newBuildkiteProvider(cfg, owner, repo), nil
+	default:
+		return &githubChecksProvider{ghClient: ghClient, owner: owner, repo: repo}, nil
+	}
+}
+
+// githubChecksProvider reads GitHub check runs, which include GitHub Actions.
+type githubChecksProvider struct {
+	ghClient *github.Client
+	owner    string
+	repo     string
+}
+
+func (p *githubChecksProvider) Name() string { return CIProviderGitHub }
+
+func (p *githubChecksProvider) ListChecks(ctx context.Context, sha string) ([]CICheck, error) {
+	checkRuns, err := p.ghClient.ListCheckRuns(ctx, p.owner, p.repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	return githubChecks(checkRuns), nil
+}
+
+func (p *githubChecksProvider) CheckLogs(ctx context.Context, check CICheck) (string, error) {
+	id, err := strconv.ParseInt(check.ID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid check run ID %q", check.ID)
+	}
+	return p.ghClient.GetJobLogs(ctx, p.owner, p.repo, id)
+}
+
+// githubChecks converts check runs to checks.
+func githubChecks(checkRuns *github.CheckRunsResponse) []CICheck {
+	checks := make([]CICheck, 0, len(checkRuns.CheckRuns))
+	for _, run := range checkRuns.CheckRuns {
+		checks = append(checks, CICheck{
+			ID:     strconv.FormatInt(run.ID, 10),
+			Name:   run.Name,
+			Status: githubCheckStatus(run.Status, run.Conclusion),
+			URL:    run.DetailsURL,
+		})
+	}
+	return checks
+}
+
+// githubCheckStatus maps GitHub check status to CIStatus.
+func githubCheckStatus(status, conclusion string) CIStatus {
+	switch status {
+	case github.CheckRunQueued, github.CheckRunInProgress:
+		return CIRunning
+	case github.CheckRunCompleted, "":
+		// Status may be omitted when the conclusion is set
+		switch conclusion {
+		case github.ConclusionSuccess:
+			return CISuccess
+		case github.ConclusionFailure, github.ConclusionCancelled, github.ConclusionTimedOut:
+			return CIFailure
+		case github.ConclusionSkipped, github.ConclusionNeutral:
+			// Skipped/neutral checks don't block
+			return CISuccess
+		default:
+			return CIPending
+		}
+	default:
+		return CIPending
+	}
+}
+
+// ciAPI is a minimal JSON client for CI providers without an adapter.
+type ciAPI struct {
+	baseURL    string
+	authHeader string
+	authValue  string
+	httpClient *http.Client
+}
+
+func newCIAPI(baseURL, authHeader, authValue string) *ciAPI {
+	return &ciAPI{
+		baseURL:    baseURL,
+		authHeader: authHeader,
+		authValue:  authValue,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// get fetches path and decodes the JSON response into result. Paths are
+// relative to the base URL; absolute URLs, such as presigned log links, are
+// fetched without credentials.
+func (a *ciAPI) get(ctx context.Context, path string, result interface{}) error {
+	u := path
+	relative := strings.HasPrefix(path, "/")
+	if relative {
+		u = a.baseURL + path
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if relative {
+		req.Header.Set(a.authHeader, a.authValue)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func TestCIProviderConfig_Validate(t *testing.T) {
+	tests := []struct {
+		cfg     CIProviderConfig
+		wantErr bool
+	}{
+		{CIProviderConfig{}, false},
+		{CIProviderConfig{Provider: "github"}, false},
+		{CIProviderConfig{Provider: "gitlab", Token: "t"}, false},
+		{CIProviderConfig{Provider: "buildkite"}, true},
+		{CIProviderConfig{Provider: "jenkins", Token: "t"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCIProviders(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		handler  func(t *testing.T, serverURL string) http.HandlerFunc
+	}{
+		{
+			name:     "gitlab pipeline jobs",
+			provider: CIProviderGitLab,
+			handler: func(t *testing.T, _ string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("PRIVATE-TOKEN") != "ci-token" {
+						t.Errorf("missing token on %s", r.URL.Path)
+					}
+					switch r.URL.Path {
+					case "/api/v4/projects/owner/repo/pipelines":
+						if r.URL.Query().Get("sha") != "abc123" {
+							t.Errorf("sha = %q", r.URL.Query().Get("sha"))
+						}
+						_, _ = w.Write([]byte(`[{"id": 9, "sha": "abc123", "status": "failed"}, {"id": 8, "sha": "abc123", "status": "success"}]`))
+					case "/api/v4/projects/owner/repo/pipelines/9/jobs":
+						_, _ = w.Write([]byte(`[
+							{"id": 1, "name": "build", "status": "success"},
+							{"id": 2, "name": "test", "status": "failed"},
+							{"id": 3, "name": "lint", "status": "failed", "allow_failure": true},
+							{"id": 4, "name": "deploy", "status": "manual"}
+						]`))
+					case "/api/v4/projects/owner/repo/jobs/2/trace":
+						_, _ = w.Write([]byte("FAIL TestLogin"))
+					default:
+						t.Errorf("unexpected request %s", r.URL.Path)
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}
+			},
+		},
+		{
+			name:     "circleci workflow jobs",
+			provider: CIProviderCircleCI,
+			handler: func(t *testing.T, serverURL string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/output" && r.Header.Get("Circle-Token") != "ci-token" {
+						t.Errorf("missing token on %s", r.URL.Path)
+					}
+					switch r.URL.Path {
+					case "/api/v2/project/gh/owner/repo/pipeline":
+						if r.URL.Query().Get("page-token") == "" {
+							_, _ = w.Write([]byte(`{"items": [{"id": "p-new", "vcs": {"revision": "def456"}}], "next_page_token": "next"}`))
+							return
+						}
+						_, _ = w.Write([]byte(`{"items": [{"id": "p1", "vcs": {"revision": "abc123"}}]}`))
+					case "/api/v2/pipeline/p1/workflow":
+						_, _ = w.Write([]byte(`{"items": [{"id": "wf1", "name": "ci"}]}`))
+					case "/api/v2/workflow/wf1/job":
+						_, _ = w.Write([]byte(`{"items": [
+							{"name": "build", "type": "build", "status": "success", "job_number": 11},
+							{"name": "test", "type": "build", "status": "failed", "job_number": 12},
+							{"name": "lint", "type": "build", "status": "running", "job_number": 13},
+							{"name": "hold", "type": "approval", "status": "on_hold"}
+						]}`))
+					case "/api/v1.1/project/gh/owner/repo/12":
+						_ = json.NewEncoder(w).Encode(map[string]interface{}{"steps": []interface{}{
+							map[string]interface{}{"name": "checkout", "actions": []interface{}{map[string]string{"status": "success", "output_url": serverURL + "/nope"}}},
+							map[string]interface{}{"name": "go test", "actions": []interface{}{map[string]string{"status": "failed", "output_url": serverURL + "/output"}}},
+						}})
+					case "/output":
+						if r.Header.Get("Circle-Token") != "" {
+							t.Error("token sent to presigned output URL")
+						}
+						_, _ = w.Write([]byte(`[{"message": "FAIL TestLogin"}]`))
+					default:
+						t.Errorf("unexpected request %s", r.URL.Path)
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}
+			},
+		},
+		{
+			name:     "buildkite build jobs",
+			provider: CIProviderBuildkite,
+			handler: func(t *testing.T, _ string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("Authorization") != "Bearer ci-token" {
+						t.Errorf("missing token on %s", r.URL.Path)
+					}
+					switch r.URL.Path {
+					case "/v2/organizations/owner/pipelines/repo/builds":
+						if r.URL.Query().Get("commit") != "abc123" {
+							t.Errorf("commit = %q", r.URL.Query().Get("commit"))
+						}
+						_, _ = w.Write([]byte(`[{"number": 42, "jobs": [
+							{"id": "j1", "type": "script", "name": "build", "state": "passed"},
+							{"id": "j2", "type": "script", "step_key": "test", "state": "failed"},
+							{"id": "j3", "type": "script", "name": "lint", "state": "failed", "soft_failed": true},
+							{"id": "w1", "type": "waiter"}
+						]}, {"number": 41, "jobs": []}]`))
+					case "/v2/organizations/owner/pipelines/repo/builds/42/jobs/j2/log":
+						_, _ = w.Write([]byte(`{"content": "FAIL TestLogin"}`))
+					default:
+						t.Errorf("unexpected request %s", r.URL.Path)
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(nil)
+			server.Config.Handler = tt.handler(t, "http://"+server.Listener.Addr().String())
+			server.Start()
+			defer server.Close()
+
+			cfg := DefaultConfig()
+			cfg.CIPollInterval = 10 * time.Millisecond
+			cfg.CIWaitTimeout = time.Second
+			cfg.CIChecks = &CIChecksConfig{Mode: "manual", Required: []string{"build", "test"}}
+			cfg.CIProvider = &CIProviderConfig{Provider: tt.provider, Token: "ci-token", BaseURL: server.URL}
+			monitor := NewCIMonitor(github.NewClient(testutil.FakeGitHubToken), "owner", "repo", cfg)
+			if monitor.Provider().Name() != tt.provider {
+				t.Fatalf("provider = %s, want %s", monitor.Provider().Name(), tt.provider)
+			}
+			ctx := context.Background()
+
+			status, err := monitor.WaitForCI(ctx, "abc123")
+			if err != nil || status != CIFailure {
+				t.Fatalf("WaitForCI() = %s, %v; want failure", status, err)
+			}
+			failed, err := monitor.GetFailedChecks(ctx, "abc123")
+			if err != nil || strings.Join(failed, ",") != "test" {
+				t.Errorf("GetFailedChecks() = %v, %v; want [test]", failed, err)
+			}
+			if logs := monitor.GetFailedCheckLogs(ctx, "abc123", 2000); logs != "=== test ===\nFAIL TestLogin" {
+				t.Errorf("GetFailedCheckLogs() = %q", logs)
+			}
+			if status, _ := monitor.GetCheckStatus(ctx, "abc123", "build"); status != CISuccess {
+				t.Errorf("build status = %s, want success", status)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// CodeReviewer reviews the diff of a PR with a model separate from the one
+// that wrote it. Implemented on top of the executor in cmd/pilot.
+type CodeReviewer interface {
+	ReviewPR(ctx context.Context, req ReviewRequest) (*ReviewResult, error)
+}
+
+// ReviewRequest describes a PR for a CodeReviewer.
+type ReviewRequest struct {
+	PRNumber    int
+	PRTitle     string
+	IssueNumber int
+	// Diff is the unified diff of the PR, built from its file patches.
+	Diff string
+}
+
+// ReviewResult is a CodeReviewer's verdict on a PR.
+type ReviewResult struct {
+	Verdict  ReviewVerdict
+	Summary  string
+	Findings []ReviewFinding
+}
+
+// ReviewFinding is a risky hunk flagged by the review.
+type ReviewFinding struct {
+	File string
+	// Line is the line in the new version of File.
+	Line     int
+	Severity string
+	Category string
+	Message  string
+}
+
+// ReviewStore keeps review findings for pattern learning.
+// Satisfied by *memory.Store.
+type ReviewStore interface {
+	SaveReviewFindings(findings []*memory.ReviewFinding) error
+}
+
+// codeReview is a review running in the background.
+type codeReview struct {
+	done   chan struct{}
+	result *ReviewResult
+	err    error
+}
+
+// codeReviewEnabled reports whether created PRs get an automated review.
+func (c *Controller) codeReviewEnabled() bool {
+	return c.codeReviewer != nil && c.config.CodeReview != nil && c.config.CodeReview.Enabled
+}
+
+// startCodeReview reviews the PR in the background while CI runs. The
+// verdict is applied once CI passes.
+func (c *Controller) startCodeReview(ctx context.Context, prState *PRState) {
+	if !c.codeReviewEnabled() || prState.ReviewVerdict != "" {
+		return
+	}
+	c.mu.Lock()
+	if _, running := c.reviews[prState.PRNumber]; running {
+		c.mu.Unlock()
+		return
+	}
+	review := &codeReview{done: make(chan struct{})}
+	c.reviews[prState.PRNumber] = review
+	c.mu.Unlock()
+
+	// The goroutine must not touch prState, which belongs to the PR loop
+	pr := *prState
+	c.log.Info("starting code review", "pr", pr.PRNumber)
+	go func() {
+		defer close(review.done)
+		review.result, review.err = c.runCodeReview(context.WithoutCancel(ctx), &pr)
+	}()
+}
+
+// runCodeReview reviews the PR diff, posts the findings on the PR and
+// stores them.
+func (c *Controller) runCodeReview(ctx context.Context, pr *PRState) (*ReviewResult, error) {
+	files, err := c.ghClient.ListPullRequestFiles(ctx, c.owner, c.repo, pr.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PR files: %w", err)
+	}
+
+	var diff strings.Builder
+	reviewable := make(map[string]map[int]bool)
+	for _, f := range files {
+		if f.Patch == "" {
+			continue // binary or too large for the API
+		}
+		fmt.Fprintf(&diff, "diff --git a/%s b/%s\n%s\n", f.Filename, f.Filename, f.Patch)
+		reviewable[f.Filename] = patchLines(f.Patch)
+	}
+	if diff.Len() == 0 {
+		return &ReviewResult{Verdict: ReviewApprove, Summary: "No reviewable changes."}, nil
+	}
+
+	result, err := c.codeReviewer.ReviewPR(ctx, ReviewRequest{
+		PRNumber:    pr.PRNumber,
+		PRTitle:     pr.PRTitle,
+		IssueNumber: pr.IssueNumber,
+		Diff:        diff.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// GitHub rejects the whole review when a comment points outside the
+	// diff, so those findings go in the review body instead
+	var comments []github.ReviewCommentInput
+	var rest []ReviewFinding
+	for _, f := range result.Findings {
+		if f.Line > 0 && reviewable[f.File][f.Line] && len(comments) < c.config.CodeReview.ResolvedMaxComments() {
+			comments = append(comments, github.ReviewCommentInput{
+				Path: f.File,
+				Line: f.Line,
+				Side: "RIGHT",
+				Body: formatFinding(f),
+			})
+			continue
+		}
+		rest = append(rest, f)
+	}
+
+	// Always COMMENT: approving or blocking on GitHub stays with humans and
+	// the approval gate
+	if _, err := c.ghClient.CreatePullRequestReview(ctx, c.owner, c.repo, pr.PRNumber, &github.PullRequestReviewInput{
+		CommitID: pr.HeadSHA,
+		Body:     reviewBody(result, rest),
+		Event:    "COMMENT",
+		Comments: comments,
+	}); err != nil {
+		c.log.Warn("failed to post code review", "pr", pr.PRNumber, "error", err)
+	}
+
+	c.recordReviewFindings(ctx, pr, result)
+	return result, nil
+}
+
+// recordReviewFindings stores the findings and feeds them to the learning
+// loop.
+func (c *Controller) recordReviewFindings(ctx context.Context, pr *PRState, result *ReviewResult) {
+	if len(result.Findings) == 0 {
+		return
+	}
+	projectPath := c.owner + "/" + c.repo
+
+	if c.reviewStore != nil {
+		findings := make([]*memory.ReviewFinding, 0, len(result.Findings))
+		for _, f := range result.Findings {
+			findings = append(findings, &memory.ReviewFinding{
+				Repo:     projectPath,
+				PRNumber: pr.PRNumber,
+				Verdict:  string(result.Verdict),
+				File:     f.File,
+				Line:     f.Line,
+				Severity: f.Severity,
+				Category: f.Category,
+				Message:  f.Message,
+			})
+		}
+		if err := c.reviewStore.SaveReviewFindings(findings); err != nil {
+			c.log.Warn("failed to save review findings", "pr", pr.PRNumber, "error", err)
+		}
+	}
+
+	if c.learningLoop != nil {
+		state := "COMMENTED"
+		if result.Verdict == ReviewRequestChanges {
+			state = "CHANGES_REQUESTED"
+		}
+		reviewData := make([]*memory.ReviewData, 0, len(result.Findings))
+		for _, f := range result.Findings {
+			reviewData = append(reviewData, &memory.ReviewData{
+				Body:     f.Message,
+				State:    state,
+				Reviewer: "pilot-code-review",
+			})
+		}
+		if err := c.learningLoop.LearnFromReview(ctx, projectPath, reviewData, pr.PRURL); err != nil {
+			c.log.Warn("Failed to learn from code review", slog.Any("error", err))
+		}
+	}
+}
+
+// applyCodeReview copies a finished review's verdict onto the PR. Returns
+// false while the review is still running. A failed or interrupted review
+// leaves no verdict and does not hold up the merge.
+func (c *Controller) applyCodeReview(prState *PRState) bool {
+	c.mu.RLock()
+	review, ok := c.reviews[prState.PRNumber]
+	c.mu.RUnlock()
+	if !ok {
+		return true
+	}
+
+	select {
+	case <-review.done:
+	default:
+		c.log.Debug("waiting for code review", "pr", prState.PRNumber)
+		return false
+	}
+	c.mu.Lock()
+	delete(c.reviews, prState.PRNumber)
+	c.mu.Unlock()
+
+	if review.err != nil {
+		c.log.Warn("code review failed", "pr", prState.PRNumber, "error", review.err)
+		return true
+	}
+	prState.ReviewVerdict = review.result.Verdict
+	prState.ReviewSummary = review.result.Summary
+	c.log.Info("code review finished",
+		"pr", prState.PRNumber,
+		"verdict", prState.ReviewVerdict,
+		"findings", len(review.result.Findings),
+	)
+	return true
+}
+
+// reviewRequiresApproval reports whether the review verdict holds the merge
+// for human approval. Without a pre-merge approval stage the verdict is
+// advisory: the comments are on the PR but the merge proceeds.
+func (m *AutoMerger) reviewRequiresApproval(prState *PRState) bool {
+	return prState.ReviewVerdict == ReviewRequestChanges &&
+		m.approvalMgr != nil && m.approvalMgr.IsStageEnabled(approval.StagePreMerge)
+}
+
+// reviewBody renders the review summary, listing findings that could not be
+// posted inline.
+func reviewBody(result *ReviewResult, rest []ReviewFinding) string {
+	var sb strings.Builder
+	sb.WriteString("🤖 **Pilot code review**: ")
+	if result.Verdict == ReviewRequestChanges {
+		sb.WriteString("changes requested\n\n")
+	} else {
+		sb.WriteString("approved\n\n")
+	}
+	if result.Summary != "" {
+		sb.WriteString(result.Summary + "\n")
+	}
+	if len(rest) > 0 {
+		sb.WriteString("\n**Other findings:**\n")
+		for _, f := range rest {
+			location := f.File
+			if f.Line > 0 {
+				location += ":" + strconv.Itoa(f.Line)
+			}
+			if location != "" {
+				sb.WriteString(fmt.Sprintf("- `%s` %s\n", location, formatFinding(f)))
+			} else {
+				sb.WriteString("- " + formatFinding(f) + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// formatFinding renders a finding as a comment line.
+func formatFinding(f ReviewFinding) string {
+	var tags []string
+	if f.Severity != "" {
+		tags = append(tags, f.Severity)
+	}
+	if f.Category != "" {
+		tags = append(tags, f.Category)
+	}
+	if len(tags) == 0 {
+		return f.Message
+	}
+	return fmt.Sprintf("**[%s]** %s", strings.Join(tags, ", "), f.Message)
+}
+
+// patchLines returns the new-side line numbers covered by a file patch,
+// the lines GitHub accepts inline comments on.
+func patchLines(patch string) map[int]bool {
+	lines := make(map[int]bool)
+	next := 0
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			// @@ -a,b +c,d @@
+			next = 0
+			for _, field := range strings.Fields(line) {
+				if strings.HasPrefix(field, "+") {
+					start, _, _ := strings.Cut(field[1:], ",")
+					next, _ = strconv.Atoi(start)
+					break
+				}
+			}
+			continue
+		}
+		if next == 0 || line == "" || strings.HasPrefix(line, "-") || strings.HasPrefix(line, `\`) {
+			continue
+		}
+		lines[next] = true
+		next++
+	}
+	return lines
+}
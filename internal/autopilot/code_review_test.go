@@ -0,0 +1,183 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// fakeCodeReviewer returns a fixed review and records the requests.
+type fakeCodeReviewer struct {
+	mu     sync.Mutex
+	reqs   []ReviewRequest
+	result *ReviewResult
+}
+
+func (f *fakeCodeReviewer) ReviewPR(ctx context.Context, req ReviewRequest) (*ReviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reqs = append(f.reqs, req)
+	return f.result, nil
+}
+
+// fakeReviewStore records saved findings.
+type fakeReviewStore struct {
+	mu       sync.Mutex
+	findings []*memory.ReviewFinding
+}
+
+func (f *fakeReviewStore) SaveReviewFindings(findings []*memory.ReviewFinding) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.findings = append(f.findings, findings...)
+	return nil
+}
+
+func TestController_CodeReview(t *testing.T) {
+	mergeable := true
+	var mu sync.Mutex
+	var posted *github.PullRequestReviewInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42/files":
+			_ = json.NewEncoder(w).Encode([]*github.PRFile{
+				{Filename: "db.go", Status: "modified", Patch: "@@ -10,3 +10,4 @@ func find() {\n ctx := context.Background()\n-q := base\n+q := base + name\n+rows, _ := db.Query(q)\n return rows"},
+				{Filename: "logo.png", Status: "added"},
+			})
+		case "/repos/owner/repo/pulls/42/reviews":
+			var input github.PullRequestReviewInput
+			_ = json.NewDecoder(r.Body).Decode(&input)
+			mu.Lock()
+			posted = &input
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(github.PullRequestReview{ID: 1})
+		case "/repos/owner/repo/pulls/42":
+			_ = json.NewEncoder(w).Encode(github.PullRequest{Number: 42, State: "open", Mergeable: &mergeable})
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	approvalCfg := approval.DefaultConfig()
+	approvalCfg.Enabled = true
+	approvalCfg.PreMerge.Enabled = true
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.CodeReview = &CodeReviewConfig{Enabled: true}
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), approval.NewManager(approvalCfg), "owner", "repo")
+	reviewer := &fakeCodeReviewer{result: &ReviewResult{
+		Verdict: ReviewRequestChanges,
+		Summary: "Query built from user input.",
+		Findings: []ReviewFinding{
+			{File: "db.go", Line: 11, Severity: "high", Category: "sql-injection", Message: "Use a placeholder"},
+			{File: "db.go", Line: 40, Severity: "low", Category: "error-handling", Message: "Query error ignored"},
+		},
+	}}
+	c.SetCodeReviewer(reviewer)
+	store := &fakeReviewStore{}
+	c.SetReviewStore(store)
+
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc1234", "pilot/GH-10", "")
+	prState, _ := c.GetPRState(42)
+
+	ctx := context.Background()
+	if err := c.ProcessPR(ctx, 42, nil); err != nil {
+		t.Fatalf("ProcessPR returned error: %v", err)
+	}
+	if prState.Stage != StageWaitingCI {
+		t.Fatalf("stage = %s, want waiting_ci while the review runs", prState.Stage)
+	}
+
+	// CI passed: the verdict is applied once the review is done
+	prState.Stage = StageCIPassed
+	deadline := time.Now().Add(5 * time.Second)
+	for prState.Stage == StageCIPassed && time.Now().Before(deadline) {
+		if err := c.ProcessPR(ctx, 42, nil); err != nil {
+			t.Fatalf("ProcessPR returned error: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if prState.Stage != StageAwaitApproval {
+		t.Fatalf("stage = %s, want await_approval after request_changes", prState.Stage)
+	}
+	if prState.ReviewVerdict != ReviewRequestChanges || prState.ReviewSummary != "Query built from user input." {
+		t.Errorf("review = %q %q", prState.ReviewVerdict, prState.ReviewSummary)
+	}
+
+	reviewer.mu.Lock()
+	reqs := append([]ReviewRequest(nil), reviewer.reqs...)
+	reviewer.mu.Unlock()
+	if len(reqs) != 1 || reqs[0].IssueNumber != 10 || !strings.Contains(reqs[0].Diff, "diff --git a/db.go b/db.go") || strings.Contains(reqs[0].Diff, "logo.png") {
+		t.Errorf("review requests = %+v", reqs)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if posted == nil {
+		t.Fatal("no review posted on the PR")
+	}
+	if posted.Event != "COMMENT" || posted.CommitID != "abc1234" {
+		t.Errorf("review event = %q, commit = %q", posted.Event, posted.CommitID)
+	}
+	if len(posted.Comments) != 1 || posted.Comments[0].Path != "db.go" || posted.Comments[0].Line != 11 || !strings.Contains(posted.Comments[0].Body, "sql-injection") {
+		t.Errorf("inline comments = %+v, want the finding inside the diff", posted.Comments)
+	}
+	for _, want := range []string{"Pilot code review", "changes requested", "`db.go:40`", "Query error ignored"} {
+		if !strings.Contains(posted.Body, want) {
+			t.Errorf("review body missing %q:\n%s", want, posted.Body)
+		}
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.findings) != 2 || store.findings[0].Repo != "owner/repo" || store.findings[0].PRNumber != 42 || store.findings[0].Verdict != "request_changes" {
+		t.Errorf("stored findings = %+v", store.findings)
+	}
+}
+
+func TestAutoMerger_ReviewRequiresApproval(t *testing.T) {
+	enabled := approval.DefaultConfig()
+	enabled.Enabled = true
+	enabled.PreMerge.Enabled = true
+
+	tests := []struct {
+		name    string
+		mgr     *approval.Manager
+		verdict ReviewVerdict
+		want    bool
+	}{
+		{name: "changes requested", mgr: approval.NewManager(enabled), verdict: ReviewRequestChanges, want: true},
+		{name: "approved", mgr: approval.NewManager(enabled), verdict: ReviewApprove},
+		{name: "no review", mgr: approval.NewManager(enabled)},
+		{name: "advisory without pre-merge stage", mgr: approval.NewManager(nil), verdict: ReviewRequestChanges},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAutoMerger(nil, tt.mgr, nil, "owner", "repo", DefaultConfig())
+			if got := m.reviewRequiresApproval(&PRState{ReviewVerdict: tt.verdict}); got != tt.want {
+				t.Errorf("reviewRequiresApproval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchLines(t *testing.T) {
+	patch := "@@ -1,3 +1,3 @@\n package main\n-var a = 1\n+var a = 2\n \n@@ -20,2 +20,3 @@ func main() {\n \tfmt.Println(a)\n+\tfmt.Println(b)\n }\n\\ No newline at end of file"
+	want := map[int]bool{1: true, 2: true, 3: true, 20: true, 21: true, 22: true}
+	if got := patchLines(patch); !reflect.DeepEqual(got, want) {
+		t.Errorf("patchLines() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package autopilot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
@@ -11,8 +12,10 @@ import (
 	"time"
 
 	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/alerts"
 	"github.com/alekspetrov/pilot/internal/approval"
 	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
 // iterationRe matches the iteration field in autopilot-meta comments.
@@ -67,6 +70,75 @@ type EvalStore interface {
 	SaveEvalTask(task *memory.EvalTask) error
 }
 
+// AlertProcessor receives autopilot events for alert rule evaluation.
+// Satisfied by *alerts.Engine.
+type AlertProcessor interface {
+	ProcessEvent(event alerts.Event)
+}
+
+// ConflictResolver merges the base branch into a PR branch, resolving the
+// conflicts, and pushes the result to the PR branch. Implemented on top of
+// the executor in cmd/pilot.
+type ConflictResolver interface {
+	ResolveConflicts(ctx context.Context, req ConflictRequest) error
+}
+
+// ConflictRequest describes a conflicting PR for a ConflictResolver.
+type ConflictRequest struct {
+	PRNumber    int
+	PRTitle     string
+	IssueNumber int
+	// Branch is the PR's head branch.
+	Branch string
+	// BaseBranch is the branch the PR merges into; empty means the
+	// repository's default branch.
+	BaseBranch string
+	// Attempt is the 1-based resolution pass for this PR.
+	Attempt int
+}
+
+// conflictResolution is a resolution pass running in the background.
+type conflictResolution struct {
+	done chan struct{}
+	err  error
+}
+
+// ReviewFixer pushes fixes for review feedback to a PR branch. Implemented
+// on top of the executor in cmd/pilot.
+type ReviewFixer interface {
+	FixReview(ctx context.Context, req ReviewFixRequest) error
+}
+
+// ReviewFixRequest describes a PR with requested changes for a ReviewFixer.
+type ReviewFixRequest struct {
+	PRNumber    int
+	PRTitle     string
+	IssueNumber int
+	// Branch is the PR's head branch.
+	Branch string
+	// Feedback holds the review threads to address, formatted as markdown.
+	Feedback string
+	// Iteration is the 1-based fix pass for this PR.
+	Iteration int
+}
+
+// reviewFix is a review fix pass running in the background.
+type reviewFix struct {
+	done      chan struct{}
+	err       error
+	startedAt time.Time
+	// reviewers requested the changes; review is requested from them again
+	// once the fix is pushed
+	reviewers []string
+}
+
+// MergeHook is called after a tracked PR is merged, whether autopilot merged
+// it or it was merged externally.
+type MergeHook func(ctx context.Context, prState *PRState)
+
+// StageHook is called after a tracked PR moves from one stage to another.
+type StageHook func(prState *PRState, from PRStage)
+
 // ControllerOption is a functional option for Controller configuration.
 type ControllerOption func(*Controller)
 
@@ -80,6 +152,45 @@ func WithProjectBoardSync(bs *github.ProjectBoardSync, doneStatus, failStatus st
 	}
 }
 
+// WithReleaseGate lets the repo veto releases. allowed is consulted each time a
+// release would trigger, so changes to the repo's .pilot.yaml take effect
+// without restarting the controller.
+func WithReleaseGate(allowed func() bool) ControllerOption {
+	return func(c *Controller) {
+		c.releaseAllowed = allowed
+	}
+}
+
+// WithVersionFiles sets the project's version files, overriding the release
+// config's version_files for this controller.
+func WithVersionFiles(files []VersionFile) ControllerOption {
+	return func(c *Controller) {
+		c.versionFiles = files
+	}
+}
+
+// WithCIProvider reads CI for this controller's repo from the given
+// provider instead of the autopilot config's ci_provider. An invalid config
+// keeps the current provider.
+func WithCIProvider(cfg *CIProviderConfig) ControllerOption {
+	return func(c *Controller) {
+		provider, err := NewCIProvider(cfg, c.ghClient, c.owner, c.repo)
+		if err != nil {
+			c.log.Warn("invalid project CI provider, keeping default", "error", err)
+			return
+		}
+		c.ciMonitor.SetProvider(provider)
+	}
+}
+
+// WithApprovalPaths adds the project's require_approval_paths to the
+// autopilot config's for this controller.
+func WithApprovalPaths(patterns []string) ControllerOption {
+	return func(c *Controller) {
+		c.autoMerger.approvalPaths = append(append([]string{}, c.autoMerger.approvalPaths...), patterns...)
+	}
+}
+
 // Controller orchestrates the autopilot loop for PR processing.
 // It manages the state machine: PR created → CI check → merge → post-merge CI → feedback loop.
 type Controller struct {
@@ -98,6 +209,17 @@ type Controller struct {
 	failStatus   string
 	log          *slog.Logger
 
+	// Merge freeze windows and manual freezes (never nil)
+	freeze *FreezeCalendar
+
+	// Coordinates merges of PRs linked across repositories (optional)
+	linked *LinkedMerges
+
+	// Per-project release gate (optional, nil = releases follow config only)
+	releaseAllowed func() bool
+	// Per-project version files (optional, nil = release config's version_files)
+	versionFiles []VersionFile
+
 	// State tracking
 	activePRs map[int]*PRState
 	mu        sync.RWMutex
@@ -105,12 +227,37 @@ type Controller struct {
 	// Persistent state store (optional, nil = in-memory only)
 	stateStore *StateStore
 
+	// Environment promotion pipeline (optional, needs the state store)
+	pipeline *Pipeline
+
 	// Learning loop for capturing review feedback (optional, nil = learning disabled)
 	learningLoop *memory.LearningLoop
 
 	// Eval store for capturing eval tasks from merged PRs (optional, nil = eval disabled)
 	evalStore EvalStore
 
+	// alerts receives post-merge rollback events (autopilot.rollback)
+	alerts AlertProcessor
+
+	// Conflict resolution passes (optional, nil = conflicting PRs are closed)
+	conflictResolver ConflictResolver
+	resolutions      map[int]*conflictResolution
+
+	// Review fix passes (optional, nil = PRs with requested changes are
+	// closed and reissued)
+	reviewFixer ReviewFixer
+	reviewFixes map[int]*reviewFix
+
+	// Automated code review (optional, nil = no review)
+	codeReviewer CodeReviewer
+	reviewStore  ReviewStore
+	reviews      map[int]*codeReview
+
+	// Merge hooks let ticket adapters close out their source ticket on merge
+	mergeHooks []MergeHook
+	stageHooks []StageHook
+	hooksMu    sync.RWMutex
+
 	// Per-PR circuit breaker: each PR has independent failure tracking.
 	// A failure on one PR does not block other PRs.
 	prFailures map[int]*prFailureState
@@ -120,6 +267,9 @@ type Controller struct {
 	lastProgressAt    time.Time
 	deadlockAlertSent bool
 
+	// Wake requests from webhooks, processed before the next tick
+	wake chan struct{}
+
 	// Metrics
 	metrics *Metrics
 
@@ -138,7 +288,11 @@ func NewController(cfg *Config, ghClient *github.Client, approvalMgr *approval.M
 		repo:           repo,
 		activePRs:      make(map[int]*PRState),
 		prFailures:     make(map[int]*prFailureState),
+		resolutions:    make(map[int]*conflictResolution),
+		reviewFixes:    make(map[int]*reviewFix),
+		reviews:        make(map[int]*codeReview),
 		lastProgressAt: time.Now(), // Initialize to now to avoid false alarm on startup
+		wake:           make(chan struct{}, 1),
 		metrics:        NewMetrics(),
 		log:            slog.Default().With("component", "autopilot"),
 	}
@@ -147,11 +301,26 @@ func NewController(cfg *Config, ghClient *github.Client, approvalMgr *approval.M
 	c.autoMerger = NewAutoMerger(ghClient, approvalMgr, c.ciMonitor, owner, repo, cfg)
 	c.feedbackLoop = NewFeedbackLoop(ghClient, owner, repo, cfg)
 
+	freeze, err := NewFreezeCalendar(cfg.Freeze)
+	if err != nil {
+		c.log.Warn("invalid freeze config, windows ignored", "error", err)
+		freeze, _ = NewFreezeCalendar(nil)
+	}
+	c.freeze = freeze
+
 	// Initialize releaser if release config exists
 	if cfg.Release != nil && cfg.Release.Enabled {
 		c.releaser = NewReleaser(ghClient, owner, repo, cfg.Release)
 	}
 
+	// With a promotion pipeline PRs merge under the first environment's rules
+	if cfg.Pipeline != nil && cfg.Pipeline.Enabled {
+		first := cfg.PipelineStages()[0].Name
+		if err := cfg.SetActiveEnvironment(first); err != nil {
+			c.log.Warn("failed to activate first pipeline environment", "env", first, "error", err)
+		}
+	}
+
 	// Initialize deployer if post-merge config exists
 	if env := cfg.ResolvedEnv(); env.PostMerge != nil && env.PostMerge.Action != "" && env.PostMerge.Action != "none" {
 		c.deployer = NewDeployer(ghClient, owner, repo, env.PostMerge)
@@ -181,6 +350,10 @@ func (c *Controller) SetMonitor(m TaskMonitor) {
 // If set, all state transitions are persisted to SQLite.
 func (c *Controller) SetStateStore(store *StateStore) {
 	c.stateStore = store
+	c.freeze.SetStore(store)
+	if c.config.Pipeline != nil && c.config.Pipeline.Enabled {
+		c.pipeline = NewPipeline(c.ghClient, c.owner, c.repo, c.config, store, c.approvalMgr, c.ciMonitor)
+	}
 }
 
 // SetLearningLoop sets the learning loop for capturing PR review feedback.
@@ -193,11 +366,84 @@ func (c *Controller) SetLearningLoop(loop *memory.LearningLoop) {
 	}
 }
 
+// SetLeakGuard sets the guard that redacts credentials from CI logs before
+// they are analyzed, learned from or posted in fix issues.
+func (c *Controller) SetLeakGuard(g *quality.LeakGuard) {
+	c.ciMonitor.leakGuard = g
+	if c.feedbackLoop != nil {
+		c.feedbackLoop.SetLeakGuard(g)
+	}
+}
+
 // SetEvalStore sets the eval store for capturing eval tasks from merged PRs.
 func (c *Controller) SetEvalStore(store EvalStore) {
 	c.evalStore = store
 }
 
+// SetAlertProcessor sets where rollback alerts are sent.
+func (c *Controller) SetAlertProcessor(p AlertProcessor) {
+	c.alerts = p
+}
+
+// SetConflictResolver sets what resolves merge conflicts GitHub cannot
+// update away. Used only when autopilot.conflict_resolution is enabled.
+func (c *Controller) SetConflictResolver(r ConflictResolver) {
+	c.conflictResolver = r
+}
+
+// SetReviewFixer sets what pushes fixes for requested changes to the PR
+// branch. Used only when autopilot.review_feedback.same_branch is enabled.
+func (c *Controller) SetReviewFixer(f ReviewFixer) {
+	c.reviewFixer = f
+}
+
+// SetCodeReviewer sets what reviews created PRs when code_review is
+// enabled.
+func (c *Controller) SetCodeReviewer(r CodeReviewer) {
+	c.codeReviewer = r
+}
+
+// SetReviewStore sets where code review findings are kept.
+func (c *Controller) SetReviewStore(store ReviewStore) {
+	c.reviewStore = store
+}
+
+// AddMergeHook registers a callback run after a PR is merged.
+func (c *Controller) AddMergeHook(hook MergeHook) {
+	c.hooksMu.Lock()
+	c.mergeHooks = append(c.mergeHooks, hook)
+	c.hooksMu.Unlock()
+}
+
+// runMergeHooks invokes all registered merge hooks.
+func (c *Controller) runMergeHooks(ctx context.Context, prState *PRState) {
+	c.hooksMu.RLock()
+	hooks := append([]MergeHook(nil), c.mergeHooks...)
+	c.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(ctx, prState)
+	}
+}
+
+// AddStageHook registers a callback run on every PR stage transition.
+func (c *Controller) AddStageHook(hook StageHook) {
+	c.hooksMu.Lock()
+	c.stageHooks = append(c.stageHooks, hook)
+	c.hooksMu.Unlock()
+}
+
+// runStageHooks invokes all registered stage hooks.
+func (c *Controller) runStageHooks(prState *PRState, from PRStage) {
+	c.hooksMu.RLock()
+	hooks := append([]StageHook(nil), c.stageHooks...)
+	c.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(prState, from)
+	}
+}
+
 // persistPRState saves a PR state to the store if available.
 func (c *Controller) persistPRState(prState *PRState) {
 	if c.stateStore == nil {
@@ -318,6 +564,18 @@ func (c *Controller) OnPRCreated(prNumber int, prURL string, issueNumber int, he
 	)
 }
 
+// SetPRConfidence records the intent judge confidence for a tracked PR, used
+// by merge policy rules on confidence.
+func (c *Controller) SetPRConfidence(prNumber int, confidence float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if prState, ok := c.activePRs[prNumber]; ok {
+		prState.Confidence = confidence
+		c.persistPRState(prState)
+	}
+}
+
 // OnReviewRequested handles PR review events from GitHub webhooks.
 // For changes_requested reviews on tracked PRs, it transitions the PR to StageReviewRequested
 // so the next processAllPRs tick will create a revision issue.
@@ -338,8 +596,9 @@ func (c *Controller) OnReviewRequested(prNumber int, action, state, reviewer str
 		return
 	}
 
-	// Only act on changes_requested reviews
-	if state != "changes_requested" {
+	// Only act on changes_requested reviews. Reviews arriving while a fix
+	// is being pushed are picked up once it is.
+	if state != "changes_requested" || prState.Stage == StageFixingReview {
 		return
 	}
 
@@ -359,10 +618,12 @@ func (c *Controller) OnReviewRequested(prNumber int, action, state, reviewer str
 	)
 
 	c.mu.Lock()
+	previousStage := prState.Stage
 	prState.Stage = StageReviewRequested
 	c.mu.Unlock()
 
 	c.persistPRState(prState)
+	c.runStageHooks(prState, previousStage)
 }
 
 // ProcessPR processes a single PR through the state machine.
@@ -381,6 +642,7 @@ func (c *Controller) ProcessPR(ctx context.Context, prNumber int, ghPR *github.P
 	if c.isPRCircuitOpen(prNumber) {
 		c.log.Warn("per-PR circuit breaker open", "pr", prNumber)
 		c.metrics.RecordCircuitBreakerTrip()
+		c.linkedPRFailed(prState)
 		return fmt.Errorf("circuit breaker: PR %d has too many consecutive failures", prNumber)
 	}
 
@@ -402,6 +664,8 @@ func (c *Controller) ProcessPR(ctx context.Context, prNumber int, ghPR *github.P
 		err = c.handlePRCreated(ctx, prState, ghPR)
 	case StageWaitingCI:
 		err = c.handleWaitingCI(ctx, prState, ghPR)
+	case StageMarkingReady:
+		err = c.handleMarkingReady(ctx, prState)
 	case StageCIPassed:
 		err = c.handleCIPassed(ctx, prState)
 	case StageCIFailed:
@@ -410,12 +674,22 @@ func (c *Controller) ProcessPR(ctx context.Context, prNumber int, ghPR *github.P
 		err = c.handleAwaitApproval(ctx, prState)
 	case StageMerging:
 		err = c.handleMerging(ctx, prState)
+	case StageMergeQueued:
+		err = c.handleMergeQueued(ctx, prState, ghPR)
+	case StageResolvingConflicts:
+		err = c.handleResolvingConflicts(ctx, prState)
 	case StageMerged:
 		err = c.handleMerged(ctx, prState)
 	case StagePostMergeCI:
 		err = c.handlePostMergeCI(ctx, prState)
+	case StageCanaryCI:
+		err = c.handleCanaryCI(ctx, prState)
+	case StageCanarySoak:
+		err = c.handleCanarySoak(ctx, prState)
 	case StageReviewRequested:
 		err = c.handleReviewRequested(ctx, prState)
+	case StageFixingReview:
+		err = c.handleFixingReview(ctx, prState)
 	case StageReleasing:
 		err = c.handleReleasing(ctx, prState)
 	case StageFailed:
@@ -437,6 +711,11 @@ func (c *Controller) ProcessPR(ctx context.Context, prNumber int, ghPR *github.P
 		c.lastProgressAt = time.Now()
 		c.deadlockAlertSent = false
 		c.mu.Unlock()
+
+		c.runStageHooks(prState, previousStage)
+		if prState.Stage == StageFailed {
+			c.linkedPRFailed(prState)
+		}
 	}
 
 	if err != nil {
@@ -480,6 +759,9 @@ func (c *Controller) handlePRCreated(ctx context.Context, prState *PRState, ghPR
 		}
 	}
 
+	// The review runs alongside CI and is applied once CI passes
+	c.startCodeReview(ctx, prState)
+
 	// All environments wait for CI - no skipping
 	prState.Stage = StageWaitingCI
 	prState.CIWaitStartedAt = time.Now()
@@ -495,15 +777,7 @@ func (c *Controller) handleWaitingCI(ctx context.Context, prState *PRState, ghPR
 		prState.CIWaitStartedAt = time.Now()
 	}
 
-	// Check for CI timeout: use the minimum of CIWaitTimeout and the environment's CITimeout.
-	// This respects explicit user overrides (e.g. short timeouts in tests) while defaulting
-	// to the environment-specific timeout when no override is set.
-	ciTimeout := c.config.CIWaitTimeout
-	envCITimeout := c.config.ResolvedEnv().CITimeout
-	if envCITimeout > 0 && (ciTimeout == 0 || envCITimeout < ciTimeout) {
-		ciTimeout = envCITimeout
-	}
-
+	ciTimeout := c.ciTimeout()
 	if time.Since(prState.CIWaitStartedAt) > ciTimeout {
 		c.log.Warn("CI timeout", "pr", prState.PRNumber, "waited", time.Since(prState.CIWaitStartedAt))
 		prState.Stage = StageFailed
@@ -538,6 +812,7 @@ func (c *Controller) handleWaitingCI(ctx context.Context, prState *PRState, ghPR
 				"old", ShortSHA(sha),
 				"new", ShortSHA(ghPR.Head.SHA),
 			)
+			prState.clearMergeChecks()
 		} else if sha == "" {
 			c.log.Info("refreshed empty HeadSHA from GitHub",
 				"pr", prState.PRNumber,
@@ -604,6 +879,10 @@ func (c *Controller) handleWaitingCI(ctx context.Context, prState *PRState, ghPR
 	case CISuccess:
 		c.log.Info("CI passed", "pr", prState.PRNumber, "sha", ShortSHA(sha))
 		prState.Stage = StageCIPassed
+		// Draft PRs go to reviewers only once CI is green
+		if ghPR != nil && ghPR.Draft {
+			prState.Stage = StageMarkingReady
+		}
 		if !prState.CIWaitStartedAt.IsZero() {
 			c.metrics.RecordCIWaitDuration(time.Since(prState.CIWaitStartedAt))
 		}
@@ -621,7 +900,33 @@ func (c *Controller) handleWaitingCI(ctx context.Context, prState *PRState, ghPR
 	return nil
 }
 
-// handleCIPassed proceeds to merge (with approval if required by environment config).
+// ciTimeout returns the minimum of CIWaitTimeout and the environment's CITimeout.
+// This respects explicit user overrides (e.g. short timeouts in tests) while defaulting
+// to the environment-specific timeout when no override is set.
+func (c *Controller) ciTimeout() time.Duration {
+	ciTimeout := c.config.CIWaitTimeout
+	envCITimeout := c.config.ResolvedEnv().CITimeout
+	if envCITimeout > 0 && (ciTimeout == 0 || envCITimeout < ciTimeout) {
+		ciTimeout = envCITimeout
+	}
+	return ciTimeout
+}
+
+// handleMarkingReady takes a draft PR out of draft once CI passed on it.
+// Failures are retried on the next poll, up to the per-PR circuit breaker.
+func (c *Controller) handleMarkingReady(ctx context.Context, prState *PRState) error {
+	if err := c.ghClient.MarkPullRequestReadyForReview(ctx, c.owner, c.repo, prState.PRNumber); err != nil {
+		return err
+	}
+	c.log.Info("draft PR marked ready for review", "pr", prState.PRNumber)
+	prState.Stage = StageCIPassed
+	return nil
+}
+
+// handleCIPassed proceeds to merge (with approval if required by the merge
+// policy, or by environment config when no policy is set). PRs touching
+// require_approval_paths always wait for approval and get the needs-human
+// label.
 func (c *Controller) handleCIPassed(ctx context.Context, prState *PRState) error {
 	c.log.Info("handleCIPassed: CI passed, determining next stage",
 		"pr", prState.PRNumber,
@@ -629,7 +934,50 @@ func (c *Controller) handleCIPassed(ctx context.Context, prState *PRState) error
 		"auto_merge", c.config.AutoMerge,
 	)
 
-	if c.config.ResolvedEnv().RequireApproval {
+	if !c.applyCodeReview(prState) {
+		return nil
+	}
+
+	requireReview := c.config.ResolvedEnv().RequireApproval
+	if c.config.MergePolicy != nil {
+		eval, err := c.autoMerger.EvaluateMergePolicy(ctx, prState)
+		if err != nil {
+			return fmt.Errorf("merge policy evaluation failed: %w", err)
+		}
+		prState.MergeEvaluation = eval
+		requireReview = eval.RequireReview()
+		c.log.Info("merge policy evaluated",
+			"pr", prState.PRNumber,
+			"decision", eval.Decision,
+			"risk", eval.Risk,
+			"decided_by", eval.DecidedBy,
+		)
+	}
+
+	// Recheck the files on every CI pass; new commits may touch new paths
+	prState.ApprovalPaths = nil
+	check, err := c.autoMerger.CheckApprovalPaths(ctx, prState)
+	if err != nil {
+		return fmt.Errorf("approval path check failed: %w", err)
+	}
+	if check.Touched() {
+		c.log.Info("PR touches paths requiring human approval",
+			"pr", prState.PRNumber,
+			"files", len(check.Matches),
+			"first", check.Matches[0].File,
+		)
+		requireReview = true
+		if err := c.ghClient.AddLabels(ctx, c.owner, c.repo, prState.PRNumber, []string{github.LabelNeedsHuman}); err != nil {
+			c.log.Warn("failed to add needs-human label", "pr", prState.PRNumber, "error", err)
+		}
+	}
+
+	if c.autoMerger.reviewRequiresApproval(prState) {
+		c.log.Info("code review requested changes", "pr", prState.PRNumber, "summary", prState.ReviewSummary)
+		requireReview = true
+	}
+
+	if requireReview {
 		c.log.Info("awaiting approval before merge", "pr", prState.PRNumber)
 		prState.Stage = StageAwaitApproval
 
@@ -703,9 +1051,11 @@ func (c *Controller) handleCIFailed(ctx context.Context, prState *PRState) error
 
 	// GH-1567: Fetch actual CI error logs to include in fix issues.
 	// This prevents Pilot from having to rediscover errors by running linter/tests itself.
-	ciLogs := c.ciMonitor.GetFailedCheckLogs(ctx, prState.HeadSHA, 2000)
+	// Only the extracted error lines are kept, not raw runner output.
+	analysis := c.ciMonitor.AnalyzeFailedChecks(ctx, prState.HeadSHA)
+	ciLogs := analysis.Excerpt(maxIssueLogLen)
 
-	issueNum, err := c.feedbackLoop.CreateFailureIssue(ctx, prState, FailureCIPreMerge, failedChecks, ciLogs, iteration+1)
+	issueNum, err := c.feedbackLoop.CreateCIFailureIssue(ctx, prState, FailureCIPreMerge, failedChecks, analysis, iteration+1)
 	if err != nil {
 		return fmt.Errorf("failed to create fix issue: %w", err)
 	}
@@ -751,8 +1101,9 @@ func (c *Controller) handleCIFailed(ctx context.Context, prState *PRState) error
 }
 
 // handleReviewRequested processes a PR that received "changes requested" review feedback.
-// It fetches reviews and comments, checks iteration limits, creates a revision issue,
-// learns from the review, then closes the PR and deletes the branch.
+// It fetches reviews and comments and learns from the review. With same_branch it starts
+// a fix pass on the PR branch; otherwise it checks iteration limits, creates a revision
+// issue, then closes the PR and deletes the branch.
 func (c *Controller) handleReviewRequested(ctx context.Context, prState *PRState) error {
 	c.log.Info("handleReviewRequested: processing review feedback",
 		"pr", prState.PRNumber,
@@ -770,6 +1121,19 @@ func (c *Controller) handleReviewRequested(ctx context.Context, prState *PRState
 		// Non-fatal: proceed with reviews only
 	}
 
+	// Only feedback from reviewers with write access is acted on or learned from
+	reviews, comments = reviewThreadsSince(reviews, comments, time.Time{})
+
+	if c.reviewFixEnabled() {
+		c.learnFromReview(ctx, prState, reviews, comments)
+		c.startReviewFix(ctx, prState, reviews, comments)
+		return nil
+	}
+	if len(changesRequestedBy(reviews, time.Time{})) == 0 {
+		c.ignoreReview(prState)
+		return nil
+	}
+
 	// Check iteration limit
 	iteration := 0
 	if prState.IssueNumber > 0 && c.config.ReviewFeedback != nil && c.config.ReviewFeedback.MaxIterations > 0 {
@@ -804,33 +1168,7 @@ func (c *Controller) handleReviewRequested(ctx context.Context, prState *PRState
 		return fmt.Errorf("failed to create review issue: %w", err)
 	}
 
-	// Learn from review (self-improvement)
-	if c.learningLoop != nil && len(reviews) > 0 {
-		var reviewData []*memory.ReviewData
-		for _, r := range reviews {
-			if r.Body == "" {
-				continue
-			}
-			reviewData = append(reviewData, &memory.ReviewData{
-				Body:     r.Body,
-				State:    r.State,
-				Reviewer: r.User.Login,
-			})
-		}
-		for _, comment := range comments {
-			reviewData = append(reviewData, &memory.ReviewData{
-				Body:     comment.Body,
-				State:    "COMMENTED",
-				Reviewer: comment.User.Login,
-			})
-		}
-		if len(reviewData) > 0 {
-			projectPath := c.owner + "/" + c.repo
-			if learnErr := c.learningLoop.LearnFromReview(ctx, projectPath, reviewData, prState.PRURL); learnErr != nil {
-				c.log.Warn("Failed to learn from review feedback", slog.Any("error", learnErr))
-			}
-		}
-	}
+	c.learnFromReview(ctx, prState, reviews, comments)
 
 	// Notify fix issue created
 	if c.notifier != nil {
@@ -857,56 +1195,262 @@ func (c *Controller) handleReviewRequested(ctx context.Context, prState *PRState
 	return nil
 }
 
-// hasChangesRequested checks if a PR has unresolved "changes requested" reviews.
-// It filters out bot reviews and only considers reviews submitted after the PR was created.
-func (c *Controller) hasChangesRequested(ctx context.Context, prState *PRState) bool {
-	reviews, err := c.ghClient.ListPullRequestReviews(ctx, c.owner, c.repo, prState.PRNumber)
-	if err != nil {
-		c.log.Warn("failed to fetch reviews for changes_requested check", "pr", prState.PRNumber, "error", err)
-		return false
+// learnFromReview feeds review bodies and line comments to the learning
+// loop (self-improvement).
+func (c *Controller) learnFromReview(ctx context.Context, prState *PRState, reviews []*github.PullRequestReview, comments []*github.PRReviewComment) {
+	if c.learningLoop == nil || len(reviews) == 0 {
+		return
 	}
-
-	// Track latest review state per user (only non-bot users)
-	latestState := make(map[string]string)
+	var reviewData []*memory.ReviewData
 	for _, r := range reviews {
-		// Skip bot reviews (self-review)
-		if strings.Contains(r.User.Login, "[bot]") || strings.HasSuffix(r.User.Login, "-bot") {
+		if r.Body == "" {
 			continue
 		}
+		reviewData = append(reviewData, &memory.ReviewData{
+			Body:     r.Body,
+			State:    r.State,
+			Reviewer: r.User.Login,
+		})
+	}
+	for _, comment := range comments {
+		reviewData = append(reviewData, &memory.ReviewData{
+			Body:     comment.Body,
+			State:    "COMMENTED",
+			Reviewer: comment.User.Login,
+		})
+	}
+	if len(reviewData) > 0 {
+		projectPath := c.owner + "/" + c.repo
+		if learnErr := c.learningLoop.LearnFromReview(ctx, projectPath, reviewData, prState.PRURL); learnErr != nil {
+			c.log.Warn("Failed to learn from review feedback", slog.Any("error", learnErr))
+		}
+	}
+}
 
-		// Only consider reviews submitted after the PR entered tracking
-		if r.SubmittedAt != "" && !prState.CreatedAt.IsZero() {
-			submittedAt, err := time.Parse(time.RFC3339, r.SubmittedAt)
-			if err == nil && submittedAt.Before(prState.CreatedAt) {
-				continue
+// reviewFixEnabled reports whether requested changes are fixed on the PR
+// branch instead of in a revision issue.
+func (c *Controller) reviewFixEnabled() bool {
+	return c.reviewFixer != nil && c.config.ReviewFeedback != nil && c.config.ReviewFeedback.SameBranch
+}
+
+// startReviewFix starts a fix pass for the review threads left since the
+// last pass in the background. Once the PR has used up its passes it is
+// left open for a human to take over.
+func (c *Controller) startReviewFix(ctx context.Context, prState *PRState, reviews []*github.PullRequestReview, comments []*github.PRReviewComment) {
+	since := reviewCutoff(prState)
+	if len(changesRequestedBy(reviews, since)) == 0 {
+		c.ignoreReview(prState)
+		return
+	}
+	if max := c.config.ReviewFeedback.MaxIterations; max > 0 && prState.ReviewFixes >= max {
+		c.log.Warn("review fix limit reached", "pr", prState.PRNumber, "passes", prState.ReviewFixes, "max", max)
+		comment := fmt.Sprintf("Changes were requested again after %d review fix pass(es). Leaving this PR for a human to take over.", prState.ReviewFixes)
+		if _, err := c.ghClient.AddPRComment(ctx, c.owner, c.repo, prState.PRNumber, comment); err != nil {
+			c.log.Warn("failed to comment on PR", "pr", prState.PRNumber, "error", err)
+		}
+		prState.Stage = StageFailed
+		prState.Error = fmt.Sprintf("review feedback iteration limit reached (%d/%d)", prState.ReviewFixes, max)
+		c.metrics.RecordPRFailed()
+		return
+	}
+
+	reviews, comments = reviewThreadsSince(reviews, comments, since)
+	feedback := formatReviewFeedback(reviews, comments)
+	if feedback == "" {
+		feedback = "The reviewer requested changes without leaving comments.\n"
+	}
+
+	prState.ReviewFixes++
+	req := ReviewFixRequest{
+		PRNumber:    prState.PRNumber,
+		PRTitle:     prState.PRTitle,
+		IssueNumber: prState.IssueNumber,
+		Branch:      prState.BranchName,
+		Feedback:    feedback,
+		Iteration:   prState.ReviewFixes,
+	}
+	fix := &reviewFix{
+		done:      make(chan struct{}),
+		startedAt: time.Now(),
+		reviewers: changesRequestedBy(reviews, since),
+	}
+	c.mu.Lock()
+	c.reviewFixes[prState.PRNumber] = fix
+	c.mu.Unlock()
+
+	c.log.Info("starting review fix", "pr", prState.PRNumber, "branch", prState.BranchName, "iteration", req.Iteration)
+	// Like conflict resolution, the pass runs an executor task for minutes
+	go func() {
+		defer close(fix.done)
+		fix.err = c.reviewFixer.FixReview(context.WithoutCancel(ctx), req)
+	}()
+
+	prState.Stage = StageFixingReview
+}
+
+// ignoreReview sends a PR back to CI when no reviewer with write access
+// has requested changes, e.g. the review came from an outside contributor.
+func (c *Controller) ignoreReview(prState *PRState) {
+	c.log.Info("no changes requested by a reviewer with write access, ignoring review", "pr", prState.PRNumber)
+	prState.Stage = StageWaitingCI
+	prState.resetHead()
+}
+
+// handleFixingReview waits for the PR's review fix pass. A pushed fix marks
+// the reviews handled and asks the reviewers to look again; a failed one
+// leaves them unhandled, so the PR gets another pass until it has used up
+// its passes. Either way the PR goes back to CI.
+func (c *Controller) handleFixingReview(ctx context.Context, prState *PRState) error {
+	c.mu.RLock()
+	fix, ok := c.reviewFixes[prState.PRNumber]
+	c.mu.RUnlock()
+
+	if ok {
+		select {
+		case <-fix.done:
+		default:
+			return nil
+		}
+		c.mu.Lock()
+		delete(c.reviewFixes, prState.PRNumber)
+		c.mu.Unlock()
+
+		if fix.err != nil {
+			c.log.Warn("review fix failed", "pr", prState.PRNumber, "iteration", prState.ReviewFixes, "error", fix.err)
+			prState.Error = fmt.Sprintf("review fix failed: %v", fix.err)
+		} else {
+			c.log.Info("review fix pushed", "pr", prState.PRNumber, "iteration", prState.ReviewFixes, "reviewers", fix.reviewers)
+			prState.Error = ""
+			prState.ReviewsAddressedAt = fix.startedAt
+			if err := c.ghClient.RequestReviewers(ctx, c.owner, c.repo, prState.PRNumber, fix.reviewers, nil); err != nil {
+				c.log.Warn("failed to re-request review", "pr", prState.PRNumber, "error", err)
 			}
 		}
+	} else {
+		// Pilot restarted while the pass was running
+		c.log.Warn("review fix interrupted", "pr", prState.PRNumber)
+	}
+
+	prState.Stage = StageWaitingCI
+	prState.resetHead() // force refresh on next tick
+	return nil
+}
+
+// reviewCutoff is when the PR's unhandled reviews start: when it entered
+// tracking, or when the last pushed review fix started.
+func reviewCutoff(prState *PRState) time.Time {
+	if prState.ReviewsAddressedAt.After(prState.CreatedAt) {
+		return prState.ReviewsAddressedAt
+	}
+	return prState.CreatedAt
+}
+
+// isBotLogin reports whether a review comes from a bot (self-review).
+func isBotLogin(login string) bool {
+	return strings.Contains(login, "[bot]") || strings.HasSuffix(login, "-bot")
+}
+
+// isReviewer reports whether a review or line comment is acted on: it comes
+// from a human with write access to the repo. Anyone can review a public
+// PR, and their feedback would otherwise become the fix pass's prompt.
+func isReviewer(login, association string) bool {
+	if isBotLogin(login) {
+		return false
+	}
+	switch association {
+	case "OWNER", "MEMBER", "COLLABORATOR":
+		return true
+	}
+	return false
+}
+
+// submittedBefore reports whether an RFC 3339 timestamp is before t.
+// Missing or unparsable timestamps count as not before.
+func submittedBefore(ts string, t time.Time) bool {
+	if ts == "" || t.IsZero() {
+		return false
+	}
+	at, err := time.Parse(time.RFC3339, ts)
+	return err == nil && at.Before(t)
+}
+
+// reviewThreadsSince keeps the reviews and line comments by reviewers with
+// write access submitted since the cutoff.
+func reviewThreadsSince(reviews []*github.PullRequestReview, comments []*github.PRReviewComment, since time.Time) ([]*github.PullRequestReview, []*github.PRReviewComment) {
+	var keptReviews []*github.PullRequestReview
+	for _, r := range reviews {
+		if isReviewer(r.User.Login, r.AuthorAssociation) && !submittedBefore(r.SubmittedAt, since) {
+			keptReviews = append(keptReviews, r)
+		}
+	}
+	var keptComments []*github.PRReviewComment
+	for _, cm := range comments {
+		if isReviewer(cm.User.Login, cm.AuthorAssociation) && !submittedBefore(cm.CreatedAt, since) {
+			keptComments = append(keptComments, cm)
+		}
+	}
+	return keptReviews, keptComments
+}
 
+// changesRequestedBy returns the reviewers with write access whose latest
+// review since the cutoff requests changes.
+func changesRequestedBy(reviews []*github.PullRequestReview, since time.Time) []string {
+	latestState := make(map[string]string)
+	var order []string
+	for _, r := range reviews {
+		if !isReviewer(r.User.Login, r.AuthorAssociation) || submittedBefore(r.SubmittedAt, since) {
+			continue
+		}
+		if _, seen := latestState[r.User.Login]; !seen {
+			order = append(order, r.User.Login)
+		}
 		latestState[r.User.Login] = r.State
 	}
 
-	for _, state := range latestState {
-		if state == "CHANGES_REQUESTED" {
-			return true
+	var reviewers []string
+	for _, login := range order {
+		if latestState[login] == "CHANGES_REQUESTED" {
+			reviewers = append(reviewers, login)
 		}
 	}
+	return reviewers
+}
 
-	return false
+// hasChangesRequested checks if a PR has unresolved "changes requested" reviews.
+// It filters out bot reviews and reviewers without write access, and only considers
+// reviews submitted after the PR was created, or after the last pushed review fix started.
+func (c *Controller) hasChangesRequested(ctx context.Context, prState *PRState) bool {
+	reviews, err := c.ghClient.ListPullRequestReviews(ctx, c.owner, c.repo, prState.PRNumber)
+	if err != nil {
+		c.log.Warn("failed to fetch reviews for changes_requested check", "pr", prState.PRNumber, "error", err)
+		return false
+	}
+	return len(changesRequestedBy(reviews, reviewCutoff(prState))) > 0
 }
 
-// handleAwaitApproval waits for human approval (prod only).
+// handleAwaitApproval waits for human approval (prod only). Linked PRs are
+// approved before they wait for their group, so PRs of one change awaiting
+// approval don't wait on each other.
 func (c *Controller) handleAwaitApproval(ctx context.Context, prState *PRState) error {
 	// This will block until approval received or timeout
-	err := c.autoMerger.MergePR(ctx, prState)
-	if err != nil {
-		if err.Error() == "merge rejected: approval denied" {
+	if err := c.autoMerger.Approve(ctx, prState); err != nil {
+		if errors.Is(err, errApprovalDenied) {
 			c.log.Info("merge approval denied", "pr", prState.PRNumber)
 			prState.Stage = StageFailed
 			return nil
 		}
 		return err
 	}
+	if c.heldByLinkedPRs(ctx, prState) {
+		return nil
+	}
+
+	if err := c.autoMerger.MergePR(ctx, prState); err != nil {
+		return err
+	}
 	prState.Stage = StageMerged
+	c.linked.markMerged(c.Repository(), prState.PRNumber)
+	c.runMergeHooks(ctx, prState)
 
 	// Notify merge success after approval
 	if c.notifier != nil {
@@ -920,6 +1464,10 @@ func (c *Controller) handleAwaitApproval(ctx context.Context, prState *PRState)
 
 // handleMerging merges the PR.
 func (c *Controller) handleMerging(ctx context.Context, prState *PRState) error {
+	if c.heldByFreeze(prState) || c.heldByLinkedPRs(ctx, prState) {
+		return nil
+	}
+
 	prState.MergeAttempts++
 
 	c.log.Info("handleMerging: attempting merge",
@@ -928,6 +1476,18 @@ func (c *Controller) handleMerging(ctx context.Context, prState *PRState) error
 		"method", c.config.MergeMethod,
 	)
 
+	canary := c.canaryEnabled()
+	if canary {
+		if err := c.retargetToCanary(ctx, prState); err != nil {
+			return fmt.Errorf("merge attempt %d failed: %w", prState.MergeAttempts, err)
+		}
+	}
+	if c.pipeline != nil {
+		if err := c.retargetToPipeline(ctx, prState); err != nil {
+			return fmt.Errorf("merge attempt %d failed: %w", prState.MergeAttempts, err)
+		}
+	}
+
 	err := c.autoMerger.MergePR(ctx, prState)
 	if err != nil {
 		c.log.Error("handleMerging: merge failed",
@@ -945,14 +1505,40 @@ func (c *Controller) handleMerging(ctx context.Context, prState *PRState) error
 
 		return fmt.Errorf("merge attempt %d failed: %w", prState.MergeAttempts, err)
 	}
+	c.linked.markMerged(c.Repository(), prState.PRNumber)
 
-	c.log.Info("PR merged successfully", "pr", prState.PRNumber)
-	prState.Stage = StageMerged
-	c.metrics.RecordPRMerged()
-	c.metrics.RecordPRTimeToMerge(time.Since(prState.CreatedAt))
+	if canary {
+		c.log.Info("PR merged into canary branch", "pr", prState.PRNumber, "branch", c.config.Canary.ResolvedBranch())
+		prState.Stage = StageCanaryCI
+		prState.CanarySHA = ""
+		prState.CIWaitStartedAt = time.Now()
+		return nil
+	}
 
-	// GH-1015: Add pilot-done label after successful merge (not at PR creation)
-	// This prevents false positives where PRs are closed without merging
+	if c.mergeQueueEnabled() {
+		prState.Stage = StageMergeQueued
+		return nil
+	}
+
+	c.log.Info("PR merged successfully", "pr", prState.PRNumber)
+	c.completeMerge(ctx, prState)
+	if c.pipeline != nil {
+		c.startPipeline(ctx, prState)
+	}
+	return nil
+}
+
+// completeMerge finishes a PR whose changes reached the target branch:
+// labels and closes the issue, deletes the branch, runs merge hooks and
+// notifies. With canary merges this runs on promotion, not on the merge
+// into the canary branch.
+func (c *Controller) completeMerge(ctx context.Context, prState *PRState) {
+	prState.Stage = StageMerged
+	c.metrics.RecordPRMerged()
+	c.metrics.RecordPRTimeToMerge(time.Since(prState.CreatedAt))
+
+	// GH-1015: Add pilot-done label after successful merge (not at PR creation)
+	// This prevents false positives where PRs are closed without merging
 	if prState.IssueNumber > 0 {
 		if err := c.ghClient.AddLabels(ctx, c.owner, c.repo, prState.IssueNumber, []string{github.LabelDone}); err != nil {
 			c.log.Warn("failed to add pilot-done label after merge", "issue", prState.IssueNumber, "error", err)
@@ -997,14 +1583,14 @@ func (c *Controller) handleMerging(ctx context.Context, prState *PRState) error
 		}
 	}
 
+	c.runMergeHooks(ctx, prState)
+
 	// Notify merge success
 	if c.notifier != nil {
 		if err := c.notifier.NotifyMerged(ctx, prState); err != nil {
 			c.log.Warn("failed to send merge notification", "error", err)
 		}
 	}
-
-	return nil
 }
 
 // handleMerged runs post-merge deployer and checks post-merge CI based on environment config.
@@ -1188,12 +1774,30 @@ func (c *Controller) maybeCloseParentIssue(ctx context.Context, prState *PRState
 
 // handlePostMergeCI monitors deployment/post-merge checks.
 func (c *Controller) handlePostMergeCI(ctx context.Context, prState *PRState) error {
-	// Get merge commit SHA from main branch
-	// For now, use head SHA - in production, should get actual merge commit
-	mainSHA, err := c.getMainBranchSHA(ctx)
-	if err != nil {
-		c.log.Warn("failed to get main branch SHA, using head SHA", "error", err)
-		mainSHA = prState.HeadSHA
+	// With rollback enabled, watch the PR's own merge commit so a failure is
+	// attributable to it; otherwise watch the main branch head.
+	var merged *github.PullRequest
+	if c.rollbackEnabled() {
+		pr, err := c.ghClient.GetPullRequest(ctx, c.owner, c.repo, prState.PRNumber)
+		if err != nil {
+			c.log.Warn("failed to get merged PR, rollback unavailable", "pr", prState.PRNumber, "error", err)
+		} else if pr.MergeCommitSHA != "" {
+			merged = pr
+		}
+	}
+
+	var mainSHA string
+	if merged != nil {
+		mainSHA = merged.MergeCommitSHA
+	} else {
+		// Get merge commit SHA from main branch
+		// For now, use head SHA - in production, should get actual merge commit
+		sha, err := c.getMainBranchSHA(ctx)
+		if err != nil {
+			c.log.Warn("failed to get main branch SHA, using head SHA", "error", err)
+			sha = prState.HeadSHA
+		}
+		mainSHA = sha
 	}
 
 	status, err := c.ciMonitor.WaitForCI(ctx, mainSHA)
@@ -1205,13 +1809,21 @@ func (c *Controller) handlePostMergeCI(ctx context.Context, prState *PRState) er
 		c.log.Warn("post-merge CI failed", "pr", prState.PRNumber)
 		failedChecks, _ := c.ciMonitor.GetFailedChecks(ctx, mainSHA)
 		// GH-1567: Fetch CI error logs for post-merge failures too
-		ciLogs := c.ciMonitor.GetFailedCheckLogs(ctx, mainSHA, 2000)
-		// Post-merge failures start a new lineage (iteration 1), not part of pre-merge cascade
-		issueNum, err := c.feedbackLoop.CreateFailureIssue(ctx, prState, FailureCIPostMerge, failedChecks, ciLogs, 1)
-		if err != nil {
-			c.log.Error("failed to create post-merge fix issue", "error", err)
+		analysis := c.ciMonitor.AnalyzeFailedChecks(ctx, mainSHA)
+		ciLogs := analysis.Excerpt(maxIssueLogLen)
+
+		// Revert the merge when the failure is within the rollback window;
+		// the revert replaces the fix issue
+		if merged != nil && c.withinRollbackWindow(merged) {
+			c.rollback(ctx, prState, merged, failedChecks)
 		} else {
-			c.log.Info("created fix issue for post-merge CI failure", "pr", prState.PRNumber, "issue", issueNum)
+			// Post-merge failures start a new lineage (iteration 1), not part of pre-merge cascade
+			issueNum, err := c.feedbackLoop.CreateCIFailureIssue(ctx, prState, FailureCIPostMerge, failedChecks, analysis, 1)
+			if err != nil {
+				c.log.Error("failed to create post-merge fix issue", "error", err)
+			} else {
+				c.log.Info("created fix issue for post-merge CI failure", "pr", prState.PRNumber, "issue", issueNum)
+			}
 		}
 
 		// GH-1964/GH-1979: Learn from post-merge CI failure patterns (self-improvement).
@@ -1238,6 +1850,312 @@ func (c *Controller) handlePostMergeCI(ctx context.Context, prState *PRState) er
 	return nil
 }
 
+// rollbackEnabled reports whether autopilot.rollback is on.
+func (c *Controller) rollbackEnabled() bool {
+	return c.config.Rollback != nil && c.config.Rollback.Enabled
+}
+
+// withinRollbackWindow reports whether pr was merged recently enough for a
+// CI failure now to be blamed on it. An unknown merge time counts as recent.
+func (c *Controller) withinRollbackWindow(pr *github.PullRequest) bool {
+	mergedAt, err := time.Parse(time.RFC3339, pr.MergedAt)
+	if err != nil {
+		return true
+	}
+	return time.Since(mergedAt) <= c.config.Rollback.ResolvedWindow()
+}
+
+// rollback opens a revert PR for a merge that broke post-merge CI, links it
+// from the merged PR and alerts. The revert is left for a human to merge.
+func (c *Controller) rollback(ctx context.Context, prState *PRState, merged *github.PullRequest, failedChecks []string) {
+	checks := strings.Join(failedChecks, ", ")
+	if checks == "" {
+		checks = "unknown"
+	}
+	mergeSHA := ShortSHA(merged.MergeCommitSHA)
+
+	title := fmt.Sprintf("Revert \"%s\"", merged.Title)
+	body := fmt.Sprintf("Reverts #%d: CI failed on merge commit %s.\n\n**Failed checks:** %s\n\nOpened automatically by Pilot autopilot (`autopilot.rollback`). Merge this PR to restore the branch, then reopen the work.",
+		prState.PRNumber, mergeSHA, checks)
+
+	metadata := map[string]string{
+		"pr_number":     strconv.Itoa(prState.PRNumber),
+		"merge_sha":     mergeSHA,
+		"failed_checks": checks,
+	}
+
+	revert, err := c.ghClient.RevertPullRequest(ctx, c.owner, c.repo, prState.PRNumber, title, body)
+	if err != nil {
+		c.log.Error("failed to open revert PR", "pr", prState.PRNumber, "error", err)
+		metadata["revert_error"] = err.Error()
+	} else {
+		c.log.Info("opened revert PR for post-merge CI failure", "pr", prState.PRNumber, "revert_pr", revert.Number)
+		metadata["revert_pr_url"] = revert.HTMLURL
+		comment := fmt.Sprintf("⏪ CI failed on the merge commit %s (%s). Autopilot opened a revert PR: %s", mergeSHA, checks, revert.HTMLURL)
+		if _, err := c.ghClient.AddPRComment(ctx, c.owner, c.repo, prState.PRNumber, comment); err != nil {
+			c.log.Warn("failed to comment on reverted PR", "pr", prState.PRNumber, "error", err)
+		}
+	}
+
+	if c.alerts != nil {
+		c.alerts.ProcessEvent(alerts.Event{
+			Type:      alerts.EventTypeAutopilotRollback,
+			TaskID:    fmt.Sprintf("pr-%d", prState.PRNumber),
+			TaskTitle: merged.Title,
+			Project:   fmt.Sprintf("%s/%s", c.owner, c.repo),
+			Error:     fmt.Sprintf("post-merge CI failed: %s", checks),
+			Metadata:  metadata,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// canaryEnabled reports whether autopilot.canary is on.
+func (c *Controller) canaryEnabled() bool {
+	return c.config.Canary != nil && c.config.Canary.Enabled
+}
+
+// promotionTarget returns the branch a canary merge is promoted to: the PR's
+// original base, or the environment branch once the base points at canary.
+func (c *Controller) promotionTarget(prState *PRState) string {
+	if prState.TargetBranch != "" && prState.TargetBranch != c.config.Canary.ResolvedBranch() {
+		return prState.TargetBranch
+	}
+	if branch := c.config.ResolvedEnv().Branch; branch != "" {
+		return branch
+	}
+	return "main"
+}
+
+// retargetToCanary points the PR at the canary branch, creating the branch
+// from the target when missing and catching it up with direct pushes to the
+// target when it can be fast-forwarded.
+func (c *Controller) retargetToCanary(ctx context.Context, prState *PRState) error {
+	canary := c.config.Canary.ResolvedBranch()
+	target := c.promotionTarget(prState)
+	prState.TargetBranch = target
+
+	targetBranch, err := c.ghClient.GetBranch(ctx, c.owner, c.repo, target)
+	if err != nil {
+		return fmt.Errorf("failed to get target branch %s: %w", target, err)
+	}
+	if _, err := c.ghClient.GetBranch(ctx, c.owner, c.repo, canary); err != nil {
+		c.log.Info("creating canary branch", "branch", canary, "from", target)
+		if err := c.ghClient.UpdateRef(ctx, c.owner, c.repo, canary, targetBranch.SHA()); err != nil {
+			return fmt.Errorf("failed to create canary branch %s: %w", canary, err)
+		}
+	} else if err := c.ghClient.FastForwardRef(ctx, c.owner, c.repo, canary, targetBranch.SHA()); err != nil {
+		// Expected while earlier merges are soaking: canary is ahead of target
+		c.log.Debug("canary branch not fast-forwarded to target", "branch", canary, "error", err)
+	}
+
+	if err := c.ghClient.UpdatePullRequestBase(ctx, c.owner, c.repo, prState.PRNumber, canary); err != nil {
+		return fmt.Errorf("failed to retarget PR to %s: %w", canary, err)
+	}
+	return nil
+}
+
+// handleCanaryCI waits for CI on the PR's merge commit in the canary branch.
+func (c *Controller) handleCanaryCI(ctx context.Context, prState *PRState) error {
+	if prState.CanarySHA == "" {
+		pr, err := c.ghClient.GetPullRequest(ctx, c.owner, c.repo, prState.PRNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get canary merge commit: %w", err)
+		}
+		if pr.MergeCommitSHA == "" {
+			return nil // Not available yet, retry next cycle
+		}
+		prState.CanarySHA = pr.MergeCommitSHA
+	}
+
+	if prState.CIWaitStartedAt.IsZero() {
+		prState.CIWaitStartedAt = time.Now()
+	}
+	if ciTimeout := c.ciTimeout(); time.Since(prState.CIWaitStartedAt) > ciTimeout {
+		c.log.Warn("canary CI timeout", "pr", prState.PRNumber, "sha", ShortSHA(prState.CanarySHA))
+		prState.Stage = StageFailed
+		prState.Error = fmt.Sprintf("canary CI timeout after %v", ciTimeout)
+		return nil
+	}
+
+	status, err := c.ciMonitor.CheckCI(ctx, prState.CanarySHA)
+	if err != nil {
+		c.log.Warn("canary CI status check failed", "pr", prState.PRNumber, "error", err)
+		return nil // Transient, retry next cycle
+	}
+	prState.CIStatus = status
+	prState.LastChecked = time.Now()
+
+	switch status {
+	case CISuccess:
+		c.log.Info("canary CI passed, soaking",
+			"pr", prState.PRNumber,
+			"sha", ShortSHA(prState.CanarySHA),
+			"soak_time", c.config.Canary.ResolvedSoakTime(),
+		)
+		prState.CanaryGreenAt = time.Now()
+		prState.Stage = StageCanarySoak
+	case CIFailure:
+		c.failCanary(ctx, prState)
+	}
+	return nil
+}
+
+// handleCanarySoak keeps checking CI on the canary merge commit until the
+// soak time has passed, then fast-forwards the target branch to it.
+func (c *Controller) handleCanarySoak(ctx context.Context, prState *PRState) error {
+	status, err := c.ciMonitor.CheckCI(ctx, prState.CanarySHA)
+	if err != nil {
+		c.log.Warn("canary CI status check failed", "pr", prState.PRNumber, "error", err)
+		return nil // Transient, retry next cycle
+	}
+	prState.CIStatus = status
+	prState.LastChecked = time.Now()
+
+	switch status {
+	case CIFailure:
+		c.failCanary(ctx, prState)
+		return nil
+	case CIPending, CIRunning:
+		// A re-run is in progress; promote only on green
+		return nil
+	}
+
+	if prState.CanaryGreenAt.IsZero() {
+		prState.CanaryGreenAt = time.Now()
+	}
+	soak := c.config.Canary.ResolvedSoakTime()
+	if time.Since(prState.CanaryGreenAt) < soak {
+		return nil
+	}
+
+	if c.heldByFreeze(prState) {
+		return nil
+	}
+
+	target := c.promotionTarget(prState)
+	if err := c.ghClient.FastForwardRef(ctx, c.owner, c.repo, target, prState.CanarySHA); err != nil {
+		return fmt.Errorf("failed to fast-forward %s to canary %s: %w", target, ShortSHA(prState.CanarySHA), err)
+	}
+	c.log.Info("promoted canary merge",
+		"pr", prState.PRNumber,
+		"branch", target,
+		"sha", ShortSHA(prState.CanarySHA),
+		"soaked", time.Since(prState.CanaryGreenAt).Round(time.Minute),
+	)
+
+	// Post-merge steps (CI, release, rollback) run on the promoted commit
+	prState.HeadSHA = prState.CanarySHA
+	c.completeMerge(ctx, prState)
+	return nil
+}
+
+// failCanary stops a canary merge whose CI failed: the target branch is never
+// fast-forwarded to it, and a fix issue is opened instead.
+func (c *Controller) failCanary(ctx context.Context, prState *PRState) {
+	c.log.Warn("canary CI failed, not promoting", "pr", prState.PRNumber, "sha", ShortSHA(prState.CanarySHA))
+	failedChecks, _ := c.ciMonitor.GetFailedChecks(ctx, prState.CanarySHA)
+	analysis := c.ciMonitor.AnalyzeFailedChecks(ctx, prState.CanarySHA)
+
+	issueNum, err := c.feedbackLoop.CreateCIFailureIssue(ctx, prState, FailureCICanary, failedChecks, analysis, 1)
+	if err != nil {
+		c.log.Error("failed to create canary fix issue", "error", err)
+	} else {
+		c.log.Info("created fix issue for canary CI failure", "pr", prState.PRNumber, "issue", issueNum)
+	}
+	c.removePR(prState.PRNumber)
+}
+
+// mergeQueueEnabled reports whether the active environment merges through
+// GitHub's merge queue.
+func (c *Controller) mergeQueueEnabled() bool {
+	return c.config.ResolvedEnv().MergeQueue
+}
+
+// handleMergeQueued follows a PR through the merge queue until GitHub merges
+// it. A PR that leaves the queue unmerged goes back to CI when its head moved,
+// to conflict handling when it conflicts, and to a fix issue when the queue's
+// checks failed on its merge group.
+func (c *Controller) handleMergeQueued(ctx context.Context, prState *PRState, ghPR *github.PullRequest) error {
+	if ghPR == nil {
+		var err error
+		ghPR, err = c.ghClient.GetPullRequest(ctx, c.owner, c.repo, prState.PRNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get PR: %w", err)
+		}
+	}
+
+	if ghPR.Merged {
+		c.log.Info("PR merged by merge queue", "pr", prState.PRNumber)
+		if ghPR.MergeCommitSHA != "" {
+			prState.HeadSHA = ghPR.MergeCommitSHA
+		}
+		prState.MergeQueuePosition = 0
+		c.completeMerge(ctx, prState)
+		return nil
+	}
+
+	entry, err := c.ghClient.GetMergeQueueEntry(ctx, c.owner, c.repo, prState.PRNumber)
+	if err != nil {
+		c.log.Warn("merge queue status check failed", "pr", prState.PRNumber, "error", err)
+		return nil // Transient, retry next cycle
+	}
+	prState.LastChecked = time.Now()
+	if entry != nil {
+		if entry.Position != prState.MergeQueuePosition {
+			c.log.Info("merge queue position", "pr", prState.PRNumber, "position", entry.Position, "state", entry.State)
+		}
+		prState.MergeQueuePosition = entry.Position
+		if entry.HeadSHA != "" {
+			prState.MergeQueueSHA = entry.HeadSHA
+		}
+		return nil
+	}
+
+	// Dequeued without a merge
+	prState.MergeQueuePosition = 0
+	c.log.Warn("PR removed from merge queue", "pr", prState.PRNumber, "merge_group", ShortSHA(prState.MergeQueueSHA))
+
+	if c.isMergeConflict(ghPR) {
+		return c.handleMergeConflict(ctx, prState)
+	}
+	if ghPR.Head.SHA != "" && ghPR.Head.SHA != prState.HeadSHA {
+		// New commits dequeue the PR; verify them before enqueueing again
+		prState.HeadSHA = ghPR.Head.SHA
+		prState.clearMergeChecks()
+		prState.Stage = StageWaitingCI
+		prState.CIStatus = CIPending
+		prState.CIWaitStartedAt = time.Now()
+		return nil
+	}
+	if prState.MergeQueueSHA != "" {
+		if failedChecks, _ := c.ciMonitor.GetFailedChecks(ctx, prState.MergeQueueSHA); len(failedChecks) > 0 {
+			c.failMergeQueue(ctx, prState, failedChecks)
+			return nil
+		}
+	}
+
+	// Removed by hand, or for a reason the queue does not report
+	prState.Stage = StageFailed
+	prState.Error = "removed from merge queue"
+	return nil
+}
+
+// failMergeQueue stops a PR whose merge group failed the queue's checks and
+// opens a fix issue for it.
+func (c *Controller) failMergeQueue(ctx context.Context, prState *PRState, failedChecks []string) {
+	c.log.Warn("merge queue checks failed", "pr", prState.PRNumber, "checks", failedChecks)
+	analysis := c.ciMonitor.AnalyzeFailedChecks(ctx, prState.MergeQueueSHA)
+
+	issueNum, err := c.feedbackLoop.CreateCIFailureIssue(ctx, prState, FailureMergeQueue, failedChecks, analysis, 1)
+	if err != nil {
+		c.log.Error("failed to create merge queue fix issue", "error", err)
+	} else {
+		c.log.Info("created fix issue for merge queue failure", "pr", prState.PRNumber, "issue", issueNum)
+	}
+	c.removePR(prState.PRNumber)
+}
+
 // getMainBranchSHA returns the current SHA of the main branch.
 func (c *Controller) getMainBranchSHA(ctx context.Context) (string, error) {
 	branch, err := c.ghClient.GetBranch(ctx, c.owner, c.repo, "main")
@@ -1259,7 +2177,14 @@ func (c *Controller) resolvedRelease() *ReleaseConfig {
 // shouldTriggerRelease returns true if auto-release is configured.
 func (c *Controller) shouldTriggerRelease() bool {
 	rel := c.resolvedRelease()
-	return rel != nil && rel.Enabled && rel.Trigger == "on_merge"
+	if rel == nil || !rel.Enabled || rel.Trigger != "on_merge" {
+		return false
+	}
+	if c.releaseAllowed != nil && !c.releaseAllowed() {
+		c.log.Info("release disabled for project, skipping")
+		return false
+	}
+	return true
 }
 
 // handleReleasing creates a release after successful merge and CI.
@@ -1270,6 +2195,10 @@ func (c *Controller) handleReleasing(ctx context.Context, prState *PRState) erro
 		return nil
 	}
 
+	if c.heldByFreeze(prState) {
+		return nil
+	}
+
 	// Race condition guard: Check if this commit already has a tag.
 	// When multiple PRs merge rapidly, each triggers handleReleasing but only
 	// the first should create a tag. Subsequent PRs will see their merge commit
@@ -1311,6 +2240,16 @@ func (c *Controller) handleReleasing(ctx context.Context, prState *PRState) erro
 		return nil
 	}
 
+	if err := c.cutRelease(ctx, prState, currentVersion, bumpType); err != nil {
+		return err
+	}
+	c.removePR(prState.PRNumber)
+	return nil
+}
+
+// cutRelease bumps the version files, then tags prState.HeadSHA with the
+// next version, or tags a release candidate when promotion is enabled.
+func (c *Controller) cutRelease(ctx context.Context, prState *PRState, currentVersion SemVer, bumpType BumpType) error {
 	// Calculate new version
 	newVersion := currentVersion.Bump(bumpType)
 	rel := c.resolvedRelease()
@@ -1323,6 +2262,36 @@ func (c *Controller) handleReleasing(ctx context.Context, prState *PRState) erro
 		"bump", bumpType,
 	)
 
+	// Commit version file bumps first so the tag includes them. The bump goes
+	// on top of the branch head: retries after a failed tag find the files
+	// already bumped there and tag the same commit.
+	versionFiles := rel.VersionFiles
+	if len(c.versionFiles) > 0 {
+		versionFiles = c.versionFiles
+	}
+	if len(versionFiles) > 0 {
+		branchName := prState.TargetBranch
+		if branchName == "" {
+			branchName = "main"
+		}
+		branch, err := c.ghClient.GetBranch(ctx, c.owner, c.repo, branchName)
+		if err != nil {
+			return fmt.Errorf("failed to get %s branch: %w", branchName, err)
+		}
+		sha, err := c.releaser.UpdateVersionFiles(ctx, versionFiles, branchName, branch.SHA(), newVersion)
+		if err != nil {
+			return fmt.Errorf("failed to update version files: %w", err)
+		}
+		c.log.Info("version files updated", "pr", prState.PRNumber, "sha", ShortSHA(sha), "files", len(versionFiles))
+		prState.HeadSHA = sha
+	}
+
+	// With promotion the merge is tagged as a release candidate and promoted
+	// through environments; the final tag is created by the last stage.
+	if rel.Promotion != nil && rel.Promotion.Enabled {
+		return c.releaseCandidate(ctx, prState, rel, newVersion)
+	}
+
 	// Create git tag only — GoReleaser CI handles the full release with binaries
 	tagName, err := c.releaser.CreateTag(ctx, prState, newVersion)
 	if err != nil {
@@ -1344,8 +2313,38 @@ func (c *Controller) handleReleasing(ctx context.Context, prState *PRState) erro
 			}
 		}
 	}
+	return nil
+}
 
-	c.removePR(prState.PRNumber)
+// releaseCandidate tags the release candidate and promotes it in the
+// background through every stage that needs no approval.
+func (c *Controller) releaseCandidate(ctx context.Context, prState *PRState, rel *ReleaseConfig, newVersion SemVer) error {
+	if c.stateStore == nil {
+		return fmt.Errorf("release promotion requires the autopilot state store")
+	}
+	promoter := NewPromoter(c.ghClient, c.owner, c.repo, rel, c.stateStore, c.approvalMgr)
+	promotion, err := promoter.CreateCandidate(ctx, newVersion, prState.HeadSHA)
+	if promotion == nil {
+		return fmt.Errorf("failed to create release candidate: %w", err)
+	}
+	if err != nil {
+		// The candidate is tagged and recorded; a failed deploy must not
+		// re-run the release.
+		c.log.Warn("release candidate deploy failed", "version", promotion.Version, "error", err)
+	}
+	c.log.Info("release candidate created",
+		"pr", prState.PRNumber,
+		"version", promotion.Version,
+		"tag", promotion.LatestTag(),
+	)
+
+	// Approval may wait for hours; it must not hold up the PR loop or be cut
+	// off by its deadline.
+	go func() {
+		if _, err := promoter.AutoPromote(context.WithoutCancel(ctx), promotion.Version); err != nil {
+			c.log.Warn("release promotion failed", "version", promotion.Version, "error", err)
+		}
+	}()
 	return nil
 }
 
@@ -1369,7 +2368,8 @@ func (c *Controller) isMergeConflict(pr *github.PullRequest) bool {
 }
 
 // handleMergeConflict tries to auto-rebase the PR branch first. If that fails,
-// falls back to closing the PR and returning the issue to the queue.
+// runs a conflict resolution pass when enabled, and once the passes are used
+// up falls back to closing the PR and returning the issue to the queue.
 // GH-1796: Saves ~$8-15 per run by avoiding full re-execution for trivial conflicts.
 func (c *Controller) handleMergeConflict(ctx context.Context, prState *PRState) error {
 	c.log.Warn("merge conflict detected",
@@ -1383,13 +2383,22 @@ func (c *Controller) handleMergeConflict(ctx context.Context, prState *PRState)
 	if err == nil {
 		c.log.Info("auto-rebased conflicting PR", "pr", prState.PRNumber)
 		prState.Stage = StageWaitingCI // rebase triggers new CI
-		prState.HeadSHA = ""           // force refresh on next tick
+		prState.resetHead()            // force refresh on next tick
 		return nil
 	}
-	c.log.Warn("auto-rebase failed, closing PR for retry", "pr", prState.PRNumber, "error", err)
+	c.log.Warn("auto-rebase failed", "pr", prState.PRNumber, "error", err)
+
+	if c.startConflictResolution(ctx, prState) {
+		return nil
+	}
+	c.log.Warn("closing conflicting PR for retry", "pr", prState.PRNumber, "conflict_attempts", prState.ConflictAttempts)
 
 	// Add comment explaining the closure
 	comment := "Merge conflict detected. Auto-rebase failed — closing PR so the issue can be re-executed from updated main."
+	if prState.ConflictAttempts > 0 {
+		comment = fmt.Sprintf("Merge conflict detected. Auto-rebase and %d conflict resolution attempt(s) failed — closing PR so the issue can be re-executed from updated main.", prState.ConflictAttempts)
+		c.alertConflictUnresolved(prState)
+	}
 	if _, err := c.ghClient.AddPRComment(ctx, c.owner, c.repo, prState.PRNumber, comment); err != nil {
 		c.log.Warn("failed to comment on conflicting PR", "pr", prState.PRNumber, "error", err)
 	}
@@ -1411,11 +2420,111 @@ func (c *Controller) handleMergeConflict(ctx context.Context, prState *PRState)
 	return nil
 }
 
-// removePR removes PR from tracking and cleans up the remote branch.
-func (c *Controller) removePR(prNumber int) {
+// conflictResolutionEnabled reports whether conflicting PRs get resolution
+// passes before they are closed.
+func (c *Controller) conflictResolutionEnabled() bool {
+	return c.conflictResolver != nil && c.config.ConflictResolution != nil && c.config.ConflictResolution.Enabled
+}
+
+// startConflictResolution starts a resolution pass on the PR branch in the
+// background. Returns false when resolution is off or the PR has used up
+// its attempts.
+func (c *Controller) startConflictResolution(ctx context.Context, prState *PRState) bool {
+	if !c.conflictResolutionEnabled() {
+		return false
+	}
+	if prState.ConflictAttempts >= c.config.ConflictResolution.ResolvedMaxAttempts() {
+		return false
+	}
+
+	prState.ConflictAttempts++
+	req := ConflictRequest{
+		PRNumber:    prState.PRNumber,
+		PRTitle:     prState.PRTitle,
+		IssueNumber: prState.IssueNumber,
+		Branch:      prState.BranchName,
+		BaseBranch:  prState.TargetBranch,
+		Attempt:     prState.ConflictAttempts,
+	}
+	res := &conflictResolution{done: make(chan struct{})}
 	c.mu.Lock()
+	c.resolutions[prState.PRNumber] = res
+	c.mu.Unlock()
+
+	c.log.Info("starting conflict resolution", "pr", prState.PRNumber, "branch", prState.BranchName, "attempt", req.Attempt)
+	// The pass runs an executor task for minutes; it must not hold up the
+	// PR loop or be cut off by its deadline.
+	go func() {
+		defer close(res.done)
+		res.err = c.conflictResolver.ResolveConflicts(context.WithoutCancel(ctx), req)
+	}()
+
+	prState.Stage = StageResolvingConflicts
+	return true
+}
+
+// handleResolvingConflicts waits for the PR's resolution pass. Either way
+// the PR goes back to CI: a resolved branch was pushed and needs new CI, and
+// a branch that still conflicts is caught there and gets another pass or is
+// closed.
+func (c *Controller) handleResolvingConflicts(ctx context.Context, prState *PRState) error {
+	c.mu.RLock()
+	res, ok := c.resolutions[prState.PRNumber]
+	c.mu.RUnlock()
+
+	if ok {
+		select {
+		case <-res.done:
+		default:
+			return nil
+		}
+		c.mu.Lock()
+		delete(c.resolutions, prState.PRNumber)
+		c.mu.Unlock()
+
+		if res.err != nil {
+			c.log.Warn("conflict resolution failed", "pr", prState.PRNumber, "attempt", prState.ConflictAttempts, "error", res.err)
+			prState.Error = fmt.Sprintf("conflict resolution failed: %v", res.err)
+		} else {
+			c.log.Info("conflict resolution pushed", "pr", prState.PRNumber, "attempt", prState.ConflictAttempts)
+			prState.Error = ""
+		}
+	} else {
+		// Pilot restarted while the pass was running
+		c.log.Warn("conflict resolution interrupted", "pr", prState.PRNumber)
+	}
+
+	prState.Stage = StageWaitingCI
+	prState.resetHead() // force refresh on next tick
+	return nil
+}
+
+// alertConflictUnresolved reports a PR closed after its resolution passes
+// failed.
+func (c *Controller) alertConflictUnresolved(prState *PRState) {
+	if c.alerts == nil {
+		return
+	}
+	c.alerts.ProcessEvent(alerts.Event{
+		Type:      alerts.EventTypeConflictUnresolved,
+		TaskID:    fmt.Sprintf("pr-%d", prState.PRNumber),
+		TaskTitle: prState.PRTitle,
+		Project:   fmt.Sprintf("%s/%s", c.owner, c.repo),
+		Error:     prState.Error,
+		Metadata: map[string]string{
+			"pr_number": strconv.Itoa(prState.PRNumber),
+			"branch":    prState.BranchName,
+			"attempts":  strconv.Itoa(prState.ConflictAttempts),
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// untrackPR drops the PR's in-memory state and returns its branch.
+func (c *Controller) untrackPR(prNumber int) (branchName string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	prState, ok := c.activePRs[prNumber]
-	var branchName string
 	if ok {
 		branchName = prState.BranchName
 		// GH-862: Clean up discovery state for this PR's SHA
@@ -1425,7 +2534,27 @@ func (c *Controller) removePR(prNumber int) {
 		delete(c.activePRs, prNumber)
 	}
 	delete(c.prFailures, prNumber)
-	c.mu.Unlock()
+	delete(c.resolutions, prNumber)
+	delete(c.reviewFixes, prNumber)
+	delete(c.reviews, prNumber)
+	return branchName, ok
+}
+
+// Release stops tracking a PR without touching it or its branch, e.g. when
+// a human takes the PR over. Returns false if the PR was not tracked.
+func (c *Controller) Release(prNumber int) bool {
+	if _, ok := c.untrackPR(prNumber); !ok {
+		return false
+	}
+	c.persistRemovePR(prNumber)
+	c.removePRFailures(prNumber)
+	c.log.Info("PR released from tracking", "pr", prNumber)
+	return true
+}
+
+// removePR removes PR from tracking and cleans up the remote branch.
+func (c *Controller) removePR(prNumber int) {
+	branchName, _ := c.untrackPR(prNumber)
 
 	// Clean up remote branch for closed/failed PRs (merged PRs already handled in handleMerging)
 	if branchName != "" && c.ghClient != nil {
@@ -1599,6 +2728,11 @@ func (c *Controller) Config() *Config {
 	return c.config
 }
 
+// Repository returns the "owner/repo" this controller manages.
+func (c *Controller) Repository() string {
+	return c.owner + "/" + c.repo
+}
+
 // GetPRFailures returns the current failure count for a specific PR.
 func (c *Controller) GetPRFailures(prNumber int) int {
 	c.mu.RLock()
@@ -1769,6 +2903,11 @@ func (c *Controller) ScanRecentlyMergedPRs(ctx context.Context) error {
 			continue
 		}
 
+		// Canary merges are released when promoted, not when merged
+		if c.canaryEnabled() && pr.Base.Ref == c.config.Canary.ResolvedBranch() {
+			continue
+		}
+
 		// Check if merged within scan window
 		// MergedAt is RFC3339 format string
 		if pr.MergedAt == "" {
@@ -1848,6 +2987,10 @@ func (c *Controller) Run(ctx context.Context) error {
 		"release_enabled", c.resolvedRelease() != nil && c.resolvedRelease().Enabled,
 	)
 
+	if c.releaseTrainEnabled() {
+		go c.runReleaseTrains(ctx)
+	}
+
 	// Dynamic poll interval settings
 	basePollInterval := c.config.CIPollInterval
 	fastPollInterval := 10 * time.Second
@@ -1862,8 +3005,13 @@ func (c *Controller) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			c.log.Info("autopilot controller stopping")
 			return ctx.Err()
+		case <-c.wake:
+			c.processAllPRs(ctx)
 		case <-ticker.C:
 			c.processAllPRs(ctx)
+			if c.pipeline != nil {
+				c.advancePipeline(ctx)
+			}
 
 			// Adjust interval based on active PR states
 			newInterval := idlePollInterval
@@ -1889,6 +3037,16 @@ func (c *Controller) Run(ctx context.Context) error {
 	}
 }
 
+// Wake processes active PRs now instead of at the next poll, e.g. when a
+// webhook reports a finished check run. Wake requests made while PRs are
+// being processed coalesce into one.
+func (c *Controller) Wake() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
 // processAllPRs processes all active PRs in one iteration.
 func (c *Controller) processAllPRs(ctx context.Context) {
 	prs := c.GetActivePRs()
@@ -1927,7 +3085,7 @@ func (c *Controller) processAllPRs(ctx context.Context) {
 
 			// Detect changes_requested reviews in polling mode (webhook mode uses OnReviewRequested).
 			// Only check PRs that haven't already been transitioned to review_requested.
-			if pr.Stage != StageReviewRequested && pr.Stage != StageFailed &&
+			if pr.Stage != StageReviewRequested && pr.Stage != StageFixingReview && pr.Stage != StageFailed &&
 				c.config.ReviewFeedback != nil && c.config.ReviewFeedback.Enabled {
 				if c.hasChangesRequested(ctx, pr) {
 					c.log.Info("detected changes_requested review in polling mode",
@@ -1935,9 +3093,11 @@ func (c *Controller) processAllPRs(ctx context.Context) {
 						"stage", pr.Stage,
 					)
 					c.mu.Lock()
+					previousStage := pr.Stage
 					pr.Stage = StageReviewRequested
 					c.mu.Unlock()
 					c.persistPRState(pr)
+					c.runStageHooks(pr, previousStage)
 				}
 			}
 
@@ -1953,6 +3113,14 @@ func (c *Controller) processAllPRs(ctx context.Context) {
 // Returns true if the PR was removed from tracking, false otherwise.
 // Accepts cached ghPR to avoid redundant API calls.
 func (c *Controller) checkExternalMergeOrClose(ctx context.Context, prState *PRState, ghPR *github.PullRequest) bool {
+	// Canary PRs were merged into the canary branch by autopilot itself
+	if prState.Stage == StageCanaryCI || prState.Stage == StageCanarySoak {
+		return false
+	}
+	// Merge queue merges are completed by handleMergeQueued
+	if prState.Stage == StageMergeQueued && ghPR.Merged {
+		return false
+	}
 
 	// Check if PR was merged externally
 	if ghPR.Merged {
@@ -1988,8 +3156,10 @@ func (c *Controller) checkExternalMergeOrClose(ctx context.Context, prState *PRS
 			if ghPR.MergeCommitSHA != "" {
 				prState.HeadSHA = ghPR.MergeCommitSHA
 			}
+			previousStage := prState.Stage
 			prState.Stage = StageReleasing
 			c.persistPRState(prState)
+			c.runStageHooks(prState, previousStage)
 			return false // Continue processing to handle release
 		}
 
@@ -2008,8 +3178,10 @@ func (c *Controller) checkExternalMergeOrClose(ctx context.Context, prState *PRS
 	return false
 }
 
-// notifyExternalMerge sends notification when a PR is merged externally.
+// notifyExternalMerge runs merge hooks and sends notification when a PR is merged externally.
 func (c *Controller) notifyExternalMerge(ctx context.Context, prState *PRState) {
+	c.runMergeHooks(ctx, prState)
+
 	if c.notifier == nil {
 		return
 	}
@@ -3,14 +3,18 @@ package autopilot
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/alerts"
 	"github.com/alekspetrov/pilot/internal/approval"
 	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/testutil"
@@ -43,6 +47,17 @@ func TestNewController(t *testing.T) {
 	}
 }
 
+func TestController_Wake(t *testing.T) {
+	c := NewController(DefaultConfig(), github.NewClient(testutil.FakeGitHubToken), nil, "owner", "repo")
+
+	// Wake requests coalesce and never block
+	c.Wake()
+	c.Wake()
+	if len(c.wake) != 1 {
+		t.Errorf("pending wake requests = %d, want 1", len(c.wake))
+	}
+}
+
 func TestController_OnPRCreated(t *testing.T) {
 	ghClient := github.NewClient(testutil.FakeGitHubToken)
 	cfg := DefaultConfig()
@@ -606,6 +621,31 @@ func TestController_RemovePR(t *testing.T) {
 	}
 }
 
+func TestController_Release(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := NewController(DefaultConfig(), github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	c.OnPRCreated(42, "url", 10, "sha", "pilot/GH-10", "")
+
+	if !c.Release(42) {
+		t.Fatal("Release() = false for a tracked PR")
+	}
+	if _, ok := c.GetPRState(42); ok {
+		t.Error("released PR should no longer be tracked")
+	}
+	if len(requests) != 0 {
+		t.Errorf("Release() should leave the PR and branch alone, made %v", requests)
+	}
+	if c.Release(42) {
+		t.Error("Release() = true for an untracked PR")
+	}
+}
+
 func TestController_SuccessResetsFailureCount(t *testing.T) {
 	// Successful processing should reset per-PR failures
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1070,6 +1110,44 @@ func TestController_CheckExternalMerge_WithNotifier(t *testing.T) {
 	}
 }
 
+func TestController_CheckExternalMerge_RunsMergeHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42":
+			resp := github.PullRequest{
+				Number:  42,
+				State:   "closed",
+				Merged:  true,
+				HTMLURL: "https://github.com/owner/repo/pull/42",
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.CIPollInterval = 10 * time.Millisecond
+
+	c := NewController(cfg, ghClient, nil, "owner", "repo")
+
+	var branches []string
+	c.AddMergeHook(func(_ context.Context, prState *PRState) {
+		branches = append(branches, prState.BranchName)
+	})
+
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 0, "abc123", "pilot/TRELLO-aB3dE5gH", "")
+	c.processAllPRs(context.Background())
+
+	if len(branches) != 1 || branches[0] != "pilot/TRELLO-aB3dE5gH" {
+		t.Errorf("merge hook calls = %v, want one call with the PR branch", branches)
+	}
+}
+
 // GH-1486: Test that external merge closes the associated issue
 func TestController_CheckExternalMerge_ClosesIssue(t *testing.T) {
 	var (
@@ -2752,6 +2830,23 @@ func TestController_ShouldTriggerRelease(t *testing.T) {
 	}
 }
 
+func TestController_ShouldTriggerRelease_ReleaseGate(t *testing.T) {
+	ghClient := github.NewClient(testutil.FakeGitHubToken)
+	cfg := DefaultConfig()
+	cfg.Release = &ReleaseConfig{Enabled: true, Trigger: "on_merge"}
+
+	allowed := false
+	c := NewController(cfg, ghClient, nil, "owner", "repo", WithReleaseGate(func() bool { return allowed }))
+	if c.shouldTriggerRelease() {
+		t.Error("shouldTriggerRelease() = true, want false when gate denies")
+	}
+
+	allowed = true
+	if !c.shouldTriggerRelease() {
+		t.Error("shouldTriggerRelease() = false, want true when gate allows")
+	}
+}
+
 func TestController_ResolvedRelease(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -3037,6 +3132,111 @@ func TestController_handleMergeConflict_AutoRebaseFails(t *testing.T) {
 	}
 }
 
+// fakeConflictResolver records resolution passes and fails them with err.
+type fakeConflictResolver struct {
+	mu   sync.Mutex
+	reqs []ConflictRequest
+	err  error
+}
+
+func (f *fakeConflictResolver) ResolveConflicts(ctx context.Context, req ConflictRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reqs = append(f.reqs, req)
+	return f.err
+}
+
+func TestController_handleMergeConflict_ResolutionPass(t *testing.T) {
+	prClosed := false
+	mergeable := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/owner/repo/pulls/42/merge":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "Pull Request is not mergeable"})
+		case r.URL.Path == "/repos/owner/repo/pulls/42/update-branch":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "merge conflict between base and head"})
+		case r.URL.Path == "/repos/owner/repo/pulls/42":
+			if r.Method == http.MethodPatch {
+				prClosed = true
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(github.PullRequest{
+				Number:         42,
+				State:          "open",
+				Mergeable:      &mergeable,
+				MergeableState: "dirty",
+				Head:           github.PRRef{Ref: "pilot/GH-10", SHA: "abc1234"},
+			})
+		case r.URL.Path == "/repos/owner/repo/issues/42/comments" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]int{"id": 1})
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.AutoReview = false
+	cfg.ConflictResolution = &ConflictResolutionConfig{Enabled: true, MaxAttempts: 1}
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	resolver := &fakeConflictResolver{err: errors.New("tests still fail")}
+	c.SetConflictResolver(resolver)
+	rec := &recordingAlerts{}
+	c.SetAlertProcessor(rec)
+
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc1234", "pilot/GH-10", "")
+	prState, _ := c.GetPRState(42)
+	prState.Stage = StageMerging
+
+	ctx := context.Background()
+	if err := c.ProcessPR(ctx, 42, nil); err != nil {
+		t.Fatalf("ProcessPR returned error: %v", err)
+	}
+	if prState.Stage != StageResolvingConflicts || prState.ConflictAttempts != 1 {
+		t.Fatalf("stage = %s, attempts = %d, want resolving_conflicts after the first conflict", prState.Stage, prState.ConflictAttempts)
+	}
+	if prClosed {
+		t.Fatal("PR should stay open while conflicts are being resolved")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for prState.Stage == StageResolvingConflicts && time.Now().Before(deadline) {
+		if err := c.ProcessPR(ctx, 42, nil); err != nil {
+			t.Fatalf("ProcessPR returned error: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if prState.Stage != StageWaitingCI || prState.HeadSHA != "" {
+		t.Fatalf("stage = %s, head = %q, want waiting_ci with refreshed head", prState.Stage, prState.HeadSHA)
+	}
+	resolver.mu.Lock()
+	reqs := append([]ConflictRequest(nil), resolver.reqs...)
+	resolver.mu.Unlock()
+	if len(reqs) != 1 || reqs[0].Branch != "pilot/GH-10" || reqs[0].IssueNumber != 10 || reqs[0].Attempt != 1 {
+		t.Errorf("resolver requests = %+v", reqs)
+	}
+
+	// The branch still conflicts and the only attempt is used up
+	prState.Stage = StageMerging
+	if err := c.ProcessPR(ctx, 42, nil); err != nil {
+		t.Fatalf("ProcessPR returned error: %v", err)
+	}
+	if prState.Stage != StageFailed || !prClosed {
+		t.Errorf("stage = %s, closed = %v, want the PR closed once attempts are used up", prState.Stage, prClosed)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != alerts.EventTypeConflictUnresolved {
+		t.Fatalf("alerts = %+v, want one conflict_unresolved event", rec.events)
+	}
+	if ev := rec.events[0]; ev.Metadata["attempts"] != "1" || ev.Metadata["branch"] != "pilot/GH-10" || !strings.Contains(ev.Error, "tests still fail") {
+		t.Errorf("event = %+v", ev)
+	}
+}
+
 // newTestLearningLoop creates a LearningLoop backed by a temp SQLite store for testing.
 // The store is returned so the caller can close and clean it up.
 func newTestLearningLoop(t *testing.T) (*memory.LearningLoop, func()) {
@@ -3346,6 +3546,101 @@ func TestHandlePostMergeCI_LearnsFromCIFailure(t *testing.T) {
 	}
 }
 
+// recordingAlerts captures events sent to the alerts engine.
+type recordingAlerts struct {
+	events []alerts.Event
+}
+
+func (r *recordingAlerts) ProcessEvent(event alerts.Event) {
+	r.events = append(r.events, event)
+}
+
+// TestHandlePostMergeCI_Rollback verifies that a post-merge CI failure on the
+// merge commit opens a revert PR and alerts instead of filing a fix issue,
+// unless the failure falls outside the rollback window.
+func TestHandlePostMergeCI_Rollback(t *testing.T) {
+	tests := []struct {
+		name         string
+		mergedAt     time.Time
+		wantRevert   bool
+		wantFixIssue bool
+	}{
+		{name: "within window", mergedAt: time.Now().Add(-5 * time.Minute), wantRevert: true},
+		{name: "outside window", mergedAt: time.Now().Add(-2 * time.Hour), wantFixIssue: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reverted, issueCreated, commented bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/repos/owner/repo/pulls/45":
+					_, _ = w.Write(mustJSON(t, github.PullRequest{
+						Number:         45,
+						NodeID:         "PR_45",
+						Title:          "feat: add widget",
+						MergeCommitSHA: "merge45sha",
+						MergedAt:       tt.mergedAt.UTC().Format(time.RFC3339),
+					}))
+				case r.URL.Path == "/repos/owner/repo/commits/merge45sha/check-runs":
+					_, _ = w.Write(mustJSON(t, github.CheckRunsResponse{
+						TotalCount: 1,
+						CheckRuns:  []github.CheckRun{{Name: "e2e", Status: "completed", Conclusion: "failure"}},
+					}))
+				case r.URL.Path == "/graphql":
+					reverted = true
+					_, _ = w.Write([]byte(`{"data": {"revertPullRequest": {"revertPullRequest": {"number": 46, "url": "https://github.com/owner/repo/pull/46"}}}}`))
+				case r.URL.Path == "/repos/owner/repo/issues/45/comments" && r.Method == http.MethodPost:
+					commented = true
+					_, _ = w.Write([]byte("{}"))
+				case r.URL.Path == "/repos/owner/repo/issues" && r.Method == http.MethodPost:
+					issueCreated = true
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write(mustJSON(t, github.Issue{Number: 301}))
+				default:
+					_, _ = w.Write([]byte("{}"))
+				}
+			}))
+			defer server.Close()
+
+			cfg := DefaultConfig()
+			cfg.Environment = EnvDev
+			cfg.CIPollInterval = 10 * time.Millisecond
+			cfg.CIWaitTimeout = time.Second
+			cfg.RequiredChecks = []string{"e2e"}
+			cfg.Rollback = &RollbackConfig{Enabled: true, Window: 30 * time.Minute}
+
+			c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+			recorder := &recordingAlerts{}
+			c.SetAlertProcessor(recorder)
+
+			prState := &PRState{PRNumber: 45, Stage: StagePostMergeCI}
+			if err := c.handlePostMergeCI(context.Background(), prState); err != nil {
+				t.Fatalf("handlePostMergeCI returned unexpected error: %v", err)
+			}
+
+			if reverted != tt.wantRevert || commented != tt.wantRevert {
+				t.Errorf("reverted = %v, commented = %v, want %v", reverted, commented, tt.wantRevert)
+			}
+			if issueCreated != tt.wantFixIssue {
+				t.Errorf("fix issue created = %v, want %v", issueCreated, tt.wantFixIssue)
+			}
+			if !tt.wantRevert {
+				if len(recorder.events) != 0 {
+					t.Errorf("unexpected alerts: %+v", recorder.events)
+				}
+				return
+			}
+			if len(recorder.events) != 1 {
+				t.Fatalf("expected 1 alert event, got %d", len(recorder.events))
+			}
+			event := recorder.events[0]
+			if event.Type != alerts.EventTypeAutopilotRollback || event.Metadata["revert_pr_url"] != "https://github.com/owner/repo/pull/46" || event.Metadata["failed_checks"] != "e2e" {
+				t.Errorf("alert event = %+v", event)
+			}
+		})
+	}
+}
+
 // TestHandleCIFailed_EmptyLogs_SkipsLearning verifies that handleCIFailed skips
 // LearnFromCIFailure when CI logs are empty or whitespace-only (GH-1979).
 // TestHandleCIFailed_EmptyLogs_SkipsLearning verifies that handleCIFailed skips
@@ -3544,13 +3839,13 @@ func TestController_HandleReviewRequested_CreatesIssue(t *testing.T) {
 		switch {
 		case r.URL.Path == "/repos/owner/repo/pulls/42/reviews":
 			resp := []*github.PullRequestReview{
-				{ID: 1, User: github.User{Login: "alice"}, Body: "Fix the nil check", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T10:00:00Z"},
+				{ID: 1, User: github.User{Login: "alice"}, AuthorAssociation: "MEMBER", Body: "Fix the nil check", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T10:00:00Z"},
 			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write(mustJSON(t, resp))
 		case r.URL.Path == "/repos/owner/repo/pulls/42/comments":
 			resp := []*github.PRReviewComment{
-				{ID: 10, Body: "Add error handling", Path: "foo.go", Line: 5, User: github.User{Login: "alice"}},
+				{ID: 10, Body: "Add error handling", Path: "foo.go", Line: 5, User: github.User{Login: "alice"}, AuthorAssociation: "MEMBER"},
 			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write(mustJSON(t, resp))
@@ -3623,7 +3918,7 @@ func TestController_HandleReviewRequested_IterationLimit(t *testing.T) {
 		switch {
 		case r.URL.Path == "/repos/owner/repo/pulls/42/reviews":
 			resp := []*github.PullRequestReview{
-				{ID: 1, User: github.User{Login: "alice"}, Body: "Still broken", State: "CHANGES_REQUESTED"},
+				{ID: 1, User: github.User{Login: "alice"}, AuthorAssociation: "MEMBER", Body: "Still broken", State: "CHANGES_REQUESTED"},
 			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write(mustJSON(t, resp))
@@ -3675,6 +3970,197 @@ func TestController_HandleReviewRequested_IterationLimit(t *testing.T) {
 	}
 }
 
+// fakeReviewFixer records review fix passes and fails them with err.
+type fakeReviewFixer struct {
+	mu   sync.Mutex
+	reqs []ReviewFixRequest
+	err  error
+}
+
+func (f *fakeReviewFixer) FixReview(ctx context.Context, req ReviewFixRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reqs = append(f.reqs, req)
+	return f.err
+}
+
+func TestController_HandleReviewRequested_SameBranch(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	var prComments []string
+	var laterReviews []*github.PullRequestReview
+	prClosed, issueCreated := false, false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/repos/owner/repo/pulls/42/reviews":
+			resp := []*github.PullRequestReview{
+				{ID: 1, User: github.User{Login: "bob"}, AuthorAssociation: "MEMBER", Body: "Outdated concern", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T08:00:00Z"},
+				{ID: 2, User: github.User{Login: "alice"}, AuthorAssociation: "MEMBER", Body: "Fix the nil check", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T10:00:00Z"},
+				{ID: 3, User: github.User{Login: "pilot[bot]"}, Body: "Self-review", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T10:00:00Z"},
+			}
+			_, _ = w.Write(mustJSON(t, append(resp, laterReviews...)))
+		case r.URL.Path == "/repos/owner/repo/pulls/42/comments":
+			resp := []*github.PRReviewComment{
+				{ID: 10, Body: "Add error handling", Path: "foo.go", Line: 5, User: github.User{Login: "alice"}, AuthorAssociation: "MEMBER", CreatedAt: "2026-03-05T10:00:00Z"},
+			}
+			_, _ = w.Write(mustJSON(t, resp))
+		case r.URL.Path == "/repos/owner/repo/pulls/42/requested_reviewers" && r.Method == http.MethodPost:
+			var body map[string][]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			requested = append(requested, body["reviewers"]...)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("{}"))
+		case r.URL.Path == "/repos/owner/repo/pulls/42" && r.Method == http.MethodPatch:
+			prClosed = true
+			_, _ = w.Write(mustJSON(t, github.PullRequest{Number: 42, State: "closed"}))
+		case r.URL.Path == "/repos/owner/repo/issues" && r.Method == http.MethodPost:
+			issueCreated = true
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(mustJSON(t, github.Issue{Number: 100}))
+		case r.URL.Path == "/repos/owner/repo/issues/42/comments" && r.Method == http.MethodPost:
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			prComments = append(prComments, body["body"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.ReviewFeedback = &ReviewFeedbackConfig{Enabled: true, MaxIterations: 1, SameBranch: true}
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	fixer := &fakeReviewFixer{}
+	c.SetReviewFixer(fixer)
+
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc123", "pilot/GH-10", "")
+	prState, _ := c.GetPRState(42)
+	prState.CreatedAt = time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	prState.Stage = StageReviewRequested
+
+	ctx := context.Background()
+	if err := c.ProcessPR(ctx, 42, nil); err != nil {
+		t.Fatalf("ProcessPR error: %v", err)
+	}
+	if prState.Stage != StageFixingReview || prState.ReviewFixes != 1 {
+		t.Fatalf("stage = %s, fixes = %d, want fixing_review after the first review", prState.Stage, prState.ReviewFixes)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for prState.Stage == StageFixingReview && time.Now().Before(deadline) {
+		if err := c.ProcessPR(ctx, 42, nil); err != nil {
+			t.Fatalf("ProcessPR error: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if prState.Stage != StageWaitingCI || prState.HeadSHA != "" || prState.ReviewsAddressedAt.IsZero() {
+		t.Fatalf("stage = %s, head = %q, addressed = %v, want waiting_ci after the fix was pushed", prState.Stage, prState.HeadSHA, prState.ReviewsAddressedAt)
+	}
+
+	fixer.mu.Lock()
+	reqs := append([]ReviewFixRequest(nil), fixer.reqs...)
+	fixer.mu.Unlock()
+	if len(reqs) != 1 || reqs[0].Branch != "pilot/GH-10" || reqs[0].Iteration != 1 {
+		t.Fatalf("fix requests = %+v", reqs)
+	}
+	if fb := reqs[0].Feedback; !strings.Contains(fb, "Fix the nil check") || !strings.Contains(fb, "Add error handling") ||
+		strings.Contains(fb, "Outdated concern") || strings.Contains(fb, "Self-review") {
+		t.Errorf("feedback should hold only the human reviews since the PR was created:\n%s", fb)
+	}
+
+	mu.Lock()
+	if len(requested) != 1 || requested[0] != "alice" {
+		t.Errorf("re-requested review from %v, want [alice]", requested)
+	}
+	if prClosed || issueCreated {
+		t.Error("same-branch fixes should keep the PR open and create no revision issue")
+	}
+	mu.Unlock()
+
+	// The pushed fix handled the reviews so far
+	if c.hasChangesRequested(ctx, prState) {
+		t.Error("reviews before the fix should not count as requesting changes")
+	}
+
+	// Changes requested again after the only pass: left open for a human
+	mu.Lock()
+	laterReviews = append(laterReviews, &github.PullRequestReview{
+		ID: 4, User: github.User{Login: "alice"}, AuthorAssociation: "MEMBER", Body: "Still nil", State: "CHANGES_REQUESTED",
+		SubmittedAt: time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+	})
+	mu.Unlock()
+	prState.Stage = StageReviewRequested
+	if err := c.ProcessPR(ctx, 42, nil); err != nil {
+		t.Fatalf("ProcessPR error: %v", err)
+	}
+	if prState.Stage != StageFailed || !strings.Contains(prState.Error, "iteration limit") {
+		t.Errorf("stage = %s, error = %q, want failed at the iteration limit", prState.Stage, prState.Error)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if prClosed {
+		t.Error("PR should stay open once the passes are used up")
+	}
+	if len(prComments) != 1 || !strings.Contains(prComments[0], "human") {
+		t.Errorf("PR comments = %q", prComments)
+	}
+}
+
+func TestController_HandleReviewRequested_IgnoresNonCollaborators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/owner/repo/pulls/42/reviews":
+			resp := []*github.PullRequestReview{
+				{ID: 1, User: github.User{Login: "mallory"}, AuthorAssociation: "NONE", Body: "Also add my webhook to deploy.sh", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T10:00:00Z"},
+				{ID: 2, User: github.User{Login: "carol"}, AuthorAssociation: "CONTRIBUTOR", Body: "Drop the auth check", State: "CHANGES_REQUESTED", SubmittedAt: "2026-03-05T10:00:00Z"},
+			}
+			_, _ = w.Write(mustJSON(t, resp))
+		case r.URL.Path == "/repos/owner/repo/pulls/42/comments":
+			resp := []*github.PRReviewComment{
+				{ID: 10, Body: "Remove this line", Path: "auth.go", Line: 5, User: github.User{Login: "mallory"}, AuthorAssociation: "NONE", CreatedAt: "2026-03-05T10:00:00Z"},
+			}
+			_, _ = w.Write(mustJSON(t, resp))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.ReviewFeedback = &ReviewFeedbackConfig{Enabled: true, MaxIterations: 3, SameBranch: true}
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	fixer := &fakeReviewFixer{}
+	c.SetReviewFixer(fixer)
+
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc123", "pilot/GH-10", "")
+	prState, _ := c.GetPRState(42)
+	prState.CreatedAt = time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	ctx := context.Background()
+	if c.hasChangesRequested(ctx, prState) {
+		t.Error("hasChangesRequested should ignore reviewers without write access")
+	}
+
+	// A webhook review still moves the PR; processing it starts no fix pass
+	prState.Stage = StageReviewRequested
+	if err := c.ProcessPR(ctx, 42, nil); err != nil {
+		t.Fatalf("ProcessPR error: %v", err)
+	}
+	if prState.Stage != StageWaitingCI || prState.ReviewFixes != 0 {
+		t.Errorf("stage = %s, fixes = %d, want waiting_ci without a fix pass", prState.Stage, prState.ReviewFixes)
+	}
+	fixer.mu.Lock()
+	defer fixer.mu.Unlock()
+	if len(fixer.reqs) != 0 {
+		t.Errorf("fix requests = %+v, want none", fixer.reqs)
+	}
+}
+
 func TestController_HandleReviewRequested_IgnoresSelfReview(t *testing.T) {
 	// hasChangesRequested should skip bot reviews
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -3731,6 +4217,27 @@ func TestController_HandleReviewRequested_DisabledByConfig(t *testing.T) {
 	}
 }
 
+func TestController_StageHooks(t *testing.T) {
+	ghClient := github.NewClient(testutil.FakeGitHubToken)
+	cfg := DefaultConfig()
+	cfg.ReviewFeedback = &ReviewFeedbackConfig{Enabled: true, MaxIterations: 3}
+
+	c := NewController(cfg, ghClient, nil, "owner", "repo")
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc123", "pilot/GH-10", "")
+
+	var transitions []string
+	c.AddStageHook(func(prState *PRState, from PRStage) {
+		transitions = append(transitions, fmt.Sprintf("%d:%s->%s", prState.PRNumber, from, prState.Stage))
+	})
+
+	c.OnReviewRequested(42, "submitted", "changes_requested", "alice")
+
+	want := []string{"42:pr_created->review_requested"}
+	if fmt.Sprint(transitions) != fmt.Sprint(want) {
+		t.Errorf("stage hook calls = %v, want %v", transitions, want)
+	}
+}
+
 func TestController_OnReviewRequested_UntrackedPR(t *testing.T) {
 	ghClient := github.NewClient(testutil.FakeGitHubToken)
 	cfg := DefaultConfig()
@@ -3754,11 +4261,12 @@ func TestController_HasChangesRequested_FilterByTime(t *testing.T) {
 		case r.URL.Path == "/repos/owner/repo/pulls/42/reviews":
 			resp := []*github.PullRequestReview{
 				{
-					ID:          1,
-					User:        github.User{Login: "alice"},
-					Body:        "Old review",
-					State:       "CHANGES_REQUESTED",
-					SubmittedAt: "2026-03-01T10:00:00Z", // Before PR creation
+					ID:                1,
+					User:              github.User{Login: "alice"},
+					AuthorAssociation: "MEMBER",
+					Body:              "Old review",
+					State:             "CHANGES_REQUESTED",
+					SubmittedAt:       "2026-03-01T10:00:00Z", // Before PR creation
 				},
 			}
 			w.WriteHeader(http.StatusOK)
@@ -3953,3 +4461,344 @@ func TestMaybeCloseParentIssue(t *testing.T) {
 		})
 	}
 }
+
+func TestCanaryMerge_PromotesAfterSoak(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/owner/repo/branches/main":
+			_, _ = w.Write(mustJSON(t, github.Branch{Name: "main", Commit: github.BranchCommit{SHA: "mainsha"}}))
+		case r.URL.Path == "/repos/owner/repo/branches/canary":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Branch not found"}`))
+		case r.URL.Path == "/repos/owner/repo/pulls/45" && r.Method == http.MethodGet:
+			_, _ = w.Write(mustJSON(t, github.PullRequest{Number: 45, Merged: true, MergeCommitSHA: "canary45"}))
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			_, _ = w.Write(mustJSON(t, github.CheckRunsResponse{
+				TotalCount: 1,
+				CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: "success"}},
+			}))
+		default:
+			if r.Method != http.MethodGet {
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body))
+			}
+			_, _ = w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.AutoReview = false
+	cfg.RequiredChecks = []string{"build"}
+	cfg.Canary = &CanaryConfig{Enabled: true, SoakTime: time.Hour}
+
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	prState := &PRState{PRNumber: 45, HeadSHA: "head45", Stage: StageMerging, TargetBranch: "main"}
+	c.activePRs[45] = prState
+	ctx := context.Background()
+
+	if err := c.handleMerging(ctx, prState); err != nil {
+		t.Fatalf("handleMerging: %v", err)
+	}
+	if prState.Stage != StageCanaryCI {
+		t.Fatalf("stage after merge = %s, want %s", prState.Stage, StageCanaryCI)
+	}
+	want := []string{
+		"PATCH /repos/owner/repo/git/refs/heads/canary map[force:true sha:mainsha]",
+		"PATCH /repos/owner/repo/pulls/45 map[base:canary]",
+	}
+	for i, w := range want {
+		if i >= len(requests) || requests[i] != w {
+			t.Fatalf("requests = %v, want prefix %v", requests, want)
+		}
+	}
+
+	if err := c.handleCanaryCI(ctx, prState); err != nil {
+		t.Fatalf("handleCanaryCI: %v", err)
+	}
+	if prState.Stage != StageCanarySoak || prState.CanarySHA != "canary45" {
+		t.Fatalf("after canary CI stage = %s, sha = %s", prState.Stage, prState.CanarySHA)
+	}
+
+	// Still soaking: main is not touched
+	requests = nil
+	if err := c.handleCanarySoak(ctx, prState); err != nil {
+		t.Fatalf("handleCanarySoak: %v", err)
+	}
+	if prState.Stage != StageCanarySoak || len(requests) != 0 {
+		t.Fatalf("during soak stage = %s, requests = %v", prState.Stage, requests)
+	}
+
+	prState.CanaryGreenAt = time.Now().Add(-2 * time.Hour)
+	if err := c.handleCanarySoak(ctx, prState); err != nil {
+		t.Fatalf("handleCanarySoak: %v", err)
+	}
+	if prState.Stage != StageMerged || prState.HeadSHA != "canary45" {
+		t.Errorf("after soak stage = %s, head = %s", prState.Stage, prState.HeadSHA)
+	}
+	if len(requests) == 0 || requests[0] != "PATCH /repos/owner/repo/git/refs/heads/main map[force:false sha:canary45]" {
+		t.Errorf("promotion requests = %v", requests)
+	}
+}
+
+func TestCanaryMerge_FailedCIIsNotPromoted(t *testing.T) {
+	var promoted, issueCreated bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			_, _ = w.Write(mustJSON(t, github.CheckRunsResponse{
+				TotalCount: 1,
+				CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: "failure"}},
+			}))
+		case strings.HasPrefix(r.URL.Path, "/repos/owner/repo/git/refs/"):
+			promoted = true
+			_, _ = w.Write([]byte("{}"))
+		case r.URL.Path == "/repos/owner/repo/issues" && r.Method == http.MethodPost:
+			issueCreated = true
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(mustJSON(t, github.Issue{Number: 302}))
+		default:
+			_, _ = w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.RequiredChecks = []string{"build"}
+	cfg.Canary = &CanaryConfig{Enabled: true}
+
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	prState := &PRState{PRNumber: 45, Stage: StageCanarySoak, CanarySHA: "canary45", CanaryGreenAt: time.Now().Add(-3 * time.Hour)}
+	c.activePRs[45] = prState
+
+	if err := c.handleCanarySoak(context.Background(), prState); err != nil {
+		t.Fatalf("handleCanarySoak: %v", err)
+	}
+	if promoted {
+		t.Error("main was fast-forwarded to a red canary commit")
+	}
+	if !issueCreated {
+		t.Error("expected a fix issue for the canary failure")
+	}
+	if _, tracked := c.GetPRState(45); tracked {
+		t.Error("failed canary PR should no longer be tracked")
+	}
+}
+
+// mergeQueueConfig returns a config whose active environment merges through
+// the merge queue.
+func mergeQueueConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.AutoReview = false
+	cfg.RequiredChecks = []string{"build"}
+	cfg.Environments = map[string]*EnvironmentConfig{
+		"queue": {Branch: "main", MergeQueue: true, SkipPostMergeCI: true},
+	}
+	if err := cfg.SetActiveEnvironment("queue"); err != nil {
+		t.Fatalf("SetActiveEnvironment: %v", err)
+	}
+	return cfg
+}
+
+func TestMergeQueue_EnqueuesAndFollowsQueue(t *testing.T) {
+	var merged, directMerge bool
+	var entry string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/graphql":
+			var req github.GraphQLRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if strings.Contains(req.Query, "enqueuePullRequest") {
+				if req.Variables["head"] != "head46" {
+					t.Errorf("enqueue expected head = %v, want head46", req.Variables["head"])
+				}
+				_, _ = w.Write([]byte(`{"data": {"enqueuePullRequest": {"mergeQueueEntry": {"position": 3, "state": "QUEUED"}}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"mergeQueueEntry": ` + entry + `}}}}`))
+		case r.URL.Path == "/repos/owner/repo/pulls/46/merge":
+			directMerge = true
+			_, _ = w.Write([]byte(`{"merged": true}`))
+		case r.URL.Path == "/repos/owner/repo/pulls/46" && r.Method == http.MethodGet:
+			_, _ = w.Write(mustJSON(t, github.PullRequest{Number: 46, NodeID: "PR_46", State: "open", Merged: merged, MergeCommitSHA: "merge46", Head: github.PRRef{SHA: "head46"}}))
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			_, _ = w.Write(mustJSON(t, github.CheckRunsResponse{
+				TotalCount: 1,
+				CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: "success"}},
+			}))
+		default:
+			_, _ = w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	c := NewController(mergeQueueConfig(t), github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	prState := &PRState{PRNumber: 46, HeadSHA: "head46", Stage: StageMerging, TargetBranch: "main"}
+	c.activePRs[46] = prState
+	ctx := context.Background()
+
+	if err := c.handleMerging(ctx, prState); err != nil {
+		t.Fatalf("handleMerging: %v", err)
+	}
+	if directMerge {
+		t.Fatal("PR was merged directly instead of through the merge queue")
+	}
+	if prState.Stage != StageMergeQueued || prState.MergeQueuePosition != 3 {
+		t.Fatalf("after enqueue stage = %s, position = %d", prState.Stage, prState.MergeQueuePosition)
+	}
+
+	entry = `{"position": 1, "state": "AWAITING_CHECKS", "headCommit": {"oid": "group46"}}`
+	if err := c.ProcessPR(ctx, 46, nil); err != nil {
+		t.Fatalf("ProcessPR: %v", err)
+	}
+	if prState.Stage != StageMergeQueued || prState.MergeQueuePosition != 1 || prState.MergeQueueSHA != "group46" {
+		t.Fatalf("while queued stage = %s, position = %d, group = %s", prState.Stage, prState.MergeQueuePosition, prState.MergeQueueSHA)
+	}
+
+	merged = true
+	entry = "null"
+	ghPR, _ := c.ghClient.GetPullRequest(ctx, "owner", "repo", 46)
+	if c.checkExternalMergeOrClose(ctx, prState, ghPR) {
+		t.Fatal("merge queue merge was treated as an external merge")
+	}
+	if err := c.ProcessPR(ctx, 46, ghPR); err != nil {
+		t.Fatalf("ProcessPR: %v", err)
+	}
+	if prState.Stage != StageMerged || prState.HeadSHA != "merge46" {
+		t.Errorf("after queue merge stage = %s, head = %s", prState.Stage, prState.HeadSHA)
+	}
+}
+
+func TestMergeQueue_Dequeued(t *testing.T) {
+	tests := []struct {
+		name        string
+		head        string
+		groupCheck  string
+		wantStage   PRStage
+		wantIssue   bool
+		wantTracked bool
+	}{
+		{
+			name:        "new commits go back to CI",
+			head:        "head47b",
+			groupCheck:  "success",
+			wantStage:   StageWaitingCI,
+			wantTracked: true,
+		},
+		{
+			name:       "failed merge group opens fix issue",
+			head:       "head47",
+			groupCheck: "failure",
+			wantIssue:  true,
+		},
+		{
+			name:        "removed by hand fails the PR",
+			head:        "head47",
+			groupCheck:  "success",
+			wantStage:   StageFailed,
+			wantTracked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issueCreated bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/graphql":
+					_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"mergeQueueEntry": null}}}}`))
+				case strings.HasSuffix(r.URL.Path, "/check-runs"):
+					_, _ = w.Write(mustJSON(t, github.CheckRunsResponse{
+						TotalCount: 1,
+						CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: tt.groupCheck}},
+					}))
+				case r.URL.Path == "/repos/owner/repo/issues" && r.Method == http.MethodPost:
+					issueCreated = true
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write(mustJSON(t, github.Issue{Number: 303}))
+				default:
+					_, _ = w.Write([]byte("{}"))
+				}
+			}))
+			defer server.Close()
+
+			c := NewController(mergeQueueConfig(t), github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+			prState := &PRState{PRNumber: 47, HeadSHA: "head47", Stage: StageMergeQueued, MergeQueuePosition: 2, MergeQueueSHA: "group47"}
+			c.activePRs[47] = prState
+			ghPR := &github.PullRequest{Number: 47, State: "open", Head: github.PRRef{SHA: tt.head}}
+
+			if err := c.handleMergeQueued(context.Background(), prState, ghPR); err != nil {
+				t.Fatalf("handleMergeQueued: %v", err)
+			}
+			if issueCreated != tt.wantIssue {
+				t.Errorf("fix issue created = %v, want %v", issueCreated, tt.wantIssue)
+			}
+			if _, tracked := c.GetPRState(47); tracked != tt.wantTracked {
+				t.Fatalf("tracked = %v, want %v", tracked, tt.wantTracked)
+			}
+			if tt.wantTracked && prState.Stage != tt.wantStage {
+				t.Errorf("stage = %s, want %s", prState.Stage, tt.wantStage)
+			}
+			if prState.MergeQueuePosition != 0 {
+				t.Errorf("position = %d after dequeue, want 0", prState.MergeQueuePosition)
+			}
+		})
+	}
+}
+
+func TestController_ProcessPR_DraftMarkedReadyOnGreen(t *testing.T) {
+	var mu sync.Mutex
+	draft, marked := true, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42":
+			_ = json.NewEncoder(w).Encode(github.PullRequest{
+				Number: 42,
+				NodeID: "PR_kwDO42",
+				Draft:  draft,
+				Head:   github.PRRef{Ref: "pilot/GH-10", SHA: "abc1234"},
+			})
+		case "/repos/owner/repo/commits/abc1234/check-runs":
+			_ = json.NewEncoder(w).Encode(github.CheckRunsResponse{
+				TotalCount: 1,
+				CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: "success"}},
+			})
+		case "/graphql":
+			marked++
+			draft = false
+			_, _ = w.Write([]byte(`{"data": {"markPullRequestReadyForReview": {"pullRequest": {"isDraft": false}}}}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Environment = EnvStage
+	cfg.AutoReview = false
+	cfg.RequiredChecks = []string{"build"}
+
+	c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc1234", "pilot/GH-10", "")
+	ctx := context.Background()
+
+	for _, want := range []PRStage{StageWaitingCI, StageMarkingReady, StageCIPassed} {
+		if err := c.ProcessPR(ctx, 42, nil); err != nil {
+			t.Fatalf("ProcessPR() error: %v", err)
+		}
+		if pr, _ := c.GetPRState(42); pr.Stage != want {
+			t.Fatalf("Stage = %s, want %s", pr.Stage, want)
+		}
+	}
+	if marked != 1 {
+		t.Errorf("ready-for-review mutation called %d times, want 1", marked)
+	}
+}
@@ -40,13 +40,25 @@ func NewDeployer(ghClient *github.Client, owner, repo string, config *PostMergeC
 }
 
 // deployWebhookPayload is the JSON body sent for webhook deploy actions.
+// Release promotions set Environment, Version and Tag instead of PRNumber.
 type deployWebhookPayload struct {
-	Action    string `json:"action"`
-	Repo      string `json:"repo"`
-	PRNumber  int    `json:"pr_number"`
-	HeadSHA   string `json:"head_sha"`
-	Branch    string `json:"branch"`
-	Timestamp string `json:"timestamp"`
+	Action      string `json:"action"`
+	Repo        string `json:"repo"`
+	PRNumber    int    `json:"pr_number"`
+	HeadSHA     string `json:"head_sha"`
+	Branch      string `json:"branch"`
+	Environment string `json:"environment,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// ReleaseDeployment describes a release tag being deployed to an environment.
+type ReleaseDeployment struct {
+	Environment string
+	Version     string
+	Tag         string
+	SHA         string
 }
 
 // Deploy executes the configured post-merge action for the given PR state.
@@ -62,29 +74,73 @@ func (d *Deployer) Deploy(ctx context.Context, prState *PRState) error {
 		return nil
 
 	case "webhook":
-		return d.deployWebhook(ctx, prState)
+		d.log.Info("sending deploy webhook",
+			"pr", prState.PRNumber,
+			"url", d.config.WebhookURL,
+		)
+		return d.sendWebhook(ctx, deployWebhookPayload{
+			Action:    "deploy",
+			Repo:      fmt.Sprintf("%s/%s", d.owner, d.repo),
+			PRNumber:  prState.PRNumber,
+			HeadSHA:   prState.HeadSHA,
+			Branch:    prState.TargetBranch,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		})
 
 	case "branch-push":
-		return d.deployBranchPush(ctx, prState)
+		d.log.Info("pushing to deploy branch",
+			"pr", prState.PRNumber,
+			"branch", d.config.DeployBranch,
+			"sha", ShortSHA(prState.HeadSHA),
+		)
+		return d.pushDeployBranch(ctx, prState.HeadSHA)
 
 	default:
 		return fmt.Errorf("unknown deploy action: %q", d.config.Action)
 	}
 }
 
-// deployWebhook sends an HTTP POST to the configured webhook URL with HMAC-SHA256 signing.
-func (d *Deployer) deployWebhook(ctx context.Context, prState *PRState) error {
-	if d.config.WebhookURL == "" {
-		return fmt.Errorf("webhook_url is required for webhook deploy action")
+// DeployRelease executes the configured action for a promoted release tag.
+// The "tag" action is a no-op: the promoter has already created the tag.
+func (d *Deployer) DeployRelease(ctx context.Context, rel ReleaseDeployment) error {
+	switch d.config.Action {
+	case "none", "", "tag":
+		d.log.Debug("no deploy action for release", "env", rel.Environment, "tag", rel.Tag)
+		return nil
+
+	case "webhook":
+		d.log.Info("sending release deploy webhook",
+			"env", rel.Environment,
+			"tag", rel.Tag,
+			"url", d.config.WebhookURL,
+		)
+		return d.sendWebhook(ctx, deployWebhookPayload{
+			Action:      "promote",
+			Repo:        fmt.Sprintf("%s/%s", d.owner, d.repo),
+			HeadSHA:     rel.SHA,
+			Environment: rel.Environment,
+			Version:     rel.Version,
+			Tag:         rel.Tag,
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		})
+
+	case "branch-push":
+		d.log.Info("pushing release to deploy branch",
+			"env", rel.Environment,
+			"tag", rel.Tag,
+			"branch", d.config.DeployBranch,
+		)
+		return d.pushDeployBranch(ctx, rel.SHA)
+
+	default:
+		return fmt.Errorf("unknown deploy action: %q", d.config.Action)
 	}
+}
 
-	payload := deployWebhookPayload{
-		Action:    "deploy",
-		Repo:      fmt.Sprintf("%s/%s", d.owner, d.repo),
-		PRNumber:  prState.PRNumber,
-		HeadSHA:   prState.HeadSHA,
-		Branch:    prState.TargetBranch,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+// sendWebhook sends an HTTP POST to the configured webhook URL with HMAC-SHA256 signing.
+func (d *Deployer) sendWebhook(ctx context.Context, payload deployWebhookPayload) error {
+	if d.config.WebhookURL == "" {
+		return fmt.Errorf("webhook_url is required for webhook deploy action")
 	}
 
 	body, err := json.Marshal(payload)
@@ -112,11 +168,6 @@ func (d *Deployer) deployWebhook(ctx context.Context, prState *PRState) error {
 		req.Header.Set("X-Hub-Signature-256", "sha256="+sig)
 	}
 
-	d.log.Info("sending deploy webhook",
-		"pr", prState.PRNumber,
-		"url", d.config.WebhookURL,
-	)
-
 	resp, err := d.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("webhook request failed: %w", err)
@@ -128,31 +179,25 @@ func (d *Deployer) deployWebhook(ctx context.Context, prState *PRState) error {
 	}
 
 	d.log.Info("deploy webhook sent successfully",
-		"pr", prState.PRNumber,
+		"action", payload.Action,
 		"status", resp.StatusCode,
 	)
 	return nil
 }
 
-// deployBranchPush updates (or creates) a deploy branch ref to point at the merged SHA.
-func (d *Deployer) deployBranchPush(ctx context.Context, prState *PRState) error {
+// pushDeployBranch updates (or creates) the deploy branch ref to point at sha.
+func (d *Deployer) pushDeployBranch(ctx context.Context, sha string) error {
 	if d.config.DeployBranch == "" {
 		return fmt.Errorf("deploy_branch is required for branch-push deploy action")
 	}
 
-	d.log.Info("pushing to deploy branch",
-		"pr", prState.PRNumber,
-		"branch", d.config.DeployBranch,
-		"sha", ShortSHA(prState.HeadSHA),
-	)
-
-	if err := d.ghClient.UpdateRef(ctx, d.owner, d.repo, d.config.DeployBranch, prState.HeadSHA); err != nil {
+	if err := d.ghClient.UpdateRef(ctx, d.owner, d.repo, d.config.DeployBranch, sha); err != nil {
 		return fmt.Errorf("failed to update deploy branch %q: %w", d.config.DeployBranch, err)
 	}
 
 	d.log.Info("deploy branch updated",
-		"pr", prState.PRNumber,
 		"branch", d.config.DeployBranch,
+		"sha", ShortSHA(sha),
 	)
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
 // FeedbackLoop creates issues when CI fails or bugs are detected.
@@ -19,6 +20,7 @@ type FeedbackLoop struct {
 	repo         string
 	issueLabels  []string
 	learningLoop *memory.LearningLoop // GH-1979: optional, annotates issues with known patterns
+	leakGuard    *quality.LeakGuard   // optional, redacts credentials from issue bodies
 	log          *slog.Logger
 }
 
@@ -38,6 +40,11 @@ func (f *FeedbackLoop) SetLearningLoop(ll *memory.LearningLoop) {
 	f.learningLoop = ll
 }
 
+// SetLeakGuard sets the guard that redacts credentials from fix issues.
+func (f *FeedbackLoop) SetLeakGuard(g *quality.LeakGuard) {
+	f.leakGuard = g
+}
+
 // FailureType categorizes the type of failure that occurred.
 type FailureType string
 
@@ -46,6 +53,15 @@ const (
 	FailureCIPreMerge FailureType = "ci_pre_merge"
 	// FailureCIPostMerge indicates CI failed after the PR was merged to main.
 	FailureCIPostMerge FailureType = "ci_post_merge"
+	// FailureCICanary indicates CI failed on the canary branch, so the PR was
+	// not promoted to the target branch.
+	FailureCICanary FailureType = "ci_canary"
+	// FailureMergeQueue indicates the merge queue's checks failed on the PR's
+	// merge group, so GitHub removed it from the queue.
+	FailureMergeQueue FailureType = "merge_queue"
+	// FailureCIPipeline indicates CI failed on the PR's change in an
+	// environment of the promotion pipeline, so it was not promoted further.
+	FailureCIPipeline FailureType = "ci_pipeline"
 	// FailureMerge indicates the PR could not be merged due to conflicts.
 	FailureMerge FailureType = "merge_conflict"
 	// FailureDeployment indicates deployment failed after merge.
@@ -60,6 +76,18 @@ const (
 // so downstream fix issues can inherit and increment the counter.
 // Returns the issue number on success.
 func (f *FeedbackLoop) CreateFailureIssue(ctx context.Context, prState *PRState, failureType FailureType, failedChecks []string, logs string, iteration int) (int, error) {
+	return f.createFailureIssue(ctx, prState, failureType, failedChecks, logs, nil, iteration)
+}
+
+// CreateCIFailureIssue creates a fix issue for a CI failure from the analysis
+// of the failed checks' logs. The issue names the failure class and shows
+// only the extracted error lines instead of raw runner output. A nil analysis
+// creates the issue without logs.
+func (f *FeedbackLoop) CreateCIFailureIssue(ctx context.Context, prState *PRState, failureType FailureType, failedChecks []string, analysis *CILogAnalysis, iteration int) (int, error) {
+	return f.createFailureIssue(ctx, prState, failureType, failedChecks, analysis.Excerpt(maxIssueLogLen), analysis, iteration)
+}
+
+func (f *FeedbackLoop) createFailureIssue(ctx context.Context, prState *PRState, failureType FailureType, failedChecks []string, logs string, analysis *CILogAnalysis, iteration int) (int, error) {
 	title := f.generateTitle(prState, failureType)
 
 	// GH-1979: Surface known patterns to annotate the fix issue body.
@@ -74,7 +102,11 @@ func (f *FeedbackLoop) CreateFailureIssue(ctx context.Context, prState *PRState,
 		}
 	}
 
-	body := f.generateBody(prState, failureType, failedChecks, logs, iteration, knownPatterns)
+	body := f.generateBody(prState, failureType, failedChecks, logs, analysis, iteration, knownPatterns)
+	if clean, found := f.leakGuard.Scrub(body); len(found) > 0 {
+		f.log.Warn("redacted secrets from fix issue", "pr", prState.PRNumber, "rules", found)
+		body = clean
+	}
 
 	input := &github.IssueInput{
 		Title:  title,
@@ -103,6 +135,12 @@ func (f *FeedbackLoop) generateTitle(prState *PRState, failureType FailureType)
 		return fmt.Sprintf("Fix CI failure from PR #%d", prState.PRNumber)
 	case FailureCIPostMerge:
 		return fmt.Sprintf("Fix post-merge CI failure (PR #%d)", prState.PRNumber)
+	case FailureCICanary:
+		return fmt.Sprintf("Fix canary CI failure (PR #%d)", prState.PRNumber)
+	case FailureMergeQueue:
+		return fmt.Sprintf("Fix merge queue failure (PR #%d)", prState.PRNumber)
+	case FailureCIPipeline:
+		return fmt.Sprintf("Fix pipeline CI failure (PR #%d)", prState.PRNumber)
 	case FailureMerge:
 		return fmt.Sprintf("Resolve merge conflict for PR #%d", prState.PRNumber)
 	case FailureDeployment:
@@ -114,8 +152,12 @@ func (f *FeedbackLoop) generateTitle(prState *PRState, failureType FailureType)
 	}
 }
 
-// generateBody creates a detailed issue body with context for Pilot.
-func (f *FeedbackLoop) generateBody(prState *PRState, failureType FailureType, failedChecks []string, logs string, iteration int, knownPatterns []*memory.CrossPattern) string {
+// maxIssueLogLen caps the CI output included in fix issues.
+const maxIssueLogLen = 2000
+
+// generateBody creates a detailed issue body with context for Pilot. When
+// analysis recognized errors, logs is its excerpt and is shown expanded.
+func (f *FeedbackLoop) generateBody(prState *PRState, failureType FailureType, failedChecks []string, logs string, analysis *CILogAnalysis, iteration int, knownPatterns []*memory.CrossPattern) string {
 	var sb strings.Builder
 
 	sb.WriteString("# Autopilot: Auto-Generated Fix Request\n\n")
@@ -127,6 +169,9 @@ func (f *FeedbackLoop) generateBody(prState *PRState, failureType FailureType, f
 		sb.WriteString(fmt.Sprintf("- **Original Issue**: #%d\n", prState.IssueNumber))
 	}
 	sb.WriteString(fmt.Sprintf("- **Failure Type**: %s\n", failureType))
+	if summary := analysis.Summary(); summary != "" {
+		sb.WriteString(fmt.Sprintf("- **Failure Class**: %s\n", summary))
+	}
 	if len(prState.HeadSHA) >= 7 {
 		sb.WriteString(fmt.Sprintf("- **SHA**: %s\n", prState.HeadSHA[:7]))
 	}
@@ -144,12 +189,18 @@ func (f *FeedbackLoop) generateBody(prState *PRState, failureType FailureType, f
 		sb.WriteString("\n")
 	}
 
-	// Error logs section in collapsible details block (GH-1567)
-	if logs != "" {
+	// Extracted errors are short enough to show expanded; raw logs go in a
+	// collapsible details block (GH-1567)
+	if analysis.HasErrors() && logs != "" {
+		sb.WriteString("## CI Errors\n\n")
+		sb.WriteString("```\n")
+		sb.WriteString(logs)
+		sb.WriteString("\n```\n\n")
+	} else if logs != "" {
 		sb.WriteString("<details><summary>CI Error Logs</summary>\n\n")
 		sb.WriteString("```\n")
-		if len(logs) > 2000 {
-			sb.WriteString(logs[:2000])
+		if len(logs) > maxIssueLogLen {
+			sb.WriteString(logs[:maxIssueLogLen])
 			sb.WriteString("\n... (truncated)")
 		} else {
 			sb.WriteString(logs)
@@ -175,6 +226,12 @@ func (f *FeedbackLoop) generateBody(prState *PRState, failureType FailureType, f
 		sb.WriteString("Fix the CI failures listed above. Run tests locally before committing.\n")
 	case FailureCIPostMerge:
 		sb.WriteString("The PR was merged but CI failed afterward. Investigate and fix.\n")
+	case FailureCICanary:
+		sb.WriteString("The PR was merged into the canary branch but CI failed there, so it was not promoted. Investigate and fix.\n")
+	case FailureMergeQueue:
+		sb.WriteString("The PR was removed from the merge queue because CI failed on its merge group with the PRs queued ahead of it. Investigate and fix.\n")
+	case FailureCIPipeline:
+		sb.WriteString("The PR was merged but CI failed on its change in a pipeline environment, so it was not promoted further. Investigate and fix.\n")
 	case FailureMerge:
 		sb.WriteString("Resolve the merge conflicts and ensure the changes integrate properly.\n")
 	case FailureDeployment:
@@ -1006,7 +1006,7 @@ func TestFeedbackLoop_GenerateBody_WithPatterns(t *testing.T) {
 		{Title: "Test timeout", Description: "Increase timeout for integration tests", Confidence: 0.92},
 	}
 
-	body := fl.generateBody(prState, FailureCIPreMerge, []string{"build"}, "Error: build failed", 0, patterns)
+	body := fl.generateBody(prState, FailureCIPreMerge, []string{"build"}, "Error: build failed", nil, 0, patterns)
 
 	if !strings.Contains(body, "Known Patterns") {
 		t.Error("body should contain Known Patterns section when patterns provided")
@@ -1036,7 +1036,7 @@ func TestFeedbackLoop_GenerateBody_NoPatterns(t *testing.T) {
 	}
 
 	// Empty patterns slice — no Known Patterns section
-	body := fl.generateBody(prState, FailureCIPreMerge, []string{"build"}, "", 0, nil)
+	body := fl.generateBody(prState, FailureCIPreMerge, []string{"build"}, "", nil, 0, nil)
 
 	if strings.Contains(body, "Known Patterns") {
 		t.Error("body should NOT contain Known Patterns section when no patterns")
@@ -0,0 +1,492 @@
+package autopilot
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/alerts"
+)
+
+// freezeRefreshInterval is how often the manual freeze is re-read from the
+// store, so `pilot freeze start` and `pilot freeze end` from another process
+// take effect
+const freezeRefreshInterval = 5 * time.Second
+
+// freezeMetadataKey stores the manual freeze in autopilot_metadata
+const freezeMetadataKey = "merge_freeze"
+
+// Freeze sources reported in FreezeStatus.
+const (
+	FreezeSourceWindow = "window" // A configured freeze window
+	FreezeSourceManual = "manual" // Started with `pilot freeze start`
+)
+
+// FreezeConfig schedules maintenance windows during which autopilot prepares
+// PRs as usual but holds merges and releases until the window ends.
+type FreezeConfig struct {
+	// Timezone is the IANA timezone the windows are written in (default: local).
+	Timezone string `yaml:"timezone,omitempty"`
+	// Windows are the scheduled freezes.
+	Windows []FreezeWindow `yaml:"windows"`
+}
+
+// FreezeWindow is one scheduled freeze. Start and End are either weekly
+// times ("Fri 16:00" to "Mon 08:00") or dates for a one-off freeze
+// ("2026-12-20" or "2026-12-20 18:00").
+type FreezeWindow struct {
+	// Name is shown in status output and alerts (e.g. "weekend", "holidays").
+	Name string `yaml:"name,omitempty"`
+	// Start is when the freeze begins.
+	Start string `yaml:"start"`
+	// End is when merges resume.
+	End string `yaml:"end"`
+}
+
+// Freeze is a manual freeze started with `pilot freeze start`.
+type Freeze struct {
+	// Reason is shown in status output and alerts.
+	Reason string `json:"reason,omitempty"`
+	// StartedAt is when the freeze was started.
+	StartedAt time.Time `json:"started_at"`
+	// Until is when the freeze lifts by itself; zero means until `pilot freeze end`.
+	Until time.Time `json:"until,omitempty"`
+}
+
+// FreezeStatus describes whether merges are frozen right now.
+type FreezeStatus struct {
+	// Frozen is true while merges and releases are held.
+	Frozen bool
+	// Source is FreezeSourceWindow or FreezeSourceManual.
+	Source string
+	// Reason is the window name or the manual freeze's reason.
+	Reason string
+	// Until is when the freeze lifts; zero for a manual freeze without end.
+	Until time.Time
+}
+
+// String describes the freeze for logs, status output and PR comments.
+func (s FreezeStatus) String() string {
+	if !s.Frozen {
+		return "not frozen"
+	}
+	desc := s.label()
+	if s.Until.IsZero() {
+		return desc + " until lifted with 'pilot freeze end'"
+	}
+	return desc + " until " + s.Until.Local().Format("Mon Jan 2 15:04")
+}
+
+// label names the freeze without its end, e.g. "merge freeze (weekend)"
+func (s FreezeStatus) label() string {
+	if s.Reason == "" {
+		return "merge freeze"
+	}
+	return "merge freeze (" + s.Reason + ")"
+}
+
+// FreezeStore persists the manual freeze. Satisfied by *StateStore.
+type FreezeStore interface {
+	GetFreeze() (*Freeze, error)
+	SaveFreeze(f *Freeze) error
+}
+
+// GetFreeze returns the manual freeze, or nil when none is set.
+func (s *StateStore) GetFreeze() (*Freeze, error) {
+	value, err := s.GetMetadata(freezeMetadataKey)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var f Freeze
+	if err := json.Unmarshal([]byte(value), &f); err != nil {
+		return nil, fmt.Errorf("invalid stored freeze: %w", err)
+	}
+	return &f, nil
+}
+
+// SaveFreeze stores the manual freeze. A nil freeze lifts it.
+func (s *StateStore) SaveFreeze(f *Freeze) error {
+	if f == nil {
+		return s.SaveMetadata(freezeMetadataKey, "")
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return s.SaveMetadata(freezeMetadataKey, string(data))
+}
+
+// FreezeCalendar answers whether merges are frozen, from the configured
+// windows and the manual freeze. All methods are safe on a nil
+// *FreezeCalendar, which is never frozen.
+type FreezeCalendar struct {
+	windows []freezeWindow
+	loc     *time.Location
+	log     *slog.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	store   FreezeStore
+	manual  *Freeze
+	checked time.Time // When manual was last read from the store
+}
+
+// freezeWindow is a parsed FreezeWindow
+type freezeWindow struct {
+	name   string
+	weekly bool
+	// Weekly windows: wall-clock minutes since Sunday 00:00
+	startMinute, endMinute int
+	// One-off windows
+	start, end time.Time
+}
+
+// NewFreezeCalendar creates a calendar for cfg's windows. A nil cfg has no
+// windows; manual freezes still apply once a store is set.
+func NewFreezeCalendar(cfg *FreezeConfig) (*FreezeCalendar, error) {
+	f := &FreezeCalendar{
+		loc: time.Local,
+		log: slog.Default().With("component", "freeze"),
+		now: time.Now,
+	}
+	if cfg == nil {
+		return f, nil
+	}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+		f.loc = loc
+	}
+	for i, w := range cfg.Windows {
+		parsed, err := parseFreezeWindow(w, f.loc)
+		if err != nil {
+			return nil, fmt.Errorf("windows[%d]: %w", i, err)
+		}
+		f.windows = append(f.windows, parsed)
+	}
+	return f, nil
+}
+
+// Validate checks the timezone and windows.
+func (c *FreezeConfig) Validate() error {
+	_, err := NewFreezeCalendar(c)
+	return err
+}
+
+// SetStore persists manual freezes in store and loads the current one.
+func (f *FreezeCalendar) SetStore(store FreezeStore) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	f.store = store
+	f.checked = time.Time{}
+	f.mu.Unlock()
+}
+
+// Start freezes merges until Until, or until End when until is zero.
+func (f *FreezeCalendar) Start(reason string, until time.Time) error {
+	if f == nil {
+		return fmt.Errorf("freeze calendar not configured")
+	}
+	freeze := &Freeze{Reason: reason, StartedAt: f.now(), Until: until}
+	if err := f.save(freeze); err != nil {
+		return err
+	}
+	f.log.Info("Merge freeze started", "reason", reason, "until", until)
+	return nil
+}
+
+// End lifts the manual freeze. Scheduled windows still apply.
+func (f *FreezeCalendar) End() error {
+	if f == nil {
+		return nil
+	}
+	if err := f.save(nil); err != nil {
+		return err
+	}
+	f.log.Info("Merge freeze lifted")
+	return nil
+}
+
+// Manual returns the manual freeze in effect, or nil.
+func (f *FreezeCalendar) Manual() *Freeze {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	stale := f.store != nil && f.now().Sub(f.checked) >= freezeRefreshInterval
+	f.mu.Unlock()
+	if stale {
+		f.refresh()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.manual == nil || (!f.manual.Until.IsZero() && !f.now().Before(f.manual.Until)) {
+		return nil
+	}
+	manual := *f.manual
+	return &manual
+}
+
+// Status reports whether merges are frozen now. A manual freeze wins over a
+// window.
+func (f *FreezeCalendar) Status() FreezeStatus {
+	if f == nil {
+		return FreezeStatus{}
+	}
+	if manual := f.Manual(); manual != nil {
+		return FreezeStatus{Frozen: true, Source: FreezeSourceManual, Reason: manual.Reason, Until: manual.Until}
+	}
+	now := f.now()
+	for _, w := range f.windows {
+		if until, ok := w.active(now, f.loc); ok {
+			return FreezeStatus{Frozen: true, Source: FreezeSourceWindow, Reason: w.name, Until: until}
+		}
+	}
+	return FreezeStatus{}
+}
+
+// Next returns the next scheduled window starting after now, if any.
+func (f *FreezeCalendar) Next() (name string, start, end time.Time, ok bool) {
+	if f == nil {
+		return "", time.Time{}, time.Time{}, false
+	}
+	now := f.now()
+	for _, w := range f.windows {
+		s, e, found := w.next(now, f.loc)
+		if found && (!ok || s.Before(start)) {
+			name, start, end, ok = w.name, s, e, true
+		}
+	}
+	return name, start, end, ok
+}
+
+func (f *FreezeCalendar) save(freeze *Freeze) error {
+	f.mu.Lock()
+	store := f.store
+	f.mu.Unlock()
+	if store != nil {
+		if err := store.SaveFreeze(freeze); err != nil {
+			return fmt.Errorf("failed to save freeze: %w", err)
+		}
+	}
+
+	f.mu.Lock()
+	f.manual = freeze
+	f.checked = f.now()
+	f.mu.Unlock()
+	return nil
+}
+
+// refresh re-reads the manual freeze, keeping the last known one when the
+// read fails
+func (f *FreezeCalendar) refresh() {
+	f.mu.Lock()
+	store := f.store
+	f.mu.Unlock()
+	if store == nil {
+		return
+	}
+	manual, err := store.GetFreeze()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checked = f.now()
+	if err != nil {
+		f.log.Warn("Failed to read merge freeze", "error", err)
+		return
+	}
+	f.manual = manual
+}
+
+// minutesPerWeek is the length of the weekly window cycle
+const minutesPerWeek = 7 * 24 * 60
+
+// active reports whether t falls inside the window and when it ends.
+func (w freezeWindow) active(t time.Time, loc *time.Location) (time.Time, bool) {
+	if !w.weekly {
+		return w.end, !t.Before(w.start) && t.Before(w.end)
+	}
+	t = t.In(loc)
+	minute := minuteOfWeek(t)
+	switch {
+	case w.startMinute < w.endMinute:
+		if minute >= w.startMinute && minute < w.endMinute {
+			return atMinuteOfWeek(t, w.endMinute), true
+		}
+	case minute >= w.startMinute:
+		// Wraps past Sunday midnight, e.g. Fri 16:00 to Mon 08:00
+		return atMinuteOfWeek(t, minutesPerWeek+w.endMinute), true
+	case minute < w.endMinute:
+		return atMinuteOfWeek(t, w.endMinute), true
+	}
+	return time.Time{}, false
+}
+
+// next returns the window's next occurrence starting after t.
+func (w freezeWindow) next(t time.Time, loc *time.Location) (time.Time, time.Time, bool) {
+	if !w.weekly {
+		return w.start, w.end, w.start.After(t)
+	}
+	t = t.In(loc)
+	startMinute := w.startMinute
+	if startMinute <= minuteOfWeek(t) {
+		startMinute += minutesPerWeek
+	}
+	endMinute := startMinute - w.startMinute + w.endMinute
+	if w.endMinute < w.startMinute {
+		endMinute += minutesPerWeek
+	}
+	return atMinuteOfWeek(t, startMinute), atMinuteOfWeek(t, endMinute), true
+}
+
+// minuteOfWeek returns t's wall-clock minutes since Sunday 00:00
+func minuteOfWeek(t time.Time) int {
+	return int(t.Weekday())*24*60 + t.Hour()*60 + t.Minute()
+}
+
+// atMinuteOfWeek returns the wall-clock time minute minutes after Sunday
+// 00:00 of t's week, in t's location
+func atMinuteOfWeek(t time.Time, minute int) time.Time {
+	y, m, d := t.Date()
+	sunday := d - int(t.Weekday())
+	return time.Date(y, m, sunday+minute/(24*60), (minute/60)%24, minute%60, 0, 0, t.Location())
+}
+
+// parseFreezeWindow parses a window written as weekly times or dates
+func parseFreezeWindow(w FreezeWindow, loc *time.Location) (freezeWindow, error) {
+	name := w.Name
+	if name == "" {
+		name = w.Start + " – " + w.End
+	}
+	startMinute, startWeekly, startErr := parseWeeklyTime(w.Start)
+	endMinute, endWeekly, endErr := parseWeeklyTime(w.End)
+	if startWeekly || endWeekly {
+		if startErr != nil {
+			return freezeWindow{}, fmt.Errorf("start: %w", startErr)
+		}
+		if endErr != nil {
+			return freezeWindow{}, fmt.Errorf("end: %w", endErr)
+		}
+		if startMinute == endMinute {
+			return freezeWindow{}, fmt.Errorf("start and end are the same time")
+		}
+		return freezeWindow{name: name, weekly: true, startMinute: startMinute, endMinute: endMinute}, nil
+	}
+
+	start, err := parseFreezeDate(w.Start, loc)
+	if err != nil {
+		return freezeWindow{}, fmt.Errorf("start: %w", err)
+	}
+	end, err := parseFreezeDate(w.End, loc)
+	if err != nil {
+		return freezeWindow{}, fmt.Errorf("end: %w", err)
+	}
+	if !end.After(start) {
+		return freezeWindow{}, fmt.Errorf("end %q is not after start %q", w.End, w.Start)
+	}
+	return freezeWindow{name: name, start: start, end: end}, nil
+}
+
+// weekdays maps weekday abbreviations to their time.Weekday
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseWeeklyTime parses "Fri 16:00" into its minutes since Sunday 00:00.
+// weekly is false when s doesn't start with a weekday.
+func parseWeeklyTime(s string) (minute int, weekly bool, err error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields[0]) < 3 {
+		return 0, false, fmt.Errorf("invalid time %q", s)
+	}
+	day, ok := weekdays[strings.ToLower(fields[0][:3])]
+	if !ok {
+		return 0, false, fmt.Errorf("invalid time %q", s)
+	}
+	if len(fields) != 2 {
+		return 0, true, fmt.Errorf("invalid time %q, want e.g. \"Fri 16:00\"", s)
+	}
+	clock, err := time.Parse("15:04", fields[1])
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid time %q, want e.g. \"Fri 16:00\"", s)
+	}
+	return int(day)*24*60 + clock.Hour()*60 + clock.Minute(), true, nil
+}
+
+// parseFreezeDate parses "2026-12-20" or "2026-12-20 18:00" in loc
+func parseFreezeDate(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, want \"Fri 16:00\", \"2006-01-02\" or \"2006-01-02 15:04\"", s)
+}
+
+// heldByFreeze reports whether a merge freeze holds the PR back from merging,
+// promoting or releasing. The first time a PR is held it is logged and
+// alerted; when the freeze ends the PR resumes where it stopped.
+func (c *Controller) heldByFreeze(prState *PRState) bool {
+	status := c.freeze.Status()
+	if !status.Frozen {
+		if !prState.FrozenAt.IsZero() {
+			c.log.Info("merge freeze over, resuming PR",
+				"pr", prState.PRNumber,
+				"stage", prState.Stage,
+				"held", time.Since(prState.FrozenAt).Round(time.Minute),
+			)
+			prState.FrozenAt = time.Time{}
+		}
+		return false
+	}
+
+	if prState.FrozenAt.IsZero() {
+		prState.FrozenAt = time.Now()
+		c.log.Info("PR held by merge freeze",
+			"pr", prState.PRNumber,
+			"stage", prState.Stage,
+			"freeze", status.String(),
+		)
+		c.alertMergeFrozen(prState, status)
+	}
+	return true
+}
+
+// alertMergeFrozen reports a PR queued behind a merge freeze.
+func (c *Controller) alertMergeFrozen(prState *PRState, status FreezeStatus) {
+	if c.alerts == nil {
+		return
+	}
+	until := ""
+	if !status.Until.IsZero() {
+		until = status.Until.Local().Format("Mon Jan 2 15:04")
+	}
+	c.alerts.ProcessEvent(alerts.Event{
+		Type:      alerts.EventTypeMergeFrozen,
+		TaskID:    fmt.Sprintf("pr-%d", prState.PRNumber),
+		TaskTitle: prState.PRTitle,
+		Project:   fmt.Sprintf("%s/%s", c.owner, c.repo),
+		Metadata: map[string]string{
+			"pr_number": strconv.Itoa(prState.PRNumber),
+			"freeze":    status.label(),
+			"until":     until,
+			"source":    status.Source,
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// Freeze returns the controller's freeze calendar.
+func (c *Controller) Freeze() *FreezeCalendar {
+	return c.freeze
+}
@@ -0,0 +1,251 @@
+package autopilot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/alerts"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// newTestFreezeCalendar returns a UTC calendar whose clock reads *now
+func newTestFreezeCalendar(t *testing.T, now *time.Time, windows ...FreezeWindow) *FreezeCalendar {
+	t.Helper()
+	f, err := NewFreezeCalendar(&FreezeConfig{Timezone: "UTC", Windows: windows})
+	if err != nil {
+		t.Fatalf("NewFreezeCalendar() error = %v", err)
+	}
+	f.now = func() time.Time { return *now }
+	return f
+}
+
+func TestFreezeCalendar_WeeklyWindow(t *testing.T) {
+	weekend := FreezeWindow{Name: "weekend", Start: "Fri 16:00", End: "Mon 08:00"}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantFrozen bool
+		wantUntil  time.Time
+	}{
+		{"thursday", time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC), false, time.Time{}},
+		{"friday before", time.Date(2026, 10, 16, 15, 59, 0, 0, time.UTC), false, time.Time{}},
+		{"friday start", time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC), true, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)},
+		{"saturday", time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), true, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)},
+		{"sunday", time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC), true, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)},
+		{"monday early", time.Date(2026, 10, 19, 7, 59, 0, 0, time.UTC), true, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)},
+		{"monday end", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := tt.now
+			status := newTestFreezeCalendar(t, &now, weekend).Status()
+			if status.Frozen != tt.wantFrozen {
+				t.Fatalf("Frozen = %v, want %v", status.Frozen, tt.wantFrozen)
+			}
+			if !status.Until.Equal(tt.wantUntil) {
+				t.Errorf("Until = %v, want %v", status.Until, tt.wantUntil)
+			}
+			if status.Frozen && (status.Source != FreezeSourceWindow || status.Reason != "weekend") {
+				t.Errorf("status = %+v, want weekend window", status)
+			}
+		})
+	}
+}
+
+func TestFreezeCalendar_WeekdayWindowAndNext(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+	f := newTestFreezeCalendar(t, &now, FreezeWindow{Name: "deploy", Start: "Wed 10:00", End: "Wed 14:00"})
+
+	if status := f.Status(); !status.Frozen || !status.Until.Equal(time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Status() = %+v, want frozen until 14:00", status)
+	}
+
+	name, start, end, ok := f.Next()
+	if !ok || name != "deploy" {
+		t.Fatalf("Next() = %q, %v", name, ok)
+	}
+	if !start.Equal(time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 10, 21, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() = %v – %v, want next Wednesday", start, end)
+	}
+}
+
+func TestFreezeCalendar_DateWindow(t *testing.T) {
+	holidays := FreezeWindow{Name: "holidays", Start: "2026-12-20", End: "2027-01-04 08:00"}
+
+	now := time.Date(2026, 12, 19, 23, 59, 0, 0, time.UTC)
+	f := newTestFreezeCalendar(t, &now, holidays)
+	if f.Status().Frozen {
+		t.Error("frozen before the window starts")
+	}
+	if _, start, _, ok := f.Next(); !ok || !start.Equal(time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() start = %v, %v", start, ok)
+	}
+
+	now = time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC)
+	if status := f.Status(); !status.Frozen || !status.Until.Equal(time.Date(2027, 1, 4, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Status() = %+v, want frozen until Jan 4 08:00", status)
+	}
+
+	now = time.Date(2027, 1, 4, 8, 0, 0, 0, time.UTC)
+	if f.Status().Frozen {
+		t.Error("frozen after the window ended")
+	}
+	if _, _, _, ok := f.Next(); ok {
+		t.Error("Next() found a past one-off window")
+	}
+}
+
+// memFreezeStore keeps the manual freeze in memory
+type memFreezeStore struct {
+	freeze *Freeze
+}
+
+func (s *memFreezeStore) GetFreeze() (*Freeze, error) { return s.freeze, nil }
+func (s *memFreezeStore) SaveFreeze(f *Freeze) error  { s.freeze = f; return nil }
+
+func TestFreezeCalendar_Manual(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	store := &memFreezeStore{}
+	f := newTestFreezeCalendar(t, &now)
+	f.SetStore(store)
+
+	if f.Status().Frozen {
+		t.Fatal("frozen without a freeze")
+	}
+
+	until := now.Add(2 * time.Hour)
+	if err := f.Start("release soak", until); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if store.freeze == nil || store.freeze.Reason != "release soak" {
+		t.Fatalf("stored freeze = %+v", store.freeze)
+	}
+	status := f.Status()
+	if !status.Frozen || status.Source != FreezeSourceManual || !status.Until.Equal(until) {
+		t.Errorf("Status() = %+v, want manual freeze", status)
+	}
+	if got := status.String(); !strings.HasPrefix(got, "merge freeze (release soak) until ") {
+		t.Errorf("String() = %q", got)
+	}
+
+	// Expires by itself
+	now = until
+	if f.Status().Frozen {
+		t.Error("manual freeze did not expire at until")
+	}
+
+	// Another process ends the freeze: picked up on the next refresh
+	now = now.Add(time.Hour)
+	_ = f.Start("", time.Time{})
+	if got := f.Status().String(); got != "merge freeze until lifted with 'pilot freeze end'" {
+		t.Errorf("String() = %q", got)
+	}
+	store.freeze = nil
+	if !f.Status().Frozen {
+		t.Error("freeze lifted before the refresh interval")
+	}
+	now = now.Add(freezeRefreshInterval)
+	if f.Status().Frozen {
+		t.Error("freeze ended in the store still active after refresh")
+	}
+}
+
+func TestStateStore_Freeze(t *testing.T) {
+	store := newTestStateStore(t)
+
+	if f, err := store.GetFreeze(); err != nil || f != nil {
+		t.Fatalf("GetFreeze() on empty store = %+v, %v", f, err)
+	}
+	want := &Freeze{Reason: "incident", StartedAt: time.Now().UTC().Truncate(time.Second)}
+	if err := store.SaveFreeze(want); err != nil {
+		t.Fatalf("SaveFreeze() error = %v", err)
+	}
+	got, err := store.GetFreeze()
+	if err != nil || got == nil || got.Reason != "incident" || !got.StartedAt.Equal(want.StartedAt) || !got.Until.IsZero() {
+		t.Fatalf("GetFreeze() = %+v, %v", got, err)
+	}
+	if err := store.SaveFreeze(nil); err != nil {
+		t.Fatalf("SaveFreeze(nil) error = %v", err)
+	}
+	if f, _ := store.GetFreeze(); f != nil {
+		t.Errorf("GetFreeze() after lift = %+v", f)
+	}
+}
+
+func TestFreezeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     FreezeConfig
+		wantErr string
+	}{
+		{"weekly", FreezeConfig{Windows: []FreezeWindow{{Start: "Fri 16:00", End: "Mon 08:00"}}}, ""},
+		{"dates", FreezeConfig{Windows: []FreezeWindow{{Start: "2026-12-20", End: "2026-12-27 18:00"}}}, ""},
+		{"timezone", FreezeConfig{Timezone: "Europe/Berlin"}, ""},
+		{"bad timezone", FreezeConfig{Timezone: "Mars/Olympus"}, "invalid timezone"},
+		{"bad day", FreezeConfig{Windows: []FreezeWindow{{Start: "Fry 16:00", End: "Mon 08:00"}}}, "windows[0]: start"},
+		{"bad clock", FreezeConfig{Windows: []FreezeWindow{{Start: "Fri 4pm", End: "Mon 08:00"}}}, "windows[0]: start"},
+		{"mixed", FreezeConfig{Windows: []FreezeWindow{{Start: "Fri 16:00", End: "2026-12-27"}}}, "windows[0]: end"},
+		{"empty window", FreezeConfig{Windows: []FreezeWindow{{Start: "Fri 16:00", End: "Fri 16:00"}}}, "same time"},
+		{"end before start", FreezeConfig{Windows: []FreezeWindow{{Start: "2026-12-27", End: "2026-12-20"}}}, "not after start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestController_HeldByFreeze verifies that a frozen controller leaves a PR
+// ready to merge in place, alerts once, and merges after the freeze ends.
+func TestController_HeldByFreeze(t *testing.T) {
+	ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, "http://127.0.0.1:0")
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+
+	c := NewController(cfg, ghClient, nil, "owner", "repo")
+	rec := &recordingAlerts{}
+	c.SetAlertProcessor(rec)
+	c.freeze.SetStore(&memFreezeStore{})
+	if err := c.freeze.Start("incident", time.Time{}); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	c.OnPRCreated(42, "https://github.com/owner/repo/pull/42", 10, "abc1234", "pilot/GH-10", "")
+	prState, _ := c.GetPRState(42)
+	prState.Stage = StageMerging
+
+	for i := 0; i < 2; i++ {
+		if err := c.handleMerging(context.Background(), prState); err != nil {
+			t.Fatalf("handleMerging() error = %v", err)
+		}
+	}
+	if prState.Stage != StageMerging || prState.MergeAttempts != 0 {
+		t.Errorf("frozen PR stage = %s, attempts = %d, want held in merging", prState.Stage, prState.MergeAttempts)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != alerts.EventTypeMergeFrozen {
+		t.Fatalf("alerts = %+v, want one merge_frozen", rec.events)
+	}
+	if got := rec.events[0].Metadata["freeze"]; got != "merge freeze (incident)" {
+		t.Errorf("freeze metadata = %q", got)
+	}
+
+	_ = c.freeze.End()
+	if c.heldByFreeze(prState) || !prState.FrozenAt.IsZero() {
+		t.Error("PR still held after the freeze ended")
+	}
+}
@@ -0,0 +1,408 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/alerts"
+)
+
+// linkedMetadataKey stores the linked PR groups in autopilot_metadata
+const linkedMetadataKey = "linked_prs"
+
+// LinkedPR is one PR of a change that spans several repositories.
+type LinkedPR struct {
+	// Repo is the "owner/repo" the PR belongs to.
+	Repo string `json:"repo"`
+	// PRNumber is 0 when the repo's task produced no PR.
+	PRNumber int `json:"pr_number,omitempty"`
+	// PRURL is the full URL to the PR.
+	PRURL string `json:"pr_url,omitempty"`
+	// Merged is set once autopilot merged the PR.
+	Merged bool `json:"merged,omitempty"`
+}
+
+// String returns "owner/repo#N", or the repo alone when it has no PR.
+func (p LinkedPR) String() string {
+	if p.PRNumber == 0 {
+		return p.Repo
+	}
+	return fmt.Sprintf("%s#%d", p.Repo, p.PRNumber)
+}
+
+// LinkedGroup is a change split into one PR per repository. Autopilot merges
+// none of its PRs until every one of them is green and ready to merge.
+type LinkedGroup struct {
+	// ID names the change, e.g. the issue "owner/repo#12".
+	ID string `json:"id"`
+	// PRs are the group's PRs, one per repository.
+	PRs []LinkedPR `json:"prs"`
+	// CreatedAt is when the group was linked.
+	CreatedAt time.Time `json:"created_at"`
+	// Incomplete is set once a PR failed after others of the group merged.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// LinkedStore persists linked groups. Satisfied by *StateStore.
+type LinkedStore interface {
+	GetLinkedGroups() ([]*LinkedGroup, error)
+	SaveLinkedGroups(groups []*LinkedGroup) error
+}
+
+// GetLinkedGroups returns the stored linked groups.
+func (s *StateStore) GetLinkedGroups() ([]*LinkedGroup, error) {
+	value, err := s.GetMetadata(linkedMetadataKey)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var groups []*LinkedGroup
+	if err := json.Unmarshal([]byte(value), &groups); err != nil {
+		return nil, fmt.Errorf("invalid stored linked PRs: %w", err)
+	}
+	return groups, nil
+}
+
+// SaveLinkedGroups replaces the stored linked groups.
+func (s *StateStore) SaveLinkedGroups(groups []*LinkedGroup) error {
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return err
+	}
+	return s.SaveMetadata(linkedMetadataKey, string(data))
+}
+
+// LinkedMerges coordinates the controllers of several repositories so the PRs
+// of a multi-repo change merge together: each PR waits in its merge stage
+// until every PR of its group is green, then all of them merge. A PR whose
+// repo has no controller, or a repo task that produced no PR, holds the whole
+// group. All methods are safe on a nil *LinkedMerges, which links nothing.
+type LinkedMerges struct {
+	mu          sync.Mutex
+	controllers map[string]*Controller // "owner/repo" -> controller
+	groups      map[string]*LinkedGroup
+	store       LinkedStore
+	log         *slog.Logger
+}
+
+// NewLinkedMerges creates an empty coordinator.
+func NewLinkedMerges() *LinkedMerges {
+	return &LinkedMerges{
+		controllers: make(map[string]*Controller),
+		groups:      make(map[string]*LinkedGroup),
+		log:         slog.Default().With("component", "linked-merges"),
+	}
+}
+
+// AddController lets the coordinator see the controller's PRs and holds its
+// linked PRs until their group is ready.
+func (l *LinkedMerges) AddController(c *Controller) {
+	if l == nil || c == nil {
+		return
+	}
+	l.mu.Lock()
+	l.controllers[strings.ToLower(c.Repository())] = c
+	l.mu.Unlock()
+	c.linked = l
+}
+
+// Controller returns the controller for repo ("owner/repo"), or nil.
+func (l *LinkedMerges) Controller(repo string) *Controller {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.controllers[strings.ToLower(repo)]
+}
+
+// SetStore persists groups in store and loads the groups linked before a restart.
+func (l *LinkedMerges) SetStore(store LinkedStore) error {
+	if l == nil {
+		return nil
+	}
+	groups, err := store.GetLinkedGroups()
+	if err != nil {
+		return fmt.Errorf("failed to load linked PRs: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.store = store
+	for _, g := range groups {
+		l.groups[g.ID] = g
+	}
+	return nil
+}
+
+// Link records a multi-repo change. Linking an ID again replaces its PRs.
+func (l *LinkedMerges) Link(id string, prs []LinkedPR) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.groups[id] = &LinkedGroup{ID: id, PRs: prs, CreatedAt: time.Now()}
+	l.log.Info("Linked multi-repo change", "id", id, "prs", linkedPRList(prs))
+	return l.saveLocked()
+}
+
+// Group returns a copy of the group holding repo's PR, or nil.
+func (l *LinkedMerges) Group(repo string, prNumber int) *LinkedGroup {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g := l.groupLocked(repo, prNumber)
+	if g == nil {
+		return nil
+	}
+	cp := *g
+	cp.PRs = append([]LinkedPR(nil), g.PRs...)
+	return &cp
+}
+
+// Waiting returns the PRs of repo's PR's group that are not ready to merge
+// yet, excluding the PR itself. Empty when the PR is not linked or its whole
+// group is ready.
+func (l *LinkedMerges) Waiting(repo string, prNumber int) []LinkedPR {
+	group := l.Group(repo, prNumber)
+	if group == nil {
+		return nil
+	}
+	var waiting []LinkedPR
+	for _, pr := range group.PRs {
+		if strings.EqualFold(pr.Repo, repo) && pr.PRNumber == prNumber {
+			continue
+		}
+		if !l.readyToMerge(pr) {
+			waiting = append(waiting, pr)
+		}
+	}
+	return waiting
+}
+
+// markMerged records that repo's PR merged. The group is forgotten once all
+// of its PRs merged.
+func (l *LinkedMerges) markMerged(repo string, prNumber int) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g := l.groupLocked(repo, prNumber)
+	if g == nil {
+		return
+	}
+	done := true
+	for i := range g.PRs {
+		if strings.EqualFold(g.PRs[i].Repo, repo) && g.PRs[i].PRNumber == prNumber {
+			g.PRs[i].Merged = true
+		}
+		done = done && g.PRs[i].Merged
+	}
+	if done {
+		delete(l.groups, g.ID)
+		l.log.Info("Multi-repo change fully merged", "id", g.ID)
+	}
+	if err := l.saveLocked(); err != nil {
+		l.log.Warn("Failed to save linked PRs", "error", err)
+	}
+}
+
+// readyToMerge reports whether pr merged or waits, green, to merge: in the
+// merge stage, or awaiting approval once approved
+func (l *LinkedMerges) readyToMerge(pr LinkedPR) bool {
+	if pr.Merged {
+		return true
+	}
+	if pr.PRNumber == 0 {
+		return false
+	}
+	c := l.Controller(pr.Repo)
+	if c == nil {
+		return false
+	}
+	state, ok := c.GetPRState(pr.PRNumber)
+	if !ok {
+		return false
+	}
+	return state.Stage == StageMerging || state.Stage == StageAwaitApproval && state.Approved
+}
+
+// verifyGroup rechecks the group of repo's PR right before the PR merges:
+// every other PR that has not merged must still be open on the head its
+// controller saw pass CI, and CI must still pass there.
+func (l *LinkedMerges) verifyGroup(ctx context.Context, repo string, prNumber int) error {
+	group := l.Group(repo, prNumber)
+	if group == nil {
+		return nil
+	}
+	for _, pr := range group.PRs {
+		if pr.Merged || strings.EqualFold(pr.Repo, repo) && pr.PRNumber == prNumber {
+			continue
+		}
+		c := l.Controller(pr.Repo)
+		if c == nil {
+			return fmt.Errorf("%s has no autopilot controller", pr)
+		}
+		state, ok := c.GetPRState(pr.PRNumber)
+		if !ok {
+			return fmt.Errorf("%s is not tracked", pr)
+		}
+		if err := c.verifyMergeable(ctx, state); err != nil {
+			return fmt.Errorf("%s: %w", pr, err)
+		}
+	}
+	return nil
+}
+
+// failed records that repo's PR will not merge. When other PRs of its group
+// already merged it returns the group and those PRs, once per group; the
+// group is kept so later failures don't report it again.
+func (l *LinkedMerges) failed(repo string, prNumber int) (*LinkedGroup, []LinkedPR) {
+	if l == nil {
+		return nil, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g := l.groupLocked(repo, prNumber)
+	if g == nil || g.Incomplete {
+		return nil, nil
+	}
+	var merged []LinkedPR
+	for _, pr := range g.PRs {
+		if pr.Merged {
+			merged = append(merged, pr)
+		}
+	}
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	g.Incomplete = true
+	if err := l.saveLocked(); err != nil {
+		l.log.Warn("Failed to save linked PRs", "error", err)
+	}
+	return g, merged
+}
+
+func (l *LinkedMerges) groupLocked(repo string, prNumber int) *LinkedGroup {
+	for _, g := range l.groups {
+		for _, pr := range g.PRs {
+			if pr.PRNumber == prNumber && strings.EqualFold(pr.Repo, repo) {
+				return g
+			}
+		}
+	}
+	return nil
+}
+
+func (l *LinkedMerges) saveLocked() error {
+	if l.store == nil {
+		return nil
+	}
+	groups := make([]*LinkedGroup, 0, len(l.groups))
+	for _, g := range l.groups {
+		groups = append(groups, g)
+	}
+	return l.store.SaveLinkedGroups(groups)
+}
+
+// linkedPRList joins PRs for logs and comments, e.g. "acme/api#3, acme/web#7"
+func linkedPRList(prs []LinkedPR) string {
+	names := make([]string, len(prs))
+	for i, pr := range prs {
+		names[i] = pr.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// heldByLinkedPRs reports whether the PR waits for the other PRs of its
+// multi-repo change to turn green. Once they are, their heads and CI are
+// checked again so none of the group merges on stale state. The wait is
+// logged once per hold.
+func (c *Controller) heldByLinkedPRs(ctx context.Context, prState *PRState) bool {
+	waiting := c.linked.Waiting(c.Repository(), prState.PRNumber)
+	if len(waiting) == 0 {
+		if err := c.linked.verifyGroup(ctx, c.Repository(), prState.PRNumber); err != nil {
+			c.log.Warn("linked PR failed the pre-merge check, holding", "pr", prState.PRNumber, "error", err)
+			return true
+		}
+		if !prState.LinkedWaitAt.IsZero() {
+			c.log.Info("linked PRs ready, merging", "pr", prState.PRNumber,
+				"waited", time.Since(prState.LinkedWaitAt).Round(time.Minute))
+			prState.LinkedWaitAt = time.Time{}
+		}
+		return false
+	}
+	if prState.LinkedWaitAt.IsZero() {
+		prState.LinkedWaitAt = time.Now()
+		c.log.Info("PR waits for linked PRs before merging",
+			"pr", prState.PRNumber,
+			"waiting", linkedPRList(waiting),
+		)
+	}
+	return true
+}
+
+// verifyMergeable checks that the PR is still open on prState.HeadSHA and
+// that CI passes there
+func (c *Controller) verifyMergeable(ctx context.Context, prState *PRState) error {
+	ghPR, err := c.ghClient.GetPullRequest(ctx, c.owner, c.repo, prState.PRNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get PR: %w", err)
+	}
+	if ghPR.Merged {
+		return nil
+	}
+	if ghPR.State != "open" {
+		return fmt.Errorf("PR is %s", ghPR.State)
+	}
+	if ghPR.Head.SHA != prState.HeadSHA {
+		return fmt.Errorf("head moved from %s to %s", ShortSHA(prState.HeadSHA), ShortSHA(ghPR.Head.SHA))
+	}
+	status, err := c.ciMonitor.GetCIStatus(ctx, prState.HeadSHA)
+	if err != nil {
+		return fmt.Errorf("failed to get CI status: %w", err)
+	}
+	if status != CISuccess {
+		return fmt.Errorf("CI is %s on %s", status, ShortSHA(prState.HeadSHA))
+	}
+	return nil
+}
+
+// linkedPRFailed alerts when a PR that will not merge leaves its multi-repo
+// change half-merged, so someone reverts or finishes the merged PRs.
+func (c *Controller) linkedPRFailed(prState *PRState) {
+	group, merged := c.linked.failed(c.Repository(), prState.PRNumber)
+	if group == nil {
+		return
+	}
+	c.log.Error("multi-repo change left half-merged",
+		"id", group.ID,
+		"pr", prState.PRNumber,
+		"merged", linkedPRList(merged),
+		"error", prState.Error,
+	)
+	if c.alerts == nil {
+		return
+	}
+	c.alerts.ProcessEvent(alerts.Event{
+		Type:      alerts.EventTypeLinkedMergeIncomplete,
+		TaskID:    fmt.Sprintf("pr-%d", prState.PRNumber),
+		TaskTitle: prState.PRTitle,
+		Project:   c.Repository(),
+		Error:     prState.Error,
+		Metadata: map[string]string{
+			"group":     group.ID,
+			"pr_number": strconv.Itoa(prState.PRNumber),
+			"merged":    linkedPRList(merged),
+		},
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,268 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/alerts"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// linkedGitHub serves the PRs of newLinkedControllers: open on the heads the
+// controllers track, with CI passing, until a test changes them
+type linkedGitHub struct {
+	mu         sync.Mutex
+	heads      map[string]string // PR path -> head SHA
+	conclusion string
+	merged     []string
+}
+
+func (g *linkedGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/check-runs"):
+		_ = json.NewEncoder(w).Encode(github.CheckRunsResponse{
+			TotalCount: 1,
+			CheckRuns:  []github.CheckRun{{Name: "build", Status: github.CheckRunCompleted, Conclusion: g.conclusion}},
+		})
+	case strings.HasSuffix(r.URL.Path, "/merge"):
+		g.merged = append(g.merged, strings.TrimSuffix(r.URL.Path, "/merge"))
+		_ = json.NewEncoder(w).Encode(map[string]bool{"merged": true})
+	case g.heads[r.URL.Path] != "":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"state": "open",
+			"head":  map[string]string{"sha": g.heads[r.URL.Path]},
+		})
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (g *linkedGitHub) set(path, head, conclusion string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.heads[path] = head
+	g.conclusion = conclusion
+}
+
+// newLinkedControllers returns controllers for acme/api and acme/web sharing
+// one coordinator, each tracking one PR
+func newLinkedControllers(t *testing.T) (*LinkedMerges, *Controller, *Controller) {
+	linked, api, web, _ := newLinkedControllersWithGitHub(t)
+	return linked, api, web
+}
+
+func newLinkedControllersWithGitHub(t *testing.T) (*LinkedMerges, *Controller, *Controller, *linkedGitHub) {
+	t.Helper()
+	gh := &linkedGitHub{
+		heads: map[string]string{
+			"/repos/acme/api/pulls/3": "aaa111",
+			"/repos/acme/web/pulls/7": "bbb222",
+		},
+		conclusion: github.ConclusionSuccess,
+	}
+	server := httptest.NewServer(gh)
+	t.Cleanup(server.Close)
+	ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+
+	api := NewController(cfg, ghClient, nil, "acme", "api")
+	web := NewController(cfg, ghClient, nil, "acme", "web")
+	api.OnPRCreated(3, "https://github.com/acme/api/pull/3", 0, "aaa111", "pilot/GH-12", "")
+	web.OnPRCreated(7, "https://github.com/acme/web/pull/7", 0, "bbb222", "pilot/GH-12", "")
+
+	linked := NewLinkedMerges()
+	linked.AddController(api)
+	linked.AddController(web)
+	if err := linked.Link("acme/api#12", []LinkedPR{
+		{Repo: "acme/api", PRNumber: 3},
+		{Repo: "acme/web", PRNumber: 7},
+	}); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	return linked, api, web, gh
+}
+
+func TestLinkedMerges_HoldsUntilAllReady(t *testing.T) {
+	linked, api, web := newLinkedControllers(t)
+
+	apiPR, _ := api.GetPRState(3)
+	webPR, _ := web.GetPRState(7)
+	apiPR.Stage = StageMerging
+	webPR.Stage = StageWaitingCI
+
+	// api is green but web is not: api waits in merging without a merge attempt
+	if err := api.handleMerging(context.Background(), apiPR); err != nil {
+		t.Fatalf("handleMerging() error = %v", err)
+	}
+	if apiPR.Stage != StageMerging || apiPR.MergeAttempts != 0 || apiPR.LinkedWaitAt.IsZero() {
+		t.Errorf("api PR stage = %s, attempts = %d, want held in merging", apiPR.Stage, apiPR.MergeAttempts)
+	}
+	if waiting := linked.Waiting("acme/api", 3); len(waiting) != 1 || waiting[0].String() != "acme/web#7" {
+		t.Errorf("Waiting() = %v, want [acme/web#7]", waiting)
+	}
+
+	// web turns green but still awaits approval: api keeps waiting
+	webPR.Stage = StageAwaitApproval
+	if waiting := linked.Waiting("acme/api", 3); len(waiting) != 1 {
+		t.Errorf("Waiting() with web awaiting approval = %v", waiting)
+	}
+
+	// web is approved: neither waits any more
+	webPR.Approved = true
+	if waiting := linked.Waiting("acme/api", 3); len(waiting) != 0 {
+		t.Errorf("Waiting() with all ready = %v", waiting)
+	}
+	if api.heldByLinkedPRs(context.Background(), apiPR) || !apiPR.LinkedWaitAt.IsZero() {
+		t.Error("api PR still held with all linked PRs ready")
+	}
+
+	// Once api merged, web still merges even though api left the merge stage
+	linked.markMerged("acme/api", 3)
+	apiPR.Stage = StageMerged
+	if waiting := linked.Waiting("acme/web", 7); len(waiting) != 0 {
+		t.Errorf("Waiting() after sibling merged = %v", waiting)
+	}
+	linked.markMerged("acme/web", 7)
+	if g := linked.Group("acme/api", 3); g != nil {
+		t.Errorf("group kept after every PR merged: %+v", g)
+	}
+}
+
+func TestLinkedMerges_MissingPRHoldsGroup(t *testing.T) {
+	linked, api, _ := newLinkedControllers(t)
+	_ = linked.Link("acme/api#12", []LinkedPR{
+		{Repo: "acme/api", PRNumber: 3},
+		{Repo: "acme/web"},               // task produced no PR
+		{Repo: "acme/docs", PRNumber: 1}, // no controller for the repo
+	})
+
+	apiPR, _ := api.GetPRState(3)
+	apiPR.Stage = StageMerging
+	if waiting := linked.Waiting("acme/api", 3); len(waiting) != 2 {
+		t.Errorf("Waiting() = %v, want acme/web and acme/docs#1", waiting)
+	}
+
+	// Unlinked PRs and a nil coordinator never wait
+	if waiting := linked.Waiting("acme/api", 99); waiting != nil {
+		t.Errorf("Waiting() for unlinked PR = %v", waiting)
+	}
+	var none *LinkedMerges
+	if waiting := none.Waiting("acme/api", 3); waiting != nil {
+		t.Errorf("nil Waiting() = %v", waiting)
+	}
+}
+
+func TestLinkedMerges_Persisted(t *testing.T) {
+	store := newTestStateStore(t)
+
+	linked := NewLinkedMerges()
+	if err := linked.SetStore(store); err != nil {
+		t.Fatalf("SetStore() error = %v", err)
+	}
+	_ = linked.Link("acme/api#12", []LinkedPR{{Repo: "acme/api", PRNumber: 3}, {Repo: "acme/web", PRNumber: 7}})
+	linked.markMerged("acme/api", 3)
+
+	restored := NewLinkedMerges()
+	if err := restored.SetStore(store); err != nil {
+		t.Fatalf("SetStore() error = %v", err)
+	}
+	g := restored.Group("acme/web", 7)
+	if g == nil || g.ID != "acme/api#12" || len(g.PRs) != 2 || !g.PRs[0].Merged || g.PRs[1].Merged {
+		t.Fatalf("restored group = %+v", g)
+	}
+}
+
+func TestLinkedMerges_ApprovedBeforeWaiting(t *testing.T) {
+	linked, api, web, gh := newLinkedControllersWithGitHub(t)
+
+	apiPR, _ := api.GetPRState(3)
+	webPR, _ := web.GetPRState(7)
+	apiPR.Stage = StageAwaitApproval
+	webPR.Stage = StageAwaitApproval
+
+	// Each PR gets its approval, then waits for the other one's
+	if err := api.handleAwaitApproval(context.Background(), apiPR); err != nil {
+		t.Fatalf("handleAwaitApproval() error = %v", err)
+	}
+	if !apiPR.Approved || apiPR.Stage != StageAwaitApproval || len(gh.merged) != 0 {
+		t.Fatalf("api approved = %v, stage = %s, merges = %v; want approved and held", apiPR.Approved, apiPR.Stage, gh.merged)
+	}
+	if waiting := linked.Waiting("acme/web", 7); len(waiting) != 0 {
+		t.Errorf("Waiting() for web with api approved = %v", waiting)
+	}
+	if err := web.handleAwaitApproval(context.Background(), webPR); err != nil {
+		t.Fatalf("handleAwaitApproval() error = %v", err)
+	}
+	if webPR.Stage != StageMerged || len(gh.merged) != 1 {
+		t.Errorf("web stage = %s, merges = %v; want merged", webPR.Stage, gh.merged)
+	}
+
+	// New commits on api drop its approval
+	apiPR.resetHead()
+	if apiPR.Approved {
+		t.Error("approval kept after the head changed")
+	}
+}
+
+func TestLinkedMerges_RechecksGroupBeforeMerge(t *testing.T) {
+	_, api, web, gh := newLinkedControllersWithGitHub(t)
+
+	apiPR, _ := api.GetPRState(3)
+	webPR, _ := web.GetPRState(7)
+	apiPR.Stage = StageMerging
+	webPR.Stage = StageMerging
+
+	// web got new commits its controller has not seen yet
+	gh.set("/repos/acme/web/pulls/7", "ccc333", github.ConclusionSuccess)
+	if !api.heldByLinkedPRs(context.Background(), apiPR) {
+		t.Error("api merged while web's head moved")
+	}
+
+	// web's CI turned red on the same head
+	gh.set("/repos/acme/web/pulls/7", "bbb222", github.ConclusionFailure)
+	if !api.heldByLinkedPRs(context.Background(), apiPR) {
+		t.Error("api merged while web's CI fails")
+	}
+
+	gh.set("/repos/acme/web/pulls/7", "bbb222", github.ConclusionSuccess)
+	if api.heldByLinkedPRs(context.Background(), apiPR) {
+		t.Error("api held with web still green")
+	}
+}
+
+func TestLinkedMerges_AlertsWhenHalfMerged(t *testing.T) {
+	linked, _, web := newLinkedControllers(t)
+	rec := &recordingAlerts{}
+	web.SetAlertProcessor(rec)
+
+	webPR, _ := web.GetPRState(7)
+	webPR.Error = "merge rejected"
+
+	// Nothing merged yet: the group just stays held
+	web.linkedPRFailed(webPR)
+	if len(rec.events) != 0 {
+		t.Fatalf("alerts = %+v, want none before any merge", rec.events)
+	}
+
+	linked.markMerged("acme/api", 3)
+	web.linkedPRFailed(webPR)
+	web.linkedPRFailed(webPR)
+	if len(rec.events) != 1 || rec.events[0].Type != alerts.EventTypeLinkedMergeIncomplete {
+		t.Fatalf("alerts = %+v, want one linked_merge_incomplete", rec.events)
+	}
+	if got := rec.events[0].Metadata["merged"]; got != "acme/api#3" {
+		t.Errorf("merged = %q, want acme/api#3", got)
+	}
+	if g := linked.Group("acme/web", 7); g == nil || !g.Incomplete {
+		t.Errorf("group = %+v, want it kept and marked incomplete", g)
+	}
+}
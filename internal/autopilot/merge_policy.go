@@ -0,0 +1,345 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// MergeDecision is the merge policy outcome for a PR.
+type MergeDecision string
+
+const (
+	// MergeDecisionAutoMerge lets autopilot merge once CI passes.
+	MergeDecisionAutoMerge MergeDecision = "auto_merge"
+	// MergeDecisionRequireReview holds the PR for human approval.
+	MergeDecisionRequireReview MergeDecision = "require_review"
+)
+
+// MergePolicyConfig decides per PR whether autopilot merges on its own or
+// waits for review. When configured, it replaces the environment's
+// require_approval switch.
+//
+// Rules are evaluated in order. A matching rule with a decision ends
+// evaluation; otherwise matching rules add their risk, and review is
+// required once the total reaches ReviewThreshold.
+type MergePolicyConfig struct {
+	// ReviewThreshold is the risk score at which review is required (default 1).
+	ReviewThreshold int `yaml:"review_threshold,omitempty"`
+	// Rules are the policy rules, evaluated in order.
+	Rules []MergePolicyRule `yaml:"rules"`
+}
+
+// MergePolicyRule adds risk to, or decides, PRs matching its conditions.
+type MergePolicyRule struct {
+	// Name identifies the rule in explanations.
+	Name string `yaml:"name"`
+	// When lists the conditions; all must hold. An empty When matches every PR.
+	When MergePolicyCondition `yaml:"when"`
+	// Risk is added to the PR's risk score when the rule matches.
+	Risk int `yaml:"risk,omitempty"`
+	// Decision, when set, decides the PR and stops evaluation.
+	Decision MergeDecision `yaml:"decision,omitempty"`
+}
+
+// MergePolicyCondition describes the PRs a rule matches. Zero values are
+// ignored.
+type MergePolicyCondition struct {
+	// MinLinesChanged matches PRs with at least this many added+deleted lines.
+	MinLinesChanged int `yaml:"min_lines_changed,omitempty"`
+	// MaxLinesChanged matches PRs with at most this many added+deleted lines.
+	MaxLinesChanged int `yaml:"max_lines_changed,omitempty"`
+	// MinFilesChanged matches PRs touching at least this many files.
+	MinFilesChanged int `yaml:"min_files_changed,omitempty"`
+	// Paths matches PRs touching any file matching one of the globs.
+	Paths []string `yaml:"paths,omitempty"`
+	// OnlyPaths matches PRs whose files all match one of the globs.
+	OnlyPaths []string `yaml:"only_paths,omitempty"`
+	// ConfidenceBelow matches PRs whose intent judge confidence is known and
+	// below this value.
+	ConfidenceBelow float64 `yaml:"confidence_below,omitempty"`
+	// MinCIFailures matches PRs with at least this many earlier CI failures
+	// (CI fix iterations of the originating issue).
+	MinCIFailures int `yaml:"min_ci_failures,omitempty"`
+	// Authors matches PRs opened by one of these logins.
+	Authors []string `yaml:"authors,omitempty"`
+}
+
+// MergePolicyInput holds the PR facts the policy is evaluated against.
+type MergePolicyInput struct {
+	LinesChanged int
+	FilesChanged int
+	Paths        []string
+	// Confidence is the intent judge confidence (0 when unknown).
+	Confidence float64
+	CIFailures int
+	Author     string
+}
+
+// MergePolicyMatch records a rule that matched and why.
+type MergePolicyMatch struct {
+	Rule     string
+	Risk     int
+	Decision MergeDecision
+	Reasons  []string
+}
+
+// MergeEvaluation is the policy outcome with its explanation.
+type MergeEvaluation struct {
+	Decision  MergeDecision
+	Risk      int
+	Threshold int
+	// DecidedBy names the rule whose decision ended evaluation, empty when
+	// the risk score decided.
+	DecidedBy string
+	Matches   []MergePolicyMatch
+	Input     MergePolicyInput
+}
+
+// RequireReview reports whether the PR must wait for human approval.
+func (e *MergeEvaluation) RequireReview() bool {
+	return e.Decision == MergeDecisionRequireReview
+}
+
+// Explain renders the evaluation for humans.
+func (e *MergeEvaluation) Explain() string {
+	var sb strings.Builder
+	in := e.Input
+	sb.WriteString(fmt.Sprintf("Decision: %s\n", e.Decision))
+	if e.DecidedBy != "" {
+		sb.WriteString(fmt.Sprintf("Decided by rule %q\n", e.DecidedBy))
+	} else {
+		sb.WriteString(fmt.Sprintf("Risk score: %d (review at %d)\n", e.Risk, e.Threshold))
+	}
+
+	sb.WriteString("\nInputs:\n")
+	sb.WriteString(fmt.Sprintf("  Lines changed: %d in %d file(s)\n", in.LinesChanged, in.FilesChanged))
+	if in.Confidence > 0 {
+		sb.WriteString(fmt.Sprintf("  Confidence:    %.2f\n", in.Confidence))
+	} else {
+		sb.WriteString("  Confidence:    unknown\n")
+	}
+	sb.WriteString(fmt.Sprintf("  CI failures:   %d\n", in.CIFailures))
+	if in.Author != "" {
+		sb.WriteString(fmt.Sprintf("  Author:        %s\n", in.Author))
+	}
+
+	if len(e.Matches) == 0 {
+		sb.WriteString("\nNo rules matched\n")
+		return sb.String()
+	}
+	sb.WriteString("\nMatched rules:\n")
+	for _, m := range e.Matches {
+		effect := fmt.Sprintf("+%d risk", m.Risk)
+		if m.Decision != "" {
+			effect = string(m.Decision)
+		}
+		sb.WriteString(fmt.Sprintf("  %s (%s): %s\n", m.Rule, effect, strings.Join(m.Reasons, "; ")))
+	}
+	return sb.String()
+}
+
+// Validate checks rule names, decisions and path globs.
+func (p *MergePolicyConfig) Validate() error {
+	if p.ReviewThreshold < 0 {
+		return fmt.Errorf("review_threshold must be >= 0, got %d", p.ReviewThreshold)
+	}
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: name is required", i+1)
+		}
+		switch rule.Decision {
+		case "", MergeDecisionAutoMerge, MergeDecisionRequireReview:
+		default:
+			return fmt.Errorf("rule %q: unknown decision %q (use auto_merge or require_review)", rule.Name, rule.Decision)
+		}
+		if rule.Decision == "" && rule.Risk == 0 {
+			return fmt.Errorf("rule %q: set risk or decision", rule.Name)
+		}
+		for _, pattern := range append(append([]string{}, rule.When.Paths...), rule.When.OnlyPaths...) {
+			if _, err := filepath.Match(strings.TrimPrefix(pattern, "**/"), ""); err != nil {
+				return fmt.Errorf("rule %q: invalid path pattern %q: %w", rule.Name, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate applies the policy to a PR.
+func (p *MergePolicyConfig) Evaluate(in MergePolicyInput) *MergeEvaluation {
+	eval := &MergeEvaluation{
+		Decision:  MergeDecisionAutoMerge,
+		Threshold: p.ReviewThreshold,
+		Input:     in,
+	}
+	if eval.Threshold <= 0 {
+		eval.Threshold = 1
+	}
+
+	for _, rule := range p.Rules {
+		reasons, ok := rule.When.match(in)
+		if !ok {
+			continue
+		}
+		eval.Matches = append(eval.Matches, MergePolicyMatch{
+			Rule:     rule.Name,
+			Risk:     rule.Risk,
+			Decision: rule.Decision,
+			Reasons:  reasons,
+		})
+		if rule.Decision != "" {
+			eval.Decision = rule.Decision
+			eval.DecidedBy = rule.Name
+			return eval
+		}
+		eval.Risk += rule.Risk
+	}
+
+	if eval.Risk >= eval.Threshold {
+		eval.Decision = MergeDecisionRequireReview
+	}
+	return eval
+}
+
+// match reports whether every set condition holds, with a reason for each.
+func (c MergePolicyCondition) match(in MergePolicyInput) ([]string, bool) {
+	var reasons []string
+	if c.MinLinesChanged > 0 {
+		if in.LinesChanged < c.MinLinesChanged {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("%d lines changed >= %d", in.LinesChanged, c.MinLinesChanged))
+	}
+	if c.MaxLinesChanged > 0 {
+		if in.LinesChanged > c.MaxLinesChanged {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("%d lines changed <= %d", in.LinesChanged, c.MaxLinesChanged))
+	}
+	if c.MinFilesChanged > 0 {
+		if in.FilesChanged < c.MinFilesChanged {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("%d files changed >= %d", in.FilesChanged, c.MinFilesChanged))
+	}
+	if len(c.Paths) > 0 {
+		file, pattern := firstPathMatch(c.Paths, in.Paths)
+		if file == "" {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("%s matches %s", file, pattern))
+	}
+	if len(c.OnlyPaths) > 0 {
+		if len(in.Paths) == 0 {
+			return nil, false
+		}
+		for _, file := range in.Paths {
+			if f, _ := firstPathMatch(c.OnlyPaths, []string{file}); f == "" {
+				return nil, false
+			}
+		}
+		reasons = append(reasons, fmt.Sprintf("all files match %s", strings.Join(c.OnlyPaths, ", ")))
+	}
+	if c.ConfidenceBelow > 0 {
+		if in.Confidence <= 0 || in.Confidence >= c.ConfidenceBelow {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("confidence %.2f < %.2f", in.Confidence, c.ConfidenceBelow))
+	}
+	if c.MinCIFailures > 0 {
+		if in.CIFailures < c.MinCIFailures {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("%d CI failures >= %d", in.CIFailures, c.MinCIFailures))
+	}
+	if len(c.Authors) > 0 {
+		found := false
+		for _, author := range c.Authors {
+			found = found || strings.EqualFold(author, in.Author)
+		}
+		if !found {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("author %s", in.Author))
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "always")
+	}
+	return reasons, true
+}
+
+// firstPathMatch returns the first file matching any pattern, and the pattern.
+func firstPathMatch(patterns, files []string) (string, string) {
+	for _, file := range files {
+		for _, pattern := range patterns {
+			if matchPolicyPath(pattern, file) {
+				return file, pattern
+			}
+		}
+	}
+	return "", ""
+}
+
+// matchPolicyPath matches a repository path against a glob. "dir/" and
+// "dir/**" match everything under dir; a leading "**/" matches at any depth.
+func matchPolicyPath(pattern, file string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		pattern = dir + "/"
+	}
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		return strings.HasPrefix(file, dir+"/")
+	}
+	if ok, _ := filepath.Match(pattern, file); ok {
+		return true
+	}
+	rest, ok := strings.CutPrefix(pattern, "**/")
+	if !ok {
+		return false
+	}
+	parts := strings.Split(file, "/")
+	for i := range parts {
+		if ok, _ := filepath.Match(rest, strings.Join(parts[i:], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateMergePolicy gathers the PR's diff size, files, author, intent
+// confidence and CI fix history and evaluates the merge policy. Returns nil
+// when no policy is configured.
+func (m *AutoMerger) EvaluateMergePolicy(ctx context.Context, prState *PRState) (*MergeEvaluation, error) {
+	policy := m.config.MergePolicy
+	if policy == nil {
+		return nil, nil
+	}
+
+	in := MergePolicyInput{Confidence: prState.Confidence}
+
+	pr, err := m.ghClient.GetPullRequest(ctx, m.owner, m.repo, prState.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+	in.Author = pr.User.Login
+
+	files, err := m.ghClient.ListPullRequestFiles(ctx, m.owner, m.repo, prState.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PR files: %w", err)
+	}
+	for _, f := range files {
+		in.Paths = append(in.Paths, f.Filename)
+		in.LinesChanged += f.Additions + f.Deletions
+	}
+	in.FilesChanged = len(files)
+
+	if prState.IssueNumber > 0 {
+		issue, err := m.ghClient.GetIssue(ctx, m.owner, m.repo, prState.IssueNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue #%d: %w", prState.IssueNumber, err)
+		}
+		in.CIFailures = parseAutopilotIteration(issue.Body)
+	}
+
+	return policy.Evaluate(in), nil
+}
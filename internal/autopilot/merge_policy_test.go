@@ -0,0 +1,224 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+func testMergePolicy() *MergePolicyConfig {
+	return &MergePolicyConfig{
+		ReviewThreshold: 3,
+		Rules: []MergePolicyRule{
+			{Name: "docs only", When: MergePolicyCondition{OnlyPaths: []string{"docs/", "**/*.md"}}, Decision: MergeDecisionAutoMerge},
+			{Name: "migrations", When: MergePolicyCondition{Paths: []string{"internal/**/migrations/*.sql"}}, Decision: MergeDecisionRequireReview},
+			{Name: "large diff", When: MergePolicyCondition{MinLinesChanged: 500}, Risk: 2},
+			{Name: "low confidence", When: MergePolicyCondition{ConfidenceBelow: 0.7}, Risk: 2},
+			{Name: "flaky ci", When: MergePolicyCondition{MinCIFailures: 2}, Risk: 1},
+			{Name: "external", When: MergePolicyCondition{Authors: []string{"Contributor"}}, Risk: 3},
+		},
+	}
+}
+
+func TestMergePolicy_Evaluate(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            MergePolicyInput
+		wantDecision  MergeDecision
+		wantRisk      int
+		wantDecidedBy string
+	}{
+		{
+			name:         "small confident change",
+			in:           MergePolicyInput{LinesChanged: 40, FilesChanged: 2, Paths: []string{"cmd/main.go", "README.md"}, Confidence: 0.9},
+			wantDecision: MergeDecisionAutoMerge,
+		},
+		{
+			name:          "docs only",
+			in:            MergePolicyInput{LinesChanged: 900, FilesChanged: 2, Paths: []string{"docs/guide.mdx", "internal/README.md"}, Author: "contributor"},
+			wantDecision:  MergeDecisionAutoMerge,
+			wantDecidedBy: "docs only",
+		},
+		{
+			name:          "migration",
+			in:            MergePolicyInput{LinesChanged: 5, FilesChanged: 1, Paths: []string{"internal/memory/migrations/002.sql"}},
+			wantDecision:  MergeDecisionRequireReview,
+			wantDecidedBy: "migrations",
+		},
+		{
+			name:         "large diff below threshold",
+			in:           MergePolicyInput{LinesChanged: 600, FilesChanged: 8, Paths: []string{"cmd/main.go"}},
+			wantDecision: MergeDecisionAutoMerge,
+			wantRisk:     2,
+		},
+		{
+			name:         "large low confidence diff",
+			in:           MergePolicyInput{LinesChanged: 600, FilesChanged: 8, Paths: []string{"cmd/main.go"}, Confidence: 0.5},
+			wantDecision: MergeDecisionRequireReview,
+			wantRisk:     4,
+		},
+		{
+			name:         "unknown confidence does not match",
+			in:           MergePolicyInput{LinesChanged: 600, FilesChanged: 8, Paths: []string{"cmd/main.go"}, CIFailures: 1},
+			wantDecision: MergeDecisionAutoMerge,
+			wantRisk:     2,
+		},
+		{
+			name:         "external author",
+			in:           MergePolicyInput{LinesChanged: 10, FilesChanged: 1, Paths: []string{"cmd/main.go"}, Author: "contributor"},
+			wantDecision: MergeDecisionRequireReview,
+			wantRisk:     3,
+		},
+	}
+
+	policy := testMergePolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := policy.Evaluate(tt.in)
+			if eval.Decision != tt.wantDecision || eval.Risk != tt.wantRisk || eval.DecidedBy != tt.wantDecidedBy {
+				t.Errorf("Evaluate() = %s risk %d by %q, want %s risk %d by %q\n%s",
+					eval.Decision, eval.Risk, eval.DecidedBy, tt.wantDecision, tt.wantRisk, tt.wantDecidedBy, eval.Explain())
+			}
+		})
+	}
+}
+
+func TestMergePolicy_DefaultThreshold(t *testing.T) {
+	policy := &MergePolicyConfig{Rules: []MergePolicyRule{{Name: "core", When: MergePolicyCondition{Paths: []string{"internal/"}}, Risk: 1}}}
+
+	eval := policy.Evaluate(MergePolicyInput{Paths: []string{"internal/executor/runner.go"}})
+	if !eval.RequireReview() || eval.Threshold != 1 {
+		t.Errorf("Evaluate() = %s threshold %d, want require_review at 1", eval.Decision, eval.Threshold)
+	}
+	explain := eval.Explain()
+	if !strings.Contains(explain, "core (+1 risk): internal/executor/runner.go matches internal/") {
+		t.Errorf("Explain() missing rule reason:\n%s", explain)
+	}
+}
+
+func TestMatchPolicyPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"docs/", "docs/a/b.md", true},
+		{"docs/**", "docs/a.md", true},
+		{"docs/", "docsite/a.md", false},
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"**/*.go", "cmd/pilot/main.go", true},
+		{"**/*.go", "main.go", true},
+		{"cmd/*/main.go", "cmd/pilot/main.go", true},
+	}
+	for _, tt := range tests {
+		if got := matchPolicyPath(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("matchPolicyPath(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestMergePolicyConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    MergePolicyRule
+		wantErr bool
+	}{
+		{"risk rule", MergePolicyRule{Name: "big", When: MergePolicyCondition{MinLinesChanged: 100}, Risk: 1}, false},
+		{"decision rule", MergePolicyRule{Name: "docs", Decision: MergeDecisionAutoMerge}, false},
+		{"missing name", MergePolicyRule{Risk: 1}, true},
+		{"no effect", MergePolicyRule{Name: "noop"}, true},
+		{"unknown decision", MergePolicyRule{Name: "x", Decision: "merge"}, true},
+		{"bad pattern", MergePolicyRule{Name: "x", Risk: 1, When: MergePolicyCondition{Paths: []string{"[a"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &MergePolicyConfig{Rules: []MergePolicyRule{tt.rule}}
+			if err := policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAutoMerger_EvaluateMergePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls/42":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"number": 42,
+				"user":   map[string]string{"login": "pilot-bot"},
+			})
+		case "/repos/owner/repo/pulls/42/files":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"filename": "internal/db/migrations/003.sql", "additions": 30, "deletions": 2},
+				{"filename": "internal/db/store.go", "additions": 10, "deletions": 8},
+			})
+		case "/repos/owner/repo/issues/7":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"number": 7,
+				"body":   "Fix CI failure\n\n<!-- autopilot-meta branch:pilot/GH-5 pr:41 iteration:2 -->\n",
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	cfg := DefaultConfig()
+	cfg.MergePolicy = testMergePolicy()
+	merger := NewAutoMerger(ghClient, nil, nil, "owner", "repo", cfg)
+
+	eval, err := merger.EvaluateMergePolicy(context.Background(), &PRState{PRNumber: 42, IssueNumber: 7, Confidence: 0.8})
+	if err != nil {
+		t.Fatalf("EvaluateMergePolicy() error = %v", err)
+	}
+	want := MergePolicyInput{
+		LinesChanged: 50,
+		FilesChanged: 2,
+		Paths:        []string{"internal/db/migrations/003.sql", "internal/db/store.go"},
+		Confidence:   0.8,
+		CIFailures:   2,
+		Author:       "pilot-bot",
+	}
+	got := eval.Input
+	if got.LinesChanged != want.LinesChanged || got.FilesChanged != want.FilesChanged || got.CIFailures != want.CIFailures ||
+		got.Author != want.Author || got.Confidence != want.Confidence || len(got.Paths) != len(want.Paths) {
+		t.Errorf("EvaluateMergePolicy() input = %+v, want %+v", got, want)
+	}
+	if eval.DecidedBy != "migrations" || !eval.RequireReview() {
+		t.Errorf("EvaluateMergePolicy() = %s by %q, want require_review by migrations", eval.Decision, eval.DecidedBy)
+	}
+}
+
+func TestAutoMerger_MergePR_PolicyRequiresReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/merge") {
+			t.Error("PR merged although the policy requires review")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	cfg := DefaultConfig()
+	cfg.Environment = EnvDev
+	cfg.MergePolicy = testMergePolicy()
+	merger := NewAutoMerger(ghClient, nil, nil, "owner", "repo", cfg)
+
+	prState := &PRState{
+		PRNumber:        42,
+		MergeEvaluation: cfg.MergePolicy.Evaluate(MergePolicyInput{Paths: []string{"cmd/main.go"}, Author: "contributor"}),
+	}
+	if err := merger.MergePR(context.Background(), prState); err == nil {
+		t.Error("MergePR() should fail without an approval manager when review is required")
+	}
+}
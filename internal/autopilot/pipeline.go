@@ -0,0 +1,407 @@
+package autopilot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/approval"
+)
+
+// ChangePromotionStatus is where a change stands in its current environment.
+type ChangePromotionStatus string
+
+const (
+	// ChangeSoaking means CI on the change is pending or green but the soak
+	// time has not passed yet.
+	ChangeSoaking ChangePromotionStatus = "soaking"
+	// ChangeAwaitingApproval means the change has soaked and the next
+	// environment requires approval.
+	ChangeAwaitingApproval ChangePromotionStatus = "awaiting_approval"
+	// ChangeComplete means the change reached the last environment.
+	ChangeComplete ChangePromotionStatus = "complete"
+	// ChangeFailed means CI failed on the change or it did not apply to the
+	// next environment's branch. It is not promoted further.
+	ChangeFailed ChangePromotionStatus = "failed"
+)
+
+// ErrChangePromotionDone is returned when promoting a change that is complete
+// or failed.
+var ErrChangePromotionDone = errors.New("change is not in the pipeline anymore")
+
+// ChangePromotion tracks one merged PR moving through the pipeline.
+type ChangePromotion struct {
+	PRNumber    int
+	PRTitle     string
+	IssueNumber int
+	// Stage is the environment the change last entered.
+	Stage string
+	// SHA is the change's commit on Stage's branch.
+	SHA    string
+	Status ChangePromotionStatus
+	Error  string
+	// GreenAt is when CI passed on SHA; zero while it is pending.
+	GreenAt time.Time
+	// History records each environment the change entered.
+	History   []ChangePromotionStep
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ChangePromotionStep records the change entering an environment.
+type ChangePromotionStep struct {
+	Stage      string    `json:"stage"`
+	Branch     string    `json:"branch"`
+	SHA        string    `json:"sha"`
+	ApprovedBy string    `json:"approved_by,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// Pipeline promotes merged changes through the configured environments,
+// recording each change's progress in the state store.
+type Pipeline struct {
+	ghClient    *github.Client
+	owner       string
+	repo        string
+	config      *Config
+	store       *StateStore
+	approvalMgr *approval.Manager
+	ciMonitor   *CIMonitor
+	log         *slog.Logger
+
+	mu sync.Mutex
+	// approving holds PRs with an approval request in flight
+	approving map[int]bool
+}
+
+// NewPipeline creates a pipeline for the autopilot config. approvalMgr may be
+// nil; environments requiring approval then wait for `pilot autopilot
+// promote`. ciMonitor is only needed to Advance changes.
+func NewPipeline(ghClient *github.Client, owner, repo string, cfg *Config, store *StateStore, approvalMgr *approval.Manager, ciMonitor *CIMonitor) *Pipeline {
+	return &Pipeline{
+		ghClient:    ghClient,
+		owner:       owner,
+		repo:        repo,
+		config:      cfg,
+		store:       store,
+		approvalMgr: approvalMgr,
+		ciMonitor:   ciMonitor,
+		log:         slog.Default().With("component", "pipeline"),
+		approving:   make(map[int]bool),
+	}
+}
+
+// Stages returns the environments changes are promoted through, in order.
+func (p *Pipeline) Stages() []PipelineStage {
+	return p.config.PipelineStages()
+}
+
+// NextStage returns the environment the change moves to next, or nil when it
+// is in the last one.
+func (p *Pipeline) NextStage(change *ChangePromotion) *PipelineStage {
+	stages := p.Stages()
+	for i := range stages {
+		if stages[i].Name == change.Stage && i+1 < len(stages) {
+			return &stages[i+1]
+		}
+	}
+	return nil
+}
+
+// Start records a PR merged into the first environment's branch as sha.
+func (p *Pipeline) Start(prState *PRState, sha string) (*ChangePromotion, error) {
+	first := p.Stages()[0]
+	now := time.Now()
+	change := &ChangePromotion{
+		PRNumber:    prState.PRNumber,
+		PRTitle:     prState.PRTitle,
+		IssueNumber: prState.IssueNumber,
+		Stage:       first.Name,
+		SHA:         sha,
+		Status:      ChangeSoaking,
+		History:     []ChangePromotionStep{{Stage: first.Name, Branch: first.Branch, SHA: sha, At: now}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	return change, p.save(change)
+}
+
+// Get returns the recorded promotion of a PR.
+func (p *Pipeline) Get(prNumber int) (*ChangePromotion, error) {
+	change, err := p.store.GetChangePromotion(prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load promotion of PR #%d: %w", prNumber, err)
+	}
+	if change == nil {
+		return nil, fmt.Errorf("PR #%d is not in the pipeline", prNumber)
+	}
+	return change, nil
+}
+
+// List returns the most recently updated changes, newest first.
+func (p *Pipeline) List(limit int) ([]*ChangePromotion, error) {
+	return p.store.ListChangePromotions(limit, false)
+}
+
+// Active returns the changes still moving through the pipeline.
+func (p *Pipeline) Active() ([]*ChangePromotion, error) {
+	return p.store.ListChangePromotions(-1, true)
+}
+
+// Advance checks CI on a change in its current environment and promotes it
+// once CI has stayed green for the soak time. A next environment requiring
+// approval is requested through the pre_promotion approval stage, or waits
+// for `pilot autopilot promote`.
+func (p *Pipeline) Advance(ctx context.Context, change *ChangePromotion) error {
+	if change.Status == ChangeComplete || change.Status == ChangeFailed {
+		return nil
+	}
+
+	status, err := p.ciMonitor.CheckCI(ctx, change.SHA)
+	if err != nil {
+		p.log.Warn("pipeline CI status check failed", "pr", change.PRNumber, "error", err)
+		return nil // Transient, retry next cycle
+	}
+	switch status {
+	case CIFailure:
+		change.Status = ChangeFailed
+		change.Error = fmt.Sprintf("CI failed in %s", change.Stage)
+		p.log.Warn("pipeline CI failed, not promoting", "pr", change.PRNumber, "stage", change.Stage, "sha", ShortSHA(change.SHA))
+		return p.save(change)
+	case CIPending, CIRunning:
+		return nil
+	}
+
+	if change.GreenAt.IsZero() {
+		change.GreenAt = time.Now()
+		if err := p.save(change); err != nil {
+			return err
+		}
+	}
+	if time.Since(change.GreenAt) < p.config.Pipeline.ResolvedSoakTime() {
+		return nil
+	}
+
+	next := p.NextStage(change)
+	if next == nil {
+		change.Status = ChangeComplete
+		return p.save(change)
+	}
+	if !next.RequireApproval {
+		return p.promote(ctx, change, next, "")
+	}
+
+	if change.Status != ChangeAwaitingApproval {
+		change.Status = ChangeAwaitingApproval
+		if err := p.save(change); err != nil {
+			return err
+		}
+	}
+	p.requestApproval(ctx, change, next)
+	return nil
+}
+
+// Promote moves a PR's change into the next environment, approved by
+// approvedBy (e.g. the CLI user), without waiting for the soak time.
+func (p *Pipeline) Promote(ctx context.Context, prNumber int, approvedBy string) (*ChangePromotion, error) {
+	change, err := p.Get(prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status == ChangeComplete || change.Status == ChangeFailed {
+		return change, fmt.Errorf("%w: PR #%d is %s", ErrChangePromotionDone, prNumber, change.Status)
+	}
+	next := p.NextStage(change)
+	if next == nil {
+		return change, fmt.Errorf("%w: PR #%d is in the last environment", ErrChangePromotionDone, prNumber)
+	}
+	return change, p.promote(ctx, change, next, approvedBy)
+}
+
+// promote cherry-picks the change onto the next environment's branch. A
+// change that does not apply is marked failed.
+func (p *Pipeline) promote(ctx context.Context, change *ChangePromotion, next *PipelineStage, approvedBy string) error {
+	sha, err := p.ghClient.CherryPickCommit(ctx, p.owner, p.repo, next.Branch, change.SHA)
+	if err != nil {
+		if errors.Is(err, github.ErrCherryPickConflict) {
+			change.Status = ChangeFailed
+			change.Error = fmt.Sprintf("conflicts with %s", next.Branch)
+			if saveErr := p.save(change); saveErr != nil {
+				return saveErr
+			}
+		}
+		return fmt.Errorf("failed to promote PR #%d to %s: %w", change.PRNumber, next.Name, err)
+	}
+	p.log.Info("change promoted", "pr", change.PRNumber, "from", change.Stage, "to", next.Name, "sha", ShortSHA(sha))
+
+	now := time.Now()
+	change.Stage = next.Name
+	change.SHA = sha
+	change.GreenAt = time.Time{}
+	change.Status = ChangeSoaking
+	if p.NextStage(change) == nil {
+		change.Status = ChangeComplete
+	}
+	change.History = append(change.History, ChangePromotionStep{
+		Stage:      next.Name,
+		Branch:     next.Branch,
+		SHA:        sha,
+		ApprovedBy: approvedBy,
+		At:         now,
+	})
+	return p.save(change)
+}
+
+// requestApproval asks for approval of the change's promotion in the
+// background, promoting it once approved. Without an approver the change
+// waits for `pilot autopilot promote`.
+func (p *Pipeline) requestApproval(ctx context.Context, change *ChangePromotion, next *PipelineStage) {
+	if p.approvalMgr == nil || !p.approvalMgr.IsStageEnabled(approval.StagePrePromotion) {
+		return
+	}
+	p.mu.Lock()
+	if p.approving[change.PRNumber] {
+		p.mu.Unlock()
+		return
+	}
+	p.approving[change.PRNumber] = true
+	p.mu.Unlock()
+
+	// Approval may wait for hours; it must not hold up the PR loop.
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			delete(p.approving, change.PRNumber)
+			p.mu.Unlock()
+		}()
+		ctx := context.WithoutCancel(ctx)
+		result, err := p.approvalMgr.RequestApproval(ctx, &approval.Request{
+			ID:          fmt.Sprintf("pipeline-%d-%s", change.PRNumber, next.Name),
+			TaskID:      fmt.Sprintf("pr-%d", change.PRNumber),
+			Stage:       approval.StagePrePromotion,
+			Title:       fmt.Sprintf("Promote PR #%d to %s", change.PRNumber, next.Name),
+			Description: fmt.Sprintf("%s is green in %s (%s). Promote it to %s?", change.PRTitle, change.Stage, ShortSHA(change.SHA), next.Name),
+			Metadata: map[string]interface{}{
+				"pr_number": change.PRNumber,
+				"sha":       change.SHA,
+				"stage":     next.Name,
+			},
+		})
+		if err != nil {
+			p.log.Warn("pipeline approval failed", "pr", change.PRNumber, "stage", next.Name, "error", err)
+			return
+		}
+		if result.Decision != approval.DecisionApproved {
+			p.log.Info("pipeline promotion not approved", "pr", change.PRNumber, "stage", next.Name, "decision", result.Decision)
+			return
+		}
+		if _, err := p.Promote(ctx, change.PRNumber, result.ApprovedBy); err != nil {
+			p.log.Warn("pipeline promotion failed", "pr", change.PRNumber, "stage", next.Name, "error", err)
+		}
+	}()
+}
+
+// save records the change, stamping its update time.
+func (p *Pipeline) save(change *ChangePromotion) error {
+	change.UpdatedAt = time.Now()
+	if err := p.store.SaveChangePromotion(change); err != nil {
+		return fmt.Errorf("failed to record promotion of PR #%d: %w", change.PRNumber, err)
+	}
+	return nil
+}
+
+// retargetToPipeline points the PR at the first environment's branch,
+// creating the branch from the PR's base when missing.
+func (c *Controller) retargetToPipeline(ctx context.Context, prState *PRState) error {
+	first := c.pipeline.Stages()[0].Branch
+	if prState.TargetBranch == first {
+		return nil
+	}
+	if _, err := c.ghClient.GetBranch(ctx, c.owner, c.repo, first); err != nil {
+		base := prState.TargetBranch
+		if base == "" {
+			base = "main"
+		}
+		baseBranch, err := c.ghClient.GetBranch(ctx, c.owner, c.repo, base)
+		if err != nil {
+			return fmt.Errorf("failed to get base branch %s: %w", base, err)
+		}
+		c.log.Info("creating pipeline branch", "branch", first, "from", base)
+		if err := c.ghClient.UpdateRef(ctx, c.owner, c.repo, first, baseBranch.SHA()); err != nil {
+			return fmt.Errorf("failed to create pipeline branch %s: %w", first, err)
+		}
+	}
+	if err := c.ghClient.UpdatePullRequestBase(ctx, c.owner, c.repo, prState.PRNumber, first); err != nil {
+		return fmt.Errorf("failed to retarget PR to %s: %w", first, err)
+	}
+	prState.TargetBranch = first
+	return nil
+}
+
+// startPipeline records a PR merged into the first environment so it is
+// promoted from there.
+func (c *Controller) startPipeline(ctx context.Context, prState *PRState) {
+	pr, err := c.ghClient.GetPullRequest(ctx, c.owner, c.repo, prState.PRNumber)
+	if err != nil || pr.MergeCommitSHA == "" {
+		c.log.Warn("failed to get merge commit, PR not added to pipeline", "pr", prState.PRNumber, "error", err)
+		return
+	}
+	change, err := c.pipeline.Start(prState, pr.MergeCommitSHA)
+	if err != nil {
+		c.log.Error("failed to start pipeline", "pr", prState.PRNumber, "error", err)
+		return
+	}
+	c.log.Info("change entered pipeline", "pr", change.PRNumber, "stage", change.Stage, "sha", ShortSHA(change.SHA))
+}
+
+// advancePipeline advances every change still in the pipeline, opening a fix
+// issue for changes that fail.
+func (c *Controller) advancePipeline(ctx context.Context) {
+	if c.freeze.Status().Frozen {
+		return
+	}
+	changes, err := c.pipeline.Active()
+	if err != nil {
+		c.log.Warn("failed to list pipeline changes", "error", err)
+		return
+	}
+	for _, change := range changes {
+		err := c.pipeline.Advance(ctx, change)
+		if err != nil {
+			c.log.Warn("failed to advance pipeline change", "pr", change.PRNumber, "stage", change.Stage, "error", err)
+		}
+		if change.Status == ChangeFailed {
+			c.failPipelineChange(ctx, change, errors.Is(err, github.ErrCherryPickConflict))
+		}
+	}
+}
+
+// failPipelineChange opens a fix issue for a change that failed CI or did not
+// apply to the next environment's branch.
+func (c *Controller) failPipelineChange(ctx context.Context, change *ChangePromotion, conflict bool) {
+	prState := &PRState{
+		PRNumber:    change.PRNumber,
+		PRTitle:     change.PRTitle,
+		IssueNumber: change.IssueNumber,
+		HeadSHA:     change.SHA,
+	}
+	failureType := FailureMerge
+	var failedChecks []string
+	var analysis *CILogAnalysis
+	if !conflict {
+		failureType = FailureCIPipeline
+		failedChecks, _ = c.ciMonitor.GetFailedChecks(ctx, change.SHA)
+		analysis = c.ciMonitor.AnalyzeFailedChecks(ctx, change.SHA)
+	}
+
+	issueNum, err := c.feedbackLoop.CreateCIFailureIssue(ctx, prState, failureType, failedChecks, analysis, 1)
+	if err != nil {
+		c.log.Error("failed to create pipeline fix issue", "error", err)
+	} else {
+		c.log.Info("created fix issue for pipeline failure", "pr", change.PRNumber, "stage", change.Stage, "issue", issueNum)
+	}
+}
@@ -0,0 +1,241 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// pipelineConfig returns a config promoting dev → stage → prod, each on its
+// own branch, with approval required for prod.
+func pipelineConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.RequiredChecks = []string{"build"}
+	cfg.Pipeline = &PipelineConfig{Enabled: true, SoakTime: time.Hour}
+	cfg.Environments = map[string]*EnvironmentConfig{
+		"dev":   {Branch: "develop"},
+		"stage": {Branch: "staging"},
+		"prod":  {Branch: "main", RequireApproval: true},
+	}
+	return cfg
+}
+
+func TestConfig_ValidatePipeline(t *testing.T) {
+	if err := pipelineConfig().ValidatePipeline(); err != nil {
+		t.Fatalf("ValidatePipeline() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{"default branches shared", func(c *Config) { c.Environments = nil }, "share branch"},
+		{"one environment", func(c *Config) { c.Pipeline.Environments = []string{"dev"} }, "at least two"},
+		{"unknown environment", func(c *Config) { c.Pipeline.Environments = []string{"dev", "qa"} }, `unknown environment "qa"`},
+		{"canary", func(c *Config) { c.Canary = &CanaryConfig{Enabled: true} }, "canary"},
+		{"merge queue", func(c *Config) { c.Environments["stage"].MergeQueue = true }, "merge_queue"},
+		{"negative soak", func(c *Config) { c.Pipeline.SoakTime = -time.Minute }, "soak_time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := pipelineConfig()
+			tt.modify(cfg)
+			if err := cfg.ValidatePipeline(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidatePipeline() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStateStore_ChangePromotions(t *testing.T) {
+	store := newTestStateStore(t)
+
+	now := time.Now().Truncate(time.Second)
+	for _, p := range []*ChangePromotion{
+		{PRNumber: 1, Stage: "prod", SHA: "a", Status: ChangeComplete, UpdatedAt: now.Add(-time.Hour)},
+		{PRNumber: 2, PRTitle: "feat: caching", IssueNumber: 7, Stage: "stage", SHA: "b", Status: ChangeSoaking, GreenAt: now,
+			History: []ChangePromotionStep{{Stage: "dev", Branch: "develop", SHA: "b0", At: now}, {Stage: "stage", Branch: "staging", SHA: "b", At: now}}, UpdatedAt: now},
+		{PRNumber: 3, Stage: "dev", SHA: "c", Status: ChangeFailed, Error: "CI failed in dev", UpdatedAt: now},
+	} {
+		if err := store.SaveChangePromotion(p); err != nil {
+			t.Fatalf("SaveChangePromotion() error = %v", err)
+		}
+	}
+
+	got, err := store.GetChangePromotion(2)
+	if err != nil || got == nil {
+		t.Fatalf("GetChangePromotion() = %v, %v", got, err)
+	}
+	if got.PRTitle != "feat: caching" || got.IssueNumber != 7 || got.Status != ChangeSoaking || !got.GreenAt.Equal(now) ||
+		len(got.History) != 2 || got.History[0].Branch != "develop" {
+		t.Errorf("GetChangePromotion() = %+v", got)
+	}
+	if missing, err := store.GetChangePromotion(99); missing != nil || err != nil {
+		t.Errorf("GetChangePromotion(missing) = %v, %v", missing, err)
+	}
+
+	all, _ := store.ListChangePromotions(10, false)
+	active, _ := store.ListChangePromotions(-1, true)
+	if len(all) != 3 || all[2].PRNumber != 1 || len(active) != 1 || active[0].PRNumber != 2 {
+		t.Errorf("all = %d, active = %d", len(all), len(active))
+	}
+}
+
+// pipelineServer fakes the check runs and cherry-picks the pipeline uses.
+// Cherry-picked commits are named after the branch they land on.
+type pipelineServer struct {
+	mu         sync.Mutex
+	conclusion string
+	conflict   bool
+	heads      map[string]string
+}
+
+func (s *pipelineServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo")
+	switch {
+	case strings.HasSuffix(path, "/check-runs"):
+		_ = json.NewEncoder(w).Encode(github.CheckRunsResponse{
+			TotalCount: 1,
+			CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: s.conclusion}},
+		})
+	case strings.HasPrefix(path, "/git/commits/"):
+		sha := strings.TrimPrefix(path, "/git/commits/")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"sha": sha, "message": "feat: caching", "tree": map[string]string{"sha": "tree-" + sha}, "parents": []map[string]string{{"sha": "parent"}},
+		})
+	case strings.HasPrefix(path, "/branches/"):
+		name := strings.TrimPrefix(path, "/branches/")
+		_ = json.NewEncoder(w).Encode(github.Branch{Name: name, Commit: github.BranchCommit{SHA: name + "-head"}})
+	case path == "/git/commits":
+		var body struct {
+			Parents []string `json:"parents"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"sha": "on-" + strings.TrimSuffix(body.Parents[0], "-head")})
+	case path == "/merges":
+		if s.conflict {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"sha":"merge"}`))
+	case strings.HasPrefix(path, "/git/refs/heads/pilot/"):
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodPatch && strings.HasPrefix(path, "/git/refs/heads/"):
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.heads[strings.TrimPrefix(path, "/git/refs/heads/")] = body["sha"].(string)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPipeline_Advance(t *testing.T) {
+	fake := &pipelineServer{conclusion: "success", heads: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cfg := pipelineConfig()
+	ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	pipeline := NewPipeline(ghClient, "owner", "repo", cfg, newTestStateStore(t), nil, NewCIMonitor(ghClient, "owner", "repo", cfg))
+	ctx := context.Background()
+
+	change, err := pipeline.Start(&PRState{PRNumber: 42, PRTitle: "feat: caching"}, "dev-sha")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// Green, but still soaking in dev
+	if err := pipeline.Advance(ctx, change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Stage != "dev" || change.Status != ChangeSoaking || change.GreenAt.IsZero() {
+		t.Fatalf("after first check: %+v", change)
+	}
+
+	// Soaked: promoted to stage without approval
+	change.GreenAt = time.Now().Add(-2 * time.Hour)
+	if err := pipeline.Advance(ctx, change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Stage != "stage" || change.SHA != "on-staging" || fake.heads["staging"] != "on-staging" || !change.GreenAt.IsZero() {
+		t.Fatalf("after soak in dev: %+v, heads %v", change, fake.heads)
+	}
+
+	// prod requires approval; without an approver it waits for the CLI
+	_ = pipeline.Advance(ctx, change)
+	change.GreenAt = time.Now().Add(-2 * time.Hour)
+	if err := pipeline.Advance(ctx, change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Stage != "stage" || change.Status != ChangeAwaitingApproval || fake.heads["main"] != "" {
+		t.Fatalf("before approval: %+v", change)
+	}
+
+	change, err = pipeline.Promote(ctx, 42, "alice")
+	if err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	if change.Stage != "prod" || change.Status != ChangeComplete || fake.heads["main"] != "on-main" {
+		t.Fatalf("after approval: %+v", change)
+	}
+	if len(change.History) != 3 || change.History[2].ApprovedBy != "alice" || change.History[1].ApprovedBy != "" {
+		t.Errorf("history = %+v", change.History)
+	}
+	if _, err := pipeline.Promote(ctx, 42, "alice"); err == nil {
+		t.Error("Promote() of a complete change should fail")
+	}
+
+	stored, err := pipeline.Get(42)
+	if err != nil || stored.Stage != "prod" || len(stored.History) != 3 {
+		t.Errorf("Get() = %+v, %v", stored, err)
+	}
+}
+
+func TestPipeline_AdvanceFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		conclusion string
+		conflict   bool
+		wantErr    string
+	}{
+		{name: "red CI", conclusion: "failure", wantErr: "CI failed in dev"},
+		{name: "conflict", conclusion: "success", conflict: true, wantErr: "conflicts with staging"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &pipelineServer{conclusion: tt.conclusion, conflict: tt.conflict, heads: make(map[string]string)}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			cfg := pipelineConfig()
+			ghClient := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+			pipeline := NewPipeline(ghClient, "owner", "repo", cfg, newTestStateStore(t), nil, NewCIMonitor(ghClient, "owner", "repo", cfg))
+
+			change, _ := pipeline.Start(&PRState{PRNumber: 42}, "dev-sha")
+			_ = pipeline.Advance(context.Background(), change)
+			change.GreenAt = time.Now().Add(-2 * time.Hour)
+			_ = pipeline.Advance(context.Background(), change)
+			if change.Status != ChangeFailed || change.Error != tt.wantErr || len(fake.heads) != 0 {
+				t.Errorf("change = %+v, heads %v", change, fake.heads)
+			}
+			if active, _ := pipeline.Active(); len(active) != 0 {
+				t.Errorf("failed change still active: %+v", active)
+			}
+		})
+	}
+}
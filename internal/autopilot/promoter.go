@@ -0,0 +1,317 @@
+package autopilot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/approval"
+)
+
+// PromotionStageCandidate is the stage of a release that has only been tagged
+// as a release candidate.
+const PromotionStageCandidate = "candidate"
+
+// ErrPromotionApprovalRequired is returned when the next stage requires
+// approval and no approver is available (pre_promotion approval disabled).
+var ErrPromotionApprovalRequired = errors.New("promotion requires approval")
+
+// ErrPromotionRejected is returned when a promotion approval is rejected.
+var ErrPromotionRejected = errors.New("promotion rejected")
+
+// ErrPromotionComplete is returned when a release has no stage left to promote to.
+var ErrPromotionComplete = errors.New("release already promoted through all stages")
+
+// Promotion tracks a release moving through the promotion pipeline.
+type Promotion struct {
+	// Version is the release version with tag prefix (e.g. "v1.3.0").
+	Version string
+	// SHA is the commit every stage tag points at.
+	SHA string
+	// Stage is the last stage reached: PromotionStageCandidate or a stage name.
+	Stage string
+	// History records each step, starting with the candidate tag.
+	History   []PromotionStep
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PromotionStep records one promotion.
+type PromotionStep struct {
+	Stage       string    `json:"stage"`
+	Tag         string    `json:"tag"`
+	ApprovedBy  string    `json:"approved_by,omitempty"`
+	DeployError string    `json:"deploy_error,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// LatestTag returns the tag of the last step.
+func (p *Promotion) LatestTag() string {
+	if len(p.History) == 0 {
+		return ""
+	}
+	return p.History[len(p.History)-1].Tag
+}
+
+// Promoter tags release candidates and promotes them through the configured
+// environments, running each stage's deploy action and recording every step
+// in the state store.
+type Promoter struct {
+	ghClient    *github.Client
+	owner       string
+	repo        string
+	tagPrefix   string
+	config      *PromotionConfig
+	store       *StateStore
+	approvalMgr *approval.Manager
+	log         *slog.Logger
+}
+
+// NewPromoter creates a promoter for the release's promotion config.
+// approvalMgr may be nil; stages requiring approval then need an explicit
+// approver passed to Promote.
+func NewPromoter(ghClient *github.Client, owner, repo string, release *ReleaseConfig, store *StateStore, approvalMgr *approval.Manager) *Promoter {
+	config := release.Promotion
+	if config == nil {
+		config = &PromotionConfig{}
+	}
+	return &Promoter{
+		ghClient:    ghClient,
+		owner:       owner,
+		repo:        repo,
+		tagPrefix:   release.TagPrefix,
+		config:      config,
+		store:       store,
+		approvalMgr: approvalMgr,
+		log:         slog.Default().With("component", "promoter"),
+	}
+}
+
+// NormalizeVersion returns version with the release tag prefix, so "1.3.0"
+// and "v1.3.0" name the same promotion.
+func (p *Promoter) NormalizeVersion(version string) (string, error) {
+	v, err := ParseSemVer(version)
+	if err != nil {
+		return "", err
+	}
+	return v.String(p.tagPrefix), nil
+}
+
+// CreateCandidate tags sha as the next release candidate of version
+// (v1.3.0-rc.1, -rc.2, ...), runs the candidate deploy action and records
+// the promotion.
+func (p *Promoter) CreateCandidate(ctx context.Context, version SemVer, sha string) (*Promotion, error) {
+	base := version.String(p.tagPrefix)
+	rc, err := p.nextCandidateNumber(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	tag := fmt.Sprintf("%s-rc.%d", base, rc)
+
+	if err := p.ghClient.CreateGitTag(ctx, p.owner, p.repo, tag, sha); err != nil {
+		return nil, fmt.Errorf("failed to create tag %s: %w", tag, err)
+	}
+	p.log.Info("release candidate tagged", "tag", tag, "sha", ShortSHA(sha))
+
+	now := time.Now()
+	promotion := &Promotion{
+		Version:   base,
+		SHA:       sha,
+		Stage:     PromotionStageCandidate,
+		History:   []PromotionStep{{Stage: PromotionStageCandidate, Tag: tag, At: now}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return promotion, p.deployAndRecord(ctx, promotion, p.config.CandidateDeploy)
+}
+
+// nextCandidateNumber returns one more than the highest existing -rc.N tag
+// for base.
+func (p *Promoter) nextCandidateNumber(ctx context.Context, base string) (int, error) {
+	tags, err := p.ghClient.ListTags(ctx, p.owner, p.repo, 100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tags: %w", err)
+	}
+	highest := 0
+	for _, tag := range tags {
+		n, err := strconv.Atoi(strings.TrimPrefix(tag.Name, base+"-rc."))
+		if err == nil && strings.HasPrefix(tag.Name, base+"-rc.") && n > highest {
+			highest = n
+		}
+	}
+	return highest + 1, nil
+}
+
+// Stages returns the stages a candidate is promoted through, in order.
+func (p *Promoter) Stages() []PromotionStage {
+	return p.config.ResolvedStages()
+}
+
+// NextStage returns the stage promotion would move to next, or nil when the
+// release has been promoted through every stage.
+func (p *Promoter) NextStage(promotion *Promotion) *PromotionStage {
+	stages := p.config.ResolvedStages()
+	if promotion.Stage == PromotionStageCandidate {
+		return &stages[0]
+	}
+	for i := range stages {
+		if stages[i].Name == promotion.Stage && i+1 < len(stages) {
+			return &stages[i+1]
+		}
+	}
+	return nil
+}
+
+// Get returns the recorded promotion for version.
+func (p *Promoter) Get(version string) (*Promotion, error) {
+	if p.store == nil {
+		return nil, fmt.Errorf("promotion requires the autopilot state store")
+	}
+	key, err := p.NormalizeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	promotion, err := p.store.GetPromotion(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load promotion %s: %w", key, err)
+	}
+	if promotion == nil {
+		return nil, fmt.Errorf("no promotion recorded for %s", key)
+	}
+	return promotion, nil
+}
+
+// List returns the most recently updated promotions, newest first.
+func (p *Promoter) List(limit int) ([]*Promotion, error) {
+	if p.store == nil {
+		return nil, fmt.Errorf("promotion requires the autopilot state store")
+	}
+	return p.store.ListPromotions(limit)
+}
+
+// Promote moves version to its next stage. A stage requiring approval is
+// approved by approvedBy when set (e.g. the CLI user); otherwise approval is
+// requested through the pre_promotion approval stage. The stage tag is
+// created on the candidate's commit, then the stage deploy action runs.
+func (p *Promoter) Promote(ctx context.Context, version, approvedBy string) (*Promotion, error) {
+	promotion, err := p.Get(version)
+	if err != nil {
+		return nil, err
+	}
+	stage := p.NextStage(promotion)
+	if stage == nil {
+		return promotion, ErrPromotionComplete
+	}
+
+	if stage.RequireApproval && approvedBy == "" {
+		approvedBy, err = p.requestApproval(ctx, promotion, stage)
+		if err != nil {
+			return promotion, err
+		}
+	}
+
+	tag := promotion.Version + stage.TagSuffix
+	if err := p.ghClient.CreateGitTag(ctx, p.owner, p.repo, tag, promotion.SHA); err != nil {
+		return promotion, fmt.Errorf("failed to create tag %s: %w", tag, err)
+	}
+	p.log.Info("release promoted", "version", promotion.Version, "stage", stage.Name, "tag", tag)
+
+	now := time.Now()
+	promotion.Stage = stage.Name
+	promotion.UpdatedAt = now
+	promotion.History = append(promotion.History, PromotionStep{
+		Stage:      stage.Name,
+		Tag:        tag,
+		ApprovedBy: approvedBy,
+		At:         now,
+	})
+	return promotion, p.deployAndRecord(ctx, promotion, stage.Deploy)
+}
+
+// AutoPromote promotes version stage by stage until a stage needs an approval
+// that is not given or every stage is reached. Stopping at an approval gate
+// is not an error.
+func (p *Promoter) AutoPromote(ctx context.Context, version string) (*Promotion, error) {
+	for {
+		promotion, err := p.Promote(ctx, version, "")
+		switch {
+		case errors.Is(err, ErrPromotionComplete):
+			return promotion, nil
+		case errors.Is(err, ErrPromotionApprovalRequired), errors.Is(err, ErrPromotionRejected):
+			p.log.Info("promotion waiting for approval",
+				"version", promotion.Version,
+				"stage", promotion.Stage,
+				"reason", err,
+			)
+			return promotion, nil
+		case err != nil:
+			return promotion, err
+		}
+	}
+}
+
+// requestApproval asks for approval of a promotion to stage and returns the
+// approver. Unlike pre-merge approval, a disabled pre_promotion stage never
+// auto-approves: the promotion waits for `pilot release promote`.
+func (p *Promoter) requestApproval(ctx context.Context, promotion *Promotion, stage *PromotionStage) (string, error) {
+	if p.approvalMgr == nil || !p.approvalMgr.IsStageEnabled(approval.StagePrePromotion) {
+		return "", fmt.Errorf("%w: promote %s to %s with `pilot release promote %s`",
+			ErrPromotionApprovalRequired, promotion.Version, stage.Name, promotion.Version)
+	}
+
+	result, err := p.approvalMgr.RequestApproval(ctx, &approval.Request{
+		ID:          fmt.Sprintf("promote-%s-%s", promotion.Version, stage.Name),
+		TaskID:      fmt.Sprintf("release-%s", promotion.Version),
+		Stage:       approval.StagePrePromotion,
+		Title:       fmt.Sprintf("Promote %s to %s", promotion.Version, stage.Name),
+		Description: fmt.Sprintf("Release %s is at %s (%s). Promote it to %s?", promotion.Version, promotion.Stage, promotion.LatestTag(), stage.Name),
+		Metadata: map[string]interface{}{
+			"version": promotion.Version,
+			"sha":     promotion.SHA,
+			"stage":   stage.Name,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("promotion approval failed: %w", err)
+	}
+	if result.Decision != approval.DecisionApproved {
+		return "", fmt.Errorf("%w: %s to %s (%s)", ErrPromotionRejected, promotion.Version, stage.Name, result.Decision)
+	}
+	return result.ApprovedBy, nil
+}
+
+// deployAndRecord runs the deploy action for the promotion's latest step and
+// saves the promotion. The step is recorded even when the deploy fails, since
+// its tag already exists; the deploy error is kept on the step.
+func (p *Promoter) deployAndRecord(ctx context.Context, promotion *Promotion, deploy *PostMergeConfig) error {
+	step := &promotion.History[len(promotion.History)-1]
+
+	var deployErr error
+	if deploy != nil {
+		deployer := NewDeployer(p.ghClient, p.owner, p.repo, deploy)
+		deployErr = deployer.DeployRelease(ctx, ReleaseDeployment{
+			Environment: step.Stage,
+			Version:     promotion.Version,
+			Tag:         step.Tag,
+			SHA:         promotion.SHA,
+		})
+		if deployErr != nil {
+			step.DeployError = deployErr.Error()
+		}
+	}
+
+	if p.store != nil {
+		if err := p.store.SavePromotion(promotion); err != nil {
+			return fmt.Errorf("failed to record promotion %s: %w", promotion.Version, err)
+		}
+	}
+	if deployErr != nil {
+		return fmt.Errorf("deploy to %s failed: %w", step.Stage, deployErr)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+)
+
+// promotionServer fakes the GitHub tag API and a deploy webhook.
+type promotionServer struct {
+	mu       sync.Mutex
+	tags     []string
+	deployed []deployWebhookPayload
+}
+
+func (s *promotionServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.URL.Path == "/repos/owner/repo/tags" && r.Method == http.MethodGet:
+			tags := make([]map[string]string, 0, len(s.tags))
+			for _, name := range s.tags {
+				tags = append(tags, map[string]string{"name": name})
+			}
+			_ = json.NewEncoder(w).Encode(tags)
+		case r.URL.Path == "/repos/owner/repo/git/refs" && r.Method == http.MethodPost:
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["sha"] != "merge-sha" {
+				t.Errorf("tag %s on %s, want merge-sha", body["ref"], body["sha"])
+			}
+			s.tags = append(s.tags, body["ref"][len("refs/tags/"):])
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/deploy":
+			var payload deployWebhookPayload
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			s.deployed = append(s.deployed, payload)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// newTestPromoter returns a promoter backed by an in-memory state store and
+// the fake server's URL, so tests can point deploy webhooks at it.
+func newTestPromoter(t *testing.T) (*Promoter, *promotionServer, string) {
+	t.Helper()
+	fake := &promotionServer{tags: []string{"v1.2.0", "v1.3.0-rc.1", "v1.3.0-rc.x"}}
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	store, err := NewStateStoreFromPath(":memory:")
+	if err != nil {
+		t.Fatalf("NewStateStoreFromPath() error = %v", err)
+	}
+	client := github.NewClientWithBaseURL("test-token", server.URL)
+	release := &ReleaseConfig{Enabled: true, TagPrefix: "v", Promotion: &PromotionConfig{Enabled: true}}
+	return NewPromoter(client, "owner", "repo", release, store, nil), fake, server.URL
+}
+
+func TestPromoter_Pipeline(t *testing.T) {
+	ctx := context.Background()
+	promoter, fake, url := newTestPromoter(t)
+	promoter.config = &PromotionConfig{
+		Enabled:         true,
+		CandidateDeploy: &PostMergeConfig{Action: "webhook", WebhookURL: url + "/deploy"},
+		Stages: []PromotionStage{
+			{Name: "staging", TagSuffix: "-staging", Deploy: &PostMergeConfig{Action: "webhook", WebhookURL: url + "/deploy"}},
+			{Name: "prod", RequireApproval: true, Deploy: &PostMergeConfig{Action: "webhook", WebhookURL: url + "/deploy"}},
+		},
+	}
+
+	promotion, err := promoter.CreateCandidate(ctx, SemVer{Major: 1, Minor: 3}, "merge-sha")
+	if err != nil {
+		t.Fatalf("CreateCandidate() error = %v", err)
+	}
+	if promotion.LatestTag() != "v1.3.0-rc.2" {
+		t.Errorf("candidate tag = %q, want v1.3.0-rc.2", promotion.LatestTag())
+	}
+
+	// Staging needs no approval; prod does and no approval manager is set.
+	promotion, err = promoter.AutoPromote(ctx, "1.3.0")
+	if err != nil {
+		t.Fatalf("AutoPromote() error = %v", err)
+	}
+	if promotion.Stage != "staging" {
+		t.Errorf("AutoPromote() stopped at %q, want staging", promotion.Stage)
+	}
+	if _, err := promoter.Promote(ctx, "v1.3.0", ""); !errors.Is(err, ErrPromotionApprovalRequired) {
+		t.Errorf("Promote() without approver error = %v, want ErrPromotionApprovalRequired", err)
+	}
+
+	promotion, err = promoter.Promote(ctx, "v1.3.0", "alice")
+	if err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	if _, err := promoter.Promote(ctx, "v1.3.0", "alice"); !errors.Is(err, ErrPromotionComplete) {
+		t.Errorf("Promote() past prod error = %v, want ErrPromotionComplete", err)
+	}
+
+	wantTags := []string{"v1.3.0-rc.2", "v1.3.0-staging", "v1.3.0"}
+	if got := fake.tags[len(fake.tags)-3:]; got[0] != wantTags[0] || got[1] != wantTags[1] || got[2] != wantTags[2] {
+		t.Errorf("created tags = %v, want %v", got, wantTags)
+	}
+	wantEnvs := []string{PromotionStageCandidate, "staging", "prod"}
+	if len(fake.deployed) != len(wantEnvs) {
+		t.Fatalf("deploy hooks = %d, want %d", len(fake.deployed), len(wantEnvs))
+	}
+	for i, payload := range fake.deployed {
+		if payload.Action != "promote" || payload.Environment != wantEnvs[i] || payload.Tag != wantTags[i] || payload.Version != "v1.3.0" {
+			t.Errorf("deploy %d = %+v", i, payload)
+		}
+	}
+
+	// The recorded promotion survives a reload from the state store.
+	stored, err := promoter.Get("v1.3.0")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.Stage != "prod" || stored.SHA != "merge-sha" || len(stored.History) != 3 {
+		t.Fatalf("stored promotion = %+v", stored)
+	}
+	if stored.History[2].ApprovedBy != "alice" || stored.History[1].ApprovedBy != "" {
+		t.Errorf("history approvers = %+v", stored.History)
+	}
+	list, err := promoter.List(10)
+	if err != nil || len(list) != 1 {
+		t.Errorf("List() = %v, %v, want one promotion", list, err)
+	}
+}
+
+func TestPromoter_DeployFailureRecorded(t *testing.T) {
+	ctx := context.Background()
+	promoter, _, url := newTestPromoter(t)
+	promoter.config = &PromotionConfig{
+		Enabled: true,
+		Stages:  []PromotionStage{{Name: "prod", Deploy: &PostMergeConfig{Action: "webhook", WebhookURL: url + "/missing"}}},
+	}
+
+	if _, err := promoter.CreateCandidate(ctx, SemVer{Major: 2}, "merge-sha"); err != nil {
+		t.Fatalf("CreateCandidate() error = %v", err)
+	}
+	if _, err := promoter.Promote(ctx, "v2.0.0", ""); err == nil {
+		t.Fatal("Promote() should report the failed deploy")
+	}
+
+	// The tag exists, so the stage is recorded with the deploy error.
+	stored, err := promoter.Get("2.0.0")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.Stage != "prod" || stored.History[1].DeployError == "" {
+		t.Errorf("stored promotion = %+v, want prod with deploy error", stored)
+	}
+}
+
+func TestPromotionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  []PromotionStage
+		wantErr bool
+	}{
+		{"defaults", nil, false},
+		{"custom", []PromotionStage{{Name: "qa", TagSuffix: "-qa"}, {Name: "staging", TagSuffix: "-staging"}, {Name: "prod"}}, false},
+		{"missing name", []PromotionStage{{TagSuffix: "-qa"}}, true},
+		{"duplicate", []PromotionStage{{Name: "qa", TagSuffix: "-qa"}, {Name: "qa"}}, true},
+		{"reserved name", []PromotionStage{{Name: PromotionStageCandidate}}, true},
+		{"final tag before last stage", []PromotionStage{{Name: "prod"}, {Name: "dr", TagSuffix: "-dr"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &PromotionConfig{Enabled: true, Stages: tt.stages}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// Release note categories, in the order they appear in the notes.
+const (
+	NoteFeature = "feature"
+	NoteBug     = "bug"
+	NoteChore   = "chore"
+	NoteOther   = "other"
+	// NoteInProgress holds epics whose sub-issues have not all shipped yet.
+	NoteInProgress = "in_progress"
+)
+
+// noteSections maps each category to its release notes heading.
+var noteSections = []struct {
+	category string
+	heading  string
+}{
+	{NoteFeature, "Features"},
+	{NoteBug, "Bug Fixes"},
+	{NoteChore, "Chores"},
+	{NoteOther, "Other Changes"},
+	{NoteInProgress, "In Progress"},
+}
+
+// labelCategories maps issue/PR label names to release note categories.
+var labelCategories = map[string]string{
+	"feature":       NoteFeature,
+	"feat":          NoteFeature,
+	"enhancement":   NoteFeature,
+	"bug":           NoteBug,
+	"bugfix":        NoteBug,
+	"fix":           NoteBug,
+	"regression":    NoteBug,
+	"chore":         NoteChore,
+	"maintenance":   NoteChore,
+	"dependencies":  NoteChore,
+	"docs":          NoteChore,
+	"documentation": NoteChore,
+	"refactor":      NoteChore,
+	"ci":            NoteChore,
+	"build":         NoteChore,
+	"test":          NoteChore,
+}
+
+// ReleaseNote is one customer-facing entry in the release notes: a merged PR
+// described by the issues it closes.
+type ReleaseNote struct {
+	Title     string
+	PRNumber  int   // 0 for commits pushed without a PR
+	PRs       []int // Sub-issue PRs folded into an epic entry
+	Issues    []int // Linked issues closed by the PR
+	Category  string
+	Requester string // GitHub login of whoever requested the change
+}
+
+// GenerateReleaseNotes builds release notes for the given commits. Each
+// commit is resolved to its merged PR, and each PR to the issues it closes;
+// issue titles and labels describe the change and the issue author is
+// credited as the requester. Commits without a PR fall back to their message.
+func (r *Releaser) GenerateReleaseNotes(ctx context.Context, commits []*github.Commit) (string, error) {
+	notes, err := r.collectReleaseNotes(ctx, commits)
+	if err != nil {
+		return "", err
+	}
+	return FormatReleaseNotes(notes), nil
+}
+
+func (r *Releaser) collectReleaseNotes(ctx context.Context, commits []*github.Commit) ([]ReleaseNote, error) {
+	var notes []ReleaseNote
+	seenPRs := make(map[int]bool)
+	issues := make(map[int]*github.Issue)
+
+	for _, commit := range commits {
+		prs, err := r.ghClient.ListPullRequestsForCommit(ctx, r.owner, r.repo, commit.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PRs for commit %s: %w", commit.SHA, err)
+		}
+
+		var pr *github.PullRequest
+		for _, candidate := range prs {
+			if candidate.MergedAt != "" {
+				pr = candidate
+				break
+			}
+		}
+		if pr == nil {
+			if note, ok := commitNote(commit); ok {
+				notes = append(notes, note)
+			}
+			continue
+		}
+		if seenPRs[pr.Number] {
+			continue
+		}
+		seenPRs[pr.Number] = true
+
+		var linked []*github.Issue
+		for _, num := range github.ParseLinkedIssues(pr.Body) {
+			issue, ok := issues[num]
+			if !ok {
+				issue, err = r.ghClient.GetIssue(ctx, r.owner, r.repo, num)
+				if err != nil {
+					// A deleted or cross-repo reference should not block the release.
+					issue = nil
+				}
+				issues[num] = issue
+			}
+			if issue != nil {
+				linked = append(linked, issue)
+			}
+		}
+		notes = append(notes, prNote(pr, linked))
+	}
+	return r.groupEpicNotes(ctx, notes, issues), nil
+}
+
+// EpicSource looks up the epic a sub-issue belongs to. Satisfied by
+// *memory.Store.
+type EpicSource interface {
+	FindEpicBySubIssue(repo string, number int) (*memory.Epic, error)
+}
+
+// SetEpicSource enables epic-level release notes: PRs for the sub-issues of
+// an epic are folded into one entry for the epic.
+func (r *Releaser) SetEpicSource(epics EpicSource) {
+	r.epics = epics
+}
+
+// groupEpicNotes folds the notes of epic sub-issues into one entry per epic,
+// placed where its first sub-issue appeared. A completed epic is announced in
+// its own category; an epic with sub-issues still outstanding is listed under
+// In Progress so a partly shipped epic is not announced as done.
+func (r *Releaser) groupEpicNotes(ctx context.Context, notes []ReleaseNote, issues map[int]*github.Issue) []ReleaseNote {
+	if r.epics == nil {
+		return notes
+	}
+	repo := r.owner + "/" + r.repo
+
+	var grouped []ReleaseNote
+	entries := make(map[string]int)  // epic → index of its entry in grouped
+	labeled := make(map[string]bool) // epic entries categorized by parent issue labels
+	for _, note := range notes {
+		epic := r.epicFor(repo, note.Issues)
+		if epic == nil {
+			grouped = append(grouped, note)
+			continue
+		}
+
+		key := epic.ProjectPath + "\x00" + epic.ParentID
+		idx, ok := entries[key]
+		if !ok {
+			entry, fromLabels := r.epicNote(ctx, epic, issues)
+			grouped = append(grouped, entry)
+			idx = len(grouped) - 1
+			entries[key] = idx
+			labeled[key] = fromLabels
+		}
+
+		entry := &grouped[idx]
+		if note.PRNumber > 0 {
+			entry.PRs = append(entry.PRs, note.PRNumber)
+		}
+		if entry.Requester == "" {
+			entry.Requester = note.Requester
+		}
+		// Without labels on the parent, a completed epic takes the most
+		// significant category among its sub-issues
+		if entry.Category != NoteInProgress && !labeled[key] &&
+			(entry.Category == "" || sectionRank(note.Category) < sectionRank(entry.Category)) {
+			entry.Category = note.Category
+		}
+	}
+	return grouped
+}
+
+// epicFor returns the epic any of the issues is a sub-issue of
+func (r *Releaser) epicFor(repo string, issueNumbers []int) *memory.Epic {
+	for _, num := range issueNumbers {
+		if epic, err := r.epics.FindEpicBySubIssue(repo, num); err == nil && epic != nil {
+			return epic
+		}
+	}
+	return nil
+}
+
+// epicNote starts the release note entry for an epic, described by its parent
+// issue when that can be fetched. Reports whether the category came from the
+// parent issue's labels.
+func (r *Releaser) epicNote(ctx context.Context, epic *memory.Epic, issues map[int]*github.Issue) (ReleaseNote, bool) {
+	note := ReleaseNote{Title: epic.Title}
+	parentNum, _ := strconv.Atoi(strings.TrimPrefix(epic.ParentID, "GH-"))
+
+	var parent *github.Issue
+	if parentNum > 0 {
+		var ok bool
+		if parent, ok = issues[parentNum]; !ok {
+			parent, _ = r.ghClient.GetIssue(ctx, r.owner, r.repo, parentNum)
+			issues[parentNum] = parent
+		}
+	}
+	if parent != nil {
+		note.Title = parent.Title
+		note.Requester = humanLogin(parent.User.Login)
+		note.Category = categoryFromLabels(parent.Labels)
+	}
+
+	if epic.Status != memory.EpicStatusCompleted {
+		ref := epic.ParentID
+		if parentNum > 0 {
+			ref = fmt.Sprintf("#%d", parentNum)
+		}
+		note.Title += fmt.Sprintf(" (epic %s, %d/%d sub-issues done)", ref, epic.Completed(), len(epic.SubIssues))
+		note.Category = NoteInProgress
+		return note, false
+	}
+	if parentNum > 0 {
+		note.Issues = []int{parentNum}
+	}
+	return note, note.Category != ""
+}
+
+// sectionRank orders categories by their position in the release notes
+func sectionRank(category string) int {
+	for i, section := range noteSections {
+		if section.category == category {
+			return i
+		}
+	}
+	return len(noteSections)
+}
+
+// prNote describes a merged PR by its first linked issue, falling back to the
+// PR itself when it closes no issues.
+func prNote(pr *github.PullRequest, linked []*github.Issue) ReleaseNote {
+	note := ReleaseNote{
+		Title:     conventionalDescription(pr.Title),
+		PRNumber:  pr.Number,
+		Requester: humanLogin(pr.User.Login),
+	}
+
+	var labels []github.Label
+	for _, issue := range linked {
+		note.Issues = append(note.Issues, issue.Number)
+		labels = append(labels, issue.Labels...)
+	}
+	if len(linked) > 0 {
+		note.Title = linked[0].Title
+		if login := humanLogin(linked[0].User.Login); login != "" {
+			note.Requester = login
+		}
+	}
+
+	note.Category = categoryFromLabels(labels)
+	if note.Category == "" {
+		note.Category = categoryFromMessage(pr.Title)
+	}
+	return note
+}
+
+// commitNote describes a commit pushed without a PR. Merge commits are skipped.
+func commitNote(commit *github.Commit) (ReleaseNote, bool) {
+	msg := commit.Commit.Message
+	if idx := strings.Index(msg, "\n"); idx > 0 {
+		msg = msg[:idx]
+	}
+	if msg == "" || strings.HasPrefix(msg, "Merge ") {
+		return ReleaseNote{}, false
+	}
+	return ReleaseNote{
+		Title:    conventionalDescription(msg),
+		Category: categoryFromMessage(msg),
+	}, true
+}
+
+// FormatReleaseNotes renders notes as markdown, grouped by category.
+func FormatReleaseNotes(notes []ReleaseNote) string {
+	grouped := make(map[string][]ReleaseNote)
+	var requesters []string
+	seen := make(map[string]bool)
+	for _, note := range notes {
+		grouped[note.Category] = append(grouped[note.Category], note)
+		if note.Requester != "" && !seen[note.Requester] {
+			seen[note.Requester] = true
+			requesters = append(requesters, note.Requester)
+		}
+	}
+
+	var sections []string
+	for _, section := range noteSections {
+		entries := grouped[section.category]
+		if len(entries) == 0 {
+			continue
+		}
+		lines := make([]string, 0, len(entries))
+		for _, note := range entries {
+			lines = append(lines, "- "+formatNote(note))
+		}
+		sections = append(sections, fmt.Sprintf("## %s\n%s", section.heading, strings.Join(lines, "\n")))
+	}
+	if len(sections) == 0 {
+		return ""
+	}
+
+	if len(requesters) > 0 {
+		sort.Strings(requesters)
+		for i, login := range requesters {
+			requesters[i] = "@" + login
+		}
+		sections = append(sections, "Thanks to "+strings.Join(requesters, ", ")+" for requesting these changes.")
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+func formatNote(note ReleaseNote) string {
+	var refs []string
+	if note.PRNumber > 0 {
+		refs = append(refs, fmt.Sprintf("#%d", note.PRNumber))
+	}
+	for _, num := range note.PRs {
+		refs = append(refs, fmt.Sprintf("#%d", num))
+	}
+	for _, num := range note.Issues {
+		refs = append(refs, fmt.Sprintf("closes #%d", num))
+	}
+
+	line := note.Title
+	if len(refs) > 0 {
+		line += " (" + strings.Join(refs, ", ") + ")"
+	}
+	if note.Requester != "" {
+		line += " — requested by @" + note.Requester
+	}
+	return line
+}
+
+// categoryFromLabels returns the category of the first recognized label.
+// Scoped labels such as "type: bug" or "kind/feature" match on their last part.
+func categoryFromLabels(labels []github.Label) string {
+	for _, label := range labels {
+		name := strings.ToLower(label.Name)
+		if idx := strings.LastIndexAny(name, ":/"); idx >= 0 {
+			name = name[idx+1:]
+		}
+		if category, ok := labelCategories[strings.TrimSpace(name)]; ok {
+			return category
+		}
+	}
+	return ""
+}
+
+// categoryFromMessage categorizes a conventional commit style message.
+func categoryFromMessage(msg string) string {
+	matches := conventionalCommitRegex.FindStringSubmatch(msg)
+	if matches == nil {
+		return NoteOther
+	}
+	if category, ok := labelCategories[strings.ToLower(matches[1])]; ok {
+		return category
+	}
+	return NoteOther
+}
+
+// conventionalDescription strips a conventional commit prefix from msg,
+// keeping the scope.
+func conventionalDescription(msg string) string {
+	if matches := conventionalCommitRegex.FindStringSubmatch(msg); matches != nil {
+		return scopedDescription(matches)
+	}
+	return msg
+}
+
+// humanLogin returns login unless it belongs to a bot account, which should
+// not be credited as a requester.
+func humanLogin(login string) string {
+	if strings.HasSuffix(login, "[bot]") {
+		return ""
+	}
+	return login
+}
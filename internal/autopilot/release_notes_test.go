@@ -0,0 +1,176 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestReleaser_GenerateReleaseNotes(t *testing.T) {
+	prsByCommit := map[string][]map[string]interface{}{
+		"sha-1": {{"number": 10, "title": "feat(api): add caching", "body": "Closes #1", "merged_at": "2026-01-01T00:00:00Z", "user": map[string]string{"login": "pilot-app[bot]"}}},
+		"sha-2": {{"number": 10, "title": "feat(api): add caching", "body": "Closes #1", "merged_at": "2026-01-01T00:00:00Z"}},
+		"sha-3": {{"number": 11, "title": "fix: handle timeouts", "body": "Fixes #2 and fixes #404", "merged_at": "2026-01-02T00:00:00Z"}},
+		"sha-4": {{"number": 12, "title": "chore: bump deps", "merged_at": "2026-01-03T00:00:00Z", "user": map[string]string{"login": "carol"}}},
+		"sha-5": {{"number": 13, "title": "Unmerged", "merged_at": ""}},
+	}
+	issues := map[string]map[string]interface{}{
+		"1": {"number": 1, "title": "Cache API responses", "labels": []map[string]string{{"name": "enhancement"}}, "user": map[string]string{"login": "alice"}},
+		"2": {"number": 2, "title": "Requests hang on slow networks", "labels": []map[string]string{{"name": "type: bug"}}, "user": map[string]string{"login": "bob"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/"), "/")
+		switch {
+		case len(parts) == 3 && parts[0] == "commits" && parts[2] == "pulls":
+			_ = json.NewEncoder(w).Encode(prsByCommit[parts[1]])
+		case len(parts) == 2 && parts[0] == "issues" && issues[parts[1]] != nil:
+			_ = json.NewEncoder(w).Encode(issues[parts[1]])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := github.NewClientWithBaseURL("test-token", server.URL)
+	r := NewReleaser(client, "owner", "repo", DefaultReleaseConfig())
+
+	commits := []*github.Commit{makeCommit("feat: a"), makeCommit("feat: b"), makeCommit("fix: c"), makeCommit("chore: d"), makeCommit("docs(readme): update install steps"), makeCommit("Merge branch 'main'")}
+	for i, sha := range []string{"sha-1", "sha-2", "sha-3", "sha-4", "sha-5", "sha-6"} {
+		commits[i].SHA = sha
+	}
+
+	notes, err := r.GenerateReleaseNotes(context.Background(), commits)
+	if err != nil {
+		t.Fatalf("GenerateReleaseNotes() error = %v", err)
+	}
+
+	want := `## Features
+- Cache API responses (#10, closes #1) — requested by @alice
+
+## Bug Fixes
+- Requests hang on slow networks (#11, closes #2) — requested by @bob
+
+## Chores
+- bump deps (#12) — requested by @carol
+- **readme:** update install steps
+
+Thanks to @alice, @bob, @carol for requesting these changes.`
+	if notes != want {
+		t.Errorf("GenerateReleaseNotes() =\n%s\n\nwant:\n%s", notes, want)
+	}
+}
+
+// fakeEpicSource maps sub-issue numbers to their epics
+type fakeEpicSource map[int]*memory.Epic
+
+func (f fakeEpicSource) FindEpicBySubIssue(repo string, number int) (*memory.Epic, error) {
+	if repo != "owner/repo" {
+		return nil, nil
+	}
+	return f[number], nil
+}
+
+func TestReleaser_GenerateReleaseNotes_Epics(t *testing.T) {
+	pr := func(number int, title, body string) []map[string]interface{} {
+		return []map[string]interface{}{{"number": number, "title": title, "body": body, "merged_at": "2026-01-01T00:00:00Z", "user": map[string]string{"login": "pilot-app[bot]"}}}
+	}
+	prsByCommit := map[string][]map[string]interface{}{
+		"sha-1": pr(21, "GH-11: Schema", "Closes #11"),
+		"sha-2": pr(22, "fix: GH-12: API", "Closes #12"),
+		"sha-3": pr(23, "GH-31: Search index", "Closes #31"),
+		"sha-4": pr(24, "fix: typo", "Closes #40"),
+	}
+	issues := map[string]map[string]interface{}{
+		"10": {"number": 10, "title": "Single sign-on", "labels": []map[string]string{{"name": "feature"}}, "user": map[string]string{"login": "alice"}},
+		"11": {"number": 11, "title": "Schema", "user": map[string]string{"login": "pilot-app[bot]"}},
+		"12": {"number": 12, "title": "API", "user": map[string]string{"login": "pilot-app[bot]"}},
+		"30": {"number": 30, "title": "Full-text search", "user": map[string]string{"login": "bob"}},
+		"31": {"number": 31, "title": "Search index", "user": map[string]string{"login": "pilot-app[bot]"}},
+		"40": {"number": 40, "title": "Typo in footer", "labels": []map[string]string{{"name": "bug"}}, "user": map[string]string{"login": "carol"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/"), "/")
+		switch {
+		case len(parts) == 3 && parts[0] == "commits" && parts[2] == "pulls":
+			_ = json.NewEncoder(w).Encode(prsByCommit[parts[1]])
+		case len(parts) == 2 && parts[0] == "issues" && issues[parts[1]] != nil:
+			_ = json.NewEncoder(w).Encode(issues[parts[1]])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	done := &memory.Epic{ParentID: "GH-10", Status: memory.EpicStatusCompleted, SubIssues: []*memory.EpicSubIssue{
+		{Position: 1, Number: 11, Status: memory.SubIssueStatusDone},
+		{Position: 2, Number: 12, Status: memory.SubIssueStatusDone},
+	}}
+	inProgress := &memory.Epic{ParentID: "GH-30", Status: memory.EpicStatusRunning, SubIssues: []*memory.EpicSubIssue{
+		{Position: 1, Number: 31, Status: memory.SubIssueStatusDone},
+		{Position: 2, Number: 32, Status: memory.SubIssueStatusRunning},
+		{Position: 3, Number: 33, Status: memory.SubIssueStatusPending},
+	}}
+
+	client := github.NewClientWithBaseURL("test-token", server.URL)
+	r := NewReleaser(client, "owner", "repo", DefaultReleaseConfig())
+	r.SetEpicSource(fakeEpicSource{11: done, 12: done, 31: inProgress, 32: inProgress, 33: inProgress})
+
+	commits := []*github.Commit{makeCommit("a"), makeCommit("b"), makeCommit("c"), makeCommit("d")}
+	for i, sha := range []string{"sha-1", "sha-2", "sha-3", "sha-4"} {
+		commits[i].SHA = sha
+	}
+
+	notes, err := r.GenerateReleaseNotes(context.Background(), commits)
+	if err != nil {
+		t.Fatalf("GenerateReleaseNotes() error = %v", err)
+	}
+
+	want := `## Features
+- Single sign-on (#21, #22, closes #10) — requested by @alice
+
+## Bug Fixes
+- Typo in footer (#24, closes #40) — requested by @carol
+
+## In Progress
+- Full-text search (epic #30, 1/3 sub-issues done) (#23) — requested by @bob
+
+Thanks to @alice, @bob, @carol for requesting these changes.`
+	if notes != want {
+		t.Errorf("GenerateReleaseNotes() =\n%s\n\nwant:\n%s", notes, want)
+	}
+}
+
+func TestFormatReleaseNotes_Empty(t *testing.T) {
+	if got := FormatReleaseNotes(nil); got != "" {
+		t.Errorf("FormatReleaseNotes(nil) = %q, want empty", got)
+	}
+}
+
+func TestCategoryFromLabels(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   string
+	}{
+		{[]string{"pilot", "Enhancement"}, NoteFeature},
+		{[]string{"kind/bug"}, NoteBug},
+		{[]string{"dependencies", "bug"}, NoteChore},
+		{[]string{"pilot"}, ""},
+	}
+	for _, tt := range tests {
+		var labels []github.Label
+		for _, name := range tt.labels {
+			labels = append(labels, github.Label{Name: name})
+		}
+		if got := categoryFromLabels(labels); got != tt.want {
+			t.Errorf("categoryFromLabels(%v) = %q, want %q", tt.labels, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReleaseTrainNotifier extends Notifier with release train notifications.
+type ReleaseTrainNotifier interface {
+	Notifier
+	// NotifyReleaseTrainSkipped sends notification when a release train
+	// with releasable commits does not depart.
+	NotifyReleaseTrainSkipped(ctx context.Context, train *ReleaseTrain) error
+}
+
+// ReleaseTrain is the outcome of one scheduled release.
+type ReleaseTrain struct {
+	// SHA is the head of the release branch the train was built from.
+	SHA string
+	// Commits is the number of commits since the last release.
+	Commits int
+	// BumpType is the bump detected from those commits.
+	BumpType BumpType
+	// Version is the released version, empty when the train was skipped.
+	Version string
+	// Skipped explains why no release was cut.
+	Skipped string
+	// FailedChecks are the checks that kept a red train from departing.
+	FailedChecks []string
+}
+
+// releaseTrainEnabled reports whether releases are cut on a schedule.
+func (c *Controller) releaseTrainEnabled() bool {
+	rel := c.resolvedRelease()
+	return rel != nil && rel.Enabled && rel.Trigger == "schedule" && c.releaser != nil
+}
+
+// runReleaseTrains runs RunReleaseTrain at every scheduled departure until
+// ctx is done.
+func (c *Controller) runReleaseTrains(ctx context.Context) {
+	rel := c.resolvedRelease()
+	for {
+		next, err := rel.NextTrain(time.Now())
+		if err != nil {
+			c.log.Error("invalid release train schedule, trains stopped", "schedule", rel.Schedule, "error", err)
+			return
+		}
+		c.log.Info("next release train scheduled", "at", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		train, err := c.RunReleaseTrain(ctx)
+		switch {
+		case err != nil:
+			c.log.Error("release train failed", "error", err)
+		case train.Skipped != "":
+			c.log.Info("release train skipped", "reason", train.Skipped, "commits", train.Commits)
+		default:
+			c.log.Info("release train departed", "version", train.Version, "commits", train.Commits, "bump", train.BumpType)
+		}
+	}
+}
+
+// RunReleaseTrain releases everything merged to the main branch since the
+// last release. The train is skipped when nothing releasable was merged or
+// when CI on the branch head is not green, so a broken main is never tagged;
+// the merged commits wait for the next train.
+func (c *Controller) RunReleaseTrain(ctx context.Context) (*ReleaseTrain, error) {
+	if c.releaser == nil {
+		return nil, fmt.Errorf("releaser not configured")
+	}
+	if c.releaseAllowed != nil && !c.releaseAllowed() {
+		return &ReleaseTrain{Skipped: "releases are disabled for the project"}, nil
+	}
+	if status := c.freeze.Status(); status.Frozen {
+		c.log.Info("release train held by merge freeze", "freeze", status.String())
+		return &ReleaseTrain{Skipped: "held by " + status.String()}, nil
+	}
+	rel := c.resolvedRelease()
+
+	sha, err := c.getMainBranchSHA(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get main branch: %w", err)
+	}
+	train := &ReleaseTrain{SHA: sha}
+
+	if tag, err := c.ghClient.GetTagForSHA(ctx, c.owner, c.repo, sha); err != nil {
+		c.log.Warn("failed to check existing tags", "error", err)
+	} else if tag != "" {
+		train.Skipped = fmt.Sprintf("%s is already released as %s", ShortSHA(sha), tag)
+		return train, nil
+	}
+
+	currentVersion, err := c.releaser.GetCurrentVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current version: %w", err)
+	}
+	if currentVersion == (SemVer{}) {
+		train.Skipped = "no previous release to compare against; cut the first release with pilot release"
+		return train, nil
+	}
+	commits, err := c.ghClient.CompareCommits(ctx, c.owner, c.repo, currentVersion.String(rel.TagPrefix), sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits since %s: %w", currentVersion.String(rel.TagPrefix), err)
+	}
+	train.Commits = len(commits)
+	train.BumpType = DetectBumpType(commits)
+	if !c.releaser.ShouldRelease(train.BumpType) {
+		train.Skipped = "no releasable commits since the last release"
+		return train, nil
+	}
+
+	// Verify CI on the exact commit to be tagged
+	if rel.RequireCI {
+		status, err := c.ciMonitor.WaitForCI(ctx, sha)
+		if err != nil {
+			train.Skipped = fmt.Sprintf("CI on %s did not finish: %v", ShortSHA(sha), err)
+		} else if status != CISuccess {
+			train.FailedChecks, _ = c.ciMonitor.GetFailedChecks(ctx, sha)
+			train.Skipped = fmt.Sprintf("CI is red on %s", ShortSHA(sha))
+			if len(train.FailedChecks) > 0 {
+				train.Skipped += ": " + strings.Join(train.FailedChecks, ", ")
+			}
+		}
+		if train.Skipped != "" {
+			c.notifyReleaseTrainSkipped(ctx, train)
+			return train, nil
+		}
+	}
+
+	prState := &PRState{
+		HeadSHA:         sha,
+		TargetBranch:    "main",
+		ReleaseBumpType: train.BumpType,
+		EnvironmentName: c.config.EnvironmentName(),
+	}
+	if err := c.cutRelease(ctx, prState, currentVersion, train.BumpType); err != nil {
+		return nil, err
+	}
+	train.Version = prState.ReleaseVersion
+	return train, nil
+}
+
+// notifyReleaseTrainSkipped reports a train held back by CI.
+func (c *Controller) notifyReleaseTrainSkipped(ctx context.Context, train *ReleaseTrain) {
+	c.log.Warn("release train held back", "sha", ShortSHA(train.SHA), "reason", train.Skipped)
+	if c.notifier == nil {
+		return
+	}
+	if n, ok := c.notifier.(ReleaseTrainNotifier); ok {
+		if err := n.NotifyReleaseTrainSkipped(ctx, train); err != nil {
+			c.log.Warn("failed to send release train notification", "error", err)
+		}
+	}
+}
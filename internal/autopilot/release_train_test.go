@@ -0,0 +1,160 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/testutil"
+)
+
+// trainNotifier records held-back release trains
+type trainNotifier struct {
+	mockNotifier
+	skipped []*ReleaseTrain
+}
+
+func (n *trainNotifier) NotifyReleaseTrainSkipped(ctx context.Context, train *ReleaseTrain) error {
+	n.skipped = append(n.skipped, train)
+	return nil
+}
+
+func TestReleaseConfig_NextTrain(t *testing.T) {
+	rel := &ReleaseConfig{Trigger: "schedule", Schedule: "0 17 * * 1-5", Timezone: "Europe/Berlin"}
+	if err := rel.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	// Friday after the train has left: the next one is Monday
+	next, err := rel.NextTrain(time.Date(2026, 10, 16, 18, 0, 0, 0, berlin))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 10, 19, 17, 0, 0, 0, berlin); !next.Equal(want) {
+		t.Errorf("NextTrain() = %v, want %v", next, want)
+	}
+
+	for _, bad := range []*ReleaseConfig{
+		{Trigger: "schedule"},
+		{Trigger: "schedule", Schedule: "every day"},
+		{Trigger: "schedule", Schedule: "0 17 * * *", Timezone: "Mars/Olympus"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", bad)
+		}
+	}
+	if err := (&ReleaseConfig{Trigger: "on_merge"}).Validate(); err != nil {
+		t.Errorf("Validate() without schedule trigger = %v", err)
+	}
+}
+
+func TestController_RunReleaseTrain(t *testing.T) {
+	tests := []struct {
+		name        string
+		commits     []string
+		conclusion  string
+		wantVersion string
+		wantSkipped string
+		wantNotice  bool
+	}{
+		{
+			name:        "green departs",
+			commits:     []string{"fix: handle timeouts", "feat(api): add caching"},
+			conclusion:  "success",
+			wantVersion: "v1.3.0",
+		},
+		{
+			name:        "red is held back",
+			commits:     []string{"feat(api): add caching"},
+			conclusion:  "failure",
+			wantSkipped: "CI is red on mainsha: build",
+			wantNotice:  true,
+		},
+		{
+			name:        "nothing releasable",
+			commits:     []string{"chore: bump deps", "docs: fix typo"},
+			conclusion:  "success",
+			wantSkipped: "no releasable commits since the last release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var tagged []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case r.URL.Path == "/repos/owner/repo/branches/main":
+					_ = json.NewEncoder(w).Encode(github.Branch{Name: "main", Commit: github.BranchCommit{SHA: "mainsha"}})
+				case r.URL.Path == "/repos/owner/repo/tags":
+					_, _ = w.Write([]byte(`[{"name": "v1.2.0", "commit": {"sha": "oldsha"}}]`))
+				case r.URL.Path == "/repos/owner/repo/releases/latest":
+					_ = json.NewEncoder(w).Encode(github.Release{TagName: "v1.2.0"})
+				case r.URL.Path == "/repos/owner/repo/compare/v1.2.0...mainsha":
+					var commits []*github.Commit
+					for _, msg := range tt.commits {
+						commits = append(commits, makeCommit(msg))
+					}
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"commits": commits})
+				case r.URL.Path == "/repos/owner/repo/commits/mainsha/check-runs":
+					_ = json.NewEncoder(w).Encode(github.CheckRunsResponse{
+						TotalCount: 1,
+						CheckRuns:  []github.CheckRun{{Name: "build", Status: "completed", Conclusion: tt.conclusion}},
+					})
+				case r.URL.Path == "/repos/owner/repo/git/refs" && r.Method == http.MethodPost:
+					var body map[string]string
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					tagged = append(tagged, body["ref"]+"@"+body["sha"])
+					w.WriteHeader(http.StatusCreated)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cfg := DefaultConfig()
+			cfg.RequiredChecks = []string{"build"}
+			cfg.CIPollInterval = 10 * time.Millisecond
+			cfg.CIWaitTimeout = time.Second
+			cfg.Release = DefaultReleaseConfig()
+			cfg.Release.Enabled = true
+			cfg.Release.Trigger = "schedule"
+			cfg.Release.Schedule = "0 17 * * 1-5"
+
+			c := NewController(cfg, github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL), nil, "owner", "repo")
+			notifier := &trainNotifier{}
+			c.SetNotifier(notifier)
+			if !c.releaseTrainEnabled() || c.shouldTriggerRelease() {
+				t.Fatal("schedule trigger should release by train, not on merge")
+			}
+
+			train, err := c.RunReleaseTrain(context.Background())
+			if err != nil {
+				t.Fatalf("RunReleaseTrain() error = %v", err)
+			}
+			if train.Version != tt.wantVersion || train.Skipped != tt.wantSkipped || train.Commits != len(tt.commits) {
+				t.Errorf("train = %+v", train)
+			}
+			var wantTags []string
+			if tt.wantVersion != "" {
+				wantTags = []string{"refs/tags/" + tt.wantVersion + "@mainsha"}
+			}
+			if strings.Join(tagged, ",") != strings.Join(wantTags, ",") {
+				t.Errorf("tags created = %v, want %v", tagged, wantTags)
+			}
+			if (len(notifier.skipped) > 0) != tt.wantNotice {
+				t.Errorf("held-back notifications = %d, want %v", len(notifier.skipped), tt.wantNotice)
+			}
+		})
+	}
+}
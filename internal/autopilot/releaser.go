@@ -17,6 +17,7 @@ type Releaser struct {
 	owner    string
 	repo     string
 	config   *ReleaseConfig
+	epics    EpicSource // Optional, groups epic sub-issues in release notes
 }
 
 // NewReleaser creates a new releaser.
@@ -98,12 +99,30 @@ func ParseSemVer(s string) (SemVer, error) {
 // conventionalCommitRegex matches conventional commit format.
 var conventionalCommitRegex = regexp.MustCompile(`^(\w+)(\(.+\))?(!)?:\s*(.+)`)
 
+// scopedDescription returns the description of a conventional commit
+// match, prefixed with its scope when it has one.
+func scopedDescription(matches []string) string {
+	if scope := strings.Trim(matches[2], "()"); scope != "" {
+		return fmt.Sprintf("**%s:** %s", scope, matches[4])
+	}
+	return matches[4]
+}
+
+// hasBreakingFooter reports whether a commit message body declares a
+// breaking change.
+func hasBreakingFooter(msg string) bool {
+	return strings.Contains(msg, "\nBREAKING CHANGE:") || strings.Contains(msg, "\nBREAKING-CHANGE:")
+}
+
 // DetectBumpType analyzes commit messages and returns the highest bump type needed.
 func DetectBumpType(commits []*github.Commit) BumpType {
 	maxBump := BumpNone
 
 	for _, commit := range commits {
 		msg := commit.Commit.Message
+		if hasBreakingFooter(msg) {
+			return BumpMajor
+		}
 		// Get first line only
 		if idx := strings.Index(msg, "\n"); idx > 0 {
 			msg = msg[:idx]
@@ -205,12 +224,14 @@ func (r *Releaser) GetCurrentVersion(ctx context.Context) (SemVer, error) {
 	return versions[0], nil
 }
 
-// GenerateChangelog generates a changelog from commits.
+// GenerateChangelog generates a changelog from commits. Entries carry the
+// commit scope, and breaking changes are also listed in their own section.
 func GenerateChangelog(commits []*github.Commit, prNumber int) string {
-	var features, fixes, others []string
+	var breaking, features, fixes, others []string
 
 	for _, commit := range commits {
 		msg := commit.Commit.Message
+		footerBreaking := hasBreakingFooter(msg)
 		// Get first line
 		if idx := strings.Index(msg, "\n"); idx > 0 {
 			msg = msg[:idx]
@@ -223,7 +244,10 @@ func GenerateChangelog(commits []*github.Commit, prNumber int) string {
 		}
 
 		commitType := strings.ToLower(matches[1])
-		description := matches[4]
+		description := scopedDescription(matches)
+		if matches[3] == "!" || footerBreaking {
+			breaking = append(breaking, fmt.Sprintf("- %s", description))
+		}
 
 		switch commitType {
 		case "feat", "feature":
@@ -237,6 +261,9 @@ func GenerateChangelog(commits []*github.Commit, prNumber int) string {
 
 	var sections []string
 
+	if len(breaking) > 0 {
+		sections = append(sections, "## Breaking Changes\n"+strings.Join(breaking, "\n"))
+	}
 	if len(features) > 0 {
 		sections = append(sections, "## Features\n"+strings.Join(features, "\n"))
 	}
@@ -274,7 +301,7 @@ func (r *Releaser) ShouldRelease(bumpType BumpType) bool {
 	if !r.config.Enabled {
 		return false
 	}
-	if r.config.Trigger != "on_merge" {
+	if r.config.Trigger != "on_merge" && r.config.Trigger != "schedule" {
 		return false
 	}
 	return bumpType != BumpNone
@@ -269,6 +269,11 @@ func TestDetectBumpType(t *testing.T) {
 			messages: []string{"feat: add feature\n\nThis is a longer description"},
 			want:     BumpMinor,
 		},
+		{
+			name:     "breaking change footer - major bump",
+			messages: []string{"fix(auth): expire tokens\n\nBREAKING CHANGE: tokens expire after a day"},
+			want:     BumpMajor,
+		},
 	}
 
 	for _, tt := range tests {
@@ -316,6 +321,18 @@ func TestGenerateChangelog(t *testing.T) {
 			prNumber: 42,
 			contains: []string{"## Other Changes", "update deps"},
 		},
+		{
+			name:     "scopes are kept",
+			messages: []string{"feat(api): add caching", "fix(executor): handle timeouts"},
+			prNumber: 42,
+			contains: []string{"- **api:** add caching", "- **executor:** handle timeouts"},
+		},
+		{
+			name:     "breaking changes listed",
+			messages: []string{"feat(auth)!: require tokens", "fix: expire sessions\n\nBREAKING CHANGE: sessions expire"},
+			prNumber: 42,
+			contains: []string{"## Breaking Changes\n- **auth:** require tokens\n- expire sessions\n\n## Features"},
+		},
 	}
 
 	for _, tt := range tests {
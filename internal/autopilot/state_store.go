@@ -2,10 +2,11 @@ package autopilot
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/memory"
 	_ "modernc.org/sqlite"
 )
 
@@ -118,18 +119,156 @@ func (s *StateStore) migrate() error {
 			result TEXT DEFAULT '',
 			PRIMARY KEY (adapter, issue_id)
 		)`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN confidence REAL DEFAULT 0`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN canary_sha TEXT DEFAULT ''`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN canary_green_at DATETIME`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN conflict_attempts INTEGER DEFAULT 0`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN review_verdict TEXT DEFAULT ''`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN review_summary TEXT DEFAULT ''`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN review_fixes INTEGER DEFAULT 0`,
+		`ALTER TABLE autopilot_pr_state ADD COLUMN reviews_addressed_at DATETIME`,
+		// Release promotions through environments (candidate → staging → prod)
+		`CREATE TABLE IF NOT EXISTS autopilot_promotions (
+			version TEXT PRIMARY KEY,
+			sha TEXT NOT NULL,
+			stage TEXT NOT NULL,
+			history TEXT NOT NULL DEFAULT '[]',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Changes moving through the environment pipeline (dev → stage → prod)
+		`CREATE TABLE IF NOT EXISTS autopilot_change_promotions (
+			pr_number INTEGER PRIMARY KEY,
+			pr_title TEXT NOT NULL DEFAULT '',
+			issue_number INTEGER DEFAULT 0,
+			stage TEXT NOT NULL,
+			sha TEXT NOT NULL,
+			status TEXT NOT NULL,
+			error TEXT DEFAULT '',
+			green_at DATETIME,
+			history TEXT NOT NULL DEFAULT '[]',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Last response of polled GitHub endpoints, for conditional requests
+		`CREATE TABLE IF NOT EXISTS github_etags (
+			key TEXT PRIMARY KEY,
+			etag TEXT NOT NULL,
+			body BLOB NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
 	}
 
-	for _, m := range migrations {
-		if _, err := s.db.Exec(m); err != nil {
-			// Ignore "duplicate column" errors from ALTER TABLE migrations
-			if strings.Contains(err.Error(), "duplicate column") {
-				continue
-			}
-			return fmt.Errorf("migration failed: %w", err)
-		}
-	}
-	return nil
+	return memory.DialectFor(s.db).Migrate(s.db, memory.Schema{SQLite: migrations, Postgres: postgresMigrations})
+}
+
+// postgresMigrations creates the autopilot tables on PostgreSQL with the
+// columns the SQLite migrations add
+var postgresMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS autopilot_pr_state (
+		pr_number BIGINT PRIMARY KEY,
+		pr_url TEXT NOT NULL,
+		issue_number BIGINT DEFAULT 0,
+		branch_name TEXT NOT NULL DEFAULT '',
+		head_sha TEXT DEFAULT '',
+		stage TEXT NOT NULL,
+		ci_status TEXT NOT NULL DEFAULT 'pending',
+		last_checked TIMESTAMPTZ,
+		ci_wait_started_at TIMESTAMPTZ,
+		merge_attempts BIGINT DEFAULT 0,
+		error TEXT DEFAULT '',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		release_version TEXT DEFAULT '',
+		release_bump_type TEXT DEFAULT '',
+		confidence DOUBLE PRECISION DEFAULT 0,
+		canary_sha TEXT DEFAULT '',
+		canary_green_at TIMESTAMPTZ,
+		conflict_attempts BIGINT DEFAULT 0,
+		review_verdict TEXT DEFAULT '',
+		review_summary TEXT DEFAULT '',
+		review_fixes BIGINT DEFAULT 0,
+		reviews_addressed_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS autopilot_processed (
+		issue_number BIGINT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS autopilot_metadata (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS autopilot_pr_failures (
+		pr_number BIGINT PRIMARY KEY,
+		failure_count BIGINT NOT NULL DEFAULT 0,
+		last_failure_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS linear_processed (
+		issue_id TEXT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS gitlab_processed (
+		issue_number BIGINT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS jira_processed (
+		issue_key TEXT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS asana_processed (
+		task_gid TEXT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS azuredevops_processed (
+		work_item_id BIGINT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS plane_processed (
+		issue_id TEXT PRIMARY KEY,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS adapter_processed (
+		adapter TEXT NOT NULL,
+		issue_id TEXT NOT NULL,
+		processed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		result TEXT DEFAULT '',
+		PRIMARY KEY (adapter, issue_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS autopilot_promotions (
+		version TEXT PRIMARY KEY,
+		sha TEXT NOT NULL,
+		stage TEXT NOT NULL,
+		history TEXT NOT NULL DEFAULT '[]',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS autopilot_change_promotions (
+		pr_number BIGINT PRIMARY KEY,
+		pr_title TEXT NOT NULL DEFAULT '',
+		issue_number BIGINT DEFAULT 0,
+		stage TEXT NOT NULL,
+		sha TEXT NOT NULL,
+		status TEXT NOT NULL,
+		error TEXT DEFAULT '',
+		green_at TIMESTAMPTZ,
+		history TEXT NOT NULL DEFAULT '[]',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS github_etags (
+		key TEXT PRIMARY KEY,
+		etag TEXT NOT NULL,
+		body BYTEA NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL
+	)`,
 }
 
 // SavePRState persists a PR state to the database (upsert).
@@ -139,8 +278,10 @@ func (s *StateStore) SavePRState(pr *PRState) error {
 			pr_number, pr_url, issue_number, branch_name, head_sha,
 			stage, ci_status, last_checked, ci_wait_started_at,
 			merge_attempts, error, created_at, updated_at,
-			release_version, release_bump_type
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?)
+			release_version, release_bump_type, confidence,
+			canary_sha, canary_green_at, conflict_attempts,
+			review_verdict, review_summary, review_fixes, reviews_addressed_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(pr_number) DO UPDATE SET
 			pr_url = excluded.pr_url,
 			issue_number = excluded.issue_number,
@@ -154,13 +295,23 @@ func (s *StateStore) SavePRState(pr *PRState) error {
 			error = excluded.error,
 			updated_at = CURRENT_TIMESTAMP,
 			release_version = excluded.release_version,
-			release_bump_type = excluded.release_bump_type
+			release_bump_type = excluded.release_bump_type,
+			confidence = excluded.confidence,
+			canary_sha = excluded.canary_sha,
+			canary_green_at = excluded.canary_green_at,
+			conflict_attempts = excluded.conflict_attempts,
+			review_verdict = excluded.review_verdict,
+			review_summary = excluded.review_summary,
+			review_fixes = excluded.review_fixes,
+			reviews_addressed_at = excluded.reviews_addressed_at
 	`,
 		pr.PRNumber, pr.PRURL, pr.IssueNumber, pr.BranchName, pr.HeadSHA,
 		string(pr.Stage), string(pr.CIStatus),
 		nullTime(pr.LastChecked), nullTime(pr.CIWaitStartedAt),
 		pr.MergeAttempts, pr.Error, nullTime(pr.CreatedAt),
-		pr.ReleaseVersion, string(pr.ReleaseBumpType),
+		pr.ReleaseVersion, string(pr.ReleaseBumpType), pr.Confidence,
+		pr.CanarySHA, nullTime(pr.CanaryGreenAt), pr.ConflictAttempts,
+		string(pr.ReviewVerdict), pr.ReviewSummary, pr.ReviewFixes, nullTime(pr.ReviewsAddressedAt),
 	)
 	return err
 }
@@ -172,7 +323,9 @@ func (s *StateStore) GetPRState(prNumber int) (*PRState, error) {
 		SELECT pr_number, pr_url, issue_number, branch_name, head_sha,
 			stage, ci_status, last_checked, ci_wait_started_at,
 			merge_attempts, error, created_at,
-			release_version, release_bump_type
+			release_version, release_bump_type, confidence,
+			canary_sha, canary_green_at, conflict_attempts,
+			review_verdict, review_summary, review_fixes, reviews_addressed_at
 		FROM autopilot_pr_state WHERE pr_number = ?
 	`, prNumber)
 
@@ -192,7 +345,9 @@ func (s *StateStore) LoadAllPRStates() ([]*PRState, error) {
 		SELECT pr_number, pr_url, issue_number, branch_name, head_sha,
 			stage, ci_status, last_checked, ci_wait_started_at,
 			merge_attempts, error, created_at,
-			release_version, release_bump_type
+			release_version, release_bump_type, confidence,
+			canary_sha, canary_green_at, conflict_attempts,
+			review_verdict, review_summary, review_fixes, reviews_addressed_at
 		FROM autopilot_pr_state
 	`)
 	if err != nil {
@@ -203,14 +358,16 @@ func (s *StateStore) LoadAllPRStates() ([]*PRState, error) {
 	var states []*PRState
 	for rows.Next() {
 		var pr PRState
-		var lastChecked, ciWaitStartedAt, createdAt sql.NullTime
-		var stage, ciStatus, relBumpType string
+		var lastChecked, ciWaitStartedAt, createdAt, canaryGreenAt, reviewsAddressedAt sql.NullTime
+		var stage, ciStatus, relBumpType, reviewVerdict string
 
 		if err := rows.Scan(
 			&pr.PRNumber, &pr.PRURL, &pr.IssueNumber, &pr.BranchName, &pr.HeadSHA,
 			&stage, &ciStatus, &lastChecked, &ciWaitStartedAt,
 			&pr.MergeAttempts, &pr.Error, &createdAt,
-			&pr.ReleaseVersion, &relBumpType,
+			&pr.ReleaseVersion, &relBumpType, &pr.Confidence,
+			&pr.CanarySHA, &canaryGreenAt, &pr.ConflictAttempts,
+			&reviewVerdict, &pr.ReviewSummary, &pr.ReviewFixes, &reviewsAddressedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -218,6 +375,7 @@ func (s *StateStore) LoadAllPRStates() ([]*PRState, error) {
 		pr.Stage = PRStage(stage)
 		pr.CIStatus = CIStatus(ciStatus)
 		pr.ReleaseBumpType = BumpType(relBumpType)
+		pr.ReviewVerdict = ReviewVerdict(reviewVerdict)
 		if lastChecked.Valid {
 			pr.LastChecked = lastChecked.Time
 		}
@@ -227,6 +385,12 @@ func (s *StateStore) LoadAllPRStates() ([]*PRState, error) {
 		if createdAt.Valid {
 			pr.CreatedAt = createdAt.Time
 		}
+		if canaryGreenAt.Valid {
+			pr.CanaryGreenAt = canaryGreenAt.Time
+		}
+		if reviewsAddressedAt.Valid {
+			pr.ReviewsAddressedAt = reviewsAddressedAt.Time
+		}
 		states = append(states, &pr)
 	}
 	return states, nil
@@ -722,6 +886,42 @@ func (s *StateStore) GetMetadata(key string) (string, error) {
 	return value, err
 }
 
+// GetETag returns the cached GitHub response for key.
+// Returns an empty etag if not found.
+func (s *StateStore) GetETag(key string) (string, []byte, error) {
+	var etag string
+	var body []byte
+	err := s.db.QueryRow(`SELECT etag, body FROM github_etags WHERE key = ?`, key).Scan(&etag, &body)
+	if err == sql.ErrNoRows {
+		return "", nil, nil
+	}
+	return etag, body, err
+}
+
+// SaveETag stores the ETag and body of a GitHub response.
+func (s *StateStore) SaveETag(key, etag string, body []byte) error {
+	_, err := s.db.Exec(`
+		INSERT INTO github_etags (key, etag, body, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			etag = excluded.etag,
+			body = excluded.body,
+			updated_at = excluded.updated_at
+	`, key, etag, body, time.Now())
+	return err
+}
+
+// PurgeOldETags removes cached GitHub responses not refreshed within the
+// given duration, e.g. check runs of commits no longer polled.
+func (s *StateStore) PurgeOldETags(olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+	result, err := s.db.Exec(`DELETE FROM github_etags WHERE updated_at < ?`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // SavePRFailures persists the per-PR failure state.
 func (s *StateStore) SavePRFailures(prNumber, failureCount int, lastFailureTime time.Time) error {
 	_, err := s.db.Exec(`
@@ -792,17 +992,173 @@ func (s *StateStore) PurgeTerminalPRStates(olderThan time.Duration) (int64, erro
 	return result.RowsAffected()
 }
 
+// SavePromotion persists a release promotion (upsert).
+func (s *StateStore) SavePromotion(p *Promotion) error {
+	history, err := json.Marshal(p.History)
+	if err != nil {
+		return fmt.Errorf("failed to encode promotion history: %w", err)
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO autopilot_promotions (version, sha, stage, history, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(version) DO UPDATE SET
+			sha = excluded.sha,
+			stage = excluded.stage,
+			history = excluded.history,
+			updated_at = excluded.updated_at
+	`, p.Version, p.SHA, p.Stage, string(history), p.CreatedAt, p.UpdatedAt)
+	return err
+}
+
+// GetPromotion retrieves a release promotion by version tag.
+// Returns nil, nil if not found.
+func (s *StateStore) GetPromotion(version string) (*Promotion, error) {
+	rows, err := s.db.Query(`
+		SELECT version, sha, stage, history, created_at, updated_at
+		FROM autopilot_promotions WHERE version = ?
+	`, version)
+	if err != nil {
+		return nil, err
+	}
+	promotions, err := scanPromotions(rows)
+	if err != nil || len(promotions) == 0 {
+		return nil, err
+	}
+	return promotions[0], nil
+}
+
+// ListPromotions returns the most recently updated promotions, newest first.
+func (s *StateStore) ListPromotions(limit int) ([]*Promotion, error) {
+	rows, err := s.db.Query(`
+		SELECT version, sha, stage, history, created_at, updated_at
+		FROM autopilot_promotions ORDER BY updated_at DESC, version DESC LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPromotions(rows)
+}
+
+// scanPromotions scans and closes promotion rows.
+func scanPromotions(rows *sql.Rows) ([]*Promotion, error) {
+	defer func() { _ = rows.Close() }()
+
+	var promotions []*Promotion
+	for rows.Next() {
+		var p Promotion
+		var history string
+		var createdAt, updatedAt sql.NullTime
+		if err := rows.Scan(&p.Version, &p.SHA, &p.Stage, &history, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(history), &p.History); err != nil {
+			return nil, fmt.Errorf("failed to decode history of %s: %w", p.Version, err)
+		}
+		p.CreatedAt = createdAt.Time
+		p.UpdatedAt = updatedAt.Time
+		promotions = append(promotions, &p)
+	}
+	return promotions, rows.Err()
+}
+
+// SaveChangePromotion persists a change's pipeline promotion (upsert).
+func (s *StateStore) SaveChangePromotion(p *ChangePromotion) error {
+	history, err := json.Marshal(p.History)
+	if err != nil {
+		return fmt.Errorf("failed to encode promotion history: %w", err)
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO autopilot_change_promotions
+			(pr_number, pr_title, issue_number, stage, sha, status, error, green_at, history, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(pr_number) DO UPDATE SET
+			pr_title = excluded.pr_title,
+			issue_number = excluded.issue_number,
+			stage = excluded.stage,
+			sha = excluded.sha,
+			status = excluded.status,
+			error = excluded.error,
+			green_at = excluded.green_at,
+			history = excluded.history,
+			updated_at = excluded.updated_at
+	`, p.PRNumber, p.PRTitle, p.IssueNumber, p.Stage, p.SHA, string(p.Status), p.Error,
+		nullTime(p.GreenAt), string(history), p.CreatedAt, p.UpdatedAt)
+	return err
+}
+
+// GetChangePromotion retrieves the pipeline promotion of a PR.
+// Returns nil, nil if not found.
+func (s *StateStore) GetChangePromotion(prNumber int) (*ChangePromotion, error) {
+	rows, err := s.db.Query(`
+		SELECT pr_number, pr_title, issue_number, stage, sha, status, error, green_at, history, created_at, updated_at
+		FROM autopilot_change_promotions WHERE pr_number = ?
+	`, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	promotions, err := scanChangePromotions(rows)
+	if err != nil || len(promotions) == 0 {
+		return nil, err
+	}
+	return promotions[0], nil
+}
+
+// ListChangePromotions returns the most recently updated pipeline
+// promotions, newest first. With activeOnly, complete and failed ones are
+// left out.
+func (s *StateStore) ListChangePromotions(limit int, activeOnly bool) ([]*ChangePromotion, error) {
+	query := `
+		SELECT pr_number, pr_title, issue_number, stage, sha, status, error, green_at, history, created_at, updated_at
+		FROM autopilot_change_promotions`
+	if activeOnly {
+		query += ` WHERE status NOT IN ('complete', 'failed')`
+	}
+	query += ` ORDER BY updated_at DESC, pr_number DESC LIMIT ?`
+	rows, err := s.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanChangePromotions(rows)
+}
+
+// scanChangePromotions scans and closes change promotion rows.
+func scanChangePromotions(rows *sql.Rows) ([]*ChangePromotion, error) {
+	defer func() { _ = rows.Close() }()
+
+	var promotions []*ChangePromotion
+	for rows.Next() {
+		var p ChangePromotion
+		var status, history string
+		var greenAt, createdAt, updatedAt sql.NullTime
+		if err := rows.Scan(&p.PRNumber, &p.PRTitle, &p.IssueNumber, &p.Stage, &p.SHA, &status, &p.Error,
+			&greenAt, &history, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(history), &p.History); err != nil {
+			return nil, fmt.Errorf("failed to decode history of PR #%d: %w", p.PRNumber, err)
+		}
+		p.Status = ChangePromotionStatus(status)
+		p.GreenAt = greenAt.Time
+		p.CreatedAt = createdAt.Time
+		p.UpdatedAt = updatedAt.Time
+		promotions = append(promotions, &p)
+	}
+	return promotions, rows.Err()
+}
+
 // scanPRState scans a single row into a PRState.
 func scanPRState(row *sql.Row) (*PRState, error) {
 	var pr PRState
-	var lastChecked, ciWaitStartedAt, createdAt sql.NullTime
-	var stage, ciStatus, relBumpType string
+	var lastChecked, ciWaitStartedAt, createdAt, canaryGreenAt, reviewsAddressedAt sql.NullTime
+	var stage, ciStatus, relBumpType, reviewVerdict string
 
 	err := row.Scan(
 		&pr.PRNumber, &pr.PRURL, &pr.IssueNumber, &pr.BranchName, &pr.HeadSHA,
 		&stage, &ciStatus, &lastChecked, &ciWaitStartedAt,
 		&pr.MergeAttempts, &pr.Error, &createdAt,
-		&pr.ReleaseVersion, &relBumpType,
+		&pr.ReleaseVersion, &relBumpType, &pr.Confidence,
+		&pr.CanarySHA, &canaryGreenAt, &pr.ConflictAttempts,
+		&reviewVerdict, &pr.ReviewSummary, &pr.ReviewFixes, &reviewsAddressedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -811,6 +1167,7 @@ func scanPRState(row *sql.Row) (*PRState, error) {
 	pr.Stage = PRStage(stage)
 	pr.CIStatus = CIStatus(ciStatus)
 	pr.ReleaseBumpType = BumpType(relBumpType)
+	pr.ReviewVerdict = ReviewVerdict(reviewVerdict)
 	if lastChecked.Valid {
 		pr.LastChecked = lastChecked.Time
 	}
@@ -820,6 +1177,12 @@ func scanPRState(row *sql.Row) (*PRState, error) {
 	if createdAt.Valid {
 		pr.CreatedAt = createdAt.Time
 	}
+	if canaryGreenAt.Valid {
+		pr.CanaryGreenAt = canaryGreenAt.Time
+	}
+	if reviewsAddressedAt.Valid {
+		pr.ReviewsAddressedAt = reviewsAddressedAt.Time
+	}
 	return &pr, nil
 }
 
@@ -4,10 +4,12 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/testutil"
 )
 
@@ -24,20 +26,25 @@ func TestStateStore_SaveAndLoadPRState(t *testing.T) {
 	store := newTestStateStore(t)
 
 	pr := &PRState{
-		PRNumber:        42,
-		PRURL:           "https://github.com/owner/repo/pull/42",
-		IssueNumber:     10,
-		BranchName:      "pilot/GH-10",
-		HeadSHA:         "abc123def456",
-		Stage:           StageWaitingCI,
-		CIStatus:        CIRunning,
-		LastChecked:     time.Now().Truncate(time.Second),
-		CIWaitStartedAt: time.Now().Add(-5 * time.Minute).Truncate(time.Second),
-		MergeAttempts:   1,
-		Error:           "",
-		CreatedAt:       time.Now().Add(-10 * time.Minute).Truncate(time.Second),
-		ReleaseVersion:  "",
-		ReleaseBumpType: BumpNone,
+		PRNumber:           42,
+		PRURL:              "https://github.com/owner/repo/pull/42",
+		IssueNumber:        10,
+		BranchName:         "pilot/GH-10",
+		HeadSHA:            "abc123def456",
+		Stage:              StageWaitingCI,
+		CIStatus:           CIRunning,
+		LastChecked:        time.Now().Truncate(time.Second),
+		CIWaitStartedAt:    time.Now().Add(-5 * time.Minute).Truncate(time.Second),
+		MergeAttempts:      1,
+		Error:              "",
+		CreatedAt:          time.Now().Add(-10 * time.Minute).Truncate(time.Second),
+		ReleaseVersion:     "",
+		ReleaseBumpType:    BumpNone,
+		ConflictAttempts:   2,
+		ReviewVerdict:      ReviewRequestChanges,
+		ReviewSummary:      "SQL built from user input",
+		ReviewFixes:        1,
+		ReviewsAddressedAt: time.Now().Add(-2 * time.Minute).Truncate(time.Second),
 	}
 
 	// Save
@@ -78,6 +85,15 @@ func TestStateStore_SaveAndLoadPRState(t *testing.T) {
 	if loaded.MergeAttempts != 1 {
 		t.Errorf("MergeAttempts = %d, want 1", loaded.MergeAttempts)
 	}
+	if loaded.ConflictAttempts != 2 {
+		t.Errorf("ConflictAttempts = %d, want 2", loaded.ConflictAttempts)
+	}
+	if loaded.ReviewVerdict != ReviewRequestChanges || loaded.ReviewSummary != pr.ReviewSummary {
+		t.Errorf("review = %q %q, want persisted verdict and summary", loaded.ReviewVerdict, loaded.ReviewSummary)
+	}
+	if loaded.ReviewFixes != 1 || !loaded.ReviewsAddressedAt.Equal(pr.ReviewsAddressedAt) {
+		t.Errorf("review fixes = %d at %v, want 1 at %v", loaded.ReviewFixes, loaded.ReviewsAddressedAt, pr.ReviewsAddressedAt)
+	}
 }
 
 func TestStateStore_LoadAllPRStates(t *testing.T) {
@@ -271,6 +287,41 @@ func TestStateStore_Metadata(t *testing.T) {
 	}
 }
 
+func TestStateStore_ETags(t *testing.T) {
+	store := newTestStateStore(t)
+	var _ github.ETagStore = store
+
+	etag, body, err := store.GetETag("missing")
+	if err != nil {
+		t.Fatalf("GetETag failed: %v", err)
+	}
+	if etag != "" || body != nil {
+		t.Errorf("expected nothing for missing key, got %q %q", etag, body)
+	}
+
+	key := "api.github.com/abcd/repos/o/r/issues?state=open"
+	if err := store.SaveETag(key, `"v1"`, []byte(`[]`)); err != nil {
+		t.Fatalf("SaveETag failed: %v", err)
+	}
+	if err := store.SaveETag(key, `"v2"`, []byte(`[{"number":1}]`)); err != nil {
+		t.Fatalf("SaveETag update failed: %v", err)
+	}
+	etag, body, err = store.GetETag(key)
+	if err != nil {
+		t.Fatalf("GetETag failed: %v", err)
+	}
+	if etag != `"v2"` || string(body) != `[{"number":1}]` {
+		t.Errorf("got %q %q, want the updated response", etag, body)
+	}
+
+	if purged, _ := store.PurgeOldETags(time.Hour); purged != 0 {
+		t.Errorf("purged = %d fresh entries, want 0", purged)
+	}
+	if purged, _ := store.PurgeOldETags(0); purged != 1 {
+		t.Errorf("purged = %d, want 1", purged)
+	}
+}
+
 func TestStateStore_PurgeOldProcessedIssues(t *testing.T) {
 	store := newTestStateStore(t)
 
@@ -1378,3 +1429,67 @@ func TestStateStore_PurgeOldAdapterProcessed(t *testing.T) {
 		t.Errorf("expected 0 after purge, got %d", len(remaining))
 	}
 }
+
+// TestStateStore_Postgres runs the store against the PostgreSQL server in
+// PILOT_TEST_POSTGRES_DSN
+func TestStateStore_Postgres(t *testing.T) {
+	dsn := os.Getenv("PILOT_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("PILOT_TEST_POSTGRES_DSN not set")
+	}
+	if err := memory.InitBackend(memory.BackendConfig{Driver: memory.DriverPostgres, DSN: dsn}); err != nil {
+		t.Fatalf("InitBackend failed: %v", err)
+	}
+	t.Cleanup(func() { _ = memory.InitBackend(memory.BackendConfig{}) })
+
+	mem, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("memory.NewStore failed: %v", err)
+	}
+	defer func() { _ = mem.Close() }()
+
+	// A second store runs the same migrations against the migrated schema
+	if _, err := NewStateStore(mem.DB()); err != nil {
+		t.Fatalf("NewStateStore failed: %v", err)
+	}
+	store, err := NewStateStore(mem.DB())
+	if err != nil {
+		t.Fatalf("second NewStateStore failed: %v", err)
+	}
+
+	prNumber := int(time.Now().UnixNano() % 1_000_000_000)
+	pr := &PRState{
+		PRNumber:      prNumber,
+		PRURL:         "https://github.com/owner/repo/pull/1",
+		BranchName:    "pilot/GH-1",
+		Stage:         StageWaitingCI,
+		CIStatus:      CIRunning,
+		LastChecked:   time.Now().Truncate(time.Second),
+		MergeAttempts: 1,
+		CreatedAt:     time.Now().Truncate(time.Second),
+		Confidence:    0.8,
+	}
+	if err := store.SavePRState(pr); err != nil {
+		t.Fatalf("SavePRState failed: %v", err)
+	}
+	pr.MergeAttempts = 2
+	if err := store.SavePRState(pr); err != nil {
+		t.Fatalf("SavePRState upsert failed: %v", err)
+	}
+	got, err := store.GetPRState(prNumber)
+	if err != nil {
+		t.Fatalf("GetPRState failed: %v", err)
+	}
+	if got == nil || got.MergeAttempts != 2 || got.Confidence != 0.8 {
+		t.Errorf("GetPRState = %+v, want the upserted state", got)
+	}
+	_ = store.RemovePRState(prNumber)
+
+	if err := store.MarkIssueProcessed(prNumber, "done"); err != nil {
+		t.Fatalf("MarkIssueProcessed failed: %v", err)
+	}
+	if processed, err := store.IsIssueProcessed(prNumber); err != nil || !processed {
+		t.Errorf("IsIssueProcessed = %v, %v, want true", processed, err)
+	}
+	_ = store.UnmarkIssueProcessed(prNumber)
+}
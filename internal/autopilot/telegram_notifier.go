@@ -119,16 +119,22 @@ func (n *TelegramNotifier) NotifyReleased(ctx context.Context, prState *PRState,
 		bumpLabel = "patch release"
 	}
 
+	// Release trains are not cut from a single PR
+	source := "From: release train"
+	if prState.PRNumber > 0 {
+		source = fmt.Sprintf("From PR: #%d", prState.PRNumber)
+	}
+
 	msg := fmt.Sprintf("%s✨ *Release %s Published*\n\n"+
 		"Version: `%s`\n"+
 		"Type: %s\n"+
-		"From PR: #%d\n\n"+
+		"%s\n\n"+
 		"[View Release](%s)",
 		envPrefix(prState),
 		escapeMarkdown(prState.ReleaseVersion),
 		prState.ReleaseVersion,
 		bumpLabel,
-		prState.PRNumber,
+		source,
 		releaseURL,
 	)
 
@@ -136,6 +142,19 @@ func (n *TelegramNotifier) NotifyReleased(ctx context.Context, prState *PRState,
 	return err
 }
 
+// NotifyReleaseTrainSkipped sends notification when CI holds back a release train.
+func (n *TelegramNotifier) NotifyReleaseTrainSkipped(ctx context.Context, train *ReleaseTrain) error {
+	msg := fmt.Sprintf("🚦 *Release train held back*\n\n"+
+		"%d commit(s) since the last release wait for the next train.\n"+
+		"Reason: %s",
+		train.Commits,
+		escapeMarkdown(train.Skipped),
+	)
+
+	_, err := n.client.SendMessage(ctx, n.chatID, msg, "Markdown")
+	return err
+}
+
 // escapeMarkdown escapes special characters for Telegram Markdown.
 func escapeMarkdown(s string) string {
 	replacer := strings.NewReplacer(
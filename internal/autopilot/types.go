@@ -2,7 +2,10 @@ package autopilot
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/robfig/cron/v3"
 )
 
 // Environment defines deployment environment behavior.
@@ -52,6 +55,9 @@ type EnvironmentConfig struct {
 	SkipPostMergeCI bool `yaml:"skip_post_merge_ci"`
 	// MergeMethod overrides the default merge method for this environment.
 	MergeMethod string `yaml:"merge_method,omitempty"`
+	// MergeQueue adds PRs to the target branch's GitHub merge queue instead
+	// of merging them directly. The queue's own merge method applies.
+	MergeQueue bool `yaml:"merge_queue,omitempty"`
 	// PostMerge defines what happens after merge (deployment trigger).
 	PostMerge *PostMergeConfig `yaml:"post_merge,omitempty"`
 	// Release holds per-environment release configuration.
@@ -102,6 +108,13 @@ type Config struct {
 	AutoMerge bool `yaml:"auto_merge"`
 	// MergeMethod specifies how to merge PRs: merge, squash, or rebase.
 	MergeMethod string `yaml:"merge_method"`
+	// MergePolicy decides per PR between auto-merge and review, replacing the
+	// environment's require_approval when set.
+	MergePolicy *MergePolicyConfig `yaml:"merge_policy,omitempty"`
+	// RequireApprovalPaths lists path globs (e.g. "migrations/",
+	// "**/billing/**") whose changes always wait for human approval before
+	// merge, whatever the environment or merge policy says.
+	RequireApprovalPaths []string `yaml:"require_approval_paths,omitempty"`
 
 	// CI Monitoring
 	// CIWaitTimeout is the maximum time to wait for CI to complete.
@@ -115,6 +128,8 @@ type Config struct {
 	RequiredChecks []string `yaml:"required_checks"`
 	// CIChecks holds CI check discovery configuration.
 	CIChecks *CIChecksConfig `yaml:"ci_checks"`
+	// CIProvider selects where CI runs. Nil means GitHub Checks.
+	CIProvider *CIProviderConfig `yaml:"ci_provider,omitempty"`
 
 	// Feedback Loop
 	// AutoCreateIssues enables automatic issue creation for CI failures.
@@ -146,6 +161,32 @@ type Config struct {
 	// Release holds auto-release configuration.
 	Release *ReleaseConfig `yaml:"release"`
 
+	// Rollback opens a revert PR when CI breaks on the target branch right
+	// after an autopilot merge. Requires post-merge CI (skip_post_merge_ci off).
+	Rollback *RollbackConfig `yaml:"rollback,omitempty"`
+
+	// Canary merges PRs into an integration branch first and fast-forwards
+	// the target branch once CI has stayed green there for the soak time.
+	Canary *CanaryConfig `yaml:"canary,omitempty"`
+
+	// Pipeline promotes each merged change through environment branches
+	// (dev → stage → prod) instead of merging under one environment.
+	Pipeline *PipelineConfig `yaml:"pipeline,omitempty"`
+
+	// ConflictResolution runs an executor task on a conflicting PR's branch
+	// to resolve merge conflicts with the base branch before giving up.
+	ConflictResolution *ConflictResolutionConfig `yaml:"conflict_resolution,omitempty"`
+
+	// CodeReview reviews each created PR with a separate, cheaper model and
+	// posts inline comments on risky hunks. A request_changes verdict routes
+	// the PR through the pre-merge approval gate.
+	CodeReview *CodeReviewConfig `yaml:"code_review,omitempty"`
+
+	// Freeze defines recurring merge freeze windows (e.g. Fri 16:00 - Mon
+	// 08:00). While frozen, PRs are still created, reviewed and tested but
+	// merges, promotions and releases wait until the freeze ends.
+	Freeze *FreezeConfig `yaml:"freeze,omitempty"`
+
 	// MergedPRScanWindow is how far back to look for merged PRs on startup (default: 30m).
 	// This catches PRs that were merged while Pilot was offline.
 	MergedPRScanWindow time.Duration `yaml:"merged_pr_scan_window"`
@@ -162,6 +203,10 @@ type ReviewFeedbackConfig struct {
 	// MaxIterations limits how many revision issues can be chained before giving up.
 	// Prevents infinite review-fix cycles. Default: 3. Set to 0 to disable the limit.
 	MaxIterations int `yaml:"max_iterations"`
+	// SameBranch pushes fixes for the review to the PR branch, resuming the
+	// PR's session, and re-requests review instead of closing the PR and
+	// opening a revision issue. Needs a configured project checkout.
+	SameBranch bool `yaml:"same_branch"`
 }
 
 // CIChecksConfig holds configuration for CI check monitoring.
@@ -179,6 +224,48 @@ type CIChecksConfig struct {
 	DiscoveryGracePeriod time.Duration `yaml:"discovery_grace_period"`
 }
 
+// CI providers autopilot can wait on.
+const (
+	CIProviderGitHub    = "github"
+	CIProviderGitLab    = "gitlab"
+	CIProviderCircleCI  = "circleci"
+	CIProviderBuildkite = "buildkite"
+)
+
+// CIProviderConfig selects the CI system whose jobs gate merges and feed fix
+// issues. Jobs are matched against ci_checks by name.
+type CIProviderConfig struct {
+	// Provider is "github" (default), "gitlab", "circleci" or "buildkite".
+	Provider string `yaml:"provider"`
+	// Token authenticates with the provider's API. Not used for github.
+	Token string `yaml:"token,omitempty"`
+	// BaseURL overrides the provider's API URL, e.g. for self-managed GitLab.
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Project is the GitLab project path (default "owner/repo") or the
+	// CircleCI project slug (default "gh/owner/repo").
+	Project string `yaml:"project,omitempty"`
+	// Organization and Pipeline are the Buildkite organization and pipeline
+	// slugs (default owner and repo).
+	Organization string `yaml:"organization,omitempty"`
+	Pipeline     string `yaml:"pipeline,omitempty"`
+}
+
+// Validate checks the provider name and that non-GitHub providers have a
+// token.
+func (c *CIProviderConfig) Validate() error {
+	switch c.Provider {
+	case "", CIProviderGitHub:
+		return nil
+	case CIProviderGitLab, CIProviderCircleCI, CIProviderBuildkite:
+		if c.Token == "" {
+			return fmt.Errorf("%s needs a token", c.Provider)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown provider %q (want github, gitlab, circleci or buildkite)", c.Provider)
+	}
+}
+
 // defaultEnvironments returns built-in environment configs matching legacy behavior.
 func defaultEnvironments() map[string]*EnvironmentConfig {
 	return map[string]*EnvironmentConfig{
@@ -304,6 +391,7 @@ func DefaultConfig() *Config {
 		ReviewFeedback: &ReviewFeedbackConfig{
 			Enabled:       true,
 			MaxIterations: 3,
+			SameBranch:    true,
 		},
 		AutoCreateIssues:    true,
 		IssueLabels:         []string{"pilot", "autopilot-fix"},
@@ -323,8 +411,14 @@ func DefaultConfig() *Config {
 type ReleaseConfig struct {
 	// Enabled controls whether auto-release is active.
 	Enabled bool `yaml:"enabled"`
-	// Trigger determines when to release: "on_merge" or "manual".
+	// Trigger determines when to release: "on_merge", "schedule" or "manual".
 	Trigger string `yaml:"trigger"`
+	// Schedule is the cron expression release trains depart on with trigger
+	// "schedule", e.g. "0 17 * * 1-5". Each train releases everything merged
+	// since the last release.
+	Schedule string `yaml:"schedule,omitempty"`
+	// Timezone is the IANA timezone of Schedule (default: local).
+	Timezone string `yaml:"timezone,omitempty"`
 	// VersionStrategy determines how to bump version: "conventional_commits" or "pr_labels".
 	VersionStrategy string `yaml:"version_strategy"`
 	// TagPrefix is prepended to version (default "v").
@@ -335,6 +429,352 @@ type ReleaseConfig struct {
 	NotifyOnRelease bool `yaml:"notify_on_release"`
 	// RequireCI waits for post-merge CI before releasing.
 	RequireCI bool `yaml:"require_ci"`
+	// VersionFiles are rewritten to the new version and committed before
+	// tagging. Projects can override this list with their own version_files.
+	VersionFiles []VersionFile `yaml:"version_files,omitempty"`
+	// Promotion turns releases into a delivery pipeline: the release is tagged
+	// as a candidate (-rc.N) and promoted through environments from there.
+	Promotion *PromotionConfig `yaml:"promotion,omitempty"`
+}
+
+// Validate checks the release train schedule and timezone.
+func (c *ReleaseConfig) Validate() error {
+	if c.Trigger != "schedule" {
+		return nil
+	}
+	if _, err := c.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	if _, err := cron.ParseStandard(c.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", c.Schedule, err)
+	}
+	return nil
+}
+
+// NextTrain returns the next release train departure after t.
+func (c *ReleaseConfig) NextTrain(after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(c.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := c.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after.In(loc)), nil
+}
+
+// location returns the schedule's timezone, defaulting to the local one.
+func (c *ReleaseConfig) location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+// PromotionConfig defines the environments a release candidate is promoted
+// through before it is tagged as a final release.
+type PromotionConfig struct {
+	// Enabled makes releases tag a candidate (v1.2.0-rc.1) instead of the
+	// final version.
+	Enabled bool `yaml:"enabled"`
+	// CandidateDeploy runs after the candidate tag is created (e.g. dev deploy).
+	CandidateDeploy *PostMergeConfig `yaml:"candidate_deploy,omitempty"`
+	// Stages are promoted through in order. Defaults to staging, then prod.
+	Stages []PromotionStage `yaml:"stages,omitempty"`
+}
+
+// PromotionStage is one environment in the promotion pipeline.
+type PromotionStage struct {
+	// Name identifies the environment (e.g. "staging", "prod").
+	Name string `yaml:"name"`
+	// TagSuffix is appended to the version for this stage's tag. Empty means
+	// the plain version tag, which is the final release.
+	TagSuffix string `yaml:"tag_suffix,omitempty"`
+	// RequireApproval gates promotion to this stage on the pre_promotion
+	// approval stage or an explicit `pilot release promote`.
+	RequireApproval bool `yaml:"require_approval"`
+	// Deploy runs after the stage tag is created.
+	Deploy *PostMergeConfig `yaml:"deploy,omitempty"`
+}
+
+// DefaultPromotionStages returns the default pipeline: staging is promoted to
+// automatically, prod waits for approval.
+func DefaultPromotionStages() []PromotionStage {
+	return []PromotionStage{
+		{Name: "staging", TagSuffix: "-staging"},
+		{Name: "prod", RequireApproval: true},
+	}
+}
+
+// ResolvedStages returns the configured stages or the defaults.
+func (c *PromotionConfig) ResolvedStages() []PromotionStage {
+	if len(c.Stages) == 0 {
+		return DefaultPromotionStages()
+	}
+	return c.Stages
+}
+
+// Validate checks that stage names are set and unique and that only the last
+// stage produces the final (unsuffixed) tag.
+func (c *PromotionConfig) Validate() error {
+	seen := make(map[string]bool)
+	stages := c.ResolvedStages()
+	for i, stage := range stages {
+		if stage.Name == "" {
+			return fmt.Errorf("promotion stage %d: name is required", i+1)
+		}
+		if stage.Name == PromotionStageCandidate {
+			return fmt.Errorf("promotion stage name %q is reserved", stage.Name)
+		}
+		if seen[stage.Name] {
+			return fmt.Errorf("duplicate promotion stage %q", stage.Name)
+		}
+		seen[stage.Name] = true
+		if stage.TagSuffix == "" && i != len(stages)-1 {
+			return fmt.Errorf("promotion stage %q: only the last stage may omit tag_suffix", stage.Name)
+		}
+	}
+	return nil
+}
+
+// defaultRollbackWindow is how long after a merge a post-merge CI failure is
+// blamed on it when rollback.window is not set.
+const defaultRollbackWindow = 30 * time.Minute
+
+// RollbackConfig controls automatic reverts of autopilot merges that break CI.
+type RollbackConfig struct {
+	// Enabled opens a revert PR and alerts when post-merge CI fails.
+	Enabled bool `yaml:"enabled"`
+	// Window is how long after the merge a CI failure still triggers a
+	// rollback (default: 30m). Later failures get a fix issue instead.
+	Window time.Duration `yaml:"window,omitempty"`
+}
+
+// ResolvedWindow returns the failure-check window, applying the default.
+func (c *RollbackConfig) ResolvedWindow() time.Duration {
+	if c.Window <= 0 {
+		return defaultRollbackWindow
+	}
+	return c.Window
+}
+
+// Validate checks the failure-check window.
+func (c *RollbackConfig) Validate() error {
+	if c.Window < 0 {
+		return fmt.Errorf("window must not be negative, got %v", c.Window)
+	}
+	return nil
+}
+
+// defaultConflictResolutionAttempts caps resolution passes per PR when
+// conflict_resolution.max_attempts is not set.
+const defaultConflictResolutionAttempts = 2
+
+// ConflictResolutionConfig controls the automatic conflict resolution pass.
+type ConflictResolutionConfig struct {
+	// Enabled runs a resolution task when GitHub cannot update the PR branch.
+	Enabled bool `yaml:"enabled"`
+	// MaxAttempts is how many resolution passes a PR gets before it is
+	// closed and its issue requeued (default: 2).
+	MaxAttempts int `yaml:"max_attempts,omitempty"`
+}
+
+// ResolvedMaxAttempts returns the attempt cap, applying the default.
+func (c *ConflictResolutionConfig) ResolvedMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return defaultConflictResolutionAttempts
+	}
+	return c.MaxAttempts
+}
+
+// Validate checks the attempt cap.
+func (c *ConflictResolutionConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts must not be negative, got %d", c.MaxAttempts)
+	}
+	return nil
+}
+
+// defaultCodeReviewComments caps inline review comments per PR when
+// code_review.max_comments is not set.
+const defaultCodeReviewComments = 10
+
+// CodeReviewConfig controls the automated code review of created PRs.
+type CodeReviewConfig struct {
+	// Enabled reviews every PR autopilot picks up.
+	Enabled bool `yaml:"enabled"`
+	// MaxComments caps the inline comments posted per PR; further findings
+	// are listed in the review body (default: 10).
+	MaxComments int `yaml:"max_comments,omitempty"`
+}
+
+// ResolvedMaxComments returns the inline comment cap, applying the default.
+func (c *CodeReviewConfig) ResolvedMaxComments() int {
+	if c.MaxComments <= 0 {
+		return defaultCodeReviewComments
+	}
+	return c.MaxComments
+}
+
+// Validate checks the comment cap.
+func (c *CodeReviewConfig) Validate() error {
+	if c.MaxComments < 0 {
+		return fmt.Errorf("max_comments must not be negative, got %d", c.MaxComments)
+	}
+	return nil
+}
+
+const (
+	// defaultCanaryBranch is the integration branch when canary.branch is not set.
+	defaultCanaryBranch = "canary"
+	// defaultCanarySoakTime is how long canary CI must stay green when
+	// canary.soak_time is not set.
+	defaultCanarySoakTime = 2 * time.Hour
+)
+
+// CanaryConfig controls the canary merge strategy: PRs merge into an
+// integration branch, and the target branch is fast-forwarded to it only
+// after CI has stayed green there for the soak time.
+type CanaryConfig struct {
+	// Enabled merges PRs into Branch instead of the target branch.
+	Enabled bool `yaml:"enabled"`
+	// Branch is the integration branch (default: "canary"). Created from the
+	// target branch when missing.
+	Branch string `yaml:"branch,omitempty"`
+	// SoakTime is how long CI must stay green on the canary merge commit
+	// before the target branch is fast-forwarded (default: 2h).
+	SoakTime time.Duration `yaml:"soak_time,omitempty"`
+}
+
+// ResolvedBranch returns the integration branch, applying the default.
+func (c *CanaryConfig) ResolvedBranch() string {
+	if c.Branch == "" {
+		return defaultCanaryBranch
+	}
+	return c.Branch
+}
+
+// ResolvedSoakTime returns the soak time, applying the default.
+func (c *CanaryConfig) ResolvedSoakTime() time.Duration {
+	if c.SoakTime <= 0 {
+		return defaultCanarySoakTime
+	}
+	return c.SoakTime
+}
+
+// Validate checks the soak time and that the branch is usable as a ref.
+func (c *CanaryConfig) Validate() error {
+	if c.SoakTime < 0 {
+		return fmt.Errorf("soak_time must not be negative, got %v", c.SoakTime)
+	}
+	if strings.ContainsAny(c.Branch, " ~^:?*[\\") {
+		return fmt.Errorf("invalid branch name %q", c.Branch)
+	}
+	return nil
+}
+
+// defaultPipelineSoakTime is how long CI must stay green on a change in an
+// environment when pipeline.soak_time is not set.
+const defaultPipelineSoakTime = 2 * time.Hour
+
+// PipelineConfig promotes every change through a chain of environments, each
+// with its own branch. PRs merge into the first environment's branch under
+// its rules; the change is then cherry-picked into each next environment's
+// branch once CI has stayed green for the soak time. Environments with
+// require_approval wait for approval before the change enters them.
+type PipelineConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Environments are promoted through in order (default: dev, stage, prod).
+	// Each must have a branch of its own.
+	Environments []string `yaml:"environments,omitempty"`
+	// SoakTime is how long CI must stay green in an environment before the
+	// change is promoted to the next one (default: 2h).
+	SoakTime time.Duration `yaml:"soak_time,omitempty"`
+}
+
+// ResolvedEnvironments returns the environment names, applying the default.
+func (c *PipelineConfig) ResolvedEnvironments() []string {
+	if len(c.Environments) == 0 {
+		return []string{"dev", "stage", "prod"}
+	}
+	return c.Environments
+}
+
+// ResolvedSoakTime returns the soak time, applying the default.
+func (c *PipelineConfig) ResolvedSoakTime() time.Duration {
+	if c.SoakTime <= 0 {
+		return defaultPipelineSoakTime
+	}
+	return c.SoakTime
+}
+
+// PipelineStage is one environment of the promotion pipeline.
+type PipelineStage struct {
+	Name            string
+	Branch          string
+	RequireApproval bool
+}
+
+// PipelineStages resolves the pipeline's environments, in order. Names not in
+// the environments map fall back to the built-in environments.
+func (c *Config) PipelineStages() []PipelineStage {
+	if c.Pipeline == nil {
+		return nil
+	}
+	defaults := defaultEnvironments()
+	var stages []PipelineStage
+	for _, name := range c.Pipeline.ResolvedEnvironments() {
+		env := c.Environments[name]
+		if env == nil {
+			env = defaults[name]
+		}
+		stage := PipelineStage{Name: name}
+		if env != nil {
+			stage.Branch = env.Branch
+			stage.RequireApproval = env.RequireApproval
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// ValidatePipeline checks that the pipeline has at least two known
+// environments with distinct branches, and is not combined with canary
+// merges or the merge queue, which also decide where merges land.
+func (c *Config) ValidatePipeline() error {
+	if c.Pipeline == nil || !c.Pipeline.Enabled {
+		return nil
+	}
+	if c.Pipeline.SoakTime < 0 {
+		return fmt.Errorf("soak_time must not be negative, got %v", c.Pipeline.SoakTime)
+	}
+	if c.Canary != nil && c.Canary.Enabled {
+		return fmt.Errorf("cannot be combined with canary merges")
+	}
+	stages := c.PipelineStages()
+	if len(stages) < 2 {
+		return fmt.Errorf("needs at least two environments, got %d", len(stages))
+	}
+	defaults := defaultEnvironments()
+	branches := make(map[string]string)
+	for _, stage := range stages {
+		env := c.Environments[stage.Name]
+		if env == nil && defaults[stage.Name] == nil {
+			return fmt.Errorf("unknown environment %q", stage.Name)
+		}
+		if env != nil && env.MergeQueue {
+			return fmt.Errorf("environment %q: merge_queue cannot be combined with the pipeline", stage.Name)
+		}
+		if stage.Branch == "" || strings.ContainsAny(stage.Branch, " ~^:?*[\\") {
+			return fmt.Errorf("environment %q: invalid branch %q", stage.Name, stage.Branch)
+		}
+		if other, ok := branches[stage.Branch]; ok {
+			return fmt.Errorf("environments %q and %q share branch %q; give each its own branch", other, stage.Name, stage.Branch)
+		}
+		branches[stage.Branch] = stage.Name
+	}
+	return nil
 }
 
 // DefaultReleaseConfig returns sensible defaults for release configuration.
@@ -360,20 +800,38 @@ const (
 	StageWaitingCI PRStage = "waiting_ci"
 	// StageCIPassed indicates all CI checks have passed.
 	StageCIPassed PRStage = "ci_passed"
+	// StageMarkingReady indicates CI passed on a draft PR and it is being
+	// marked ready for review.
+	StageMarkingReady PRStage = "marking_ready"
 	// StageCIFailed indicates one or more CI checks have failed.
 	StageCIFailed PRStage = "ci_failed"
 	// StageAwaitApproval indicates the PR is waiting for human approval.
 	StageAwaitApproval PRStage = "awaiting_approval"
 	// StageMerging indicates the PR is being merged.
 	StageMerging PRStage = "merging"
+	// StageResolvingConflicts indicates an executor task is resolving merge
+	// conflicts on the PR branch.
+	StageResolvingConflicts PRStage = "resolving_conflicts"
+	// StageMergeQueued indicates the PR is in the GitHub merge queue, waiting
+	// for GitHub to merge it.
+	StageMergeQueued PRStage = "merge_queued"
 	// StageMerged indicates the PR has been successfully merged.
 	StageMerged PRStage = "merged"
 	// StagePostMergeCI indicates post-merge CI is running on main branch.
 	StagePostMergeCI PRStage = "post_merge_ci"
+	// StageCanaryCI indicates the PR was merged into the canary branch and CI
+	// is running on the canary merge commit.
+	StageCanaryCI PRStage = "canary_ci"
+	// StageCanarySoak indicates canary CI passed and the merge is soaking
+	// before the target branch is fast-forwarded to it.
+	StageCanarySoak PRStage = "canary_soak"
 	// StageReleasing indicates the PR is triggering an automatic release.
 	StageReleasing PRStage = "releasing"
 	// StageReviewRequested indicates a human reviewer requested changes on the PR.
 	StageReviewRequested PRStage = "review_requested"
+	// StageFixingReview indicates an executor task is addressing review
+	// feedback on the PR branch.
+	StageFixingReview PRStage = "fixing_review"
 	// StageFailed indicates the PR pipeline has failed and requires intervention.
 	StageFailed PRStage = "failed"
 )
@@ -392,6 +850,17 @@ const (
 	CIFailure CIStatus = "failure"
 )
 
+// ReviewVerdict is the outcome of the automated code review of a PR.
+type ReviewVerdict string
+
+const (
+	// ReviewApprove means the reviewer found nothing that blocks the merge.
+	ReviewApprove ReviewVerdict = "approve"
+	// ReviewRequestChanges means the reviewer found a likely bug or
+	// vulnerability; the merge waits for human approval.
+	ReviewRequestChanges ReviewVerdict = "request_changes"
+)
+
 // BumpType represents semantic version bump types.
 type BumpType string
 
@@ -456,4 +925,55 @@ type PRState struct {
 	TargetBranch string
 	// IssueNodeID is the GraphQL global node ID of the linked issue, used for board sync.
 	IssueNodeID string
+	// Confidence is the intent judge's confidence in the change (0 when unknown).
+	Confidence float64
+	// CanarySHA is the PR's merge commit on the canary branch.
+	CanarySHA string
+	// CanaryGreenAt is when CI first passed on CanarySHA; the soak time
+	// counts from here.
+	CanaryGreenAt time.Time
+	// ConflictAttempts counts the conflict resolution passes run on the PR.
+	ConflictAttempts int
+	// ReviewFixes counts the review fix passes run on the PR branch.
+	ReviewFixes int
+	// ReviewsAddressedAt is when the last review fix pass started; reviews
+	// submitted before it have been handled.
+	ReviewsAddressedAt time.Time
+	// ReviewVerdict is the automated code review's verdict (empty until the
+	// review finished).
+	ReviewVerdict ReviewVerdict
+	// ReviewSummary is the code review's one-line summary.
+	ReviewSummary string
+	// MergeQueuePosition is the PR's 1-based position in the merge queue
+	// (not persisted, refreshed every cycle while queued).
+	MergeQueuePosition int
+	// MergeQueueSHA is the merge group commit CI last ran on (not persisted).
+	MergeQueueSHA string
+	// MergeEvaluation caches the merge policy outcome once CI passes (not persisted).
+	MergeEvaluation *MergeEvaluation
+	// ApprovalPaths caches the require_approval_paths check once CI passes (not persisted).
+	ApprovalPaths *ApprovalPathCheck
+	// Approved is set once the head got any approval it needs to merge (not persisted).
+	Approved bool
+	// FrozenAt is when a merge freeze first held the PR (not persisted).
+	FrozenAt time.Time
+	// LinkedWaitAt is when the PR first waited for the other PRs of its
+	// multi-repo change (not persisted).
+	LinkedWaitAt time.Time
+}
+
+// resetHead forgets the PR's head commit so the next tick looks it up again,
+// along with the merge checks made on it.
+func (p *PRState) resetHead() {
+	p.HeadSHA = ""
+	p.clearMergeChecks()
+}
+
+// clearMergeChecks drops the merge policy and require_approval_paths
+// outcomes and the approval given for an earlier head, so new commits are
+// checked and approved again before merging.
+func (p *PRState) clearMergeChecks() {
+	p.MergeEvaluation = nil
+	p.ApprovalPaths = nil
+	p.Approved = false
 }
@@ -164,3 +164,24 @@ func TestEnvironmentName_NewStyle(t *testing.T) {
 		t.Errorf("EnvironmentName() = %q, want %q", got2, "canary")
 	}
 }
+
+func TestCanaryConfig(t *testing.T) {
+	cfg := &CanaryConfig{Enabled: true}
+	if cfg.ResolvedBranch() != "canary" || cfg.ResolvedSoakTime() != 2*time.Hour {
+		t.Errorf("defaults = %s / %v, want canary / 2h", cfg.ResolvedBranch(), cfg.ResolvedSoakTime())
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	cfg = &CanaryConfig{Branch: "integration", SoakTime: 6 * time.Hour}
+	if cfg.ResolvedBranch() != "integration" || cfg.ResolvedSoakTime() != 6*time.Hour {
+		t.Errorf("resolved = %s / %v", cfg.ResolvedBranch(), cfg.ResolvedSoakTime())
+	}
+	if err := (&CanaryConfig{SoakTime: -time.Hour}).Validate(); err == nil {
+		t.Error("Validate() should reject negative soak_time")
+	}
+	if err := (&CanaryConfig{Branch: "bad branch"}).Validate(); err == nil {
+		t.Error("Validate() should reject invalid branch names")
+	}
+}
@@ -0,0 +1,178 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Version file types with built-in patterns.
+const (
+	VersionFilePackageJSON = "package.json"
+	VersionFilePyproject   = "pyproject.toml"
+	VersionFileHelm        = "helm"
+	VersionFileGo          = "go"
+)
+
+// VersionFile maps a file in the repository to the version string it carries.
+// The releaser rewrites it to the new version and commits it before tagging.
+type VersionFile struct {
+	// Path is the file path relative to the repository root.
+	Path string `yaml:"path"`
+	// Type selects a built-in pattern: "package.json", "pyproject.toml",
+	// "helm" (Chart.yaml version and appVersion) or "go" (a Version constant).
+	// Detected from Path when empty.
+	Type string `yaml:"type,omitempty"`
+	// Pattern is a regular expression overriding Type. Its first capture group
+	// is replaced by the version (without tag prefix) on every match.
+	Pattern string `yaml:"pattern,omitempty"`
+}
+
+// versionFilePatterns are the built-in patterns per type. Only the first match
+// of each pattern is rewritten, so nested "version" keys are left alone.
+var versionFilePatterns = map[string][]*regexp.Regexp{
+	VersionFilePackageJSON: {regexp.MustCompile(`(?m)^\s*"version"\s*:\s*"([^"]*)"`)},
+	VersionFilePyproject:   {regexp.MustCompile(`(?m)^version\s*=\s*["']([^"']*)["']`)},
+	VersionFileHelm: {
+		regexp.MustCompile(`(?m)^version:\s*["']?([^"'\s#]+)`),
+		regexp.MustCompile(`(?m)^appVersion:\s*["']?v?([^"'\s#]+)`),
+	},
+	VersionFileGo: {regexp.MustCompile(`\bVersion\s*(?:string\s*)?=\s*"v?([^"]*)"`)},
+}
+
+// DetectVersionFileType infers the version file type from its name.
+// Returns "" when the type cannot be inferred.
+func DetectVersionFileType(filePath string) string {
+	base := path.Base(filePath)
+	switch {
+	case base == "package.json":
+		return VersionFilePackageJSON
+	case base == "pyproject.toml":
+		return VersionFilePyproject
+	case base == "Chart.yaml":
+		return VersionFileHelm
+	case strings.HasSuffix(base, ".go"):
+		return VersionFileGo
+	}
+	return ""
+}
+
+// Validate checks that the file has a path and a usable type or pattern.
+func (f VersionFile) Validate() error {
+	if f.Path == "" {
+		return fmt.Errorf("version file path is required")
+	}
+	if f.Pattern != "" {
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern for %s: %w", f.Path, err)
+		}
+		if re.NumSubexp() < 1 {
+			return fmt.Errorf("pattern for %s must have a capture group for the version", f.Path)
+		}
+		return nil
+	}
+	fileType := f.Type
+	if fileType == "" {
+		fileType = DetectVersionFileType(f.Path)
+	}
+	if _, ok := versionFilePatterns[fileType]; !ok {
+		return fmt.Errorf("unknown version file type %q for %s (set type or pattern)", fileType, f.Path)
+	}
+	return nil
+}
+
+// Apply returns content with the version rewritten. It fails when the file
+// contains no version to replace, so a misconfigured mapping is not silently
+// released without a bump.
+func (f VersionFile) Apply(content []byte, version string) ([]byte, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	if f.Pattern != "" {
+		re := regexp.MustCompile(f.Pattern)
+		if !re.Match(content) {
+			return nil, fmt.Errorf("pattern for %s did not match", f.Path)
+		}
+		return re.ReplaceAllFunc(content, func(match []byte) []byte {
+			return replaceFirstGroup(re, match, version)
+		}), nil
+	}
+
+	fileType := f.Type
+	if fileType == "" {
+		fileType = DetectVersionFileType(f.Path)
+	}
+	matched := false
+	for _, re := range versionFilePatterns[fileType] {
+		loc := re.FindSubmatchIndex(content)
+		if loc == nil {
+			continue
+		}
+		matched = true
+		updated := make([]byte, 0, len(content)+len(version))
+		updated = append(updated, content[:loc[2]]...)
+		updated = append(updated, version...)
+		updated = append(updated, content[loc[3]:]...)
+		content = updated
+	}
+	if !matched {
+		return nil, fmt.Errorf("no %s version found in %s", fileType, f.Path)
+	}
+	return content, nil
+}
+
+// replaceFirstGroup replaces capture group 1 within a single match of re.
+func replaceFirstGroup(re *regexp.Regexp, match []byte, version string) []byte {
+	loc := re.FindSubmatchIndex(match)
+	if loc == nil || loc[2] < 0 {
+		return match
+	}
+	out := make([]byte, 0, len(match)+len(version))
+	out = append(out, match[:loc[2]]...)
+	out = append(out, version...)
+	return append(out, match[loc[3]:]...)
+}
+
+// UpdateVersionFiles rewrites files to newVersion as of baseSHA and commits
+// the changes to branch. It returns the SHA to tag: the new commit, or
+// baseSHA when every file already carries the version (e.g. on retry).
+func (r *Releaser) UpdateVersionFiles(ctx context.Context, files []VersionFile, branch, baseSHA string, newVersion SemVer) (string, error) {
+	if len(files) == 0 {
+		return baseSHA, nil
+	}
+
+	version := newVersion.String("")
+	changed := make(map[string][]byte)
+	for _, f := range files {
+		content, err := r.ghClient.GetFileContent(ctx, r.owner, r.repo, f.Path, baseSHA)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		if pending, ok := changed[f.Path]; ok {
+			// Several mappings may target the same file.
+			content = pending
+		}
+		updated, err := f.Apply(content, version)
+		if err != nil {
+			return "", err
+		}
+		if string(updated) != string(content) || changed[f.Path] != nil {
+			changed[f.Path] = updated
+		}
+	}
+	if len(changed) == 0 {
+		return baseSHA, nil
+	}
+
+	tagName := newVersion.String(r.config.TagPrefix)
+	message := fmt.Sprintf("chore(release): %s", tagName)
+	sha, err := r.ghClient.CommitFiles(ctx, r.owner, r.repo, branch, baseSHA, message, changed)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit version files: %w", err)
+	}
+	return sha, nil
+}
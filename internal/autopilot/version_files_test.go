@@ -0,0 +1,172 @@
+package autopilot
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+)
+
+func TestVersionFile_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    VersionFile
+		content string
+		want    string
+	}{
+		{
+			name:    "package.json top-level version only",
+			file:    VersionFile{Path: "web/package.json"},
+			content: "{\n  \"name\": \"web\",\n  \"version\": \"1.2.3\",\n  \"engines\": {\"version\": \"18\"}\n}\n",
+			want:    "{\n  \"name\": \"web\",\n  \"version\": \"2.0.0\",\n  \"engines\": {\"version\": \"18\"}\n}\n",
+		},
+		{
+			name:    "pyproject.toml",
+			file:    VersionFile{Path: "pyproject.toml"},
+			content: "[project]\nname = \"svc\"\nversion = \"0.9.1\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\n",
+			want:    "[project]\nname = \"svc\"\nversion = \"2.0.0\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\n",
+		},
+		{
+			name:    "helm chart version and appVersion",
+			file:    VersionFile{Path: "charts/api/Chart.yaml"},
+			content: "apiVersion: v2\nname: api\nversion: 1.2.3\nappVersion: \"v1.2.3\"\n",
+			want:    "apiVersion: v2\nname: api\nversion: 2.0.0\nappVersion: \"v2.0.0\"\n",
+		},
+		{
+			name:    "go version constant keeps v prefix",
+			file:    VersionFile{Path: "internal/version/version.go"},
+			content: "package version\n\n// Version is the release version.\nconst Version = \"v1.2.3\"\n",
+			want:    "package version\n\n// Version is the release version.\nconst Version = \"v2.0.0\"\n",
+		},
+		{
+			name:    "go var with type",
+			file:    VersionFile{Path: "main.go", Type: VersionFileGo},
+			content: "var Version string = \"1.2.3\"\n",
+			want:    "var Version string = \"2.0.0\"\n",
+		},
+		{
+			name:    "custom pattern replaces every match",
+			file:    VersionFile{Path: "README.md", Pattern: `install\.sh@v([\d.]+)`},
+			content: "curl .../install.sh@v1.2.3\nor install.sh@v1.2.3 again\n",
+			want:    "curl .../install.sh@v2.0.0\nor install.sh@v2.0.0 again\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.file.Apply([]byte(tt.content), "2.0.0")
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Apply() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionFile_ApplyNoMatch(t *testing.T) {
+	f := VersionFile{Path: "package.json"}
+	if _, err := f.Apply([]byte(`{"name": "web"}`), "2.0.0"); err == nil {
+		t.Error("Apply() should fail when the file has no version")
+	}
+}
+
+func TestVersionFile_Validate(t *testing.T) {
+	tests := []struct {
+		file    VersionFile
+		wantErr bool
+	}{
+		{VersionFile{Path: "package.json"}, false},
+		{VersionFile{Path: "deploy/Chart.yaml"}, false},
+		{VersionFile{Path: "VERSION", Pattern: `^(\S+)`}, false},
+		{VersionFile{Path: "VERSION"}, true},
+		{VersionFile{Path: "VERSION", Pattern: `^\S+`}, true},
+		{VersionFile{Path: "VERSION", Pattern: `(`}, true},
+		{VersionFile{Path: "setup.cfg", Type: "ini"}, true},
+		{VersionFile{}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.file.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.file, err, tt.wantErr)
+		}
+	}
+}
+
+func TestReleaser_UpdateVersionFiles(t *testing.T) {
+	contents := map[string]string{
+		"package.json":    "{\n  \"version\": \"1.2.3\"\n}\n",
+		"version/main.go": "package version\n\nconst Version = \"v1.3.0\"\n",
+	}
+	var committed map[string]interface{}
+	var refUpdate map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/repos/owner/repo/contents/"):
+			if r.URL.Query().Get("ref") != "merge-sha" {
+				t.Errorf("contents ref = %q, want merge-sha", r.URL.Query().Get("ref"))
+			}
+			content, ok := contents[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+			})
+		case r.URL.Path == "/repos/owner/repo/git/commits/merge-sha":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"tree": map[string]string{"sha": "base-tree"}})
+		case r.URL.Path == "/repos/owner/repo/git/trees" && r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&committed)
+			_ = json.NewEncoder(w).Encode(map[string]string{"sha": "new-tree"})
+		case r.URL.Path == "/repos/owner/repo/git/commits" && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(map[string]string{"sha": "bump-sha"})
+		case r.URL.Path == "/repos/owner/repo/git/refs/heads/main" && r.Method == http.MethodPatch:
+			_ = json.NewDecoder(r.Body).Decode(&refUpdate)
+			_ = json.NewEncoder(w).Encode(map[string]string{"ref": "refs/heads/main"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := github.NewClientWithBaseURL("test-token", server.URL)
+	r := NewReleaser(client, "owner", "repo", &ReleaseConfig{Enabled: true, TagPrefix: "v"})
+	files := []VersionFile{{Path: "package.json"}, {Path: "version/main.go"}}
+
+	sha, err := r.UpdateVersionFiles(context.Background(), files, "main", "merge-sha", SemVer{Major: 1, Minor: 3})
+	if err != nil {
+		t.Fatalf("UpdateVersionFiles() error = %v", err)
+	}
+	if sha != "bump-sha" {
+		t.Errorf("UpdateVersionFiles() = %q, want bump-sha", sha)
+	}
+
+	// version/main.go already carries v1.3.0, so only package.json is committed.
+	entries, _ := committed["tree"].([]interface{})
+	if committed["base_tree"] != "base-tree" || len(entries) != 1 {
+		t.Fatalf("tree request = %v, want one entry on base-tree", committed)
+	}
+	entry := entries[0].(map[string]interface{})
+	if entry["path"] != "package.json" || entry["content"] != "{\n  \"version\": \"1.3.0\"\n}\n" {
+		t.Errorf("tree entry = %v", entry)
+	}
+	if refUpdate["sha"] != "bump-sha" || refUpdate["force"] != false {
+		t.Errorf("ref update = %v, want non-forced update to bump-sha", refUpdate)
+	}
+
+	// Nothing left to bump: the base commit is tagged as-is.
+	contents["package.json"] = "{\n  \"version\": \"1.3.0\"\n}\n"
+	sha, err = r.UpdateVersionFiles(context.Background(), files, "main", "merge-sha", SemVer{Major: 1, Minor: 3})
+	if err != nil || sha != "merge-sha" {
+		t.Errorf("UpdateVersionFiles() = %q, %v, want merge-sha", sha, err)
+	}
+}
@@ -325,6 +325,60 @@ func formatTelegramTokens(tokens int64) string {
 	return fmt.Sprintf("%.1fM", float64(tokens)/1000000)
 }
 
+// DeliverDigest sends a personal digest to the member's Telegram and Slack
+// direct messages. Platforms without a client or member mapping are skipped.
+func (d *DeliveryService) DeliverDigest(ctx context.Context, digest *Digest) []DeliveryResult {
+	var results []DeliveryResult
+	text := FormatDigest(digest)
+
+	if d.telegramSender != nil && digest.Member.TelegramID != 0 {
+		chatID := fmt.Sprintf("%d", digest.Member.TelegramID)
+		result := DeliveryResult{
+			Channel: fmt.Sprintf("telegram:%s", chatID),
+			SentAt:  time.Now(),
+		}
+		resp, err := d.telegramSender.SendBriefMessage(ctx, chatID, text, "Markdown")
+		if err != nil {
+			result.Error = err
+			d.logger.Error("failed to deliver digest to Telegram",
+				"member", digest.Member.Email,
+				"error", err,
+			)
+		} else {
+			result.Success = true
+			if resp != nil {
+				result.MessageID = fmt.Sprintf("%d", resp.MessageID)
+			}
+		}
+		results = append(results, result)
+	}
+
+	if d.slackClient != nil && digest.Member.SlackUserID != "" {
+		result := DeliveryResult{
+			Channel: fmt.Sprintf("slack:%s", digest.Member.SlackUserID),
+			SentAt:  time.Now(),
+		}
+		// Slack uses single asterisks for bold, as the digest formatter does
+		resp, err := d.slackClient.PostMessage(ctx, &slack.Message{
+			Channel: digest.Member.SlackUserID,
+			Text:    text,
+		})
+		if err != nil {
+			result.Error = err
+			d.logger.Error("failed to deliver digest to Slack",
+				"member", digest.Member.Email,
+				"error", err,
+			)
+		} else {
+			result.Success = true
+			result.MessageID = resp.TS
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
 // Deliver sends brief to a specific channel by name
 func (d *DeliveryService) Deliver(ctx context.Context, brief *Brief, channelName string) (DeliveryResult, error) {
 	for _, channel := range d.config.Channels {
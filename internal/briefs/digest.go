@@ -0,0 +1,311 @@
+package briefs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+// MemberLister lists teams and their members (satisfied by *teams.Service)
+type MemberLister interface {
+	ListTeams() ([]*teams.Team, error)
+	ListMembers(teamID string) ([]*teams.Member, error)
+}
+
+// ApprovalLister lists pending approval requests (satisfied by *approval.Manager)
+type ApprovalLister interface {
+	GetPendingRequests() []*approval.Request
+}
+
+// DigestGenerator creates personal digests for team members
+type DigestGenerator struct {
+	store     *memory.Store
+	members   MemberLister
+	approvals ApprovalLister
+	config    *BriefConfig
+}
+
+// NewDigestGenerator creates a new digest generator.
+// The approvals parameter is optional; without it digests omit pending approvals.
+func NewDigestGenerator(store *memory.Store, members MemberLister, approvals ApprovalLister, config *BriefConfig) *DigestGenerator {
+	if config == nil {
+		config = DefaultBriefConfig()
+	}
+	return &DigestGenerator{
+		store:     store,
+		members:   members,
+		approvals: approvals,
+		config:    config,
+	}
+}
+
+// Members returns the opted-in members reachable on Telegram or Slack.
+// A person in several teams is returned once, keyed by email.
+func (g *DigestGenerator) Members() ([]DigestMember, error) {
+	teamList, err := g.members.ListTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	byEmail := make(map[string]*DigestMember)
+	var order []string
+	for _, team := range teamList {
+		members, err := g.members.ListMembers(team.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			key := strings.ToLower(m.Email)
+			dm, ok := byEmail[key]
+			if !ok {
+				dm = &DigestMember{Email: m.Email}
+				byEmail[key] = dm
+				order = append(order, key)
+			}
+			dm.IDs = append(dm.IDs, m.ID)
+			if dm.Name == "" {
+				dm.Name = m.Name
+			}
+			if dm.GitHubUser == "" {
+				dm.GitHubUser = m.GitHubUser
+			}
+			if dm.TelegramID == 0 {
+				dm.TelegramID = m.TelegramID
+			}
+			if dm.SlackUserID == "" {
+				dm.SlackUserID = m.SlackUserID
+			}
+			if teams.Role(dm.Role).Level() < m.Role.Level() {
+				dm.Role = string(m.Role)
+			}
+		}
+	}
+
+	var result []DigestMember
+	for _, key := range order {
+		dm := byEmail[key]
+		if dm.TelegramID == 0 && dm.SlackUserID == "" {
+			continue
+		}
+		if !g.optedIn(dm) {
+			continue
+		}
+		result = append(result, *dm)
+	}
+	return result, nil
+}
+
+// optedIn checks the member against the configured digest member list
+func (g *DigestGenerator) optedIn(member *DigestMember) bool {
+	if g.config.Digest == nil || len(g.config.Digest.Members) == 0 {
+		return true
+	}
+	for _, want := range g.config.Digest.Members {
+		want = strings.TrimPrefix(want, "@")
+		if strings.EqualFold(want, member.Email) || (member.GitHubUser != "" && strings.EqualFold(want, member.GitHubUser)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate creates a digest for member covering the specified period
+func (g *DigestGenerator) Generate(member DigestMember, period BriefPeriod) (*Digest, error) {
+	executions, err := g.store.GetExecutionsRequestedBy(member.IDs, period.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := &Digest{
+		GeneratedAt: time.Now(),
+		Period:      period,
+		Member:      member,
+		Completed:   []TaskSummary{},
+		InProgress:  []TaskSummary{},
+		Failed:      []BlockedTask{},
+		Approvals:   []PendingApproval{},
+	}
+	maxItems := g.config.Content.MaxItemsPerSection
+
+	// Executions are newest first; only the latest attempt of a task counts
+	seen := make(map[string]bool)
+	for _, exec := range executions {
+		if seen[exec.TaskID] {
+			continue
+		}
+		seen[exec.TaskID] = true
+
+		title := exec.TaskTitle
+		if title == "" {
+			title = exec.TaskID
+		}
+		summary := TaskSummary{
+			ID:          exec.TaskID,
+			Title:       title,
+			ProjectPath: exec.ProjectPath,
+			Status:      exec.Status,
+			PRUrl:       exec.PRUrl,
+			DurationMs:  exec.DurationMs,
+			CompletedAt: exec.CompletedAt,
+		}
+
+		switch exec.Status {
+		case "completed":
+			if len(digest.Completed) < maxItems {
+				digest.Completed = append(digest.Completed, summary)
+			}
+		case "failed":
+			if len(digest.Failed) < maxItems {
+				failed := BlockedTask{TaskSummary: summary, Error: exec.Error}
+				if exec.CompletedAt != nil {
+					failed.FailedAt = *exec.CompletedAt
+				}
+				digest.Failed = append(digest.Failed, failed)
+			}
+		case "running", "queued", "pending":
+			if len(digest.InProgress) < maxItems {
+				digest.InProgress = append(digest.InProgress, summary)
+			}
+		}
+	}
+
+	if g.approvals != nil {
+		pending := g.approvals.GetPendingRequests()
+		sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+		for _, req := range pending {
+			if len(digest.Approvals) >= maxItems {
+				break
+			}
+			if !approvalNeeds(req, member) {
+				continue
+			}
+			prURL, _ := req.Metadata["pr_url"].(string)
+			digest.Approvals = append(digest.Approvals, PendingApproval{
+				ID:        req.ID,
+				TaskID:    req.TaskID,
+				Stage:     string(req.Stage),
+				Title:     req.Title,
+				PRUrl:     prURL,
+				CreatedAt: req.CreatedAt,
+				ExpiresAt: req.ExpiresAt,
+			})
+		}
+	}
+
+	return digest, nil
+}
+
+// GenerateDaily creates a digest for every opted-in member covering the
+// previous 24 hours
+func (g *DigestGenerator) GenerateDaily() ([]*Digest, error) {
+	members, err := g.Members()
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	period := BriefPeriod{Start: end.Add(-24 * time.Hour), End: end}
+
+	digests := make([]*Digest, 0, len(members))
+	for _, member := range members {
+		digest, err := g.Generate(member, period)
+		if err != nil {
+			return nil, fmt.Errorf("digest for %s: %w", member.Email, err)
+		}
+		digests = append(digests, digest)
+	}
+	return digests, nil
+}
+
+// approvalNeeds reports whether req waits on member. Requests without
+// approvers can be decided by any owner or admin.
+func approvalNeeds(req *approval.Request, member DigestMember) bool {
+	if len(req.Approvers) == 0 {
+		return teams.Role(member.Role).Level() >= teams.RoleAdmin.Level()
+	}
+
+	identities := append([]string{member.Email, member.GitHubUser, member.SlackUserID}, member.IDs...)
+	if member.TelegramID != 0 {
+		identities = append(identities, strconv.FormatInt(member.TelegramID, 10))
+	}
+	for _, approver := range req.Approvers {
+		approver = strings.TrimPrefix(approver, "@")
+		for _, id := range identities {
+			if id != "" && strings.EqualFold(approver, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FormatDigest formats a digest as Markdown for Telegram and Slack
+func FormatDigest(digest *Digest) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🌅 *Good morning, %s*\n", digest.Member.DisplayName()))
+	sb.WriteString(fmt.Sprintf("Your Pilot digest for %s\n\n", digest.GeneratedAt.Format("Jan 2, 2006")))
+
+	if digest.IsEmpty() {
+		sb.WriteString("Nothing needs your attention today. ✨\n")
+		return sb.String()
+	}
+
+	if len(digest.Approvals) > 0 {
+		sb.WriteString(fmt.Sprintf("⏳ *Waiting on you (%d)*\n", len(digest.Approvals)))
+		for _, a := range digest.Approvals {
+			line := fmt.Sprintf("• %s", a.Title)
+			if a.PRUrl != "" {
+				line += fmt.Sprintf(" — %s", a.PRUrl)
+			}
+			if !a.ExpiresAt.IsZero() {
+				line += fmt.Sprintf(" (expires %s)", a.ExpiresAt.Format("Jan 2 15:04"))
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(digest.Failed) > 0 {
+		sb.WriteString(fmt.Sprintf("❌ *Failed (%d)*\n", len(digest.Failed)))
+		for _, task := range digest.Failed {
+			sb.WriteString(fmt.Sprintf("• %s: %s\n", task.ID, task.Title))
+			if task.Error != "" {
+				errLine := strings.Split(task.Error, "\n")[0]
+				if len(errLine) > 80 {
+					errLine = errLine[:80] + "..."
+				}
+				sb.WriteString(fmt.Sprintf("  %s\n", errLine))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(digest.InProgress) > 0 {
+		sb.WriteString(fmt.Sprintf("🔄 *In progress (%d)*\n", len(digest.InProgress)))
+		for _, task := range digest.InProgress {
+			sb.WriteString(fmt.Sprintf("• %s: %s (%s)\n", task.ID, task.Title, task.Status))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(digest.Completed) > 0 {
+		sb.WriteString(fmt.Sprintf("✅ *Completed (%d)*\n", len(digest.Completed)))
+		for _, task := range digest.Completed {
+			line := fmt.Sprintf("• %s: %s", task.ID, task.Title)
+			if task.PRUrl != "" {
+				line += fmt.Sprintf(" — %s", task.PRUrl)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
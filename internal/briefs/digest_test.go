@@ -0,0 +1,237 @@
+package briefs
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+type mockApprovalLister struct {
+	requests []*approval.Request
+}
+
+func (m *mockApprovalLister) GetPendingRequests() []*approval.Request {
+	return m.requests
+}
+
+type mockTelegramSender struct {
+	chatIDs []string
+	texts   []string
+}
+
+func (m *mockTelegramSender) SendBriefMessage(ctx context.Context, chatID, text, parseMode string) (*TelegramMessageResponse, error) {
+	m.chatIDs = append(m.chatIDs, chatID)
+	m.texts = append(m.texts, text)
+	return &TelegramMessageResponse{MessageID: 1}, nil
+}
+
+// setupDigestTeams creates two teams sharing one member and returns the team service
+func setupDigestTeams(t *testing.T, store *memory.Store) (*teams.Service, *teams.Member, *teams.Member) {
+	t.Helper()
+
+	teamStore, err := teams.NewStore(store.DB())
+	if err != nil {
+		t.Fatalf("failed to create team store: %v", err)
+	}
+	service := teams.NewService(teamStore)
+
+	backend, owner, _ := service.CreateTeam("backend", "lead@example.com")
+	frontend, owner2, _ := service.CreateTeam("frontend", "lead@example.com")
+	devBackend, _ := service.AddMember(backend.ID, owner.ID, "dev@example.com", teams.RoleDeveloper, nil)
+	devFrontend, _ := service.AddMember(frontend.ID, owner2.ID, "dev@example.com", teams.RoleDeveloper, nil)
+	_, _ = service.AddMember(backend.ID, owner.ID, "unmapped@example.com", teams.RoleDeveloper, nil)
+
+	if err := service.UpdateMemberIdentity(backend.ID, owner.ID, owner.ID, "lead", 0, "ULEAD"); err != nil {
+		t.Fatalf("failed to link lead: %v", err)
+	}
+	if err := service.UpdateMemberIdentity(backend.ID, devBackend.ID, devBackend.ID, "dev", 1001, ""); err != nil {
+		t.Fatalf("failed to link dev: %v", err)
+	}
+	return service, devBackend, devFrontend
+}
+
+func TestDigestGeneratorMembers(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	service, devBackend, devFrontend := setupDigestTeams(t, store)
+
+	generator := NewDigestGenerator(store, service, nil, DefaultBriefConfig())
+	members, err := generator.Members()
+	if err != nil {
+		t.Fatalf("Members() error = %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("expected 2 mapped members, got %d: %+v", len(members), members)
+	}
+
+	var dev DigestMember
+	for _, m := range members {
+		if m.Email == "dev@example.com" {
+			dev = m
+		}
+		if m.Email == "lead@example.com" && m.Role != string(teams.RoleOwner) {
+			t.Errorf("lead role = %q, want owner", m.Role)
+		}
+	}
+	if len(dev.IDs) != 2 || dev.IDs[0] != devBackend.ID || dev.IDs[1] != devFrontend.ID {
+		t.Errorf("dev IDs = %v, want memberships in both teams", dev.IDs)
+	}
+	if dev.TelegramID != 1001 || dev.GitHubUser != "dev" {
+		t.Errorf("dev identity = %+v", dev)
+	}
+
+	// Opt-in list restricts recipients by email or GitHub username
+	config := DefaultBriefConfig()
+	config.Digest = &DigestConfig{Enabled: true, Members: []string{"@dev"}}
+	members, _ = NewDigestGenerator(store, service, nil, config).Members()
+	if len(members) != 1 || members[0].Email != "dev@example.com" {
+		t.Errorf("opted-in members = %+v, want only dev", members)
+	}
+}
+
+func TestDigestGeneratorGenerate(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	service, devBackend, devFrontend := setupDigestTeams(t, store)
+
+	executions := []*memory.Execution{
+		{ID: "e1", TaskID: "GH-1", TaskTitle: "Add login", Status: "completed", PRUrl: "https://github.com/o/r/pull/1", RequestedBy: devBackend.ID},
+		{ID: "e2", TaskID: "GH-2", TaskTitle: "Fix build", Status: "failed", Error: "tests failed", RequestedBy: devFrontend.ID},
+		{ID: "e3", TaskID: "GH-3", TaskTitle: "Refactor", Status: "queued", RequestedBy: devBackend.ID},
+		{ID: "e4", TaskID: "GH-4", TaskTitle: "Someone else", Status: "completed", RequestedBy: "other"},
+	}
+	for _, exec := range executions {
+		if err := store.SaveExecution(exec); err != nil {
+			t.Fatalf("failed to save execution: %v", err)
+		}
+	}
+
+	approvals := &mockApprovalLister{requests: []*approval.Request{
+		{ID: "a1", TaskID: "GH-9", Stage: approval.StagePreMerge, Title: "Merge PR #9", Approvers: []string{"@dev"},
+			Metadata: map[string]interface{}{"pr_url": "https://github.com/o/r/pull/9"}},
+		{ID: "a2", TaskID: "GH-10", Stage: approval.StagePreMerge, Title: "Merge PR #10"},
+		{ID: "a3", TaskID: "GH-11", Stage: approval.StagePreMerge, Title: "Merge PR #11", Approvers: []string{"someone"}},
+	}}
+
+	generator := NewDigestGenerator(store, service, approvals, DefaultBriefConfig())
+	members, err := generator.Members()
+	if err != nil {
+		t.Fatalf("Members() error = %v", err)
+	}
+
+	now := time.Now()
+	period := BriefPeriod{Start: now.Add(-24 * time.Hour), End: now}
+	for _, member := range members {
+		digest, err := generator.Generate(member, period)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		switch member.Email {
+		case "dev@example.com":
+			if len(digest.Completed) != 1 || digest.Completed[0].ID != "GH-1" {
+				t.Errorf("dev completed = %+v", digest.Completed)
+			}
+			if len(digest.Failed) != 1 || digest.Failed[0].Error != "tests failed" {
+				t.Errorf("dev failed = %+v", digest.Failed)
+			}
+			if len(digest.InProgress) != 1 || digest.InProgress[0].ID != "GH-3" {
+				t.Errorf("dev in progress = %+v", digest.InProgress)
+			}
+			if len(digest.Approvals) != 1 || digest.Approvals[0].PRUrl != "https://github.com/o/r/pull/9" {
+				t.Errorf("dev approvals = %+v", digest.Approvals)
+			}
+		case "lead@example.com":
+			// Owners get approvals without explicit approvers, and nothing they didn't request
+			if len(digest.Completed)+len(digest.Failed)+len(digest.InProgress) != 0 {
+				t.Errorf("lead tasks = %+v", digest)
+			}
+			if len(digest.Approvals) != 1 || digest.Approvals[0].ID != "a2" {
+				t.Errorf("lead approvals = %+v", digest.Approvals)
+			}
+		}
+	}
+}
+
+func TestFormatDigest(t *testing.T) {
+	digest := &Digest{
+		GeneratedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		Member:      DigestMember{Email: "dev@example.com"},
+	}
+	text := FormatDigest(digest)
+	if !strings.Contains(text, "dev@example.com") || !strings.Contains(text, "Nothing needs your attention") {
+		t.Errorf("empty digest text = %q", text)
+	}
+
+	digest.Member.Name = "Dev"
+	digest.Approvals = []PendingApproval{{Title: "Merge PR #9", PRUrl: "https://github.com/o/r/pull/9"}}
+	digest.Failed = []BlockedTask{{TaskSummary: TaskSummary{ID: "GH-2", Title: "Fix build"}, Error: "tests failed\nstack"}}
+	text = FormatDigest(digest)
+	for _, want := range []string{"Good morning, Dev", "Waiting on you (1)", "Merge PR #9 — https://github.com/o/r/pull/9", "GH-2: Fix build", "tests failed"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("digest text missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "stack") {
+		t.Errorf("digest text should only include the first error line:\n%s", text)
+	}
+}
+
+func TestDeliverDigest(t *testing.T) {
+	sender := &mockTelegramSender{}
+	delivery := NewDeliveryService(DefaultBriefConfig(), WithTelegramSender(sender))
+
+	digest := &Digest{GeneratedAt: time.Now(), Member: DigestMember{Email: "dev@example.com", TelegramID: 1001, SlackUserID: "U1"}}
+	results := delivery.DeliverDigest(context.Background(), digest)
+
+	// Slack is skipped without a client
+	if len(results) != 1 || !results[0].Success || results[0].Channel != "telegram:1001" {
+		t.Fatalf("results = %+v", results)
+	}
+	if len(sender.chatIDs) != 1 || sender.chatIDs[0] != "1001" {
+		t.Errorf("telegram chat IDs = %v, want [1001]", sender.chatIDs)
+	}
+}
+
+func TestSchedulerDigestOnly(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	service, _, _ := setupDigestTeams(t, store)
+	sender := &mockTelegramSender{}
+
+	config := &BriefConfig{
+		Enabled:  false,
+		Schedule: "0 9 * * 1-5",
+		Timezone: "UTC",
+		Digest:   &DigestConfig{Enabled: true, Schedule: "30 8 * * 1-5", SkipEmpty: true},
+	}
+	delivery := NewDeliveryService(config, WithTelegramSender(sender))
+	scheduler := NewScheduler(NewGenerator(store, config), delivery, config, nil, store)
+	scheduler.SetDigest(NewDigestGenerator(store, service, nil, config))
+
+	// The digest runs on its own even with the global brief disabled
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer scheduler.Stop()
+	if !scheduler.IsRunning() {
+		t.Fatal("scheduler should run for personal digests")
+	}
+
+	// Nothing happened, so every digest is empty and skipped
+	results, err := scheduler.RunDigestNow(context.Background())
+	if err != nil {
+		t.Fatalf("RunDigestNow() error = %v", err)
+	}
+	if len(results) != 0 || len(sender.texts) != 0 {
+		t.Errorf("expected empty digests to be skipped, got %+v", results)
+	}
+}
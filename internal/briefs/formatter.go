@@ -72,6 +72,16 @@ func (f *PlainTextFormatter) Format(brief *Brief) (string, error) {
 		sb.WriteString("\n")
 	}
 
+	// Flaky gates
+	if len(brief.FlakyGates) > 0 {
+		sb.WriteString(fmt.Sprintf("FLAKY GATES (%d)\n", len(brief.FlakyGates)))
+		sb.WriteString(strings.Repeat("-", 30) + "\n")
+		for _, gate := range brief.FlakyGates {
+			sb.WriteString(fmt.Sprintf("  • %s — %s\n", gate.Label(), gate.Summary()))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Upcoming
 	sb.WriteString(fmt.Sprintf("UPCOMING (%d)\n", len(brief.Upcoming)))
 	sb.WriteString(strings.Repeat("-", 30) + "\n")
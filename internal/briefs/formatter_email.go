@@ -103,6 +103,18 @@ h2 { color: #4a4a68; margin-top: 24px; }
 		sb.WriteString("</div>\n")
 	}
 
+	// Flaky gates
+	if len(brief.FlakyGates) > 0 {
+		sb.WriteString("<div class=\"section\">\n")
+		sb.WriteString(fmt.Sprintf("<h2>🎲 Flaky Gates (%d)</h2>\n", len(brief.FlakyGates)))
+		for _, gate := range brief.FlakyGates {
+			sb.WriteString("<div class=\"task\">\n")
+			sb.WriteString(fmt.Sprintf("<span class=\"task-id\">%s</span> — %s", html.EscapeString(gate.Label()), html.EscapeString(gate.Summary())))
+			sb.WriteString("</div>\n")
+		}
+		sb.WriteString("</div>\n")
+	}
+
 	// Upcoming
 	sb.WriteString("<div class=\"section\">\n")
 	sb.WriteString(fmt.Sprintf("<h2>📋 Upcoming (%d)</h2>\n", len(brief.Upcoming)))
@@ -63,6 +63,15 @@ func (f *SlackFormatter) Format(brief *Brief) (string, error) {
 		sb.WriteString("\n")
 	}
 
+	// Flaky gates
+	if len(brief.FlakyGates) > 0 {
+		sb.WriteString(fmt.Sprintf("*:game_die: Flaky Gates (%d)*\n", len(brief.FlakyGates)))
+		for _, gate := range brief.FlakyGates {
+			sb.WriteString(fmt.Sprintf("• `%s` — %s\n", gate.Label(), gate.Summary()))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Upcoming
 	sb.WriteString(fmt.Sprintf("*:clipboard: Upcoming (%d)*\n", len(brief.Upcoming)))
 	if len(brief.Upcoming) == 0 {
@@ -161,6 +170,21 @@ func (f *SlackFormatter) SlackBlocks(brief *Brief) []map[string]interface{} {
 		})
 	}
 
+	// Flaky gates section (if any)
+	if len(brief.FlakyGates) > 0 {
+		flakyText := fmt.Sprintf(":game_die: *Flaky Gates (%d)*\n", len(brief.FlakyGates))
+		for _, gate := range brief.FlakyGates {
+			flakyText += fmt.Sprintf("• `%s` — %s\n", gate.Label(), gate.Summary())
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": flakyText,
+			},
+		})
+	}
+
 	// Upcoming section
 	upcomingText := fmt.Sprintf(":clipboard: *Upcoming (%d)*\n", len(brief.Upcoming))
 	if len(brief.Upcoming) == 0 {
@@ -64,6 +64,9 @@ func createTestBrief() *Brief {
 				Status:      "queued",
 			},
 		},
+		FlakyGates: []FlakyGate{
+			{ProjectPath: "/test/project", Gate: "test", Occurrences: 3, Fingerprints: 2},
+		},
 		Metrics: BriefMetrics{
 			TotalTasks:     20,
 			CompletedCount: 17,
@@ -97,6 +100,8 @@ func TestPlainTextFormatter(t *testing.T) {
 		"FAILED (1)",
 		"TASK-004",
 		"auth_test.go:42",
+		"FLAKY GATES (1)",
+		"test (project) — flaky in 3 runs, 2 distinct failures",
 		"UPCOMING (1)",
 		"TASK-005",
 		"METRICS",
@@ -130,6 +135,8 @@ func TestSlackFormatter(t *testing.T) {
 		"▓", // Progress bar filled
 		"░", // Progress bar empty
 		":no_entry: Blocked (1)",
+		":game_die: Flaky Gates (1)",
+		"`test (project)` — flaky in 3 runs",
 		":clipboard: Upcoming (1)",
 		":chart_with_upwards_trend: Metrics",
 		"*85%*",
@@ -165,9 +172,9 @@ func TestSlackFormatterBlocks(t *testing.T) {
 		}
 	}
 
-	// At minimum: completed, in progress, blocked, upcoming = 4 sections
-	if sectionCount < 4 {
-		t.Errorf("expected at least 4 section blocks, got %d", sectionCount)
+	// Completed, in progress, blocked, flaky gates, upcoming = 5 sections
+	if sectionCount != 5 {
+		t.Errorf("expected 5 section blocks, got %d", sectionCount)
 	}
 
 	// Should have a divider
@@ -218,6 +225,8 @@ func TestEmailFormatter(t *testing.T) {
 		"65%",
 		"TASK-004",
 		"auth_test.go:42",
+		"Flaky Gates (1)",
+		"test (project)",
 		"85%",
 		"12m",
 	}
@@ -614,3 +623,24 @@ func TestSlackFormatterBlocksWithEmptySections(t *testing.T) {
 		}
 	}
 }
+
+func TestFlakyGateSummary(t *testing.T) {
+	tests := []struct {
+		gate FlakyGate
+		want string
+	}{
+		{FlakyGate{Gate: "test", Occurrences: 1, Fingerprints: 1}, "flaky in 1 run"},
+		{FlakyGate{Gate: "test", Occurrences: 4, Fingerprints: 1}, "flaky in 4 runs"},
+		{FlakyGate{Gate: "test", Occurrences: 4, Fingerprints: 3}, "flaky in 4 runs, 3 distinct failures"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.gate.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+
+	if got := (FlakyGate{Gate: "lint"}).Label(); got != "lint" {
+		t.Errorf("Label() without project = %q, want %q", got, "lint")
+	}
+}
@@ -73,6 +73,12 @@ func (g *Generator) Generate(period BriefPeriod) (*Brief, error) {
 		return nil, err
 	}
 
+	// Get quality gates that were flaky
+	flakyGates, err := g.store.GetFlakyGates(query)
+	if err != nil {
+		return nil, err
+	}
+
 	brief := &Brief{
 		GeneratedAt: time.Now(),
 		Period:      period,
@@ -80,9 +86,22 @@ func (g *Generator) Generate(period BriefPeriod) (*Brief, error) {
 		InProgress:  []TaskSummary{},
 		Blocked:     []BlockedTask{},
 		Upcoming:    []TaskSummary{},
+		FlakyGates:  []FlakyGate{},
 		Metrics:     convertMetrics(metricsData),
 	}
 
+	for _, gate := range flakyGates {
+		if len(brief.FlakyGates) >= g.config.Content.MaxItemsPerSection {
+			break
+		}
+		brief.FlakyGates = append(brief.FlakyGates, FlakyGate{
+			ProjectPath:  gate.ProjectPath,
+			Gate:         gate.Gate,
+			Occurrences:  gate.Occurrences,
+			Fingerprints: gate.Fingerprints,
+		})
+	}
+
 	// First pass: collect completed task IDs to filter out retried failures
 	completedTaskIDs := make(map[string]bool)
 	for _, exec := range executions {
@@ -113,6 +113,38 @@ func TestGeneratorGenerate(t *testing.T) {
 	}
 }
 
+func TestGeneratorGenerateWithFlakyGates(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	runs := []*memory.FlakyGateRun{
+		{ExecutionID: "exec-1", ProjectPath: "/test/project", Gate: "test", Fingerprint: "aaaa"},
+		{ExecutionID: "exec-2", ProjectPath: "/test/project", Gate: "test", Fingerprint: "bbbb"},
+		{ExecutionID: "exec-2", ProjectPath: "/test/project", Gate: "lint", Fingerprint: "cccc"},
+	}
+	if err := store.SaveFlakyGates(runs); err != nil {
+		t.Fatalf("failed to save flaky gates: %v", err)
+	}
+
+	config := DefaultBriefConfig()
+	config.Content.MaxItemsPerSection = 1
+	generator := NewGenerator(store, config)
+
+	now := time.Now()
+	brief, err := generator.Generate(BriefPeriod{Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("failed to generate brief: %v", err)
+	}
+
+	if len(brief.FlakyGates) != 1 {
+		t.Fatalf("expected 1 flaky gate (max items), got %d", len(brief.FlakyGates))
+	}
+	gate := brief.FlakyGates[0]
+	if gate.Gate != "test" || gate.Occurrences != 2 || gate.Fingerprints != 2 {
+		t.Errorf("expected test gate flaky in 2 runs with 2 fingerprints, got %+v", gate)
+	}
+}
+
 func TestGeneratorGenerateDaily(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
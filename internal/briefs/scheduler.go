@@ -2,6 +2,7 @@ package briefs
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
@@ -21,6 +22,9 @@ type Scheduler struct {
 	entryID   cron.EntryID
 	logger    *slog.Logger
 	store     *memory.Store // nullable for graceful degradation
+
+	digest        *DigestGenerator // nil when personal digests are not set up
+	digestEntryID cron.EntryID
 }
 
 // NewScheduler creates a new brief scheduler.
@@ -46,6 +50,19 @@ func NewScheduler(generator *Generator, delivery *DeliveryService, config *Brief
 	}
 }
 
+// SetDigest enables personal digests, delivered on the digest schedule
+// through the scheduler's delivery service. Must be called before Start.
+func (s *Scheduler) SetDigest(generator *DigestGenerator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digest = generator
+}
+
+// digestEnabled reports whether personal digests are configured and set up
+func (s *Scheduler) digestEnabled() bool {
+	return s.digest != nil && s.config.Digest != nil && s.config.Digest.Enabled
+}
+
 // Start begins the scheduler
 func (s *Scheduler) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -55,23 +72,44 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		return nil
 	}
 
-	if !s.config.Enabled {
+	if !s.config.Enabled && !s.digestEnabled() {
 		s.logger.Info("brief scheduler disabled")
 		return nil
 	}
 
-	// Add the scheduled job
-	entryID, err := s.cron.AddFunc(s.config.Schedule, func() {
-		s.runBrief(ctx)
-	})
-	if err != nil {
-		return err
+	if s.config.Enabled {
+		// Add the scheduled job
+		entryID, err := s.cron.AddFunc(s.config.Schedule, func() {
+			s.runBrief(ctx)
+		})
+		if err != nil {
+			return err
+		}
+		s.entryID = entryID
+	}
+
+	if s.digestEnabled() {
+		schedule := s.config.Digest.Schedule
+		if schedule == "" {
+			schedule = s.config.Schedule
+		}
+		entryID, err := s.cron.AddFunc(schedule, func() {
+			s.runDigest(ctx)
+		})
+		if err != nil {
+			return err
+		}
+		s.digestEntryID = entryID
+		s.logger.Info("personal digests scheduled", "schedule", schedule)
 	}
 
-	s.entryID = entryID
 	s.cron.Start()
 	s.running = true
 
+	if !s.config.Enabled {
+		return nil
+	}
+
 	// Get next run without lock (we already hold it)
 	nextRun := s.cron.Entry(s.entryID).Next
 
@@ -200,6 +238,70 @@ func (s *Scheduler) runBriefWithResults(ctx context.Context) ([]DeliveryResult,
 	return results, nil
 }
 
+// RunDigestNow generates and delivers personal digests immediately
+func (s *Scheduler) RunDigestNow(ctx context.Context) ([]DeliveryResult, error) {
+	return s.runDigestWithResults(ctx)
+}
+
+// runDigest generates and delivers personal digests (called by cron)
+func (s *Scheduler) runDigest(ctx context.Context) {
+	results, err := s.runDigestWithResults(ctx)
+	if err != nil {
+		s.logger.Error("failed to generate digests", "error", err)
+		return
+	}
+
+	for _, result := range results {
+		if result.Success {
+			s.logger.Info("digest delivered", "channel", result.Channel)
+		} else {
+			s.logger.Error("digest delivery failed",
+				"channel", result.Channel,
+				"error", result.Error,
+			)
+		}
+	}
+}
+
+// runDigestWithResults generates and delivers a digest to every opted-in
+// member, returning results
+func (s *Scheduler) runDigestWithResults(ctx context.Context) ([]DeliveryResult, error) {
+	s.mu.Lock()
+	generator := s.digest
+	s.mu.Unlock()
+	if generator == nil {
+		return nil, fmt.Errorf("personal digests not configured")
+	}
+
+	digests, err := generator.GenerateDaily()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []DeliveryResult
+	for _, digest := range digests {
+		if digest.IsEmpty() && s.config.Digest != nil && s.config.Digest.SkipEmpty {
+			s.logger.Debug("skipping empty digest", "member", digest.Member.Email)
+			continue
+		}
+		for _, result := range s.delivery.DeliverDigest(ctx, digest) {
+			if result.Success && s.store != nil {
+				record := &memory.BriefRecord{
+					SentAt:    time.Now(),
+					Channel:   result.Channel,
+					BriefType: "digest",
+				}
+				if err := s.store.RecordBriefSent(record); err != nil {
+					s.logger.Warn("failed to record digest sent", "channel", result.Channel, "error", err)
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
 // maybeCatchUp checks if a scheduled brief was missed and fires one if needed.
 // A brief is considered missed if the last sent time is before the previous scheduled run time.
 func (s *Scheduler) maybeCatchUp(ctx context.Context) {
@@ -1,6 +1,10 @@
 package briefs
 
-import "time"
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
 
 // Brief represents a daily summary brief
 type Brief struct {
@@ -10,6 +14,7 @@ type Brief struct {
 	InProgress  []TaskSummary
 	Blocked     []BlockedTask
 	Upcoming    []TaskSummary
+	FlakyGates  []FlakyGate
 	Metrics     BriefMetrics
 }
 
@@ -39,6 +44,36 @@ type BlockedTask struct {
 	RetryCount int
 }
 
+// FlakyGate is a quality gate that failed nondeterministically in the period:
+// its failures differed between retries, or it passed on retry
+type FlakyGate struct {
+	ProjectPath  string
+	Gate         string
+	Occurrences  int // Executions in which the gate was flaky
+	Fingerprints int // Distinct failure fingerprints seen
+}
+
+// Label returns the gate name with the project it runs in, e.g. "test (api)"
+func (g FlakyGate) Label() string {
+	if g.ProjectPath == "" {
+		return g.Gate
+	}
+	return fmt.Sprintf("%s (%s)", g.Gate, filepath.Base(g.ProjectPath))
+}
+
+// Summary describes how flaky the gate was, e.g. "flaky in 3 runs, 2 distinct failures"
+func (g FlakyGate) Summary() string {
+	runs := "runs"
+	if g.Occurrences == 1 {
+		runs = "run"
+	}
+	s := fmt.Sprintf("flaky in %d %s", g.Occurrences, runs)
+	if g.Fingerprints > 1 {
+		s += fmt.Sprintf(", %d distinct failures", g.Fingerprints)
+	}
+	return s
+}
+
 // BriefMetrics contains aggregate metrics for the period
 type BriefMetrics struct {
 	TotalTasks       int
@@ -60,6 +95,7 @@ type BriefConfig struct {
 	Channels []ChannelConfig `yaml:"channels"`
 	Content  ContentConfig   `yaml:"content"`
 	Filters  FilterConfig    `yaml:"filters"`
+	Digest   *DigestConfig   `yaml:"digest"`
 }
 
 // ChannelConfig defines a delivery channel
@@ -81,6 +117,61 @@ type FilterConfig struct {
 	Projects []string `yaml:"projects"` // Empty = all projects
 }
 
+// DigestConfig configures personal digests sent to mapped team members.
+// Digests are opt-in and independent of the global brief.
+type DigestConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	Schedule  string   `yaml:"schedule"`   // Cron syntax, defaults to the brief schedule
+	Members   []string `yaml:"members"`    // Emails or GitHub usernames; empty = all mapped members
+	SkipEmpty bool     `yaml:"skip_empty"` // Don't send digests with nothing to report
+}
+
+// Digest is a personal summary of Pilot work relevant to one team member
+type Digest struct {
+	GeneratedAt time.Time
+	Period      BriefPeriod
+	Member      DigestMember
+	Completed   []TaskSummary     // Tasks the member requested that completed
+	InProgress  []TaskSummary     // Tasks the member requested that are running or queued
+	Failed      []BlockedTask     // Tasks the member requested that failed
+	Approvals   []PendingApproval // Approvals waiting on the member
+}
+
+// DigestMember identifies a digest recipient across teams
+type DigestMember struct {
+	IDs         []string // Member IDs in every team the person belongs to
+	Name        string
+	Email       string
+	GitHubUser  string
+	TelegramID  int64
+	SlackUserID string
+	Role        string // Highest role across the member's teams
+}
+
+// DisplayName returns the member's name, falling back to email
+func (m DigestMember) DisplayName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return m.Email
+}
+
+// PendingApproval is an approval request waiting for a decision
+type PendingApproval struct {
+	ID        string
+	TaskID    string
+	Stage     string
+	Title     string
+	PRUrl     string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// IsEmpty reports whether the digest has nothing to report
+func (d *Digest) IsEmpty() bool {
+	return len(d.Completed) == 0 && len(d.InProgress) == 0 && len(d.Failed) == 0 && len(d.Approvals) == 0
+}
+
 // DeliveryResult represents the result of sending a brief
 type DeliveryResult struct {
 	Channel   string
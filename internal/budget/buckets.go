@@ -0,0 +1,224 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bucket is a spend cap over the tasks of one adapter, project or team
+type bucket struct {
+	kind    string
+	name    string
+	daily   float64 // USD, 0 = uncapped
+	monthly float64 // USD, 0 = uncapped
+	spend   func(start, end time.Time) (float64, error)
+}
+
+// label names the bucket in block reasons: adapters by name as before,
+// projects and teams with their kind
+func (b bucket) label() string {
+	if b.kind == BucketAdapter {
+		return b.name
+	}
+	return b.kind + " " + b.name
+}
+
+// buckets returns the capped buckets a task counts against: its adapter,
+// its project, and the teams it is run for or whose projects include it
+func (e *Enforcer) buckets(teamID, adapter, projectPath string) []bucket {
+	var buckets []bucket
+	if limit, ok := e.config.Adapters[adapter]; ok && adapter != "" {
+		buckets = append(buckets, e.adapterBucket(adapter, limit))
+	}
+
+	project := normalizeProjectPath(projectPath)
+	if project != "" {
+		for _, p := range e.config.Projects {
+			if normalizeProjectPath(p.Path) == project {
+				buckets = append(buckets, e.projectBucket(p))
+			}
+		}
+	}
+	for _, t := range e.config.Teams {
+		if (teamID != "" && t.Name == teamID) || (project != "" && slices.Contains(normalizeProjectPaths(t.Projects), project)) {
+			buckets = append(buckets, e.teamBucket(t))
+		}
+	}
+
+	capped := buckets[:0]
+	for _, b := range buckets {
+		if b.daily > 0 || b.monthly > 0 {
+			capped = append(capped, b)
+		}
+	}
+	return capped
+}
+
+func (e *Enforcer) adapterBucket(adapter string, limit AdapterLimit) bucket {
+	return bucket{
+		kind:    BucketAdapter,
+		name:    adapter,
+		daily:   limit.DailyLimit,
+		monthly: limit.MonthlyLimit,
+		spend: func(start, end time.Time) (float64, error) {
+			return e.provider.GetAdapterSpend(adapter, start, end)
+		},
+	}
+}
+
+func (e *Enforcer) projectBucket(p ProjectBudget) bucket {
+	projects := normalizeProjectPaths([]string{p.Path})
+	return bucket{
+		kind:    BucketProject,
+		name:    p.Path,
+		daily:   p.DailyLimit,
+		monthly: p.MonthlyLimit,
+		spend: func(start, end time.Time) (float64, error) {
+			return e.provider.GetProjectSpend(projects, start, end)
+		},
+	}
+}
+
+func (e *Enforcer) teamBucket(t TeamBudget) bucket {
+	projects := normalizeProjectPaths(t.Projects)
+	return bucket{
+		kind:    BucketTeam,
+		name:    t.Name,
+		daily:   t.DailyLimit,
+		monthly: t.MonthlyLimit,
+		spend: func(start, end time.Time) (float64, error) {
+			if len(projects) == 0 {
+				return 0, nil
+			}
+			return e.provider.GetProjectSpend(projects, start, end)
+		},
+	}
+}
+
+// checkBucket checks a bucket's caps after the global limits allowed the
+// task. It returns the blocking result, or result with its headroom
+// narrowed to the bucket's. Spend lookup errors fail open.
+func (e *Enforcer) checkBucket(b bucket, result *CheckResult) *CheckResult {
+	now := time.Now()
+	dayStart, monthStart := periodStarts(now)
+
+	if b.monthly > 0 {
+		spent, err := b.spend(monthStart, now)
+		if err != nil {
+			e.log.Error("Failed to get bucket spend", slog.String(b.kind, b.name), slog.String("error", err.Error()))
+			return result
+		}
+		if spent >= b.monthly {
+			action := e.config.OnExceed.Monthly
+			if action == ActionStop || action == ActionPause {
+				e.incrementBlocked()
+				return &CheckResult{
+					Allowed:     false,
+					Action:      action,
+					Reason:      fmt.Sprintf("%s monthly budget exceeded: $%.2f / $%.2f", b.label(), spent, b.monthly),
+					DailyLeft:   result.DailyLeft,
+					MonthlyLeft: 0,
+				}
+			}
+		}
+		result.MonthlyLeft = min(result.MonthlyLeft, b.monthly-spent)
+	}
+
+	if b.daily > 0 {
+		spent, err := b.spend(dayStart, now)
+		if err != nil {
+			e.log.Error("Failed to get bucket spend", slog.String(b.kind, b.name), slog.String("error", err.Error()))
+			return result
+		}
+		if spent >= b.daily {
+			action := e.config.OnExceed.Daily
+			if action == ActionStop || action == ActionPause {
+				e.incrementBlocked()
+				return &CheckResult{
+					Allowed:     false,
+					Action:      action,
+					Reason:      fmt.Sprintf("%s daily budget exceeded: $%.2f / $%.2f", b.label(), spent, b.daily),
+					DailyLeft:   0,
+					MonthlyLeft: result.MonthlyLeft,
+				}
+			}
+		}
+		result.DailyLeft = min(result.DailyLeft, b.daily-spent)
+	}
+
+	return result
+}
+
+// GetBucketStatuses returns the spend of every configured adapter, project
+// and team bucket, grouped in that order
+func (e *Enforcer) GetBucketStatuses(ctx context.Context) ([]BucketStatus, error) {
+	var buckets []bucket
+	adapters := make([]string, 0, len(e.config.Adapters))
+	for name := range e.config.Adapters {
+		adapters = append(adapters, name)
+	}
+	sort.Strings(adapters)
+	for _, name := range adapters {
+		buckets = append(buckets, e.adapterBucket(name, e.config.Adapters[name]))
+	}
+	for _, p := range e.config.Projects {
+		buckets = append(buckets, e.projectBucket(p))
+	}
+	for _, t := range e.config.Teams {
+		buckets = append(buckets, e.teamBucket(t))
+	}
+
+	now := time.Now()
+	dayStart, monthStart := periodStarts(now)
+	statuses := make([]BucketStatus, 0, len(buckets))
+	for _, b := range buckets {
+		daily, err := b.spend(dayStart, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s spend: %w", b.label(), err)
+		}
+		monthly, err := b.spend(monthStart, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s spend: %w", b.label(), err)
+		}
+		statuses = append(statuses, BucketStatus{
+			Kind:         b.kind,
+			Name:         b.name,
+			DailySpent:   daily,
+			DailyLimit:   b.daily,
+			MonthlySpent: monthly,
+			MonthlyLimit: b.monthly,
+		})
+	}
+	return statuses, nil
+}
+
+// normalizeProjectPath expands ~ and cleans a project path so configured
+// paths match the paths executions are recorded with
+func normalizeProjectPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return filepath.Clean(path)
+}
+
+func normalizeProjectPaths(paths []string) []string {
+	normalized := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if p = normalizeProjectPath(p); p != "" {
+			normalized = append(normalized, p)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,193 @@
+package budget
+
+import (
+	"context"
+	"testing"
+)
+
+func bucketConfig() *Config {
+	return &Config{
+		Enabled:      true,
+		DailyLimit:   100,
+		MonthlyLimit: 1000,
+		OnExceed:     ExceedAction{Daily: ActionPause, Monthly: ActionStop},
+		Adapters:     map[string]AdapterLimit{"telegram": {DailyLimit: 20}},
+		Projects: []ProjectBudget{
+			{Path: "/repos/api", DailyLimit: 10},
+			{Path: "/repos/web/", MonthlyLimit: 50},
+		},
+		Teams: []TeamBudget{
+			{Name: "platform", Projects: []string{"/repos/api", "/repos/infra"}, DailyLimit: 15},
+			{Name: "growth", Projects: []string{"/repos/web"}},
+		},
+	}
+}
+
+func TestEnforcer_CheckProjectBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		teamID       string
+		adapter      string
+		project      string
+		projectSpend map[string]float64
+		adapterSpend float64
+		wantAllow    bool
+		wantAction   Action
+		wantReason   string
+	}{
+		{
+			name:         "unbucketed project",
+			adapter:      "github",
+			project:      "/repos/other",
+			projectSpend: map[string]float64{"/repos/other": 90},
+			wantAllow:    true,
+		},
+		{
+			name:         "under project and team caps",
+			project:      "/repos/api",
+			projectSpend: map[string]float64{"/repos/api": 9, "/repos/infra": 5},
+			wantAllow:    true,
+		},
+		{
+			name:         "project daily cap reached",
+			project:      "/repos/api",
+			projectSpend: map[string]float64{"/repos/api": 10},
+			wantAction:   ActionPause,
+			wantReason:   "project /repos/api daily budget exceeded: $10.00 / $10.00",
+		},
+		{
+			name:         "team cap reached by another project",
+			project:      "/repos/api",
+			projectSpend: map[string]float64{"/repos/api": 5, "/repos/infra": 12},
+			wantAction:   ActionPause,
+			wantReason:   "team platform daily budget exceeded: $17.00 / $15.00",
+		},
+		{
+			name:         "team cap for a task run for the team",
+			teamID:       "platform",
+			project:      "/repos/other",
+			projectSpend: map[string]float64{"/repos/infra": 15},
+			wantAction:   ActionPause,
+			wantReason:   "team platform daily budget exceeded: $15.00 / $15.00",
+		},
+		{
+			name:         "project monthly cap with unclean path",
+			project:      "/repos/web/./",
+			projectSpend: map[string]float64{"/repos/web": 60},
+			wantAction:   ActionStop,
+			wantReason:   "project /repos/web/ monthly budget exceeded: $60.00 / $50.00",
+		},
+		{
+			name:         "adapter cap checked first",
+			adapter:      "telegram",
+			project:      "/repos/api",
+			adapterSpend: 25,
+			projectSpend: map[string]float64{"/repos/api": 10},
+			wantAction:   ActionPause,
+			wantReason:   "telegram daily budget exceeded: $25.00 / $20.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockUsageProvider{
+				adapterSpend: map[string]float64{"telegram": tt.adapterSpend},
+				projectSpend: tt.projectSpend,
+			}
+			enforcer := NewEnforcer(bucketConfig(), provider)
+
+			result, err := enforcer.CheckProjectBudget(context.Background(), tt.teamID, "", tt.adapter, tt.project)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Allowed != tt.wantAllow {
+				t.Fatalf("Allowed = %v, want %v (%s)", result.Allowed, tt.wantAllow, result.Reason)
+			}
+			if result.Action != tt.wantAction || result.Reason != tt.wantReason {
+				t.Errorf("result = %s %q, want %s %q", result.Action, result.Reason, tt.wantAction, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestEnforcer_CheckProjectBudget_Headroom(t *testing.T) {
+	provider := &mockUsageProvider{projectSpend: map[string]float64{"/repos/api": 4, "/repos/infra": 9}}
+	enforcer := NewEnforcer(bucketConfig(), provider)
+
+	result, err := enforcer.CheckProjectBudget(context.Background(), "", "", "github", "/repos/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Allowed {
+		t.Fatalf("task should be allowed: %q", result.Reason)
+	}
+	if result.DailyLeft != 2 {
+		t.Errorf("DailyLeft = %v, want team headroom 2", result.DailyLeft)
+	}
+}
+
+func TestEnforcer_CheckBudget_Team(t *testing.T) {
+	provider := &mockUsageProvider{projectSpend: map[string]float64{"/repos/infra": 20}}
+	enforcer := NewEnforcer(bucketConfig(), provider)
+
+	result, err := enforcer.CheckBudget(context.Background(), "platform", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Allowed {
+		t.Error("team over its cap should be blocked")
+	}
+
+	result, err = enforcer.CheckBudget(context.Background(), "growth", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Allowed {
+		t.Errorf("uncapped team should be allowed: %q", result.Reason)
+	}
+}
+
+func TestEnforcer_GetBucketStatuses(t *testing.T) {
+	config := bucketConfig()
+	config.Adapters["slack"] = AdapterLimit{MonthlyLimit: 30}
+	provider := &mockUsageProvider{
+		adapterSpend: map[string]float64{"telegram": 3, "slack": 1},
+		projectSpend: map[string]float64{"/repos/api": 4, "/repos/infra": 2, "/repos/web": 8},
+	}
+	enforcer := NewEnforcer(config, provider)
+
+	statuses, err := enforcer.GetBucketStatuses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []BucketStatus{
+		{Kind: BucketAdapter, Name: "slack", DailySpent: 1, MonthlySpent: 1, MonthlyLimit: 30},
+		{Kind: BucketAdapter, Name: "telegram", DailySpent: 3, DailyLimit: 20, MonthlySpent: 3},
+		{Kind: BucketProject, Name: "/repos/api", DailySpent: 4, DailyLimit: 10, MonthlySpent: 4},
+		{Kind: BucketProject, Name: "/repos/web/", DailySpent: 8, MonthlySpent: 8, MonthlyLimit: 50},
+		{Kind: BucketTeam, Name: "platform", DailySpent: 6, DailyLimit: 15, MonthlySpent: 6},
+		{Kind: BucketTeam, Name: "growth", DailySpent: 8, MonthlySpent: 8},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("got %d statuses, want %d: %+v", len(statuses), len(want), statuses)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Errorf("statuses[%d] = %+v, want %+v", i, statuses[i], want[i])
+		}
+	}
+	if got := statuses[2].DailyPercent(); got != 40 {
+		t.Errorf("DailyPercent() = %v, want 40", got)
+	}
+	if got := statuses[5].MonthlyPercent(); got != 0 {
+		t.Errorf("uncapped MonthlyPercent() = %v, want 0", got)
+	}
+}
+
+func TestEnforcer_GetBucketStatuses_ProviderError(t *testing.T) {
+	enforcer := NewEnforcer(bucketConfig(), &errorUsageProvider{})
+	if _, err := enforcer.GetBucketStatuses(context.Background()); err == nil {
+		t.Error("expected error from provider")
+	}
+}
@@ -14,6 +14,8 @@ import (
 // UsageProvider interface for getting usage data
 type UsageProvider interface {
 	GetUsageSummary(query memory.UsageQuery) (*memory.UsageSummary, error)
+	GetAdapterSpend(adapter string, start, end time.Time) (float64, error)
+	GetProjectSpend(projects []string, start, end time.Time) (float64, error)
 }
 
 // AlertCallback is called when budget thresholds are crossed
@@ -48,8 +50,41 @@ func (e *Enforcer) OnAlert(callback AlertCallback) {
 	e.onAlert = callback
 }
 
-// CheckBudget checks if a new task can be started
+// CheckBudget checks if a new task can be started: the global limits, then
+// the caps of the team the task is run for
 func (e *Enforcer) CheckBudget(ctx context.Context, teamID, userID string) (*CheckResult, error) {
+	return e.checkBuckets(ctx, teamID, userID, e.buckets(teamID, "", ""))
+}
+
+// CheckAdapterBudget checks the global limits and then the caps of the
+// adapter the task came from, so ad-hoc channels can be capped below the
+// global budget.
+func (e *Enforcer) CheckAdapterBudget(ctx context.Context, teamID, userID, adapter string) (*CheckResult, error) {
+	return e.checkBuckets(ctx, teamID, userID, e.buckets(teamID, adapter, ""))
+}
+
+// CheckProjectBudget checks the global limits and then the caps of the
+// task's adapter, its project and the teams the project belongs to.
+func (e *Enforcer) CheckProjectBudget(ctx context.Context, teamID, userID, adapter, projectPath string) (*CheckResult, error) {
+	return e.checkBuckets(ctx, teamID, userID, e.buckets(teamID, adapter, projectPath))
+}
+
+// checkBuckets checks the global limits, then each bucket in turn
+func (e *Enforcer) checkBuckets(ctx context.Context, teamID, userID string, buckets []bucket) (*CheckResult, error) {
+	result, err := e.checkGlobal(ctx, teamID, userID)
+	if err != nil || !result.Allowed || !e.config.Enabled {
+		return result, err
+	}
+	for _, b := range buckets {
+		if result = e.checkBucket(b, result); !result.Allowed {
+			break
+		}
+	}
+	return result, nil
+}
+
+// checkGlobal checks the global daily and monthly limits
+func (e *Enforcer) checkGlobal(ctx context.Context, teamID, userID string) (*CheckResult, error) {
 	if !e.config.Enabled {
 		return &CheckResult{Allowed: true}, nil
 	}
@@ -119,8 +154,7 @@ func (e *Enforcer) CheckBudget(ctx context.Context, teamID, userID string) (*Che
 // GetStatus returns the current budget status
 func (e *Enforcer) GetStatus(ctx context.Context, teamID, userID string) (*Status, error) {
 	now := time.Now()
-	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	dayStart, monthStart := periodStarts(now)
 
 	// Get daily usage
 	dailyQuery := memory.UsageQuery{
@@ -170,6 +204,22 @@ func (e *Enforcer) GetStatus(ctx context.Context, teamID, userID string) (*Statu
 	return status, nil
 }
 
+// DailySpendPercent returns today's spend in percent of the daily limit, or
+// 0 when enforcement is disabled or there is no daily limit. It lets the
+// model router degrade routing as spend approaches the limit.
+func (e *Enforcer) DailySpendPercent(ctx context.Context) (float64, error) {
+	if !e.config.Enabled || e.config.DailyLimit <= 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	dayStart, _ := periodStarts(now)
+	summary, err := e.provider.GetUsageSummary(memory.UsageQuery{Start: dayStart, End: now})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get daily usage: %w", err)
+	}
+	return summary.TotalCost / e.config.DailyLimit * 100, nil
+}
+
 // GetPerTaskLimits returns the per-task limits for executor
 func (e *Enforcer) GetPerTaskLimits() (maxTokens int64, maxDuration time.Duration) {
 	if !e.config.Enabled {
@@ -220,6 +270,13 @@ func (e *Enforcer) ResetDaily() {
 	e.log.Info("Daily budget counters reset")
 }
 
+// periodStarts returns the start of the day and month containing now
+func periodStarts(now time.Time) (dayStart, monthStart time.Time) {
+	dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	return dayStart, monthStart
+}
+
 func (e *Enforcer) incrementBlocked() {
 	e.mu.Lock()
 	e.blockedTasks++
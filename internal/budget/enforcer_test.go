@@ -11,10 +11,12 @@ import (
 
 // mockUsageProvider implements UsageProvider for testing
 type mockUsageProvider struct {
-	dailyCost   float64
-	monthlyCost float64
-	callCount   int
-	mu          sync.Mutex
+	dailyCost    float64
+	monthlyCost  float64
+	adapterSpend map[string]float64
+	projectSpend map[string]float64
+	callCount    int
+	mu           sync.Mutex
 }
 
 func (m *mockUsageProvider) GetUsageSummary(query memory.UsageQuery) (*memory.UsageSummary, error) {
@@ -36,6 +38,18 @@ func (m *mockUsageProvider) GetUsageSummary(query memory.UsageQuery) (*memory.Us
 	}, nil
 }
 
+func (m *mockUsageProvider) GetAdapterSpend(adapter string, start, end time.Time) (float64, error) {
+	return m.adapterSpend[adapter], nil
+}
+
+func (m *mockUsageProvider) GetProjectSpend(projects []string, start, end time.Time) (float64, error) {
+	var spend float64
+	for _, p := range projects {
+		spend += m.projectSpend[p]
+	}
+	return spend, nil
+}
+
 func (m *mockUsageProvider) Reset() {
 	m.mu.Lock()
 	m.callCount = 0
@@ -335,6 +349,24 @@ func TestEnforcer_GetStatus_Warning(t *testing.T) {
 	}
 }
 
+func TestEnforcer_DailySpendPercent(t *testing.T) {
+	config := &Config{Enabled: true, DailyLimit: 50.0, MonthlyLimit: 500.0}
+	enforcer := NewEnforcer(config, &mockUsageProvider{dailyCost: 35.0})
+
+	percent, err := enforcer.DailySpendPercent(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if percent != 70.0 {
+		t.Errorf("expected 70%%, got %v", percent)
+	}
+
+	disabled := NewEnforcer(&Config{Enabled: false, DailyLimit: 50.0}, &mockUsageProvider{dailyCost: 35.0})
+	if percent, _ := disabled.DailySpendPercent(context.Background()); percent != 0 {
+		t.Errorf("expected 0%% when disabled, got %v", percent)
+	}
+}
+
 func TestEnforcer_GetPerTaskLimits(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -744,6 +776,14 @@ func (e *errorUsageProvider) GetUsageSummary(query memory.UsageQuery) (*memory.U
 	return nil, context.DeadlineExceeded
 }
 
+func (e *errorUsageProvider) GetAdapterSpend(adapter string, start, end time.Time) (float64, error) {
+	return 0, context.DeadlineExceeded
+}
+
+func (e *errorUsageProvider) GetProjectSpend(projects []string, start, end time.Time) (float64, error) {
+	return 0, context.DeadlineExceeded
+}
+
 func TestEnforcer_CheckBudget_ProviderError(t *testing.T) {
 	config := &Config{
 		Enabled:      true,
@@ -778,3 +818,110 @@ func TestEnforcer_GetStatus_ProviderError(t *testing.T) {
 		t.Error("expected error from GetStatus when provider fails")
 	}
 }
+
+func TestEnforcer_CheckAdapterBudget(t *testing.T) {
+	tests := []struct {
+		name       string
+		adapter    string
+		limit      AdapterLimit
+		spend      float64
+		wantAllow  bool
+		wantAction Action
+		wantReason string
+	}{
+		{
+			name:      "uncapped adapter",
+			adapter:   "github",
+			spend:     100,
+			wantAllow: true,
+		},
+		{
+			name:      "under daily cap",
+			adapter:   "telegram",
+			limit:     AdapterLimit{DailyLimit: 5},
+			spend:     4.99,
+			wantAllow: true,
+		},
+		{
+			name:       "daily cap reached",
+			adapter:    "telegram",
+			limit:      AdapterLimit{DailyLimit: 5},
+			spend:      5,
+			wantAction: ActionPause,
+			wantReason: "telegram daily budget exceeded: $5.00 / $5.00",
+		},
+		{
+			name:       "monthly cap reached",
+			adapter:    "telegram",
+			limit:      AdapterLimit{DailyLimit: 50, MonthlyLimit: 20},
+			spend:      25,
+			wantAction: ActionStop,
+			wantReason: "telegram monthly budget exceeded: $25.00 / $20.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Enabled:      true,
+				DailyLimit:   100,
+				MonthlyLimit: 1000,
+				OnExceed:     ExceedAction{Daily: ActionPause, Monthly: ActionStop},
+				Adapters:     map[string]AdapterLimit{"telegram": tt.limit},
+			}
+			provider := &mockUsageProvider{adapterSpend: map[string]float64{tt.adapter: tt.spend}}
+			enforcer := NewEnforcer(config, provider)
+
+			result, err := enforcer.CheckAdapterBudget(context.Background(), "", "", tt.adapter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Allowed != tt.wantAllow {
+				t.Fatalf("Allowed = %v, want %v (%s)", result.Allowed, tt.wantAllow, result.Reason)
+			}
+			if result.Action != tt.wantAction || result.Reason != tt.wantReason {
+				t.Errorf("result = %s %q, want %s %q", result.Action, result.Reason, tt.wantAction, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestEnforcer_CheckAdapterBudget_WarnOnly(t *testing.T) {
+	config := &Config{
+		Enabled:      true,
+		DailyLimit:   100,
+		MonthlyLimit: 1000,
+		OnExceed:     ExceedAction{Daily: ActionWarn, Monthly: ActionWarn},
+		Adapters:     map[string]AdapterLimit{"slack": {DailyLimit: 1}},
+	}
+	enforcer := NewEnforcer(config, &mockUsageProvider{adapterSpend: map[string]float64{"slack": 3}})
+
+	result, err := enforcer.CheckAdapterBudget(context.Background(), "", "", "slack")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Allowed {
+		t.Errorf("warn action should not block: %q", result.Reason)
+	}
+	if result.DailyLeft != -2 {
+		t.Errorf("DailyLeft = %v, want adapter headroom -2", result.DailyLeft)
+	}
+}
+
+func TestEnforcer_CheckAdapterBudget_ProviderError(t *testing.T) {
+	config := &Config{
+		Enabled:      true,
+		DailyLimit:   50.0,
+		MonthlyLimit: 500.0,
+		Adapters:     map[string]AdapterLimit{"telegram": {DailyLimit: 5}},
+	}
+	enforcer := NewEnforcer(config, &errorUsageProvider{})
+
+	result, err := enforcer.CheckAdapterBudget(context.Background(), "", "", "telegram")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Allowed {
+		t.Error("expected task allowed on provider error (fail-open)")
+	}
+}
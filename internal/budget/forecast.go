@@ -0,0 +1,163 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// AlertForecastExceeded is the alert type fired when projected month-end
+// spend exceeds the monthly limit
+const AlertForecastExceeded = "monthly_forecast_exceeded"
+
+// forecastTrendDays is the trailing window of the trend projection
+const forecastTrendDays = 7
+
+// forecastTopProjects is how many cost-driving projects a forecast lists
+const forecastTopProjects = 5
+
+// ProjectUsageProvider reports usage grouped by project. Satisfied by
+// *memory.Store; forecasts list no projects without it.
+type ProjectUsageProvider interface {
+	GetUsageByProject(query memory.UsageQuery) ([]*memory.ProjectUsage, error)
+}
+
+// Forecast projects month-end spend from the usage so far
+type Forecast struct {
+	MonthStart   time.Time `json:"month_start"`
+	MonthEnd     time.Time `json:"month_end"`
+	DaysElapsed  float64   `json:"days_elapsed"`
+	DaysInMonth  float64   `json:"days_in_month"`
+	MonthToDate  float64   `json:"month_to_date"`
+	MonthlyLimit float64   `json:"monthly_limit"`
+
+	// Linear extrapolates the month's average daily spend to month end
+	Linear float64 `json:"linear"`
+	// TrendDaily is the average daily spend over the trailing 7 days
+	TrendDaily float64 `json:"trend_daily"`
+	// Trend adds TrendDaily for every remaining day to the spend so far
+	Trend float64 `json:"trend"`
+	// Projected is the higher of the two projections
+	Projected float64 `json:"projected"`
+
+	// Projects driving the month's cost, most expensive first
+	Projects []ProjectForecast `json:"projects,omitempty"`
+}
+
+// ProjectForecast is one project's share of the month's spend
+type ProjectForecast struct {
+	Project     string  `json:"project"`
+	MonthToDate float64 `json:"month_to_date"`
+	Share       float64 `json:"share"`     // Percent of month-to-date spend
+	Projected   float64 `json:"projected"` // Share of the projected spend
+}
+
+// ProjectedPercent returns projected spend in percent of the monthly limit,
+// 0 when there is no monthly limit
+func (f *Forecast) ProjectedPercent() float64 {
+	if f.MonthlyLimit <= 0 {
+		return 0
+	}
+	return f.Projected / f.MonthlyLimit * 100
+}
+
+// ExceedsLimit reports whether projected spend exceeds the monthly limit
+func (f *Forecast) ExceedsLimit() bool {
+	return f.MonthlyLimit > 0 && f.Projected > f.MonthlyLimit
+}
+
+// Forecast projects month-end spend as of now, both linearly from the
+// month's average and from the trailing 7-day trend
+func (e *Enforcer) Forecast(ctx context.Context, now time.Time) (*Forecast, error) {
+	_, monthStart := periodStarts(now)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	month, err := e.provider.GetUsageSummary(memory.UsageQuery{Start: monthStart, End: now})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly usage: %w", err)
+	}
+	trend, err := e.provider.GetUsageSummary(memory.UsageQuery{Start: now.AddDate(0, 0, -forecastTrendDays), End: now})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trailing usage: %w", err)
+	}
+
+	f := &Forecast{
+		MonthStart:   monthStart,
+		MonthEnd:     monthEnd,
+		DaysElapsed:  now.Sub(monthStart).Hours() / 24,
+		DaysInMonth:  monthEnd.Sub(monthStart).Hours() / 24,
+		MonthToDate:  month.TotalCost,
+		MonthlyLimit: e.config.MonthlyLimit,
+		TrendDaily:   trend.TotalCost / forecastTrendDays,
+	}
+	// Count the first day as a full day so the first hours' spend isn't
+	// extrapolated to the whole month
+	f.Linear = f.MonthToDate / max(f.DaysElapsed, 1) * f.DaysInMonth
+	f.Trend = f.MonthToDate + f.TrendDaily*(f.DaysInMonth-f.DaysElapsed)
+	f.Projected = max(f.Linear, f.Trend)
+
+	projects, ok := e.provider.(ProjectUsageProvider)
+	if !ok || f.MonthToDate <= 0 {
+		return f, nil
+	}
+	usage, err := projects.GetUsageByProject(memory.UsageQuery{Start: monthStart, End: now})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project usage: %w", err)
+	}
+	for _, pu := range usage {
+		if len(f.Projects) == forecastTopProjects {
+			break
+		}
+		if pu.TotalCost <= 0 {
+			continue
+		}
+		share := pu.TotalCost / f.MonthToDate
+		f.Projects = append(f.Projects, ProjectForecast{
+			Project:     pu.ProjectID,
+			MonthToDate: pu.TotalCost,
+			Share:       share * 100,
+			Projected:   share * f.Projected,
+		})
+	}
+	return f, nil
+}
+
+// CheckForecast forecasts month-end spend and fires a warning alert when
+// the projection exceeds the monthly limit
+func (e *Enforcer) CheckForecast(ctx context.Context) (*Forecast, error) {
+	if !e.config.Enabled || e.config.MonthlyLimit <= 0 {
+		return nil, nil
+	}
+	f, err := e.Forecast(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if f.ExceedsLimit() && e.onAlert != nil {
+		message := fmt.Sprintf("Projected month-end spend $%.2f exceeds monthly budget $%.2f (%.0f%%)",
+			f.Projected, f.MonthlyLimit, f.ProjectedPercent())
+		if len(f.Projects) > 0 {
+			message += fmt.Sprintf("; top project %s at $%.2f", f.Projects[0].Project, f.Projects[0].MonthToDate)
+		}
+		e.onAlert(AlertForecastExceeded, message, "warning")
+	}
+	return f, nil
+}
+
+// WatchForecast runs CheckForecast every interval until ctx is cancelled
+func (e *Enforcer) WatchForecast(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := e.CheckForecast(ctx); err != nil {
+			e.log.Warn("Budget forecast failed", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package budget
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// forecastProvider serves month-to-date and trailing usage by query start
+type forecastProvider struct {
+	mockUsageProvider
+	monthStart   time.Time
+	monthToDate  float64
+	trailing     float64
+	projectUsage []*memory.ProjectUsage
+}
+
+func (p *forecastProvider) GetUsageSummary(query memory.UsageQuery) (*memory.UsageSummary, error) {
+	if query.Start.Equal(p.monthStart) {
+		return &memory.UsageSummary{TotalCost: p.monthToDate}, nil
+	}
+	return &memory.UsageSummary{TotalCost: p.trailing}, nil
+}
+
+func (p *forecastProvider) GetUsageByProject(query memory.UsageQuery) ([]*memory.ProjectUsage, error) {
+	return p.projectUsage, nil
+}
+
+func TestEnforcer_Forecast(t *testing.T) {
+	now := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	provider := &forecastProvider{
+		monthStart:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local),
+		monthToDate: 100,
+		trailing:    140,
+		projectUsage: []*memory.ProjectUsage{
+			{ProjectID: "/repos/api", TotalCost: 75},
+			{ProjectID: "/repos/web", TotalCost: 25},
+			{ProjectID: "/repos/idle", TotalCost: 0},
+		},
+	}
+	enforcer := NewEnforcer(&Config{Enabled: true, MonthlyLimit: 500}, provider)
+
+	f, err := enforcer.Forecast(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	approx := func(got, want float64) bool { return math.Abs(got-want) < 0.01 }
+	if !approx(f.DaysElapsed, 10) || !approx(f.DaysInMonth, 31) {
+		t.Errorf("days = %v of %v, want 10 of 31", f.DaysElapsed, f.DaysInMonth)
+	}
+	if !approx(f.Linear, 310) {
+		t.Errorf("Linear = %v, want 310", f.Linear)
+	}
+	if !approx(f.TrendDaily, 20) || !approx(f.Trend, 520) {
+		t.Errorf("Trend = %v at %v/day, want 520 at 20/day", f.Trend, f.TrendDaily)
+	}
+	if !approx(f.Projected, 520) || !f.ExceedsLimit() || !approx(f.ProjectedPercent(), 104) {
+		t.Errorf("Projected = %v (%v%%), want 520 over the limit", f.Projected, f.ProjectedPercent())
+	}
+
+	if len(f.Projects) != 2 {
+		t.Fatalf("got %d projects, want 2 with spend: %+v", len(f.Projects), f.Projects)
+	}
+	if p := f.Projects[0]; p.Project != "/repos/api" || !approx(p.Share, 75) || !approx(p.Projected, 390) {
+		t.Errorf("top project = %+v", p)
+	}
+}
+
+func TestEnforcer_Forecast_FirstDay(t *testing.T) {
+	now := time.Date(2026, 4, 1, 2, 0, 0, 0, time.Local)
+	provider := &forecastProvider{
+		monthStart:  time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local),
+		monthToDate: 3,
+	}
+	enforcer := NewEnforcer(&Config{Enabled: true, MonthlyLimit: 500}, provider)
+
+	f, err := enforcer.Forecast(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Two hours of spend extrapolate as a full day, not 1/12 of one
+	if math.Abs(f.Linear-90) > 0.01 {
+		t.Errorf("Linear = %v, want 90", f.Linear)
+	}
+	if f.ExceedsLimit() {
+		t.Error("forecast should not exceed the limit")
+	}
+}
+
+func TestEnforcer_CheckForecast(t *testing.T) {
+	_, monthStart := periodStarts(time.Now())
+	provider := &forecastProvider{monthStart: monthStart, monthToDate: 450, trailing: 700}
+
+	var alerts []string
+	enforcer := NewEnforcer(&Config{Enabled: true, MonthlyLimit: 500}, provider)
+	enforcer.OnAlert(func(alertType, message, severity string) {
+		alerts = append(alerts, alertType+": "+message)
+	})
+
+	f, err := enforcer.CheckForecast(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.ExceedsLimit() {
+		t.Fatalf("forecast should exceed the limit: %+v", f)
+	}
+	if len(alerts) != 1 || !strings.HasPrefix(alerts[0], AlertForecastExceeded+": Projected month-end spend") {
+		t.Errorf("alerts = %q", alerts)
+	}
+
+	alerts = nil
+	provider.monthToDate, provider.trailing = 0, 0
+	if _, err := enforcer.CheckForecast(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 0 {
+		t.Errorf("forecast under the limit should not alert: %q", alerts)
+	}
+}
+
+func TestEnforcer_CheckForecast_Disabled(t *testing.T) {
+	enforcer := NewEnforcer(&Config{Enabled: false, MonthlyLimit: 500}, &errorUsageProvider{})
+	f, err := enforcer.CheckForecast(context.Background())
+	if f != nil || err != nil {
+		t.Errorf("disabled budget should skip the forecast, got %+v, %v", f, err)
+	}
+}
+
+func TestEnforcer_Forecast_ProviderError(t *testing.T) {
+	enforcer := NewEnforcer(&Config{Enabled: true, MonthlyLimit: 500}, &errorUsageProvider{})
+	if _, err := enforcer.Forecast(context.Background(), time.Now()); err == nil {
+		t.Error("expected error from provider")
+	}
+}
@@ -22,6 +22,39 @@ type Config struct {
 	PerTask      PerTaskConfig   `yaml:"per_task" json:"per_task"`
 	OnExceed     ExceedAction    `yaml:"on_exceed" json:"on_exceed"`
 	Thresholds   ThresholdConfig `yaml:"thresholds" json:"thresholds"`
+	// Adapters caps spend per source adapter (telegram, github, ...).
+	// Adapters without an entry are only bound by the global limits.
+	Adapters map[string]AdapterLimit `yaml:"adapters,omitempty" json:"adapters,omitempty"`
+	// Projects caps spend per project. Projects without an entry are only
+	// bound by the other limits.
+	Projects []ProjectBudget `yaml:"projects,omitempty" json:"projects,omitempty"`
+	// Teams caps the combined spend of each team's projects.
+	Teams []TeamBudget `yaml:"teams,omitempty" json:"teams,omitempty"`
+}
+
+// AdapterLimit caps the spend of tasks from one source adapter. A zero limit
+// leaves that period uncapped. Exceeding a cap uses the matching OnExceed action.
+type AdapterLimit struct {
+	DailyLimit   float64 `yaml:"daily_limit" json:"daily_limit"`     // USD
+	MonthlyLimit float64 `yaml:"monthly_limit" json:"monthly_limit"` // USD
+}
+
+// ProjectBudget caps the spend of tasks in one project. A zero limit leaves
+// that period uncapped. Exceeding a cap uses the matching OnExceed action.
+type ProjectBudget struct {
+	Path         string  `yaml:"path" json:"path"`                   // Project path, as in projects[].path
+	DailyLimit   float64 `yaml:"daily_limit" json:"daily_limit"`     // USD
+	MonthlyLimit float64 `yaml:"monthly_limit" json:"monthly_limit"` // USD
+}
+
+// TeamBudget caps the combined spend of a team's projects. Tasks in any of
+// the projects, or run for the team, count against it. A zero limit leaves
+// that period uncapped.
+type TeamBudget struct {
+	Name         string   `yaml:"name" json:"name"`                   // Team ID or name
+	Projects     []string `yaml:"projects" json:"projects"`           // Project paths
+	DailyLimit   float64  `yaml:"daily_limit" json:"daily_limit"`     // USD
+	MonthlyLimit float64  `yaml:"monthly_limit" json:"monthly_limit"` // USD
 }
 
 // PerTaskConfig defines per-task limits
@@ -86,6 +119,40 @@ type Status struct {
 	LastUpdated    time.Time `json:"last_updated"`
 }
 
+// Bucket kinds
+const (
+	BucketAdapter = "adapter"
+	BucketProject = "project"
+	BucketTeam    = "team"
+)
+
+// BucketStatus is the spend of one adapter, project or team bucket against
+// its caps. A zero limit means the period is uncapped.
+type BucketStatus struct {
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	DailySpent   float64 `json:"daily_spent"`
+	DailyLimit   float64 `json:"daily_limit"`
+	MonthlySpent float64 `json:"monthly_spent"`
+	MonthlyLimit float64 `json:"monthly_limit"`
+}
+
+// DailyPercent returns the daily spend in percent of the daily cap, 0 when uncapped
+func (b BucketStatus) DailyPercent() float64 {
+	if b.DailyLimit <= 0 {
+		return 0
+	}
+	return b.DailySpent / b.DailyLimit * 100
+}
+
+// MonthlyPercent returns the monthly spend in percent of the monthly cap, 0 when uncapped
+func (b BucketStatus) MonthlyPercent() float64 {
+	if b.MonthlyLimit <= 0 {
+		return 0
+	}
+	return b.MonthlySpent / b.MonthlyLimit * 100
+}
+
 // IsExceeded returns true if any limit is exceeded
 func (s *Status) IsExceeded() bool {
 	return s.DailyPercent >= 100 || s.MonthlyPercent >= 100
@@ -0,0 +1,148 @@
+// Package cluster spreads task execution over several machines. The
+// coordinator keeps the task queue in its memory store and runs the
+// pollers and autopilot; it leaves queued tasks to worker nodes started
+// with `pilot worker --join <coordinator>`. Workers lease tasks over HTTP,
+// run them with their own executor, renew the lease while they run, and
+// stream progress and the result back. A task whose worker stops renewing
+// is requeued when the lease expires.
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+)
+
+// PathPrefix is where the coordinator serves workers on the gateway
+const PathPrefix = "/cluster/"
+
+const (
+	defaultLeaseTTL          = 2 * time.Minute
+	defaultHeartbeatInterval = 30 * time.Second
+	defaultPollInterval      = 5 * time.Second
+)
+
+// ErrLeaseLost is returned when a worker no longer holds the lease on its
+// task: it expired and the task was requeued, or the task was cancelled
+var ErrLeaseLost = errors.New("cluster: lease lost")
+
+// Config configures the coordinator and the token workers join with
+type Config struct {
+	Enabled           bool          `yaml:"enabled"`            // Run queued tasks on worker nodes instead of locally
+	Token             string        `yaml:"token"`              // Shared secret workers present to the coordinator
+	LeaseTTL          time.Duration `yaml:"lease_ttl"`          // How long a task stays leased without a heartbeat (default: 2m)
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"` // How often workers renew their leases (default: 30s)
+	PollInterval      time.Duration `yaml:"poll_interval"`      // How often idle workers ask for a task (default: 5s)
+}
+
+// DefaultConfig returns cluster mode disabled with default timings
+func DefaultConfig() *Config {
+	return &Config{
+		LeaseTTL:          defaultLeaseTTL,
+		HeartbeatInterval: defaultHeartbeatInterval,
+		PollInterval:      defaultPollInterval,
+	}
+}
+
+// Validate requires a token and a lease that outlives several heartbeats
+func (c *Config) Validate() error {
+	if c.Token == "" {
+		return fmt.Errorf("token is required so only your workers can lease tasks")
+	}
+	if c.LeaseTTL < 0 || c.HeartbeatInterval < 0 || c.PollInterval < 0 {
+		return fmt.Errorf("lease_ttl, heartbeat_interval and poll_interval must not be negative")
+	}
+	if c.leaseTTL() < 2*c.heartbeatInterval() {
+		return fmt.Errorf("lease_ttl (%s) must be at least twice heartbeat_interval (%s)", c.leaseTTL(), c.heartbeatInterval())
+	}
+	return nil
+}
+
+func (c *Config) leaseTTL() time.Duration {
+	if c.LeaseTTL <= 0 {
+		return defaultLeaseTTL
+	}
+	return c.LeaseTTL
+}
+
+func (c *Config) heartbeatInterval() time.Duration {
+	if c.HeartbeatInterval <= 0 {
+		return defaultHeartbeatInterval
+	}
+	return c.HeartbeatInterval
+}
+
+func (c *Config) pollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+	return c.PollInterval
+}
+
+// Project is a project by name, mapped to where it is checked out on this
+// machine. Workers and the coordinator agree on names; paths may differ.
+type Project struct {
+	Name string
+	Path string
+}
+
+// RegisterRequest is sent by a worker when it joins
+type RegisterRequest struct {
+	WorkerID string   `json:"worker_id"`
+	Hostname string   `json:"hostname"`
+	Projects []string `json:"projects"` // Project names the worker has checked out
+	Capacity int      `json:"capacity"` // Tasks it runs at once
+}
+
+// RegisterResponse tells a worker the coordinator's timings
+type RegisterResponse struct {
+	HeartbeatInterval time.Duration `json:"heartbeat_interval"`
+	PollInterval      time.Duration `json:"poll_interval"`
+	LeaseTTL          time.Duration `json:"lease_ttl"`
+}
+
+// LeaseRequest asks for the next queued task of the worker's projects
+type LeaseRequest struct {
+	WorkerID string   `json:"worker_id"`
+	Projects []string `json:"projects"`
+}
+
+// LeasedTask is a task leased to a worker
+type LeasedTask struct {
+	ExecutionID string         `json:"execution_id"`
+	Project     string         `json:"project"` // Project name; the worker runs the task in its own checkout
+	Task        *executor.Task `json:"task"`
+}
+
+// HeartbeatRequest renews the lease on a running task
+type HeartbeatRequest struct {
+	WorkerID string `json:"worker_id"`
+}
+
+// ProgressUpdate is a progress report of a running task
+type ProgressUpdate struct {
+	WorkerID string `json:"worker_id"`
+	TaskID   string `json:"task_id"`
+	Phase    string `json:"phase"`
+	Progress int    `json:"progress"`
+	Message  string `json:"message"`
+}
+
+// ResultRequest reports how a task ended
+type ResultRequest struct {
+	WorkerID string            `json:"worker_id"`
+	Outcome  *executor.Outcome `json:"outcome"`
+}
+
+// WorkerInfo is a worker known to the coordinator
+type WorkerInfo struct {
+	ID           string    `json:"id"`
+	Hostname     string    `json:"hostname"`
+	Projects     []string  `json:"projects"`
+	Capacity     int       `json:"capacity"`
+	Running      []string  `json:"running"` // Execution IDs
+	RegisteredAt time.Time `json:"registered_at"`
+	LastSeen     time.Time `json:"last_seen"`
+}
@@ -0,0 +1,251 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// fakeRunner records the tasks it runs and reports progress for each
+type fakeRunner struct {
+	mu        sync.Mutex
+	tasks     []*executor.Task
+	callbacks map[string]executor.ProgressCallback
+}
+
+func (r *fakeRunner) Execute(ctx context.Context, task *executor.Task) (*executor.ExecutionResult, error) {
+	r.mu.Lock()
+	r.tasks = append(r.tasks, task)
+	callbacks := make([]executor.ProgressCallback, 0, len(r.callbacks))
+	for _, cb := range r.callbacks {
+		callbacks = append(callbacks, cb)
+	}
+	r.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(task.ID, "Implementing", 50, "Writing code")
+	}
+	return &executor.ExecutionResult{
+		TaskID:      task.ID,
+		Success:     true,
+		PRUrl:       "https://github.com/org/app/pull/7",
+		TokensTotal: 1200,
+	}, nil
+}
+
+func (r *fakeRunner) AddProgressCallback(name string, callback executor.ProgressCallback) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.callbacks == nil {
+		r.callbacks = make(map[string]executor.ProgressCallback)
+	}
+	r.callbacks[name] = callback
+}
+
+func (r *fakeRunner) RemoveProgressCallback(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.callbacks, name)
+}
+
+// fakeEmitter records the progress the coordinator forwards
+type fakeEmitter struct {
+	mu     sync.Mutex
+	phases []string
+}
+
+func (e *fakeEmitter) EmitProgress(taskID, phase string, progress int, message string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.phases = append(e.phases, phase)
+}
+
+func (e *fakeEmitter) saw(phase string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, p := range e.phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestCoordinator(t *testing.T) (*Coordinator, *memory.Store, *httptest.Server) {
+	t.Helper()
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	cfg := &Config{
+		Enabled:           true,
+		Token:             "secret",
+		LeaseTTL:          time.Minute,
+		HeartbeatInterval: 10 * time.Millisecond,
+		PollInterval:      10 * time.Millisecond,
+	}
+	coord := NewCoordinator(cfg, store, []Project{{Name: "app", Path: "/srv/app"}})
+	mux := http.NewServeMux()
+	mux.Handle(PathPrefix, coord.Handler())
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return coord, store, server
+}
+
+func queueTask(t *testing.T, store *memory.Store, id string) {
+	t.Helper()
+	if err := store.SaveExecution(&memory.Execution{
+		ID:          id,
+		TaskID:      "GH-" + id,
+		ProjectPath: "/srv/app",
+		Status:      "queued",
+		TaskTitle:   "Fix the thing",
+	}); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err == nil {
+		t.Error("Validate() should require a token")
+	}
+	cfg := DefaultConfig()
+	cfg.Token = "secret"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	cfg.LeaseTTL = 30 * time.Second
+	cfg.HeartbeatInterval = 20 * time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject a lease shorter than two heartbeats")
+	}
+	cfg.LeaseTTL = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject negative lease_ttl")
+	}
+}
+
+func TestWorker_RunsLeasedTask(t *testing.T) {
+	coord, store, server := newTestCoordinator(t)
+	emitter := &fakeEmitter{}
+	coord.SetProgressEmitter(emitter)
+	queueTask(t, store, "exec-1")
+
+	client, err := NewClient(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	runner := &fakeRunner{}
+	worker := NewWorker(client, runner, []Project{{Name: "app", Path: "/home/worker/app"}}, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- worker.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	var exec *memory.Execution
+	for time.Now().Before(deadline) {
+		exec, err = store.GetExecution("exec-1")
+		if err == nil && exec.Status == "completed" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+
+	if exec == nil || exec.Status != "completed" {
+		t.Fatalf("execution = %+v, want completed", exec)
+	}
+	if exec.PRUrl != "https://github.com/org/app/pull/7" || exec.TokensTotal != 1200 {
+		t.Errorf("execution PR = %q, tokens = %d, want the worker's result", exec.PRUrl, exec.TokensTotal)
+	}
+
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	if len(runner.tasks) != 1 {
+		t.Fatalf("runner ran %d tasks, want 1", len(runner.tasks))
+	}
+	if task := runner.tasks[0]; task.ProjectPath != "/home/worker/app" || task.Title != "Fix the thing" {
+		t.Errorf("task = %+v, want it in the worker's checkout", task)
+	}
+	if !emitter.saw("Completed") {
+		t.Errorf("emitted phases = %v, want Completed", emitter.phases)
+	}
+
+	workers := coord.Workers()
+	if len(workers) != 1 || workers[0].ID != worker.ID() || len(workers[0].Running) != 0 {
+		t.Errorf("workers = %+v, want %s with nothing running", workers, worker.ID())
+	}
+}
+
+func TestCoordinator_RejectsBadToken(t *testing.T) {
+	_, _, server := newTestCoordinator(t)
+
+	client, err := NewClient(server.URL, "wrong")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := client.Register(context.Background(), RegisterRequest{WorkerID: "w1"}); err == nil {
+		t.Error("Register() with a wrong token should fail")
+	}
+	if _, err := NewClient("pilot.internal:9090", "secret"); err == nil {
+		t.Error("NewClient() should reject a URL without scheme")
+	}
+}
+
+func TestCoordinator_LeaseLost(t *testing.T) {
+	coord, store, server := newTestCoordinator(t)
+	queueTask(t, store, "exec-1")
+	ctx := context.Background()
+
+	client, err := NewClient(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := client.Lease(ctx, LeaseRequest{WorkerID: "w1", Projects: []string{"app"}}); !errors.Is(err, errUnregistered) {
+		t.Fatalf("Lease() before Register = %v, want errUnregistered", err)
+	}
+	if _, err := client.Register(ctx, RegisterRequest{WorkerID: "w1", Projects: []string{"app"}}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	leased, err := client.Lease(ctx, LeaseRequest{WorkerID: "w1", Projects: []string{"app"}})
+	if err != nil || leased == nil {
+		t.Fatalf("Lease() = %v, %v, want a task", leased, err)
+	}
+	if leased.ExecutionID != "exec-1" || leased.Project != "app" {
+		t.Errorf("leased = %+v, want exec-1 of app", leased)
+	}
+	if err := client.Heartbeat(ctx, "exec-1", "w1"); err != nil {
+		t.Errorf("Heartbeat() = %v", err)
+	}
+
+	// The worker went quiet past its lease
+	coord.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	coord.sweep()
+	if exec, _ := store.GetExecution("exec-1"); exec.Status != "queued" {
+		t.Errorf("status = %q, want requeued", exec.Status)
+	}
+
+	if err := client.Heartbeat(ctx, "exec-1", "w1"); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Heartbeat() after requeue = %v, want ErrLeaseLost", err)
+	}
+	outcome := &executor.Outcome{Status: executor.OutcomeCompleted}
+	if err := client.Result(ctx, "exec-1", "w1", outcome); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Result() after requeue = %v, want ErrLeaseLost", err)
+	}
+	if exec, _ := store.GetExecution("exec-1"); exec.Status != "queued" {
+		t.Errorf("status = %q, want the late result dropped", exec.Status)
+	}
+}
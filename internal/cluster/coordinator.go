@@ -0,0 +1,399 @@
+package cluster
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// maxRequestBody bounds worker requests; results carry token breakdowns
+// but no output
+const maxRequestBody = 1 << 20
+
+// ProgressEmitter receives the progress of tasks running on workers.
+// Satisfied by *executor.Runner, whose progress callbacks feed the
+// dashboard and chat adapters.
+type ProgressEmitter interface {
+	EmitProgress(taskID, phase string, progress int, message string)
+}
+
+// Coordinator hands queued tasks to workers and records their results
+type Coordinator struct {
+	config   *Config
+	store    *memory.Store
+	progress ProgressEmitter
+	projects map[string]string // name -> path on the coordinator
+	log      *slog.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	workers map[string]*WorkerInfo
+}
+
+// NewCoordinator creates a coordinator for the projects in the store's queue
+func NewCoordinator(cfg *Config, store *memory.Store, projects []Project) *Coordinator {
+	byName := make(map[string]string, len(projects))
+	for _, p := range projects {
+		byName[p.Name] = p.Path
+	}
+	return &Coordinator{
+		config:   cfg,
+		store:    store,
+		projects: byName,
+		log:      logging.WithComponent("cluster"),
+		now:      time.Now,
+		workers:  make(map[string]*WorkerInfo),
+	}
+}
+
+// SetProgressEmitter forwards the progress workers report
+func (c *Coordinator) SetProgressEmitter(emitter ProgressEmitter) {
+	c.progress = emitter
+}
+
+// Run requeues tasks whose worker stopped renewing the lease and forgets
+// workers that stopped calling in. Blocks until ctx is cancelled.
+func (c *Coordinator) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.heartbeatInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.sweep()
+		}
+	}
+}
+
+// sweep runs one round of Run
+func (c *Coordinator) sweep() {
+	now := c.now()
+	requeued, err := c.store.RequeueExpiredLeases(now)
+	if err != nil {
+		c.log.Warn("Failed to requeue expired leases", slog.Any("error", err))
+	} else if requeued > 0 {
+		c.log.Warn("Requeued tasks of unresponsive workers", slog.Int("count", requeued))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, w := range c.workers {
+		if now.Sub(w.LastSeen) > 2*c.config.leaseTTL() {
+			c.log.Info("Worker left", slog.String("worker", id))
+			delete(c.workers, id)
+		}
+	}
+}
+
+// Workers returns the workers that called in recently, by ID
+func (c *Coordinator) Workers() []WorkerInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	workers := make([]WorkerInfo, 0, len(c.workers))
+	for _, w := range c.workers {
+		info := *w
+		info.Running = append([]string(nil), w.Running...)
+		workers = append(workers, info)
+	}
+	sort.Slice(workers, func(i, j int) bool { return workers[i].ID < workers[j].ID })
+	return workers
+}
+
+// Handler serves workers under PathPrefix. Every request must carry the
+// cluster token as a bearer token.
+func (c *Coordinator) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(c.config.Token)) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, PathPrefix)
+		if path == "workers" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			writeJSON(w, http.StatusOK, c.Workers())
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBody)
+
+		switch path {
+		case "register":
+			c.handleRegister(w, r)
+			return
+		case "lease":
+			c.handleLease(w, r)
+			return
+		}
+
+		// executions/{id}/{action}
+		parts := strings.Split(path, "/")
+		if len(parts) != 3 || parts[0] != "executions" || parts[1] == "" {
+			http.NotFound(w, r)
+			return
+		}
+		switch parts[2] {
+		case "heartbeat":
+			c.handleHeartbeat(w, r, parts[1])
+		case "progress":
+			c.handleProgress(w, r, parts[1])
+		case "result":
+			c.handleResult(w, r, parts[1])
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func (c *Coordinator) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req RegisterRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.WorkerID == "" {
+		http.Error(w, "worker_id is required", http.StatusBadRequest)
+		return
+	}
+
+	now := c.now()
+	c.mu.Lock()
+	c.workers[req.WorkerID] = &WorkerInfo{
+		ID:           req.WorkerID,
+		Hostname:     req.Hostname,
+		Projects:     req.Projects,
+		Capacity:     req.Capacity,
+		RegisteredAt: now,
+		LastSeen:     now,
+	}
+	c.mu.Unlock()
+
+	var unknown []string
+	for _, name := range req.Projects {
+		if _, ok := c.projects[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	c.log.Info("Worker joined",
+		slog.String("worker", req.WorkerID),
+		slog.String("hostname", req.Hostname),
+		slog.Any("projects", req.Projects),
+		slog.Int("capacity", req.Capacity),
+	)
+	if len(unknown) > 0 {
+		c.log.Warn("Worker has projects the coordinator does not know", slog.String("worker", req.WorkerID), slog.Any("projects", unknown))
+	}
+
+	writeJSON(w, http.StatusOK, RegisterResponse{
+		HeartbeatInterval: c.config.heartbeatInterval(),
+		PollInterval:      c.config.pollInterval(),
+		LeaseTTL:          c.config.leaseTTL(),
+	})
+}
+
+func (c *Coordinator) handleLease(w http.ResponseWriter, r *http.Request) {
+	var req LeaseRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !c.touch(req.WorkerID) {
+		http.Error(w, "unknown worker, register first", http.StatusConflict)
+		return
+	}
+
+	// Only the worker's projects, mapped to the coordinator's paths
+	var paths []string
+	names := make(map[string]string)
+	for _, name := range req.Projects {
+		if path, ok := c.projects[name]; ok {
+			paths = append(paths, path)
+			names[path] = name
+		}
+	}
+	if len(paths) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	exec, err := c.store.LeaseQueuedExecution(req.WorkerID, paths, c.config.leaseTTL(), c.now())
+	if err != nil {
+		c.log.Error("Failed to lease task", slog.String("worker", req.WorkerID), slog.Any("error", err))
+		http.Error(w, "failed to lease task", http.StatusInternalServerError)
+		return
+	}
+	if exec == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	c.mu.Lock()
+	if info, ok := c.workers[req.WorkerID]; ok {
+		info.Running = append(info.Running, exec.ID)
+	}
+	c.mu.Unlock()
+
+	c.log.Info("Task leased",
+		slog.String("execution_id", exec.ID),
+		slog.String("task_id", exec.TaskID),
+		slog.String("worker", req.WorkerID),
+	)
+	if c.progress != nil {
+		c.progress.EmitProgress(exec.TaskID, "Running", 2, fmt.Sprintf("Leased by worker %s", req.WorkerID))
+	}
+	writeJSON(w, http.StatusOK, LeasedTask{
+		ExecutionID: exec.ID,
+		Project:     names[exec.ProjectPath],
+		Task:        executor.TaskFromExecution(exec),
+	})
+}
+
+func (c *Coordinator) handleHeartbeat(w http.ResponseWriter, r *http.Request, execID string) {
+	var req HeartbeatRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	c.touch(req.WorkerID)
+
+	held, err := c.store.RenewExecutionLease(execID, req.WorkerID, c.config.leaseTTL(), c.now())
+	if err != nil {
+		c.log.Error("Failed to renew lease", slog.String("execution_id", execID), slog.Any("error", err))
+		http.Error(w, "failed to renew lease", http.StatusInternalServerError)
+		return
+	}
+	if !held {
+		c.forget(req.WorkerID, execID)
+		http.Error(w, ErrLeaseLost.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Coordinator) handleProgress(w http.ResponseWriter, r *http.Request, execID string) {
+	var req ProgressUpdate
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	c.touch(req.WorkerID)
+	if c.progress != nil && req.TaskID != "" {
+		c.progress.EmitProgress(req.TaskID, req.Phase, req.Progress, req.Message)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Coordinator) handleResult(w http.ResponseWriter, r *http.Request, execID string) {
+	var req ResultRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Outcome == nil {
+		http.Error(w, "outcome is required", http.StatusBadRequest)
+		return
+	}
+	c.touch(req.WorkerID)
+	defer c.forget(req.WorkerID, execID)
+
+	// A worker that lost its lease may finish after the task was requeued
+	// or cancelled; its result is dropped
+	held, err := c.store.ReleaseExecutionLease(execID, req.WorkerID)
+	if err != nil {
+		c.log.Error("Failed to release lease", slog.String("execution_id", execID), slog.Any("error", err))
+		http.Error(w, "failed to release lease", http.StatusInternalServerError)
+		return
+	}
+	if !held {
+		http.Error(w, ErrLeaseLost.Error(), http.StatusConflict)
+		return
+	}
+
+	exec, err := c.store.GetExecution(execID)
+	if err != nil {
+		c.log.Error("Failed to load execution", slog.String("execution_id", execID), slog.Any("error", err))
+		http.Error(w, "failed to load execution", http.StatusInternalServerError)
+		return
+	}
+	if err := executor.SaveOutcome(c.store, exec, req.Outcome); err != nil {
+		c.log.Error("Failed to record execution outcome", slog.String("execution_id", execID), slog.Any("error", err))
+	}
+
+	c.log.Info("Task finished on worker",
+		slog.String("execution_id", execID),
+		slog.String("task_id", exec.TaskID),
+		slog.String("worker", req.WorkerID),
+		slog.String("status", req.Outcome.Status),
+	)
+	if c.progress != nil {
+		switch req.Outcome.Status {
+		case executor.OutcomeCompleted:
+			msg := fmt.Sprintf("Completed on %s", req.WorkerID)
+			if req.Outcome.PRUrl != "" {
+				msg = fmt.Sprintf("Completed with PR: %s", req.Outcome.PRUrl)
+			}
+			c.progress.EmitProgress(exec.TaskID, "Completed", 100, msg)
+		case executor.OutcomeFailed:
+			c.progress.EmitProgress(exec.TaskID, "Failed", 100, fmt.Sprintf("Task failed on %s: %s", req.WorkerID, req.Outcome.Error))
+		case executor.OutcomeCancelled:
+			c.progress.EmitProgress(exec.TaskID, "Cancelled", 100, "Task cancelled")
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// touch marks a worker as seen. Returns false for unknown workers.
+func (c *Coordinator) touch(workerID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info, ok := c.workers[workerID]
+	if ok {
+		info.LastSeen = c.now()
+	}
+	return ok
+}
+
+// forget removes a finished task from its worker's running list
+func (c *Coordinator) forget(workerID, execID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info, ok := c.workers[workerID]
+	if !ok {
+		return
+	}
+	for i, id := range info.Running {
+		if id == execID {
+			info.Running = append(info.Running[:i], info.Running[i+1:]...)
+			return
+		}
+	}
+}
+
+// decodeRequest decodes a JSON body, answering 400 when it is malformed
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
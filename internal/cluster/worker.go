@@ -0,0 +1,389 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// errUnregistered is returned when the coordinator does not know the
+// worker, e.g. after it restarted
+var errUnregistered = errors.New("cluster: worker not registered")
+
+// progressBuffer bounds the progress updates waiting to be sent; beyond it
+// updates are dropped rather than slowing the task down
+const progressBuffer = 64
+
+// resultAttempts is how often a worker tries to deliver a result before
+// leaving the task to be requeued when its lease expires
+const resultAttempts = 5
+
+// Client calls the coordinator's worker API
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient creates a client for the coordinator at coordinatorURL, the
+// gateway address such as http://pilot.internal:9090
+func NewClient(coordinatorURL, token string) (*Client, error) {
+	u, err := url.Parse(coordinatorURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid coordinator URL %q: want http(s)://host:port", coordinatorURL)
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(u.String(), "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Register joins the cluster and returns the coordinator's timings
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error) {
+	var resp RegisterResponse
+	if _, err := c.post(ctx, "register", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Lease asks for the next task; nil when the queue has none for the worker
+func (c *Client) Lease(ctx context.Context, req LeaseRequest) (*LeasedTask, error) {
+	var task LeasedTask
+	status, err := c.post(ctx, "lease", req, &task)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNoContent {
+		return nil, nil
+	}
+	return &task, nil
+}
+
+// Heartbeat renews the lease on a running task. Returns ErrLeaseLost when
+// the worker must stop it.
+func (c *Client) Heartbeat(ctx context.Context, execID, workerID string) error {
+	_, err := c.post(ctx, "executions/"+url.PathEscape(execID)+"/heartbeat", HeartbeatRequest{WorkerID: workerID}, nil)
+	return err
+}
+
+// Progress reports the progress of a running task
+func (c *Client) Progress(ctx context.Context, execID string, update ProgressUpdate) error {
+	_, err := c.post(ctx, "executions/"+url.PathEscape(execID)+"/progress", update, nil)
+	return err
+}
+
+// Result reports how a task ended. Returns ErrLeaseLost when the
+// coordinator dropped it.
+func (c *Client) Result(ctx context.Context, execID, workerID string, outcome *executor.Outcome) error {
+	_, err := c.post(ctx, "executions/"+url.PathEscape(execID)+"/result", ResultRequest{WorkerID: workerID, Outcome: outcome}, nil)
+	return err
+}
+
+// post sends body as JSON and decodes a 200 response into out
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+PathPrefix+path, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusOK && out != nil:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("decode %s response: %w", path, err)
+		}
+		return resp.StatusCode, nil
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent:
+		return resp.StatusCode, nil
+	case resp.StatusCode == http.StatusConflict && path == "lease":
+		return resp.StatusCode, errUnregistered
+	case resp.StatusCode == http.StatusConflict:
+		return resp.StatusCode, ErrLeaseLost
+	case resp.StatusCode == http.StatusUnauthorized:
+		return resp.StatusCode, fmt.Errorf("coordinator rejected the cluster token")
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("coordinator %s: %s: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+}
+
+// Runner executes leased tasks. Satisfied by *executor.Runner.
+type Runner interface {
+	Execute(ctx context.Context, task *executor.Task) (*executor.ExecutionResult, error)
+	AddProgressCallback(name string, callback executor.ProgressCallback)
+	RemoveProgressCallback(name string)
+}
+
+// Worker leases tasks from the coordinator and runs them locally
+type Worker struct {
+	client   *Client
+	runner   Runner
+	id       string
+	projects map[string]string // name -> local checkout
+	capacity int
+	log      *slog.Logger
+
+	timings RegisterResponse
+
+	mu      sync.Mutex
+	running map[string]string // task ID -> execution ID
+	updates chan progressMessage
+}
+
+type progressMessage struct {
+	execID string
+	update ProgressUpdate
+}
+
+// NewWorker creates a worker that runs up to capacity tasks at once, for
+// the projects checked out on this machine
+func NewWorker(client *Client, runner Runner, projects []Project, capacity int) *Worker {
+	byName := make(map[string]string, len(projects))
+	for _, p := range projects {
+		byName[p.Name] = p.Path
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "worker"
+	}
+	return &Worker{
+		client:   client,
+		runner:   runner,
+		id:       fmt.Sprintf("%s-%d", host, os.Getpid()),
+		projects: byName,
+		capacity: capacity,
+		log:      logging.WithComponent("worker"),
+		running:  make(map[string]string),
+		updates:  make(chan progressMessage, progressBuffer),
+	}
+}
+
+// ID returns the worker's ID, hostname-pid
+func (w *Worker) ID() string {
+	return w.id
+}
+
+// Run joins the cluster and runs leased tasks until ctx is cancelled.
+// Tasks still running then are abandoned; the coordinator requeues them
+// when their leases expire.
+func (w *Worker) Run(ctx context.Context) error {
+	if len(w.projects) == 0 {
+		return fmt.Errorf("worker has no projects configured")
+	}
+	if err := w.register(ctx); err != nil {
+		return err
+	}
+
+	w.runner.AddProgressCallback("cluster", w.onProgress)
+	defer w.runner.RemoveProgressCallback("cluster")
+	go w.sendProgress(ctx)
+
+	slots := make(chan struct{}, w.capacity)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case slots <- struct{}{}:
+		}
+
+		leased, err := w.client.Lease(ctx, LeaseRequest{WorkerID: w.id, Projects: w.projectNames()})
+		if errors.Is(err, errUnregistered) {
+			err = w.register(ctx)
+		}
+		if err != nil || leased == nil {
+			<-slots
+			if err != nil && ctx.Err() == nil {
+				w.log.Warn("Failed to lease task", slog.Any("error", err))
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(w.timings.PollInterval):
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			w.runTask(ctx, leased)
+		}()
+	}
+}
+
+// register joins the cluster and adopts the coordinator's timings
+func (w *Worker) register(ctx context.Context) error {
+	host, _ := os.Hostname()
+	timings, err := w.client.Register(ctx, RegisterRequest{
+		WorkerID: w.id,
+		Hostname: host,
+		Projects: w.projectNames(),
+		Capacity: w.capacity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to join coordinator: %w", err)
+	}
+	if timings.PollInterval <= 0 {
+		timings.PollInterval = defaultPollInterval
+	}
+	if timings.HeartbeatInterval <= 0 {
+		timings.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	w.timings = *timings
+	w.log.Info("Joined coordinator", slog.String("worker", w.id), slog.Any("projects", w.projectNames()))
+	return nil
+}
+
+// runTask runs a leased task in the local checkout of its project,
+// renewing the lease until it ends, and reports the outcome
+func (w *Worker) runTask(ctx context.Context, leased *LeasedTask) {
+	log := w.log.With(slog.String("execution_id", leased.ExecutionID), slog.String("task_id", leased.Task.ID))
+	task := leased.Task
+	if path, ok := w.projects[leased.Project]; ok {
+		task.ProjectPath = path
+	}
+
+	w.mu.Lock()
+	w.running[task.ID] = leased.ExecutionID
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.running, task.ID)
+		w.mu.Unlock()
+	}()
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var lost sync.Once
+	leaseLost := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(w.timings.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-taskCtx.Done():
+				return
+			case <-ticker.C:
+				err := w.client.Heartbeat(taskCtx, leased.ExecutionID, w.id)
+				if errors.Is(err, ErrLeaseLost) {
+					log.Warn("Lease lost, stopping task")
+					lost.Do(func() { close(leaseLost) })
+					cancel()
+					return
+				}
+				if err != nil && taskCtx.Err() == nil {
+					log.Warn("Heartbeat failed", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+
+	log.Info("Running leased task", slog.String("project", task.ProjectPath))
+	start := time.Now()
+	result, execErr := w.runner.Execute(taskCtx, task)
+	outcome := executor.NewOutcome(result, execErr, time.Since(start))
+	cancel()
+
+	select {
+	case <-leaseLost:
+		return // Requeued or cancelled on the coordinator
+	default:
+	}
+	if ctx.Err() != nil {
+		return // Shutting down; the lease expires and the task is requeued
+	}
+
+	for attempt := 1; attempt <= resultAttempts; attempt++ {
+		err := w.client.Result(ctx, leased.ExecutionID, w.id, outcome)
+		if err == nil {
+			log.Info("Task finished", slog.String("status", outcome.Status))
+			return
+		}
+		if errors.Is(err, ErrLeaseLost) {
+			log.Warn("Result dropped, lease lost")
+			return
+		}
+		log.Warn("Failed to report result", slog.Int("attempt", attempt), slog.Any("error", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+}
+
+// onProgress queues progress of leased tasks for the coordinator
+func (w *Worker) onProgress(taskID, phase string, progress int, message string) {
+	w.mu.Lock()
+	execID, ok := w.running[taskID]
+	w.mu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case w.updates <- progressMessage{execID: execID, update: ProgressUpdate{
+		WorkerID: w.id,
+		TaskID:   taskID,
+		Phase:    phase,
+		Progress: progress,
+		Message:  message,
+	}}:
+	default:
+		// Coordinator is slow; drop rather than block the task
+	}
+}
+
+// sendProgress streams queued progress to the coordinator
+func (w *Worker) sendProgress(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-w.updates:
+			if err := w.client.Progress(ctx, msg.execID, msg.update); err != nil && ctx.Err() == nil {
+				w.log.Debug("Failed to send progress", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+func (w *Worker) projectNames() []string {
+	names := make([]string, 0, len(w.projects))
+	for name := range w.projects {
+		names = append(names, name)
+	}
+	return names
+}
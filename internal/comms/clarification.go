@@ -0,0 +1,164 @@
+package comms
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+// clarification returns the runner's clarification policy, nil when unset
+func (h *Handler) clarification() *executor.ClarificationConfig {
+	if h.runner == nil || h.runner.Config() == nil {
+		return nil
+	}
+	return h.runner.Config().Clarification
+}
+
+// askQuestion pauses a task that stopped for its requester: the question is
+// stored and posted to the chat or thread the task came from. It reports
+// false when err did not pause the task or the question can't be kept.
+func (h *Handler) askQuestion(ctx context.Context, contextID, threadID, senderID string, task *executor.Task, err error) bool {
+	c, ok := executor.ClarificationFor(err)
+	if !ok || h.store == nil {
+		return false
+	}
+
+	q := &memory.PendingQuestion{
+		TaskID:      task.ID,
+		Adapter:     h.adapter,
+		ContextID:   contextID,
+		ThreadID:    threadID,
+		SenderID:    senderID,
+		Question:    c.Question,
+		SessionID:   c.SessionID,
+		NeedsInfo:   c.NeedsInfo,
+		Description: task.Description,
+		ProjectPath: task.ProjectPath,
+		CreatePR:    task.CreatePR,
+	}
+	if err := h.store.SavePendingQuestion(q); err != nil {
+		h.log.Warn("Failed to save pending question",
+			slog.String("task_id", task.ID),
+			slog.Any("error", err))
+		return false
+	}
+
+	var sb strings.Builder
+	sb.WriteString(c.Question)
+	sb.WriteString("\n\nReply here and Pilot will continue the task with your answer.")
+	if timeout := h.clarification().TimeoutDuration(); timeout > 0 {
+		if h.clarification().ProceedOnTimeout() {
+			sb.WriteString(fmt.Sprintf(" Without an answer within %s, it proceeds with its best judgement.", timeout))
+		} else {
+			sb.WriteString(fmt.Sprintf(" Without an answer within %s, the task is dropped.", timeout))
+		}
+	}
+	_ = h.messenger.SendChunked(ctx, contextID, threadID, sb.String(), fmt.Sprintf("❓ %s needs your input", task.ID))
+
+	h.log.Info("Task waiting for an answer",
+		slog.String("task_id", task.ID),
+		slog.String("context_id", contextID))
+	return true
+}
+
+// answerQuestion resumes the task waiting on a question in the message's
+// chat or thread, with the message as the answer. Only the task's requester
+// may answer, and only while allowed to run tasks. It reports false when no
+// question waits on the sender. A pending task confirmation takes precedence.
+func (h *Handler) answerQuestion(ctx context.Context, msg *IncomingMessage) bool {
+	text := strings.TrimSpace(msg.Text)
+	if h.store == nil || text == "" || strings.HasPrefix(text, "/") {
+		return false
+	}
+	h.mu.Lock()
+	_, confirming := h.pendingTasks[msg.ContextID]
+	h.mu.Unlock()
+	if confirming {
+		return false
+	}
+
+	q, err := h.store.GetPendingQuestion(h.adapter, msg.ContextID, msg.ThreadID)
+	if err != nil {
+		h.log.Warn("Failed to look up pending question", slog.Any("error", err))
+		return false
+	}
+	if q == nil || q.SenderID != msg.SenderID {
+		return false
+	}
+	// The answer becomes part of the task prompt, so answering is running it
+	if !h.AllowCommand(ctx, msg.ContextID, msg.SenderID, teams.CommandRun) {
+		return true
+	}
+	resolved, err := h.store.ResolvePendingQuestion(q.ID, memory.QuestionAnswered, text)
+	if err != nil {
+		h.log.Warn("Failed to record answer",
+			slog.String("task_id", q.TaskID),
+			slog.Any("error", err))
+		return false
+	}
+	if !resolved {
+		return false
+	}
+
+	h.log.Info("Question answered, resuming task",
+		slog.String("task_id", q.TaskID),
+		slog.String("context_id", msg.ContextID))
+	h.resumeTask(ctx, q, text)
+	return true
+}
+
+// resumeTask runs a paused task again with the answer to its question,
+// continuing the backend session when there is one
+func (h *Handler) resumeTask(ctx context.Context, q *memory.PendingQuestion, answer string) {
+	if !h.checkBudget(ctx, q.ContextID, q.TaskID) {
+		return
+	}
+	description := q.Description + "\n\n" + executor.ClarificationPrompt(q.Question, answer)
+	task := h.newTask(q.ContextID, q.TaskID, description, q.CreatePR, "")
+	if q.ProjectPath != "" {
+		task.ProjectPath = q.ProjectPath
+	}
+	task.ResumeSessionID = q.SessionID
+	task.Clarified = q.NeedsInfo
+	h.runTask(ctx, q.ContextID, q.ThreadID, q.SenderID, task)
+}
+
+// expireQuestions applies the clarification timeout to questions nobody
+// answered: the task is dropped, or resumed when the policy is to proceed
+func (h *Handler) expireQuestions(ctx context.Context) {
+	timeout := h.clarification().TimeoutDuration()
+	if h.store == nil || timeout == 0 {
+		return
+	}
+	questions, err := h.store.GetPendingQuestionsBefore(h.adapter, time.Now().Add(-timeout))
+	if err != nil {
+		h.log.Warn("Failed to list expired questions", slog.Any("error", err))
+		return
+	}
+
+	proceed := h.clarification().ProceedOnTimeout()
+	for _, q := range questions {
+		resolved, err := h.store.ResolvePendingQuestion(q.ID, memory.QuestionExpired, "")
+		if err != nil || !resolved {
+			continue
+		}
+		h.log.Info("Question timed out",
+			slog.String("task_id", q.TaskID),
+			slog.Bool("proceed", proceed))
+		prefix := fmt.Sprintf("⌛ %s", q.TaskID)
+		if !proceed {
+			_ = h.messenger.SendChunked(ctx, q.ContextID, q.ThreadID,
+				fmt.Sprintf("Nobody answered within %s, so the task was dropped. Send the request again to retry.", timeout), prefix)
+			continue
+		}
+		_ = h.messenger.SendChunked(ctx, q.ContextID, q.ThreadID,
+			fmt.Sprintf("Nobody answered within %s, so Pilot continues with its best judgement.", timeout), prefix)
+		go h.resumeTask(ctx, q, executor.NoAnswerReply(timeout))
+	}
+}
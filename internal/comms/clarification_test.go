@@ -0,0 +1,177 @@
+package comms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/budget"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func newClarificationTestHandler(t *testing.T, m *handlerMock, clarification *executor.ClarificationConfig) (*Handler, *memory.Store) {
+	t.Helper()
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	runner, err := executor.NewRunnerWithConfig(&executor.BackendConfig{Clarification: clarification})
+	if err != nil {
+		t.Fatalf("NewRunnerWithConfig: %v", err)
+	}
+	h := NewHandler(&HandlerConfig{
+		Messenger:    m,
+		Runner:       runner,
+		Store:        store,
+		TaskIDPrefix: "SLACK",
+		Adapter:      "slack",
+	})
+	return h, store
+}
+
+func TestAskQuestion(t *testing.T) {
+	m := &handlerMock{}
+	h, store := newClarificationTestHandler(t, m, &executor.ClarificationConfig{Timeout: "1h", OnTimeout: "proceed"})
+	task := &executor.Task{ID: "SLACK-1", Description: "Add uploads", ProjectPath: "/app", CreatePR: true}
+
+	if h.askQuestion(context.Background(), "C1", "111.222", "U1", task, errors.New("boom")) {
+		t.Fatal("a plain failure should not ask a question")
+	}
+
+	blocked := fmt.Errorf("task SLACK-1: %w", &executor.BlockedError{Reason: "Which bucket?", SessionID: "sess-1"})
+	if !h.askQuestion(context.Background(), "C1", "111.222", "U1", task, blocked) {
+		t.Fatal("a blocked task should ask a question")
+	}
+
+	if len(m.chunks) != 1 {
+		t.Fatalf("chunks = %+v, want the question", m.chunks)
+	}
+	sent := m.chunks[0]
+	if sent.threadID != "111.222" || !strings.Contains(sent.prefix, "SLACK-1 needs your input") ||
+		!strings.Contains(sent.content, "Which bucket?") || !strings.Contains(sent.content, "best judgement") {
+		t.Errorf("unexpected question message: %+v", sent)
+	}
+
+	q, err := store.GetPendingQuestion("slack", "C1", "111.222")
+	if err != nil || q == nil {
+		t.Fatalf("question not stored: %v", err)
+	}
+	if q.SessionID != "sess-1" || q.SenderID != "U1" || q.Description != "Add uploads" || !q.CreatePR || q.ProjectPath != "/app" {
+		t.Errorf("unexpected stored question: %+v", q)
+	}
+}
+
+func TestAskQuestion_NoStore(t *testing.T) {
+	m := &handlerMock{}
+	h := newTestHandler(m)
+	err := fmt.Errorf("task T-1: %w", &executor.NeedsInfoError{Verdict: executor.TriageQuestion, Reason: "A question"})
+	if h.askQuestion(context.Background(), "ch1", "", "", &executor.Task{ID: "T-1"}, err) {
+		t.Error("without a store the task should be reported as failed")
+	}
+}
+
+func TestHandleMessage_AnswerResumesTask(t *testing.T) {
+	m := &handlerMock{}
+	h, store := newClarificationTestHandler(t, m, nil)
+	q := &memory.PendingQuestion{TaskID: "SLACK-1", Adapter: "slack", ContextID: "C1", ThreadID: "111.222", SenderID: "U1", Question: "Which bucket?", Description: "Add uploads"}
+	if err := store.SavePendingQuestion(q); err != nil {
+		t.Fatalf("SavePendingQuestion: %v", err)
+	}
+
+	// Stop the resumed task at the budget check so nothing executes
+	h.SetBudgetEnforcer(budget.NewEnforcer(&budget.Config{
+		Enabled:      true,
+		DailyLimit:   100,
+		MonthlyLimit: 1000,
+		OnExceed:     budget.ExceedAction{Daily: budget.ActionPause},
+		Adapters:     map[string]budget.AdapterLimit{"slack": {DailyLimit: 1}},
+	}, store))
+	h.recordExecution(&executor.Task{ID: "SLACK-0"}, &executor.ExecutionResult{Success: true, EstimatedCostUSD: 2}, nil, time.Second)
+
+	// A message in another thread is not the answer
+	if h.answerQuestion(context.Background(), &IncomingMessage{ContextID: "C1", ThreadID: "999.000", Text: "hello"}) {
+		t.Fatal("message in another thread answered the question")
+	}
+
+	// Nor is a message from someone other than the requester
+	if h.answerQuestion(context.Background(), &IncomingMessage{ContextID: "C1", ThreadID: "111.222", SenderID: "U2", Text: "rm -rf"}) {
+		t.Fatal("another sender answered the question")
+	}
+
+	h.HandleMessage(context.Background(), &IncomingMessage{ContextID: "C1", ThreadID: "111.222", SenderID: "U1", Text: "assets-prod", Platform: "slack"})
+
+	if got, _ := store.GetPendingQuestion("slack", "C1", "111.222"); got != nil {
+		t.Errorf("question still pending after the answer: %+v", got)
+	}
+	texts := m.getTexts()
+	if len(texts) != 1 || !strings.Contains(texts[0].text, "SLACK-1 not started") {
+		t.Errorf("texts = %+v, want the resumed task to reach the budget check", texts)
+	}
+}
+
+func TestAnswerQuestion_Unauthorized(t *testing.T) {
+	m := &handlerMock{}
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	checker := &hMockCommandChecker{denied: map[string]bool{"run": true}}
+	h := NewHandler(&HandlerConfig{
+		Messenger:      m,
+		Store:          store,
+		MemberResolver: &hMockMemberResolver{memberID: "m1"},
+		Commands:       checker,
+		TaskIDPrefix:   "SLACK",
+		Adapter:        "slack",
+	})
+	q := &memory.PendingQuestion{TaskID: "SLACK-1", Adapter: "slack", ContextID: "C1", SenderID: "U1", Question: "Which bucket?", Description: "Add uploads"}
+	if err := store.SavePendingQuestion(q); err != nil {
+		t.Fatalf("SavePendingQuestion: %v", err)
+	}
+
+	// The requester lost the run permission since asking
+	if !h.answerQuestion(context.Background(), &IncomingMessage{ContextID: "C1", SenderID: "U1", Text: "assets-prod"}) {
+		t.Fatal("expected the answer to be handled")
+	}
+	texts := m.getTexts()
+	if len(texts) != 1 || !strings.HasPrefix(texts[0].text, "⛔") {
+		t.Errorf("texts = %+v, want a denial", texts)
+	}
+	if got, _ := store.GetPendingQuestion("slack", "C1", ""); got == nil {
+		t.Error("denied answer should leave the question pending")
+	}
+	if strings.Join(checker.checked, ",") != "run" {
+		t.Errorf("checked = %v, want run", checker.checked)
+	}
+}
+
+func TestExpireQuestions(t *testing.T) {
+	m := &handlerMock{}
+	h, store := newClarificationTestHandler(t, m, &executor.ClarificationConfig{Timeout: "1h", OnTimeout: "fail"})
+	old := &memory.PendingQuestion{TaskID: "SLACK-1", Adapter: "slack", ContextID: "C1", Question: "Which bucket?", Description: "Add uploads", CreatedAt: time.Now().Add(-2 * time.Hour)}
+	fresh := &memory.PendingQuestion{TaskID: "SLACK-2", Adapter: "slack", ContextID: "C2", Question: "Which region?", Description: "Deploy"}
+	for _, q := range []*memory.PendingQuestion{old, fresh} {
+		if err := store.SavePendingQuestion(q); err != nil {
+			t.Fatalf("SavePendingQuestion: %v", err)
+		}
+	}
+
+	h.cleanupExpiredTasks(context.Background())
+
+	if got, _ := store.GetPendingQuestion("slack", "C1", ""); got != nil {
+		t.Errorf("timed out question still pending: %+v", got)
+	}
+	if got, _ := store.GetPendingQuestion("slack", "C2", ""); got == nil {
+		t.Error("fresh question should still be pending")
+	}
+	if len(m.chunks) != 1 || m.chunks[0].contextID != "C1" || !strings.Contains(m.chunks[0].content, "dropped") {
+		t.Errorf("chunks = %+v, want a timeout notice for C1", m.chunks)
+	}
+}
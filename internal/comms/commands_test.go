@@ -537,10 +537,12 @@ func stringContains(s, substr string) bool {
 }
 
 func mustCreateMemoryStore(t *testing.T) *memory.Store {
-	store, err := memory.NewStore(":memory:")
+	t.Helper()
+	store, err := memory.NewStore(t.TempDir())
 	if err != nil {
 		t.Fatalf("failed to create memory store: %v", err)
 	}
+	t.Cleanup(func() { _ = store.Close() })
 	return store
 }
 
@@ -3,16 +3,21 @@ package comms
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/alekspetrov/pilot/internal/budget"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/intent"
 	"github.com/alekspetrov/pilot/internal/logging"
 	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/teams"
 )
 
 // MemberResolver resolves a platform user to a team member ID for RBAC.
@@ -23,6 +28,12 @@ type MemberResolver interface {
 	ResolveIdentity(senderID string) (string, error)
 }
 
+// CommandChecker checks a team member's role against the command permission
+// matrix (see teams.DefaultCommandRoles).
+type CommandChecker interface {
+	CheckCommand(memberID, command string) error
+}
+
 // HandlerConfig holds configuration for creating a shared Handler.
 type HandlerConfig struct {
 	Messenger      Messenger
@@ -33,10 +44,17 @@ type HandlerConfig struct {
 	LLMClassifier  intent.Classifier
 	ConvStore      *intent.ConversationStore
 	MemberResolver MemberResolver
-	Store          *memory.Store
+	// Commands restricts chat commands by team role. Nil allows every command.
+	Commands CommandChecker
+	Store    *memory.Store
 	// TaskIDPrefix is the adapter-specific prefix for task IDs (e.g., "TG", "SLACK").
 	TaskIDPrefix string
-	Log          *slog.Logger
+	// Adapter is the source adapter recorded on executions and checked against
+	// per-adapter budget caps (e.g., "telegram", "slack").
+	Adapter string
+	// Enforcer blocks tasks over budget. Nil skips the check.
+	Enforcer *budget.Enforcer
+	Log      *slog.Logger
 }
 
 // Handler implements platform-agnostic message handling with intent dispatch,
@@ -50,8 +68,11 @@ type Handler struct {
 	llmClassifier  intent.Classifier
 	convStore      *intent.ConversationStore
 	memberResolver MemberResolver
+	commands       CommandChecker
 	store          *memory.Store
 	taskIDPrefix   string
+	adapter        string
+	enforcer       *budget.Enforcer
 	log            *slog.Logger
 
 	activeProject map[string]string       // contextID -> projectPath
@@ -89,8 +110,11 @@ func NewHandler(cfg *HandlerConfig) *Handler {
 		llmClassifier:  cfg.LLMClassifier,
 		convStore:      cfg.ConvStore,
 		memberResolver: cfg.MemberResolver,
+		commands:       cfg.Commands,
 		store:          cfg.Store,
 		taskIDPrefix:   prefix,
+		adapter:        cfg.Adapter,
+		enforcer:       cfg.Enforcer,
 		log:            lg,
 		activeProject:  make(map[string]string),
 		pendingTasks:   make(map[string]*PendingTask),
@@ -99,6 +123,12 @@ func NewHandler(cfg *HandlerConfig) *Handler {
 	}
 }
 
+// SetBudgetEnforcer sets the enforcer checked before each task, for callers
+// that create it after the handler. Call before handling messages.
+func (h *Handler) SetBudgetEnforcer(enforcer *budget.Enforcer) {
+	h.enforcer = enforcer
+}
+
 // HandleMessage is the main entry point for processing an incoming message.
 // It performs rate limiting, intent detection, and dispatches to the appropriate handler.
 func (h *Handler) HandleMessage(ctx context.Context, msg *IncomingMessage) {
@@ -119,10 +149,18 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *IncomingMessage) {
 		return
 	}
 
+	// A reply to a question a paused task asked resumes that task
+	if !msg.IsCallback && h.answerQuestion(ctx, msg) {
+		return
+	}
+
 	// Handle callback (button press) — check pending confirmation
 	if msg.IsCallback {
 		_ = h.messenger.AcknowledgeCallback(ctx, msg.CallbackID)
 		confirmed := msg.ActionID == "execute" || msg.ActionID == "confirm" || msg.ActionID == "yes"
+		if confirmed && !h.AllowCommand(ctx, contextID, msg.SenderID, teams.CommandRun) {
+			return
+		}
 		h.handleConfirmation(ctx, contextID, msg.ThreadID, confirmed)
 		return
 	}
@@ -130,6 +168,9 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *IncomingMessage) {
 	// Text-based confirmation shortcuts
 	lower := strings.ToLower(strings.TrimSpace(text))
 	if lower == "yes" || lower == "y" || lower == "confirm" || lower == "ok" {
+		if !h.AllowCommand(ctx, contextID, msg.SenderID, teams.CommandRun) {
+			return
+		}
 		h.handleConfirmation(ctx, contextID, msg.ThreadID, true)
 		return
 	}
@@ -141,6 +182,10 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *IncomingMessage) {
 	// Detect intent
 	detected := h.detectIntent(ctx, contextID, text)
 
+	if !h.AllowCommand(ctx, contextID, msg.SenderID, commandForIntent(detected, text)) {
+		return
+	}
+
 	// Record user message in conversation history
 	if h.convStore != nil {
 		h.convStore.Add(contextID, "user", TruncateText(text, 500))
@@ -160,12 +205,35 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *IncomingMessage) {
 		h.handleChat(ctx, contextID, msg.ThreadID, text)
 	case intent.IntentTask:
 		h.handleTask(ctx, contextID, msg.ThreadID, text, msg.SenderID)
+	case intent.IntentCommand:
+		h.handleCommand(ctx, contextID, msg.ThreadID, text, msg.SenderID)
 	default:
 		// Fallback: treat as task
 		h.handleTask(ctx, contextID, msg.ThreadID, text, msg.SenderID)
 	}
 }
 
+// handleCommand handles slash commands that platforms without their own
+// command routing send as plain messages.
+func (h *Handler) handleCommand(ctx context.Context, contextID, threadID, text, senderID string) {
+	fields := strings.Fields(text)
+	if strings.ToLower(fields[0]) != "/cancel" {
+		// Fallback: treat as task
+		h.handleTask(ctx, contextID, threadID, text, senderID)
+		return
+	}
+
+	if len(fields) > 1 {
+		if err := h.CancelTaskByID(ctx, contextID, fields[1]); err != nil {
+			_ = h.messenger.SendText(ctx, contextID, fmt.Sprintf("❌ %v", err))
+		}
+		return
+	}
+	if err := h.CancelTask(ctx, contextID); err != nil {
+		_ = h.messenger.SendText(ctx, contextID, "No task to cancel.")
+	}
+}
+
 // ---------- intent detection ----------
 
 func (h *Handler) detectIntent(ctx context.Context, contextID, text string) intent.Intent {
@@ -486,7 +554,10 @@ func (h *Handler) ExecuteDirectTask(ctx context.Context, contextID, threadID, ta
 		imagePath = opts.ImagePath
 	}
 
-	h.executeTaskCore(ctx, contextID, threadID, taskID, description, createPR, imagePath)
+	h.mu.Lock()
+	senderID := h.lastSender[contextID]
+	h.mu.Unlock()
+	h.executeTaskCore(ctx, contextID, threadID, senderID, taskID, description, createPR, imagePath)
 }
 
 func (h *Handler) shouldCreatePR(description string) bool {
@@ -520,18 +591,53 @@ func (h *Handler) handleConfirmation(ctx context.Context, contextID, threadID st
 		return
 	}
 
-	h.executeTask(ctx, contextID, threadID, pending.TaskID, pending.Description)
+	h.executeTask(ctx, contextID, threadID, pending.SenderID, pending.TaskID, pending.Description)
 }
 
-func (h *Handler) executeTask(ctx context.Context, contextID, threadID, taskID, description string) {
+func (h *Handler) executeTask(ctx context.Context, contextID, threadID, senderID, taskID, description string) {
 	createPR := h.shouldCreatePR(description)
-	h.executeTaskCore(ctx, contextID, threadID, taskID, description, createPR, "")
+	h.executeTaskCore(ctx, contextID, threadID, senderID, taskID, description, createPR, "")
+}
+
+func (h *Handler) executeTaskCore(ctx context.Context, contextID, threadID, senderID, taskID, description string, createPR bool, imagePath string) {
+	if !h.checkBudget(ctx, contextID, taskID) {
+		return
+	}
+	h.runTask(ctx, contextID, threadID, senderID, h.newTask(contextID, taskID, description, createPR, imagePath))
 }
 
-func (h *Handler) executeTaskCore(ctx context.Context, contextID, threadID, taskID, description string, createPR bool, imagePath string) {
+// newTask builds the executor task for a chat request
+func (h *Handler) newTask(contextID, taskID, description string, createPR bool, imagePath string) *executor.Task {
+	branch := ""
+	baseBranch := ""
+	if createPR {
+		branch = fmt.Sprintf("pilot/%s", taskID)
+		baseBranch = "main"
+	}
+
+	return &executor.Task{
+		ID:          taskID,
+		Title:       TruncateText(description, 50),
+		Description: description,
+		ProjectPath: h.getActiveProjectPath(contextID),
+		Verbose:     false,
+		Branch:      branch,
+		BaseBranch:  baseBranch,
+		CreatePR:    createPR,
+		MemberID:    h.resolveMemberID(contextID),
+		ImagePath:   imagePath,
+	}
+}
+
+// runTask executes a task and reports progress and the result to the chat.
+// senderID is the requester, the only one who may answer a question the
+// task asks.
+func (h *Handler) runTask(ctx context.Context, contextID, threadID, senderID string, task *executor.Task) {
+	taskID := task.ID
+
 	// Send starting message
 	prNote := ""
-	if !createPR {
+	if !task.CreatePR {
 		prNote = " (no PR)"
 	}
 	detail := fmt.Sprintf("🚀 Starting %s%s...", taskID, prNote)
@@ -559,29 +665,6 @@ func (h *Handler) executeTaskCore(ctx context.Context, contextID, threadID, task
 		taskCancel()
 	}()
 
-	// Build executor task
-	branch := ""
-	baseBranch := ""
-	if createPR {
-		branch = fmt.Sprintf("pilot/%s", taskID)
-		baseBranch = "main"
-	}
-
-	memberID := h.resolveMemberID(contextID)
-
-	task := &executor.Task{
-		ID:          taskID,
-		Title:       TruncateText(description, 50),
-		Description: description,
-		ProjectPath: h.getActiveProjectPath(contextID),
-		Verbose:     false,
-		Branch:      branch,
-		BaseBranch:  baseBranch,
-		CreatePR:    createPR,
-		MemberID:    memberID,
-		ImagePath:   imagePath,
-	}
-
 	// Progress callback with throttling (named callback for parallel-safe execution)
 	callbackName := fmt.Sprintf("comms-%s", taskID)
 	if msgRef != "" && h.runner != nil {
@@ -615,14 +698,25 @@ func (h *Handler) executeTaskCore(ctx context.Context, contextID, threadID, task
 	h.log.Info("Executing task",
 		slog.String("task_id", taskID),
 		slog.String("context_id", contextID))
+	start := time.Now()
 	result, err := h.runner.Execute(taskCtx, task)
 
 	// Remove named progress callback
 	if h.runner != nil {
 		h.runner.RemoveProgressCallback(callbackName)
 	}
+	h.recordExecution(task, result, err, time.Since(start))
 
 	if err != nil {
+		if h.askQuestion(ctx, contextID, threadID, senderID, task, err) {
+			return
+		}
+		var deferred *executor.DeferredError
+		if errors.As(err, &deferred) {
+			_ = h.messenger.SendText(ctx, contextID, fmt.Sprintf("⏸ %s deferred: daily spend is at %.0f%% of budget. Send it again after %s.",
+				taskID, deferred.SpendPercent, deferred.Until.Format("Jan 2 15:04")))
+			return
+		}
 		_ = h.messenger.SendResult(ctx, contextID, threadID, taskID, false, err.Error(), "")
 		return
 	}
@@ -631,6 +725,93 @@ func (h *Handler) executeTaskCore(ctx context.Context, contextID, threadID, task
 	_ = h.messenger.SendResult(ctx, contextID, threadID, taskID, result.Success, output, result.PRUrl)
 }
 
+// checkBudget reports whether the task may run, telling the user when the
+// budget or the cap of this adapter, the project or its team blocks it.
+func (h *Handler) checkBudget(ctx context.Context, contextID, taskID string) bool {
+	if h.enforcer == nil {
+		return true
+	}
+	result, err := h.enforcer.CheckProjectBudget(ctx, "", "", h.adapter, h.getActiveProjectPath(contextID))
+	if err != nil {
+		h.log.Warn("Budget check failed, allowing task (fail-open)",
+			slog.String("task_id", taskID),
+			slog.Any("error", err))
+		return true
+	}
+	if result.Allowed {
+		return true
+	}
+	h.log.Warn("Task blocked by budget enforcement",
+		slog.String("task_id", taskID),
+		slog.String("adapter", h.adapter),
+		slog.String("reason", result.Reason))
+	_ = h.messenger.SendText(ctx, contextID, fmt.Sprintf("⛔ %s not started: %s", taskID, result.Reason))
+	return false
+}
+
+// recordExecution stores a finished task with its cost and source adapter so
+// it counts toward budgets and shows in metrics.
+func (h *Handler) recordExecution(task *executor.Task, result *executor.ExecutionResult, execErr error, duration time.Duration) {
+	if h.store == nil {
+		return
+	}
+
+	completedAt := time.Now()
+	exec := &memory.Execution{
+		ID:              uuid.New().String(),
+		TaskID:          task.ID,
+		ProjectPath:     task.ProjectPath,
+		Status:          "completed",
+		DurationMs:      duration.Milliseconds(),
+		CompletedAt:     &completedAt,
+		TaskTitle:       task.Title,
+		TaskDescription: task.Description,
+		TaskBranch:      task.Branch,
+		TaskBaseBranch:  task.BaseBranch,
+		TaskCreatePR:    task.CreatePR,
+		RequestedBy:     task.MemberID,
+		SourceAdapter:   h.adapter,
+		TaskLabels:      task.Labels,
+	}
+	if result != nil {
+		exec.Output = result.Output
+		exec.Error = result.Error
+		exec.PRUrl = result.PRUrl
+		exec.CommitSHA = result.CommitSHA
+		exec.TokensInput = result.TokensInput
+		exec.TokensOutput = result.TokensOutput
+		exec.TokensTotal = result.TokensTotal
+		exec.EstimatedCostUSD = result.EstimatedCostUSD
+		exec.FilesChanged = result.FilesChanged
+		exec.LinesAdded = result.LinesAdded
+		exec.LinesRemoved = result.LinesRemoved
+		exec.ModelName = result.ModelName
+		if !result.Success {
+			exec.Status = "failed"
+		}
+	}
+	if execErr != nil {
+		exec.Status = "failed"
+		switch {
+		case errors.Is(execErr, executor.ErrTaskCancelled):
+			exec.Status = "cancelled"
+		case errors.Is(execErr, executor.ErrTaskBlocked):
+			exec.Status = "blocked"
+		case errors.Is(execErr, executor.ErrTaskNeedsInfo):
+			exec.Status = "needs_info"
+		case errors.Is(execErr, executor.ErrTaskDeferred):
+			exec.Status = "deferred"
+		}
+		exec.Error = execErr.Error()
+	}
+
+	if err := h.store.SaveExecution(exec); err != nil {
+		h.log.Warn("Failed to record execution",
+			slog.String("task_id", task.ID),
+			slog.Any("error", err))
+	}
+}
+
 // ---------- project management ----------
 
 func (h *Handler) getActiveProjectPath(contextID string) string {
@@ -693,6 +874,71 @@ func (h *Handler) resolveMemberID(contextID string) string {
 	return memberID
 }
 
+// AllowCommand reports whether the sender may run command, replying in the
+// chat when they may not. Senders who don't map to a team member, and
+// handlers without a CommandChecker, are not restricted.
+func (h *Handler) AllowCommand(ctx context.Context, contextID, senderID, command string) bool {
+	if h.commands == nil || h.memberResolver == nil || senderID == "" || command == "" {
+		return true
+	}
+
+	memberID, err := h.memberResolver.ResolveIdentity(senderID)
+	if err != nil {
+		h.log.Warn("failed to resolve identity",
+			slog.String("sender_id", senderID),
+			slog.Any("error", err))
+		return true
+	}
+	if memberID == "" {
+		return true
+	}
+
+	if err := h.commands.CheckCommand(memberID, command); err != nil {
+		h.log.Info("command denied",
+			slog.String("sender_id", senderID),
+			slog.String("command", command),
+			slog.Any("error", err))
+		_ = h.messenger.SendText(ctx, contextID, fmt.Sprintf("⛔ %v", err))
+		return false
+	}
+	return true
+}
+
+// CommandForSlash returns the permission matrix command a slash command
+// needs, or "" for commands anyone may use.
+func CommandForSlash(slash string) string {
+	switch strings.ToLower(slash) {
+	case "/start", "/help", "/status", "/queue", "/projects", "/project", "/switch",
+		"/history", "/tasks", "/list", "/brief", "/voice":
+		return teams.CommandStatus
+	case "/budget":
+		return teams.CommandBudget
+	case "/run", "/nopr", "/pr":
+		return teams.CommandRun
+	case "/cancel", "/stop":
+		return teams.CommandCancel
+	default:
+		return ""
+	}
+}
+
+// commandForIntent returns the permission matrix command a message needs
+func commandForIntent(detected intent.Intent, text string) string {
+	switch detected {
+	case intent.IntentGreeting:
+		return ""
+	case intent.IntentQuestion, intent.IntentResearch, intent.IntentPlanning, intent.IntentChat:
+		return teams.CommandAsk
+	case intent.IntentCommand:
+		if fields := strings.Fields(text); len(fields) > 0 && strings.EqualFold(fields[0], "/cancel") {
+			return teams.CommandCancel
+		}
+		return teams.CommandRun
+	default:
+		return teams.CommandRun
+	}
+}
+
 // ---------- state accessors (for CommandHandler wiring) ----------
 
 // GetPendingTask returns the pending task for a context, if any.
@@ -734,9 +980,33 @@ func (h *Handler) CancelTask(ctx context.Context, contextID string) error {
 	return fmt.Errorf("no task to cancel")
 }
 
+// CancelTaskByID aborts a running task by ID, including tasks started from
+// other chats or the issue poller. The runner kills the task's process and
+// cleans up its worktree.
+func (h *Handler) CancelTaskByID(ctx context.Context, contextID, taskID string) error {
+	if h.runner == nil {
+		return fmt.Errorf("task %s is not running", taskID)
+	}
+	if err := h.runner.Cancel(taskID); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	for id, running := range h.runningTasks {
+		if running.TaskID == taskID {
+			delete(h.runningTasks, id)
+		}
+	}
+	h.mu.Unlock()
+
+	_ = h.messenger.SendText(ctx, contextID, fmt.Sprintf("🛑 Cancelling task %s", taskID))
+	return nil
+}
+
 // ---------- cleanup ----------
 
-// CleanupLoop runs a background goroutine that removes expired pending tasks.
+// CleanupLoop runs a background goroutine that removes expired pending tasks
+// and applies the clarification timeout to unanswered questions.
 // Call with go h.CleanupLoop(ctx) and track with a WaitGroup externally.
 func (h *Handler) CleanupLoop(ctx context.Context) {
 	ticker := time.NewTicker(time.Minute)
@@ -768,4 +1038,5 @@ func (h *Handler) cleanupExpiredTasks(ctx context.Context) {
 	for _, id := range expired {
 		_ = h.messenger.SendText(ctx, id, "⏰ Pending task expired (5 min timeout). Send a new request.")
 	}
+	h.expireQuestions(ctx)
 }
@@ -3,11 +3,15 @@ package comms
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/budget"
+	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/intent"
+	"github.com/alekspetrov/pilot/internal/memory"
 )
 
 // handlerMock records all Messenger calls for assertion in handler tests.
@@ -364,6 +368,49 @@ func TestHandleTask_RateLimited(t *testing.T) {
 	}
 }
 
+func TestExecuteTask_AdapterBudgetExceeded(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	m := &handlerMock{}
+	h := NewHandler(&HandlerConfig{
+		Messenger:    m,
+		Store:        store,
+		TaskIDPrefix: "TG",
+		Adapter:      "telegram",
+	})
+	h.SetBudgetEnforcer(budget.NewEnforcer(&budget.Config{
+		Enabled:      true,
+		DailyLimit:   100,
+		MonthlyLimit: 1000,
+		OnExceed:     budget.ExceedAction{Daily: budget.ActionPause},
+		Adapters:     map[string]budget.AdapterLimit{"telegram": {DailyLimit: 5}},
+	}, store))
+
+	// A finished task is recorded under the handler's adapter
+	h.recordExecution(&executor.Task{ID: "TG-1", ProjectPath: "/repo"},
+		&executor.ExecutionResult{Success: true, EstimatedCostUSD: 5.5}, nil, time.Second)
+	now := time.Now()
+	spend, err := store.GetAdapterSpend("telegram", now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil || spend != 5.5 {
+		t.Fatalf("telegram spend = %v, %v, want 5.5", spend, err)
+	}
+
+	// Runner is nil: the task must be stopped before execution
+	h.executeTaskCore(context.Background(), "ch1", "", "", "TG-2", "add a feature", false, "")
+
+	texts := m.getTexts()
+	if len(texts) != 1 || !strings.Contains(texts[0].text, "telegram daily budget exceeded") {
+		t.Errorf("texts = %+v, want budget exceeded message", texts)
+	}
+	if len(m.progress) != 0 {
+		t.Errorf("task started despite exceeded budget: %+v", m.progress)
+	}
+}
+
 func TestHandleTask_ExistingPending(t *testing.T) {
 	m := &handlerMock{}
 	h := newTestHandler(m)
@@ -446,6 +493,73 @@ func TestResolveMemberID(t *testing.T) {
 	}
 }
 
+// hMockCommandChecker denies the listed commands
+type hMockCommandChecker struct {
+	denied  map[string]bool
+	checked []string
+}
+
+func (c *hMockCommandChecker) CheckCommand(_ string, command string) error {
+	c.checked = append(c.checked, command)
+	if c.denied[command] {
+		return fmt.Errorf("permission denied: %s requires the developer role", command)
+	}
+	return nil
+}
+
+func TestAllowCommand(t *testing.T) {
+	m := &handlerMock{}
+	checker := &hMockCommandChecker{denied: map[string]bool{"run": true, "cancel": true}}
+	h := NewHandler(&HandlerConfig{
+		Messenger:      m,
+		MemberResolver: &hMockMemberResolver{memberID: "m1"},
+		Commands:       checker,
+		TaskIDPrefix:   "TEST",
+	})
+
+	// A viewer cannot confirm a pending task
+	h.mu.Lock()
+	h.pendingTasks["ch1"] = &PendingTask{TaskID: "TEST-1", Description: "fix bug", ContextID: "ch1"}
+	h.mu.Unlock()
+	h.HandleMessage(context.Background(), &IncomingMessage{ContextID: "ch1", SenderID: "u1", Text: "yes"})
+	if h.GetPendingTask("ch1") == nil {
+		t.Error("pending task should not be consumed by a denied confirmation")
+	}
+
+	h.HandleMessage(context.Background(), &IncomingMessage{ContextID: "ch1", SenderID: "u1", Text: "/cancel"})
+	texts := m.getTexts()
+	if len(texts) != 2 || !strings.HasPrefix(texts[1].text, "⛔") {
+		t.Fatalf("expected two denials, got %+v", texts)
+	}
+	if h.GetPendingTask("ch1") == nil {
+		t.Error("denied /cancel should not cancel the pending task")
+	}
+
+	// Greetings need no permission; unknown senders are not restricted
+	h.HandleMessage(context.Background(), &IncomingMessage{ContextID: "ch1", SenderID: "u1", Text: "hello"})
+	if !h.AllowCommand(context.Background(), "ch1", "", "run") {
+		t.Error("expected sender without identity to be allowed")
+	}
+	if strings.Join(checker.checked, ",") != "run,cancel" {
+		t.Errorf("checked = %v", checker.checked)
+	}
+}
+
+func TestCommandForSlash(t *testing.T) {
+	tests := map[string]string{
+		"/status":  "status",
+		"/Budget":  "budget",
+		"/nopr":    "run",
+		"/stop":    "cancel",
+		"/unknown": "",
+	}
+	for slash, want := range tests {
+		if got := CommandForSlash(slash); got != want {
+			t.Errorf("CommandForSlash(%q) = %q, want %q", slash, got, want)
+		}
+	}
+}
+
 func TestCancelTask_Pending(t *testing.T) {
 	m := &handlerMock{}
 	h := newTestHandler(m)
@@ -481,6 +595,30 @@ func TestCancelTask_None(t *testing.T) {
 	}
 }
 
+func TestHandleMessage_CancelCommand(t *testing.T) {
+	m := &handlerMock{}
+	h := NewHandler(&HandlerConfig{Messenger: m, Runner: executor.NewRunner()})
+
+	h.HandleMessage(context.Background(), &IncomingMessage{ContextID: "ch1", Text: "/cancel GH-7"})
+	texts := m.getTexts()
+	if len(texts) != 1 || !strings.Contains(texts[0].text, "task GH-7 is not running") {
+		t.Errorf("texts = %+v, want not-running error", texts)
+	}
+
+	// Without an ID, /cancel cancels this chat's own task
+	h.mu.Lock()
+	h.pendingTasks["ch1"] = &PendingTask{TaskID: "T-1", ContextID: "ch1", CreatedAt: time.Now()}
+	h.mu.Unlock()
+	h.HandleMessage(context.Background(), &IncomingMessage{ContextID: "ch1", Text: "/cancel"})
+	if h.GetPendingTask("ch1") != nil {
+		t.Error("pending task should be removed")
+	}
+	texts = m.getTexts()
+	if len(texts) != 2 || !strings.Contains(texts[1].text, "Cancelled pending task T-1") {
+		t.Errorf("texts = %+v", texts)
+	}
+}
+
 func TestCleanupExpiredTasks(t *testing.T) {
 	m := &handlerMock{}
 	h := newTestHandler(m)
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -12,22 +13,36 @@ import (
 
 	"github.com/alekspetrov/pilot/internal/adapters/asana"
 	"github.com/alekspetrov/pilot/internal/adapters/azuredevops"
+	"github.com/alekspetrov/pilot/internal/adapters/clickup"
 	"github.com/alekspetrov/pilot/internal/adapters/discord"
+	"github.com/alekspetrov/pilot/internal/adapters/gitea"
 	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/adapters/gitlab"
 	"github.com/alekspetrov/pilot/internal/adapters/jira"
 	"github.com/alekspetrov/pilot/internal/adapters/linear"
+	"github.com/alekspetrov/pilot/internal/adapters/mattermost"
+	"github.com/alekspetrov/pilot/internal/adapters/notion"
 	"github.com/alekspetrov/pilot/internal/adapters/plane"
 	"github.com/alekspetrov/pilot/internal/adapters/slack"
 	"github.com/alekspetrov/pilot/internal/adapters/telegram"
+	"github.com/alekspetrov/pilot/internal/adapters/trello"
 	"github.com/alekspetrov/pilot/internal/alerts"
 	"github.com/alekspetrov/pilot/internal/approval"
 	"github.com/alekspetrov/pilot/internal/autopilot"
 	"github.com/alekspetrov/pilot/internal/budget"
+	"github.com/alekspetrov/pilot/internal/cluster"
+	"github.com/alekspetrov/pilot/internal/encryption"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/ha"
+	"github.com/alekspetrov/pilot/internal/hooks"
 	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/quality"
+	"github.com/alekspetrov/pilot/internal/retention"
+	"github.com/alekspetrov/pilot/internal/scheduler"
+	"github.com/alekspetrov/pilot/internal/secrets"
+	"github.com/alekspetrov/pilot/internal/teams"
 	"github.com/alekspetrov/pilot/internal/tunnel"
 	"github.com/alekspetrov/pilot/internal/webhooks"
 )
@@ -55,6 +70,40 @@ type Config struct {
 	Webhooks       *webhooks.Config        `yaml:"webhooks"`
 	TeamID         string                  `yaml:"team_id"` // Optional team ID for scoping execution
 	Team           *TeamConfig             `yaml:"team"`
+	Preflight      *PreflightConfig        `yaml:"preflight"`
+	Scheduler      *scheduler.Config       `yaml:"scheduler"`    // Recurring tasks on cron schedules
+	ScriptHooks    *hooks.Config           `yaml:"script_hooks"` // Starlark scripting hooks
+	HA             *ha.Config              `yaml:"ha"`           // Warm standby pair sharing the memory store
+	Cluster        *cluster.Config         `yaml:"cluster"`      // Worker nodes that run queued tasks
+	Encryption     *encryption.Config      `yaml:"encryption"`   // Encryption at rest for recordings and the memory store
+	Retention      *retention.Config       `yaml:"retention"`    // How long executions, recordings, transcripts and identity data are kept
+	Secrets        *secrets.Config         `yaml:"secrets"`      // Vault and AWS Secrets Manager behind vault:// and aws-sm:// values
+
+	// LeakGuard scans pushed diffs, issue comments and CI log excerpts for credentials
+	LeakGuard *quality.LeakGuardConfig `yaml:"leak_guard"`
+}
+
+// PreflightConfig controls the startup preflight report printed by `pilot start`.
+type PreflightConfig struct {
+	// FailOn selects when error findings abort startup:
+	// "never" (default, report only), "prod" (only when autopilot runs in prod), or "always".
+	FailOn string `yaml:"fail_on"`
+}
+
+// ShouldFailFast reports whether error findings should abort startup.
+// prod is true when the active autopilot environment is production.
+func (c *PreflightConfig) ShouldFailFast(prod bool) bool {
+	if c == nil {
+		return false
+	}
+	switch strings.ToLower(c.FailOn) {
+	case "always":
+		return true
+	case "prod":
+		return prod
+	default:
+		return false
+	}
 }
 
 // TeamConfig holds settings for team-based project access control (GH-635).
@@ -63,6 +112,14 @@ type TeamConfig struct {
 	Enabled     bool   `yaml:"enabled"`
 	TeamID      string `yaml:"team_id"`      // Team ID or name to scope execution
 	MemberEmail string `yaml:"member_email"` // Email of the member executing tasks
+
+	// OIDC maps members to identities at an identity provider; members must
+	// log in with `pilot team login` and IdP groups decide their roles
+	OIDC *teams.OIDCConfig `yaml:"oidc"`
+
+	// CommandRoles overrides the minimum role for chat commands and approvals
+	// (see teams.DefaultCommandRoles), e.g. {run: admin}
+	CommandRoles map[string]teams.Role `yaml:"command_roles"`
 }
 
 // AdaptersConfig holds configuration for external service adapters.
@@ -78,6 +135,11 @@ type AdaptersConfig struct {
 	Asana       *asana.Config       `yaml:"asana"`
 	Plane       *plane.Config       `yaml:"plane"`
 	Discord     *discord.Config     `yaml:"discord"`
+	Mattermost  *mattermost.Config  `yaml:"mattermost"`
+	Notion      *notion.Config      `yaml:"notion"`
+	Trello      *trello.Config      `yaml:"trello"`
+	ClickUp     *clickup.Config     `yaml:"clickup"`
+	Gitea       *gitea.Config       `yaml:"gitea"`
 }
 
 // OrchestratorConfig holds settings for the task orchestrator including
@@ -88,6 +150,9 @@ type OrchestratorConfig struct {
 	DailyBrief    *DailyBriefConfig `yaml:"daily_brief"`
 	Execution     *ExecutionConfig  `yaml:"execution"`
 	Autopilot     *autopilot.Config `yaml:"autopilot"`
+
+	// Fairness schedules queued tasks fairly across projects and requesters
+	Fairness *executor.FairnessConfig `yaml:"fairness"`
 }
 
 // ExecutionConfig holds settings for task execution mode.
@@ -121,6 +186,7 @@ type DailyBriefConfig struct {
 	Channels []BriefChannelConfig `yaml:"channels"`
 	Content  BriefContentConfig   `yaml:"content"`
 	Filters  BriefFilterConfig    `yaml:"filters"`
+	Digest   *BriefDigestConfig   `yaml:"digest"`
 }
 
 // BriefChannelConfig defines a delivery channel for daily briefs (Slack or email).
@@ -142,6 +208,15 @@ type BriefFilterConfig struct {
 	Projects []string `yaml:"projects"` // Empty = all projects
 }
 
+// BriefDigestConfig configures opt-in personal digests sent to each team member
+// mapped to Telegram or Slack, separate from the global brief.
+type BriefDigestConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	Schedule  string   `yaml:"schedule"`   // Cron syntax, defaults to the brief schedule
+	Members   []string `yaml:"members"`    // Emails or GitHub usernames; empty = all mapped members
+	SkipEmpty bool     `yaml:"skip_empty"` // Don't send digests with nothing to report
+}
+
 // LearningConfig holds settings for the pattern learning system.
 type LearningConfig struct {
 	Enabled       bool    `yaml:"enabled"`        // Enable learning system (default: true)
@@ -165,6 +240,13 @@ type MemoryConfig struct {
 	Path         string          `yaml:"path"`
 	CrossProject bool            `yaml:"cross_project"`
 	Learning     *LearningConfig `yaml:"learning"`
+	Driver       string          `yaml:"driver"` // "sqlite" (default) or "postgres"
+	DSN          string          `yaml:"dsn"`    // PostgreSQL connection string, e.g. postgres://pilot@db/pilot
+}
+
+// Backend returns the database backend the memory store opens
+func (m *MemoryConfig) Backend() memory.BackendConfig {
+	return memory.BackendConfig{Driver: m.Driver, DSN: m.DSN}
 }
 
 // ProjectConfig holds configuration for a registered project.
@@ -177,6 +259,31 @@ type ProjectConfig struct {
 	TeamReviewers []string             `yaml:"team_reviewers,omitempty"`
 	GitHub        *ProjectGitHubConfig `yaml:"github,omitempty"`
 	Linear        *ProjectLinearConfig `yaml:"linear,omitempty"`
+
+	// VersionFiles are bumped and committed by the releaser before tagging,
+	// overriding the autopilot release config's version_files.
+	VersionFiles []autopilot.VersionFile `yaml:"version_files,omitempty"`
+
+	// CIProvider selects where this project's CI runs, overriding the
+	// autopilot config's ci_provider.
+	CIProvider *autopilot.CIProviderConfig `yaml:"ci_provider,omitempty"`
+
+	// RequireApprovalPaths lists path globs whose changes wait for human
+	// approval before autopilot merges, added to the autopilot config's.
+	RequireApprovalPaths []string `yaml:"require_approval_paths,omitempty"`
+
+	// Scope lists the directories (or path globs) of a monorepo this project
+	// owns when several projects share a repository. Issues mentioning them
+	// route here and tasks may only change files in scope.
+	Scope []string `yaml:"scope,omitempty"`
+
+	// Labels route issues carrying any of them to this project when several
+	// projects share a repository.
+	Labels []string `yaml:"labels,omitempty"`
+
+	// Features restricts Pilot capabilities for this project. The same keys
+	// can be set by repo owners in a .pilot.yaml at the repository root.
+	Features executor.ProjectFeatures `yaml:",inline"`
 }
 
 // ProjectGitHubConfig holds GitHub-specific project configuration for PR creation and issue tracking.
@@ -216,6 +323,9 @@ type AlertsConfig struct {
 	Channels []AlertChannelConfig `yaml:"channels"`
 	Rules    []AlertRuleConfig    `yaml:"rules"`
 	Defaults AlertDefaultsConfig  `yaml:"defaults"`
+
+	// Members assigns quiet hours to team members; channels opt in via member
+	Members []alerts.MemberQuietHours `yaml:"members,omitempty"`
 }
 
 // AlertChannelConfig configures a destination channel for alerts.
@@ -227,6 +337,11 @@ type AlertChannelConfig struct {
 	Enabled    bool     `yaml:"enabled"`
 	Severities []string `yaml:"severities"` // Which severities to receive
 
+	// Quiet hours hold non-critical alerts and deliver them as a digest
+	// afterward. Without its own setting a channel inherits Member's.
+	Member     string                   `yaml:"member,omitempty"`
+	QuietHours *alerts.QuietHoursConfig `yaml:"quiet_hours,omitempty"`
+
 	// Channel-specific config (types from alerts package)
 	Slack     *alerts.SlackChannelConfig     `yaml:"slack,omitempty"`
 	Telegram  *alerts.TelegramChannelConfig  `yaml:"telegram,omitempty"`
@@ -257,6 +372,8 @@ type AlertConditionConfig struct {
 	Pattern              string        `yaml:"pattern"`
 	FilePattern          string        `yaml:"file_pattern"`
 	Paths                []string      `yaml:"paths"`
+	RepeatedStalls       int           `yaml:"repeated_stalls"`
+	StallWindow          time.Duration `yaml:"stall_window"`
 }
 
 // AlertDefaultsConfig contains default settings applied to all alert rules.
@@ -291,6 +408,11 @@ func DefaultConfig() *Config {
 			Asana:       asana.DefaultConfig(),
 			Plane:       plane.DefaultConfig(),
 			Discord:     discord.DefaultConfig(),
+			Mattermost:  mattermost.DefaultConfig(),
+			Notion:      notion.DefaultConfig(),
+			Trello:      trello.DefaultConfig(),
+			ClickUp:     clickup.DefaultConfig(),
+			Gitea:       gitea.DefaultConfig(),
 		},
 		Orchestrator: &OrchestratorConfig{
 			Model:         "claude-sonnet-4-6",
@@ -403,11 +525,93 @@ func defaultAlertRules() []AlertRuleConfig {
 			Cooldown:    4 * time.Hour,
 			Description: "Alert when budget limit is exceeded",
 		},
+		{
+			Name:    "repeated_stalls",
+			Type:    "heartbeat_timeout",
+			Enabled: true,
+			Condition: AlertConditionConfig{
+				RepeatedStalls: 3,
+				StallWindow:    1 * time.Hour,
+			},
+			Severity:    "warning",
+			Channels:    []string{},
+			Cooldown:    1 * time.Hour,
+			Description: "Alert when the backend stalls 3 or more times within an hour",
+		},
+		{
+			Name:        "task_blocked",
+			Type:        "task_blocked",
+			Enabled:     true,
+			Severity:    "warning",
+			Channels:    []string{},
+			Cooldown:    15 * time.Minute,
+			Description: "Alert when the agent signals it is blocked",
+		},
+		{
+			Name:        "budget_routing",
+			Type:        "budget_routing",
+			Enabled:     true,
+			Severity:    "info",
+			Channels:    []string{},
+			Cooldown:    1 * time.Hour,
+			Description: "Alert when budget pressure routes a task to a cheaper model or defers it",
+		},
+		{
+			Name:        "budget_forecast",
+			Type:        "budget_forecast",
+			Enabled:     true,
+			Severity:    "warning",
+			Channels:    []string{},
+			Cooldown:    24 * time.Hour,
+			Description: "Alert when projected month-end spend exceeds the monthly budget",
+		},
+		{
+			Name:        "ha_takeover",
+			Type:        "ha_takeover",
+			Enabled:     true,
+			Severity:    "critical",
+			Channels:    []string{},
+			Description: "Alert when a standby takes over as HA primary or the primary loses its lease",
+		},
+		{
+			Name:        "post_merge_rollback",
+			Type:        "post_merge_failure",
+			Enabled:     true,
+			Severity:    "critical",
+			Channels:    []string{},
+			Description: "Alert when CI breaks on main after an autopilot merge and a revert PR is opened",
+		},
+		{
+			Name:        "conflict_unresolved",
+			Type:        "conflict_unresolved",
+			Enabled:     true,
+			Severity:    "warning",
+			Channels:    []string{},
+			Description: "Alert when autopilot cannot resolve merge conflicts on a PR and closes it",
+		},
+		{
+			Name:        "merge_frozen",
+			Type:        "merge_frozen",
+			Enabled:     true,
+			Severity:    "info",
+			Channels:    []string{},
+			Description: "Alert when a PR ready to merge is held by a merge freeze",
+		},
+		{
+			Name:        "linked_merge_incomplete",
+			Type:        "linked_merge_incomplete",
+			Enabled:     true,
+			Severity:    "critical",
+			Channels:    []string{},
+			Description: "Alert when a linked PR fails after other PRs of its multi-repo change merged",
+		},
 	}
 }
 
 // Load reads and parses configuration from a YAML file at the given path.
-// Environment variables in the file are expanded using os.ExpandEnv syntax.
+// Environment variables in the file are expanded using os.ExpandEnv syntax,
+// ${env:…}, ${keychain:…} and ${secret:…} references are resolved through
+// the secrets package, and so are vault:// and aws-sm:// values.
 // If the file does not exist, default configuration is returned.
 // Returns an error if the file cannot be read or parsed.
 func Load(path string) (*Config, error) {
@@ -421,10 +625,27 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
-	// Expand environment variables
-	expanded := os.ExpandEnv(string(data))
+	// Expand environment variables and secret references
+	expanded, err := secrets.NewResolver().Expand(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config secrets: %w", err)
+	}
 
-	if err := yaml.Unmarshal([]byte(expanded), config); err != nil {
+	// Resolve vault:// and aws-sm:// values through the providers the
+	// secrets section configures
+	var providers struct {
+		Secrets *secrets.Config `yaml:"secrets"`
+	}
+	if err := yaml.Unmarshal([]byte(expanded), &providers); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	secrets.DefaultProviders().Configure(providers.Secrets)
+	resolved, err := secrets.DefaultProviders().ResolveConfig(context.Background(), []byte(expanded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config secrets: %w", err)
+	}
+
+	if err := yaml.Unmarshal(resolved, config); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
@@ -435,6 +656,11 @@ func Load(path string) (*Config, error) {
 	for _, project := range config.Projects {
 		project.Path = expandPath(project.Path)
 	}
+	if config.ScriptHooks != nil {
+		for i, script := range config.ScriptHooks.Scripts {
+			config.ScriptHooks.Scripts[i] = expandPath(script)
+		}
+	}
 
 	// Log deprecation warnings
 	config.CheckDeprecations()
@@ -515,6 +741,9 @@ func (c *Config) Validate() error {
 	if c.Gateway.Port < 1 || c.Gateway.Port > 65535 {
 		return fmt.Errorf("invalid gateway port: %d", c.Gateway.Port)
 	}
+	if c.Gateway.GRPCPort < 0 || c.Gateway.GRPCPort > 65535 || (c.Gateway.GRPCPort != 0 && c.Gateway.GRPCPort == c.Gateway.Port) {
+		return fmt.Errorf("invalid gateway grpc_port: %d", c.Gateway.GRPCPort)
+	}
 	if c.Auth != nil && c.Auth.Type == gateway.AuthTypeAPIToken && c.Auth.Token == "" {
 		return fmt.Errorf("API token is required when auth type is api-token")
 	}
@@ -535,6 +764,34 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Executor != nil {
+		if c.Executor.Type != "" && !executor.IsRegisteredBackend(c.Executor.Type) {
+			return fmt.Errorf("invalid executor.type: %q (must be one of %s)", c.Executor.Type, strings.Join(executor.RegisteredBackends(), ", "))
+		}
+		for _, name := range c.Executor.AllowedBackends {
+			if !executor.IsRegisteredBackend(name) {
+				return fmt.Errorf("invalid executor.allowed_backends entry: %q (must be one of %s)", name, strings.Join(executor.RegisteredBackends(), ", "))
+			}
+		}
+		if fb := c.Executor.FallbackBackend; fb != "" && !executor.IsRegisteredBackend(fb) {
+			return fmt.Errorf("invalid executor.fallback_backend: %q (must be one of %s)", fb, strings.Join(executor.RegisteredBackends(), ", "))
+		}
+	}
+
+	if c.Adapters != nil && c.Adapters.GitHub != nil && c.Adapters.GitHub.Enabled {
+		if err := c.Adapters.GitHub.Validate(); err != nil {
+			return fmt.Errorf("adapters.github: %w", err)
+		}
+	}
+
+	if c.Preflight != nil {
+		switch strings.ToLower(c.Preflight.FailOn) {
+		case "", "never", "prod", "always":
+		default:
+			return fmt.Errorf("invalid preflight.fail_on: %q (must be never, prod, or always)", c.Preflight.FailOn)
+		}
+	}
+
 	// Validate default project exists if specified
 	if c.DefaultProject != "" && len(c.Projects) > 0 {
 		found := false
@@ -549,6 +806,179 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, p := range c.Projects {
+		if p.Features.MaxPRSize < 0 {
+			return fmt.Errorf("projects.%s.max_pr_size must be >= 0, got %d", p.Name, p.Features.MaxPRSize)
+		}
+		for _, f := range p.VersionFiles {
+			if err := f.Validate(); err != nil {
+				return fmt.Errorf("projects.%s.version_files: %w", p.Name, err)
+			}
+		}
+		if p.CIProvider != nil {
+			if err := p.CIProvider.Validate(); err != nil {
+				return fmt.Errorf("projects.%s.ci_provider: %w", p.Name, err)
+			}
+		}
+		if err := autopilot.ValidateApprovalPaths(p.RequireApprovalPaths); err != nil {
+			return fmt.Errorf("projects.%s.require_approval_paths: %w", p.Name, err)
+		}
+		if err := validateScope(p.Scope); err != nil {
+			return fmt.Errorf("projects.%s.scope: %w", p.Name, err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.CIProvider != nil {
+		if err := c.Orchestrator.Autopilot.CIProvider.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.ci_provider: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.Release != nil {
+		if err := c.Orchestrator.Autopilot.Release.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.release: %w", err)
+		}
+		for _, f := range c.Orchestrator.Autopilot.Release.VersionFiles {
+			if err := f.Validate(); err != nil {
+				return fmt.Errorf("orchestrator.autopilot.release.version_files: %w", err)
+			}
+		}
+		if promotion := c.Orchestrator.Autopilot.Release.Promotion; promotion != nil && promotion.Enabled {
+			if err := promotion.Validate(); err != nil {
+				return fmt.Errorf("orchestrator.autopilot.release.promotion: %w", err)
+			}
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.Rollback != nil {
+		if err := c.Orchestrator.Autopilot.Rollback.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.rollback: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.Canary != nil {
+		if err := c.Orchestrator.Autopilot.Canary.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.canary: %w", err)
+		}
+		if c.Orchestrator.Autopilot.Canary.Enabled {
+			for name, env := range c.Orchestrator.Autopilot.Environments {
+				if env != nil && env.MergeQueue {
+					return fmt.Errorf("orchestrator.autopilot.environments.%s: merge_queue cannot be combined with canary merges", name)
+				}
+			}
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil {
+		if err := c.Orchestrator.Autopilot.ValidatePipeline(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.pipeline: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.ConflictResolution != nil {
+		if err := c.Orchestrator.Autopilot.ConflictResolution.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.conflict_resolution: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.CodeReview != nil {
+		if err := c.Orchestrator.Autopilot.CodeReview.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.code_review: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.Freeze != nil {
+		if err := c.Orchestrator.Autopilot.Freeze.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.freeze: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil && c.Orchestrator.Autopilot.MergePolicy != nil {
+		if err := c.Orchestrator.Autopilot.MergePolicy.Validate(); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.merge_policy: %w", err)
+		}
+	}
+
+	if c.Orchestrator != nil && c.Orchestrator.Autopilot != nil {
+		if err := autopilot.ValidateApprovalPaths(c.Orchestrator.Autopilot.RequireApprovalPaths); err != nil {
+			return fmt.Errorf("orchestrator.autopilot.require_approval_paths: %w", err)
+		}
+	}
+
+	if c.Retention != nil {
+		if err := c.Retention.Validate(); err != nil {
+			return fmt.Errorf("retention: %w", err)
+		}
+	}
+
+	if c.Memory != nil {
+		if err := c.Memory.Backend().Validate(); err != nil {
+			return fmt.Errorf("memory: %w", err)
+		}
+	}
+
+	if c.Encryption != nil {
+		if err := c.Encryption.Validate(); err != nil {
+			return fmt.Errorf("encryption: %w", err)
+		}
+	}
+
+	if c.ScriptHooks != nil && c.ScriptHooks.Enabled {
+		if err := c.ScriptHooks.Validate(); err != nil {
+			return fmt.Errorf("script_hooks: %w", err)
+		}
+	}
+
+	if c.HA != nil && c.HA.Enabled {
+		if err := c.HA.Validate(); err != nil {
+			return fmt.Errorf("ha: %w", err)
+		}
+	}
+
+	if c.Cluster != nil && c.Cluster.Enabled {
+		if err := c.Cluster.Validate(); err != nil {
+			return fmt.Errorf("cluster: %w", err)
+		}
+	}
+
+	if c.Secrets != nil {
+		if err := c.Secrets.Validate(); err != nil {
+			return fmt.Errorf("secrets: %w", err)
+		}
+	}
+
+	if c.Scheduler != nil {
+		if c.Scheduler.Enabled {
+			if err := c.Scheduler.Validate(); err != nil {
+				return fmt.Errorf("scheduler: %w", err)
+			}
+		}
+		// Jobs only run in configured projects; an unknown name is never
+		// treated as a path, even while the scheduler is disabled.
+		for _, job := range c.Scheduler.Jobs {
+			if job.Project != "" && c.GetProjectByName(job.Project) == nil && c.GetProject(job.Project) == nil {
+				return fmt.Errorf("scheduler: job %s: project %q not found in projects list", job.Name, job.Project)
+			}
+		}
+	}
+
+	if c.Alerts != nil {
+		for _, ch := range c.Alerts.Channels {
+			if ch.QuietHours != nil && ch.QuietHours.Enabled {
+				if err := ch.QuietHours.Validate(); err != nil {
+					return fmt.Errorf("alerts.channels.%s.quiet_hours: %w", ch.Name, err)
+				}
+			}
+		}
+		for _, m := range c.Alerts.Members {
+			if m.QuietHours != nil && m.QuietHours.Enabled {
+				if err := m.QuietHours.Validate(); err != nil {
+					return fmt.Errorf("alerts.members.%s.quiet_hours: %w", m.Email, err)
+				}
+			}
+		}
+	}
+
 	// GH-1124: Validate bounds and orchestrator configuration
 	if c.Orchestrator != nil {
 		// Validate max_concurrent >= 1
@@ -565,15 +995,70 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Orchestrator != nil && c.Orchestrator.Fairness != nil {
+		if err := c.Orchestrator.Fairness.Validate(); err != nil {
+			return fmt.Errorf("invalid orchestrator.fairness config: %w", err)
+		}
+	}
+
+	if c.Executor != nil && c.Executor.Concurrency != nil {
+		if err := c.Executor.Concurrency.Validate(); err != nil {
+			return fmt.Errorf("invalid executor.concurrency config: %w", err)
+		}
+	}
+
+	if c.Executor != nil && c.Executor.Policy != nil {
+		if err := c.Executor.Policy.Validate(); err != nil {
+			return fmt.Errorf("invalid executor.policy config: %w", err)
+		}
+	}
+
 	// Validate quality on_failure max_retries in [0, 10]
 	if c.Quality != nil && (c.Quality.OnFailure.MaxRetries < 0 || c.Quality.OnFailure.MaxRetries > 10) {
 		return fmt.Errorf("quality.on_failure.max_retries must be in range [0, 10], got %d", c.Quality.OnFailure.MaxRetries)
 	}
 
+	// Validate gate definitions, including matrix cells, when gates are enabled
+	if c.Quality != nil && c.Quality.Enabled {
+		if err := c.Quality.Validate(); err != nil {
+			return fmt.Errorf("invalid quality config: %w", err)
+		}
+	}
+
+	if c.Approval != nil {
+		if err := c.Approval.Validate(); err != nil {
+			return fmt.Errorf("invalid approval config: %w", err)
+		}
+	}
+
+	if c.Team != nil && c.Team.OIDC != nil {
+		if err := c.Team.OIDC.Validate(); err != nil {
+			return fmt.Errorf("invalid team.oidc config: %w", err)
+		}
+	}
+	if c.Team != nil {
+		if err := teams.ValidateCommandRoles(c.Team.CommandRoles); err != nil {
+			return fmt.Errorf("invalid team.command_roles config: %w", err)
+		}
+	}
+
+	if c.LeakGuard != nil {
+		if err := c.LeakGuard.Validate(); err != nil {
+			return fmt.Errorf("invalid leak_guard config: %w", err)
+		}
+	}
+
 	// Validate budget daily_limit > 0 when budget is enabled
 	if c.Budget != nil && c.Budget.Enabled && c.Budget.DailyLimit <= 0 {
 		return fmt.Errorf("budget.daily_limit must be > 0 when budget is enabled, got %g", c.Budget.DailyLimit)
 	}
+	if c.Budget != nil {
+		for adapter, limit := range c.Budget.Adapters {
+			if limit.DailyLimit < 0 || limit.MonthlyLimit < 0 {
+				return fmt.Errorf("budget.adapters.%s limits must be >= 0", adapter)
+			}
+		}
+	}
 
 	return nil
 }
@@ -3,10 +3,14 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/autopilot"
 	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/quality"
+	"github.com/alekspetrov/pilot/internal/scheduler"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -305,6 +309,33 @@ adapters:
 		}
 	})
 
+	t.Run("SecretReferences", func(t *testing.T) {
+		t.Setenv("TEST_LINEAR_TOKEN", "env-token")
+		t.Setenv("PILOT_SECRETS_FILE", filepath.Join(t.TempDir(), "missing.enc"))
+
+		tmpDir := t.TempDir()
+		configPath := filepath.Join(tmpDir, "config.yaml")
+
+		if err := os.WriteFile(configPath, []byte("adapters:\n  linear:\n    api_key: \"${env:TEST_LINEAR_TOKEN}\"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		config, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if config.Adapters.Linear.APIKey != "env-token" {
+			t.Errorf("Linear.APIKey = %q, want %q", config.Adapters.Linear.APIKey, "env-token")
+		}
+
+		if err := os.WriteFile(configPath, []byte("adapters:\n  linear:\n    api_key: \"${secret:missing}\"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		t.Setenv("PILOT_SECRETS_PASSPHRASE", "test")
+		if _, err := Load(configPath); err == nil {
+			t.Error("Load should fail for a secret that does not exist")
+		}
+	})
+
 	t.Run("PathExpansionTilde", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		configPath := filepath.Join(tmpDir, "config.yaml")
@@ -492,6 +523,16 @@ func TestValidate(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid gateway port",
 		},
+		{
+			name: "GRPCPortSameAsHTTP",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Gateway.GRPCPort = c.Gateway.Port
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "invalid gateway grpc_port",
+		},
 		{
 			name: "InvalidPortTooHigh",
 			config: func() *Config {
@@ -520,6 +561,82 @@ func TestValidate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "InvalidPreflightFailOn",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Preflight = &PreflightConfig{FailOn: "sometimes"}
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "invalid preflight.fail_on",
+		},
+		{
+			name: "ValidPreflightFailOn",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Preflight = &PreflightConfig{FailOn: "prod"}
+				return c
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "SchedulerInvalidCron",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Scheduler = &scheduler.Config{Enabled: true, Jobs: []scheduler.JobConfig{
+					{Name: "deps", Schedule: "weekly", Description: "Update dependencies"},
+				}}
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "scheduler: job deps: invalid schedule",
+		},
+		{
+			name: "SchedulerUnknownProject",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Scheduler = &scheduler.Config{Enabled: true, Jobs: []scheduler.JobConfig{
+					{Name: "deps", Schedule: "0 3 * * 1", Project: "missing", Description: "Update dependencies"},
+				}}
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "project \"missing\" not found",
+		},
+		{
+			name: "SchedulerDisabledUnknownProject",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Scheduler = &scheduler.Config{Jobs: []scheduler.JobConfig{
+					{Name: "deps", Schedule: "0 3 * * 1", Project: "missing", Description: "Update dependencies"},
+				}}
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "project \"missing\" not found",
+		},
+		{
+			name: "UnknownFallbackBackend",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Executor.FallbackBackend = "nope"
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "invalid executor.fallback_backend",
+		},
+		{
+			name: "QualityMatrixCellWithoutName",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Quality.Enabled = true
+				c.Quality.Gates[1].Matrix = []quality.MatrixCell{{Command: "go1.22.0 test ./..."}}
+				return c
+			}(),
+			wantErr:     true,
+			errContains: "invalid quality config",
+		},
 		{
 			name: "APITokenAuthWithoutToken",
 			config: func() *Config {
@@ -1190,6 +1307,79 @@ orchestrator:
 	}
 }
 
+func TestLoadProjectFeatures(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+version: "1.0"
+projects:
+  - name: api
+    path: /tmp/api
+    allow_decompose: false
+    allow_release: false
+    max_pr_size: 300
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	features := cfg.Projects[0].Features
+	if features.DecomposeAllowed() || features.ReleaseAllowed() {
+		t.Errorf("features = %+v, want decompose and release disabled", features)
+	}
+	if !features.DirectCommitAllowed() || !features.EpicsAllowed() {
+		t.Error("unset flags should default to allowed")
+	}
+	if features.PRSizeLimit() != 300 {
+		t.Errorf("PRSizeLimit() = %d, want 300", features.PRSizeLimit())
+	}
+
+	cfg.Projects[0].Features.MaxPRSize = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "max_pr_size") {
+		t.Errorf("Validate() = %v, want max_pr_size error", err)
+	}
+}
+
+func TestLoadProjectVersionFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+version: "1.0"
+projects:
+  - name: web
+    path: /tmp/web
+    version_files:
+      - path: package.json
+      - path: deploy/chart/Chart.yaml
+      - path: VERSION
+        pattern: '^(\S+)'
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	files := cfg.Projects[0].VersionFiles
+	if len(files) != 3 || files[1].Path != "deploy/chart/Chart.yaml" || files[2].Pattern != `^(\S+)` {
+		t.Fatalf("VersionFiles = %+v", files)
+	}
+
+	cfg.Projects[0].VersionFiles = append(files, autopilot.VersionFile{Path: "VERSION.txt"})
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "projects.web.version_files") {
+		t.Errorf("Validate() = %v, want version_files error", err)
+	}
+}
+
 func TestLoadTeamConfig(t *testing.T) {
 	t.Run("team config from YAML", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -1379,3 +1569,26 @@ projects:
 		t.Errorf("TeamReviewers = %v, want [backend-team]", proj.TeamReviewers)
 	}
 }
+
+func TestPreflightConfig_ShouldFailFast(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *PreflightConfig
+		prod   bool
+		want   bool
+	}{
+		{"nil config", nil, true, false},
+		{"never", &PreflightConfig{FailOn: "never"}, true, false},
+		{"prod outside prod", &PreflightConfig{FailOn: "prod"}, false, false},
+		{"prod in prod", &PreflightConfig{FailOn: "prod"}, true, true},
+		{"always", &PreflightConfig{FailOn: "always"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ShouldFailFast(tt.prod); got != tt.want {
+				t.Errorf("ShouldFailFast(%v) = %v, want %v", tt.prod, got, tt.want)
+			}
+		})
+	}
+}
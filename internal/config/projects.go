@@ -1,6 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/autopilot"
 	"github.com/alekspetrov/pilot/internal/comms"
 )
 
@@ -67,3 +72,105 @@ func (a *ProjectSourceAdapter) toProjectInfo(proj *ProjectConfig) *comms.Project
 		DefaultBranch: proj.DefaultBranch,
 	}
 }
+
+// RouteProject returns the project an issue from ownerRepo belongs to. When
+// several projects share the repository, as teams owning directories of a
+// monorepo do, the first project whose labels the issue carries wins, then
+// the project whose scope the title or body mentions most, then the project
+// without a scope, then the first one. Returns nil when no project is
+// configured for the repository.
+func (c *Config) RouteProject(ownerRepo string, labels []string, text string) *ProjectConfig {
+	var candidates []*ProjectConfig
+	for _, p := range c.Projects {
+		if p.GitHub != nil && strings.EqualFold(p.GitHub.Owner+"/"+p.GitHub.Repo, ownerRepo) {
+			candidates = append(candidates, p)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil
+	case 1:
+		return candidates[0]
+	}
+
+	for _, p := range candidates {
+		for _, want := range p.Labels {
+			for _, label := range labels {
+				if strings.EqualFold(want, label) {
+					return p
+				}
+			}
+		}
+	}
+
+	var best *ProjectConfig
+	bestMentions := 0
+	for _, p := range candidates {
+		mentions := 0
+		for _, entry := range p.Scope {
+			if mentionsPath(text, scopeDir(entry)) {
+				mentions++
+			}
+		}
+		if mentions > bestMentions {
+			best, bestMentions = p, mentions
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	for _, p := range candidates {
+		if len(p.Scope) == 0 {
+			return p
+		}
+	}
+	return candidates[0]
+}
+
+// scopeDir returns the literal directory a scope entry names, without a
+// trailing slash or glob: "libs/money/*.go" gives "libs/money"
+func scopeDir(entry string) string {
+	entry = strings.TrimPrefix(entry, "./")
+	if i := strings.IndexAny(entry, "*?["); i >= 0 {
+		entry = path.Dir(entry[:i] + "x")
+	}
+	return strings.TrimSuffix(entry, "/")
+}
+
+// mentionsPath reports whether text mentions dir as a path, e.g.
+// "services/billing" in "fix services/billing/invoice.go" but not in
+// "services/billing-v2"
+func mentionsPath(text, dir string) bool {
+	if dir == "" || dir == "." {
+		return false
+	}
+	for i := 0; ; {
+		j := strings.Index(text[i:], dir)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(dir)
+		if (start == 0 || !isPathChar(text[start-1])) && (end == len(text) || !isPathChar(text[end]) || text[end] == '/') {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// isPathChar reports whether b can be part of a path segment
+func isPathChar(b byte) bool {
+	return b == '/' || b == '.' || b == '-' || b == '_' ||
+		(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// validateScope checks a project's scope entries: relative path globs that
+// stay inside the repository
+func validateScope(scope []string) error {
+	for _, entry := range scope {
+		if strings.HasPrefix(entry, "/") || strings.HasPrefix(entry, "../") || strings.Contains(entry, "/../") {
+			return fmt.Errorf("%q must be a path inside the repository", entry)
+		}
+	}
+	return autopilot.ValidateApprovalPaths(scope)
+}
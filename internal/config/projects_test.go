@@ -0,0 +1,81 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouteProject(t *testing.T) {
+	monorepo := &ProjectGitHubConfig{Owner: "acme", Repo: "monorepo"}
+	cfg := &Config{
+		Projects: []*ProjectConfig{
+			{Name: "platform", Path: "/src/monorepo", GitHub: monorepo},
+			{Name: "billing", Path: "/src/monorepo", GitHub: monorepo, Scope: []string{"services/billing/", "libs/money/*.go"}, Labels: []string{"team:billing"}},
+			{Name: "search", Path: "/src/monorepo", GitHub: monorepo, Scope: []string{"services/search/"}, Labels: []string{"team:search"}},
+			{Name: "web", Path: "/src/web", GitHub: &ProjectGitHubConfig{Owner: "acme", Repo: "web"}, Scope: []string{"app/"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		repo   string
+		labels []string
+		text   string
+		want   string
+	}{
+		{"label wins over mentions", "acme/monorepo", []string{"pilot", "Team:Search"}, "Fix services/billing/invoice.go", "search"},
+		{"path mention", "acme/monorepo", []string{"pilot"}, "Rounding bug in `services/billing/invoice.go`", "billing"},
+		{"glob scope mention", "acme/monorepo", nil, "libs/money/round.go drops cents", "billing"},
+		{"most mentions", "acme/monorepo", nil, "services/search/query.go calls services/billing/api.go and libs/money", "billing"},
+		{"prefix of another dir is no mention", "acme/monorepo", nil, "Update services/billing-v2/README", "platform"},
+		{"no match falls back to unscoped project", "acme/monorepo", nil, "Bump Go version", "platform"},
+		{"single project for repo", "acme/web", nil, "Anything", "web"},
+		{"repo match is case-insensitive", "Acme/Web", nil, "", "web"},
+		{"unknown repo", "acme/other", nil, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.RouteProject(tt.repo, tt.labels, tt.text)
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("RouteProject() = %q, want %q", name, tt.want)
+			}
+		})
+	}
+
+	// Without an unscoped project the first one is the fallback
+	cfg.Projects = cfg.Projects[1:]
+	if got := cfg.RouteProject("acme/monorepo", nil, "Bump Go version"); got == nil || got.Name != "billing" {
+		t.Errorf("RouteProject() fallback = %v, want billing", got)
+	}
+}
+
+func TestValidateScope(t *testing.T) {
+	tests := []struct {
+		scope   []string
+		wantErr string
+	}{
+		{[]string{"services/billing/", "libs/**/*.go"}, ""},
+		{[]string{"/etc/"}, "inside the repository"},
+		{[]string{"../other/"}, "inside the repository"},
+		{[]string{"services/../../x"}, "inside the repository"},
+		{[]string{""}, "empty path pattern"},
+		{[]string{"services/[billing"}, "invalid path pattern"},
+	}
+	for _, tt := range tests {
+		err := validateScope(tt.scope)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateScope(%v) error = %v", tt.scope, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateScope(%v) error = %v, want %q", tt.scope, err, tt.wantErr)
+		}
+	}
+}
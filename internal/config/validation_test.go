@@ -4,10 +4,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alekspetrov/pilot/internal/autopilot"
 	"github.com/alekspetrov/pilot/internal/budget"
+	"github.com/alekspetrov/pilot/internal/cluster"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/gateway"
 	"github.com/alekspetrov/pilot/internal/quality"
+	"github.com/alekspetrov/pilot/internal/secrets"
 )
 
 // baseValidConfig returns a minimal valid config for testing
@@ -456,6 +459,25 @@ func TestConfig_Validate_BudgetBounds(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "adapter caps are valid",
+			budget: &budget.Config{
+				Enabled:    true,
+				DailyLimit: 50.0,
+				Adapters:   map[string]budget.AdapterLimit{"telegram": {DailyLimit: 5}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative adapter cap is invalid",
+			budget: &budget.Config{
+				Enabled:    true,
+				DailyLimit: 50.0,
+				Adapters:   map[string]budget.AdapterLimit{"telegram": {MonthlyLimit: -1}},
+			},
+			wantErr:   true,
+			errSubstr: "budget.adapters.telegram limits must be >= 0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -478,3 +500,88 @@ func TestConfig_Validate_BudgetBounds(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Validate_MergeQueueWithCanary(t *testing.T) {
+	cfg := baseValidConfig()
+	cfg.Orchestrator = &OrchestratorConfig{
+		MaxConcurrent: 1,
+		Autopilot: &autopilot.Config{
+			Environments: map[string]*autopilot.EnvironmentConfig{
+				"prod": {Branch: "main", MergeQueue: true},
+			},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("merge queue without canary: Validate() = %v", err)
+	}
+
+	cfg.Orchestrator.Autopilot.Canary = &autopilot.CanaryConfig{Enabled: true}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "merge_queue cannot be combined with canary") {
+		t.Errorf("merge queue with canary: Validate() = %v", err)
+	}
+}
+
+func TestConfig_Validate_MemoryDriver(t *testing.T) {
+	tests := []struct {
+		name    string
+		memory  *MemoryConfig
+		wantErr string
+	}{
+		{name: "default", memory: &MemoryConfig{Path: "/tmp/pilot"}},
+		{name: "sqlite", memory: &MemoryConfig{Driver: "sqlite"}},
+		{name: "postgres", memory: &MemoryConfig{Driver: "postgres", DSN: "postgres://pilot@db/pilot"}},
+		{name: "postgres without dsn", memory: &MemoryConfig{Driver: "postgres"}, wantErr: "requires a dsn"},
+		{name: "unknown driver", memory: &MemoryConfig{Driver: "mysql"}, wantErr: "unknown driver"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseValidConfig()
+			cfg.Memory = tt.memory
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Cluster(t *testing.T) {
+	cfg := baseValidConfig()
+	cfg.Cluster = &cluster.Config{Enabled: true}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "cluster: token is required") {
+		t.Errorf("cluster without token: Validate() = %v", err)
+	}
+
+	cfg.Cluster.Token = "secret"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("cluster with token: Validate() = %v", err)
+	}
+
+	// Disabled cluster config is not validated
+	cfg.Cluster = &cluster.Config{}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("disabled cluster: Validate() = %v", err)
+	}
+}
+
+func TestConfig_Validate_Secrets(t *testing.T) {
+	cfg := baseValidConfig()
+	cfg.Secrets = &secrets.Config{Vault: &secrets.VaultConfig{KVVersion: 3}}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "secrets: vault.kv_version") {
+		t.Errorf("kv_version 3: Validate() = %v", err)
+	}
+
+	cfg.Secrets.Vault.KVVersion = 1
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("kv_version 1: Validate() = %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gorilla/websocket"
+
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+const (
+	// remoteMetricsInterval is how often lifetime metrics are re-read from
+	// the daemon's store for the metrics cards.
+	remoteMetricsInterval = 30 * time.Second
+	// remoteReadTimeout drops a connection that has gone quiet for longer
+	// than the daemon's ping interval allows.
+	remoteReadTimeout = 90 * time.Second
+	// remoteMinBackoff and remoteMaxBackoff bound reconnect delays.
+	remoteMinBackoff = time.Second
+	remoteMaxBackoff = 30 * time.Second
+)
+
+// remoteStatusMsg reports whether the remote daemon is reachable
+type remoteStatusMsg struct {
+	connected bool
+	err       error
+}
+
+// remoteSnapshotMsg replaces the dashboard state with the daemon's
+type remoteSnapshotMsg gateway.DashboardState
+
+// remotePRsMsg replaces the autopilot PRs with the daemon's
+type remotePRsMsg []gateway.DashboardPR
+
+// remoteHubMessage is a hub message with its payload left undecoded
+type remoteHubMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// RemoteClient follows a running daemon's dashboard read-only. It streams
+// /ws/dashboard/live and polls /api/v1/metrics on the daemon's gateway and
+// sends each update to the TUI. It never writes to the daemon.
+type RemoteClient struct {
+	addr   string // Gateway host:port
+	token  string // API token, sent as a bearer token when set
+	send   func(tea.Msg)
+	client *http.Client
+	dialer *websocket.Dialer
+}
+
+// NewRemoteClient creates a client for the gateway at addr (host:port).
+// send receives TUI messages; pass (*tea.Program).Send.
+func NewRemoteClient(addr, token string, send func(tea.Msg)) *RemoteClient {
+	return &RemoteClient{
+		addr:   addr,
+		token:  token,
+		send:   send,
+		client: &http.Client{Timeout: 10 * time.Second},
+		dialer: &websocket.Dialer{HandshakeTimeout: 10 * time.Second},
+	}
+}
+
+// Run follows the daemon until ctx is cancelled, reconnecting with backoff
+// whenever the connection drops. Each reconnect starts from a fresh snapshot.
+func (c *RemoteClient) Run(ctx context.Context) {
+	go c.pollMetrics(ctx)
+
+	backoff := remoteMinBackoff
+	for {
+		connected, err := c.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.send(remoteStatusMsg{err: err})
+		if connected {
+			backoff = remoteMinBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > remoteMaxBackoff {
+			backoff = remoteMaxBackoff
+		}
+	}
+}
+
+// follow streams one connection until it drops. Reports whether the
+// connection was established.
+func (c *RemoteClient) follow(ctx context.Context) (bool, error) {
+	header := http.Header{}
+	if c.token != "" {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+	conn, resp, err := c.dialer.DialContext(ctx, "ws://"+c.addr+"/ws/dashboard/live", header)
+	if err != nil {
+		if resp != nil {
+			return false, fmt.Errorf("daemon returned %s", resp.Status)
+		}
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	_ = conn.SetReadDeadline(time.Now().Add(remoteReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(remoteReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	c.send(remoteStatusMsg{connected: true})
+
+	for {
+		var raw remoteHubMessage
+		if err := conn.ReadJSON(&raw); err != nil {
+			return true, err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(remoteReadTimeout))
+		msg, err := decodeHubMessage(raw)
+		if err != nil {
+			return true, err
+		}
+		if msg != nil {
+			c.send(msg)
+		}
+	}
+}
+
+// decodeHubMessage converts a hub message to the TUI message that applies
+// it. Unknown message types are ignored so older clients keep working.
+func decodeHubMessage(raw remoteHubMessage) (tea.Msg, error) {
+	var (
+		msg tea.Msg
+		err error
+	)
+	switch raw.Type {
+	case gateway.HubMessageSnapshot:
+		var state gateway.DashboardState
+		err = json.Unmarshal(raw.Data, &state)
+		msg = remoteSnapshotMsg(state)
+	case gateway.HubMessageTasks:
+		var tasks []gateway.DashboardTask
+		err = json.Unmarshal(raw.Data, &tasks)
+		msg = updateTasksMsg(displayTasks(tasks))
+	case gateway.HubMessageLog:
+		var line string
+		err = json.Unmarshal(raw.Data, &line)
+		msg = addLogMsg(line)
+	case gateway.HubMessageTokens:
+		var tokens gateway.DashboardTokens
+		err = json.Unmarshal(raw.Data, &tokens)
+		msg = updateTokensMsg(TokenUsage(tokens))
+	case gateway.HubMessageCompleted:
+		var task gateway.DashboardCompletedTask
+		err = json.Unmarshal(raw.Data, &task)
+		msg = addCompletedTaskMsg(completedTask(task))
+	case gateway.HubMessageAutopilot:
+		var prs []gateway.DashboardPR
+		err = json.Unmarshal(raw.Data, &prs)
+		msg = remotePRsMsg(prs)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s message: %w", raw.Type, err)
+	}
+	return msg, nil
+}
+
+// pollMetrics refreshes the metrics cards from the daemon's store until ctx
+// is cancelled. Failed reads keep the last values.
+func (c *RemoteClient) pollMetrics(ctx context.Context) {
+	ticker := time.NewTicker(remoteMetricsInterval)
+	defer ticker.Stop()
+	for {
+		if data, err := c.fetchMetrics(ctx); err == nil {
+			c.send(updateMetricsCardMsg(data))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchMetrics reads lifetime metrics and 7-day sparklines from the daemon
+func (c *RemoteClient) fetchMetrics(ctx context.Context) (MetricsCardData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+c.addr+"/api/v1/metrics", nil)
+	if err != nil {
+		return MetricsCardData{}, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return MetricsCardData{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return MetricsCardData{}, fmt.Errorf("daemon returned %s", resp.Status)
+	}
+
+	var metrics struct {
+		TotalTokens    int       `json:"totalTokens"`
+		InputTokens    int       `json:"inputTokens"`
+		OutputTokens   int       `json:"outputTokens"`
+		TotalCostUSD   float64   `json:"totalCostUSD"`
+		TotalTasks     int       `json:"totalTasks"`
+		SucceededTasks int       `json:"succeededTasks"`
+		FailedTasks    int       `json:"failedTasks"`
+		TokenSparkline []int64   `json:"tokenSparkline"`
+		CostSparkline  []float64 `json:"costSparkline"`
+		QueueSparkline []int     `json:"queueSparkline"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return MetricsCardData{}, err
+	}
+
+	data := MetricsCardData{
+		TotalTokens:  metrics.TotalTokens,
+		InputTokens:  metrics.InputTokens,
+		OutputTokens: metrics.OutputTokens,
+		TotalCostUSD: metrics.TotalCostUSD,
+		TotalTasks:   metrics.TotalTasks,
+		Succeeded:    metrics.SucceededTasks,
+		Failed:       metrics.FailedTasks,
+		TokenHistory: metrics.TokenSparkline,
+		CostHistory:  metrics.CostSparkline,
+		TaskHistory:  metrics.QueueSparkline,
+	}
+	if data.TotalTasks > 0 {
+		data.CostPerTask = data.TotalCostUSD / float64(data.TotalTasks)
+	}
+	return data, nil
+}
+
+// displayTasks converts hub task rows back to dashboard task rows
+func displayTasks(tasks []gateway.DashboardTask) []TaskDisplay {
+	out := make([]TaskDisplay, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, TaskDisplay(t))
+	}
+	return out
+}
+
+// completedTask converts a hub history entry back to a dashboard entry
+func completedTask(t gateway.DashboardCompletedTask) CompletedTask {
+	return CompletedTask{
+		ID:          t.ID,
+		Title:       t.Title,
+		Status:      t.Status,
+		Duration:    t.Duration,
+		CompletedAt: t.CompletedAt,
+		ParentID:    t.ParentID,
+		IsEpic:      t.IsEpic,
+	}
+}
@@ -0,0 +1,156 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gorilla/websocket"
+
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+func TestRemoteClient_FollowsDaemon(t *testing.T) {
+	var gotAuth string
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/dashboard/live", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		for _, msg := range []gateway.HubMessage{
+			{Type: gateway.HubMessageSnapshot, Data: gateway.DashboardState{
+				Tasks:     []gateway.DashboardTask{{ID: "GH-1", Title: "Fix login", Status: "running", Progress: 40}},
+				Logs:      []string{"started GH-1"},
+				Tokens:    gateway.DashboardTokens{InputTokens: 100, OutputTokens: 50, TotalTokens: 150},
+				Completed: []gateway.DashboardCompletedTask{{ID: "GH-0", Title: "Add docs", Status: "success"}},
+				Autopilot: []gateway.DashboardPR{{Number: 7, Stage: "waiting_ci", CIStatus: "pending"}},
+			}},
+			{Type: gateway.HubMessageLog, Data: "GH-1 implementing"},
+			{Type: "future", Data: 1},
+		} {
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+		_, _, _ = conn.ReadMessage()
+	})
+	mux.HandleFunc("/api/v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalTokens": 1500, "totalCostUSD": 3.0, "totalTasks": 4, "succeededTasks": 3, "failedTasks": 1,
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	msgs := make(chan tea.Msg, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewRemoteClient(strings.TrimPrefix(server.URL, "http://"), "secret", func(msg tea.Msg) { msgs <- msg })
+	go client.Run(ctx)
+
+	var m tea.Model = NewModel("test")
+	model := m.(Model)
+	model.SetRemote("daemon:9090")
+	m = model
+	var sawConnected, sawMetrics, sawLog bool
+	deadline := time.After(5 * time.Second)
+	for !(sawConnected && sawMetrics && sawLog) {
+		select {
+		case msg := <-msgs:
+			switch msg := msg.(type) {
+			case remoteStatusMsg:
+				sawConnected = sawConnected || msg.connected
+			case updateMetricsCardMsg:
+				sawMetrics = true
+			case addLogMsg:
+				sawLog = true
+			}
+			m, _ = m.Update(msg)
+		case <-deadline:
+			t.Fatalf("timed out: connected=%v metrics=%v log=%v", sawConnected, sawMetrics, sawLog)
+		}
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want bearer token", gotAuth)
+	}
+
+	got := m.(Model)
+	if len(got.tasks) != 1 || got.tasks[0].ID != "GH-1" || got.tasks[0].Progress != 40 {
+		t.Errorf("tasks = %+v", got.tasks)
+	}
+	if len(got.logs) != 2 || got.logs[1] != "GH-1 implementing" {
+		t.Errorf("logs = %v", got.logs)
+	}
+	if len(got.completedTasks) != 1 || got.completedTasks[0].ID != "GH-0" {
+		t.Errorf("completed = %+v", got.completedTasks)
+	}
+	if got.tokenUsage.TotalTokens != 150 {
+		t.Errorf("tokens = %+v", got.tokenUsage)
+	}
+	if got.metricsCard.TotalTasks != 4 || got.metricsCard.CostPerTask != 0.75 {
+		t.Errorf("metrics card = %+v", got.metricsCard)
+	}
+
+	plain := stripANSI(got.renderDashboard())
+	for _, want := range []string{"REMOTE", "daemon:9090", "read-only", "#7: Waiting CI", "CI: pending"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, plain)
+		}
+	}
+}
+
+func TestRemoteModel_ReadOnly(t *testing.T) {
+	m := NewModel("test")
+	m.SetRemote("daemon:9090")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if updated.(Model).gitGraphMode != GitGraphHidden {
+		t.Error("g key should not open the git graph of a remote daemon")
+	}
+	if help := stripANSI(m.renderHelp()); strings.Contains(help, "g: graph") {
+		t.Errorf("help offers git graph in remote mode: %q", help)
+	}
+
+	updated, _ = m.Update(remoteStatusMsg{err: context.DeadlineExceeded})
+	plain := stripANSI(updated.(Model).renderRemote())
+	for _, want := range []string{"reconnecting", "deadline exceeded"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("remote panel missing %q:\n%s", want, plain)
+		}
+	}
+}
+
+func TestDecodeHubMessage(t *testing.T) {
+	msg, err := decodeHubMessage(remoteHubMessage{Type: gateway.HubMessageTokens, Data: json.RawMessage(`{"inputTokens":3,"outputTokens":4,"totalTokens":7}`)})
+	if err != nil || msg != updateTokensMsg(TokenUsage{InputTokens: 3, OutputTokens: 4, TotalTokens: 7}) {
+		t.Errorf("tokens = %v, %v", msg, err)
+	}
+	if msg, err := decodeHubMessage(remoteHubMessage{Type: "unknown"}); msg != nil || err != nil {
+		t.Errorf("unknown type = %v, %v, want ignored", msg, err)
+	}
+	if _, err := decodeHubMessage(remoteHubMessage{Type: gateway.HubMessageTasks, Data: json.RawMessage(`"oops"`)}); err == nil {
+		t.Error("malformed payload should fail")
+	}
+}
+
+func TestRemoteAutopilot_MergeQueuePosition(t *testing.T) {
+	m := NewModel("test")
+	m.SetRemote("daemon:9090")
+
+	updated, _ := m.Update(remotePRsMsg{{Number: 9, Stage: "merge_queued", QueuePosition: 2}})
+	plain := stripANSI(updated.(Model).renderDashboard())
+	for _, want := range []string{"# #9: Merge Queue", "Position: 2"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, plain)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"math"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strings"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/alekspetrov/pilot/internal/autopilot"
 	"github.com/alekspetrov/pilot/internal/banner"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
 	"github.com/alekspetrov/pilot/internal/memory"
 )
 
@@ -120,6 +123,10 @@ var (
 type AutopilotPanel struct {
 	controller *autopilot.Controller
 	panelWidth int // dynamic panel width, set before View()
+
+	// Remote mode renders PRs streamed from a daemon instead of a controller
+	remote    bool
+	remotePRs []gateway.DashboardPR
 }
 
 // NewAutopilotPanel creates an autopilot panel.
@@ -136,6 +143,10 @@ func (p *AutopilotPanel) View() string {
 	}
 	w := tw - 4
 
+	if p.remote {
+		return renderPanel("AUTOPILOT", p.remoteView(w), tw)
+	}
+
 	if p.controller == nil {
 		content.WriteString("  Disabled")
 		return renderPanel("AUTOPILOT", content.String(), tw)
@@ -193,6 +204,12 @@ func (p *AutopilotPanel) View() string {
 				content.WriteString("\n")
 			}
 
+			// Show queue position while in the merge queue
+			if pr.Stage == autopilot.StageMergeQueued && pr.MergeQueuePosition > 0 {
+				content.WriteString(fmt.Sprintf("     Position: %d", pr.MergeQueuePosition))
+				content.WriteString("\n")
+			}
+
 			// Show error if in failed state
 			if pr.Stage == autopilot.StageFailed && pr.Error != "" {
 				errLine := fmt.Sprintf("     Error: %s", truncateString(pr.Error, 30))
@@ -213,6 +230,31 @@ func (p *AutopilotPanel) View() string {
 	return renderPanel("AUTOPILOT", content.String(), tw)
 }
 
+// remoteView renders the PRs streamed from a remote daemon, which sends
+// their stages but not the controller's configuration.
+func (p *AutopilotPanel) remoteView(w int) string {
+	var content strings.Builder
+	content.WriteString(dotLeader("Active PRs", fmt.Sprintf("%d", len(p.remotePRs)), w))
+	for _, pr := range p.remotePRs {
+		stage := autopilot.PRStage(pr.Stage)
+		content.WriteString("\n")
+		content.WriteString(fmt.Sprintf("  %s #%d: %s", p.stageIcon(stage), pr.Number, p.stageLabel(stage)))
+		if pr.Repo != "" {
+			content.WriteString(" (" + pr.Repo + ")")
+		}
+		if stage == autopilot.StageWaitingCI {
+			content.WriteString(fmt.Sprintf("\n     CI: %s", pr.CIStatus))
+		}
+		if stage == autopilot.StageMergeQueued && pr.QueuePosition > 0 {
+			content.WriteString(fmt.Sprintf("\n     Position: %d", pr.QueuePosition))
+		}
+		if stage == autopilot.StageFailed && pr.Error != "" {
+			content.WriteString(fmt.Sprintf("\n     Error: %s", truncateString(pr.Error, 30)))
+		}
+	}
+	return content.String()
+}
+
 // formatDuration formats a duration for display (e.g., "2m", "1h30m").
 func (p *AutopilotPanel) formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -244,6 +286,8 @@ func (p *AutopilotPanel) stageIcon(stage autopilot.PRStage) string {
 		return "+"
 	case autopilot.StageWaitingCI:
 		return "~"
+	case autopilot.StageMarkingReady:
+		return "*"
 	case autopilot.StageCIPassed:
 		return "*"
 	case autopilot.StageCIFailed:
@@ -252,10 +296,20 @@ func (p *AutopilotPanel) stageIcon(stage autopilot.PRStage) string {
 		return "?"
 	case autopilot.StageMerging:
 		return ">"
+	case autopilot.StageResolvingConflicts:
+		return "&"
+	case autopilot.StageFixingReview:
+		return "&"
+	case autopilot.StageMergeQueued:
+		return "#"
 	case autopilot.StageMerged:
 		return "*"
 	case autopilot.StagePostMergeCI:
 		return "~"
+	case autopilot.StageCanaryCI:
+		return "~"
+	case autopilot.StageCanarySoak:
+		return "%"
 	case autopilot.StageReleasing:
 		return "^"
 	case autopilot.StageFailed:
@@ -272,6 +326,8 @@ func (p *AutopilotPanel) stageLabel(stage autopilot.PRStage) string {
 		return "PR Created"
 	case autopilot.StageWaitingCI:
 		return "Waiting CI"
+	case autopilot.StageMarkingReady:
+		return "Marking Ready"
 	case autopilot.StageCIPassed:
 		return "CI Passed"
 	case autopilot.StageCIFailed:
@@ -280,10 +336,20 @@ func (p *AutopilotPanel) stageLabel(stage autopilot.PRStage) string {
 		return "Awaiting Approval"
 	case autopilot.StageMerging:
 		return "Merging"
+	case autopilot.StageResolvingConflicts:
+		return "Resolving Conflicts"
+	case autopilot.StageFixingReview:
+		return "Fixing Review"
+	case autopilot.StageMergeQueued:
+		return "Merge Queue"
 	case autopilot.StageMerged:
 		return "Merged"
 	case autopilot.StagePostMergeCI:
 		return "Post-Merge CI"
+	case autopilot.StageCanaryCI:
+		return "Canary CI"
+	case autopilot.StageCanarySoak:
+		return "Canary Soak"
 	case autopilot.StageReleasing:
 		return "Releasing"
 	case autopilot.StageFailed:
@@ -303,6 +369,7 @@ type TaskDisplay struct {
 	Duration string
 	IssueURL string
 	PRURL    string
+	Step     string // Current step from structured signals, e.g. "EDIT main.go"
 }
 
 // TokenUsage tracks token consumption
@@ -383,6 +450,37 @@ type Model struct {
 	gitGraphScroll int
 	gitGraphFocus  bool
 	projectPath    string // Working directory for git commands
+
+	// Remote dashboard mirror (nil = local only)
+	hub *gateway.DashboardHub
+
+	// Execution slots and worktree pools (nil = panel hidden)
+	concurrency gateway.ConcurrencySource
+
+	// Fair scheduling shares and starvation (nil = panel hidden)
+	fairness FairnessSource
+
+	// Pipeline pause toggle (nil = p key disabled)
+	pause PauseControl
+
+	// Remote daemon followed read-only ("" = local dashboard)
+	remote          string
+	remoteConnected bool
+	remoteErr       string
+}
+
+// FairnessSource provides fair scheduler state for the QUEUE panel.
+// Satisfied by *executor.FairScheduler.
+type FairnessSource interface {
+	FairnessSnapshot() executor.FairnessSnapshot
+}
+
+// PauseControl pauses and resumes dispatching of new work.
+// Satisfied by *executor.PipelinePause.
+type PauseControl interface {
+	State() memory.PipelineState
+	Pause(reason string) error
+	Resume() error
 }
 
 // isStackedMode returns true when the git graph is visible and the terminal is
@@ -647,6 +745,85 @@ func (m *Model) SetProjectPath(path string) {
 	m.projectPath = path
 }
 
+// SetHub mirrors every task, log, token and history update the dashboard
+// receives into hub, so remote dashboards see the same state.
+func (m *Model) SetHub(hub *gateway.DashboardHub) {
+	m.hub = hub
+}
+
+// SetConcurrencySource shows per-repo and per-project execution slots, tasks
+// waiting on their limits, and autoscaled worktree pools in a CAPACITY panel.
+func (m *Model) SetConcurrencySource(source gateway.ConcurrencySource) {
+	m.concurrency = source
+}
+
+// SetFairnessSource shows dispatch slots, per-project and per-requester
+// shares, and starving projects in a QUEUE panel.
+func (m *Model) SetFairnessSource(source FairnessSource) {
+	m.fairness = source
+}
+
+// SetPauseControl enables the p key to pause and resume the pipeline and
+// shows a PAUSED panel while new work is held back.
+func (m *Model) SetPauseControl(pause PauseControl) {
+	m.pause = pause
+}
+
+// SetRemote marks the dashboard as following the daemon at addr read-only.
+// The git graph is disabled since it would show the local checkout, and
+// the autopilot panel renders the PRs the daemon streams.
+func (m *Model) SetRemote(addr string) {
+	m.remote = addr
+	m.autopilotPanel.remote = true
+}
+
+// togglePauseCmd pauses a running pipeline or resumes a paused one
+func togglePauseCmd(pause PauseControl) tea.Cmd {
+	return func() tea.Msg {
+		if pause.State().Paused {
+			if err := pause.Resume(); err != nil {
+				return addLogMsg(fmt.Sprintf("❌ Failed to resume pipeline: %v", err))
+			}
+			return addLogMsg("▶️ Pipeline resumed — dispatching new work")
+		}
+		if err := pause.Pause("paused from dashboard"); err != nil {
+			return addLogMsg(fmt.Sprintf("❌ Failed to pause pipeline: %v", err))
+		}
+		return addLogMsg("⏸ Pipeline paused — in-flight tasks will finish")
+	}
+}
+
+// HubTasks converts dashboard task rows for the remote dashboard hub.
+func HubTasks(tasks []TaskDisplay) []gateway.DashboardTask {
+	out := make([]gateway.DashboardTask, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, gateway.DashboardTask{
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   t.Status,
+			Phase:    t.Phase,
+			Progress: t.Progress,
+			Duration: t.Duration,
+			IssueURL: t.IssueURL,
+			PRURL:    t.PRURL,
+			Step:     t.Step,
+		})
+	}
+	return out
+}
+
+func hubCompletedTask(t CompletedTask) gateway.DashboardCompletedTask {
+	return gateway.DashboardCompletedTask{
+		ID:          t.ID,
+		Title:       t.Title,
+		Status:      t.Status,
+		Duration:    t.Duration,
+		CompletedAt: t.CompletedAt,
+		ParentID:    t.ParentID,
+		IsEpic:      t.IsEpic,
+	}
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -677,6 +854,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showLogs = !m.showLogs
 			return m, tea.ClearScreen // GH-1249: Logs toggle changes height
 		case "g":
+			if m.remote != "" {
+				return m, nil
+			}
 			// Toggle git graph: Hidden ↔ Visible (auto-sizes)
 			if m.gitGraphMode == GitGraphHidden {
 				m.gitGraphMode = GitGraphVisible
@@ -759,6 +939,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				default:
 				}
 			}
+		case "p":
+			if m.pause != nil {
+				return m, togglePauseCmd(m.pause)
+			}
 		}
 
 	case tea.WindowSizeMsg:
@@ -772,9 +956,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tickCmd()
 
 	case updateTasksMsg:
-		prevLen := len(m.tasks)
+		prevLines := taskLineCount(m.tasks)
 		m.tasks = msg
-		if len(m.tasks) != prevLen {
+		if m.hub != nil {
+			m.hub.UpdateTasks(HubTasks(msg))
+		}
+		if taskLineCount(m.tasks) != prevLines {
 			// GH-1249: Task count changed → content height changed.
 			// Force full repaint to prevent ghost lines from Bubbletea's diff renderer.
 			return m, tea.ClearScreen
@@ -782,6 +969,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case addLogMsg:
 		m.logs = append(m.logs, string(msg))
+		if m.hub != nil {
+			m.hub.AddLog(string(msg))
+		}
 		if len(m.logs) > 100 {
 			m.logs = m.logs[1:]
 		}
@@ -792,6 +982,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		outputDelta := msg.OutputTokens - m.tokenUsage.OutputTokens
 		m.tokenUsage = TokenUsage(msg)
 		m.persistTokenUsage(inputDelta, outputDelta)
+		if m.hub != nil {
+			m.hub.UpdateTokens(msg.InputTokens, msg.OutputTokens)
+		}
 
 		// Add deltas to lifetime metrics card totals (not replace with session values)
 		m.metricsCard.InputTokens += inputDelta
@@ -809,6 +1002,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case addCompletedTaskMsg:
 		prevLen := len(m.completedTasks)
 		m.completedTasks = append(m.completedTasks, CompletedTask(msg))
+		if m.hub != nil {
+			m.hub.AddCompletedTask(hubCompletedTask(CompletedTask(msg)))
+		}
 		if len(m.completedTasks) > 5 {
 			m.completedTasks = m.completedTasks[len(m.completedTasks)-5:]
 		}
@@ -832,6 +1028,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case updateMetricsCardMsg:
 		m.metricsCard = MetricsCardData(msg)
 
+	case remoteStatusMsg:
+		m.remoteConnected = msg.connected
+		m.remoteErr = ""
+		if msg.err != nil {
+			m.remoteErr = msg.err.Error()
+		}
+
+	case remoteSnapshotMsg:
+		// Replace rather than append: a reconnect re-sends everything
+		m.tasks = displayTasks(msg.Tasks)
+		m.logs = append([]string{}, msg.Logs...)
+		m.tokenUsage = TokenUsage(msg.Tokens)
+		m.completedTasks = make([]CompletedTask, 0, len(msg.Completed))
+		for _, t := range msg.Completed {
+			m.completedTasks = append(m.completedTasks, completedTask(t))
+		}
+		m.autopilotPanel.remotePRs = msg.Autopilot
+		if m.selectedTask >= len(m.tasks) {
+			m.selectedTask = 0
+		}
+		return m, tea.ClearScreen
+
+	case remotePRsMsg:
+		prevLen := len(m.autopilotPanel.remotePRs)
+		m.autopilotPanel.remotePRs = msg
+		if len(msg) != prevLen {
+			return m, tea.ClearScreen
+		}
+
 	case updateAvailableMsg:
 		m.updateInfo = &UpdateInfo{
 			CurrentVersion: msg.CurrentVersion,
@@ -956,6 +1181,18 @@ func (m Model) renderDashboard() string {
 		b.WriteString("\n")
 	}
 
+	// Remote daemon connection — always visible regardless of banner
+	if remotePanel := m.renderRemote(); remotePanel != "" {
+		b.WriteString(remotePanel)
+		b.WriteString("\n")
+	}
+
+	// Paused pipeline — always visible regardless of banner
+	if pausePanel := m.renderPaused(); pausePanel != "" {
+		b.WriteString(pausePanel)
+		b.WriteString("\n")
+	}
+
 	// Metrics cards (tokens, cost, tasks)
 	b.WriteString(m.renderMetricsCards())
 	b.WriteString("\n")
@@ -968,6 +1205,24 @@ func (m Model) renderDashboard() string {
 	b.WriteString(m.autopilotPanel.View())
 	b.WriteString("\n")
 
+	// Epics
+	if epicsPanel := m.renderEpics(); epicsPanel != "" {
+		b.WriteString(epicsPanel)
+		b.WriteString("\n")
+	}
+
+	// Execution slots and back-pressure
+	if capacityPanel := m.renderCapacity(); capacityPanel != "" {
+		b.WriteString(capacityPanel)
+		b.WriteString("\n")
+	}
+
+	// Fair scheduling and starvation
+	if queuePanel := m.renderQueue(); queuePanel != "" {
+		b.WriteString(queuePanel)
+		b.WriteString("\n")
+	}
+
 	// Eval stats
 	if evalPanel := m.renderEvalStats(); evalPanel != "" {
 		b.WriteString(evalPanel)
@@ -997,6 +1252,14 @@ func (m Model) renderHelp() string {
 	case m.gitGraphMode == GitGraphHidden:
 		// Graph hidden: show navigation and graph-open key
 		parts = []string{"q: quit", "l: logs", "b: banner", "g: graph", "j/k: select"}
+		if m.remote != "" {
+			parts = []string{"q: quit", "l: logs", "b: banner", "j/k: select"}
+		}
+		if m.pause != nil && m.pause.State().Paused {
+			parts = append(parts, "p: resume")
+		} else if m.pause != nil {
+			parts = append(parts, "p: pause")
+		}
 	case m.gitGraphFocus:
 		// Graph visible, graph panel focused
 		parts = []string{"q: quit", "b: banner", "g: close", "tab: dashboard"}
@@ -1456,14 +1719,16 @@ func taskStatePriority(status string) int {
 		return 0
 	case "running":
 		return 1
-	case "queued":
+	case "blocked":
 		return 2
-	case "pending":
+	case "queued":
 		return 3
-	case "failed":
+	case "pending":
 		return 4
-	default:
+	case "failed":
 		return 5
+	default:
+		return 6
 	}
 }
 
@@ -1496,12 +1761,34 @@ func (m Model) renderTasks() string {
 				queueIdx++
 			}
 			content.WriteString(m.renderTask(task, i == m.selectedTask, offset))
+			if hasStepLine(task) {
+				// Indented under the task ID
+				step := truncateVisual(task.Step, m.effectivePanelTotalWidth()-4-13)
+				content.WriteString("\n" + dimStyle.Render(strings.Repeat(" ", 11)+"↳ "+step))
+			}
 		}
 	}
 
 	return renderPanel("QUEUE", content.String(), m.effectivePanelTotalWidth())
 }
 
+// hasStepLine reports whether a running or blocked task shows its current
+// step below it
+func hasStepLine(task TaskDisplay) bool {
+	return (task.Status == "running" || task.Status == "blocked") && task.Step != ""
+}
+
+// taskLineCount returns how many lines the tasks take in the QUEUE panel
+func taskLineCount(tasks []TaskDisplay) int {
+	n := len(tasks)
+	for _, t := range tasks {
+		if hasStepLine(t) {
+			n++
+		}
+	}
+	return n
+}
+
 // renderTask renders a single task row with state-aware icons, bars, and meta.
 //
 // Layout (65 inner chars):
@@ -1524,6 +1811,17 @@ func (m Model) renderTask(task TaskDisplay, selected bool, queueOffset int) stri
 		meta = fmt.Sprintf("%4d%%", task.Progress)
 		iconStyle = statusRunningStyle
 		barStyle = progressBarStyle
+		if task.Phase == executor.PhaseBlocked {
+			icon = "!"
+			stateLabel = "blocked"
+			iconStyle = warningStyle
+		}
+	case "blocked":
+		icon = "!"
+		stateLabel = "blocked"
+		meta = "reply"
+		iconStyle = warningStyle
+		barStyle = warningStyle
 	case "queued":
 		icon = "◌"
 		stateLabel = "queued"
@@ -1569,6 +1867,9 @@ func (m Model) renderTask(task TaskDisplay, selected bool, queueOffset int) stri
 		progressBar = m.renderShimmerBar(14, queueOffset)
 	case "failed":
 		progressBar = m.renderFailedBar(task.Progress, 14)
+	case "blocked":
+		filled := task.Progress * 14 / 100
+		progressBar = "[" + barStyle.Render(strings.Repeat("█", filled)) + progressEmptyStyle.Render(strings.Repeat("░", 14-filled)) + "]"
 	default: // pending
 		bar := progressEmptyStyle.Render(strings.Repeat(" ", 14))
 		progressBar = "[" + bar + "]"
@@ -1809,6 +2110,191 @@ func (m Model) renderEvalStats() string {
 	return renderPanel("EVAL", line, tw)
 }
 
+// renderCapacity renders limited repos and projects with their running and
+// waiting tasks, and autoscaled worktree pools. Unlimited scopes without
+// waiting tasks are left out. Returns "" when there is nothing to show.
+func (m Model) renderCapacity() string {
+	if m.concurrency == nil {
+		return ""
+	}
+	snap := m.concurrency.ConcurrencySnapshot()
+
+	tw := m.effectivePanelTotalWidth()
+	iw := tw - 4
+	var lines []string
+	for _, slot := range snap.Slots {
+		if slot.Limit == 0 && slot.Waiting == 0 {
+			continue
+		}
+		label := truncateVisual(slot.Scope+" "+shortProjectName(slot.Key), iw/2)
+		limit := "∞"
+		if slot.Limit > 0 {
+			limit = fmt.Sprintf("%d", slot.Limit)
+		}
+		value := fmt.Sprintf("%d/%s running", slot.Active, limit)
+		if slot.Waiting > 0 {
+			value += fmt.Sprintf(" · %d waiting", slot.Waiting)
+			lines = append(lines, dotLeaderStyled(label, value, statusPendingStyle, iw))
+			continue
+		}
+		lines = append(lines, dotLeader(label, value, iw))
+	}
+	for _, pool := range snap.Pools {
+		label := truncateVisual("pool "+shortProjectName(pool.ProjectPath), iw/2)
+		lines = append(lines, dotLeader(label, fmt.Sprintf("%d/%d busy · target %d", pool.InUse, pool.Size, pool.Target), iw))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return renderPanel("CAPACITY", strings.Join(lines, "\n"), tw)
+}
+
+// shortProjectName keeps the last path element of a project path; repo
+// names ("owner/repo") are returned unchanged.
+func shortProjectName(key string) string {
+	if !strings.HasPrefix(key, "/") {
+		return key
+	}
+	return filepath.Base(key)
+}
+
+// queuePanelRequesters is how many requesters the QUEUE panel lists
+const queuePanelRequesters = 5
+
+// renderQueue renders fair scheduling state: dispatch slots, each project's
+// weight, queue and wait, and the busiest requesters. Projects waiting past
+// the starvation threshold are highlighted. Returns "" when nothing has been
+// scheduled yet.
+func (m Model) renderQueue() string {
+	if m.fairness == nil {
+		return ""
+	}
+	snap := m.fairness.FairnessSnapshot()
+	if len(snap.Projects) == 0 {
+		return ""
+	}
+
+	tw := m.effectivePanelTotalWidth()
+	iw := tw - 4
+	slots := "∞"
+	if snap.Slots > 0 {
+		slots = fmt.Sprintf("%d", snap.Slots)
+	}
+	summary := fmt.Sprintf("%d/%s running", snap.Active, slots)
+	if starving := snap.Starving(); len(starving) > 0 {
+		summary += fmt.Sprintf(" · %d starving", len(starving))
+	}
+	lines := []string{dotLeader("slots", summary, iw)}
+
+	for _, p := range snap.Projects {
+		label := truncateVisual(fmt.Sprintf("%s ×%d", shortProjectName(p.Key), p.Weight), iw/2)
+		value := fmt.Sprintf("%d queued · %d run · avg %s", p.Queued, p.Dispatched, formatWait(p.AvgWait()))
+		switch {
+		case p.Waiting && p.Wait > snap.StarvationThreshold:
+			lines = append(lines, dotLeaderStyled(label, value+" · starving "+formatWait(p.Wait), statusFailedStyle, iw))
+		case p.Waiting:
+			lines = append(lines, dotLeaderStyled(label, value+" · waiting "+formatWait(p.Wait), statusPendingStyle, iw))
+		default:
+			lines = append(lines, dotLeader(label, value, iw))
+		}
+	}
+
+	requesters := append([]executor.FairShare(nil), snap.Requesters...)
+	sort.SliceStable(requesters, func(i, j int) bool { return requesters[i].Dispatched > requesters[j].Dispatched })
+	if len(requesters) > queuePanelRequesters {
+		requesters = requesters[:queuePanelRequesters]
+	}
+	for _, r := range requesters {
+		label := truncateVisual(fmt.Sprintf("@%s ×%d", r.Key, r.Weight), iw/2)
+		value := fmt.Sprintf("%d run · max wait %s", r.Dispatched, formatWait(r.MaxWait))
+		if r.Starved > 0 {
+			lines = append(lines, dotLeaderStyled(label, value+fmt.Sprintf(" · %d starved", r.Starved), statusPendingStyle, iw))
+			continue
+		}
+		lines = append(lines, dotLeader(label, value, iw))
+	}
+	return renderPanel("QUEUE", strings.Join(lines, "\n"), tw)
+}
+
+// formatWait formats a queue wait compactly: 45s, 12m, 2h05m
+func formatWait(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// epicPanelWindow is how long a completed epic stays in the EPICS panel.
+const epicPanelWindow = 24 * time.Hour
+
+// renderEpics renders epics from the store: unfinished epics expanded with
+// their sub-issues, and epics completed within epicPanelWindow collapsed to
+// one line. Returns "" when there is nothing to show.
+func (m Model) renderEpics() string {
+	if m.store == nil {
+		return ""
+	}
+	epics, err := m.store.ListEpics(10)
+	if err != nil {
+		return ""
+	}
+
+	tw := m.effectivePanelTotalWidth()
+	iw := tw - 4
+	var lines []string
+	shown := 0
+	for _, epic := range epics {
+		if shown == 3 {
+			break
+		}
+		completed := epic.Status == memory.EpicStatusCompleted
+		if completed && time.Since(epic.UpdatedAt) > epicPanelWindow {
+			continue
+		}
+		shown++
+
+		task := CompletedTask{
+			ID:          epic.ParentID,
+			Title:       epic.Title,
+			Status:      "success",
+			Duration:    fmt.Sprintf("$%.2f", epic.CostUSD),
+			CompletedAt: epic.UpdatedAt,
+			TotalSubs:   len(epic.SubIssues),
+			DoneSubs:    epic.Completed(),
+			IsEpic:      true,
+		}
+		if completed {
+			lines = append(lines, renderCompletedEpicLine(task, iw))
+			continue
+		}
+		lines = append(lines, renderActiveEpicLine(task, iw))
+		for _, sub := range epic.SubIssues {
+			lines = append(lines, renderSubIssueLine(epicSubIssueTask(sub), iw))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return renderPanel("EPICS", strings.Join(lines, "\n"), tw)
+}
+
+// epicSubIssueTask adapts a stored sub-issue for the shared history line renderers.
+func epicSubIssueTask(sub *memory.EpicSubIssue) CompletedTask {
+	id := sub.Identifier
+	if sub.Number > 0 {
+		id = fmt.Sprintf("GH-%d", sub.Number)
+	}
+	status := sub.Status
+	if status == memory.SubIssueStatusDone {
+		status = "success"
+	}
+	return CompletedTask{ID: id, Title: sub.Title, Status: status, CompletedAt: sub.UpdatedAt}
+}
+
 // renderHistory renders completed tasks history with epic-aware grouping.
 // Active epics show expanded with sub-issue tree; completed epics collapse to one line.
 func (m Model) renderHistory() string {
@@ -1971,6 +2457,10 @@ func statusIconStyle(status string) (string, lipgloss.Style) {
 		return "+", statusCompletedStyle
 	case "failed":
 		return "x", statusFailedStyle
+	case "cancelled":
+		return "-", dimStyle
+	case "blocked":
+		return "!", warningStyle
 	case "running":
 		return "~", statusRunningStyle
 	default:
@@ -2120,6 +2610,49 @@ func (m Model) renderUpdateNotification() string {
 	return result
 }
 
+// renderPaused renders the PAUSED panel while the pipeline holds back new work
+func (m Model) renderPaused() string {
+	if m.pause == nil {
+		return ""
+	}
+	state := m.pause.State()
+	if !state.Paused {
+		return ""
+	}
+	tw := m.effectivePanelTotalWidth()
+	iw := tw - 4
+
+	since := ""
+	if !state.UpdatedAt.IsZero() {
+		since = "since " + state.UpdatedAt.Local().Format("15:04")
+	}
+	content := formatPanelRow("No new work is dispatched", since, iw)
+	if state.Reason != "" {
+		content += "\n  " + truncateVisual(state.Reason, iw-4)
+	}
+
+	hintLine := fmt.Sprintf("%*s", tw, "p: resume")
+	return renderOrangePanel("|| PAUSED", content, tw) + "\n" + dimStyle.Render(hintLine)
+}
+
+// renderRemote shows the followed daemon and whether it is reachable
+func (m Model) renderRemote() string {
+	if m.remote == "" {
+		return ""
+	}
+	tw := m.effectivePanelTotalWidth()
+	iw := tw - 4
+
+	if m.remoteConnected {
+		return renderPanel("REMOTE", formatPanelRow(m.remote, "connected · read-only", iw), tw)
+	}
+	content := formatPanelRow(m.remote, "reconnecting...", iw)
+	if m.remoteErr != "" {
+		content += "\n  " + truncateVisual(m.remoteErr, iw-4)
+	}
+	return renderOrangePanel("REMOTE", content, tw)
+}
+
 // formatPanelRow creates a full-width row with left and right aligned text
 func formatPanelRow(left, right string, iw int) string {
 	leftWidth := lipgloss.Width(left)
@@ -12,6 +12,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
 	"github.com/alekspetrov/pilot/internal/memory"
 )
 
@@ -254,6 +256,41 @@ func TestRenderTaskCard_ShowsQueueDepth(t *testing.T) {
 	}
 }
 
+func TestRenderTasks_StepSignals(t *testing.T) {
+	m := NewModel("test")
+	m.tasks = []TaskDisplay{
+		{ID: "GH-1", Title: "Fix login", Status: "running", Phase: executor.PhaseTesting, Step: "TEST ./auth/..."},
+		{ID: "GH-2", Title: "Add export", Status: "running", Phase: executor.PhaseBlocked, Step: "BLOCKED need S3 bucket"},
+	}
+
+	output := stripANSI(m.renderTasks())
+	for _, want := range []string{"↳ TEST ./auth/...", "↳ BLOCKED need S3 bucket", "blocked"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("tasks panel missing %q:\n%s", want, output)
+		}
+	}
+	if got := taskLineCount(m.tasks); got != 4 {
+		t.Errorf("taskLineCount() = %d, want 4", got)
+	}
+}
+
+func TestRenderTasks_BlockedTask(t *testing.T) {
+	m := NewModel("test")
+	m.tasks = []TaskDisplay{
+		{ID: "GH-3", Title: "Add billing", Status: "blocked", Phase: executor.PhaseBlocked, Progress: 60, Step: "waiting for reply: missing STRIPE_KEY"},
+	}
+
+	output := stripANSI(m.renderTasks())
+	for _, want := range []string{"!", "blocked", "reply", "↳ waiting for reply: missing STRIPE_KEY"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("tasks panel missing %q:\n%s", want, output)
+		}
+	}
+	if got := taskLineCount(m.tasks); got != 2 {
+		t.Errorf("taskLineCount() = %d, want 2", got)
+	}
+}
+
 func TestRenderTaskCard_EmptyQueue(t *testing.T) {
 	m := NewModel("test")
 	// Historical tasks exist but queue is empty
@@ -381,6 +418,36 @@ func TestUpdateTokensMsg_AddsToLifetimeTotals(t *testing.T) {
 	}
 }
 
+func TestModel_MirrorsToHub(t *testing.T) {
+	hub := gateway.NewDashboardHub()
+	m := NewModel("test")
+	m.SetHub(hub)
+
+	var model tea.Model = m
+	for _, msg := range []tea.Msg{
+		UpdateTasks([]TaskDisplay{{ID: "GH-1", Title: "Add caching", Status: "running", Progress: 40}})(),
+		AddLog("GH-1: Implementing")(),
+		UpdateTokens(2000, 1000)(),
+		AddCompletedTask("GH-2", "Fix login", "success", "1m", "GH-498", false)(),
+	} {
+		model, _ = model.Update(msg)
+	}
+
+	state := hub.Snapshot()
+	if len(state.Tasks) != 1 || state.Tasks[0].ID != "GH-1" || state.Tasks[0].Progress != 40 {
+		t.Errorf("Tasks = %+v", state.Tasks)
+	}
+	if len(state.Logs) != 1 || state.Logs[0] != "GH-1: Implementing" {
+		t.Errorf("Logs = %v", state.Logs)
+	}
+	if state.Tokens.TotalTokens != 3000 {
+		t.Errorf("TotalTokens = %d, want 3000", state.Tokens.TotalTokens)
+	}
+	if len(state.Completed) != 1 || state.Completed[0].ParentID != "GH-498" {
+		t.Errorf("Completed = %+v", state.Completed)
+	}
+}
+
 func TestAddCompletedTask_NewFieldsStored(t *testing.T) {
 	m := NewModel("test")
 
@@ -1223,3 +1290,176 @@ func TestRenderEvalStats(t *testing.T) {
 		}
 	})
 }
+
+func TestRenderEpics(t *testing.T) {
+	if got := (Model{}).renderEpics(); got != "" {
+		t.Errorf("expected empty string for nil store, got %q", got)
+	}
+
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	m := Model{store: store}
+	if got := m.renderEpics(); got != "" {
+		t.Errorf("expected empty string for no epics, got %q", got)
+	}
+
+	epics := []*memory.Epic{
+		{ProjectPath: "/repo", ParentID: "GH-10", Title: "Auth overhaul", SubIssues: []*memory.EpicSubIssue{
+			{Position: 1, Number: 11, Title: "Schema"},
+			{Position: 2, Number: 12, Title: "Login API"},
+		}},
+		{ProjectPath: "/repo", ParentID: "GH-20", Title: "Search", SubIssues: []*memory.EpicSubIssue{
+			{Position: 1, Number: 21, Title: "Index"},
+		}},
+	}
+	for _, epic := range epics {
+		if err := store.SaveEpic(epic); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, update := range []struct {
+		parent string
+		sub    *memory.EpicSubIssue
+	}{
+		{"GH-10", &memory.EpicSubIssue{Position: 1, Status: memory.SubIssueStatusDone, CostUSD: 1.25}},
+		{"GH-10", &memory.EpicSubIssue{Position: 2, Status: memory.SubIssueStatusRunning}},
+		{"GH-20", &memory.EpicSubIssue{Position: 1, Status: memory.SubIssueStatusDone}},
+	} {
+		if err := store.UpdateEpicSubIssue("/repo", update.parent, update.sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plain := stripANSI(m.renderEpics())
+	for _, want := range []string{"EPICS", "GH-10", "Auth overhaul", "1/2", "$1.25", "GH-11", "Schema", "GH-12", "Login API", "GH-20", "[1/1]"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("EPICS panel missing %q:\n%s", want, plain)
+		}
+	}
+	// Completed epics collapse to one line
+	if strings.Contains(plain, "GH-21") {
+		t.Errorf("completed epic should not list sub-issues:\n%s", plain)
+	}
+}
+
+type fakeConcurrencySource executor.ConcurrencySnapshot
+
+func (f fakeConcurrencySource) ConcurrencySnapshot() executor.ConcurrencySnapshot {
+	return executor.ConcurrencySnapshot(f)
+}
+
+func TestRenderCapacity(t *testing.T) {
+	if got := (Model{}).renderCapacity(); got != "" {
+		t.Errorf("expected empty string without a source, got %q", got)
+	}
+
+	// Unlimited scopes with nothing waiting are not shown
+	m := Model{concurrency: fakeConcurrencySource{Slots: []executor.SlotUsage{
+		{Scope: executor.ScopeProject, Key: "/srv/api", Active: 3},
+	}}}
+	if got := m.renderCapacity(); got != "" {
+		t.Errorf("expected empty panel for unlimited scopes, got %q", got)
+	}
+
+	m.concurrency = fakeConcurrencySource{
+		Slots: []executor.SlotUsage{
+			{Scope: executor.ScopeRepo, Key: "acme/api", Limit: 2, Active: 2, Waiting: 1},
+			{Scope: executor.ScopeProject, Key: "/srv/web", Limit: 3, Active: 1},
+		},
+		Pools: []executor.WorktreePoolUsage{{ProjectPath: "/srv/web", Size: 2, InUse: 1, Target: 3}},
+	}
+	plain := stripANSI(m.renderCapacity())
+	for _, want := range []string{"CAPACITY", "repo acme/api", "2/2 running · 1 waiting", "project web", "1/3 running", "pool web", "1/2 busy · target 3"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("capacity panel missing %q:\n%s", want, plain)
+		}
+	}
+}
+
+type fakeFairnessSource executor.FairnessSnapshot
+
+func (f fakeFairnessSource) FairnessSnapshot() executor.FairnessSnapshot {
+	return executor.FairnessSnapshot(f)
+}
+
+func TestRenderQueue(t *testing.T) {
+	if got := (Model{}).renderQueue(); got != "" {
+		t.Errorf("expected empty string without a source, got %q", got)
+	}
+	m := Model{fairness: fakeFairnessSource{Slots: 2}}
+	if got := m.renderQueue(); got != "" {
+		t.Errorf("expected empty panel before any scheduling, got %q", got)
+	}
+
+	m.fairness = fakeFairnessSource{
+		Slots:               2,
+		Active:              2,
+		StarvationThreshold: 15 * time.Minute,
+		Projects: []executor.FairShare{
+			{Key: "/srv/api", Weight: 3, Queued: 4, Dispatched: 12, TotalWait: 24 * time.Minute},
+			{Key: "/srv/docs", Weight: 1, Queued: 1, Waiting: true, Wait: 40 * time.Minute, Dispatched: 1},
+			{Key: "/srv/web", Weight: 1, Queued: 2, Waiting: true, Wait: 90 * time.Second},
+		},
+		Requesters: []executor.FairShare{
+			{Key: "alice", Weight: 1, Dispatched: 2, MaxWait: 20 * time.Minute, Starved: 1},
+			{Key: "bob", Weight: 2, Dispatched: 11, MaxWait: 5 * time.Minute},
+		},
+	}
+	plain := stripANSI(m.renderQueue())
+	for _, want := range []string{
+		"QUEUE", "2/2 running · 1 starving",
+		"api ×3", "4 queued · 12 run · avg 2m",
+		"docs ×1", "starving 40m",
+		"web ×1", "waiting 1m",
+		"@alice ×1", "2 run · max wait 20m · 1 starved",
+		"@bob ×2", "11 run · max wait 5m",
+	} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("queue panel missing %q:\n%s", want, plain)
+		}
+	}
+	// Busiest requesters first
+	if strings.Index(plain, "@bob") > strings.Index(plain, "@alice") {
+		t.Errorf("requesters not sorted by tasks run:\n%s", plain)
+	}
+}
+
+func TestPauseToggle(t *testing.T) {
+	m := Model{}
+	if got := m.renderPaused(); got != "" {
+		t.Errorf("expected empty string without a pause control, got %q", got)
+	}
+
+	pause := executor.NewPipelinePause(nil)
+	m.SetPauseControl(pause)
+	if got := m.renderPaused(); got != "" {
+		t.Errorf("expected no panel while running, got %q", got)
+	}
+	if help := stripANSI(m.renderHelp()); !strings.Contains(help, "p: pause") {
+		t.Errorf("help missing pause key: %q", help)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if cmd == nil {
+		t.Fatal("p key returned no command")
+	}
+	if msg, ok := cmd().(addLogMsg); !ok || !strings.Contains(string(msg), "paused") {
+		t.Errorf("toggle message = %v", msg)
+	}
+	plain := stripANSI(m.renderPaused())
+	for _, want := range []string{"PAUSED", "No new work is dispatched", "paused from dashboard", "p: resume"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("paused panel missing %q:\n%s", want, plain)
+		}
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	cmd()
+	if pause.Paused() {
+		t.Error("second p press should resume the pipeline")
+	}
+}
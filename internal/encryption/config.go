@@ -0,0 +1,108 @@
+package encryption
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Keychain entry holding the key: the service name on macOS and the
+// "service" attribute of the secret on Linux.
+const (
+	KeychainService = "pilot-encryption"
+	KeychainAccount = "pilot"
+)
+
+// Config controls encryption at rest.
+//
+// Example YAML configuration:
+//
+//	encryption:
+//	  enabled: true
+//	  key_file: ~/.pilot/encryption.key
+type Config struct {
+	// Enabled encrypts recordings and the memory store.
+	Enabled bool `yaml:"enabled"`
+	// KeyFile holds the base64-encoded 32-byte key.
+	KeyFile string `yaml:"key_file,omitempty"`
+	// Keychain reads the key from the OS keychain instead (macOS Keychain
+	// or the Linux Secret Service).
+	Keychain bool `yaml:"keychain,omitempty"`
+}
+
+// Validate checks that an enabled config names exactly one key source.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.KeyFile == "" && !c.Keychain {
+		return fmt.Errorf("key_file or keychain is required when enabled")
+	}
+	if c.KeyFile != "" && c.Keychain {
+		return fmt.Errorf("key_file and keychain are mutually exclusive")
+	}
+	return nil
+}
+
+// LoadKey reads the key from the configured source.
+func LoadKey(cfg *Config) (*Key, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Keychain {
+		encoded, err := readKeychain()
+		if err != nil {
+			return nil, err
+		}
+		return ParseKey(encoded)
+	}
+
+	path := expandHome(cfg.KeyFile)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	if info.Mode().Perm()&0077 != 0 && runtime.GOOS != "windows" {
+		return nil, fmt.Errorf("key file %s is accessible by other users, run: chmod 600 %s", path, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return ParseKey(string(data))
+}
+
+// readKeychain looks the key up with the platform's keychain CLI.
+func readKeychain() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", KeychainService, "-a", KeychainAccount, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", KeychainService, "account", KeychainAccount)
+	default:
+		return "", fmt.Errorf("keychain is not supported on %s, use key_file", runtime.GOOS)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read key from keychain (%s): %w", cmd.Path, err)
+	}
+	encoded := strings.TrimSpace(string(out))
+	if encoded == "" {
+		return "", fmt.Errorf("keychain entry %q is empty", KeychainService)
+	}
+	return encoded, nil
+}
+
+// expandHome expands a leading ~ in path.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
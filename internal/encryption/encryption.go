@@ -0,0 +1,356 @@
+// Package encryption encrypts Pilot's local data at rest: execution
+// recordings and the SQLite memory store. Data is sealed with AES-256-GCM
+// under a key read from a key file or the OS keychain.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// KeySize is the size of an encryption key in bytes (AES-256).
+const KeySize = 32
+
+// chunkSize is the plaintext size of one sealed chunk. Files are sealed in
+// chunks so large databases never have to fit in memory.
+const chunkSize = 64 * 1024
+
+// magic starts every sealed file, followed by a version byte.
+var magic = []byte("PILOTENC")
+
+const formatVersion = 1
+
+// linePrefix marks a sealed line in a line-oriented file such as a
+// recording's stream.jsonl.
+const linePrefix = "enc:v1:"
+
+// ErrKeyUnavailable is returned when data is encrypted but no key is
+// configured or the configured key cannot be loaded.
+var ErrKeyUnavailable = errors.New("encryption key unavailable")
+
+// Key seals and opens data.
+type Key struct {
+	aead cipher.AEAD
+}
+
+// NewKey creates a key from KeySize raw bytes.
+func NewKey(raw []byte) (*Key, error) {
+	if len(raw) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{aead: aead}, nil
+}
+
+// ParseKey decodes a base64-encoded key, as stored in key files and the
+// keychain.
+func ParseKey(encoded string) (*Key, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	return NewKey(raw)
+}
+
+// GenerateKey returns a new random key, base64-encoded.
+func GenerateKey() (string, error) {
+	raw := make([]byte, KeySize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+var (
+	defaultKey *Key
+	defaultErr error
+	defaultMu  sync.RWMutex
+)
+
+// Init loads the key of cfg as the process-wide default used by the
+// recorder and the memory store. A nil or disabled config turns encryption
+// off. When the key cannot be loaded, writes that would be encrypted fail
+// with the returned error instead of falling back to plaintext.
+func Init(cfg *Config) error {
+	var key *Key
+	var err error
+	if cfg != nil && cfg.Enabled {
+		key, err = LoadKey(cfg)
+		if err != nil {
+			err = fmt.Errorf("%w: %v", ErrKeyUnavailable, err)
+		}
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultKey, defaultErr = key, err
+	return err
+}
+
+// SetDefault sets the process-wide key directly; nil turns encryption off.
+func SetDefault(key *Key) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultKey, defaultErr = key, nil
+}
+
+// Default returns the process-wide key. Both are nil when encryption is
+// off; the error is set when encryption is on but the key is unavailable.
+func Default() (*Key, error) {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultKey, defaultErr
+}
+
+// Seal encrypts plaintext.
+func (k *Key) Seal(plaintext []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := k.Encrypt(&buf, bytes.NewReader(plaintext)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Open decrypts data sealed by Seal or Encrypt.
+func (k *Key) Open(sealed []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := k.Decrypt(&buf, bytes.NewReader(sealed)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Encrypt streams r to w sealed. The output is the magic header, an 8-byte
+// nonce prefix and length-prefixed chunks. Each chunk's nonce is the prefix
+// and its index, and the last chunk is authenticated as such, so reordered
+// or truncated files fail to decrypt.
+func (k *Key) Encrypt(w io.Writer, r io.Reader) error {
+	prefix := make([]byte, 8)
+	if _, err := rand.Read(prefix); err != nil {
+		return err
+	}
+	header := append(append(append([]byte{}, magic...), formatVersion), prefix...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	var sealed []byte
+	for index := uint32(0); ; index++ {
+		n, err := io.ReadFull(r, buf)
+		final := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !final {
+			return err
+		}
+
+		sealed = k.aead.Seal(sealed[:0], chunkNonce(prefix, index), buf[:n], chunkAD(final))
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(sealed)))
+		if _, err := w.Write(length[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(sealed); err != nil {
+			return err
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// Decrypt streams sealed data from r to w.
+func (k *Key) Decrypt(w io.Writer, r io.Reader) error {
+	header := make([]byte, len(magic)+1+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("not an encrypted file: %w", err)
+	}
+	if !bytes.Equal(header[:len(magic)], magic) {
+		return fmt.Errorf("not an encrypted file")
+	}
+	if v := header[len(magic)]; v != formatVersion {
+		return fmt.Errorf("unsupported encryption format version %d", v)
+	}
+	prefix := header[len(magic)+1:]
+
+	maxSealed := uint32(chunkSize + k.aead.Overhead())
+	sealed := make([]byte, maxSealed)
+	var plain []byte
+	for index := uint32(0); ; index++ {
+		var length [4]byte
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			return fmt.Errorf("encrypted data is truncated")
+		}
+		n := binary.BigEndian.Uint32(length[:])
+		if n > maxSealed {
+			return fmt.Errorf("encrypted data is corrupt")
+		}
+		if _, err := io.ReadFull(r, sealed[:n]); err != nil {
+			return fmt.Errorf("encrypted data is truncated")
+		}
+
+		nonce := chunkNonce(prefix, index)
+		final := false
+		var err error
+		plain, err = k.aead.Open(plain[:0], nonce, sealed[:n], chunkAD(false))
+		if err != nil {
+			plain, err = k.aead.Open(plain[:0], nonce, sealed[:n], chunkAD(true))
+			if err != nil {
+				return fmt.Errorf("decryption failed: wrong key or corrupt data")
+			}
+			final = true
+		}
+		if _, err := w.Write(plain); err != nil {
+			return err
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+func chunkNonce(prefix []byte, index uint32) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[8:], index)
+	return nonce
+}
+
+func chunkAD(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// IsEncrypted reports whether data starts with the sealed file header.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// SealLine encrypts one line of a line-oriented file. The result has no
+// newlines.
+func (k *Key) SealLine(line []byte) (string, error) {
+	sealed, err := k.Seal(line)
+	if err != nil {
+		return "", err
+	}
+	return linePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenLine decrypts a line sealed by SealLine. Plaintext lines are returned
+// as they are, so files written before encryption was enabled stay
+// readable.
+func OpenLine(line []byte) ([]byte, error) {
+	if !bytes.HasPrefix(line, []byte(linePrefix)) {
+		return line, nil
+	}
+	key, err := requireKey()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(string(line[len(linePrefix):]))
+	if err != nil {
+		return nil, fmt.Errorf("encrypted line is corrupt: %w", err)
+	}
+	return key.Open(sealed)
+}
+
+// WriteFile writes data to path, sealed with the default key when
+// encryption is on.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	key, err := Default()
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return os.WriteFile(path, data, perm)
+	}
+	sealed, err := key.Seal(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, sealed, perm)
+}
+
+// ReadFile reads path, decrypting it with the default key when it is
+// sealed. Plaintext files are returned as they are.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil || !IsEncrypted(data) {
+		return data, err
+	}
+	key, err := requireKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key.Open(data)
+}
+
+// EncryptFile seals src into dst. dst is replaced atomically, so a crash
+// never leaves a partial file behind.
+func (k *Key) EncryptFile(src, dst string) error {
+	return transformFile(src, dst, k.Encrypt)
+}
+
+// DecryptFile opens the sealed src into dst, replacing dst atomically.
+func (k *Key) DecryptFile(src, dst string) error {
+	return transformFile(src, dst, k.Decrypt)
+}
+
+func transformFile(src, dst string, transform func(io.Writer, io.Reader) error) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := out.Name()
+	defer func() { _ = os.Remove(tmp) }()
+
+	if err := transform(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// requireKey returns the default key for reading sealed data.
+func requireKey() (*Key, error) {
+	key, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: data is encrypted but encryption is not configured", ErrKeyUnavailable)
+	}
+	return key, nil
+}
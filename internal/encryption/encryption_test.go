@@ -0,0 +1,197 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(t *testing.T) *Key {
+	t.Helper()
+	encoded, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	key, err := ParseKey(encoded + "\n")
+	if err != nil {
+		t.Fatalf("ParseKey failed: %v", err)
+	}
+	return key
+}
+
+func TestSealOpen(t *testing.T) {
+	key := testKey(t)
+
+	large := make([]byte, 3*chunkSize+17)
+	_, _ = rand.Read(large)
+	for name, plaintext := range map[string][]byte{
+		"empty":        {},
+		"short":        []byte("implement the login flow"),
+		"exact chunk":  bytes.Repeat([]byte("a"), chunkSize),
+		"multi chunks": large,
+	} {
+		t.Run(name, func(t *testing.T) {
+			sealed, err := key.Seal(plaintext)
+			if err != nil {
+				t.Fatalf("Seal failed: %v", err)
+			}
+			if !IsEncrypted(sealed) {
+				t.Error("sealed data should be recognized as encrypted")
+			}
+			if len(plaintext) > 0 && bytes.Contains(sealed, plaintext) {
+				t.Error("sealed data contains the plaintext")
+			}
+			opened, err := key.Open(sealed)
+			if err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			if !bytes.Equal(opened, plaintext) {
+				t.Errorf("round trip changed %d bytes into %d", len(plaintext), len(opened))
+			}
+		})
+	}
+}
+
+func TestOpen_Rejects(t *testing.T) {
+	key := testKey(t)
+	sealed, err := key.Seal(bytes.Repeat([]byte("x"), 2*chunkSize+5))
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	if _, err := testKey(t).Open(sealed); err == nil {
+		t.Error("opening with another key should fail")
+	}
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := key.Open(tampered); err == nil {
+		t.Error("tampered data should fail to decrypt")
+	}
+
+	// Dropping the final chunk must not pass as a shorter file
+	firstTwo := len(magic) + 1 + 8 + 2*(4+chunkSize+key.aead.Overhead())
+	if _, err := key.Open(sealed[:firstTwo]); err == nil || !strings.Contains(err.Error(), "truncated") {
+		t.Errorf("truncated data: err = %v, want truncated", err)
+	}
+
+	if _, err := key.Open([]byte("plain text")); err == nil {
+		t.Error("plaintext should not decrypt")
+	}
+}
+
+func TestKeyFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	encoded, _ := GenerateKey()
+	keyFile := filepath.Join(dir, "encryption.key")
+	if err := os.WriteFile(keyFile, []byte(encoded+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKey(&Config{Enabled: true, KeyFile: keyFile}); err != nil {
+		t.Errorf("LoadKey failed: %v", err)
+	}
+
+	if err := os.Chmod(keyFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKey(&Config{Enabled: true, KeyFile: keyFile}); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+		t.Errorf("world-readable key file: err = %v", err)
+	}
+
+	short := filepath.Join(dir, "short.key")
+	_ = os.WriteFile(short, []byte("c2hvcnQ="), 0600)
+	if _, err := LoadKey(&Config{Enabled: true, KeyFile: short}); err == nil {
+		t.Error("short key should be rejected")
+	}
+
+	for _, cfg := range []*Config{
+		{Enabled: true},
+		{Enabled: true, KeyFile: keyFile, Keychain: true},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", cfg)
+		}
+	}
+	if err := (&Config{}).Validate(); err != nil {
+		t.Errorf("disabled config should be valid: %v", err)
+	}
+}
+
+func TestDefaultKeyFiles(t *testing.T) {
+	defer SetDefault(nil)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "metadata.json")
+
+	// Written before encryption was enabled
+	if err := WriteFile(path, []byte(`{"id":"TG-1"}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	key := testKey(t)
+	SetDefault(key)
+	if data, err := ReadFile(path); err != nil || string(data) != `{"id":"TG-1"}` {
+		t.Errorf("plaintext file: %q, %v", data, err)
+	}
+
+	if err := WriteFile(path, []byte(`{"id":"TG-2"}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	if !IsEncrypted(raw) {
+		t.Fatal("file should be written encrypted")
+	}
+	if data, err := ReadFile(path); err != nil || string(data) != `{"id":"TG-2"}` {
+		t.Errorf("encrypted file: %q, %v", data, err)
+	}
+
+	line, err := key.SealLine([]byte(`{"seq":1}`))
+	if err != nil || strings.Contains(line, "seq") {
+		t.Fatalf("SealLine = %q, %v", line, err)
+	}
+	if got, err := OpenLine([]byte(line)); err != nil || string(got) != `{"seq":1}` {
+		t.Errorf("OpenLine = %q, %v", got, err)
+	}
+
+	SetDefault(nil)
+	if _, err := ReadFile(path); !errors.Is(err, ErrKeyUnavailable) {
+		t.Errorf("reading without a key: err = %v, want ErrKeyUnavailable", err)
+	}
+	if _, err := OpenLine([]byte(line)); !errors.Is(err, ErrKeyUnavailable) {
+		t.Errorf("opening a line without a key: err = %v, want ErrKeyUnavailable", err)
+	}
+
+	if err := Init(&Config{Enabled: true, KeyFile: filepath.Join(dir, "missing.key")}); !errors.Is(err, ErrKeyUnavailable) {
+		t.Fatalf("Init with a missing key file: err = %v", err)
+	}
+	if err := WriteFile(path, []byte("{}"), 0644); !errors.Is(err, ErrKeyUnavailable) {
+		t.Errorf("writing with encryption on but no key: err = %v, want ErrKeyUnavailable", err)
+	}
+}
+
+func TestEncryptFile(t *testing.T) {
+	key := testKey(t)
+	dir := t.TempDir()
+	src := filepath.Join(dir, "pilot.db")
+	content := bytes.Repeat([]byte("SQLite format 3\x00"), 10000)
+	_ = os.WriteFile(src, content, 0600)
+
+	if err := key.EncryptFile(src, src+".enc"); err != nil {
+		t.Fatalf("EncryptFile failed: %v", err)
+	}
+	out := filepath.Join(dir, "restored.db")
+	if err := key.DecryptFile(src+".enc", out); err != nil {
+		t.Fatalf("DecryptFile failed: %v", err)
+	}
+	restored, _ := os.ReadFile(out)
+	if !bytes.Equal(restored, content) {
+		t.Error("decrypted file differs from the original")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 3 {
+		t.Errorf("dir has %d entries, want no temp files left", len(entries))
+	}
+}
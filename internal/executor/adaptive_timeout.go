@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AdaptiveTimeoutConfig derives execution timeouts from historical durations
+// of the same project and complexity class instead of the static table.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  timeout:
+//	    adaptive:
+//	      enabled: true
+//	      percentile: 0.95   # p95 of recent successful runs
+//	      multiplier: 1.5    # headroom on top of the percentile
+//	      min_samples: 5     # fall back to static timeouts below this
+//	      window: 50         # most recent runs considered
+//	      floor: "5m"
+//	      ceiling: "2h"
+type AdaptiveTimeoutConfig struct {
+	// Enabled turns on adaptive timeouts
+	Enabled bool `yaml:"enabled"`
+
+	// Percentile of historical durations used as the baseline (0 < p <= 1)
+	Percentile float64 `yaml:"percentile"`
+
+	// Multiplier is applied to the percentile duration
+	Multiplier float64 `yaml:"multiplier"`
+
+	// MinSamples is the number of recorded runs required before adapting
+	MinSamples int `yaml:"min_samples"`
+
+	// Window is the number of most recent runs considered
+	Window int `yaml:"window"`
+
+	// Floor is the lowest timeout adaptive mode may choose
+	Floor string `yaml:"floor"`
+
+	// Ceiling is the highest timeout adaptive mode may choose
+	Ceiling string `yaml:"ceiling"`
+}
+
+// DefaultAdaptiveTimeoutConfig returns default adaptive timeout settings.
+// Adaptive timeouts are disabled by default.
+func DefaultAdaptiveTimeoutConfig() *AdaptiveTimeoutConfig {
+	return &AdaptiveTimeoutConfig{
+		Enabled:    false,
+		Percentile: 0.95,
+		Multiplier: 1.5,
+		MinSamples: 5,
+		Window:     50,
+		Floor:      "5m",
+		Ceiling:    "2h",
+	}
+}
+
+// DurationHistory records and returns execution durations per project and
+// complexity class. Satisfied by *memory.Store.
+type DurationHistory interface {
+	RecordTaskDuration(projectPath, complexity string, duration time.Duration) error
+	GetTaskDurations(projectPath, complexity string, limit int) ([]time.Duration, error)
+}
+
+// adaptiveTimeout computes a timeout from samples: the configured percentile
+// times the multiplier, clamped to [floor, ceiling]. Returns false when there
+// are not enough samples to adapt.
+func (c *AdaptiveTimeoutConfig) adaptiveTimeout(samples []time.Duration) (time.Duration, bool) {
+	minSamples := c.MinSamples
+	if minSamples <= 0 {
+		minSamples = 1
+	}
+	if len(samples) < minSamples {
+		return 0, false
+	}
+
+	percentile := c.Percentile
+	if percentile <= 0 || percentile > 1 {
+		percentile = 0.95
+	}
+	multiplier := c.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1.5
+	}
+
+	timeout := time.Duration(float64(durationPercentile(samples, percentile)) * multiplier)
+
+	if floor, err := time.ParseDuration(c.Floor); err == nil && timeout < floor {
+		timeout = floor
+	}
+	if ceiling, err := time.ParseDuration(c.Ceiling); err == nil && ceiling > 0 && timeout > ceiling {
+		timeout = ceiling
+	}
+	return timeout, true
+}
+
+// durationPercentile returns the nearest-rank percentile p (0 < p <= 1) of samples.
+func durationPercentile(samples []time.Duration, p float64) time.Duration {
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
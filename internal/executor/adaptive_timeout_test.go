@@ -0,0 +1,168 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeDurationHistory struct {
+	samples  map[string][]time.Duration
+	recorded map[string][]time.Duration
+	err      error
+}
+
+func newFakeDurationHistory() *fakeDurationHistory {
+	return &fakeDurationHistory{
+		samples:  make(map[string][]time.Duration),
+		recorded: make(map[string][]time.Duration),
+	}
+}
+
+func (f *fakeDurationHistory) RecordTaskDuration(projectPath, complexity string, duration time.Duration) error {
+	key := projectPath + "|" + complexity
+	f.recorded[key] = append(f.recorded[key], duration)
+	return nil
+}
+
+func (f *fakeDurationHistory) GetTaskDurations(projectPath, complexity string, limit int) ([]time.Duration, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	samples := f.samples[projectPath+"|"+complexity]
+	if len(samples) > limit {
+		samples = samples[:limit]
+	}
+	return samples, nil
+}
+
+func minutes(ms ...int) []time.Duration {
+	out := make([]time.Duration, len(ms))
+	for i, m := range ms {
+		out[i] = time.Duration(m) * time.Minute
+	}
+	return out
+}
+
+func TestDurationPercentile(t *testing.T) {
+	samples := minutes(10, 1, 9, 2, 8, 3, 7, 4, 6, 5)
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0.5, 5 * time.Minute},
+		{0.95, 10 * time.Minute},
+		{0.9, 9 * time.Minute},
+		{0.01, time.Minute},
+		{1, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := durationPercentile(samples, tt.p); got != tt.want {
+			t.Errorf("durationPercentile(p=%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestAdaptiveTimeoutConfig_Compute(t *testing.T) {
+	cfg := &AdaptiveTimeoutConfig{
+		Enabled:    true,
+		Percentile: 0.95,
+		Multiplier: 1.5,
+		MinSamples: 3,
+		Floor:      "5m",
+		Ceiling:    "2h",
+	}
+
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		want    time.Duration
+		wantOK  bool
+	}{
+		{"too few samples", minutes(10, 20), 0, false},
+		{"p95 times multiplier", minutes(10, 20, 40), 60 * time.Minute, true},
+		{"clamped to floor", minutes(1, 1, 2), 5 * time.Minute, true},
+		{"clamped to ceiling", minutes(60, 90, 120), 2 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cfg.adaptiveTimeout(tt.samples)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("adaptiveTimeout() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestModelRouter_SelectTimeout_Adaptive(t *testing.T) {
+	task := &Task{Description: "Fix typo", ProjectPath: "/repo"}
+	key := "/repo|" + string(DetectComplexity(task))
+
+	newRouter := func(enabled bool, history DurationHistory) *ModelRouter {
+		cfg := DefaultTimeoutConfig()
+		cfg.Adaptive.Enabled = enabled
+		cfg.Adaptive.MinSamples = 3
+		router := NewModelRouter(nil, cfg)
+		router.SetDurationHistory(history)
+		return router
+	}
+
+	t.Run("uses history when enabled", func(t *testing.T) {
+		history := newFakeDurationHistory()
+		history.samples[key] = minutes(8, 10, 12)
+
+		got := newRouter(true, history).SelectTimeout(task)
+		if got != 18*time.Minute {
+			t.Errorf("SelectTimeout() = %v, want 18m", got)
+		}
+	})
+
+	t.Run("static when disabled", func(t *testing.T) {
+		history := newFakeDurationHistory()
+		history.samples[key] = minutes(8, 10, 12)
+
+		got := newRouter(false, history).SelectTimeout(task)
+		if got != 5*time.Minute {
+			t.Errorf("SelectTimeout() = %v, want static 5m", got)
+		}
+	})
+
+	t.Run("static with insufficient history", func(t *testing.T) {
+		history := newFakeDurationHistory()
+		history.samples[key] = minutes(8)
+
+		got := newRouter(true, history).SelectTimeout(task)
+		if got != 5*time.Minute {
+			t.Errorf("SelectTimeout() = %v, want static 5m", got)
+		}
+	})
+
+	t.Run("static on history error", func(t *testing.T) {
+		history := newFakeDurationHistory()
+		history.err = errors.New("db locked")
+
+		got := newRouter(true, history).SelectTimeout(task)
+		if got != 5*time.Minute {
+			t.Errorf("SelectTimeout() = %v, want static 5m", got)
+		}
+	})
+}
+
+func TestRunner_RecordDuration(t *testing.T) {
+	runner := NewRunner()
+	history := newFakeDurationHistory()
+	runner.SetDurationHistory(history)
+
+	task := &Task{ID: "TASK-1", ProjectPath: "/repo"}
+	runner.recordDuration(task, &ExecutionResult{Success: true}, ComplexitySimple, 7*time.Minute)
+	runner.recordDuration(task, &ExecutionResult{Success: false}, ComplexitySimple, time.Hour)
+
+	got := history.recorded["/repo|"+string(ComplexitySimple)]
+	if len(got) != 1 || got[0] != 7*time.Minute {
+		t.Errorf("recorded = %v, want only the successful 7m run", got)
+	}
+	if runner.ModelRouter().durationHistory != history {
+		t.Error("expected duration history to be wired into the model router")
+	}
+}
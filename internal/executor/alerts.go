@@ -46,4 +46,10 @@ const (
 	AlertEventTypeStagnationWarn  AlertEventType = "stagnation_warn"
 	AlertEventTypeStagnationPause AlertEventType = "stagnation_pause"
 	AlertEventTypeStagnationAbort AlertEventType = "stagnation_abort"
+
+	// The agent signalled it is blocked (BLOCKED step)
+	AlertEventTypeTaskBlocked AlertEventType = "task_blocked"
+
+	// Budget-aware routing downgraded a model or deferred a task
+	AlertEventTypeBudgetRouting AlertEventType = "budget_routing"
 )
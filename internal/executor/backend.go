@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -110,6 +111,15 @@ type BackendEvent struct {
 	// CacheReadInputTokens is the cache read input token count (GH-2164)
 	CacheReadInputTokens int64
 
+	// MessageID identifies the assistant message the event belongs to (if available)
+	MessageID string
+
+	// MessageTokensInput and MessageTokensOutput are the usage of the assistant
+	// message, for attributing tokens to phases and tools. They are already
+	// included in the totals reported by the result event.
+	MessageTokensInput  int64
+	MessageTokensOutput int64
+
 	// Model is the model name used (if available)
 	Model string
 
@@ -192,7 +202,7 @@ type BackendResult struct {
 
 // BackendConfig contains configuration for executor backends.
 type BackendConfig struct {
-	// Type specifies which backend to use ("claude-code", "opencode", or "qwen-code")
+	// Type specifies which backend to use ("claude-code", "opencode", "qwen-code", "openai", "gemini", or "ollama")
 	Type string `yaml:"type"`
 
 	// AutoCreatePR controls whether PRs are created by default after successful execution.
@@ -204,6 +214,15 @@ type BackendConfig struct {
 	// Intended for users who rely on manual QA instead of code review.
 	DirectCommit bool `yaml:"direct_commit,omitempty"`
 
+	// DraftPRs opens PRs as drafts. Autopilot marks a draft PR ready for
+	// review once CI passes, so red PRs stay out of reviewers' queues.
+	// Forges without gh CLI support (e.g. Gitea) keep opening regular PRs.
+	DraftPRs bool `yaml:"draft_prs,omitempty"`
+
+	// CommitPolicy rewrites the messages of the commits a task pushes to
+	// follow conventional commits, scoped by the paths they change.
+	CommitPolicy *CommitPolicyConfig `yaml:"commit_policy,omitempty"`
+
 	// DetectEphemeral enables automatic detection of ephemeral tasks (serve, run, etc.)
 	// that shouldn't create PRs. When true (default), commands like "serve the app"
 	// or "run dev server" will execute without creating a PR.
@@ -223,6 +242,24 @@ type BackendConfig struct {
 	// QwenCode contains Qwen Code specific settings
 	QwenCode *QwenCodeConfig `yaml:"qwen_code,omitempty"`
 
+	// OpenAI contains OpenAI (Codex CLI) specific settings
+	OpenAI *OpenAIConfig `yaml:"openai,omitempty"`
+
+	// Gemini contains Gemini CLI specific settings. Also used when model routing
+	// sends a task to a gemini-* model while another backend is primary.
+	Gemini *GeminiConfig `yaml:"gemini,omitempty"`
+
+	// Ollama contains settings for local models served by Ollama
+	Ollama *OllamaConfig `yaml:"ollama,omitempty"`
+
+	// AllowedBackends limits which backends a task may pick with Task.Backend
+	// or a "backend:<name>" issue label. Empty allows any registered backend.
+	AllowedBackends []string `yaml:"allowed_backends,omitempty"`
+
+	// FallbackBackend re-runs a task on another backend when the primary one
+	// fails with an infrastructure error (crash, auth, rate limit). Empty disables failover.
+	FallbackBackend string `yaml:"fallback_backend,omitempty"`
+
 	// ModelRouting contains model selection based on task complexity
 	ModelRouting *ModelRoutingConfig `yaml:"model_routing,omitempty"`
 
@@ -235,6 +272,14 @@ type BackendConfig struct {
 	// EffortClassifier contains LLM-based effort classification settings (GH-727)
 	EffortClassifier *EffortClassifierConfig `yaml:"effort_classifier,omitempty"`
 
+	// Triage classifies issues before execution and asks for clarification
+	// instead of executing questions, duplicates and vague requests
+	Triage *TriageConfig `yaml:"triage,omitempty"`
+
+	// Clarification sets how long a task waits for the requester to answer a
+	// clarifying question, and what happens when nobody does
+	Clarification *ClarificationConfig `yaml:"clarification,omitempty"`
+
 	// Decompose contains auto-decomposition settings for complex tasks
 	Decompose *DecomposeConfig `yaml:"decompose,omitempty"`
 
@@ -260,6 +305,11 @@ type BackendConfig struct {
 	// Default: 0 (disabled)
 	WorktreePoolSize int `yaml:"worktree_pool_size,omitempty"`
 
+	// Concurrency limits parallel tasks per repository and per project, and
+	// can autoscale a worktree pool per project to match.
+	// Default: nil (only orchestrator.max_concurrent applies)
+	Concurrency *ConcurrencyConfig `yaml:"concurrency,omitempty"`
+
 	// SyncMainAfterTask enables syncing the local main branch with origin after task completion.
 	// When true, Pilot fetches origin/main and resets local main to match after each task.
 	// This prevents local/remote divergence over time.
@@ -273,6 +323,13 @@ type BackendConfig struct {
 	// Stagnation contains stagnation detection settings (GH-925)
 	Stagnation *StagnationConfig `yaml:"stagnation,omitempty"`
 
+	// ChurnGuard cancels executions that modify files at a runaway rate
+	ChurnGuard *ChurnGuardConfig `yaml:"churn_guard,omitempty"`
+
+	// Policy restricts the paths a task may change, the commands it may run
+	// and its diff size. Violations abort the task before anything is pushed.
+	Policy *PolicyConfig `yaml:"policy,omitempty"`
+
 	// Simplification contains code simplification settings (GH-995)
 	// When enabled, Pilot auto-simplifies code after implementation for clarity.
 	Simplification *SimplifyConfig `yaml:"simplification,omitempty"`
@@ -314,6 +371,23 @@ func (c *BackendConfig) EffectiveHeartbeatTimeout() time.Duration {
 	return c.HeartbeatTimeout
 }
 
+// BackendAllowed reports whether a task may run on the named backend.
+// Unregistered backends are never allowed.
+func (c *BackendConfig) BackendAllowed(name string) bool {
+	if !IsRegisteredBackend(name) {
+		return false
+	}
+	if c == nil || len(c.AllowedBackends) == 0 {
+		return true
+	}
+	for _, allowed := range c.AllowedBackends {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // ModelRoutingConfig controls which model to use based on task complexity.
 // Enables cost optimization by using cheaper models for simple tasks.
 //
@@ -349,6 +423,47 @@ type ModelRoutingConfig struct {
 	// Complex is the model for architectural work (refactors, migrations, new systems).
 	// Default: "claude-opus"
 	Complex string `yaml:"complex"`
+
+	// Review is the model for the autopilot code review of created PRs.
+	// Applies even when routing is disabled.
+	// Default: "claude-haiku-4-5-20251001"
+	Review string `yaml:"review,omitempty"`
+
+	// Budget makes routing cost-aware: as the day's spend approaches the
+	// daily budget, tasks route to cheaper models and low-priority tasks
+	// wait for the next day. Requires budget enforcement with a daily limit.
+	Budget *BudgetRoutingConfig `yaml:"budget,omitempty"`
+}
+
+// BudgetRoutingConfig sets the share of the daily budget at which routing
+// degrades.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  model_routing:
+//	    budget:
+//	      enabled: true
+//	      downgrade_at: 70   # % of daily limit: one tier cheaper
+//	      defer_at: 90       # % of daily limit: low-priority tasks wait
+//	      low_priority_labels: ["priority:low"]
+type BudgetRoutingConfig struct {
+	// Enabled turns on budget-aware routing. Default: false
+	Enabled bool `yaml:"enabled"`
+
+	// DowngradeAt is the daily spend, in percent of the daily limit, from
+	// which tasks run on the model of the next cheaper complexity tier
+	// (complex → medium → simple → trivial). Only applies when model routing
+	// is enabled. Default: 70
+	DowngradeAt float64 `yaml:"downgrade_at,omitempty"`
+
+	// DeferAt is the daily spend, in percent of the daily limit, from which
+	// low-priority tasks are deferred to the next day. Default: 90
+	DeferAt float64 `yaml:"defer_at,omitempty"`
+
+	// LowPriorityLabels mark the tasks that may be deferred.
+	// Default: ["priority:low", "low-priority"]
+	LowPriorityLabels []string `yaml:"low_priority_labels,omitempty"`
 }
 
 // TimeoutConfig controls execution timeouts to prevent stuck tasks.
@@ -367,6 +482,9 @@ type TimeoutConfig struct {
 
 	// Complex is the timeout for complex tasks (longer)
 	Complex string `yaml:"complex"`
+
+	// Adaptive derives timeouts from historical durations when enough data exists
+	Adaptive *AdaptiveTimeoutConfig `yaml:"adaptive,omitempty"`
 }
 
 // EffortRoutingConfig controls the effort level based on task complexity.
@@ -437,6 +555,82 @@ func DefaultEffortClassifierConfig() *EffortClassifierConfig {
 	}
 }
 
+// TriageConfig configures the pre-execution triage pass. A cheap model
+// classifies each picked-up issue before any tokens are spent on it.
+// Questions, duplicates of recent tasks and issues without acceptance
+// criteria are handed back to the requester with clarifying questions and
+// the pilot-needs-info label instead of being executed. Issues labeled
+// no-triage skip the pass.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  triage:
+//	    enabled: true
+//	    model: "claude-haiku-4-5-20251001"
+//	    timeout: 30s
+//	    flag: [question, duplicate, missing_criteria]
+type TriageConfig struct {
+	// Enabled controls whether issues are triaged before execution.
+	// Default: false.
+	Enabled bool `yaml:"enabled"`
+
+	// Model is the model used for triage.
+	// Default: "claude-haiku-4-5-20251001"
+	Model string `yaml:"model,omitempty"`
+
+	// Timeout is the maximum time to wait for the triage response. On
+	// timeout or any other triage error the task is executed.
+	// Default: "30s"
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Flag lists the verdicts that stop execution: question, duplicate,
+	// missing_criteria. Empty flags all three.
+	Flag []string `yaml:"flag,omitempty"`
+}
+
+// DefaultTriageConfig returns default triage settings. Triage is opt-in.
+func DefaultTriageConfig() *TriageConfig {
+	return &TriageConfig{
+		Enabled: false,
+		Model:   "claude-haiku-4-5-20251001",
+		Timeout: "30s",
+	}
+}
+
+// ClarificationConfig configures the clarification loop. When the agent
+// stops on a BLOCKED signal or triage flags an issue, Pilot asks the
+// requester in the channel the task came from (the issue, or the Telegram
+// or Slack thread), keeps the task's state and resumes it once an answer
+// arrives.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  clarification:
+//	    timeout: 72h
+//	    on_timeout: proceed
+type ClarificationConfig struct {
+	// Timeout is how long a question waits for an answer. Empty waits
+	// indefinitely.
+	// Default: ""
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// OnTimeout is what happens when the timeout passes without an answer:
+	// "fail" gives the task up, "proceed" resumes it and lets the agent
+	// make its best judgement.
+	// Default: "fail"
+	OnTimeout string `yaml:"on_timeout,omitempty"`
+}
+
+// DefaultClarificationConfig returns default clarification settings.
+// Questions wait for an answer indefinitely.
+func DefaultClarificationConfig() *ClarificationConfig {
+	return &ClarificationConfig{
+		OnTimeout: ClarifyOnTimeoutFail,
+	}
+}
+
 // IntentJudgeConfig configures the LLM intent judge that compares diffs against
 // the original issue to catch scope creep and missing requirements.
 //
@@ -516,6 +710,66 @@ type QwenCodeConfig struct {
 	UseSessionResume bool `yaml:"use_session_resume,omitempty"`
 }
 
+// OpenAIConfig contains OpenAI backend configuration.
+// Runs OpenAI models through the Codex CLI (`codex exec --json`), which
+// authenticates with `codex login` or the OPENAI_API_KEY environment variable.
+type OpenAIConfig struct {
+	// Command is the path to the codex CLI (default: "codex")
+	Command string `yaml:"command,omitempty"`
+
+	// Model is the default model when model routing does not pick one (default: "gpt-5-codex")
+	Model string `yaml:"model,omitempty"`
+
+	// ExtraArgs are additional arguments to pass to `codex exec`
+	ExtraArgs []string `yaml:"extra_args,omitempty"`
+
+	// UseSessionResume enables `codex exec resume` for self-review.
+	// Default: false
+	UseSessionResume bool `yaml:"use_session_resume,omitempty"`
+}
+
+// GeminiConfig contains Gemini backend configuration.
+// Runs Gemini models through the Gemini CLI (`gemini -p --output-format stream-json`),
+// which authenticates with `gemini` login or the GEMINI_API_KEY environment variable.
+type GeminiConfig struct {
+	// Command is the path to the gemini CLI (default: "gemini")
+	Command string `yaml:"command,omitempty"`
+
+	// Model is the default model when model routing does not pick one (default: "gemini-2.5-pro")
+	Model string `yaml:"model,omitempty"`
+
+	// ExtraArgs are additional arguments to pass to the gemini CLI
+	ExtraArgs []string `yaml:"extra_args,omitempty"`
+
+	// UseSessionResume enables --resume for self-review.
+	// Default: false
+	UseSessionResume bool `yaml:"use_session_resume,omitempty"`
+}
+
+// OllamaConfig contains Ollama backend configuration.
+// Runs local models through the Ollama HTTP API for air-gapped environments.
+// Pilot drives the agent loop itself and executes tool calls (Read, Write,
+// Edit, Bash, Glob, Grep) inside the project directory.
+type OllamaConfig struct {
+	// Host is the Ollama server URL (default: "http://127.0.0.1:11434")
+	Host string `yaml:"host,omitempty"`
+
+	// Model is the model to run (default: "qwen2.5-coder:32b"). Must be pulled beforehand.
+	Model string `yaml:"model,omitempty"`
+
+	// ToolMode selects how tools are offered to the model:
+	// "native" uses the API's tool calling, "emulated" describes tools in the
+	// system prompt and parses <tool_call> blocks from the reply, for models
+	// without tool-calling support. Default: "native"
+	ToolMode string `yaml:"tool_mode,omitempty"`
+
+	// MaxTurns caps model round-trips per execution (default: 50)
+	MaxTurns int `yaml:"max_turns,omitempty"`
+
+	// NumCtx overrides the model context window (num_ctx). 0 uses the server default.
+	NumCtx int `yaml:"num_ctx,omitempty"`
+}
+
 // OpenCodeConfig contains OpenCode backend configuration.
 type OpenCodeConfig struct {
 	// ServerURL is the OpenCode server URL (default: "http://127.0.0.1:4096")
@@ -551,6 +805,20 @@ func DefaultBackendConfig() *BackendConfig {
 		QwenCode: &QwenCodeConfig{
 			Command: "qwen",
 		},
+		OpenAI: &OpenAIConfig{
+			Command: "codex",
+			Model:   DefaultOpenAIModel,
+		},
+		Gemini: &GeminiConfig{
+			Command: "gemini",
+			Model:   DefaultGeminiModel,
+		},
+		Ollama: &OllamaConfig{
+			Host:     DefaultOllamaHost,
+			Model:    DefaultOllamaModel,
+			ToolMode: OllamaToolModeNative,
+			MaxTurns: DefaultOllamaMaxTurns,
+		},
 		OpenCode: &OpenCodeConfig{
 			ServerURL:       "http://127.0.0.1:4096",
 			Model:           "anthropic/claude-sonnet-4-6",
@@ -562,12 +830,15 @@ func DefaultBackendConfig() *BackendConfig {
 		Timeout:          DefaultTimeoutConfig(),
 		EffortRouting:    DefaultEffortRoutingConfig(),
 		EffortClassifier: DefaultEffortClassifierConfig(),
+		Triage:           DefaultTriageConfig(),
+		Clarification:    DefaultClarificationConfig(),
 		Decompose:        DefaultDecomposeConfig(),
 		IntentJudge:      DefaultIntentJudgeConfig(),
 		Navigator:        DefaultNavigatorConfig(),
 		Hooks:            DefaultHooksConfig(),
 		Retry:            DefaultRetryConfig(),
 		Stagnation:       DefaultStagnationConfig(),
+		ChurnGuard:       DefaultChurnGuardConfig(),
 		Simplification:   DefaultSimplifyConfig(),
 	}
 }
@@ -609,11 +880,12 @@ func DefaultEffortRoutingConfig() *EffortRoutingConfig {
 // Timeouts are calibrated to prevent stuck tasks while allowing complex work.
 func DefaultTimeoutConfig() *TimeoutConfig {
 	return &TimeoutConfig{
-		Default: "30m",
-		Trivial: "5m",
-		Simple:  "10m",
-		Medium:  "30m",
-		Complex: "60m",
+		Default:  "30m",
+		Trivial:  "5m",
+		Simple:   "10m",
+		Medium:   "30m",
+		Complex:  "60m",
+		Adaptive: DefaultAdaptiveTimeoutConfig(),
 	}
 }
 
@@ -672,9 +944,69 @@ func DefaultStagnationConfig() *StagnationConfig {
 	}
 }
 
+// ChurnGuardConfig limits how much an execution may modify the working tree.
+// Exceeding any limit cancels the execution with a "runaway file churn"
+// failure, which usually indicates the model is thrashing.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  churn_guard:
+//	    enabled: true
+//	    max_files_modified: 60
+//	    max_lines_changed: 8000
+//	    max_rewrites_per_file: 20
+type ChurnGuardConfig struct {
+	// Enabled controls whether the churn guard is active.
+	// Default: true.
+	Enabled bool `yaml:"enabled"`
+
+	// MaxFilesModified is the maximum number of distinct files written. 0 disables the check.
+	MaxFilesModified int `yaml:"max_files_modified"`
+
+	// MaxLinesChanged is the maximum total lines written across all Write/Edit calls. 0 disables the check.
+	MaxLinesChanged int `yaml:"max_lines_changed"`
+
+	// MaxRewritesPerFile is the maximum number of Write/Edit calls on a single file. 0 disables the check.
+	MaxRewritesPerFile int `yaml:"max_rewrites_per_file"`
+}
+
+// DefaultChurnGuardConfig returns default churn guard settings.
+// Limits are generous so that large legitimate changes are not cut short.
+func DefaultChurnGuardConfig() *ChurnGuardConfig {
+	return &ChurnGuardConfig{
+		Enabled:            true,
+		MaxFilesModified:   60,
+		MaxLinesChanged:    8000,
+		MaxRewritesPerFile: 20,
+	}
+}
+
 // BackendType constants for configuration.
 const (
 	BackendTypeClaudeCode = "claude-code"
 	BackendTypeOpenCode   = "opencode"
 	BackendTypeQwenCode   = "qwen-code"
+	BackendTypeOpenAI     = "openai"
+	BackendTypeGemini     = "gemini"
+	BackendTypeOllama     = "ollama"
+)
+
+// DefaultOpenAIModel is the model used by the OpenAI backend when none is configured.
+const DefaultOpenAIModel = "gpt-5-codex"
+
+// DefaultGeminiModel is the model used by the Gemini backend when none is configured.
+const DefaultGeminiModel = "gemini-2.5-pro"
+
+// Ollama backend defaults.
+const (
+	DefaultOllamaHost     = "http://127.0.0.1:11434"
+	DefaultOllamaModel    = "qwen2.5-coder:32b"
+	DefaultOllamaMaxTurns = 50
+)
+
+// Ollama tool modes.
+const (
+	OllamaToolModeNative   = "native"
+	OllamaToolModeEmulated = "emulated"
 )
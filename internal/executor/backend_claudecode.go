@@ -12,7 +12,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -56,6 +55,8 @@ const (
 	ErrorTypeOOM ClaudeCodeErrorType = "oom_killed"
 	// ErrorTypeSessionNotFound indicates the session for --from-pr or --resume was not found (GH-1267)
 	ErrorTypeSessionNotFound ClaudeCodeErrorType = "session_not_found"
+	// ErrorTypeHeartbeatTimeout indicates the process was killed after its event stream went silent
+	ErrorTypeHeartbeatTimeout ClaudeCodeErrorType = "heartbeat_timeout"
 	// ErrorTypeUnknown indicates an unclassified error
 	ErrorTypeUnknown ClaudeCodeErrorType = "unknown"
 )
@@ -201,6 +202,12 @@ type ClaudeCodeBackend struct {
 	log              *slog.Logger
 }
 
+func init() {
+	RegisterBackend(BackendTypeClaudeCode, func(config *BackendConfig) Backend {
+		return NewClaudeCodeBackend(config.ClaudeCode)
+	})
+}
+
 // NewClaudeCodeBackend creates a new Claude Code backend.
 func NewClaudeCodeBackend(config *ClaudeCodeConfig) *ClaudeCodeBackend {
 	if config == nil {
@@ -233,7 +240,8 @@ func (b *ClaudeCodeBackend) IsAvailable() bool {
 }
 
 // Execute runs a prompt through Claude Code CLI.
-// If --from-pr is used and fails with session not found, it falls back to executing without it.
+// If --from-pr or --resume is used and fails with session not found, it falls
+// back to executing without it.
 func (b *ClaudeCodeBackend) Execute(ctx context.Context, opts ExecuteOptions) (*BackendResult, error) {
 	result, err := b.executeWithFromPR(ctx, opts, true)
 
@@ -249,6 +257,18 @@ func (b *ClaudeCodeBackend) Execute(ctx context.Context, opts ExecuteOptions) (*
 		}
 	}
 
+	// A resumed session may be gone (e.g. it ran in a removed worktree)
+	if err != nil && opts.ResumeSessionID != "" {
+		if ccErr, ok := err.(*ClaudeCodeError); ok && ccErr.Type == ErrorTypeSessionNotFound {
+			b.log.Warn("Session not found for --resume, retrying without it",
+				slog.String("session_id", opts.ResumeSessionID),
+				slog.String("error", ccErr.Message),
+			)
+			opts.ResumeSessionID = ""
+			return b.executeWithFromPR(ctx, opts, true)
+		}
+	}
+
 	return result, err
 }
 
@@ -353,56 +373,11 @@ func (b *ClaudeCodeBackend) executeWithFromPR(ctx context.Context, opts ExecuteO
 	// Channel to signal command completion
 	cmdDone := make(chan struct{})
 
-	// Heartbeat tracking: store last event time as Unix nano (atomic int64)
-	var lastEventAt atomic.Int64
-	lastEventAt.Store(time.Now().UnixNano())
-
-	// Heartbeat monitor goroutine
+	// Heartbeat monitor: probes, then kills the process after a silent stream (GH-884)
+	heartbeat := newHeartbeatMonitor(b.heartbeatTimeout)
 	heartbeatCtx, cancelHeartbeat := context.WithCancel(context.Background())
 	defer cancelHeartbeat()
-	go func() {
-		ticker := time.NewTicker(HeartbeatCheckInterval)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-heartbeatCtx.Done():
-				return
-			case <-cmdDone:
-				return
-			case <-ticker.C:
-				lastNano := lastEventAt.Load()
-				lastTime := time.Unix(0, lastNano)
-				age := time.Since(lastTime)
-				if age > b.heartbeatTimeout {
-					b.log.Warn("Heartbeat timeout detected, killing hung process",
-						slog.Int("pid", cmd.Process.Pid),
-						slog.Duration("last_event_age", age),
-						slog.Duration("timeout", b.heartbeatTimeout),
-					)
-
-					// Invoke callback if provided
-					if opts.HeartbeatCallback != nil {
-						opts.HeartbeatCallback(cmd.Process.Pid, age)
-					}
-
-					// Kill the hung process
-					if cmd.Process != nil {
-						if err := cmd.Process.Kill(); err != nil {
-							b.log.Error("Failed to kill hung process",
-								slog.Int("pid", cmd.Process.Pid),
-								slog.Any("error", err),
-							)
-						} else {
-							b.log.Info("Hung process killed successfully",
-								slog.Int("pid", cmd.Process.Pid),
-							)
-						}
-					}
-					return
-				}
-			}
-		}
-	}()
+	go heartbeat.Watch(heartbeatCtx, cmdDone, cmd.Process, b.log, opts.HeartbeatCallback)
 
 	// Watchdog goroutine: hard kill after absolute timeout (GH-882)
 	// This is a safety net for processes that ignore context cancellation.
@@ -455,15 +430,13 @@ func (b *ClaudeCodeBackend) executeWithFromPR(ctx context.Context, opts ExecuteO
 		for scanner.Scan() {
 			line := scanner.Text()
 
-			// Update heartbeat timestamp on each stream event
-			lastEventAt.Store(time.Now().UnixNano())
-
 			if opts.Verbose {
 				fmt.Printf("   %s\n", line)
 			}
 
 			// Parse and convert to BackendEvent
 			event := b.parseStreamEvent(line)
+			heartbeat.Observe(event)
 			if opts.EventHandler != nil {
 				opts.EventHandler(event)
 			}
@@ -586,6 +559,15 @@ func (b *ClaudeCodeBackend) executeWithFromPR(ctx context.Context, opts ExecuteO
 		stderr := stderrOutput.String()
 		ccErr := parseClaudeCodeError(stderr, err).(*ClaudeCodeError)
 
+		// The heartbeat kill exits with SIGKILL, which would otherwise look like an OOM kill
+		if heartbeat.Stalled() {
+			ccErr = &ClaudeCodeError{
+				Type:    ErrorTypeHeartbeatTimeout,
+				Message: fmt.Sprintf("No stream events for %v, process killed", b.heartbeatTimeout),
+				Stderr:  strings.TrimSpace(stderr),
+			}
+		}
+
 		// GH-2112: Log OOM kills at error level for monitoring
 		if ccErr.Type == ErrorTypeOOM {
 			b.log.Error("Claude Code process OOM-killed",
@@ -647,6 +629,11 @@ func (b *ClaudeCodeBackend) parseStreamEvent(line string) BackendEvent {
 
 	case "assistant":
 		if streamEvent.Message != nil {
+			event.MessageID = streamEvent.Message.ID
+			if usage := streamEvent.Message.Usage; usage != nil {
+				event.MessageTokensInput = usage.InputTokens + usage.CacheCreationInputTokens + usage.CacheReadInputTokens
+				event.MessageTokensOutput = usage.OutputTokens
+			}
 			for _, block := range streamEvent.Message.Content {
 				switch block.Type {
 				case "tool_use":
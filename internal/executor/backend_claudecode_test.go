@@ -144,6 +144,26 @@ func TestClaudeCodeBackendParseUsageInfo(t *testing.T) {
 	}
 }
 
+func TestClaudeCodeBackendParseMessageUsage(t *testing.T) {
+	backend := NewClaudeCodeBackend(nil)
+
+	line := `{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":10,"cache_creation_input_tokens":200,"cache_read_input_tokens":3000,"output_tokens":40},"content":[{"type":"tool_use","name":"Read","input":{}}]}}`
+	event := backend.parseStreamEvent(line)
+
+	if event.MessageID != "msg_1" {
+		t.Errorf("MessageID = %q, want msg_1", event.MessageID)
+	}
+	if event.MessageTokensInput != 3210 {
+		t.Errorf("MessageTokensInput = %d, want 3210", event.MessageTokensInput)
+	}
+	if event.MessageTokensOutput != 40 {
+		t.Errorf("MessageTokensOutput = %d, want 40", event.MessageTokensOutput)
+	}
+	if event.TokensInput != 0 || event.TokensOutput != 0 {
+		t.Errorf("per-message usage should not be billed, got %d/%d", event.TokensInput, event.TokensOutput)
+	}
+}
+
 func TestClaudeCodeBackendParseToolInput(t *testing.T) {
 	backend := NewClaudeCodeBackend(nil)
 
@@ -0,0 +1,51 @@
+package executor
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// failoverErrorTypes are BackendError types caused by the backend itself
+// rather than the task, so another backend may succeed where it failed.
+// Timeouts and session errors are excluded: a task that is too large or
+// resumes a missing session would fail the same way elsewhere.
+var failoverErrorTypes = map[string]bool{
+	"rate_limit":        true,
+	"api_error":         true,
+	"invalid_config":    true,
+	"oom_killed":        true,
+	"heartbeat_timeout": true,
+	"unknown":           true, // CLI exited with an unrecognised error
+}
+
+// FailoverReason reports whether err is an infrastructure failure that
+// warrants retrying the task on the fallback backend, along with its
+// category for logs and alerts.
+func FailoverReason(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	var beErr BackendError
+	if errors.As(err, &beErr) {
+		t := beErr.ErrorType()
+		return t, failoverErrorTypes[t]
+	}
+
+	// Backends wrap exec start failures, e.g. "failed to start Codex: ..."
+	if errors.Is(err, exec.ErrNotFound) || strings.HasPrefix(err.Error(), "failed to start") {
+		return "backend_unavailable", true
+	}
+	return "", false
+}
+
+// fallbackBackendFor returns the configured fallback backend for a task that
+// failed on failed, or nil when failover is disabled, would re-run on the same
+// backend, or the fallback is not available on this host.
+func (r *Runner) fallbackBackendFor(failed Backend) Backend {
+	if r.config == nil || r.config.FallbackBackend == "" || r.config.FallbackBackend == failed.Name() {
+		return nil
+	}
+	return r.namedBackend(r.config.FallbackBackend)
+}
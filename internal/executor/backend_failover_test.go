@@ -0,0 +1,61 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+func TestFailoverReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantOK     bool
+	}{
+		{"nil", nil, "", false},
+		{"rate limit", &ClaudeCodeError{Type: ErrorTypeRateLimit}, "rate_limit", true},
+		{"auth", &ClaudeCodeError{Type: ErrorTypeAPIError}, "api_error", true},
+		{"crash", &ClaudeCodeError{Type: ErrorTypeOOM}, "oom_killed", true},
+		{"wrapped", fmt.Errorf("run: %w", &ClaudeCodeError{Type: ErrorTypeUnknown}), "unknown", true},
+		{"timeout stays on primary", &ClaudeCodeError{Type: ErrorTypeTimeout}, "timeout", false},
+		{"session not found", &ClaudeCodeError{Type: ErrorTypeSessionNotFound}, "session_not_found", false},
+		{"missing CLI", fmt.Errorf("failed to start Codex: %w", exec.ErrNotFound), "backend_unavailable", true},
+		{"unclassified", errors.New("boom"), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := FailoverReason(tt.err)
+			if reason != tt.wantReason || ok != tt.wantOK {
+				t.Errorf("FailoverReason() = (%q, %v), want (%q, %v)", reason, ok, tt.wantReason, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRunnerFallbackBackendFor(t *testing.T) {
+	RegisterBackend("test-override", func(*BackendConfig) Backend {
+		return &overrideTestBackend{available: true}
+	})
+	primary := &mockSelfReviewBackend{}
+
+	runner := NewRunnerWithBackend(primary)
+	if b := runner.fallbackBackendFor(primary); b != nil {
+		t.Errorf("no fallback configured, got %s", b.Name())
+	}
+
+	runner.config = &BackendConfig{FallbackBackend: "test-override"}
+	fallback := runner.fallbackBackendFor(primary)
+	if fallback == nil || fallback.Name() != "test-override" {
+		t.Fatalf("fallbackBackendFor() = %v, want test-override", fallback)
+	}
+	if b := runner.fallbackBackendFor(fallback); b != nil {
+		t.Error("fallback should not fail over to itself")
+	}
+
+	runner.config = &BackendConfig{FallbackBackend: "no-such-backend"}
+	if b := runner.fallbackBackendFor(primary); b != nil {
+		t.Error("unknown fallback should be ignored")
+	}
+}
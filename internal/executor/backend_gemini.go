@@ -0,0 +1,583 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// geminiToolNameMap normalizes Gemini CLI tool names to the PascalCase names
+// expected by Runner's handleToolUse() for progress phase detection.
+var geminiToolNameMap = map[string]string{
+	"read_file":           "Read",
+	"read_many_files":     "Read",
+	"write_file":          "Write",
+	"replace":             "Edit",
+	"run_shell_command":   "Bash",
+	"search_file_content": "Grep",
+	"glob":                "Glob",
+	"list_directory":      "Bash",
+	"web_fetch":           "WebFetch",
+	"google_web_search":   "WebSearch",
+	"write_todos":         "TodoWrite",
+	"save_memory":         "TodoWrite",
+}
+
+// normalizeGeminiToolName maps Gemini CLI tool names to Runner-compatible
+// PascalCase names. Unknown and MCP tools pass through unchanged.
+func normalizeGeminiToolName(name string) string {
+	if mapped, ok := geminiToolNameMap[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+// isGeminiModel reports whether a model name belongs to the Gemini family.
+func isGeminiModel(model string) bool {
+	return strings.HasPrefix(strings.ToLower(model), "gemini")
+}
+
+// GeminiErrorType categorizes different types of Gemini CLI failures.
+type GeminiErrorType string
+
+const (
+	GeminiErrorTypeRateLimit        GeminiErrorType = "rate_limit"
+	GeminiErrorTypeAPIError         GeminiErrorType = "api_error"
+	GeminiErrorTypeTimeout          GeminiErrorType = "timeout"
+	GeminiErrorTypeInvalidConfig    GeminiErrorType = "invalid_config"
+	GeminiErrorTypeSessionNotFound  GeminiErrorType = "session_not_found"
+	GeminiErrorTypeHeartbeatTimeout GeminiErrorType = "heartbeat_timeout"
+	GeminiErrorTypeUnknown          GeminiErrorType = "unknown"
+)
+
+// GeminiError represents a classified error from the Gemini CLI.
+type GeminiError struct {
+	Type    GeminiErrorType
+	Message string
+	Stderr  string
+}
+
+func (e *GeminiError) Error() string {
+	if e.Stderr != "" {
+		return fmt.Sprintf("%s: %s (stderr: %s)", e.Type, e.Message, e.Stderr)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// ErrorType implements BackendError.
+func (e *GeminiError) ErrorType() string { return string(e.Type) }
+
+// ErrorMessage implements BackendError.
+func (e *GeminiError) ErrorMessage() string { return e.Message }
+
+// ErrorStderr implements BackendError.
+func (e *GeminiError) ErrorStderr() string { return e.Stderr }
+
+// classifyGeminiError examines stderr and the streamed error message to classify the error.
+func classifyGeminiError(stderr, streamErr string, originalErr error) *GeminiError {
+	combined := strings.ToLower(stderr + "\n" + streamErr)
+	trimmed := strings.TrimSpace(stderr)
+
+	// Rate limit / quota detection
+	if strings.Contains(combined, "resource_exhausted") ||
+		strings.Contains(combined, "quota") ||
+		strings.Contains(combined, "rate limit") ||
+		strings.Contains(combined, "429") {
+		return &GeminiError{
+			Type:    GeminiErrorTypeRateLimit,
+			Message: "Gemini rate limit reached",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Invalid config detection
+	if strings.Contains(combined, "model not found") ||
+		strings.Contains(combined, "is not found for api version") ||
+		strings.Contains(combined, "unknown argument") ||
+		strings.Contains(combined, "unknown arguments") {
+		return &GeminiError{
+			Type:    GeminiErrorTypeInvalidConfig,
+			Message: "Invalid Gemini CLI configuration",
+			Stderr:  trimmed,
+		}
+	}
+
+	// API errors
+	if strings.Contains(combined, "api key not valid") ||
+		strings.Contains(combined, "permission_denied") ||
+		strings.Contains(combined, "unauthenticated") ||
+		strings.Contains(combined, "401") ||
+		strings.Contains(combined, "403") ||
+		strings.Contains(combined, "500") ||
+		strings.Contains(combined, "503") {
+		return &GeminiError{
+			Type:    GeminiErrorTypeAPIError,
+			Message: "Gemini API error",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Session not found
+	if strings.Contains(combined, "session not found") ||
+		strings.Contains(combined, "no previous sessions") ||
+		strings.Contains(combined, "invalid session") {
+		return &GeminiError{
+			Type:    GeminiErrorTypeSessionNotFound,
+			Message: "Gemini session not found",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Timeout/killed
+	if strings.Contains(combined, "killed") ||
+		strings.Contains(combined, "signal") ||
+		strings.Contains(combined, "timeout") {
+		return &GeminiError{
+			Type:    GeminiErrorTypeTimeout,
+			Message: "Process killed or timed out",
+			Stderr:  trimmed,
+		}
+	}
+
+	msg := "Unknown error"
+	if streamErr != "" {
+		msg = streamErr
+	} else if originalErr != nil {
+		msg = originalErr.Error()
+	}
+	return &GeminiError{
+		Type:    GeminiErrorTypeUnknown,
+		Message: msg,
+		Stderr:  trimmed,
+	}
+}
+
+// GeminiBackend implements Backend for Google's Gemini CLI.
+// It runs `gemini -p <prompt> --output-format stream-json` and maps the
+// JSONL init/message/tool_use/tool_result/result events to BackendEvents.
+type GeminiBackend struct {
+	config           *GeminiConfig
+	heartbeatTimeout time.Duration
+	log              *slog.Logger
+}
+
+func init() {
+	RegisterBackend(BackendTypeGemini, func(config *BackendConfig) Backend {
+		return NewGeminiBackend(config.Gemini)
+	})
+}
+
+// NewGeminiBackend creates a new Gemini backend.
+func NewGeminiBackend(config *GeminiConfig) *GeminiBackend {
+	if config == nil {
+		config = &GeminiConfig{}
+	}
+	if config.Command == "" {
+		config.Command = "gemini"
+	}
+	if config.Model == "" {
+		config.Model = DefaultGeminiModel
+	}
+	return &GeminiBackend{
+		config:           config,
+		heartbeatTimeout: DefaultHeartbeatTimeout,
+		log:              logging.WithComponent("executor.gemini"),
+	}
+}
+
+// SetHeartbeatTimeout sets a custom heartbeat timeout for this backend.
+func (b *GeminiBackend) SetHeartbeatTimeout(d time.Duration) {
+	b.heartbeatTimeout = d
+}
+
+// Name returns the backend identifier.
+func (b *GeminiBackend) Name() string {
+	return BackendTypeGemini
+}
+
+// IsAvailable checks if the Gemini CLI is installed.
+func (b *GeminiBackend) IsAvailable() bool {
+	path, err := exec.LookPath(b.config.Command)
+	if err != nil {
+		return false
+	}
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		b.log.Warn("gemini: could not determine version", "error", err)
+		return true
+	}
+	b.log.Info("gemini: detected version", "version", strings.TrimSpace(string(out)))
+	return true
+}
+
+// buildArgs constructs the CLI arguments for Gemini CLI execution.
+func (b *GeminiBackend) buildArgs(opts ExecuteOptions) []string {
+	var args []string
+
+	// Session resume support
+	if opts.ResumeSessionID != "" && b.config.UseSessionResume {
+		args = append(args, "--resume", opts.ResumeSessionID)
+		b.log.Info("Resuming Gemini session",
+			slog.String("session_id", opts.ResumeSessionID),
+		)
+	}
+
+	// Core flags
+	args = append(args,
+		"-p", opts.Prompt,
+		"--output-format", "stream-json",
+		"--yolo", // Gemini's equivalent of --dangerously-skip-permissions
+	)
+
+	// Model flag: routed model first, then configured default
+	model := opts.Model
+	if model == "" {
+		model = b.config.Model
+	}
+	if model != "" {
+		args = append(args, "--model", model)
+	}
+
+	// Note: --effort and --from-pr are not supported by Gemini CLI — skip silently
+
+	// Extra args from config
+	args = append(args, b.config.ExtraArgs...)
+
+	return args
+}
+
+// Execute runs a prompt through the Gemini CLI.
+func (b *GeminiBackend) Execute(ctx context.Context, opts ExecuteOptions) (*BackendResult, error) {
+	args := b.buildArgs(opts)
+
+	cmd := exec.CommandContext(ctx, b.config.Command, args...)
+	cmd.Dir = opts.ProjectPath
+
+	b.log.Debug("Starting Gemini CLI",
+		slog.String("command", b.config.Command),
+		slog.String("project", opts.ProjectPath),
+	)
+
+	// Create pipes for output
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	// Start the command
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start Gemini CLI: %w", err)
+	}
+	b.log.Debug("Gemini CLI started", slog.Int("pid", cmd.Process.Pid))
+
+	// Track results
+	result := &BackendResult{}
+	parser := &geminiStreamParser{}
+	var stderrOutput strings.Builder
+	var wg sync.WaitGroup
+
+	// Channel to signal command completion
+	cmdDone := make(chan struct{})
+
+	// Heartbeat monitor: probes, then kills the process after a silent stream (GH-884)
+	heartbeat := newHeartbeatMonitor(b.heartbeatTimeout)
+	heartbeatCtx, cancelHeartbeat := context.WithCancel(context.Background())
+	defer cancelHeartbeat()
+	go heartbeat.Watch(heartbeatCtx, cmdDone, cmd.Process, b.log, opts.HeartbeatCallback)
+
+	// Watchdog goroutine: hard kill after absolute timeout
+	if opts.WatchdogTimeout > 0 {
+		go func() {
+			select {
+			case <-cmdDone:
+				return
+			case <-time.After(opts.WatchdogTimeout):
+				if cmd.Process == nil {
+					return
+				}
+
+				b.log.Warn("Watchdog timeout expired, forcibly killing subprocess",
+					slog.Int("pid", cmd.Process.Pid),
+					slog.Duration("watchdog_timeout", opts.WatchdogTimeout),
+				)
+
+				if opts.WatchdogCallback != nil {
+					opts.WatchdogCallback(cmd.Process.Pid, opts.WatchdogTimeout)
+				}
+
+				if err := cmd.Process.Kill(); err != nil {
+					b.log.Error("Watchdog failed to kill process",
+						slog.Int("pid", cmd.Process.Pid),
+						slog.Any("error", err),
+					)
+				} else {
+					b.log.Info("Watchdog killed process successfully",
+						slog.Int("pid", cmd.Process.Pid),
+					)
+				}
+			}
+		}()
+	}
+
+	// Read stdout (stream-json events)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stdout)
+		buf := make([]byte, 0, 64*1024)
+		scanner.Buffer(buf, 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if opts.Verbose {
+				fmt.Printf("   %s\n", line)
+			}
+
+			event := parser.Parse(line)
+			heartbeat.Observe(event)
+			if opts.EventHandler != nil {
+				opts.EventHandler(event)
+			}
+
+			switch {
+			case event.Type == EventTypeResult && event.IsError:
+				result.Error = event.Message
+			case event.Type == EventTypeResult:
+				result.Output = event.Message
+				result.SawSuccessResult = true
+			case event.Type == EventTypeError && event.IsError:
+				result.Error = event.Message
+			case event.Type == EventTypeInit && event.SessionID != "":
+				result.SessionID = event.SessionID
+			}
+
+			// Accumulate token usage
+			result.TokensInput += event.TokensInput
+			result.TokensOutput += event.TokensOutput
+			result.CacheReadInputTokens += event.CacheReadInputTokens
+			if event.Model != "" {
+				result.Model = event.Model
+			}
+		}
+	}()
+
+	// Read stderr
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			stderrOutput.WriteString(line + "\n")
+			if opts.Verbose {
+				fmt.Printf("   [err] %s\n", line)
+			}
+		}
+	}()
+
+	// Monitor context for timeout and handle hard kill
+	go func() {
+		select {
+		case <-cmdDone:
+			return
+		case <-ctx.Done():
+			if cmd.Process == nil {
+				return
+			}
+
+			b.log.Warn("Context cancelled, waiting grace period before hard kill",
+				slog.Int("pid", cmd.Process.Pid),
+				slog.Duration("grace_period", GracePeriod),
+			)
+
+			select {
+			case <-cmdDone:
+				return
+			case <-time.After(GracePeriod):
+				if cmd.Process != nil {
+					b.log.Warn("Grace period expired, sending SIGKILL",
+						slog.Int("pid", cmd.Process.Pid),
+					)
+					if err := cmd.Process.Kill(); err != nil {
+						b.log.Error("Failed to kill process",
+							slog.Int("pid", cmd.Process.Pid),
+							slog.Any("error", err),
+						)
+					}
+				}
+			}
+		}
+	}()
+
+	// Wait for output readers
+	wg.Wait()
+
+	// Wait for command to complete
+	err = cmd.Wait()
+	close(cmdDone)
+
+	if err == nil && result.Error != "" && !result.SawSuccessResult {
+		err = fmt.Errorf("gemini run failed: %s", result.Error)
+	}
+
+	if err != nil {
+		result.Success = false
+
+		stderrStr := stderrOutput.String()
+		gErr := classifyGeminiError(stderrStr, result.Error, err)
+		if heartbeat.Stalled() {
+			gErr = &GeminiError{
+				Type:    GeminiErrorTypeHeartbeatTimeout,
+				Message: fmt.Sprintf("No stream events for %v, process killed", b.heartbeatTimeout),
+				Stderr:  strings.TrimSpace(stderrStr),
+			}
+		}
+
+		b.log.Warn("Gemini CLI execution failed",
+			slog.String("error_type", string(gErr.Type)),
+			slog.String("message", gErr.Message),
+			slog.String("stderr", gErr.Stderr),
+		)
+
+		// Fallback if --resume fails with session not found
+		if gErr.Type == GeminiErrorTypeSessionNotFound && opts.ResumeSessionID != "" {
+			b.log.Warn("gemini: session not found, retrying without --resume",
+				"session_id", opts.ResumeSessionID)
+			opts.ResumeSessionID = ""
+			return b.Execute(ctx, opts)
+		}
+
+		if result.Error == "" {
+			result.Error = gErr.Error()
+		}
+
+		return result, gErr
+	}
+
+	result.Success = true
+	return result, nil
+}
+
+// geminiStreamEvent is a single line of Gemini CLI stream-json output.
+type geminiStreamEvent struct {
+	Type       string                 `json:"type"`
+	SessionID  string                 `json:"session_id,omitempty"`
+	Model      string                 `json:"model,omitempty"`
+	Role       string                 `json:"role,omitempty"`
+	Content    string                 `json:"content,omitempty"`
+	Delta      bool                   `json:"delta,omitempty"`
+	ToolName   string                 `json:"tool_name,omitempty"`
+	ToolID     string                 `json:"tool_id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Status     string                 `json:"status,omitempty"`
+	Output     string                 `json:"output,omitempty"`
+	Severity   string                 `json:"severity,omitempty"`
+	Message    string                 `json:"message,omitempty"`
+	Error      *geminiStreamError     `json:"error,omitempty"`
+	Stats      *geminiStreamStats     `json:"stats,omitempty"`
+}
+
+type geminiStreamError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type geminiStreamStats struct {
+	TotalTokens  int64 `json:"total_tokens"`
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+	Cached       int64 `json:"cached"`
+}
+
+// geminiStreamParser converts Gemini CLI stream-json lines to BackendEvents.
+// Assistant text arrives as deltas; the parser collects the text written after
+// the last tool call so the result event carries the final answer.
+type geminiStreamParser struct {
+	finalText strings.Builder
+}
+
+// Parse converts one stream-json line into a BackendEvent.
+func (p *geminiStreamParser) Parse(line string) BackendEvent {
+	event := BackendEvent{Raw: line}
+
+	var ev geminiStreamEvent
+	if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Type == "" {
+		event.Type = EventTypeText
+		event.Message = line
+		return event
+	}
+
+	switch ev.Type {
+	case "init":
+		event.Type = EventTypeInit
+		event.SessionID = ev.SessionID
+		event.Model = ev.Model
+		event.Message = "Gemini CLI initialized"
+
+	case "message":
+		if ev.Role != "assistant" {
+			event.Type = EventTypeProgress
+			return event
+		}
+		event.Type = EventTypeText
+		event.Message = ev.Content
+		if !ev.Delta {
+			p.finalText.Reset()
+		}
+		p.finalText.WriteString(ev.Content)
+
+	case "tool_use":
+		p.finalText.Reset()
+		event.Type = EventTypeToolUse
+		event.ToolName = normalizeGeminiToolName(ev.ToolName)
+		event.ToolInput = ev.Parameters
+		event.Message = fmt.Sprintf("Using %s", event.ToolName)
+
+	case "tool_result":
+		event.Type = EventTypeToolResult
+		event.ToolResult = ev.Output
+		event.IsError = ev.Status == "error"
+		if ev.Error != nil && event.ToolResult == "" {
+			event.ToolResult = ev.Error.Message
+		}
+
+	case "error":
+		event.Type = EventTypeError
+		event.Message = ev.Message
+		// Warnings (e.g. loop detection notices) don't fail the run
+		event.IsError = ev.Severity != "warning"
+
+	case "result":
+		event.Type = EventTypeResult
+		event.IsError = ev.Status != "success"
+		if event.IsError {
+			if ev.Error != nil {
+				event.Message = ev.Error.Message
+			}
+		} else {
+			event.Message = strings.TrimSpace(p.finalText.String())
+		}
+		if ev.Stats != nil {
+			// input_tokens includes cached prompt tokens; report them separately
+			event.TokensInput = ev.Stats.InputTokens - ev.Stats.Cached
+			event.CacheReadInputTokens = ev.Stats.Cached
+			event.TokensOutput = ev.Stats.OutputTokens
+		}
+	}
+
+	return event
+}
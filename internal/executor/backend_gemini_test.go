@@ -0,0 +1,329 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewGeminiBackend(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *GeminiConfig
+		expectCommand string
+		expectModel   string
+	}{
+		{
+			name:          "nil config uses defaults",
+			config:        nil,
+			expectCommand: "gemini",
+			expectModel:   DefaultGeminiModel,
+		},
+		{
+			name:          "custom command and model",
+			config:        &GeminiConfig{Command: "/custom/gemini", Model: "gemini-2.5-flash"},
+			expectCommand: "/custom/gemini",
+			expectModel:   "gemini-2.5-flash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := NewGeminiBackend(tt.config)
+			if backend.config.Command != tt.expectCommand {
+				t.Errorf("Command = %q, want %q", backend.config.Command, tt.expectCommand)
+			}
+			if backend.config.Model != tt.expectModel {
+				t.Errorf("Model = %q, want %q", backend.config.Model, tt.expectModel)
+			}
+		})
+	}
+}
+
+func TestGeminiBackendName(t *testing.T) {
+	if got := NewGeminiBackend(nil).Name(); got != BackendTypeGemini {
+		t.Errorf("Name() = %q, want %q", got, BackendTypeGemini)
+	}
+}
+
+func TestGeminiBackendIsAvailable(t *testing.T) {
+	backend := NewGeminiBackend(&GeminiConfig{Command: "/nonexistent/path/to/gemini"})
+	if backend.IsAvailable() {
+		t.Error("IsAvailable() should return false for non-existent command")
+	}
+}
+
+func TestGeminiBuildArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *GeminiConfig
+		opts     ExecuteOptions
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "defaults",
+			config:   &GeminiConfig{},
+			opts:     ExecuteOptions{Prompt: "do it"},
+			contains: []string{"-p do it", "--output-format stream-json", "--yolo", "--model " + DefaultGeminiModel},
+			excludes: []string{"--resume", "--effort"},
+		},
+		{
+			name:     "routed model overrides config",
+			config:   &GeminiConfig{Model: "gemini-2.5-pro"},
+			opts:     ExecuteOptions{Prompt: "do it", Model: "gemini-2.5-flash", Effort: "high"},
+			contains: []string{"--model gemini-2.5-flash"},
+			excludes: []string{"gemini-2.5-pro", "high"},
+		},
+		{
+			name:     "resume ignored unless enabled",
+			config:   &GeminiConfig{},
+			opts:     ExecuteOptions{Prompt: "do it", ResumeSessionID: "sess-1"},
+			excludes: []string{"--resume"},
+		},
+		{
+			name:     "resume and extra args",
+			config:   &GeminiConfig{UseSessionResume: true, ExtraArgs: []string{"--sandbox"}},
+			opts:     ExecuteOptions{Prompt: "do it", ResumeSessionID: "sess-1"},
+			contains: []string{"--resume sess-1", "--sandbox"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := strings.Join(NewGeminiBackend(tt.config).buildArgs(tt.opts), " ")
+			for _, want := range tt.contains {
+				if !strings.Contains(args, want) {
+					t.Errorf("args %q missing %q", args, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(args, unwanted) {
+					t.Errorf("args %q should not contain %q", args, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestGeminiStreamParser(t *testing.T) {
+	p := &geminiStreamParser{}
+
+	init := p.Parse(`{"type":"init","session_id":"sess-1","model":"gemini-2.5-flash"}`)
+	if init.Type != EventTypeInit || init.SessionID != "sess-1" || init.Model != "gemini-2.5-flash" {
+		t.Errorf("init = %+v", init)
+	}
+
+	user := p.Parse(`{"type":"message","role":"user","content":"do it"}`)
+	if user.Type != EventTypeProgress {
+		t.Errorf("user message type = %s, want progress", user.Type)
+	}
+
+	p.Parse(`{"type":"message","role":"assistant","content":"Let me look","delta":true}`)
+
+	tool := p.Parse(`{"type":"tool_use","tool_name":"replace","tool_id":"t1","parameters":{"file_path":"main.go","old_string":"a","new_string":"b"}}`)
+	if tool.Type != EventTypeToolUse || tool.ToolName != "Edit" {
+		t.Errorf("tool_use = %+v", tool)
+	}
+	if tool.ToolInput["file_path"] != "main.go" {
+		t.Errorf("tool input = %v", tool.ToolInput)
+	}
+
+	res := p.Parse(`{"type":"tool_result","tool_id":"t1","status":"error","error":{"type":"edit_failed","message":"no match"}}`)
+	if res.Type != EventTypeToolResult || !res.IsError || res.ToolResult != "no match" {
+		t.Errorf("tool_result = %+v", res)
+	}
+
+	p.Parse(`{"type":"message","role":"assistant","content":"Done, ","delta":true}`)
+	p.Parse(`{"type":"message","role":"assistant","content":"tests pass.","delta":true}`)
+
+	warn := p.Parse(`{"type":"error","severity":"warning","message":"loop detected"}`)
+	if warn.Type != EventTypeError || warn.IsError {
+		t.Errorf("warning = %+v", warn)
+	}
+
+	final := p.Parse(`{"type":"result","status":"success","stats":{"total_tokens":1250,"input_tokens":1000,"output_tokens":250,"cached":400}}`)
+	if final.Type != EventTypeResult || final.IsError {
+		t.Fatalf("result = %+v", final)
+	}
+	if final.Message != "Done, tests pass." {
+		t.Errorf("result message = %q, want text after last tool call", final.Message)
+	}
+	if final.TokensInput != 600 || final.CacheReadInputTokens != 400 || final.TokensOutput != 250 {
+		t.Errorf("tokens = in %d cached %d out %d", final.TokensInput, final.CacheReadInputTokens, final.TokensOutput)
+	}
+
+	plain := p.Parse("Loaded cached credentials.")
+	if plain.Type != EventTypeText || plain.Message != "Loaded cached credentials." {
+		t.Errorf("plain line = %+v", plain)
+	}
+}
+
+func TestNormalizeGeminiToolName(t *testing.T) {
+	tests := map[string]string{
+		"read_file":           "Read",
+		"write_file":          "Write",
+		"replace":             "Edit",
+		"run_shell_command":   "Bash",
+		"search_file_content": "Grep",
+		"glob":                "Glob",
+		"mcp__github__search": "mcp__github__search",
+	}
+	for in, want := range tests {
+		if got := normalizeGeminiToolName(in); got != want {
+			t.Errorf("normalizeGeminiToolName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClassifyGeminiError(t *testing.T) {
+	tests := []struct {
+		name      string
+		stderr    string
+		streamErr string
+		want      GeminiErrorType
+	}{
+		{"quota", "", "RESOURCE_EXHAUSTED: Quota exceeded", GeminiErrorTypeRateLimit},
+		{"bad key", "API key not valid. Please pass a valid API key.", "", GeminiErrorTypeAPIError},
+		{"bad model", "models/gemini-9 is not found for API version v1beta", "", GeminiErrorTypeInvalidConfig},
+		{"session", "Error: session not found", "", GeminiErrorTypeSessionNotFound},
+		{"killed", "signal: killed", "", GeminiErrorTypeTimeout},
+		{"unknown", "", "something odd", GeminiErrorTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyGeminiError(tt.stderr, tt.streamErr, nil)
+			if got.Type != tt.want {
+				t.Errorf("type = %s, want %s", got.Type, tt.want)
+			}
+		})
+	}
+}
+
+// fakeGemini writes a shell script that prints the given JSONL and exits 0.
+func fakeGemini(t *testing.T, jsonl string) string {
+	t.Helper()
+	dir := t.TempDir()
+	data := filepath.Join(dir, "events.jsonl")
+	if err := os.WriteFile(data, []byte(jsonl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "gemini")
+	body := "#!/bin/sh\ncat " + data + "\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+func TestGeminiBackendExecute(t *testing.T) {
+	script := fakeGemini(t, strings.Join([]string{
+		`{"type":"init","session_id":"sess-9","model":"gemini-2.5-flash"}`,
+		`{"type":"tool_use","tool_name":"run_shell_command","tool_id":"t1","parameters":{"command":"ls"}}`,
+		`{"type":"tool_result","tool_id":"t1","status":"success","output":"main.go"}`,
+		`{"type":"message","role":"assistant","content":"Implemented","delta":true}`,
+		`{"type":"result","status":"success","stats":{"input_tokens":500,"output_tokens":50,"cached":100}}`,
+	}, "\n")+"\n")
+
+	backend := NewGeminiBackend(&GeminiConfig{Command: script})
+	var events []BackendEvent
+	result, err := backend.Execute(context.Background(), ExecuteOptions{
+		Prompt:       "task",
+		ProjectPath:  t.TempDir(),
+		EventHandler: func(e BackendEvent) { events = append(events, e) },
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || result.Output != "Implemented" || result.SessionID != "sess-9" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.TokensInput != 400 || result.CacheReadInputTokens != 100 || result.TokensOutput != 50 {
+		t.Errorf("tokens = in %d cached %d out %d", result.TokensInput, result.CacheReadInputTokens, result.TokensOutput)
+	}
+	if result.Model != "gemini-2.5-flash" {
+		t.Errorf("Model = %q, want gemini-2.5-flash", result.Model)
+	}
+	if len(events) != 5 {
+		t.Errorf("expected 5 events, got %d", len(events))
+	}
+}
+
+func TestGeminiBackendExecute_ResultError(t *testing.T) {
+	script := fakeGemini(t, strings.Join([]string{
+		`{"type":"init","session_id":"sess-9","model":"gemini-2.5-pro"}`,
+		`{"type":"result","status":"error","error":{"type":"api_error","message":"429 RESOURCE_EXHAUSTED"}}`,
+	}, "\n")+"\n")
+
+	backend := NewGeminiBackend(&GeminiConfig{Command: script})
+	result, err := backend.Execute(context.Background(), ExecuteOptions{Prompt: "task", ProjectPath: t.TempDir()})
+	if err == nil {
+		t.Fatal("expected error for failed result")
+	}
+	gErr, ok := err.(*GeminiError)
+	if !ok || gErr.Type != GeminiErrorTypeRateLimit {
+		t.Errorf("error = %v, want rate_limit GeminiError", err)
+	}
+	if result.Success {
+		t.Error("result should not be successful")
+	}
+}
+
+func TestBackendFactoryGemini(t *testing.T) {
+	config := DefaultBackendConfig()
+	config.Type = BackendTypeGemini
+	config.Gemini = &GeminiConfig{Command: "/custom/gemini", Model: "gemini-2.5-flash"}
+
+	backend, err := NewBackend(config)
+	if err != nil {
+		t.Fatalf("NewBackend() error = %v", err)
+	}
+	g, ok := backend.(*GeminiBackend)
+	if !ok {
+		t.Fatalf("expected *GeminiBackend, got %T", backend)
+	}
+	if g.config.Command != "/custom/gemini" || g.config.Model != "gemini-2.5-flash" {
+		t.Errorf("config = %+v", g.config)
+	}
+}
+
+func TestRunnerBackendForModel(t *testing.T) {
+	primary := &mockSelfReviewBackend{}
+
+	t.Run("non-gemini model stays on primary backend", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		backend, model := runner.backendForModel("claude-opus-4-6")
+		if backend != primary || model != "claude-opus-4-6" {
+			t.Errorf("got (%v, %q), want primary with model unchanged", backend, model)
+		}
+	})
+
+	t.Run("gemini model routes to gemini CLI", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		runner.config = DefaultBackendConfig()
+		runner.config.Gemini = &GeminiConfig{Command: fakeGemini(t, "")}
+
+		backend, model := runner.backendForModel("gemini-2.5-flash")
+		if backend.Name() != BackendTypeGemini || model != "gemini-2.5-flash" {
+			t.Errorf("got (%s, %q), want gemini backend", backend.Name(), model)
+		}
+		again, _ := runner.backendForModel("gemini-2.5-flash-lite")
+		if again != backend {
+			t.Error("gemini backend should be created once and reused")
+		}
+	})
+
+	t.Run("missing gemini CLI falls back to primary default model", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		runner.config = DefaultBackendConfig()
+		runner.config.Gemini = &GeminiConfig{Command: "/nonexistent/gemini"}
+
+		backend, model := runner.backendForModel("gemini-2.5-flash")
+		if backend != primary || model != "" {
+			t.Errorf("got (%v, %q), want primary with empty model", backend, model)
+		}
+	})
+}
@@ -0,0 +1,591 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// ollamaModelPrefix marks models served by Ollama. Reported model names carry
+// it so cost estimation prices local inference at zero.
+const ollamaModelPrefix = "ollama/"
+
+// OllamaErrorType categorizes different types of Ollama failures.
+type OllamaErrorType string
+
+const (
+	OllamaErrorTypeAPIError         OllamaErrorType = "api_error"
+	OllamaErrorTypeTimeout          OllamaErrorType = "timeout"
+	OllamaErrorTypeInvalidConfig    OllamaErrorType = "invalid_config"
+	OllamaErrorTypeHeartbeatTimeout OllamaErrorType = "heartbeat_timeout"
+	OllamaErrorTypeTurnLimit        OllamaErrorType = "turn_limit"
+	OllamaErrorTypeUnknown          OllamaErrorType = "unknown"
+)
+
+// OllamaError represents a classified error from the Ollama backend.
+// Ollama runs over HTTP, so there is no stderr; Stderr holds the server's
+// error body when one was returned.
+type OllamaError struct {
+	Type    OllamaErrorType
+	Message string
+	Stderr  string
+}
+
+func (e *OllamaError) Error() string {
+	if e.Stderr != "" {
+		return fmt.Sprintf("%s: %s (server: %s)", e.Type, e.Message, e.Stderr)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// ErrorType implements BackendError.
+func (e *OllamaError) ErrorType() string { return string(e.Type) }
+
+// ErrorMessage implements BackendError.
+func (e *OllamaError) ErrorMessage() string { return e.Message }
+
+// ErrorStderr implements BackendError.
+func (e *OllamaError) ErrorStderr() string { return e.Stderr }
+
+// classifyOllamaError examines the server error body and the transport error to classify the failure.
+func classifyOllamaError(serverErr string, originalErr error) *OllamaError {
+	combined := strings.ToLower(serverErr)
+	if originalErr != nil {
+		combined += "\n" + strings.ToLower(originalErr.Error())
+	}
+	trimmed := strings.TrimSpace(serverErr)
+
+	// Model not pulled or unknown
+	if (strings.Contains(combined, "not found") && strings.Contains(combined, "model")) ||
+		strings.Contains(combined, "does not support tools") {
+		return &OllamaError{
+			Type:    OllamaErrorTypeInvalidConfig,
+			Message: "Invalid Ollama model configuration (is the model pulled and tool-capable? try tool_mode: emulated)",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Server unreachable or out of resources
+	if strings.Contains(combined, "connection refused") ||
+		strings.Contains(combined, "no such host") ||
+		strings.Contains(combined, "requires more system memory") ||
+		strings.Contains(combined, "out of memory") ||
+		strings.Contains(combined, "500") ||
+		strings.Contains(combined, "503") {
+		return &OllamaError{
+			Type:    OllamaErrorTypeAPIError,
+			Message: "Ollama server error",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Timeout/cancelled
+	if strings.Contains(combined, "deadline exceeded") ||
+		strings.Contains(combined, "context canceled") ||
+		strings.Contains(combined, "timeout") {
+		return &OllamaError{
+			Type:    OllamaErrorTypeTimeout,
+			Message: "Request cancelled or timed out",
+			Stderr:  trimmed,
+		}
+	}
+
+	msg := "Unknown error"
+	if trimmed != "" {
+		msg = trimmed
+	} else if originalErr != nil {
+		msg = originalErr.Error()
+	}
+	return &OllamaError{
+		Type:    OllamaErrorTypeUnknown,
+		Message: msg,
+		Stderr:  trimmed,
+	}
+}
+
+// OllamaBackend implements Backend for local models served by Ollama.
+// Unlike the CLI backends there is no agent process: the backend runs the
+// agent loop itself against /api/chat and executes tool calls locally in the
+// project directory, emitting the same tool_use/tool_result events so progress
+// tracking, churn detection and per-task token limits keep working.
+type OllamaBackend struct {
+	config           *OllamaConfig
+	heartbeatTimeout time.Duration
+	log              *slog.Logger
+	httpClient       *http.Client
+}
+
+func init() {
+	RegisterBackend(BackendTypeOllama, func(config *BackendConfig) Backend {
+		return NewOllamaBackend(config.Ollama)
+	})
+}
+
+// NewOllamaBackend creates a new Ollama backend.
+func NewOllamaBackend(config *OllamaConfig) *OllamaBackend {
+	if config == nil {
+		config = &OllamaConfig{}
+	}
+	if config.Host == "" {
+		config.Host = DefaultOllamaHost
+	}
+	config.Host = strings.TrimRight(config.Host, "/")
+	if config.Model == "" {
+		config.Model = DefaultOllamaModel
+	}
+	if config.ToolMode == "" {
+		config.ToolMode = OllamaToolModeNative
+	}
+	if config.MaxTurns <= 0 {
+		config.MaxTurns = DefaultOllamaMaxTurns
+	}
+	return &OllamaBackend{
+		config:           config,
+		heartbeatTimeout: DefaultHeartbeatTimeout,
+		log:              logging.WithComponent("executor.ollama"),
+		// No client timeout: generation is bounded by ctx, heartbeat and watchdog
+		httpClient: &http.Client{},
+	}
+}
+
+// SetHeartbeatTimeout sets a custom heartbeat timeout for this backend.
+func (b *OllamaBackend) SetHeartbeatTimeout(d time.Duration) {
+	b.heartbeatTimeout = d
+}
+
+// Name returns the backend identifier.
+func (b *OllamaBackend) Name() string {
+	return BackendTypeOllama
+}
+
+// IsAvailable checks if the Ollama server is responding.
+func (b *OllamaBackend) IsAvailable() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", b.config.Host+"/api/version", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// model returns the model for this execution. Routed models are used when
+// they name a local model; hosted model names (claude-*, gpt-*, gemini-*)
+// from model routing fall back to the configured model.
+func (b *OllamaBackend) model(opts ExecuteOptions) string {
+	model := strings.TrimPrefix(opts.Model, ollamaModelPrefix)
+	lower := strings.ToLower(model)
+	if model == "" || strings.HasPrefix(lower, "claude") || strings.HasPrefix(lower, "gpt-") || isGeminiModel(lower) {
+		return b.config.Model
+	}
+	return model
+}
+
+// Execute runs a prompt through the local agent loop.
+func (b *OllamaBackend) Execute(ctx context.Context, opts ExecuteOptions) (*BackendResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	model := b.model(opts)
+	reportedModel := ollamaModelPrefix + model
+	emulated := b.config.ToolMode == OllamaToolModeEmulated
+	tools := newOllamaToolbox(opts.ProjectPath)
+
+	b.log.Debug("Starting Ollama execution",
+		slog.String("host", b.config.Host),
+		slog.String("model", model),
+		slog.String("tool_mode", b.config.ToolMode),
+		slog.String("project", opts.ProjectPath),
+	)
+
+	result := &BackendResult{Model: reportedModel}
+	heartbeat := newHeartbeatMonitor(b.heartbeatTimeout)
+	emit := func(event BackendEvent) {
+		heartbeat.Observe(event)
+		if opts.EventHandler != nil {
+			opts.EventHandler(event)
+		}
+	}
+
+	// Heartbeat monitor: there is no subprocess to kill, so a stall cancels the request (GH-884)
+	go b.watchHeartbeat(ctx, heartbeat, cancel, opts.HeartbeatCallback)
+
+	// Watchdog: hard stop after absolute timeout
+	var watchdogFired atomic.Bool
+	if opts.WatchdogTimeout > 0 {
+		timer := time.AfterFunc(opts.WatchdogTimeout, func() {
+			watchdogFired.Store(true)
+			b.log.Warn("Watchdog timeout expired, cancelling Ollama execution",
+				slog.Duration("watchdog_timeout", opts.WatchdogTimeout),
+			)
+			if opts.WatchdogCallback != nil {
+				opts.WatchdogCallback(0, opts.WatchdogTimeout)
+			}
+			cancel()
+		})
+		defer timer.Stop()
+	}
+
+	emit(BackendEvent{
+		Type:    EventTypeInit,
+		Raw:     ollamaRaw(map[string]interface{}{"type": "init", "model": reportedModel}),
+		Message: "Ollama session started",
+		Model:   reportedModel,
+	})
+
+	messages := []ollamaMessage{
+		{Role: "system", Content: ollamaSystemPrompt(emulated)},
+		{Role: "user", Content: opts.Prompt},
+	}
+
+	fail := func(oErr *OllamaError) (*BackendResult, error) {
+		if heartbeat.Stalled() {
+			oErr = &OllamaError{
+				Type:    OllamaErrorTypeHeartbeatTimeout,
+				Message: fmt.Sprintf("No stream events for %v, request cancelled", b.heartbeatTimeout),
+			}
+		} else if watchdogFired.Load() {
+			oErr = &OllamaError{
+				Type:    OllamaErrorTypeTimeout,
+				Message: fmt.Sprintf("Watchdog cancelled execution after %v", opts.WatchdogTimeout),
+			}
+		}
+		b.log.Warn("Ollama execution failed",
+			slog.String("error_type", string(oErr.Type)),
+			slog.String("message", oErr.Message),
+			slog.String("server", oErr.Stderr),
+		)
+		emit(BackendEvent{
+			Type:    EventTypeResult,
+			Raw:     ollamaRaw(map[string]interface{}{"type": "result", "is_error": true, "error": oErr.Error()}),
+			Message: oErr.Error(),
+			IsError: true,
+			Model:   reportedModel,
+		})
+		result.Success = false
+		result.Error = oErr.Error()
+		return result, oErr
+	}
+
+	for turn := 1; turn <= b.config.MaxTurns; turn++ {
+		reply, err := b.chat(ctx, b.chatRequest(model, messages, emulated), opts.Verbose, heartbeat)
+		if err != nil {
+			return fail(err)
+		}
+
+		content := reply.Message.Content
+		calls := reply.Message.ToolCalls
+		if emulated {
+			calls, content = parseEmulatedToolCalls(content)
+		}
+
+		result.TokensInput += reply.PromptEvalCount
+		result.TokensOutput += reply.EvalCount
+		emit(BackendEvent{
+			Type:         EventTypeText,
+			Raw:          ollamaRaw(reply),
+			Message:      content,
+			TokensInput:  reply.PromptEvalCount,
+			TokensOutput: reply.EvalCount,
+			Model:        reportedModel,
+		})
+
+		assistant := ollamaMessage{Role: "assistant", Content: reply.Message.Content}
+		if !emulated {
+			assistant.ToolCalls = calls
+		}
+		messages = append(messages, assistant)
+
+		if len(calls) == 0 {
+			result.Output = strings.TrimSpace(content)
+			result.Success = true
+			result.SawSuccessResult = true
+			emit(BackendEvent{
+				Type:    EventTypeResult,
+				Raw:     ollamaRaw(map[string]interface{}{"type": "result", "result": result.Output}),
+				Message: result.Output,
+				Model:   reportedModel,
+			})
+			return result, nil
+		}
+
+		var emulatedResults strings.Builder
+		for _, call := range calls {
+			name := normalizeOllamaToolName(call.Function.Name)
+			emit(BackendEvent{
+				Type:      EventTypeToolUse,
+				Raw:       ollamaRaw(map[string]interface{}{"type": "tool_use", "name": name, "input": call.Function.Arguments}),
+				Message:   fmt.Sprintf("Using %s", name),
+				ToolName:  name,
+				ToolInput: call.Function.Arguments,
+			})
+
+			output, isError := tools.Run(ctx, name, call.Function.Arguments)
+			if opts.Verbose {
+				fmt.Printf("   [%s] %s\n", name, truncateText(output, 200))
+			}
+			emit(BackendEvent{
+				Type:       EventTypeToolResult,
+				Raw:        ollamaRaw(map[string]interface{}{"type": "tool_result", "name": name, "is_error": isError}),
+				ToolResult: output,
+				IsError:    isError,
+			})
+
+			if emulated {
+				fmt.Fprintf(&emulatedResults, "<tool_result name=%q>\n%s\n</tool_result>\n", name, output)
+			} else {
+				messages = append(messages, ollamaMessage{Role: "tool", ToolName: name, Content: output})
+			}
+		}
+		if emulated {
+			messages = append(messages, ollamaMessage{Role: "user", Content: emulatedResults.String()})
+		}
+
+		if ctx.Err() != nil {
+			return fail(classifyOllamaError("", ctx.Err()))
+		}
+	}
+
+	return fail(&OllamaError{
+		Type:    OllamaErrorTypeTurnLimit,
+		Message: fmt.Sprintf("Model did not finish within %d turns", b.config.MaxTurns),
+	})
+}
+
+// watchHeartbeat cancels the execution when the heartbeat monitor declares a stall.
+// Mirrors heartbeatMonitor.Watch for backends without a subprocess.
+func (b *OllamaBackend) watchHeartbeat(ctx context.Context, heartbeat *heartbeatMonitor, cancel context.CancelFunc, callback func(pid int, lastEventAge time.Duration)) {
+	ticker := time.NewTicker(HeartbeatCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			verdict, age := heartbeat.Check(now)
+			if verdict != heartbeatStalled {
+				continue
+			}
+			b.log.Warn("Heartbeat timeout detected, cancelling Ollama request",
+				slog.Duration("last_event_age", age),
+				slog.Duration("timeout", b.heartbeatTimeout),
+			)
+			if callback != nil {
+				callback(0, age)
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+// chatRequest builds the /api/chat payload for one turn.
+func (b *OllamaBackend) chatRequest(model string, messages []ollamaMessage, emulated bool) *ollamaChatRequest {
+	req := &ollamaChatRequest{
+		Model:    model,
+		Messages: messages,
+		Stream:   true,
+	}
+	if !emulated {
+		req.Tools = ollamaTools()
+	}
+	if b.config.NumCtx > 0 {
+		req.Options = map[string]interface{}{"num_ctx": b.config.NumCtx}
+	}
+	return req
+}
+
+// chat sends one streaming /api/chat request and assembles the reply.
+// Every streamed chunk counts as a heartbeat.
+func (b *OllamaBackend) chat(ctx context.Context, payload *ollamaChatRequest, verbose bool, heartbeat *heartbeatMonitor) (*ollamaChatResponse, *OllamaError) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, classifyOllamaError("", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", b.config.Host+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, classifyOllamaError("", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, classifyOllamaError("", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			body = []byte(apiErr.Error)
+		}
+		return nil, classifyOllamaError(string(body), fmt.Errorf("ollama returned HTTP %d", resp.StatusCode))
+	}
+
+	reply := &ollamaChatResponse{}
+	var content strings.Builder
+
+	scanner := bufio.NewScanner(resp.Body)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if verbose {
+			fmt.Printf("   %s\n", line)
+		}
+		heartbeat.Observe(BackendEvent{Type: EventTypeProgress})
+
+		var chunk ollamaChatResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != "" {
+			return nil, classifyOllamaError(chunk.Error, nil)
+		}
+
+		content.WriteString(chunk.Message.Content)
+		reply.Message.ToolCalls = append(reply.Message.ToolCalls, chunk.Message.ToolCalls...)
+		if chunk.Done {
+			reply.Model = chunk.Model
+			reply.Done = true
+			reply.DoneReason = chunk.DoneReason
+			reply.PromptEvalCount = chunk.PromptEvalCount
+			reply.EvalCount = chunk.EvalCount
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, classifyOllamaError("", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, classifyOllamaError("", err)
+	}
+	if !reply.Done {
+		return nil, classifyOllamaError("stream ended before done", nil)
+	}
+
+	reply.Message.Role = "assistant"
+	reply.Message.Content = content.String()
+	return reply, nil
+}
+
+// ollamaMessage is a chat message in the /api/chat format.
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function ollamaToolCallFunction `json:"function"`
+}
+
+type ollamaToolCallFunction struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+type ollamaChatRequest struct {
+	Model    string                 `json:"model"`
+	Messages []ollamaMessage        `json:"messages"`
+	Tools    []ollamaTool           `json:"tools,omitempty"`
+	Stream   bool                   `json:"stream"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+}
+
+// ollamaChatResponse is one NDJSON chunk of a streaming /api/chat response.
+// The final chunk has done=true and carries token counts.
+type ollamaChatResponse struct {
+	Model           string        `json:"model"`
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	DoneReason      string        `json:"done_reason,omitempty"`
+	PromptEvalCount int64         `json:"prompt_eval_count,omitempty"`
+	EvalCount       int64         `json:"eval_count,omitempty"`
+	Error           string        `json:"error,omitempty"`
+}
+
+// emulatedToolCallPattern matches <tool_call>{...}</tool_call> blocks.
+var emulatedToolCallPattern = regexp.MustCompile("(?s)<tool_call>\\s*(?:```(?:json)?)?\\s*(.*?)\\s*(?:```)?\\s*</tool_call>")
+
+// parseEmulatedToolCalls extracts tool calls written as <tool_call> blocks
+// and returns them with the remaining text. Blocks that are not valid JSON
+// are left in the text so the model sees them echoed back as plain output.
+func parseEmulatedToolCalls(content string) ([]ollamaToolCall, string) {
+	var calls []ollamaToolCall
+	text := emulatedToolCallPattern.ReplaceAllStringFunc(content, func(block string) string {
+		m := emulatedToolCallPattern.FindStringSubmatch(block)
+		var fn ollamaToolCallFunction
+		if err := json.Unmarshal([]byte(m[1]), &fn); err != nil || fn.Name == "" {
+			return block
+		}
+		calls = append(calls, ollamaToolCall{Function: fn})
+		return ""
+	})
+	return calls, strings.TrimSpace(text)
+}
+
+// ollamaSystemPrompt returns the system prompt. In emulated mode the tool
+// catalogue and call syntax are spelled out, since the API does not carry them.
+func ollamaSystemPrompt(emulated bool) string {
+	var sb strings.Builder
+	sb.WriteString("You are an autonomous coding agent working inside a git repository. ")
+	sb.WriteString("The working directory is the repository root; use relative paths. ")
+	sb.WriteString("Use the tools to read, search and edit files and to run commands (tests, builds, git). ")
+	sb.WriteString("Work until the task is complete, then reply with a short summary and no tool calls.\n")
+
+	if !emulated {
+		return sb.String()
+	}
+
+	sb.WriteString("\nTo call a tool, reply with one or more blocks of exactly this form:\n")
+	sb.WriteString(`<tool_call>{"name": "Read", "arguments": {"file_path": "main.go"}}</tool_call>`)
+	sb.WriteString("\nTool output is returned in <tool_result> blocks. Available tools:\n")
+	for _, t := range ollamaTools() {
+		params := make([]string, 0, len(t.Function.Parameters.Properties))
+		for _, name := range ollamaToolParamOrder {
+			if p, ok := t.Function.Parameters.Properties[name]; ok {
+				params = append(params, fmt.Sprintf("%s (%s)", name, p.Description))
+			}
+		}
+		fmt.Fprintf(&sb, "- %s: %s Arguments: %s\n", t.Function.Name, t.Function.Description, strings.Join(params, ", "))
+	}
+	return sb.String()
+}
+
+// ollamaRaw encodes v for BackendEvent.Raw.
+func ollamaRaw(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,359 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeOllama serves /api/chat from a script of replies, one per request,
+// and records the decoded requests.
+type fakeOllama struct {
+	mu       sync.Mutex
+	replies  [][]string // NDJSON lines per request
+	requests []ollamaChatRequest
+}
+
+func (f *fakeOllama) start(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			_, _ = fmt.Fprint(w, `{"version":"0.9.0"}`)
+			return
+		}
+		var req ollamaChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		n := len(f.requests)
+		f.requests = append(f.requests, req)
+		f.mu.Unlock()
+
+		reply := f.replies[len(f.replies)-1]
+		if n < len(f.replies) {
+			reply = f.replies[n]
+		}
+		for _, line := range reply {
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewOllamaBackend(t *testing.T) {
+	b := NewOllamaBackend(nil)
+	if b.config.Host != DefaultOllamaHost || b.config.Model != DefaultOllamaModel {
+		t.Errorf("config = %+v", b.config)
+	}
+	if b.config.ToolMode != OllamaToolModeNative || b.config.MaxTurns != DefaultOllamaMaxTurns {
+		t.Errorf("config = %+v", b.config)
+	}
+	if b.Name() != BackendTypeOllama {
+		t.Errorf("Name() = %q", b.Name())
+	}
+
+	b = NewOllamaBackend(&OllamaConfig{Host: "http://gpu-box:11434/"})
+	if b.config.Host != "http://gpu-box:11434" {
+		t.Errorf("Host = %q, want trailing slash trimmed", b.config.Host)
+	}
+}
+
+func TestOllamaBackendIsAvailable(t *testing.T) {
+	srv := (&fakeOllama{}).start(t)
+	if !NewOllamaBackend(&OllamaConfig{Host: srv.URL}).IsAvailable() {
+		t.Error("IsAvailable() should be true for a responding server")
+	}
+	if NewOllamaBackend(&OllamaConfig{Host: "http://127.0.0.1:1"}).IsAvailable() {
+		t.Error("IsAvailable() should be false for an unreachable server")
+	}
+}
+
+func TestOllamaBackendModel(t *testing.T) {
+	b := NewOllamaBackend(&OllamaConfig{Model: "qwen2.5-coder:7b"})
+	tests := map[string]string{
+		"":                "qwen2.5-coder:7b",
+		"claude-sonnet":   "qwen2.5-coder:7b",
+		"gemini-2.5-pro":  "qwen2.5-coder:7b",
+		"llama3.1:8b":     "llama3.1:8b",
+		"ollama/devstral": "devstral",
+	}
+	for routed, want := range tests {
+		if got := b.model(ExecuteOptions{Model: routed}); got != want {
+			t.Errorf("model(%q) = %q, want %q", routed, got, want)
+		}
+	}
+}
+
+func TestOllamaBackendExecute_NativeTools(t *testing.T) {
+	fake := &fakeOllama{replies: [][]string{
+		{
+			`{"model":"m","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"Write","arguments":{"file_path":"hello.txt","content":"hi\n"}}}]},"done":false}`,
+			`{"model":"m","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":100,"eval_count":20}`,
+		},
+		{
+			`{"model":"m","message":{"role":"assistant","content":"Created "},"done":false}`,
+			`{"model":"m","message":{"role":"assistant","content":"hello.txt"},"done":false}`,
+			`{"model":"m","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":150,"eval_count":5}`,
+		},
+	}}
+	srv := fake.start(t)
+	dir := t.TempDir()
+
+	var events []BackendEvent
+	b := NewOllamaBackend(&OllamaConfig{Host: srv.URL, Model: "m"})
+	result, err := b.Execute(context.Background(), ExecuteOptions{
+		Prompt:       "create hello.txt",
+		ProjectPath:  dir,
+		EventHandler: func(e BackendEvent) { events = append(events, e) },
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !result.Success || result.Output != "Created hello.txt" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.TokensInput != 250 || result.TokensOutput != 25 {
+		t.Errorf("tokens = %d/%d, want 250/25", result.TokensInput, result.TokensOutput)
+	}
+	if result.Model != "ollama/m" {
+		t.Errorf("Model = %q", result.Model)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "hello.txt")); err != nil || string(data) != "hi\n" {
+		t.Errorf("hello.txt = %q, %v", data, err)
+	}
+
+	var types []string
+	var eventTokens int64
+	for _, e := range events {
+		types = append(types, string(e.Type))
+		eventTokens += e.TokensInput + e.TokensOutput
+	}
+	want := "init,text,tool_use,tool_result,text,result"
+	if got := strings.Join(types, ","); got != want {
+		t.Errorf("events = %s, want %s", got, want)
+	}
+	if eventTokens != 275 {
+		t.Errorf("event tokens = %d, want 275 (budget accounting)", eventTokens)
+	}
+	if events[2].ToolName != "Write" || events[2].ToolInput["file_path"] != "hello.txt" {
+		t.Errorf("tool_use event = %+v", events[2])
+	}
+
+	if len(fake.requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(fake.requests))
+	}
+	if len(fake.requests[0].Tools) == 0 {
+		t.Error("native mode should send tool definitions")
+	}
+	last := fake.requests[1].Messages[len(fake.requests[1].Messages)-1]
+	if last.Role != "tool" || last.ToolName != "Write" {
+		t.Errorf("tool result message = %+v", last)
+	}
+}
+
+func TestOllamaBackendExecute_EmulatedTools(t *testing.T) {
+	call := `<tool_call>{\"name\":\"read_file\",\"arguments\":{\"file_path\":\"notes.txt\"}}</tool_call>`
+	fake := &fakeOllama{replies: [][]string{
+		{`{"model":"m","message":{"role":"assistant","content":"Let me look. ` + call + `"},"done":true,"prompt_eval_count":10,"eval_count":10}`},
+		{`{"model":"m","message":{"role":"assistant","content":"It says secret."},"done":true,"prompt_eval_count":10,"eval_count":3}`},
+	}}
+	srv := fake.start(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var toolNames []string
+	b := NewOllamaBackend(&OllamaConfig{Host: srv.URL, Model: "m", ToolMode: OllamaToolModeEmulated})
+	result, err := b.Execute(context.Background(), ExecuteOptions{
+		Prompt:      "read notes",
+		ProjectPath: dir,
+		EventHandler: func(e BackendEvent) {
+			if e.Type == EventTypeToolUse {
+				toolNames = append(toolNames, e.ToolName)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Output != "It says secret." {
+		t.Errorf("Output = %q", result.Output)
+	}
+	if len(toolNames) != 1 || toolNames[0] != "Read" {
+		t.Errorf("tool names = %v, want [Read]", toolNames)
+	}
+	if len(fake.requests[0].Tools) != 0 {
+		t.Error("emulated mode should not send tool definitions")
+	}
+	if !strings.Contains(fake.requests[0].Messages[0].Content, "<tool_call>") {
+		t.Error("emulated system prompt should describe the call syntax")
+	}
+	last := fake.requests[1].Messages[len(fake.requests[1].Messages)-1]
+	if last.Role != "user" || !strings.Contains(last.Content, `<tool_result name="Read">`) || !strings.Contains(last.Content, "secret") {
+		t.Errorf("tool result message = %+v", last)
+	}
+}
+
+func TestOllamaBackendExecute_TurnLimit(t *testing.T) {
+	fake := &fakeOllama{replies: [][]string{
+		{`{"model":"m","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"Bash","arguments":{"command":"true"}}}]},"done":true}`},
+	}}
+	srv := fake.start(t)
+
+	b := NewOllamaBackend(&OllamaConfig{Host: srv.URL, Model: "m", MaxTurns: 2})
+	result, err := b.Execute(context.Background(), ExecuteOptions{Prompt: "loop", ProjectPath: t.TempDir()})
+
+	oErr, ok := err.(*OllamaError)
+	if !ok || oErr.Type != OllamaErrorTypeTurnLimit {
+		t.Fatalf("err = %v, want turn_limit", err)
+	}
+	if result.Success || len(fake.requests) != 2 {
+		t.Errorf("success = %v, requests = %d", result.Success, len(fake.requests))
+	}
+}
+
+func TestOllamaBackendExecute_ModelNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"error":"model \"nope\" not found, try pulling it first"}`)
+	}))
+	defer srv.Close()
+
+	b := NewOllamaBackend(&OllamaConfig{Host: srv.URL, Model: "nope"})
+	_, err := b.Execute(context.Background(), ExecuteOptions{Prompt: "x", ProjectPath: t.TempDir()})
+
+	oErr, ok := err.(*OllamaError)
+	if !ok || oErr.Type != OllamaErrorTypeInvalidConfig {
+		t.Fatalf("err = %v, want invalid_config", err)
+	}
+	var be BackendError = oErr
+	if !strings.Contains(be.ErrorStderr(), "try pulling it first") {
+		t.Errorf("ErrorStderr() = %q", be.ErrorStderr())
+	}
+}
+
+func TestClassifyOllamaError(t *testing.T) {
+	tests := []struct {
+		serverErr string
+		err       error
+		want      OllamaErrorType
+	}{
+		{`model "x" not found, try pulling it first`, nil, OllamaErrorTypeInvalidConfig},
+		{"registry.ollama.ai/library/phi does not support tools", nil, OllamaErrorTypeInvalidConfig},
+		{"", fmt.Errorf("dial tcp 127.0.0.1:11434: connect: connection refused"), OllamaErrorTypeAPIError},
+		{"model requires more system memory (40 GiB) than is available", nil, OllamaErrorTypeAPIError},
+		{"", context.DeadlineExceeded, OllamaErrorTypeTimeout},
+		{"something odd", nil, OllamaErrorTypeUnknown},
+	}
+	for _, tt := range tests {
+		if got := classifyOllamaError(tt.serverErr, tt.err).Type; got != tt.want {
+			t.Errorf("classifyOllamaError(%q, %v) = %s, want %s", tt.serverErr, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseEmulatedToolCalls(t *testing.T) {
+	content := "Plan:\n<tool_call>\n```json\n{\"name\": \"Bash\", \"arguments\": {\"command\": \"go test ./...\"}}\n```\n</tool_call>\n" +
+		"<tool_call>not json</tool_call>"
+
+	calls, text := parseEmulatedToolCalls(content)
+	if len(calls) != 1 || calls[0].Function.Name != "Bash" || calls[0].Function.Arguments["command"] != "go test ./..." {
+		t.Fatalf("calls = %+v", calls)
+	}
+	if !strings.HasPrefix(text, "Plan:") || !strings.Contains(text, "<tool_call>not json</tool_call>") {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestOllamaToolbox(t *testing.T) {
+	dir := t.TempDir()
+	tools := newOllamaToolbox(dir)
+	ctx := context.Background()
+	run := func(name string, args map[string]interface{}) (string, bool) {
+		return tools.Run(ctx, name, args)
+	}
+
+	if _, isErr := run("Write", map[string]interface{}{"file_path": "pkg/a.go", "content": "package pkg\n\nfunc A() {}\n"}); isErr {
+		t.Fatal("Write failed")
+	}
+	if out, isErr := run("Edit", map[string]interface{}{"file_path": "pkg/a.go", "old_string": "func A() {}", "new_string": "func A() int { return 1 }"}); isErr {
+		t.Fatalf("Edit failed: %s", out)
+	}
+	if out, _ := run("Read", map[string]interface{}{"file_path": filepath.Join(dir, "pkg/a.go")}); !strings.Contains(out, "return 1") {
+		t.Errorf("Read = %q", out)
+	}
+	if out, _ := run("Glob", map[string]interface{}{"pattern": "**/*.go"}); out != filepath.Join("pkg", "a.go") {
+		t.Errorf("Glob = %q", out)
+	}
+	if out, _ := run("Grep", map[string]interface{}{"pattern": `func \w+\(\)`}); !strings.Contains(out, "a.go:3:func A()") {
+		t.Errorf("Grep = %q", out)
+	}
+	if out, isErr := run("Bash", map[string]interface{}{"command": "ls pkg"}); isErr || strings.TrimSpace(out) != "a.go" {
+		t.Errorf("Bash = %q, %v", out, isErr)
+	}
+
+	failures := []struct {
+		name string
+		args map[string]interface{}
+		want string
+	}{
+		{"Read", map[string]interface{}{"file_path": "../outside.txt"}, "outside the project"},
+		{"Write", map[string]interface{}{"file_path": "/etc/pilot-test", "content": "x"}, "outside the project"},
+		{"Edit", map[string]interface{}{"file_path": "pkg/a.go", "old_string": "missing", "new_string": "x"}, "not found"},
+		{"Bash", map[string]interface{}{"command": "exit 3"}, "exit status 3"},
+		{"Delete", map[string]interface{}{}, "unknown tool"},
+	}
+	for _, f := range failures {
+		out, isErr := run(f.name, f.args)
+		if !isErr || !strings.Contains(out, f.want) {
+			t.Errorf("%s(%v) = %q, %v; want error containing %q", f.name, f.args, out, isErr, f.want)
+		}
+	}
+}
+
+func TestNormalizeOllamaToolName(t *testing.T) {
+	tests := map[string]string{
+		"read_file":         "Read",
+		"write":             "Write",
+		"Edit":              "Edit",
+		"run_shell_command": "Bash",
+		"glob":              "Glob",
+		"search":            "Grep",
+		"mcp_custom":        "mcp_custom",
+	}
+	for in, want := range tests {
+		if got := normalizeOllamaToolName(in); got != want {
+			t.Errorf("normalizeOllamaToolName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBackendFactoryOllama(t *testing.T) {
+	config := DefaultBackendConfig()
+	config.Type = BackendTypeOllama
+	config.Ollama = &OllamaConfig{Host: "http://gpu-box:11434", Model: "devstral"}
+
+	backend, err := NewBackend(config)
+	if err != nil {
+		t.Fatalf("NewBackend() error = %v", err)
+	}
+	o, ok := backend.(*OllamaBackend)
+	if !ok {
+		t.Fatalf("expected *OllamaBackend, got %T", backend)
+	}
+	if o.config.Host != "http://gpu-box:11434" || o.config.Model != "devstral" {
+		t.Errorf("config = %+v", o.config)
+	}
+}
@@ -0,0 +1,379 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxOllamaToolOutput caps tool output returned to the model; local
+	// models have small context windows.
+	maxOllamaToolOutput = 32 * 1024
+
+	// maxOllamaSearchResults caps Glob and Grep matches.
+	maxOllamaSearchResults = 200
+
+	// maxOllamaGrepFileSize skips large files when grepping.
+	maxOllamaGrepFileSize = 1024 * 1024
+)
+
+// ollamaToolParamOrder lists tool parameters in the order they are documented.
+var ollamaToolParamOrder = []string{"file_path", "content", "old_string", "new_string", "command", "pattern", "path"}
+
+// ollamaTool is a tool definition in the /api/chat "tools" format.
+type ollamaTool struct {
+	Type     string             `json:"type"`
+	Function ollamaToolFunction `json:"function"`
+}
+
+type ollamaToolFunction struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Parameters  ollamaToolParameters `json:"parameters"`
+}
+
+type ollamaToolParameters struct {
+	Type       string                        `json:"type"`
+	Required   []string                      `json:"required"`
+	Properties map[string]ollamaToolProperty `json:"properties"`
+}
+
+type ollamaToolProperty struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ollamaTools returns the tools offered to local models. Names and argument
+// keys match Claude Code's so Runner phase detection and ChurnGuard work unchanged.
+func ollamaTools() []ollamaTool {
+	str := func(desc string) ollamaToolProperty {
+		return ollamaToolProperty{Type: "string", Description: desc}
+	}
+	tool := func(name, desc string, required []string, props map[string]ollamaToolProperty) ollamaTool {
+		return ollamaTool{
+			Type: "function",
+			Function: ollamaToolFunction{
+				Name:        name,
+				Description: desc,
+				Parameters:  ollamaToolParameters{Type: "object", Required: required, Properties: props},
+			},
+		}
+	}
+
+	return []ollamaTool{
+		tool("Read", "Read a file.", []string{"file_path"}, map[string]ollamaToolProperty{
+			"file_path": str("path of the file to read"),
+		}),
+		tool("Write", "Create or overwrite a file.", []string{"file_path", "content"}, map[string]ollamaToolProperty{
+			"file_path": str("path of the file to write"),
+			"content":   str("full file content"),
+		}),
+		tool("Edit", "Replace one exact occurrence of old_string with new_string in a file.", []string{"file_path", "old_string", "new_string"}, map[string]ollamaToolProperty{
+			"file_path":  str("path of the file to edit"),
+			"old_string": str("exact text to replace, must be unique in the file"),
+			"new_string": str("replacement text"),
+		}),
+		tool("Bash", "Run a shell command in the repository root and return its output.", []string{"command"}, map[string]ollamaToolProperty{
+			"command": str("shell command"),
+		}),
+		tool("Glob", "List files matching a glob pattern, e.g. **/*.go.", []string{"pattern"}, map[string]ollamaToolProperty{
+			"pattern": str("glob pattern relative to the repository root"),
+		}),
+		tool("Grep", "Search file contents with a regular expression.", []string{"pattern"}, map[string]ollamaToolProperty{
+			"pattern": str("regular expression"),
+			"path":    str("optional file or directory to search"),
+		}),
+	}
+}
+
+// normalizeOllamaToolName maps tool names to the PascalCase names expected by
+// Runner's handleToolUse(). Models often lowercase or snake_case them.
+func normalizeOllamaToolName(name string) string {
+	switch strings.ToLower(strings.ReplaceAll(name, "_", "")) {
+	case "read", "readfile":
+		return "Read"
+	case "write", "writefile":
+		return "Write"
+	case "edit", "editfile", "replace":
+		return "Edit"
+	case "bash", "shell", "runshellcommand":
+		return "Bash"
+	case "glob":
+		return "Glob"
+	case "grep", "search":
+		return "Grep"
+	default:
+		return name
+	}
+}
+
+// ollamaToolbox executes tool calls confined to the project directory.
+type ollamaToolbox struct {
+	root string
+}
+
+func newOllamaToolbox(root string) *ollamaToolbox {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		abs = root
+	}
+	return &ollamaToolbox{root: filepath.Clean(abs)}
+}
+
+// Run executes a tool call and returns its output and whether it failed.
+// Failures are reported back to the model rather than aborting execution.
+func (t *ollamaToolbox) Run(ctx context.Context, name string, args map[string]interface{}) (string, bool) {
+	arg := func(key string) string {
+		s, _ := args[key].(string)
+		return s
+	}
+
+	var out string
+	var err error
+	switch name {
+	case "Read":
+		out, err = t.read(arg("file_path"))
+	case "Write":
+		out, err = t.write(arg("file_path"), arg("content"))
+	case "Edit":
+		out, err = t.edit(arg("file_path"), arg("old_string"), arg("new_string"))
+	case "Bash":
+		out, err = t.bash(ctx, arg("command"))
+	case "Glob":
+		out, err = t.glob(arg("pattern"))
+	case "Grep":
+		out, err = t.grep(arg("pattern"), arg("path"))
+	default:
+		err = fmt.Errorf("unknown tool %q", name)
+	}
+
+	if err != nil {
+		if out != "" {
+			return truncateToolOutput(out + "\n" + err.Error()), true
+		}
+		return "Error: " + err.Error(), true
+	}
+	return truncateToolOutput(out), false
+}
+
+// resolve returns the absolute path for p, rejecting paths outside the project.
+func (t *ollamaToolbox) resolve(p string) (string, error) {
+	if p == "" {
+		return "", fmt.Errorf("file_path is required")
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(t.root, p)
+	}
+	p = filepath.Clean(p)
+
+	rel, err := filepath.Rel(t.root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside the project directory", p)
+	}
+	return p, nil
+}
+
+func (t *ollamaToolbox) read(path string) (string, error) {
+	p, err := t.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (t *ollamaToolbox) write(path, content string) (string, error) {
+	p, err := t.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Wrote %d bytes to %s", len(content), path), nil
+}
+
+func (t *ollamaToolbox) edit(path, oldString, newString string) (string, error) {
+	p, err := t.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	if oldString == "" {
+		return "", fmt.Errorf("old_string is required")
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	switch n := strings.Count(string(data), oldString); n {
+	case 0:
+		return "", fmt.Errorf("old_string not found in %s", path)
+	case 1:
+	default:
+		return "", fmt.Errorf("old_string matches %d times in %s, include more context", n, path)
+	}
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return "", err
+	}
+	updated := strings.Replace(string(data), oldString, newString, 1)
+	if err := os.WriteFile(p, []byte(updated), info.Mode().Perm()); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Edited %s", path), nil
+}
+
+func (t *ollamaToolbox) bash(ctx context.Context, command string) (string, error) {
+	if strings.TrimSpace(command) == "" {
+		return "", fmt.Errorf("command is required")
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = t.root
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("command failed: %w", err)
+	}
+	return string(out), nil
+}
+
+func (t *ollamaToolbox) glob(pattern string) (string, error) {
+	if pattern == "" {
+		return "", fmt.Errorf("pattern is required")
+	}
+
+	var matches []string
+	err := t.walk(t.root, func(rel string, _ fs.DirEntry) bool {
+		if matchGlob(pattern, rel) {
+			matches = append(matches, rel)
+		}
+		return len(matches) < maxOllamaSearchResults
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "No files found", nil
+	}
+	return strings.Join(matches, "\n"), nil
+}
+
+// matchGlob matches rel against pattern. A leading "**/" matches any
+// directory depth, which filepath.Match does not support.
+func matchGlob(pattern, rel string) bool {
+	pattern = filepath.ToSlash(pattern)
+	rel = filepath.ToSlash(rel)
+	if ok, _ := filepath.Match(pattern, rel); ok {
+		return true
+	}
+	rest, ok := strings.CutPrefix(pattern, "**/")
+	if !ok {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := range parts {
+		if ok, _ := filepath.Match(rest, strings.Join(parts[i:], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ollamaToolbox) grep(pattern, path string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern: %w", err)
+	}
+	start := t.root
+	if path != "" {
+		if start, err = t.resolve(path); err != nil {
+			return "", err
+		}
+	}
+
+	var matches []string
+	err = t.walk(start, func(rel string, d fs.DirEntry) bool {
+		info, err := d.Info()
+		if err != nil || info.Size() > maxOllamaGrepFileSize {
+			return true
+		}
+		data, err := os.ReadFile(filepath.Join(t.root, rel))
+		if err != nil || bytes.IndexByte(data[:min(len(data), 512)], 0) >= 0 {
+			return true // unreadable or binary
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxOllamaGrepFileSize)
+		for line := 1; scanner.Scan(); line++ {
+			if re.MatchString(scanner.Text()) {
+				matches = append(matches, fmt.Sprintf("%s:%d:%s", rel, line, scanner.Text()))
+				if len(matches) >= maxOllamaSearchResults {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "No matches found", nil
+	}
+	return strings.Join(matches, "\n"), nil
+}
+
+// walk visits regular files under start (a file or directory), skipping VCS
+// and dependency directories. visit receives the project-relative path and
+// returns false to stop.
+func (t *ollamaToolbox) walk(start string, visit func(rel string, d fs.DirEntry) bool) error {
+	stop := fmt.Errorf("stop")
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "node_modules", "vendor":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(t.root, p)
+		if err != nil {
+			return nil
+		}
+		if !visit(rel, d) {
+			return stop
+		}
+		return nil
+	})
+	if err == stop {
+		return nil
+	}
+	return err
+}
+
+// truncateToolOutput keeps tool output within maxOllamaToolOutput.
+func truncateToolOutput(s string) string {
+	if len(s) <= maxOllamaToolOutput {
+		return s
+	}
+	return s[:maxOllamaToolOutput] + fmt.Sprintf("\n... (truncated, %d bytes total)", len(s))
+}
@@ -0,0 +1,714 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// OpenAIErrorType categorizes different types of OpenAI (Codex CLI) failures.
+type OpenAIErrorType string
+
+const (
+	OpenAIErrorTypeRateLimit        OpenAIErrorType = "rate_limit"
+	OpenAIErrorTypeAPIError         OpenAIErrorType = "api_error"
+	OpenAIErrorTypeTimeout          OpenAIErrorType = "timeout"
+	OpenAIErrorTypeInvalidConfig    OpenAIErrorType = "invalid_config"
+	OpenAIErrorTypeSessionNotFound  OpenAIErrorType = "session_not_found"
+	OpenAIErrorTypeHeartbeatTimeout OpenAIErrorType = "heartbeat_timeout"
+	OpenAIErrorTypeUnknown          OpenAIErrorType = "unknown"
+)
+
+// OpenAIError represents a classified error from the Codex CLI.
+type OpenAIError struct {
+	Type    OpenAIErrorType
+	Message string
+	Stderr  string
+}
+
+func (e *OpenAIError) Error() string {
+	if e.Stderr != "" {
+		return fmt.Sprintf("%s: %s (stderr: %s)", e.Type, e.Message, e.Stderr)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// ErrorType implements BackendError.
+func (e *OpenAIError) ErrorType() string { return string(e.Type) }
+
+// ErrorMessage implements BackendError.
+func (e *OpenAIError) ErrorMessage() string { return e.Message }
+
+// ErrorStderr implements BackendError.
+func (e *OpenAIError) ErrorStderr() string { return e.Stderr }
+
+// classifyOpenAIError examines stderr and the streamed error message to classify the error.
+// Codex reports API failures as JSON error events on stdout, so both are inspected.
+func classifyOpenAIError(stderr, streamErr string, originalErr error) *OpenAIError {
+	combined := strings.ToLower(stderr + "\n" + streamErr)
+	trimmed := strings.TrimSpace(stderr)
+
+	// Rate limit / quota detection
+	if strings.Contains(combined, "rate limit") ||
+		strings.Contains(combined, "rate_limit") ||
+		strings.Contains(combined, "too many requests") ||
+		strings.Contains(combined, "insufficient_quota") ||
+		strings.Contains(combined, "429") {
+		return &OpenAIError{
+			Type:    OpenAIErrorTypeRateLimit,
+			Message: "OpenAI rate limit reached",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Invalid config detection
+	if strings.Contains(combined, "model_not_found") ||
+		strings.Contains(combined, "does not exist") ||
+		strings.Contains(combined, "unsupported model") ||
+		strings.Contains(combined, "unexpected argument") ||
+		strings.Contains(combined, "unrecognized") {
+		return &OpenAIError{
+			Type:    OpenAIErrorTypeInvalidConfig,
+			Message: "Invalid Codex configuration",
+			Stderr:  trimmed,
+		}
+	}
+
+	// API errors
+	if strings.Contains(combined, "invalid_api_key") ||
+		strings.Contains(combined, "unauthorized") ||
+		strings.Contains(combined, "not logged in") ||
+		strings.Contains(combined, "401") ||
+		strings.Contains(combined, "403") ||
+		strings.Contains(combined, "500") ||
+		strings.Contains(combined, "502") ||
+		strings.Contains(combined, "503") {
+		return &OpenAIError{
+			Type:    OpenAIErrorTypeAPIError,
+			Message: "OpenAI API error",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Session not found
+	if strings.Contains(combined, "session not found") ||
+		strings.Contains(combined, "no rollout found") ||
+		strings.Contains(combined, "thread not found") {
+		return &OpenAIError{
+			Type:    OpenAIErrorTypeSessionNotFound,
+			Message: "Codex session not found",
+			Stderr:  trimmed,
+		}
+	}
+
+	// Timeout/killed
+	if strings.Contains(combined, "killed") ||
+		strings.Contains(combined, "signal") ||
+		strings.Contains(combined, "timeout") {
+		return &OpenAIError{
+			Type:    OpenAIErrorTypeTimeout,
+			Message: "Process killed or timed out",
+			Stderr:  trimmed,
+		}
+	}
+
+	msg := "Unknown error"
+	if streamErr != "" {
+		msg = streamErr
+	} else if originalErr != nil {
+		msg = originalErr.Error()
+	}
+	return &OpenAIError{
+		Type:    OpenAIErrorTypeUnknown,
+		Message: msg,
+		Stderr:  trimmed,
+	}
+}
+
+// OpenAIBackend implements Backend for OpenAI models through the Codex CLI.
+// It runs `codex exec --json`, which streams JSONL thread/turn/item events,
+// and maps them to BackendEvents compatible with the Runner's progress parser.
+type OpenAIBackend struct {
+	config           *OpenAIConfig
+	heartbeatTimeout time.Duration
+	log              *slog.Logger
+}
+
+func init() {
+	RegisterBackend(BackendTypeOpenAI, func(config *BackendConfig) Backend {
+		return NewOpenAIBackend(config.OpenAI)
+	})
+}
+
+// NewOpenAIBackend creates a new OpenAI backend.
+func NewOpenAIBackend(config *OpenAIConfig) *OpenAIBackend {
+	if config == nil {
+		config = &OpenAIConfig{}
+	}
+	if config.Command == "" {
+		config.Command = "codex"
+	}
+	if config.Model == "" {
+		config.Model = DefaultOpenAIModel
+	}
+	return &OpenAIBackend{
+		config:           config,
+		heartbeatTimeout: DefaultHeartbeatTimeout,
+		log:              logging.WithComponent("executor.openai"),
+	}
+}
+
+// SetHeartbeatTimeout sets a custom heartbeat timeout for this backend.
+func (b *OpenAIBackend) SetHeartbeatTimeout(d time.Duration) {
+	b.heartbeatTimeout = d
+}
+
+// Name returns the backend identifier.
+func (b *OpenAIBackend) Name() string {
+	return BackendTypeOpenAI
+}
+
+// IsAvailable checks if the Codex CLI is installed.
+func (b *OpenAIBackend) IsAvailable() bool {
+	path, err := exec.LookPath(b.config.Command)
+	if err != nil {
+		return false
+	}
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		b.log.Warn("openai: could not determine codex version", "error", err)
+		return true
+	}
+	b.log.Info("openai: detected codex version", "version", strings.TrimSpace(string(out)))
+	return true
+}
+
+// model returns the model for this execution: the routed model if set, otherwise the configured one.
+func (b *OpenAIBackend) model(opts ExecuteOptions) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	return b.config.Model
+}
+
+// openAIReasoningEffort maps Pilot effort levels to Codex model_reasoning_effort values.
+func openAIReasoningEffort(effort string) string {
+	switch effort {
+	case "low", "medium", "high":
+		return effort
+	case "max":
+		return "high"
+	default:
+		return ""
+	}
+}
+
+// buildArgs constructs the CLI arguments for Codex execution.
+func (b *OpenAIBackend) buildArgs(opts ExecuteOptions) []string {
+	args := []string{
+		"exec",
+		"--json",
+		"--dangerously-bypass-approvals-and-sandbox", // Codex equivalent of --dangerously-skip-permissions
+		"--skip-git-repo-check",
+	}
+
+	if model := b.model(opts); model != "" {
+		args = append(args, "--model", model)
+	}
+
+	if effort := openAIReasoningEffort(opts.Effort); effort != "" {
+		args = append(args, "-c", "model_reasoning_effort="+effort)
+	}
+
+	// Note: --from-pr is not supported by Codex — skip silently
+
+	// Extra args from config
+	args = append(args, b.config.ExtraArgs...)
+
+	// Session resume is a subcommand of exec: `codex exec [flags] resume <id> <prompt>`
+	if opts.ResumeSessionID != "" && b.config.UseSessionResume {
+		args = append(args, "resume", opts.ResumeSessionID)
+		b.log.Info("Resuming Codex session",
+			slog.String("session_id", opts.ResumeSessionID),
+		)
+	}
+
+	return append(args, opts.Prompt)
+}
+
+// Execute runs a prompt through the Codex CLI.
+func (b *OpenAIBackend) Execute(ctx context.Context, opts ExecuteOptions) (*BackendResult, error) {
+	args := b.buildArgs(opts)
+
+	cmd := exec.CommandContext(ctx, b.config.Command, args...)
+	cmd.Dir = opts.ProjectPath
+
+	b.log.Debug("Starting Codex",
+		slog.String("command", b.config.Command),
+		slog.String("project", opts.ProjectPath),
+	)
+
+	// Create pipes for output
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	// Start the command
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start Codex: %w", err)
+	}
+	b.log.Debug("Codex started", slog.Int("pid", cmd.Process.Pid))
+
+	// Track results
+	result := &BackendResult{Model: b.model(opts)}
+	parser := newCodexStreamParser(result.Model)
+	var stderrOutput strings.Builder
+	var wg sync.WaitGroup
+
+	// Channel to signal command completion
+	cmdDone := make(chan struct{})
+
+	// Heartbeat monitor: probes, then kills the process after a silent stream (GH-884)
+	heartbeat := newHeartbeatMonitor(b.heartbeatTimeout)
+	heartbeatCtx, cancelHeartbeat := context.WithCancel(context.Background())
+	defer cancelHeartbeat()
+	go heartbeat.Watch(heartbeatCtx, cmdDone, cmd.Process, b.log, opts.HeartbeatCallback)
+
+	// Watchdog goroutine: hard kill after absolute timeout
+	if opts.WatchdogTimeout > 0 {
+		go func() {
+			select {
+			case <-cmdDone:
+				return
+			case <-time.After(opts.WatchdogTimeout):
+				if cmd.Process == nil {
+					return
+				}
+
+				b.log.Warn("Watchdog timeout expired, forcibly killing subprocess",
+					slog.Int("pid", cmd.Process.Pid),
+					slog.Duration("watchdog_timeout", opts.WatchdogTimeout),
+				)
+
+				if opts.WatchdogCallback != nil {
+					opts.WatchdogCallback(cmd.Process.Pid, opts.WatchdogTimeout)
+				}
+
+				if err := cmd.Process.Kill(); err != nil {
+					b.log.Error("Watchdog failed to kill process",
+						slog.Int("pid", cmd.Process.Pid),
+						slog.Any("error", err),
+					)
+				} else {
+					b.log.Info("Watchdog killed process successfully",
+						slog.Int("pid", cmd.Process.Pid),
+					)
+				}
+			}
+		}()
+	}
+
+	// Read stdout (JSONL events)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stdout)
+		buf := make([]byte, 0, 64*1024)
+		scanner.Buffer(buf, 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if opts.Verbose {
+				fmt.Printf("   %s\n", line)
+			}
+
+			for _, event := range parser.Parse(line) {
+				heartbeat.Observe(event)
+				if opts.EventHandler != nil {
+					opts.EventHandler(event)
+				}
+
+				switch {
+				case event.Type == EventTypeResult && event.IsError:
+					result.Error = event.Message
+				case event.Type == EventTypeResult:
+					result.Output = event.Message
+					result.SawSuccessResult = true
+				case event.Type == EventTypeError:
+					result.Error = event.Message
+				case event.Type == EventTypeInit && event.SessionID != "":
+					result.SessionID = event.SessionID
+				}
+
+				// Accumulate token usage
+				result.TokensInput += event.TokensInput
+				result.TokensOutput += event.TokensOutput
+				result.CacheReadInputTokens += event.CacheReadInputTokens
+			}
+		}
+	}()
+
+	// Read stderr
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			stderrOutput.WriteString(line + "\n")
+			if opts.Verbose {
+				fmt.Printf("   [err] %s\n", line)
+			}
+		}
+	}()
+
+	// Monitor context for timeout and handle hard kill
+	go func() {
+		select {
+		case <-cmdDone:
+			return
+		case <-ctx.Done():
+			if cmd.Process == nil {
+				return
+			}
+
+			b.log.Warn("Context cancelled, waiting grace period before hard kill",
+				slog.Int("pid", cmd.Process.Pid),
+				slog.Duration("grace_period", GracePeriod),
+			)
+
+			select {
+			case <-cmdDone:
+				return
+			case <-time.After(GracePeriod):
+				if cmd.Process != nil {
+					b.log.Warn("Grace period expired, sending SIGKILL",
+						slog.Int("pid", cmd.Process.Pid),
+					)
+					if err := cmd.Process.Kill(); err != nil {
+						b.log.Error("Failed to kill process",
+							slog.Int("pid", cmd.Process.Pid),
+							slog.Any("error", err),
+						)
+					}
+				}
+			}
+		}
+	}()
+
+	// Wait for output readers
+	wg.Wait()
+
+	// Wait for command to complete
+	err = cmd.Wait()
+	close(cmdDone)
+
+	// Codex can exit 0 after a failed turn; treat a streamed failure as an error too
+	if err == nil && result.Error != "" && !result.SawSuccessResult {
+		err = fmt.Errorf("codex turn failed: %s", result.Error)
+	}
+
+	if err != nil {
+		result.Success = false
+
+		stderrStr := stderrOutput.String()
+		oaErr := classifyOpenAIError(stderrStr, result.Error, err)
+		if heartbeat.Stalled() {
+			oaErr = &OpenAIError{
+				Type:    OpenAIErrorTypeHeartbeatTimeout,
+				Message: fmt.Sprintf("No stream events for %v, process killed", b.heartbeatTimeout),
+				Stderr:  strings.TrimSpace(stderrStr),
+			}
+		}
+
+		b.log.Warn("Codex execution failed",
+			slog.String("error_type", string(oaErr.Type)),
+			slog.String("message", oaErr.Message),
+			slog.String("stderr", oaErr.Stderr),
+		)
+
+		// Fallback if resume fails with session not found
+		if oaErr.Type == OpenAIErrorTypeSessionNotFound && opts.ResumeSessionID != "" {
+			b.log.Warn("openai: session not found, retrying without resume",
+				"session_id", opts.ResumeSessionID)
+			opts.ResumeSessionID = ""
+			return b.Execute(ctx, opts)
+		}
+
+		if result.Error == "" {
+			result.Error = oaErr.Error()
+		}
+
+		return result, oaErr
+	}
+
+	result.Success = true
+	return result, nil
+}
+
+// codexEvent is a single line of `codex exec --json` output.
+type codexEvent struct {
+	Type     string      `json:"type"`
+	ThreadID string      `json:"thread_id,omitempty"`
+	Item     *codexItem  `json:"item,omitempty"`
+	Usage    *codexUsage `json:"usage,omitempty"`
+	Error    *codexError `json:"error,omitempty"`
+	Message  string      `json:"message,omitempty"`
+}
+
+// codexItem is a thread item: agent message, reasoning, command, file change or tool call.
+type codexItem struct {
+	ID               string              `json:"id"`
+	Type             string              `json:"type"`
+	Text             string              `json:"text,omitempty"`
+	Command          string              `json:"command,omitempty"`
+	AggregatedOutput string              `json:"aggregated_output,omitempty"`
+	ExitCode         *int                `json:"exit_code,omitempty"`
+	Status           string              `json:"status,omitempty"`
+	Changes          []codexFileChange   `json:"changes,omitempty"`
+	Server           string              `json:"server,omitempty"`
+	Tool             string              `json:"tool,omitempty"`
+	Arguments        json.RawMessage     `json:"arguments,omitempty"`
+	Query            string              `json:"query,omitempty"`
+	Result           *codexMCPToolResult `json:"result,omitempty"`
+	Error            *codexError         `json:"error,omitempty"`
+}
+
+type codexFileChange struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"` // add, delete, update
+}
+
+type codexMCPToolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text,omitempty"`
+	} `json:"content,omitempty"`
+}
+
+type codexUsage struct {
+	InputTokens       int64 `json:"input_tokens"`
+	CachedInputTokens int64 `json:"cached_input_tokens"`
+	OutputTokens      int64 `json:"output_tokens"`
+}
+
+type codexError struct {
+	Message string `json:"message"`
+}
+
+// codexStreamParser converts Codex JSONL events to BackendEvents.
+//
+// Codex reports tool-like items (commands, MCP calls, web searches) as
+// item.started followed by item.completed, while file changes only arrive
+// completed. The parser remembers started items so every tool_use gets
+// exactly one matching tool_result, which keeps the heartbeat monitor's
+// in-flight accounting balanced.
+type codexStreamParser struct {
+	model       string
+	started     map[string]bool
+	lastMessage string
+}
+
+func newCodexStreamParser(model string) *codexStreamParser {
+	return &codexStreamParser{
+		model:   model,
+		started: make(map[string]bool),
+	}
+}
+
+// Parse converts one JSONL line into zero or more BackendEvents.
+func (p *codexStreamParser) Parse(line string) []BackendEvent {
+	var ev codexEvent
+	if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Type == "" {
+		return []BackendEvent{{Type: EventTypeText, Raw: line, Message: line}}
+	}
+
+	base := BackendEvent{Raw: line}
+
+	switch ev.Type {
+	case "thread.started":
+		base.Type = EventTypeInit
+		base.SessionID = ev.ThreadID
+		base.Model = p.model
+		base.Message = "Codex initialized"
+		return []BackendEvent{base}
+
+	case "turn.started":
+		base.Type = EventTypeProgress
+		base.Message = "Turn started"
+		return []BackendEvent{base}
+
+	case "item.started":
+		if ev.Item == nil || !isCodexToolItem(ev.Item.Type) {
+			return nil
+		}
+		p.started[ev.Item.ID] = true
+		return []BackendEvent{p.toolUse(base, ev.Item)}
+
+	case "item.updated":
+		if ev.Item != nil && ev.Item.Type == "todo_list" {
+			base.Type = EventTypeProgress
+			base.Message = "Updated plan"
+			return []BackendEvent{base}
+		}
+		return nil
+
+	case "item.completed":
+		if ev.Item == nil {
+			return nil
+		}
+		return p.itemCompleted(base, ev.Item)
+
+	case "turn.completed":
+		base.Type = EventTypeResult
+		base.Message = p.lastMessage
+		base.Model = p.model
+		if ev.Usage != nil {
+			// input_tokens includes cached tokens; report them separately for cache-aware pricing
+			base.TokensInput = ev.Usage.InputTokens - ev.Usage.CachedInputTokens
+			base.CacheReadInputTokens = ev.Usage.CachedInputTokens
+			base.TokensOutput = ev.Usage.OutputTokens
+		}
+		return []BackendEvent{base}
+
+	case "turn.failed":
+		base.Type = EventTypeResult
+		base.IsError = true
+		if ev.Error != nil {
+			base.Message = ev.Error.Message
+		}
+		return []BackendEvent{base}
+
+	case "error":
+		base.Type = EventTypeError
+		base.IsError = true
+		base.Message = ev.Message
+		if base.Message == "" && ev.Error != nil {
+			base.Message = ev.Error.Message
+		}
+		return []BackendEvent{base}
+	}
+
+	return nil
+}
+
+func (p *codexStreamParser) itemCompleted(base BackendEvent, item *codexItem) []BackendEvent {
+	switch item.Type {
+	case "agent_message":
+		p.lastMessage = item.Text
+		base.Type = EventTypeText
+		base.Message = item.Text
+		return []BackendEvent{base}
+
+	case "reasoning":
+		base.Type = EventTypeText
+		base.Message = item.Text
+		return []BackendEvent{base}
+
+	case "file_change":
+		// One Write/Edit tool_use + tool_result pair per changed file, so the
+		// Runner tracks modified files and the churn guard sees every write.
+		var events []BackendEvent
+		for _, change := range item.Changes {
+			use := base
+			use.Type = EventTypeToolUse
+			use.ToolName = "Edit"
+			if change.Kind == "add" {
+				use.ToolName = "Write"
+			}
+			use.ToolInput = map[string]interface{}{"file_path": change.Path}
+			use.Message = fmt.Sprintf("Using %s", use.ToolName)
+
+			res := base
+			res.Type = EventTypeToolResult
+			res.ToolResult = fmt.Sprintf("%s %s", change.Kind, change.Path)
+			res.IsError = item.Status == "failed"
+
+			events = append(events, use, res)
+		}
+		return events
+
+	case "todo_list":
+		base.Type = EventTypeProgress
+		base.Message = "Updated plan"
+		return []BackendEvent{base}
+	}
+
+	if !isCodexToolItem(item.Type) {
+		return nil
+	}
+
+	var events []BackendEvent
+	if !p.started[item.ID] {
+		events = append(events, p.toolUse(base, item))
+	}
+	delete(p.started, item.ID)
+
+	res := base
+	res.Type = EventTypeToolResult
+	res.IsError = item.Status == "failed" || (item.ExitCode != nil && *item.ExitCode != 0) || item.Error != nil
+	switch item.Type {
+	case "command_execution":
+		res.ToolResult = item.AggregatedOutput
+	case "mcp_tool_call":
+		if item.Result != nil {
+			var parts []string
+			for _, c := range item.Result.Content {
+				if c.Text != "" {
+					parts = append(parts, c.Text)
+				}
+			}
+			res.ToolResult = strings.Join(parts, "\n")
+		}
+		if item.Error != nil {
+			res.ToolResult = item.Error.Message
+		}
+	}
+	return append(events, res)
+}
+
+// toolUse builds a tool_use event for a Codex tool item, using the PascalCase
+// tool names the Runner's handleToolUse() expects.
+func (p *codexStreamParser) toolUse(base BackendEvent, item *codexItem) BackendEvent {
+	base.Type = EventTypeToolUse
+	switch item.Type {
+	case "command_execution":
+		base.ToolName = "Bash"
+		base.ToolInput = map[string]interface{}{"command": item.Command}
+	case "mcp_tool_call":
+		base.ToolName = fmt.Sprintf("mcp__%s__%s", item.Server, item.Tool)
+		var input map[string]interface{}
+		if len(item.Arguments) > 0 && json.Unmarshal(item.Arguments, &input) == nil {
+			base.ToolInput = input
+		}
+	case "web_search":
+		base.ToolName = "WebSearch"
+		base.ToolInput = map[string]interface{}{"query": item.Query}
+	}
+	base.Message = fmt.Sprintf("Using %s", base.ToolName)
+	return base
+}
+
+// isCodexToolItem reports whether an item type represents a tool invocation.
+func isCodexToolItem(itemType string) bool {
+	switch itemType {
+	case "command_execution", "mcp_tool_call", "web_search":
+		return true
+	}
+	return false
+}
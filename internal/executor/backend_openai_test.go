@@ -0,0 +1,291 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewOpenAIBackend(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *OpenAIConfig
+		expectCommand string
+		expectModel   string
+	}{
+		{
+			name:          "nil config uses defaults",
+			config:        nil,
+			expectCommand: "codex",
+			expectModel:   DefaultOpenAIModel,
+		},
+		{
+			name:          "custom command and model",
+			config:        &OpenAIConfig{Command: "/custom/codex", Model: "gpt-5-mini"},
+			expectCommand: "/custom/codex",
+			expectModel:   "gpt-5-mini",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := NewOpenAIBackend(tt.config)
+			if backend.config.Command != tt.expectCommand {
+				t.Errorf("Command = %q, want %q", backend.config.Command, tt.expectCommand)
+			}
+			if backend.config.Model != tt.expectModel {
+				t.Errorf("Model = %q, want %q", backend.config.Model, tt.expectModel)
+			}
+		})
+	}
+}
+
+func TestOpenAIBackendName(t *testing.T) {
+	if got := NewOpenAIBackend(nil).Name(); got != BackendTypeOpenAI {
+		t.Errorf("Name() = %q, want %q", got, BackendTypeOpenAI)
+	}
+}
+
+func TestOpenAIBackendIsAvailable(t *testing.T) {
+	backend := NewOpenAIBackend(&OpenAIConfig{Command: "/nonexistent/path/to/codex"})
+	if backend.IsAvailable() {
+		t.Error("IsAvailable() should return false for non-existent command")
+	}
+}
+
+func TestOpenAIBuildArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *OpenAIConfig
+		opts     ExecuteOptions
+		contains []string
+		excludes []string
+		last     []string
+	}{
+		{
+			name:     "defaults",
+			config:   &OpenAIConfig{},
+			opts:     ExecuteOptions{Prompt: "do it"},
+			contains: []string{"exec", "--json", "--dangerously-bypass-approvals-and-sandbox", "--model " + DefaultOpenAIModel},
+			excludes: []string{"model_reasoning_effort", "resume"},
+			last:     []string{"do it"},
+		},
+		{
+			name:     "routed model and max effort",
+			config:   &OpenAIConfig{},
+			opts:     ExecuteOptions{Prompt: "do it", Model: "gpt-5-mini", Effort: "max"},
+			contains: []string{"--model gpt-5-mini", "-c model_reasoning_effort=high"},
+		},
+		{
+			name:     "resume when enabled",
+			config:   &OpenAIConfig{UseSessionResume: true, ExtraArgs: []string{"--oss"}},
+			opts:     ExecuteOptions{Prompt: "review", ResumeSessionID: "thread-1"},
+			contains: []string{"--oss"},
+			last:     []string{"resume", "thread-1", "review"},
+		},
+		{
+			name:     "resume ignored when disabled",
+			config:   &OpenAIConfig{},
+			opts:     ExecuteOptions{Prompt: "review", ResumeSessionID: "thread-1"},
+			excludes: []string{"resume"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := NewOpenAIBackend(tt.config).buildArgs(tt.opts)
+			joined := strings.Join(args, " ")
+			for _, want := range tt.contains {
+				if !strings.Contains(joined, want) {
+					t.Errorf("args %q missing %q", joined, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(joined, unwanted) {
+					t.Errorf("args %q should not contain %q", joined, unwanted)
+				}
+			}
+			if len(tt.last) > 0 {
+				tail := args[len(args)-len(tt.last):]
+				if strings.Join(tail, " ") != strings.Join(tt.last, " ") {
+					t.Errorf("args tail = %q, want %q", tail, tt.last)
+				}
+			}
+		})
+	}
+}
+
+func TestCodexStreamParser(t *testing.T) {
+	p := newCodexStreamParser("gpt-5-codex")
+
+	init := p.Parse(`{"type":"thread.started","thread_id":"thread-1"}`)
+	if len(init) != 1 || init[0].Type != EventTypeInit || init[0].SessionID != "thread-1" || init[0].Model != "gpt-5-codex" {
+		t.Fatalf("thread.started = %+v", init)
+	}
+
+	// Command: started → tool_use, completed → tool_result only
+	started := p.Parse(`{"type":"item.started","item":{"id":"item_1","type":"command_execution","command":"go test ./...","status":"in_progress"}}`)
+	if len(started) != 1 || started[0].Type != EventTypeToolUse || started[0].ToolName != "Bash" || started[0].ToolInput["command"] != "go test ./..." {
+		t.Fatalf("command started = %+v", started)
+	}
+	done := p.Parse(`{"type":"item.completed","item":{"id":"item_1","type":"command_execution","command":"go test ./...","aggregated_output":"FAIL","exit_code":1,"status":"failed"}}`)
+	if len(done) != 1 || done[0].Type != EventTypeToolResult || done[0].ToolResult != "FAIL" || !done[0].IsError {
+		t.Fatalf("command completed = %+v", done)
+	}
+
+	// File change: one Write/Edit tool_use + tool_result pair per file
+	changes := p.Parse(`{"type":"item.completed","item":{"id":"item_2","type":"file_change","changes":[{"path":"/repo/a.go","kind":"add"},{"path":"/repo/b.go","kind":"update"}],"status":"completed"}}`)
+	if len(changes) != 4 {
+		t.Fatalf("expected 4 events for 2 file changes, got %d", len(changes))
+	}
+	if changes[0].ToolName != "Write" || changes[0].ToolInput["file_path"] != "/repo/a.go" || changes[1].Type != EventTypeToolResult {
+		t.Errorf("first change = %+v / %+v", changes[0], changes[1])
+	}
+	if changes[2].ToolName != "Edit" || changes[2].ToolInput["file_path"] != "/repo/b.go" {
+		t.Errorf("second change = %+v", changes[2])
+	}
+
+	// Tool item seen only as completed still yields a balanced pair
+	search := p.Parse(`{"type":"item.completed","item":{"id":"item_3","type":"web_search","query":"codex json"}}`)
+	if len(search) != 2 || search[0].ToolName != "WebSearch" || search[1].Type != EventTypeToolResult {
+		t.Errorf("web_search = %+v", search)
+	}
+
+	msg := p.Parse(`{"type":"item.completed","item":{"id":"item_4","type":"agent_message","text":"All done"}}`)
+	if len(msg) != 1 || msg[0].Type != EventTypeText || msg[0].Message != "All done" {
+		t.Errorf("agent_message = %+v", msg)
+	}
+
+	result := p.Parse(`{"type":"turn.completed","usage":{"input_tokens":1200,"cached_input_tokens":1000,"output_tokens":300}}`)
+	if len(result) != 1 || result[0].Type != EventTypeResult || result[0].Message != "All done" {
+		t.Fatalf("turn.completed = %+v", result)
+	}
+	if result[0].TokensInput != 200 || result[0].CacheReadInputTokens != 1000 || result[0].TokensOutput != 300 {
+		t.Errorf("usage = in %d cached %d out %d", result[0].TokensInput, result[0].CacheReadInputTokens, result[0].TokensOutput)
+	}
+
+	failed := p.Parse(`{"type":"turn.failed","error":{"message":"stream disconnected"}}`)
+	if len(failed) != 1 || failed[0].Type != EventTypeResult || !failed[0].IsError || failed[0].Message != "stream disconnected" {
+		t.Errorf("turn.failed = %+v", failed)
+	}
+
+	plain := p.Parse("not json")
+	if len(plain) != 1 || plain[0].Type != EventTypeText || plain[0].Message != "not json" {
+		t.Errorf("non-JSON line = %+v", plain)
+	}
+}
+
+func TestClassifyOpenAIError(t *testing.T) {
+	tests := []struct {
+		name      string
+		stderr    string
+		streamErr string
+		want      OpenAIErrorType
+	}{
+		{"rate limit on stderr", "ERROR: 429 Too Many Requests", "", OpenAIErrorTypeRateLimit},
+		{"quota in stream", "", "insufficient_quota: You exceeded your current quota", OpenAIErrorTypeRateLimit},
+		{"bad model", "", "model_not_found: The model `gpt-9` does not exist", OpenAIErrorTypeInvalidConfig},
+		{"auth", "401 Unauthorized", "", OpenAIErrorTypeAPIError},
+		{"session", "Error: no rollout found for thread id", "", OpenAIErrorTypeSessionNotFound},
+		{"killed", "signal: killed", "", OpenAIErrorTypeTimeout},
+		{"unknown", "something odd", "", OpenAIErrorTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyOpenAIError(tt.stderr, tt.streamErr, nil)
+			if got.Type != tt.want {
+				t.Errorf("type = %s, want %s", got.Type, tt.want)
+			}
+		})
+	}
+}
+
+// fakeCodex writes a shell script that prints the given JSONL and exits 0.
+func fakeCodex(t *testing.T, jsonl string) string {
+	t.Helper()
+	dir := t.TempDir()
+	data := filepath.Join(dir, "events.jsonl")
+	if err := os.WriteFile(data, []byte(jsonl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "codex")
+	body := "#!/bin/sh\ncat " + data + "\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+func TestOpenAIBackendExecute(t *testing.T) {
+	script := fakeCodex(t, strings.Join([]string{
+		`{"type":"thread.started","thread_id":"thread-9"}`,
+		`{"type":"item.started","item":{"id":"item_1","type":"command_execution","command":"ls","status":"in_progress"}}`,
+		`{"type":"item.completed","item":{"id":"item_1","type":"command_execution","command":"ls","aggregated_output":"main.go","exit_code":0,"status":"completed"}}`,
+		`{"type":"item.completed","item":{"id":"item_2","type":"agent_message","text":"Implemented"}}`,
+		`{"type":"turn.completed","usage":{"input_tokens":500,"cached_input_tokens":100,"output_tokens":50}}`,
+	}, "\n")+"\n")
+
+	backend := NewOpenAIBackend(&OpenAIConfig{Command: script})
+	var events []BackendEvent
+	result, err := backend.Execute(context.Background(), ExecuteOptions{
+		Prompt:       "task",
+		ProjectPath:  t.TempDir(),
+		EventHandler: func(e BackendEvent) { events = append(events, e) },
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || result.Output != "Implemented" || result.SessionID != "thread-9" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.TokensInput != 400 || result.CacheReadInputTokens != 100 || result.TokensOutput != 50 {
+		t.Errorf("tokens = in %d cached %d out %d", result.TokensInput, result.CacheReadInputTokens, result.TokensOutput)
+	}
+	if result.Model != DefaultOpenAIModel {
+		t.Errorf("Model = %q, want %q", result.Model, DefaultOpenAIModel)
+	}
+	if len(events) != 5 {
+		t.Errorf("expected 5 events, got %d", len(events))
+	}
+}
+
+func TestOpenAIBackendExecute_TurnFailed(t *testing.T) {
+	script := fakeCodex(t, strings.Join([]string{
+		`{"type":"thread.started","thread_id":"thread-9"}`,
+		`{"type":"turn.failed","error":{"message":"429 Too Many Requests"}}`,
+	}, "\n")+"\n")
+
+	backend := NewOpenAIBackend(&OpenAIConfig{Command: script})
+	result, err := backend.Execute(context.Background(), ExecuteOptions{Prompt: "task", ProjectPath: t.TempDir()})
+	if err == nil {
+		t.Fatal("expected error for failed turn")
+	}
+	oaErr, ok := err.(*OpenAIError)
+	if !ok || oaErr.Type != OpenAIErrorTypeRateLimit {
+		t.Errorf("error = %v, want rate_limit OpenAIError", err)
+	}
+	if result.Success {
+		t.Error("result should not be successful")
+	}
+}
+
+func TestBackendFactoryOpenAI(t *testing.T) {
+	config := DefaultBackendConfig()
+	config.Type = BackendTypeOpenAI
+	config.OpenAI = &OpenAIConfig{Command: "/custom/codex", Model: "gpt-5"}
+
+	backend, err := NewBackend(config)
+	if err != nil {
+		t.Fatalf("NewBackend() error = %v", err)
+	}
+	oa, ok := backend.(*OpenAIBackend)
+	if !ok {
+		t.Fatalf("expected *OpenAIBackend, got %T", backend)
+	}
+	if oa.config.Command != "/custom/codex" || oa.config.Model != "gpt-5" {
+		t.Errorf("config = %+v", oa.config)
+	}
+}
@@ -28,6 +28,12 @@ type OpenCodeBackend struct {
 	serverMu   sync.Mutex
 }
 
+func init() {
+	RegisterBackend(BackendTypeOpenCode, func(config *BackendConfig) Backend {
+		return NewOpenCodeBackend(config.OpenCode)
+	})
+}
+
 // NewOpenCodeBackend creates a new OpenCode backend.
 func NewOpenCodeBackend(config *OpenCodeConfig) *OpenCodeBackend {
 	if config == nil {
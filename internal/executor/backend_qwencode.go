@@ -9,7 +9,6 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/alekspetrov/pilot/internal/logging"
@@ -53,6 +52,7 @@ const (
 	QwenErrorTypeTimeout          QwenCodeErrorType = "timeout"
 	QwenErrorTypeInvalidConfig    QwenCodeErrorType = "invalid_config"
 	QwenErrorTypeSessionNotFound  QwenCodeErrorType = "session_not_found"
+	QwenErrorTypeHeartbeatTimeout QwenCodeErrorType = "heartbeat_timeout"
 	QwenErrorTypeUnknown          QwenCodeErrorType = "unknown"
 )
 
@@ -163,6 +163,12 @@ type QwenCodeBackend struct {
 	log              *slog.Logger
 }
 
+func init() {
+	RegisterBackend(BackendTypeQwenCode, func(config *BackendConfig) Backend {
+		return NewQwenCodeBackend(config.QwenCode)
+	})
+}
+
 // NewQwenCodeBackend creates a new Qwen Code backend.
 func NewQwenCodeBackend(config *QwenCodeConfig) *QwenCodeBackend {
 	if config == nil {
@@ -274,54 +280,11 @@ func (b *QwenCodeBackend) Execute(ctx context.Context, opts ExecuteOptions) (*Ba
 	// Channel to signal command completion
 	cmdDone := make(chan struct{})
 
-	// Heartbeat tracking: store last event time as Unix nano (atomic int64)
-	var lastEventAt atomic.Int64
-	lastEventAt.Store(time.Now().UnixNano())
-
-	// Heartbeat monitor goroutine
+	// Heartbeat monitor: probes, then kills the process after a silent stream (GH-884)
+	heartbeat := newHeartbeatMonitor(b.heartbeatTimeout)
 	heartbeatCtx, cancelHeartbeat := context.WithCancel(context.Background())
 	defer cancelHeartbeat()
-	go func() {
-		ticker := time.NewTicker(HeartbeatCheckInterval)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-heartbeatCtx.Done():
-				return
-			case <-cmdDone:
-				return
-			case <-ticker.C:
-				lastNano := lastEventAt.Load()
-				lastTime := time.Unix(0, lastNano)
-				age := time.Since(lastTime)
-				if age > b.heartbeatTimeout {
-					b.log.Warn("Heartbeat timeout detected, killing hung process",
-						slog.Int("pid", cmd.Process.Pid),
-						slog.Duration("last_event_age", age),
-						slog.Duration("timeout", b.heartbeatTimeout),
-					)
-
-					if opts.HeartbeatCallback != nil {
-						opts.HeartbeatCallback(cmd.Process.Pid, age)
-					}
-
-					if cmd.Process != nil {
-						if err := cmd.Process.Kill(); err != nil {
-							b.log.Error("Failed to kill hung process",
-								slog.Int("pid", cmd.Process.Pid),
-								slog.Any("error", err),
-							)
-						} else {
-							b.log.Info("Hung process killed successfully",
-								slog.Int("pid", cmd.Process.Pid),
-							)
-						}
-					}
-					return
-				}
-			}
-		}
-	}()
+	go heartbeat.Watch(heartbeatCtx, cmdDone, cmd.Process, b.log, opts.HeartbeatCallback)
 
 	// Watchdog goroutine: hard kill after absolute timeout
 	if opts.WatchdogTimeout > 0 {
@@ -368,15 +331,13 @@ func (b *QwenCodeBackend) Execute(ctx context.Context, opts ExecuteOptions) (*Ba
 		for scanner.Scan() {
 			line := scanner.Text()
 
-			// Update heartbeat timestamp
-			lastEventAt.Store(time.Now().UnixNano())
-
 			if opts.Verbose {
 				fmt.Printf("   %s\n", line)
 			}
 
 			// Parse and convert to BackendEvent
 			event := b.parseStreamEvent(line)
+			heartbeat.Observe(event)
 			if opts.EventHandler != nil {
 				opts.EventHandler(event)
 			}
@@ -471,6 +432,13 @@ func (b *QwenCodeBackend) Execute(ctx context.Context, opts ExecuteOptions) (*Ba
 
 		stderrStr := stderrOutput.String()
 		qcErr := classifyQwenCodeError(stderrStr, err)
+		if heartbeat.Stalled() {
+			qcErr = &QwenCodeError{
+				Type:    QwenErrorTypeHeartbeatTimeout,
+				Message: fmt.Sprintf("No stream events for %v, process killed", b.heartbeatTimeout),
+				Stderr:  strings.TrimSpace(stderrStr),
+			}
+		}
 
 		b.log.Warn("Qwen Code execution failed",
 			slog.String("error_type", string(qcErr.Type)),
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackendLabelPrefix marks issue labels that pick the backend for a task,
+// e.g. "backend:opencode".
+const BackendLabelPrefix = "backend:"
+
+// BackendFactory creates a backend from the shared backend configuration.
+// Factories read their own section of config (e.g. config.OpenCode).
+type BackendFactory func(config *BackendConfig) Backend
+
+var (
+	backendRegistryMu sync.RWMutex
+	backendRegistry   = map[string]BackendFactory{}
+)
+
+// RegisterBackend adds a backend factory under name.
+// Typically called from a backend's init() function.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendRegistryMu.Lock()
+	defer backendRegistryMu.Unlock()
+	backendRegistry[name] = factory
+}
+
+// RegisteredBackends returns the names of all registered backends, sorted.
+func RegisteredBackends() []string {
+	backendRegistryMu.RLock()
+	defer backendRegistryMu.RUnlock()
+	names := make([]string, 0, len(backendRegistry))
+	for name := range backendRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsRegisteredBackend reports whether a backend is registered under name.
+func IsRegisteredBackend(name string) bool {
+	backendRegistryMu.RLock()
+	defer backendRegistryMu.RUnlock()
+	_, ok := backendRegistry[name]
+	return ok
+}
+
+// heartbeatSetter is implemented by backends with a stall watchdog.
+type heartbeatSetter interface {
+	SetHeartbeatTimeout(d time.Duration)
+}
+
+// NewBackend creates a Backend instance based on configuration.
+func NewBackend(config *BackendConfig) (Backend, error) {
+	if config == nil {
+		config = DefaultBackendConfig()
+	}
+
+	name := config.Type
+	if name == "" {
+		name = BackendTypeClaudeCode
+	}
+
+	backendRegistryMu.RLock()
+	factory, ok := backendRegistry[name]
+	backendRegistryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown backend type: %s", config.Type)
+	}
+
+	b := factory(config)
+	if hb, ok := b.(heartbeatSetter); ok {
+		hb.SetHeartbeatTimeout(config.EffectiveHeartbeatTimeout())
+	}
+	return b, nil
+}
+
+// NewBackendFromType creates a Backend instance using default config for the type.
+func NewBackendFromType(backendType string) (Backend, error) {
+	config := DefaultBackendConfig()
+	config.Type = backendType
+	return NewBackend(config)
+}
+
+// BackendFromLabels returns the backend named by a "backend:<name>" label,
+// or "" when no label selects one. The first matching label wins.
+func BackendFromLabels(labels []string) string {
+	for _, label := range labels {
+		if len(label) > len(BackendLabelPrefix) && strings.EqualFold(label[:len(BackendLabelPrefix)], BackendLabelPrefix) {
+			return strings.ToLower(strings.TrimSpace(label[len(BackendLabelPrefix):]))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,291 @@
+package executor
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestNewBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *BackendConfig
+		expectType  string
+		expectError bool
+	}{
+		{
+			name:       "nil config defaults to claude-code",
+			config:     nil,
+			expectType: BackendTypeClaudeCode,
+		},
+		{
+			name:       "empty type defaults to claude-code",
+			config:     &BackendConfig{Type: ""},
+			expectType: BackendTypeClaudeCode,
+		},
+		{
+			name:       "claude-code type",
+			config:     &BackendConfig{Type: BackendTypeClaudeCode},
+			expectType: BackendTypeClaudeCode,
+		},
+		{
+			name:       "opencode type",
+			config:     &BackendConfig{Type: BackendTypeOpenCode},
+			expectType: BackendTypeOpenCode,
+		},
+		{
+			name:        "unknown type",
+			config:      &BackendConfig{Type: "unknown-backend"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewBackend(tt.config)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if backend == nil {
+				t.Fatal("backend is nil")
+			}
+			if backend.Name() != tt.expectType {
+				t.Errorf("Name() = %q, want %q", backend.Name(), tt.expectType)
+			}
+		})
+	}
+}
+
+func TestNewBackendFromType(t *testing.T) {
+	tests := []struct {
+		name        string
+		backendType string
+		expectType  string
+		expectError bool
+	}{
+		{
+			name:        "claude-code",
+			backendType: BackendTypeClaudeCode,
+			expectType:  BackendTypeClaudeCode,
+		},
+		{
+			name:        "opencode",
+			backendType: BackendTypeOpenCode,
+			expectType:  BackendTypeOpenCode,
+		},
+		{
+			name:        "unknown",
+			backendType: "invalid",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewBackendFromType(tt.backendType)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if backend.Name() != tt.expectType {
+				t.Errorf("Name() = %q, want %q", backend.Name(), tt.expectType)
+			}
+		})
+	}
+}
+
+func TestNewBackendWithClaudeCodeConfig(t *testing.T) {
+	config := &BackendConfig{
+		Type: BackendTypeClaudeCode,
+		ClaudeCode: &ClaudeCodeConfig{
+			Command:   "/custom/claude",
+			ExtraArgs: []string{"--verbose"},
+		},
+	}
+
+	backend, err := NewBackend(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if backend.Name() != BackendTypeClaudeCode {
+		t.Errorf("Name() = %q, want %q", backend.Name(), BackendTypeClaudeCode)
+	}
+
+	// Verify it's a ClaudeCodeBackend
+	ccBackend, ok := backend.(*ClaudeCodeBackend)
+	if !ok {
+		t.Fatal("backend is not *ClaudeCodeBackend")
+	}
+	if ccBackend.config.Command != "/custom/claude" {
+		t.Errorf("Command = %q, want /custom/claude", ccBackend.config.Command)
+	}
+}
+
+func TestNewBackendWithOpenCodeConfig(t *testing.T) {
+	config := &BackendConfig{
+		Type: BackendTypeOpenCode,
+		OpenCode: &OpenCodeConfig{
+			ServerURL: "http://localhost:5000",
+			Model:     "anthropic/claude-opus-4",
+		},
+	}
+
+	backend, err := NewBackend(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if backend.Name() != BackendTypeOpenCode {
+		t.Errorf("Name() = %q, want %q", backend.Name(), BackendTypeOpenCode)
+	}
+
+	// Verify it's an OpenCodeBackend
+	ocBackend, ok := backend.(*OpenCodeBackend)
+	if !ok {
+		t.Fatal("backend is not *OpenCodeBackend")
+	}
+	if ocBackend.config.ServerURL != "http://localhost:5000" {
+		t.Errorf("ServerURL = %q, want http://localhost:5000", ocBackend.config.ServerURL)
+	}
+}
+
+func TestRegisteredBackends(t *testing.T) {
+	got := RegisteredBackends()
+	for _, want := range []string{
+		BackendTypeClaudeCode, BackendTypeOpenCode, BackendTypeQwenCode,
+		BackendTypeOpenAI, BackendTypeGemini, BackendTypeOllama,
+	} {
+		if !IsRegisteredBackend(want) {
+			t.Errorf("%s not registered (got %v)", want, got)
+		}
+	}
+	if !sort.StringsAreSorted(got) {
+		t.Errorf("RegisteredBackends() = %v, want sorted", got)
+	}
+}
+
+func TestNewBackend_AppliesHeartbeatTimeout(t *testing.T) {
+	backend, err := NewBackend(&BackendConfig{Type: BackendTypeQwenCode, HeartbeatTimeout: 7 * time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := backend.(*QwenCodeBackend).heartbeatTimeout; got != 7*time.Minute {
+		t.Errorf("heartbeatTimeout = %v, want 7m", got)
+	}
+}
+
+func TestBackendFromLabels(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"pilot", "bug"}, ""},
+		{[]string{"pilot", "backend:opencode"}, "opencode"},
+		{[]string{"Backend:Ollama", "backend:gemini"}, "ollama"},
+		{[]string{"backend:"}, ""},
+	}
+	for _, tt := range tests {
+		if got := BackendFromLabels(tt.labels); got != tt.want {
+			t.Errorf("BackendFromLabels(%v) = %q, want %q", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestBackendConfig_BackendAllowed(t *testing.T) {
+	var nilCfg *BackendConfig
+	if !nilCfg.BackendAllowed(BackendTypeOpenCode) {
+		t.Error("nil config should allow registered backends")
+	}
+	if nilCfg.BackendAllowed("nope") {
+		t.Error("unregistered backend should never be allowed")
+	}
+
+	cfg := &BackendConfig{AllowedBackends: []string{"ollama"}}
+	if !cfg.BackendAllowed(BackendTypeOllama) || cfg.BackendAllowed(BackendTypeOpenCode) {
+		t.Errorf("allowed_backends %v not enforced", cfg.AllowedBackends)
+	}
+}
+
+// overrideTestBackend is registered under a test-only name to exercise
+// per-task backend selection through the registry.
+type overrideTestBackend struct {
+	mockSelfReviewBackend
+	available bool
+}
+
+func (b *overrideTestBackend) Name() string      { return "test-override" }
+func (b *overrideTestBackend) IsAvailable() bool { return b.available }
+
+func TestRunnerBackendForTask(t *testing.T) {
+	available := true
+	RegisterBackend("test-override", func(*BackendConfig) Backend {
+		return &overrideTestBackend{available: available}
+	})
+
+	primary := &mockSelfReviewBackend{}
+
+	t.Run("no override keeps routed model on primary", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		backend, model := runner.backendForTask(&Task{ID: "T-1"}, "claude-opus-4-6")
+		if backend != primary || model != "claude-opus-4-6" {
+			t.Errorf("got (%v, %q), want primary with routed model", backend, model)
+		}
+	})
+
+	t.Run("label override uses registered backend with its own model", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		task := &Task{ID: "T-2", Labels: []string{"backend:test-override"}}
+		backend, model := runner.backendForTask(task, "claude-opus-4-6")
+		if backend.Name() != "test-override" || model != "" {
+			t.Errorf("got (%s, %q), want test-override with empty model", backend.Name(), model)
+		}
+		again, _ := runner.backendForTask(task, "")
+		if again != backend {
+			t.Error("override backend should be created once and reused")
+		}
+	})
+
+	t.Run("field override wins over label", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		task := &Task{ID: "T-3", Backend: "test-override", Labels: []string{"backend:nope"}}
+		if backend, _ := runner.backendForTask(task, ""); backend.Name() != "test-override" {
+			t.Errorf("backend = %s, want test-override", backend.Name())
+		}
+	})
+
+	t.Run("unknown, disallowed or unavailable override falls back", func(t *testing.T) {
+		runner := NewRunnerWithBackend(primary)
+		if backend, _ := runner.backendForTask(&Task{Backend: "nope"}, ""); backend != primary {
+			t.Error("unknown backend should fall back to primary")
+		}
+
+		runner.config = &BackendConfig{AllowedBackends: []string{BackendTypeOllama}}
+		if backend, _ := runner.backendForTask(&Task{Backend: "test-override"}, ""); backend != primary {
+			t.Error("disallowed backend should fall back to primary")
+		}
+
+		available = false
+		defer func() { available = true }()
+		runner = NewRunnerWithBackend(primary)
+		if backend, _ := runner.backendForTask(&Task{Backend: "test-override"}, ""); backend != primary {
+			t.Error("unavailable backend should fall back to primary")
+		}
+	})
+}
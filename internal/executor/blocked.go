@@ -0,0 +1,38 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ErrTaskBlocked is returned by Execute when the agent stopped on a BLOCKED
+// step signal. Callers hand the task back to its requester instead of
+// reporting a failure.
+var ErrTaskBlocked = errors.New("task blocked")
+
+// BlockedError describes why a task is blocked and which backend session to
+// resume once the requester replies
+type BlockedError struct {
+	Reason    string
+	SessionID string // Empty when the backend reported no session
+}
+
+func (e *BlockedError) Error() string {
+	return "task blocked: " + e.Reason
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrTaskBlocked
+}
+
+// blockedResult marks an execution the agent could not finish without help
+func (r *Runner) blockedResult(task *Task, result *ExecutionResult, reason, sessionID string) (*ExecutionResult, error) {
+	r.log.Info("Task blocked", slog.String("task_id", task.ID), slog.String("reason", reason))
+	if r.monitor != nil {
+		r.monitor.Block(task.ID, reason)
+	}
+	result.Success = false
+	result.Error = "blocked: " + reason
+	return result, fmt.Errorf("task %s: %w", task.ID, &BlockedError{Reason: reason, SessionID: sessionID})
+}
@@ -0,0 +1,47 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunner_BlockedResult(t *testing.T) {
+	runner := NewRunner()
+	monitor := NewMonitor()
+	runner.SetMonitor(monitor)
+	monitor.Register("GH-1", "Add billing", "")
+	monitor.Start("GH-1")
+
+	result, err := runner.blockedResult(&Task{ID: "GH-1"}, &ExecutionResult{TaskID: "GH-1"}, "missing STRIPE_KEY", "sess-1")
+
+	if !errors.Is(err, ErrTaskBlocked) {
+		t.Fatalf("error = %v, want ErrTaskBlocked", err)
+	}
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) || blocked.Reason != "missing STRIPE_KEY" || blocked.SessionID != "sess-1" {
+		t.Errorf("BlockedError = %+v", blocked)
+	}
+	if result.Success || result.Error != "blocked: missing STRIPE_KEY" {
+		t.Errorf("result = %+v", result)
+	}
+	state, ok := monitor.Get("GH-1")
+	if !ok || state.Status != StatusBlocked || state.Phase != PhaseBlocked || state.Error != "missing STRIPE_KEY" || state.CompletedAt == nil {
+		t.Errorf("monitor state = %+v, want blocked", state)
+	}
+}
+
+func TestRunner_BlockedClearedByLaterStep(t *testing.T) {
+	runner := NewRunner()
+	state := &progressState{phase: "Starting", task: &Task{ID: "GH-1"}}
+
+	runner.parseNavigatorPatterns("GH-1", "```pilot-signal\n{\"v\":2,\"type\":\"blocked\",\"reason\":\"which region?\"}\n```", state)
+	if !state.blocked {
+		t.Fatal("BLOCKED step should mark the run blocked")
+	}
+
+	// The agent found a way forward on its own
+	runner.parseNavigatorPatterns("GH-1", "```pilot-signal\n{\"v\":2,\"type\":\"edit\",\"file\":\"deploy.go\"}\n```", state)
+	if state.blocked {
+		t.Error("a later step should clear the blocked state")
+	}
+}
@@ -0,0 +1,194 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTaskDeferred is returned by Execute when budget-aware routing held back
+// a low-priority task. Callers retry it the next day instead of reporting a
+// failure.
+var ErrTaskDeferred = errors.New("task deferred")
+
+// DeferredError describes why a task was deferred and when to retry it
+type DeferredError struct {
+	SpendPercent float64   // Daily spend in percent of the daily limit
+	Until        time.Time // Start of the next budget day
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("task deferred: daily spend at %.0f%% of budget, retry after %s",
+		e.SpendPercent, e.Until.Format(time.RFC3339))
+}
+
+func (e *DeferredError) Unwrap() error {
+	return ErrTaskDeferred
+}
+
+var deferredPattern = regexp.MustCompile(`daily spend at (\d+)% of budget, retry after (\S+)`)
+
+// ParseDeferred decodes an execution record holding a DeferredError. A
+// record it can't read defers to the next day.
+func ParseDeferred(detail string) *DeferredError {
+	e := &DeferredError{Until: nextBudgetDay(time.Now())}
+	if m := deferredPattern.FindStringSubmatch(detail); m != nil {
+		e.SpendPercent, _ = strconv.ParseFloat(m[1], 64)
+		if until, err := time.Parse(time.RFC3339, m[2]); err == nil {
+			e.Until = until
+		}
+	}
+	return e
+}
+
+// BudgetState reports the day's spend against the daily budget. Satisfied
+// by *budget.Enforcer.
+type BudgetState interface {
+	DailySpendPercent(ctx context.Context) (float64, error)
+}
+
+const (
+	defaultBudgetDowngradeAt = 70
+	defaultBudgetDeferAt     = 90
+)
+
+var defaultLowPriorityLabels = []string{"priority:low", "low-priority"}
+
+func (c *BudgetRoutingConfig) downgradeAt() float64 {
+	if c.DowngradeAt > 0 {
+		return c.DowngradeAt
+	}
+	return defaultBudgetDowngradeAt
+}
+
+func (c *BudgetRoutingConfig) deferAt() float64 {
+	if c.DeferAt > 0 {
+		return c.DeferAt
+	}
+	return defaultBudgetDeferAt
+}
+
+// lowPriority reports whether the task carries a low-priority label
+func (c *BudgetRoutingConfig) lowPriority(task *Task) bool {
+	labels := c.LowPriorityLabels
+	if len(labels) == 0 {
+		labels = defaultLowPriorityLabels
+	}
+	for _, l := range task.Labels {
+		for _, low := range labels {
+			if strings.EqualFold(l, low) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BudgetRoute is how the day's spend changes a task's routing
+type BudgetRoute struct {
+	SpendPercent float64
+	Downgrade    bool // Run on the next cheaper tier's model
+	Defer        bool // Low-priority task waits for the next day
+}
+
+// SetBudgetState sets the source of daily spend for budget-aware routing.
+func (r *ModelRouter) SetBudgetState(s BudgetState) {
+	r.budgetState = s
+}
+
+// BudgetRoute returns how budget pressure affects the task. It is zero when
+// budget routing is disabled, no budget state is set, or spend is below the
+// thresholds.
+func (r *ModelRouter) BudgetRoute(task *Task) BudgetRoute {
+	if r.modelConfig == nil || r.modelConfig.Budget == nil || !r.modelConfig.Budget.Enabled || r.budgetState == nil {
+		return BudgetRoute{}
+	}
+	cfg := r.modelConfig.Budget
+
+	percent, err := r.budgetState.DailySpendPercent(context.Background())
+	if err != nil {
+		slog.Warn("Budget state unavailable, routing without it", slog.Any("error", err))
+		return BudgetRoute{}
+	}
+	return BudgetRoute{
+		SpendPercent: percent,
+		Downgrade:    r.modelConfig.Enabled && percent >= cfg.downgradeAt(),
+		Defer:        percent >= cfg.deferAt() && cfg.lowPriority(task),
+	}
+}
+
+// cheaperComplexity returns the tier below c, whose model is cheaper
+func cheaperComplexity(c Complexity) Complexity {
+	switch c {
+	case ComplexityEpic, ComplexityComplex:
+		return ComplexityMedium
+	case ComplexityMedium:
+		return ComplexitySimple
+	default:
+		return ComplexityTrivial
+	}
+}
+
+// nextBudgetDay returns the start of the day after now, when the daily
+// budget resets
+func nextBudgetDay(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+}
+
+// SetBudgetState wires daily spend into model routing, so routing degrades
+// as spend approaches the daily budget.
+func (r *Runner) SetBudgetState(s BudgetState) {
+	if r.modelRouter != nil {
+		r.modelRouter.SetBudgetState(s)
+	}
+}
+
+// checkBudgetRoute applies budget pressure before a task runs: a low-priority
+// task is deferred to the next day, and a downgrade to a cheaper model is
+// recorded. Both emit a budget_routing alert event.
+func (r *Runner) checkBudgetRoute(task *Task) (*ExecutionResult, error) {
+	route := r.modelRouter.BudgetRoute(task)
+	if !route.Defer && !route.Downgrade {
+		return nil, nil
+	}
+
+	action := "downgrade"
+	metadata := map[string]string{"spend_percent": fmt.Sprintf("%.0f", route.SpendPercent)}
+	var deferred *DeferredError
+	if route.Defer {
+		action = "defer"
+		deferred = &DeferredError{SpendPercent: route.SpendPercent, Until: nextBudgetDay(time.Now())}
+		metadata["until"] = deferred.Until.Format(time.RFC3339)
+	}
+	metadata["action"] = action
+
+	r.log.Info("Budget-aware routing",
+		slog.String("task_id", task.ID),
+		slog.String("action", action),
+		slog.Float64("spend_percent", route.SpendPercent),
+	)
+	r.emitAlertEvent(AlertEvent{
+		Type:      AlertEventTypeBudgetRouting,
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+		Project:   task.ProjectPath,
+		Metadata:  metadata,
+		Timestamp: time.Now(),
+	})
+
+	if deferred == nil {
+		return nil, nil
+	}
+	r.saveLogEntry(task.ID, "info", deferred.Error())
+	result := &ExecutionResult{
+		TaskID:  task.ID,
+		Success: false,
+		Error:   "deferred: " + deferred.Error(),
+	}
+	return result, fmt.Errorf("task %s: %w", task.ID, deferred)
+}
@@ -0,0 +1,148 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fixedBudgetState struct {
+	percent float64
+	err     error
+}
+
+func (s fixedBudgetState) DailySpendPercent(context.Context) (float64, error) {
+	return s.percent, s.err
+}
+
+func budgetRoutingConfig() *ModelRoutingConfig {
+	return &ModelRoutingConfig{
+		Enabled: true,
+		Trivial: "claude-haiku",
+		Simple:  "claude-sonnet",
+		Medium:  "claude-sonnet",
+		Complex: "claude-opus",
+		Budget:  &BudgetRoutingConfig{Enabled: true},
+	}
+}
+
+func TestModelRouter_BudgetRoute(t *testing.T) {
+	low := &Task{ID: "GH-1", Labels: []string{"pilot", "Priority:Low"}}
+	normal := &Task{ID: "GH-2", Labels: []string{"pilot"}}
+
+	tests := []struct {
+		name          string
+		config        *ModelRoutingConfig
+		state         BudgetState
+		task          *Task
+		wantDowngrade bool
+		wantDefer     bool
+	}{
+		{"below thresholds", budgetRoutingConfig(), fixedBudgetState{percent: 50}, low, false, false},
+		{"downgrade at 70%", budgetRoutingConfig(), fixedBudgetState{percent: 70}, low, true, false},
+		{"defer low priority at 90%", budgetRoutingConfig(), fixedBudgetState{percent: 95}, low, true, true},
+		{"normal priority never deferred", budgetRoutingConfig(), fixedBudgetState{percent: 95}, normal, true, false},
+		{"budget state error", budgetRoutingConfig(), fixedBudgetState{err: errors.New("db locked")}, low, false, false},
+		{"no budget state", budgetRoutingConfig(), nil, low, false, false},
+		{"budget routing disabled", &ModelRoutingConfig{Enabled: true, Budget: &BudgetRoutingConfig{}}, fixedBudgetState{percent: 95}, low, false, false},
+		{"model routing disabled still defers", &ModelRoutingConfig{Budget: &BudgetRoutingConfig{Enabled: true}}, fixedBudgetState{percent: 95}, low, false, true},
+		{
+			name: "custom thresholds and labels",
+			config: &ModelRoutingConfig{Enabled: true, Budget: &BudgetRoutingConfig{
+				Enabled: true, DowngradeAt: 50, DeferAt: 60, LowPriorityLabels: []string{"pilot"},
+			}},
+			state:         fixedBudgetState{percent: 65},
+			task:          normal,
+			wantDowngrade: true,
+			wantDefer:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewModelRouter(tt.config, nil)
+			if tt.state != nil {
+				router.SetBudgetState(tt.state)
+			}
+			route := router.BudgetRoute(tt.task)
+			if route.Downgrade != tt.wantDowngrade || route.Defer != tt.wantDefer {
+				t.Errorf("BudgetRoute() = %+v, want downgrade=%v defer=%v", route, tt.wantDowngrade, tt.wantDefer)
+			}
+		})
+	}
+}
+
+func TestModelRouter_SelectModelBudgetDowngrade(t *testing.T) {
+	router := NewModelRouter(budgetRoutingConfig(), nil)
+	task := &Task{Description: "Refactor the authentication system"}
+
+	router.SetBudgetState(fixedBudgetState{percent: 40})
+	if got := router.SelectModel(task); got != "claude-opus" {
+		t.Errorf("under budget SelectModel() = %q, want claude-opus", got)
+	}
+
+	router.SetBudgetState(fixedBudgetState{percent: 75})
+	if got := router.SelectModel(task); got != "claude-sonnet" {
+		t.Errorf("over 70%% SelectModel() = %q, want claude-sonnet", got)
+	}
+	if got := router.SelectModel(&Task{Description: "Fix typo in README"}); got != "claude-haiku" {
+		t.Errorf("trivial task SelectModel() = %q, want claude-haiku", got)
+	}
+}
+
+func TestRunner_CheckBudgetRoute(t *testing.T) {
+	runner := NewRunner()
+	recorder := &stallAlertRecorder{}
+	runner.SetAlertProcessor(recorder)
+	runner.modelRouter = NewModelRouter(budgetRoutingConfig(), nil)
+
+	task := &Task{ID: "GH-7", Title: "Tidy docs", Labels: []string{"priority:low"}}
+
+	runner.SetBudgetState(fixedBudgetState{percent: 75})
+	if result, err := runner.checkBudgetRoute(task); result != nil || err != nil {
+		t.Fatalf("downgraded task should run, got %+v, %v", result, err)
+	}
+	if len(recorder.events) != 1 || recorder.events[0].Metadata["action"] != "downgrade" {
+		t.Fatalf("expected a downgrade alert event, got %+v", recorder.events)
+	}
+
+	runner.SetBudgetState(fixedBudgetState{percent: 92})
+	result, err := runner.checkBudgetRoute(task)
+	if !errors.Is(err, ErrTaskDeferred) {
+		t.Fatalf("expected ErrTaskDeferred, got %v", err)
+	}
+	var deferred *DeferredError
+	if !errors.As(err, &deferred) || !deferred.Until.After(time.Now()) || deferred.SpendPercent != 92 {
+		t.Errorf("unexpected deferral: %+v", deferred)
+	}
+	if result == nil || result.Success {
+		t.Errorf("expected failed result, got %+v", result)
+	}
+	event := recorder.events[len(recorder.events)-1]
+	if event.Type != AlertEventTypeBudgetRouting || event.Metadata["action"] != "defer" || event.Metadata["spend_percent"] != "92" {
+		t.Errorf("unexpected alert event: %+v", event)
+	}
+}
+
+func TestParseDeferred(t *testing.T) {
+	until := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	err := fmt.Errorf("task GH-7: %w", &DeferredError{SpendPercent: 92, Until: until})
+
+	got := ParseDeferred(err.Error())
+	if got.SpendPercent != 92 || !got.Until.Equal(until) {
+		t.Errorf("ParseDeferred() = %+v", got)
+	}
+	if got := ParseDeferred("garbled"); !got.Until.After(time.Now()) {
+		t.Errorf("unreadable record should defer to the next day, got %+v", got)
+	}
+}
+
+func TestNextBudgetDay(t *testing.T) {
+	now := time.Date(2026, 3, 31, 22, 15, 0, 0, time.Local)
+	want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)
+	if got := nextBudgetDay(now); !got.Equal(want) {
+		t.Errorf("nextBudgetDay() = %s, want %s", got, want)
+	}
+}
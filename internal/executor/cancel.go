@@ -0,0 +1,57 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ErrTaskCancelled is returned by Execute when the task was aborted with
+// Cancel. Callers use it to report a cancellation instead of a failure.
+var ErrTaskCancelled = errors.New("task cancelled")
+
+// taskCancel aborts one Execute call
+type taskCancel struct {
+	cancel    context.CancelFunc
+	cancelled bool // Set by Cancel
+}
+
+// trackCancel derives a cancellable context for a task so Cancel can abort
+// it. finish stops tracking and reports whether Cancel was called.
+func (r *Runner) trackCancel(ctx context.Context, taskID string) (context.Context, func() bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	tc := &taskCancel{cancel: cancel}
+
+	r.mu.Lock()
+	if r.cancels == nil {
+		r.cancels = make(map[string]*taskCancel)
+	}
+	r.cancels[taskID] = tc
+	r.mu.Unlock()
+
+	return ctx, func() bool {
+		r.mu.Lock()
+		if r.cancels[taskID] == tc {
+			delete(r.cancels, taskID)
+		}
+		cancelled := tc.cancelled
+		r.mu.Unlock()
+		cancel()
+		return cancelled
+	}
+}
+
+// cancelledResult marks an execution aborted by Cancel
+func (r *Runner) cancelledResult(task *Task, result *ExecutionResult) (*ExecutionResult, error) {
+	r.log.Info("Task cancelled", slog.String("task_id", task.ID))
+	if r.monitor != nil {
+		r.monitor.Cancel(task.ID)
+	}
+	if result == nil {
+		result = &ExecutionResult{TaskID: task.ID}
+	}
+	result.Success = false
+	result.Error = "cancelled by user"
+	return result, fmt.Errorf("task %s: %w", task.ID, ErrTaskCancelled)
+}
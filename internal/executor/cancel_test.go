@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRunner_CancelWaitingTask(t *testing.T) {
+	runner := NewRunner()
+	runner.SetConcurrencyLimiter(NewConcurrencyLimiter(&ConcurrencyConfig{DefaultPerProject: 1}))
+	monitor := NewMonitor()
+	runner.SetMonitor(monitor)
+
+	if err := runner.Cancel("TASK-2"); err == nil {
+		t.Fatal("Cancel() of unknown task should fail")
+	}
+
+	release, err := runner.acquireSlot(context.Background(), &Task{ID: "TASK-1", ProjectPath: "/repo"})
+	if err != nil {
+		t.Fatalf("acquireSlot failed: %v", err)
+	}
+	defer release()
+
+	type outcome struct {
+		result *ExecutionResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	monitor.Register("TASK-2", "Blocked task", "")
+	go func() {
+		result, err := runner.Execute(context.Background(), &Task{ID: "TASK-2", ProjectPath: "/repo"})
+		done <- outcome{result, err}
+	}()
+	waitFor(t, func() bool { return runner.ConcurrencySnapshot().Waiting() == 1 })
+
+	if !runner.IsRunning("TASK-2") {
+		t.Error("IsRunning() = false for a task waiting for a slot")
+	}
+	if err := runner.Cancel("TASK-2"); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+
+	got := <-done
+	if !errors.Is(got.err, ErrTaskCancelled) {
+		t.Fatalf("Execute() error = %v, want ErrTaskCancelled", got.err)
+	}
+	if got.result == nil || got.result.Success || got.result.Error != "cancelled by user" {
+		t.Errorf("Execute() result = %+v", got.result)
+	}
+	if runner.IsRunning("TASK-2") {
+		t.Error("IsRunning() = true after cancellation")
+	}
+	if state, ok := monitor.Get("TASK-2"); !ok || state.Status != StatusCancelled {
+		t.Errorf("monitor state = %+v, want cancelled", state)
+	}
+}
+
+func TestRunner_ContextCancelIsNotUserCancel(t *testing.T) {
+	runner := NewRunner()
+	runner.SetConcurrencyLimiter(NewConcurrencyLimiter(&ConcurrencyConfig{DefaultPerProject: 1}))
+	release, _ := runner.acquireSlot(context.Background(), &Task{ID: "TASK-1", ProjectPath: "/repo"})
+	defer release()
+
+	// A caller's own cancellation (e.g. shutdown) is reported as before
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := runner.Execute(ctx, &Task{ID: "TASK-2", ProjectPath: "/repo"}); err == nil || errors.Is(err, ErrTaskCancelled) {
+		t.Errorf("Execute() error = %v, want slot wait error", err)
+	}
+}
@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileChurnErrorPrefix prefixes ExecutionResult.Error when the churn guard
+// cancels an execution, so callers can tell it apart from other failures.
+const FileChurnErrorPrefix = "runaway file churn"
+
+// ChurnGuard tracks file writes during a single execution and detects runaway
+// churn: touching too many files, changing too many lines, or rewriting the
+// same file over and over. Any of these usually means the model is thrashing
+// rather than converging, so the runner cancels the execution.
+type ChurnGuard struct {
+	config       *ChurnGuardConfig
+	writes       map[string]int // file path → number of Write/Edit calls
+	linesChanged int
+	reason       string
+}
+
+// NewChurnGuard creates a guard for one execution.
+// Returns nil if the guard is disabled.
+func NewChurnGuard(config *ChurnGuardConfig) *ChurnGuard {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+	return &ChurnGuard{
+		config: config,
+		writes: make(map[string]int),
+	}
+}
+
+// RecordToolUse records a file-writing tool call and returns a non-empty
+// reason once a limit is exceeded. Non-writing tools and Navigator files
+// under .agent/ are ignored. A nil guard never trips.
+func (g *ChurnGuard) RecordToolUse(toolName string, input map[string]interface{}) string {
+	if g == nil || g.reason != "" {
+		return g.Reason()
+	}
+
+	var lines int
+	switch toolName {
+	case "Write":
+		lines = countLines(stringInput(input, "content"))
+	case "Edit":
+		lines = countLines(stringInput(input, "old_string")) + countLines(stringInput(input, "new_string"))
+	case "MultiEdit":
+		edits, _ := input["edits"].([]interface{})
+		for _, e := range edits {
+			if edit, ok := e.(map[string]interface{}); ok {
+				lines += countLines(stringInput(edit, "old_string")) + countLines(stringInput(edit, "new_string"))
+			}
+		}
+	default:
+		return ""
+	}
+
+	fp := stringInput(input, "file_path")
+	if fp == "" || strings.Contains(fp, ".agent/") {
+		return ""
+	}
+
+	g.writes[fp]++
+	g.linesChanged += lines
+
+	switch {
+	case g.config.MaxFilesModified > 0 && len(g.writes) > g.config.MaxFilesModified:
+		g.reason = fmt.Sprintf("modified %d files (limit %d)", len(g.writes), g.config.MaxFilesModified)
+	case g.config.MaxLinesChanged > 0 && g.linesChanged > g.config.MaxLinesChanged:
+		g.reason = fmt.Sprintf("changed %d lines (limit %d)", g.linesChanged, g.config.MaxLinesChanged)
+	case g.config.MaxRewritesPerFile > 0 && g.writes[fp] > g.config.MaxRewritesPerFile:
+		g.reason = fmt.Sprintf("rewrote %s %d times (limit %d)", fp, g.writes[fp], g.config.MaxRewritesPerFile)
+	}
+	return g.reason
+}
+
+// Reason returns why the guard tripped, or "" if it has not.
+func (g *ChurnGuard) Reason() string {
+	if g == nil {
+		return ""
+	}
+	return g.reason
+}
+
+func stringInput(input map[string]interface{}, key string) string {
+	s, _ := input[key].(string)
+	return s
+}
+
+// countLines counts lines in s; a trailing line without newline counts as one.
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
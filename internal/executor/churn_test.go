@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChurnGuard_Disabled(t *testing.T) {
+	if g := NewChurnGuard(&ChurnGuardConfig{Enabled: false}); g != nil {
+		t.Fatal("disabled config should return nil guard")
+	}
+	var g *ChurnGuard
+	if reason := g.RecordToolUse("Write", map[string]interface{}{"file_path": "a.go"}); reason != "" {
+		t.Errorf("nil guard tripped: %q", reason)
+	}
+}
+
+func TestChurnGuard_Limits(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ChurnGuardConfig
+		calls  []map[string]interface{}
+		want   string
+	}{
+		{
+			name:   "too many files",
+			config: ChurnGuardConfig{Enabled: true, MaxFilesModified: 2},
+			calls: []map[string]interface{}{
+				{"file_path": "a.go"}, {"file_path": "b.go"}, {"file_path": "c.go"},
+			},
+			want: "modified 3 files (limit 2)",
+		},
+		{
+			name:   "too many lines",
+			config: ChurnGuardConfig{Enabled: true, MaxLinesChanged: 4},
+			calls: []map[string]interface{}{
+				{"file_path": "a.go", "content": "1\n2\n3\n"},
+				{"file_path": "a.go", "old_string": "1", "new_string": "one"},
+			},
+			want: "changed 5 lines (limit 4)",
+		},
+		{
+			name:   "repeated rewrites",
+			config: ChurnGuardConfig{Enabled: true, MaxRewritesPerFile: 2},
+			calls: []map[string]interface{}{
+				{"file_path": "a.go"}, {"file_path": "b.go"}, {"file_path": "a.go"}, {"file_path": "a.go"},
+			},
+			want: "rewrote a.go 3 times (limit 2)",
+		},
+		{
+			name:   "navigator files ignored",
+			config: ChurnGuardConfig{Enabled: true, MaxRewritesPerFile: 1},
+			calls: []map[string]interface{}{
+				{"file_path": ".agent/tasks/T.md"}, {"file_path": ".agent/tasks/T.md"},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewChurnGuard(&tt.config)
+			var got string
+			for _, input := range tt.calls {
+				tool := "Write"
+				if _, ok := input["old_string"]; ok {
+					tool = "Edit"
+				}
+				got = g.RecordToolUse(tool, input)
+			}
+			if got != tt.want {
+				t.Errorf("reason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChurnGuard_IgnoresReadTools(t *testing.T) {
+	g := NewChurnGuard(&ChurnGuardConfig{Enabled: true, MaxFilesModified: 1})
+	for _, fp := range []string{"a.go", "b.go", "c.go"} {
+		if reason := g.RecordToolUse("Read", map[string]interface{}{"file_path": fp}); reason != "" {
+			t.Fatalf("Read tripped the guard: %q", reason)
+		}
+	}
+}
+
+func TestChurnGuard_MultiEdit(t *testing.T) {
+	g := NewChurnGuard(&ChurnGuardConfig{Enabled: true, MaxLinesChanged: 3})
+	reason := g.RecordToolUse("MultiEdit", map[string]interface{}{
+		"file_path": "a.go",
+		"edits": []interface{}{
+			map[string]interface{}{"old_string": "a", "new_string": "b"},
+			map[string]interface{}{"old_string": "c", "new_string": "d\ne"},
+		},
+	})
+	if !strings.HasPrefix(reason, "changed 5 lines") {
+		t.Errorf("reason = %q, want lines limit", reason)
+	}
+}
+
+func TestProcessBackendEvent_FileChurnCancels(t *testing.T) {
+	runner := NewRunner()
+
+	cancelCalled := false
+	state := &progressState{
+		phase:        "Starting",
+		budgetCancel: func() { cancelCalled = true },
+		churn:        NewChurnGuard(&ChurnGuardConfig{Enabled: true, MaxRewritesPerFile: 1}),
+	}
+
+	write := BackendEvent{
+		Type:      EventTypeToolUse,
+		ToolName:  "Edit",
+		ToolInput: map[string]interface{}{"file_path": "/repo/main.go", "old_string": "a", "new_string": "b"},
+	}
+
+	runner.processBackendEvent("TASK-1", write, state)
+	if state.churnExceeded || cancelCalled {
+		t.Fatal("first write should not trip the guard")
+	}
+
+	runner.processBackendEvent("TASK-1", write, state)
+	if !state.churnExceeded {
+		t.Error("second rewrite should trip the guard")
+	}
+	if !cancelCalled {
+		t.Error("cancel function should have been called")
+	}
+	if !strings.Contains(state.churn.Reason(), "main.go") {
+		t.Errorf("reason = %q, want file name", state.churn.Reason())
+	}
+}
@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Clarification timeout policies
+const (
+	ClarifyOnTimeoutFail    = "fail"    // Give the task up
+	ClarifyOnTimeoutProceed = "proceed" // Resume with the agent's best judgement
+)
+
+// TimeoutDuration returns how long a question waits for an answer. Zero
+// means no timeout.
+func (c *ClarificationConfig) TimeoutDuration() time.Duration {
+	if c == nil || c.Timeout == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.Timeout)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// ProceedOnTimeout reports whether an unanswered task resumes when the
+// timeout passes rather than being given up.
+func (c *ClarificationConfig) ProceedOnTimeout() bool {
+	return c != nil && strings.EqualFold(c.OnTimeout, ClarifyOnTimeoutProceed)
+}
+
+// Clarification is a question a paused task asks its requester
+type Clarification struct {
+	Question  string
+	SessionID string // Backend session to resume, empty when the task never started
+	NeedsInfo bool   // Triage stopped the task before execution
+}
+
+// ClarificationFor returns the question behind an execution error. ok is
+// false when the error did not pause the task for its requester.
+func ClarificationFor(err error) (c Clarification, ok bool) {
+	var blocked *BlockedError
+	var needsInfo *NeedsInfoError
+	switch {
+	case errors.As(err, &blocked):
+		return Clarification{Question: blocked.Reason, SessionID: blocked.SessionID}, true
+	case errors.As(err, &needsInfo):
+		question := needsInfo.Reason
+		for _, q := range needsInfo.Questions {
+			question += "\n- " + q
+		}
+		return Clarification{Question: question, NeedsInfo: true}, true
+	}
+	return Clarification{}, false
+}
+
+// ClarificationPrompt describes the requester's answer for the resumed run
+func ClarificationPrompt(question, answer string) string {
+	var sb strings.Builder
+	sb.WriteString("## Unblocked\n\n")
+	if question != "" {
+		sb.WriteString(fmt.Sprintf("You stopped earlier because: %s\n\n", question))
+	}
+	sb.WriteString("The requester replied:\n\n")
+	sb.WriteString(answer)
+	sb.WriteString("\n\nContinue the task with this information.")
+	return sb.String()
+}
+
+// NoAnswerReply stands in for the requester's answer when a question timed
+// out and the policy is to proceed
+func NoAnswerReply(timeout time.Duration) string {
+	return fmt.Sprintf("Nobody answered within %s. Proceed with your best judgement and list the assumptions you made in the PR description.", timeout)
+}
@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestClarificationConfig_Policy(t *testing.T) {
+	var nilCfg *ClarificationConfig
+	if nilCfg.TimeoutDuration() != 0 || nilCfg.ProceedOnTimeout() {
+		t.Error("nil config should wait indefinitely")
+	}
+	if d := DefaultClarificationConfig().TimeoutDuration(); d != 0 {
+		t.Errorf("default timeout = %s, want none", d)
+	}
+	if (&ClarificationConfig{Timeout: "soon"}).TimeoutDuration() != 0 {
+		t.Error("invalid timeout should mean no timeout")
+	}
+
+	cfg := &ClarificationConfig{Timeout: "72h", OnTimeout: "Proceed"}
+	if cfg.TimeoutDuration() != 72*time.Hour {
+		t.Errorf("timeout = %s, want 72h", cfg.TimeoutDuration())
+	}
+	if !cfg.ProceedOnTimeout() {
+		t.Error("on_timeout proceed should proceed")
+	}
+	if DefaultClarificationConfig().ProceedOnTimeout() {
+		t.Error("default policy should fail")
+	}
+}
+
+func TestClarificationFor(t *testing.T) {
+	c, ok := ClarificationFor(fmt.Errorf("task GH-1: %w", &BlockedError{Reason: "Missing key", SessionID: "sess-1"}))
+	if !ok || c.Question != "Missing key" || c.SessionID != "sess-1" || c.NeedsInfo {
+		t.Errorf("blocked: %+v, %v", c, ok)
+	}
+
+	c, ok = ClarificationFor(fmt.Errorf("task GH-2: %w", &NeedsInfoError{
+		Verdict:   TriageMissingCriteria,
+		Reason:    "No target given",
+		Questions: []string{"Which endpoint?"},
+	}))
+	if !ok || !c.NeedsInfo || c.Question != "No target given\n- Which endpoint?" {
+		t.Errorf("needs info: %+v, %v", c, ok)
+	}
+
+	if _, ok := ClarificationFor(errors.New("boom")); ok {
+		t.Error("plain errors ask no question")
+	}
+}
@@ -0,0 +1,154 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Code review verdicts
+const (
+	ReviewVerdictApprove        = "approve"
+	ReviewVerdictRequestChanges = "request_changes"
+)
+
+// CodeReview is the outcome of reviewing a PR diff
+type CodeReview struct {
+	Verdict  string              `json:"verdict"` // approve or request_changes
+	Summary  string              `json:"summary"`
+	Findings []CodeReviewFinding `json:"findings"`
+}
+
+// CodeReviewFinding is a risky hunk the reviewer wants a human to look at
+type CodeReviewFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`     // Line in the new version of File
+	Severity string `json:"severity"` // low, medium, high
+	Category string `json:"category"` // Short kebab-case tag, e.g. sql-injection
+	Message  string `json:"message"`
+}
+
+// CodeReviewer reviews PR diffs with a model separate from the one that wrote
+// them. It calls the Anthropic API directly, like the intent judge.
+type CodeReviewer struct {
+	apiKey     string
+	apiURL     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewCodeReviewer creates a reviewer using the given model, normally the
+// model router's ReviewModel.
+func NewCodeReviewer(apiKey, model string) *CodeReviewer {
+	if model == "" {
+		model = defaultReviewModel
+	}
+	return &CodeReviewer{
+		apiKey: apiKey,
+		apiURL: "https://api.anthropic.com/v1/messages",
+		model:  model,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// maxReviewDiffChars bounds the diff sent for review
+const maxReviewDiffChars = 30000
+
+const codeReviewSystemPrompt = `You are a senior engineer reviewing a pull request written by an AI coding agent. Look for bugs, security issues, data loss, race conditions, missing error handling and missing tests. Ignore style and formatting.
+
+Only report findings worth a human's attention. Each finding must point at a line that was added in the diff, using its line number in the new version of the file.
+
+Respond with a single JSON object and nothing else:
+{"verdict":"approve"|"request_changes","summary":"one or two sentences","findings":[{"file":"path","line":42,"severity":"low"|"medium"|"high","category":"kebab-case-tag","message":"what is wrong and how to fix it"}]}
+
+Use request_changes only when a finding would cause a bug, vulnerability or data loss in production.`
+
+// Review reviews the diff of a PR against the issue it implements
+func (c *CodeReviewer) Review(ctx context.Context, title, body, diff string) (*CodeReview, error) {
+	if diff == "" {
+		return nil, fmt.Errorf("empty diff")
+	}
+	if len(diff) > maxReviewDiffChars {
+		diff = diff[:maxReviewDiffChars] + "\n...[truncated]"
+	}
+
+	userContent := fmt.Sprintf("## Title\n%s\n\n## Description\n%s\n\n## Diff\n```diff\n%s\n```",
+		title, body, diff)
+
+	reqBody := haikuRequest{
+		Model:     c.model,
+		MaxTokens: 2048,
+		System:    codeReviewSystemPrompt,
+		Messages: []haikuMessage{
+			{Role: "user", Content: userContent},
+		},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("API request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var apiResp haikuResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(apiResp.Content) == 0 || apiResp.Content[0].Text == "" {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	return parseCodeReview(apiResp.Content[0].Text)
+}
+
+// parseCodeReview extracts the review JSON from the model's response. Unknown
+// verdicts are treated as request_changes so a confused reviewer never
+// approves.
+func parseCodeReview(text string) (*CodeReview, error) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in review response")
+	}
+
+	var review CodeReview
+	if err := json.Unmarshal([]byte(text[start:end+1]), &review); err != nil {
+		return nil, fmt.Errorf("parse review: %w", err)
+	}
+	if review.Verdict != ReviewVerdictApprove {
+		review.Verdict = ReviewVerdictRequestChanges
+	}
+
+	findings := review.Findings[:0]
+	for _, f := range review.Findings {
+		if strings.TrimSpace(f.Message) == "" {
+			continue
+		}
+		findings = append(findings, f)
+	}
+	review.Findings = findings
+	return &review, nil
+}
@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCodeReviewer_Review(t *testing.T) {
+	var gotModel string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req haikuRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		gotModel = req.Model
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"content":[{"text":"Here is my review:\n{\"verdict\":\"request_changes\",\"summary\":\"SQL built from input.\",\"findings\":[{\"file\":\"db.go\",\"line\":12,\"severity\":\"high\",\"category\":\"sql-injection\",\"message\":\"Use a placeholder\"},{\"file\":\"db.go\",\"line\":3,\"message\":\" \"}]}"}]}`)
+	}))
+	defer server.Close()
+
+	reviewer := NewCodeReviewer("fake-api-key", "claude-sonnet-4-6")
+	reviewer.apiURL = server.URL
+	review, err := reviewer.Review(context.Background(), "Add search", "Search users by name", "diff --git a/db.go b/db.go\n+q := \"SELECT * FROM users WHERE name='\" + name + \"'\"")
+	if err != nil {
+		t.Fatalf("Review failed: %v", err)
+	}
+	if gotModel != "claude-sonnet-4-6" {
+		t.Errorf("model = %q, want configured review model", gotModel)
+	}
+	if review.Verdict != ReviewVerdictRequestChanges || review.Summary != "SQL built from input." {
+		t.Errorf("review = %+v", review)
+	}
+	if len(review.Findings) != 1 || review.Findings[0].Line != 12 || review.Findings[0].Category != "sql-injection" {
+		t.Errorf("findings = %+v, want the one with a message", review.Findings)
+	}
+}
+
+func TestCodeReviewer_EmptyDiff(t *testing.T) {
+	if _, err := NewCodeReviewer("fake-api-key", "").Review(context.Background(), "t", "b", ""); err == nil {
+		t.Error("expected error for empty diff")
+	}
+}
+
+func TestParseCodeReview(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		wantVerdict string
+		wantErr     bool
+	}{
+		{name: "approve", text: `{"verdict":"approve","summary":"LGTM","findings":[]}`, wantVerdict: ReviewVerdictApprove},
+		{name: "unknown verdict never approves", text: `{"verdict":"maybe"}`, wantVerdict: ReviewVerdictRequestChanges},
+		{name: "no JSON", text: "Looks good to me", wantErr: true},
+		{name: "malformed JSON", text: `{"verdict":}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review, err := parseCodeReview(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && review.Verdict != tt.wantVerdict {
+				t.Errorf("Verdict = %q, want %q", review.Verdict, tt.wantVerdict)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultCommitTypes are the conventional commit types allowed when
+// CommitPolicyConfig.Types is empty.
+var DefaultCommitTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// CommitPolicyConfig enforces conventional commit messages on the commits a
+// task pushes. Non-conforming messages are rewritten before the push.
+type CommitPolicyConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Types are the allowed commit types. Default: DefaultCommitTypes
+	Types []string `yaml:"types,omitempty"`
+
+	// Scopes maps path prefixes to commit scopes, e.g.
+	// "internal/adapters/github": "github". Other paths are scoped by the
+	// directory they share.
+	Scopes map[string]string `yaml:"scopes,omitempty"`
+}
+
+// conventionalSubject matches "type(scope)!: description"
+var conventionalSubject = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()\s]+)\))?(!)?: (\S.*)$`)
+
+// ConventionalCommit is a parsed conventional commit subject line
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+// ParseConventionalCommit parses the subject line of a commit message
+func ParseConventionalCommit(msg string) (ConventionalCommit, bool) {
+	subject, _, _ := strings.Cut(msg, "\n")
+	m := conventionalSubject.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return ConventionalCommit{}, false
+	}
+	return ConventionalCommit{Type: strings.ToLower(m[1]), Scope: m[2], Breaking: m[3] == "!", Description: m[4]}, true
+}
+
+// String formats the subject line
+func (c ConventionalCommit) String() string {
+	subject := c.Type
+	if c.Scope != "" {
+		subject += "(" + c.Scope + ")"
+	}
+	if c.Breaking {
+		subject += "!"
+	}
+	return subject + ": " + c.Description
+}
+
+func (p *CommitPolicyConfig) types() []string {
+	if len(p.Types) > 0 {
+		return p.Types
+	}
+	return DefaultCommitTypes
+}
+
+func (p *CommitPolicyConfig) allowed(commitType string) bool {
+	for _, t := range p.types() {
+		if strings.EqualFold(t, commitType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check reports why a commit message does not follow the policy
+func (p *CommitPolicyConfig) Check(msg string) error {
+	cc, ok := ParseConventionalCommit(msg)
+	if !ok {
+		return fmt.Errorf("subject is not in type(scope): description form")
+	}
+	if !p.allowed(cc.Type) {
+		return fmt.Errorf("commit type %q is not one of %s", cc.Type, strings.Join(p.types(), ", "))
+	}
+	return nil
+}
+
+// Conform returns msg with a conventional subject line. Conforming subjects
+// only gain the scope of paths when they have none; others are rewritten
+// with a type inferred from the subject and paths, or fallbackType. The
+// message body is kept.
+func (p *CommitPolicyConfig) Conform(msg string, paths []string, fallbackType string) string {
+	subject, body, hasBody := strings.Cut(strings.TrimLeft(msg, "\n"), "\n")
+	subject = strings.TrimSpace(subject)
+
+	cc, ok := ParseConventionalCommit(subject)
+	switch {
+	case ok && p.allowed(cc.Type):
+	case ok:
+		cc.Type = p.inferType(cc.Type, paths, fallbackType)
+	default:
+		desc := conventionalDescription(subject)
+		cc = ConventionalCommit{Type: p.inferType(desc, paths, fallbackType), Description: desc}
+	}
+	// A scope repeating the type, as in docs(docs), adds nothing
+	if scope := p.ScopeFor(paths); cc.Scope == "" && scope != cc.Type {
+		cc.Scope = scope
+	}
+
+	out := cc.String()
+	if hasBody {
+		out += "\n" + body
+	}
+	return out
+}
+
+// commitTypeSynonyms maps the first word of a subject to a commit type
+var commitTypeSynonyms = map[string]string{
+	"feat": "feat", "feature": "feat", "add": "feat", "adds": "feat", "added": "feat",
+	"implement": "feat", "implements": "feat", "introduce": "feat", "support": "feat",
+	"create": "feat", "allow": "feat", "enable": "feat",
+	"fix": "fix", "fixes": "fix", "fixed": "fix", "bugfix": "fix", "resolve": "fix",
+	"resolves": "fix", "correct": "fix", "handle": "fix",
+	"doc": "docs", "docs": "docs", "document": "docs", "documentation": "docs",
+	"test": "test", "tests": "test",
+	"refactor": "refactor", "rename": "refactor", "move": "refactor", "extract": "refactor",
+	"simplify": "refactor", "cleanup": "refactor", "restructure": "refactor",
+	"perf": "perf", "optimize": "perf", "speed": "perf",
+	"bump": "chore", "upgrade": "chore", "chore": "chore",
+	"revert": "revert",
+}
+
+// inferType picks a commit type for a subject from its first word, then
+// from the kind of files changed, then fallbackType
+func (p *CommitPolicyConfig) inferType(subject string, paths []string, fallbackType string) string {
+	word := strings.ToLower(strings.TrimFunc(strings.Fields(subject + " x")[0], func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}))
+	candidates := []string{commitTypeSynonyms[word], pathsCommitType(paths), fallbackType, "chore"}
+	for _, t := range candidates {
+		if t != "" && p.allowed(t) {
+			return t
+		}
+	}
+	return p.types()[0]
+}
+
+// pathsCommitType returns docs or test when every path is documentation or
+// a test
+func pathsCommitType(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	docs, tests := true, true
+	for _, f := range paths {
+		ext := path.Ext(f)
+		docs = docs && (ext == ".md" || ext == ".mdx" || strings.HasPrefix(f, "docs/"))
+		tests = tests && (strings.HasSuffix(f, "_test.go") || strings.Contains(f, ".test.") ||
+			strings.Contains(f, ".spec.") || strings.Contains("/"+f, "/testdata/"))
+	}
+	switch {
+	case docs:
+		return "docs"
+	case tests:
+		return "test"
+	}
+	return ""
+}
+
+// conventionalDescription drops a task ID prefix and trailing period from a
+// free-form subject and lowercases its first word
+func conventionalDescription(subject string) string {
+	if id, rest, ok := strings.Cut(subject, ": "); ok && !strings.Contains(id, " ") {
+		subject = rest
+	}
+	subject = strings.TrimSuffix(strings.TrimSpace(subject), ".")
+	runes := []rune(subject)
+	// Keep acronyms such as "API" as they are
+	if len(runes) > 1 && unicode.IsUpper(runes[0]) && !unicode.IsUpper(runes[1]) {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+// containerDirs group code without naming what it does, so they do not
+// make a useful scope
+var containerDirs = map[string]bool{
+	"internal": true, "cmd": true, "pkg": true, "src": true, "lib": true,
+	"app": true, "apps": true, "packages": true, "services": true,
+}
+
+// ScopeFor returns the commit scope of the changed paths: their configured
+// scope when they share one, otherwise the name of the deepest directory
+// they share. Empty when the paths have nothing in common.
+func (p *CommitPolicyConfig) ScopeFor(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	scope, mapped := "", true
+	for i, f := range paths {
+		s := p.mappedScope(f)
+		if s == "" || (i > 0 && s != scope) {
+			mapped = false
+			break
+		}
+		scope = s
+	}
+	if mapped {
+		return scope
+	}
+
+	common := strings.Split(path.Dir(paths[0]), "/")
+	for _, f := range paths[1:] {
+		dir := strings.Split(path.Dir(f), "/")
+		n := 0
+		for n < len(common) && n < len(dir) && common[n] == dir[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 {
+		return ""
+	}
+	last := common[len(common)-1]
+	if last == "." || containerDirs[last] {
+		return ""
+	}
+	return last
+}
+
+// mappedScope returns the scope of the longest configured prefix of f
+func (p *CommitPolicyConfig) mappedScope(f string) string {
+	best, scope := -1, ""
+	for prefix, s := range p.Scopes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if (f == prefix || strings.HasPrefix(f, prefix+"/")) && len(prefix) > best {
+			best, scope = len(prefix), s
+		}
+	}
+	return scope
+}
+
+// commitPolicy returns the enabled commit policy, nil when there is none
+func (c *BackendConfig) commitPolicy() *CommitPolicyConfig {
+	if c == nil || c.CommitPolicy == nil || !c.CommitPolicy.Enabled {
+		return nil
+	}
+	return c.CommitPolicy
+}
+
+// commitTypeForTask is the commit type used when a subject gives no hint
+func commitTypeForTask(task *Task) string {
+	for _, label := range task.Labels {
+		switch strings.ToLower(label) {
+		case "bug", "bugfix", "fix", "regression":
+			return "fix"
+		case "docs", "documentation":
+			return "docs"
+		case "chore", "maintenance", "dependencies":
+			return "chore"
+		}
+	}
+	return "feat"
+}
+
+// CommitRewrite is a commit message changed to follow the commit policy
+type CommitRewrite struct {
+	SHA  string // Original commit
+	From string // Original message
+	To   string
+}
+
+// ConformCommits rewrites the messages of the commits on HEAD that are not
+// reachable from any of the exclude refs and do not follow policy. The
+// commits are re-created with their authors, dates and trees, so exclude
+// must cover everything already pushed. Refs that do not exist are ignored.
+func (g *GitOperations) ConformCommits(ctx context.Context, policy *CommitPolicyConfig, fallbackType string, exclude ...string) ([]CommitRewrite, error) {
+	args := []string{"rev-list", "--reverse", "--parents", "HEAD"}
+	for _, ref := range exclude {
+		if _, err := g.git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+			args = append(args, "^"+ref)
+		}
+	}
+	out, err := g.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	type commit struct{ sha, parents, msg, conformed string }
+	var commits []commit
+	first := -1
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		c := commit{sha: fields[0], parents: strings.Join(fields[1:], " ")}
+		if c.msg, err = g.git(ctx, "log", "-1", "--format=%B", c.sha); err != nil {
+			return nil, err
+		}
+		c.msg = strings.TrimRight(c.msg, "\n")
+		files, err := g.git(ctx, "diff-tree", "--no-commit-id", "--name-only", "-r", "--root", c.sha)
+		if err != nil {
+			return nil, err
+		}
+		c.conformed = policy.Conform(c.msg, strings.Split(strings.TrimSpace(files), "\n"), fallbackType)
+		if first < 0 && c.conformed != c.msg {
+			first = len(commits)
+		}
+		commits = append(commits, c)
+	}
+	if first < 0 {
+		return nil, nil
+	}
+
+	// Only a linear history can be re-created commit by commit
+	for i := first; i < len(commits); i++ {
+		parents := strings.Fields(commits[i].parents)
+		if len(parents) != 1 || (i > first && parents[0] != commits[i-1].sha) {
+			return nil, fmt.Errorf("cannot rewrite commit %s: history is not linear", commits[i].sha)
+		}
+	}
+
+	var rewrites []CommitRewrite
+	parent := commits[first].parents
+	for _, c := range commits[first:] {
+		author, err := g.git(ctx, "log", "-1", "--format=%an%x00%ae%x00%aI", c.sha)
+		if err != nil {
+			return nil, err
+		}
+		parts := strings.SplitN(strings.TrimSpace(author), "\x00", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("cannot read author of %s", c.sha)
+		}
+
+		cmd := exec.CommandContext(ctx, "git", "commit-tree", c.sha+"^{tree}", "-p", parent, "-F", "-")
+		cmd.Dir = g.projectPath
+		cmd.Stdin = strings.NewReader(c.conformed + "\n")
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME="+parts[0], "GIT_AUTHOR_EMAIL="+parts[1], "GIT_AUTHOR_DATE="+parts[2])
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite commit %s: %w", c.sha, err)
+		}
+		parent = strings.TrimSpace(string(output))
+		if c.conformed != c.msg {
+			rewrites = append(rewrites, CommitRewrite{SHA: c.sha, From: c.msg, To: c.conformed})
+		}
+	}
+
+	if _, err := g.git(ctx, "update-ref", "-m", "pilot: conform commit messages", "HEAD", parent, commits[len(commits)-1].sha); err != nil {
+		return nil, err
+	}
+	return rewrites, nil
+}
+
+// git runs a git command in the repo and returns its stdout
+func (g *GitOperations) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,166 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitPolicy_Check(t *testing.T) {
+	policy := &CommitPolicyConfig{Enabled: true}
+	tests := []struct {
+		msg     string
+		wantErr bool
+	}{
+		{"feat(auth): add login (GH-12)", false},
+		{"fix!: drop legacy tokens\n\nBREAKING CHANGE: tokens expire", false},
+		{"Add login", true},
+		{"feature: add login", true},
+		{"feat(auth):add login", true},
+	}
+	for _, tt := range tests {
+		if err := policy.Check(tt.msg); (err != nil) != tt.wantErr {
+			t.Errorf("Check(%q) = %v, wantErr %v", tt.msg, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCommitPolicy_Conform(t *testing.T) {
+	policy := &CommitPolicyConfig{Enabled: true, Scopes: map[string]string{"internal/adapters/github/": "github"}}
+	tests := []struct {
+		name     string
+		msg      string
+		paths    []string
+		fallback string
+		want     string
+	}{
+		{
+			name:  "conforming kept",
+			msg:   "feat(api): add caching",
+			paths: []string{"internal/executor/runner.go"},
+			want:  "feat(api): add caching",
+		},
+		{
+			name:  "scope added",
+			msg:   "fix: handle timeouts",
+			paths: []string{"internal/executor/runner.go", "internal/executor/git.go"},
+			want:  "fix(executor): handle timeouts",
+		},
+		{
+			name:     "free-form subject with task ID",
+			msg:      "GH-12: Fix race in poller.\n\nThe poller read the map unlocked.",
+			paths:    []string{"internal/adapters/github/poller.go"},
+			fallback: "feat",
+			want:     "fix(github): fix race in poller\n\nThe poller read the map unlocked.",
+		},
+		{
+			name:     "unknown type mapped",
+			msg:      "feature: add dark mode",
+			paths:    []string{"web/theme.css", "web/app.css"},
+			fallback: "feat",
+			want:     "feat(web): add dark mode",
+		},
+		{
+			name:     "docs from paths",
+			msg:      "Explain config reload",
+			paths:    []string{"docs/content/config.mdx", "README.md"},
+			fallback: "feat",
+			want:     "docs: explain config reload",
+		},
+		{
+			name:     "fallback type",
+			msg:      "API tokens expire after a day",
+			paths:    []string{"cmd/pilot/main.go", "internal/config/config.go"},
+			fallback: "fix",
+			want:     "fix: API tokens expire after a day",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Conform(tt.msg, tt.paths, tt.fallback); got != tt.want {
+				t.Errorf("Conform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitPolicy_ScopeFor(t *testing.T) {
+	policy := &CommitPolicyConfig{Scopes: map[string]string{"internal/adapters/github": "gh", "internal/adapters/github/webhooks": "webhooks"}}
+	tests := []struct {
+		paths []string
+		want  string
+	}{
+		{[]string{"internal/adapters/github/webhooks/push.go"}, "webhooks"},
+		{[]string{"internal/adapters/github/client.go", "internal/adapters/github/types.go"}, "gh"},
+		{[]string{"internal/adapters/github/client.go", "internal/adapters/gitlab/client.go"}, "adapters"},
+		{[]string{"internal/executor/runner.go", "cmd/pilot/main.go"}, ""},
+		{[]string{"internal/config.go"}, ""},
+		{[]string{"go.mod"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := policy.ScopeFor(tt.paths); got != tt.want {
+			t.Errorf("ScopeFor(%v) = %q, want %q", tt.paths, got, tt.want)
+		}
+	}
+}
+
+func TestConformCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repoPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(repoPath) }()
+
+	ctx := context.Background()
+	git := NewGitOperations(repoPath)
+	if err := git.CreateBranch(ctx, "pilot/GH-7"); err != nil {
+		t.Fatal(err)
+	}
+	commit := func(file, msg string) {
+		t.Helper()
+		full := filepath.Join(repoPath, file)
+		_ = os.MkdirAll(filepath.Dir(full), 0755)
+		if err := os.WriteFile(full, []byte(msg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := git.Commit(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit("api/handler.go", "feat(api): add handler")
+	commit("api/routes.go", "Wire the handler")
+	commit("docs/api.md", "docs: describe the handler")
+	head, _ := git.GetCurrentCommitSHA(ctx)
+
+	rewrites, err := git.ConformCommits(ctx, &CommitPolicyConfig{Enabled: true}, "feat", "main", "origin/pilot/GH-7")
+	if err != nil {
+		t.Fatalf("ConformCommits() error = %v", err)
+	}
+	if len(rewrites) != 1 || rewrites[0].To != "feat(api): wire the handler" {
+		t.Fatalf("rewrites = %+v", rewrites)
+	}
+
+	out, err := exec.Command("git", "-C", repoPath, "log", "--format=%s|%an", "main..HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "docs: describe the handler|Test User\nfeat(api): wire the handler|Test User\nfeat(api): add handler|Test User"
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("log =\n%s\nwant:\n%s", got, want)
+	}
+	if diff, _ := exec.Command("git", "-C", repoPath, "diff", head, "HEAD").Output(); len(diff) != 0 {
+		t.Errorf("rewrite changed the tree:\n%s", diff)
+	}
+	if dirty, _ := git.HasUncommittedChanges(ctx); dirty {
+		t.Error("rewrite left uncommitted changes")
+	}
+
+	// Conforming history is left alone
+	if rewrites, err := git.ConformCommits(ctx, &CommitPolicyConfig{Enabled: true}, "feat", "main"); err != nil || len(rewrites) != 0 {
+		t.Errorf("second ConformCommits() = %+v, %v", rewrites, err)
+	}
+}
@@ -0,0 +1,425 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ConcurrencyConfig limits how many tasks run at once per repository and per
+// project, on top of the pollers' global orchestrator.max_concurrent, and
+// lets worktree pools autoscale to the resulting concurrency.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  concurrency:
+//	    default_per_repo: 2      # 0 = unlimited
+//	    per_repo:
+//	      acme/monorepo: 1       # heavy CI, one PR at a time
+//	    per_project:
+//	      /home/dev/web: 3
+//	    worktree_pool:
+//	      autoscale: true
+//	      max_size: 4
+//	      idle_timeout: 10m
+type ConcurrencyConfig struct {
+	// DefaultPerRepo limits repositories not listed in PerRepo (0 = unlimited)
+	DefaultPerRepo int `yaml:"default_per_repo"`
+
+	// DefaultPerProject limits projects not listed in PerProject (0 = unlimited)
+	DefaultPerProject int `yaml:"default_per_project"`
+
+	// PerRepo maps "owner/repo" to its concurrency limit
+	PerRepo map[string]int `yaml:"per_repo"`
+
+	// PerProject maps a project path to its concurrency limit
+	PerProject map[string]int `yaml:"per_project"`
+
+	// WorktreePool configures per-project worktree pool autoscaling
+	WorktreePool *WorktreePoolConfig `yaml:"worktree_pool"`
+}
+
+// WorktreePoolConfig controls worktree pool autoscaling. When enabled, each
+// project gets its own pool that grows while tasks wait for a worktree, up to
+// the project's effective concurrency, and shrinks back to worktree_pool_size
+// once extra worktrees sit idle.
+type WorktreePoolConfig struct {
+	// Autoscale enables per-project pools sized to effective concurrency
+	Autoscale bool `yaml:"autoscale"`
+
+	// MaxSize caps a project's pool when its concurrency is unlimited
+	MaxSize int `yaml:"max_size"`
+
+	// IdleTimeout is how long a worktree above the target stays idle before
+	// it is removed (default: 10m)
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+}
+
+const (
+	defaultPoolMaxSize     = 4
+	defaultPoolIdleTimeout = 10 * time.Minute
+)
+
+// Validate checks that limits and pool settings are not negative
+func (c *ConcurrencyConfig) Validate() error {
+	if c.DefaultPerRepo < 0 || c.DefaultPerProject < 0 {
+		return fmt.Errorf("default limits must not be negative")
+	}
+	for repo, n := range c.PerRepo {
+		if n < 0 {
+			return fmt.Errorf("per_repo limit for %s must not be negative, got %d", repo, n)
+		}
+	}
+	for project, n := range c.PerProject {
+		if n < 0 {
+			return fmt.Errorf("per_project limit for %s must not be negative, got %d", project, n)
+		}
+	}
+	if p := c.WorktreePool; p != nil && (p.MaxSize < 0 || p.IdleTimeout < 0) {
+		return fmt.Errorf("worktree_pool max_size and idle_timeout must not be negative")
+	}
+	return nil
+}
+
+// Concurrency scopes
+const (
+	ScopeRepo    = "repo"
+	ScopeProject = "project"
+)
+
+// SlotUsage reports execution slots for one repository or project.
+// Waiting tasks are back-pressure: they are ready but held by the limit.
+type SlotUsage struct {
+	Scope     string        // ScopeRepo or ScopeProject
+	Key       string        // "owner/repo" or project path
+	Limit     int           // 0 = unlimited
+	Active    int           // Tasks running
+	Waiting   int           // Tasks blocked on the limit
+	Throttled int64         // Tasks that had to wait, since start
+	WaitTime  time.Duration // Total time tasks spent waiting, since start
+}
+
+// WorktreePoolUsage reports an autoscaled worktree pool
+type WorktreePoolUsage struct {
+	ProjectPath string
+	Size        int // Worktrees in the pool
+	InUse       int // Worktrees acquired by tasks
+	Target      int // Size the pool is scaling towards
+}
+
+// ConcurrencySnapshot is a point-in-time view of execution slots and
+// worktree pools, for the dashboard and Prometheus metrics
+type ConcurrencySnapshot struct {
+	Slots []SlotUsage
+	Pools []WorktreePoolUsage
+}
+
+// Waiting returns the total number of tasks held back by project limits.
+// Tasks waiting on a repo limit also count once towards their project.
+func (s ConcurrencySnapshot) Waiting() int {
+	n := 0
+	for _, slot := range s.Slots {
+		if slot.Scope == ScopeProject {
+			n += slot.Waiting
+		}
+	}
+	return n
+}
+
+// ConcurrencyLimiter hands out execution slots under per-repo and
+// per-project limits. A task takes a slot in both scopes at once, so a
+// waiting task never holds capacity another task could use.
+type ConcurrencyLimiter struct {
+	config  *ConcurrencyConfig
+	mu      sync.Mutex
+	slots   map[string]*SlotUsage // keyed by scope + ":" + key
+	changed chan struct{}         // closed and replaced when a slot frees
+}
+
+// NewConcurrencyLimiter creates a limiter. A nil config tracks usage without
+// limiting.
+func NewConcurrencyLimiter(config *ConcurrencyConfig) *ConcurrencyLimiter {
+	if config == nil {
+		config = &ConcurrencyConfig{}
+	}
+	return &ConcurrencyLimiter{
+		config:  config,
+		slots:   make(map[string]*SlotUsage),
+		changed: make(chan struct{}),
+	}
+}
+
+// RepoLimit returns the concurrency limit for a repository (0 = unlimited)
+func (l *ConcurrencyLimiter) RepoLimit(repo string) int {
+	if n, ok := l.config.PerRepo[repo]; ok {
+		return n
+	}
+	return l.config.DefaultPerRepo
+}
+
+// ProjectLimit returns the concurrency limit for a project (0 = unlimited)
+func (l *ConcurrencyLimiter) ProjectLimit(projectPath string) int {
+	for path, n := range l.config.PerProject {
+		if filepath.Clean(path) == filepath.Clean(projectPath) {
+			return n
+		}
+	}
+	return l.config.DefaultPerProject
+}
+
+// Acquire blocks until the task's repository and project both have a free
+// slot, or ctx is done. The returned release must be called exactly once.
+// An empty repo or project skips that scope.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, repo, projectPath string) (func(), error) {
+	l.mu.Lock()
+	var scopes []*SlotUsage
+	if repo != "" {
+		scopes = append(scopes, l.slot(ScopeRepo, repo, l.RepoLimit(repo)))
+	}
+	if projectPath != "" {
+		scopes = append(scopes, l.slot(ScopeProject, projectPath, l.ProjectLimit(projectPath)))
+	}
+
+	var waitStart time.Time
+	for !fits(scopes) {
+		if waitStart.IsZero() {
+			waitStart = time.Now()
+			for _, s := range scopes {
+				s.Waiting++
+				s.Throttled++
+			}
+		}
+		changed := l.changed
+		l.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.stopWaiting(scopes, waitStart)
+			l.mu.Unlock()
+			return nil, ctx.Err()
+		case <-changed:
+		}
+		l.mu.Lock()
+	}
+	if !waitStart.IsZero() {
+		l.stopWaiting(scopes, waitStart)
+	}
+	for _, s := range scopes {
+		s.Active++
+	}
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			for _, s := range scopes {
+				s.Active--
+			}
+			close(l.changed)
+			l.changed = make(chan struct{})
+			l.mu.Unlock()
+		})
+	}, nil
+}
+
+// slot returns the usage entry for a scope, creating it on first use.
+// Called with l.mu held.
+func (l *ConcurrencyLimiter) slot(scope, key string, limit int) *SlotUsage {
+	id := scope + ":" + key
+	s, ok := l.slots[id]
+	if !ok {
+		s = &SlotUsage{Scope: scope, Key: key}
+		l.slots[id] = s
+	}
+	s.Limit = limit
+	return s
+}
+
+// stopWaiting records the end of a wait. Called with l.mu held.
+func (l *ConcurrencyLimiter) stopWaiting(scopes []*SlotUsage, waitStart time.Time) {
+	waited := time.Since(waitStart)
+	for _, s := range scopes {
+		s.Waiting--
+		s.WaitTime += waited
+	}
+}
+
+// fits reports whether every scope has a free slot
+func fits(scopes []*SlotUsage) bool {
+	for _, s := range scopes {
+		if s.Limit > 0 && s.Active >= s.Limit {
+			return false
+		}
+	}
+	return true
+}
+
+// Usage returns slot usage for one scope and key
+func (l *ConcurrencyLimiter) Usage(scope, key string) SlotUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s, ok := l.slots[scope+":"+key]; ok {
+		return *s
+	}
+	return SlotUsage{Scope: scope, Key: key}
+}
+
+// Slots returns usage for every repository and project seen, sorted by
+// scope and key
+func (l *ConcurrencyLimiter) Slots() []SlotUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]SlotUsage, 0, len(l.slots))
+	for _, s := range l.slots {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Scope != out[j].Scope {
+			return out[i].Scope < out[j].Scope
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// SetConcurrencyLimiter replaces the limiter created from
+// executor.concurrency, e.g. to share one limiter between runners.
+func (r *Runner) SetConcurrencyLimiter(l *ConcurrencyLimiter) {
+	r.concurrency = l
+}
+
+// ConcurrencySnapshot returns execution slot and worktree pool usage
+func (r *Runner) ConcurrencySnapshot() ConcurrencySnapshot {
+	var snap ConcurrencySnapshot
+	if r.concurrency != nil {
+		snap.Slots = r.concurrency.Slots()
+	}
+	r.worktreePoolsMu.Lock()
+	for path, pool := range r.worktreePools {
+		size, inUse := pool.PoolUsage()
+		snap.Pools = append(snap.Pools, WorktreePoolUsage{
+			ProjectPath: path,
+			Size:        size,
+			InUse:       inUse,
+			Target:      pool.Target(),
+		})
+	}
+	r.worktreePoolsMu.Unlock()
+	sort.Slice(snap.Pools, func(i, j int) bool { return snap.Pools[i].ProjectPath < snap.Pools[j].ProjectPath })
+	return snap
+}
+
+// acquireSlot waits for an execution slot for the task. Returns a no-op
+// release when no limiter is configured.
+func (r *Runner) acquireSlot(ctx context.Context, task *Task) (func(), error) {
+	if r.concurrency == nil {
+		return func() {}, nil
+	}
+	if usage := r.concurrency.Usage(ScopeProject, task.ProjectPath); usage.Limit > 0 && usage.Active >= usage.Limit {
+		r.log.Info("Waiting for execution slot",
+			slog.String("task_id", task.ID),
+			slog.String("project", task.ProjectPath),
+			slog.Int("limit", usage.Limit),
+			slog.Int("waiting", usage.Waiting+1),
+		)
+	} else if usage := r.concurrency.Usage(ScopeRepo, task.SourceRepo); task.SourceRepo != "" && usage.Limit > 0 && usage.Active >= usage.Limit {
+		r.log.Info("Waiting for execution slot",
+			slog.String("task_id", task.ID),
+			slog.String("repo", task.SourceRepo),
+			slog.Int("limit", usage.Limit),
+			slog.Int("waiting", usage.Waiting+1),
+		)
+	}
+	release, err := r.concurrency.Acquire(ctx, task.SourceRepo, task.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	r.scaleWorktreePool(task.ProjectPath)
+	return func() {
+		release()
+		r.scaleWorktreePool(task.ProjectPath)
+	}, nil
+}
+
+// poolAutoscale returns the worktree pool autoscaling config, or nil when
+// autoscaling is off
+func (r *Runner) poolAutoscale() *WorktreePoolConfig {
+	if r.config == nil || r.config.Concurrency == nil {
+		return nil
+	}
+	if p := r.config.Concurrency.WorktreePool; p != nil && p.Autoscale {
+		return p
+	}
+	return nil
+}
+
+// worktreePoolFor returns the pool worktrees for the project are taken from:
+// the project's autoscaled pool, the static pool set up by
+// InitWorktreePool, or nil to create worktrees directly.
+func (r *Runner) worktreePoolFor(projectPath string) *WorktreeManager {
+	if autoscale := r.poolAutoscale(); autoscale != nil {
+		r.worktreePoolsMu.Lock()
+		defer r.worktreePoolsMu.Unlock()
+		pool, ok := r.worktreePools[projectPath]
+		if !ok {
+			pool = NewWorktreeManagerWithPool(projectPath, r.config.WorktreePoolSize)
+			pool.SetAutoscale(r.poolMaxSize(projectPath), autoscale.IdleTimeout)
+			if r.worktreePools == nil {
+				r.worktreePools = make(map[string]*WorktreeManager)
+			}
+			r.worktreePools[projectPath] = pool
+		}
+		return pool
+	}
+	if r.worktreeManager != nil && r.worktreeManager.PoolSize() > 0 {
+		return r.worktreeManager
+	}
+	return nil
+}
+
+// poolMaxSize is the largest a project's pool may grow: its effective
+// concurrency limit, or max_size when unlimited
+func (r *Runner) poolMaxSize(projectPath string) int {
+	maxSize := defaultPoolMaxSize
+	if p := r.poolAutoscale(); p != nil && p.MaxSize > 0 {
+		maxSize = p.MaxSize
+	}
+	if r.concurrency != nil {
+		if limit := r.concurrency.ProjectLimit(projectPath); limit > 0 && limit < maxSize {
+			maxSize = limit
+		}
+	}
+	return maxSize
+}
+
+// scaleWorktreePool points a project's autoscaled pool at its current demand:
+// tasks running plus waiting, capped by the project's limit
+func (r *Runner) scaleWorktreePool(projectPath string) {
+	if r.poolAutoscale() == nil || r.concurrency == nil {
+		return
+	}
+	r.worktreePoolsMu.Lock()
+	pool := r.worktreePools[projectPath]
+	r.worktreePoolsMu.Unlock()
+	if pool == nil {
+		return
+	}
+	usage := r.concurrency.Usage(ScopeProject, projectPath)
+	pool.ScaleTo(usage.Active + usage.Waiting)
+}
+
+// closeWorktreePools drains every autoscaled pool
+func (r *Runner) closeWorktreePools() {
+	r.worktreePoolsMu.Lock()
+	defer r.worktreePoolsMu.Unlock()
+	for _, pool := range r.worktreePools {
+		pool.Close()
+	}
+	r.worktreePools = nil
+}
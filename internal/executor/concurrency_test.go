@@ -0,0 +1,142 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ConcurrencyConfig
+		wantErr bool
+	}{
+		{"empty", ConcurrencyConfig{}, false},
+		{"limits", ConcurrencyConfig{DefaultPerRepo: 2, PerProject: map[string]int{"/app": 1}}, false},
+		{"negative default", ConcurrencyConfig{DefaultPerProject: -1}, true},
+		{"negative repo", ConcurrencyConfig{PerRepo: map[string]int{"acme/api": -2}}, true},
+		{"negative pool", ConcurrencyConfig{WorktreePool: &WorktreePoolConfig{MaxSize: -1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimiter_Limits(t *testing.T) {
+	limiter := NewConcurrencyLimiter(&ConcurrencyConfig{
+		DefaultPerRepo: 2,
+		PerRepo:        map[string]int{"acme/mono": 0},
+		PerProject:     map[string]int{"/app/": 1},
+	})
+	if limiter.RepoLimit("acme/api") != 2 || limiter.RepoLimit("acme/mono") != 0 || limiter.ProjectLimit("/app") != 1 {
+		t.Fatalf("limits = %d/%d/%d", limiter.RepoLimit("acme/api"), limiter.RepoLimit("acme/mono"), limiter.ProjectLimit("/app"))
+	}
+
+	ctx := context.Background()
+	releaseA, err := limiter.Acquire(ctx, "acme/api", "/app")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	// The project is full: a second task waits until the first releases
+	acquired := make(chan func())
+	go func() {
+		release, err := limiter.Acquire(ctx, "acme/api", "/app")
+		if err != nil {
+			t.Errorf("waiting Acquire failed: %v", err)
+		}
+		acquired <- release
+	}()
+
+	waitFor(t, func() bool { return limiter.Usage(ScopeProject, "/app").Waiting == 1 })
+	if usage := limiter.Usage(ScopeRepo, "acme/api"); usage.Waiting != 1 || usage.Active != 1 {
+		t.Errorf("repo usage = %+v, want 1 active, 1 waiting", usage)
+	}
+
+	// Other projects of the same repo still have a repo slot
+	releaseB, err := limiter.Acquire(ctx, "acme/api", "/other")
+	if err != nil {
+		t.Fatalf("Acquire for other project failed: %v", err)
+	}
+
+	releaseA()
+	releaseA() // release is idempotent
+	releaseC := <-acquired
+
+	usage := limiter.Usage(ScopeProject, "/app")
+	if usage.Active != 1 || usage.Waiting != 0 || usage.Throttled != 1 || usage.WaitTime <= 0 {
+		t.Errorf("project usage = %+v, want 1 active, 1 throttled", usage)
+	}
+	releaseB()
+	releaseC()
+
+	slots := limiter.Slots()
+	if len(slots) != 3 || slots[0].Scope != ScopeProject || slots[2].Key != "acme/api" {
+		t.Errorf("Slots() = %+v", slots)
+	}
+	for _, slot := range slots {
+		if slot.Active != 0 {
+			t.Errorf("slot %s:%s still active", slot.Scope, slot.Key)
+		}
+	}
+}
+
+func TestConcurrencyLimiter_Cancel(t *testing.T) {
+	limiter := NewConcurrencyLimiter(&ConcurrencyConfig{DefaultPerRepo: 1})
+	release, err := limiter.Acquire(context.Background(), "acme/api", "")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx, "acme/api", ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Acquire error = %v, want deadline exceeded", err)
+	}
+	if usage := limiter.Usage(ScopeRepo, "acme/api"); usage.Waiting != 0 || usage.Active != 1 {
+		t.Errorf("usage after cancel = %+v", usage)
+	}
+}
+
+func TestRunner_ExecuteWaitsForSlot(t *testing.T) {
+	runner := NewRunner()
+	runner.SetConcurrencyLimiter(NewConcurrencyLimiter(&ConcurrencyConfig{DefaultPerProject: 1}))
+
+	task := &Task{ID: "TASK-1", ProjectPath: "/repo"}
+	release, err := runner.acquireSlot(context.Background(), task)
+	if err != nil {
+		t.Fatalf("acquireSlot failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result, err := runner.Execute(ctx, &Task{ID: "TASK-2", ProjectPath: "/repo"})
+	if err == nil || result == nil || result.Success {
+		t.Fatalf("Execute() = %+v, %v, want cancellation while waiting", result, err)
+	}
+
+	snap := runner.ConcurrencySnapshot()
+	if len(snap.Slots) != 1 || snap.Slots[0].Active != 1 || snap.Slots[0].Throttled != 1 || snap.Waiting() != 0 {
+		t.Errorf("snapshot = %+v", snap)
+	}
+}
+
+// waitFor polls cond for up to a second
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
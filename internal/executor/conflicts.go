@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// MergeConflictTask is a PR branch that no longer merges cleanly into its
+// base branch.
+type MergeConflictTask struct {
+	ID          string // Task ID for logs and the dashboard
+	Title       string
+	ProjectPath string // Checkout the worktree is created from
+	Branch      string // PR head branch
+	BaseBranch  string // Empty means the repository's default branch
+}
+
+// ResolveMergeConflicts checks the PR branch out into a temporary worktree,
+// has the agent merge the base branch into it and resolve the conflicts,
+// then pushes the merge to the PR branch.
+func (r *Runner) ResolveMergeConflicts(ctx context.Context, mt *MergeConflictTask) error {
+	git := NewGitOperations(mt.ProjectPath)
+	base := mt.BaseBranch
+	if base == "" {
+		var err error
+		if base, err = git.GetDefaultBranch(ctx); err != nil {
+			base = "main"
+		}
+	}
+	if err := git.FetchBranches(ctx, mt.Branch, base); err != nil {
+		return err
+	}
+
+	path, cleanup, err := CreateWorktreeWithBranch(ctx, mt.ProjectPath, mt.ID, mt.Branch, "origin/"+mt.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to check out %s: %w", mt.Branch, err)
+	}
+	defer cleanup()
+
+	r.log.Info("Resolving merge conflicts",
+		slog.String("task_id", mt.ID),
+		slog.String("branch", mt.Branch),
+		slog.String("base", base),
+	)
+	result, err := r.Execute(ctx, &Task{
+		ID:          mt.ID,
+		Title:       mt.Title,
+		Description: mergeConflictPrompt(mt.Branch, base),
+		ProjectPath: path,
+	})
+	if err != nil {
+		return fmt.Errorf("resolution task failed: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("resolution task failed: %s", result.Error)
+	}
+
+	wt := NewGitOperations(path)
+	if err := wt.FinishMerge(ctx); err != nil {
+		return err
+	}
+	if !wt.HasMerged(ctx, "origin/"+base) {
+		return fmt.Errorf("%s was not merged into %s", base, mt.Branch)
+	}
+	if err := r.checkPushLeaks(ctx, wt, mt.ID, "origin/"+base); err != nil {
+		return err
+	}
+	return wt.Push(ctx, mt.Branch)
+}
+
+// mergeConflictPrompt asks the agent to merge base into the checked out
+// PR branch
+func mergeConflictPrompt(branch, base string) string {
+	return fmt.Sprintf(`## Resolve merge conflicts
+
+This checkout is branch %[1]s of an open pull request. It conflicts with %[2]s and can no longer be merged.
+
+1. Run `+"`git merge origin/%[2]s`"+`.
+2. Resolve every conflict. Keep the intent of both sides: the pull request's change must still work on top of the new %[2]s.
+3. Build and run the tests that cover the conflicted files.
+4. Commit the merge. Do not rebase, squash or push.
+
+Make no changes beyond what resolving the conflicts requires.`, branch, base)
+}
@@ -270,6 +270,10 @@ func (d *TaskDecomposer) createSubtasks(parent *Task, parts []string, partType s
 			BaseBranch:  parent.BaseBranch,
 			CreatePR:    false, // Only final subtask creates PR
 			Verbose:     parent.Verbose,
+			Backend:     parent.taskBackend(),
+			MemberID:    parent.MemberID,
+			// Subtask spend counts against the parent's adapter budget
+			SourceAdapter: parent.SourceAdapter,
 		}
 
 		// Last subtask creates the PR
@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -30,7 +31,8 @@ func DefaultDispatcherConfig() *DispatcherConfig {
 
 // Dispatcher manages task queuing and per-project workers.
 // It ensures that tasks for the same project are executed serially
-// while allowing parallel execution across different projects.
+// while allowing parallel execution across different projects. With a
+// FairScheduler, projects share a fixed number of dispatch slots instead.
 // Progress updates are emitted via runner.EmitProgress() so they
 // flow through the same callback path as execution progress.
 type Dispatcher struct {
@@ -38,6 +40,8 @@ type Dispatcher struct {
 	store      *memory.Store
 	runner     *Runner
 	decomposer *TaskDecomposer           // Optional task decomposer
+	scheduler  *FairScheduler            // Optional fair scheduler
+	remote     bool                      // Queued tasks run on remote workers
 	workers    map[string]*ProjectWorker // key: project path
 	mu         sync.RWMutex
 	log        *slog.Logger
@@ -72,6 +76,20 @@ func (d *Dispatcher) SetDecomposer(decomposer *TaskDecomposer) {
 	d.decomposer = decomposer
 }
 
+// SetFairScheduler makes project workers share dispatch slots handed out
+// fairly across projects, and pick tasks fairly across requesters. Must be
+// called before Start.
+func (d *Dispatcher) SetFairScheduler(scheduler *FairScheduler) {
+	d.scheduler = scheduler
+}
+
+// SetRemote leaves queued tasks to remote workers, which lease them from
+// the cluster coordinator, instead of running them in this process. Must be
+// called before Start.
+func (d *Dispatcher) SetRemote(remote bool) {
+	d.remote = remote
+}
+
 // Start initializes the dispatcher and recovers from any stale tasks.
 func (d *Dispatcher) Start() error {
 	d.log.Info("Starting dispatcher")
@@ -129,7 +147,8 @@ func (d *Dispatcher) recoverStaleTasks() error {
 }
 
 // QueueTask adds a task to the execution queue and returns the execution ID.
-// The task will be executed by the project's worker in FIFO order.
+// The task will be executed by the project's worker in FIFO order, or in
+// weighted round-robin order across requesters when a fair scheduler is set.
 // If a decomposer is configured and the task is complex, it will be split
 // into subtasks that are queued instead of the parent task.
 func (d *Dispatcher) QueueTask(ctx context.Context, task *Task) (string, error) {
@@ -141,8 +160,9 @@ func (d *Dispatcher) QueueTask(ctx context.Context, task *Task) (string, error)
 		return "", fmt.Errorf("task %s is already queued or running", task.ID)
 	}
 
-	// Try decomposition if decomposer is configured
-	if d.decomposer != nil {
+	// Try decomposition if decomposer is configured. A resumed session
+	// continues as one task.
+	if d.decomposer != nil && task.ResumeSessionID == "" {
 		result := d.decomposer.Decompose(task)
 		if result.Decomposed && len(result.Subtasks) > 1 {
 			return d.queueDecomposedTask(ctx, task, result)
@@ -171,6 +191,10 @@ func (d *Dispatcher) queueDecomposedTask(ctx context.Context, parent *Task, resu
 		TaskBaseBranch:  parent.BaseBranch,
 		TaskCreatePR:    parent.CreatePR,
 		TaskVerbose:     parent.Verbose,
+		TaskBackend:     parent.Backend,
+		RequestedBy:     parent.MemberID,
+		SourceAdapter:   parent.SourceAdapter,
+		TaskLabels:      parent.Labels,
 	}
 
 	if err := d.store.SaveExecution(parentExec); err != nil {
@@ -226,6 +250,11 @@ func (d *Dispatcher) queueSingleTask(ctx context.Context, task *Task) (string, e
 		TaskBaseBranch:  task.BaseBranch,
 		TaskCreatePR:    task.CreatePR,
 		TaskVerbose:     task.Verbose,
+		TaskBackend:     task.taskBackend(),
+		RequestedBy:     task.MemberID,
+		SessionID:       task.ResumeSessionID,
+		SourceAdapter:   task.SourceAdapter,
+		TaskLabels:      task.Labels,
 	}
 
 	if err := d.store.SaveExecution(exec); err != nil {
@@ -248,7 +277,12 @@ func (d *Dispatcher) queueSingleTask(ctx context.Context, task *Task) (string, e
 }
 
 // ensureWorker creates a worker for the project if it doesn't exist and starts it.
+// With remote workers the task stays queued until a worker leases it.
 func (d *Dispatcher) ensureWorker(projectPath string) {
+	if d.remote {
+		return
+	}
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -260,6 +294,7 @@ func (d *Dispatcher) ensureWorker(projectPath string) {
 
 	// Create new worker
 	worker := NewProjectWorker(projectPath, d.store, d.runner, d.log)
+	worker.scheduler = d.scheduler
 	d.workers[projectPath] = worker
 
 	// Start worker in background
@@ -314,7 +349,7 @@ func (d *Dispatcher) WaitForExecution(ctx context.Context, execID string, pollIn
 
 			// Check if terminal state
 			switch exec.Status {
-			case "completed", "failed", "cancelled":
+			case "completed", "failed", "cancelled", "blocked", "needs_info", "deferred":
 				return exec, nil
 			}
 		}
@@ -335,6 +370,7 @@ type ProjectWorker struct {
 	projectPath   string
 	store         *memory.Store
 	runner        *Runner
+	scheduler     *FairScheduler // Optional, shared by all workers
 	log           *slog.Logger
 	signal        chan struct{}
 	processing    atomic.Bool
@@ -435,17 +471,10 @@ func (w *ProjectWorker) processQueue(ctx context.Context) {
 		}
 
 		// Get next queued task for THIS project
-		tasks, err := w.store.GetQueuedTasksForProject(w.projectPath, 1)
-		if err != nil {
-			w.log.Error("Failed to get queued tasks", slog.Any("error", err))
+		exec, release, ok := w.nextTask(ctx)
+		if !ok {
 			return
 		}
-
-		if len(tasks) == 0 {
-			return // Queue empty
-		}
-
-		exec := tasks[0]
 		w.currentTaskID.Store(exec.TaskID)
 
 		w.log.Info("Processing task",
@@ -457,6 +486,7 @@ func (w *ProjectWorker) processQueue(ctx context.Context) {
 		// Update status to running
 		if err := w.store.UpdateExecutionStatus(exec.ID, "running"); err != nil {
 			w.log.Error("Failed to update status to running", slog.Any("error", err))
+			release()
 			continue
 		}
 
@@ -464,58 +494,65 @@ func (w *ProjectWorker) processQueue(ctx context.Context) {
 		w.runner.EmitProgress(exec.TaskID, "Running", 2, fmt.Sprintf("Worker started: %s", truncateForLog(exec.TaskTitle, 40)))
 
 		// Build task from execution record (full details stored when queued)
-		task := &Task{
-			ID:          exec.TaskID,
-			Title:       exec.TaskTitle,
-			Description: exec.TaskDescription,
-			ProjectPath: exec.ProjectPath,
-			Branch:      exec.TaskBranch,
-			BaseBranch:  exec.TaskBaseBranch,
-			CreatePR:    exec.TaskCreatePR,
-			Verbose:     exec.TaskVerbose,
-		}
+		task := TaskFromExecution(exec)
 
 		// Execute (blocking)
 		start := time.Now()
 		result, execErr := w.runner.Execute(ctx, task)
 		duration := time.Since(start)
+		release()
 
 		// Update execution record with result
-		if execErr != nil {
-			w.log.Error("Task execution failed",
+		outcome := NewOutcome(result, execErr, duration)
+		switch outcome.Status {
+		case OutcomeNeedsInfo:
+			var needsInfo *NeedsInfoError
+			errors.As(execErr, &needsInfo)
+			w.log.Info("Task needs info",
 				slog.String("task_id", exec.TaskID),
-				slog.Any("error", execErr),
+				slog.String("verdict", string(needsInfo.Verdict)),
+			)
+		case OutcomeBlocked:
+			w.log.Info("Task blocked",
+				slog.String("task_id", exec.TaskID),
+				slog.String("reason", outcome.Error),
 				slog.Duration("duration", duration),
 			)
-			if err := w.store.UpdateExecutionStatus(exec.ID, "failed", execErr.Error()); err != nil {
-				w.log.Error("Failed to update status to failed", slog.Any("error", err))
-			}
-			// Emit progress callback for task failed
-			w.runner.EmitProgress(exec.TaskID, "Failed", 100, fmt.Sprintf("Execution error: %s", truncateForLog(execErr.Error(), 60)))
-		} else if !result.Success {
-			w.log.Warn("Task completed with failure",
+		case OutcomeDeferred:
+			w.log.Info("Task deferred for budget",
+				slog.String("task_id", exec.TaskID),
+				slog.Any("error", execErr),
+			)
+		case OutcomeCancelled:
+			w.log.Info("Task cancelled",
 				slog.String("task_id", exec.TaskID),
-				slog.String("error", result.Error),
 				slog.Duration("duration", duration),
 			)
-			if err := w.store.UpdateExecutionStatus(exec.ID, "failed", result.Error); err != nil {
-				w.log.Error("Failed to update status to failed", slog.Any("error", err))
+			w.runner.EmitProgress(exec.TaskID, "Cancelled", 100, "Task cancelled")
+		case OutcomeFailed:
+			if execErr != nil {
+				w.log.Error("Task execution failed",
+					slog.String("task_id", exec.TaskID),
+					slog.Any("error", execErr),
+					slog.Duration("duration", duration),
+				)
+				// Emit progress callback for task failed
+				w.runner.EmitProgress(exec.TaskID, "Failed", 100, fmt.Sprintf("Execution error: %s", truncateForLog(execErr.Error(), 60)))
+			} else {
+				w.log.Warn("Task completed with failure",
+					slog.String("task_id", exec.TaskID),
+					slog.String("error", result.Error),
+					slog.Duration("duration", duration),
+				)
+				// Emit progress callback for task failed
+				w.runner.EmitProgress(exec.TaskID, "Failed", 100, fmt.Sprintf("Task failed: %s", truncateForLog(result.Error, 60)))
 			}
-			// Emit progress callback for task failed
-			w.runner.EmitProgress(exec.TaskID, "Failed", 100, fmt.Sprintf("Task failed: %s", truncateForLog(result.Error, 60)))
-		} else {
+		case OutcomeCompleted:
 			w.log.Info("Task completed successfully",
 				slog.String("task_id", exec.TaskID),
 				slog.Duration("duration", duration),
 				slog.String("pr_url", result.PRUrl),
 			)
-			if err := w.store.UpdateExecutionStatus(exec.ID, "completed"); err != nil {
-				w.log.Error("Failed to update status to completed", slog.Any("error", err))
-			}
-			// Update result fields (PR URL, commit SHA, duration)
-			if err := w.store.UpdateExecutionResult(exec.ID, result.PRUrl, result.CommitSHA, duration.Milliseconds()); err != nil {
-				w.log.Error("Failed to update execution result", slog.Any("error", err))
-			}
 			// Emit progress callback for task completed
 			msg := fmt.Sprintf("Completed in %s", duration.Round(time.Second))
 			if result.PRUrl != "" {
@@ -524,28 +561,79 @@ func (w *ProjectWorker) processQueue(ctx context.Context) {
 			w.runner.EmitProgress(exec.TaskID, "Completed", 100, msg)
 		}
 
-		// Persist execution metrics (tokens, cost, code changes) so they survive restarts.
-		// This is needed for GetLifetimeTokens() to return real data (GH-533).
-		if result != nil {
-			if err := w.store.SaveExecutionMetrics(&memory.ExecutionMetrics{
-				ExecutionID:      exec.ID,
-				TokensInput:      result.TokensInput,
-				TokensOutput:     result.TokensOutput,
-				TokensTotal:      result.TokensTotal,
-				EstimatedCostUSD: result.EstimatedCostUSD,
-				FilesChanged:     result.FilesChanged,
-				LinesAdded:       result.LinesAdded,
-				LinesRemoved:     result.LinesRemoved,
-				ModelName:        result.ModelName,
-			}); err != nil {
-				w.log.Error("Failed to save execution metrics", slog.Any("error", err))
-			}
+		// Persist the status and execution metrics (tokens, cost, code changes)
+		// so they survive restarts (GH-533).
+		if err := SaveOutcome(w.store, exec, outcome); err != nil {
+			w.log.Error("Failed to record execution outcome", slog.Any("error", err))
 		}
 
 		w.currentTaskID.Store("")
 	}
 }
 
+// nextTask returns the next task to run and a release for its dispatch slot.
+// Without a fair scheduler this is the oldest queued task. With one, the
+// worker first waits for a slot, then picks fairly across requesters.
+// Returns false when the queue is empty or the worker should stop.
+func (w *ProjectWorker) nextTask(ctx context.Context) (*memory.Execution, func(), bool) {
+	if w.scheduler == nil {
+		tasks, err := w.store.GetQueuedTasksForProject(w.projectPath, 1)
+		if err != nil {
+			w.log.Error("Failed to get queued tasks", slog.Any("error", err))
+			return nil, nil, false
+		}
+		if len(tasks) == 0 {
+			return nil, nil, false // Queue empty
+		}
+		return tasks[0], func() {}, true
+	}
+
+	tasks, err := w.store.GetQueuedTasksForProject(w.projectPath, fairQueueWindow)
+	if err != nil {
+		w.log.Error("Failed to get queued tasks", slog.Any("error", err))
+		return nil, nil, false
+	}
+	if len(tasks) == 0 {
+		return nil, nil, false // Queue empty
+	}
+
+	release, err := w.scheduler.Acquire(ctx, w.projectPath, tasks)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	// The queue may have changed while waiting for a slot
+	tasks, err = w.store.GetQueuedTasksForProject(w.projectPath, fairQueueWindow)
+	if err != nil || len(tasks) == 0 {
+		if err != nil {
+			w.log.Error("Failed to get queued tasks", slog.Any("error", err))
+		}
+		release()
+		return nil, nil, false
+	}
+	return w.scheduler.Pick(w.projectPath, tasks), release, true
+}
+
+// TaskFromExecution rebuilds a queued task from its execution record,
+// which holds the full task details
+func TaskFromExecution(exec *memory.Execution) *Task {
+	return &Task{
+		ID:              exec.TaskID,
+		Title:           exec.TaskTitle,
+		Description:     exec.TaskDescription,
+		ProjectPath:     exec.ProjectPath,
+		Branch:          exec.TaskBranch,
+		BaseBranch:      exec.TaskBaseBranch,
+		CreatePR:        exec.TaskCreatePR,
+		Verbose:         exec.TaskVerbose,
+		Backend:         exec.TaskBackend,
+		MemberID:        exec.RequestedBy,
+		ResumeSessionID: exec.SessionID,
+		SourceAdapter:   exec.SourceAdapter,
+		Labels:          exec.TaskLabels,
+	}
+}
+
 // truncateForLog truncates a string for log messages, removing newlines and adding ellipsis
 func truncateForLog(s string, maxLen int) string {
 	// Replace newlines with spaces
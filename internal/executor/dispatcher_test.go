@@ -55,6 +55,7 @@ func TestDispatcher_QueueTask(t *testing.T) {
 		ProjectPath: "/tmp/test-project",
 		Branch:      "test-branch",
 		CreatePR:    true,
+		Labels:      []string{"backend:opencode"},
 	}
 
 	// Queue the task
@@ -96,6 +97,11 @@ func TestDispatcher_QueueTask(t *testing.T) {
 	if exec.TaskCreatePR != task.CreatePR {
 		t.Errorf("expected task create PR %v, got %v", task.CreatePR, exec.TaskCreatePR)
 	}
+
+	// Labels are not persisted, so the label-selected backend must be
+	if exec.TaskBackend != BackendTypeOpenCode {
+		t.Errorf("expected task backend %s, got %q", BackendTypeOpenCode, exec.TaskBackend)
+	}
 }
 
 func TestDispatcher_DuplicateTask(t *testing.T) {
@@ -517,3 +523,35 @@ func TestDispatcher_ExecutionStatusPath(t *testing.T) {
 		t.Errorf("unexpected execution status: %s", exec.Status)
 	}
 }
+
+func TestDispatcher_RemoteLeavesTasksQueued(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	dispatcher := NewDispatcher(store, NewRunner(), nil)
+	dispatcher.SetRemote(true)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer dispatcher.Stop()
+
+	execID, err := dispatcher.QueueTask(context.Background(), &Task{
+		ID:          "TEST-REMOTE",
+		Title:       "Remote Task",
+		ProjectPath: filepath.Join(os.TempDir(), "test-remote"),
+	})
+	if err != nil {
+		t.Fatalf("failed to queue task: %v", err)
+	}
+
+	if status := dispatcher.GetWorkerStatus(); len(status) != 0 {
+		t.Errorf("started %d local workers, want none", len(status))
+	}
+	exec, err := dispatcher.GetExecutionStatus(execID)
+	if err != nil {
+		t.Fatalf("failed to get execution status: %v", err)
+	}
+	if exec.Status != "queued" {
+		t.Errorf("status = %q, want queued for a remote worker", exec.Status)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alekspetrov/pilot/internal/memory"
 )
 
 // HasNoPlanKeyword checks whether the task title or description contains the [no-plan]
@@ -419,10 +421,12 @@ func (r *Runner) CreateSubIssues(ctx context.Context, plan *EpicPlan, executionP
 
 	// GH-1471: Check if we should use the SubIssueCreator interface
 	// Conditions: non-nil creator AND non-empty SourceAdapter AND not "github"
+	// AND a parent issue in that adapter to attach the sub-issues to
 	useAdapterCreator := r.subIssueCreator != nil &&
 		plan.ParentTask != nil &&
 		plan.ParentTask.SourceAdapter != "" &&
-		plan.ParentTask.SourceAdapter != "github"
+		plan.ParentTask.SourceAdapter != "github" &&
+		plan.ParentTask.SourceIssueID != ""
 
 	if useAdapterCreator {
 		return r.createSubIssuesViaAdapter(ctx, plan)
@@ -628,8 +632,15 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 		"total_issues", total,
 	)
 
+	// Sub-issues completed before an interruption are skipped
+	done := r.completedSubIssues(parent)
+
 	// Update parent with start message
 	startMsg := fmt.Sprintf("🚀 Starting sequential execution of %d sub-issues", total)
+	if len(done) > 0 {
+		startMsg = fmt.Sprintf("🔁 Resuming epic: %d/%d sub-issues already done", len(done), total)
+		r.recordEpicEvent(parent, memory.EpicEventResumed, fmt.Sprintf("%d/%d sub-issues already done", len(done), total))
+	}
 	if err := r.UpdateIssueProgress(ctx, projectPath, parent.ID, startMsg); err != nil {
 		r.log.Warn("Failed to update parent progress", "error", err)
 		// Non-fatal, continue execution
@@ -643,6 +654,16 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 		default:
 		}
 
+		position := i + 1
+		if done[position] {
+			r.log.Info("Skipping sub-issue completed before restart",
+				"parent_id", parent.ID,
+				"order", position,
+				"title", issue.Subtask.Title,
+			)
+			continue
+		}
+
 		// GH-1471: Determine issue reference and task ID format
 		// For GitHub issues (Number > 0): use "GH-N" format for backwards compatibility
 		// For non-GitHub adapters (Number == 0): use Identifier directly (e.g., "APP-123")
@@ -688,6 +709,7 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 
 		// Execute the sub-task (use override if set, for testing)
 		// GH-948: Use executeWithOptions to prevent recursive worktree creation
+		r.recordSubIssue(parent, &memory.EpicSubIssue{Position: position, Status: memory.SubIssueStatusRunning})
 		var result *ExecutionResult
 		var err error
 		if r.executeFunc != nil {
@@ -701,6 +723,7 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 			failMsg := fmt.Sprintf("❌ Failed on %d/%d: %s - Error: %v",
 				i+1, total, issue.Subtask.Title, err)
 			_ = r.UpdateIssueProgress(ctx, projectPath, parent.ID, failMsg)
+			r.recordSubIssue(parent, &memory.EpicSubIssue{Position: position, Status: memory.SubIssueStatusFailed, Error: err.Error()})
 			return fmt.Errorf("sub-issue %s failed: %w", issueRef, err)
 		}
 
@@ -708,6 +731,13 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 			failMsg := fmt.Sprintf("❌ Failed on %d/%d: %s - %s",
 				i+1, total, issue.Subtask.Title, result.Error)
 			_ = r.UpdateIssueProgress(ctx, projectPath, parent.ID, failMsg)
+			r.recordSubIssue(parent, &memory.EpicSubIssue{
+				Position: position,
+				Status:   memory.SubIssueStatusFailed,
+				PRURL:    result.PRUrl,
+				Error:    result.Error,
+				CostUSD:  result.EstimatedCostUSD,
+			})
 			return fmt.Errorf("sub-issue %s failed: %s", issueRef, result.Error)
 		}
 
@@ -733,6 +763,13 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 			// Non-fatal, continue
 		}
 
+		r.recordSubIssue(parent, &memory.EpicSubIssue{
+			Position: position,
+			Status:   memory.SubIssueStatusDone,
+			PRURL:    result.PRUrl,
+			CostUSD:  result.EstimatedCostUSD,
+		})
+
 		r.log.Info("Sub-issue completed",
 			"parent_id", parent.ID,
 			"sub_issue", issueRef,
@@ -749,6 +786,8 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 		// Non-fatal
 	}
 
+	r.recordEpicEvent(parent, memory.EpicEventCompleted, "")
+
 	r.log.Info("Epic execution completed",
 		"parent_id", parent.ID,
 		"total_completed", total,
@@ -756,3 +795,116 @@ func (r *Runner) ExecuteSubIssues(ctx context.Context, parent *Task, issues []Cr
 
 	return nil
 }
+
+// EpicStore persists epics: the plan, sub-issue progress and a timeline, so
+// an epic interrupted by a restart resumes where it stopped. Satisfied by
+// *memory.Store.
+type EpicStore interface {
+	SaveEpic(epic *memory.Epic) error
+	GetEpic(projectPath, parentID string) (*memory.Epic, error)
+	UpdateEpicSubIssue(projectPath, parentID string, sub *memory.EpicSubIssue) error
+	AddEpicEvent(projectPath, parentID, kind, message string) error
+}
+
+// saveEpic persists the created sub-issues of an epic in execution order.
+// Failures are logged; the epic still runs, it just cannot resume.
+func (r *Runner) saveEpic(task *Task, issues []CreatedIssue) {
+	if r.epicStore == nil {
+		return
+	}
+	epic := &memory.Epic{
+		ProjectPath: task.ProjectPath,
+		ParentID:    task.ID,
+		SourceRepo:  task.SourceRepo,
+		Title:       task.Title,
+	}
+	for i, issue := range issues {
+		epic.SubIssues = append(epic.SubIssues, &memory.EpicSubIssue{
+			Position:    i + 1,
+			Number:      issue.Number,
+			Identifier:  issue.Identifier,
+			URL:         issue.URL,
+			Title:       issue.Subtask.Title,
+			Description: issue.Subtask.Description,
+			DependsOn:   issue.Subtask.DependsOn,
+			Status:      memory.SubIssueStatusPending,
+		})
+	}
+	if err := r.epicStore.SaveEpic(epic); err != nil {
+		r.log.Warn("Failed to persist epic plan", "task_id", task.ID, "error", err)
+	}
+}
+
+// resumableEpic returns the stored plan and sub-issues of an epic that was
+// interrupted before completing, or nil when the task has none.
+func (r *Runner) resumableEpic(task *Task) (*EpicPlan, []CreatedIssue) {
+	if r.epicStore == nil {
+		return nil, nil
+	}
+	epic, err := r.epicStore.GetEpic(task.ProjectPath, task.ID)
+	if err != nil {
+		r.log.Warn("Failed to load epic progress", "task_id", task.ID, "error", err)
+		return nil, nil
+	}
+	if epic == nil || epic.Status == memory.EpicStatusCompleted || len(epic.SubIssues) == 0 {
+		return nil, nil
+	}
+
+	plan := &EpicPlan{ParentTask: task}
+	issues := make([]CreatedIssue, 0, len(epic.SubIssues))
+	for _, sub := range epic.SubIssues {
+		subtask := PlannedSubtask{
+			Title:       sub.Title,
+			Description: sub.Description,
+			Order:       sub.Position,
+			DependsOn:   sub.DependsOn,
+		}
+		plan.Subtasks = append(plan.Subtasks, subtask)
+		issues = append(issues, CreatedIssue{
+			Number:     sub.Number,
+			Identifier: sub.Identifier,
+			URL:        sub.URL,
+			Subtask:    subtask,
+		})
+	}
+	return plan, issues
+}
+
+// completedSubIssues returns the positions of the parent's sub-issues that
+// are already done.
+func (r *Runner) completedSubIssues(parent *Task) map[int]bool {
+	done := make(map[int]bool)
+	if r.epicStore == nil {
+		return done
+	}
+	epic, err := r.epicStore.GetEpic(parent.ProjectPath, parent.ID)
+	if err != nil || epic == nil {
+		return done
+	}
+	for _, sub := range epic.SubIssues {
+		if sub.Status == memory.SubIssueStatusDone {
+			done[sub.Position] = true
+		}
+	}
+	return done
+}
+
+// recordSubIssue persists a sub-issue's progress.
+func (r *Runner) recordSubIssue(parent *Task, sub *memory.EpicSubIssue) {
+	if r.epicStore == nil {
+		return
+	}
+	if err := r.epicStore.UpdateEpicSubIssue(parent.ProjectPath, parent.ID, sub); err != nil {
+		r.log.Warn("Failed to persist sub-issue progress", "parent_id", parent.ID, "order", sub.Position, "error", err)
+	}
+}
+
+// recordEpicEvent adds an epic-level event to the parent's timeline.
+func (r *Runner) recordEpicEvent(parent *Task, kind, message string) {
+	if r.epicStore == nil {
+		return
+	}
+	if err := r.epicStore.AddEpicEvent(parent.ProjectPath, parent.ID, kind, message); err != nil {
+		r.log.Warn("Failed to persist epic event", "parent_id", parent.ID, "kind", kind, "error", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
 )
 
 // execCall records the arguments of an executeFunc invocation.
@@ -603,6 +605,79 @@ func TestSequentialEpicFlow_PartialSuccessThenFailure(t *testing.T) {
 	}
 }
 
+// TestSequentialEpicFlow_ResumeAfterFailure verifies that persisted progress
+// lets a re-run epic skip the sub-issues completed before it stopped.
+func TestSequentialEpicFlow_ResumeAfterFailure(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	issues := makeSubIssues(3, 700)
+	parent := &Task{ID: "GH-70", Title: "[epic] Resume test", ProjectPath: "/repo"}
+
+	failSecond := true
+	sr := newSequentialRunner(func(idx int, task *Task) (*ExecutionResult, error) {
+		if task.ID == "GH-701" && failSecond {
+			return &ExecutionResult{TaskID: task.ID, Success: false, Error: "build failed"}, nil
+		}
+		return &ExecutionResult{TaskID: task.ID, Success: true, EstimatedCostUSD: 0.25}, nil
+	})
+	sr.Runner.SetEpicStore(store)
+
+	if plan, _ := sr.Runner.resumableEpic(parent); plan != nil {
+		t.Fatal("resumableEpic() returned a plan before any run was saved")
+	}
+	sr.Runner.saveEpic(parent, issues)
+
+	if err := sr.Runner.ExecuteSubIssues(context.Background(), parent, issues, ""); err == nil {
+		t.Fatal("expected error from second sub-issue")
+	}
+	epic, err := store.GetEpic("/repo", "GH-70")
+	if err != nil || epic == nil {
+		t.Fatalf("GetEpic() = %v, %v", epic, err)
+	}
+	if epic.Status != memory.EpicStatusFailed || epic.Completed() != 1 {
+		t.Errorf("epic status = %s with %d done, want failed with 1 done", epic.Status, epic.Completed())
+	}
+	if epic.SubIssues[1].Error != "build failed" {
+		t.Errorf("sub-issue error = %q, want build failed", epic.SubIssues[1].Error)
+	}
+
+	// Restart: the stored plan is resumed from the failed sub-issue
+	plan, resumed := sr.Runner.resumableEpic(parent)
+	if plan == nil || len(resumed) != 3 || resumed[2].Number != 702 || plan.Subtasks[1].Title != "Sub-issue 2" {
+		t.Fatalf("resumableEpic() = %+v, %+v", plan, resumed)
+	}
+	failSecond = false
+	sr.ExecCalls = nil
+	if err := sr.Runner.ExecuteSubIssues(context.Background(), parent, resumed, ""); err != nil {
+		t.Fatalf("resumed ExecuteSubIssues() error = %v", err)
+	}
+	if len(sr.ExecCalls) != 2 || sr.ExecCalls[0].TaskID != "GH-701" || sr.ExecCalls[1].TaskID != "GH-702" {
+		t.Errorf("resumed exec calls = %+v, want GH-701 and GH-702", sr.ExecCalls)
+	}
+
+	epic, _ = store.GetEpic("/repo", "GH-70")
+	if epic.Status != memory.EpicStatusCompleted || epic.Completed() != 3 || epic.CostUSD != 0.75 {
+		t.Errorf("epic = %s with %d done costing %v, want completed with 3 done costing 0.75",
+			epic.Status, epic.Completed(), epic.CostUSD)
+	}
+	var kinds []string
+	for _, event := range epic.Timeline {
+		if event.Position == 0 {
+			kinds = append(kinds, event.Kind)
+		}
+	}
+	if want := []string{memory.EpicEventPlanned, memory.EpicEventResumed, memory.EpicEventCompleted}; strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Errorf("epic-level events = %v, want %v", kinds, want)
+	}
+	if plan, _ := sr.Runner.resumableEpic(parent); plan != nil {
+		t.Error("resumableEpic() returned a plan for a completed epic")
+	}
+}
+
 // TestSequentialEpicFlow_ContextDeadline verifies that a context timeout
 // is respected between sub-issue executions.
 func TestSequentialEpicFlow_ContextDeadline(t *testing.T) {
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// estimateWindow is the number of recent successful runs a prediction uses
+const estimateWindow = 20
+
+// EstimateTracker predicts task effort before execution and stores the
+// estimate with its actuals for calibration. Satisfied by *memory.Store.
+type EstimateTracker interface {
+	PredictTaskEffort(projectPath, complexity string, window int) (time.Duration, int64, int, error)
+	RecordTaskEstimate(est *memory.TaskEstimate) error
+}
+
+// SetEstimateTracker sets the store that records estimated vs actual effort
+func (r *Runner) SetEstimateTracker(t EstimateTracker) {
+	r.estimateTracker = t
+}
+
+// HasEstimateTracker reports whether an estimate tracker is wired
+func (r *Runner) HasEstimateTracker() bool { return r.estimateTracker != nil }
+
+// estimateTask predicts duration and tokens for a task from past runs of the
+// same project and complexity class. Returns nil without a tracker.
+func (r *Runner) estimateTask(task *Task, complexity Complexity, model, effort string) *memory.TaskEstimate {
+	if r.estimateTracker == nil {
+		return nil
+	}
+	est := &memory.TaskEstimate{
+		TaskID:      task.ID,
+		ProjectPath: task.ProjectPath,
+		Complexity:  string(complexity),
+		Effort:      effort,
+		Model:       model,
+	}
+	duration, tokens, samples, err := r.estimateTracker.PredictTaskEffort(task.ProjectPath, string(complexity), estimateWindow)
+	if err != nil {
+		r.log.Warn("Failed to predict task effort", slog.String("task_id", task.ID), slog.Any("error", err))
+		return est
+	}
+	est.PredictedDuration, est.PredictedTokens, est.Samples = duration, tokens, samples
+	return est
+}
+
+// recordEstimate stores the estimate with the execution's actuals
+func (r *Runner) recordEstimate(est *memory.TaskEstimate, result *ExecutionResult, duration time.Duration) {
+	if r.estimateTracker == nil || est == nil {
+		return
+	}
+	est.ActualDuration = duration
+	est.ActualTokens = result.TokensInput + result.TokensOutput
+	est.Success = result.Success
+	if result.ModelName != "" {
+		est.Model = result.ModelName
+	}
+	if err := r.estimateTracker.RecordTaskEstimate(est); err != nil {
+		r.log.Warn("Failed to record task estimate", slog.Any("error", err))
+	}
+}
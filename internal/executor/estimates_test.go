@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestRunner_EstimateAndRecord(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	runner := NewRunner()
+	task := &Task{ID: "TASK-1", ProjectPath: "/repo"}
+	if est := runner.estimateTask(task, ComplexityMedium, "", ""); est != nil {
+		t.Fatalf("estimateTask() without tracker = %+v, want nil", est)
+	}
+
+	runner.SetEstimateTracker(store)
+	if !runner.HasEstimateTracker() {
+		t.Fatal("HasEstimateTracker() = false after SetEstimateTracker")
+	}
+
+	// First run has no history to predict from
+	est := runner.estimateTask(task, ComplexityMedium, "claude-sonnet", "medium")
+	if est.Estimated() {
+		t.Errorf("first estimate = %+v, want no prediction", est)
+	}
+	runner.recordEstimate(est, &ExecutionResult{Success: true, TokensInput: 800, TokensOutput: 200, ModelName: "claude-opus"}, 10*time.Minute)
+
+	est = runner.estimateTask(&Task{ID: "TASK-2", ProjectPath: "/repo"}, ComplexityMedium, "", "")
+	if !est.Estimated() || est.PredictedDuration != 10*time.Minute || est.PredictedTokens != 1000 {
+		t.Errorf("second estimate = %+v, want 10m / 1000 tokens", est)
+	}
+	runner.recordEstimate(est, &ExecutionResult{Success: false}, 20*time.Minute)
+
+	recorded, err := store.GetTaskEstimates(memory.MetricsQuery{Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("GetTaskEstimates failed: %v", err)
+	}
+	if len(recorded) != 2 {
+		t.Fatalf("recorded %d estimates, want 2", len(recorded))
+	}
+	first := recorded[0]
+	if first.Model != "claude-opus" || first.Effort != "medium" || first.ActualTokens != 1000 || !first.Success {
+		t.Errorf("first recorded = %+v", first)
+	}
+	if second := recorded[1]; second.Samples != 1 || second.ActualDuration != 20*time.Minute || second.Success {
+		t.Errorf("second recorded = %+v", second)
+	}
+}
+
+type failingEstimateTracker struct{ recorded []*memory.TaskEstimate }
+
+func (f *failingEstimateTracker) PredictTaskEffort(string, string, int) (time.Duration, int64, int, error) {
+	return 0, 0, 0, errors.New("db locked")
+}
+
+func (f *failingEstimateTracker) RecordTaskEstimate(est *memory.TaskEstimate) error {
+	f.recorded = append(f.recorded, est)
+	return nil
+}
+
+func TestRunner_EstimatePredictionError(t *testing.T) {
+	runner := NewRunner()
+	tracker := &failingEstimateTracker{}
+	runner.SetEstimateTracker(tracker)
+
+	// A failed prediction still records actuals, just without an estimate
+	est := runner.estimateTask(&Task{ID: "TASK-1", ProjectPath: "/repo"}, ComplexitySimple, "", "")
+	runner.recordEstimate(est, &ExecutionResult{Success: true}, time.Minute)
+	if len(tracker.recorded) != 1 || tracker.recorded[0].Estimated() {
+		t.Errorf("recorded = %+v, want one unestimated run", tracker.recorded)
+	}
+}
@@ -0,0 +1,406 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// FairnessConfig enables fair scheduling in the dispatcher. Instead of every
+// project worker starting tasks as soon as they are queued, workers share a
+// fixed number of dispatch slots handed out by weighted round-robin across
+// projects, and each project picks its next task by weighted round-robin
+// across requesters rather than strictly FIFO.
+//
+// Example YAML configuration:
+//
+//	orchestrator:
+//	  fairness:
+//	    enabled: true
+//	    max_concurrent: 3          # default: orchestrator.max_concurrent
+//	    project_weights:
+//	      /home/dev/web: 3         # gets 3 slots for every 1 of other projects
+//	    requester_weights:
+//	      oncall-bot: 2
+//	    starvation_threshold: 15m
+type FairnessConfig struct {
+	// Enabled turns on fair scheduling
+	Enabled bool `yaml:"enabled"`
+
+	// MaxConcurrent is the number of tasks the dispatcher runs at once
+	// across all projects (0 = unlimited, fairness only orders requesters)
+	MaxConcurrent int `yaml:"max_concurrent"`
+
+	// DefaultWeight applies to projects and requesters without an explicit
+	// weight (default: 1)
+	DefaultWeight int `yaml:"default_weight"`
+
+	// ProjectWeights maps a project path to its scheduling weight
+	ProjectWeights map[string]int `yaml:"project_weights"`
+
+	// RequesterWeights maps a requester (team member ID) to its weight
+	RequesterWeights map[string]int `yaml:"requester_weights"`
+
+	// StarvationThreshold is how long a task may wait in the queue before it
+	// counts as starved (default: 15m)
+	StarvationThreshold time.Duration `yaml:"starvation_threshold"`
+}
+
+const (
+	defaultFairnessWeight      = 1
+	defaultStarvationThreshold = 15 * time.Minute
+	fairQueueWindow            = 50 // Queued tasks a worker considers per pick
+	unknownRequester           = "unknown"
+)
+
+// Validate checks that weights are positive and limits are not negative
+func (c *FairnessConfig) Validate() error {
+	if c.MaxConcurrent < 0 || c.DefaultWeight < 0 || c.StarvationThreshold < 0 {
+		return fmt.Errorf("max_concurrent, default_weight and starvation_threshold must not be negative")
+	}
+	for project, w := range c.ProjectWeights {
+		if w <= 0 {
+			return fmt.Errorf("project weight for %s must be positive, got %d", project, w)
+		}
+	}
+	for requester, w := range c.RequesterWeights {
+		if w <= 0 {
+			return fmt.Errorf("requester weight for %s must be positive, got %d", requester, w)
+		}
+	}
+	return nil
+}
+
+// FairShare reports how one project or requester has been served. Queue
+// wait is measured from when a task was queued until it started.
+type FairShare struct {
+	Key        string        // Project path or requester
+	Weight     int           // Scheduling weight
+	Queued     int           // Tasks queued when last seen (projects only)
+	Waiting    bool          // Held back waiting for a dispatch slot (projects only)
+	Wait       time.Duration // How long the oldest queued task has waited, while Waiting
+	Dispatched int64         // Tasks started, since start
+	TotalWait  time.Duration // Queue wait of all started tasks
+	MaxWait    time.Duration // Longest queue wait of a started task
+	Starved    int64         // Started tasks that waited past the starvation threshold
+}
+
+// AvgWait returns the mean queue wait of started tasks
+func (f FairShare) AvgWait() time.Duration {
+	if f.Dispatched == 0 {
+		return 0
+	}
+	return f.TotalWait / time.Duration(f.Dispatched)
+}
+
+// FairnessSnapshot is a point-in-time view of the fair scheduler, for the
+// dashboard
+type FairnessSnapshot struct {
+	Slots               int // 0 = unlimited
+	Active              int
+	StarvationThreshold time.Duration
+	Projects            []FairShare // Sorted by key
+	Requesters          []FairShare // Sorted by key, aggregated across projects
+}
+
+// Starving returns the projects whose oldest task has been waiting for a
+// slot longer than the starvation threshold
+func (s FairnessSnapshot) Starving() []FairShare {
+	var out []FairShare
+	for _, p := range s.Projects {
+		if p.Waiting && p.Wait > s.StarvationThreshold {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// fairProject holds a project's share and round-robin state
+type fairProject struct {
+	share       FairShare
+	queuedSince time.Time
+	requesters  map[string]int // Requester round-robin counters within the project
+	grant       chan struct{}  // Non-nil while waiting for a slot
+	waitSeq     uint64         // Order in which waiting started
+}
+
+// FairScheduler hands out dispatch slots to project workers by smooth
+// weighted round-robin, so a busy project cannot starve the others, and
+// orders each project's queue across requesters the same way.
+type FairScheduler struct {
+	config     *FairnessConfig
+	mu         sync.Mutex
+	active     int
+	projects   map[string]*fairProject
+	rotation   map[string]int // Project round-robin counters
+	seq        uint64
+	requesters map[string]*FairShare
+	now        func() time.Time
+}
+
+// NewFairScheduler creates a fair scheduler. A nil config schedules with
+// equal weights and unlimited slots.
+func NewFairScheduler(config *FairnessConfig) *FairScheduler {
+	if config == nil {
+		config = &FairnessConfig{}
+	}
+	return &FairScheduler{
+		config:     config,
+		projects:   make(map[string]*fairProject),
+		rotation:   make(map[string]int),
+		requesters: make(map[string]*FairShare),
+		now:        time.Now,
+	}
+}
+
+// ProjectWeight returns the scheduling weight of a project
+func (s *FairScheduler) ProjectWeight(projectPath string) int {
+	for path, w := range s.config.ProjectWeights {
+		if filepath.Clean(path) == filepath.Clean(projectPath) {
+			return w
+		}
+	}
+	return s.defaultWeight()
+}
+
+// RequesterWeight returns the scheduling weight of a requester
+func (s *FairScheduler) RequesterWeight(requester string) int {
+	if w, ok := s.config.RequesterWeights[requester]; ok {
+		return w
+	}
+	return s.defaultWeight()
+}
+
+func (s *FairScheduler) defaultWeight() int {
+	if s.config.DefaultWeight > 0 {
+		return s.config.DefaultWeight
+	}
+	return defaultFairnessWeight
+}
+
+func (s *FairScheduler) starvationThreshold() time.Duration {
+	if s.config.StarvationThreshold > 0 {
+		return s.config.StarvationThreshold
+	}
+	return defaultStarvationThreshold
+}
+
+// Acquire blocks until the project is granted a dispatch slot, or ctx is
+// done. queued is the project's queue, oldest first; it is used for queue
+// metrics only. The returned release must be called exactly once.
+func (s *FairScheduler) Acquire(ctx context.Context, projectPath string, queued []*memory.Execution) (func(), error) {
+	s.mu.Lock()
+	p := s.project(projectPath)
+	p.share.Queued = len(queued)
+	p.queuedSince = time.Time{}
+	if len(queued) > 0 {
+		p.queuedSince = queued[0].CreatedAt
+	}
+
+	// Take a free slot directly unless other projects are already waiting,
+	// so newcomers cannot jump the round-robin
+	if s.hasSlot() && !s.anyWaiting() {
+		s.active++
+		s.mu.Unlock()
+		return s.releaseFunc(), nil
+	}
+
+	grant := make(chan struct{})
+	s.seq++
+	p.grant, p.waitSeq = grant, s.seq
+	p.share.Waiting = true
+	s.mu.Unlock()
+
+	select {
+	case <-grant:
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if p.grant == grant {
+			p.grant = nil
+			p.share.Waiting = false
+			return nil, ctx.Err()
+		}
+		// Granted while cancelling: hand the slot on
+		s.active--
+		s.dispatch()
+		return nil, ctx.Err()
+	}
+}
+
+// releaseFunc returns a release that frees a slot and grants it to the next
+// waiting project
+func (s *FairScheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.active--
+			s.dispatch()
+			s.mu.Unlock()
+		})
+	}
+}
+
+// dispatch grants free slots to waiting projects by smooth weighted
+// round-robin. Called with s.mu held.
+func (s *FairScheduler) dispatch() {
+	for s.hasSlot() {
+		var waiting []string
+		for path, p := range s.projects {
+			if p.grant != nil {
+				waiting = append(waiting, path)
+			}
+		}
+		if len(waiting) == 0 {
+			return
+		}
+		sort.Slice(waiting, func(i, j int) bool {
+			return s.projects[waiting[i]].waitSeq < s.projects[waiting[j]].waitSeq
+		})
+		path := smoothWeightedPick(waiting, s.rotation, s.ProjectWeight)
+		p := s.projects[path]
+		close(p.grant)
+		p.grant = nil
+		p.share.Waiting = false
+		s.active++
+	}
+}
+
+// Pick chooses the next task to run from a project's queue, oldest first:
+// the oldest task of the requester picked by weighted round-robin. It
+// records the task's queue wait and returns nil for an empty queue.
+func (s *FairScheduler) Pick(projectPath string, queued []*memory.Execution) *memory.Execution {
+	if len(queued) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(projectPath)
+
+	oldest := make(map[string]*memory.Execution)
+	var requesters []string
+	for _, exec := range queued {
+		r := requesterKey(exec.RequestedBy)
+		if _, ok := oldest[r]; !ok {
+			oldest[r] = exec
+			requesters = append(requesters, r)
+		}
+	}
+	requester := smoothWeightedPick(requesters, p.requesters, s.RequesterWeight)
+	exec := oldest[requester]
+
+	wait := s.now().Sub(exec.CreatedAt)
+	if wait < 0 || exec.CreatedAt.IsZero() {
+		wait = 0
+	}
+	starved := wait > s.starvationThreshold()
+	p.share.Queued = len(queued) - 1
+	recordDispatch(&p.share, wait, starved)
+
+	r, ok := s.requesters[requester]
+	if !ok {
+		r = &FairShare{Key: requester}
+		s.requesters[requester] = r
+	}
+	recordDispatch(r, wait, starved)
+	return exec
+}
+
+// smoothWeightedPick picks one of keys by smooth weighted round-robin: each
+// candidate's counter grows by its weight, the largest wins and is lowered
+// by the total weight. Over time each key is picked in proportion to its
+// weight, interleaved rather than in bursts. Ties go to the earliest key, so
+// keys are passed longest-waiting first.
+func smoothWeightedPick(keys []string, counters map[string]int, weight func(string) int) string {
+	total := 0
+	best := ""
+	for _, key := range keys {
+		w := weight(key)
+		total += w
+		counters[key] += w
+		if best == "" || counters[key] > counters[best] {
+			best = key
+		}
+	}
+	counters[best] -= total
+	return best
+}
+
+func recordDispatch(share *FairShare, wait time.Duration, starved bool) {
+	share.Dispatched++
+	share.TotalWait += wait
+	if wait > share.MaxWait {
+		share.MaxWait = wait
+	}
+	if starved {
+		share.Starved++
+	}
+}
+
+// FairnessSnapshot returns slot usage and per-project and per-requester shares
+func (s *FairScheduler) FairnessSnapshot() FairnessSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap := FairnessSnapshot{
+		Slots:               s.config.MaxConcurrent,
+		Active:              s.active,
+		StarvationThreshold: s.starvationThreshold(),
+	}
+	now := s.now()
+	for path, p := range s.projects {
+		share := p.share
+		share.Weight = s.ProjectWeight(path)
+		if share.Waiting && !p.queuedSince.IsZero() {
+			share.Wait = now.Sub(p.queuedSince)
+		}
+		snap.Projects = append(snap.Projects, share)
+	}
+	for key, r := range s.requesters {
+		share := *r
+		share.Weight = s.RequesterWeight(key)
+		snap.Requesters = append(snap.Requesters, share)
+	}
+	sort.Slice(snap.Projects, func(i, j int) bool { return snap.Projects[i].Key < snap.Projects[j].Key })
+	sort.Slice(snap.Requesters, func(i, j int) bool { return snap.Requesters[i].Key < snap.Requesters[j].Key })
+	return snap
+}
+
+// project returns a project's state, creating it on first use. Called with
+// s.mu held.
+func (s *FairScheduler) project(projectPath string) *fairProject {
+	p, ok := s.projects[projectPath]
+	if !ok {
+		p = &fairProject{share: FairShare{Key: projectPath}, requesters: make(map[string]int)}
+		s.projects[projectPath] = p
+	}
+	return p
+}
+
+// hasSlot reports whether a dispatch slot is free. Called with s.mu held.
+func (s *FairScheduler) hasSlot() bool {
+	return s.config.MaxConcurrent <= 0 || s.active < s.config.MaxConcurrent
+}
+
+// anyWaiting reports whether any project is waiting. Called with s.mu held.
+func (s *FairScheduler) anyWaiting() bool {
+	for _, p := range s.projects {
+		if p.grant != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func requesterKey(requestedBy string) string {
+	if requestedBy == "" {
+		return unknownRequester
+	}
+	return requestedBy
+}
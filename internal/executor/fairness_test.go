@@ -0,0 +1,252 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestFairnessConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  FairnessConfig
+		wantErr string
+	}{
+		{name: "empty", config: FairnessConfig{}},
+		{name: "valid", config: FairnessConfig{Enabled: true, MaxConcurrent: 2, ProjectWeights: map[string]int{"/a": 3}}},
+		{name: "negative slots", config: FairnessConfig{MaxConcurrent: -1}, wantErr: "must not be negative"},
+		{name: "zero project weight", config: FairnessConfig{ProjectWeights: map[string]int{"/a": 0}}, wantErr: "project weight for /a"},
+		{name: "negative requester weight", config: FairnessConfig{RequesterWeights: map[string]int{"bob": -2}}, wantErr: "requester weight for bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSmoothWeightedPick(t *testing.T) {
+	weights := map[string]int{"a": 2, "b": 1}
+	counters := make(map[string]int)
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, smoothWeightedPick([]string{"a", "b"}, counters, func(k string) int { return weights[k] }))
+	}
+	if strings.Join(got, "") != "abaaba" {
+		t.Errorf("picks = %v, want a b a a b a", got)
+	}
+}
+
+func TestFairScheduler_RoundRobinAcrossProjects(t *testing.T) {
+	s := NewFairScheduler(&FairnessConfig{MaxConcurrent: 1, ProjectWeights: map[string]int{"/busy": 2}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type grant struct {
+		project string
+		release func()
+	}
+	grants := make(chan grant)
+	wait := func(project string) {
+		go func() {
+			if release, err := s.Acquire(ctx, project, nil); err == nil {
+				grants <- grant{project, release}
+			}
+		}()
+	}
+
+	hold, err := s.Acquire(ctx, "/busy", nil)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	wait("/quiet")
+	waitFor(t, func() bool { return waitingProjects(s) == 1 })
+	wait("/busy")
+	waitFor(t, func() bool { return waitingProjects(s) == 2 })
+
+	snap := s.FairnessSnapshot()
+	if snap.Active != 1 || snap.Slots != 1 || len(snap.Projects) != 2 {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+	hold()
+
+	// Both projects always have more work: each granted project queues up
+	// again before releasing its slot
+	counts := make(map[string]int)
+	var first string
+	for i := 0; i < 9; i++ {
+		var g grant
+		select {
+		case g = <-grants:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d grants", i)
+		}
+		if first == "" {
+			first = g.project
+		}
+		counts[g.project]++
+		wait(g.project)
+		waitFor(t, func() bool { return waitingProjects(s) == 2 })
+		g.release()
+	}
+	if first != "/busy" || counts["/busy"] != 6 || counts["/quiet"] != 3 {
+		t.Errorf("first = %s, grants = %v, want /busy first and 6:3", first, counts)
+	}
+}
+
+func TestFairScheduler_AcquireCancel(t *testing.T) {
+	s := NewFairScheduler(&FairnessConfig{MaxConcurrent: 1})
+	release, err := s.Acquire(context.Background(), "/a", nil)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Acquire(ctx, "/b", nil)
+		done <- err
+	}()
+	waitFor(t, func() bool { return waitingProjects(s) == 1 })
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Acquire() after cancel = %v, want context.Canceled", err)
+	}
+	if waitingProjects(s) != 0 {
+		t.Error("cancelled project still waiting")
+	}
+
+	release()
+	if _, err := s.Acquire(context.Background(), "/c", nil); err != nil {
+		t.Errorf("Acquire after release = %v", err)
+	}
+}
+
+func TestFairScheduler_PickAcrossRequesters(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	s := NewFairScheduler(&FairnessConfig{StarvationThreshold: 30 * time.Minute})
+	s.now = func() time.Time { return now }
+
+	queue := []*memory.Execution{
+		{ID: "1", RequestedBy: "alice", CreatedAt: now.Add(-time.Hour)},
+		{ID: "2", RequestedBy: "alice", CreatedAt: now.Add(-50 * time.Minute)},
+		{ID: "3", RequestedBy: "alice", CreatedAt: now.Add(-40 * time.Minute)},
+		{ID: "4", CreatedAt: now.Add(-10 * time.Minute)},
+	}
+	var got []string
+	for len(queue) > 0 {
+		exec := s.Pick("/repo", queue)
+		got = append(got, exec.ID)
+		for i, q := range queue {
+			if q == exec {
+				queue = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+	}
+	// The anonymous task is not stuck behind alice's backlog
+	if strings.Join(got, ",") != "1,4,2,3" {
+		t.Errorf("pick order = %v, want 1,4,2,3", got)
+	}
+	if s.Pick("/repo", nil) != nil {
+		t.Error("Pick() on empty queue should return nil")
+	}
+
+	snap := s.FairnessSnapshot()
+	if len(snap.Requesters) != 2 || snap.Requesters[0].Key != "alice" || snap.Requesters[1].Key != unknownRequester {
+		t.Fatalf("requesters = %+v", snap.Requesters)
+	}
+	alice := snap.Requesters[0]
+	if alice.Dispatched != 3 || alice.MaxWait != time.Hour || alice.Starved != 3 || alice.AvgWait() != 50*time.Minute {
+		t.Errorf("alice = %+v", alice)
+	}
+	if repo := snap.Projects[0]; repo.Dispatched != 4 || repo.Starved != 3 || repo.Queued != 0 {
+		t.Errorf("project = %+v", repo)
+	}
+}
+
+func TestFairnessSnapshot_Starving(t *testing.T) {
+	now := time.Now()
+	s := NewFairScheduler(&FairnessConfig{MaxConcurrent: 1, StarvationThreshold: time.Minute})
+	release, _ := s.Acquire(context.Background(), "/busy", nil)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_, _ = s.Acquire(ctx, "/quiet", []*memory.Execution{{CreatedAt: now.Add(-5 * time.Minute)}})
+	}()
+	waitFor(t, func() bool { return waitingProjects(s) == 1 })
+
+	starving := s.FairnessSnapshot().Starving()
+	if len(starving) != 1 || starving[0].Key != "/quiet" || starving[0].Queued != 1 || starving[0].Wait < 5*time.Minute {
+		t.Errorf("Starving() = %+v", starving)
+	}
+}
+
+func TestDispatcher_FairScheduler(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	dispatcher := NewDispatcher(store, NewRunner(), nil)
+	scheduler := NewFairScheduler(&FairnessConfig{Enabled: true, MaxConcurrent: 1})
+	dispatcher.SetFairScheduler(scheduler)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer dispatcher.Stop()
+
+	ctx := context.Background()
+	var execIDs []string
+	for _, task := range []*Task{
+		{ID: "FAIR-1", Title: "A1", ProjectPath: "/tmp/fair-a", MemberID: "alice"},
+		{ID: "FAIR-2", Title: "B1", ProjectPath: "/tmp/fair-b", MemberID: "bob"},
+	} {
+		execID, err := dispatcher.QueueTask(ctx, task)
+		if err != nil {
+			t.Fatalf("QueueTask(%s) failed: %v", task.ID, err)
+		}
+		execIDs = append(execIDs, execID)
+	}
+	for _, id := range execIDs {
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if _, err := dispatcher.WaitForExecution(waitCtx, id, 50*time.Millisecond); err != nil {
+			cancel()
+			t.Fatalf("WaitForExecution(%s) failed: %v", id, err)
+		}
+		cancel()
+	}
+
+	snap := scheduler.FairnessSnapshot()
+	if snap.Active != 0 || len(snap.Projects) != 2 || len(snap.Requesters) != 2 {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+	for _, p := range snap.Projects {
+		if p.Dispatched != 1 {
+			t.Errorf("project %s dispatched %d tasks, want 1", p.Key, p.Dispatched)
+		}
+	}
+}
+
+// waitingProjects counts projects waiting for a dispatch slot
+func waitingProjects(s *FairScheduler) int {
+	n := 0
+	for _, p := range s.FairnessSnapshot().Projects {
+		if p.Waiting {
+			n++
+		}
+	}
+	return n
+}
@@ -3,7 +3,10 @@ package executor
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -92,11 +95,24 @@ func (g *GitOperations) Push(ctx context.Context, branchName string) error {
 
 // CreatePR creates a pull request using gh CLI
 func (g *GitOperations) CreatePR(ctx context.Context, title, body, baseBranch string) (string, error) {
-	cmd := exec.CommandContext(ctx, "gh", "pr", "create",
+	return g.createPR(ctx, title, body, baseBranch, false)
+}
+
+// CreateDraftPR creates a draft pull request using gh CLI
+func (g *GitOperations) CreateDraftPR(ctx context.Context, title, body, baseBranch string) (string, error) {
+	return g.createPR(ctx, title, body, baseBranch, true)
+}
+
+func (g *GitOperations) createPR(ctx context.Context, title, body, baseBranch string, draft bool) (string, error) {
+	args := []string{"pr", "create",
 		"--title", title,
 		"--body", body,
 		"--base", baseBranch,
-	)
+	}
+	if draft {
+		args = append(args, "--draft")
+	}
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	cmd.Dir = g.projectPath
 	output, err := cmd.CombinedOutput()
 	outputStr := string(output)
@@ -191,6 +207,22 @@ func (g *GitOperations) GetChangedFiles(ctx context.Context) ([]string, error) {
 	return files, nil
 }
 
+// GetChangedFilesSince returns files changed between baseBranch and HEAD
+func (g *GitOperations) GetChangedFilesSince(ctx context.Context, baseBranch string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", baseBranch+"...HEAD")
+	cmd.Dir = g.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	files := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(files) == 1 && files[0] == "" {
+		return []string{}, nil
+	}
+	return files, nil
+}
+
 // HasUncommittedChanges checks if there are uncommitted changes
 func (g *GitOperations) HasUncommittedChanges(ctx context.Context) (bool, error) {
 	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
@@ -258,6 +290,31 @@ func (g *GitOperations) GetDiff(ctx context.Context, baseBranch string) (string,
 	return string(output), nil
 }
 
+// DiffLineCount returns the number of lines added plus removed between
+// baseBranch and HEAD. Binary files are not counted.
+func (g *GitOperations) DiffLineCount(ctx context.Context, baseBranch string) (int, error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--numstat", baseBranch+"...HEAD")
+	cmd.Dir = g.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("git diff --numstat failed: %w", err)
+	}
+
+	total := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		for _, f := range fields[:2] {
+			if n, err := strconv.Atoi(f); err == nil {
+				total += n
+			}
+		}
+	}
+	return total, nil
+}
+
 // Pull fetches and merges changes from remote for the specified branch
 func (g *GitOperations) Pull(ctx context.Context, branch string) error {
 	cmd := exec.CommandContext(ctx, "git", "pull", "origin", branch)
@@ -351,3 +408,74 @@ func (g *GitOperations) RemoteBranchExists(ctx context.Context, branchName strin
 	// ls-remote returns non-empty output if branch exists
 	return len(strings.TrimSpace(string(output))) > 0
 }
+
+// FetchBranches fetches the given branches from origin.
+func (g *GitOperations) FetchBranches(ctx context.Context, branches ...string) error {
+	args := append([]string{"fetch", "origin"}, branches...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.projectPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w: %s", strings.Join(branches, ", "), err, output)
+	}
+	return nil
+}
+
+// HasMerged reports whether ref is an ancestor of HEAD.
+func (g *GitOperations) HasMerged(ctx context.Context, ref string) bool {
+	cmd := exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", ref, "HEAD")
+	cmd.Dir = g.projectPath
+	return cmd.Run() == nil
+}
+
+// FinishMerge commits a merge left in progress. Conflicted files must be
+// resolved: a file that still holds conflict markers fails the merge.
+func (g *GitOperations) FinishMerge(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = g.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to list unmerged files: %w", err)
+	}
+	var unresolved []string
+	for _, file := range strings.Fields(string(output)) {
+		if hasConflictMarkers(filepath.Join(g.projectPath, file)) {
+			unresolved = append(unresolved, file)
+		}
+	}
+	if len(unresolved) > 0 {
+		return fmt.Errorf("unresolved conflicts in %s", strings.Join(unresolved, ", "))
+	}
+
+	mergeHead := exec.CommandContext(ctx, "git", "rev-parse", "-q", "--verify", "MERGE_HEAD")
+	mergeHead.Dir = g.projectPath
+	if mergeHead.Run() != nil {
+		return nil // No merge in progress
+	}
+
+	addCmd := exec.CommandContext(ctx, "git", "add", "-A")
+	addCmd.Dir = g.projectPath
+	if output, err := addCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stage resolved files: %w: %s", err, output)
+	}
+	commitCmd := exec.CommandContext(ctx, "git", "commit", "--no-edit")
+	commitCmd.Dir = g.projectPath
+	if output, err := commitCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit merge: %w: %s", err, output)
+	}
+	return nil
+}
+
+// hasConflictMarkers reports whether a file still holds conflict markers
+func hasConflictMarkers(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false // Deleted while resolving
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+			return true
+		}
+	}
+	return false
+}
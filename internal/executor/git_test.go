@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -409,6 +410,40 @@ func TestCountNewCommits(t *testing.T) {
 	})
 }
 
+func TestDiffLineCount(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+
+	_ = exec.CommandContext(ctx, "git", "-C", tmpDir, "init").Run()
+	_ = exec.CommandContext(ctx, "git", "-C", tmpDir, "config", "user.email", "test@test.com").Run()
+	_ = exec.CommandContext(ctx, "git", "-C", tmpDir, "config", "user.name", "Test User").Run()
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("one\ntwo\n"), 0644)
+	_ = exec.CommandContext(ctx, "git", "-C", tmpDir, "add", ".").Run()
+	_ = exec.CommandContext(ctx, "git", "-C", tmpDir, "commit", "-m", "initial").Run()
+
+	git := NewGitOperations(tmpDir)
+	defaultBranch, _ := git.GetCurrentBranch(ctx)
+	_ = git.CreateBranch(ctx, "pilot/GH-100")
+
+	// 1 removed + 1 added in test.txt, 3 added in new.txt, binary ignored
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("one\nTWO\n"), 0644)
+	_ = os.WriteFile(filepath.Join(tmpDir, "new.txt"), []byte("a\nb\nc\n"), 0644)
+	_ = os.WriteFile(filepath.Join(tmpDir, "blob.bin"), []byte{0, 1, 2, 0}, 0644)
+	_, _ = git.Commit(ctx, "feat: change files")
+
+	count, err := git.DiffLineCount(ctx, defaultBranch)
+	if err != nil {
+		t.Fatalf("DiffLineCount failed: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("count = %d, want 5", count)
+	}
+}
+
 func TestExtractPRURL(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -622,3 +657,55 @@ func TestRemoteBranchExists_WithRemote(t *testing.T) {
 		t.Error("RemoteBranchExists should return false for nonexistent branch")
 	}
 }
+
+func TestFinishMerge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+	run := func(args ...string) {
+		_ = exec.CommandContext(ctx, "git", append([]string{"-C", tmpDir}, args...)...).Run()
+	}
+
+	run("init")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("one\n"), 0644)
+	run("add", ".")
+	run("commit", "-m", "initial")
+
+	git := NewGitOperations(tmpDir)
+	defaultBranch, _ := git.GetCurrentBranch(ctx)
+	_ = git.CreateBranch(ctx, "pilot/GH-101")
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("branch\n"), 0644)
+	_, _ = git.Commit(ctx, "feat: branch change")
+	_ = git.SwitchBranch(ctx, defaultBranch)
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("main\n"), 0644)
+	_, _ = git.Commit(ctx, "feat: main change")
+	_ = git.SwitchBranch(ctx, "pilot/GH-101")
+
+	if err := git.FinishMerge(ctx); err != nil {
+		t.Fatalf("FinishMerge without a merge in progress: %v", err)
+	}
+
+	run("merge", defaultBranch)
+	if err := git.FinishMerge(ctx); err == nil || !strings.Contains(err.Error(), "test.txt") {
+		t.Fatalf("FinishMerge with conflict markers = %v, want unresolved test.txt", err)
+	}
+	if git.HasMerged(ctx, defaultBranch) {
+		t.Fatal("HasMerged should be false before the merge is committed")
+	}
+
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("branch\nmain\n"), 0644)
+	if err := git.FinishMerge(ctx); err != nil {
+		t.Fatalf("FinishMerge after resolving: %v", err)
+	}
+	if !git.HasMerged(ctx, defaultBranch) {
+		t.Error("HasMerged should be true after the merge is committed")
+	}
+	if dirty, _ := git.HasUncommittedChanges(ctx); dirty {
+		t.Error("resolved merge should be committed")
+	}
+}
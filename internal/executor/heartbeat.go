@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// heartbeatVerdict is the outcome of a single heartbeat check.
+type heartbeatVerdict int
+
+const (
+	// heartbeatAlive means a stream event arrived within the timeout.
+	heartbeatAlive heartbeatVerdict = iota
+	// heartbeatProbing means the stream went silent while a tool call was in
+	// flight; the process gets one extra timeout window before being killed.
+	heartbeatProbing
+	// heartbeatStalled means the process is considered hung and must be killed.
+	heartbeatStalled
+)
+
+// heartbeatMonitor tracks stream liveness of a backend subprocess (GH-884).
+//
+// Silence longer than the timeout triggers a probe. A tool call that has not
+// returned yet (long test suite, slow build) explains the silence, so the
+// process gets one more timeout window. Otherwise, or if the extra window
+// also passes without events, the process is declared stalled.
+type heartbeatMonitor struct {
+	timeout time.Duration
+
+	lastEventAt   atomic.Int64 // Unix nano of the last stream event
+	probeDeadline atomic.Int64 // Unix nano; 0 when no probe is pending
+	toolsInFlight atomic.Int32 // tool_use events without a matching tool_result
+	stalled       atomic.Bool
+}
+
+// newHeartbeatMonitor creates a monitor whose clock starts now.
+func newHeartbeatMonitor(timeout time.Duration) *heartbeatMonitor {
+	h := &heartbeatMonitor{timeout: timeout}
+	h.lastEventAt.Store(time.Now().UnixNano())
+	return h
+}
+
+// Observe records a stream event, resetting the silence clock and any pending probe.
+func (h *heartbeatMonitor) Observe(event BackendEvent) {
+	h.lastEventAt.Store(time.Now().UnixNano())
+	h.probeDeadline.Store(0)
+
+	switch event.Type {
+	case EventTypeToolUse:
+		h.toolsInFlight.Add(1)
+	case EventTypeToolResult:
+		if h.toolsInFlight.Add(-1) < 0 {
+			h.toolsInFlight.Store(0)
+		}
+	}
+}
+
+// Check evaluates liveness at now and returns the verdict with the time since the last event.
+func (h *heartbeatMonitor) Check(now time.Time) (heartbeatVerdict, time.Duration) {
+	age := now.Sub(time.Unix(0, h.lastEventAt.Load()))
+	if age <= h.timeout {
+		return heartbeatAlive, age
+	}
+
+	deadline := h.probeDeadline.Load()
+	if deadline == 0 {
+		if h.toolsInFlight.Load() > 0 {
+			h.probeDeadline.Store(now.Add(h.timeout).UnixNano())
+			return heartbeatProbing, age
+		}
+		h.stalled.Store(true)
+		return heartbeatStalled, age
+	}
+	if now.UnixNano() < deadline {
+		return heartbeatProbing, age
+	}
+	h.stalled.Store(true)
+	return heartbeatStalled, age
+}
+
+// Stalled reports whether the monitor declared the process hung.
+func (h *heartbeatMonitor) Stalled() bool {
+	return h.stalled.Load()
+}
+
+// Watch checks liveness every HeartbeatCheckInterval until ctx or done is closed.
+// On a stall it invokes callback (if set) and kills proc.
+func (h *heartbeatMonitor) Watch(ctx context.Context, done <-chan struct{}, proc *os.Process, log *slog.Logger, callback func(pid int, lastEventAge time.Duration)) {
+	ticker := time.NewTicker(HeartbeatCheckInterval)
+	defer ticker.Stop()
+
+	probeLogged := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case now := <-ticker.C:
+			verdict, age := h.Check(now)
+			switch verdict {
+			case heartbeatAlive:
+				probeLogged = false
+			case heartbeatProbing:
+				if !probeLogged {
+					log.Warn("No stream events while a tool call is running, extending heartbeat once",
+						slog.Int("pid", proc.Pid),
+						slog.Duration("last_event_age", age),
+						slog.Duration("timeout", h.timeout),
+					)
+					probeLogged = true
+				}
+			case heartbeatStalled:
+				log.Warn("Heartbeat timeout detected, killing hung process",
+					slog.Int("pid", proc.Pid),
+					slog.Duration("last_event_age", age),
+					slog.Duration("timeout", h.timeout),
+				)
+
+				if callback != nil {
+					callback(proc.Pid, age)
+				}
+
+				if err := proc.Kill(); err != nil {
+					log.Error("Failed to kill hung process",
+						slog.Int("pid", proc.Pid),
+						slog.Any("error", err),
+					)
+				} else {
+					log.Info("Hung process killed successfully",
+						slog.Int("pid", proc.Pid),
+					)
+				}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package executor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeartbeatMonitor_AliveWithinTimeout(t *testing.T) {
+	h := newHeartbeatMonitor(time.Minute)
+
+	verdict, _ := h.Check(time.Now().Add(30 * time.Second))
+	if verdict != heartbeatAlive {
+		t.Errorf("verdict = %v, want alive", verdict)
+	}
+	if h.Stalled() {
+		t.Error("monitor should not be stalled")
+	}
+}
+
+func TestHeartbeatMonitor_StallsWithoutToolInFlight(t *testing.T) {
+	h := newHeartbeatMonitor(time.Minute)
+	h.Observe(BackendEvent{Type: EventTypeText})
+
+	verdict, age := h.Check(time.Now().Add(2 * time.Minute))
+	if verdict != heartbeatStalled {
+		t.Errorf("verdict = %v, want stalled", verdict)
+	}
+	if age < 2*time.Minute-time.Second {
+		t.Errorf("age = %v, want ~2m", age)
+	}
+	if !h.Stalled() {
+		t.Error("Stalled() should report true")
+	}
+}
+
+func TestHeartbeatMonitor_ProbeExtendsForRunningTool(t *testing.T) {
+	h := newHeartbeatMonitor(time.Minute)
+	h.Observe(BackendEvent{Type: EventTypeToolUse, ToolName: "Bash"})
+	start := time.Now()
+
+	// First silent window: a tool is running, so probe instead of killing
+	if verdict, _ := h.Check(start.Add(90 * time.Second)); verdict != heartbeatProbing {
+		t.Fatalf("verdict = %v, want probing", verdict)
+	}
+	// Still inside the extra window
+	if verdict, _ := h.Check(start.Add(2 * time.Minute)); verdict != heartbeatProbing {
+		t.Fatalf("verdict = %v, want probing", verdict)
+	}
+	// Extra window exhausted
+	if verdict, _ := h.Check(start.Add(3 * time.Minute)); verdict != heartbeatStalled {
+		t.Fatalf("verdict = %v, want stalled", verdict)
+	}
+}
+
+func TestHeartbeatMonitor_EventResetsProbe(t *testing.T) {
+	h := newHeartbeatMonitor(time.Minute)
+	h.Observe(BackendEvent{Type: EventTypeToolUse, ToolName: "Bash"})
+
+	if verdict, _ := h.Check(time.Now().Add(90 * time.Second)); verdict != heartbeatProbing {
+		t.Fatalf("verdict = %v, want probing", verdict)
+	}
+
+	// Tool returns: the clock and the probe reset, and nothing is in flight anymore
+	h.Observe(BackendEvent{Type: EventTypeToolResult})
+	if verdict, _ := h.Check(time.Now().Add(30 * time.Second)); verdict != heartbeatAlive {
+		t.Fatalf("verdict = %v, want alive", verdict)
+	}
+	if verdict, _ := h.Check(time.Now().Add(2 * time.Minute)); verdict != heartbeatStalled {
+		t.Fatalf("verdict = %v, want stalled once no tool is running", verdict)
+	}
+}
+
+func TestHeartbeatMonitor_UnmatchedToolResult(t *testing.T) {
+	h := newHeartbeatMonitor(time.Minute)
+	h.Observe(BackendEvent{Type: EventTypeToolResult})
+
+	if got := h.toolsInFlight.Load(); got != 0 {
+		t.Errorf("toolsInFlight = %d, want 0", got)
+	}
+}
+
+type stallAlertRecorder struct {
+	events []AlertEvent
+}
+
+func (r *stallAlertRecorder) ProcessEvent(event AlertEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestRunner_HeartbeatCallbackEmitsAlert(t *testing.T) {
+	runner := NewRunner()
+	recorder := &stallAlertRecorder{}
+	runner.SetAlertProcessor(recorder)
+
+	task := &Task{ID: "TASK-1", Title: "Stuck task", ProjectPath: "/repo"}
+	runner.heartbeatCallback(task, 1)(4242, 5*time.Minute)
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("expected 1 alert event, got %d", len(recorder.events))
+	}
+	ev := recorder.events[0]
+	if ev.Type != AlertEventTypeHeartbeatTimeout {
+		t.Errorf("type = %s, want %s", ev.Type, AlertEventTypeHeartbeatTimeout)
+	}
+	if ev.Project != "/repo" || ev.TaskID != "TASK-1" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+	if ev.Metadata["pid"] != "4242" || ev.Metadata["attempt"] != "1" {
+		t.Errorf("unexpected metadata: %v", ev.Metadata)
+	}
+}
+
+func TestRetrier_Evaluate_HeartbeatTimeout(t *testing.T) {
+	retrier := NewRetrier(&RetryConfig{
+		Enabled: true,
+		HeartbeatTimeout: &RetryStrategy{
+			MaxAttempts:       1,
+			InitialBackoff:    10 * time.Second,
+			BackoffMultiplier: 2.0,
+		},
+	})
+	err := &ClaudeCodeError{Type: ErrorTypeHeartbeatTimeout, Message: "stalled"}
+
+	decision := retrier.Evaluate(err, 0, 10*time.Minute)
+	if !decision.ShouldRetry {
+		t.Fatal("expected restart after first stall")
+	}
+	if decision.BackoffDuration != 10*time.Second {
+		t.Errorf("backoff = %v, want 10s", decision.BackoffDuration)
+	}
+	if decision.ExtendedTimeout != 0 {
+		t.Errorf("stall restarts should not extend the timeout, got %v", decision.ExtendedTimeout)
+	}
+
+	if retrier.Evaluate(err, 1, 10*time.Minute).ShouldRetry {
+		t.Error("expected no restart after max attempts")
+	}
+}
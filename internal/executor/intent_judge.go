@@ -22,6 +22,19 @@ type JudgeVerdict struct {
 	Confidence float64
 }
 
+// PassConfidence returns the judge's confidence that the diff matches the
+// issue: Confidence for a pass, its complement for a veto. Returns 0 when
+// the judge gave no confidence.
+func (v *JudgeVerdict) PassConfidence() float64 {
+	if v.Confidence <= 0 {
+		return 0
+	}
+	if v.Passed {
+		return v.Confidence
+	}
+	return 1 - v.Confidence
+}
+
 // IntentJudge compares git diffs against the original issue to catch scope creep,
 // missing requirements, and unrelated changes. Uses Claude Haiku for fast, cheap evaluation.
 // Industry research (Spotify) shows this catches ~25% of PRs that would ship wrong code.
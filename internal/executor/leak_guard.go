@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// SecretLeakErrorPrefix prefixes ExecutionResult.Error when the leak guard
+// stops a push because the diff adds credentials.
+const SecretLeakErrorPrefix = "secret detected"
+
+// pushDiffBase returns the ref the task's commits are diffed against before
+// they are pushed: the task's base branch, the repo's default branch, or
+// main. Direct commits compare against the remote branch they are pushed to.
+func pushDiffBase(ctx context.Context, git *GitOperations, task *Task) string {
+	base := task.BaseBranch
+	if base == "" {
+		base, _ = git.GetDefaultBranch(ctx)
+		if base == "" {
+			base = "main"
+		}
+	}
+	if task.DirectCommit {
+		base = "origin/" + base
+	}
+	return base
+}
+
+// checkPushLeaks scans the commits between base and HEAD for credentials
+// and must run right before every push. It returns an error prefixed with
+// SecretLeakErrorPrefix when the diff adds secrets, or cannot be read, and
+// leak_guard.block_push is on. Otherwise findings are only logged.
+func (r *Runner) checkPushLeaks(ctx context.Context, git *GitOperations, taskID, base string) error {
+	if r.leakGuard == nil {
+		return nil
+	}
+	diff, err := git.GetDiff(ctx, base)
+	if err != nil {
+		if r.leakGuard.BlocksPush() {
+			return fmt.Errorf("%s: diff against %s could not be scanned: %w", SecretLeakErrorPrefix, base, err)
+		}
+		r.log.Warn("Failed to read diff, pushing without a secret leak scan (leak_guard.block_push is off)",
+			slog.String("task_id", taskID),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	var leaks []string
+	for _, f := range r.leakGuard.ScanDiff(diff) {
+		leaks = append(leaks, f.Location()+": "+f.Message)
+	}
+	if len(leaks) == 0 {
+		return nil
+	}
+	if r.leakGuard.BlocksPush() {
+		r.log.Warn("Push blocked by leak guard",
+			slog.String("task_id", taskID),
+			slog.Int("secrets", len(leaks)),
+		)
+		return fmt.Errorf("%s: %s", SecretLeakErrorPrefix, strings.Join(leaks, "; "))
+	}
+	r.log.Warn("Diff adds possible secrets, pushing anyway (leak_guard.block_push is off)",
+		slog.String("task_id", taskID),
+		slog.Any("secrets", leaks),
+	)
+	return nil
+}
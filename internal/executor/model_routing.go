@@ -16,6 +16,8 @@ type ModelRouter struct {
 	effortConfig     *EffortRoutingConfig
 	effortClassifier *EffortClassifier          // LLM-based effort classifier (GH-727)
 	outcomeTracker   *memory.ModelOutcomeTracker // Outcome-based escalation (GH-1991)
+	durationHistory  DurationHistory             // Historical durations for adaptive timeouts
+	budgetState      BudgetState                 // Daily spend for budget-aware routing
 }
 
 // NewModelRouter creates a new ModelRouter with the given configuration.
@@ -99,6 +101,8 @@ func (r *ModelRouter) resolveComplexity(task *Task) Complexity {
 // SelectModel returns the appropriate model name for a task based on its complexity.
 // If model routing is disabled, returns empty string (use backend default).
 // When an outcome tracker is set, checks failure rates and escalates if needed (GH-1991).
+// Under budget pressure the task runs on the next cheaper tier's model and
+// is never escalated.
 func (r *ModelRouter) SelectModel(task *Task) string {
 	if r.modelConfig == nil || !r.modelConfig.Enabled {
 		return ""
@@ -107,6 +111,18 @@ func (r *ModelRouter) SelectModel(task *Task) string {
 	complexity := r.resolveComplexity(task)
 	model := r.GetModelForComplexity(complexity)
 
+	if route := r.BudgetRoute(task); route.Downgrade {
+		cheaper := r.GetModelForComplexity(cheaperComplexity(complexity))
+		slog.Info("Model downgraded for budget",
+			slog.String("task_id", task.ID),
+			slog.String("complexity", string(complexity)),
+			slog.String("original_model", model),
+			slog.String("routed_model", cheaper),
+			slog.Float64("spend_percent", route.SpendPercent),
+		)
+		return cheaper
+	}
+
 	// GH-1991: Check outcome tracker for escalation
 	if r.outcomeTracker != nil && model != "" {
 		taskType := string(complexity)
@@ -144,12 +160,51 @@ func (r *ModelRouter) GetModelForComplexity(complexity Complexity) string {
 	}
 }
 
+// defaultReviewModel is the model for PR code review when none is configured
+const defaultReviewModel = "claude-haiku-4-5-20251001"
+
+// ReviewModel returns the model for reviewing PRs. Review is a cheap second
+// opinion, so it defaults to Haiku rather than the task's model.
+func (r *ModelRouter) ReviewModel() string {
+	if r.modelConfig != nil && r.modelConfig.Review != "" {
+		return r.modelConfig.Review
+	}
+	return defaultReviewModel
+}
+
 // SelectTimeout returns the appropriate timeout duration for a task based on its complexity.
+// When adaptive timeouts are enabled and enough history exists for the task's project
+// and complexity class, the timeout is derived from past durations instead.
 func (r *ModelRouter) SelectTimeout(task *Task) time.Duration {
+	if timeout, ok := r.adaptiveTimeout(task); ok {
+		return timeout
+	}
 	complexity := r.resolveComplexity(task)
 	return r.GetTimeoutForComplexity(complexity)
 }
 
+// adaptiveTimeout looks up historical durations for the task's project and
+// heuristic complexity. Returns false when adaptive mode is off or history is too thin.
+func (r *ModelRouter) adaptiveTimeout(task *Task) (time.Duration, bool) {
+	if r.durationHistory == nil || r.timeoutConfig == nil {
+		return 0, false
+	}
+	cfg := r.timeoutConfig.Adaptive
+	if cfg == nil || !cfg.Enabled {
+		return 0, false
+	}
+
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultAdaptiveTimeoutConfig().Window
+	}
+	samples, err := r.durationHistory.GetTaskDurations(task.ProjectPath, string(DetectComplexity(task)), window)
+	if err != nil {
+		return 0, false
+	}
+	return cfg.adaptiveTimeout(samples)
+}
+
 // GetTimeoutForComplexity returns the timeout duration for a given complexity level.
 func (r *ModelRouter) GetTimeoutForComplexity(complexity Complexity) time.Duration {
 	if r.timeoutConfig == nil {
@@ -203,6 +258,11 @@ func (r *ModelRouter) SetOutcomeTracker(t *memory.ModelOutcomeTracker) {
 	r.outcomeTracker = t
 }
 
+// SetDurationHistory attaches the duration history used for adaptive timeouts.
+func (r *ModelRouter) SetDurationHistory(h DurationHistory) {
+	r.durationHistory = h
+}
+
 // SelectEffort returns the appropriate effort level for a task.
 // If an LLM classifier is attached and enabled, it tries LLM classification first.
 // Falls back to static complexity→effort mapping if LLM fails or is disabled.
@@ -200,6 +200,17 @@ func TestModelRouter_GetModelForComplexity(t *testing.T) {
 	}
 }
 
+func TestModelRouter_ReviewModel(t *testing.T) {
+	if got := NewModelRouter(nil, nil).ReviewModel(); got != "claude-haiku-4-5-20251001" {
+		t.Errorf("default ReviewModel() = %q", got)
+	}
+	// Review model applies even with routing disabled
+	router := NewModelRouter(&ModelRoutingConfig{Enabled: false, Review: "claude-sonnet-4-6"}, nil)
+	if got := router.ReviewModel(); got != "claude-sonnet-4-6" {
+		t.Errorf("ReviewModel() = %q, want configured model", got)
+	}
+}
+
 func TestModelRouter_SelectEffort(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -18,6 +18,7 @@ const (
 	StatusCompleted TaskStatus = "completed"
 	StatusFailed    TaskStatus = "failed"
 	StatusCancelled TaskStatus = "cancelled"
+	StatusBlocked   TaskStatus = "blocked"
 )
 
 // TaskState holds the current state of a task
@@ -148,6 +149,20 @@ func (m *Monitor) Cancel(taskID string) {
 	}
 }
 
+// Block marks a task as waiting for its requester to unblock it
+func (m *Monitor) Block(taskID, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if state, ok := m.tasks[taskID]; ok {
+		now := time.Now()
+		state.Status = StatusBlocked
+		state.CompletedAt = &now
+		state.Phase = PhaseBlocked
+		state.Error = reason
+	}
+}
+
 // Get returns the state of a task
 func (m *Monitor) Get(taskID string) (*TaskState, bool) {
 	m.mu.RLock()
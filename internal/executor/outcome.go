@@ -0,0 +1,159 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/quality"
+)
+
+// Execution statuses a task ends in
+const (
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+	OutcomeCancelled = "cancelled"
+	OutcomeBlocked   = "blocked"
+	OutcomeNeedsInfo = "needs_info"
+	OutcomeDeferred  = "deferred"
+)
+
+// Outcome is how a queued execution ended, in the form it is recorded in
+// the memory store. Remote workers send it to the cluster coordinator,
+// which records it like a task run by a local project worker.
+type Outcome struct {
+	Status     string `json:"status"`               // One of the Outcome* statuses
+	Error      string `json:"error,omitempty"`      // Failure, block or needs-info detail
+	SessionID  string `json:"session_id,omitempty"` // Session a blocked task resumes
+	PRUrl      string `json:"pr_url,omitempty"`
+	CommitSHA  string `json:"commit_sha,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+
+	// Set when the task ran
+	Metrics    *memory.ExecutionMetrics   `json:"metrics,omitempty"`
+	Coverage   *quality.CoverageDelta     `json:"coverage,omitempty"`
+	FlakyGates []OutcomeFlakyGate         `json:"flaky_gates,omitempty"`
+	Tokens     []*memory.TokenAttribution `json:"tokens,omitempty"`
+}
+
+// OutcomeFlakyGate is a quality gate that failed nondeterministically
+type OutcomeFlakyGate struct {
+	Gate        string `json:"gate"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// NewOutcome classifies the result of Runner.Execute
+func NewOutcome(result *ExecutionResult, execErr error, duration time.Duration) *Outcome {
+	o := &Outcome{DurationMs: duration.Milliseconds()}
+
+	var blocked *BlockedError
+	var needsInfo *NeedsInfoError
+	switch {
+	case errors.As(execErr, &needsInfo):
+		o.Status = OutcomeNeedsInfo
+		o.Error = needsInfo.Detail()
+	case errors.As(execErr, &blocked):
+		o.Status = OutcomeBlocked
+		o.Error = blocked.Reason
+		o.SessionID = blocked.SessionID
+	case errors.Is(execErr, ErrTaskDeferred):
+		o.Status = OutcomeDeferred
+		o.Error = execErr.Error()
+	case errors.Is(execErr, ErrTaskCancelled):
+		o.Status = OutcomeCancelled
+		if result != nil {
+			o.Error = result.Error
+		}
+	case execErr != nil:
+		o.Status = OutcomeFailed
+		o.Error = execErr.Error()
+	case !result.Success:
+		o.Status = OutcomeFailed
+		o.Error = result.Error
+	default:
+		o.Status = OutcomeCompleted
+		o.PRUrl = result.PRUrl
+		o.CommitSHA = result.CommitSHA
+	}
+
+	if result == nil {
+		return o
+	}
+	o.Metrics = &memory.ExecutionMetrics{
+		TokensInput:      result.TokensInput,
+		TokensOutput:     result.TokensOutput,
+		TokensTotal:      result.TokensTotal,
+		EstimatedCostUSD: result.EstimatedCostUSD,
+		FilesChanged:     result.FilesChanged,
+		LinesAdded:       result.LinesAdded,
+		LinesRemoved:     result.LinesRemoved,
+		ModelName:        result.ModelName,
+	}
+	o.Coverage = result.QualityGates.CoverageDelta()
+	for _, gate := range result.QualityGates.FlakyGates() {
+		o.FlakyGates = append(o.FlakyGates, OutcomeFlakyGate{Gate: gate.Label(), Fingerprint: gate.Fingerprint})
+	}
+	o.Tokens = result.TokenBreakdown.Attributions()
+	return o
+}
+
+// SaveOutcome records how exec ended: its status, result and the metrics
+// that must survive restarts (GH-533). Every write is attempted; the
+// returned error joins those that failed.
+func SaveOutcome(store *memory.Store, exec *memory.Execution, o *Outcome) error {
+	var errs []error
+	var err error
+	switch o.Status {
+	case OutcomeNeedsInfo:
+		err = store.MarkExecutionNeedsInfo(exec.ID, o.Error)
+	case OutcomeBlocked:
+		err = store.MarkExecutionBlocked(exec.ID, o.Error, o.SessionID)
+	case OutcomeCompleted:
+		err = store.UpdateExecutionStatus(exec.ID, o.Status)
+		// Result fields (PR URL, commit SHA, duration)
+		if resultErr := store.UpdateExecutionResult(exec.ID, o.PRUrl, o.CommitSHA, o.DurationMs); resultErr != nil {
+			errs = append(errs, fmt.Errorf("update execution result: %w", resultErr))
+		}
+	case OutcomeFailed, OutcomeCancelled, OutcomeDeferred:
+		err = store.UpdateExecutionStatus(exec.ID, o.Status, o.Error)
+	default:
+		err = fmt.Errorf("unknown outcome status %q", o.Status)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("update status to %s: %w", o.Status, err))
+	}
+
+	if o.Metrics != nil {
+		metrics := *o.Metrics
+		metrics.ExecutionID = exec.ID
+		if err := store.SaveExecutionMetrics(&metrics); err != nil {
+			errs = append(errs, fmt.Errorf("save execution metrics: %w", err))
+		}
+	}
+	if o.Coverage != nil {
+		if err := store.SaveCoverageDelta(exec.ID, o.Coverage.Base, o.Coverage.Head); err != nil {
+			errs = append(errs, fmt.Errorf("save coverage delta: %w", err))
+		}
+	}
+	if len(o.FlakyGates) > 0 {
+		runs := make([]*memory.FlakyGateRun, len(o.FlakyGates))
+		for i, gate := range o.FlakyGates {
+			runs[i] = &memory.FlakyGateRun{
+				ExecutionID: exec.ID,
+				ProjectPath: exec.ProjectPath,
+				Gate:        gate.Gate,
+				Fingerprint: gate.Fingerprint,
+			}
+		}
+		if err := store.SaveFlakyGates(runs); err != nil {
+			errs = append(errs, fmt.Errorf("save flaky gates: %w", err))
+		}
+	}
+	if len(o.Tokens) > 0 {
+		if err := store.SaveTokenAttribution(exec.ID, o.Tokens); err != nil {
+			errs = append(errs, fmt.Errorf("save token attribution: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestNewOutcome(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     *ExecutionResult
+		err        error
+		wantStatus string
+		wantError  string
+	}{
+		{
+			name:       "completed",
+			result:     &ExecutionResult{Success: true, PRUrl: "https://github.com/o/r/pull/1"},
+			wantStatus: OutcomeCompleted,
+		},
+		{
+			name:       "task failed",
+			result:     &ExecutionResult{Error: "tests failed"},
+			wantStatus: OutcomeFailed,
+			wantError:  "tests failed",
+		},
+		{
+			name:       "execution error",
+			err:        errors.New("backend crashed"),
+			wantStatus: OutcomeFailed,
+			wantError:  "backend crashed",
+		},
+		{
+			name:       "cancelled",
+			result:     &ExecutionResult{Error: "cancelled by user"},
+			err:        ErrTaskCancelled,
+			wantStatus: OutcomeCancelled,
+			wantError:  "cancelled by user",
+		},
+		{
+			name:       "deferred",
+			err:        fmt.Errorf("over budget: %w", ErrTaskDeferred),
+			wantStatus: OutcomeDeferred,
+			wantError:  "over budget: " + ErrTaskDeferred.Error(),
+		},
+		{
+			name:       "blocked",
+			err:        &BlockedError{Reason: "needs a token", SessionID: "sess-1"},
+			wantStatus: OutcomeBlocked,
+			wantError:  "needs a token",
+		},
+		{
+			name:       "needs info",
+			err:        &NeedsInfoError{Verdict: TriageVerdict("ambiguous"), Reason: "which API?"},
+			wantStatus: OutcomeNeedsInfo,
+			wantError:  "ambiguous: which API?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewOutcome(tt.result, tt.err, 2*time.Second)
+			if o.Status != tt.wantStatus || o.Error != tt.wantError {
+				t.Errorf("NewOutcome() = %s %q, want %s %q", o.Status, o.Error, tt.wantStatus, tt.wantError)
+			}
+			if o.DurationMs != 2000 {
+				t.Errorf("DurationMs = %d, want 2000", o.DurationMs)
+			}
+			if (o.Metrics != nil) != (tt.result != nil) {
+				t.Errorf("Metrics = %v, want them only when the task ran", o.Metrics)
+			}
+		})
+	}
+}
+
+func TestSaveOutcome(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	exec := &memory.Execution{ID: "exec-outcome", TaskID: "GH-1", ProjectPath: "/app", Status: "running"}
+	if err := store.SaveExecution(exec); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+
+	outcome := NewOutcome(&ExecutionResult{
+		Success:          true,
+		PRUrl:            "https://github.com/o/r/pull/7",
+		CommitSHA:        "abc123",
+		TokensInput:      100,
+		TokensOutput:     50,
+		TokensTotal:      150,
+		EstimatedCostUSD: 0.25,
+	}, nil, 3*time.Second)
+	if err := SaveOutcome(store, exec, outcome); err != nil {
+		t.Fatalf("SaveOutcome failed: %v", err)
+	}
+
+	got, err := store.GetExecution(exec.ID)
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if got.Status != "completed" || got.PRUrl != outcome.PRUrl || got.CommitSHA != "abc123" || got.DurationMs != 3000 {
+		t.Errorf("execution = %+v, want completed with the PR, commit and duration", got)
+	}
+	if got.TokensTotal != 150 || got.EstimatedCostUSD != 0.25 {
+		t.Errorf("metrics = %d tokens $%.2f, want 150 tokens $0.25", got.TokensTotal, got.EstimatedCostUSD)
+	}
+
+	if err := SaveOutcome(store, exec, &Outcome{Status: "exploded"}); err == nil {
+		t.Error("SaveOutcome accepted an unknown status")
+	}
+}
@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// pauseRefreshInterval is how often a paused pipeline re-reads its state, so
+// `pilot pause` and `pilot resume` from another process take effect
+const pauseRefreshInterval = 5 * time.Second
+
+// PauseStore persists the pipeline pause state. Satisfied by *memory.Store.
+type PauseStore interface {
+	GetPipelineState() (*memory.PipelineState, error)
+	SetPipelineState(state *memory.PipelineState) error
+}
+
+// PipelinePause stops pollers from dispatching new work while paused. Tasks
+// already running finish normally. The state is persisted so a restarted
+// daemon stays paused. All methods are safe on a nil *PipelinePause, which
+// is never paused.
+type PipelinePause struct {
+	store    PauseStore
+	log      *slog.Logger
+	now      func() time.Time
+	interval time.Duration // How often state is re-read from the store
+
+	mu      sync.Mutex
+	state   memory.PipelineState
+	checked time.Time // When state was last read from the store
+}
+
+// NewPipelinePause creates a pause gate backed by store. A nil store keeps
+// the state in memory only.
+func NewPipelinePause(store PauseStore) *PipelinePause {
+	p := &PipelinePause{
+		store:    store,
+		log:      logging.WithComponent("pause"),
+		now:      time.Now,
+		interval: pauseRefreshInterval,
+	}
+	p.refresh()
+	return p
+}
+
+// State returns the current pause state
+func (p *PipelinePause) State() memory.PipelineState {
+	if p == nil {
+		return memory.PipelineState{}
+	}
+	p.mu.Lock()
+	stale := p.store != nil && p.now().Sub(p.checked) >= p.interval
+	p.mu.Unlock()
+	if stale {
+		p.refresh()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state
+}
+
+// Paused reports whether new work is being held back
+func (p *PipelinePause) Paused() bool {
+	return p.State().Paused
+}
+
+// Pause holds back new work until Resume
+func (p *PipelinePause) Pause(reason string) error {
+	return p.set(memory.PipelineState{Paused: true, Reason: reason})
+}
+
+// Resume lets pollers dispatch new work again
+func (p *PipelinePause) Resume() error {
+	return p.set(memory.PipelineState{})
+}
+
+// Wait blocks while the pipeline is paused. Returns the context's error if
+// it is cancelled first.
+func (p *PipelinePause) Wait(ctx context.Context) error {
+	if !p.Paused() {
+		return nil
+	}
+	p.log.Info("Pipeline paused, holding new work")
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for p.Paused() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	p.log.Info("Pipeline resumed, dispatching held work")
+	return nil
+}
+
+func (p *PipelinePause) set(state memory.PipelineState) error {
+	if p == nil {
+		return nil
+	}
+	state.UpdatedAt = p.now()
+	if p.store != nil {
+		if err := p.store.SetPipelineState(&state); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	p.state = state
+	p.checked = state.UpdatedAt
+	p.mu.Unlock()
+
+	if state.Paused {
+		p.log.Info("Pipeline paused", slog.String("reason", state.Reason))
+	} else {
+		p.log.Info("Pipeline resumed")
+	}
+	return nil
+}
+
+// refresh re-reads the state from the store, keeping the last known state
+// when the read fails
+func (p *PipelinePause) refresh() {
+	if p.store == nil {
+		return
+	}
+	state, err := p.store.GetPipelineState()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checked = p.now()
+	if err != nil {
+		p.log.Warn("Failed to read pipeline state", slog.Any("error", err))
+		return
+	}
+	p.state = *state
+}
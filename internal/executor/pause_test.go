@@ -0,0 +1,86 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestPipelinePause_PersistsAcrossRestart(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	pause := NewPipelinePause(store)
+	if pause.Paused() {
+		t.Fatal("new pipeline should not be paused")
+	}
+	if err := pause.Pause("incident"); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+
+	restarted := NewPipelinePause(store)
+	if state := restarted.State(); !state.Paused || state.Reason != "incident" {
+		t.Errorf("state after restart = %+v, want paused for incident", state)
+	}
+	if err := restarted.Resume(); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if NewPipelinePause(store).Paused() {
+		t.Error("pipeline still paused after Resume")
+	}
+}
+
+func TestPipelinePause_WaitForResume(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	pause := NewPipelinePause(store)
+	pause.interval = time.Millisecond
+	if err := pause.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() on running pipeline = %v", err)
+	}
+	_ = pause.Pause("")
+
+	done := make(chan error, 1)
+	go func() { done <- pause.Wait(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Wait() returned %v while paused", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Resumed from another process, e.g. `pilot resume`
+	if err := NewPipelinePause(store).Resume(); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Wait() = %v after resume", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait() did not return after resume")
+	}
+
+	_ = pause.Pause("")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pause.Wait(ctx); err != context.Canceled {
+		t.Errorf("Wait() with cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestPipelinePause_Nil(t *testing.T) {
+	var pause *PipelinePause
+	if pause.Paused() || pause.Wait(context.Background()) != nil || pause.Pause("x") != nil {
+		t.Error("nil PipelinePause should never be paused")
+	}
+}
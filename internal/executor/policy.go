@@ -0,0 +1,355 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// PolicyErrorPrefix prefixes ExecutionResult.Error when the executor policy
+// aborts a task, so callers can tell it apart from other failures.
+const PolicyErrorPrefix = "policy violation"
+
+// PolicyConfig constrains what an execution may touch. Writes to denied
+// paths and forbidden commands cancel the execution as soon as the backend
+// attempts them; the changed files and diff size are checked again before
+// anything is pushed.
+//
+// Example YAML configuration:
+//
+//	executor:
+//	  policy:
+//	    enabled: true
+//	    deny_paths:
+//	      - .github/workflows/
+//	      - "**/*.pem"
+//	    forbidden_commands:
+//	      - terraform apply
+//	      - kubectl
+//	    max_diff_lines: 2000
+//	    projects:
+//	      /home/dev/infra:
+//	        allow_paths: [modules/, docs/]
+type PolicyConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Path globs applying to every project
+	PathPolicy `yaml:",inline"`
+
+	// ForbiddenCommands lists commands the backend must not run. An entry
+	// matches a shell command starting with its words, e.g. "terraform apply"
+	// matches "terraform apply -auto-approve" but not "terraform plan".
+	ForbiddenCommands []string `yaml:"forbidden_commands,omitempty"`
+
+	// MaxDiffLines caps the lines changed (added + removed) by a task.
+	// 0 means no limit.
+	MaxDiffLines int `yaml:"max_diff_lines,omitempty"`
+
+	// Projects maps a project path to path globs added for that project
+	Projects map[string]*PathPolicy `yaml:"projects,omitempty"`
+}
+
+// PathPolicy restricts the files a task may change. Entries are globs (a
+// leading "**/" matches any depth) or directories ending in "/".
+type PathPolicy struct {
+	// AllowPaths, when set, lists the only paths a task may change
+	AllowPaths []string `yaml:"allow_paths,omitempty"`
+
+	// DenyPaths lists paths a task must not change, even when allowed
+	DenyPaths []string `yaml:"deny_paths,omitempty"`
+}
+
+// Validate checks that limits are not negative and commands are not empty
+func (c *PolicyConfig) Validate() error {
+	if c.MaxDiffLines < 0 {
+		return fmt.Errorf("max_diff_lines must be >= 0, got %d", c.MaxDiffLines)
+	}
+	for _, cmd := range c.ForbiddenCommands {
+		if strings.TrimSpace(cmd) == "" {
+			return fmt.Errorf("forbidden_commands entries must not be empty")
+		}
+	}
+	return nil
+}
+
+// Policy is the policy in effect for one project
+type Policy struct {
+	allow        []string
+	deny         []string
+	scope        []string
+	commands     [][]string
+	maxDiffLines int
+}
+
+// For returns the policy for a project: the global deny paths plus the
+// project's, and the project's allow paths if it sets any, otherwise the
+// global ones. Returns nil when the policy is disabled.
+func (c *PolicyConfig) For(projectPath string) *Policy {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	p := &Policy{
+		allow:        c.AllowPaths,
+		deny:         c.DenyPaths,
+		maxDiffLines: c.MaxDiffLines,
+	}
+	for path, pp := range c.Projects {
+		if pp == nil || filepath.Clean(path) != filepath.Clean(projectPath) {
+			continue
+		}
+		if len(pp.AllowPaths) > 0 {
+			p.allow = pp.AllowPaths
+		}
+		p.deny = append(append([]string{}, p.deny...), pp.DenyPaths...)
+	}
+	for _, cmd := range c.ForbiddenCommands {
+		if words := strings.Fields(cmd); len(words) > 0 {
+			p.commands = append(p.commands, words)
+		}
+	}
+	return p
+}
+
+// WithScope returns the policy restricted to the scope of a monorepo
+// project: files outside every scope entry violate it, on top of the allow
+// and deny paths. A nil policy gains a scope-only policy; an empty scope
+// returns p unchanged.
+func (p *Policy) WithScope(scope []string) *Policy {
+	if len(scope) == 0 {
+		return p
+	}
+	scoped := &Policy{}
+	if p != nil {
+		*scoped = *p
+	}
+	scoped.scope = scope
+	return scoped
+}
+
+// CheckPath returns why changing file (relative to the project root)
+// violates the policy, or "" if it does not.
+func (p *Policy) CheckPath(file string) string {
+	if p == nil {
+		return ""
+	}
+	for _, pattern := range p.deny {
+		if matchProtectedPath(pattern, file) {
+			return fmt.Sprintf("%s matches deny_paths entry %q", file, pattern)
+		}
+	}
+	if len(p.scope) > 0 && !matchesAnyPath(p.scope, file) {
+		return fmt.Sprintf("%s is outside the project scope", file)
+	}
+	if len(p.allow) == 0 {
+		return ""
+	}
+	for _, pattern := range p.allow {
+		if matchProtectedPath(pattern, file) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s is outside allow_paths", file)
+}
+
+// CheckCommand returns why running a shell command line violates the
+// policy, or "" if it does not. Each command of a pipeline or list is
+// checked, ignoring leading variable assignments and sudo.
+func (p *Policy) CheckCommand(line string) string {
+	if p == nil || len(p.commands) == 0 {
+		return ""
+	}
+	for _, segment := range splitShellCommands(line) {
+		words := strings.Fields(segment)
+		for len(words) > 0 && (words[0] == "sudo" || isEnvAssignment(words[0])) {
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+		words[0] = filepath.Base(words[0])
+		for _, forbidden := range p.commands {
+			if hasWordPrefix(words, forbidden) {
+				return fmt.Sprintf("ran forbidden command %q", strings.Join(forbidden, " "))
+			}
+		}
+	}
+	return ""
+}
+
+// CheckDiff returns the violations of a task's changed files and total
+// changed lines.
+func (p *Policy) CheckDiff(files []string, linesChanged int) []string {
+	if p == nil {
+		return nil
+	}
+	var violations []string
+	for _, file := range files {
+		if v := p.CheckPath(file); v != "" {
+			violations = append(violations, v)
+		}
+	}
+	if p.maxDiffLines > 0 && linesChanged > p.maxDiffLines {
+		violations = append(violations, fmt.Sprintf("changed %d lines (max_diff_lines %d)", linesChanged, p.maxDiffLines))
+	}
+	return violations
+}
+
+// checkCommits checks the changes between base and HEAD, whatever made them,
+// against the policy. It returns an error prefixed with PolicyErrorPrefix on
+// violations, and when the diff cannot be inspected: a diff nobody checked
+// must not be pushed.
+func (p *Policy) checkCommits(ctx context.Context, git *GitOperations, base string) error {
+	if p == nil {
+		return nil
+	}
+	files, err := git.GetChangedFilesSince(ctx, base)
+	if err != nil {
+		return fmt.Errorf("%s: changed files could not be inspected: %w", PolicyErrorPrefix, err)
+	}
+	lines, err := git.DiffLineCount(ctx, base)
+	if err != nil {
+		return fmt.Errorf("%s: diff size could not be inspected: %w", PolicyErrorPrefix, err)
+	}
+	if violations := p.CheckDiff(files, lines); len(violations) > 0 {
+		return fmt.Errorf("%s: %s", PolicyErrorPrefix, strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// PolicyGuard checks the tool calls of a single execution against a policy.
+type PolicyGuard struct {
+	policy *Policy
+	root   string
+	reason string
+}
+
+// NewPolicyGuard creates a guard for an execution in root.
+// Returns nil if policy is nil.
+func NewPolicyGuard(policy *Policy, root string) *PolicyGuard {
+	if policy == nil {
+		return nil
+	}
+	return &PolicyGuard{policy: policy, root: root}
+}
+
+// RecordToolUse checks a tool call and returns a non-empty reason once it
+// violates the policy. Bash commands are checked against forbidden commands
+// and file writes inside the project against the path globs. A nil guard
+// never trips.
+func (g *PolicyGuard) RecordToolUse(toolName string, input map[string]interface{}) string {
+	if g == nil || g.reason != "" {
+		return g.Reason()
+	}
+
+	switch toolName {
+	case "Bash":
+		g.reason = g.policy.CheckCommand(stringInput(input, "command"))
+	case "Write", "Edit", "MultiEdit":
+		fp := stringInput(input, "file_path")
+		if fp == "" {
+			return ""
+		}
+		if filepath.IsAbs(fp) {
+			rel, err := filepath.Rel(g.root, fp)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				// Outside the project, e.g. scratch files in /tmp
+				return ""
+			}
+			fp = rel
+		}
+		if strings.HasPrefix(filepath.ToSlash(fp), ".agent/") {
+			return ""
+		}
+		g.reason = g.policy.CheckPath(filepath.ToSlash(fp))
+	}
+	return g.reason
+}
+
+// Reason returns why the guard tripped, or "" if it has not.
+func (g *PolicyGuard) Reason() string {
+	if g == nil {
+		return ""
+	}
+	return g.reason
+}
+
+// writePolicyInstructions tells the backend about the policy up front, so
+// it does not waste an execution on a change that will be rejected.
+func writePolicyInstructions(sb *strings.Builder, p *Policy) {
+	if p == nil || (len(p.allow) == 0 && len(p.deny) == 0 && len(p.scope) == 0 && len(p.commands) == 0) {
+		return
+	}
+	sb.WriteString("\n## Execution Policy\n\n")
+	sb.WriteString("Violating these rules aborts the task:\n")
+	if len(p.scope) > 0 {
+		sb.WriteString(fmt.Sprintf("- This project owns only: %s. Do NOT change files elsewhere in the repository\n", quotePatterns(p.scope)))
+	}
+	if len(p.allow) > 0 {
+		sb.WriteString(fmt.Sprintf("- Only change files matching: %s\n", quotePatterns(p.allow)))
+	}
+	if len(p.deny) > 0 {
+		sb.WriteString(fmt.Sprintf("- Do NOT change files matching: %s\n", quotePatterns(p.deny)))
+	}
+	if len(p.commands) > 0 {
+		var cmds []string
+		for _, c := range p.commands {
+			cmds = append(cmds, strings.Join(c, " "))
+		}
+		sb.WriteString(fmt.Sprintf("- Do NOT run: %s\n", quotePatterns(cmds)))
+	}
+}
+
+func quotePatterns(patterns []string) string {
+	quoted := make([]string, len(patterns))
+	for i, p := range patterns {
+		quoted[i] = "`" + p + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// splitShellCommands splits a command line on ;, &, | and newlines.
+// Quoting is not interpreted, so a quoted separator splits too; that only
+// makes the check stricter.
+func splitShellCommands(line string) []string {
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return r == ';' || r == '&' || r == '|' || r == '\n' || r == '(' || r == ')' || r == '`'
+	})
+}
+
+func isEnvAssignment(word string) bool {
+	name, _, ok := strings.Cut(word, "=")
+	return ok && name != "" && !strings.ContainsAny(name, "/-.")
+}
+
+func hasWordPrefix(words, prefix []string) bool {
+	if len(words) < len(prefix) {
+		return false
+	}
+	for i, w := range prefix {
+		if words[i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// policyFor returns the policy for a task: its project's policy, narrowed to
+// the task's monorepo scope
+func (c *BackendConfig) policyFor(task *Task) *Policy {
+	var p *Policy
+	if c != nil {
+		p = c.Policy.For(task.ProjectPath)
+	}
+	return p.WithScope(task.Scope)
+}
+
+// matchesAnyPath reports whether file matches one of the path globs
+func matchesAnyPath(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if matchProtectedPath(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testPolicyConfig() *PolicyConfig {
+	return &PolicyConfig{
+		Enabled:           true,
+		PathPolicy:        PathPolicy{DenyPaths: []string{".github/workflows/", "**/*.pem"}},
+		ForbiddenCommands: []string{"terraform apply", "kubectl"},
+		MaxDiffLines:      100,
+		Projects: map[string]*PathPolicy{
+			"/home/dev/infra/": {AllowPaths: []string{"modules/", "docs/*.md"}, DenyPaths: []string{"modules/prod/"}},
+		},
+	}
+}
+
+func TestPolicyConfig_For(t *testing.T) {
+	if p := (&PolicyConfig{}).For("/repo"); p != nil {
+		t.Error("disabled policy should be nil")
+	}
+	var nilConfig *PolicyConfig
+	if p := nilConfig.For("/repo"); p != nil || p.CheckPath("a.go") != "" || p.CheckCommand("kubectl get pods") != "" {
+		t.Error("nil policy should allow everything")
+	}
+
+	tests := []struct {
+		project string
+		file    string
+		want    string
+	}{
+		{"/repo", "internal/app.go", ""},
+		{"/repo", ".github/workflows/ci.yml", `.github/workflows/ci.yml matches deny_paths entry ".github/workflows/"`},
+		{"/repo", "certs/dev/key.pem", `certs/dev/key.pem matches deny_paths entry "**/*.pem"`},
+		{"/home/dev/infra", "modules/vpc/main.tf", ""},
+		{"/home/dev/infra", "docs/README.md", ""},
+		{"/home/dev/infra", "main.tf", "main.tf is outside allow_paths"},
+		{"/home/dev/infra", "modules/prod/main.tf", `modules/prod/main.tf matches deny_paths entry "modules/prod/"`},
+		{"/home/dev/infra", "modules/ca.pem", `modules/ca.pem matches deny_paths entry "**/*.pem"`},
+	}
+	cfg := testPolicyConfig()
+	for _, tt := range tests {
+		if got := cfg.For(tt.project).CheckPath(tt.file); got != tt.want {
+			t.Errorf("For(%s).CheckPath(%s) = %q, want %q", tt.project, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestPolicy_WithScope(t *testing.T) {
+	scope := []string{"services/billing/", "libs/money/*.go"}
+
+	// A scope alone restricts a task even when the policy is disabled
+	cfg := &BackendConfig{}
+	p := cfg.policyFor(&Task{ProjectPath: "/repo", Scope: scope})
+	if p == nil {
+		t.Fatal("scoped task got no policy")
+	}
+	if v := p.CheckPath("services/billing/invoice.go"); v != "" {
+		t.Errorf("in-scope file: %s", v)
+	}
+	if v := p.CheckPath("libs/money/round.go"); v != "" {
+		t.Errorf("in-scope glob: %s", v)
+	}
+	if v := p.CheckPath("services/search/index.go"); v != "services/search/index.go is outside the project scope" {
+		t.Errorf("out-of-scope file = %q", v)
+	}
+	if cfg.policyFor(&Task{ProjectPath: "/repo"}) != nil {
+		t.Error("unscoped task with disabled policy should have no policy")
+	}
+
+	// Scope narrows an enabled policy; deny paths still apply inside it
+	policy := testPolicyConfig().For("/repo").WithScope([]string{"services/billing/"})
+	if v := policy.CheckPath("services/billing/certs/key.pem"); !strings.Contains(v, "deny_paths") {
+		t.Errorf("denied file in scope = %q", v)
+	}
+	if v := policy.CheckCommand("kubectl apply -f x.yaml"); v == "" {
+		t.Error("forbidden commands dropped by WithScope")
+	}
+
+	var sb strings.Builder
+	writePolicyInstructions(&sb, policy)
+	if !strings.Contains(sb.String(), "This project owns only: `services/billing/`") {
+		t.Errorf("instructions missing scope:\n%s", sb.String())
+	}
+}
+
+func TestPolicy_CheckCommand(t *testing.T) {
+	p := testPolicyConfig().For("/repo")
+	tests := []struct {
+		command string
+		blocked bool
+	}{
+		{"terraform plan", false},
+		{"terraform apply -auto-approve", true},
+		{"cd infra && terraform apply", true},
+		{"TF_LOG=debug terraform apply", true},
+		{"sudo /usr/local/bin/kubectl delete ns prod", true},
+		{"echo $(kubectl get pods)", true},
+		{"go test ./... | tee out.txt", false},
+		{"grep -r kubectl docs/", false},
+	}
+	for _, tt := range tests {
+		if got := p.CheckCommand(tt.command); (got != "") != tt.blocked {
+			t.Errorf("CheckCommand(%q) = %q, blocked want %v", tt.command, got, tt.blocked)
+		}
+	}
+}
+
+func TestPolicy_CheckDiff(t *testing.T) {
+	p := testPolicyConfig().For("/repo")
+	if v := p.CheckDiff([]string{"main.go"}, 100); len(v) != 0 {
+		t.Errorf("CheckDiff() = %v, want none", v)
+	}
+	v := p.CheckDiff([]string{"main.go", ".github/workflows/ci.yml"}, 101)
+	if len(v) != 2 || !strings.Contains(v[1], "changed 101 lines (max_diff_lines 100)") {
+		t.Errorf("CheckDiff() = %v", v)
+	}
+}
+
+func TestPolicy_CheckCommits(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(repoPath) }()
+
+	if out, err := exec.Command("git", "-C", repoPath, "checkout", "-b", "pilot/GH-1").CombinedOutput(); err != nil {
+		t.Fatalf("git checkout: %v\n%s", err, out)
+	}
+	if err := os.MkdirAll(filepath.Join(repoPath, ".github", "workflows"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, ".github", "workflows", "ci.yml"), []byte("on: push\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	git := NewGitOperations(repoPath)
+	if _, err := git.Commit(context.Background(), "Change CI"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	p := testPolicyConfig().For("/repo")
+	tests := []struct {
+		name    string
+		policy  *Policy
+		base    string
+		wantErr string
+	}{
+		{"violation", p, "main", ".github/workflows/ci.yml"},
+		{"uninspectable diff", p, "no-such-branch", "could not be inspected"},
+		{"no policy", nil, "no-such-branch", ""},
+		{"clean", p, "pilot/GH-1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.checkCommits(context.Background(), git, tt.base)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkCommits() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), PolicyErrorPrefix) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkCommits() = %v, want %q error", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyConfig_Validate(t *testing.T) {
+	if err := testPolicyConfig().Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	if err := (&PolicyConfig{MaxDiffLines: -1}).Validate(); err == nil {
+		t.Error("negative max_diff_lines should fail")
+	}
+	if err := (&PolicyConfig{ForbiddenCommands: []string{" "}}).Validate(); err == nil {
+		t.Error("empty forbidden command should fail")
+	}
+}
+
+func TestProcessBackendEvent_PolicyCancels(t *testing.T) {
+	runner := NewRunner()
+
+	tests := []struct {
+		name  string
+		event BackendEvent
+		want  string
+	}{
+		{
+			name:  "write outside project ignored",
+			event: BackendEvent{Type: EventTypeToolUse, ToolName: "Write", ToolInput: map[string]interface{}{"file_path": "/tmp/scratch.pem"}},
+		},
+		{
+			name:  "allowed write",
+			event: BackendEvent{Type: EventTypeToolUse, ToolName: "Edit", ToolInput: map[string]interface{}{"file_path": "/repo/main.go"}},
+		},
+		{
+			name:  "denied write",
+			event: BackendEvent{Type: EventTypeToolUse, ToolName: "Write", ToolInput: map[string]interface{}{"file_path": "/repo/.github/workflows/ci.yml"}},
+			want:  "matches deny_paths",
+		},
+		{
+			name:  "forbidden command",
+			event: BackendEvent{Type: EventTypeToolUse, ToolName: "Bash", ToolInput: map[string]interface{}{"command": "kubectl apply -f deploy.yaml"}},
+			want:  `ran forbidden command "kubectl"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancelCalled := false
+			state := &progressState{
+				phase:        "Starting",
+				budgetCancel: func() { cancelCalled = true },
+				policy:       NewPolicyGuard(testPolicyConfig().For("/repo"), "/repo"),
+			}
+			runner.processBackendEvent("TASK-1", tt.event, state)
+			if tripped := tt.want != ""; state.policyViolated != tripped || cancelCalled != tripped {
+				t.Fatalf("policyViolated = %v, cancelled = %v, want %v", state.policyViolated, cancelCalled, tripped)
+			}
+			if !strings.Contains(state.policy.Reason(), tt.want) {
+				t.Errorf("reason = %q, want %q", state.policy.Reason(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWritePolicyInstructions(t *testing.T) {
+	var sb strings.Builder
+	writePolicyInstructions(&sb, testPolicyConfig().For("/home/dev/infra"))
+	got := sb.String()
+	for _, want := range []string{"## Execution Policy", "Only change files matching: `modules/`, `docs/*.md`", "`modules/prod/`", "Do NOT run: `terraform apply`, `kubectl`"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("instructions missing %q:\n%s", want, got)
+		}
+	}
+
+	sb.Reset()
+	writePolicyInstructions(&sb, (&PolicyConfig{Enabled: true, MaxDiffLines: 10}).For("/repo"))
+	if sb.Len() != 0 {
+		t.Errorf("diff-only policy should add no instructions, got %q", sb.String())
+	}
+}
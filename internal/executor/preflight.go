@@ -40,7 +40,7 @@ type PreflightOptions struct {
 	// is enabled, as the worktree is always clean (created from a commit).
 	SkipGitClean bool
 
-	// BackendType specifies the configured backend ("claude-code", "opencode", "qwen-code").
+	// BackendType specifies the configured backend ("claude-code", "opencode", "qwen-code", "openai", "gemini", "ollama").
 	// When set, the CLI availability check matches the active backend instead of
 	// always requiring 'claude'.
 	BackendType string
@@ -134,6 +134,7 @@ func checkClaudeAvailable(ctx context.Context, _ string) error {
 }
 
 // backendCLICommands maps backend type to the CLI command and version flag.
+// Ollama is not listed: it is reached over HTTP and the server may be remote.
 var backendCLICommands = map[string]struct {
 	command     string
 	versionFlag string
@@ -141,6 +142,8 @@ var backendCLICommands = map[string]struct {
 	"claude-code": {command: "claude", versionFlag: "--version"},
 	"opencode":    {command: "opencode", versionFlag: "version"},
 	"qwen-code":   {command: "qwen", versionFlag: "--version"},
+	"openai":      {command: "codex", versionFlag: "--version"},
+	"gemini":      {command: "gemini", versionFlag: "--version"},
 }
 
 // checkBackendCLI verifies the CLI for the given backend type is available.
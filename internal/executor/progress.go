@@ -467,7 +467,7 @@ func (p *ProgressDisplay) printExecutionReport(report *ExecutionReport, allFiles
 
 			// Format: "  ✅ build     12s"
 			durationStr := gate.Duration.Round(time.Second).String()
-			fmt.Printf("  %s %-10s %s", icon, statusStyle.Render(gate.Name), dimStyle.Render(durationStr))
+			fmt.Printf("  %s %-10s %s", icon, statusStyle.Render(gate.Label()), dimStyle.Render(durationStr))
 
 			// Add retry count if any
 			if gate.RetryCount > 0 {
@@ -0,0 +1,107 @@
+package executor
+
+// ProjectFeatures toggles Pilot capabilities for a single project.
+//
+// Flags can be set in the project entry of the daemon config and in a
+// .pilot.yaml at the repository root. The flags only ever narrow what the
+// global config allows: a capability is used when it is enabled globally and
+// neither source disables it.
+//
+// Example .pilot.yaml:
+//
+//	allow_decompose: false
+//	allow_direct_commit: false
+//	allow_release: false
+//	allow_epics: true
+//	max_pr_size: 400
+type ProjectFeatures struct {
+	// AllowDecompose permits splitting complex tasks into subtasks. Default: true
+	AllowDecompose *bool `yaml:"allow_decompose,omitempty"`
+
+	// AllowDirectCommit permits pushing straight to the default branch. Default: true
+	AllowDirectCommit *bool `yaml:"allow_direct_commit,omitempty"`
+
+	// AllowRelease permits autopilot to tag releases after merge. Default: true
+	AllowRelease *bool `yaml:"allow_release,omitempty"`
+
+	// AllowEpics permits epic planning and sub-issue creation. Default: true
+	AllowEpics *bool `yaml:"allow_epics,omitempty"`
+
+	// MaxPRSize caps the lines changed (added + removed) in a Pilot PR.
+	// Larger changes fail instead of opening a PR. 0 means no limit.
+	MaxPRSize int `yaml:"max_pr_size,omitempty"`
+}
+
+// DecomposeAllowed reports whether task decomposition is permitted.
+func (f *ProjectFeatures) DecomposeAllowed() bool {
+	return f == nil || f.AllowDecompose == nil || *f.AllowDecompose
+}
+
+// DirectCommitAllowed reports whether direct commits are permitted.
+func (f *ProjectFeatures) DirectCommitAllowed() bool {
+	return f == nil || f.AllowDirectCommit == nil || *f.AllowDirectCommit
+}
+
+// ReleaseAllowed reports whether autopilot releases are permitted.
+func (f *ProjectFeatures) ReleaseAllowed() bool {
+	return f == nil || f.AllowRelease == nil || *f.AllowRelease
+}
+
+// EpicsAllowed reports whether epic planning is permitted.
+func (f *ProjectFeatures) EpicsAllowed() bool {
+	return f == nil || f.AllowEpics == nil || *f.AllowEpics
+}
+
+// PRSizeLimit returns the maximum PR size in changed lines, or 0 for no limit.
+func (f *ProjectFeatures) PRSizeLimit() int {
+	if f == nil || f.MaxPRSize < 0 {
+		return 0
+	}
+	return f.MaxPRSize
+}
+
+// Merge combines two feature sets, keeping the most restrictive value of
+// each flag. Either side may be nil.
+func (f *ProjectFeatures) Merge(other *ProjectFeatures) *ProjectFeatures {
+	if f == nil {
+		return other
+	}
+	if other == nil {
+		return f
+	}
+
+	restrict := func(a, b *bool) *bool {
+		if a == nil {
+			return b
+		}
+		if b == nil || *a == *b {
+			return a
+		}
+		denied := false
+		return &denied
+	}
+
+	merged := &ProjectFeatures{
+		AllowDecompose:    restrict(f.AllowDecompose, other.AllowDecompose),
+		AllowDirectCommit: restrict(f.AllowDirectCommit, other.AllowDirectCommit),
+		AllowRelease:      restrict(f.AllowRelease, other.AllowRelease),
+		AllowEpics:        restrict(f.AllowEpics, other.AllowEpics),
+		MaxPRSize:         f.PRSizeLimit(),
+	}
+	if limit := other.PRSizeLimit(); limit > 0 && (merged.MaxPRSize == 0 || limit < merged.MaxPRSize) {
+		merged.MaxPRSize = limit
+	}
+	return merged
+}
+
+// ProjectFeaturesResolver returns the configured feature flags for the project
+// at projectPath, or nil when none are configured.
+type ProjectFeaturesResolver func(projectPath string) *ProjectFeatures
+
+// ResolveProjectFeatures merges the daemon config's flags for a project with
+// the repository's .pilot.yaml. An unreadable .pilot.yaml is returned as an
+// error alongside the configured flags so callers can log it and continue.
+func ResolveProjectFeatures(configured *ProjectFeatures, projectPath string) (*ProjectFeatures, error) {
+	repo, err := LoadRepoConfig(projectPath)
+	return configured.Merge(repo.Features()), err
+}
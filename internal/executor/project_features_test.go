@@ -0,0 +1,110 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectFeatures_NilAllowsEverything(t *testing.T) {
+	var f *ProjectFeatures
+	if !f.DecomposeAllowed() || !f.DirectCommitAllowed() || !f.ReleaseAllowed() || !f.EpicsAllowed() {
+		t.Error("nil features should allow every capability")
+	}
+	if f.PRSizeLimit() != 0 {
+		t.Errorf("PRSizeLimit() = %d, want 0", f.PRSizeLimit())
+	}
+}
+
+func TestProjectFeatures_Merge(t *testing.T) {
+	configured := &ProjectFeatures{
+		AllowDecompose: boolPtr(true),
+		AllowRelease:   boolPtr(false),
+		MaxPRSize:      500,
+	}
+	repo := &ProjectFeatures{
+		AllowDecompose: boolPtr(false),
+		AllowRelease:   boolPtr(true),
+		AllowEpics:     boolPtr(false),
+		MaxPRSize:      200,
+	}
+
+	merged := configured.Merge(repo)
+	if merged.DecomposeAllowed() {
+		t.Error("repo should be able to disable decompose")
+	}
+	if merged.ReleaseAllowed() {
+		t.Error("repo should not be able to re-enable release disabled in config")
+	}
+	if merged.EpicsAllowed() {
+		t.Error("epics disabled by repo only should stay disabled")
+	}
+	if !merged.DirectCommitAllowed() {
+		t.Error("unset direct commit should stay allowed")
+	}
+	if merged.PRSizeLimit() != 200 {
+		t.Errorf("PRSizeLimit() = %d, want 200", merged.PRSizeLimit())
+	}
+
+	if got := (&ProjectFeatures{MaxPRSize: 300}).Merge(&ProjectFeatures{}); got.PRSizeLimit() != 300 {
+		t.Errorf("unset repo limit should keep configured limit, got %d", got.PRSizeLimit())
+	}
+	if got := (*ProjectFeatures)(nil).Merge(repo); got != repo {
+		t.Error("nil receiver should return other")
+	}
+}
+
+func TestResolveProjectFeatures(t *testing.T) {
+	dir := t.TempDir()
+
+	content := "allow_direct_commit: false\nmax_pr_size: 400\n"
+	if err := os.WriteFile(filepath.Join(dir, RepoConfigFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := ResolveProjectFeatures(&ProjectFeatures{MaxPRSize: 600}, dir)
+	if err != nil {
+		t.Fatalf("ResolveProjectFeatures: %v", err)
+	}
+	if f.DirectCommitAllowed() {
+		t.Error("allow_direct_commit: false not applied")
+	}
+	if f.PRSizeLimit() != 400 {
+		t.Errorf("PRSizeLimit() = %d, want 400", f.PRSizeLimit())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, RepoConfigFile), []byte("max_pr_size: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configured := &ProjectFeatures{AllowRelease: boolPtr(false)}
+	resolved, err := ResolveProjectFeatures(configured, dir)
+	if err == nil {
+		t.Error("expected parse error for invalid .pilot.yaml")
+	}
+	if resolved != configured {
+		t.Error("invalid .pilot.yaml should fall back to configured features")
+	}
+}
+
+func TestRunner_ProjectFeaturesFor(t *testing.T) {
+	dir := t.TempDir()
+	repo := &RepoConfig{ProjectFeatures: ProjectFeatures{AllowEpics: boolPtr(false)}}
+
+	runner := NewRunner()
+	if f := runner.projectFeaturesFor(dir, repo); f.EpicsAllowed() {
+		t.Error(".pilot.yaml should apply without a resolver")
+	}
+
+	runner.SetProjectFeaturesResolver(func(path string) *ProjectFeatures {
+		if path != dir {
+			t.Errorf("resolver called with %q, want %q", path, dir)
+		}
+		return &ProjectFeatures{AllowDecompose: boolPtr(false)}
+	})
+	if !runner.HasProjectFeaturesResolver() {
+		t.Error("HasProjectFeaturesResolver() = false after set")
+	}
+	f := runner.projectFeaturesFor(dir, repo)
+	if f.DecomposeAllowed() || f.EpicsAllowed() {
+		t.Errorf("features = %+v, want decompose and epics disabled", f)
+	}
+}
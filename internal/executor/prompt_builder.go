@@ -27,6 +27,18 @@ func (r *Runner) BuildPrompt(task *Task, executionPath string) string {
 	// ignoring --local flag entirely.
 	if task.LocalMode {
 		prompt := r.buildLocalModePrompt(task)
+		if repoCfg := r.loadRepoConfig(executionPath); repoCfg != nil {
+			var sb strings.Builder
+			sb.WriteString(prompt)
+			writeRepoInstructions(&sb, repoCfg)
+			prompt = sb.String()
+		}
+		if policy := r.config.policyFor(task); policy != nil {
+			var sb strings.Builder
+			sb.WriteString(prompt)
+			writePolicyInstructions(&sb, policy)
+			prompt = sb.String()
+		}
 		// GH-2147: Inject learned patterns (keep prompt lean)
 		if r.patternContext != nil {
 			injected, err := r.patternContext.InjectPatterns(
@@ -112,6 +124,9 @@ func (r *Runner) BuildPrompt(task *Task, executionPath string) string {
 			sb.WriteString("\n")
 		}
 
+		// Include history of failed attempts so retries don't repeat them
+		writePriorAttempts(&sb, task.PriorAttempts)
+
 		if task.Branch != "" {
 			sb.WriteString(fmt.Sprintf("Create branch `%s` before starting.\n\n", task.Branch))
 		}
@@ -212,6 +227,9 @@ func (r *Runner) BuildPrompt(task *Task, executionPath string) string {
 			sb.WriteString("\n")
 		}
 
+		// Include history of failed attempts so retries don't repeat them
+		writePriorAttempts(&sb, task.PriorAttempts)
+
 		sb.WriteString("## Instructions\n\n")
 		sb.WriteString("This is a trivial change. Execute quickly without Navigator workflow.\n\n")
 
@@ -239,6 +257,9 @@ func (r *Runner) BuildPrompt(task *Task, executionPath string) string {
 			sb.WriteString("\n")
 		}
 
+		// Include history of failed attempts so retries don't repeat them
+		writePriorAttempts(&sb, task.PriorAttempts)
+
 		sb.WriteString("## Constraints\n\n")
 		sb.WriteString("- ONLY create files explicitly mentioned in the task\n")
 		sb.WriteString("- Do NOT create additional files, tests, configs, or dependencies\n")
@@ -261,6 +282,10 @@ func (r *Runner) BuildPrompt(task *Task, executionPath string) string {
 		sb.WriteString("\nWork autonomously. Do not ask for confirmation.\n")
 	}
 
+	// Repo-specific instructions and protected paths from .pilot.yaml
+	writeRepoInstructions(&sb, r.loadRepoConfig(executionPath))
+	writePolicyInstructions(&sb, r.config.policyFor(task))
+
 	// GH-997: Inject re-anchor prompt if drift detected
 	if r.driftDetector != nil && r.driftDetector.ShouldReanchor() {
 		sb.WriteString(r.driftDetector.GetReanchorPrompt())
@@ -282,6 +307,20 @@ func (r *Runner) BuildPrompt(task *Task, executionPath string) string {
 	return prompt
 }
 
+// writePriorAttempts appends the "Previous Attempts" section when earlier
+// attempts on the same issue failed.
+func writePriorAttempts(sb *strings.Builder, attempts []string) {
+	if len(attempts) == 0 {
+		return
+	}
+	sb.WriteString("## Previous Attempts\n\n")
+	sb.WriteString("This issue has been attempted before and failed. Do NOT repeat the same approach:\n")
+	for i, attempt := range attempts {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, attempt))
+	}
+	sb.WriteString("\n")
+}
+
 // buildLocalModePrompt constructs a problem-solving prompt for local execution (GH-2103).
 // It skips Navigator workflow, PR constraints, and project context injection.
 // Designed for `pilot task --local` where the goal is direct problem-solving.
@@ -303,6 +342,8 @@ func (r *Runner) buildLocalModePrompt(task *Task) string {
 		sb.WriteString("\n")
 	}
 
+	writePriorAttempts(&sb, task.PriorAttempts)
+
 	sb.WriteString("## Instructions\n\n")
 
 	// Test-first instruction when task mentions test files
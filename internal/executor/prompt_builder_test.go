@@ -864,4 +864,41 @@ func TestBuildPromptNoNavigator(t *testing.T) {
 	if !strings.Contains(prompt, "Regular development task") {
 		t.Error("Should contain task description")
 	}
-}
\ No newline at end of file
+}
+func TestBuildPromptWithPriorAttempts(t *testing.T) {
+	tempDir := t.TempDir()
+	runner := NewRunner()
+
+	task := &Task{
+		ID:          "GH-42",
+		Title:       "Fix flaky login test",
+		Description: "Login test fails intermittently",
+		ProjectPath: tempDir,
+		PriorAttempts: []string{
+			"2026-01-02 10:00: quality gate failed: go test ./internal/auth",
+			"2026-01-03 11:30: completed without making any changes (no commits or PR)",
+		},
+	}
+
+	prompt := runner.BuildPrompt(task, tempDir)
+	if !strings.Contains(prompt, "## Previous Attempts") {
+		t.Fatal("prompt should contain previous attempts section")
+	}
+	if !strings.Contains(prompt, "1. 2026-01-02 10:00: quality gate failed") {
+		t.Error("prompt should list first prior attempt")
+	}
+	if !strings.Contains(prompt, "2. 2026-01-03 11:30: completed without making any changes") {
+		t.Error("prompt should list second prior attempt")
+	}
+
+	task.LocalMode = true
+	if prompt := runner.BuildPrompt(task, tempDir); !strings.Contains(prompt, "## Previous Attempts") {
+		t.Error("local mode prompt should contain previous attempts section")
+	}
+
+	task.LocalMode = false
+	task.PriorAttempts = nil
+	if prompt := runner.BuildPrompt(task, tempDir); strings.Contains(prompt, "## Previous Attempts") {
+		t.Error("prompt should not contain previous attempts section without history")
+	}
+}
@@ -2,7 +2,11 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
 // QualityGateDetail represents detailed information about a single gate check.
@@ -10,10 +14,16 @@ import (
 // without creating import cycles.
 type QualityGateDetail struct {
 	Name       string
+	Matrix     string // Matrix cell (toolchain version), empty for non-matrix gates
 	Passed     bool
 	Duration   time.Duration
 	RetryCount int
 	Error      string
+	Findings   []quality.Finding // Security scan findings, empty for other gates
+	// CoverageDelta is the coverage before and after the change, set by the coverage delta check only
+	CoverageDelta *quality.CoverageDelta
+	Fingerprint   string // Fingerprint of the last failure's normalized output
+	Flaky         bool   // Failed with differing output, or passed on retry
 }
 
 // QualityOutcome represents the result of quality gate checks.
@@ -29,6 +39,17 @@ type QualityOutcome struct {
 	TotalDuration time.Duration
 }
 
+// SecurityBlocked returns true if a failed gate reported security findings.
+// Such a branch must not be published, not even as a draft PR.
+func (o *QualityOutcome) SecurityBlocked() bool {
+	for _, gate := range o.GateDetails {
+		if !gate.Passed && len(gate.Findings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // QualityChecker is an interface for running quality gate checks.
 // This interface allows the executor to run quality gates without
 // importing the quality package directly, avoiding import cycles.
@@ -43,3 +64,63 @@ type QualityChecker interface {
 // The factory is typically implemented in main.go where both packages
 // can be imported.
 type QualityCheckerFactory func(taskID, projectPath string) QualityChecker
+
+// combinedQualityChecker runs several checkers in order and merges their
+// outcomes. Used when a repo's .pilot.yaml adds gates to the configured ones.
+type combinedQualityChecker []QualityChecker
+
+// Check runs every checker and passes only if all of them pass.
+func (c combinedQualityChecker) Check(ctx context.Context) (*QualityOutcome, error) {
+	combined := &QualityOutcome{Passed: true}
+	var feedback []string
+	for _, checker := range c {
+		outcome, err := checker.Check(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !outcome.Passed {
+			combined.Passed = false
+			combined.ShouldRetry = combined.ShouldRetry || outcome.ShouldRetry
+			if outcome.RetryFeedback != "" {
+				feedback = append(feedback, outcome.RetryFeedback)
+			}
+		}
+		combined.Attempt = max(combined.Attempt, outcome.Attempt)
+		combined.GateDetails = append(combined.GateDetails, outcome.GateDetails...)
+		combined.TotalDuration += outcome.TotalDuration
+	}
+	combined.RetryFeedback = strings.Join(feedback, "\n\n")
+	return combined, nil
+}
+
+// formatSecurityFindings renders the security scan findings of the quality
+// gates as a PR body section, or returns "" when there are none. Findings
+// that reach here are below the blocking severity.
+func formatSecurityFindings(gates *QualityGatesResult) string {
+	if gates == nil {
+		return ""
+	}
+	var findings []quality.Finding
+	for _, gate := range gates.Gates {
+		findings = append(findings, gate.Findings...)
+	}
+	if len(findings) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\n## Security Findings\n\n")
+	sb.WriteString(fmt.Sprintf("The security scan reported %d non-blocking finding(s):\n\n", len(findings)))
+	sb.WriteString("| Severity | Kind | Rule | Location | Details |\n")
+	sb.WriteString("|----------|------|------|----------|---------|\n")
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+	for _, f := range findings {
+		details := f.Message
+		if f.FixedIn != "" {
+			details += " (fixed in " + f.FixedIn + ")"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | `%s` | %s |\n",
+			f.Severity, f.Kind, escape.Replace(f.Rule), f.Location(), escape.Replace(details)))
+	}
+	return sb.String()
+}
@@ -2,7 +2,10 @@ package executor
 
 import (
 	"context"
+	"strings"
 	"testing"
+
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
 // mockQualityChecker implements QualityChecker for testing
@@ -128,3 +131,61 @@ func TestQualityOutcomeFields(t *testing.T) {
 		t.Errorf("Expected Attempt to be 1, got %d", outcome.Attempt)
 	}
 }
+
+func TestCombinedQualityChecker(t *testing.T) {
+	checker := combinedQualityChecker{
+		&mockQualityChecker{outcome: &QualityOutcome{Passed: true, GateDetails: []QualityGateDetail{{Name: "build", Passed: true}}}},
+		&mockQualityChecker{outcome: &QualityOutcome{Passed: false, ShouldRetry: true, RetryFeedback: "tests failed", GateDetails: []QualityGateDetail{{Name: "test"}}}},
+	}
+
+	outcome, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Passed || !outcome.ShouldRetry {
+		t.Errorf("outcome = %+v, want failed with retry", outcome)
+	}
+	if outcome.RetryFeedback != "tests failed" || len(outcome.GateDetails) != 2 {
+		t.Errorf("outcome = %+v", outcome)
+	}
+}
+
+func TestFormatSecurityFindings(t *testing.T) {
+	if got := formatSecurityFindings(&QualityGatesResult{Gates: []QualityGateResult{{Name: "build", Passed: true}}}); got != "" {
+		t.Errorf("no findings should add nothing, got %q", got)
+	}
+
+	gates := &QualityGatesResult{Gates: []QualityGateResult{{
+		Name:   quality.SecurityGateName,
+		Passed: true,
+		Findings: []quality.Finding{
+			{Kind: quality.FindingSecret, Severity: quality.SeverityMedium, Rule: "generic-secret", File: "app.go", Line: 12, Message: "Hardcoded credential s3cr****"},
+			{Kind: quality.FindingDependency, Severity: quality.SeverityLow, Rule: "GO-2024-0001", Package: "golang.org/x/net", Version: "v0.1.0", FixedIn: "v0.2.0", Message: "a | b"},
+		},
+	}}}
+	got := formatSecurityFindings(gates)
+	for _, want := range []string{"## Security Findings", "2 non-blocking", "`app.go:12`", "`golang.org/x/net@v0.1.0`", "(fixed in v0.2.0)", `a \| b`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("section missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestQualityOutcome_SecurityBlocked(t *testing.T) {
+	finding := quality.Finding{Kind: quality.FindingSecret, Severity: quality.SeverityHigh}
+	tests := []struct {
+		name  string
+		gates []QualityGateDetail
+		want  bool
+	}{
+		{"no findings", []QualityGateDetail{{Name: "test"}}, false},
+		{"passed with findings", []QualityGateDetail{{Name: quality.SecurityGateName, Passed: true, Findings: []quality.Finding{finding}}}, false},
+		{"failed with findings", []QualityGateDetail{{Name: quality.SecurityGateName, Findings: []quality.Finding{finding}}}, true},
+	}
+	for _, tt := range tests {
+		outcome := &QualityOutcome{GateDetails: tt.gates}
+		if got := outcome.SecurityBlocked(); got != tt.want {
+			t.Errorf("%s: SecurityBlocked() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alekspetrov/pilot/internal/quality"
+)
+
+// RepoConfigFile is the optional in-repo configuration file. It is versioned
+// with the code it governs, so repo owners can adjust Pilot without access to
+// the daemon host's config.
+const RepoConfigFile = ".pilot.yaml"
+
+// RepoConfig holds project settings read from .pilot.yaml at the repository
+// root. Settings are merged with the daemon config at execution time.
+//
+// Example .pilot.yaml:
+//
+//	base_branch: develop
+//	quality:
+//	  gates:
+//	    - name: test
+//	      command: make test
+//	      required: true
+//	protected_paths:
+//	  - migrations/
+//	  - "**/*.lock"
+//	prompt: |
+//	  Use the repository's logger package, never fmt.Println.
+//	allow_release: false
+type RepoConfig struct {
+	// Feature flags, merged with the project entry so the most restrictive wins
+	ProjectFeatures `yaml:",inline"`
+
+	// BaseBranch is the branch PRs target when the task does not set one.
+	// Empty means the repository's default branch.
+	BaseBranch string `yaml:"base_branch,omitempty"`
+
+	// Quality adds gates that run alongside the daemon's quality gates
+	Quality *RepoQualityConfig `yaml:"quality,omitempty"`
+
+	// ProtectedPaths lists files Pilot must not change. Entries are globs
+	// (a leading "**/" matches any depth) or directories ending in "/".
+	ProtectedPaths []string `yaml:"protected_paths,omitempty"`
+
+	// Prompt is appended to every execution prompt for this repository
+	Prompt string `yaml:"prompt,omitempty"`
+}
+
+// RepoQualityConfig holds repo-defined quality gates.
+type RepoQualityConfig struct {
+	Gates []*quality.Gate `yaml:"gates"`
+}
+
+// LoadRepoConfig reads .pilot.yaml from the repository root.
+// Returns nil without error when the file does not exist.
+func LoadRepoConfig(projectPath string) (*RepoConfig, error) {
+	if projectPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(projectPath, RepoConfigFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", RepoConfigFile, err)
+	}
+	return ParseRepoConfig(data)
+}
+
+// ParseRepoConfig parses and validates the contents of a .pilot.yaml file.
+func ParseRepoConfig(data []byte) (*RepoConfig, error) {
+	var cfg RepoConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", RepoConfigFile, err)
+	}
+	for _, gate := range cfg.QualityGates() {
+		if gate == nil || gate.Name == "" || gate.Command == "" {
+			return nil, fmt.Errorf("invalid %s: quality gates need a name and command", RepoConfigFile)
+		}
+	}
+	if gates := cfg.QualityGates(); len(gates) > 0 {
+		if err := (&quality.Config{Gates: gates}).Validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", RepoConfigFile, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// Features returns the repo's feature flags, or nil for a nil config.
+func (c *RepoConfig) Features() *ProjectFeatures {
+	if c == nil {
+		return nil
+	}
+	return &c.ProjectFeatures
+}
+
+// QualityGates returns the repo-defined quality gates, if any.
+func (c *RepoConfig) QualityGates() []*quality.Gate {
+	if c == nil || c.Quality == nil {
+		return nil
+	}
+	return c.Quality.Gates
+}
+
+// ProtectedMatches returns the files that match a protected path.
+func (c *RepoConfig) ProtectedMatches(files []string) []string {
+	if c == nil || len(c.ProtectedPaths) == 0 {
+		return nil
+	}
+	var matched []string
+	for _, file := range files {
+		for _, pattern := range c.ProtectedPaths {
+			if matchProtectedPath(pattern, file) {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// matchProtectedPath reports whether file is covered by pattern. Directory
+// patterns ("docs/") and plain paths ("docs") match everything beneath them.
+func matchProtectedPath(pattern, file string) bool {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	file = filepath.ToSlash(file)
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		return strings.HasPrefix(file, dir+"/")
+	}
+	return matchGlob(pattern, file) || strings.HasPrefix(file, pattern+"/")
+}
+
+// writeRepoInstructions appends the repo's prompt additions and protected
+// paths to a prompt.
+func writeRepoInstructions(sb *strings.Builder, cfg *RepoConfig) {
+	if cfg == nil {
+		return
+	}
+	if prompt := strings.TrimSpace(cfg.Prompt); prompt != "" {
+		sb.WriteString("\n## Repository Instructions\n\n")
+		sb.WriteString(prompt)
+		sb.WriteString("\n")
+	}
+	if len(cfg.ProtectedPaths) > 0 {
+		sb.WriteString("\n## Protected Paths\n\n")
+		sb.WriteString("Do NOT modify, create, or delete files matching these paths. Changes to them will be rejected:\n")
+		for _, p := range cfg.ProtectedPaths {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", p))
+		}
+	}
+}
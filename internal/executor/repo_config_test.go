@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRepoConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, RepoConfigFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRepoConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := LoadRepoConfig(dir)
+	if err != nil || cfg != nil {
+		t.Fatalf("missing file: got %+v, %v; want nil, nil", cfg, err)
+	}
+
+	writeRepoConfig(t, dir, `
+base_branch: develop
+allow_release: false
+quality:
+  gates:
+    - name: test
+      command: make test
+      required: true
+protected_paths:
+  - migrations/
+prompt: Use the internal logger.
+`)
+	cfg, err = LoadRepoConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadRepoConfig: %v", err)
+	}
+	if cfg.BaseBranch != "develop" {
+		t.Errorf("BaseBranch = %q, want develop", cfg.BaseBranch)
+	}
+	if cfg.Features().ReleaseAllowed() {
+		t.Error("allow_release: false not applied")
+	}
+	if gates := cfg.QualityGates(); len(gates) != 1 || gates[0].Command != "make test" || !gates[0].Required {
+		t.Errorf("QualityGates() = %+v", gates)
+	}
+	if len(cfg.ProtectedPaths) != 1 || cfg.Prompt != "Use the internal logger." {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	writeRepoConfig(t, dir, "quality:\n  gates:\n    - name: lint\n")
+	if _, err := LoadRepoConfig(dir); err == nil {
+		t.Error("expected error for gate without command")
+	}
+}
+
+func TestRepoConfig_NilSafe(t *testing.T) {
+	var cfg *RepoConfig
+	if cfg.Features() != nil || cfg.QualityGates() != nil || cfg.ProtectedMatches([]string{"a.go"}) != nil {
+		t.Error("nil RepoConfig accessors should return nil")
+	}
+}
+
+func TestRepoConfig_ProtectedMatches(t *testing.T) {
+	cfg := &RepoConfig{ProtectedPaths: []string{"migrations/", "**/*.lock", "LICENSE", "./deploy"}}
+	files := []string{
+		"migrations/001_init.sql",
+		"internal/migrations.go",
+		"web/yarn.lock",
+		"LICENSE",
+		"deploy/prod.yaml",
+		"deployments/x.yaml",
+		"main.go",
+	}
+
+	got := cfg.ProtectedMatches(files)
+	want := []string{"migrations/001_init.sql", "web/yarn.lock", "LICENSE", "deploy/prod.yaml"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ProtectedMatches() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildPrompt_RepoInstructions(t *testing.T) {
+	dir := t.TempDir()
+	writeRepoConfig(t, dir, "prompt: Always run make generate.\nprotected_paths:\n  - vendor/\n")
+
+	runner := NewRunner()
+	for _, local := range []bool{false, true} {
+		task := &Task{ID: "GH-1", Description: "Fix bug", ProjectPath: dir, LocalMode: local}
+		prompt := runner.BuildPrompt(task, dir)
+		if !strings.Contains(prompt, "## Repository Instructions") || !strings.Contains(prompt, "Always run make generate.") {
+			t.Errorf("local=%v: prompt missing repo instructions", local)
+		}
+		if !strings.Contains(prompt, "- `vendor/`") {
+			t.Errorf("local=%v: prompt missing protected paths", local)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/alekspetrov/pilot/internal/logging"
@@ -25,6 +27,72 @@ type RetryStrategy struct {
 
 	// TimeoutMultiplier is how much to extend the timeout (default: 1.5)
 	TimeoutMultiplier float64 `yaml:"timeout_multiplier,omitempty"`
+
+	// DraftPR opens a draft PR with the work so far once attempts run out,
+	// instead of failing without a PR (quality gate failures only)
+	DraftPR bool `yaml:"draft_pr,omitempty"`
+}
+
+// FailureClass groups task failures that share a retry policy.
+type FailureClass string
+
+const (
+	// FailureClassCompileError is a failed build, lint or typecheck gate
+	FailureClassCompileError FailureClass = "compile_error"
+	// FailureClassTestFailure is a failed test gate
+	FailureClassTestFailure FailureClass = "test_failure"
+	// FailureClassRateLimit is the backend's rate limit
+	FailureClassRateLimit FailureClass = "rate_limit"
+	// FailureClassInfra is Pilot's environment failing rather than the code,
+	// e.g. quality gates that could not run or a backend killed for memory
+	FailureClassInfra FailureClass = "infra"
+)
+
+// ClassifyQualityFailure returns the failure class of a failed quality gate
+// run. A test failure is only reported when every other gate passed, since
+// compile errors usually break the tests too.
+func ClassifyQualityFailure(outcome *QualityOutcome) FailureClass {
+	if outcome == nil {
+		return FailureClassCompileError
+	}
+	testFailed := false
+	for _, gate := range outcome.GateDetails {
+		if gate.Passed {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(gate.Name), "test") {
+			return FailureClassCompileError
+		}
+		testFailed = true
+	}
+	if testFailed {
+		return FailureClassTestFailure
+	}
+	return FailureClassCompileError
+}
+
+// RetryReport records the retry policy applied to a task's last failure,
+// for the execution comment.
+type RetryReport struct {
+	Class       FailureClass
+	Attempts    int  // Retries made for this class
+	MaxAttempts int  // Retries the policy allows
+	DraftPR     bool // A draft PR was opened after retries ran out
+}
+
+// String formats the report as "test_failure: 1/1 retries, draft PR opened".
+func (r *RetryReport) String() string {
+	if r == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%s: %d/%d retries", r.Class, r.Attempts, r.MaxAttempts)
+	if r.MaxAttempts == 0 {
+		s = fmt.Sprintf("%s: not retried", r.Class)
+	}
+	if r.DraftPR {
+		s += ", draft PR opened"
+	}
+	return s
 }
 
 // RetryConfig holds error-type-specific retry strategies.
@@ -46,6 +114,16 @@ type RetryStrategy struct {
 //	      max_attempts: 2
 //	      extend_timeout: true
 //	      timeout_multiplier: 1.5
+//	    heartbeat_timeout:
+//	      max_attempts: 1
+//	      initial_backoff: 10s
+//	    compile_error:
+//	      max_attempts: 2
+//	    test_failure:
+//	      max_attempts: 1
+//	      draft_pr: true
+//	    infra:
+//	      max_attempts: 0
 type RetryConfig struct {
 	// Enabled controls whether smart retry is active
 	Enabled bool `yaml:"enabled"`
@@ -59,12 +137,28 @@ type RetryConfig struct {
 	// Timeout strategy for timeout errors (retry with extended timeout)
 	Timeout *RetryStrategy `yaml:"timeout,omitempty"`
 
+	// HeartbeatTimeout strategy for processes killed after their event stream
+	// went silent (restart with a fresh subprocess)
+	HeartbeatTimeout *RetryStrategy `yaml:"heartbeat_timeout,omitempty"`
+
 	// DecomposeOnKill enables automatic decomposition when execution is killed
 	// (OOM, signal:killed, timeout). Instead of plain retry, the task is decomposed
 	// into subtasks. Requires decomposer to be configured. Default: false.
 	// GH-1716: Prevents tasks too large for single execution from failing permanently.
 	DecomposeOnKill bool `yaml:"decompose_on_kill,omitempty"`
 
+	// CompileError strategy for failed build/lint gates. Retries feed the
+	// gate output back to the backend. Applies even when Enabled is false.
+	CompileError *RetryStrategy `yaml:"compile_error,omitempty"`
+
+	// TestFailure strategy for failed test gates. Applies even when Enabled
+	// is false.
+	TestFailure *RetryStrategy `yaml:"test_failure,omitempty"`
+
+	// Infra strategy for quality gates that could not run. Failures are
+	// always alerted with failure_class=infra.
+	Infra *RetryStrategy `yaml:"infra,omitempty"`
+
 	// Note: invalid_config has no entry = fail fast (no retry)
 }
 
@@ -87,6 +181,21 @@ func DefaultRetryConfig() *RetryConfig {
 			ExtendTimeout:     true,
 			TimeoutMultiplier: 1.5,
 		},
+		HeartbeatTimeout: &RetryStrategy{
+			MaxAttempts:       1, // Restart once
+			InitialBackoff:    10 * time.Second,
+			BackoffMultiplier: 2.0,
+		},
+		CompileError: &RetryStrategy{
+			MaxAttempts: 2, // Retry with the build errors as context
+		},
+		TestFailure: &RetryStrategy{
+			MaxAttempts: 1,
+			DraftPR:     true, // Hand the work to a human instead of discarding it
+		},
+		Infra: &RetryStrategy{
+			MaxAttempts: 0, // Retrying won't fix the environment
+		},
 	}
 }
 
@@ -153,6 +262,9 @@ func (r *Retrier) Evaluate(err error, attempt int, originalTimeout time.Duration
 	case "timeout":
 		strategy = r.config.Timeout
 		errorName = "timeout"
+	case "heartbeat_timeout":
+		strategy = r.config.HeartbeatTimeout
+		errorName = "heartbeat_timeout"
 	case "invalid_config":
 		// Invalid config should never be retried - fail fast
 		return RetryDecision{
@@ -181,11 +293,7 @@ func (r *Retrier) Evaluate(err error, attempt int, originalTimeout time.Duration
 		}
 	}
 
-	// Calculate backoff: initial * multiplier^attempt
-	backoff := strategy.InitialBackoff
-	for i := 0; i < attempt; i++ {
-		backoff = time.Duration(float64(backoff) * strategy.BackoffMultiplier)
-	}
+	backoff := strategy.Backoff(attempt)
 
 	decision := RetryDecision{
 		ShouldRetry:     true,
@@ -213,9 +321,58 @@ func (r *Retrier) Evaluate(err error, attempt int, originalTimeout time.Duration
 	return decision
 }
 
+// Policy returns the strategy for a failure class. Quality gate classes
+// (compile_error, test_failure, infra) fall back to the defaults when not
+// configured; rate_limit returns nil unless smart retry is enabled.
+func (r *Retrier) Policy(class FailureClass) *RetryStrategy {
+	cfg := &RetryConfig{}
+	if r != nil && r.config != nil {
+		cfg = r.config
+	}
+	defaults := DefaultRetryConfig()
+
+	switch class {
+	case FailureClassCompileError:
+		return strategyOr(cfg.CompileError, defaults.CompileError)
+	case FailureClassTestFailure:
+		return strategyOr(cfg.TestFailure, defaults.TestFailure)
+	case FailureClassInfra:
+		return strategyOr(cfg.Infra, defaults.Infra)
+	case FailureClassRateLimit:
+		if !cfg.Enabled {
+			return nil
+		}
+		return cfg.RateLimit
+	}
+	return nil
+}
+
+func strategyOr(configured, fallback *RetryStrategy) *RetryStrategy {
+	if configured != nil {
+		return configured
+	}
+	return fallback
+}
+
+// Backoff returns the wait before retry number attempt (0-based):
+// initial * multiplier^attempt.
+func (s *RetryStrategy) Backoff(attempt int) time.Duration {
+	if s == nil {
+		return 0
+	}
+	backoff := s.InitialBackoff
+	for i := 0; i < attempt; i++ {
+		backoff = time.Duration(float64(backoff) * s.BackoffMultiplier)
+	}
+	return backoff
+}
+
 // Sleep waits for the backoff duration, respecting context cancellation.
+// Safe on a nil *Retrier.
 func (r *Retrier) Sleep(ctx context.Context, duration time.Duration) error {
-	r.log.Debug("Waiting before retry", slog.Duration("duration", duration))
+	if r != nil {
+		r.log.Debug("Waiting before retry", slog.Duration("duration", duration))
+	}
 
 	select {
 	case <-ctx.Done():
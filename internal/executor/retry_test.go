@@ -202,3 +202,79 @@ func TestDefaultRetryConfig(t *testing.T) {
 		t.Error("Expected Timeout to be configured")
 	}
 }
+
+func TestClassifyQualityFailure(t *testing.T) {
+	tests := []struct {
+		name  string
+		gates []QualityGateDetail
+		want  FailureClass
+	}{
+		{"build failed", []QualityGateDetail{{Name: "build"}, {Name: "test"}}, FailureClassCompileError},
+		{"only tests failed", []QualityGateDetail{{Name: "build", Passed: true}, {Name: "unit-tests"}}, FailureClassTestFailure},
+		{"lint failed", []QualityGateDetail{{Name: "test", Passed: true}, {Name: "lint"}}, FailureClassCompileError},
+		{"no details", nil, FailureClassCompileError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyQualityFailure(&QualityOutcome{GateDetails: tt.gates}); got != tt.want {
+				t.Errorf("ClassifyQualityFailure() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetrier_Policy(t *testing.T) {
+	// Quality gate classes fall back to the defaults, even on a nil retrier
+	var nilRetrier *Retrier
+	if p := nilRetrier.Policy(FailureClassCompileError); p.MaxAttempts != 2 {
+		t.Errorf("compile_error max attempts = %d, want 2", p.MaxAttempts)
+	}
+	if p := nilRetrier.Policy(FailureClassTestFailure); p.MaxAttempts != 1 || !p.DraftPR {
+		t.Errorf("test_failure policy = %+v, want 1 attempt with draft PR", p)
+	}
+	if p := nilRetrier.Policy(FailureClassInfra); p.MaxAttempts != 0 {
+		t.Errorf("infra max attempts = %d, want 0", p.MaxAttempts)
+	}
+	if p := nilRetrier.Policy(FailureClassRateLimit); p != nil {
+		t.Errorf("rate_limit policy without config = %+v, want nil", p)
+	}
+
+	retrier := NewRetrier(&RetryConfig{
+		Enabled:     true,
+		RateLimit:   &RetryStrategy{MaxAttempts: 4},
+		TestFailure: &RetryStrategy{MaxAttempts: 3},
+	})
+	if p := retrier.Policy(FailureClassTestFailure); p.MaxAttempts != 3 || p.DraftPR {
+		t.Errorf("configured test_failure policy = %+v", p)
+	}
+	if p := retrier.Policy(FailureClassRateLimit); p == nil || p.MaxAttempts != 4 {
+		t.Errorf("rate_limit policy = %+v, want 4 attempts", p)
+	}
+}
+
+func TestRetryStrategy_Backoff(t *testing.T) {
+	s := &RetryStrategy{InitialBackoff: 10 * time.Second, BackoffMultiplier: 3}
+	if got := s.Backoff(2); got != 90*time.Second {
+		t.Errorf("Backoff(2) = %v, want 90s", got)
+	}
+	if got := (*RetryStrategy)(nil).Backoff(1); got != 0 {
+		t.Errorf("nil Backoff() = %v, want 0", got)
+	}
+}
+
+func TestRetryReport_String(t *testing.T) {
+	tests := []struct {
+		report *RetryReport
+		want   string
+	}{
+		{&RetryReport{Class: FailureClassCompileError, Attempts: 2, MaxAttempts: 2}, "compile_error: 2/2 retries"},
+		{&RetryReport{Class: FailureClassTestFailure, Attempts: 1, MaxAttempts: 1, DraftPR: true}, "test_failure: 1/1 retries, draft PR opened"},
+		{&RetryReport{Class: FailureClassInfra}, "infra: not retried"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.report.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// ReviewFixTask is an open PR a reviewer requested changes on.
+type ReviewFixTask struct {
+	ID          string // Task ID for logs and the dashboard
+	Title       string
+	ProjectPath string // Checkout the worktree is created from
+	Branch      string // PR head branch
+	PRNumber    int    // Session of the PR is resumed with --from-pr
+	Feedback    string // Review threads the fix must address
+}
+
+// FixReviewFeedback checks the PR branch out into a temporary worktree, has
+// the agent address the review feedback, then pushes the fix commits to
+// the PR branch.
+func (r *Runner) FixReviewFeedback(ctx context.Context, ft *ReviewFixTask) error {
+	git := NewGitOperations(ft.ProjectPath)
+	if err := git.FetchBranches(ctx, ft.Branch); err != nil {
+		return err
+	}
+
+	path, cleanup, err := CreateWorktreeWithBranch(ctx, ft.ProjectPath, ft.ID, ft.Branch, "origin/"+ft.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to check out %s: %w", ft.Branch, err)
+	}
+	defer cleanup()
+
+	wt := NewGitOperations(path)
+	before, err := wt.GetCurrentCommitSHA(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.log.Info("Fixing review feedback",
+		slog.String("task_id", ft.ID),
+		slog.String("branch", ft.Branch),
+		slog.Int("pr", ft.PRNumber),
+	)
+	result, err := r.Execute(ctx, &Task{
+		ID:          ft.ID,
+		Title:       ft.Title,
+		Description: reviewFixPrompt(ft.Branch, ft.PRNumber, ft.Feedback),
+		ProjectPath: path,
+		FromPR:      ft.PRNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("review fix task failed: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("review fix task failed: %s", result.Error)
+	}
+
+	// Commit what the agent left staged or unstaged
+	if dirty, err := wt.HasUncommittedChanges(ctx); err == nil && dirty {
+		if _, err := wt.Commit(ctx, fmt.Sprintf("Address review feedback on PR #%d", ft.PRNumber)); err != nil {
+			return err
+		}
+	}
+	after, err := wt.GetCurrentCommitSHA(ctx)
+	if err != nil {
+		return err
+	}
+	if after == before {
+		return fmt.Errorf("no changes were made to %s", ft.Branch)
+	}
+	if err := r.checkPushLeaks(ctx, wt, ft.ID, "origin/"+ft.Branch); err != nil {
+		return err
+	}
+	return wt.Push(ctx, ft.Branch)
+}
+
+// reviewFixPrompt asks the agent to address review feedback on the checked
+// out PR branch
+func reviewFixPrompt(branch string, prNumber int, feedback string) string {
+	return fmt.Sprintf(`## Address review feedback
+
+This checkout is branch %[1]s of pull request #%[2]d. A reviewer requested changes:
+
+%[3]s
+
+1. Address every comment above. Where you disagree with a comment, leave the code as is.
+2. Build and run the tests that cover the changed files.
+3. Commit the fixes. Do not rebase, squash or push.
+
+Make no changes beyond what the review asks for.`, branch, prNumber, feedback)
+}
@@ -14,6 +14,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/hooks"
 	"github.com/alekspetrov/pilot/internal/logging"
 	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/quality"
@@ -48,7 +49,9 @@ type UsageInfo struct {
 
 // AssistantMsg represents the message field in assistant events
 type AssistantMsg struct {
+	ID      string         `json:"id,omitempty"`
 	Content []ContentBlock `json:"content"`
+	Usage   *UsageInfo     `json:"usage,omitempty"` // Usage of this message alone
 }
 
 // ContentBlock represents content in assistant messages.
@@ -96,12 +99,24 @@ type progressState struct {
 	budgetExceeded bool               // Set when per-task token/duration limit is exceeded
 	budgetReason   string             // Human-readable reason for budget cancellation
 	budgetCancel   context.CancelFunc // Cancel function to terminate execution on budget breach
+	// Runaway file churn guard (nil when disabled)
+	churn         *ChurnGuard
+	churnExceeded bool // Set when the churn guard cancelled execution
+	// Executor policy guard (nil when no policy applies)
+	policy         *PolicyGuard
+	policyViolated bool // Set when the policy guard cancelled execution
 	// Smart retry tracking (GH-920)
 	smartRetryAttempt int // Current retry attempt for error-based retries
 	// Session resume support (GH-1265)
 	sessionID string // Claude Code session ID for resume in self-review
 	// Modified files tracking (GH-1388)
 	modifiedFiles []string // List of actually modified files from Write/Edit tool events
+	// Structured step signals
+	task          *Task  // Task being executed, for alert context
+	blockedReason string // Last BLOCKED reason, alerted once
+	blocked       bool   // Set by a BLOCKED step, cleared by any later step
+	// Where the tokens went, by phase and tool category
+	tokens *tokenAttribution
 }
 
 // Task represents a task to be executed by the Runner.
@@ -135,6 +150,10 @@ type Task struct {
 	// Used for cross-project execution validation to prevent issues from one repo
 	// being executed against a different project.
 	SourceRepo string
+	// Scope lists the monorepo directories the task's project owns, when
+	// several projects share the repository. The execution policy aborts
+	// changes outside it.
+	Scope []string
 	// MemberID is the team member ID for permission checks (GH-634).
 	// When set and a TeamChecker is configured, the runner enforces RBAC before execution.
 	MemberID string
@@ -144,11 +163,21 @@ type Task struct {
 	// AcceptanceCriteria contains extracted acceptance criteria from the issue body (GH-920).
 	// When present, included in the prompt and verified before commit.
 	AcceptanceCriteria []string
+	// PriorAttempts is a condensed history of earlier failed attempts on the same issue.
+	// When present, included in the prompt so a retry doesn't repeat the previous mistakes.
+	PriorAttempts []string
 	// FromPR is the PR number to resume session context from (GH-1267).
 	// When set and UseFromPR is enabled, uses --from-pr <N> to resume the session
 	// linked to the original PR, giving Claude full context of previous changes.
 	// Typically set for autopilot-fix issues to continue from the failed PR's session.
 	FromPR int
+	// ResumeSessionID is the backend session of a blocked run to continue once
+	// its requester replied. The reply is part of Description. When the session
+	// is gone, the task runs fresh.
+	ResumeSessionID string
+	// Clarified is set when the requester answered a clarifying question. The
+	// answer is part of Description, and the task skips triage.
+	Clarified bool
 	// SourceAdapter identifies the adapter that originated this task (GH-1471).
 	// Examples: "github", "linear", "jira", "gitlab", "azuredevops"
 	// When non-empty and not "github", epic sub-issue creation uses the SubIssueCreator
@@ -164,12 +193,26 @@ type Task struct {
 	// When true, BuildPrompt skips Navigator detection and uses a focused
 	// problem-solving prompt suitable for local execution.
 	LocalMode bool
+	// Backend overrides the configured executor backend for this task
+	// (e.g. "opencode"). When empty, a "backend:<name>" label selects it.
+	Backend string
+}
+
+// taskBackend returns the backend requested for the task by its Backend
+// field or a "backend:<name>" label, or "" for the configured backend.
+func (t *Task) taskBackend() string {
+	if t.Backend != "" {
+		return t.Backend
+	}
+	return BackendFromLabels(t.Labels)
 }
 
 // QualityGateResult represents the result of a single quality gate check.
 type QualityGateResult struct {
 	// Name is the gate name (e.g., "build", "test", "lint")
 	Name string
+	// Matrix is the toolchain cell (e.g., "go1.22") for matrix gates, empty otherwise
+	Matrix string
 	// Passed indicates whether the gate passed
 	Passed bool
 	// Duration is how long the gate took to run
@@ -178,6 +221,22 @@ type QualityGateResult struct {
 	RetryCount int
 	// Error contains the error message if the gate failed
 	Error string
+	// Findings contains the security scan findings, empty for other gates
+	Findings []quality.Finding
+	// CoverageDelta is the coverage before and after the change, set by the coverage delta check only
+	CoverageDelta *quality.CoverageDelta
+	// Fingerprint identifies the last failure by its normalized output, empty if the gate never failed
+	Fingerprint string
+	// Flaky indicates the gate failed with differing output or passed on retry
+	Flaky bool
+}
+
+// Label returns the gate name qualified with its matrix cell, e.g. "test (go1.22)".
+func (g QualityGateResult) Label() string {
+	if g.Matrix == "" {
+		return g.Name
+	}
+	return fmt.Sprintf("%s (%s)", g.Name, g.Matrix)
 }
 
 // QualityGatesResult represents the aggregate quality gate results.
@@ -194,6 +253,34 @@ type QualityGatesResult struct {
 	TotalRetries int
 }
 
+// CoverageDelta returns the coverage measured by the coverage delta check,
+// or nil if it did not run or could not measure coverage.
+func (q *QualityGatesResult) CoverageDelta() *quality.CoverageDelta {
+	if q == nil {
+		return nil
+	}
+	for _, gate := range q.Gates {
+		if gate.CoverageDelta != nil {
+			return gate.CoverageDelta
+		}
+	}
+	return nil
+}
+
+// FlakyGates returns the gates that failed nondeterministically.
+func (q *QualityGatesResult) FlakyGates() []QualityGateResult {
+	if q == nil {
+		return nil
+	}
+	var flaky []QualityGateResult
+	for _, gate := range q.Gates {
+		if gate.Flaky {
+			flaky = append(flaky, gate)
+		}
+	}
+	return flaky
+}
+
 // ExecutionResult represents the result of task execution by the Runner.
 // It contains the execution outcome, any output or errors, and metrics
 // about resource usage including token counts and estimated costs.
@@ -224,6 +311,9 @@ type ExecutionResult struct {
 	CacheReadInputTokens int64
 	// ResearchTokens is the number of tokens used by parallel research phase (GH-217).
 	ResearchTokens int64
+	// TokenBreakdown attributes the tokens to phases and tool categories.
+	// Nil when the backend reported no usage.
+	TokenBreakdown *TokenBreakdown
 	// EstimatedCostUSD is the estimated cost in USD based on token usage.
 	EstimatedCostUSD float64
 	// FilesChanged is the number of files modified during execution.
@@ -243,6 +333,20 @@ type ExecutionResult struct {
 	// IntentWarning contains the reason if the intent judge flagged a mismatch.
 	// When set, the PR was created despite intent misalignment (after retry failed).
 	IntentWarning string
+	// Confidence is the intent judge's confidence (0.0-1.0) that the change does
+	// what the task asked. 0 when the judge did not run.
+	Confidence float64
+	// RecordingID identifies the execution recording (empty when recording is disabled).
+	RecordingID string
+	// Backend is the name of the backend that produced this result.
+	Backend string
+	// FailoverFrom names the backend that failed when the result came from
+	// executor.fallback_backend; FailoverReason is that failure's category.
+	FailoverFrom   string
+	FailoverReason string
+	// Retry records the executor.retry policy applied to the last failure
+	// (nil when nothing was retried).
+	Retry *RetryReport
 }
 
 // ProgressCallback is a function called during execution with progress updates.
@@ -276,6 +380,14 @@ type SubIssueCreator interface {
 	CreateIssue(ctx context.Context, parentID, title, body string, labels []string) (identifier string, url string, err error)
 }
 
+// PRCreator opens pull requests on forges the gh CLI does not cover (e.g. Gitea).
+// Registered per source adapter via SetPRCreator; tasks from other adapters
+// keep using the gh CLI.
+type PRCreator interface {
+	// CreatePR opens a pull request from head into base and returns its URL.
+	CreatePR(ctx context.Context, title, body, head, base string) (url string, err error)
+}
+
 // Runner executes development tasks using an AI backend (Claude Code, OpenCode, etc.).
 // It manages task lifecycle including branch creation, AI invocation,
 // progress tracking, PR creation, and execution recording. Runner is safe for
@@ -290,6 +402,7 @@ type Runner struct {
 	tokenMu               sync.RWMutex                // Protects tokenCallbacks
 	mu                    sync.Mutex
 	running               map[string]*exec.Cmd
+	cancels               map[string]*taskCancel // Tasks in Execute, for Cancel
 	log                   *slog.Logger
 	recordingsPath        string                                                          // Path to recordings directory (empty = default)
 	enableRecording       bool                                                            // Whether to record executions
@@ -299,11 +412,13 @@ type Runner struct {
 	modelRouter           *ModelRouter                                                    // Model and timeout routing based on complexity
 	parallelRunner        *ParallelRunner                                                 // Optional parallel research runner (GH-217)
 	decomposer            *TaskDecomposer                                                 // Optional task decomposer for complex tasks (GH-218)
+	triager               *Triager                                                        // Optional pre-execution triage; nil skips it
 	subtaskParser         *SubtaskParser                                                  // Haiku-based subtask parser; nil falls back to regex (GH-501)
 	suppressProgressLogs  bool                                                            // Suppress slog output for progress (use when visual display is active)
 	tokenLimitCheck       TokenLimitCallback                                              // Optional per-task token/duration limit check (GH-539)
 	onSubIssuePRCreated   SubIssuePRCallback                                              // Optional callback when a sub-issue PR is created (GH-596)
 	intentJudge           *IntentJudge                                                    // Optional intent judge for diff-vs-ticket alignment (GH-624)
+	leakGuard             *quality.LeakGuard                                              // Optional secret scan of diffs before push
 	teamChecker           TeamChecker                                                     // Optional team RBAC checker (GH-633)
 	executeFunc           func(ctx context.Context, task *Task) (*ExecutionResult, error) // Internal override for testing
 	skipPreflightChecks   bool                                                            // Skip preflight checks (for testing with mock backends)
@@ -323,6 +438,12 @@ type Runner struct {
 	worktreeManager       *WorktreeManager // Optional worktree manager with pool support
 	// GH-1471: SubIssueCreator for non-GitHub adapters
 	subIssueCreator       SubIssueCreator // Optional creator for sub-issues in external trackers
+	// PR creators for non-GitHub forges, keyed by Task.SourceAdapter
+	prCreators   map[string]PRCreator
+	prCreatorsMu sync.RWMutex
+	// Scripting hooks; hookTargetFor resolves where before_pr_create actions go
+	hooks         *hooks.Engine
+	hookTargetFor func(task *Task) hooks.Target
 	// GH-1599: Execution log store for milestone entries
 	logStore              *memory.Store // Optional log store for writing execution milestones
 	// GH-1811: Learning system (self-improvement)
@@ -330,8 +451,19 @@ type Runner struct {
 	patternContext       *PatternContext                // Optional pattern context for prompt injection
 	selfReviewExtractor  SelfReviewExtractor            // Optional extractor for self-review pattern learning (GH-1955)
 	outcomeTracker       *memory.ModelOutcomeTracker    // Optional outcome tracker for model escalation (GH-1991)
+	durationHistory      DurationHistory                // Optional duration history for adaptive timeouts
+	epicStore            EpicStore                      // Optional store for epic progress and resumption
+	estimateTracker      EstimateTracker                // Optional store for estimated vs actual effort
+	concurrency          *ConcurrencyLimiter            // Per-repo and per-project execution slots
+	worktreePools        map[string]*WorktreeManager    // Autoscaled worktree pools by project path
+	worktreePoolsMu      sync.Mutex                     // Protects worktreePools
+	backends             map[string]Backend             // Lazily created backends for routed models and per-task overrides
+	backendsMu           sync.Mutex                     // Protects backends
 	// GH-2015: Knowledge graph integration for execution learnings
 	knowledgeGraph       KnowledgeGraphRecorder         // Optional knowledge graph for cross-project learnings
+	projectFeatures      ProjectFeaturesResolver        // Optional per-project feature flags from config
+	staleRepoConfigs     map[string]bool                // Projects whose .pilot.yaml changed upstream
+	staleRepoConfigsMu   sync.Mutex                     // Protects staleRepoConfigs
 }
 
 // NewRunner creates a new Runner instance with Claude Code backend by default.
@@ -348,6 +480,7 @@ func NewRunner() *Runner {
 		enableRecording:   true, // Recording enabled by default
 		modelRouter:       NewModelRouter(nil, nil),
 		signalParser:      NewSignalParser(log),
+		leakGuard:         quality.NewLeakGuard(nil),
 	}
 }
 
@@ -367,6 +500,7 @@ func NewRunnerWithBackend(backend Backend) *Runner {
 		enableRecording:   true,
 		modelRouter:       NewModelRouter(nil, nil),
 		signalParser:      NewSignalParser(log),
+		leakGuard:         quality.NewLeakGuard(nil),
 	}
 }
 
@@ -388,6 +522,7 @@ func NewRunnerWithConfig(config *BackendConfig) (*Runner, error) {
 	}
 	runner := NewRunnerWithBackend(backend)
 	runner.config = config
+	runner.concurrency = NewConcurrencyLimiter(config.Concurrency)
 
 	// Configure model routing, timeouts, and effort from config
 	if config != nil {
@@ -415,6 +550,14 @@ func NewRunnerWithConfig(config *BackendConfig) (*Runner, error) {
 			)
 		}
 
+		// Triage issues before spending tokens on them
+		if config.Triage != nil && config.Triage.Enabled {
+			runner.triager = NewTriager(config.Triage)
+			if config.ClaudeCode != nil {
+				runner.triager.SetUseStructuredOutput(config.ClaudeCode.UseStructuredOutput)
+			}
+		}
+
 		// Configure task decomposition (GH-218)
 		if config.Decompose != nil && config.Decompose.Enabled {
 			runner.decomposer = NewTaskDecomposer(config.Decompose)
@@ -480,6 +623,88 @@ func (r *Runner) backendType() string {
 	return "claude-code"
 }
 
+// backendForModel returns the backend that should run the routed model together
+// with the model to pass to it. Gemini models are sent to the Gemini CLI when the
+// primary backend is something else, so model routing can keep cheap tasks on
+// Gemini and complex ones on Claude. If the Gemini CLI is not installed, the
+// primary backend runs the task with its own default model.
+func (r *Runner) backendForModel(model string) (Backend, string) {
+	if !isGeminiModel(model) || r.backend.Name() == BackendTypeGemini {
+		return r.backend, model
+	}
+
+	b := r.namedBackend(BackendTypeGemini)
+	if b == nil {
+		r.log.Warn("Model routed to Gemini but the gemini CLI is not available, using primary backend",
+			slog.String("model", model),
+			slog.String("backend", r.backend.Name()),
+		)
+		return r.backend, ""
+	}
+	return b, model
+}
+
+// backendForTask returns the backend that runs the task together with the
+// model to pass to it. A per-task override (Task.Backend or a "backend:<name>"
+// label) wins over model routing and runs with the override backend's own
+// configured model, since routed models are picked for the primary backend.
+// Unknown, disallowed, or unavailable overrides fall back to the primary backend.
+func (r *Runner) backendForTask(task *Task, model string) (Backend, string) {
+	name := task.taskBackend()
+	if name == "" || name == r.backend.Name() {
+		return r.backendForModel(model)
+	}
+
+	if !r.config.BackendAllowed(name) {
+		r.log.Warn("Task requested a backend that is not allowed, using primary backend",
+			slog.String("task_id", task.ID),
+			slog.String("requested", name),
+			slog.String("backend", r.backend.Name()),
+		)
+		return r.backendForModel(model)
+	}
+
+	b := r.namedBackend(name)
+	if b == nil {
+		r.log.Warn("Task requested a backend that is not available, using primary backend",
+			slog.String("task_id", task.ID),
+			slog.String("requested", name),
+			slog.String("backend", r.backend.Name()),
+		)
+		return r.backendForModel(model)
+	}
+	return b, ""
+}
+
+// namedBackend returns a backend of the given type built from the runner's
+// config, creating it on first use. Returns nil when the type is unknown or
+// the backend is not available on this host.
+func (r *Runner) namedBackend(name string) Backend {
+	r.backendsMu.Lock()
+	defer r.backendsMu.Unlock()
+
+	if b, ok := r.backends[name]; ok {
+		return b
+	}
+
+	cfg := DefaultBackendConfig()
+	if r.config != nil {
+		c := *r.config
+		cfg = &c
+	}
+	cfg.Type = name
+
+	b, err := NewBackend(cfg)
+	if err != nil || !b.IsAvailable() {
+		return nil
+	}
+	if r.backends == nil {
+		r.backends = make(map[string]Backend)
+	}
+	r.backends[name] = b
+	return b
+}
+
 // SetBackend changes the execution backend.
 func (r *Runner) SetBackend(backend Backend) {
 	r.backend = backend
@@ -514,6 +739,9 @@ func (r *Runner) InitWorktreePool(ctx context.Context, repoPath string) error {
 	if r.config == nil || r.config.WorktreePoolSize <= 0 {
 		return nil // Pooling disabled
 	}
+	if r.poolAutoscale() != nil {
+		return r.worktreePoolFor(repoPath).WarmPool(ctx)
+	}
 
 	r.worktreeManager = NewWorktreeManagerWithPool(repoPath, r.config.WorktreePoolSize)
 	return r.worktreeManager.WarmPool(ctx)
@@ -526,6 +754,7 @@ func (r *Runner) CloseWorktreePool() {
 	if r.worktreeManager != nil {
 		r.worktreeManager.Close()
 	}
+	r.closeWorktreePools()
 }
 
 // SetAlertProcessor sets the alert processor for emitting task lifecycle events.
@@ -613,6 +842,121 @@ func (r *Runner) SetSubIssueCreator(creator SubIssueCreator) {
 	r.subIssueCreator = creator
 }
 
+// SetPRCreator registers the pull request creator for tasks whose SourceAdapter
+// equals adapter. Passing nil removes it.
+func (r *Runner) SetPRCreator(adapter string, creator PRCreator) {
+	r.prCreatorsMu.Lock()
+	defer r.prCreatorsMu.Unlock()
+	if creator == nil {
+		delete(r.prCreators, adapter)
+		return
+	}
+	if r.prCreators == nil {
+		r.prCreators = make(map[string]PRCreator)
+	}
+	r.prCreators[adapter] = creator
+}
+
+// SetHooks sets the scripting hooks engine. before_pr_create runs just before
+// a PR is opened and may change its title and body; its comments and labels go
+// to the target returned by targetFor, typically the source issue. targetFor
+// may be nil or return nil, in which case those actions are dropped.
+func (r *Runner) SetHooks(engine *hooks.Engine, targetFor func(task *Task) hooks.Target) {
+	r.hooks = engine
+	r.hookTargetFor = targetFor
+}
+
+// Hooks returns the scripting hooks engine (may be nil).
+func (r *Runner) Hooks() *hooks.Engine { return r.hooks }
+
+// runBeforePRCreateHook lets scripts adjust the PR title and body. Script
+// failures are logged and never block PR creation.
+func (r *Runner) runBeforePRCreateHook(ctx context.Context, task *Task, title, body string) (string, string) {
+	if !r.hooks.Has(hooks.HookBeforePRCreate) {
+		return title, body
+	}
+	issueNumber, _ := strconv.Atoi(strings.TrimPrefix(task.ID, "GH-"))
+	if task.SourceIssueID != "" {
+		issueNumber, _ = strconv.Atoi(task.SourceIssueID)
+	}
+	result, err := r.hooks.Run(ctx, hooks.Event{
+		Hook:   hooks.HookBeforePRCreate,
+		Repo:   task.SourceRepo,
+		Number: issueNumber,
+		Title:  title,
+		Body:   body,
+		Labels: task.Labels,
+		Branch: task.Branch,
+	})
+	if err != nil {
+		r.log.Warn("before_pr_create hook failed", slog.String("task_id", task.ID), slog.Any("error", err))
+	}
+	if len(result.Comments) > 0 || len(result.Labels) > 0 {
+		var target hooks.Target
+		if r.hookTargetFor != nil {
+			target = r.hookTargetFor(task)
+		}
+		if target == nil {
+			r.log.Warn("before_pr_create hook requested comments or labels but the task has no hook target",
+				slog.String("task_id", task.ID))
+		} else if err := result.Apply(ctx, target); err != nil {
+			r.log.Warn("Failed to apply before_pr_create hook actions", slog.String("task_id", task.ID), slog.Any("error", err))
+		}
+	}
+	if result.Title == "" {
+		result.Title = title
+	}
+	return result.Title, result.Body
+}
+
+// prCreatorFor returns the PR creator registered for the task's source adapter, if any.
+func (r *Runner) prCreatorFor(task *Task) PRCreator {
+	if task.SourceAdapter == "" {
+		return nil
+	}
+	r.prCreatorsMu.RLock()
+	defer r.prCreatorsMu.RUnlock()
+	return r.prCreators[task.SourceAdapter]
+}
+
+// openDraftPR pushes the task branch and opens a draft PR with the work so
+// far, for failures whose retry policy hands off to a human. Forges with a
+// registered PRCreator are skipped since it cannot open drafts.
+func (r *Runner) openDraftPR(ctx context.Context, task *Task, git *GitOperations, failure string) (string, error) {
+	if !task.CreatePR || task.Branch == "" || task.DirectCommit {
+		return "", fmt.Errorf("task does not open pull requests")
+	}
+	if r.prCreatorFor(task) != nil {
+		return "", fmt.Errorf("draft PRs are not supported for %s", task.SourceAdapter)
+	}
+
+	baseBranch := task.BaseBranch
+	if baseBranch == "" {
+		baseBranch, _ = git.GetDefaultBranch(ctx)
+		if baseBranch == "" {
+			baseBranch = "main"
+		}
+	}
+	if err := r.checkPushLeaks(ctx, git, task.ID, pushDiffBase(ctx, git, task)); err != nil {
+		return "", err
+	}
+	if err := git.Push(ctx, task.Branch); err != nil && !git.RemoteBranchExists(ctx, task.Branch) {
+		return "", fmt.Errorf("push failed: %w", err)
+	}
+
+	// "Refs", not "Closes": the work is incomplete
+	issueNum := strings.TrimPrefix(task.ID, "GH-")
+	title := fmt.Sprintf("%s: %s", task.ID, task.Title)
+	body := fmt.Sprintf("## Summary\n\nDraft PR opened by Pilot for task %s: quality gates still fail after the configured retries.\n\nRefs #%s\n\n## Failure\n\n```\n%s\n```", task.ID, issueNum, truncateText(failure, 4000))
+	return git.CreateDraftPR(ctx, title, body, baseBranch)
+}
+
+// SetLeakGuard sets the guard that scans diffs for credentials before they
+// are pushed. A nil guard disables the scan.
+func (r *Runner) SetLeakGuard(g *quality.LeakGuard) {
+	r.leakGuard = g
+}
+
 // SetIntentJudge sets the intent judge for diff-vs-ticket alignment verification (GH-624).
 func (r *Runner) SetIntentJudge(judge *IntentJudge) {
 	r.intentJudge = judge
@@ -677,6 +1021,24 @@ func (r *Runner) SetOutcomeTracker(t *memory.ModelOutcomeTracker) {
 // HasOutcomeTracker reports whether an outcome tracker is wired.
 func (r *Runner) HasOutcomeTracker() bool { return r.outcomeTracker != nil }
 
+// SetDurationHistory sets the store that records task durations and feeds
+// adaptive timeouts in the model router.
+func (r *Runner) SetDurationHistory(h DurationHistory) {
+	r.durationHistory = h
+	if r.modelRouter != nil {
+		r.modelRouter.SetDurationHistory(h)
+	}
+}
+
+// SetEpicStore sets the store that persists epics and their sub-issue
+// progress, so an epic interrupted by a restart resumes where it stopped.
+func (r *Runner) SetEpicStore(store EpicStore) {
+	r.epicStore = store
+}
+
+// HasEpicStore reports whether an epic store is wired.
+func (r *Runner) HasEpicStore() bool { return r.epicStore != nil }
+
 // SetKnowledgeGraph sets the knowledge graph for execution learning recording (GH-2015).
 func (r *Runner) SetKnowledgeGraph(kg KnowledgeGraphRecorder) {
 	r.knowledgeGraph = kg
@@ -685,6 +1047,99 @@ func (r *Runner) SetKnowledgeGraph(kg KnowledgeGraphRecorder) {
 // HasKnowledgeGraph reports whether a knowledge graph is wired.
 func (r *Runner) HasKnowledgeGraph() bool { return r.knowledgeGraph != nil }
 
+// SetProjectFeaturesResolver sets the lookup for per-project feature flags
+// from the daemon config. The repo's .pilot.yaml is read with or without it.
+func (r *Runner) SetProjectFeaturesResolver(fn ProjectFeaturesResolver) {
+	r.projectFeatures = fn
+}
+
+// HasProjectFeaturesResolver reports whether a project features resolver is wired.
+func (r *Runner) HasProjectFeaturesResolver() bool { return r.projectFeatures != nil }
+
+// ReloadRepoConfig marks the .pilot.yaml of a project as changed upstream,
+// e.g. after a config change PR merged. The next execution in the project
+// pulls the default branch before reading it.
+func (r *Runner) ReloadRepoConfig(projectPath string) {
+	r.staleRepoConfigsMu.Lock()
+	defer r.staleRepoConfigsMu.Unlock()
+	if r.staleRepoConfigs == nil {
+		r.staleRepoConfigs = make(map[string]bool)
+	}
+	r.staleRepoConfigs[projectPath] = true
+}
+
+// refreshRepoConfig pulls the default branch into projectPath when its
+// .pilot.yaml was marked stale. A checkout with uncommitted changes is left
+// alone and keeps the config it has.
+func (r *Runner) refreshRepoConfig(ctx context.Context, projectPath string) {
+	r.staleRepoConfigsMu.Lock()
+	stale := r.staleRepoConfigs[projectPath]
+	delete(r.staleRepoConfigs, projectPath)
+	r.staleRepoConfigsMu.Unlock()
+	if !stale {
+		return
+	}
+
+	git := NewGitOperations(projectPath)
+	if dirty, err := git.HasUncommittedChanges(ctx); err != nil || dirty {
+		r.log.Warn("Not reloading repo config: checkout has uncommitted changes",
+			slog.String("project", projectPath),
+		)
+		return
+	}
+	if branch, err := git.SwitchToDefaultBranchAndPull(ctx); err != nil {
+		r.log.Warn("Failed to reload repo config", slog.String("project", projectPath), slog.Any("error", err))
+	} else {
+		r.log.Info("Reloaded repo config", slog.String("project", projectPath), slog.String("branch", branch))
+	}
+}
+
+// projectFeaturesFor returns the effective feature flags for a project,
+// merging configured flags with the repo's .pilot.yaml.
+func (r *Runner) projectFeaturesFor(projectPath string, repo *RepoConfig) *ProjectFeatures {
+	var configured *ProjectFeatures
+	if r.projectFeatures != nil {
+		configured = r.projectFeatures(projectPath)
+	}
+	return configured.Merge(repo.Features())
+}
+
+// loadRepoConfig reads the repo's .pilot.yaml. An invalid file is logged and
+// ignored so a bad commit to it does not block execution.
+func (r *Runner) loadRepoConfig(projectPath string) *RepoConfig {
+	cfg, err := LoadRepoConfig(projectPath)
+	if err != nil {
+		r.log.Warn("Ignoring invalid repo config",
+			slog.String("project", projectPath),
+			slog.Any("error", err),
+		)
+	}
+	return cfg
+}
+
+// qualityCheckerFor returns the checker for a task, combining the configured
+// quality gates with gates defined in the repo's .pilot.yaml.
+func (r *Runner) qualityCheckerFor(taskID, executionPath string, repo *RepoConfig) QualityChecker {
+	var checkers []QualityChecker
+	if r.qualityCheckerFactory != nil {
+		checkers = append(checkers, r.qualityCheckerFactory(taskID, executionPath))
+	}
+	if gates := repo.QualityGates(); len(gates) > 0 {
+		cfg := quality.DefaultConfig()
+		cfg.Enabled = true
+		cfg.Gates = gates
+		checkers = append(checkers, &simpleQualityChecker{
+			config:      cfg,
+			projectPath: executionPath,
+			taskID:      taskID,
+		})
+	}
+	if len(checkers) == 1 {
+		return checkers[0]
+	}
+	return combinedQualityChecker(checkers)
+}
+
 // HasTokenLimitCheck reports whether a token limit check callback is wired.
 func (r *Runner) HasTokenLimitCheck() bool { return r.tokenLimitCheck != nil }
 
@@ -836,7 +1291,29 @@ func (r *Runner) EmitProgress(taskID, phase string, progress int, message string
 // When a decomposer is configured and enabled, complex tasks are automatically
 // split into subtasks that run sequentially (GH-218). Only the final subtask
 // creates a PR, accumulating all changes from previous subtasks.
+//
+// Tasks wait for a slot when their repository or project is at its
+// executor.concurrency limit.
 func (r *Runner) Execute(ctx context.Context, task *Task) (*ExecutionResult, error) {
+	ctx, finish := r.trackCancel(ctx, task.ID)
+	result, err := r.executeWithSlot(ctx, task)
+	if finish() {
+		return r.cancelledResult(task, result)
+	}
+	return result, err
+}
+
+// executeWithSlot waits for an execution slot, then runs the task
+func (r *Runner) executeWithSlot(ctx context.Context, task *Task) (*ExecutionResult, error) {
+	release, err := r.acquireSlot(ctx, task)
+	if err != nil {
+		return &ExecutionResult{
+			TaskID:  task.ID,
+			Success: false,
+			Error:   fmt.Sprintf("cancelled while waiting for an execution slot: %v", err),
+		}, fmt.Errorf("waiting for execution slot: %w", err)
+	}
+	defer release()
 	return r.executeWithOptions(ctx, task, true)
 }
 
@@ -876,6 +1353,35 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 		}
 	}
 
+	// Triage before spending tokens. Sub-issues and decomposed subtasks
+	// (allowWorktree false) were written by Pilot and are not triaged.
+	if allowWorktree && r.triager != nil && needsTriage(task) {
+		if result, err := r.triageTask(ctx, task); err != nil {
+			return result, err
+		}
+	}
+
+	// Budget pressure: low-priority tasks wait for the next day
+	if allowWorktree {
+		if result, err := r.checkBudgetRoute(task); err != nil {
+			return result, err
+		}
+	}
+
+	// Per-project settings from config and the repo's .pilot.yaml
+	r.refreshRepoConfig(ctx, task.ProjectPath)
+	repoCfg := r.loadRepoConfig(task.ProjectPath)
+	features := r.projectFeaturesFor(task.ProjectPath, repoCfg)
+	if task.BaseBranch == "" && repoCfg != nil && repoCfg.BaseBranch != "" {
+		task.BaseBranch = repoCfg.BaseBranch
+	}
+	if task.DirectCommit && !features.DirectCommitAllowed() {
+		r.log.Info("Direct commit disabled for project, opening a PR instead",
+			slog.String("task_id", task.ID),
+		)
+		task.DirectCommit = false
+	}
+
 	// GH-936: Create isolated worktree if configured
 	// This allows execution even when user has uncommitted changes in their working directory
 	executionPath := task.ProjectPath
@@ -902,12 +1408,12 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 		var err error
 
 		// GH-1078: Use pool if available, otherwise fall back to direct creation
-		if r.worktreeManager != nil && r.worktreeManager.PoolSize() > 0 {
+		if pool := r.worktreePoolFor(task.ProjectPath); pool != nil {
 			r.log.Debug("Using worktree pool",
-				slog.Int("pool_available", r.worktreeManager.PoolAvailable()),
+				slog.Int("pool_available", pool.PoolAvailable()),
 			)
 			var result *WorktreeResult
-			result, err = r.worktreeManager.Acquire(ctx, task.ID, task.Branch, "")
+			result, err = pool.Acquire(ctx, task.ID, task.Branch, "")
 			if err == nil {
 				worktreePath = result.Path
 				cleanup = result.Cleanup
@@ -1010,18 +1516,29 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 		slog.Any("labels", task.Labels),
 		slog.Bool("has_no_decompose", hasNoDecompose),
 		slog.Bool("is_epic", complexity.IsEpic()),
+		slog.Bool("epics_allowed", features.EpicsAllowed()),
 		slog.String("complexity", string(complexity)),
 	)
 
 	// GH-405: Epic tasks trigger planning mode instead of execution
-	if complexity.IsEpic() && !hasNoDecompose {
+	if complexity.IsEpic() && !hasNoDecompose && features.EpicsAllowed() {
 		r.log.Info("Epic task detected, running planning mode",
 			slog.String("task_id", task.ID),
 			slog.String("title", task.Title),
 		)
-		r.reportProgress(task.ID, "Planning", 10, "Running epic planning...")
-
-		plan, err := r.PlanEpic(ctx, task, executionPath)
+		// Resume an epic interrupted by a restart instead of planning it again
+		plan, resumedIssues := r.resumableEpic(task)
+		var err error
+		if plan != nil {
+			r.log.Info("Resuming interrupted epic",
+				slog.String("task_id", task.ID),
+				slog.Int("sub_issues", len(resumedIssues)),
+			)
+			r.reportProgress(task.ID, "Planning", 10, "Resuming interrupted epic...")
+		} else {
+			r.reportProgress(task.ID, "Planning", 10, "Running epic planning...")
+			plan, err = r.PlanEpic(ctx, task, executionPath)
+		}
 		if err != nil {
 			// GH-1687: Planning failure is non-fatal — fall through to direct execution
 			r.log.Warn("Epic planning failed, falling back to direct execution",
@@ -1038,7 +1555,7 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 		// separate GitHub issues. Creating N sub-issues that all touch the same
 		// package causes merge conflicts because each sub-issue branches from main
 		// independently and redeclares shared types (e.g., the "pilot onboard" cascade).
-		if isSinglePackageScope(plan.Subtasks, task.Description) {
+		if resumedIssues == nil && isSinglePackageScope(plan.Subtasks, task.Description) {
 			r.log.Info("Single-package scope detected, skipping epic decomposition — executing as single task",
 				slog.String("task_id", task.ID),
 				slog.Int("planned_subtasks", len(plan.Subtasks)),
@@ -1054,18 +1571,22 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 			// Multi-package epic: safe to create separate GitHub issues
 
 			// GH-412: Create sub-issues from the plan
-			r.reportProgress(task.ID, "Creating Issues", 40, "Creating GitHub sub-issues...")
+			issues := resumedIssues
+			if issues == nil {
+				r.reportProgress(task.ID, "Creating Issues", 40, "Creating GitHub sub-issues...")
 
-			issues, err := r.CreateSubIssues(ctx, plan, executionPath)
-			if err != nil {
-				return &ExecutionResult{
-					TaskID:   task.ID,
-					Success:  false,
-					Error:    fmt.Sprintf("failed to create sub-issues: %v", err),
-					Duration: time.Since(start),
-					IsEpic:   true,
-					EpicPlan: plan,
-				}, nil
+				issues, err = r.CreateSubIssues(ctx, plan, executionPath)
+				if err != nil {
+					return &ExecutionResult{
+						TaskID:   task.ID,
+						Success:  false,
+						Error:    fmt.Sprintf("failed to create sub-issues: %v", err),
+						Duration: time.Since(start),
+						IsEpic:   true,
+						EpicPlan: plan,
+					}, nil
+				}
+				r.saveEpic(task, issues)
 			}
 
 			r.reportProgress(task.ID, "Executing", 50, fmt.Sprintf("Executing %d sub-issues sequentially...", len(issues)))
@@ -1098,6 +1619,12 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 
 				r.reportProgress(task.ID, "Creating PR", 96, "Pushing epic branch...")
 
+				if err := r.checkPushLeaks(ctx, epicGit, task.ID, pushDiffBase(ctx, epicGit, task)); err != nil {
+					epicResult.Success = false
+					epicResult.Error = err.Error()
+					r.reportProgress(task.ID, "Secret Detected", 100, epicResult.Error)
+					return epicResult, nil
+				}
 				if err := epicGit.Push(ctx, task.Branch); err != nil {
 					r.log.Warn("Epic branch push failed",
 						slog.String("task_id", task.ID),
@@ -1145,7 +1672,7 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 
 	// Check for task decomposition (GH-218)
 	// Decomposition happens before timeout setup because subtasks have their own timeouts
-	if r.decomposer != nil {
+	if r.decomposer != nil && features.DecomposeAllowed() {
 		result := r.decomposer.Decompose(task)
 		if result.Decomposed && len(result.Subtasks) > 1 {
 			r.log.Info("Task decomposed",
@@ -1171,6 +1698,13 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 
 	// Select model if routing is enabled
 	selectedModel := r.modelRouter.SelectModel(task)
+
+	// Run the selected model on the backend that serves it (gemini-* → Gemini CLI),
+	// unless the task picked its own backend
+	backend, selectedModel := r.backendForTask(task, selectedModel)
+	if backend != r.backend {
+		log = log.With(slog.String("routed_backend", backend.Name()))
+	}
 	if selectedModel != "" {
 		log = log.With(slog.String("routed_model", selectedModel))
 	}
@@ -1181,6 +1715,9 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 		log = log.With(slog.String("routed_effort", selectedEffort))
 	}
 
+	// Predict effort up front so estimates can be calibrated against actuals
+	estimate := r.estimateTask(task, complexity, selectedModel, selectedEffort)
+
 	log.Info("Starting task execution",
 		slog.String("project", task.ProjectPath),
 		slog.String("branch", task.Branch),
@@ -1304,7 +1841,11 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 	}
 
 	// State for tracking progress
-	state := &progressState{phase: "Starting", budgetCancel: cancel}
+	state := &progressState{phase: "Starting", budgetCancel: cancel, task: task, tokens: newTokenAttribution()}
+	if r.config != nil {
+		state.churn = NewChurnGuard(r.config.ChurnGuard)
+	}
+	state.policy = NewPolicyGuard(r.config.policyFor(task), executionPath)
 
 	// Initialize recorder if recording is enabled
 	var recorder *replay.Recorder
@@ -1315,12 +1856,15 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 			log.Warn("Failed to create recorder, continuing without recording", slog.Any("error", recErr))
 		} else {
 			recorder.SetBranch(task.Branch)
+			recorder.SetTokenBreakdownSource(func() *replay.TokenBreakdown {
+				return state.tokens.breakdown().Replay()
+			})
 			log.Debug("Recording enabled", slog.String("recording_id", recorder.GetRecordingID()))
 		}
 	}
 
 	// Report start
-	backendName := r.backend.Name()
+	backendName := backend.Name()
 	r.reportProgress(task.ID, "Starting", 0, fmt.Sprintf("Initializing %s...", backendName))
 
 	// Clean stale pilot hooks unconditionally — even when hooks.enabled is false.
@@ -1398,14 +1942,16 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 	// Watchdog kills subprocess after 2x timeout as a safety net for processes
 	// that ignore context cancellation.
 	watchdogTimeout := 2 * timeout
-	backendResult, err := r.backend.Execute(ctx, ExecuteOptions{
-		Prompt:          prompt,
-		ProjectPath:     executionPath, // Use worktree path if active
-		Verbose:         task.Verbose,
-		Model:           selectedModel,
-		Effort:          selectedEffort,
-		FromPR:          task.FromPR, // GH-1267: session resumption from PR context
-		WatchdogTimeout: watchdogTimeout,
+	backendResult, err := backend.Execute(ctx, ExecuteOptions{
+		Prompt:            prompt,
+		ProjectPath:       executionPath, // Use worktree path if active
+		Verbose:           task.Verbose,
+		Model:             selectedModel,
+		Effort:            selectedEffort,
+		FromPR:            task.FromPR, // GH-1267: session resumption from PR context
+		ResumeSessionID:   task.ResumeSessionID,
+		HeartbeatCallback: r.heartbeatCallback(task, 0),
+		WatchdogTimeout:   watchdogTimeout,
 		WatchdogCallback: func(pid int, watchdogDuration time.Duration) {
 			log.Warn("Watchdog killed subprocess",
 				slog.Int("pid", pid),
@@ -1449,6 +1995,38 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 	result := &ExecutionResult{
 		TaskID:   task.ID,
 		Duration: duration,
+		Backend:  backend.Name(),
+	}
+	if recorder != nil {
+		result.RecordingID = recorder.GetRecordingID()
+	}
+
+	// The agent stopped on a BLOCKED step: hand the task back to its
+	// requester instead of failing it
+	if state.blocked && ctx.Err() == nil {
+		result.TokensInput = state.tokensInput
+		result.TokensOutput = state.tokensOutput
+		result.TokensTotal = state.tokensInput + state.tokensOutput
+		result.TokenBreakdown = state.tokens.breakdown()
+		result.ModelName = state.modelName
+		if result.ModelName == "" {
+			result.ModelName = "claude-opus-4-6"
+		}
+		result.EstimatedCostUSD = estimateCostWithCache(result.TokensInput, result.TokensOutput, state.cacheCreationInputTokens, state.cacheReadInputTokens, result.ModelName)
+		sessionID := state.sessionID
+		if backendResult != nil && backendResult.SessionID != "" {
+			sessionID = backendResult.SessionID
+		}
+		r.reportProgress(task.ID, PhaseBlocked, state.navProgress, "Waiting for reply: "+state.blockedReason)
+
+		if recorder != nil {
+			recorder.SetModel(state.modelName)
+			recorder.SetNavigator(state.hasNavigator)
+			if finErr := recorder.Finish("blocked"); finErr != nil {
+				log.Warn("Failed to finish recording", slog.Any("error", finErr))
+			}
+		}
+		return r.blockedResult(task, result, state.blockedReason, sessionID)
 	}
 
 	if err != nil {
@@ -1460,6 +2038,7 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 			result.TokensInput = state.tokensInput
 			result.TokensOutput = state.tokensOutput
 			result.TokensTotal = state.tokensInput + state.tokensOutput
+			result.TokenBreakdown = state.tokens.breakdown()
 			result.CacheCreationInputTokens = state.cacheCreationInputTokens
 			result.CacheReadInputTokens = state.cacheReadInputTokens
 			result.ModelName = state.modelName
@@ -1501,6 +2080,91 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 			return result, nil
 		}
 
+		// Check if the churn guard cancelled execution
+		if state.churnExceeded {
+			result.Error = FileChurnErrorPrefix + ": " + state.churn.Reason()
+			result.TokensInput = state.tokensInput
+			result.TokensOutput = state.tokensOutput
+			result.TokensTotal = state.tokensInput + state.tokensOutput
+			result.TokenBreakdown = state.tokens.breakdown()
+			result.ModelName = state.modelName
+			if result.ModelName == "" {
+				result.ModelName = "claude-opus-4-6"
+			}
+			result.EstimatedCostUSD = estimateCostWithCache(result.TokensInput, result.TokensOutput, state.cacheCreationInputTokens, state.cacheReadInputTokens, result.ModelName)
+			log.Warn("Task cancelled due to runaway file churn",
+				slog.String("task_id", task.ID),
+				slog.String("reason", state.churn.Reason()),
+				slog.Int("files_modified", len(state.modifiedFiles)),
+				slog.Duration("duration", duration),
+			)
+			r.reportProgress(task.ID, "File Churn", 100, result.Error)
+
+			r.emitAlertEvent(AlertEvent{
+				Type:      AlertEventTypeTaskFailed,
+				TaskID:    task.ID,
+				TaskTitle: task.Title,
+				Project:   task.ProjectPath,
+				Error:     result.Error,
+				Metadata: map[string]string{
+					"reason": "file_churn",
+					"detail": state.churn.Reason(),
+				},
+				Timestamp: time.Now(),
+			})
+
+			if recorder != nil {
+				recorder.SetModel(state.modelName)
+				recorder.SetNavigator(state.hasNavigator)
+				if finErr := recorder.Finish("file_churn"); finErr != nil {
+					log.Warn("Failed to finish recording", slog.Any("error", finErr))
+				}
+			}
+			return result, nil
+		}
+
+		// Check if the policy guard cancelled execution
+		if state.policyViolated {
+			result.Error = PolicyErrorPrefix + ": " + state.policy.Reason()
+			result.TokensInput = state.tokensInput
+			result.TokensOutput = state.tokensOutput
+			result.TokensTotal = state.tokensInput + state.tokensOutput
+			result.TokenBreakdown = state.tokens.breakdown()
+			result.ModelName = state.modelName
+			if result.ModelName == "" {
+				result.ModelName = "claude-opus-4-6"
+			}
+			result.EstimatedCostUSD = estimateCostWithCache(result.TokensInput, result.TokensOutput, state.cacheCreationInputTokens, state.cacheReadInputTokens, result.ModelName)
+			log.Warn("Task aborted by executor policy",
+				slog.String("task_id", task.ID),
+				slog.String("reason", state.policy.Reason()),
+				slog.Duration("duration", duration),
+			)
+			r.reportProgress(task.ID, "Policy Violation", 100, result.Error)
+
+			r.emitAlertEvent(AlertEvent{
+				Type:      AlertEventTypeTaskFailed,
+				TaskID:    task.ID,
+				TaskTitle: task.Title,
+				Project:   task.ProjectPath,
+				Error:     result.Error,
+				Metadata: map[string]string{
+					"reason": "policy_violation",
+					"detail": state.policy.Reason(),
+				},
+				Timestamp: time.Now(),
+			})
+
+			if recorder != nil {
+				recorder.SetModel(state.modelName)
+				recorder.SetNavigator(state.hasNavigator)
+				if finErr := recorder.Finish("policy_violation"); finErr != nil {
+					log.Warn("Failed to finish recording", slog.Any("error", finErr))
+				}
+			}
+			return result, nil
+		}
+
 		// Check if this was a timeout
 		timedOut := ctx.Err() == context.DeadlineExceeded
 		if timedOut {
@@ -1570,6 +2234,16 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 					)
 					r.reportProgress(task.ID, "Config Error", 100, beErr.ErrorMessage())
 
+				case "heartbeat_timeout":
+					// The stall itself was already alerted from the heartbeat callback
+					errorCategory = "heartbeat_timeout"
+					log.Warn("Backend stalled and was killed",
+						slog.String("task_id", task.ID),
+						slog.String("message", beErr.ErrorMessage()),
+						slog.Duration("duration", duration),
+					)
+					r.reportProgress(task.ID, "Stalled", 100, beErr.ErrorMessage())
+
 				case "api_error":
 					alertType = AlertEventTypeAPIError
 					errorCategory = "api_error"
@@ -1611,6 +2285,13 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 						r.driftDetector.RecordCorrection("retry_triggered", fmt.Sprintf("Error: %s, Retry attempt: %d", err.Error(), state.smartRetryAttempt+1))
 					}
 					state.smartRetryAttempt++
+					if errorCategory == string(FailureClassRateLimit) {
+						result.Retry = &RetryReport{
+							Class:       FailureClassRateLimit,
+							Attempts:    state.smartRetryAttempt,
+							MaxAttempts: r.retrier.Policy(FailureClassRateLimit).MaxAttempts,
+						}
+					}
 					log.Info("Smart retry triggered",
 						slog.String("task_id", task.ID),
 						slog.String("error_category", errorCategory),
@@ -1633,13 +2314,14 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 
 						r.reportProgress(task.ID, "Re-executing", 55, fmt.Sprintf("Retry attempt %d with %v timeout...", state.smartRetryAttempt, retryTimeout))
 
-						retryResult, retryErr := r.backend.Execute(retryCtx, ExecuteOptions{
-							Prompt:          prompt,
-							ProjectPath:     task.ProjectPath,
-							Verbose:         task.Verbose,
-							Model:           selectedModel,
-							Effort:          selectedEffort,
-							WatchdogTimeout: 2 * retryTimeout,
+						retryResult, retryErr := backend.Execute(retryCtx, ExecuteOptions{
+							Prompt:            prompt,
+							ProjectPath:       task.ProjectPath,
+							Verbose:           task.Verbose,
+							Model:             selectedModel,
+							Effort:            selectedEffort,
+							HeartbeatCallback: r.heartbeatCallback(task, state.smartRetryAttempt),
+							WatchdogTimeout:   2 * retryTimeout,
 							EventHandler: func(event BackendEvent) {
 								if recorder != nil {
 									_ = recorder.RecordEvent(event.Raw)
@@ -1672,9 +2354,66 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 				}
 			}
 
+			// Re-run on executor.fallback_backend when the primary backend failed
+			// for infrastructure reasons (crash, auth, rate limit) rather than the task
+			var failoverErr error
+			failoverBackend := ""
+			if reason, ok := FailoverReason(err); ok {
+				if fallback := r.fallbackBackendFor(backend); fallback != nil {
+					log.Warn("Backend failed, failing over",
+						slog.String("task_id", task.ID),
+						slog.String("from", backend.Name()),
+						slog.String("to", fallback.Name()),
+						slog.String("reason", reason),
+					)
+					r.reportProgress(task.ID, "Failover", 55, fmt.Sprintf("%s failed (%s), retrying on %s...", backend.Name(), reason, fallback.Name()))
+					r.saveLogEntry(task.ID, "warn", fmt.Sprintf("Failing over from %s to %s: %s", backend.Name(), fallback.Name(), reason))
+
+					failoverCtx, failoverCancel := context.WithTimeout(context.Background(), timeout)
+					fallbackResult, fallbackErr := fallback.Execute(failoverCtx, ExecuteOptions{
+						Prompt:            prompt,
+						ProjectPath:       executionPath,
+						Verbose:           task.Verbose,
+						HeartbeatCallback: r.heartbeatCallback(task, state.smartRetryAttempt+1),
+						WatchdogTimeout:   watchdogTimeout,
+						EventHandler: func(event BackendEvent) {
+							if recorder != nil {
+								_ = recorder.RecordEvent(event.Raw)
+							}
+							r.processBackendEvent(task.ID, event, state)
+						},
+					})
+					failoverCancel()
+
+					if fallbackErr == nil && fallbackResult != nil {
+						log.Info("Failover backend produced the result",
+							slog.String("task_id", task.ID),
+							slog.String("backend", fallback.Name()),
+							slog.Bool("success", fallbackResult.Success),
+						)
+						result.Backend = fallback.Name()
+						result.FailoverFrom = backend.Name()
+						result.FailoverReason = reason
+						// Later quality retries stay on the working backend with its own model
+						backend = fallback
+						selectedModel = ""
+						backendResult = fallbackResult
+						err = nil
+						goto retrySucceeded
+					}
+					log.Warn("Failover backend failed",
+						slog.String("task_id", task.ID),
+						slog.String("backend", fallback.Name()),
+						slog.Any("error", fallbackErr),
+					)
+					failoverBackend = fallback.Name()
+					failoverErr = fallbackErr
+				}
+			}
+
 			// GH-1716: If execution was killed and decompose_on_kill is enabled,
 			// attempt decomposition as last resort before failing.
-			if r.retrier != nil && r.retrier.config.DecomposeOnKill && r.decomposer != nil {
+			if r.retrier != nil && r.retrier.config.DecomposeOnKill && r.decomposer != nil && features.DecomposeAllowed() {
 				if beErr, ok := err.(BackendError); ok && beErr.ErrorType() == "timeout" {
 					log.Info("Execution killed, attempting decomposition fallback",
 						slog.String("task_id", task.ID))
@@ -1696,6 +2435,12 @@ func (r *Runner) executeWithOptions(ctx context.Context, task *Task, allowWorktr
 			if stderrOutput != "" {
 				metadata["stderr"] = stderrOutput
 			}
+			if failoverBackend != "" {
+				metadata["failover_backend"] = failoverBackend
+				if failoverErr != nil {
+					metadata["failover_error"] = failoverErr.Error()
+				}
+			}
 
 			// Emit alert event with error category metadata
 			r.emitAlertEvent(AlertEvent{
@@ -1747,6 +2492,8 @@ retrySucceeded:
 	if researchResult != nil {
 		result.ResearchTokens = researchResult.TotalTokens
 		result.TokensTotal += researchResult.TotalTokens
+		// Research subagents report a single total, counted as input
+		state.tokens.add(TokenPhaseResearch, ToolCategorySubagent, TokenCount{Input: researchResult.TotalTokens})
 	}
 
 	// Extract commit SHA from state (parsed from Claude Code output)
@@ -1799,6 +2546,7 @@ retrySucceeded:
 
 	// Fill in additional metrics from state
 	result.FilesChanged = state.filesWrite
+	result.TokenBreakdown = state.tokens.breakdown()
 	result.CacheCreationInputTokens = state.cacheCreationInputTokens
 	result.CacheReadInputTokens = state.cacheReadInputTokens
 	if result.ModelName == "" {
@@ -1914,7 +2662,7 @@ The previous execution completed but made no code changes. This task requires ac
 %s`, task.Title, task.Description)
 
 				// Execute retry
-				retryResult, retryErr := r.backend.Execute(ctx, ExecuteOptions{
+				retryResult, retryErr := backend.Execute(ctx, ExecuteOptions{
 					Prompt:          retryPrompt,
 					ProjectPath:     task.ProjectPath,
 					Verbose:         task.Verbose,
@@ -1927,6 +2675,7 @@ The previous execution completed but made no code changes. This task requires ac
 						state.tokensOutput += event.TokensOutput
 						state.cacheCreationInputTokens += event.CacheCreationInputTokens
 						state.cacheReadInputTokens += event.CacheReadInputTokens
+						state.tokens.record(TokenPhaseImplement, event)
 						// Extract any commit SHAs from retry
 						if event.Type == EventTypeToolResult && event.ToolResult != "" {
 							extractCommitSHA(event.ToolResult, state)
@@ -2003,7 +2752,8 @@ The previous execution completed but made no code changes. This task requires ac
 
 		// Auto-enable minimal build gate if not configured (GH-363)
 		// This ensures broken code never becomes a PR, even without explicit quality config
-		if r.qualityCheckerFactory == nil {
+		// Skipped when the repo's .pilot.yaml defines its own gates
+		if r.qualityCheckerFactory == nil && len(repoCfg.QualityGates()) == 0 {
 			buildCmd := quality.DetectBuildCommand(executionPath)
 			if buildCmd != "" {
 				log.Info("Auto-enabling build gate (no quality config)",
@@ -2028,23 +2778,45 @@ The previous execution completed but made no code changes. This task requires ac
 		qualityGatesPassed := false
 
 		// Run quality gates if configured (skip in LocalMode — no PR workflow)
-		if r.qualityCheckerFactory != nil && !task.LocalMode {
-			const maxAutoRetries = 2 // Circuit breaker to prevent infinite loops
-
+		if (r.qualityCheckerFactory != nil || len(repoCfg.QualityGates()) > 0) && !task.LocalMode {
 			// Track quality gate results across retries (GH-209)
 			var finalOutcome *QualityOutcome
 			var totalQualityRetries int
 
-			for retryAttempt := 0; retryAttempt <= maxAutoRetries; retryAttempt++ {
+			// Retries are budgeted per failure class (executor.retry), which
+			// also bounds the loop
+			classRetries := make(map[FailureClass]int)
+
+			for retryAttempt := 0; ; retryAttempt++ {
 				r.reportProgress(task.ID, "Quality Gates", 91, "Running quality checks...")
 				r.saveLogEntry(task.ID, "info", "Running tests...")
 
-				checker := r.qualityCheckerFactory(task.ID, executionPath)
+				checker := r.qualityCheckerFor(task.ID, executionPath, repoCfg)
 				outcome, qErr := checker.Check(ctx)
 				if qErr != nil {
+					// The gates could not run at all: an infra failure, not the code's
+					infraPolicy := r.retrier.Policy(FailureClassInfra)
+					if classRetries[FailureClassInfra] < infraPolicy.MaxAttempts {
+						backoff := infraPolicy.Backoff(classRetries[FailureClassInfra])
+						classRetries[FailureClassInfra]++
+						log.Warn("Quality gate check error, retrying",
+							slog.Any("error", qErr),
+							slog.Int("attempt", classRetries[FailureClassInfra]),
+							slog.Duration("backoff", backoff),
+						)
+						if sleepErr := r.retrier.Sleep(ctx, backoff); sleepErr == nil {
+							continue
+						}
+					}
+
 					log.Error("Quality gate check error", slog.Any("error", qErr))
 					result.Success = false
 					result.Error = fmt.Sprintf("quality gate error: %v", qErr)
+					result.Retry = &RetryReport{
+						Class:       FailureClassInfra,
+						Attempts:    classRetries[FailureClassInfra],
+						MaxAttempts: infraPolicy.MaxAttempts,
+					}
 					r.reportProgress(task.ID, "Quality Failed", 100, result.Error)
 
 					// Emit task failed event
@@ -2054,6 +2826,9 @@ The previous execution completed but made no code changes. This task requires ac
 						TaskTitle: task.Title,
 						Project:   task.ProjectPath,
 						Error:     result.Error,
+						Metadata: map[string]string{
+							"failure_class": string(FailureClassInfra),
+						},
 						Timestamp: time.Now(),
 					})
 
@@ -2103,17 +2878,27 @@ The previous execution completed but made no code changes. This task requires ac
 				finalOutcome = outcome
 
 				// Quality gates failed
+				failureClass := ClassifyQualityFailure(outcome)
+				policy := r.retrier.Policy(failureClass)
+				result.Retry = &RetryReport{
+					Class:       failureClass,
+					Attempts:    classRetries[failureClass],
+					MaxAttempts: policy.MaxAttempts,
+				}
 				log.Warn("Quality gates failed",
 					slog.Bool("should_retry", outcome.ShouldRetry),
+					slog.String("failure_class", string(failureClass)),
 					slog.Int("attempt", outcome.Attempt),
 					slog.Int("retry_attempt", retryAttempt),
 				)
 
 				// Check if we should retry with Claude Code
-				if outcome.ShouldRetry && retryAttempt < maxAutoRetries {
+				if outcome.ShouldRetry && classRetries[failureClass] < result.Retry.MaxAttempts {
+					classRetries[failureClass]++
+					result.Retry.Attempts = classRetries[failureClass]
 					totalQualityRetries++ // Track total retries across all gates (GH-209)
 					r.reportProgress(task.ID, "Quality Retry", 92,
-						fmt.Sprintf("Fixing issues (attempt %d/%d)...", retryAttempt+1, maxAutoRetries))
+						fmt.Sprintf("Fixing %s (attempt %d/%d)...", strings.ReplaceAll(string(failureClass), "_", " "), result.Retry.Attempts, result.Retry.MaxAttempts))
 
 					// GH-1066: Record correction for drift detection
 					if r.driftDetector != nil {
@@ -2127,8 +2912,9 @@ The previous execution completed but made no code changes. This task requires ac
 						TaskTitle: task.Title,
 						Project:   task.ProjectPath,
 						Metadata: map[string]string{
-							"attempt":  strconv.Itoa(retryAttempt + 1),
-							"feedback": truncateText(outcome.RetryFeedback, 500),
+							"attempt":       strconv.Itoa(retryAttempt + 1),
+							"failure_class": string(failureClass),
+							"feedback":      truncateText(outcome.RetryFeedback, 500),
 						},
 						Timestamp: time.Now(),
 					})
@@ -2142,7 +2928,8 @@ The previous execution completed but made no code changes. This task requires ac
 					)
 
 					// Re-invoke backend with retry prompt
-					retryResult, retryErr := r.backend.Execute(ctx, ExecuteOptions{
+					state.tokens.setPhase(TokenPhaseQualityFix)
+					retryResult, retryErr := backend.Execute(ctx, ExecuteOptions{
 						Prompt:      retryPrompt,
 						ProjectPath: task.ProjectPath,
 						Verbose:     task.Verbose,
@@ -2157,6 +2944,7 @@ The previous execution completed but made no code changes. This task requires ac
 							r.processBackendEvent(task.ID, event, state)
 						},
 					})
+					state.tokens.setPhase("")
 
 					if retryErr != nil {
 						result.Success = false
@@ -2250,14 +3038,32 @@ The previous execution completed but made no code changes. This task requires ac
 
 				// No more retries allowed - fail the task
 				result.Success = false
-				if retryAttempt >= maxAutoRetries {
-					result.Error = fmt.Sprintf("quality gates failed after %d auto-retries", maxAutoRetries)
+				if result.Retry.Attempts > 0 {
+					result.Error = fmt.Sprintf("quality gates failed after %d auto-retries (%s)", result.Retry.Attempts, failureClass)
 				} else {
-					result.Error = "quality gates failed, max retries exhausted"
+					result.Error = fmt.Sprintf("quality gates failed, max retries exhausted (%s)", failureClass)
 				}
 
 				r.reportProgress(task.ID, "Quality Failed", 100, "Quality gates did not pass")
 
+				// Hand the work so far to a human as a draft PR, unless
+				// publishing the branch would leak what the security scan found
+				if policy.DraftPR && outcome.SecurityBlocked() {
+					log.Warn("Not opening a draft PR: security scan found blocking issues")
+				} else if policy.DraftPR {
+					prURL, prErr := r.openDraftPR(ctx, task, git, outcome.RetryFeedback)
+					if prErr != nil {
+						log.Warn("Failed to open draft PR", slog.Any("error", prErr))
+					} else {
+						result.PRUrl = prURL
+						result.Retry.DraftPR = true
+						r.saveLogEntry(task.ID, "info", "Draft PR opened: "+prURL)
+						if recorder != nil {
+							recorder.SetPRUrl(prURL)
+						}
+					}
+				}
+
 				// Emit task failed event
 				r.emitAlertEvent(AlertEvent{
 					Type:      AlertEventTypeTaskFailed,
@@ -2388,9 +3194,15 @@ The previous execution completed but made no code changes. This task requires ac
 			log.Warn("Self-review error", slog.Any("error", selfReviewErr))
 			// Continue anyway - self-review is advisory
 		}
+		if runSelfReview {
+			result.TokenBreakdown = state.tokens.breakdown()
+		}
 
 		// Handle intent judge result
 		if runIntentJudge {
+			if intentVerdict != nil {
+				result.Confidence = intentVerdict.PassConfidence()
+			}
 			if intentErr != nil {
 				log.Warn("Intent judge error (continuing to PR)",
 					slog.String("task_id", task.ID),
@@ -2412,7 +3224,7 @@ The previous execution completed but made no code changes. This task requires ac
 						intentVerdict.Reason, task.Title, task.Description,
 					)
 
-					_, retryErr := r.backend.Execute(ctx, ExecuteOptions{
+					_, retryErr := backend.Execute(ctx, ExecuteOptions{
 						Prompt:      retryPrompt,
 						ProjectPath: task.ProjectPath,
 						Verbose:     task.Verbose,
@@ -2423,6 +3235,7 @@ The previous execution completed but made no code changes. This task requires ac
 							state.tokensOutput += event.TokensOutput
 							state.cacheCreationInputTokens += event.CacheCreationInputTokens
 							state.cacheReadInputTokens += event.CacheReadInputTokens
+							state.tokens.record(TokenPhaseImplement, event)
 							if event.Type == EventTypeToolResult && event.ToolResult != "" {
 								extractCommitSHA(event.ToolResult, state)
 							}
@@ -2434,11 +3247,15 @@ The previous execution completed but made no code changes. This task requires ac
 						result.TokensInput = state.tokensInput
 						result.TokensOutput = state.tokensOutput
 						result.TokensTotal = state.tokensInput + state.tokensOutput
+						result.TokenBreakdown = state.tokens.breakdown()
 
 						// Re-judge the new diff
 						newDiff, _ := git.GetDiff(ctx, intentBaseBranch)
 						if newDiff != "" {
 							v2, _ := r.intentJudge.Judge(ctx, task.Title, task.Description, newDiff)
+							if v2 != nil {
+								result.Confidence = v2.PassConfidence()
+							}
 							if v2 != nil && !v2.Passed {
 								result.IntentWarning = v2.Reason
 							}
@@ -2452,6 +3269,21 @@ The previous execution completed but made no code changes. This task requires ac
 			}
 		}
 
+		// Check the whole diff against the executor policy before anything is
+		// pushed; shell commands can change files the guard never saw
+		if policy := r.config.policyFor(task); policy != nil && !task.LocalMode {
+			if err := policy.checkCommits(ctx, git, pushDiffBase(ctx, git, task)); err != nil {
+				result.Success = false
+				result.Error = err.Error()
+				log.Warn("Task aborted by executor policy",
+					slog.String("task_id", task.ID),
+					slog.Any("error", err),
+				)
+				r.reportProgress(task.ID, "Policy Violation", 100, result.Error)
+				return result, nil
+			}
+		}
+
 		// Handle direct commit mode: push directly to main
 
 		// Pre-push lint gate (GH-1376)
@@ -2468,6 +3300,13 @@ The previous execution completed but made no code changes. This task requires ac
 		if task.DirectCommit {
 			r.reportProgress(task.ID, "Pushing", 96, "Pushing to main...")
 
+			// Scan the commits for credentials before they leave the machine
+			if err := r.checkPushLeaks(ctx, git, task.ID, pushDiffBase(ctx, git, task)); err != nil {
+				result.Success = false
+				result.Error = err.Error()
+				r.reportProgress(task.ID, "Secret Detected", 100, result.Error)
+				return result, nil
+			}
 			if err := git.PushToMain(ctx); err != nil {
 				result.Success = false
 				result.Error = fmt.Sprintf("push to main failed: %v", err)
@@ -2502,6 +3341,74 @@ The previous execution completed but made no code changes. This task requires ac
 					}
 				}
 			}
+
+			// Determine base branch
+			baseBranch := task.BaseBranch
+			if baseBranch == "" {
+				baseBranch, _ = git.GetDefaultBranch(ctx)
+				if baseBranch == "" {
+					baseBranch = "main"
+				}
+			}
+
+			// Reject changes to paths protected by the repo's .pilot.yaml
+			if repoCfg != nil && len(repoCfg.ProtectedPaths) > 0 {
+				changedFiles, err := git.GetChangedFilesSince(ctx, baseBranch)
+				if err != nil {
+					log.Warn("Failed to list changed files, skipping protected_paths check",
+						slog.String("task_id", task.ID),
+						slog.Any("error", err),
+					)
+				} else if touched := repoCfg.ProtectedMatches(changedFiles); len(touched) > 0 {
+					result.Success = false
+					result.Error = fmt.Sprintf("changes touch protected paths: %s", strings.Join(touched, ", "))
+					r.reportProgress(task.ID, "PR Failed", 100, result.Error)
+					return result, nil
+				}
+			}
+
+			// Enforce the project's max_pr_size before anything leaves the machine
+			if limit := features.PRSizeLimit(); limit > 0 {
+				changed, err := git.DiffLineCount(ctx, baseBranch)
+				if err != nil {
+					log.Warn("Failed to measure PR size, skipping max_pr_size check",
+						slog.String("task_id", task.ID),
+						slog.Any("error", err),
+					)
+				} else if changed > limit {
+					result.Success = false
+					result.Error = fmt.Sprintf("PR exceeds project max_pr_size: %d lines changed (limit %d)", changed, limit)
+					r.reportProgress(task.ID, "PR Failed", 100, result.Error)
+					return result, nil
+				}
+			}
+
+			// Rewrite non-conventional commit messages while they are still local
+			if policy := r.config.commitPolicy(); policy != nil {
+				rewrites, err := git.ConformCommits(ctx, policy, commitTypeForTask(task), baseBranch, "origin/"+baseBranch, "origin/"+task.Branch)
+				if err != nil {
+					log.Warn("Failed to apply commit policy, pushing messages as they are",
+						slog.String("task_id", task.ID),
+						slog.Any("error", err),
+					)
+				}
+				for _, rw := range rewrites {
+					log.Info("Commit message rewritten by commit policy",
+						slog.String("task_id", task.ID),
+						slog.String("commit", rw.SHA[:min(7, len(rw.SHA))]),
+						slog.String("to", strings.SplitN(rw.To, "\n", 2)[0]),
+					)
+				}
+			}
+
+			// Scan the commits for credentials before they leave the machine
+			if err := r.checkPushLeaks(ctx, git, task.ID, pushDiffBase(ctx, git, task)); err != nil {
+				result.Success = false
+				result.Error = err.Error()
+				r.reportProgress(task.ID, "Secret Detected", 100, result.Error)
+				return result, nil
+			}
+
 			// Push branch
 			if err := git.Push(ctx, task.Branch); err != nil {
 				// GH-1389: Worktree push may fail with chdir error even if data was already pushed.
@@ -2541,22 +3448,27 @@ The previous execution completed but made no code changes. This task requires ac
 
 			r.reportProgress(task.ID, "Creating PR", 98, "Creating pull request...")
 
-			// Determine base branch
-			baseBranch := task.BaseBranch
-			if baseBranch == "" {
-				baseBranch, _ = git.GetDefaultBranch(ctx)
-				if baseBranch == "" {
-					baseBranch = "main"
-				}
-			}
-
 			// Generate PR body with GitHub auto-close keyword
 			issueNum := strings.TrimPrefix(task.ID, "GH-")
+			prCreator := r.prCreatorFor(task)
+			if prCreator != nil && task.SourceIssueID != "" {
+				issueNum = task.SourceIssueID
+			}
 			prBody := fmt.Sprintf("## Summary\n\nAutomated PR created by Pilot for task %s.\n\nCloses #%s\n\n## Changes\n\n%s", task.ID, issueNum, task.Description)
+			prBody += formatSecurityFindings(result.QualityGates)
 
-			// Create PR
+			// Create PR (via the source forge's API when registered, gh CLI otherwise)
 			prTitle := fmt.Sprintf("%s: %s", task.ID, task.Title)
-			prURL, err := git.CreatePR(ctx, prTitle, prBody, baseBranch)
+			prTitle, prBody = r.runBeforePRCreateHook(ctx, task, prTitle, prBody)
+			var prURL string
+			var err error
+			if prCreator != nil {
+				prURL, err = prCreator.CreatePR(ctx, prTitle, prBody, task.Branch, baseBranch)
+			} else if r.config != nil && r.config.DraftPRs {
+				prURL, err = git.CreateDraftPR(ctx, prTitle, prBody, baseBranch)
+			} else {
+				prURL, err = git.CreatePR(ctx, prTitle, prBody, baseBranch)
+			}
 			if err != nil {
 				result.Success = false
 				result.Error = fmt.Sprintf("PR creation failed: %v", err)
@@ -2724,20 +3636,40 @@ The previous execution completed but made no code changes. This task requires ac
 	// GH-1991: Record outcome for model routing escalation
 	r.recordOutcome(task, result, complexity, duration)
 
+	// Record wall-clock duration for adaptive timeouts
+	r.recordDuration(task, result, complexity, time.Since(start))
+
+	// Store the pre-execution estimate with its actuals for calibration
+	r.recordEstimate(estimate, result, time.Since(start))
+
 	return result, nil
 }
-// Cancel terminates a running task by killing its Claude Code process.
-// Returns an error if the task is not currently running.
+// Cancel aborts a task started with Execute: its context is cancelled, which
+// kills the backend subprocess, and the worktree is cleaned up as execution
+// unwinds. Execute then returns ErrTaskCancelled. A task still waiting for
+// an execution slot stops waiting. Returns an error if the task is not
+// currently running.
 func (r *Runner) Cancel(taskID string) error {
 	r.mu.Lock()
+	tc, tracked := r.cancels[taskID]
+	if tracked {
+		tc.cancelled = true
+	}
 	cmd, ok := r.running[taskID]
 	r.mu.Unlock()
 
-	if !ok {
+	if !tracked && !ok {
 		return fmt.Errorf("task %s is not running", taskID)
 	}
 
-	return cmd.Process.Kill()
+	if tracked {
+		r.log.Info("Cancelling task", slog.String("task_id", taskID))
+		tc.cancel()
+	}
+	if ok {
+		return cmd.Process.Kill()
+	}
+	return nil
 }
 
 // recordLearning records the execution outcome for pattern learning.
@@ -2854,6 +3786,18 @@ func (r *Runner) recordOutcome(task *Task, result *ExecutionResult, complexity C
 	}
 }
 
+// recordDuration stores the duration of a successful execution so future
+// tasks of the same project and complexity get an adaptive timeout.
+// Failed runs are skipped: timeouts and crashes would skew the distribution.
+func (r *Runner) recordDuration(task *Task, result *ExecutionResult, complexity Complexity, duration time.Duration) {
+	if r.durationHistory == nil || !result.Success {
+		return
+	}
+	if err := r.durationHistory.RecordTaskDuration(task.ProjectPath, string(complexity), duration); err != nil {
+		r.log.Warn("Failed to record task duration", slog.Any("error", err))
+	}
+}
+
 // CancelAll terminates all running subprocesses gracefully.
 // It sends SIGTERM to allow processes to clean up, then forcefully kills
 // any remaining processes after a 10-second grace period.
@@ -2910,7 +3854,8 @@ func (r *Runner) IsRunning(taskID string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	_, ok := r.running[taskID]
-	return ok
+	_, tracked := r.cancels[taskID]
+	return ok || tracked
 }
 
 
@@ -2944,6 +3889,7 @@ func (r *Runner) runSelfReview(ctx context.Context, task *Task, state *progressS
 	// Select model and effort (use same routing as main execution)
 	selectedModel := r.modelRouter.SelectModel(task)
 	selectedEffort := r.modelRouter.SelectEffort(task)
+	backend, selectedModel := r.backendForTask(task, selectedModel)
 
 	// GH-1265: Determine if session resume is enabled and session ID is available
 	var resumeSessionID string
@@ -2957,7 +3903,7 @@ func (r *Runner) runSelfReview(ctx context.Context, task *Task, state *progressS
 		}
 	}
 
-	result, err := r.backend.Execute(reviewCtx, ExecuteOptions{
+	result, err := backend.Execute(reviewCtx, ExecuteOptions{
 		Prompt:          reviewPrompt,
 		ProjectPath:     task.ProjectPath,
 		Verbose:         task.Verbose,
@@ -2970,6 +3916,7 @@ func (r *Runner) runSelfReview(ctx context.Context, task *Task, state *progressS
 			state.tokensOutput += event.TokensOutput
 			state.cacheCreationInputTokens += event.CacheCreationInputTokens
 			state.cacheReadInputTokens += event.CacheReadInputTokens
+			state.tokens.record(TokenPhaseReview, event)
 			// Extract any new commit SHAs from self-review fixes
 			if event.Type == EventTypeToolResult && event.ToolResult != "" {
 				extractCommitSHA(event.ToolResult, state)
@@ -3113,6 +4060,9 @@ func (r *Runner) parseStreamEvent(taskID, line string, state *progressState) (st
 // processBackendEvent handles events from any backend and updates progress state.
 // This is the unified event handler that works with both Claude Code and OpenCode.
 func (r *Runner) processBackendEvent(taskID string, event BackendEvent, state *progressState) {
+	// Attribute tokens once the event has updated the phase
+	defer func() { state.tokens.record(tokenPhase(state), event) }()
+
 	// Track token usage
 	state.tokensInput += event.TokensInput
 	state.tokensOutput += event.TokensOutput
@@ -3160,6 +4110,32 @@ func (r *Runner) processBackendEvent(taskID string, event BackendEvent, state *p
 		}
 
 	case EventTypeToolUse:
+		if !state.churnExceeded {
+			if reason := state.churn.RecordToolUse(event.ToolName, event.ToolInput); reason != "" {
+				state.churnExceeded = true
+				r.log.Warn("Runaway file churn detected, cancelling execution",
+					slog.String("task_id", taskID),
+					slog.String("reason", reason),
+				)
+				if state.budgetCancel != nil {
+					state.budgetCancel()
+				}
+				return
+			}
+		}
+		if !state.policyViolated {
+			if reason := state.policy.RecordToolUse(event.ToolName, event.ToolInput); reason != "" {
+				state.policyViolated = true
+				r.log.Warn("Executor policy violated, cancelling execution",
+					slog.String("task_id", taskID),
+					slog.String("reason", reason),
+				)
+				if state.budgetCancel != nil {
+					state.budgetCancel()
+				}
+				return
+			}
+		}
 		r.handleToolUse(taskID, event.ToolName, event.ToolInput, state)
 
 	case EventTypeToolResult:
@@ -3367,6 +4343,16 @@ func (r *Runner) handleStructuredSignals(taskID string, signals []PilotSignal, s
 
 		case SignalTypeStagnation:
 			r.reportProgress(taskID, "⚠️ Stalled", 0, "Navigator detected stagnation")
+
+		case SignalTypePlan, SignalTypeEdit, SignalTypeTest, SignalTypeBlocked:
+			if step, ok := signal.Step(); ok {
+				r.handleProgressStep(taskID, step, state)
+			} else {
+				r.log.Warn("Ignoring step signal without required field",
+					slog.String("task_id", taskID),
+					slog.String("type", signal.Type),
+				)
+			}
 		}
 
 		// Check for exit signal from any signal type
@@ -3381,6 +4367,34 @@ func (r *Runner) handleStructuredSignals(taskID string, signals []PilotSignal, s
 	}
 }
 
+// handleProgressStep shows a step signal as the task's phase. A BLOCKED step
+// also emits a task_blocked alert event, once per reason.
+func (r *Runner) handleProgressStep(taskID string, step ProgressStep, state *progressState) {
+	state.phase = step.Phase()
+	state.blocked = step.Kind == StepBlocked
+	r.reportProgress(taskID, step.Phase(), state.navProgress, step.String())
+
+	if step.Kind != StepBlocked || step.Detail == state.blockedReason {
+		return
+	}
+	state.blockedReason = step.Detail
+	r.saveLogEntry(taskID, "warn", "Agent blocked: "+step.Detail)
+
+	event := AlertEvent{
+		Type:      AlertEventTypeTaskBlocked,
+		TaskID:    taskID,
+		Phase:     state.navPhase,
+		Progress:  state.navProgress,
+		Error:     step.Detail,
+		Timestamp: time.Now(),
+	}
+	if state.task != nil {
+		event.TaskTitle = state.task.Title
+		event.Project = state.task.ProjectPath
+	}
+	r.emitAlertEvent(event)
+}
+
 // handleNavigatorPhase maps Navigator phases to progress
 func (r *Runner) handleNavigatorPhase(taskID, phase string, state *progressState) {
 	phase = strings.ToUpper(strings.TrimSpace(phase))
@@ -3713,6 +4727,9 @@ func modelPricing(model string) (inputPrice, outputPrice float64) {
 
 	modelLower := strings.ToLower(model)
 	switch {
+	case strings.HasPrefix(modelLower, ollamaModelPrefix):
+		// Local inference via Ollama has no per-token cost
+		return 0, 0
 	case strings.Contains(modelLower, "opus-4-1") || strings.Contains(modelLower, "opus-4-0") || model == "claude-opus-4":
 		// Legacy Opus 4.1/4.0
 		return opus41InputPrice, opus41OutputPrice
@@ -3731,6 +4748,51 @@ func modelPricing(model string) (inputPrice, outputPrice float64) {
 		default:
 			return 0.07, 0.30 // Qwen3-Coder-Next (default)
 		}
+	case strings.HasPrefix(modelLower, "gpt-") || strings.HasPrefix(modelLower, "o3") ||
+		strings.HasPrefix(modelLower, "o4") || strings.HasPrefix(modelLower, "codex-"):
+		// OpenAI pricing (per 1M tokens); cached input is billed at 10%, matching estimateCostWithCache
+		// Source: https://openai.com/api/pricing
+		switch {
+		case strings.HasPrefix(modelLower, "gpt-4.1"):
+			switch {
+			case strings.Contains(modelLower, "nano"):
+				return 0.10, 0.40
+			case strings.Contains(modelLower, "mini"):
+				return 0.40, 1.60
+			default:
+				return 2.00, 8.00
+			}
+		case strings.HasPrefix(modelLower, "gpt-4o"):
+			if strings.Contains(modelLower, "mini") {
+				return 0.15, 0.60
+			}
+			return 2.50, 10.00
+		case strings.HasPrefix(modelLower, "o4-mini"):
+			return 1.10, 4.40
+		case strings.HasPrefix(modelLower, "o3"):
+			return 2.00, 8.00
+		case strings.HasPrefix(modelLower, "codex-mini"):
+			return 1.50, 6.00
+		case strings.Contains(modelLower, "nano"):
+			return 0.05, 0.40 // GPT-5 nano
+		case strings.Contains(modelLower, "mini"):
+			return 0.25, 2.00 // GPT-5 mini
+		default:
+			return 1.25, 10.00 // GPT-5 / GPT-5-Codex
+		}
+	case strings.HasPrefix(modelLower, "gemini"):
+		// Gemini pricing (per 1M tokens, prompts up to 200K)
+		// Source: https://ai.google.dev/gemini-api/docs/pricing
+		switch {
+		case strings.Contains(modelLower, "flash-lite") || strings.Contains(modelLower, "2.0-flash"):
+			return 0.10, 0.40
+		case strings.Contains(modelLower, "flash"):
+			return 0.30, 2.50 // Gemini 2.5 Flash
+		case strings.HasPrefix(modelLower, "gemini-3"):
+			return 2.00, 12.00 // Gemini 3 Pro
+		default:
+			return 1.25, 10.00 // Gemini 2.5 Pro
+		}
 	default:
 		return sonnetInputPrice, sonnetOutputPrice
 	}
@@ -3763,6 +4825,36 @@ func (r *Runner) emitAlertEvent(event AlertEvent) {
 	r.alertProcessor.ProcessEvent(event)
 }
 
+// heartbeatCallback builds the ExecuteOptions.HeartbeatCallback for a task.
+// It reports the stall and emits a heartbeat_timeout alert event so repeated
+// stalls can be alerted on (GH-884).
+func (r *Runner) heartbeatCallback(task *Task, attempt int) func(pid int, lastEventAge time.Duration) {
+	return func(pid int, lastEventAge time.Duration) {
+		r.log.Warn("Backend stalled, killing subprocess",
+			slog.String("task_id", task.ID),
+			slog.Int("pid", pid),
+			slog.Duration("last_event_age", lastEventAge),
+			slog.Int("attempt", attempt),
+		)
+		r.reportProgress(task.ID, "Stalled", 100, fmt.Sprintf("No output for %v, killing backend", lastEventAge.Round(time.Second)))
+		r.saveLogEntry(task.ID, "warn", fmt.Sprintf("Backend stalled: no stream events for %v", lastEventAge.Round(time.Second)))
+
+		r.emitAlertEvent(AlertEvent{
+			Type:      AlertEventTypeHeartbeatTimeout,
+			TaskID:    task.ID,
+			TaskTitle: task.Title,
+			Project:   task.ProjectPath,
+			Error:     fmt.Sprintf("no stream events for %v", lastEventAge.Round(time.Second)),
+			Metadata: map[string]string{
+				"pid":            fmt.Sprintf("%d", pid),
+				"last_event_age": lastEventAge.Round(time.Second).String(),
+				"attempt":        fmt.Sprintf("%d", attempt),
+			},
+			Timestamp: time.Now(),
+		})
+	}
+}
+
 // dispatchWebhook sends a webhook event if webhook manager is configured
 func (r *Runner) dispatchWebhook(ctx context.Context, eventType webhooks.EventType, data any) {
 	if r.webhooks == nil {
@@ -3875,11 +4967,16 @@ func (c *simpleQualityChecker) Check(ctx context.Context) (*QualityOutcome, erro
 
 	for _, r := range results.Results {
 		outcome.GateDetails = append(outcome.GateDetails, QualityGateDetail{
-			Name:       r.GateName,
-			Passed:     r.Status == quality.StatusPassed,
-			Duration:   r.Duration,
-			RetryCount: r.RetryCount,
-			Error:      r.Error,
+			Name:          r.GateName,
+			Matrix:        r.Matrix,
+			Passed:        r.Status == quality.StatusPassed,
+			Duration:      r.Duration,
+			RetryCount:    r.RetryCount,
+			Error:         r.Error,
+			Findings:      r.Findings,
+			CoverageDelta: r.CoverageDelta,
+			Fingerprint:   r.Fingerprint,
+			Flaky:         r.Flaky,
 		})
 	}
 
@@ -214,6 +214,45 @@ func TestRunner_Integration_AlertEmission(t *testing.T) {
 	}
 }
 
+// TestRunner_Integration_BackendFailover verifies infrastructure failures on
+// the primary backend re-run the task on executor.fallback_backend
+func TestRunner_Integration_BackendFailover(t *testing.T) {
+	primary := newMockIntegrationBackend("test-backend", true)
+	primary.executeErr = &ClaudeCodeError{Type: ErrorTypeRateLimit, Message: "rate limit reached"}
+
+	fallback := newMockIntegrationBackend("test-fallback", true)
+	fallback.addResult(&BackendResult{Success: true, Output: "Completed on fallback"})
+	RegisterBackend("test-fallback", func(*BackendConfig) Backend { return fallback })
+
+	runner := NewRunnerWithBackend(primary)
+	runner.SetRecordingEnabled(false)
+	runner.config = &BackendConfig{FallbackBackend: "test-fallback"}
+
+	task := &Task{
+		ID:          "INTEG-FAILOVER",
+		Title:       "Failover test",
+		Description: "Primary backend is rate limited",
+		ProjectPath: setupTestRepo(t),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := runner.Execute(ctx, task)
+	if err != nil {
+		t.Fatalf("Execute() returned error: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected success, got failure: %s", result.Error)
+	}
+	if result.Backend != "test-fallback" || result.FailoverFrom != "test-backend" || result.FailoverReason != "rate_limit" {
+		t.Errorf("result backend=%q from=%q reason=%q", result.Backend, result.FailoverFrom, result.FailoverReason)
+	}
+	if primary.getExecCount() != 1 || fallback.getExecCount() < 1 {
+		t.Errorf("exec counts: primary=%d fallback=%d", primary.getExecCount(), fallback.getExecCount())
+	}
+}
+
 // TestRunner_Integration_QualityGates verifies quality gate integration
 func TestRunner_Integration_QualityGates(t *testing.T) {
 	// Backend: initial execution -> self-review (after quality passes)
@@ -3,6 +3,7 @@ package executor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/hooks"
 	"github.com/alekspetrov/pilot/internal/memory"
 )
 
@@ -173,6 +175,33 @@ func TestIsRunning(t *testing.T) {
 	}
 }
 
+type stubPRCreator struct{ url string }
+
+func (s *stubPRCreator) CreatePR(ctx context.Context, title, body, head, base string) (string, error) {
+	return s.url, nil
+}
+
+func TestSetPRCreator_RoutesBySourceAdapter(t *testing.T) {
+	runner := NewRunner()
+	creator := &stubPRCreator{url: "https://gitea.test/o/r/pulls/1"}
+	runner.SetPRCreator("gitea", creator)
+
+	if got := runner.prCreatorFor(&Task{SourceAdapter: "gitea"}); got != creator {
+		t.Errorf("prCreatorFor(gitea) = %v, want registered creator", got)
+	}
+	if got := runner.prCreatorFor(&Task{SourceAdapter: "github"}); got != nil {
+		t.Errorf("prCreatorFor(github) = %v, want nil", got)
+	}
+	if got := runner.prCreatorFor(&Task{}); got != nil {
+		t.Errorf("prCreatorFor(no adapter) = %v, want nil", got)
+	}
+
+	runner.SetPRCreator("gitea", nil)
+	if got := runner.prCreatorFor(&Task{SourceAdapter: "gitea"}); got != nil {
+		t.Errorf("prCreatorFor after removal = %v, want nil", got)
+	}
+}
+
 func TestParseStreamEvent(t *testing.T) {
 	runner := NewRunner()
 
@@ -798,6 +827,70 @@ func TestEstimateCost(t *testing.T) {
 			minCost:      0.06,
 			maxCost:      0.08,
 		},
+		{
+			name:         "gpt-5-codex 1M input + 1M output",
+			inputTokens:  1000000,
+			outputTokens: 1000000,
+			model:        "gpt-5-codex",
+			minCost:      11.24,
+			maxCost:      11.26,
+		},
+		{
+			name:         "gpt-5-mini 1M output tokens",
+			inputTokens:  0,
+			outputTokens: 1000000,
+			model:        "gpt-5-mini",
+			minCost:      1.99,
+			maxCost:      2.01,
+		},
+		{
+			name:         "o4-mini 1M input tokens",
+			inputTokens:  1000000,
+			outputTokens: 0,
+			model:        "o4-mini",
+			minCost:      1.09,
+			maxCost:      1.11,
+		},
+		{
+			name:         "gpt-4.1 1M output tokens",
+			inputTokens:  0,
+			outputTokens: 1000000,
+			model:        "gpt-4.1",
+			minCost:      7.99,
+			maxCost:      8.01,
+		},
+		{
+			name:         "gemini-2.5-pro 1M input tokens",
+			inputTokens:  1000000,
+			outputTokens: 0,
+			model:        "gemini-2.5-pro",
+			minCost:      1.24,
+			maxCost:      1.26,
+		},
+		{
+			name:         "gemini-2.5-flash 1M output tokens",
+			inputTokens:  0,
+			outputTokens: 1000000,
+			model:        "gemini-2.5-flash",
+			minCost:      2.49,
+			maxCost:      2.51,
+		},
+		{
+			name:         "gemini-2.5-flash-lite 1M input tokens",
+			inputTokens:  1000000,
+			outputTokens: 0,
+			model:        "gemini-2.5-flash-lite",
+			minCost:      0.09,
+			maxCost:      0.11,
+		},
+		{
+			name:         "ollama local model is free",
+			inputTokens:  1000000,
+			outputTokens: 1000000,
+			model:        "ollama/qwen2.5-coder:32b",
+			minCost:      0,
+			maxCost:      0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -3195,3 +3288,123 @@ func TestLocalModeSkipsQualityGates(t *testing.T) {
 		t.Errorf("quality checker factory was called in LocalMode — quality gates should be skipped")
 	}
 }
+
+func TestQualityGates_FailureClassPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		outcome   *QualityOutcome
+		checkErr  error
+		wantClass FailureClass
+		wantRuns  int
+	}{
+		{
+			name: "compile error retried twice",
+			outcome: &QualityOutcome{ShouldRetry: true, GateDetails: []QualityGateDetail{
+				{Name: "build", Error: "undefined: foo"},
+			}},
+			wantClass: FailureClassCompileError,
+			wantRuns:  3,
+		},
+		{
+			name: "test failure retried once",
+			outcome: &QualityOutcome{ShouldRetry: true, GateDetails: []QualityGateDetail{
+				{Name: "build", Passed: true},
+				{Name: "test", Error: "exit 1"},
+			}},
+			wantClass: FailureClassTestFailure,
+			wantRuns:  2,
+		},
+		{
+			name:      "infra not retried",
+			checkErr:  errors.New("go: command not found"),
+			wantClass: FailureClassInfra,
+			wantRuns:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewRunnerWithBackend(&mockSelfReviewBackend{output: "done"})
+			runner.config = &BackendConfig{}
+			runner.SetRecordingEnabled(false)
+			runner.skipPreflightChecks = true
+
+			runs := 0
+			runner.SetQualityCheckerFactory(func(taskID, projectPath string) QualityChecker {
+				runs++
+				return &mockQualityChecker{outcome: tt.outcome, err: tt.checkErr}
+			})
+
+			result, err := runner.Execute(context.Background(), &Task{
+				ID:          "QG-POLICY",
+				Title:       "Failure class policy",
+				Description: "Quality gates keep failing",
+				ProjectPath: t.TempDir(),
+			})
+			if err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			if result.Success {
+				t.Fatal("Execute() succeeded, want quality gate failure")
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("quality gates ran %d times, want %d", runs, tt.wantRuns)
+			}
+			if result.Retry == nil || result.Retry.Class != tt.wantClass || result.Retry.Attempts != tt.wantRuns-1 {
+				t.Errorf("Retry = %+v, want %s with %d retries", result.Retry, tt.wantClass, tt.wantRuns-1)
+			}
+		})
+	}
+}
+
+type recordingHookTarget struct {
+	comments []string
+	labels   []string
+}
+
+func (t *recordingHookTarget) Comment(_ context.Context, body string) error {
+	t.comments = append(t.comments, body)
+	return nil
+}
+
+func (t *recordingHookTarget) AddLabels(_ context.Context, labels []string) error {
+	t.labels = append(t.labels, labels...)
+	return nil
+}
+
+func TestRunner_BeforePRCreateHook(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "pr.star")
+	src := `
+def before_pr_create(event):
+    pilot.set("title", event.title + " [" + event.repo + "]")
+    pilot.set("body", event.body + "\n\nIssue: #%d" % event.number)
+    pilot.label("pr-opened")
+`
+	if err := os.WriteFile(script, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := hooks.New(&hooks.Config{Scripts: []string{script}})
+	if err != nil {
+		t.Fatalf("hooks.New() error = %v", err)
+	}
+
+	runner := NewRunner()
+	task := &Task{ID: "GH-42", SourceRepo: "acme/api", Branch: "pilot/GH-42"}
+
+	// Without hooks the title and body are unchanged
+	if title, body := runner.runBeforePRCreateHook(context.Background(), task, "GH-42: Fix", "Closes #42"); title != "GH-42: Fix" || body != "Closes #42" {
+		t.Errorf("without hooks = %q / %q", title, body)
+	}
+
+	target := &recordingHookTarget{}
+	runner.SetHooks(engine, func(task *Task) hooks.Target { return target })
+	if runner.Hooks() != engine {
+		t.Error("Hooks() did not return the engine")
+	}
+	title, body := runner.runBeforePRCreateHook(context.Background(), task, "GH-42: Fix", "Closes #42")
+	if title != "GH-42: Fix [acme/api]" || body != "Closes #42\n\nIssue: #42" {
+		t.Errorf("with hooks = %q / %q", title, body)
+	}
+	if len(target.labels) != 1 || target.labels[0] != "pr-opened" {
+		t.Errorf("target labels = %v, want [pr-opened]", target.labels)
+	}
+}
@@ -12,6 +12,31 @@ const (
 	SignalTypeExit       = "exit"
 	SignalTypePhase      = "phase"
 	SignalTypeStagnation = "stagnation"
+
+	// Step signals report what the agent is doing right now, finer than
+	// the INIT→COMPLETE phases
+	SignalTypePlan    = "plan"    // Planning the change; message summarizes the plan
+	SignalTypeEdit    = "edit"    // Editing a file; file is required
+	SignalTypeTest    = "test"    // Running tests; package is optional
+	SignalTypeBlocked = "blocked" // Cannot proceed without help; reason says why
+)
+
+// StepKind is the kind of a granular progress step
+type StepKind string
+
+const (
+	StepPlan    StepKind = "PLAN"
+	StepEdit    StepKind = "EDIT"
+	StepTest    StepKind = "TEST"
+	StepBlocked StepKind = "BLOCKED"
+)
+
+// Dashboard phases reported for progress steps
+const (
+	PhasePlanning = "Planning"
+	PhaseEditing  = "Editing"
+	PhaseTesting  = "Testing"
+	PhaseBlocked  = "Blocked"
 )
 
 // PilotSignal represents a v2 structured signal from Navigator
@@ -27,6 +52,45 @@ type PilotSignal struct {
 	Success    bool            `json:"success,omitempty"`
 	Reason     string          `json:"reason,omitempty"`
 	Message    string          `json:"message,omitempty"`
+	File       string          `json:"file,omitempty"`    // Edited file (edit)
+	Package    string          `json:"package,omitempty"` // Tested package (test)
+}
+
+// ProgressStep is a typed progress event parsed from a step signal
+type ProgressStep struct {
+	Kind   StepKind
+	Detail string // File for EDIT, package for TEST, reason for BLOCKED, summary for PLAN
+}
+
+// String renders the step as in the signal contract, e.g. "EDIT main.go"
+func (s ProgressStep) String() string {
+	if s.Detail == "" {
+		return string(s.Kind)
+	}
+	return string(s.Kind) + " " + s.Detail
+}
+
+// Phase returns the dashboard phase shown while the step is in progress
+func (s ProgressStep) Phase() string {
+	switch s.Kind {
+	case StepPlan:
+		return PhasePlanning
+	case StepEdit:
+		return PhaseEditing
+	case StepTest:
+		return PhaseTesting
+	default:
+		return PhaseBlocked
+	}
+}
+
+// IsStepPhase reports whether phase is one reported for progress steps
+func IsStepPhase(phase string) bool {
+	switch phase {
+	case PhasePlanning, PhaseEditing, PhaseTesting, PhaseBlocked:
+		return true
+	}
+	return false
 }
 
 // signalBlockRegex matches ```pilot-signal\n{...}\n```
@@ -113,6 +177,26 @@ func (p *SignalParser) HasExitSignal(signals []PilotSignal) bool {
 	return false
 }
 
+// Step converts a step signal to a typed progress step. ok is false for
+// other signal types and for edit signals without a file.
+func (s PilotSignal) Step() (step ProgressStep, ok bool) {
+	switch s.Type {
+	case SignalTypePlan:
+		return ProgressStep{Kind: StepPlan, Detail: s.Message}, true
+	case SignalTypeEdit:
+		return ProgressStep{Kind: StepEdit, Detail: s.File}, s.File != ""
+	case SignalTypeTest:
+		return ProgressStep{Kind: StepTest, Detail: s.Package}, true
+	case SignalTypeBlocked:
+		reason := s.Reason
+		if reason == "" {
+			reason = s.Message
+		}
+		return ProgressStep{Kind: StepBlocked, Detail: reason}, true
+	}
+	return ProgressStep{}, false
+}
+
 // GetLatestProgress returns the progress from the last status signal
 // Returns -1 if no status signals found
 func (p *SignalParser) GetLatestProgress(signals []PilotSignal) int {
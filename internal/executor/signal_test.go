@@ -3,6 +3,7 @@ package executor
 import (
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -254,3 +255,64 @@ func TestTruncateSignalForLog(t *testing.T) {
 		}
 	}
 }
+
+func TestPilotSignal_Step(t *testing.T) {
+	tests := []struct {
+		signal PilotSignal
+		want   string
+		phase  string
+		ok     bool
+	}{
+		{PilotSignal{Type: SignalTypePlan, Message: "add retry, then tests"}, "PLAN add retry, then tests", PhasePlanning, true},
+		{PilotSignal{Type: SignalTypeEdit, File: "internal/foo.go"}, "EDIT internal/foo.go", PhaseEditing, true},
+		{PilotSignal{Type: SignalTypeEdit}, "", "", false},
+		{PilotSignal{Type: SignalTypeTest, Package: "./internal/..."}, "TEST ./internal/...", PhaseTesting, true},
+		{PilotSignal{Type: SignalTypeTest}, "TEST", PhaseTesting, true},
+		{PilotSignal{Type: SignalTypeBlocked, Message: "no DB access"}, "BLOCKED no DB access", PhaseBlocked, true},
+		{PilotSignal{Type: SignalTypeStatus, Progress: 40}, "", "", false},
+	}
+	for _, tt := range tests {
+		step, ok := tt.signal.Step()
+		if ok != tt.ok {
+			t.Errorf("%s Step() ok = %v, want %v", tt.signal.Type, ok, tt.ok)
+			continue
+		}
+		if ok && (step.String() != tt.want || step.Phase() != tt.phase) {
+			t.Errorf("%s Step() = %q (%s), want %q (%s)", tt.signal.Type, step, step.Phase(), tt.want, tt.phase)
+		}
+	}
+}
+
+func TestRunner_ProgressStepSignals(t *testing.T) {
+	runner := NewRunner()
+	alerts := &stallAlertRecorder{}
+	runner.SetAlertProcessor(alerts)
+
+	var phases, messages []string
+	runner.OnProgress(func(taskID, phase string, progress int, message string) {
+		phases = append(phases, phase)
+		messages = append(messages, message)
+	})
+
+	state := &progressState{phase: "Starting", task: &Task{ID: "GH-1", Title: "Fix login", ProjectPath: "/repo"}}
+	text := "```pilot-signal\n{\"v\":2,\"type\":\"edit\",\"file\":\"auth.go\"}\n```\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"test\",\"package\":\"./auth/...\"}\n```\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"blocked\",\"reason\":\"missing API key\"}\n```"
+	runner.parseNavigatorPatterns("GH-1", text, state)
+	// The same reason again does not raise a second alert
+	runner.parseNavigatorPatterns("GH-1", "```pilot-signal\n{\"type\":\"blocked\",\"reason\":\"missing API key\"}\n```", state)
+
+	if strings.Join(phases, ",") != "Editing,Testing,Blocked,Blocked" {
+		t.Errorf("phases = %v", phases)
+	}
+	if messages[0] != "EDIT auth.go" || messages[2] != "BLOCKED missing API key" {
+		t.Errorf("messages = %v", messages)
+	}
+	if len(alerts.events) != 1 {
+		t.Fatalf("expected 1 alert event, got %d", len(alerts.events))
+	}
+	event := alerts.events[0]
+	if event.Type != AlertEventTypeTaskBlocked || event.Error != "missing API key" || event.TaskTitle != "Fix login" || event.Project != "/repo" {
+		t.Errorf("alert event = %+v", event)
+	}
+}
@@ -13,6 +13,9 @@ const ClassificationSchema = `{"type":"object","properties":{"complexity":{"type
 // EffortSchema for effort classifier
 const EffortSchema = `{"type":"object","properties":{"effort":{"type":"string","enum":["low","medium","high"]},"reason":{"type":"string"}},"required":["effort","reason"]}`
 
+// TriageSchema for the pre-execution triage classifier
+const TriageSchema = `{"type":"object","properties":{"verdict":{"type":"string","enum":["actionable","question","duplicate","missing_criteria"]},"reason":{"type":"string"},"questions":{"type":"array","items":{"type":"string"}}},"required":["verdict","reason"]}`
+
 // PostExecutionSummarySchema for branch/SHA/files extraction
 const PostExecutionSummarySchema = `{"type":"object","properties":{"branch_name":{"type":"string"},"commit_sha":{"type":"string"},"files_changed":{"type":"array","items":{"type":"string"}},"summary":{"type":"string"}},"required":["branch_name","commit_sha"]}`
 
@@ -0,0 +1,233 @@
+package executor
+
+import (
+	"sort"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/replay"
+)
+
+// Phases tokens are attributed to
+const (
+	TokenPhaseResearch   = "research"
+	TokenPhaseImplement  = "implement"
+	TokenPhaseVerify     = "verify"
+	TokenPhaseQualityFix = "quality-fix"
+	TokenPhaseReview     = "review"
+)
+
+// Tool categories tokens are attributed to. Messages without a tool call
+// count as ToolCategoryText.
+const (
+	ToolCategoryRead     = "read"
+	ToolCategoryEdit     = "edit"
+	ToolCategoryShell    = "shell"
+	ToolCategoryWeb      = "web"
+	ToolCategorySubagent = "subagent"
+	ToolCategoryOther    = "other"
+	ToolCategoryText     = "text"
+)
+
+// ToolCategory returns the category of a tool for token attribution
+func ToolCategory(tool string) string {
+	switch tool {
+	case "":
+		return ToolCategoryText
+	case "Read", "Glob", "Grep", "LS", "NotebookRead":
+		return ToolCategoryRead
+	case "Write", "Edit", "MultiEdit", "NotebookEdit":
+		return ToolCategoryEdit
+	case "Bash", "BashOutput", "KillShell":
+		return ToolCategoryShell
+	case "WebFetch", "WebSearch":
+		return ToolCategoryWeb
+	case "Task":
+		return ToolCategorySubagent
+	default:
+		return ToolCategoryOther
+	}
+}
+
+// TokenCount is input and output tokens. Input includes cache reads and
+// writes when the backend reports per-message usage.
+type TokenCount struct {
+	Input  int64 `json:"input"`
+	Output int64 `json:"output"`
+}
+
+// Total returns input plus output tokens
+func (c TokenCount) Total() int64 {
+	return c.Input + c.Output
+}
+
+// TokenBreakdown is where an execution's tokens went
+type TokenBreakdown struct {
+	Phases map[string]TokenCount `json:"phases"`
+	Tools  map[string]TokenCount `json:"tools"`
+}
+
+func newTokenBreakdown() TokenBreakdown {
+	return TokenBreakdown{Phases: make(map[string]TokenCount), Tools: make(map[string]TokenCount)}
+}
+
+func (b TokenBreakdown) add(phase, tool string, c TokenCount) {
+	p := b.Phases[phase]
+	b.Phases[phase] = TokenCount{Input: p.Input + c.Input, Output: p.Output + c.Output}
+	t := b.Tools[tool]
+	b.Tools[tool] = TokenCount{Input: t.Input + c.Input, Output: t.Output + c.Output}
+}
+
+func (b TokenBreakdown) empty() bool {
+	return len(b.Phases) == 0
+}
+
+// Attributions flattens the breakdown for the memory store, phases then
+// tools, largest first
+func (b *TokenBreakdown) Attributions() []*memory.TokenAttribution {
+	if b == nil {
+		return nil
+	}
+	var rows []*memory.TokenAttribution
+	for _, group := range []struct {
+		kind   string
+		counts map[string]TokenCount
+	}{{memory.TokenKindPhase, b.Phases}, {memory.TokenKindTool, b.Tools}} {
+		start := len(rows)
+		for name, c := range group.counts {
+			rows = append(rows, &memory.TokenAttribution{Kind: group.kind, Name: name, InputTokens: c.Input, OutputTokens: c.Output})
+		}
+		sort.Slice(rows[start:], func(i, j int) bool {
+			a, b := rows[start+i], rows[start+j]
+			if a.TotalTokens() != b.TotalTokens() {
+				return a.TotalTokens() > b.TotalTokens()
+			}
+			return a.Name < b.Name
+		})
+	}
+	return rows
+}
+
+// Replay converts the breakdown for an execution recording
+func (b *TokenBreakdown) Replay() *replay.TokenBreakdown {
+	if b == nil {
+		return nil
+	}
+	convert := func(counts map[string]TokenCount) map[string]replay.TokenUsage {
+		usage := make(map[string]replay.TokenUsage, len(counts))
+		for name, c := range counts {
+			usage[name] = replay.TokenUsage{InputTokens: c.Input, OutputTokens: c.Output, TotalTokens: c.Total()}
+		}
+		return usage
+	}
+	return &replay.TokenBreakdown{ByPhase: convert(b.Phases), ByTool: convert(b.Tools)}
+}
+
+// tokenAttribution attributes the tokens of a stream to phases and tools.
+// Per-message usage is attributed to the tool the message called. Backends
+// that only report billed increments have those attributed instead.
+type tokenAttribution struct {
+	phase    string // Overrides the phase derived from progress, e.g. review
+	messages TokenBreakdown
+	billed   TokenBreakdown
+	perMsg   bool // The backend reported per-message usage
+
+	// The last message, which may be split over several events
+	lastID    string
+	lastPhase string
+	lastTool  string
+	last      TokenCount
+}
+
+func newTokenAttribution() *tokenAttribution {
+	return &tokenAttribution{messages: newTokenBreakdown(), billed: newTokenBreakdown()}
+}
+
+// record attributes an event's tokens to phase and the event's tool
+func (a *tokenAttribution) record(phase string, event BackendEvent) {
+	if a == nil {
+		return
+	}
+	if a.phase != "" {
+		phase = a.phase
+	}
+	tool := ToolCategory(event.ToolName)
+
+	if billed := (TokenCount{Input: event.TokensInput, Output: event.TokensOutput}); billed.Total() > 0 {
+		a.billed.add(phase, tool, billed)
+	}
+
+	msg := TokenCount{Input: event.MessageTokensInput, Output: event.MessageTokensOutput}
+	if msg.Total() == 0 {
+		return
+	}
+	a.perMsg = true
+	if event.MessageID != "" && event.MessageID == a.lastID {
+		// Another block of the same message: count it once, against its
+		// tool call rather than its text
+		if a.lastTool == ToolCategoryText && tool != ToolCategoryText {
+			a.messages.add(a.lastPhase, ToolCategoryText, TokenCount{Input: -a.last.Input, Output: -a.last.Output})
+			a.messages.add(a.lastPhase, tool, a.last)
+			a.lastTool = tool
+		}
+		return
+	}
+	a.messages.add(phase, tool, msg)
+	a.lastID, a.lastPhase, a.lastTool, a.last = event.MessageID, phase, tool, msg
+}
+
+// setPhase attributes the following tokens to phase, or to the phase of
+// the stream's progress when phase is empty
+func (a *tokenAttribution) setPhase(phase string) {
+	if a != nil {
+		a.phase = phase
+	}
+}
+
+// add attributes tokens reported outside the stream, e.g. by research
+// subagents
+func (a *tokenAttribution) add(phase, tool string, c TokenCount) {
+	if a == nil {
+		return
+	}
+	a.messages.add(phase, tool, c)
+	a.billed.add(phase, tool, c)
+}
+
+// breakdown returns the attributed tokens, nil when there are none
+func (a *tokenAttribution) breakdown() *TokenBreakdown {
+	if a == nil {
+		return nil
+	}
+	b := a.billed
+	if a.perMsg {
+		b = a.messages
+	}
+	if b.empty() {
+		return nil
+	}
+	// Drop categories a re-attributed message emptied
+	for name, c := range b.Tools {
+		if c.Total() == 0 {
+			delete(b.Tools, name)
+		}
+	}
+	for name, c := range b.Phases {
+		if c.Total() == 0 {
+			delete(b.Phases, name)
+		}
+	}
+	return &b
+}
+
+// tokenPhase maps the progress phase of a stream to the phase its tokens
+// are attributed to
+func tokenPhase(state *progressState) string {
+	switch state.phase {
+	case "Starting", "Init", "Exploring", "Research", "Navigator", "Loop Mode", "Task Mode", PhasePlanning:
+		return TokenPhaseResearch
+	case "Verify", "Complete", PhaseTesting:
+		return TokenPhaseVerify
+	default:
+		return TokenPhaseImplement
+	}
+}
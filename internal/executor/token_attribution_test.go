@@ -0,0 +1,155 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestToolCategory(t *testing.T) {
+	tests := map[string]string{
+		"":          ToolCategoryText,
+		"Read":      ToolCategoryRead,
+		"Grep":      ToolCategoryRead,
+		"Edit":      ToolCategoryEdit,
+		"Write":     ToolCategoryEdit,
+		"Bash":      ToolCategoryShell,
+		"WebSearch": ToolCategoryWeb,
+		"Task":      ToolCategorySubagent,
+		"TodoWrite": ToolCategoryOther,
+	}
+	for tool, want := range tests {
+		if got := ToolCategory(tool); got != want {
+			t.Errorf("ToolCategory(%q) = %q, want %q", tool, got, want)
+		}
+	}
+}
+
+func TestTokenAttribution_PerMessage(t *testing.T) {
+	a := newTokenAttribution()
+
+	// A message with text and a tool call arrives as two events carrying the
+	// same usage; it counts once, against the tool
+	a.record(TokenPhaseResearch, BackendEvent{Type: EventTypeText, MessageID: "m1", MessageTokensInput: 100, MessageTokensOutput: 10})
+	a.record(TokenPhaseResearch, BackendEvent{Type: EventTypeToolUse, ToolName: "Read", MessageID: "m1", MessageTokensInput: 100, MessageTokensOutput: 10})
+	a.record(TokenPhaseImplement, BackendEvent{Type: EventTypeToolUse, ToolName: "Edit", MessageID: "m2", MessageTokensInput: 200, MessageTokensOutput: 20})
+	// The result's billed totals are ignored once messages report usage
+	a.record(TokenPhaseVerify, BackendEvent{Type: EventTypeResult, TokensInput: 5000, TokensOutput: 500})
+
+	b := a.breakdown()
+	if b == nil {
+		t.Fatal("breakdown() = nil")
+	}
+	if got := b.Phases[TokenPhaseResearch]; got != (TokenCount{Input: 100, Output: 10}) {
+		t.Errorf("research = %+v", got)
+	}
+	if got := b.Phases[TokenPhaseImplement]; got != (TokenCount{Input: 200, Output: 20}) {
+		t.Errorf("implement = %+v", got)
+	}
+	if _, ok := b.Phases[TokenPhaseVerify]; ok {
+		t.Errorf("verify should have no tokens: %+v", b.Phases)
+	}
+	if _, ok := b.Tools[ToolCategoryText]; ok {
+		t.Errorf("re-attributed text should be dropped: %+v", b.Tools)
+	}
+	if got := b.Tools[ToolCategoryRead].Total(); got != 110 {
+		t.Errorf("read = %d, want 110", got)
+	}
+}
+
+func TestTokenAttribution_BilledFallback(t *testing.T) {
+	a := newTokenAttribution()
+	a.record(TokenPhaseImplement, BackendEvent{Type: EventTypeToolUse, ToolName: "Bash", TokensInput: 300, TokensOutput: 30})
+	a.setPhase(TokenPhaseQualityFix)
+	a.record(TokenPhaseImplement, BackendEvent{Type: EventTypeToolUse, ToolName: "Edit", TokensInput: 100, TokensOutput: 10})
+	a.setPhase("")
+	a.add(TokenPhaseResearch, ToolCategorySubagent, TokenCount{Input: 50})
+
+	b := a.breakdown()
+	if got := b.Phases[TokenPhaseImplement].Total(); got != 330 {
+		t.Errorf("implement = %d, want 330", got)
+	}
+	if got := b.Phases[TokenPhaseQualityFix].Total(); got != 110 {
+		t.Errorf("quality-fix = %d, want 110", got)
+	}
+	if got := b.Tools[ToolCategorySubagent].Total(); got != 50 {
+		t.Errorf("subagent = %d, want 50", got)
+	}
+}
+
+func TestTokenAttribution_Empty(t *testing.T) {
+	var nilAttribution *tokenAttribution
+	nilAttribution.record(TokenPhaseImplement, BackendEvent{TokensInput: 1})
+	if nilAttribution.breakdown() != nil {
+		t.Error("nil attribution should have no breakdown")
+	}
+	if newTokenAttribution().breakdown() != nil {
+		t.Error("empty attribution should have no breakdown")
+	}
+
+	var b *TokenBreakdown
+	if b.Attributions() != nil || b.Replay() != nil {
+		t.Error("nil breakdown should convert to nil")
+	}
+}
+
+func TestTokenBreakdown_Attributions(t *testing.T) {
+	b := newTokenBreakdown()
+	b.add(TokenPhaseImplement, ToolCategoryEdit, TokenCount{Input: 100, Output: 10})
+	b.add(TokenPhaseVerify, ToolCategoryShell, TokenCount{Input: 500, Output: 50})
+	b.add(TokenPhaseVerify, ToolCategoryEdit, TokenCount{Input: 10})
+
+	rows := b.Attributions()
+	want := []memory.TokenAttribution{
+		{Kind: memory.TokenKindPhase, Name: TokenPhaseVerify, InputTokens: 510, OutputTokens: 50},
+		{Kind: memory.TokenKindPhase, Name: TokenPhaseImplement, InputTokens: 100, OutputTokens: 10},
+		{Kind: memory.TokenKindTool, Name: ToolCategoryShell, InputTokens: 500, OutputTokens: 50},
+		{Kind: memory.TokenKindTool, Name: ToolCategoryEdit, InputTokens: 110, OutputTokens: 10},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i := range want {
+		if *rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, *rows[i], want[i])
+		}
+	}
+
+	replayed := b.Replay()
+	if got := replayed.ByPhase[TokenPhaseVerify].TotalTokens; got != 560 {
+		t.Errorf("replay verify = %d, want 560", got)
+	}
+}
+
+func TestProcessBackendEvent_AttributesTokens(t *testing.T) {
+	runner := NewRunner()
+	state := &progressState{phase: "Starting", tokens: newTokenAttribution()}
+
+	runner.processBackendEvent("TASK-1", BackendEvent{
+		Type:               EventTypeToolUse,
+		ToolName:           "Grep",
+		ToolInput:          map[string]interface{}{"pattern": "func"},
+		MessageID:          "m1",
+		MessageTokensInput: 1000,
+	}, state)
+	state.phase = "Implementing"
+	runner.processBackendEvent("TASK-1", BackendEvent{
+		Type:                EventTypeToolUse,
+		ToolName:            "Edit",
+		ToolInput:           map[string]interface{}{"file_path": "/repo/main.go"},
+		MessageID:           "m2",
+		MessageTokensInput:  400,
+		MessageTokensOutput: 40,
+	}, state)
+
+	b := state.tokens.breakdown()
+	if got := b.Phases[TokenPhaseResearch].Total(); got != 1000 {
+		t.Errorf("research = %d, want 1000", got)
+	}
+	if got := b.Phases[TokenPhaseImplement].Total(); got != 440 {
+		t.Errorf("implement = %d, want 440", got)
+	}
+	if got := b.Tools[ToolCategoryEdit].Total(); got != 440 {
+		t.Errorf("edit = %d, want 440", got)
+	}
+}
@@ -0,0 +1,348 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// NoTriageLabel skips the pre-execution triage for an issue, e.g. after a
+// maintainer decided a flagged issue is actionable as written.
+const NoTriageLabel = "no-triage"
+
+// TriageVerdict is the triage classification of an issue
+type TriageVerdict string
+
+const (
+	TriageActionable      TriageVerdict = "actionable"
+	TriageQuestion        TriageVerdict = "question"         // Asks something rather than requesting a change
+	TriageDuplicate       TriageVerdict = "duplicate"        // Repeats a task that was already picked up
+	TriageMissingCriteria TriageVerdict = "missing_criteria" // No way to tell when the work is done
+)
+
+// TriageResult is the outcome of triaging an issue
+type TriageResult struct {
+	Verdict   TriageVerdict
+	Reason    string
+	Questions []string // Clarifying questions for the requester
+}
+
+// ErrTaskNeedsInfo is returned by Execute when triage stopped a task before
+// execution. Callers ask the requester for clarification instead of
+// reporting a failure.
+var ErrTaskNeedsInfo = errors.New("task needs info")
+
+// NeedsInfoError describes why triage did not execute a task
+type NeedsInfoError struct {
+	Verdict   TriageVerdict
+	Reason    string
+	Questions []string
+}
+
+func (e *NeedsInfoError) Error() string {
+	return fmt.Sprintf("task needs info (%s): %s", e.Verdict, e.Reason)
+}
+
+func (e *NeedsInfoError) Unwrap() error {
+	return ErrTaskNeedsInfo
+}
+
+// Detail encodes the error for the execution record: the verdict and reason
+// on the first line, then one question per line. ParseNeedsInfo reverses it.
+func (e *NeedsInfoError) Detail() string {
+	lines := []string{fmt.Sprintf("%s: %s", e.Verdict, e.Reason)}
+	for _, q := range e.Questions {
+		lines = append(lines, "- "+q)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseNeedsInfo decodes an execution record written by Detail
+func ParseNeedsInfo(detail string) *NeedsInfoError {
+	lines := strings.Split(strings.TrimSpace(detail), "\n")
+	e := &NeedsInfoError{Reason: lines[0]}
+	if verdict, reason, ok := strings.Cut(lines[0], ": "); ok && validTriageVerdict(TriageVerdict(verdict)) {
+		e.Verdict, e.Reason = TriageVerdict(verdict), reason
+	}
+	for _, line := range lines[1:] {
+		if q := strings.TrimSpace(strings.TrimPrefix(line, "- ")); q != "" {
+			e.Questions = append(e.Questions, q)
+		}
+	}
+	return e
+}
+
+// triageResponse is the JSON structure returned by the LLM
+type triageResponse struct {
+	Verdict   string   `json:"verdict"`
+	Reason    string   `json:"reason"`
+	Questions []string `json:"questions"`
+}
+
+const triageSystemPrompt = `You triage issues for an autonomous coding agent before it spends time implementing them. Classify the issue into exactly one verdict.
+
+Verdicts:
+- actionable: Requests a concrete code change and it is clear enough to tell when the work is done. Most issues are actionable.
+- question: Asks how something works, asks for advice or discussion, and requests no change.
+- duplicate: Requests the same change as one of the recent tasks listed below.
+- missing_criteria: Requests a change, but it is so vague that no implementation could be checked against it (e.g. "improve performance", "fix the bug" with no details).
+
+Be conservative: only flag an issue when you are confident. Short issues with an obvious change are actionable. For anything other than actionable, write one to three short questions that would make the issue actionable.
+
+Respond with ONLY a JSON object (no markdown, no explanation):
+{"verdict": "actionable|question|duplicate|missing_criteria", "reason": "one sentence", "questions": ["..."]}`
+
+// Triager runs a cheap classification pass on an issue before execution to
+// catch questions, duplicates and issues without acceptance criteria. It
+// uses Claude Code (Haiku model) like the effort classifier.
+type Triager struct {
+	model               string
+	timeout             time.Duration
+	flag                map[TriageVerdict]bool
+	log                 *slog.Logger
+	useStructuredOutput bool
+
+	// cmdRunner is the function that executes the claude command.
+	// Can be overridden for testing.
+	cmdRunner func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+// NewTriager creates a triager from config. Verdicts not listed in
+// config.Flag are logged but don't stop execution; an empty list flags all.
+func NewTriager(config *TriageConfig) *Triager {
+	t := &Triager{
+		model:   "claude-haiku-4-5-20251001",
+		timeout: 30 * time.Second,
+		flag:    make(map[TriageVerdict]bool),
+		log:     logging.WithComponent("triage"),
+	}
+	t.cmdRunner = t.defaultCmdRunner
+	if config == nil {
+		config = DefaultTriageConfig()
+	}
+	if config.Model != "" {
+		t.model = config.Model
+	}
+	if config.Timeout != "" {
+		if d, err := time.ParseDuration(config.Timeout); err == nil {
+			t.timeout = d
+		}
+	}
+	flag := config.Flag
+	if len(flag) == 0 {
+		flag = []string{string(TriageQuestion), string(TriageDuplicate), string(TriageMissingCriteria)}
+	}
+	for _, v := range flag {
+		t.flag[TriageVerdict(strings.ToLower(v))] = true
+	}
+	return t
+}
+
+// defaultCmdRunner executes the claude command.
+func (t *Triager) defaultCmdRunner(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "claude", args...)
+	return cmd.Output()
+}
+
+// SetUseStructuredOutput configures whether to use Claude Code's --json-schema structured output.
+func (t *Triager) SetUseStructuredOutput(enabled bool) {
+	t.useStructuredOutput = enabled
+}
+
+// Flags reports whether the result should stop execution
+func (t *Triager) Flags(result *TriageResult) bool {
+	return result != nil && result.Verdict != TriageActionable && t.flag[result.Verdict]
+}
+
+// Triage classifies a task. recent lists the titles of recent tasks in the
+// same project, to spot duplicates. Returns nil when classification fails,
+// so execution goes ahead rather than blocking on the triage model.
+func (t *Triager) Triage(ctx context.Context, task *Task, recent []string) *TriageResult {
+	if task == nil {
+		return nil
+	}
+	result, err := t.triage(ctx, task, recent)
+	if err != nil {
+		t.log.Warn("Triage failed, executing task without it",
+			slog.String("task_id", task.ID),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	t.log.Info("Triaged task",
+		slog.String("task_id", task.ID),
+		slog.String("verdict", string(result.Verdict)),
+		slog.String("reason", result.Reason),
+	)
+	return result
+}
+
+// triage calls Claude Code subprocess with Haiku model and parses the response.
+func (t *Triager) triage(ctx context.Context, task *Task, recent []string) (*TriageResult, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Issue Title\n%s\n\n## Issue Description\n%s", task.Title, task.Description))
+	if len(task.AcceptanceCriteria) > 0 {
+		sb.WriteString("\n\n## Acceptance Criteria\n")
+		for _, c := range task.AcceptanceCriteria {
+			sb.WriteString("- " + c + "\n")
+		}
+	}
+	userContent := sb.String()
+
+	// Truncate to avoid token overflow (description can be very long)
+	const maxChars = 4000
+	if len(userContent) > maxChars {
+		userContent = userContent[:maxChars] + "\n...[truncated]"
+	}
+	if len(recent) > 0 {
+		userContent += "\n\n## Recent Tasks In This Project\n- " + strings.Join(recent, "\n- ")
+	}
+
+	prompt := fmt.Sprintf("%s\n\n---\n\n%s", triageSystemPrompt, userContent)
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	args := []string{
+		"--print",
+		"-p", prompt,
+		"--model", t.model,
+		"--output-format", "text",
+	}
+	if t.useStructuredOutput {
+		args = []string{
+			"--print",
+			"-p", prompt,
+			"--model", t.model,
+			"--output-format", "json",
+			"--json-schema", TriageSchema,
+		}
+	}
+
+	output, err := t.cmdRunner(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("claude command failed: %w", err)
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("empty response from claude")
+	}
+
+	if t.useStructuredOutput {
+		structured, err := extractStructuredOutput(output)
+		if err != nil {
+			return nil, fmt.Errorf("extract structured output: %w", err)
+		}
+		return parseTriageResponse(string(structured))
+	}
+	return parseTriageResponse(string(output))
+}
+
+// parseTriageResponse extracts the verdict from the LLM's JSON response.
+func parseTriageResponse(text string) (*TriageResult, error) {
+	// Strip any markdown code fence wrapper
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	text = strings.TrimSpace(text)
+
+	var resp triageResponse
+	if err := json.Unmarshal([]byte(text), &resp); err != nil {
+		return nil, fmt.Errorf("parse triage JSON: %w (raw: %s)", err, text)
+	}
+
+	verdict := TriageVerdict(strings.ToLower(strings.TrimSpace(resp.Verdict)))
+	if !validTriageVerdict(verdict) {
+		return nil, fmt.Errorf("unknown triage verdict: %q", resp.Verdict)
+	}
+	result := &TriageResult{Verdict: verdict, Reason: strings.Join(strings.Fields(resp.Reason), " ")}
+	for _, q := range resp.Questions {
+		if q = strings.Join(strings.Fields(q), " "); q != "" {
+			result.Questions = append(result.Questions, q)
+		}
+	}
+	return result, nil
+}
+
+func validTriageVerdict(v TriageVerdict) bool {
+	switch v {
+	case TriageActionable, TriageQuestion, TriageDuplicate, TriageMissingCriteria:
+		return true
+	}
+	return false
+}
+
+// needsTriage reports whether a task gets the triage pass. Tasks Pilot
+// created itself, resumed or clarified tasks and issues labeled no-triage
+// skip it.
+func needsTriage(task *Task) bool {
+	if task.LocalMode || task.FromPR > 0 || task.ResumeSessionID != "" || task.Clarified {
+		return false
+	}
+	for _, label := range task.Labels {
+		if strings.EqualFold(label, NoTriageLabel) || strings.EqualFold(label, "autopilot-fix") {
+			return false
+		}
+	}
+	return true
+}
+
+// triageTask runs the triage pass. It returns a result and error only when
+// the task should not be executed.
+func (r *Runner) triageTask(ctx context.Context, task *Task) (*ExecutionResult, error) {
+	r.reportProgress(task.ID, "Triage", 1, "Checking the issue is actionable...")
+	triage := r.triager.Triage(ctx, task, r.recentTaskTitles(task))
+	if !r.triager.Flags(triage) {
+		return nil, nil
+	}
+
+	r.log.Info("Task needs info",
+		slog.String("task_id", task.ID),
+		slog.String("verdict", string(triage.Verdict)),
+		slog.String("reason", triage.Reason),
+	)
+	r.saveLogEntry(task.ID, "info", fmt.Sprintf("Triage flagged task as %s: %s", triage.Verdict, triage.Reason))
+	result := &ExecutionResult{
+		TaskID:  task.ID,
+		Success: false,
+		Error:   "needs info: " + triage.Reason,
+	}
+	return result, fmt.Errorf("task %s: %w", task.ID, &NeedsInfoError{
+		Verdict:   triage.Verdict,
+		Reason:    triage.Reason,
+		Questions: triage.Questions,
+	})
+}
+
+// recentTaskTitles returns the titles of recent tasks in the task's project
+func (r *Runner) recentTaskTitles(task *Task) []string {
+	if r.logStore == nil {
+		return nil
+	}
+	execs, err := r.logStore.GetRecentExecutions(100)
+	if err != nil {
+		r.log.Debug("Failed to load recent tasks for triage", slog.Any("error", err))
+		return nil
+	}
+	const maxTitles = 20
+	seen := make(map[string]bool)
+	var titles []string
+	for _, e := range execs {
+		if e.ProjectPath != task.ProjectPath || e.TaskID == task.ID || e.TaskTitle == "" || seen[e.TaskID] {
+			continue
+		}
+		seen[e.TaskID] = true
+		titles = append(titles, fmt.Sprintf("%s: %s (%s)", e.TaskID, e.TaskTitle, e.Status))
+		if len(titles) == maxTitles {
+			break
+		}
+	}
+	return titles
+}
@@ -0,0 +1,175 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mockTriageRunner creates a test runner that returns a canned response.
+func mockTriageRunner(response string, err error) func(ctx context.Context, args ...string) ([]byte, error) {
+	return func(ctx context.Context, args ...string) ([]byte, error) {
+		if err != nil {
+			return nil, err
+		}
+		return []byte(response), nil
+	}
+}
+
+func TestParseTriageResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      TriageVerdict
+		questions int
+		wantErr   bool
+	}{
+		{"actionable", `{"verdict":"actionable","reason":"clear change"}`, TriageActionable, 0, false},
+		{"question with questions", `{"verdict":"question","reason":"asks how","questions":["What should change?",""]}`, TriageQuestion, 1, false},
+		{"code fence", "```json\n{\"verdict\":\"duplicate\",\"reason\":\"same as GH-1\"}\n```", TriageDuplicate, 0, false},
+		{"uppercase", `{"verdict":"MISSING_CRITERIA","reason":"vague"}`, TriageMissingCriteria, 0, false},
+		{"unknown verdict", `{"verdict":"spam","reason":"x"}`, "", 0, true},
+		{"invalid JSON", `not json`, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTriageResponse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Verdict != tt.want {
+				t.Errorf("Verdict = %q, want %q", result.Verdict, tt.want)
+			}
+			if len(result.Questions) != tt.questions {
+				t.Errorf("Questions = %v, want %d", result.Questions, tt.questions)
+			}
+		})
+	}
+}
+
+func TestTriager_Flags(t *testing.T) {
+	all := NewTriager(&TriageConfig{Enabled: true})
+	for _, v := range []TriageVerdict{TriageQuestion, TriageDuplicate, TriageMissingCriteria} {
+		if !all.Flags(&TriageResult{Verdict: v}) {
+			t.Errorf("default config should flag %s", v)
+		}
+	}
+	if all.Flags(&TriageResult{Verdict: TriageActionable}) {
+		t.Error("actionable should never be flagged")
+	}
+	if all.Flags(nil) {
+		t.Error("nil result should not be flagged")
+	}
+
+	questionsOnly := NewTriager(&TriageConfig{Enabled: true, Flag: []string{"question"}})
+	if !questionsOnly.Flags(&TriageResult{Verdict: TriageQuestion}) {
+		t.Error("question should be flagged")
+	}
+	if questionsOnly.Flags(&TriageResult{Verdict: TriageDuplicate}) {
+		t.Error("duplicate should not be flagged when not listed")
+	}
+}
+
+func TestTriager_Triage(t *testing.T) {
+	tr := NewTriager(nil)
+	tr.cmdRunner = mockTriageRunner(`{"verdict":"question","reason":"Asks how retries work","questions":["Should retries change?"]}`, nil)
+
+	result := tr.Triage(context.Background(), &Task{ID: "GH-1", Title: "How do retries work?"}, []string{"GH-0: Add retries (completed)"})
+	if result == nil {
+		t.Fatal("expected result")
+	}
+	if result.Verdict != TriageQuestion || result.Reason != "Asks how retries work" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestTriager_FailsOpen(t *testing.T) {
+	tr := NewTriager(nil)
+	tr.cmdRunner = mockTriageRunner("", errors.New("claude not found"))
+	if result := tr.Triage(context.Background(), &Task{ID: "GH-1"}, nil); result != nil {
+		t.Errorf("expected nil on error, got %+v", result)
+	}
+
+	tr.cmdRunner = mockTriageRunner("garbage", nil)
+	if result := tr.Triage(context.Background(), &Task{ID: "GH-1"}, nil); result != nil {
+		t.Errorf("expected nil on unparseable response, got %+v", result)
+	}
+
+	if result := tr.Triage(context.Background(), nil, nil); result != nil {
+		t.Errorf("expected nil for nil task, got %+v", result)
+	}
+}
+
+func TestNeedsInfoError_DetailRoundTrip(t *testing.T) {
+	orig := &NeedsInfoError{
+		Verdict:   TriageMissingCriteria,
+		Reason:    "No target latency given",
+		Questions: []string{"Which endpoint is slow?", "What latency is acceptable?"},
+	}
+	got := ParseNeedsInfo(orig.Detail())
+	if got.Verdict != orig.Verdict || got.Reason != orig.Reason {
+		t.Errorf("ParseNeedsInfo = %+v, want %+v", got, orig)
+	}
+	if len(got.Questions) != 2 || got.Questions[1] != orig.Questions[1] {
+		t.Errorf("Questions = %v, want %v", got.Questions, orig.Questions)
+	}
+
+	// Records without a verdict keep the whole line as the reason
+	legacy := ParseNeedsInfo("something odd")
+	if legacy.Verdict != "" || legacy.Reason != "something odd" {
+		t.Errorf("unexpected parse of legacy detail: %+v", legacy)
+	}
+}
+
+func TestNeedsTriage(t *testing.T) {
+	tests := []struct {
+		name string
+		task *Task
+		want bool
+	}{
+		{"issue", &Task{ID: "GH-1"}, true},
+		{"local mode", &Task{ID: "GH-1", LocalMode: true}, false},
+		{"from PR", &Task{ID: "GH-1", FromPR: 12}, false},
+		{"resumed", &Task{ID: "GH-1", ResumeSessionID: "abc"}, false},
+		{"clarified", &Task{ID: "GH-1", Clarified: true}, false},
+		{"no-triage label", &Task{ID: "GH-1", Labels: []string{"pilot", "No-Triage"}}, false},
+		{"autopilot fix", &Task{ID: "GH-1", Labels: []string{"autopilot-fix"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsTriage(tt.task); got != tt.want {
+				t.Errorf("needsTriage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunner_TriageTask(t *testing.T) {
+	runner := NewRunner()
+	runner.triager = NewTriager(nil)
+	runner.triager.cmdRunner = mockTriageRunner(`{"verdict":"duplicate","reason":"Same as GH-3","questions":["Is this different from GH-3?"]}`, nil)
+
+	result, err := runner.triageTask(context.Background(), &Task{ID: "GH-4", Title: "Add retries"})
+	if !errors.Is(err, ErrTaskNeedsInfo) {
+		t.Fatalf("expected ErrTaskNeedsInfo, got %v", err)
+	}
+	var needsInfo *NeedsInfoError
+	if !errors.As(err, &needsInfo) || needsInfo.Verdict != TriageDuplicate || len(needsInfo.Questions) != 1 {
+		t.Errorf("unexpected error detail: %+v", needsInfo)
+	}
+	if result == nil || result.Success {
+		t.Errorf("expected failed result, got %+v", result)
+	}
+
+	runner.triager.cmdRunner = mockTriageRunner(`{"verdict":"actionable","reason":"clear"}`, nil)
+	if result, err := runner.triageTask(context.Background(), &Task{ID: "GH-5"}); result != nil || err != nil {
+		t.Errorf("actionable task should proceed, got %+v, %v", result, err)
+	}
+}
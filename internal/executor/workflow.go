@@ -35,6 +35,31 @@ Output this block at start of execution to confirm mode.
 
 const autonomousWorkflowInstructions = `## Autonomous Execution Workflow
 
+### Step Signals
+
+Between phase signals, report each step as you start it so progress is visible
+while you work. Emit one block per step:
+
+` + "```" + `pilot-signal
+{"v":2,"type":"plan","message":"add retry to webhook client, then tests"}
+` + "```" + `
+` + "```" + `pilot-signal
+{"v":2,"type":"edit","file":"internal/webhooks/client.go"}
+` + "```" + `
+` + "```" + `pilot-signal
+{"v":2,"type":"test","package":"./internal/webhooks/..."}
+` + "```" + `
+` + "```" + `pilot-signal
+{"v":2,"type":"blocked","reason":"WEBHOOK_SECRET is not set in the test environment"}
+` + "```" + `
+
+- **plan**: once you know what to change; ` + "`message`" + ` is a one-line summary
+- **edit**: before changing a file; ` + "`file`" + ` is required
+- **test**: before running tests; ` + "`package`" + ` is the package pattern
+- **blocked**: when you cannot proceed without outside help (missing credentials, unclear requirement, external dependency); ` + "`reason`" + ` says what is missing. Then stop without committing: Pilot asks the requester and resumes this session with their answer. If you find a way forward instead, continue with your next step.
+
+---
+
 ### Phase 1: INIT (0-10%)
 
 **Do**:
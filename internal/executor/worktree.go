@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"log/slog"
 	"os"
@@ -17,6 +18,7 @@ type PooledWorktree struct {
 	Path      string    // Absolute path to the worktree directory
 	CreatedAt time.Time // When this worktree was created
 	InUse     bool      // Whether currently acquired
+	IdleSince time.Time // When it was last released
 }
 
 // WorktreeManager handles git worktree creation and cleanup for isolated task execution.
@@ -32,6 +34,12 @@ type WorktreeManager struct {
 	pool     []*PooledWorktree // Pre-created worktrees for reuse
 	poolSize int               // Configured pool size (0 = disabled)
 	poolMu   sync.Mutex        // Protects pool operations
+
+	// Autoscaling: the pool grows on demand up to maxSize and shrinks back
+	// towards target once extra worktrees idle for idleTimeout
+	maxSize     int // 0 = static pool
+	target      int
+	idleTimeout time.Duration
 }
 
 // NewWorktreeManager creates a worktree manager for the given repository.
@@ -226,8 +234,7 @@ func (m *WorktreeManager) WarmPool(ctx context.Context) error {
 
 // createPooledWorktree creates a single worktree for the pool.
 func (m *WorktreeManager) createPooledWorktree(ctx context.Context, index int) (*PooledWorktree, error) {
-	// Pool paths use consistent naming: /tmp/pilot-worktree-pool-N/
-	worktreePath := filepath.Join(os.TempDir(), fmt.Sprintf("pilot-worktree-pool-%d", index))
+	worktreePath := m.poolPath(index)
 
 	// Remove any existing directory at this path
 	_ = os.RemoveAll(worktreePath)
@@ -254,9 +261,119 @@ func (m *WorktreeManager) createPooledWorktree(ctx context.Context, index int) (
 		Path:      worktreePath,
 		CreatedAt: time.Now(),
 		InUse:     false,
+		IdleSince: time.Now(),
 	}, nil
 }
 
+// poolPath returns the directory of the pooled worktree with the given index.
+// Static pools use /tmp/pilot-worktree-pool-N/; autoscaled pools add a hash
+// of the repository path since every project has its own pool.
+func (m *WorktreeManager) poolPath(index int) string {
+	if m.maxSize > 0 {
+		sum := sha256.Sum256([]byte(m.repoPath))
+		return filepath.Join(os.TempDir(), fmt.Sprintf("pilot-worktree-pool-%x-%d", sum[:4], index))
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("pilot-worktree-pool-%d", index))
+}
+
+// SetAutoscale lets the pool grow past its warm size, up to maxSize
+// worktrees, whenever all pooled worktrees are busy. Worktrees above the
+// target set by ScaleTo are removed after idling for idleTimeout.
+func (m *WorktreeManager) SetAutoscale(maxSize int, idleTimeout time.Duration) {
+	if idleTimeout <= 0 {
+		idleTimeout = defaultPoolIdleTimeout
+	}
+	m.poolMu.Lock()
+	defer m.poolMu.Unlock()
+	m.maxSize = maxSize
+	m.idleTimeout = idleTimeout
+	if m.target < m.poolSize {
+		m.target = m.poolSize
+	}
+}
+
+// ScaleTo sets the size an autoscaled pool converges to, clamped between
+// the warm size and the maximum, and removes idle worktrees above it that
+// have been idle for the idle timeout. Growth happens in Acquire.
+func (m *WorktreeManager) ScaleTo(target int) {
+	m.poolMu.Lock()
+	defer m.poolMu.Unlock()
+	if m.maxSize <= 0 {
+		return
+	}
+	m.target = max(m.poolSize, min(target, m.maxSize))
+
+	kept := m.pool[:0]
+	excess := len(m.pool) - m.target
+	for _, wt := range m.pool {
+		if excess > 0 && !wt.InUse && time.Since(wt.IdleSince) >= m.idleTimeout {
+			excess--
+			removeCmd := exec.Command("git", "-C", m.repoPath, "worktree", "remove", "--force", wt.Path)
+			_ = removeCmd.Run()
+			_ = os.RemoveAll(wt.Path)
+			slog.Info("Removed idle pooled worktree", slog.String("path", wt.Path))
+			continue
+		}
+		kept = append(kept, wt)
+	}
+	m.pool = kept
+}
+
+// Target returns the size an autoscaled pool converges to
+func (m *WorktreeManager) Target() int {
+	m.poolMu.Lock()
+	defer m.poolMu.Unlock()
+	return m.target
+}
+
+// growPool adds a worktree to an autoscaled pool when every pooled worktree
+// is busy and the pool is below its maximum. The new worktree is returned
+// already marked in use, or nil when the pool cannot grow.
+func (m *WorktreeManager) growPool(ctx context.Context) *PooledWorktree {
+	m.poolMu.Lock()
+	if m.maxSize <= 0 || len(m.pool) >= m.maxSize {
+		m.poolMu.Unlock()
+		return nil
+	}
+	// Reserve the lowest free index so concurrent growth never shares a path
+	used := make(map[string]bool, len(m.pool))
+	for _, wt := range m.pool {
+		used[wt.Path] = true
+	}
+	index := 0
+	for used[m.poolPath(index)] {
+		index++
+	}
+	placeholder := &PooledWorktree{Path: m.poolPath(index), InUse: true}
+	m.pool = append(m.pool, placeholder)
+	m.poolMu.Unlock()
+
+	m.createMu.Lock()
+	wt, err := m.createPooledWorktree(ctx, index)
+	m.createMu.Unlock()
+
+	m.poolMu.Lock()
+	defer m.poolMu.Unlock()
+	for i, pooled := range m.pool {
+		if pooled == placeholder {
+			m.pool = append(m.pool[:i], m.pool[i+1:]...)
+			break
+		}
+	}
+	if err != nil {
+		slog.Warn("Failed to grow worktree pool", slog.String("repo", m.repoPath), slog.Any("error", err))
+		return nil
+	}
+	wt.InUse = true
+	m.pool = append(m.pool, wt)
+	slog.Info("Grew worktree pool",
+		slog.String("repo", m.repoPath),
+		slog.Int("size", len(m.pool)),
+		slog.Int("max", m.maxSize),
+	)
+	return wt
+}
+
 // Acquire gets a worktree from the pool and prepares it for the given branch.
 // If the pool is empty, falls back to CreateWorktreeWithBranch.
 // GH-1078: Reuses pooled worktrees by running git clean -fd && git checkout -B <branch>.
@@ -277,6 +394,11 @@ func (m *WorktreeManager) Acquire(ctx context.Context, taskID, branchName, baseB
 
 	m.poolMu.Unlock()
 
+	// Autoscaled pools grow while every worktree is busy
+	if acquired == nil {
+		acquired = m.growPool(ctx)
+	}
+
 	// Pool empty or no available worktrees - fall back to standard creation
 	if acquired == nil {
 		slog.Debug("Pool empty, falling back to CreateWorktreeWithBranch",
@@ -407,6 +529,7 @@ func (m *WorktreeManager) Release(taskID string, wt *PooledWorktree) {
 	// Mark as available
 	m.poolMu.Lock()
 	wt.InUse = false
+	wt.IdleSince = time.Now()
 	m.poolMu.Unlock()
 
 	slog.Debug("Released worktree back to pool",
@@ -480,6 +603,18 @@ func (m *WorktreeManager) PoolSize() int {
 	return m.poolSize
 }
 
+// PoolUsage returns the number of pooled worktrees and how many are in use.
+func (m *WorktreeManager) PoolUsage() (size, inUse int) {
+	m.poolMu.Lock()
+	defer m.poolMu.Unlock()
+	for _, wt := range m.pool {
+		if wt.InUse {
+			inUse++
+		}
+	}
+	return len(m.pool), inUse
+}
+
 // PoolAvailable returns the number of available (not in use) worktrees in the pool.
 func (m *WorktreeManager) PoolAvailable() int {
 	m.poolMu.Lock()
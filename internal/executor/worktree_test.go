@@ -1245,3 +1245,54 @@ func TestWorktreePoolSizeZeroDisabled(t *testing.T) {
 		t.Errorf("expected branch pilot/no-pool, got %q", strings.TrimSpace(string(output)))
 	}
 }
+
+// TestWorktreePoolAutoscale tests that an autoscaled pool grows while all
+// worktrees are busy, stops at its maximum and shrinks idle worktrees.
+func TestWorktreePoolAutoscale(t *testing.T) {
+	localRepo, remoteRepo := setupTestRepoWithRemote(t)
+	defer func() { _ = os.RemoveAll(localRepo) }()
+	defer func() { _ = os.RemoveAll(remoteRepo) }()
+
+	ctx := context.Background()
+	manager := NewWorktreeManagerWithPool(localRepo, 0)
+	manager.SetAutoscale(2, time.Nanosecond)
+	defer manager.Close()
+
+	var results []*WorktreeResult
+	for i := 0; i < 3; i++ {
+		result, err := manager.Acquire(ctx, fmt.Sprintf("GH-%d", i), fmt.Sprintf("pilot/autoscale-%d", i), "main")
+		if err != nil {
+			t.Fatalf("Acquire %d failed: %v", i, err)
+		}
+		results = append(results, result)
+	}
+
+	// The third task falls back to a standalone worktree at max size
+	if size, inUse := manager.PoolUsage(); size != 2 || inUse != 2 {
+		t.Errorf("PoolUsage() = %d/%d, want 2 pooled, 2 in use", size, inUse)
+	}
+	if strings.Contains(results[2].Path, "pilot-worktree-pool") {
+		t.Errorf("third worktree %s should not be pooled", results[2].Path)
+	}
+	if results[0].Path == results[1].Path || !strings.Contains(results[0].Path, "pilot-worktree-pool-") {
+		t.Errorf("pooled paths = %s, %s", results[0].Path, results[1].Path)
+	}
+
+	for _, result := range results {
+		result.Cleanup()
+	}
+
+	manager.ScaleTo(1)
+	if size, inUse := manager.PoolUsage(); size != 1 || inUse != 0 {
+		t.Errorf("after ScaleTo(1) PoolUsage() = %d/%d, want 1 idle", size, inUse)
+	}
+	if manager.Target() != 1 {
+		t.Errorf("Target() = %d, want 1", manager.Target())
+	}
+
+	// Targets are clamped to the maximum
+	manager.ScaleTo(10)
+	if manager.Target() != 2 {
+		t.Errorf("Target() = %d, want clamped to 2", manager.Target())
+	}
+}
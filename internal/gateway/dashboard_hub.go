@@ -0,0 +1,322 @@
+package gateway
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/gorilla/websocket"
+)
+
+// Dashboard hub message types sent on /ws/dashboard/live.
+const (
+	HubMessageSnapshot  = "snapshot"  // Full state, sent once on connect
+	HubMessageTasks     = "tasks"     // Task list replaced
+	HubMessageLog       = "log"       // Log line appended
+	HubMessageTokens    = "tokens"    // Token counters updated
+	HubMessageCompleted = "completed" // Task appended to history
+	HubMessageAutopilot = "autopilot" // Autopilot PR states changed
+)
+
+const (
+	// hubMaxLogs and hubMaxCompleted match what the TUI dashboard keeps.
+	hubMaxLogs      = 100
+	hubMaxCompleted = 5
+	// hubAutopilotInterval is how often autopilot PR states are polled for
+	// each connected client.
+	hubAutopilotInterval = 2 * time.Second
+)
+
+// DashboardTask is a task row in the dashboard task list.
+type DashboardTask struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Phase    string `json:"phase"`
+	Progress int    `json:"progress"`
+	Duration string `json:"duration"`
+	IssueURL string `json:"issueURL,omitempty"`
+	PRURL    string `json:"prURL,omitempty"`
+	Step     string `json:"step,omitempty"`
+}
+
+// DashboardTokens holds the session token counters.
+type DashboardTokens struct {
+	InputTokens  int `json:"inputTokens"`
+	OutputTokens int `json:"outputTokens"`
+	TotalTokens  int `json:"totalTokens"`
+}
+
+// DashboardCompletedTask is an entry in the dashboard task history.
+type DashboardCompletedTask struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Status      string    `json:"status"`
+	Duration    string    `json:"duration"`
+	CompletedAt time.Time `json:"completedAt"`
+	ParentID    string    `json:"parentID,omitempty"`
+	IsEpic      bool      `json:"isEpic,omitempty"`
+}
+
+// DashboardPR is an autopilot PR as shown in the dashboard.
+type DashboardPR struct {
+	Number        int    `json:"number"`
+	URL           string `json:"url"`
+	Stage         string `json:"stage"`
+	CIStatus      string `json:"ciStatus"`
+	Error         string `json:"error,omitempty"`
+	BranchName    string `json:"branchName"`
+	Repo          string `json:"repo,omitempty"`
+	QueuePosition int    `json:"queuePosition,omitempty"`
+}
+
+// DashboardState is the full dashboard state sent to clients on connect.
+type DashboardState struct {
+	Tasks     []DashboardTask          `json:"tasks"`
+	Logs      []string                 `json:"logs"`
+	Tokens    DashboardTokens          `json:"tokens"`
+	Completed []DashboardCompletedTask `json:"completed"`
+	Autopilot []DashboardPR            `json:"autopilot"`
+}
+
+// HubMessage is a message pushed to remote dashboard clients.
+type HubMessage struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// DashboardHub mirrors the state rendered by the TUI dashboard so remote
+// dashboards can follow it over WebSocket. Feed it the same updates the TUI
+// receives; it keeps a snapshot for new clients and fans out each update.
+// DashboardHub is safe for concurrent use.
+type DashboardHub struct {
+	mu    sync.Mutex
+	state DashboardState
+	subs  map[chan HubMessage]struct{}
+}
+
+// NewDashboardHub creates an empty dashboard hub.
+func NewDashboardHub() *DashboardHub {
+	return &DashboardHub{
+		state: DashboardState{
+			Tasks:     []DashboardTask{},
+			Logs:      []string{},
+			Completed: []DashboardCompletedTask{},
+		},
+		subs: make(map[chan HubMessage]struct{}),
+	}
+}
+
+// UpdateTasks replaces the task list.
+func (h *DashboardHub) UpdateTasks(tasks []DashboardTask) {
+	tasks = append([]DashboardTask{}, tasks...)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.state.Tasks = tasks
+	h.broadcast(HubMessage{Type: HubMessageTasks, Data: tasks})
+}
+
+// AddLog appends a log line.
+func (h *DashboardHub) AddLog(line string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.state.Logs = append(h.state.Logs, line)
+	if len(h.state.Logs) > hubMaxLogs {
+		h.state.Logs = h.state.Logs[len(h.state.Logs)-hubMaxLogs:]
+	}
+	h.broadcast(HubMessage{Type: HubMessageLog, Data: line})
+}
+
+// UpdateTokens sets the session token counters.
+func (h *DashboardHub) UpdateTokens(input, output int) {
+	tokens := DashboardTokens{InputTokens: input, OutputTokens: output, TotalTokens: input + output}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.state.Tokens = tokens
+	h.broadcast(HubMessage{Type: HubMessageTokens, Data: tokens})
+}
+
+// AddCompletedTask appends a finished task to the history.
+func (h *DashboardHub) AddCompletedTask(task DashboardCompletedTask) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.state.Completed = append(h.state.Completed, task)
+	if len(h.state.Completed) > hubMaxCompleted {
+		h.state.Completed = h.state.Completed[len(h.state.Completed)-hubMaxCompleted:]
+	}
+	h.broadcast(HubMessage{Type: HubMessageCompleted, Data: task})
+}
+
+// Snapshot returns a copy of the current state. Autopilot PRs are not part of
+// the hub's state; they are read from the server's AutopilotProvider.
+func (h *DashboardHub) Snapshot() DashboardState {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.snapshotLocked()
+}
+
+func (h *DashboardHub) snapshotLocked() DashboardState {
+	return DashboardState{
+		Tasks:     append([]DashboardTask{}, h.state.Tasks...),
+		Logs:      append([]string{}, h.state.Logs...),
+		Tokens:    h.state.Tokens,
+		Completed: append([]DashboardCompletedTask{}, h.state.Completed...),
+	}
+}
+
+// subscribe registers a client and returns its channel with the state at the
+// time of subscription, so no update is missed or applied twice.
+func (h *DashboardHub) subscribe() (chan HubMessage, DashboardState) {
+	ch := make(chan HubMessage, eventBufferSize)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[ch] = struct{}{}
+	return ch, h.snapshotLocked()
+}
+
+func (h *DashboardHub) unsubscribe(ch chan HubMessage) {
+	h.mu.Lock()
+	delete(h.subs, ch)
+	h.mu.Unlock()
+}
+
+// broadcast must be called with h.mu held. Slow clients miss updates rather
+// than blocking the dashboard.
+func (h *DashboardHub) broadcast(msg HubMessage) {
+	for ch := range h.subs {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// SetDashboardHub configures the hub served on /ws/dashboard/live.
+func (s *Server) SetDashboardHub(hub *DashboardHub) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dashboardHub = hub
+}
+
+// dashboardPRs returns the autopilot PRs from the configured provider.
+func (s *Server) dashboardPRs() []DashboardPR {
+	s.mu.RLock()
+	provider := s.autopilotProvider
+	s.mu.RUnlock()
+
+	prs := []DashboardPR{}
+	if provider == nil {
+		return prs
+	}
+	for _, pr := range provider.GetActivePRs() {
+		prs = append(prs, DashboardPR{
+			Number:        pr.PRNumber,
+			URL:           pr.PRURL,
+			Stage:         pr.Stage,
+			CIStatus:      pr.CIStatus,
+			Error:         pr.Error,
+			BranchName:    pr.BranchName,
+			Repo:          pr.Repo,
+			QueuePosition: pr.QueuePosition,
+		})
+	}
+	return prs
+}
+
+// handleDashboardHub upgrades the connection to WebSocket and streams the
+// dashboard state: a snapshot on connect, then each update as it happens.
+func (s *Server) handleDashboardHub(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	hub := s.dashboardHub
+	s.mu.RUnlock()
+
+	if hub == nil {
+		http.Error(w, "dashboard hub not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := s.hubUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logging.WithComponent("gateway").Error("dashboard hub WS upgrade error", slog.Any("error", err))
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	log := logging.WithComponent("gateway")
+	log.Info("remote dashboard connected", slog.String("remote", r.RemoteAddr))
+
+	sub, state := hub.subscribe()
+	defer hub.unsubscribe(sub)
+
+	prs := s.dashboardPRs()
+	state.Autopilot = prs
+	if err := writeHubMessage(conn, HubMessage{Type: HubMessageSnapshot, Data: state}); err != nil {
+		log.Debug("dashboard hub WS initial send failed", slog.Any("error", err))
+		return
+	}
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPingInterval + wsPongTimeout))
+	})
+	_ = conn.SetReadDeadline(time.Now().Add(wsPingInterval + wsPongTimeout))
+
+	// Read pump: drain client messages (none expected) and detect disconnect.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	poll := time.NewTicker(hubAutopilotInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case msg := <-sub:
+			if err := writeHubMessage(conn, msg); err != nil {
+				return
+			}
+		case <-poll.C:
+			latest := s.dashboardPRs()
+			if reflect.DeepEqual(latest, prs) {
+				continue
+			}
+			prs = latest
+			if err := writeHubMessage(conn, HubMessage{Type: HubMessageAutopilot, Data: prs}); err != nil {
+				return
+			}
+		case <-ping.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func writeHubMessage(conn *websocket.Conn, msg HubMessage) error {
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteJSON(msg)
+}
+
+// withQueryToken lets browser WebSocket clients, which cannot set headers,
+// pass the bearer token as ?token=. An Authorization header takes precedence.
+func withQueryToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,212 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDashboardHub_State(t *testing.T) {
+	hub := NewDashboardHub()
+	for i := 0; i < hubMaxLogs+5; i++ {
+		hub.AddLog("line")
+	}
+	for i := 0; i < hubMaxCompleted+2; i++ {
+		hub.AddCompletedTask(DashboardCompletedTask{ID: "GH-1", Status: "success"})
+	}
+	hub.UpdateTokens(100, 50)
+	tasks := []DashboardTask{{ID: "GH-2", Status: "running"}}
+	hub.UpdateTasks(tasks)
+	tasks[0].Status = "mutated"
+
+	state := hub.Snapshot()
+	if len(state.Logs) != hubMaxLogs || len(state.Completed) != hubMaxCompleted {
+		t.Errorf("kept %d logs and %d completed, want %d and %d", len(state.Logs), len(state.Completed), hubMaxLogs, hubMaxCompleted)
+	}
+	if state.Tokens.TotalTokens != 150 {
+		t.Errorf("TotalTokens = %d, want 150", state.Tokens.TotalTokens)
+	}
+	if len(state.Tasks) != 1 || state.Tasks[0].Status != "running" {
+		t.Errorf("Tasks = %+v, want the tasks as passed in", state.Tasks)
+	}
+}
+
+// readHubMessage reads one message and decodes its data into data.
+func readHubMessage(t *testing.T, conn *websocket.Conn, data interface{}) string {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	if err := json.Unmarshal(msg.Data, data); err != nil {
+		t.Fatalf("decode %s data: %v", msg.Type, err)
+	}
+	return msg.Type
+}
+
+func TestDashboardHub_WebSocket(t *testing.T) {
+	hub := NewDashboardHub()
+	hub.AddLog("started")
+	hub.UpdateTasks([]DashboardTask{{ID: "GH-1", Title: "Add caching", Status: "running", Progress: 40}})
+
+	srv := NewServer(&Config{Host: "127.0.0.1", Port: 0})
+	srv.SetDashboardHub(hub)
+	srv.SetAutopilotProvider(&mockAutopilotProvider{
+		activePRs: []*AutopilotPRState{{PRNumber: 7, Stage: "waiting_ci", Repo: "acme/api"}},
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(srv.handleDashboardHub))
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("WebSocket dial failed: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	var state DashboardState
+	if typ := readHubMessage(t, conn, &state); typ != HubMessageSnapshot {
+		t.Fatalf("first message = %q, want snapshot", typ)
+	}
+	if len(state.Logs) != 1 || len(state.Tasks) != 1 || state.Tasks[0].Progress != 40 {
+		t.Errorf("snapshot = %+v", state)
+	}
+	if len(state.Autopilot) != 1 || state.Autopilot[0].Number != 7 || state.Autopilot[0].Repo != "acme/api" {
+		t.Errorf("snapshot autopilot = %+v", state.Autopilot)
+	}
+
+	hub.UpdateTokens(10, 5)
+	var tokens DashboardTokens
+	if typ := readHubMessage(t, conn, &tokens); typ != HubMessageTokens || tokens.TotalTokens != 15 {
+		t.Errorf("got %s %+v, want tokens with total 15", typ, tokens)
+	}
+
+	hub.AddLog("GH-1: Implementing")
+	var line string
+	if typ := readHubMessage(t, conn, &line); typ != HubMessageLog || line != "GH-1: Implementing" {
+		t.Errorf("got %s %q, want log line", typ, line)
+	}
+}
+
+func TestDashboardHub_NotConfigured(t *testing.T) {
+	srv := NewServer(&Config{Host: "127.0.0.1", Port: 0})
+	w := httptest.NewRecorder()
+	srv.handleDashboardHub(w, httptest.NewRequest(http.MethodGet, "/ws/dashboard/live", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestWithQueryToken(t *testing.T) {
+	auth := NewAuthenticator(&AuthConfig{Type: AuthTypeAPIToken, Token: "secret"})
+	handler := withQueryToken(auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   int
+	}{
+		{"query token", "/ws/dashboard/live?token=secret", "", http.StatusOK},
+		{"header token", "/ws/dashboard/live", "Bearer secret", http.StatusOK},
+		{"wrong query token", "/ws/dashboard/live?token=nope", "", http.StatusUnauthorized},
+		{"header wins over query", "/ws/dashboard/live?token=secret", "Bearer nope", http.StatusUnauthorized},
+		{"no token", "/ws/dashboard/live", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardHub_AllowedOrigins(t *testing.T) {
+	srv := NewServer(&Config{Host: "127.0.0.1", Port: 0, DashboardOrigins: []string{"https://ops.example.com/"}})
+	srv.SetDashboardHub(NewDashboardHub())
+
+	ts := httptest.NewServer(http.HandlerFunc(srv.handleDashboardHub))
+	defer ts.Close()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"", true},
+		{"http://localhost:3000", true},
+		{"https://ops.example.com", true},
+		{"https://OPS.example.com", true},
+		{"https://evil.example.com", false},
+		{"http://ops.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+			conn, _, err := websocket.DefaultDialer.Dial(url, header)
+			if conn != nil {
+				_ = conn.Close()
+			}
+			if tt.allowed && err != nil {
+				t.Errorf("origin %q rejected: %v", tt.origin, err)
+			}
+			if !tt.allowed && err == nil {
+				t.Errorf("origin %q accepted, want rejected", tt.origin)
+			}
+		})
+	}
+
+	// The control-plane upgrader keeps accepting localhost only
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Header.Set("Origin", "https://ops.example.com")
+	if srv.upgrader.CheckOrigin(r) {
+		t.Error("dashboard origins must not widen the /ws upgrader")
+	}
+}
+
+func TestDashboardHub_RequiresToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		auth   *AuthConfig
+		target string
+		want   int
+	}{
+		{"no auth configured", nil, "/ws/dashboard/live", http.StatusForbidden},
+		{"no auth configured, query token", nil, "/ws/dashboard/live?token=secret", http.StatusForbidden},
+		{"missing token", &AuthConfig{Type: AuthTypeAPIToken, Token: "secret"}, "/ws/dashboard/live", http.StatusUnauthorized},
+		{"query token", &AuthConfig{Type: AuthTypeAPIToken, Token: "secret"}, "/ws/dashboard/live?token=secret", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewServerWithAuth(&Config{Host: "127.0.0.1", Port: 0}, tt.auth)
+			// No hub is set, so an authorized request ends in 503
+			handler := withQueryToken(srv.requireToken(http.HandlerFunc(srv.handleDashboardHub)))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Event types streamed on /events.
+const (
+	EventProgress  = "progress"  // Runner progress callback
+	EventTokens    = "tokens"    // Token usage update
+	EventAutopilot = "autopilot" // Autopilot PR stage transition
+)
+
+const (
+	// sseHeartbeatInterval is how often a comment line is sent to keep idle
+	// connections (and intermediate proxies) from timing out.
+	sseHeartbeatInterval = 15 * time.Second
+	// eventBufferSize is the per-subscriber buffer; events are dropped for
+	// subscribers that fall further behind.
+	eventBufferSize = 64
+)
+
+// Event is a live update pushed to /events subscribers.
+type Event struct {
+	ID     uint64      `json:"id"`
+	Type   string      `json:"type"`
+	TaskID string      `json:"task_id,omitempty"`
+	Time   time.Time   `json:"time"`
+	Data   interface{} `json:"data"`
+}
+
+// ProgressEventData is the payload of EventProgress events.
+type ProgressEventData struct {
+	Phase    string `json:"phase"`
+	Progress int    `json:"progress"`
+	Message  string `json:"message"`
+}
+
+// TokenEventData is the payload of EventTokens events.
+type TokenEventData struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+}
+
+// AutopilotEventData is the payload of EventAutopilot events.
+type AutopilotEventData struct {
+	PRNumber  int    `json:"pr_number"`
+	PRURL     string `json:"pr_url,omitempty"`
+	Repo      string `json:"repo,omitempty"`
+	FromStage string `json:"from_stage"`
+	Stage     string `json:"stage"`
+	Error     string `json:"error,omitempty"`
+}
+
+// PublishEvent sends ev to all /events subscribers. ID and Time are filled in
+// when zero. It never blocks: slow subscribers miss events.
+func (s *Server) PublishEvent(ev Event) {
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+
+	s.eventSeq++
+	ev.ID = s.eventSeq
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	for ch := range s.eventSubs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+func (s *Server) subscribeEvents() chan Event {
+	ch := make(chan Event, eventBufferSize)
+	s.eventMu.Lock()
+	s.eventSubs[ch] = struct{}{}
+	s.eventMu.Unlock()
+	return ch
+}
+
+func (s *Server) unsubscribeEvents(ch chan Event) {
+	s.eventMu.Lock()
+	delete(s.eventSubs, ch)
+	s.eventMu.Unlock()
+}
+
+// closeEventStreams ends all open /events streams so shutdown does not wait
+// for them to time out.
+func (s *Server) closeEventStreams() {
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+	for ch := range s.eventSubs {
+		delete(s.eventSubs, ch)
+		close(ch)
+	}
+}
+
+// handleEvents streams events as Server-Sent Events. Optional query params
+// narrow the stream: ?task_id=GH-1,GH-2 and ?type=progress,tokens,autopilot.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	taskIDs := splitFilter(r.URL.Query().Get("task_id"))
+	types := splitFilter(r.URL.Query().Get("type"))
+
+	// The server's WriteTimeout would otherwise cut the stream after 15s.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	sub := s.subscribeEvents()
+	defer s.unsubscribeEvents(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprint(w, "retry: 3000\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case ev, ok := <-sub:
+			if !ok {
+				return
+			}
+			if (len(types) > 0 && !types[ev.Type]) || (len(taskIDs) > 0 && !taskIDs[ev.TaskID]) {
+				continue
+			}
+			data, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// splitFilter parses a comma-separated query value into a set.
+func splitFilter(value string) map[string]bool {
+	set := make(map[string]bool)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
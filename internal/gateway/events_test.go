@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandleEvents(t *testing.T) {
+	s := NewServer(&Config{Host: "127.0.0.1", Port: 0})
+	ts := httptest.NewServer(http.HandlerFunc(s.handleEvents))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/events?task_id=GH-1&type=progress,autopilot")
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	// Wait for the handler to subscribe before publishing.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		s.eventMu.Lock()
+		n := len(s.eventSubs)
+		s.eventMu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber not registered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	s.PublishEvent(Event{Type: EventProgress, TaskID: "GH-2", Data: ProgressEventData{Phase: "Other task"}})
+	s.PublishEvent(Event{Type: EventTokens, TaskID: "GH-1", Data: TokenEventData{InputTokens: 10}})
+	s.PublishEvent(Event{Type: EventProgress, TaskID: "GH-1", Data: ProgressEventData{Phase: "Implementing", Progress: 40}})
+
+	reader := bufio.NewReader(resp.Body)
+	var eventName, data string
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			eventName = strings.TrimSpace(strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		}
+	}
+
+	if eventName != EventProgress {
+		t.Errorf("event = %q, want %q", eventName, EventProgress)
+	}
+	var ev struct {
+		ID     uint64            `json:"id"`
+		TaskID string            `json:"task_id"`
+		Data   ProgressEventData `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(data), &ev); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if ev.ID != 3 || ev.TaskID != "GH-1" || ev.Data.Phase != "Implementing" || ev.Data.Progress != 40 {
+		t.Errorf("event = %+v, want the third event (GH-1 progress)", ev)
+	}
+
+	s.closeEventStreams()
+	if rest, err := io.ReadAll(reader); err != nil || strings.TrimSpace(string(rest)) != "" {
+		t.Errorf("expected stream to end after closeEventStreams, got %q (err %v)", rest, err)
+	}
+}
+
+func TestHandleEvents_MethodNotAllowed(t *testing.T) {
+	s := NewServer(&Config{Host: "127.0.0.1", Port: 0})
+	w := httptest.NewRecorder()
+	s.handleEvents(w, httptest.NewRequest(http.MethodPost, "/events", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestEventsRequireToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		auth           *AuthConfig
+		authHeader     string
+		expectedStatus int
+	}{
+		{"no auth configured", nil, "", http.StatusForbidden},
+		{"claude-code auth", &AuthConfig{Type: AuthTypeClaudeCode}, "", http.StatusForbidden},
+		{"missing token", &AuthConfig{Type: AuthTypeAPIToken, Token: "secret"}, "", http.StatusUnauthorized},
+		{"wrong token", &AuthConfig{Type: AuthTypeAPIToken, Token: "secret"}, "Bearer nope", http.StatusUnauthorized},
+		{"valid token", &AuthConfig{Type: AuthTypeAPIToken, Token: "secret"}, "Bearer secret", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServerWithAuth(&Config{Host: "127.0.0.1", Port: 0}, tt.auth)
+			// POST reaches handleEvents' method check without opening a stream
+			r := httptest.NewRequest(http.MethodPost, "/events", nil)
+			if tt.authHeader != "" {
+				r.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+			s.requireToken(http.HandlerFunc(s.handleEvents)).ServeHTTP(w, r)
+			if w.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
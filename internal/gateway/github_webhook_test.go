@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestGithubWebhook_SignatureAndDeliveryDedup(t *testing.T) {
+	const secret = "webhook-secret"
+	server := NewServer(&Config{Host: "127.0.0.1", Port: 9090, GithubWebhookSecret: secret})
+
+	var routed int
+	server.Router().RegisterWebhookHandler("github", func(payload map[string]interface{}) {
+		routed++
+		if payload["_delivery"] != "delivery-1" {
+			t.Errorf("_delivery = %v, want delivery-1", payload["_delivery"])
+		}
+	})
+
+	body := `{"action":"created","issue":{"number":1}}`
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	send := func(signature, delivery string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "issue_comment")
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		if signature != "" {
+			req.Header.Set("X-Hub-Signature-256", signature)
+		}
+		w := httptest.NewRecorder()
+		server.handleGithubWebhook(w, req)
+		return w.Code
+	}
+
+	// Unsigned requests are rejected without claiming the delivery ID
+	if code := send("", "delivery-1"); code != http.StatusUnauthorized {
+		t.Errorf("unsigned delivery status = %d, want 401", code)
+	}
+	if code := send(signature, "delivery-1"); code != http.StatusOK {
+		t.Errorf("signed delivery status = %d, want 200", code)
+	}
+	if code := send(signature, "delivery-1"); code != http.StatusOK {
+		t.Errorf("redelivery status = %d, want 200", code)
+	}
+	if code := send(signature, ""); code != http.StatusBadRequest {
+		t.Errorf("delivery without ID status = %d, want 400", code)
+	}
+	if routed != 1 {
+		t.Errorf("routed %d deliveries, want 1", routed)
+	}
+}
+
+func TestGithubWebhook_DeliveryStoreOutlivesServer(t *testing.T) {
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	var routed int
+	send := func() int {
+		// A new server per delivery, as after a restart
+		server := NewServer(&Config{Host: "127.0.0.1", Port: 9090})
+		server.SetDeliveryStore(store)
+		server.Router().RegisterWebhookHandler("github", func(map[string]interface{}) { routed++ })
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(`{"action":"opened"}`))
+		req.Header.Set("X-GitHub-Event", "issues")
+		req.Header.Set("X-GitHub-Delivery", "delivery-1")
+		w := httptest.NewRecorder()
+		server.handleGithubWebhook(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := send(); code != http.StatusOK {
+			t.Errorf("delivery %d status = %d, want 200", i+1, code)
+		}
+	}
+	if routed != 1 {
+		t.Errorf("routed %d deliveries, want 1", routed)
+	}
+}
@@ -0,0 +1,300 @@
+package gateway
+
+import (
+	_ "embed"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// portalOpenAPISpec describes the /api/v1/portal endpoints for Backstage
+// plugins and internal dashboards.
+//
+//go:embed portal_openapi.json
+var portalOpenAPISpec []byte
+
+const (
+	portalDefaultDays  = 30
+	portalDefaultTasks = 10
+	// portalScanLimit caps how many executions are read per request.
+	portalScanLimit = 1000
+)
+
+// PortalRepoResolver maps an execution's project path to the repository
+// name reported by the portal API (typically "owner/repo"). Returning ""
+// falls back to the project directory name.
+type PortalRepoResolver func(projectPath string) string
+
+// SetPortalRepoResolver sets how the portal API names repositories.
+// Must be called before Start().
+func (s *Server) SetPortalRepoResolver(resolver PortalRepoResolver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.portalRepoResolver = resolver
+}
+
+// --- JSON response types ---
+
+type portalReposResponse struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	WindowDays  int                 `json:"windowDays"`
+	Repos       []portalRepoSummary `json:"repos"`
+}
+
+type portalRepoSummary struct {
+	Repo        string            `json:"repo"`
+	ProjectPath string            `json:"projectPath,omitempty"`
+	Metrics     portalRepoMetrics `json:"metrics"`
+	RecentTasks []portalTask      `json:"recentTasks"`
+	OpenPRs     []portalPR        `json:"openPRs"`
+}
+
+type portalRepoMetrics struct {
+	TotalTasks     int        `json:"totalTasks"`
+	SucceededTasks int        `json:"succeededTasks"`
+	FailedTasks    int        `json:"failedTasks"`
+	SuccessRate    float64    `json:"successRate"`
+	PRsCreated     int        `json:"prsCreated"`
+	AvgDurationMs  int64      `json:"avgDurationMs"`
+	TotalCostUSD   float64    `json:"totalCostUSD"`
+	LastActivityAt *time.Time `json:"lastActivityAt,omitempty"`
+}
+
+type portalTask struct {
+	ID          string     `json:"id"`
+	IssueID     string     `json:"issueID"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	PRURL       string     `json:"prURL,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	DurationMs  int64      `json:"durationMs"`
+}
+
+type portalPR struct {
+	Number   int    `json:"number"`
+	URL      string `json:"url"`
+	Branch   string `json:"branch"`
+	Stage    string `json:"stage"`
+	CIStatus string `json:"ciStatus"`
+	Error    string `json:"error,omitempty"`
+}
+
+// --- Handlers ---
+
+// handlePortalRepos returns an activity summary for every repo with tasks in
+// the window or open autopilot PRs.
+func (s *Server) handlePortalRepos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, ok := s.portalSummaries(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, resp)
+}
+
+// handlePortalRepo returns the summary for a single repo, addressed as
+// /api/v1/portal/repos/{owner}/{repo} (or /api/v1/portal/repos/{name}).
+func (s *Server) handlePortalRepo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/portal/repos/"), "/")
+	if name == "" {
+		http.Error(w, "repo not specified", http.StatusBadRequest)
+		return
+	}
+
+	resp, ok := s.portalSummaries(w, r)
+	if !ok {
+		return
+	}
+	for _, repo := range resp.Repos {
+		if strings.EqualFold(repo.Repo, name) {
+			writeJSON(w, repo)
+			return
+		}
+	}
+	http.Error(w, "repo not found", http.StatusNotFound)
+}
+
+// handlePortalOpenAPI serves the OpenAPI 3 spec for the portal endpoints.
+func (s *Server) handlePortalOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(portalOpenAPISpec)
+}
+
+// portalSummaries builds per-repo summaries from the dashboard store and the
+// autopilot provider. Writes an error response and returns false on failure.
+func (s *Server) portalSummaries(w http.ResponseWriter, r *http.Request) (*portalReposResponse, bool) {
+	s.mu.RLock()
+	store := s.dashboardStore
+	provider := s.autopilotProvider
+	resolver := s.portalRepoResolver
+	s.mu.RUnlock()
+
+	if store == nil {
+		http.Error(w, "dashboard store not configured", http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	days := portalQueryInt(r, "days", portalDefaultDays)
+	taskLimit := portalQueryInt(r, "tasks", portalDefaultTasks)
+
+	execs, err := store.GetRecentExecutions(portalScanLimit)
+	if err != nil {
+		http.Error(w, "failed to fetch executions", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+	byRepo := make(map[string]*portalRepoSummary)
+	summaryFor := func(name string) *portalRepoSummary {
+		if sum, ok := byRepo[name]; ok {
+			return sum
+		}
+		sum := &portalRepoSummary{Repo: name, RecentTasks: []portalTask{}, OpenPRs: []portalPR{}}
+		byRepo[name] = sum
+		return sum
+	}
+
+	totalDuration := make(map[string]int64)
+	for _, exec := range execs {
+		if exec.CreatedAt.Before(since) {
+			continue
+		}
+		name := portalRepoName(resolver, exec.ProjectPath)
+		sum := summaryFor(name)
+		if sum.ProjectPath == "" {
+			sum.ProjectPath = exec.ProjectPath
+		}
+		addPortalExecution(sum, exec, taskLimit)
+		totalDuration[name] += exec.DurationMs
+	}
+
+	for name, sum := range byRepo {
+		m := &sum.Metrics
+		if finished := m.SucceededTasks + m.FailedTasks; finished > 0 {
+			m.SuccessRate = float64(m.SucceededTasks) / float64(finished)
+			m.AvgDurationMs = totalDuration[name] / int64(finished)
+		}
+	}
+
+	if provider != nil {
+		for _, pr := range provider.GetActivePRs() {
+			if !portalPROpen(pr.Stage) {
+				continue
+			}
+			name := pr.Repo
+			if name == "" {
+				name = "unknown"
+			}
+			sum := summaryFor(name)
+			sum.OpenPRs = append(sum.OpenPRs, portalPR{
+				Number:   pr.PRNumber,
+				URL:      pr.PRURL,
+				Branch:   pr.BranchName,
+				Stage:    pr.Stage,
+				CIStatus: pr.CIStatus,
+				Error:    pr.Error,
+			})
+		}
+	}
+
+	resp := &portalReposResponse{
+		GeneratedAt: now,
+		WindowDays:  days,
+		Repos:       make([]portalRepoSummary, 0, len(byRepo)),
+	}
+	for _, sum := range byRepo {
+		sort.Slice(sum.OpenPRs, func(i, j int) bool { return sum.OpenPRs[i].Number < sum.OpenPRs[j].Number })
+		resp.Repos = append(resp.Repos, *sum)
+	}
+	sort.Slice(resp.Repos, func(i, j int) bool { return resp.Repos[i].Repo < resp.Repos[j].Repo })
+	return resp, true
+}
+
+// addPortalExecution folds one execution into a repo summary. Executions
+// arrive newest first, so the first taskLimit become the recent tasks.
+func addPortalExecution(sum *portalRepoSummary, exec *memory.Execution, taskLimit int) {
+	m := &sum.Metrics
+	m.TotalTasks++
+	switch exec.Status {
+	case "completed":
+		m.SucceededTasks++
+	case "failed":
+		m.FailedTasks++
+	}
+	if exec.PRUrl != "" {
+		m.PRsCreated++
+	}
+	m.TotalCostUSD += exec.EstimatedCostUSD
+
+	last := exec.CreatedAt
+	if exec.CompletedAt != nil {
+		last = *exec.CompletedAt
+	}
+	if m.LastActivityAt == nil || last.After(*m.LastActivityAt) {
+		m.LastActivityAt = &last
+	}
+
+	if len(sum.RecentTasks) < taskLimit {
+		sum.RecentTasks = append(sum.RecentTasks, portalTask{
+			ID:          exec.ID,
+			IssueID:     issueIDFromTaskID(exec.TaskID),
+			Title:       exec.TaskTitle,
+			Status:      normalizeDashboardStatus(exec.Status),
+			PRURL:       exec.PRUrl,
+			CreatedAt:   exec.CreatedAt,
+			CompletedAt: exec.CompletedAt,
+			DurationMs:  exec.DurationMs,
+		})
+	}
+}
+
+// portalRepoName names the repo for a project path, preferring the resolver.
+func portalRepoName(resolver PortalRepoResolver, projectPath string) string {
+	if resolver != nil {
+		if name := resolver(projectPath); name != "" {
+			return name
+		}
+	}
+	if projectPath == "" {
+		return "unknown"
+	}
+	return filepath.Base(filepath.Clean(projectPath))
+}
+
+// portalPROpen reports whether an autopilot stage means the PR is still open.
+func portalPROpen(stage string) bool {
+	switch stage {
+	case "merged", "post_merge_ci", "releasing", "canary_ci", "canary_soak":
+		return false
+	}
+	return true
+}
+
+func portalQueryInt(r *http.Request, key string, def int) int {
+	if v := r.URL.Query().Get(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return def
+}
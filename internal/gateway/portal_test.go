@@ -0,0 +1,128 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func newTestPortalServer() *Server {
+	now := time.Now().UTC()
+	completedAt := now.Add(-30 * time.Minute)
+	s := newTestServerWithDashboard(&mockDashboardStore{
+		executions: []*memory.Execution{
+			{ID: "exec-1", TaskID: "GH-3", Status: "running", ProjectPath: "/src/api", CreatedAt: now},
+			{ID: "exec-2", TaskID: "GH-2", Status: "completed", ProjectPath: "/src/api", PRUrl: "https://github.com/org/api/pull/7",
+				DurationMs: 60000, EstimatedCostUSD: 0.5, CreatedAt: now.Add(-time.Hour), CompletedAt: &completedAt},
+			{ID: "exec-3", TaskID: "GH-1", Status: "failed", ProjectPath: "/src/api", DurationMs: 20000, CreatedAt: now.Add(-2 * time.Hour)},
+			{ID: "exec-4", TaskID: "GH-9", Status: "completed", ProjectPath: "/src/web", CreatedAt: now.Add(-3 * time.Hour)},
+			{ID: "exec-old", TaskID: "GH-0", Status: "completed", ProjectPath: "/src/api", CreatedAt: now.AddDate(0, 0, -60)},
+		},
+	})
+	s.autopilotProvider = &mockAutopilotProvider{activePRs: []*AutopilotPRState{
+		{PRNumber: 7, PRURL: "https://github.com/org/api/pull/7", Stage: "waiting_ci", CIStatus: "pending", Repo: "org/api"},
+		{PRNumber: 5, Stage: "merged", Repo: "org/api"},
+	}}
+	s.portalRepoResolver = func(projectPath string) string {
+		if projectPath == "/src/api" {
+			return "org/api"
+		}
+		return ""
+	}
+	return s
+}
+
+func TestHandlePortalRepos(t *testing.T) {
+	s := newTestPortalServer()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portal/repos?tasks=2", nil)
+	w := httptest.NewRecorder()
+	s.handlePortalRepos(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp portalReposResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.WindowDays != portalDefaultDays || len(resp.Repos) != 2 {
+		t.Fatalf("got windowDays=%d repos=%d, want %d and 2", resp.WindowDays, len(resp.Repos), portalDefaultDays)
+	}
+
+	api := resp.Repos[0]
+	if api.Repo != "org/api" || resp.Repos[1].Repo != "web" {
+		t.Fatalf("repos = %q, %q; want org/api, web", api.Repo, resp.Repos[1].Repo)
+	}
+	m := api.Metrics
+	if m.TotalTasks != 3 || m.SucceededTasks != 1 || m.FailedTasks != 1 || m.PRsCreated != 1 {
+		t.Errorf("metrics = %+v, old execution should be outside the window", m)
+	}
+	if m.SuccessRate != 0.5 || m.AvgDurationMs != 40000 || m.TotalCostUSD != 0.5 {
+		t.Errorf("rate=%v avg=%d cost=%v", m.SuccessRate, m.AvgDurationMs, m.TotalCostUSD)
+	}
+	if len(api.RecentTasks) != 2 || api.RecentTasks[0].IssueID != "GH-3" {
+		t.Errorf("recentTasks = %+v, want newest 2", api.RecentTasks)
+	}
+	if len(api.OpenPRs) != 1 || api.OpenPRs[0].Number != 7 {
+		t.Errorf("openPRs = %+v, want only unmerged PR 7", api.OpenPRs)
+	}
+}
+
+func TestHandlePortalRepo(t *testing.T) {
+	s := newTestPortalServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portal/repos/org/api", nil)
+	w := httptest.NewRecorder()
+	s.handlePortalRepo(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var repo portalRepoSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &repo); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if repo.Repo != "org/api" || repo.Metrics.TotalTasks != 3 {
+		t.Errorf("repo = %+v", repo)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/portal/repos/org/missing", nil)
+	w = httptest.NewRecorder()
+	s.handlePortalRepo(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown repo, got %d", w.Code)
+	}
+}
+
+func TestHandlePortalRepos_NoStore(t *testing.T) {
+	s := NewServer(&Config{Host: "127.0.0.1", Port: 9090})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portal/repos", nil)
+	w := httptest.NewRecorder()
+	s.handlePortalRepos(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestHandlePortalOpenAPI(t *testing.T) {
+	s := NewServer(&Config{Host: "127.0.0.1", Port: 9090})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portal/openapi.json", nil)
+	w := httptest.NewRecorder()
+	s.handlePortalOpenAPI(w, req)
+
+	var spec struct {
+		OpenAPI string                 `json:"openapi"`
+		Paths   map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+	for _, path := range []string{"/api/v1/portal/repos", "/api/v1/portal/repos/{owner}/{repo}"} {
+		if _, ok := spec.Paths[path]; !ok {
+			t.Errorf("spec missing path %s", path)
+		}
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"sort"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/executor"
 )
 
 // PrometheusExporter formats metrics for Prometheus scraping.
 type PrometheusExporter struct {
-	metricsSource MetricsSource
+	metricsSource     MetricsSource
+	concurrencySource ConcurrencySource
 }
 
 // MetricsSource provides metrics data for the exporter.
@@ -20,6 +23,12 @@ type MetricsSource interface {
 	HistogramSnapshot() autopilot.HistogramData
 }
 
+// ConcurrencySource provides execution slot and worktree pool usage.
+// Satisfied by *executor.Runner.
+type ConcurrencySource interface {
+	ConcurrencySnapshot() executor.ConcurrencySnapshot
+}
+
 // NewPrometheusExporter creates a new Prometheus exporter.
 func NewPrometheusExporter(source MetricsSource) *PrometheusExporter {
 	return &PrometheusExporter{metricsSource: source}
@@ -27,6 +36,11 @@ func NewPrometheusExporter(source MetricsSource) *PrometheusExporter {
 
 // WritePrometheus writes metrics in Prometheus text format to the writer.
 func (e *PrometheusExporter) WritePrometheus(w io.Writer) error {
+	if e.metricsSource == nil {
+		e.writeConcurrency(w)
+		writeGitHubRateLimits(w)
+		return nil
+	}
 	snap := e.metricsSource.Snapshot()
 	hist := e.metricsSource.HistogramSnapshot()
 
@@ -133,9 +147,138 @@ func (e *PrometheusExporter) WritePrometheus(w io.Writer) error {
 		hist.CIWaitDurations,
 		[]float64{30, 60, 120, 300, 600, 900, 1200, 1800, 3600}) // 30s, 1m, 2m, 5m, 10m, 15m, 20m, 30m, 1h
 
+	e.writeConcurrency(w)
+	writeGitHubRateLimits(w)
 	return nil
 }
 
+// writeConcurrency writes execution slot and worktree pool gauges. Waiting
+// slots are back-pressure from per-repo and per-project limits.
+func (e *PrometheusExporter) writeConcurrency(w io.Writer) {
+	if e.concurrencySource == nil {
+		return
+	}
+	snap := e.concurrencySource.ConcurrencySnapshot()
+
+	writeHelp(w, "pilot_execution_slots_active", "Tasks running per repo or project")
+	writeType(w, "pilot_execution_slots_active", "gauge")
+	for _, s := range snap.Slots {
+		writeGaugeLabeled(w, "pilot_execution_slots_active", float64(s.Active), "scope", s.Scope, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_execution_slots_limit", "Concurrency limit per repo or project (0 = unlimited)")
+	writeType(w, "pilot_execution_slots_limit", "gauge")
+	for _, s := range snap.Slots {
+		writeGaugeLabeled(w, "pilot_execution_slots_limit", float64(s.Limit), "scope", s.Scope, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_execution_slots_waiting", "Tasks waiting for a slot per repo or project")
+	writeType(w, "pilot_execution_slots_waiting", "gauge")
+	for _, s := range snap.Slots {
+		writeGaugeLabeled(w, "pilot_execution_slots_waiting", float64(s.Waiting), "scope", s.Scope, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_execution_throttled_total", "Tasks that waited for a slot per repo or project")
+	writeType(w, "pilot_execution_throttled_total", "counter")
+	for _, s := range snap.Slots {
+		writeCounter(w, "pilot_execution_throttled_total", s.Throttled, "scope", s.Scope, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_execution_slot_wait_seconds_total", "Time tasks spent waiting for a slot per repo or project")
+	writeType(w, "pilot_execution_slot_wait_seconds_total", "counter")
+	for _, s := range snap.Slots {
+		writeGaugeLabeled(w, "pilot_execution_slot_wait_seconds_total", s.WaitTime.Seconds(), "scope", s.Scope, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_worktree_pool_size", "Worktrees in each autoscaled pool")
+	writeType(w, "pilot_worktree_pool_size", "gauge")
+	for _, p := range snap.Pools {
+		writeGaugeLabeled(w, "pilot_worktree_pool_size", float64(p.Size), "project", p.ProjectPath)
+	}
+
+	writeHelp(w, "pilot_worktree_pool_in_use", "Pooled worktrees acquired by tasks")
+	writeType(w, "pilot_worktree_pool_in_use", "gauge")
+	for _, p := range snap.Pools {
+		writeGaugeLabeled(w, "pilot_worktree_pool_in_use", float64(p.InUse), "project", p.ProjectPath)
+	}
+
+	writeHelp(w, "pilot_worktree_pool_target", "Size each autoscaled pool is converging to")
+	writeType(w, "pilot_worktree_pool_target", "gauge")
+	for _, p := range snap.Pools {
+		writeGaugeLabeled(w, "pilot_worktree_pool_target", float64(p.Target), "project", p.ProjectPath)
+	}
+}
+
+// writeGitHubRateLimits writes quota and queue gauges for each GitHub token
+// in use. Clients sharing a token share one limiter, so the quota is
+// reported once per token.
+func writeGitHubRateLimits(w io.Writer) {
+	snaps := github.RateLimitSnapshots()
+	if len(snaps) == 0 {
+		return
+	}
+
+	writeHelp(w, "pilot_github_ratelimit_remaining", "GitHub API requests left in the current window")
+	writeType(w, "pilot_github_ratelimit_remaining", "gauge")
+	for _, s := range snaps {
+		for _, q := range s.Quotas {
+			writeGaugeLabeled(w, "pilot_github_ratelimit_remaining", float64(q.Remaining), "key", s.Key, "resource", q.Resource)
+		}
+	}
+
+	writeHelp(w, "pilot_github_ratelimit_limit", "GitHub API requests allowed per window")
+	writeType(w, "pilot_github_ratelimit_limit", "gauge")
+	for _, s := range snaps {
+		for _, q := range s.Quotas {
+			writeGaugeLabeled(w, "pilot_github_ratelimit_limit", float64(q.Limit), "key", s.Key, "resource", q.Resource)
+		}
+	}
+
+	writeHelp(w, "pilot_github_ratelimit_reset_timestamp_seconds", "When the GitHub API window resets")
+	writeType(w, "pilot_github_ratelimit_reset_timestamp_seconds", "gauge")
+	for _, s := range snaps {
+		for _, q := range s.Quotas {
+			writeGaugeLabeled(w, "pilot_github_ratelimit_reset_timestamp_seconds", float64(q.Reset.Unix()), "key", s.Key, "resource", q.Resource)
+		}
+	}
+
+	writeHelp(w, "pilot_github_requests_in_flight", "GitHub API requests being sent")
+	writeType(w, "pilot_github_requests_in_flight", "gauge")
+	for _, s := range snaps {
+		writeGaugeLabeled(w, "pilot_github_requests_in_flight", float64(s.InFlight), "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_github_requests_queued", "GitHub API requests waiting for quota or a slot")
+	writeType(w, "pilot_github_requests_queued", "gauge")
+	for _, s := range snaps {
+		writeGaugeLabeled(w, "pilot_github_requests_queued", float64(s.Queued), "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_github_requests_throttled_total", "GitHub API requests delayed to stay within quota")
+	writeType(w, "pilot_github_requests_throttled_total", "counter")
+	for _, s := range snaps {
+		writeCounter(w, "pilot_github_requests_throttled_total", s.Throttled, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_github_ratelimited_total", "Rate limit responses from GitHub")
+	writeType(w, "pilot_github_ratelimited_total", "counter")
+	for _, s := range snaps {
+		writeCounter(w, "pilot_github_ratelimited_total", s.Limited, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_github_requests_rejected_total", "GitHub API requests failed because quota stayed exhausted")
+	writeType(w, "pilot_github_requests_rejected_total", "counter")
+	for _, s := range snaps {
+		writeCounter(w, "pilot_github_requests_rejected_total", s.Rejected, "key", s.Key)
+	}
+
+	writeHelp(w, "pilot_github_not_modified_total", "Conditional GitHub API requests answered from the ETag cache")
+	writeType(w, "pilot_github_not_modified_total", "counter")
+	for _, s := range snaps {
+		writeCounter(w, "pilot_github_not_modified_total", s.NotModified, "key", s.Key)
+	}
+}
+
 // writeHelp writes a HELP line for a metric.
 func writeHelp(w io.Writer, name, help string) {
 	_, _ = fmt.Fprintf(w, "# HELP %s %s\n", name, help)
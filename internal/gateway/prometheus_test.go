@@ -2,11 +2,17 @@ package gateway
 
 import (
 	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/adapters/github"
 	"github.com/alekspetrov/pilot/internal/autopilot"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/testutil"
 )
 
 // mockMetricsSource implements MetricsSource for testing.
@@ -185,6 +191,49 @@ func TestPrometheusExporter_WritePrometheus(t *testing.T) {
 	}
 }
 
+type mockConcurrencySource struct {
+	snapshot executor.ConcurrencySnapshot
+}
+
+func (m *mockConcurrencySource) ConcurrencySnapshot() executor.ConcurrencySnapshot {
+	return m.snapshot
+}
+
+func TestPrometheusExporter_Concurrency(t *testing.T) {
+	exporter := NewPrometheusExporter(nil)
+	exporter.concurrencySource = &mockConcurrencySource{snapshot: executor.ConcurrencySnapshot{
+		Slots: []executor.SlotUsage{
+			{Scope: executor.ScopeRepo, Key: "acme/api", Limit: 2, Active: 2, Waiting: 3, Throttled: 5, WaitTime: 90 * time.Second},
+		},
+		Pools: []executor.WorktreePoolUsage{{ProjectPath: "/srv/api", Size: 2, InUse: 1, Target: 3}},
+	}}
+
+	var buf bytes.Buffer
+	if err := exporter.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"# TYPE pilot_execution_slots_waiting gauge",
+		`pilot_execution_slots_active{scope="repo",key="acme/api"} 2`,
+		`pilot_execution_slots_limit{scope="repo",key="acme/api"} 2`,
+		`pilot_execution_slots_waiting{scope="repo",key="acme/api"} 3`,
+		`pilot_execution_throttled_total{scope="repo",key="acme/api"} 5`,
+		`pilot_execution_slot_wait_seconds_total{scope="repo",key="acme/api"} 90`,
+		`pilot_worktree_pool_size{project="/srv/api"} 2`,
+		`pilot_worktree_pool_in_use{project="/srv/api"} 1`,
+		`pilot_worktree_pool_target{project="/srv/api"} 3`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output missing expected string: %q\nGot:\n%s", want, output)
+		}
+	}
+	// Without a metrics source only concurrency metrics are written
+	if strings.Contains(output, "pilot_issues_processed_total") {
+		t.Errorf("unexpected autopilot metrics without a metrics source:\n%s", output)
+	}
+}
+
 func TestEscapeLabel(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -228,3 +277,39 @@ func TestFormatLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestPrometheusExporter_GitHubRateLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Resource", "core")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4990")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		_, _ = w.Write([]byte(`{"number": 1}`))
+	}))
+	defer server.Close()
+
+	client := github.NewClientWithBaseURL(testutil.FakeGitHubToken, server.URL)
+	if _, err := client.GetIssue(context.Background(), "owner", "repo", 1); err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	key := github.SharedRateLimiter(server.URL, testutil.FakeGitHubToken).Snapshot().Key
+
+	var buf bytes.Buffer
+	if err := NewPrometheusExporter(nil).WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"# TYPE pilot_github_ratelimit_remaining gauge",
+		`pilot_github_ratelimit_remaining{key="` + key + `",resource="core"} 4990`,
+		`pilot_github_ratelimit_limit{key="` + key + `",resource="core"} 5000`,
+		`pilot_github_ratelimit_reset_timestamp_seconds{key="` + key + `",resource="core"} 1.7e+09`,
+		`pilot_github_requests_queued{key="` + key + `"} 0`,
+		`pilot_github_ratelimited_total{key="` + key + `"} 0`,
+		`pilot_github_not_modified_total{key="` + key + `"} 0`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output missing expected string: %q\nGot:\n%s", want, output)
+		}
+	}
+}
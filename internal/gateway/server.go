@@ -40,12 +40,14 @@ type livenessState struct {
 // AutopilotPRState holds the state of a single PR tracked by autopilot.
 // Used by AutopilotProvider to decouple gateway from autopilot package.
 type AutopilotPRState struct {
-	PRNumber   int
-	PRURL      string
-	Stage      string
-	CIStatus   string
-	Error      string
-	BranchName string
+	PRNumber      int
+	PRURL         string
+	Stage         string
+	CIStatus      string
+	Error         string
+	BranchName    string
+	Repo          string // "owner/repo", empty when unknown
+	QueuePosition int    // Merge queue position, 0 when not queued
 }
 
 // AutopilotProvider exposes autopilot state to the gateway API.
@@ -66,20 +68,47 @@ type Server struct {
 	sessions            *SessionManager
 	router              *Router
 	upgrader            websocket.Upgrader
+	hubUpgrader         websocket.Upgrader // /ws/dashboard/live, allows Config.DashboardOrigins
 	server              *http.Server
 	mu                  sync.RWMutex
 	running             bool
 	customHandlers      map[string]http.Handler
-	githubWebhookSecret string // Secret for GitHub webhook signature validation
-	dashboardFS         fs.FS  // Embedded React frontend (nil if not embedded)
+	apiHandlers         map[string]http.Handler // Authenticated /api/v1/ handlers from RegisterAPIHandler
+	githubWebhookSecret string                  // Secret for GitHub webhook signature validation
+	githubDeliveries    *github.DeliveryLog     // Recent X-GitHub-Delivery IDs, without a deliveryStore or when it fails
+	deliveryStore       DeliveryStore           // Processed delivery IDs, kept across restarts
+	dashboardFS         fs.FS                   // Embedded React frontend (nil if not embedded)
 	readinessCheckers   []ReadinessChecker
 	liveness            *livenessState
 	prometheusExporter  *PrometheusExporter
+	concurrencySource   ConcurrencySource
 	autopilotProvider   AutopilotProvider
 	dashboardStore      DashboardStore
 	logStreamStore      LogStreamStore
 	gitGraphPath        string          // Project path for git graph API (defaults to ".")
 	gitGraphFetcher     GitGraphFetcher // Injected to avoid import cycle with internal/dashboard
+	portalRepoResolver  PortalRepoResolver
+	dashboardHub        *DashboardHub           // Remote dashboard state (nil = /ws/dashboard/live disabled)
+	eventSubs           map[chan Event]struct{} // /events subscribers
+	eventSeq            uint64                  // Last published event ID
+	eventMu             sync.Mutex              // Protects eventSubs and eventSeq
+	statusComponents    []ReadinessChecker      // Shown on the status page only
+	startedAt           time.Time
+}
+
+// GitHub lets webhook deliveries be redelivered for three days. Delivery IDs
+// are remembered that long so a redelivery or replay is processed once.
+const (
+	githubDeliveryTTL   = 72 * time.Hour
+	maxGithubDeliveries = 10000
+)
+
+// DeliveryStore persists processed webhook delivery IDs, so redeliveries
+// are dropped across restarts and by every instance sharing the store.
+type DeliveryStore interface {
+	// RecordDelivery records id until ttl passes and reports whether it
+	// was already recorded
+	RecordDelivery(source, id string, ttl time.Duration, now time.Time) (bool, error)
 }
 
 // Config holds gateway server configuration including network binding options.
@@ -88,6 +117,13 @@ type Config struct {
 	Host string `yaml:"host"`
 	// Port is the TCP port number to listen on.
 	Port int `yaml:"port"`
+	// GRPCPort is the TCP port for the gRPC control-plane API. 0 disables it.
+	GRPCPort int `yaml:"grpc_port,omitempty"`
+	// DashboardOrigins lists browser origins (e.g. "https://ops.example.com")
+	// allowed to open the remote dashboard WebSocket besides localhost.
+	DashboardOrigins []string `yaml:"dashboard_origins,omitempty"`
+	// StatusPage serves a public status page at /status when enabled.
+	StatusPage *StatusPageConfig `yaml:"status_page,omitempty"`
 	// GithubWebhookSecret is the secret for GitHub webhook signature validation.
 	// If set, incoming GitHub webhooks must have valid HMAC-SHA256 signatures.
 	GithubWebhookSecret string `yaml:"-"` // Set programmatically from adapters config
@@ -118,6 +154,18 @@ func isLocalhost(origin string) bool {
 	return false
 }
 
+// originAllowed reports whether origin is one of allowed. Scheme and host
+// must match exactly; case and a trailing slash are ignored.
+func originAllowed(origin string, allowed []string) bool {
+	origin = strings.TrimSuffix(origin, "/")
+	for _, a := range allowed {
+		if strings.EqualFold(origin, strings.TrimSuffix(a, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewServer creates a new gateway server with the given configuration.
 // The server is not started until Start is called.
 func NewServer(config *Config) *Server {
@@ -138,7 +186,10 @@ func NewServerWithAuth(config *Config, authConfig *AuthConfig) *Server {
 		sessions:            NewSessionManager(),
 		router:              NewRouter(),
 		customHandlers:      make(map[string]http.Handler),
+		apiHandlers:         make(map[string]http.Handler),
+		eventSubs:           make(map[chan Event]struct{}),
 		githubWebhookSecret: config.GithubWebhookSecret,
+		githubDeliveries:    github.NewDeliveryLog(githubDeliveryTTL, maxGithubDeliveries),
 		readinessCheckers:   make([]ReadinessChecker, 0),
 		liveness: &livenessState{
 			maxGoroutines:   1000,
@@ -164,6 +215,14 @@ func NewServerWithAuth(config *Config, authConfig *AuthConfig) *Server {
 			},
 		},
 	}
+	s.hubUpgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || isLocalhost(origin) || originAllowed(origin, config.DashboardOrigins)
+		},
+	}
 	// Initialize heartbeat
 	s.liveness.lastHeartbeat.Store(time.Now().Unix())
 	return s
@@ -179,6 +238,7 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("server already running")
 	}
 	s.running = true
+	s.startedAt = time.Now()
 	s.mu.Unlock()
 
 	mux := http.NewServeMux()
@@ -194,24 +254,60 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/ready", s.handleReady)
 	mux.HandleFunc("/live", s.handleLive)
 	mux.HandleFunc("/metrics", s.handleMetrics)
+	if s.config.StatusPage != nil && s.config.StatusPage.Enabled {
+		mux.HandleFunc("/status", s.handleStatusPage)
+		mux.HandleFunc("/status.json", s.handleStatusPageJSON)
+	}
+
+	// Protected API endpoints (auth required when configured).
+	// Handlers registered via RegisterAPIHandler replace built-in routes on the same path.
+	s.mu.RLock()
+	apiHandlers := make(map[string]http.Handler, len(s.apiHandlers))
+	for path, handler := range s.apiHandlers {
+		apiHandlers[path] = handler
+	}
+	s.mu.RUnlock()
 
-	// Protected API endpoints (auth required when configured)
 	apiMux := http.NewServeMux()
-	apiMux.HandleFunc("/api/v1/status", s.handleStatus)
-	apiMux.HandleFunc("/api/v1/tasks", s.handleTasks)
-	apiMux.HandleFunc("/api/v1/autopilot", s.handleAutopilot)
-	apiMux.HandleFunc("/api/v1/metrics", s.handleDashboardMetrics)
-	apiMux.HandleFunc("/api/v1/queue", s.handleDashboardQueue)
-	apiMux.HandleFunc("/api/v1/history", s.handleDashboardHistory)
-	apiMux.HandleFunc("/api/v1/logs", s.handleDashboardLogs)
-	apiMux.HandleFunc("/api/v1/gitgraph", s.handleGitGraph)
-
-	// Apply auth middleware to API routes
-	if s.auth != nil {
-		mux.Handle("/api/v1/", s.auth.Middleware(apiMux))
-	} else {
-		mux.Handle("/api/v1/", apiMux)
+	handleAPI := func(path string, handler http.HandlerFunc) {
+		if _, ok := apiHandlers[path]; !ok {
+			apiMux.HandleFunc(path, handler)
+		}
 	}
+	handleAPI("/api/v1/status", s.handleStatus)
+	handleAPI("/api/v1/tasks", s.handleTasks)
+	handleAPI("/api/v1/autopilot", s.handleAutopilot)
+	handleAPI("/api/v1/metrics", s.handleDashboardMetrics)
+	handleAPI("/api/v1/queue", s.handleDashboardQueue)
+	handleAPI("/api/v1/history", s.handleDashboardHistory)
+	handleAPI("/api/v1/logs", s.handleDashboardLogs)
+	handleAPI("/api/v1/gitgraph", s.handleGitGraph)
+	handleAPI("/api/v1/portal/repos", s.handlePortalRepos)
+	handleAPI("/api/v1/portal/repos/", s.handlePortalRepo)
+	handleAPI("/api/v1/portal/openapi.json", s.handlePortalOpenAPI)
+	for path, handler := range apiHandlers {
+		apiMux.Handle(path, handler)
+	}
+
+	// Apply auth middleware to API routes. Requests that change state
+	// (creating or cancelling tasks, releasing PRs) always need the API token.
+	readAPI := s.auth.Middleware(apiMux)
+	writeAPI := s.requireToken(apiMux)
+	mux.Handle("/api/v1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isReadOnlyMethod(r.Method) {
+			readAPI.ServeHTTP(w, r)
+			return
+		}
+		writeAPI.ServeHTTP(w, r)
+	}))
+
+	// Server-Sent Events stream of task progress, token usage and autopilot
+	// stages. Task titles and errors leak through it, so it needs the API token.
+	mux.Handle("/events", s.requireToken(http.HandlerFunc(s.handleEvents)))
+
+	// Remote dashboard: mirrors the TUI dashboard state over WebSocket. It is
+	// meant to be reached from other machines, so the API token is required.
+	mux.Handle("/ws/dashboard/live", withQueryToken(s.requireToken(http.HandlerFunc(s.handleDashboardHub))))
 
 	// Webhook endpoints for adapters (use signature validation, not bearer tokens)
 	mux.HandleFunc("/webhooks/linear", s.handleLinearWebhook)
@@ -240,6 +336,7 @@ func (s *Server) Start(ctx context.Context) error {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	s.server.RegisterOnShutdown(s.closeEventStreams)
 
 	logging.WithComponent("gateway").Info("Gateway starting", slog.String("addr", addr))
 
@@ -258,6 +355,33 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 }
 
+// tokenAuthConfigured reports whether API token auth is set up with a token.
+func (s *Server) tokenAuthConfigured() bool {
+	return s.auth != nil && s.auth.config != nil &&
+		s.auth.config.Type == AuthTypeAPIToken && s.auth.config.Token != ""
+}
+
+// requireToken serves next only to requests bearing the configured API token.
+// Without API token auth configured the route is refused outright rather than
+// left open.
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	if !s.tokenAuthConfigured() {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Forbidden: configure auth.type api-token with a token to use this endpoint", http.StatusForbidden)
+		})
+	}
+	return s.auth.Middleware(next)
+}
+
+// isReadOnlyMethod reports whether an HTTP method cannot change server state.
+func isReadOnlyMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
 // RegisterHandler registers a custom HTTP handler for a path.
 // Must be called before Start(). The handler will be registered when the server starts.
 func (s *Server) RegisterHandler(path string, handler http.Handler) {
@@ -266,12 +390,34 @@ func (s *Server) RegisterHandler(path string, handler http.Handler) {
 	s.customHandlers[path] = handler
 }
 
+// RegisterAPIHandler registers an HTTP handler under the authenticated /api/v1/ prefix.
+// A handler registered for a built-in route replaces it.
+// Must be called before Start().
+func (s *Server) RegisterAPIHandler(path string, handler http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiHandlers[path] = handler
+}
+
 // SetMetricsSource sets the metrics source for the Prometheus /metrics endpoint.
 // Must be called before Start().
 func (s *Server) SetMetricsSource(source MetricsSource) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.prometheusExporter = NewPrometheusExporter(source)
+	s.prometheusExporter.concurrencySource = s.concurrencySource
+}
+
+// SetConcurrencySource adds execution slot and worktree pool usage to the
+// Prometheus /metrics endpoint. Must be called before Start().
+func (s *Server) SetConcurrencySource(source ConcurrencySource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.concurrencySource = source
+	if s.prometheusExporter == nil {
+		s.prometheusExporter = NewPrometheusExporter(nil)
+	}
+	s.prometheusExporter.concurrencySource = source
 }
 
 // SetAutopilotProvider sets the autopilot provider for the /api/v1/autopilot endpoint.
@@ -503,12 +649,13 @@ func (s *Server) handleAutopilot(w http.ResponseWriter, r *http.Request) {
 	activePRs := make([]map[string]interface{}, 0, len(prs))
 	for _, pr := range prs {
 		activePRs = append(activePRs, map[string]interface{}{
-			"number":     pr.PRNumber,
-			"url":        pr.PRURL,
-			"stage":      pr.Stage,
-			"ciStatus":   pr.CIStatus,
-			"error":      pr.Error,
-			"branchName": pr.BranchName,
+			"number":        pr.PRNumber,
+			"url":           pr.PRURL,
+			"stage":         pr.Stage,
+			"ciStatus":      pr.CIStatus,
+			"error":         pr.Error,
+			"branchName":    pr.BranchName,
+			"queuePosition": pr.QueuePosition,
 		})
 	}
 
@@ -557,6 +704,11 @@ func (s *Server) handleGithubWebhook(w http.ResponseWriter, r *http.Request) {
 	// GitHub sends event type in header
 	eventType := r.Header.Get("X-GitHub-Event")
 	signature := r.Header.Get("X-Hub-Signature-256")
+	deliveryID := r.Header.Get("X-GitHub-Delivery")
+	if deliveryID == "" {
+		http.Error(w, "Missing X-GitHub-Delivery header", http.StatusBadRequest)
+		return
+	}
 
 	// Read raw body first (required for HMAC signature validation)
 	body, err := io.ReadAll(r.Body)
@@ -581,9 +733,20 @@ func (s *Server) handleGithubWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Drop deliveries already processed. Checked after the signature so
+	// unsigned requests cannot claim delivery IDs.
+	if s.githubDeliverySeen(deliveryID) {
+		logging.WithComponent("gateway").Info("Ignoring duplicate GitHub webhook delivery",
+			slog.String("event_type", eventType),
+			slog.String("delivery", deliveryID))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
 	// Add metadata to payload for handler
 	payload["_event_type"] = eventType
 	payload["_signature"] = signature
+	payload["_delivery"] = deliveryID
 
 	logging.WithComponent("gateway").Info("Received GitHub webhook", slog.String("event_type", eventType))
 
@@ -593,6 +756,33 @@ func (s *Server) handleGithubWebhook(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// SetDeliveryStore configures where processed webhook delivery IDs are
+// kept. Without a store they are kept in memory until the server stops.
+func (s *Server) SetDeliveryStore(store DeliveryStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveryStore = store
+}
+
+// githubDeliverySeen records a GitHub delivery ID and reports whether it
+// was processed before
+func (s *Server) githubDeliverySeen(id string) bool {
+	s.mu.RLock()
+	store := s.deliveryStore
+	s.mu.RUnlock()
+
+	if store != nil {
+		seen, err := store.RecordDelivery("github", id, githubDeliveryTTL, time.Now())
+		if err == nil {
+			return seen
+		}
+		logging.WithComponent("gateway").Warn("Failed to record GitHub webhook delivery, deduplicating in memory",
+			slog.String("delivery", id),
+			slog.Any("error", err))
+	}
+	return s.githubDeliveries.Seen(id)
+}
+
 // handleJiraWebhook receives webhooks from Jira
 func (s *Server) handleJiraWebhook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
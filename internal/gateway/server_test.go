@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -772,6 +773,7 @@ func TestGithubWebhookTableDriven(t *testing.T) {
 		payload        string
 		eventType      string
 		signature      string
+		noDelivery     bool
 		expectedStatus int
 	}{
 		{
@@ -822,6 +824,15 @@ func TestGithubWebhookTableDriven(t *testing.T) {
 			signature:      "sha256=def456",
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "missing delivery ID",
+			method:         http.MethodPost,
+			payload:        `{"action": "opened", "issue": {"number": 1}}`,
+			eventType:      "issues",
+			signature:      "",
+			noDelivery:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "invalid JSON",
 			method:         http.MethodPost,
@@ -849,6 +860,9 @@ func TestGithubWebhookTableDriven(t *testing.T) {
 			if tt.signature != "" {
 				req.Header.Set("X-Hub-Signature-256", tt.signature)
 			}
+			if !tt.noDelivery {
+				req.Header.Set("X-GitHub-Delivery", tt.name)
+			}
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
@@ -1191,6 +1205,124 @@ func TestAPIEndpointsRequireAuth(t *testing.T) {
 	}
 }
 
+func TestRegisterAPIHandler(t *testing.T) {
+	config := &Config{Host: "127.0.0.1", Port: 19094}
+	authConfig := &AuthConfig{
+		Type:  AuthTypeAPIToken,
+		Token: "secret-api-token",
+	}
+	server := NewServerWithAuth(config, authConfig)
+
+	// Replaces the built-in /api/v1/tasks route and adds a new one
+	server.RegisterAPIHandler("/api/v1/tasks", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	server.RegisterAPIHandler("/api/v1/budget", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = server.Start(ctx)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	tests := []struct {
+		endpoint       string
+		authHeader     string
+		expectedStatus int
+	}{
+		{"/api/v1/tasks", "", http.StatusUnauthorized},
+		{"/api/v1/tasks", "Bearer secret-api-token", http.StatusTeapot},
+		{"/api/v1/budget", "", http.StatusUnauthorized},
+		{"/api/v1/budget", "Bearer secret-api-token", http.StatusAccepted},
+		{"/api/v1/status", "Bearer secret-api-token", http.StatusOK},
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:19094"+tt.endpoint, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		if tt.authHeader != "" {
+			req.Header.Set("Authorization", tt.authHeader)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != tt.expectedStatus {
+			t.Errorf("%s (auth=%t): status = %d, want %d", tt.endpoint, tt.authHeader != "", resp.StatusCode, tt.expectedStatus)
+		}
+	}
+}
+
+func TestMutatingAPIRequiresToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		port           int
+		auth           *AuthConfig
+		method         string
+		authHeader     string
+		expectedStatus int
+	}{
+		{"no auth, GET allowed", 19095, nil, http.MethodGet, "", http.StatusAccepted},
+		{"no auth, POST refused", 19095, nil, http.MethodPost, "", http.StatusForbidden},
+		{"no auth, DELETE refused", 19095, nil, http.MethodDelete, "", http.StatusForbidden},
+		{"claude-code auth, POST refused", 19096, &AuthConfig{Type: AuthTypeClaudeCode}, http.MethodPost, "", http.StatusForbidden},
+		{"empty token, POST refused", 19097, &AuthConfig{Type: AuthTypeAPIToken}, http.MethodPost, "Bearer ", http.StatusForbidden},
+		{"token, POST without header", 19098, &AuthConfig{Type: AuthTypeAPIToken, Token: "secret-api-token"}, http.MethodPost, "", http.StatusUnauthorized},
+		{"token, POST with header", 19098, &AuthConfig{Type: AuthTypeAPIToken, Token: "secret-api-token"}, http.MethodPost, "Bearer secret-api-token", http.StatusAccepted},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(map[int]bool)
+	for _, tt := range tests {
+		if started[tt.port] {
+			continue
+		}
+		server := NewServerWithAuth(&Config{Host: "127.0.0.1", Port: tt.port}, tt.auth)
+		server.RegisterAPIHandler("/api/v1/tasks", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		go func() {
+			_ = server.Start(ctx)
+		}()
+		started[tt.port] = true
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, fmt.Sprintf("http://127.0.0.1:%d/api/v1/tasks", tt.port), nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.expectedStatus)
+			}
+		})
+	}
+}
+
 func TestWebhooksDoNotRequireBearerAuth(t *testing.T) {
 	config := &Config{Host: "127.0.0.1", Port: 19093}
 	authConfig := &AuthConfig{
@@ -0,0 +1,246 @@
+package gateway
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Overall status reported by the status page.
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusDown        = "down"
+)
+
+// statusScanLimit caps how many executions and queued tasks are read per page.
+const statusScanLimit = 1000
+
+// StatusPageConfig configures the shared "is Pilot ok?" page served at /status
+// (HTML) and /status.json. The page is public: it shows component health,
+// queue depth and per-repo activity, but no task titles or errors.
+type StatusPageConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Title   string `yaml:"title,omitempty"` // Page heading (default: "Pilot Status")
+}
+
+// StatusPage is the data rendered by the status page.
+type StatusPage struct {
+	Title       string            `json:"title"`
+	Status      string            `json:"status"` // operational, degraded, down
+	GeneratedAt time.Time         `json:"generatedAt"`
+	StartedAt   *time.Time        `json:"startedAt,omitempty"`
+	Components  []StatusComponent `json:"components"`
+	QueueDepth  int               `json:"queueDepth"`
+	Running     int               `json:"running"`
+	Repos       []StatusRepo      `json:"repos"`
+}
+
+// StatusComponent is the health of a single component such as an adapter poller.
+type StatusComponent struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+}
+
+// StatusRepo summarizes execution activity for a repository.
+type StatusRepo struct {
+	Repo          string     `json:"repo"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastRunAt     time.Time  `json:"lastRunAt"`
+	LastStatus    string     `json:"lastStatus"`
+}
+
+// RegisterStatusComponent adds a component shown on the status page. Unlike
+// readiness checkers, status components never fail /ready.
+func (s *Server) RegisterStatusComponent(checker ReadinessChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusComponents = append(s.statusComponents, checker)
+}
+
+// BuildStatusPage assembles a status page from the store and component health.
+// A nil store yields a page without queue or repo data.
+func BuildStatusPage(title string, store DashboardStore, resolver PortalRepoResolver, components []StatusComponent, now time.Time) (*StatusPage, error) {
+	if title == "" {
+		title = "Pilot Status"
+	}
+	page := &StatusPage{
+		Title:       title,
+		Status:      StatusOperational,
+		GeneratedAt: now.UTC(),
+		Components:  components,
+		Repos:       []StatusRepo{},
+	}
+	if page.Components == nil {
+		page.Components = []StatusComponent{}
+	}
+	for _, c := range page.Components {
+		if !c.Healthy {
+			page.Status = StatusDegraded
+		}
+	}
+
+	if store == nil {
+		return page, nil
+	}
+
+	queued, err := store.GetQueuedTasks(statusScanLimit)
+	if err != nil {
+		return nil, err
+	}
+	page.QueueDepth = len(queued)
+
+	active, err := store.GetActiveExecutions()
+	if err != nil {
+		return nil, err
+	}
+	page.Running = len(active)
+
+	execs, err := store.GetRecentExecutions(statusScanLimit)
+	if err != nil {
+		return nil, err
+	}
+	byRepo := make(map[string]*StatusRepo)
+	for _, exec := range execs {
+		if exec.Status == "queued" || exec.Status == "pending" {
+			continue
+		}
+		name := portalRepoName(resolver, exec.ProjectPath)
+		repo, ok := byRepo[name]
+		if !ok {
+			// Executions are newest first, so the first one seen is the latest run
+			repo = &StatusRepo{Repo: name, LastRunAt: exec.CreatedAt.UTC(), LastStatus: exec.Status}
+			byRepo[name] = repo
+		}
+		if repo.LastSuccessAt == nil && exec.Status == "completed" {
+			at := exec.CreatedAt
+			if exec.CompletedAt != nil {
+				at = *exec.CompletedAt
+			}
+			at = at.UTC()
+			repo.LastSuccessAt = &at
+		}
+	}
+	for _, repo := range byRepo {
+		page.Repos = append(page.Repos, *repo)
+	}
+	sort.Slice(page.Repos, func(i, j int) bool { return page.Repos[i].Repo < page.Repos[j].Repo })
+
+	return page, nil
+}
+
+// statusPage builds the live status page for this server.
+func (s *Server) statusPage() (*StatusPage, error) {
+	s.mu.RLock()
+	store := s.dashboardStore
+	resolver := s.portalRepoResolver
+	checkers := make([]ReadinessChecker, 0, len(s.readinessCheckers)+len(s.statusComponents))
+	checkers = append(checkers, s.readinessCheckers...)
+	checkers = append(checkers, s.statusComponents...)
+	s.mu.RUnlock()
+
+	components := make([]StatusComponent, 0, len(checkers))
+	for _, checker := range checkers {
+		components = append(components, StatusComponent{Name: checker.Name(), Healthy: checker.Ready()})
+	}
+
+	title := ""
+	if s.config.StatusPage != nil {
+		title = s.config.StatusPage.Title
+	}
+	page, err := BuildStatusPage(title, store, resolver, components, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	startedAt := s.startedAt.UTC()
+	page.StartedAt = &startedAt
+	return page, nil
+}
+
+// handleStatusPage serves the status page as HTML.
+func (s *Server) handleStatusPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	page, err := s.statusPage()
+	if err != nil {
+		http.Error(w, "failed to build status page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = RenderStatusPage(w, page)
+}
+
+// handleStatusPageJSON serves the status page data as JSON.
+func (s *Server) handleStatusPageJSON(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	page, err := s.statusPage()
+	if err != nil {
+		http.Error(w, "failed to build status page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, page)
+}
+
+// formatStatusAge renders a timestamp relative to now, e.g. "5m ago".
+func formatStatusAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return t.Format("Jan 2 15:04 MST")
+	}
+}
+
+// RenderStatusPage writes page as a self-contained HTML document, suitable
+// for serving or exporting as a static file.
+func RenderStatusPage(w io.Writer, page *StatusPage) error {
+	return statusPageTemplate.Execute(w, page)
+}
+
+var statusPageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"ago": formatStatusAge,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="60">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",sans-serif;max-width:720px;margin:2rem auto;padding:0 1rem;color:#1f2328}
+h1{font-size:1.5rem}h2{font-size:1.1rem;margin-top:2rem}
+.banner{padding:1rem;border-radius:6px;font-weight:600;color:#fff}
+.operational{background:#1a7f37}.degraded{background:#9a6700}.down{background:#cf222e}
+table{width:100%;border-collapse:collapse}td,th{text-align:left;padding:.4rem;border-bottom:1px solid #d0d7de}
+.ok{color:#1a7f37}.bad{color:#cf222e}.muted{color:#656d76;font-size:.85rem}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="banner {{.Status}}">{{if eq .Status "operational"}}All systems operational{{else if eq .Status "degraded"}}Degraded: some components are unhealthy{{else}}Pilot is down{{end}}</div>
+<p class="muted">{{if .StartedAt}}Up since {{.StartedAt.Format "Jan 2 15:04 MST"}} · {{end}}Queue: {{.QueueDepth}} waiting, {{.Running}} running</p>
+{{if .Components}}<h2>Components</h2>
+<table>{{range .Components}}<tr><td>{{.Name}}</td><td class="{{if .Healthy}}ok{{else}}bad{{end}}">{{if .Healthy}}healthy{{else}}unhealthy{{end}}</td></tr>{{end}}</table>{{end}}
+<h2>Repositories</h2>
+{{if .Repos}}<table><tr><th>Repository</th><th>Last success</th><th>Last run</th></tr>
+{{range .Repos}}<tr><td>{{.Repo}}</td><td>{{if .LastSuccessAt}}{{ago .LastSuccessAt}}{{else}}—{{end}}</td><td>{{.LastStatus}} {{ago .LastRunAt}}</td></tr>
+{{end}}</table>{{else}}<p class="muted">No executions yet.</p>{{end}}
+<p class="muted">Generated {{.GeneratedAt.Format "Jan 2 15:04:05 MST"}}</p>
+</body>
+</html>
+`))
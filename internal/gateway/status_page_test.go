@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func TestBuildStatusPage(t *testing.T) {
+	now := time.Now().UTC()
+	completedAt := now.Add(-90 * time.Minute)
+	store := &mockDashboardStore{
+		executions: []*memory.Execution{
+			{ID: "e1", Status: "queued", ProjectPath: "/src/api", CreatedAt: now},
+			{ID: "e2", Status: "failed", ProjectPath: "/src/api", CreatedAt: now.Add(-time.Hour)},
+			{ID: "e3", Status: "completed", ProjectPath: "/src/api", CreatedAt: now.Add(-2 * time.Hour), CompletedAt: &completedAt},
+			{ID: "e4", Status: "failed", ProjectPath: "/src/web", CreatedAt: now.Add(-3 * time.Hour)},
+		},
+		queuedTasks: []*memory.Execution{{ID: "e1"}},
+		activeExecs: []*memory.Execution{{ID: "e5"}, {ID: "e6"}},
+	}
+	resolver := func(projectPath string) string {
+		if projectPath == "/src/api" {
+			return "org/api"
+		}
+		return ""
+	}
+
+	page, err := BuildStatusPage("", store, resolver, []StatusComponent{{Name: "github:org/api", Healthy: true}}, now)
+	if err != nil {
+		t.Fatalf("BuildStatusPage() error = %v", err)
+	}
+	if page.Title != "Pilot Status" || page.Status != StatusOperational {
+		t.Errorf("title/status = %q/%q", page.Title, page.Status)
+	}
+	if page.QueueDepth != 1 || page.Running != 2 {
+		t.Errorf("queue = %d running = %d, want 1 and 2", page.QueueDepth, page.Running)
+	}
+	if len(page.Repos) != 2 {
+		t.Fatalf("repos = %+v, want 2", page.Repos)
+	}
+
+	api, web := page.Repos[0], page.Repos[1]
+	if api.Repo != "org/api" || api.LastStatus != "failed" || api.LastSuccessAt == nil || !api.LastSuccessAt.Equal(completedAt) {
+		t.Errorf("api = %+v, want last run failed and last success at completion", api)
+	}
+	if web.Repo != "web" || web.LastSuccessAt != nil {
+		t.Errorf("web = %+v, want no success", web)
+	}
+
+	page, _ = BuildStatusPage("Team Pilot", nil, nil, []StatusComponent{{Name: "github:org/api", Healthy: false}}, now)
+	if page.Status != StatusDegraded || page.Title != "Team Pilot" {
+		t.Errorf("status = %q title = %q, want degraded Team Pilot", page.Status, page.Title)
+	}
+}
+
+func TestHandleStatusPage(t *testing.T) {
+	s := newTestServerWithDashboard(&mockDashboardStore{})
+	s.config.StatusPage = &StatusPageConfig{Enabled: true, Title: "Acme Pilot"}
+	s.startedAt = time.Now().Add(-time.Hour)
+	s.RegisterReadinessChecker(&mockReadinessChecker{name: "executor", ready: true})
+	s.RegisterStatusComponent(&mockReadinessChecker{name: "github:acme/app", ready: false})
+
+	w := httptest.NewRecorder()
+	s.handleStatusPageJSON(w, httptest.NewRequest(http.MethodGet, "/status.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var page StatusPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if page.Status != StatusDegraded || len(page.Components) != 2 || page.StartedAt == nil {
+		t.Errorf("page = %+v, want degraded with 2 components", page)
+	}
+
+	// Status components do not affect readiness
+	w = httptest.NewRecorder()
+	s.handleReady(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("/ready = %d, want 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	s.handleStatusPage(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+	body := w.Body.String()
+	for _, want := range []string{"<title>Acme Pilot</title>", "Degraded", "github:acme/app", "unhealthy"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("status page missing %q", want)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	s.handleStatusPage(w, httptest.NewRequest(http.MethodPost, "/status", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /status = %d, want 405", w.Code)
+	}
+}
+
+func TestRenderStatusPage_Escapes(t *testing.T) {
+	var buf bytes.Buffer
+	page := &StatusPage{Title: "<script>x</script>", Status: StatusDown, Repos: []StatusRepo{{Repo: "a<b", LastRunAt: time.Now()}}}
+	if err := RenderStatusPage(&buf, page); err != nil {
+		t.Fatalf("RenderStatusPage() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "<script>x</script>") || !strings.Contains(buf.String(), "Pilot is down") {
+		t.Errorf("unexpected render:\n%s", buf.String())
+	}
+}
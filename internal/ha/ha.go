@@ -0,0 +1,253 @@
+// Package ha runs Pilot as a warm standby pair. Both daemons share one
+// memory store; the primary renews a lease in it on every heartbeat while
+// the standby waits. When the primary misses enough heartbeats, or shuts
+// down and releases the lease, the standby takes over polling and the
+// autopilot controllers.
+package ha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// LeaseName is the lease the primary holds in the shared store
+const LeaseName = "primary"
+
+const (
+	defaultHeartbeatInterval = 10 * time.Second
+	defaultMissedHeartbeats  = 3
+)
+
+// ErrLeadershipLost is returned by Elector.Run when another node took the
+// lease, or it could not be renewed before it expired
+var ErrLeadershipLost = errors.New("ha: leadership lost")
+
+// Config configures the warm standby pair
+type Config struct {
+	Enabled           bool          `yaml:"enabled"`
+	NodeID            string        `yaml:"node_id"`            // Unique per daemon (default: hostname-pid)
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"` // How often the primary renews its lease (default: 10s)
+	MissedHeartbeats  int           `yaml:"missed_heartbeats"`  // Missed heartbeats before the standby takes over (default: 3)
+}
+
+// DefaultConfig returns HA disabled with default timings
+func DefaultConfig() *Config {
+	return &Config{
+		HeartbeatInterval: defaultHeartbeatInterval,
+		MissedHeartbeats:  defaultMissedHeartbeats,
+	}
+}
+
+// Validate rejects negative timings
+func (c *Config) Validate() error {
+	if c.HeartbeatInterval < 0 {
+		return fmt.Errorf("heartbeat_interval must not be negative")
+	}
+	if c.MissedHeartbeats < 0 {
+		return fmt.Errorf("missed_heartbeats must not be negative")
+	}
+	return nil
+}
+
+// Interval returns the heartbeat interval, defaulted when unset
+func (c *Config) Interval() time.Duration {
+	if c.HeartbeatInterval <= 0 {
+		return defaultHeartbeatInterval
+	}
+	return c.HeartbeatInterval
+}
+
+// LeaseTTL is how long the lease lives without a heartbeat
+func (c *Config) LeaseTTL() time.Duration {
+	missed := c.MissedHeartbeats
+	if missed <= 0 {
+		missed = defaultMissedHeartbeats
+	}
+	return c.Interval() * time.Duration(missed)
+}
+
+// ResolvedNodeID returns the configured node ID, or hostname-pid
+func (c *Config) ResolvedNodeID() string {
+	if c.NodeID != "" {
+		return c.NodeID
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "pilot"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// LeaseStore persists the primary lease. Satisfied by *memory.Store.
+type LeaseStore interface {
+	AcquireLease(name, holder string, ttl time.Duration, now time.Time) (*memory.Lease, error)
+	ReleaseLease(name, holder string) error
+}
+
+// Elector decides which node of the pair is primary
+type Elector struct {
+	store    LeaseStore
+	node     string
+	interval time.Duration
+	ttl      time.Duration
+	log      *slog.Logger
+	now      func() time.Time
+
+	mu       sync.Mutex
+	leader   bool
+	renewed  time.Time // Last successful renewal
+	previous string    // Primary this node took over from
+}
+
+// NewElector creates an elector for this node
+func NewElector(cfg *Config, store LeaseStore) *Elector {
+	return &Elector{
+		store:    store,
+		node:     cfg.ResolvedNodeID(),
+		interval: cfg.Interval(),
+		ttl:      cfg.LeaseTTL(),
+		log:      logging.WithComponent("ha"),
+		now:      time.Now,
+	}
+}
+
+// NodeID returns this node's ID
+func (e *Elector) NodeID() string {
+	return e.node
+}
+
+// IsLeader reports whether this node currently holds the lease
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Previous returns the primary this node took over from, or "" when the
+// lease was free when it started
+func (e *Elector) Previous() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.previous
+}
+
+// WaitForLeadership blocks until this node holds the lease, trying once per
+// heartbeat interval. Returns the context's error if it is cancelled first.
+func (e *Elector) WaitForLeadership(ctx context.Context) error {
+	var primary string
+	for {
+		lease, err := e.tryAcquire()
+		switch {
+		case err != nil:
+			e.log.Warn("Failed to check primary lease", slog.Any("error", err))
+		case lease.Holder == e.node:
+			e.mu.Lock()
+			e.previous = primary
+			e.mu.Unlock()
+			if primary != "" {
+				e.log.Warn("Took over primary lease", slog.String("node", e.node), slog.String("previous", primary))
+			} else {
+				e.log.Info("Acquired primary lease", slog.String("node", e.node))
+			}
+			return nil
+		case lease.Holder != primary:
+			primary = lease.Holder
+			e.log.Info("Standing by",
+				slog.String("node", e.node),
+				slog.String("primary", primary),
+				slog.Time("lease_expires", lease.ExpiresAt),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// Run renews the lease every heartbeat interval until ctx is cancelled.
+// Returns ErrLeadershipLost if another node took the lease, or renewals
+// kept failing until it expired, at which point this node must stop work.
+func (e *Elector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := e.heartbeat(); err != nil {
+			return err
+		}
+	}
+}
+
+// Release gives up the lease so the standby takes over immediately
+func (e *Elector) Release() error {
+	e.mu.Lock()
+	wasLeader := e.leader
+	e.leader = false
+	e.mu.Unlock()
+	if !wasLeader {
+		return nil
+	}
+	if err := e.store.ReleaseLease(LeaseName, e.node); err != nil {
+		return fmt.Errorf("failed to release primary lease: %w", err)
+	}
+	e.log.Info("Released primary lease", slog.String("node", e.node))
+	return nil
+}
+
+// heartbeat renews the lease once
+func (e *Elector) heartbeat() error {
+	lease, err := e.tryAcquire()
+	if err == nil && lease.Holder == e.node {
+		return nil
+	}
+
+	e.mu.Lock()
+	expired := e.now().Sub(e.renewed) >= e.ttl
+	if err == nil || expired {
+		e.leader = false
+	}
+	e.mu.Unlock()
+
+	switch {
+	case err == nil:
+		e.log.Error("Primary lease taken over", slog.String("node", e.node), slog.String("primary", lease.Holder))
+		return ErrLeadershipLost
+	case expired:
+		e.log.Error("Primary lease expired without renewal", slog.String("node", e.node), slog.Any("error", err))
+		return ErrLeadershipLost
+	default:
+		e.log.Warn("Failed to renew primary lease", slog.Any("error", err))
+		return nil
+	}
+}
+
+// tryAcquire takes or renews the lease, recording the result
+func (e *Elector) tryAcquire() (*memory.Lease, error) {
+	now := e.now()
+	lease, err := e.store.AcquireLease(LeaseName, e.node, e.ttl, now)
+	if err != nil {
+		return nil, err
+	}
+	if lease.Holder == e.node {
+		e.mu.Lock()
+		e.leader = true
+		e.renewed = now
+		e.mu.Unlock()
+	}
+	return lease, nil
+}
@@ -0,0 +1,191 @@
+package ha
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// fakeLeaseStore keeps one lease in memory and can be told to fail
+type fakeLeaseStore struct {
+	mu    sync.Mutex
+	lease *memory.Lease
+	err   error
+}
+
+func (s *fakeLeaseStore) AcquireLease(name, holder string, ttl time.Duration, now time.Time) (*memory.Lease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.lease == nil || s.lease.Holder == holder || !now.Before(s.lease.ExpiresAt) {
+		s.lease = &memory.Lease{Name: name, Holder: holder, HeartbeatAt: now, ExpiresAt: now.Add(ttl)}
+	}
+	lease := *s.lease
+	return &lease, nil
+}
+
+func (s *fakeLeaseStore) ReleaseLease(name, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lease != nil && s.lease.Holder == holder {
+		s.lease = nil
+	}
+	return nil
+}
+
+func (s *fakeLeaseStore) setErr(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+func (s *fakeLeaseStore) steal(holder string) {
+	s.mu.Lock()
+	s.lease = &memory.Lease{Holder: holder, ExpiresAt: time.Now().Add(time.Hour)}
+	s.mu.Unlock()
+}
+
+func newTestElector(store LeaseStore, node string) *Elector {
+	return NewElector(&Config{NodeID: node, HeartbeatInterval: 5 * time.Millisecond, MissedHeartbeats: 3}, store)
+}
+
+func TestConfig(t *testing.T) {
+	cfg := &Config{}
+	if cfg.Interval() != 10*time.Second || cfg.LeaseTTL() != 30*time.Second {
+		t.Errorf("defaults = %v / %v, want 10s / 30s", cfg.Interval(), cfg.LeaseTTL())
+	}
+	cfg = &Config{HeartbeatInterval: 2 * time.Second, MissedHeartbeats: 5}
+	if cfg.LeaseTTL() != 10*time.Second {
+		t.Errorf("LeaseTTL() = %v, want 10s", cfg.LeaseTTL())
+	}
+	if cfg.ResolvedNodeID() == "" {
+		t.Error("ResolvedNodeID() should default to hostname-pid")
+	}
+	if err := (&Config{MissedHeartbeats: -1}).Validate(); err == nil {
+		t.Error("Validate() should reject negative missed_heartbeats")
+	}
+	if err := (&Config{HeartbeatInterval: -time.Second}).Validate(); err == nil {
+		t.Error("Validate() should reject negative heartbeat_interval")
+	}
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("DefaultConfig().Validate() = %v", err)
+	}
+}
+
+func TestElector_StandbyTakesOver(t *testing.T) {
+	store := &fakeLeaseStore{}
+	primary := newTestElector(store, "node-a")
+	standby := newTestElector(store, "node-b")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := primary.WaitForLeadership(ctx); err != nil {
+		t.Fatalf("primary WaitForLeadership() = %v", err)
+	}
+	if prev := primary.Previous(); prev != "" {
+		t.Errorf("first primary Previous() = %q, want empty", prev)
+	}
+
+	primaryCtx, stopPrimary := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- primary.Run(primaryCtx) }()
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- standby.WaitForLeadership(ctx) }()
+	select {
+	case err := <-acquired:
+		t.Fatalf("standby took over a live primary: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if standby.IsLeader() {
+		t.Fatal("standby should not be leader while primary heartbeats")
+	}
+
+	// The primary stops heartbeating without releasing, as on a host reboot
+	stopPrimary()
+	<-done
+	if err := <-acquired; err != nil {
+		t.Fatalf("standby WaitForLeadership() = %v", err)
+	}
+	if !standby.IsLeader() {
+		t.Error("standby should be leader after takeover")
+	}
+	if prev := standby.Previous(); prev != "node-a" {
+		t.Errorf("standby Previous() = %q, want node-a", prev)
+	}
+
+	// The old primary notices on its next heartbeat
+	if err := primary.heartbeat(); !errors.Is(err, ErrLeadershipLost) {
+		t.Errorf("old primary heartbeat() = %v, want ErrLeadershipLost", err)
+	}
+	if primary.IsLeader() {
+		t.Error("old primary should no longer be leader")
+	}
+}
+
+func TestElector_RunLosesLease(t *testing.T) {
+	store := &fakeLeaseStore{}
+	e := newTestElector(store, "node-a")
+	if err := e.WaitForLeadership(context.Background()); err != nil {
+		t.Fatalf("WaitForLeadership() = %v", err)
+	}
+	store.steal("node-b")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.Run(ctx); !errors.Is(err, ErrLeadershipLost) {
+		t.Errorf("Run() = %v, want ErrLeadershipLost", err)
+	}
+}
+
+func TestElector_RenewalFailures(t *testing.T) {
+	store := &fakeLeaseStore{}
+	e := newTestElector(store, "node-a")
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	if err := e.WaitForLeadership(context.Background()); err != nil {
+		t.Fatalf("WaitForLeadership() = %v", err)
+	}
+
+	// A failed renewal inside the TTL is tolerated
+	store.setErr(errors.New("database is locked"))
+	now = now.Add(10 * time.Millisecond)
+	if err := e.heartbeat(); err != nil || !e.IsLeader() {
+		t.Fatalf("heartbeat() within TTL = %v, leader = %v", err, e.IsLeader())
+	}
+
+	// Once the lease may have expired the node steps down
+	now = now.Add(15 * time.Millisecond)
+	if err := e.heartbeat(); !errors.Is(err, ErrLeadershipLost) || e.IsLeader() {
+		t.Errorf("heartbeat() past TTL = %v, leader = %v", err, e.IsLeader())
+	}
+}
+
+func TestElector_Release(t *testing.T) {
+	store := &fakeLeaseStore{}
+	primary := newTestElector(store, "node-a")
+	if err := primary.WaitForLeadership(context.Background()); err != nil {
+		t.Fatalf("WaitForLeadership() = %v", err)
+	}
+	if err := primary.Release(); err != nil {
+		t.Fatalf("Release() = %v", err)
+	}
+	if primary.IsLeader() || store.lease != nil {
+		t.Errorf("after Release leader = %v, lease = %+v", primary.IsLeader(), store.lease)
+	}
+
+	// The standby takes over on its first try instead of waiting out the TTL
+	standby := newTestElector(store, "node-b")
+	standby.interval = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := standby.WaitForLeadership(ctx); err != nil {
+		t.Errorf("standby WaitForLeadership() = %v", err)
+	}
+}
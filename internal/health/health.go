@@ -131,6 +131,27 @@ var backends = []backendInfo{
 		versionArgs: []string{"--version"},
 		installCmd:  "See https://github.com/anthropics/qwen-code",
 	},
+	{
+		name:        "codex",
+		backendType: "openai",
+		command:     "codex",
+		versionArgs: []string{"--version"},
+		installCmd:  "npm install -g @openai/codex",
+	},
+	{
+		name:        "gemini",
+		backendType: "gemini",
+		command:     "gemini",
+		versionArgs: []string{"--version"},
+		installCmd:  "npm install -g @google/gemini-cli",
+	},
+	{
+		name:        "ollama",
+		backendType: "ollama",
+		command:     "ollama",
+		versionArgs: []string{"--version"},
+		installCmd:  "See https://ollama.com/download",
+	},
 	{
 		name:        "opencode",
 		backendType: "opencode",
@@ -0,0 +1,320 @@
+package health
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// Severity ranks a preflight finding.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// String returns string representation
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the severity by name for JSON output.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ColorSymbol returns the colored symbol for a severity
+func (s Severity) ColorSymbol() string {
+	switch s {
+	case SeverityInfo:
+		return "\033[36mi\033[0m" // cyan
+	case SeverityWarning:
+		return "\033[33m○\033[0m" // yellow
+	case SeverityError:
+		return "\033[31m✗\033[0m" // red
+	default:
+		return "?"
+	}
+}
+
+// PreflightFinding is a single actionable problem found before startup.
+type PreflightFinding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"`
+}
+
+// PreflightReport collects startup findings that were previously logged
+// piecemeal while adapters initialized (missing tokens, repo/project
+// mismatches, Slack Socket Mode without app_token, teams DB problems).
+type PreflightReport struct {
+	Environment string             `json:"environment,omitempty"`
+	Findings    []PreflightFinding `json:"findings"`
+}
+
+// PreflightOptions carries runtime context that is not part of the config.
+type PreflightOptions struct {
+	// ProjectPath is the resolved default project (flag > config default > cwd).
+	// Used for repo/project matching of adapters.github.repo.
+	ProjectPath string
+
+	// Environment is the active autopilot environment name, if any.
+	Environment string
+}
+
+// RunPreflight checks the configuration for problems that would otherwise
+// surface as scattered warnings while Pilot starts.
+func RunPreflight(cfg *config.Config, opts PreflightOptions) *PreflightReport {
+	report := &PreflightReport{
+		Environment: opts.Environment,
+		Findings:    []PreflightFinding{},
+	}
+
+	report.checkBackend(cfg)
+	report.checkAdapterTokens(cfg)
+	report.checkSlack(cfg)
+	report.checkTelegram(cfg)
+	report.checkProjects(cfg, opts.ProjectPath)
+	report.checkTeams(cfg)
+
+	return report
+}
+
+func (r *PreflightReport) add(check string, severity Severity, message, fix string) {
+	r.Findings = append(r.Findings, PreflightFinding{
+		Check:    check,
+		Severity: severity,
+		Message:  message,
+		Fix:      fix,
+	})
+}
+
+// Count returns the number of findings with the given severity.
+func (r *PreflightReport) Count(severity Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// HasErrors returns true if any finding is an error.
+func (r *PreflightReport) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// checkBackend verifies git and the active backend CLI are installed.
+func (r *PreflightReport) checkBackend(cfg *config.Config) {
+	backendType := executor.BackendTypeClaudeCode
+	if cfg.Executor != nil && cfg.Executor.Type != "" {
+		backendType = cfg.Executor.Type
+	}
+
+	for _, d := range checkDependenciesWithBackend(backendType) {
+		switch d.Status {
+		case StatusError:
+			r.add("dependency."+d.Name, SeverityError, d.Message, d.Fix)
+		case StatusWarning:
+			r.add("dependency."+d.Name, SeverityWarning, d.Message, d.Fix)
+		}
+	}
+}
+
+// checkAdapterTokens reports enabled adapters without credentials.
+func (r *PreflightReport) checkAdapterTokens(cfg *config.Config) {
+	a := cfg.Adapters
+	if a == nil {
+		return
+	}
+
+	missing := func(check, fix string) {
+		r.add(check, SeverityError, "adapter enabled but credential missing", fix)
+	}
+
+	if a.GitHub != nil && a.GitHub.Enabled && a.GitHub.Token == "" && os.Getenv("GITHUB_TOKEN") == "" {
+		missing("github.token", "Set adapters.github.token or export GITHUB_TOKEN")
+	}
+	if a.GitLab != nil && a.GitLab.Enabled && a.GitLab.Token == "" {
+		missing("gitlab.token", "Set adapters.gitlab.token")
+	}
+	if a.Gitea != nil && a.Gitea.Enabled && a.Gitea.Token == "" {
+		missing("gitea.token", "Set adapters.gitea.token")
+	}
+	if a.AzureDevOps != nil && a.AzureDevOps.Enabled && a.AzureDevOps.PAT == "" {
+		missing("azure_devops.pat", "Set adapters.azure_devops.pat")
+	}
+	if a.Linear != nil && a.Linear.Enabled && !linearHasAPIKey(cfg) {
+		missing("linear.api_key", "Set adapters.linear.api_key or an api_key per workspace")
+	}
+	if a.Jira != nil && a.Jira.Enabled && a.Jira.APIToken == "" {
+		missing("jira.api_token", "Set adapters.jira.api_token")
+	}
+	if a.Asana != nil && a.Asana.Enabled && a.Asana.AccessToken == "" {
+		missing("asana.access_token", "Set adapters.asana.access_token")
+	}
+	if a.Plane != nil && a.Plane.Enabled && a.Plane.APIKey == "" {
+		missing("plane.api_key", "Set adapters.plane.api_key")
+	}
+	if a.Notion != nil && a.Notion.Enabled && a.Notion.APIKey == "" {
+		missing("notion.api_key", "Set adapters.notion.api_key")
+	}
+	if a.Trello != nil && a.Trello.Enabled && (a.Trello.APIKey == "" || a.Trello.Token == "") {
+		missing("trello.token", "Set adapters.trello.api_key and adapters.trello.token")
+	}
+	if a.ClickUp != nil && a.ClickUp.Enabled && a.ClickUp.APIToken == "" {
+		missing("clickup.api_token", "Set adapters.clickup.api_token")
+	}
+	if a.Telegram != nil && a.Telegram.Enabled && a.Telegram.BotToken == "" {
+		missing("telegram.bot_token", "Get token from @BotFather and add to config")
+	}
+	if a.Slack != nil && a.Slack.Enabled && a.Slack.BotToken == "" {
+		missing("slack.bot_token", "Add xoxb-... token to config")
+	}
+	if a.Discord != nil && a.Discord.Enabled && a.Discord.BotToken == "" {
+		missing("discord.bot_token", "Set adapters.discord.bot_token")
+	}
+	if a.Mattermost != nil && a.Mattermost.Enabled && a.Mattermost.BotToken == "" {
+		missing("mattermost.bot_token", "Set adapters.mattermost.bot_token")
+	}
+}
+
+// linearHasAPIKey checks the legacy key and per-workspace keys.
+func linearHasAPIKey(cfg *config.Config) bool {
+	l := cfg.Adapters.Linear
+	if l.APIKey != "" {
+		return true
+	}
+	for _, ws := range l.Workspaces {
+		if ws != nil && ws.APIKey != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSlack reports Socket Mode without an app-level token (GH-710).
+func (r *PreflightReport) checkSlack(cfg *config.Config) {
+	if cfg.Adapters == nil || cfg.Adapters.Slack == nil {
+		return
+	}
+	s := cfg.Adapters.Slack
+	if s.SocketMode && s.AppToken == "" {
+		r.add("slack.app_token", SeverityWarning,
+			"socket_mode enabled but app_token not configured, Slack Socket Mode will be skipped",
+			"Create an xapp-... app-level token with connections:write and set adapters.slack.app_token")
+	}
+}
+
+// checkTelegram reports an open bot that accepts tasks from anyone.
+func (r *PreflightReport) checkTelegram(cfg *config.Config) {
+	if cfg.Adapters == nil || cfg.Adapters.Telegram == nil || !cfg.Adapters.Telegram.Enabled {
+		return
+	}
+	// chat_id is also allowed, so only a bot with neither is open to everyone
+	if len(cfg.Adapters.Telegram.AllowedIDs) == 0 && cfg.Adapters.Telegram.ChatID == "" {
+		r.add("telegram.allowed_ids", SeverityWarning,
+			"allowed_ids is empty - ALL users can interact with the bot",
+			"Add your Telegram user ID to adapters.telegram.allowed_ids")
+	}
+}
+
+// checkProjects verifies project paths exist and that polled repos match
+// the directory they execute in (GH-386).
+func (r *PreflightReport) checkProjects(cfg *config.Config, projectPath string) {
+	if len(cfg.Projects) == 0 {
+		r.add("projects", SeverityWarning, "none configured", "Add projects to config.yaml")
+	}
+	for _, p := range cfg.Projects {
+		if p.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(expandPath(p.Path)); err != nil {
+			r.add("projects."+p.Name, SeverityError,
+				fmt.Sprintf("path %s does not exist", p.Path),
+				"Fix the project path in config.yaml")
+		}
+	}
+
+	if cfg.Adapters == nil || cfg.Adapters.GitHub == nil || !cfg.Adapters.GitHub.Enabled {
+		return
+	}
+
+	seen := map[string]bool{}
+	checkMatch := func(repo, path string) {
+		if seen[repo] {
+			return
+		}
+		seen[repo] = true
+		if err := executor.ValidateRepoProjectMatch(repo, path); err != nil {
+			r.add("github.repo", SeverityWarning,
+				fmt.Sprintf("repo %s polls into %s (expected project directory %q)", repo, path, executor.ExtractRepoName(repo)),
+				"Point the project path at a checkout of "+repo)
+		}
+	}
+
+	if cfg.Adapters.GitHub.Repo != "" {
+		checkMatch(cfg.Adapters.GitHub.Repo, projectPath)
+	}
+	for _, p := range cfg.Projects {
+		if p.GitHub == nil || p.GitHub.Owner == "" || p.GitHub.Repo == "" {
+			continue
+		}
+		path := p.Path
+		if path == "" {
+			path = projectPath
+		}
+		checkMatch(p.GitHub.Owner+"/"+p.GitHub.Repo, path)
+	}
+}
+
+// checkTeams verifies team RBAC can be enforced (GH-635). A misconfigured
+// team section silently disables project access checks, so it is an error.
+func (r *PreflightReport) checkTeams(cfg *config.Config) {
+	if cfg.Team == nil || !cfg.Team.Enabled {
+		return
+	}
+	if cfg.Team.TeamID == "" || cfg.Team.MemberEmail == "" {
+		r.add("team", SeverityError,
+			"team enabled but team_id or member_email not set, project access checks are skipped",
+			"Set team.team_id and team.member_email")
+	}
+	if cfg.Memory == nil || cfg.Memory.Path == "" {
+		r.add("team.db", SeverityError,
+			"memory path not configured, teams DB unavailable",
+			"Set memory.path in config.yaml")
+		return
+	}
+	if cfg.Memory.Driver == memory.DriverPostgres {
+		// Teams live in the shared PostgreSQL database
+		return
+	}
+	dbPath := filepath.Join(expandPath(cfg.Memory.Path), "pilot.db")
+	_, err := os.Stat(dbPath)
+	if err != nil {
+		// Encrypted at rest while no store has it open
+		_, err = os.Stat(dbPath + ".enc")
+	}
+	if err != nil {
+		r.add("team.db", SeverityError,
+			fmt.Sprintf("teams DB %s not found, project access checks are skipped", dbPath),
+			"Create the team with 'pilot team create' before starting")
+	}
+}
@@ -0,0 +1,174 @@
+package health
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/adapters/github"
+	"github.com/alekspetrov/pilot/internal/adapters/linear"
+	"github.com/alekspetrov/pilot/internal/adapters/slack"
+	"github.com/alekspetrov/pilot/internal/adapters/telegram"
+	"github.com/alekspetrov/pilot/internal/config"
+)
+
+func findFinding(report *PreflightReport, check string) *PreflightFinding {
+	for i := range report.Findings {
+		if report.Findings[i].Check == check {
+			return &report.Findings[i]
+		}
+	}
+	return nil
+}
+
+func TestRunPreflight_MissingTokens(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	cfg := &config.Config{
+		Adapters: &config.AdaptersConfig{
+			GitHub:   &github.Config{Enabled: true},
+			Linear:   &linear.Config{Enabled: true, Workspaces: []*linear.WorkspaceConfig{{APIKey: "lin_key"}}},
+			Telegram: &telegram.Config{Enabled: true},
+		},
+	}
+
+	report := RunPreflight(cfg, PreflightOptions{})
+
+	for _, check := range []string{"github.token", "telegram.bot_token"} {
+		f := findFinding(report, check)
+		if f == nil {
+			t.Fatalf("expected %s finding", check)
+		}
+		if f.Severity != SeverityError {
+			t.Errorf("%s severity = %v, want error", check, f.Severity)
+		}
+	}
+	if findFinding(report, "linear.api_key") != nil {
+		t.Error("workspace api_key should satisfy linear credentials")
+	}
+	if !report.HasErrors() {
+		t.Error("HasErrors() should be true")
+	}
+}
+
+func TestRunPreflight_GitHubTokenFromEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghp_env")
+	cfg := &config.Config{
+		Adapters: &config.AdaptersConfig{GitHub: &github.Config{Enabled: true}},
+	}
+
+	if f := findFinding(RunPreflight(cfg, PreflightOptions{}), "github.token"); f != nil {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
+
+func TestRunPreflight_SlackAndTelegramWarnings(t *testing.T) {
+	cfg := &config.Config{
+		Adapters: &config.AdaptersConfig{
+			Slack:    &slack.Config{Enabled: true, BotToken: "xoxb", SocketMode: true},
+			Telegram: &telegram.Config{Enabled: true, BotToken: "123:abc"},
+		},
+	}
+
+	report := RunPreflight(cfg, PreflightOptions{})
+
+	for _, check := range []string{"slack.app_token", "telegram.allowed_ids"} {
+		f := findFinding(report, check)
+		if f == nil {
+			t.Fatalf("expected %s finding", check)
+		}
+		if f.Severity != SeverityWarning {
+			t.Errorf("%s severity = %v, want warning", check, f.Severity)
+		}
+	}
+
+	cfg.Adapters.Telegram.ChatID = "42"
+	if findFinding(RunPreflight(cfg, PreflightOptions{}), "telegram.allowed_ids") != nil {
+		t.Error("chat_id should count as an allowed ID")
+	}
+}
+
+func TestRunPreflight_RepoProjectMismatch(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghp_env")
+	root := t.TempDir()
+	match := filepath.Join(root, "api")
+	other := filepath.Join(root, "web")
+	for _, dir := range []string{match, other} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{
+		Adapters: &config.AdaptersConfig{GitHub: &github.Config{Enabled: true, Repo: "acme/api"}},
+		Projects: []*config.ProjectConfig{
+			{Name: "web", Path: other, GitHub: &config.ProjectGitHubConfig{Owner: "acme", Repo: "frontend"}},
+		},
+	}
+
+	report := RunPreflight(cfg, PreflightOptions{ProjectPath: match})
+
+	var mismatches []string
+	for _, f := range report.Findings {
+		if f.Check == "github.repo" {
+			mismatches = append(mismatches, f.Message)
+		}
+	}
+	if len(mismatches) != 1 || !strings.Contains(mismatches[0], "acme/frontend") {
+		t.Errorf("mismatches = %v, want only acme/frontend", mismatches)
+	}
+}
+
+func TestRunPreflight_InvalidProjectPath(t *testing.T) {
+	cfg := &config.Config{
+		Projects: []*config.ProjectConfig{{Name: "gone", Path: "/nonexistent/path/xyz123"}},
+	}
+
+	f := findFinding(RunPreflight(cfg, PreflightOptions{}), "projects.gone")
+	if f == nil || f.Severity != SeverityError {
+		t.Errorf("finding = %+v, want error", f)
+	}
+}
+
+func TestRunPreflight_Teams(t *testing.T) {
+	memDir := t.TempDir()
+	cfg := &config.Config{
+		Team:   &config.TeamConfig{Enabled: true, TeamID: "core"},
+		Memory: &config.MemoryConfig{Path: memDir},
+	}
+
+	report := RunPreflight(cfg, PreflightOptions{})
+	if f := findFinding(report, "team"); f == nil || f.Severity != SeverityError {
+		t.Errorf("team finding = %+v, want error for missing member_email", f)
+	}
+	if f := findFinding(report, "team.db"); f == nil || !strings.Contains(f.Message, "pilot.db") {
+		t.Errorf("team.db finding = %+v, want missing DB", f)
+	}
+
+	cfg.Team.MemberEmail = "dev@acme.io"
+	if err := os.WriteFile(filepath.Join(memDir, "pilot.db"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report = RunPreflight(cfg, PreflightOptions{})
+	if findFinding(report, "team") != nil || findFinding(report, "team.db") != nil {
+		t.Errorf("unexpected team findings: %+v", report.Findings)
+	}
+}
+
+func TestPreflightReport_JSON(t *testing.T) {
+	report := &PreflightReport{Environment: "prod"}
+	report.add("slack.app_token", SeverityWarning, "missing", "")
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"environment":"prod","findings":[{"check":"slack.app_token","severity":"warning","message":"missing"}]}`
+	if string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+	if report.Count(SeverityWarning) != 1 || report.HasErrors() {
+		t.Errorf("counts wrong: %+v", report)
+	}
+}
@@ -0,0 +1,354 @@
+// Package hooks runs user Starlark scripts at points in the task lifecycle,
+// for per-org customization too specific for built-in config.
+//
+// A script defines any of the hook functions on_issue_received(event),
+// before_pr_create(event) and after_merge(event). The event is read-only;
+// scripts act through the predeclared pilot module:
+//
+//	pilot.comment(text)      comment on the issue or PR
+//	pilot.label(name, ...)   add labels to the issue or PR
+//	pilot.set(field, value)  change "title" or "body" before Pilot uses it
+//
+// Scripts are sandboxed: load() is unavailable, there is no file or network
+// access, and each hook call has a step and time budget.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// Hook names, which are also the function names scripts define
+const (
+	HookIssueReceived  = "on_issue_received"
+	HookBeforePRCreate = "before_pr_create"
+	HookAfterMerge     = "after_merge"
+)
+
+// settableFields lists the event fields each hook may change with pilot.set
+var settableFields = map[string][]string{
+	HookIssueReceived:  {"title", "body"},
+	HookBeforePRCreate: {"title", "body"},
+}
+
+const (
+	defaultTimeout = 5 * time.Second
+	maxSteps       = 10_000_000
+	callStateKey   = "pilot.call"
+)
+
+// Config configures scripting hooks
+type Config struct {
+	Enabled bool          `yaml:"enabled"`
+	Scripts []string      `yaml:"scripts"` // Paths to .star files, run in order
+	Timeout time.Duration `yaml:"timeout"` // Per script and hook call (default: 5s)
+}
+
+// Validate checks the script list and timeout. Scripts are compiled by New.
+func (c *Config) Validate() error {
+	if len(c.Scripts) == 0 {
+		return fmt.Errorf("at least one script is required")
+	}
+	for _, path := range c.Scripts {
+		if path == "" {
+			return fmt.Errorf("script path must not be empty")
+		}
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	return nil
+}
+
+// Event is the payload passed to a hook
+type Event struct {
+	Hook   string
+	Repo   string // "owner/repo"
+	Number int    // Issue number, or PR number for after_merge
+	Title  string
+	Body   string
+	Labels []string
+	Author string
+	Branch string
+	URL    string
+}
+
+// Result collects the actions requested by scripts
+type Result struct {
+	Title    string // Event title after pilot.set
+	Body     string // Event body after pilot.set
+	Comments []string
+	Labels   []string
+}
+
+// Target receives comments and labels for the issue or PR an event is about
+type Target interface {
+	Comment(ctx context.Context, body string) error
+	AddLabels(ctx context.Context, labels []string) error
+}
+
+// Apply posts the requested comments and labels to target
+func (r *Result) Apply(ctx context.Context, target Target) error {
+	var errs []error
+	for _, comment := range r.Comments {
+		if err := target.Comment(ctx, comment); err != nil {
+			errs = append(errs, fmt.Errorf("comment: %w", err))
+		}
+	}
+	if len(r.Labels) > 0 {
+		if err := target.AddLabels(ctx, r.Labels); err != nil {
+			errs = append(errs, fmt.Errorf("labels: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// script is a loaded hook script
+type script struct {
+	path    string
+	globals starlark.StringDict
+}
+
+// Engine runs hook functions defined by the configured scripts
+type Engine struct {
+	scripts []script
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+// Option configures an Engine
+type Option func(*Engine)
+
+// WithLogger sets the logger, which also receives script print() output
+func WithLogger(logger *slog.Logger) Option {
+	return func(e *Engine) {
+		e.logger = logger
+	}
+}
+
+// New loads and compiles the configured scripts
+func New(cfg *Config, opts ...Option) (*Engine, error) {
+	e := &Engine{timeout: cfg.Timeout, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.timeout == 0 {
+		e.timeout = defaultTimeout
+	}
+
+	for _, path := range cfg.Scripts {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hook script: %w", err)
+		}
+		thread := e.newThread(path)
+		globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, src, predeclared)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load hook script %s: %w", path, err)
+		}
+		for _, hook := range []string{HookIssueReceived, HookBeforePRCreate, HookAfterMerge} {
+			if fn, ok := globals[hook]; ok {
+				if _, callable := fn.(starlark.Callable); !callable {
+					return nil, fmt.Errorf("hook script %s: %s must be a function", path, hook)
+				}
+			}
+		}
+		e.scripts = append(e.scripts, script{path: path, globals: globals})
+	}
+	return e, nil
+}
+
+// Has reports whether any script defines the hook
+func (e *Engine) Has(hook string) bool {
+	if e == nil {
+		return false
+	}
+	for _, s := range e.scripts {
+		if _, ok := s.globals[hook]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Run calls the event's hook in every script that defines it, in order. Fields
+// set by one script are visible to the next. A failing script does not stop
+// the others; the returned error joins their failures and the result still
+// holds the actions of the scripts that succeeded.
+func (e *Engine) Run(ctx context.Context, event Event) (*Result, error) {
+	result := &Result{Title: event.Title, Body: event.Body}
+	if e == nil {
+		return result, nil
+	}
+
+	var errs []error
+	for _, s := range e.scripts {
+		fn, ok := s.globals[event.Hook]
+		if !ok {
+			continue
+		}
+		if err := e.call(ctx, s.path, fn, event, result); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", s.path, event.Hook, err))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// call runs one hook function, recording its actions into result only if it
+// succeeds
+func (e *Engine) call(ctx context.Context, path string, fn starlark.Value, event Event, result *Result) error {
+	state := &callState{hook: event.Hook, result: &Result{Title: result.Title, Body: result.Body}}
+	thread := e.newThread(path)
+	thread.SetLocal(callStateKey, state)
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	event.Title, event.Body = result.Title, result.Body
+	if _, err := starlark.Call(thread, fn, starlark.Tuple{eventValue(event)}, nil); err != nil {
+		return err
+	}
+
+	result.Title, result.Body = state.result.Title, state.result.Body
+	result.Comments = append(result.Comments, state.result.Comments...)
+	for _, label := range state.result.Labels {
+		if !slices.Contains(result.Labels, label) {
+			result.Labels = append(result.Labels, label)
+		}
+	}
+	return nil
+}
+
+// newThread creates a sandboxed thread: load() fails and print() is logged
+func (e *Engine) newThread(path string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: path,
+		Print: func(_ *starlark.Thread, msg string) {
+			e.logger.Info("hook script output", slog.String("script", path), slog.String("message", msg))
+		},
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	return thread
+}
+
+// eventValue converts an event to a read-only Starlark struct
+func eventValue(event Event) starlark.Value {
+	labels := make(starlark.Tuple, 0, len(event.Labels))
+	for _, label := range event.Labels {
+		labels = append(labels, starlark.String(label))
+	}
+	return starlarkstruct.FromStringDict(starlark.String("event"), starlark.StringDict{
+		"hook":   starlark.String(event.Hook),
+		"repo":   starlark.String(event.Repo),
+		"number": starlark.MakeInt(event.Number),
+		"title":  starlark.String(event.Title),
+		"body":   starlark.String(event.Body),
+		"labels": labels,
+		"author": starlark.String(event.Author),
+		"branch": starlark.String(event.Branch),
+		"url":    starlark.String(event.URL),
+	})
+}
+
+// callState tracks the hook being called and the actions it requested
+type callState struct {
+	hook   string
+	result *Result
+}
+
+// predeclared exposes the pilot module to scripts
+var predeclared = starlark.StringDict{
+	"pilot": &starlarkstruct.Module{
+		Name: "pilot",
+		Members: starlark.StringDict{
+			"comment": starlark.NewBuiltin("comment", builtinComment),
+			"label":   starlark.NewBuiltin("label", builtinLabel),
+			"set":     starlark.NewBuiltin("set", builtinSet),
+		},
+	},
+}
+
+// currentCall returns the hook call a builtin runs in
+func currentCall(thread *starlark.Thread, b *starlark.Builtin) (*callState, error) {
+	state, ok := thread.Local(callStateKey).(*callState)
+	if !ok {
+		return nil, fmt.Errorf("pilot.%s can only be called from a hook function", b.Name())
+	}
+	return state, nil
+}
+
+func builtinComment(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var text string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &text); err != nil {
+		return nil, err
+	}
+	state, err := currentCall(thread, b)
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, fmt.Errorf("pilot.comment: text must not be empty")
+	}
+	state.result.Comments = append(state.result.Comments, text)
+	return starlark.None, nil
+}
+
+func builtinLabel(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if len(kwargs) > 0 {
+		return nil, fmt.Errorf("pilot.label: unexpected keyword arguments")
+	}
+	state, err := currentCall(thread, b)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("pilot.label: at least one label is required")
+	}
+	for _, arg := range args {
+		label, ok := starlark.AsString(arg)
+		if !ok || label == "" {
+			return nil, fmt.Errorf("pilot.label: labels must be non-empty strings, got %s", arg.Type())
+		}
+		state.result.Labels = append(state.result.Labels, label)
+	}
+	return starlark.None, nil
+}
+
+func builtinSet(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var field, value string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &field, &value); err != nil {
+		return nil, err
+	}
+	state, err := currentCall(thread, b)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(settableFields[state.hook], field) {
+		return nil, fmt.Errorf("pilot.set: field %q cannot be set in %s", field, state.hook)
+	}
+	switch field {
+	case "title":
+		state.result.Title = value
+	case "body":
+		state.result.Body = value
+	}
+	return starlark.None, nil
+}
@@ -0,0 +1,223 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeScript(t *testing.T, name, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+type mockTarget struct {
+	comments []string
+	labels   []string
+	err      error
+}
+
+func (m *mockTarget) Comment(_ context.Context, body string) error {
+	m.comments = append(m.comments, body)
+	return m.err
+}
+
+func (m *mockTarget) AddLabels(_ context.Context, labels []string) error {
+	m.labels = append(m.labels, labels...)
+	return m.err
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"valid", Config{Scripts: []string{"triage.star"}}, false},
+		{"no scripts", Config{}, true},
+		{"empty path", Config{Scripts: []string{""}}, true},
+		{"negative timeout", Config{Scripts: []string{"triage.star"}, Timeout: -time.Second}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEngine_Run(t *testing.T) {
+	triage := writeScript(t, "triage.star", `
+def on_issue_received(event):
+    if "security" in event.labels:
+        pilot.label("priority:high", "needs-review")
+        pilot.comment("Security issue #%d in %s routed to the security team" % (event.number, event.repo))
+    pilot.set("title", "[" + event.author + "] " + event.title)
+`)
+	pr := writeScript(t, "pr.star", `
+def on_issue_received(event):
+    pilot.set("body", event.title + "\n\n" + event.body)
+    pilot.label("needs-review")
+
+def before_pr_create(event):
+    pilot.set("body", event.body + "\n\nReviewed-by: platform-team")
+`)
+	engine, err := New(&Config{Scripts: []string{triage, pr}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !engine.Has(HookIssueReceived) || !engine.Has(HookBeforePRCreate) || engine.Has(HookAfterMerge) {
+		t.Errorf("Has() reports wrong hooks")
+	}
+
+	result, err := engine.Run(context.Background(), Event{
+		Hook:   HookIssueReceived,
+		Repo:   "acme/api",
+		Number: 42,
+		Title:  "Fix token leak",
+		Body:   "Tokens are logged",
+		Labels: []string{"pilot", "security"},
+		Author: "dev",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	// The second script sees the title set by the first
+	if result.Title != "[dev] Fix token leak" || result.Body != "[dev] Fix token leak\n\nTokens are logged" {
+		t.Errorf("fields = %q / %q", result.Title, result.Body)
+	}
+	if want := []string{"priority:high", "needs-review"}; !reflect.DeepEqual(result.Labels, want) {
+		t.Errorf("Labels = %v, want %v", result.Labels, want)
+	}
+	if len(result.Comments) != 1 || result.Comments[0] != "Security issue #42 in acme/api routed to the security team" {
+		t.Errorf("Comments = %v", result.Comments)
+	}
+
+	result, err = engine.Run(context.Background(), Event{Hook: HookBeforePRCreate, Title: "GH-42: Fix", Body: "Closes #42"})
+	if err != nil {
+		t.Fatalf("Run(before_pr_create) error = %v", err)
+	}
+	if result.Title != "GH-42: Fix" || result.Body != "Closes #42\n\nReviewed-by: platform-team" {
+		t.Errorf("before_pr_create fields = %q / %q", result.Title, result.Body)
+	}
+
+	// Hooks no script defines are a no-op
+	result, err = engine.Run(context.Background(), Event{Hook: HookAfterMerge, Title: "t"})
+	if err != nil || result.Title != "t" || len(result.Comments) != 0 {
+		t.Errorf("Run(after_merge) = %+v, %v", result, err)
+	}
+}
+
+func TestEngine_RunErrors(t *testing.T) {
+	bad := writeScript(t, "bad.star", `
+def after_merge(event):
+    pilot.label("merged")
+    pilot.set("title", "nope")
+`)
+	good := writeScript(t, "good.star", `
+def after_merge(event):
+    pilot.comment("Merged " + event.url)
+`)
+	engine, err := New(&Config{Scripts: []string{bad, good}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	result, err := engine.Run(context.Background(), Event{Hook: HookAfterMerge, URL: "https://github.com/acme/api/pull/7"})
+	if err == nil || !strings.Contains(err.Error(), `field "title" cannot be set in after_merge`) {
+		t.Fatalf("Run() error = %v, want set rejected", err)
+	}
+	// The failing script's actions are discarded; the other script still runs
+	if len(result.Labels) != 0 {
+		t.Errorf("Labels = %v, want none from the failed script", result.Labels)
+	}
+	if len(result.Comments) != 1 {
+		t.Errorf("Comments = %v, want the good script's comment", result.Comments)
+	}
+}
+
+func TestEngine_Sandbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{"load is unavailable", `load("os.star", "system")`, "load"},
+		{"api outside hook", `pilot.comment("hi")`, "can only be called from a hook function"},
+		{"hook not a function", `on_issue_received = 1`, "must be a function"},
+		{"syntax error", `def on_issue_received(event)`, "failed to load"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeScript(t, "hook.star", tt.src)
+			if _, err := New(&Config{Scripts: []string{path}}); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := New(&Config{Scripts: []string{filepath.Join(t.TempDir(), "missing.star")}}); err == nil {
+		t.Error("New() with missing script should fail")
+	}
+}
+
+func TestEngine_Timeout(t *testing.T) {
+	path := writeScript(t, "slow.star", `
+def on_issue_received(event):
+    n = 0
+    for i in range(100000000):
+        n += i
+`)
+	engine, err := New(&Config{Scripts: []string{path}, Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	start := time.Now()
+	if _, err := engine.Run(context.Background(), Event{Hook: HookIssueReceived}); err == nil {
+		t.Fatal("Run() error = nil, want cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Run() took %s, want it cancelled", elapsed)
+	}
+}
+
+func TestResult_Apply(t *testing.T) {
+	result := &Result{Comments: []string{"a", "b"}, Labels: []string{"x"}}
+	target := &mockTarget{}
+	if err := result.Apply(context.Background(), target); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !reflect.DeepEqual(target.comments, []string{"a", "b"}) || !reflect.DeepEqual(target.labels, []string{"x"}) {
+		t.Errorf("target = %+v", target)
+	}
+
+	failing := &mockTarget{err: errors.New("forbidden")}
+	if err := result.Apply(context.Background(), failing); err == nil {
+		t.Error("Apply() error = nil, want target failure")
+	}
+	if len(failing.comments) != 2 {
+		t.Errorf("Apply() stopped early: %v", failing.comments)
+	}
+}
+
+func TestEngine_NilIsNoop(t *testing.T) {
+	var engine *Engine
+	if engine.Has(HookIssueReceived) {
+		t.Error("nil engine Has() = true")
+	}
+	result, err := engine.Run(context.Background(), Event{Hook: HookIssueReceived, Title: "t", Body: "b"})
+	if err != nil || result.Title != "t" || result.Body != "b" {
+		t.Errorf("nil engine Run() = %+v, %v", result, err)
+	}
+}
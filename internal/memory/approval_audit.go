@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+)
+
+// ApprovalAudit is one event in the life of an approval request: who was
+// asked, who answered and what was decided.
+type ApprovalAudit struct {
+	ID        int64
+	RequestID string
+	TaskID    string
+	Stage     string
+	Channel   string // Approval channel, empty for events outside a channel
+	Event     string // requested, voted, escalated, decided or timed_out
+	Actor     string // Approver, "system" for timeouts, empty when nobody acted
+	Decision  string
+	CreatedAt time.Time
+}
+
+// SaveApprovalAudit appends an event to the approval audit trail and sets its ID
+func (s *Store) SaveApprovalAudit(e *ApprovalAudit) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	return s.withRetry("SaveApprovalAudit", func() error {
+		var err error
+		e.ID, err = s.Dialect().InsertID(s.db, `
+			INSERT INTO approval_audit (request_id, task_id, stage, channel, event, actor, decision, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, e.RequestID, e.TaskID, e.Stage, e.Channel, e.Event, e.Actor, e.Decision, e.CreatedAt.UTC())
+		return err
+	})
+}
+
+// GetApprovalAudit returns the most recent approval audit events, oldest
+// first. An empty taskID returns events for every task; limit <= 0 returns
+// them all.
+func (s *Store) GetApprovalAudit(taskID string, limit int) ([]*ApprovalAudit, error) {
+	query := `
+		SELECT id, request_id, task_id, stage, channel, event, actor, decision, created_at
+		FROM approval_audit WHERE (? = '' OR task_id = ?) ORDER BY id DESC`
+	args := []interface{}{taskID, taskID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query approval audit: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []*ApprovalAudit
+	for rows.Next() {
+		var e ApprovalAudit
+		if err := rows.Scan(&e.ID, &e.RequestID, &e.TaskID, &e.Stage, &e.Channel, &e.Event, &e.Actor, &e.Decision, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
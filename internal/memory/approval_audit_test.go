@@ -0,0 +1,41 @@
+package memory
+
+import "testing"
+
+func TestApprovalAudit(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	events := []*ApprovalAudit{
+		{RequestID: "r1", TaskID: "GH-1", Stage: "pre_merge", Channel: "slack", Event: "requested"},
+		{RequestID: "r1", TaskID: "GH-1", Stage: "pre_merge", Channel: "slack", Event: "decided", Actor: "alice", Decision: "approved"},
+		{RequestID: "r2", TaskID: "GH-2", Stage: "pre_execution", Event: "timed_out", Actor: "system", Decision: "rejected"},
+	}
+	for _, e := range events {
+		if err := store.SaveApprovalAudit(e); err != nil {
+			t.Fatalf("SaveApprovalAudit failed: %v", err)
+		}
+		if e.ID == 0 {
+			t.Fatalf("ID not set: %+v", e)
+		}
+	}
+
+	trail, err := store.GetApprovalAudit("GH-1", 0)
+	if err != nil {
+		t.Fatalf("GetApprovalAudit failed: %v", err)
+	}
+	if len(trail) != 2 || trail[0].Event != "requested" || trail[1].Actor != "alice" || trail[1].Decision != "approved" {
+		t.Errorf("unexpected trail for GH-1: %+v", trail)
+	}
+
+	all, err := store.GetApprovalAudit("", 2)
+	if err != nil {
+		t.Fatalf("GetApprovalAudit failed: %v", err)
+	}
+	if len(all) != 2 || all[0].Event != "decided" || all[1].TaskID != "GH-2" {
+		t.Errorf("expected the two latest events oldest first, got %+v", all)
+	}
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
+)
+
+// atRest keeps the database file encrypted while no process has it open.
+//
+// SQLite needs a plaintext file to work on, so the database is decrypted
+// from pilot.db.enc when the first store opens it and sealed back when the
+// last one closes. Every open store holds a shared lock on pilot.db.lock;
+// only a store that can take the lock exclusively on close is the last one
+// and seals the database.
+type atRest struct {
+	key     *encryption.Key
+	dbPath  string
+	encPath string
+	lock    *os.File
+}
+
+// openAtRest prepares the plaintext database for NewStore. Returns nil when
+// encryption is off and the database is not sealed.
+func openAtRest(dbPath string) (*atRest, error) {
+	encPath := dbPath + ".enc"
+	key, err := encryption.Default()
+	if err != nil {
+		return nil, fmt.Errorf("memory store: %w", err)
+	}
+	if key == nil {
+		if !exists(dbPath) && exists(encPath) {
+			return nil, fmt.Errorf("memory store %s is encrypted: %w", encPath, encryption.ErrKeyUnavailable)
+		}
+		return nil, nil
+	}
+
+	lock, err := os.OpenFile(dbPath+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database lock: %w", err)
+	}
+	a := &atRest{key: key, dbPath: dbPath, encPath: encPath, lock: lock}
+
+	// Stores hold the lock shared while open. Decrypting takes it
+	// exclusively, and another store may seal the database again before the
+	// lock is shared, so check until it sticks
+	for attempt := 0; attempt < 5; attempt++ {
+		if err := lockShared(lock); err != nil {
+			_ = lock.Close()
+			return nil, fmt.Errorf("failed to lock database: %w", err)
+		}
+		// A plaintext file next to the sealed one is newer: a store is
+		// open, or the last one crashed before sealing
+		if exists(dbPath) || !exists(encPath) {
+			return a, nil
+		}
+		if err := lockExclusive(lock); err != nil {
+			_ = lock.Close()
+			return nil, fmt.Errorf("failed to lock database: %w", err)
+		}
+		if !exists(dbPath) && exists(encPath) {
+			if err := key.DecryptFile(encPath, dbPath); err != nil {
+				_ = unlock(lock)
+				_ = lock.Close()
+				return nil, fmt.Errorf("failed to decrypt memory store: %w", err)
+			}
+		}
+	}
+	_ = unlock(lock)
+	_ = lock.Close()
+	return nil, fmt.Errorf("memory store %s was sealed repeatedly while opening", dbPath)
+}
+
+// close closes db and seals the database when no other store has it open.
+func (a *atRest) close(db *sql.DB) error {
+	defer func() { _ = a.lock.Close() }()
+
+	if !tryLockExclusive(a.lock) {
+		// Other stores still use the plaintext file
+		return db.Close()
+	}
+	defer func() { _ = unlock(a.lock) }()
+
+	// Fold the WAL into the main file so sealing it captures everything
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		slog.Warn("WAL checkpoint before sealing failed", slog.Any("error", err))
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+	if err := a.key.EncryptFile(a.dbPath, a.encPath); err != nil {
+		return fmt.Errorf("failed to encrypt memory store: %w", err)
+	}
+	for _, path := range []string{a.dbPath, a.dbPath + "-wal", a.dbPath + "-shm"} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove plaintext database: %w", err)
+		}
+	}
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
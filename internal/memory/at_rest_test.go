@@ -0,0 +1,79 @@
+//go:build !windows
+
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
+)
+
+func TestStore_EncryptedAtRest(t *testing.T) {
+	encoded, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encryption.ParseKey(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryption.SetDefault(key)
+	defer encryption.SetDefault(nil)
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "pilot.db")
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	// A second store shares the plaintext file; closing it must not seal
+	other, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("second NewStore failed: %v", err)
+	}
+	if err := store.SaveExecution(&Execution{ID: "exec-1", TaskID: "TG-1", ProjectPath: "/secret/project", Status: "completed"}); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+	if err := other.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		t.Fatalf("plaintext database removed while a store is open: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := os.Stat(dbPath); !os.IsNotExist(err) {
+		t.Errorf("plaintext database left behind after the last close: %v", err)
+	}
+	sealed, err := os.ReadFile(dbPath + ".enc")
+	if err != nil {
+		t.Fatalf("sealed database missing: %v", err)
+	}
+	if !encryption.IsEncrypted(sealed) || bytes.Contains(sealed, []byte("/secret/project")) {
+		t.Error("sealed database is not encrypted")
+	}
+
+	store, err = NewStore(dir)
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	exec, err := store.GetExecution("exec-1")
+	if err != nil || exec.ProjectPath != "/secret/project" {
+		t.Errorf("GetExecution after reopening = %+v, %v", exec, err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	encryption.SetDefault(nil)
+	if _, err := NewStore(dir); !errors.Is(err, encryption.ErrKeyUnavailable) {
+		t.Errorf("opening without a key: err = %v, want ErrKeyUnavailable", err)
+	}
+}
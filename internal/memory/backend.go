@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory store database drivers
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// BackendConfig selects the database behind every Store. SQLite keeps one
+// database file per data path and suits a single Pilot instance.
+// PostgreSQL lets several instances share executions, patterns, autopilot
+// state, teams and budgets.
+type BackendConfig struct {
+	Driver string // DriverSQLite (default) or DriverPostgres
+	DSN    string // PostgreSQL connection string, unused by SQLite
+}
+
+var (
+	backendMu      sync.RWMutex
+	defaultBackend BackendConfig
+)
+
+// Validate checks the driver and that PostgreSQL has a DSN
+func (c BackendConfig) Validate() error {
+	switch c.Driver {
+	case "", DriverSQLite:
+		return nil
+	case DriverPostgres:
+		if c.DSN == "" {
+			return fmt.Errorf("driver %q requires a dsn", c.Driver)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown driver %q (must be %s or %s)", c.Driver, DriverSQLite, DriverPostgres)
+	}
+}
+
+// InitBackend sets the process-wide backend that NewStore opens. Without
+// it, stores use SQLite.
+func InitBackend(cfg BackendConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.Driver == "" {
+		cfg.Driver = DriverSQLite
+	}
+
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	defaultBackend = cfg
+	return nil
+}
+
+// currentBackend returns the process-wide backend
+func currentBackend() BackendConfig {
+	backendMu.RLock()
+	defer backendMu.RUnlock()
+	if defaultBackend.Driver == "" {
+		return BackendConfig{Driver: DriverSQLite}
+	}
+	return defaultBackend
+}
+
+// openSQLite opens the SQLite database in dataPath, sealed at rest when
+// encryption is on
+func openSQLite(dataPath string) (*sql.DB, *atRest, error) {
+	dbPath := filepath.Join(dataPath, "pilot.db")
+	atRest, err := openAtRest(dbPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode and busy timeout for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL; PRAGMA busy_timeout=10000;"); err != nil {
+		return nil, nil, fmt.Errorf("failed to set database pragmas: %w", err)
+	}
+
+	// SQLite supports only one writer at a time. Limiting to 1 open connection
+	// serializes all database access, eliminating SQLITE_BUSY contention.
+	// WAL mode still allows the single connection to interleave reads and writes efficiently.
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0) // Don't close idle connections
+
+	return db, atRest, nil
+}
+
+// openPostgres connects to PostgreSQL through the driver that rebinds the
+// stores' placeholders
+func openPostgres(dsn string) (*sql.DB, error) {
+	db, err := sql.Open(postgresDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(30 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	return db, nil
+}
+
+// dbTime scans a timestamp that SQLite returns as text, e.g. the result of
+// MAX() over a DATETIME column, as well as native time values
+type dbTime struct {
+	Time time.Time
+}
+
+// dbTimeLayouts are the layouts SQLite stores timestamps in
+var dbTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700 MST", // time.Time written by the driver
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05", // CURRENT_TIMESTAMP
+}
+
+// Scan implements sql.Scanner
+func (t *dbTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into time", src)
+	}
+}
+
+func (t *dbTime) parse(s string) error {
+	// Drop the monotonic clock reading of time.Time.String()
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	for _, layout := range dbTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse time %q", s)
+}
+
+// Snapshot writes a consistent copy of the SQLite database to path, which
+// must not exist. The copy is plaintext even when the store is encrypted
+// at rest. PostgreSQL stores are backed up with the server's own tools.
+func (s *Store) Snapshot(path string) error {
+	if s.driver == DriverPostgres {
+		return fmt.Errorf("snapshot of a %s memory store is not supported, use pg_dump", s.driver)
+	}
+	if _, err := s.db.Exec("VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to snapshot memory store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Chargeback groupings
+const (
+	ChargebackByMember  = "member"
+	ChargebackByLabel   = "label"
+	ChargebackByProject = "project"
+)
+
+// Chargeback keys for executions without the grouped attribute
+const (
+	ChargebackUnattributed = "(unattributed)"
+	ChargebackUnlabeled    = "(unlabeled)"
+)
+
+// ChargebackQuery selects the executions to charge back and how to group
+// their costs
+type ChargebackQuery struct {
+	Start    time.Time
+	End      time.Time
+	Projects []string // Empty = all projects
+	GroupBy  string   // ChargebackByMember, ChargebackByLabel or ChargebackByProject
+	// IgnoreLabels are not charged, e.g. Pilot's trigger and status labels
+	IgnoreLabels []string
+}
+
+// ChargebackRow is the cost of one member, label or project
+type ChargebackRow struct {
+	Key          string  `json:"key"` // Member ID, label or project path
+	Executions   int     `json:"executions"`
+	TokensInput  int64   `json:"tokens_input"`
+	TokensOutput int64   `json:"tokens_output"`
+	TokensTotal  int64   `json:"tokens_total"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// GetChargeback sums the cost of the executions created in the query
+// period by member, label or project, most expensive first. An execution
+// with several labels is split evenly between them, so the rows always add
+// up to the total spend.
+func (s *Store) GetChargeback(query ChargebackQuery) ([]*ChargebackRow, error) {
+	switch query.GroupBy {
+	case ChargebackByMember, ChargebackByLabel, ChargebackByProject:
+	default:
+		return nil, fmt.Errorf("unsupported chargeback grouping: %q", query.GroupBy)
+	}
+
+	args := []interface{}{query.Start, query.End}
+	projectFilter := ""
+	if len(query.Projects) > 0 {
+		placeholders := ""
+		for i, p := range query.Projects {
+			if i > 0 {
+				placeholders += ","
+			}
+			placeholders += "?"
+			args = append(args, p)
+		}
+		projectFilter = " AND project_path IN (" + placeholders + ")"
+	}
+
+	rows, err := s.db.Query(`
+		SELECT project_path, COALESCE(requested_by, ''), COALESCE(task_labels, ''),
+			COALESCE(tokens_input, 0), COALESCE(tokens_output, 0), COALESCE(tokens_total, 0),
+			COALESCE(estimated_cost_usd, 0)
+		FROM executions
+		WHERE created_at >= ? AND created_at < ?`+projectFilter+`
+			AND (COALESCE(estimated_cost_usd, 0) > 0 OR COALESCE(tokens_total, 0) > 0)
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	ignore := make(map[string]bool, len(query.IgnoreLabels))
+	for _, l := range query.IgnoreLabels {
+		ignore[l] = true
+	}
+
+	totals := make(map[string]*ChargebackRow)
+	charge := func(key string, share float64, input, output, total int64, cost float64) {
+		row, ok := totals[key]
+		if !ok {
+			row = &ChargebackRow{Key: key}
+			totals[key] = row
+		}
+		row.Executions++
+		row.TokensInput += int64(float64(input) * share)
+		row.TokensOutput += int64(float64(output) * share)
+		row.TokensTotal += int64(float64(total) * share)
+		row.CostUSD += cost * share
+	}
+
+	for rows.Next() {
+		var project, member, labelsJSON string
+		var input, output, total int64
+		var cost float64
+		if err := rows.Scan(&project, &member, &labelsJSON, &input, &output, &total, &cost); err != nil {
+			return nil, err
+		}
+
+		switch query.GroupBy {
+		case ChargebackByMember:
+			if member == "" {
+				member = ChargebackUnattributed
+			}
+			charge(member, 1, input, output, total, cost)
+		case ChargebackByProject:
+			charge(project, 1, input, output, total, cost)
+		case ChargebackByLabel:
+			var labels []string
+			for _, l := range unmarshalLabels(labelsJSON) {
+				if !ignore[l] {
+					labels = append(labels, l)
+				}
+			}
+			if len(labels) == 0 {
+				labels = []string{ChargebackUnlabeled}
+			}
+			share := 1 / float64(len(labels))
+			for _, l := range labels {
+				charge(l, share, input, output, total, cost)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*ChargebackRow, 0, len(totals))
+	for _, row := range totals {
+		result = append(result, row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CostUSD != result[j].CostUSD {
+			return result[i].CostUSD > result[j].CostUSD
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+// marshalLabels encodes task labels for the task_labels column, NULL when
+// there are none
+func marshalLabels(labels []string) (interface{}, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return nil, fmt.Errorf("marshal task labels: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalLabels decodes the task_labels column
+func unmarshalLabels(data string) []string {
+	if data == "" {
+		return nil
+	}
+	var labels []string
+	_ = json.Unmarshal([]byte(data), &labels)
+	return labels
+}
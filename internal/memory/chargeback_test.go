@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetChargeback(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	execs := []*Execution{
+		{ID: "e1", ProjectPath: "/api", RequestedBy: "alice", TaskLabels: []string{"pilot", "team:payments"}, TokensTotal: 1000, EstimatedCostUSD: 6},
+		{ID: "e2", ProjectPath: "/api", RequestedBy: "bob", TaskLabels: []string{"team:payments", "team:search"}, TokensTotal: 2000, EstimatedCostUSD: 4},
+		{ID: "e3", ProjectPath: "/web", TaskLabels: []string{"pilot"}, TokensTotal: 500, EstimatedCostUSD: 1},
+		{ID: "e4", ProjectPath: "/web", RequestedBy: "alice", Status: "queued"}, // Nothing spent yet
+	}
+	for _, e := range execs {
+		e.TaskID = e.ID
+		if e.Status == "" {
+			e.Status = "completed"
+		}
+		if err := store.SaveExecution(e); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+	}
+
+	got, err := store.GetExecution("e2")
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.TaskLabels, []string{"team:payments", "team:search"}) {
+		t.Errorf("TaskLabels = %v, want round trip", got.TaskLabels)
+	}
+
+	now := time.Now()
+	query := ChargebackQuery{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+	charge := func(groupBy string, ignore ...string) map[string]ChargebackRow {
+		t.Helper()
+		q := query
+		q.GroupBy, q.IgnoreLabels = groupBy, ignore
+		rows, err := store.GetChargeback(q)
+		if err != nil {
+			t.Fatalf("GetChargeback(%s) failed: %v", groupBy, err)
+		}
+		byKey := make(map[string]ChargebackRow)
+		for i, r := range rows {
+			if i > 0 && r.CostUSD > rows[i-1].CostUSD {
+				t.Errorf("rows not sorted by cost: %+v", rows)
+			}
+			byKey[r.Key] = *r
+		}
+		return byKey
+	}
+	approx := func(got, want float64) bool { return math.Abs(got-want) < 0.001 }
+
+	members := charge(ChargebackByMember)
+	if len(members) != 3 || !approx(members["alice"].CostUSD, 6) || members["alice"].Executions != 1 {
+		t.Errorf("members = %+v", members)
+	}
+	if !approx(members[ChargebackUnattributed].CostUSD, 1) {
+		t.Errorf("unattributed = %+v", members[ChargebackUnattributed])
+	}
+
+	projects := charge(ChargebackByProject)
+	if !approx(projects["/api"].CostUSD, 10) || projects["/api"].Executions != 2 || projects["/api"].TokensTotal != 3000 {
+		t.Errorf("projects = %+v", projects)
+	}
+
+	// e2 is split between its labels; the ignored trigger label leaves e3
+	// unlabeled
+	labels := charge(ChargebackByLabel, "pilot")
+	if !approx(labels["team:payments"].CostUSD, 8) || labels["team:payments"].TokensTotal != 2000 {
+		t.Errorf("team:payments = %+v", labels["team:payments"])
+	}
+	if !approx(labels["team:search"].CostUSD, 2) {
+		t.Errorf("team:search = %+v", labels["team:search"])
+	}
+	if !approx(labels[ChargebackUnlabeled].CostUSD, 1) {
+		t.Errorf("unlabeled = %+v", labels[ChargebackUnlabeled])
+	}
+	var total float64
+	for _, r := range labels {
+		total += r.CostUSD
+	}
+	if !approx(total, 11) {
+		t.Errorf("label costs add up to %v, want total spend 11", total)
+	}
+
+	if _, err := store.GetChargeback(ChargebackQuery{GroupBy: "model"}); err == nil {
+		t.Error("expected error for unsupported grouping")
+	}
+}
@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecordDelivery records a webhook delivery ID from source until ttl
+// passes and reports whether it was already recorded. Redeliveries and
+// replays are then processed once, across restarts and by every instance
+// sharing the store. Expired IDs are removed as new ones are recorded.
+func (s *Store) RecordDelivery(source, id string, ttl time.Duration, now time.Time) (bool, error) {
+	var inserted int64
+	err := s.withRetry("RecordDelivery", func() error {
+		if _, err := s.db.Exec(`DELETE FROM webhook_deliveries WHERE expires_at <= ?`, now.UnixMilli()); err != nil {
+			return err
+		}
+		result, err := s.db.Exec(`
+			INSERT INTO webhook_deliveries (source, delivery_id, expires_at) VALUES (?, ?, ?)
+			ON CONFLICT(source, delivery_id) DO NOTHING
+		`, source, id, now.Add(ttl).UnixMilli())
+		if err != nil {
+			return err
+		}
+		inserted, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record %s delivery %s: %w", source, id, err)
+	}
+	return inserted == 0, nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordDelivery(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	now := time.Now()
+	record := func(source, id string, at time.Time) bool {
+		t.Helper()
+		seen, err := store.RecordDelivery(source, id, time.Hour, at)
+		if err != nil {
+			t.Fatalf("RecordDelivery(%s, %s) failed: %v", source, id, err)
+		}
+		return seen
+	}
+
+	if record("github", "d1", now) {
+		t.Error("first delivery reported as seen")
+	}
+	if !record("github", "d1", now.Add(time.Minute)) {
+		t.Error("redelivery not reported as seen")
+	}
+	if record("gitlab", "d1", now) {
+		t.Error("same ID from another source reported as seen")
+	}
+	if record("github", "d1", now.Add(2*time.Hour)) {
+		t.Error("delivery reported as seen after its TTL")
+	}
+
+	var n int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM webhook_deliveries`).Scan(&n); err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("%d deliveries stored, want 1 after expired IDs are removed", n)
+	}
+}
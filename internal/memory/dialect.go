@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Dialect is the SQL that differs between the database backends. Stores
+// write portable SQL with ? placeholders, which PostgreSQL connections
+// rebind, and take everything else from the dialect: the schema, date,
+// text and JSON functions, and reading back generated ids.
+type Dialect interface {
+	// Name returns DriverSQLite or DriverPostgres
+	Name() string
+
+	// Migrate runs the backend's statements of schema in order
+	Migrate(db *sql.DB, schema Schema) error
+
+	// InsertID runs an INSERT into a table with a generated id column
+	// named id and returns the new id
+	InsertID(db Execer, query string, args ...interface{}) (int64, error)
+
+	// Day formats a timestamp as YYYY-MM-DD
+	Day(expr string) string
+	// Hour returns the hour of a timestamp, 0-23
+	Hour(expr string) string
+	// DaysSince returns the days, with fractions, since a timestamp
+	DaysSince(expr string) string
+	// Greatest returns the larger of two values
+	Greatest(a, b string) string
+	// Least returns the smaller of two values
+	Least(a, b string) string
+	// JSONText returns a top-level key of a JSON text column as text
+	JSONText(expr, key string) string
+	// Position returns the 1-based index of needle in haystack, or 0
+	Position(haystack, needle string) string
+	// FirstLine returns text up to its first newline
+	FirstLine(expr string) string
+	// ILike matches expr against a LIKE pattern, ignoring case
+	ILike(expr, pattern string) string
+}
+
+// Schema is a store's migrations for each backend. The SQLite list keeps
+// the ALTERs that upgrade older databases; PostgreSQL support is newer, so
+// its list creates each table with all of its columns.
+type Schema struct {
+	SQLite   []string
+	Postgres []string
+}
+
+// Execer runs statements; *sql.DB and *sql.Tx satisfy it
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// DialectFor returns the dialect of db, for stores sharing the connection
+// of a Store
+func DialectFor(db *sql.DB) Dialect {
+	if _, ok := db.Driver().(*postgresDriver); ok {
+		return postgresDialect{}
+	}
+	return sqliteDialect{}
+}
+
+// Dialect returns the dialect of the store's database
+func (s *Store) Dialect() Dialect {
+	return DialectFor(s.db)
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return DriverSQLite }
+
+func (sqliteDialect) Migrate(db *sql.DB, schema Schema) error {
+	for _, migration := range schema.SQLite {
+		if _, err := db.Exec(migration); err != nil {
+			// ALTER TABLE ADD COLUMN fails on databases that have the column
+			if strings.Contains(err.Error(), "duplicate column") {
+				continue
+			}
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (sqliteDialect) InsertID(db Execer, query string, args ...interface{}) (int64, error) {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// Timestamps written by the driver carry a zone suffix that date() can't
+// parse; every stored form starts with the date
+func (sqliteDialect) Day(expr string) string {
+	return "substr(" + expr + ", 1, 10)"
+}
+
+func (sqliteDialect) Hour(expr string) string {
+	return "CAST(strftime('%H', " + expr + ") AS INTEGER)"
+}
+
+func (sqliteDialect) DaysSince(expr string) string {
+	return "(julianday('now') - julianday(" + expr + "))"
+}
+
+// Two-argument MAX and MIN are scalar functions in SQLite
+func (sqliteDialect) Greatest(a, b string) string {
+	return "MAX(" + a + ", " + b + ")"
+}
+
+func (sqliteDialect) Least(a, b string) string {
+	return "MIN(" + a + ", " + b + ")"
+}
+
+func (sqliteDialect) JSONText(expr, key string) string {
+	return "json_extract(" + expr + ", '$." + key + "')"
+}
+
+func (sqliteDialect) Position(haystack, needle string) string {
+	return "INSTR(" + haystack + ", " + needle + ")"
+}
+
+func (sqliteDialect) FirstLine(expr string) string {
+	return "SUBSTR(" + expr + ", 1, INSTR(" + expr + " || char(10), char(10)) - 1)"
+}
+
+// LIKE ignores case for ASCII in SQLite
+func (sqliteDialect) ILike(expr, pattern string) string {
+	return expr + " LIKE " + pattern
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return DriverPostgres }
+
+// Migrate runs the schema on one connection holding the migration lock,
+// so Pilot instances starting together don't race on CREATE statements
+func (postgresDialect) Migrate(db *sql.DB, schema Schema) error {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", postgresMigrationLock); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer func() { _, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock(?)", postgresMigrationLock) }()
+
+	for _, migration := range schema.Postgres {
+		if _, err := conn.ExecContext(ctx, migration); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// InsertID reads the id back with RETURNING; PostgreSQL has no
+// LastInsertId
+func (postgresDialect) InsertID(db Execer, query string, args ...interface{}) (int64, error) {
+	var id int64
+	err := db.QueryRow(query+" RETURNING id", args...).Scan(&id)
+	return id, err
+}
+
+func (postgresDialect) Day(expr string) string {
+	return "to_char(" + expr + ", 'YYYY-MM-DD')"
+}
+
+func (postgresDialect) Hour(expr string) string {
+	return "CAST(EXTRACT(HOUR FROM " + expr + ") AS INTEGER)"
+}
+
+func (postgresDialect) DaysSince(expr string) string {
+	return "(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - " + expr + ")) / 86400)"
+}
+
+func (postgresDialect) Greatest(a, b string) string {
+	return "GREATEST(" + a + ", " + b + ")"
+}
+
+func (postgresDialect) Least(a, b string) string {
+	return "LEAST(" + a + ", " + b + ")"
+}
+
+func (postgresDialect) JSONText(expr, key string) string {
+	return "(" + expr + "::jsonb ->> '" + key + "')"
+}
+
+func (postgresDialect) Position(haystack, needle string) string {
+	return "strpos(" + haystack + ", " + needle + ")"
+}
+
+func (postgresDialect) FirstLine(expr string) string {
+	return "split_part(" + expr + ", chr(10), 1)"
+}
+
+func (postgresDialect) ILike(expr, pattern string) string {
+	return expr + " ILIKE " + pattern
+}
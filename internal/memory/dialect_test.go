@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"database/sql"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDialectExpressions covers every expression shape the stores build
+func TestDialectExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     func(d Dialect) string
+		sqlite   string
+		postgres string
+	}{
+		{
+			name:     "daily metrics",
+			expr:     func(d Dialect) string { return d.Day("created_at") },
+			sqlite:   "substr(created_at, 1, 10)",
+			postgres: "to_char(created_at, 'YYYY-MM-DD')",
+		},
+		{
+			name:     "daily usage",
+			expr:     func(d Dialect) string { return d.Day("timestamp") },
+			sqlite:   "substr(timestamp, 1, 10)",
+			postgres: "to_char(timestamp, 'YYYY-MM-DD')",
+		},
+		{
+			name:     "peak hours",
+			expr:     func(d Dialect) string { return d.Hour("created_at") },
+			sqlite:   "CAST(strftime('%H', created_at) AS INTEGER)",
+			postgres: "CAST(EXTRACT(HOUR FROM created_at) AS INTEGER)",
+		},
+		{
+			name:     "failure reasons",
+			expr:     func(d Dialect) string { return d.FirstLine("error") },
+			sqlite:   "SUBSTR(error, 1, INSTR(error || char(10), char(10)) - 1)",
+			postgres: "split_part(error, chr(10), 1)",
+		},
+		{
+			name:     "token metadata",
+			expr:     func(d Dialect) string { return d.JSONText("metadata", "input_tokens") },
+			sqlite:   "json_extract(metadata, '$.input_tokens')",
+			postgres: "(metadata::jsonb ->> 'input_tokens')",
+		},
+		{
+			name:     "memory decay",
+			expr:     func(d Dialect) string { return d.Greatest("0.0", "confidence - (? * "+d.DaysSince("updated_at")+")") },
+			sqlite:   "MAX(0.0, confidence - (? * (julianday('now') - julianday(updated_at))))",
+			postgres: "GREATEST(0.0, confidence - (? * (EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - updated_at)) / 86400)))",
+		},
+		{
+			name:     "memory reinforcement",
+			expr:     func(d Dialect) string { return d.Least("1.0", "confidence + ?") },
+			sqlite:   "MIN(1.0, confidence + ?)",
+			postgres: "LEAST(1.0, confidence + ?)",
+		},
+		{
+			name:     "pattern success",
+			expr:     func(d Dialect) string { return d.Least("0.95", d.Greatest("0.1", "confidence + ?")) },
+			sqlite:   "MIN(0.95, MAX(0.1, confidence + ?))",
+			postgres: "LEAST(0.95, GREATEST(0.1, confidence + ?))",
+		},
+		{
+			name:     "pattern failure",
+			expr:     func(d Dialect) string { return d.Greatest("0.1", d.Least("0.95", "confidence - ?")) },
+			sqlite:   "MAX(0.1, MIN(0.95, confidence - ?))",
+			postgres: "GREATEST(0.1, LEAST(0.95, confidence - ?))",
+		},
+		{
+			name:     "audit log purge",
+			expr:     func(d Dialect) string { return d.Position("COALESCE(details, '')", "?") },
+			sqlite:   "INSTR(COALESCE(details, ''), ?)",
+			postgres: "strpos(COALESCE(details, ''), ?)",
+		},
+		{
+			name:     "search",
+			expr:     func(d Dialect) string { return d.ILike("title", "?") },
+			sqlite:   "title LIKE ?",
+			postgres: "title ILIKE ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expr(sqliteDialect{}); got != tt.sqlite {
+				t.Errorf("sqlite\n got %s\nwant %s", got, tt.sqlite)
+			}
+			if got := tt.expr(postgresDialect{}); got != tt.postgres {
+				t.Errorf("postgres\n got %s\nwant %s", got, tt.postgres)
+			}
+		})
+	}
+}
+
+// TestSQLiteDialectEvaluates runs the SQLite expressions on values the
+// way the stores write them
+func TestSQLiteDialectEvaluates(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	d := store.Dialect()
+	if d.Name() != DriverSQLite {
+		t.Fatalf("Dialect().Name() = %q, want %q", d.Name(), DriverSQLite)
+	}
+
+	var day, line string
+	var hour, pos int
+	var least, greatest float64
+	var tokens int64
+	err = store.db.QueryRow(`SELECT `+
+		d.Day("written")+`, `+
+		d.Hour("defaulted")+`, `+
+		d.FirstLine("error")+`, `+
+		d.Position("details", "email")+`, `+
+		d.Least("0.95", "raised")+`, `+
+		d.Greatest("0.1", "lowered")+`, `+
+		`CAST(`+d.JSONText("metadata", "input_tokens")+` AS BIGINT)
+		FROM (SELECT ? AS written, ? AS defaulted, ? AS error, ? AS details, ? AS email,
+			? AS raised, ? AS lowered, ? AS metadata)`,
+		time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+		"2026-03-04 05:06:07",
+		"build failed\nat step 2",
+		"token abc leaked", "abc",
+		1.2,
+		-0.5,
+		`{"input_tokens": 42}`,
+	).Scan(&day, &hour, &line, &pos, &least, &greatest, &tokens)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if day != "2026-03-04" || hour != 5 || line != "build failed" || pos != 7 || least != 0.95 || greatest != 0.1 || tokens != 42 {
+		t.Errorf("got day=%q hour=%d line=%q pos=%d least=%v greatest=%v tokens=%d", day, hour, line, pos, least, greatest, tokens)
+	}
+
+	var matched bool
+	if err := store.db.QueryRow(`SELECT `+d.ILike("?", "?"), "Use Context", "%context%").Scan(&matched); err != nil || !matched {
+		t.Errorf("ILike = %v, %v, want a case-insensitive match", matched, err)
+	}
+	var days float64
+	if err := store.db.QueryRow(`SELECT `+d.DaysSince("?"), time.Now().UTC().Add(-36*time.Hour).Format("2006-01-02 15:04:05")).Scan(&days); err != nil || days < 1.4 || days > 1.6 {
+		t.Errorf("DaysSince = %v, %v, want 1.5", days, err)
+	}
+}
+
+func TestDialectFor(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if got := DialectFor(store.DB()).Name(); got != DriverSQLite {
+		t.Errorf("DialectFor(sqlite) = %q, want %q", got, DriverSQLite)
+	}
+
+	// sql.Open doesn't connect
+	db, err := sql.Open(postgresDriverName, "postgres://localhost/pilot")
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if got := DialectFor(db).Name(); got != DriverPostgres {
+		t.Errorf("DialectFor(postgres) = %q, want %q", got, DriverPostgres)
+	}
+}
+
+// TestPostgresSchemaMatchesSQLite checks that the PostgreSQL schema has
+// the columns SQLite ends up with after its ALTERs
+func TestPostgresSchemaMatchesSQLite(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	postgres := postgresColumns(t, postgresSchema)
+	for table, want := range postgres {
+		rows, err := store.db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+		if err != nil {
+			t.Fatalf("table_info(%s) failed: %v", table, err)
+		}
+		var got []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				t.Fatalf("scan failed: %v", err)
+			}
+			got = append(got, name)
+		}
+		_ = rows.Close()
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s columns\n sqlite   %v\n postgres %v", table, got, want)
+		}
+	}
+
+	var tables []string
+	rows, err := store.db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		t.Fatalf("listing tables failed: %v", err)
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	_ = rows.Close()
+	for _, table := range tables {
+		if _, ok := postgres[table]; !ok {
+			t.Errorf("table %s has no PostgreSQL schema", table)
+		}
+	}
+}
+
+var createTableRe = regexp.MustCompile(`(?s)^CREATE TABLE IF NOT EXISTS (\w+) \((.*)\)$`)
+
+// postgresColumns returns the sorted column names of each table in schema
+func postgresColumns(t *testing.T, schema []string) map[string][]string {
+	t.Helper()
+	tables := make(map[string][]string)
+	for _, stmt := range schema {
+		m := createTableRe.FindStringSubmatch(stmt)
+		if m == nil {
+			continue
+		}
+		var columns []string
+		for _, line := range strings.Split(m[2], "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			switch {
+			case fields[0] == "PRIMARY", fields[0] == "CHECK", strings.HasPrefix(fields[0], "UNIQUE"):
+				continue
+			}
+			columns = append(columns, fields[0])
+		}
+		sort.Strings(columns)
+		tables[m[1]] = columns
+	}
+	if len(tables) == 0 {
+		t.Fatal("no CREATE TABLE statements in schema")
+	}
+	return tables
+}
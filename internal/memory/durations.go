@@ -0,0 +1,38 @@
+package memory
+
+import "time"
+
+// RecordTaskDuration stores the wall-clock duration of a successful execution
+// for the given project and complexity class.
+func (s *Store) RecordTaskDuration(projectPath, complexity string, duration time.Duration) error {
+	return s.withRetry("RecordTaskDuration", func() error {
+		_, err := s.db.Exec(
+			`INSERT INTO task_durations (project_path, complexity, duration_ms) VALUES (?, ?, ?)`,
+			projectPath, complexity, duration.Milliseconds(),
+		)
+		return err
+	})
+}
+
+// GetTaskDurations returns up to limit most recent durations recorded for the
+// given project and complexity class, newest first.
+func (s *Store) GetTaskDurations(projectPath, complexity string, limit int) ([]time.Duration, error) {
+	rows, err := s.db.Query(
+		`SELECT duration_ms FROM task_durations WHERE project_path = ? AND complexity = ? ORDER BY id DESC LIMIT ?`,
+		projectPath, complexity, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var durations []time.Duration
+	for rows.Next() {
+		var ms int64
+		if err := rows.Scan(&ms); err != nil {
+			return nil, err
+		}
+		durations = append(durations, time.Duration(ms)*time.Millisecond)
+	}
+	return durations, rows.Err()
+}
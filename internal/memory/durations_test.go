@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskDurations_RecordAndGet(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, d := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		if err := store.RecordTaskDuration("/repo", "medium", d); err != nil {
+			t.Fatalf("RecordTaskDuration failed: %v", err)
+		}
+	}
+	if err := store.RecordTaskDuration("/repo", "complex", time.Hour); err != nil {
+		t.Fatalf("RecordTaskDuration failed: %v", err)
+	}
+	if err := store.RecordTaskDuration("/other", "medium", time.Hour); err != nil {
+		t.Fatalf("RecordTaskDuration failed: %v", err)
+	}
+
+	got, err := store.GetTaskDurations("/repo", "medium", 2)
+	if err != nil {
+		t.Fatalf("GetTaskDurations failed: %v", err)
+	}
+	want := []time.Duration{3 * time.Minute, 2 * time.Minute}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	none, err := store.GetTaskDurations("/repo", "trivial", 10)
+	if err != nil {
+		t.Fatalf("GetTaskDurations failed: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("expected no durations, got %v", none)
+	}
+}
@@ -0,0 +1,366 @@
+package memory
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Epic statuses, aggregated from the statuses of its sub-issues
+const (
+	EpicStatusPending   = "pending"
+	EpicStatusRunning   = "running"
+	EpicStatusFailed    = "failed"
+	EpicStatusCompleted = "completed"
+)
+
+// Sub-issue statuses
+const (
+	SubIssueStatusPending = "pending"
+	SubIssueStatusRunning = "running"
+	SubIssueStatusDone    = "done"
+	SubIssueStatusFailed  = "failed"
+)
+
+// Epic timeline event kinds
+const (
+	EpicEventPlanned         = "planned"
+	EpicEventResumed         = "resumed"
+	EpicEventSubIssueStarted = "sub_issue_started"
+	EpicEventSubIssueDone    = "sub_issue_done"
+	EpicEventSubIssueFailed  = "sub_issue_failed"
+	EpicEventCompleted       = "completed"
+)
+
+// subIssueEvents maps a sub-issue status to the timeline event it records
+var subIssueEvents = map[string]string{
+	SubIssueStatusRunning: EpicEventSubIssueStarted,
+	SubIssueStatusDone:    EpicEventSubIssueDone,
+	SubIssueStatusFailed:  EpicEventSubIssueFailed,
+}
+
+// Epic is a task Pilot decomposed into sub-issues, identified by project and
+// parent task ID. Status and CostUSD are aggregated from the sub-issues.
+type Epic struct {
+	ProjectPath string
+	ParentID    string // Parent task ID, e.g. "GH-405"
+	SourceRepo  string // "owner/repo" for GitHub epics
+	Title       string
+	Status      string
+	CostUSD     float64
+	SubIssues   []*EpicSubIssue
+	Timeline    []*EpicEvent
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// EpicSubIssue is one sub-issue of an epic, in execution order.
+type EpicSubIssue struct {
+	Position    int // 1-indexed execution order
+	Number      int // GitHub issue number, 0 for other trackers
+	Identifier  string
+	URL         string
+	Title       string
+	Description string
+	DependsOn   []int
+	Status      string
+	PRURL       string
+	Error       string
+	CostUSD     float64
+	UpdatedAt   time.Time
+}
+
+// EpicEvent is one entry in an epic's timeline.
+type EpicEvent struct {
+	Kind      string
+	Position  int // Sub-issue position, 0 for epic-level events
+	Message   string
+	CreatedAt time.Time
+}
+
+// Completed returns the number of sub-issues that are done.
+func (e *Epic) Completed() int {
+	n := 0
+	for _, sub := range e.SubIssues {
+		if sub.Status == SubIssueStatusDone {
+			n++
+		}
+	}
+	return n
+}
+
+// aggregate derives the epic's status and cost from its sub-issues.
+func (e *Epic) aggregate() {
+	var running, failed int
+	e.CostUSD = 0
+	for _, sub := range e.SubIssues {
+		e.CostUSD += sub.CostUSD
+		switch sub.Status {
+		case SubIssueStatusRunning:
+			running++
+		case SubIssueStatusFailed:
+			failed++
+		}
+	}
+	done := e.Completed()
+	switch {
+	case len(e.SubIssues) > 0 && done == len(e.SubIssues):
+		e.Status = EpicStatusCompleted
+	case failed > 0:
+		e.Status = EpicStatusFailed
+	case running > 0 || done > 0:
+		e.Status = EpicStatusRunning
+	default:
+		e.Status = EpicStatusPending
+	}
+}
+
+// SaveEpic stores a newly planned epic, replacing any earlier epic for the
+// same project and parent task along with its timeline.
+func (s *Store) SaveEpic(epic *Epic) error {
+	return s.withRetry("SaveEpic", func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if _, err := tx.Exec(`
+			INSERT INTO epics (project_path, parent_id, source_repo, title)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT(project_path, parent_id) DO UPDATE SET
+				source_repo = excluded.source_repo,
+				title = excluded.title,
+				created_at = CURRENT_TIMESTAMP,
+				updated_at = CURRENT_TIMESTAMP
+		`, epic.ProjectPath, epic.ParentID, epic.SourceRepo, epic.Title); err != nil {
+			return err
+		}
+		for _, table := range []string{"epic_sub_issues", "epic_events"} {
+			if _, err := tx.Exec(`DELETE FROM `+table+` WHERE project_path = ? AND parent_id = ?`,
+				epic.ProjectPath, epic.ParentID); err != nil {
+				return err
+			}
+		}
+		for _, sub := range epic.SubIssues {
+			dependsOn, err := json.Marshal(sub.DependsOn)
+			if err != nil {
+				return fmt.Errorf("marshal depends_on: %w", err)
+			}
+			status := sub.Status
+			if status == "" {
+				status = SubIssueStatusPending
+			}
+			if _, err := tx.Exec(`
+				INSERT INTO epic_sub_issues (project_path, parent_id, position, number, identifier, url,
+					title, description, depends_on, status, pr_url, error, cost_usd)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, epic.ProjectPath, epic.ParentID, sub.Position, sub.Number, sub.Identifier, sub.URL,
+				sub.Title, sub.Description, string(dependsOn), status, sub.PRURL, sub.Error, sub.CostUSD); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO epic_events (project_path, parent_id, kind, message) VALUES (?, ?, ?, ?)
+		`, epic.ProjectPath, epic.ParentID, EpicEventPlanned, fmt.Sprintf("%d sub-issues", len(epic.SubIssues))); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// UpdateEpicSubIssue records the progress of one sub-issue, identified by its
+// position, and adds the matching event to the epic's timeline.
+func (s *Store) UpdateEpicSubIssue(projectPath, parentID string, sub *EpicSubIssue) error {
+	return s.withRetry("UpdateEpicSubIssue", func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		result, err := tx.Exec(`
+			UPDATE epic_sub_issues SET status = ?, pr_url = ?, error = ?, cost_usd = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE project_path = ? AND parent_id = ? AND position = ?
+		`, sub.Status, sub.PRURL, sub.Error, sub.CostUSD, projectPath, parentID, sub.Position)
+		if err != nil {
+			return err
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return fmt.Errorf("epic %s has no sub-issue at position %d", parentID, sub.Position)
+		}
+		if _, err := tx.Exec(
+			`UPDATE epics SET updated_at = CURRENT_TIMESTAMP WHERE project_path = ? AND parent_id = ?`,
+			projectPath, parentID,
+		); err != nil {
+			return err
+		}
+		if kind, ok := subIssueEvents[sub.Status]; ok {
+			message := sub.Error
+			if message == "" {
+				message = sub.PRURL
+			}
+			if _, err := tx.Exec(`
+				INSERT INTO epic_events (project_path, parent_id, kind, position, message) VALUES (?, ?, ?, ?, ?)
+			`, projectPath, parentID, kind, sub.Position, message); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// AddEpicEvent appends an epic-level event to the epic's timeline.
+func (s *Store) AddEpicEvent(projectPath, parentID, kind, message string) error {
+	return s.withRetry("AddEpicEvent", func() error {
+		_, err := s.db.Exec(`
+			INSERT INTO epic_events (project_path, parent_id, kind, message) VALUES (?, ?, ?, ?)
+		`, projectPath, parentID, kind, message)
+		return err
+	})
+}
+
+// GetEpic returns the epic for a project and parent task, or nil when none is
+// stored.
+func (s *Store) GetEpic(projectPath, parentID string) (*Epic, error) {
+	epic := &Epic{ProjectPath: projectPath, ParentID: parentID}
+	var sourceRepo, title sql.NullString
+	err := s.db.QueryRow(`
+		SELECT source_repo, title, created_at, updated_at FROM epics
+		WHERE project_path = ? AND parent_id = ?
+	`, projectPath, parentID).Scan(&sourceRepo, &title, &epic.CreatedAt, &epic.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epic: %w", err)
+	}
+	epic.SourceRepo, epic.Title = sourceRepo.String, title.String
+
+	if epic.SubIssues, err = s.getEpicSubIssues(projectPath, parentID); err != nil {
+		return nil, err
+	}
+	if epic.Timeline, err = s.getEpicEvents(projectPath, parentID); err != nil {
+		return nil, err
+	}
+	epic.aggregate()
+	return epic, nil
+}
+
+// GetEpics returns the epics for a parent task across all projects, most
+// recently updated first.
+func (s *Store) GetEpics(parentID string) ([]*Epic, error) {
+	return s.queryEpics(`SELECT project_path, parent_id FROM epics WHERE parent_id = ? ORDER BY updated_at DESC`, parentID)
+}
+
+// ListEpics returns up to limit epics, most recently updated first.
+func (s *Store) ListEpics(limit int) ([]*Epic, error) {
+	return s.queryEpics(`SELECT project_path, parent_id FROM epics ORDER BY updated_at DESC LIMIT ?`, limit)
+}
+
+// FindEpicBySubIssue returns the epic a GitHub issue in repo ("owner/repo")
+// belongs to as a sub-issue, or nil when it is not part of an epic.
+func (s *Store) FindEpicBySubIssue(repo string, number int) (*Epic, error) {
+	epics, err := s.queryEpics(`
+		SELECT e.project_path, e.parent_id FROM epics e
+		JOIN epic_sub_issues sub ON sub.project_path = e.project_path AND sub.parent_id = e.parent_id
+		WHERE e.source_repo = ? AND sub.number = ?
+		ORDER BY e.updated_at DESC
+		LIMIT 1
+	`, repo, number)
+	if err != nil || len(epics) == 0 {
+		return nil, err
+	}
+	return epics[0], nil
+}
+
+// queryEpics loads the epics whose keys the query selects.
+func (s *Store) queryEpics(query string, args ...any) ([]*Epic, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epics: %w", err)
+	}
+	type epicKey struct{ projectPath, parentID string }
+	var keys []epicKey
+	for rows.Next() {
+		var key epicKey
+		if err := rows.Scan(&key.projectPath, &key.parentID); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	epics := make([]*Epic, 0, len(keys))
+	for _, key := range keys {
+		epic, err := s.GetEpic(key.projectPath, key.parentID)
+		if err != nil {
+			return nil, err
+		}
+		if epic != nil {
+			epics = append(epics, epic)
+		}
+	}
+	return epics, nil
+}
+
+func (s *Store) getEpicSubIssues(projectPath, parentID string) ([]*EpicSubIssue, error) {
+	rows, err := s.db.Query(`
+		SELECT position, number, identifier, url, title, description, depends_on, status, pr_url, error, cost_usd, updated_at
+		FROM epic_sub_issues
+		WHERE project_path = ? AND parent_id = ?
+		ORDER BY position
+	`, projectPath, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epic sub-issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var subs []*EpicSubIssue
+	for rows.Next() {
+		sub := &EpicSubIssue{}
+		var identifier, url, title, description, dependsOn, prURL, errMsg sql.NullString
+		var cost sql.NullFloat64
+		if err := rows.Scan(&sub.Position, &sub.Number, &identifier, &url, &title, &description,
+			&dependsOn, &sub.Status, &prURL, &errMsg, &cost, &sub.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan epic sub-issue: %w", err)
+		}
+		sub.Identifier, sub.URL, sub.Title, sub.Description = identifier.String, url.String, title.String, description.String
+		sub.PRURL, sub.Error, sub.CostUSD = prURL.String, errMsg.String, cost.Float64
+		if dependsOn.String != "" {
+			_ = json.Unmarshal([]byte(dependsOn.String), &sub.DependsOn)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *Store) getEpicEvents(projectPath, parentID string) ([]*EpicEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT kind, position, message, created_at FROM epic_events
+		WHERE project_path = ? AND parent_id = ?
+		ORDER BY id
+	`, projectPath, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epic events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*EpicEvent
+	for rows.Next() {
+		event := &EpicEvent{}
+		var message sql.NullString
+		if err := rows.Scan(&event.Kind, &event.Position, &message, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan epic event: %w", err)
+		}
+		event.Message = message.String
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
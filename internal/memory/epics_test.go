@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEpics_SaveAndUpdate(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if epic, err := store.GetEpic("/repo", "GH-1"); err != nil || epic != nil {
+		t.Fatalf("GetEpic() on empty store = %v, %v", epic, err)
+	}
+
+	epic := &Epic{
+		ProjectPath: "/repo",
+		ParentID:    "GH-1",
+		SourceRepo:  "acme/api",
+		Title:       "Auth overhaul",
+		SubIssues: []*EpicSubIssue{
+			{Position: 1, Number: 2, Identifier: "2", Title: "Schema", Description: "Add tables"},
+			{Position: 2, Number: 3, Identifier: "3", Title: "API", DependsOn: []int{1}},
+		},
+	}
+	if err := store.SaveEpic(epic); err != nil {
+		t.Fatalf("SaveEpic failed: %v", err)
+	}
+	got, _ := store.GetEpic("/repo", "GH-1")
+	if got.Status != EpicStatusPending {
+		t.Errorf("Status = %s, want pending before any sub-issue starts", got.Status)
+	}
+
+	updates := []*EpicSubIssue{
+		{Position: 1, Status: SubIssueStatusRunning},
+		{Position: 1, Status: SubIssueStatusDone, PRURL: "https://github.com/acme/api/pull/9", CostUSD: 0.75},
+		{Position: 2, Status: SubIssueStatusFailed, Error: "tests failed", CostUSD: 0.5},
+	}
+	for _, sub := range updates {
+		if err := store.UpdateEpicSubIssue("/repo", "GH-1", sub); err != nil {
+			t.Fatalf("UpdateEpicSubIssue failed: %v", err)
+		}
+	}
+	if err := store.UpdateEpicSubIssue("/repo", "GH-1", &EpicSubIssue{Position: 9, Status: SubIssueStatusDone}); err == nil {
+		t.Error("UpdateEpicSubIssue() with unknown position should fail")
+	}
+
+	got, err = store.GetEpic("/repo", "GH-1")
+	if err != nil || got == nil {
+		t.Fatalf("GetEpic() = %v, %v", got, err)
+	}
+	if got.Title != "Auth overhaul" || got.SourceRepo != "acme/api" || got.Status != EpicStatusFailed || len(got.SubIssues) != 2 {
+		t.Fatalf("epic = %+v", got)
+	}
+	if got.Completed() != 1 || got.CostUSD != 1.25 {
+		t.Errorf("Completed() = %d, CostUSD = %v; want 1, 1.25", got.Completed(), got.CostUSD)
+	}
+	first, second := got.SubIssues[0], got.SubIssues[1]
+	if first.Status != SubIssueStatusDone || first.PRURL != "https://github.com/acme/api/pull/9" || first.Description != "Add tables" {
+		t.Errorf("first sub-issue = %+v", first)
+	}
+	if second.Status != SubIssueStatusFailed || second.Error != "tests failed" || !reflect.DeepEqual(second.DependsOn, []int{1}) {
+		t.Errorf("second sub-issue = %+v", second)
+	}
+
+	var kinds []string
+	for _, event := range got.Timeline {
+		kinds = append(kinds, event.Kind)
+	}
+	wantKinds := []string{EpicEventPlanned, EpicEventSubIssueStarted, EpicEventSubIssueDone, EpicEventSubIssueFailed}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Errorf("timeline = %v, want %v", kinds, wantKinds)
+	}
+	if got.Timeline[3].Position != 2 || got.Timeline[3].Message != "tests failed" {
+		t.Errorf("failed event = %+v", got.Timeline[3])
+	}
+
+	// Finishing the last sub-issue completes the epic
+	if err := store.UpdateEpicSubIssue("/repo", "GH-1", &EpicSubIssue{Position: 2, Status: SubIssueStatusDone}); err != nil {
+		t.Fatalf("UpdateEpicSubIssue failed: %v", err)
+	}
+	if err := store.AddEpicEvent("/repo", "GH-1", EpicEventCompleted, ""); err != nil {
+		t.Fatalf("AddEpicEvent failed: %v", err)
+	}
+	got, _ = store.GetEpic("/repo", "GH-1")
+	if got.Status != EpicStatusCompleted || got.Timeline[len(got.Timeline)-1].Kind != EpicEventCompleted {
+		t.Errorf("epic = %+v, want completed", got)
+	}
+
+	// Saving a new plan for the same epic replaces the old one and its timeline
+	epic.SubIssues = epic.SubIssues[:1]
+	if err := store.SaveEpic(epic); err != nil {
+		t.Fatalf("SaveEpic failed: %v", err)
+	}
+	got, _ = store.GetEpic("/repo", "GH-1")
+	if got.Status != EpicStatusPending || len(got.SubIssues) != 1 || len(got.Timeline) != 1 {
+		t.Errorf("replaced epic = %+v", got)
+	}
+}
+
+func TestEpics_Lookup(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, epic := range []*Epic{
+		{ProjectPath: "/api", ParentID: "GH-1", SourceRepo: "acme/api", SubIssues: []*EpicSubIssue{{Position: 1, Number: 2}}},
+		{ProjectPath: "/web", ParentID: "GH-1", SourceRepo: "acme/web", SubIssues: []*EpicSubIssue{{Position: 1, Number: 5}}},
+		{ProjectPath: "/web", ParentID: "APP-7", SubIssues: []*EpicSubIssue{{Position: 1, Identifier: "APP-8"}}},
+	} {
+		if err := store.SaveEpic(epic); err != nil {
+			t.Fatalf("SaveEpic failed: %v", err)
+		}
+	}
+
+	if epics, err := store.GetEpics("GH-1"); err != nil || len(epics) != 2 {
+		t.Errorf("GetEpics() = %d epics, %v; want 2", len(epics), err)
+	}
+	if epics, err := store.ListEpics(2); err != nil || len(epics) != 2 {
+		t.Errorf("ListEpics(2) = %d epics, %v; want 2", len(epics), err)
+	}
+
+	epic, err := store.FindEpicBySubIssue("acme/web", 5)
+	if err != nil || epic == nil || epic.ProjectPath != "/web" {
+		t.Errorf("FindEpicBySubIssue(acme/web#5) = %+v, %v", epic, err)
+	}
+	if epic, err := store.FindEpicBySubIssue("acme/api", 5); err != nil || epic != nil {
+		t.Errorf("FindEpicBySubIssue(acme/api#5) = %+v, %v; want none", epic, err)
+	}
+}
+
+func TestEpics_MigratesEpicRuns(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	// Recreate the schema stores had before the epics table replaced epic_runs
+	for _, stmt := range []string{
+		`DROP TABLE epic_sub_issues`,
+		`CREATE TABLE epic_runs (
+			project_path TEXT NOT NULL,
+			parent_id TEXT NOT NULL,
+			title TEXT,
+			status TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (project_path, parent_id)
+		)`,
+		`CREATE TABLE epic_sub_issues (
+			project_path TEXT NOT NULL,
+			parent_id TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			number INTEGER DEFAULT 0,
+			identifier TEXT,
+			url TEXT,
+			title TEXT,
+			description TEXT,
+			depends_on TEXT,
+			status TEXT NOT NULL,
+			pr_url TEXT,
+			error TEXT,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (project_path, parent_id, position)
+		)`,
+		`INSERT INTO epic_runs (project_path, parent_id, title, status) VALUES ('/repo', 'GH-1', 'Auth overhaul', 'running')`,
+		`INSERT INTO epic_sub_issues (project_path, parent_id, position, number, title, status) VALUES ('/repo', 'GH-1', 1, 2, 'Schema', 'done')`,
+	} {
+		if _, err := store.db.Exec(stmt); err != nil {
+			t.Fatalf("setup %q: %v", stmt, err)
+		}
+	}
+	_ = store.Close()
+
+	store, err = NewStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	epic, err := store.GetEpic("/repo", "GH-1")
+	if err != nil || epic == nil {
+		t.Fatalf("GetEpic() = %v, %v", epic, err)
+	}
+	if epic.Title != "Auth overhaul" || len(epic.SubIssues) != 1 || epic.SubIssues[0].Number != 2 {
+		t.Errorf("migrated epic = %+v", epic)
+	}
+	var n int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'epic_runs'`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("epic_runs still present (count %d, err %v)", n, err)
+	}
+}
@@ -0,0 +1,249 @@
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskEstimate is the effort predicted for a task before execution, stored
+// with the actuals it turned out to have.
+type TaskEstimate struct {
+	TaskID      string
+	ProjectPath string
+	Complexity  string // Effort class from complexity detection
+	Effort      string // Routed reasoning effort, if any
+	Model       string
+
+	// Samples is the number of past runs the prediction is based on.
+	// Zero means there was no history and nothing was predicted.
+	Samples           int
+	PredictedDuration time.Duration
+	PredictedTokens   int64
+
+	ActualDuration time.Duration
+	ActualTokens   int64
+	Success        bool
+	CreatedAt      time.Time
+}
+
+// Estimated reports whether the task had a prediction to compare against
+func (e *TaskEstimate) Estimated() bool {
+	return e.Samples > 0 && e.PredictedDuration > 0
+}
+
+// RecordTaskEstimate stores a task's estimate together with its actuals
+func (s *Store) RecordTaskEstimate(est *TaskEstimate) error {
+	createdAt := est.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return s.withRetry("RecordTaskEstimate", func() error {
+		_, err := s.db.Exec(`
+			INSERT INTO task_estimates (task_id, project_path, complexity, effort, model, samples,
+				predicted_duration_ms, predicted_tokens, actual_duration_ms, actual_tokens, success, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, est.TaskID, est.ProjectPath, est.Complexity, est.Effort, est.Model, est.Samples,
+			est.PredictedDuration.Milliseconds(), est.PredictedTokens,
+			est.ActualDuration.Milliseconds(), est.ActualTokens, est.Success, createdAt.UTC())
+		return err
+	})
+}
+
+// PredictTaskEffort predicts duration and tokens for a task of the given
+// project and complexity class as the medians of up to window most recent
+// successful runs. Returns zero samples when there is no history.
+func (s *Store) PredictTaskEffort(projectPath, complexity string, window int) (time.Duration, int64, int, error) {
+	rows, err := s.db.Query(`
+		SELECT actual_duration_ms, actual_tokens FROM task_estimates
+		WHERE project_path = ? AND complexity = ? AND success = 1
+		ORDER BY id DESC LIMIT ?
+	`, projectPath, complexity, window)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to query task estimates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var durations, tokens []int64
+	for rows.Next() {
+		var ms, tok int64
+		if err := rows.Scan(&ms, &tok); err != nil {
+			return 0, 0, 0, err
+		}
+		durations = append(durations, ms)
+		tokens = append(tokens, tok)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+	if len(durations) == 0 {
+		return 0, 0, 0, nil
+	}
+	return time.Duration(median(durations)) * time.Millisecond, median(tokens), len(durations), nil
+}
+
+// median returns the median of values, averaging the middle pair
+func median(values []int64) int64 {
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// GetTaskEstimates returns estimates recorded in the query period, oldest first
+func (s *Store) GetTaskEstimates(query MetricsQuery) ([]*TaskEstimate, error) {
+	where := "WHERE created_at >= ? AND created_at < ?"
+	args := []interface{}{query.Start.UTC(), query.End.UTC()}
+	if len(query.Projects) > 0 {
+		where += " AND project_path IN (?" + strings.Repeat(",?", len(query.Projects)-1) + ")"
+		for _, p := range query.Projects {
+			args = append(args, p)
+		}
+	}
+
+	rows, err := s.db.Query(`
+		SELECT task_id, project_path, complexity, COALESCE(effort, ''), COALESCE(model, ''), samples,
+			predicted_duration_ms, predicted_tokens, actual_duration_ms, actual_tokens, success, created_at
+		FROM task_estimates
+		`+where+`
+		ORDER BY created_at, id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task estimates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var estimates []*TaskEstimate
+	for rows.Next() {
+		var est TaskEstimate
+		var predictedMs, actualMs int64
+		if err := rows.Scan(&est.TaskID, &est.ProjectPath, &est.Complexity, &est.Effort, &est.Model, &est.Samples,
+			&predictedMs, &est.PredictedTokens, &actualMs, &est.ActualTokens, &est.Success, &est.CreatedAt); err != nil {
+			return nil, err
+		}
+		est.PredictedDuration = time.Duration(predictedMs) * time.Millisecond
+		est.ActualDuration = time.Duration(actualMs) * time.Millisecond
+		estimates = append(estimates, &est)
+	}
+	return estimates, rows.Err()
+}
+
+// CalibrationStats measures estimate accuracy for a group of tasks.
+// Errors are relative to the estimate: a bias of +0.2 means tasks took 20%
+// longer than predicted.
+type CalibrationStats struct {
+	Key         string
+	Tasks       int     // All tasks in the group
+	Estimated   int     // Tasks that had a prediction
+	SuccessRate float64 // Over all tasks
+
+	DurationMAPE   float64 // Mean absolute percentage error of duration
+	DurationBias   float64 // Mean signed error of duration
+	DurationWithin float64 // Share of durations within ±CalibrationTolerance
+	TokensMAPE     float64
+	TokensBias     float64
+}
+
+// CalibrationTolerance is the relative error an estimate counts as accurate within
+const CalibrationTolerance = 0.25
+
+// CalibrationReport breaks estimate accuracy down by project, complexity
+// class, routed effort and week
+type CalibrationReport struct {
+	Overall      CalibrationStats
+	ByProject    []CalibrationStats
+	ByComplexity []CalibrationStats
+	ByEffort     []CalibrationStats
+	ByWeek       []CalibrationStats // Key is the Monday of the week, oldest first
+}
+
+// GetCalibrationReport computes estimate accuracy for tasks in the query period
+func (s *Store) GetCalibrationReport(query MetricsQuery) (*CalibrationReport, error) {
+	estimates, err := s.GetTaskEstimates(query)
+	if err != nil {
+		return nil, err
+	}
+	return BuildCalibrationReport(estimates), nil
+}
+
+// BuildCalibrationReport aggregates estimates into a calibration report
+func BuildCalibrationReport(estimates []*TaskEstimate) *CalibrationReport {
+	report := &CalibrationReport{Overall: calibrationStats("all", estimates)}
+	report.ByProject = groupCalibration(estimates, func(e *TaskEstimate) string { return e.ProjectPath })
+	report.ByComplexity = groupCalibration(estimates, func(e *TaskEstimate) string { return e.Complexity })
+	report.ByEffort = groupCalibration(estimates, func(e *TaskEstimate) string {
+		if e.Effort == "" {
+			return "default"
+		}
+		return e.Effort
+	})
+	report.ByWeek = groupCalibration(estimates, func(e *TaskEstimate) string {
+		day := e.CreatedAt.UTC().Truncate(24 * time.Hour)
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset).Format("2006-01-02")
+	})
+	return report
+}
+
+// groupCalibration computes stats per key, sorted by key
+func groupCalibration(estimates []*TaskEstimate, key func(*TaskEstimate) string) []CalibrationStats {
+	groups := make(map[string][]*TaskEstimate)
+	for _, est := range estimates {
+		k := key(est)
+		groups[k] = append(groups[k], est)
+	}
+	stats := make([]CalibrationStats, 0, len(groups))
+	for k, group := range groups {
+		stats = append(stats, calibrationStats(k, group))
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Key < stats[j].Key })
+	return stats
+}
+
+func calibrationStats(key string, estimates []*TaskEstimate) CalibrationStats {
+	stats := CalibrationStats{Key: key, Tasks: len(estimates)}
+	if len(estimates) == 0 {
+		return stats
+	}
+
+	var succeeded, within, tokenSamples int
+	for _, est := range estimates {
+		if est.Success {
+			succeeded++
+		}
+		if !est.Estimated() {
+			continue
+		}
+		stats.Estimated++
+		errDuration := (est.ActualDuration.Seconds() - est.PredictedDuration.Seconds()) / est.PredictedDuration.Seconds()
+		stats.DurationBias += errDuration
+		stats.DurationMAPE += math.Abs(errDuration)
+		if math.Abs(errDuration) <= CalibrationTolerance {
+			within++
+		}
+		if est.PredictedTokens > 0 {
+			errTokens := float64(est.ActualTokens-est.PredictedTokens) / float64(est.PredictedTokens)
+			stats.TokensBias += errTokens
+			stats.TokensMAPE += math.Abs(errTokens)
+			tokenSamples++
+		}
+	}
+
+	stats.SuccessRate = float64(succeeded) / float64(len(estimates))
+	if stats.Estimated > 0 {
+		stats.DurationBias /= float64(stats.Estimated)
+		stats.DurationMAPE /= float64(stats.Estimated)
+		stats.DurationWithin = float64(within) / float64(stats.Estimated)
+	}
+	if tokenSamples > 0 {
+		stats.TokensBias /= float64(tokenSamples)
+		stats.TokensMAPE /= float64(tokenSamples)
+	}
+	return stats
+}
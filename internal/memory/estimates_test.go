@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTaskEstimates_PredictAndRecord(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	_, _, samples, err := store.PredictTaskEffort("/repo", "medium", 10)
+	if err != nil {
+		t.Fatalf("PredictTaskEffort failed: %v", err)
+	}
+	if samples != 0 {
+		t.Errorf("samples = %d, want 0 without history", samples)
+	}
+
+	runs := []struct {
+		duration time.Duration
+		tokens   int64
+		success  bool
+	}{
+		{time.Minute, 1000, true},
+		{3 * time.Minute, 3000, true},
+		{2 * time.Minute, 2000, true},
+		{time.Hour, 90000, false}, // failed runs don't feed predictions
+	}
+	for i, run := range runs {
+		if err := store.RecordTaskEstimate(&TaskEstimate{
+			TaskID:         "GH-" + string(rune('1'+i)),
+			ProjectPath:    "/repo",
+			Complexity:     "medium",
+			ActualDuration: run.duration,
+			ActualTokens:   run.tokens,
+			Success:        run.success,
+		}); err != nil {
+			t.Fatalf("RecordTaskEstimate failed: %v", err)
+		}
+	}
+
+	duration, tokens, samples, err := store.PredictTaskEffort("/repo", "medium", 10)
+	if err != nil {
+		t.Fatalf("PredictTaskEffort failed: %v", err)
+	}
+	if duration != 2*time.Minute || tokens != 2000 || samples != 3 {
+		t.Errorf("prediction = %v / %d / %d samples, want 2m / 2000 / 3", duration, tokens, samples)
+	}
+
+	// The window keeps only the most recent runs
+	duration, _, samples, _ = store.PredictTaskEffort("/repo", "medium", 2)
+	if duration != 150*time.Second || samples != 2 {
+		t.Errorf("windowed prediction = %v / %d samples, want 2m30s / 2", duration, samples)
+	}
+
+	all, err := store.GetTaskEstimates(MetricsQuery{Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("GetTaskEstimates failed: %v", err)
+	}
+	if len(all) != 4 || all[0].TaskID != "GH-1" || all[3].Success {
+		t.Errorf("GetTaskEstimates = %+v", all)
+	}
+	other, _ := store.GetTaskEstimates(MetricsQuery{Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour), Projects: []string{"/other"}})
+	if len(other) != 0 {
+		t.Errorf("project filter returned %d estimates", len(other))
+	}
+}
+
+func TestBuildCalibrationReport(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	estimates := []*TaskEstimate{
+		// Took 50% longer than predicted
+		{ProjectPath: "/a", Complexity: "medium", Effort: "high", Samples: 5, PredictedDuration: 10 * time.Minute,
+			PredictedTokens: 1000, ActualDuration: 15 * time.Minute, ActualTokens: 1500, Success: true, CreatedAt: monday},
+		// Spot on
+		{ProjectPath: "/a", Complexity: "simple", Samples: 5, PredictedDuration: 4 * time.Minute,
+			PredictedTokens: 1000, ActualDuration: 4 * time.Minute, ActualTokens: 1000, Success: true, CreatedAt: monday.AddDate(0, 0, 3)},
+		// Half the predicted time, failed
+		{ProjectPath: "/b", Complexity: "medium", Effort: "high", Samples: 2, PredictedDuration: 10 * time.Minute,
+			ActualDuration: 5 * time.Minute, CreatedAt: monday.AddDate(0, 0, 8)},
+		// No history, not estimated
+		{ProjectPath: "/b", Complexity: "complex", ActualDuration: time.Hour, Success: true, CreatedAt: monday.AddDate(0, 0, 9)},
+	}
+
+	report := BuildCalibrationReport(estimates)
+	overall := report.Overall
+	if overall.Tasks != 4 || overall.Estimated != 3 {
+		t.Errorf("overall tasks = %d estimated = %d, want 4 / 3", overall.Tasks, overall.Estimated)
+	}
+	approx := func(got, want float64) bool { return math.Abs(got-want) < 1e-9 }
+	if !approx(overall.DurationMAPE, (0.5+0+0.5)/3) || !approx(overall.DurationBias, 0) {
+		t.Errorf("duration MAPE = %v bias = %v", overall.DurationMAPE, overall.DurationBias)
+	}
+	if !approx(overall.DurationWithin, 1.0/3) || !approx(overall.SuccessRate, 0.75) {
+		t.Errorf("within = %v success = %v", overall.DurationWithin, overall.SuccessRate)
+	}
+	// Only estimates with predicted tokens count towards token accuracy
+	if !approx(overall.TokensMAPE, 0.25) || !approx(overall.TokensBias, 0.25) {
+		t.Errorf("tokens MAPE = %v bias = %v", overall.TokensMAPE, overall.TokensBias)
+	}
+
+	if len(report.ByProject) != 2 || report.ByProject[0].Key != "/a" || !approx(report.ByProject[0].DurationBias, 0.25) {
+		t.Errorf("ByProject = %+v", report.ByProject)
+	}
+	if len(report.ByComplexity) != 3 || report.ByComplexity[1].Key != "medium" || report.ByComplexity[1].Estimated != 2 {
+		t.Errorf("ByComplexity = %+v", report.ByComplexity)
+	}
+	if len(report.ByEffort) != 2 || report.ByEffort[0].Key != "default" || report.ByEffort[1].Key != "high" {
+		t.Errorf("ByEffort = %+v", report.ByEffort)
+	}
+	if len(report.ByWeek) != 2 || report.ByWeek[0].Key != "2026-03-02" || report.ByWeek[0].Tasks != 2 || report.ByWeek[1].Key != "2026-03-09" {
+		t.Errorf("ByWeek = %+v", report.ByWeek)
+	}
+}
@@ -133,14 +133,13 @@ type EvalStats struct {
 // SaveEvalResult persists an eval result row.
 func (s *Store) SaveEvalResult(r *EvalResult) error {
 	return s.withRetry("SaveEvalResult", func() error {
-		res, err := s.db.Exec(`
+		id, err := s.Dialect().InsertID(s.db, `
 			INSERT INTO eval_results (run_id, task_id, model, passed, duration_ms, tokens_used, cost_usd, error_msg)
 			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		`, r.RunID, r.TaskID, r.Model, r.Passed, r.DurationMs, r.TokensUsed, r.CostUSD, r.ErrorMsg)
 		if err != nil {
 			return err
 		}
-		id, _ := res.LastInsertId()
 		r.ID = id
 		return nil
 	})
@@ -199,8 +198,8 @@ func (s *Store) GetEvalResults(filter EvalResultFilter) ([]*EvalResult, error) {
 
 // GetEvalStats returns aggregated stats per model for a given run. If runID is empty, aggregates all runs.
 func (s *Store) GetEvalStats(runID string) ([]*EvalStats, error) {
-	query := `SELECT model, COUNT(*) as total, SUM(CASE WHEN passed THEN 1 ELSE 0 END) as passed,
-		SUM(CASE WHEN passed THEN 0 ELSE 1 END) as failed,
+	query := `SELECT model, COUNT(*) as total, SUM(CASE WHEN passed = 1 THEN 1 ELSE 0 END) as passed,
+		SUM(CASE WHEN passed = 1 THEN 0 ELSE 1 END) as failed,
 		AVG(duration_ms) as avg_dur, AVG(tokens_used) as avg_tok, SUM(cost_usd) as total_cost
 		FROM eval_results`
 	var args []interface{}
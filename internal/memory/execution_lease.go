@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// leaseAttempts bounds how often LeaseQueuedExecution retries after losing
+// a race for the oldest task
+const leaseAttempts = 3
+
+// LeaseQueuedExecution hands the oldest queued execution to a remote
+// worker. Tasks of a project run one at a time, so projects with a running
+// execution are skipped. The execution is marked running and leased to
+// workerID until now+ttl; the worker renews the lease while it runs.
+// Only projects in the list are considered, all when it is empty. Returns
+// nil when there is nothing to lease.
+func (s *Store) LeaseQueuedExecution(workerID string, projects []string, ttl time.Duration, now time.Time) (*Execution, error) {
+	args := []interface{}{}
+	projectFilter := ""
+	if len(projects) > 0 {
+		placeholders := make([]string, len(projects))
+		for i, p := range projects {
+			placeholders[i] = "?"
+			args = append(args, p)
+		}
+		projectFilter = " AND project_path IN (" + strings.Join(placeholders, ",") + ")"
+	}
+
+	for attempt := 0; attempt < leaseAttempts; attempt++ {
+		var id string
+		err := s.db.QueryRow(`
+			SELECT id FROM executions
+			WHERE status = 'queued'`+projectFilter+`
+				AND project_path NOT IN (SELECT project_path FROM executions WHERE status = 'running')
+			ORDER BY created_at ASC, id ASC
+			LIMIT 1
+		`, args...).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find queued execution: %w", err)
+		}
+
+		var leased int64
+		err = s.withRetry("LeaseQueuedExecution", func() error {
+			result, err := s.db.Exec(`
+				UPDATE executions SET status = 'running', worker_id = ?, lease_expires_at = ?
+				WHERE id = ? AND status = 'queued'
+			`, workerID, now.Add(ttl).UnixMilli(), id)
+			if err != nil {
+				return err
+			}
+			leased, err = result.RowsAffected()
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to lease execution %s: %w", id, err)
+		}
+		if leased == 1 {
+			return s.GetExecution(id)
+		}
+		// Another worker took it first
+	}
+	return nil, nil
+}
+
+// RenewExecutionLease extends workerID's lease on a running execution to
+// now+ttl. Returns false when the worker no longer holds it: the lease
+// expired and the task was requeued, or the execution was cancelled.
+func (s *Store) RenewExecutionLease(id, workerID string, ttl time.Duration, now time.Time) (bool, error) {
+	var renewed int64
+	err := s.withRetry("RenewExecutionLease", func() error {
+		result, err := s.db.Exec(`
+			UPDATE executions SET lease_expires_at = ?
+			WHERE id = ? AND worker_id = ? AND status = 'running'
+		`, now.Add(ttl).UnixMilli(), id, workerID)
+		if err != nil {
+			return err
+		}
+		renewed, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to renew lease on execution %s: %w", id, err)
+	}
+	return renewed == 1, nil
+}
+
+// ReleaseExecutionLease ends workerID's lease on a running execution so
+// its result can be recorded. Returns false when the worker no longer
+// holds it, in which case the result must be dropped.
+func (s *Store) ReleaseExecutionLease(id, workerID string) (bool, error) {
+	var released int64
+	err := s.withRetry("ReleaseExecutionLease", func() error {
+		result, err := s.db.Exec(`
+			UPDATE executions SET lease_expires_at = NULL
+			WHERE id = ? AND worker_id = ? AND status = 'running' AND lease_expires_at IS NOT NULL
+		`, id, workerID)
+		if err != nil {
+			return err
+		}
+		released, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to release lease on execution %s: %w", id, err)
+	}
+	return released == 1, nil
+}
+
+// RequeueExpiredLeases puts running executions whose worker stopped
+// renewing the lease before now back in the queue. Returns how many were
+// requeued.
+func (s *Store) RequeueExpiredLeases(now time.Time) (int, error) {
+	var requeued int64
+	err := s.withRetry("RequeueExpiredLeases", func() error {
+		result, err := s.db.Exec(`
+			UPDATE executions SET status = 'queued', worker_id = NULL, lease_expires_at = NULL
+			WHERE status = 'running' AND lease_expires_at IS NOT NULL AND lease_expires_at < ?
+		`, now.UnixMilli())
+		if err != nil {
+			return err
+		}
+		requeued, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue expired leases: %w", err)
+	}
+	return int(requeued), nil
+}
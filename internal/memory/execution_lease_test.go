@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExecutionLease(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, exec := range []*Execution{
+		{ID: "exec-1", TaskID: "GH-1", ProjectPath: "/app", Status: "queued", TaskTitle: "First"},
+		{ID: "exec-2", TaskID: "GH-2", ProjectPath: "/app", Status: "queued"},
+		{ID: "exec-3", TaskID: "GH-3", ProjectPath: "/api", Status: "queued"},
+	} {
+		if err := store.SaveExecution(exec); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+	}
+
+	now := time.Now()
+	ttl := time.Minute
+
+	// Only projects the worker has
+	leased, err := store.LeaseQueuedExecution("worker-a", []string{"/app"}, ttl, now)
+	if err != nil {
+		t.Fatalf("LeaseQueuedExecution failed: %v", err)
+	}
+	if leased == nil || leased.ID != "exec-1" || leased.Status != "running" || leased.TaskTitle != "First" {
+		t.Fatalf("leased = %+v, want exec-1 running with its task", leased)
+	}
+
+	// /app is busy until exec-1 finishes
+	leased, err = store.LeaseQueuedExecution("worker-b", []string{"/app"}, ttl, now)
+	if err != nil {
+		t.Fatalf("LeaseQueuedExecution failed: %v", err)
+	}
+	if leased != nil {
+		t.Fatalf("leased %s while the project has a running task", leased.ID)
+	}
+	leased, err = store.LeaseQueuedExecution("worker-b", nil, ttl, now)
+	if err != nil {
+		t.Fatalf("LeaseQueuedExecution failed: %v", err)
+	}
+	if leased == nil || leased.ID != "exec-3" {
+		t.Fatalf("leased = %+v, want exec-3", leased)
+	}
+
+	if ok, err := store.RenewExecutionLease("exec-1", "worker-a", ttl, now.Add(30*time.Second)); err != nil || !ok {
+		t.Errorf("RenewExecutionLease by holder = %v, %v, want true", ok, err)
+	}
+	if ok, err := store.RenewExecutionLease("exec-1", "worker-b", ttl, now); err != nil || ok {
+		t.Errorf("RenewExecutionLease by other worker = %v, %v, want false", ok, err)
+	}
+
+	// exec-3's worker went away; exec-1's renewed lease is still live
+	requeued, err := store.RequeueExpiredLeases(now.Add(ttl + time.Second))
+	if err != nil {
+		t.Fatalf("RequeueExpiredLeases failed: %v", err)
+	}
+	if requeued != 1 {
+		t.Errorf("requeued %d executions, want 1", requeued)
+	}
+	if exec, _ := store.GetExecution("exec-3"); exec.Status != "queued" {
+		t.Errorf("exec-3 status = %q, want queued", exec.Status)
+	}
+	if ok, err := store.RenewExecutionLease("exec-3", "worker-b", ttl, now); err != nil || ok {
+		t.Errorf("RenewExecutionLease after requeue = %v, %v, want false", ok, err)
+	}
+	if ok, err := store.ReleaseExecutionLease("exec-3", "worker-b"); err != nil || ok {
+		t.Errorf("ReleaseExecutionLease after requeue = %v, %v, want false", ok, err)
+	}
+
+	// Leased executions are not reset as stale on restart
+	stale, err := store.GetStaleRunningExecutions(-time.Hour)
+	if err != nil {
+		t.Fatalf("GetStaleRunningExecutions failed: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("stale = %d executions, want leased executions excluded", len(stale))
+	}
+
+	if ok, err := store.ReleaseExecutionLease("exec-1", "worker-a"); err != nil || !ok {
+		t.Errorf("ReleaseExecutionLease by holder = %v, %v, want true", ok, err)
+	}
+	if ok, err := store.ReleaseExecutionLease("exec-1", "worker-a"); err != nil || ok {
+		t.Errorf("second ReleaseExecutionLease = %v, %v, want false", ok, err)
+	}
+}
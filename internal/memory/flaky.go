@@ -0,0 +1,89 @@
+package memory
+
+import "time"
+
+// FlakyGateRun is one execution in which a quality gate failed
+// nondeterministically: its failures differed, or it passed on retry
+type FlakyGateRun struct {
+	ExecutionID string
+	ProjectPath string
+	Gate        string // Gate label, qualified with its matrix cell
+	Fingerprint string // Fingerprint of the gate's last failure
+}
+
+// FlakyGate summarizes how often a gate was flaky in a period
+type FlakyGate struct {
+	ProjectPath  string
+	Gate         string
+	Occurrences  int // Executions in which the gate was flaky
+	Fingerprints int // Distinct failure fingerprints seen
+	LastSeen     time.Time
+}
+
+// SaveFlakyGates records the gates that were flaky in an execution
+func (s *Store) SaveFlakyGates(runs []*FlakyGateRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	return s.withRetry("SaveFlakyGates", func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		for _, r := range runs {
+			if _, err := tx.Exec(`
+				INSERT INTO flaky_gates (execution_id, project_path, gate, fingerprint)
+				VALUES (?, ?, ?, ?)
+			`, r.ExecutionID, r.ProjectPath, r.Gate, r.Fingerprint); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// GetFlakyGates returns the gates that were flaky in the query period, most
+// frequently flaky first. If query.Projects is non-empty, results are
+// filtered to those projects only.
+func (s *Store) GetFlakyGates(query BriefQuery) ([]*FlakyGate, error) {
+	args := []interface{}{query.Start, query.End}
+	projectFilter := ""
+	if len(query.Projects) > 0 {
+		placeholders := ""
+		for i, p := range query.Projects {
+			if i > 0 {
+				placeholders += ","
+			}
+			placeholders += "?"
+			args = append(args, p)
+		}
+		projectFilter = " AND project_path IN (" + placeholders + ")"
+	}
+
+	rows, err := s.db.Query(`
+		SELECT project_path, gate, COUNT(DISTINCT execution_id),
+			COUNT(DISTINCT NULLIF(fingerprint, '')), MAX(created_at)
+		FROM flaky_gates
+		WHERE created_at >= ? AND created_at < ?`+projectFilter+`
+		GROUP BY project_path, gate
+		ORDER BY COUNT(DISTINCT execution_id) DESC, MAX(id) DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var gates []*FlakyGate
+	for rows.Next() {
+		var g FlakyGate
+		var lastSeen dbTime
+		if err := rows.Scan(&g.ProjectPath, &g.Gate, &g.Occurrences, &g.Fingerprints, &lastSeen); err != nil {
+			return nil, err
+		}
+		g.LastSeen = lastSeen.Time
+		gates = append(gates, &g)
+	}
+	return gates, rows.Err()
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlakyGates(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SaveFlakyGates(nil); err != nil {
+		t.Fatalf("SaveFlakyGates(nil) failed: %v", err)
+	}
+	runs := []*FlakyGateRun{
+		{ExecutionID: "exec-1", ProjectPath: "/app", Gate: "test", Fingerprint: "aaaa"},
+		{ExecutionID: "exec-1", ProjectPath: "/app", Gate: "lint", Fingerprint: "cccc"},
+		{ExecutionID: "exec-2", ProjectPath: "/app", Gate: "test", Fingerprint: "bbbb"},
+		{ExecutionID: "exec-3", ProjectPath: "/app", Gate: "test", Fingerprint: "aaaa"},
+		{ExecutionID: "exec-4", ProjectPath: "/other", Gate: "build"},
+	}
+	if err := store.SaveFlakyGates(runs); err != nil {
+		t.Fatalf("SaveFlakyGates failed: %v", err)
+	}
+
+	now := time.Now()
+	query := BriefQuery{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+	gates, err := store.GetFlakyGates(query)
+	if err != nil {
+		t.Fatalf("GetFlakyGates failed: %v", err)
+	}
+	if len(gates) != 3 {
+		t.Fatalf("got %d flaky gates, want 3: %+v", len(gates), gates)
+	}
+	top := gates[0]
+	if top.ProjectPath != "/app" || top.Gate != "test" || top.Occurrences != 3 || top.Fingerprints != 2 {
+		t.Errorf("top flaky gate = %+v, want /app test flaky 3 times with 2 fingerprints", top)
+	}
+	if top.LastSeen.IsZero() {
+		t.Error("LastSeen not set")
+	}
+
+	query.Projects = []string{"/other"}
+	gates, err = store.GetFlakyGates(query)
+	if err != nil {
+		t.Fatalf("GetFlakyGates with projects failed: %v", err)
+	}
+	if len(gates) != 1 || gates[0].Gate != "build" || gates[0].Fingerprints != 0 {
+		t.Errorf("filtered flaky gates = %+v, want /other build without fingerprints", gates)
+	}
+
+	query = BriefQuery{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)}
+	if gates, err = store.GetFlakyGates(query); err != nil || len(gates) != 0 {
+		t.Errorf("GetFlakyGates outside period = %+v, %v, want none", gates, err)
+	}
+}
@@ -43,19 +43,25 @@ type Memory struct {
 // KnowledgeStore manages experiential memories using SQLite.
 // It provides storage, retrieval, and time-based decay of memories.
 type KnowledgeStore struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect Dialect
 }
 
 // NewKnowledgeStore creates a new KnowledgeStore backed by the given database.
 func NewKnowledgeStore(db *sql.DB) *KnowledgeStore {
-	return &KnowledgeStore{db: db}
+	return &KnowledgeStore{db: db, dialect: DialectFor(db)}
 }
 
 // InitSchema creates the memories table and indexes.
 // Safe to call multiple times (uses IF NOT EXISTS).
 func (k *KnowledgeStore) InitSchema() error {
-	_, err := k.db.Exec(`
-		CREATE TABLE IF NOT EXISTS memories (
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_memories_type ON memories(type)`,
+		`CREATE INDEX IF NOT EXISTS idx_memories_project ON memories(project_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_memories_confidence ON memories(confidence DESC)`,
+	}
+	return k.dialect.Migrate(k.db, Schema{
+		SQLite: append([]string{`CREATE TABLE IF NOT EXISTS memories (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			type TEXT NOT NULL,
 			content TEXT NOT NULL,
@@ -64,12 +70,18 @@ func (k *KnowledgeStore) InitSchema() error {
 			project_id TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);
-		CREATE INDEX IF NOT EXISTS idx_memories_type ON memories(type);
-		CREATE INDEX IF NOT EXISTS idx_memories_project ON memories(project_id);
-		CREATE INDEX IF NOT EXISTS idx_memories_confidence ON memories(confidence DESC);
-	`)
-	return err
+		)`}, indexes...),
+		Postgres: append([]string{`CREATE TABLE IF NOT EXISTS memories (
+			id BIGSERIAL PRIMARY KEY,
+			type TEXT NOT NULL,
+			content TEXT NOT NULL,
+			context TEXT,
+			confidence DOUBLE PRECISION DEFAULT 1.0,
+			project_id TEXT,
+			created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+		)`}, indexes...),
+	})
 }
 
 // AddMemory stores a new memory entry.
@@ -78,14 +90,14 @@ func (k *KnowledgeStore) AddMemory(m *Memory) error {
 	if m.Confidence == 0 {
 		m.Confidence = 1.0 // Default confidence
 	}
-	result, err := k.db.Exec(`
+	id, err := k.dialect.InsertID(k.db, `
 		INSERT INTO memories (type, content, context, confidence, project_id)
 		VALUES (?, ?, ?, ?, ?)
 	`, m.Type, m.Content, m.Context, m.Confidence, m.ProjectID)
 	if err != nil {
 		return err
 	}
-	m.ID, _ = result.LastInsertId()
+	m.ID = id
 	return nil
 }
 
@@ -117,7 +129,7 @@ func (k *KnowledgeStore) QueryByTopic(topic string, projectID string) ([]*Memory
 	rows, err := k.db.Query(`
 		SELECT id, type, content, context, confidence, project_id, created_at, updated_at
 		FROM memories
-		WHERE (content LIKE ? OR context LIKE ?)
+		WHERE (`+k.dialect.ILike("content", "?")+` OR `+k.dialect.ILike("context", "?")+`)
 		AND (project_id = ? OR project_id IS NULL)
 		AND confidence > 0.1
 		ORDER BY confidence DESC, updated_at DESC
@@ -202,7 +214,7 @@ func (k *KnowledgeStore) UpdateMemory(m *Memory) error {
 func (k *KnowledgeStore) ReinforceMemory(id int64, delta float64) error {
 	_, err := k.db.Exec(`
 		UPDATE memories
-		SET confidence = MIN(1.0, confidence + ?), updated_at = CURRENT_TIMESTAMP
+		SET confidence = `+k.dialect.Least("1.0", "confidence + ?")+`, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`, delta, id)
 	return err
@@ -213,7 +225,7 @@ func (k *KnowledgeStore) ReinforceMemory(id int64, delta float64) error {
 func (k *KnowledgeStore) DecayConfidence(rate float64) error {
 	_, err := k.db.Exec(`
 		UPDATE memories
-		SET confidence = MAX(0.0, confidence - (? * (julianday('now') - julianday(updated_at))))
+		SET confidence = `+k.dialect.Greatest("0.0", "confidence - (? * "+k.dialect.DaysSince("updated_at")+")")+`
 		WHERE confidence > 0.1
 	`, rate)
 	return err
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Lease is a named, time-limited lock held by one daemon, renewed by
+// heartbeats. Used to elect the primary of a warm standby pair.
+type Lease struct {
+	Name        string
+	Holder      string
+	AcquiredAt  time.Time // When Holder took the lease
+	HeartbeatAt time.Time // Last renewal
+	ExpiresAt   time.Time // Others may take the lease after this
+}
+
+// AcquireLease takes or renews the named lease for holder. The lease is
+// granted when it is free, already held by holder, or expired at now.
+// Returns the lease as stored afterwards; compare its Holder to tell whether
+// holder got it.
+func (s *Store) AcquireLease(name, holder string, ttl time.Duration, now time.Time) (*Lease, error) {
+	now = now.UTC()
+	err := s.withRetry("AcquireLease", func() error {
+		_, err := s.db.Exec(`
+			INSERT INTO leases (name, holder, acquired_at, heartbeat_at, expires_at) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(name) DO UPDATE SET
+				acquired_at = CASE WHEN leases.holder = excluded.holder THEN leases.acquired_at ELSE excluded.acquired_at END,
+				holder = excluded.holder,
+				heartbeat_at = excluded.heartbeat_at,
+				expires_at = excluded.expires_at
+			WHERE leases.holder = excluded.holder OR leases.expires_at <= ?
+		`, name, holder, now, now, now.Add(ttl).UnixMilli(), now.UnixMilli())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lease %s: %w", name, err)
+	}
+	lease, err := s.GetLease(name)
+	if err != nil {
+		return nil, err
+	}
+	if lease == nil {
+		return nil, fmt.Errorf("lease %s missing after acquire", name)
+	}
+	return lease, nil
+}
+
+// ReleaseLease gives up the named lease if holder has it, so another daemon
+// can take over without waiting for it to expire
+func (s *Store) ReleaseLease(name, holder string) error {
+	return s.withRetry("ReleaseLease", func() error {
+		_, err := s.db.Exec(`DELETE FROM leases WHERE name = ? AND holder = ?`, name, holder)
+		return err
+	})
+}
+
+// GetLease returns the named lease, or nil if nobody has taken it
+func (s *Store) GetLease(name string) (*Lease, error) {
+	lease := Lease{Name: name}
+	var expiresAt int64
+	err := s.db.QueryRow(`SELECT holder, acquired_at, heartbeat_at, expires_at FROM leases WHERE name = ?`, name).
+		Scan(&lease.Holder, &lease.AcquiredAt, &lease.HeartbeatAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lease %s: %w", name, err)
+	}
+	lease.ExpiresAt = time.UnixMilli(expiresAt).UTC()
+	return &lease, nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLease(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if lease, err := store.GetLease("primary"); err != nil || lease != nil {
+		t.Fatalf("GetLease() on empty store = %+v, %v", lease, err)
+	}
+
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	ttl := 30 * time.Second
+	lease, err := store.AcquireLease("primary", "node-a", ttl, start)
+	if err != nil {
+		t.Fatalf("AcquireLease failed: %v", err)
+	}
+	if lease.Holder != "node-a" || !lease.ExpiresAt.Equal(start.Add(ttl)) {
+		t.Fatalf("lease = %+v, want node-a until %v", lease, start.Add(ttl))
+	}
+
+	// A standby cannot take a live lease
+	lease, _ = store.AcquireLease("primary", "node-b", ttl, start.Add(10*time.Second))
+	if lease.Holder != "node-a" {
+		t.Errorf("holder = %s, want node-a while lease is live", lease.Holder)
+	}
+
+	// Renewal keeps the acquisition time and pushes the expiry out
+	lease, _ = store.AcquireLease("primary", "node-a", ttl, start.Add(20*time.Second))
+	if !lease.AcquiredAt.Equal(start) || !lease.ExpiresAt.Equal(start.Add(50*time.Second)) {
+		t.Errorf("renewed lease = %+v", lease)
+	}
+
+	// Once the heartbeats stop the standby takes over
+	takeover := start.Add(time.Minute)
+	lease, _ = store.AcquireLease("primary", "node-b", ttl, takeover)
+	if lease.Holder != "node-b" || !lease.AcquiredAt.Equal(takeover) {
+		t.Errorf("lease after expiry = %+v, want node-b since %v", lease, takeover)
+	}
+
+	// Releasing someone else's lease is a no-op
+	if err := store.ReleaseLease("primary", "node-a"); err != nil {
+		t.Fatalf("ReleaseLease failed: %v", err)
+	}
+	if lease, _ := store.GetLease("primary"); lease == nil || lease.Holder != "node-b" {
+		t.Errorf("lease after foreign release = %+v", lease)
+	}
+	if err := store.ReleaseLease("primary", "node-b"); err != nil {
+		t.Fatalf("ReleaseLease failed: %v", err)
+	}
+	if lease, _ := store.GetLease("primary"); lease != nil {
+		t.Errorf("lease after release = %+v, want nil", lease)
+	}
+}
@@ -221,10 +221,11 @@ func (s *Store) GetUsageSummary(query UsageQuery) (*UsageSummary, error) {
 	}
 
 	// Get token metrics
+	d := s.Dialect()
 	row = s.db.QueryRow(`
 		SELECT
-			COALESCE(SUM(CAST(json_extract(metadata, '$.input_tokens') AS INTEGER)), 0),
-			COALESCE(SUM(CAST(json_extract(metadata, '$.output_tokens') AS INTEGER)), 0),
+			COALESCE(SUM(CAST(`+d.JSONText("metadata", "input_tokens")+` AS BIGINT)), 0),
+			COALESCE(SUM(CAST(`+d.JSONText("metadata", "output_tokens")+` AS BIGINT)), 0),
 			COALESCE(SUM(quantity), 0),
 			COALESCE(SUM(total_cost), 0)
 		FROM usage_events
@@ -286,16 +287,17 @@ func (s *Store) GetDailyUsage(query UsageQuery) ([]*DailyUsage, error) {
 		args = append(args, query.ProjectID)
 	}
 
+	day := s.Dialect().Day("timestamp")
 	rows, err := s.db.Query(`
 		SELECT
-			substr(timestamp, 1, 10) as day,
+			`+day+` as day,
 			event_type,
 			COUNT(*) as event_count,
 			COALESCE(SUM(quantity), 0) as total_quantity,
 			COALESCE(SUM(total_cost), 0) as total_cost
 		FROM usage_events
 		`+whereClause+`
-		GROUP BY substr(timestamp, 1, 10), event_type
+		GROUP BY `+day+`, event_type
 		ORDER BY day DESC, event_type
 	`, args...)
 	if err != nil {
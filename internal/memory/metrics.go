@@ -59,6 +59,10 @@ type MetricsSummary struct {
 	// PRs
 	PRsCreated int
 
+	// Coverage, over executions where the coverage delta check measured it
+	CoverageMeasured int
+	AvgCoverageDelta float64 // Average change in percentage points
+
 	// Time period
 	PeriodStart time.Time
 	PeriodEnd   time.Time
@@ -136,6 +140,9 @@ func EstimateCost(inputTokens, outputTokens int64, model string) float64 {
 
 	modelLower := strings.ToLower(model)
 	switch {
+	case strings.HasPrefix(modelLower, "ollama/"):
+		// Local models served by Ollama are free
+		return 0
 	case strings.Contains(modelLower, "opus-4-1") || strings.Contains(modelLower, "opus-4-0") || model == "claude-opus-4":
 		// Legacy Opus 4.1/4.0 pricing
 		inputPrice = Opus41InputPricePerMillion
@@ -180,6 +187,16 @@ func (s *Store) SaveExecutionMetrics(metrics *ExecutionMetrics) error {
 	})
 }
 
+// SaveCoverageDelta records the coverage measured before and after an
+// execution's change by the coverage delta quality check
+func (s *Store) SaveCoverageDelta(executionID string, base, head float64) error {
+	return s.withRetry("SaveCoverageDelta", func() error {
+		_, err := s.db.Exec(`UPDATE executions SET coverage_base = ?, coverage_head = ? WHERE id = ?`,
+			base, head, executionID)
+		return err
+	})
+}
+
 // GetMetricsSummary returns aggregated metrics for a time period
 func (s *Store) GetMetricsSummary(query MetricsQuery) (*MetricsSummary, error) {
 	summary := &MetricsSummary{
@@ -210,7 +227,7 @@ func (s *Store) GetMetricsSummary(query MetricsQuery) (*MetricsSummary, error) {
 			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0) as completed,
 			COALESCE(SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END), 0) as failed,
 			COALESCE(SUM(duration_ms), 0) as total_duration,
-			CAST(COALESCE(AVG(CASE WHEN status = 'completed' THEN duration_ms END), 0) AS INTEGER) as avg_duration,
+			CAST(COALESCE(AVG(CASE WHEN status = 'completed' THEN duration_ms END), 0) AS BIGINT) as avg_duration,
 			COALESCE(MIN(CASE WHEN status = 'completed' THEN duration_ms END), 0) as min_duration,
 			COALESCE(MAX(CASE WHEN status = 'completed' THEN duration_ms END), 0) as max_duration,
 			COALESCE(SUM(tokens_input), 0) as total_input,
@@ -220,7 +237,9 @@ func (s *Store) GetMetricsSummary(query MetricsQuery) (*MetricsSummary, error) {
 			COALESCE(SUM(files_changed), 0) as files_changed,
 			COALESCE(SUM(lines_added), 0) as lines_added,
 			COALESCE(SUM(lines_removed), 0) as lines_removed,
-			COALESCE(SUM(CASE WHEN pr_url != '' AND pr_url IS NOT NULL THEN 1 ELSE 0 END), 0) as prs
+			COALESCE(SUM(CASE WHEN pr_url != '' AND pr_url IS NOT NULL THEN 1 ELSE 0 END), 0) as prs,
+			COUNT(coverage_head) as coverage_measured,
+			COALESCE(AVG(coverage_head - coverage_base), 0) as avg_coverage_delta
 		FROM executions
 	`+whereClause, args...)
 
@@ -240,6 +259,8 @@ func (s *Store) GetMetricsSummary(query MetricsQuery) (*MetricsSummary, error) {
 		&summary.TotalLinesAdded,
 		&summary.TotalLinesRemoved,
 		&summary.PRsCreated,
+		&summary.CoverageMeasured,
+		&summary.AvgCoverageDelta,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get metrics summary: %w", err)
@@ -255,6 +276,49 @@ func (s *Store) GetMetricsSummary(query MetricsQuery) (*MetricsSummary, error) {
 	return summary, nil
 }
 
+// GetAdapterSpend returns the estimated cost of executions that came from the
+// given source adapter within [start, end)
+func (s *Store) GetAdapterSpend(adapter string, start, end time.Time) (float64, error) {
+	var spend float64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(estimated_cost_usd), 0)
+		FROM executions
+		WHERE source_adapter = ? AND created_at >= ? AND created_at < ?
+	`, adapter, start, end).Scan(&spend)
+	if err != nil {
+		return 0, err
+	}
+	return spend, nil
+}
+
+// GetProjectSpend returns the estimated cost of executions in any of the
+// given projects within [start, end)
+func (s *Store) GetProjectSpend(projects []string, start, end time.Time) (float64, error) {
+	if len(projects) == 0 {
+		return 0, nil
+	}
+	args := []interface{}{start, end}
+	placeholders := ""
+	for i, p := range projects {
+		if i > 0 {
+			placeholders += ","
+		}
+		placeholders += "?"
+		args = append(args, p)
+	}
+
+	var spend float64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(estimated_cost_usd), 0)
+		FROM executions
+		WHERE created_at >= ? AND created_at < ? AND project_path IN (`+placeholders+`)
+	`, args...).Scan(&spend)
+	if err != nil {
+		return 0, err
+	}
+	return spend, nil
+}
+
 // GetDailyMetrics returns metrics aggregated by day
 func (s *Store) GetDailyMetrics(query MetricsQuery) ([]*DailyMetrics, error) {
 	var args []interface{}
@@ -273,9 +337,10 @@ func (s *Store) GetDailyMetrics(query MetricsQuery) ([]*DailyMetrics, error) {
 		whereClause += " AND project_path IN (" + placeholders + ")"
 	}
 
+	day := s.Dialect().Day("created_at")
 	rows, err := s.db.Query(`
 		SELECT
-			date(created_at) as day,
+			`+day+` as day,
 			COUNT(*) as total,
 			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0) as completed,
 			COALESCE(SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END), 0) as failed,
@@ -288,7 +353,7 @@ func (s *Store) GetDailyMetrics(query MetricsQuery) ([]*DailyMetrics, error) {
 			COALESCE(SUM(CASE WHEN pr_url != '' AND pr_url IS NOT NULL THEN 1 ELSE 0 END), 0) as prs
 		FROM executions
 		`+whereClause+`
-		GROUP BY date(created_at)
+		GROUP BY `+day+`
 		ORDER BY day DESC
 	`, args...)
 	if err != nil {
@@ -354,7 +419,7 @@ func (s *Store) GetProjectMetrics(query MetricsQuery) ([]*ProjectMetrics, error)
 		FROM executions e
 		LEFT JOIN projects p ON e.project_path = p.path
 		`+whereClause+`
-		GROUP BY e.project_path
+		GROUP BY e.project_path, p.name
 		ORDER BY total DESC
 	`, args...)
 	if err != nil {
@@ -365,6 +430,7 @@ func (s *Store) GetProjectMetrics(query MetricsQuery) ([]*ProjectMetrics, error)
 	var metrics []*ProjectMetrics
 	for rows.Next() {
 		var m ProjectMetrics
+		var lastExec dbTime
 		if err := rows.Scan(
 			&m.ProjectPath,
 			&m.ProjectName,
@@ -374,10 +440,11 @@ func (s *Store) GetProjectMetrics(query MetricsQuery) ([]*ProjectMetrics, error)
 			&m.TotalDurationMs,
 			&m.TotalTokens,
 			&m.TotalCostUSD,
-			&m.LastExecution,
+			&lastExec,
 		); err != nil {
 			return nil, err
 		}
+		m.LastExecution = lastExec.Time
 		if m.ExecutionCount > 0 {
 			m.SuccessRate = float64(m.SuccessCount) / float64(m.ExecutionCount)
 		}
@@ -410,7 +477,7 @@ func (s *Store) GetFailureReasons(query MetricsQuery, limit int) ([]*FailureReas
 	// Group by first line of error (usually the main error message)
 	rows, err := s.db.Query(`
 		SELECT
-			SUBSTR(error, 1, INSTR(error || char(10), char(10)) - 1) as reason,
+			`+s.Dialect().FirstLine("error")+` as reason,
 			COUNT(*) as count
 		FROM executions
 		`+whereClause+`
@@ -455,7 +522,7 @@ func (s *Store) GetPeakUsageHours(query MetricsQuery) (map[int]int, error) {
 
 	rows, err := s.db.Query(`
 		SELECT
-			CAST(strftime('%H', created_at) AS INTEGER) as hour,
+			`+s.Dialect().Hour("created_at")+` as hour,
 			COUNT(*) as count
 		FROM executions
 		`+whereClause+`
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PipelineState is the persisted pause state of the dispatch pipeline
+type PipelineState struct {
+	Paused    bool
+	Reason    string
+	UpdatedAt time.Time
+}
+
+// GetPipelineState returns the pipeline state. A pipeline that was never
+// paused reports the zero state.
+func (s *Store) GetPipelineState() (*PipelineState, error) {
+	var state PipelineState
+	var reason sql.NullString
+	var updatedAt sql.NullTime
+	err := s.db.QueryRow(`SELECT paused, reason, updated_at FROM pipeline_state WHERE id = 1`).
+		Scan(&state.Paused, &reason, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pipeline state: %w", err)
+	}
+	state.Reason = reason.String
+	state.UpdatedAt = updatedAt.Time
+	return &state, nil
+}
+
+// SetPipelineState stores the pipeline state
+func (s *Store) SetPipelineState(state *PipelineState) error {
+	updatedAt := state.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+	return s.withRetry("SetPipelineState", func() error {
+		_, err := s.db.Exec(`
+			INSERT INTO pipeline_state (id, paused, reason, updated_at) VALUES (1, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET paused = excluded.paused, reason = excluded.reason, updated_at = excluded.updated_at
+		`, state.Paused, state.Reason, updatedAt.UTC())
+		return err
+	})
+}
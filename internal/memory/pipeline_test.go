@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPipelineState(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	state, err := store.GetPipelineState()
+	if err != nil {
+		t.Fatalf("GetPipelineState failed: %v", err)
+	}
+	if state.Paused || !state.UpdatedAt.IsZero() {
+		t.Errorf("initial state = %+v, want zero", state)
+	}
+
+	pausedAt := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := store.SetPipelineState(&PipelineState{Paused: true, Reason: "incident", UpdatedAt: pausedAt}); err != nil {
+		t.Fatalf("SetPipelineState failed: %v", err)
+	}
+	state, _ = store.GetPipelineState()
+	if !state.Paused || state.Reason != "incident" || !state.UpdatedAt.Equal(pausedAt) {
+		t.Errorf("state = %+v, want paused for incident", state)
+	}
+
+	if err := store.SetPipelineState(&PipelineState{}); err != nil {
+		t.Fatalf("SetPipelineState failed: %v", err)
+	}
+	state, _ = store.GetPipelineState()
+	if state.Paused || state.Reason != "" || state.UpdatedAt.IsZero() {
+		t.Errorf("state after resume = %+v", state)
+	}
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// postgresDriverName is the database/sql driver for PostgreSQL. It wraps
+// pgx and rebinds the ? placeholders the stores write to $1, $2, ...;
+// everything else that differs from SQLite comes from the Dialect.
+const postgresDriverName = "pilot-postgres"
+
+func init() {
+	sql.Register(postgresDriverName, &postgresDriver{})
+}
+
+// postgresMigrationLock is the advisory lock that serializes schema
+// changes between Pilot instances starting at the same time
+const postgresMigrationLock = 7_306_517_046
+
+type postgresDriver struct{}
+
+// Open implements driver.Driver
+func (d *postgresDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := stdlib.GetDefaultDriver().Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresConn{conn: conn.(*stdlib.Conn)}, nil
+}
+
+// postgresConn rebinds placeholders before handing statements to pgx
+type postgresConn struct {
+	conn *stdlib.Conn
+}
+
+func (c *postgresConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.conn.PrepareContext(ctx, rebindPlaceholders(query))
+}
+
+func (c *postgresConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *postgresConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *postgresConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.conn.BeginTx(ctx, opts)
+}
+
+func (c *postgresConn) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+func (c *postgresConn) ResetSession(ctx context.Context) error {
+	return c.conn.ResetSession(ctx)
+}
+
+// CheckNamedValue passes arguments through like pgx, except booleans:
+// SQLite stores them as integers and so does the PostgreSQL schema
+func (c *postgresConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if valuer, ok := nv.Value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		nv.Value = v
+	}
+	switch v := nv.Value.(type) {
+	case bool:
+		nv.Value = boolInt(v)
+	case *bool:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = boolInt(*v)
+		}
+	}
+	return nil
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.conn.ExecContext(ctx, rebindPlaceholders(query), args)
+}
+
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.conn.QueryContext(ctx, rebindPlaceholders(query), args)
+}
+
+// rebindPlaceholders replaces SQLite's ? placeholders with $1, $2, ...
+// outside string literals
+func rebindPlaceholders(query string) string {
+	n := 0
+	return rewriteOutsideLiterals(query, func(code string) string {
+		if !strings.Contains(code, "?") {
+			return code
+		}
+		var sb strings.Builder
+		for _, r := range code {
+			if r == '?' {
+				n++
+				sb.WriteString("$" + strconv.Itoa(n))
+				continue
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String()
+	})
+}
+
+// rewriteOutsideLiterals applies fn to the parts of query outside single
+// quoted string literals
+func rewriteOutsideLiterals(query string, fn func(string) string) string {
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(query, '\'')
+		if start < 0 {
+			sb.WriteString(fn(query))
+			return sb.String()
+		}
+		sb.WriteString(fn(query[:start]))
+
+		// Find the closing quote; '' is an escaped quote
+		end := start + 1
+		for end < len(query) {
+			if query[end] == '\'' {
+				if end+1 < len(query) && query[end+1] == '\'' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		if end >= len(query) {
+			sb.WriteString(query[start:])
+			return sb.String()
+		}
+		sb.WriteString(query[start : end+1])
+		query = query[end+1:]
+	}
+}
@@ -0,0 +1,374 @@
+package memory
+
+// postgresSchema creates the Store's tables on PostgreSQL. It matches the
+// SQLite migrations in Store.migrate after every ALTER has run: booleans
+// are SMALLINT 0 or 1 as in SQLite, integers are BIGINT, and there are no
+// foreign keys, which SQLite never enforced.
+var postgresSchema = []string{
+	`CREATE TABLE IF NOT EXISTS executions (
+		id TEXT PRIMARY KEY,
+		task_id TEXT NOT NULL,
+		project_path TEXT NOT NULL,
+		status TEXT NOT NULL,
+		output TEXT,
+		error TEXT,
+		duration_ms BIGINT,
+		pr_url TEXT,
+		commit_sha TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		completed_at TIMESTAMPTZ,
+		tokens_input BIGINT DEFAULT 0,
+		tokens_output BIGINT DEFAULT 0,
+		tokens_total BIGINT DEFAULT 0,
+		estimated_cost_usd DOUBLE PRECISION DEFAULT 0.0,
+		files_changed BIGINT DEFAULT 0,
+		lines_added BIGINT DEFAULT 0,
+		lines_removed BIGINT DEFAULT 0,
+		model_name TEXT DEFAULT 'claude-sonnet-4-5',
+		task_title TEXT,
+		task_description TEXT,
+		task_branch TEXT,
+		task_base_branch TEXT,
+		task_create_pr SMALLINT DEFAULT 0,
+		task_verbose SMALLINT DEFAULT 0,
+		task_backend TEXT,
+		requested_by TEXT,
+		session_id TEXT,
+		source_adapter TEXT,
+		coverage_base DOUBLE PRECISION,
+		coverage_head DOUBLE PRECISION,
+		task_labels TEXT,
+		worker_id TEXT,
+		lease_expires_at BIGINT
+	)`,
+	`CREATE TABLE IF NOT EXISTS patterns (
+		id BIGSERIAL PRIMARY KEY,
+		project_path TEXT,
+		pattern_type TEXT NOT NULL,
+		content TEXT NOT NULL,
+		confidence DOUBLE PRECISION DEFAULT 1.0,
+		uses BIGINT DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS projects (
+		path TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		navigator_enabled SMALLINT DEFAULT 1,
+		last_active TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		settings TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS cross_patterns (
+		id TEXT PRIMARY KEY,
+		pattern_type TEXT NOT NULL,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL,
+		context TEXT,
+		examples TEXT,
+		confidence DOUBLE PRECISION DEFAULT 0.5,
+		occurrences BIGINT DEFAULT 1,
+		is_anti_pattern SMALLINT DEFAULT 0,
+		scope TEXT DEFAULT 'org',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS pattern_projects (
+		pattern_id TEXT NOT NULL,
+		project_path TEXT NOT NULL,
+		uses BIGINT DEFAULT 1,
+		success_count BIGINT DEFAULT 0,
+		failure_count BIGINT DEFAULT 0,
+		last_used TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (pattern_id, project_path)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pattern_feedback (
+		id BIGSERIAL PRIMARY KEY,
+		pattern_id TEXT NOT NULL,
+		execution_id TEXT NOT NULL,
+		project_path TEXT NOT NULL,
+		outcome TEXT NOT NULL,
+		confidence_delta DOUBLE PRECISION DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_executions_task ON executions(task_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_executions_project ON executions(project_path)`,
+	`CREATE INDEX IF NOT EXISTS idx_executions_created ON executions(created_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_executions_status ON executions(status)`,
+	`CREATE INDEX IF NOT EXISTS idx_patterns_project ON patterns(project_path)`,
+	`CREATE INDEX IF NOT EXISTS idx_cross_patterns_type ON cross_patterns(pattern_type)`,
+	`CREATE INDEX IF NOT EXISTS idx_cross_patterns_scope ON cross_patterns(scope)`,
+	`CREATE INDEX IF NOT EXISTS idx_cross_patterns_confidence ON cross_patterns(confidence DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_cross_patterns_updated ON cross_patterns(updated_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_cross_patterns_title ON cross_patterns(title)`,
+	`CREATE INDEX IF NOT EXISTS idx_pattern_projects_project ON pattern_projects(project_path)`,
+	`CREATE INDEX IF NOT EXISTS idx_pattern_feedback_pattern ON pattern_feedback(pattern_id)`,
+	`CREATE TABLE IF NOT EXISTS usage_events (
+		id TEXT PRIMARY KEY,
+		timestamp TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		user_id TEXT NOT NULL,
+		project_id TEXT NOT NULL,
+		event_type TEXT NOT NULL,
+		quantity BIGINT DEFAULT 0,
+		unit_cost DOUBLE PRECISION DEFAULT 0.0,
+		total_cost DOUBLE PRECISION DEFAULT 0.0,
+		metadata TEXT,
+		execution_id TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_usage_events_user ON usage_events(user_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_usage_events_project ON usage_events(project_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_usage_events_timestamp ON usage_events(timestamp)`,
+	`CREATE INDEX IF NOT EXISTS idx_usage_events_type ON usage_events(event_type)`,
+	`CREATE INDEX IF NOT EXISTS idx_usage_events_execution ON usage_events(execution_id)`,
+	`CREATE TABLE IF NOT EXISTS sessions (
+		id TEXT PRIMARY KEY,
+		date TEXT NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL,
+		ended_at TIMESTAMPTZ,
+		total_input_tokens BIGINT DEFAULT 0,
+		total_output_tokens BIGINT DEFAULT 0,
+		total_cost_cents BIGINT DEFAULT 0,
+		tasks_completed BIGINT DEFAULT 0,
+		tasks_failed BIGINT DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_sessions_date ON sessions(date)`,
+	`CREATE TABLE IF NOT EXISTS autopilot_metrics (
+		id BIGSERIAL PRIMARY KEY,
+		snapshot_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		issues_success BIGINT DEFAULT 0,
+		issues_failed BIGINT DEFAULT 0,
+		issues_rate_limited BIGINT DEFAULT 0,
+		prs_merged BIGINT DEFAULT 0,
+		prs_failed BIGINT DEFAULT 0,
+		prs_conflicting BIGINT DEFAULT 0,
+		circuit_breaker_trips BIGINT DEFAULT 0,
+		api_errors_total BIGINT DEFAULT 0,
+		api_error_rate DOUBLE PRECISION DEFAULT 0.0,
+		queue_depth BIGINT DEFAULT 0,
+		failed_queue_depth BIGINT DEFAULT 0,
+		active_prs BIGINT DEFAULT 0,
+		success_rate DOUBLE PRECISION DEFAULT 0.0,
+		avg_ci_wait_ms BIGINT DEFAULT 0,
+		avg_merge_time_ms BIGINT DEFAULT 0,
+		avg_execution_ms BIGINT DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_autopilot_metrics_at ON autopilot_metrics(snapshot_at)`,
+	`CREATE TABLE IF NOT EXISTS brief_history (
+		id BIGSERIAL PRIMARY KEY,
+		sent_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		channel TEXT NOT NULL,
+		brief_type TEXT NOT NULL DEFAULT 'daily',
+		recipient TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_brief_history_sent_at ON brief_history(sent_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_brief_history_channel ON brief_history(channel)`,
+	`CREATE TABLE IF NOT EXISTS execution_logs (
+		id BIGSERIAL PRIMARY KEY,
+		execution_id TEXT,
+		timestamp TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		level TEXT NOT NULL DEFAULT 'info',
+		message TEXT NOT NULL,
+		component TEXT DEFAULT 'executor'
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_execution_logs_timestamp ON execution_logs(timestamp)`,
+	`CREATE TABLE IF NOT EXISTS model_outcomes (
+		id BIGSERIAL PRIMARY KEY,
+		task_type TEXT NOT NULL,
+		model TEXT NOT NULL,
+		outcome TEXT NOT NULL,
+		tokens_used BIGINT DEFAULT 0,
+		duration_ms BIGINT DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_model_outcomes_task_model ON model_outcomes(task_type, model)`,
+	`CREATE INDEX IF NOT EXISTS idx_model_outcomes_created ON model_outcomes(created_at)`,
+	`CREATE TABLE IF NOT EXISTS pattern_performance (
+		id BIGSERIAL PRIMARY KEY,
+		pattern_id TEXT NOT NULL,
+		project_id TEXT NOT NULL,
+		task_type TEXT NOT NULL,
+		model TEXT NOT NULL DEFAULT '',
+		success_count BIGINT DEFAULT 0,
+		failure_count BIGINT DEFAULT 0,
+		last_used TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(pattern_id, project_id, task_type)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_pattern_performance_pattern ON pattern_performance(pattern_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_pattern_performance_project ON pattern_performance(project_id)`,
+	`CREATE TABLE IF NOT EXISTS eval_tasks (
+		id TEXT PRIMARY KEY,
+		execution_id TEXT NOT NULL,
+		issue_number BIGINT NOT NULL,
+		issue_title TEXT NOT NULL,
+		repo TEXT NOT NULL,
+		success SMALLINT NOT NULL,
+		pass_criteria TEXT,
+		files_changed TEXT,
+		duration_ms BIGINT DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(repo, issue_number)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_tasks_repo ON eval_tasks(repo)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_tasks_success ON eval_tasks(success)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_tasks_created ON eval_tasks(created_at)`,
+	`CREATE TABLE IF NOT EXISTS eval_results (
+		id BIGSERIAL PRIMARY KEY,
+		run_id TEXT NOT NULL,
+		task_id TEXT NOT NULL,
+		model TEXT NOT NULL,
+		passed SMALLINT NOT NULL,
+		duration_ms BIGINT DEFAULT 0,
+		tokens_used BIGINT DEFAULT 0,
+		cost_usd DOUBLE PRECISION DEFAULT 0.0,
+		error_msg TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_results_run ON eval_results(run_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_results_task ON eval_results(task_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_results_model ON eval_results(model)`,
+	`CREATE INDEX IF NOT EXISTS idx_eval_results_created ON eval_results(created_at)`,
+	`CREATE TABLE IF NOT EXISTS task_durations (
+		id BIGSERIAL PRIMARY KEY,
+		project_path TEXT NOT NULL,
+		complexity TEXT NOT NULL,
+		duration_ms BIGINT NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_task_durations_lookup ON task_durations(project_path, complexity, id)`,
+	`CREATE TABLE IF NOT EXISTS epics (
+		project_path TEXT NOT NULL,
+		parent_id TEXT NOT NULL,
+		source_repo TEXT,
+		title TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (project_path, parent_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_epics_updated ON epics(updated_at)`,
+	`CREATE TABLE IF NOT EXISTS epic_sub_issues (
+		project_path TEXT NOT NULL,
+		parent_id TEXT NOT NULL,
+		position BIGINT NOT NULL,
+		number BIGINT DEFAULT 0,
+		identifier TEXT,
+		url TEXT,
+		title TEXT,
+		description TEXT,
+		depends_on TEXT,
+		status TEXT NOT NULL,
+		pr_url TEXT,
+		error TEXT,
+		cost_usd DOUBLE PRECISION DEFAULT 0.0,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (project_path, parent_id, position)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_epic_sub_issues_number ON epic_sub_issues(number)`,
+	`CREATE TABLE IF NOT EXISTS epic_events (
+		id BIGSERIAL PRIMARY KEY,
+		project_path TEXT NOT NULL,
+		parent_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		position BIGINT DEFAULT 0,
+		message TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_epic_events_epic ON epic_events(project_path, parent_id, id)`,
+	`CREATE TABLE IF NOT EXISTS task_estimates (
+		id BIGSERIAL PRIMARY KEY,
+		task_id TEXT NOT NULL,
+		project_path TEXT NOT NULL,
+		complexity TEXT NOT NULL,
+		effort TEXT,
+		model TEXT,
+		samples BIGINT DEFAULT 0,
+		predicted_duration_ms BIGINT DEFAULT 0,
+		predicted_tokens BIGINT DEFAULT 0,
+		actual_duration_ms BIGINT DEFAULT 0,
+		actual_tokens BIGINT DEFAULT 0,
+		success SMALLINT DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_task_estimates_lookup ON task_estimates(project_path, complexity, id)`,
+	`CREATE INDEX IF NOT EXISTS idx_task_estimates_created ON task_estimates(created_at)`,
+	`CREATE TABLE IF NOT EXISTS pipeline_state (
+		id BIGINT PRIMARY KEY CHECK (id = 1),
+		paused SMALLINT NOT NULL DEFAULT 0,
+		reason TEXT,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS leases (
+		name TEXT PRIMARY KEY,
+		holder TEXT NOT NULL,
+		acquired_at TIMESTAMPTZ NOT NULL,
+		heartbeat_at TIMESTAMPTZ NOT NULL,
+		expires_at BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS review_findings (
+		id BIGSERIAL PRIMARY KEY,
+		repo TEXT NOT NULL,
+		pr_number BIGINT NOT NULL,
+		verdict TEXT NOT NULL,
+		file TEXT,
+		line BIGINT DEFAULT 0,
+		severity TEXT,
+		category TEXT,
+		message TEXT NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_review_findings_repo ON review_findings(repo, id)`,
+	`CREATE TABLE IF NOT EXISTS flaky_gates (
+		id BIGSERIAL PRIMARY KEY,
+		execution_id TEXT NOT NULL,
+		project_path TEXT NOT NULL,
+		gate TEXT NOT NULL,
+		fingerprint TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_flaky_gates_created ON flaky_gates(created_at)`,
+	`CREATE TABLE IF NOT EXISTS token_attribution (
+		execution_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		input_tokens BIGINT NOT NULL DEFAULT 0,
+		output_tokens BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (execution_id, kind, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pending_questions (
+		id BIGSERIAL PRIMARY KEY,
+		task_id TEXT NOT NULL,
+		adapter TEXT NOT NULL,
+		context_id TEXT NOT NULL,
+		thread_id TEXT NOT NULL DEFAULT '',
+		sender_id TEXT NOT NULL DEFAULT '',
+		question TEXT NOT NULL,
+		session_id TEXT NOT NULL DEFAULT '',
+		needs_info SMALLINT NOT NULL DEFAULT 0,
+		description TEXT NOT NULL,
+		project_path TEXT NOT NULL DEFAULT '',
+		create_pr SMALLINT NOT NULL DEFAULT 0,
+		status TEXT NOT NULL DEFAULT 'pending',
+		answer TEXT,
+		created_at TIMESTAMPTZ NOT NULL,
+		resolved_at TIMESTAMPTZ
+	)`,
+	`ALTER TABLE pending_questions ADD COLUMN IF NOT EXISTS sender_id TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS idx_pending_questions_context ON pending_questions(adapter, context_id, status)`,
+	`CREATE TABLE IF NOT EXISTS approval_audit (
+		id BIGSERIAL PRIMARY KEY,
+		request_id TEXT NOT NULL,
+		task_id TEXT NOT NULL,
+		stage TEXT NOT NULL,
+		channel TEXT NOT NULL DEFAULT '',
+		event TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		decision TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_approval_audit_task ON approval_audit(task_id)`,
+	`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		source TEXT NOT NULL,
+		delivery_id TEXT NOT NULL,
+		expires_at BIGINT NOT NULL,
+		PRIMARY KEY (source, delivery_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_expires ON webhook_deliveries(expires_at)`,
+}
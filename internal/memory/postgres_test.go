@@ -0,0 +1,291 @@
+package memory
+
+import (
+	"database/sql/driver"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRebindPlaceholders(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "placeholders",
+			query: "SELECT id FROM executions WHERE project_path = ? AND status = ?",
+			want:  "SELECT id FROM executions WHERE project_path = $1 AND status = $2",
+		},
+		{
+			name:  "none",
+			query: "SELECT COUNT(*) FROM executions",
+			want:  "SELECT COUNT(*) FROM executions",
+		},
+		{
+			name:  "placeholder in literal",
+			query: "SELECT id FROM executions WHERE error = 'why?' AND status = ?",
+			want:  "SELECT id FROM executions WHERE error = 'why?' AND status = $1",
+		},
+		{
+			name:  "escaped quote",
+			query: "SELECT 'it''s ?' WHERE id = ?",
+			want:  "SELECT 'it''s ?' WHERE id = $1",
+		},
+		{
+			name:  "unterminated literal",
+			query: "SELECT ? WHERE note = 'open ?",
+			want:  "SELECT $1 WHERE note = 'open ?",
+		},
+		{
+			name:  "dialect expressions",
+			query: "UPDATE team_audit_log SET details = NULL WHERE actor_email = ? OR strpos(COALESCE(details, ''), ?) > 0",
+			want:  "UPDATE team_audit_log SET details = NULL WHERE actor_email = $1 OR strpos(COALESCE(details, ''), $2) > 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebindPlaceholders(tt.query); got != tt.want {
+				t.Errorf("rebindPlaceholders()\n got %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgresConnCheckNamedValue(t *testing.T) {
+	conn := &postgresConn{}
+	yes := true
+	tests := []struct {
+		value interface{}
+		want  interface{}
+	}{
+		{true, int64(1)},
+		{false, int64(0)},
+		{&yes, int64(1)},
+		{(*bool)(nil), nil},
+		{"text", "text"},
+		{int64(7), int64(7)},
+	}
+	for _, tt := range tests {
+		nv := &driver.NamedValue{Value: tt.value}
+		if err := conn.CheckNamedValue(nv); err != nil {
+			t.Fatalf("CheckNamedValue(%v) failed: %v", tt.value, err)
+		}
+		if nv.Value != tt.want {
+			t.Errorf("CheckNamedValue(%v) = %v (%T), want %v", tt.value, nv.Value, nv.Value, tt.want)
+		}
+	}
+}
+
+func TestDBTimeScan(t *testing.T) {
+	want := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"time", want},
+		{"current timestamp", "2026-03-04 05:06:07"},
+		{"driver string", "2026-03-04 05:06:07 +0000 UTC"},
+		{"monotonic", "2026-03-04 05:06:07 +0000 UTC m=+0.001"},
+		{"rfc3339", []byte("2026-03-04T05:06:07Z")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got dbTime
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%v) failed: %v", tt.src, err)
+			}
+			if !got.Time.Equal(want) {
+				t.Errorf("Scan(%v) = %v, want %v", tt.src, got.Time, want)
+			}
+		})
+	}
+
+	var null dbTime
+	if err := null.Scan(nil); err != nil || !null.Time.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v, want zero time", null.Time, err)
+	}
+	if err := null.Scan("yesterday"); err == nil {
+		t.Error("Scan(\"yesterday\") succeeded")
+	}
+}
+
+func TestInitBackend(t *testing.T) {
+	t.Cleanup(func() { _ = InitBackend(BackendConfig{}) })
+
+	if err := InitBackend(BackendConfig{Driver: "mysql"}); err == nil {
+		t.Error("unknown driver accepted")
+	}
+	if err := InitBackend(BackendConfig{Driver: DriverPostgres}); err == nil {
+		t.Error("postgres without a dsn accepted")
+	}
+	if got := currentBackend().Driver; got != DriverSQLite {
+		t.Errorf("backend after rejected configs = %q, want %q", got, DriverSQLite)
+	}
+
+	if err := InitBackend(BackendConfig{Driver: DriverPostgres, DSN: "postgres://localhost/pilot"}); err != nil {
+		t.Fatalf("InitBackend failed: %v", err)
+	}
+	if got := currentBackend(); got.Driver != DriverPostgres || got.DSN != "postgres://localhost/pilot" {
+		t.Errorf("backend = %+v, want postgres", got)
+	}
+
+	_ = InitBackend(BackendConfig{})
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore on sqlite failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if store.Driver() != DriverSQLite {
+		t.Errorf("Driver() = %q, want %q", store.Driver(), DriverSQLite)
+	}
+}
+
+// TestPostgresStore runs the store against the PostgreSQL server in
+// PILOT_TEST_POSTGRES_DSN, e.g. postgres://postgres@localhost/pilot_test.
+// CI runs it against a postgres service.
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv("PILOT_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("PILOT_TEST_POSTGRES_DSN not set")
+	}
+	if err := InitBackend(BackendConfig{Driver: DriverPostgres, DSN: dsn}); err != nil {
+		t.Fatalf("InitBackend failed: %v", err)
+	}
+	t.Cleanup(func() { _ = InitBackend(BackendConfig{}) })
+
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if store.Driver() != DriverPostgres {
+		t.Fatalf("Driver() = %q, want %q", store.Driver(), DriverPostgres)
+	}
+
+	// A second instance runs the same migrations against the migrated schema
+	second, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("second NewStore failed: %v", err)
+	}
+	_ = second.Close()
+
+	id := "pg-" + time.Now().Format("20060102150405.000000000")
+	exec := &Execution{
+		ID:           id,
+		TaskID:       "GH-1",
+		ProjectPath:  "/pg",
+		Status:       "completed",
+		TaskLabels:   []string{"backend"},
+		TaskCreatePR: true,
+	}
+	if err := store.SaveExecution(exec); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+	got, err := store.GetExecution(id)
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if got.TaskID != "GH-1" || !got.TaskCreatePR || len(got.TaskLabels) != 1 {
+		t.Errorf("GetExecution = %+v, want the saved execution", got)
+	}
+
+	pattern := &Pattern{ProjectPath: "/pg", Type: "code", Content: "use context", Confidence: 0.7}
+	if err := store.SavePattern(pattern); err != nil {
+		t.Fatalf("SavePattern failed: %v", err)
+	}
+	if pattern.ID == 0 {
+		t.Error("SavePattern did not set the generated id")
+	}
+
+	metrics := MetricsQuery{Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)}
+	if _, err := store.GetProjectMetrics(metrics); err != nil {
+		t.Errorf("GetProjectMetrics failed: %v", err)
+	}
+	if _, err := store.GetDailyMetrics(metrics); err != nil {
+		t.Errorf("GetDailyMetrics failed: %v", err)
+	}
+	if _, err := store.GetFailureReasons(metrics, 5); err != nil {
+		t.Errorf("GetFailureReasons failed: %v", err)
+	}
+	if _, err := store.GetPeakUsageHours(metrics); err != nil {
+		t.Errorf("GetPeakUsageHours failed: %v", err)
+	}
+
+	cross := &CrossPattern{ID: id, Type: "code", Title: "Wrap Errors", Description: "wrap with %w", Confidence: 0.5, Scope: "org"}
+	if err := store.SaveCrossPattern(cross); err != nil {
+		t.Fatalf("SaveCrossPattern failed: %v", err)
+	}
+	feedback := &PatternFeedback{PatternID: id, ExecutionID: id, ProjectPath: "/pg", Outcome: "success", ConfidenceDelta: 0.1}
+	if err := store.RecordPatternFeedback(feedback); err != nil {
+		t.Errorf("RecordPatternFeedback failed: %v", err)
+	}
+	if feedback.ID == 0 {
+		t.Error("RecordPatternFeedback did not set the generated id")
+	}
+	if found, err := store.SearchCrossPatterns("wrap errors", 5); err != nil || len(found) == 0 {
+		t.Errorf("SearchCrossPatterns = %d patterns, %v, want a case-insensitive match", len(found), err)
+	}
+
+	usage := UsageQuery{UserID: id, Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)}
+	if err := store.RecordUsageEvent(&UsageEvent{
+		ID:        id,
+		Timestamp: time.Now(),
+		UserID:    id,
+		ProjectID: "/pg",
+		EventType: EventTypeToken,
+		Quantity:  30,
+		Metadata:  map[string]interface{}{"input_tokens": 10, "output_tokens": 20},
+	}); err != nil {
+		t.Fatalf("RecordUsageEvent failed: %v", err)
+	}
+	summary, err := store.GetUsageSummary(usage)
+	if err != nil {
+		t.Fatalf("GetUsageSummary failed: %v", err)
+	}
+	if summary.TokensInput != 10 || summary.TokensOutput != 20 {
+		t.Errorf("GetUsageSummary tokens = %d/%d, want 10/20", summary.TokensInput, summary.TokensOutput)
+	}
+	if daily, err := store.GetDailyUsage(usage); err != nil || len(daily) != 1 {
+		t.Errorf("GetDailyUsage = %d days, %v, want 1", len(daily), err)
+	}
+
+	question := &PendingQuestion{TaskID: id, Adapter: "slack", ContextID: id, Question: "which branch?", Description: "fix"}
+	if err := store.SavePendingQuestion(question); err != nil {
+		t.Fatalf("SavePendingQuestion failed: %v", err)
+	}
+	if question.ID == 0 {
+		t.Error("SavePendingQuestion did not set the generated id")
+	}
+
+	if seen, err := store.RecordDelivery("github", id, time.Hour, time.Now()); err != nil || seen {
+		t.Errorf("RecordDelivery = %v, %v, want a new delivery", seen, err)
+	}
+	if seen, err := store.RecordDelivery("github", id, time.Hour, time.Now()); err != nil || !seen {
+		t.Errorf("RecordDelivery = %v, %v, want a redelivery", seen, err)
+	}
+
+	knowledge := NewKnowledgeStore(store.DB())
+	for i := 0; i < 2; i++ {
+		if err := knowledge.InitSchema(); err != nil {
+			t.Fatalf("InitSchema failed: %v", err)
+		}
+	}
+	mem := &Memory{Type: MemoryTypePattern, Content: "Use JWT " + id, ProjectID: id, Confidence: 0.5}
+	if err := knowledge.AddMemory(mem); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if mem.ID == 0 {
+		t.Error("AddMemory did not set the generated id")
+	}
+	if err := knowledge.ReinforceMemory(mem.ID, 0.2); err != nil {
+		t.Errorf("ReinforceMemory failed: %v", err)
+	}
+	if err := knowledge.DecayConfidence(0.01); err != nil {
+		t.Errorf("DecayConfidence failed: %v", err)
+	}
+	if found, err := knowledge.QueryByTopic("use jwt", id); err != nil || len(found) != 1 {
+		t.Errorf("QueryByTopic = %d memories, %v, want a case-insensitive match", len(found), err)
+	}
+}
@@ -0,0 +1,257 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// executionChildTables hold rows keyed by execution_id that are removed
+// together with their execution.
+var executionChildTables = []string{"execution_logs", "usage_events", "pattern_feedback", "eval_tasks", "flaky_gates", "token_attribution"}
+
+// PurgeFilter selects the executions removed by PurgeExecutions. Queued and
+// running executions are never removed.
+type PurgeFilter struct {
+	// Before removes executions created before this time.
+	Before time.Time
+	// RequestedBy removes executions requested by these team member IDs.
+	// Combined with Before, only their executions older than it are removed.
+	RequestedBy []string
+}
+
+// PurgeResult reports what a purge removed.
+type PurgeResult struct {
+	// TaskIDs of the removed executions, so their recordings can be removed too.
+	TaskIDs []string
+	// Rows removed per table.
+	Rows map[string]int64
+}
+
+// PurgeExecutions deletes the executions matching filter along with their
+// logs, usage events, pattern feedback, eval tasks, flaky gate runs, token
+// attribution and the clarifying questions their tasks asked in chats. An
+// empty filter is rejected rather than deleting every execution.
+func (s *Store) PurgeExecutions(filter PurgeFilter) (*PurgeResult, error) {
+	if filter.Before.IsZero() && len(filter.RequestedBy) == 0 {
+		return nil, fmt.Errorf("purge filter is empty")
+	}
+
+	where := []string{"status NOT IN ('queued', 'pending', 'running')"}
+	var args []interface{}
+	if !filter.Before.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, filter.Before.UTC())
+	}
+	if len(filter.RequestedBy) > 0 {
+		where = append(where, "requested_by IN ("+strings.TrimSuffix(strings.Repeat("?,", len(filter.RequestedBy)), ",")+")")
+		for _, id := range filter.RequestedBy {
+			args = append(args, id)
+		}
+	}
+	matching := "SELECT id FROM executions WHERE " + strings.Join(where, " AND ")
+
+	result := &PurgeResult{Rows: make(map[string]int64)}
+	err := s.withRetry("PurgeExecutions", func() error {
+		result.TaskIDs = nil
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		rows, err := tx.Query("SELECT DISTINCT task_id FROM executions WHERE id IN ("+matching+")", args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var taskID string
+			if err := rows.Scan(&taskID); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			result.TaskIDs = append(result.TaskIDs, taskID)
+		}
+		_ = rows.Close()
+
+		// Questions are keyed by task, so they go before the executions
+		n, err := execRows(tx, "DELETE FROM pending_questions WHERE task_id IN (SELECT task_id FROM executions WHERE id IN ("+matching+"))", args...)
+		if err != nil {
+			return fmt.Errorf("failed to purge pending_questions: %w", err)
+		}
+		result.Rows["pending_questions"] = n
+
+		for _, table := range append(executionChildTables, "executions") {
+			column := "execution_id"
+			if table == "executions" {
+				column = "id"
+			}
+			n, err := execRows(tx, "DELETE FROM "+table+" WHERE "+column+" IN ("+matching+")", args...)
+			if err != nil {
+				return fmt.Errorf("failed to purge %s: %w", table, err)
+			}
+			result.Rows[table] = n
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PurgeTranscripts removes the conversation with the model from executions
+// created before the cutoff while keeping the executions themselves: their
+// output is cleared and their log lines deleted. Answered and expired
+// clarifying questions asked before the cutoff, which hold the task
+// description and the chat answer, are deleted too. Returns rows affected
+// per table.
+func (s *Store) PurgeTranscripts(before time.Time) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	err := s.withRetry("PurgeTranscripts", func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		n, err := execRows(tx, `
+			UPDATE executions SET output = ''
+			WHERE created_at < ? AND COALESCE(output, '') != ''
+			AND status NOT IN ('queued', 'pending', 'running')
+		`, before.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to clear execution output: %w", err)
+		}
+		counts["executions"] = n
+
+		n, err = execRows(tx, `DELETE FROM execution_logs WHERE timestamp < ?`, before.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to purge execution logs: %w", err)
+		}
+		counts["execution_logs"] = n
+
+		n, err = execRows(tx, `DELETE FROM pending_questions WHERE created_at < ? AND status != ?`, before.UTC(), QuestionPending)
+		if err != nil {
+			return fmt.Errorf("failed to purge pending questions: %w", err)
+		}
+		counts["pending_questions"] = n
+		return tx.Commit()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// PurgeUsageEvents deletes the usage events recorded for any of the given
+// user IDs.
+func (s *Store) PurgeUsageEvents(userIDs []string) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	args := make([]interface{}, 0, len(userIDs))
+	for _, id := range userIDs {
+		args = append(args, id)
+	}
+	var n int64
+	err := s.withRetry("PurgeUsageEvents", func() error {
+		var execErr error
+		n, execErr = execRows(s.db, `DELETE FROM usage_events WHERE user_id IN (`+strings.TrimSuffix(strings.Repeat("?,", len(userIDs)), ",")+`)`, args...)
+		return execErr
+	})
+	return n, err
+}
+
+// PruneApprovalAudit deletes approval audit events recorded before before.
+func (s *Store) PruneApprovalAudit(before time.Time) (int64, error) {
+	var n int64
+	err := s.withRetry("PruneApprovalAudit", func() error {
+		var execErr error
+		n, execErr = execRows(s.db, `DELETE FROM approval_audit WHERE created_at < ?`, before.UTC())
+		return execErr
+	})
+	return n, err
+}
+
+// AnonymizeApprovalActors replaces any of actors in the actor of approval
+// audit events with replacement, matching case-insensitively. Quorum
+// decisions list several voters; only the matching names are replaced, so
+// the decision and the other voters stay on record. Returns the number of
+// events changed.
+func (s *Store) AnonymizeApprovalActors(actors []string, replacement string) (int64, error) {
+	match := make(map[string]bool, len(actors))
+	for _, a := range actors {
+		if a = strings.TrimSpace(a); a != "" {
+			match[strings.ToLower(a)] = true
+		}
+	}
+	if len(match) == 0 {
+		return 0, nil
+	}
+
+	var n int64
+	err := s.withRetry("AnonymizeApprovalActors", func() error {
+		n = 0
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		rows, err := tx.Query(`SELECT id, actor FROM approval_audit WHERE actor != ''`)
+		if err != nil {
+			return fmt.Errorf("failed to query approval audit: %w", err)
+		}
+		updates := make(map[int64]string)
+		for rows.Next() {
+			var id int64
+			var actor string
+			if err := rows.Scan(&id, &actor); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			names := strings.Split(actor, ", ")
+			changed := false
+			for i, name := range names {
+				if match[strings.ToLower(strings.TrimSpace(name))] {
+					names[i] = replacement
+					changed = true
+				}
+			}
+			if changed {
+				updates[id] = strings.Join(names, ", ")
+			}
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for id, actor := range updates {
+			if _, err := tx.Exec(`UPDATE approval_audit SET actor = ? WHERE id = ?`, actor, id); err != nil {
+				return fmt.Errorf("failed to anonymize approval audit: %w", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		n = int64(len(updates))
+		return nil
+	})
+	return n, err
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func execRows(db execer, query string, args ...interface{}) (int64, error) {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPurgeExecutions(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, exec := range []*Execution{
+		{ID: "old", TaskID: "GH-1", ProjectPath: "/p", Status: "completed", Output: "transcript"},
+		{ID: "new", TaskID: "GH-2", ProjectPath: "/p", Status: "completed", Output: "transcript"},
+		{ID: "member", TaskID: "GH-3", ProjectPath: "/p", Status: "failed", RequestedBy: "m-1"},
+		{ID: "running", TaskID: "GH-4", ProjectPath: "/p", Status: "running", RequestedBy: "m-1"},
+	} {
+		if err := store.SaveExecution(exec); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+		_ = store.SaveLogEntry(&LogEntry{ExecutionID: exec.ID, Timestamp: time.Now(), Level: "info", Message: "working", Component: "executor"})
+		_ = store.RecordTaskUsage(exec.ID, "m-1", "/p", 1000, 10, 10)
+	}
+	if _, err := store.db.Exec(`UPDATE executions SET created_at = '2020-01-01 00:00:00' WHERE id IN ('old', 'running')`); err != nil {
+		t.Fatal(err)
+	}
+	questions := map[string]*PendingQuestion{}
+	for _, taskID := range []string{"GH-1", "GH-2", "GH-4"} {
+		q := &PendingQuestion{TaskID: taskID, Adapter: "slack", ContextID: "C-" + taskID, Question: "Which bucket?", Description: "Add uploads"}
+		if err := store.SavePendingQuestion(q); err != nil {
+			t.Fatalf("SavePendingQuestion failed: %v", err)
+		}
+		questions[taskID] = q
+	}
+	if _, err := store.ResolvePendingQuestion(questions["GH-2"].ID, QuestionAnswered, "assets-prod"); err != nil {
+		t.Fatalf("ResolvePendingQuestion failed: %v", err)
+	}
+
+	if _, err := store.PurgeExecutions(PurgeFilter{}); err == nil {
+		t.Error("empty filter should be rejected")
+	}
+
+	result, err := store.PurgeExecutions(PurgeFilter{Before: time.Now().AddDate(0, 0, -30)})
+	if err != nil {
+		t.Fatalf("PurgeExecutions failed: %v", err)
+	}
+	if len(result.TaskIDs) != 1 || result.TaskIDs[0] != "GH-1" || result.Rows["executions"] != 1 || result.Rows["execution_logs"] != 1 ||
+		result.Rows["pending_questions"] != 1 {
+		t.Errorf("age purge = %+v, want only the old completed execution", result)
+	}
+
+	result, err = store.PurgeExecutions(PurgeFilter{RequestedBy: []string{"m-1"}})
+	if err != nil {
+		t.Fatalf("PurgeExecutions failed: %v", err)
+	}
+	if len(result.TaskIDs) != 1 || result.TaskIDs[0] != "GH-3" {
+		t.Errorf("member purge removed %v, want GH-3 and the running task kept", result.TaskIDs)
+	}
+	if _, err := store.GetExecution("running"); err != nil {
+		t.Errorf("running execution was purged: %v", err)
+	}
+
+	counts, err := store.PurgeTranscripts(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeTranscripts failed: %v", err)
+	}
+	if counts["executions"] != 1 || counts["execution_logs"] != 2 || counts["pending_questions"] != 1 {
+		t.Errorf("PurgeTranscripts = %v", counts)
+	}
+	if q, _ := store.GetPendingQuestion("slack", "C-GH-4", ""); q == nil {
+		t.Error("unanswered question should survive the transcript purge")
+	}
+	exec, err := store.GetExecution("new")
+	if err != nil || exec.Output != "" {
+		t.Errorf("execution after transcript purge = %+v, %v; want kept without output", exec, err)
+	}
+
+	n, err := store.PurgeUsageEvents([]string{"m-1"})
+	if err != nil || n == 0 {
+		t.Errorf("PurgeUsageEvents = %d, %v", n, err)
+	}
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Pending question statuses
+const (
+	QuestionPending  = "pending"
+	QuestionAnswered = "answered"
+	QuestionExpired  = "expired"
+)
+
+// PendingQuestion is a clarifying question a paused task asked its requester
+// in a chat. It keeps what is needed to resume the task once answered.
+type PendingQuestion struct {
+	ID          int64
+	TaskID      string
+	Adapter     string // Source adapter, e.g. "telegram", "slack"
+	ContextID   string // Chat or channel the question was asked in
+	ThreadID    string // Thread the question was asked in, empty outside threads
+	SenderID    string // Requester who may answer, empty when unknown
+	Question    string
+	SessionID   string // Backend session to resume, empty when the task never started
+	NeedsInfo   bool   // Triage stopped the task before execution
+	Description string // Task description the answer is appended to
+	ProjectPath string
+	CreatePR    bool
+	Status      string
+	Answer      string
+	CreatedAt   time.Time
+	ResolvedAt  *time.Time
+}
+
+// SavePendingQuestion stores a question waiting for an answer and sets its ID
+func (s *Store) SavePendingQuestion(q *PendingQuestion) error {
+	if q.CreatedAt.IsZero() {
+		q.CreatedAt = time.Now()
+	}
+	q.Status = QuestionPending
+	return s.withRetry("SavePendingQuestion", func() error {
+		var err error
+		q.ID, err = s.Dialect().InsertID(s.db, `
+			INSERT INTO pending_questions (task_id, adapter, context_id, thread_id, sender_id, question, session_id,
+				needs_info, description, project_path, create_pr, status, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, q.TaskID, q.Adapter, q.ContextID, q.ThreadID, q.SenderID, q.Question, q.SessionID,
+			q.NeedsInfo, q.Description, q.ProjectPath, q.CreatePR, q.Status, q.CreatedAt.UTC())
+		return err
+	})
+}
+
+// GetPendingQuestion returns the latest unanswered question in a chat, or
+// nil when there is none. A question asked in a thread only matches messages
+// in that thread; messages outside threads match any question in the chat.
+func (s *Store) GetPendingQuestion(adapter, contextID, threadID string) (*PendingQuestion, error) {
+	rows, err := s.queryPendingQuestions(`
+		WHERE status = ? AND adapter = ? AND context_id = ? AND (? = '' OR thread_id = '' OR thread_id = ?)
+		ORDER BY id DESC LIMIT 1
+	`, QuestionPending, adapter, contextID, threadID, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0], nil
+}
+
+// GetPendingQuestionsBefore returns the unanswered questions of an adapter
+// asked before cutoff, oldest first
+func (s *Store) GetPendingQuestionsBefore(adapter string, cutoff time.Time) ([]*PendingQuestion, error) {
+	return s.queryPendingQuestions(`
+		WHERE status = ? AND adapter = ? AND created_at < ?
+		ORDER BY id
+	`, QuestionPending, adapter, cutoff.UTC())
+}
+
+// ResolvePendingQuestion records the answer to a question, or that it
+// expired. It reports false when the question was already resolved, so a
+// task is resumed only once.
+func (s *Store) ResolvePendingQuestion(id int64, status, answer string) (bool, error) {
+	var resolved bool
+	err := s.withRetry("ResolvePendingQuestion", func() error {
+		res, err := s.db.Exec(`
+			UPDATE pending_questions SET status = ?, answer = ?, resolved_at = ?
+			WHERE id = ? AND status = ?
+		`, status, answer, time.Now().UTC(), id, QuestionPending)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		resolved = n > 0
+		return err
+	})
+	return resolved, err
+}
+
+func (s *Store) queryPendingQuestions(where string, args ...interface{}) ([]*PendingQuestion, error) {
+	rows, err := s.db.Query(`
+		SELECT id, task_id, adapter, context_id, thread_id, sender_id, question, session_id, needs_info,
+			description, project_path, create_pr, status, COALESCE(answer, ''), created_at, resolved_at
+		FROM pending_questions
+	`+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending questions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var questions []*PendingQuestion
+	for rows.Next() {
+		var q PendingQuestion
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&q.ID, &q.TaskID, &q.Adapter, &q.ContextID, &q.ThreadID, &q.SenderID, &q.Question, &q.SessionID, &q.NeedsInfo,
+			&q.Description, &q.ProjectPath, &q.CreatePR, &q.Status, &q.Answer, &q.CreatedAt, &resolvedAt); err != nil {
+			return nil, err
+		}
+		if resolvedAt.Valid {
+			q.ResolvedAt = &resolvedAt.Time
+		}
+		questions = append(questions, &q)
+	}
+	if err := rows.Err(); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return questions, nil
+}
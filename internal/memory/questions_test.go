@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPendingQuestions(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	old := &PendingQuestion{
+		TaskID:      "TG-1",
+		Adapter:     "telegram",
+		ContextID:   "42",
+		SenderID:    "1001",
+		Question:    "Which region?",
+		SessionID:   "sess-1",
+		Description: "Deploy the bucket",
+		ProjectPath: "/app",
+		CreatePR:    true,
+		CreatedAt:   time.Now().Add(-2 * time.Hour),
+	}
+	threaded := &PendingQuestion{
+		TaskID:      "SLACK-2",
+		Adapter:     "slack",
+		ContextID:   "C1",
+		ThreadID:    "111.222",
+		Question:    "Which bucket?",
+		NeedsInfo:   true,
+		Description: "Add uploads",
+	}
+	for _, q := range []*PendingQuestion{old, threaded} {
+		if err := store.SavePendingQuestion(q); err != nil {
+			t.Fatalf("SavePendingQuestion failed: %v", err)
+		}
+		if q.ID == 0 || q.Status != QuestionPending {
+			t.Fatalf("question not initialized: %+v", q)
+		}
+	}
+
+	got, err := store.GetPendingQuestion("telegram", "42", "")
+	if err != nil {
+		t.Fatalf("GetPendingQuestion failed: %v", err)
+	}
+	if got == nil || got.TaskID != "TG-1" || got.SenderID != "1001" || got.SessionID != "sess-1" || !got.CreatePR || got.ProjectPath != "/app" {
+		t.Fatalf("unexpected question: %+v", got)
+	}
+
+	// Thread matching
+	if got, _ := store.GetPendingQuestion("slack", "C1", "999.000"); got != nil {
+		t.Errorf("question in another thread matched: %+v", got)
+	}
+	if got, _ := store.GetPendingQuestion("slack", "C1", "111.222"); got == nil || !got.NeedsInfo {
+		t.Errorf("question in its thread not matched: %+v", got)
+	}
+	if got, _ := store.GetPendingQuestion("slack", "C1", ""); got == nil {
+		t.Error("message outside threads should match the chat's question")
+	}
+	if got, _ := store.GetPendingQuestion("slack", "C2", ""); got != nil {
+		t.Errorf("question in another chat matched: %+v", got)
+	}
+
+	expired, err := store.GetPendingQuestionsBefore("telegram", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetPendingQuestionsBefore failed: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != old.ID {
+		t.Errorf("expected the old question, got %+v", expired)
+	}
+
+	ok, err := store.ResolvePendingQuestion(old.ID, QuestionAnswered, "eu-west-1")
+	if err != nil || !ok {
+		t.Fatalf("ResolvePendingQuestion = %v, %v", ok, err)
+	}
+	if ok, _ := store.ResolvePendingQuestion(old.ID, QuestionExpired, ""); ok {
+		t.Error("a resolved question should not resolve again")
+	}
+	if got, _ := store.GetPendingQuestion("telegram", "42", ""); got != nil {
+		t.Errorf("answered question still pending: %+v", got)
+	}
+}
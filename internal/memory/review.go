@@ -0,0 +1,96 @@
+package memory
+
+import "time"
+
+// ReviewFinding is an issue the automated code review flagged on a PR
+type ReviewFinding struct {
+	ID       int64
+	Repo     string // owner/repo
+	PRNumber int
+	Verdict  string // Verdict of the review that raised it: approve, request_changes
+	File     string
+	Line     int // Line in the new version of File, 0 when not tied to a line
+	Severity string
+	Category string
+	Message  string
+	// CreatedAt is set by the store
+	CreatedAt time.Time
+}
+
+// SaveReviewFindings stores the findings of one review
+func (s *Store) SaveReviewFindings(findings []*ReviewFinding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+	return s.withRetry("SaveReviewFindings", func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		for _, f := range findings {
+			if _, err := tx.Exec(`
+				INSERT INTO review_findings (repo, pr_number, verdict, file, line, severity, category, message)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			`, f.Repo, f.PRNumber, f.Verdict, f.File, f.Line, f.Severity, f.Category, f.Message); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// GetReviewFindings returns up to limit most recent findings for a repo,
+// newest first. An empty repo returns findings of all repos.
+func (s *Store) GetReviewFindings(repo string, limit int) ([]*ReviewFinding, error) {
+	rows, err := s.db.Query(`
+		SELECT id, repo, pr_number, verdict, COALESCE(file, ''), COALESCE(line, 0),
+			COALESCE(severity, ''), COALESCE(category, ''), message, created_at
+		FROM review_findings
+		WHERE ? = '' OR repo = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`, repo, repo, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var findings []*ReviewFinding
+	for rows.Next() {
+		var f ReviewFinding
+		if err := rows.Scan(&f.ID, &f.Repo, &f.PRNumber, &f.Verdict, &f.File, &f.Line,
+			&f.Severity, &f.Category, &f.Message, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		findings = append(findings, &f)
+	}
+	return findings, rows.Err()
+}
+
+// GetReviewFindingCounts returns how often each category was flagged in a
+// repo, showing the mistakes the agent keeps making
+func (s *Store) GetReviewFindingCounts(repo string) (map[string]int, error) {
+	rows, err := s.db.Query(`
+		SELECT COALESCE(category, ''), COUNT(*)
+		FROM review_findings
+		WHERE repo = ?
+		GROUP BY category
+	`, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var category string
+		var n int
+		if err := rows.Scan(&category, &n); err != nil {
+			return nil, err
+		}
+		counts[category] = n
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,51 @@
+package memory
+
+import "testing"
+
+func TestReviewFindings(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SaveReviewFindings(nil); err != nil {
+		t.Fatalf("SaveReviewFindings(nil) failed: %v", err)
+	}
+	findings := []*ReviewFinding{
+		{Repo: "org/app", PRNumber: 1, Verdict: "request_changes", File: "db.go", Line: 12, Severity: "high", Category: "sql-injection", Message: "query built from user input"},
+		{Repo: "org/app", PRNumber: 2, Verdict: "approve", File: "api.go", Line: 40, Severity: "low", Category: "error-handling", Message: "error ignored"},
+		{Repo: "org/app", PRNumber: 3, Verdict: "approve", File: "cli.go", Severity: "low", Category: "error-handling", Message: "error ignored"},
+		{Repo: "org/other", PRNumber: 1, Verdict: "approve", Message: "missing test"},
+	}
+	if err := store.SaveReviewFindings(findings); err != nil {
+		t.Fatalf("SaveReviewFindings failed: %v", err)
+	}
+
+	got, err := store.GetReviewFindings("org/app", 2)
+	if err != nil {
+		t.Fatalf("GetReviewFindings failed: %v", err)
+	}
+	if len(got) != 2 || got[0].PRNumber != 3 || got[1].PRNumber != 2 {
+		t.Fatalf("findings = %+v, want PRs 3 and 2 newest first", got)
+	}
+	if got[1].File != "api.go" || got[1].Line != 40 || got[1].Category != "error-handling" || got[1].CreatedAt.IsZero() {
+		t.Errorf("finding = %+v", got[1])
+	}
+
+	all, err := store.GetReviewFindings("", 10)
+	if err != nil {
+		t.Fatalf("GetReviewFindings failed: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("all repos: got %d findings, want 4", len(all))
+	}
+
+	counts, err := store.GetReviewFindingCounts("org/app")
+	if err != nil {
+		t.Fatalf("GetReviewFindingCounts failed: %v", err)
+	}
+	if counts["error-handling"] != 2 || counts["sql-injection"] != 1 || len(counts) != 2 {
+		t.Errorf("counts = %v", counts)
+	}
+}
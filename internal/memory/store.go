@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -14,18 +13,21 @@ import (
 	_ "modernc.org/sqlite"
 )
 
-// Store provides persistent storage for Pilot using SQLite.
+// Store provides persistent storage for Pilot using SQLite or PostgreSQL.
 // It manages executions, patterns, projects, and cross-project learning data.
 // Store handles database migrations automatically on initialization.
 type Store struct {
-	db   *sql.DB
-	path string
+	db     *sql.DB
+	driver string
+	path   string
+	atRest *atRest
 
 	logSubMu      sync.RWMutex
 	logSubscribers map[chan *LogEntry]struct{}
 }
 
-// NewStore creates a new Store instance with a SQLite database at the given path.
+// NewStore creates a new Store instance with a SQLite database at the given path,
+// or on the PostgreSQL server set with InitBackend.
 // It creates the data directory if it does not exist and runs database migrations.
 // Returns an error if the database cannot be opened or migrations fail.
 func NewStore(dataPath string) (*Store, error) {
@@ -34,27 +36,25 @@ func NewStore(dataPath string) (*Store, error) {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	dbPath := filepath.Join(dataPath, "pilot.db")
-	db, err := sql.Open("sqlite", dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	backend := currentBackend()
+	var db *sql.DB
+	var atRest *atRest
+	var err error
+	switch backend.Driver {
+	case DriverPostgres:
+		db, err = openPostgres(backend.DSN)
+	default:
+		db, atRest, err = openSQLite(dataPath)
 	}
-
-	// Enable WAL mode and busy timeout for better concurrency
-	if _, err := db.Exec("PRAGMA journal_mode=WAL; PRAGMA busy_timeout=10000;"); err != nil {
-		return nil, fmt.Errorf("failed to set database pragmas: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
-	// SQLite supports only one writer at a time. Limiting to 1 open connection
-	// serializes all database access, eliminating SQLITE_BUSY contention.
-	// WAL mode still allows the single connection to interleave reads and writes efficiently.
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
-	db.SetConnMaxLifetime(0) // Don't close idle connections
-
 	store := &Store{
 		db:             db,
+		driver:         backend.Driver,
 		path:           dataPath,
+		atRest:         atRest,
 		logSubscribers: make(map[chan *LogEntry]struct{}),
 	}
 
@@ -153,6 +153,15 @@ func (s *Store) migrate() error {
 		`ALTER TABLE executions ADD COLUMN task_base_branch TEXT`,
 		`ALTER TABLE executions ADD COLUMN task_create_pr BOOLEAN DEFAULT FALSE`,
 		`ALTER TABLE executions ADD COLUMN task_verbose BOOLEAN DEFAULT FALSE`,
+		`ALTER TABLE executions ADD COLUMN task_backend TEXT`,
+		`ALTER TABLE executions ADD COLUMN requested_by TEXT`,
+		`ALTER TABLE executions ADD COLUMN session_id TEXT`,
+		`ALTER TABLE executions ADD COLUMN source_adapter TEXT`,
+		`ALTER TABLE executions ADD COLUMN coverage_base REAL`,
+		`ALTER TABLE executions ADD COLUMN coverage_head REAL`,
+		`ALTER TABLE executions ADD COLUMN task_labels TEXT`,
+		`ALTER TABLE executions ADD COLUMN worker_id TEXT`,
+		`ALTER TABLE executions ADD COLUMN lease_expires_at INTEGER`,
 		`CREATE INDEX IF NOT EXISTS idx_executions_status ON executions(status)`,
 		`CREATE INDEX IF NOT EXISTS idx_patterns_project ON patterns(project_path)`,
 		// Cross-project pattern indexes
@@ -295,21 +304,201 @@ func (s *Store) migrate() error {
 		`CREATE INDEX IF NOT EXISTS idx_eval_results_task ON eval_results(task_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_eval_results_model ON eval_results(model)`,
 		`CREATE INDEX IF NOT EXISTS idx_eval_results_created ON eval_results(created_at)`,
+		// Task durations per project and complexity, used for adaptive timeouts
+		`CREATE TABLE IF NOT EXISTS task_durations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_path TEXT NOT NULL,
+			complexity TEXT NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_durations_lookup ON task_durations(project_path, complexity, id)`,
+		// Epics: parent task, sub-issues in execution order and a timeline of
+		// progress events. Epic status is aggregated from its sub-issues.
+		`CREATE TABLE IF NOT EXISTS epics (
+			project_path TEXT NOT NULL,
+			parent_id TEXT NOT NULL,
+			source_repo TEXT,
+			title TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (project_path, parent_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_epics_updated ON epics(updated_at)`,
+		`CREATE TABLE IF NOT EXISTS epic_sub_issues (
+			project_path TEXT NOT NULL,
+			parent_id TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			number INTEGER DEFAULT 0,
+			identifier TEXT,
+			url TEXT,
+			title TEXT,
+			description TEXT,
+			depends_on TEXT,
+			status TEXT NOT NULL,
+			pr_url TEXT,
+			error TEXT,
+			cost_usd REAL DEFAULT 0.0,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (project_path, parent_id, position)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_epic_sub_issues_number ON epic_sub_issues(number)`,
+		`CREATE TABLE IF NOT EXISTS epic_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_path TEXT NOT NULL,
+			parent_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			position INTEGER DEFAULT 0,
+			message TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_epic_events_epic ON epic_events(project_path, parent_id, id)`,
+		// Pre-execution estimates stored with the actuals they predicted, for
+		// planning-accuracy calibration
+		`CREATE TABLE IF NOT EXISTS task_estimates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id TEXT NOT NULL,
+			project_path TEXT NOT NULL,
+			complexity TEXT NOT NULL,
+			effort TEXT,
+			model TEXT,
+			samples INTEGER DEFAULT 0,
+			predicted_duration_ms INTEGER DEFAULT 0,
+			predicted_tokens INTEGER DEFAULT 0,
+			actual_duration_ms INTEGER DEFAULT 0,
+			actual_tokens INTEGER DEFAULT 0,
+			success BOOLEAN DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_estimates_lookup ON task_estimates(project_path, complexity, id)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_estimates_created ON task_estimates(created_at)`,
+		// Pipeline pause state, a single row kept across restarts
+		`CREATE TABLE IF NOT EXISTS pipeline_state (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			paused BOOLEAN NOT NULL DEFAULT 0,
+			reason TEXT,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Leases for primary election between daemons sharing this store
+		`CREATE TABLE IF NOT EXISTS leases (
+			name TEXT PRIMARY KEY,
+			holder TEXT NOT NULL,
+			acquired_at DATETIME NOT NULL,
+			heartbeat_at DATETIME NOT NULL,
+			expires_at INTEGER NOT NULL
+		)`,
+		// Findings of automated PR code reviews, for pattern learning
+		`CREATE TABLE IF NOT EXISTS review_findings (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			repo TEXT NOT NULL,
+			pr_number INTEGER NOT NULL,
+			verdict TEXT NOT NULL,
+			file TEXT,
+			line INTEGER DEFAULT 0,
+			severity TEXT,
+			category TEXT,
+			message TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_review_findings_repo ON review_findings(repo, id)`,
+		// Quality gates that failed nondeterministically, for flaky gate reports
+		`CREATE TABLE IF NOT EXISTS flaky_gates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			execution_id TEXT NOT NULL,
+			project_path TEXT NOT NULL,
+			gate TEXT NOT NULL,
+			fingerprint TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_flaky_gates_created ON flaky_gates(created_at)`,
+		// Where each execution's tokens went, by phase and by tool category
+		`CREATE TABLE IF NOT EXISTS token_attribution (
+			execution_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			input_tokens INTEGER NOT NULL DEFAULT 0,
+			output_tokens INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (execution_id, kind, name)
+		)`,
+		// Clarifying questions paused chat tasks wait on
+		`CREATE TABLE IF NOT EXISTS pending_questions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id TEXT NOT NULL,
+			adapter TEXT NOT NULL,
+			context_id TEXT NOT NULL,
+			thread_id TEXT NOT NULL DEFAULT '',
+			sender_id TEXT NOT NULL DEFAULT '',
+			question TEXT NOT NULL,
+			session_id TEXT NOT NULL DEFAULT '',
+			needs_info BOOLEAN NOT NULL DEFAULT 0,
+			description TEXT NOT NULL,
+			project_path TEXT NOT NULL DEFAULT '',
+			create_pr BOOLEAN NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT 'pending',
+			answer TEXT,
+			created_at DATETIME NOT NULL,
+			resolved_at DATETIME
+		)`,
+		`ALTER TABLE pending_questions ADD COLUMN sender_id TEXT NOT NULL DEFAULT ''`,
+		`CREATE INDEX IF NOT EXISTS idx_pending_questions_context ON pending_questions(adapter, context_id, status)`,
+		// Who was asked to approve what, who answered and the decision
+		`CREATE TABLE IF NOT EXISTS approval_audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			request_id TEXT NOT NULL,
+			task_id TEXT NOT NULL,
+			stage TEXT NOT NULL,
+			channel TEXT NOT NULL DEFAULT '',
+			event TEXT NOT NULL,
+			actor TEXT NOT NULL DEFAULT '',
+			decision TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_approval_audit_task ON approval_audit(task_id)`,
+		// Webhook delivery IDs already processed, kept until they can no
+		// longer be redelivered
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			source TEXT NOT NULL,
+			delivery_id TEXT NOT NULL,
+			expires_at INTEGER NOT NULL,
+			PRIMARY KEY (source, delivery_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_expires ON webhook_deliveries(expires_at)`,
 	}
 
-	for _, migration := range migrations {
-		_, err := s.db.Exec(migration)
-		if err != nil {
-			// Ignore "duplicate column" errors from ALTER TABLE migrations
-			// SQLite returns "duplicate column name" when column already exists
-			errStr := err.Error()
-			if strings.Contains(errStr, "duplicate column") {
-				continue
-			}
-			return fmt.Errorf("migration failed: %w", err)
+	if err := s.Dialect().Migrate(s.db, Schema{SQLite: migrations, Postgres: postgresSchema}); err != nil {
+		return err
+	}
+	if s.driver == DriverPostgres {
+		return nil
+	}
+
+	return s.migrateEpicRuns()
+}
+
+// migrateEpicRuns upgrades SQLite stores from before the epics table replaced
+// epic_runs: epic headers move to epics, sub-issues gain cost_usd and
+// epic_runs is dropped.
+func (s *Store) migrateEpicRuns() error {
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM epic_runs`).Scan(&n); err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return nil
 		}
+		return fmt.Errorf("epic_runs migration failed: %w", err)
 	}
 
+	migrations := []string{
+		`ALTER TABLE epic_sub_issues ADD COLUMN cost_usd REAL DEFAULT 0.0`,
+		`INSERT INTO epics (project_path, parent_id, title, created_at, updated_at)
+			SELECT project_path, parent_id, title, created_at, updated_at FROM epic_runs r
+			WHERE NOT EXISTS (SELECT 1 FROM epics e WHERE e.project_path = r.project_path AND e.parent_id = r.parent_id)`,
+		`DROP TABLE epic_runs`,
+	}
+	for _, migration := range migrations {
+		if _, err := s.db.Exec(migration); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return fmt.Errorf("epic_runs migration failed: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -318,8 +507,17 @@ func (s *Store) DB() *sql.DB {
 	return s.db
 }
 
+// Driver returns the database driver behind the store, DriverSQLite or
+// DriverPostgres
+func (s *Store) Driver() string {
+	return s.driver
+}
+
 // Close closes the database connection and releases resources.
 func (s *Store) Close() error {
+	if s.atRest != nil {
+		return s.atRest.close(s.db)
+	}
 	return s.db.Close()
 }
 
@@ -380,20 +578,36 @@ type Execution struct {
 	TaskBaseBranch  string
 	TaskCreatePR    bool
 	TaskVerbose     bool
+	TaskBackend     string // Per-task backend override, empty = configured backend
+	// RequestedBy is the team member ID of the task requester, empty when unknown.
+	RequestedBy string
+	// SessionID is the backend session a queued task resumes, or the session
+	// to resume once a blocked execution is answered.
+	SessionID string
+	// SourceAdapter is the adapter the task came from (github, telegram, ...),
+	// used to enforce per-adapter budget caps. Empty when unknown.
+	SourceAdapter string
+	// TaskLabels are the issue labels of the task, used to restore queued
+	// tasks and to charge costs back by label.
+	TaskLabels []string
 }
 
 // SaveExecution saves an execution record to the database.
 // The execution ID must be unique; duplicate IDs will cause an error.
 func (s *Store) SaveExecution(exec *Execution) error {
+	labels, err := marshalLabels(exec.TaskLabels)
+	if err != nil {
+		return err
+	}
 	return s.withRetry("SaveExecution", func() error {
 		_, err := s.db.Exec(`
 			INSERT INTO executions (id, task_id, project_path, status, output, error, duration_ms, pr_url, commit_sha, completed_at,
 				tokens_input, tokens_output, tokens_total, estimated_cost_usd, files_changed, lines_added, lines_removed, model_name,
-				task_title, task_description, task_branch, task_base_branch, task_create_pr, task_verbose)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				task_title, task_description, task_branch, task_base_branch, task_create_pr, task_verbose, task_backend, requested_by, session_id, source_adapter, task_labels)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`, exec.ID, exec.TaskID, exec.ProjectPath, exec.Status, exec.Output, exec.Error, exec.DurationMs, exec.PRUrl, exec.CommitSHA, exec.CompletedAt,
 			exec.TokensInput, exec.TokensOutput, exec.TokensTotal, exec.EstimatedCostUSD, exec.FilesChanged, exec.LinesAdded, exec.LinesRemoved, exec.ModelName,
-			exec.TaskTitle, exec.TaskDescription, exec.TaskBranch, exec.TaskBaseBranch, exec.TaskCreatePR, exec.TaskVerbose)
+			exec.TaskTitle, exec.TaskDescription, exec.TaskBranch, exec.TaskBaseBranch, exec.TaskCreatePR, exec.TaskVerbose, exec.TaskBackend, exec.RequestedBy, exec.SessionID, exec.SourceAdapter, labels)
 		return err
 	})
 }
@@ -407,18 +621,22 @@ func (s *Store) GetExecution(id string) (*Execution, error) {
 			COALESCE(estimated_cost_usd, 0), COALESCE(files_changed, 0), COALESCE(lines_added, 0),
 			COALESCE(lines_removed, 0), COALESCE(model_name, ''),
 			COALESCE(task_title, ''), COALESCE(task_description, ''), COALESCE(task_branch, ''),
-			COALESCE(task_base_branch, ''), COALESCE(task_create_pr, 0), COALESCE(task_verbose, 0)
+			COALESCE(task_base_branch, ''), COALESCE(task_create_pr, 0), COALESCE(task_verbose, 0), COALESCE(task_backend, ''),
+			COALESCE(session_id, ''), COALESCE(source_adapter, ''), COALESCE(task_labels, '')
 		FROM executions WHERE id = ?
 	`, id)
 
 	var exec Execution
 	var completedAt sql.NullTime
+	var labels string
 	err := row.Scan(&exec.ID, &exec.TaskID, &exec.ProjectPath, &exec.Status, &exec.Output, &exec.Error, &exec.DurationMs, &exec.PRUrl, &exec.CommitSHA, &exec.CreatedAt, &completedAt,
 		&exec.TokensInput, &exec.TokensOutput, &exec.TokensTotal, &exec.EstimatedCostUSD, &exec.FilesChanged, &exec.LinesAdded, &exec.LinesRemoved, &exec.ModelName,
-		&exec.TaskTitle, &exec.TaskDescription, &exec.TaskBranch, &exec.TaskBaseBranch, &exec.TaskCreatePR, &exec.TaskVerbose)
+		&exec.TaskTitle, &exec.TaskDescription, &exec.TaskBranch, &exec.TaskBaseBranch, &exec.TaskCreatePR, &exec.TaskVerbose, &exec.TaskBackend,
+		&exec.SessionID, &exec.SourceAdapter, &labels)
 	if err != nil {
 		return nil, err
 	}
+	exec.TaskLabels = unmarshalLabels(labels)
 
 	if completedAt.Valid {
 		exec.CompletedAt = &completedAt.Time
@@ -433,7 +651,7 @@ func (s *Store) GetRecentExecutions(limit int) ([]*Execution, error) {
 	rows, err := s.db.Query(`
 		SELECT id, task_id, project_path, status, output, error, duration_ms, pr_url, commit_sha, created_at, completed_at,
 			COALESCE(task_title, ''), COALESCE(task_description, ''), COALESCE(task_branch, ''),
-			COALESCE(task_base_branch, ''), COALESCE(task_create_pr, 0), COALESCE(task_verbose, 0)
+			COALESCE(task_base_branch, ''), COALESCE(task_create_pr, 0), COALESCE(task_verbose, 0), COALESCE(task_backend, '')
 		FROM executions ORDER BY created_at DESC LIMIT ?
 	`, limit)
 	if err != nil {
@@ -446,7 +664,7 @@ func (s *Store) GetRecentExecutions(limit int) ([]*Execution, error) {
 		var exec Execution
 		var completedAt sql.NullTime
 		if err := rows.Scan(&exec.ID, &exec.TaskID, &exec.ProjectPath, &exec.Status, &exec.Output, &exec.Error, &exec.DurationMs, &exec.PRUrl, &exec.CommitSHA, &exec.CreatedAt, &completedAt,
-			&exec.TaskTitle, &exec.TaskDescription, &exec.TaskBranch, &exec.TaskBaseBranch, &exec.TaskCreatePR, &exec.TaskVerbose); err != nil {
+			&exec.TaskTitle, &exec.TaskDescription, &exec.TaskBranch, &exec.TaskBaseBranch, &exec.TaskCreatePR, &exec.TaskVerbose, &exec.TaskBackend); err != nil {
 			return nil, err
 		}
 		if completedAt.Valid {
@@ -477,14 +695,13 @@ type Pattern struct {
 func (s *Store) SavePattern(pattern *Pattern) error {
 	if pattern.ID == 0 {
 		return s.withRetry("SavePattern", func() error {
-			result, err := s.db.Exec(`
+			id, err := s.Dialect().InsertID(s.db, `
 				INSERT INTO patterns (project_path, pattern_type, content, confidence)
 				VALUES (?, ?, ?, ?)
 			`, pattern.ProjectPath, pattern.Type, pattern.Content, pattern.Confidence)
 			if err != nil {
 				return err
 			}
-			id, _ := result.LastInsertId()
 			pattern.ID = id
 			return nil
 		})
@@ -674,6 +891,50 @@ func (s *Store) GetExecutionsInPeriod(query BriefQuery) ([]*Execution, error) {
 	return executions, nil
 }
 
+// GetExecutionsRequestedBy retrieves executions requested by any of the given
+// team member IDs that were created since the given time or are still queued
+// or running. Results are ordered newest first.
+func (s *Store) GetExecutionsRequestedBy(memberIDs []string, since time.Time) ([]*Execution, error) {
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(memberIDs)), ",")
+	args := make([]interface{}, 0, len(memberIDs)+1)
+	for _, id := range memberIDs {
+		args = append(args, id)
+	}
+	args = append(args, since)
+
+	rows, err := s.db.Query(`
+		SELECT id, task_id, project_path, status, output, error, duration_ms, pr_url, commit_sha, created_at, completed_at,
+			COALESCE(task_title, ''), COALESCE(requested_by, '')
+		FROM executions
+		WHERE requested_by IN (`+placeholders+`)
+		AND (created_at >= ? OR status IN ('queued', 'pending', 'running'))
+		ORDER BY created_at DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var executions []*Execution
+	for rows.Next() {
+		var exec Execution
+		var completedAt sql.NullTime
+		if err := rows.Scan(&exec.ID, &exec.TaskID, &exec.ProjectPath, &exec.Status, &exec.Output, &exec.Error, &exec.DurationMs, &exec.PRUrl, &exec.CommitSHA, &exec.CreatedAt, &completedAt,
+			&exec.TaskTitle, &exec.RequestedBy); err != nil {
+			return nil, err
+		}
+		if completedAt.Valid {
+			exec.CompletedAt = &completedAt.Time
+		}
+		executions = append(executions, &exec)
+	}
+
+	return executions, rows.Err()
+}
+
 // GetActiveExecutions retrieves all executions with status "running".
 func (s *Store) GetActiveExecutions() ([]*Execution, error) {
 	rows, err := s.db.Query(`
@@ -730,7 +991,7 @@ func (s *Store) GetBriefMetrics(query BriefQuery) (*BriefMetricsData, error) {
 			COUNT(*) as total,
 			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0) as completed,
 			COALESCE(SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END), 0) as failed,
-			CAST(COALESCE(AVG(CASE WHEN status = 'completed' THEN duration_ms END), 0) AS INTEGER) as avg_duration,
+			CAST(COALESCE(AVG(CASE WHEN status = 'completed' THEN duration_ms END), 0) AS BIGINT) as avg_duration,
 			COALESCE(SUM(CASE WHEN pr_url != '' THEN 1 ELSE 0 END), 0) as prs_created,
 			COALESCE(SUM(tokens_total), 0) as total_tokens,
 			COALESCE(SUM(estimated_cost_usd), 0) as total_cost
@@ -798,7 +1059,8 @@ func (s *Store) GetQueuedTasksForProject(projectPath string, limit int) ([]*Exec
 	rows, err := s.db.Query(`
 		SELECT id, task_id, project_path, status, output, error, duration_ms, pr_url, commit_sha, created_at, completed_at,
 			COALESCE(task_title, ''), COALESCE(task_description, ''), COALESCE(task_branch, ''),
-			COALESCE(task_base_branch, ''), COALESCE(task_create_pr, 0), COALESCE(task_verbose, 0)
+			COALESCE(task_base_branch, ''), COALESCE(task_create_pr, 0), COALESCE(task_verbose, 0), COALESCE(task_backend, ''),
+			COALESCE(requested_by, ''), COALESCE(session_id, ''), COALESCE(source_adapter, ''), COALESCE(task_labels, '')
 		FROM executions
 		WHERE (status = 'queued' OR status = 'pending') AND project_path = ?
 		ORDER BY created_at ASC
@@ -813,10 +1075,13 @@ func (s *Store) GetQueuedTasksForProject(projectPath string, limit int) ([]*Exec
 	for rows.Next() {
 		var exec Execution
 		var completedAt sql.NullTime
+		var labels string
 		if err := rows.Scan(&exec.ID, &exec.TaskID, &exec.ProjectPath, &exec.Status, &exec.Output, &exec.Error, &exec.DurationMs, &exec.PRUrl, &exec.CommitSHA, &exec.CreatedAt, &completedAt,
-			&exec.TaskTitle, &exec.TaskDescription, &exec.TaskBranch, &exec.TaskBaseBranch, &exec.TaskCreatePR, &exec.TaskVerbose); err != nil {
+			&exec.TaskTitle, &exec.TaskDescription, &exec.TaskBranch, &exec.TaskBaseBranch, &exec.TaskCreatePR, &exec.TaskVerbose, &exec.TaskBackend,
+			&exec.RequestedBy, &exec.SessionID, &exec.SourceAdapter, &labels); err != nil {
 			return nil, err
 		}
+		exec.TaskLabels = unmarshalLabels(labels)
 		if completedAt.Valid {
 			exec.CompletedAt = &completedAt.Time
 		}
@@ -835,7 +1100,7 @@ func (s *Store) UpdateExecutionStatus(id, status string, errorMsg ...string) err
 	}
 
 	// Set completed_at for terminal states
-	if status == "completed" || status == "failed" || status == "cancelled" {
+	if status == "completed" || status == "failed" || status == "cancelled" || status == "deferred" {
 		return s.withRetry("UpdateExecutionStatus", func() error {
 			_, err := s.db.Exec(`
 				UPDATE executions
@@ -869,14 +1134,41 @@ func (s *Store) UpdateExecutionResult(id string, prURL, commitSHA string, durati
 	})
 }
 
+// MarkExecutionBlocked records that an execution stopped waiting for its
+// requester, with the reason and the backend session to resume.
+func (s *Store) MarkExecutionBlocked(id, reason, sessionID string) error {
+	return s.withRetry("MarkExecutionBlocked", func() error {
+		_, err := s.db.Exec(`
+			UPDATE executions
+			SET status = 'blocked', error = ?, session_id = ?, completed_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, reason, sessionID, id)
+		return err
+	})
+}
+
+// MarkExecutionNeedsInfo records that triage handed an execution back to its
+// requester for clarification before it ran.
+func (s *Store) MarkExecutionNeedsInfo(id, detail string) error {
+	return s.withRetry("MarkExecutionNeedsInfo", func() error {
+		_, err := s.db.Exec(`
+			UPDATE executions
+			SET status = 'needs_info', error = ?, completed_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, detail, id)
+		return err
+	})
+}
+
 // GetStaleRunningExecutions returns executions that have been in "running" status
 // for longer than the specified duration. Used to detect crashed workers on restart.
+// Executions leased by remote workers are excluded; their leases expire instead.
 func (s *Store) GetStaleRunningExecutions(staleDuration time.Duration) ([]*Execution, error) {
 	staleTime := time.Now().Add(-staleDuration)
 	rows, err := s.db.Query(`
 		SELECT id, task_id, project_path, status, output, error, duration_ms, pr_url, commit_sha, created_at, completed_at
 		FROM executions
-		WHERE status = 'running' AND created_at < ?
+		WHERE status = 'running' AND created_at < ? AND lease_expires_at IS NULL
 		ORDER BY created_at ASC
 	`, staleTime)
 	if err != nil {
@@ -1129,7 +1421,7 @@ func (s *Store) GetProjectsForPattern(patternID string) ([]*PatternProjectLink,
 // confidence score and updates project-level success/failure counts.
 func (s *Store) RecordPatternFeedback(feedback *PatternFeedback) error {
 	err := s.withRetry("RecordPatternFeedback", func() error {
-		result, err := s.db.Exec(`
+		id, err := s.Dialect().InsertID(s.db, `
 			INSERT INTO pattern_feedback (pattern_id, execution_id, project_path, outcome, confidence_delta)
 			VALUES (?, ?, ?, ?, ?)
 		`, feedback.PatternID, feedback.ExecutionID, feedback.ProjectPath, feedback.Outcome, feedback.ConfidenceDelta)
@@ -1137,7 +1429,6 @@ func (s *Store) RecordPatternFeedback(feedback *PatternFeedback) error {
 			return err
 		}
 
-		id, _ := result.LastInsertId()
 		feedback.ID = id
 		return nil
 	})
@@ -1146,11 +1437,12 @@ func (s *Store) RecordPatternFeedback(feedback *PatternFeedback) error {
 	}
 
 	// Update pattern confidence and project link based on outcome
+	d := s.Dialect()
 	switch feedback.Outcome {
 	case "success":
 		_ = s.withRetry("RecordPatternFeedback:updateConfidence", func() error {
 			_, err := s.db.Exec(`
-				UPDATE cross_patterns SET confidence = min(0.95, max(0.1, confidence + ?)) WHERE id = ?
+				UPDATE cross_patterns SET confidence = `+d.Least("0.95", d.Greatest("0.1", "confidence + ?"))+` WHERE id = ?
 			`, feedback.ConfidenceDelta, feedback.PatternID)
 			return err
 		})
@@ -1163,7 +1455,7 @@ func (s *Store) RecordPatternFeedback(feedback *PatternFeedback) error {
 	case "failure":
 		_ = s.withRetry("RecordPatternFeedback:updateConfidence", func() error {
 			_, err := s.db.Exec(`
-				UPDATE cross_patterns SET confidence = max(0.1, min(0.95, confidence - ?)) WHERE id = ?
+				UPDATE cross_patterns SET confidence = `+d.Greatest("0.1", d.Least("0.95", "confidence - ?"))+` WHERE id = ?
 			`, feedback.ConfidenceDelta, feedback.PatternID)
 			return err
 		})
@@ -1182,10 +1474,11 @@ func (s *Store) RecordPatternFeedback(feedback *PatternFeedback) error {
 // Results are ordered by confidence and occurrence count descending, up to the specified limit.
 func (s *Store) SearchCrossPatterns(query string, limit int) ([]*CrossPattern, error) {
 	searchTerm := "%" + query + "%"
+	d := s.Dialect()
 	rows, err := s.db.Query(`
 		SELECT id, pattern_type, title, description, context, examples, confidence, occurrences, is_anti_pattern, scope, created_at, updated_at
 		FROM cross_patterns
-		WHERE title LIKE ? OR description LIKE ? OR context LIKE ?
+		WHERE `+d.ILike("title", "?")+` OR `+d.ILike("description", "?")+` OR `+d.ILike("context", "?")+`
 		ORDER BY confidence DESC, occurrences DESC
 		LIMIT ?
 	`, searchTerm, searchTerm, searchTerm, limit)
@@ -1517,14 +1810,13 @@ type BriefRecord struct {
 // RecordBriefSent records that a brief was sent to a channel.
 func (s *Store) RecordBriefSent(record *BriefRecord) error {
 	return s.withRetry("RecordBriefSent", func() error {
-		result, err := s.db.Exec(`
+		id, err := s.Dialect().InsertID(s.db, `
 			INSERT INTO brief_history (sent_at, channel, brief_type, recipient)
 			VALUES (?, ?, ?, ?)
 		`, record.SentAt, record.Channel, record.BriefType, record.Recipient)
 		if err != nil {
 			return err
 		}
-		id, _ := result.LastInsertId()
 		record.ID = id
 		return nil
 	})
@@ -1543,14 +1835,13 @@ type LogEntry struct {
 // SaveLogEntry persists an execution log entry and notifies all subscribers.
 func (s *Store) SaveLogEntry(entry *LogEntry) error {
 	err := s.withRetry("SaveLogEntry", func() error {
-		result, err := s.db.Exec(`
+		id, err := s.Dialect().InsertID(s.db, `
 			INSERT INTO execution_logs (execution_id, timestamp, level, message, component)
 			VALUES (?, ?, ?, ?, ?)
 		`, entry.ExecutionID, entry.Timestamp, entry.Level, entry.Message, entry.Component)
 		if err != nil {
 			return err
 		}
-		id, _ := result.LastInsertId()
 		entry.ID = id
 		return nil
 	})
@@ -72,6 +72,165 @@ func TestExecutionCRUD(t *testing.T) {
 	}
 }
 
+func TestMarkExecutionBlocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore(tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SaveExecution(&Execution{ID: "exec-1", TaskID: "GH-7", ProjectPath: "/repo", Status: "running"}); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+	if err := store.MarkExecutionBlocked("exec-1", "missing STRIPE_KEY", "sess-1"); err != nil {
+		t.Fatalf("MarkExecutionBlocked failed: %v", err)
+	}
+
+	got, err := store.GetExecution("exec-1")
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if got.Status != "blocked" || got.Error != "missing STRIPE_KEY" || got.SessionID != "sess-1" {
+		t.Errorf("execution = status %q, error %q, session %q", got.Status, got.Error, got.SessionID)
+	}
+	if got.CompletedAt == nil {
+		t.Error("blocked execution should have a completion time")
+	}
+}
+
+func TestGetAdapterSpend(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, exec := range []struct {
+		id, adapter string
+		cost        float64
+	}{
+		{"exec-1", "telegram", 1.25},
+		{"exec-2", "telegram", 2.50},
+		{"exec-3", "github", 10},
+		{"exec-4", "", 4},
+	} {
+		if err := store.SaveExecution(&Execution{ID: exec.id, TaskID: exec.id, ProjectPath: "/repo", Status: "completed", SourceAdapter: exec.adapter}); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+		if err := store.SaveExecutionMetrics(&ExecutionMetrics{ExecutionID: exec.id, EstimatedCostUSD: exec.cost}); err != nil {
+			t.Fatalf("SaveExecutionMetrics failed: %v", err)
+		}
+	}
+
+	got, err := store.GetExecution("exec-1")
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if got.SourceAdapter != "telegram" {
+		t.Errorf("SourceAdapter = %q, want telegram", got.SourceAdapter)
+	}
+
+	now := time.Now()
+	spend, err := store.GetAdapterSpend("telegram", now.Add(-24*time.Hour), now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetAdapterSpend failed: %v", err)
+	}
+	if spend != 3.75 {
+		t.Errorf("telegram spend = %v, want 3.75", spend)
+	}
+
+	spend, err = store.GetAdapterSpend("telegram", now.Add(24*time.Hour), now.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("GetAdapterSpend failed: %v", err)
+	}
+	if spend != 0 {
+		t.Errorf("spend outside period = %v, want 0", spend)
+	}
+}
+
+func TestGetProjectSpend(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, exec := range []struct {
+		id, project string
+		cost        float64
+	}{
+		{"exec-1", "/repos/api", 1.25},
+		{"exec-2", "/repos/api", 2.50},
+		{"exec-3", "/repos/web", 10},
+		{"exec-4", "/repos/docs", 4},
+	} {
+		if err := store.SaveExecution(&Execution{ID: exec.id, TaskID: exec.id, ProjectPath: exec.project, Status: "completed"}); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+		if err := store.SaveExecutionMetrics(&ExecutionMetrics{ExecutionID: exec.id, EstimatedCostUSD: exec.cost}); err != nil {
+			t.Fatalf("SaveExecutionMetrics failed: %v", err)
+		}
+	}
+
+	now := time.Now()
+	spend, err := store.GetProjectSpend([]string{"/repos/api"}, now.Add(-24*time.Hour), now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetProjectSpend failed: %v", err)
+	}
+	if spend != 3.75 {
+		t.Errorf("api spend = %v, want 3.75", spend)
+	}
+
+	spend, err = store.GetProjectSpend([]string{"/repos/api", "/repos/web"}, now.Add(-24*time.Hour), now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetProjectSpend failed: %v", err)
+	}
+	if spend != 13.75 {
+		t.Errorf("api+web spend = %v, want 13.75", spend)
+	}
+
+	spend, err = store.GetProjectSpend(nil, now.Add(-24*time.Hour), now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetProjectSpend failed: %v", err)
+	}
+	if spend != 0 {
+		t.Errorf("spend of no projects = %v, want 0", spend)
+	}
+}
+
+func TestSaveCoverageDelta(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, id := range []string{"exec-1", "exec-2", "exec-3"} {
+		if err := store.SaveExecution(&Execution{ID: id, TaskID: id, ProjectPath: "/repo", Status: "completed"}); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+	}
+	if err := store.SaveCoverageDelta("exec-1", 80, 82); err != nil {
+		t.Fatalf("SaveCoverageDelta failed: %v", err)
+	}
+	if err := store.SaveCoverageDelta("exec-2", 70, 69); err != nil {
+		t.Fatalf("SaveCoverageDelta failed: %v", err)
+	}
+
+	now := time.Now()
+	summary, err := store.GetMetricsSummary(MetricsQuery{Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("GetMetricsSummary failed: %v", err)
+	}
+	if summary.TotalExecutions != 3 || summary.CoverageMeasured != 2 {
+		t.Errorf("executions = %d, coverage measured = %d; want 3, 2", summary.TotalExecutions, summary.CoverageMeasured)
+	}
+	if summary.AvgCoverageDelta != 0.5 {
+		t.Errorf("AvgCoverageDelta = %v, want 0.5", summary.AvgCoverageDelta)
+	}
+}
+
 func TestGetRecentExecutions(t *testing.T) {
 	tmpDir, _ := os.MkdirTemp("", "pilot-test-*")
 	defer func() { _ = os.RemoveAll(tmpDir) }()
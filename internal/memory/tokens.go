@@ -0,0 +1,91 @@
+package memory
+
+// Token attribution kinds
+const (
+	TokenKindPhase = "phase"
+	TokenKindTool  = "tool"
+)
+
+// TokenAttribution is the tokens spent on one phase or tool category
+type TokenAttribution struct {
+	Kind         string // TokenKindPhase or TokenKindTool
+	Name         string // Phase or tool category
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// TotalTokens returns input plus output tokens
+func (t *TokenAttribution) TotalTokens() int64 {
+	return t.InputTokens + t.OutputTokens
+}
+
+// SaveTokenAttribution records where an execution's tokens went, replacing
+// any earlier breakdown of the execution
+func (s *Store) SaveTokenAttribution(executionID string, rows []*TokenAttribution) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return s.withRetry("SaveTokenAttribution", func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if _, err := tx.Exec(`DELETE FROM token_attribution WHERE execution_id = ?`, executionID); err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if _, err := tx.Exec(`
+				INSERT INTO token_attribution (execution_id, kind, name, input_tokens, output_tokens)
+				VALUES (?, ?, ?, ?, ?)
+			`, executionID, r.Kind, r.Name, r.InputTokens, r.OutputTokens); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// GetTokenAttribution returns the tokens of the executions created in the
+// query period, summed by phase and tool category, most tokens first within
+// each kind. If query.Projects is non-empty, results are filtered to those
+// projects only.
+func (s *Store) GetTokenAttribution(query MetricsQuery) ([]*TokenAttribution, error) {
+	args := []interface{}{query.Start, query.End}
+	projectFilter := ""
+	if len(query.Projects) > 0 {
+		placeholders := ""
+		for i, p := range query.Projects {
+			if i > 0 {
+				placeholders += ","
+			}
+			placeholders += "?"
+			args = append(args, p)
+		}
+		projectFilter = " AND e.project_path IN (" + placeholders + ")"
+	}
+
+	rows, err := s.db.Query(`
+		SELECT t.kind, t.name, SUM(t.input_tokens), SUM(t.output_tokens)
+		FROM token_attribution t
+		JOIN executions e ON e.id = t.execution_id
+		WHERE e.created_at >= ? AND e.created_at < ?`+projectFilter+`
+		GROUP BY t.kind, t.name
+		ORDER BY t.kind, SUM(t.input_tokens) + SUM(t.output_tokens) DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*TokenAttribution
+	for rows.Next() {
+		var t TokenAttribution
+		if err := rows.Scan(&t.Kind, &t.Name, &t.InputTokens, &t.OutputTokens); err != nil {
+			return nil, err
+		}
+		result = append(result, &t)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenAttribution(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SaveTokenAttribution("exec-1", nil); err != nil {
+		t.Fatalf("SaveTokenAttribution(nil) failed: %v", err)
+	}
+	for _, exec := range []struct{ id, project string }{{"exec-1", "/app"}, {"exec-2", "/app"}, {"exec-3", "/other"}} {
+		if err := store.SaveExecution(&Execution{ID: exec.id, TaskID: exec.id, ProjectPath: exec.project, Status: "completed"}); err != nil {
+			t.Fatalf("SaveExecution failed: %v", err)
+		}
+	}
+
+	save := func(id string, rows ...*TokenAttribution) {
+		t.Helper()
+		if err := store.SaveTokenAttribution(id, rows); err != nil {
+			t.Fatalf("SaveTokenAttribution failed: %v", err)
+		}
+	}
+	save("exec-1",
+		&TokenAttribution{Kind: TokenKindPhase, Name: "implement", InputTokens: 900, OutputTokens: 100},
+		&TokenAttribution{Kind: TokenKindTool, Name: "edit", InputTokens: 500, OutputTokens: 50},
+	)
+	// A second save replaces the execution's breakdown
+	save("exec-1",
+		&TokenAttribution{Kind: TokenKindPhase, Name: "implement", InputTokens: 1000, OutputTokens: 200},
+		&TokenAttribution{Kind: TokenKindPhase, Name: "verify", InputTokens: 300, OutputTokens: 20},
+		&TokenAttribution{Kind: TokenKindTool, Name: "edit", InputTokens: 800, OutputTokens: 150},
+		&TokenAttribution{Kind: TokenKindTool, Name: "shell", InputTokens: 500, OutputTokens: 70},
+	)
+	save("exec-2", &TokenAttribution{Kind: TokenKindPhase, Name: "verify", InputTokens: 2000, OutputTokens: 100})
+	save("exec-3", &TokenAttribution{Kind: TokenKindPhase, Name: "review", InputTokens: 50, OutputTokens: 5})
+
+	now := time.Now()
+	got, err := store.GetTokenAttribution(MetricsQuery{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Projects: []string{"/app"}})
+	if err != nil {
+		t.Fatalf("GetTokenAttribution failed: %v", err)
+	}
+	want := []TokenAttribution{
+		{Kind: TokenKindPhase, Name: "verify", InputTokens: 2300, OutputTokens: 120},
+		{Kind: TokenKindPhase, Name: "implement", InputTokens: 1000, OutputTokens: 200},
+		{Kind: TokenKindTool, Name: "edit", InputTokens: 800, OutputTokens: 150},
+		{Kind: TokenKindTool, Name: "shell", InputTokens: 500, OutputTokens: 70},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+	if got[0].TotalTokens() != 2420 {
+		t.Errorf("TotalTokens() = %d, want 2420", got[0].TotalTokens())
+	}
+
+	got, err = store.GetTokenAttribution(MetricsQuery{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)})
+	if err != nil {
+		t.Fatalf("GetTokenAttribution failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("rows outside period = %+v, want none", got)
+	}
+}
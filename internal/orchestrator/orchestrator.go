@@ -17,6 +17,7 @@ import (
 	"github.com/alekspetrov/pilot/internal/adapters/slack"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/quality"
 )
 
 // Config holds orchestrator configuration
@@ -35,6 +36,7 @@ type Orchestrator struct {
 
 	taskQueue             chan *Task
 	running               map[string]bool
+	cancelled             map[string]bool
 	progressCallback      func(taskID, phase string, progress int, message string)
 	completionCallback    func(taskID, prURL string, success bool, errMsg string)
 	qualityCheckerFactory executor.QualityCheckerFactory
@@ -71,6 +73,7 @@ func NewOrchestrator(config *Config, notifier *slack.Notifier) (*Orchestrator, e
 		notifier:  notifier,
 		taskQueue: make(chan *Task, 100),
 		running:   make(map[string]bool),
+		cancelled: make(map[string]bool),
 		ctx:       ctx,
 		cancel:    cancel,
 	}
@@ -176,12 +179,19 @@ func (o *Orchestrator) processTask(task *Task) {
 		o.mu.Unlock()
 		return
 	}
+	if o.cancelled[task.ID] {
+		delete(o.cancelled, task.ID)
+		o.mu.Unlock()
+		logging.WithTask(task.ID).Info("Skipping cancelled task")
+		return
+	}
 	o.running[task.ID] = true
 	o.mu.Unlock()
 
 	defer func() {
 		o.mu.Lock()
 		delete(o.running, task.ID)
+		delete(o.cancelled, task.ID)
 		o.mu.Unlock()
 	}()
 
@@ -193,17 +203,27 @@ func (o *Orchestrator) processTask(task *Task) {
 		_ = o.notifier.TaskStarted(o.ctx, task.ID, task.Document.Title)
 	}
 
-	// Execute task
+	// Execute task. Only Linear tasks carry a ticket; others set Priority directly.
+	priority := int(task.Priority)
+	if task.Ticket != nil {
+		priority = task.Ticket.Priority
+	}
 	execTask := &executor.Task{
 		ID:          task.ID,
 		Title:       task.Document.Title,
 		Description: task.Document.Markdown,
-		Priority:    task.Ticket.Priority,
+		Priority:    priority,
 		ProjectPath: task.ProjectPath,
 		Branch:      task.Branch,
 	}
 
 	result, err := o.runner.Execute(o.ctx, execTask)
+	if o.isCancelled(task.ID) {
+		logging.WithTask(task.ID).Info("Task cancelled")
+		o.monitor.Cancel(task.ID)
+		o.fireCompletion(task.ID, "", false, "cancelled")
+		return
+	}
 	if err != nil {
 		logging.WithTask(task.ID).Error("Task execution error", slog.Any("error", err))
 		o.monitor.Fail(task.ID, err.Error())
@@ -235,6 +255,44 @@ func (o *Orchestrator) processTask(task *Task) {
 	o.fireCompletion(task.ID, result.PRUrl, true, "")
 }
 
+// CancelTask cancels a pending or running task. Pending tasks are skipped when
+// a worker dequeues them; running tasks have their executor process killed.
+func (o *Orchestrator) CancelTask(taskID string) error {
+	state, ok := o.monitor.Get(taskID)
+	if !ok {
+		return fmt.Errorf("task %s not found", taskID)
+	}
+
+	switch state.Status {
+	case executor.StatusPending, executor.StatusQueued:
+		o.mu.Lock()
+		o.cancelled[taskID] = true
+		o.mu.Unlock()
+		o.monitor.Cancel(taskID)
+		return nil
+	case executor.StatusRunning:
+		o.mu.Lock()
+		o.cancelled[taskID] = true
+		o.mu.Unlock()
+		if err := o.runner.Cancel(taskID); err != nil {
+			o.mu.Lock()
+			delete(o.cancelled, taskID)
+			o.mu.Unlock()
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("task %s is already %s", taskID, state.Status)
+	}
+}
+
+// isCancelled reports whether CancelTask was called for a task.
+func (o *Orchestrator) isCancelled(taskID string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.cancelled[taskID]
+}
+
 // handleProgress handles progress updates from the executor
 func (o *Orchestrator) handleProgress(taskID, phase string, progress int, message string) {
 	o.monitor.UpdateProgress(taskID, phase, progress, message)
@@ -316,6 +374,12 @@ func (o *Orchestrator) SuppressProgressLogs(suppress bool) {
 	o.runner.SuppressProgressLogs(suppress)
 }
 
+// SetLeakGuard sets the guard that scans diffs for credentials before the
+// runner pushes them.
+func (o *Orchestrator) SetLeakGuard(g *quality.LeakGuard) {
+	o.runner.SetLeakGuard(g)
+}
+
 // SetQualityCheckerFactory sets the factory for creating quality checkers.
 // The factory is called for each task to create a task-specific quality checker.
 // Quality gates run after task execution to validate code quality before PR creation.
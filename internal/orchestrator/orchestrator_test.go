@@ -1,9 +1,11 @@
 package orchestrator
 
 import (
+	"context"
 	"testing"
 
 	"github.com/alekspetrov/pilot/internal/adapters/linear"
+	"github.com/alekspetrov/pilot/internal/executor"
 )
 
 func TestExtractLabelNames(t *testing.T) {
@@ -682,3 +684,41 @@ func TestExtractLabelNamesLargeInput(t *testing.T) {
 		t.Errorf("Last label mismatch")
 	}
 }
+
+func TestCancelTask(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	o := &Orchestrator{
+		runner:    executor.NewRunner(),
+		monitor:   executor.NewMonitor(),
+		running:   make(map[string]bool),
+		cancelled: make(map[string]bool),
+		ctx:       ctx,
+	}
+
+	if err := o.CancelTask("missing"); err == nil {
+		t.Error("expected error for unknown task")
+	}
+
+	task := &Task{ID: "API-1", Document: &TaskDocument{ID: "API-1", Title: "Queued"}}
+	o.monitor.Register(task.ID, task.Document.Title, "")
+	if err := o.CancelTask(task.ID); err != nil {
+		t.Fatalf("CancelTask(pending) error = %v", err)
+	}
+	if state, _ := o.monitor.Get(task.ID); state.Status != executor.StatusCancelled {
+		t.Errorf("status = %s, want cancelled", state.Status)
+	}
+
+	// A cancelled task is skipped when dequeued instead of being executed
+	o.processTask(task)
+	if state, _ := o.monitor.Get(task.ID); state.Status != executor.StatusCancelled {
+		t.Errorf("status after dequeue = %s, want cancelled", state.Status)
+	}
+	if o.isCancelled(task.ID) {
+		t.Error("cancel marker should be cleared once the task is skipped")
+	}
+
+	if err := o.CancelTask(task.ID); err == nil {
+		t.Error("expected error cancelling an already cancelled task")
+	}
+}
@@ -0,0 +1,516 @@
+package pilot
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/orchestrator"
+)
+
+// Management API limits for /api/v1/executions and /api/v1/approvals/audit
+const (
+	apiDefaultExecutionLimit = 50
+	apiMaxExecutionLimit     = 500
+)
+
+// apiTask is the JSON representation of an orchestrator task.
+type apiTask struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	Phase       string     `json:"phase,omitempty"`
+	Progress    int        `json:"progress"`
+	Message     string     `json:"message,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	PRURL       string     `json:"prUrl,omitempty"`
+	IssueURL    string     `json:"issueUrl,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// apiCreateTaskRequest is the body of POST /api/v1/tasks.
+type apiCreateTaskRequest struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Project     string `json:"project"` // Project name or path; defaults to the first project
+	Branch      string `json:"branch"`
+}
+
+// apiExecution is the JSON representation of a stored execution.
+type apiExecution struct {
+	ID           string     `json:"id"`
+	TaskID       string     `json:"taskId"`
+	ProjectPath  string     `json:"projectPath"`
+	Status       string     `json:"status"`
+	Title        string     `json:"title,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	PRURL        string     `json:"prUrl,omitempty"`
+	Backend      string     `json:"backend,omitempty"`
+	Model        string     `json:"model,omitempty"`
+	DurationMs   int64      `json:"durationMs"`
+	TokensTotal  int64      `json:"tokensTotal"`
+	CostUSD      float64    `json:"costUsd"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+	FilesChanged int        `json:"filesChanged"`
+}
+
+// apiApprovalEvent is the JSON representation of an approval audit entry.
+type apiApprovalEvent struct {
+	RequestID string    `json:"requestId"`
+	TaskID    string    `json:"taskId"`
+	Stage     string    `json:"stage"`
+	Channel   string    `json:"channel,omitempty"`
+	Event     string    `json:"event"`
+	Actor     string    `json:"actor,omitempty"`
+	Decision  string    `json:"decision,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// registerAPI mounts the management REST API on the gateway's authenticated
+// /api/v1/ routes, replacing the gateway's placeholder task list.
+func (p *Pilot) registerAPI() {
+	p.gateway.RegisterAPIHandler("/api/v1/tasks", http.HandlerFunc(p.handleAPITasks))
+	p.gateway.RegisterAPIHandler("/api/v1/tasks/", http.HandlerFunc(p.handleAPITask))
+	p.gateway.RegisterAPIHandler("/api/v1/executions", http.HandlerFunc(p.handleAPIExecutions))
+	p.gateway.RegisterAPIHandler("/api/v1/executions/", http.HandlerFunc(p.handleAPIExecution))
+	p.gateway.RegisterAPIHandler("/api/v1/autopilot/prs", http.HandlerFunc(p.handleAPIAutopilotPRs))
+	p.gateway.RegisterAPIHandler("/api/v1/budget", http.HandlerFunc(p.handleAPIBudget))
+	p.gateway.RegisterAPIHandler("/api/v1/approvals/audit", http.HandlerFunc(p.handleAPIApprovalAudit))
+}
+
+// handleAPITasks lists orchestrator tasks (GET) or queues a new task (POST).
+func (p *Pilot) handleAPITasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		status := r.URL.Query().Get("status")
+		states := p.orchestrator.GetTaskStates()
+		tasks := make([]apiTask, 0, len(states))
+		for _, state := range states {
+			if status != "" && string(state.Status) != status {
+				continue
+			}
+			tasks = append(tasks, toAPITask(state))
+		}
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+		writeAPIJSON(w, http.StatusOK, map[string]interface{}{"tasks": tasks})
+	case http.MethodPost:
+		p.createAPITask(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Errors returned by queueAPITask, mapped to HTTP and gRPC status codes by callers.
+var (
+	errInvalidTask = errors.New("invalid task")
+	errTaskExists  = errors.New("task already exists")
+)
+
+// createAPITask queues a task described by the request body on the orchestrator.
+func (p *Pilot) createAPITask(w http.ResponseWriter, r *http.Request) {
+	var req apiCreateTaskRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	task, err := p.queueAPITask(req)
+	switch {
+	case errors.Is(err, errTaskExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		writeAPIJSON(w, http.StatusAccepted, task)
+	}
+}
+
+// queueAPITask validates req, fills in defaults and queues the task on the
+// orchestrator. It backs both the REST and gRPC task submission endpoints.
+func (p *Pilot) queueAPITask(req apiCreateTaskRequest) (apiTask, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return apiTask{}, fmt.Errorf("%w: title is required", errInvalidTask)
+	}
+
+	projectPath, err := p.apiProjectPath(req.Project)
+	if err != nil {
+		return apiTask{}, fmt.Errorf("%w: %v", errInvalidTask, err)
+	}
+
+	if req.ID == "" {
+		req.ID = fmt.Sprintf("API-%d", time.Now().UnixMilli())
+	}
+	if _, ok := p.taskState(req.ID); ok {
+		return apiTask{}, fmt.Errorf("%w: %s", errTaskExists, req.ID)
+	}
+	if req.Branch == "" {
+		req.Branch = "pilot/" + req.ID
+	}
+
+	description := req.Description
+	if description == "" {
+		description = req.Title
+	}
+	p.orchestrator.QueueTask(&orchestrator.Task{
+		ID:          req.ID,
+		Document:    &orchestrator.TaskDocument{ID: req.ID, Title: req.Title, Markdown: description},
+		ProjectPath: projectPath,
+		Branch:      req.Branch,
+	})
+	logging.WithComponent("api").Info("Task queued via API",
+		slog.String("task_id", req.ID),
+		slog.String("project", projectPath))
+
+	if state, ok := p.taskState(req.ID); ok {
+		return toAPITask(state), nil
+	}
+	return apiTask{ID: req.ID, Title: req.Title, Status: string(executor.StatusPending)}, nil
+}
+
+// apiProjectPath resolves a project name or path to a configured project path.
+// Arbitrary paths are rejected so the API cannot run tasks outside known projects.
+func (p *Pilot) apiProjectPath(project string) (string, error) {
+	if project == "" {
+		if path := p.defaultProjectPath(); path != "" {
+			return path, nil
+		}
+		return "", fmt.Errorf("no projects configured")
+	}
+	if proj := p.config.GetProjectByName(project); proj != nil {
+		return proj.Path, nil
+	}
+	if proj := p.config.GetProject(project); proj != nil {
+		return proj.Path, nil
+	}
+	return "", fmt.Errorf("unknown project %q", project)
+}
+
+// handleAPITask returns (GET) or cancels (DELETE) a single task. DELETE also
+// reaches tasks running outside the orchestrator, e.g. from the issue poller.
+func (p *Pilot) handleAPITask(w http.ResponseWriter, r *http.Request) {
+	taskID := strings.TrimPrefix(r.URL.Path, "/api/v1/tasks/")
+	if taskID == "" || strings.Contains(taskID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		state, ok := p.taskState(taskID)
+		if !ok {
+			http.Error(w, "task not found", http.StatusNotFound)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, toAPITask(state))
+	case http.MethodDelete:
+		if _, ok := p.taskState(taskID); !ok {
+			// Issue and chat tasks run on Pilot's own runners, not the orchestrator
+			if !p.cancelRunningTask(taskID) {
+				http.Error(w, "task not found", http.StatusNotFound)
+				return
+			}
+			logging.WithComponent("api").Info("Task cancelled via API", slog.String("task_id", taskID))
+			writeAPIJSON(w, http.StatusAccepted, map[string]string{"id": taskID, "status": "cancelling"})
+			return
+		}
+		if err := p.orchestrator.CancelTask(taskID); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeAPIJSON(w, http.StatusAccepted, map[string]string{"id": taskID, "status": "cancelling"})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAPIExecutions lists recent executions, optionally filtered by ?status=.
+// The filter applies to the most recent ?limit= executions.
+func (p *Pilot) handleAPIExecutions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := apiDefaultExecutionLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, apiMaxExecutionLimit)
+	}
+	status := r.URL.Query().Get("status")
+
+	execs, err := p.store.GetRecentExecutions(limit)
+	if err != nil {
+		http.Error(w, "failed to fetch executions", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]apiExecution, 0, len(execs))
+	for _, exec := range execs {
+		if status != "" && exec.Status != status {
+			continue
+		}
+		result = append(result, toAPIExecution(exec))
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{"executions": result})
+}
+
+// handleAPIExecution serves GET /api/v1/executions/{id} and
+// POST /api/v1/executions/{id}/cancel.
+func (p *Pilot) handleAPIExecution(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/v1/executions/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		exec, err := p.store.GetExecution(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "execution not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to fetch execution", http.StatusInternalServerError)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, toAPIExecution(exec))
+	case action == "cancel" && r.Method == http.MethodPost:
+		p.cancelAPIExecution(w, id)
+	case action == "" || action == "cancel":
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// cancelAPIExecution cancels a queued execution by marking it cancelled, or a
+// running one by killing its process on whichever runner owns it.
+func (p *Pilot) cancelAPIExecution(w http.ResponseWriter, id string) {
+	exec, err := p.store.GetExecution(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to fetch execution", http.StatusInternalServerError)
+		return
+	}
+
+	switch exec.Status {
+	case "queued", "pending":
+		if err := p.store.UpdateExecutionStatus(id, "cancelled", "cancelled via API"); err != nil {
+			http.Error(w, "failed to cancel execution", http.StatusInternalServerError)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, map[string]string{"id": id, "status": "cancelled"})
+	case "running":
+		if !p.cancelRunningTask(exec.TaskID) {
+			http.Error(w, fmt.Sprintf("execution %s is not running in this process", id), http.StatusConflict)
+			return
+		}
+		logging.WithComponent("api").Info("Execution cancelled via API",
+			slog.String("execution_id", id),
+			slog.String("task_id", exec.TaskID))
+		writeAPIJSON(w, http.StatusAccepted, map[string]string{"id": id, "status": "cancelling"})
+	default:
+		http.Error(w, fmt.Sprintf("execution %s is already %s", id, exec.Status), http.StatusConflict)
+	}
+}
+
+// cancelRunningTask kills taskID on the orchestrator or any runner Pilot knows about.
+func (p *Pilot) cancelRunningTask(taskID string) bool {
+	if state, ok := p.taskState(taskID); ok && state.Status == executor.StatusRunning {
+		if p.orchestrator.CancelTask(taskID) == nil {
+			return true
+		}
+	}
+	for _, runner := range []*executor.Runner{p.executionRunner, p.telegramRunner, p.slackRunner} {
+		if runner != nil && runner.Cancel(taskID) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// handleAPIAutopilotPRs lists PRs tracked by autopilot, optionally filtered by ?stage=.
+func (p *Pilot) handleAPIAutopilotPRs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	prs := make([]map[string]interface{}, 0)
+	if p.autopilotProvider == nil {
+		writeAPIJSON(w, http.StatusOK, map[string]interface{}{"enabled": false, "prs": prs})
+		return
+	}
+
+	stage := r.URL.Query().Get("stage")
+	for _, pr := range p.autopilotProvider.GetActivePRs() {
+		if stage != "" && pr.Stage != stage {
+			continue
+		}
+		prs = append(prs, map[string]interface{}{
+			"number":        pr.PRNumber,
+			"url":           pr.PRURL,
+			"repo":          pr.Repo,
+			"stage":         pr.Stage,
+			"ciStatus":      pr.CIStatus,
+			"error":         pr.Error,
+			"branchName":    pr.BranchName,
+			"queuePosition": pr.QueuePosition,
+		})
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i]["number"].(int) < prs[j]["number"].(int) })
+
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{
+		"enabled":     true,
+		"environment": p.autopilotProvider.GetEnvironment(),
+		"prs":         prs,
+	})
+}
+
+// handleAPIBudget returns current spend against the configured budget limits.
+func (p *Pilot) handleAPIBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if p.budgetEnforcer == nil {
+		writeAPIJSON(w, http.StatusOK, map[string]interface{}{"enabled": false})
+		return
+	}
+
+	status, err := p.budgetEnforcer.GetStatus(r.Context(), "", "")
+	if err != nil {
+		http.Error(w, "failed to fetch budget status", http.StatusInternalServerError)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{
+		"enabled":        true,
+		"dailySpent":     status.DailySpent,
+		"dailyLimit":     status.DailyLimit,
+		"dailyPercent":   status.DailyPercent,
+		"monthlySpent":   status.MonthlySpent,
+		"monthlyLimit":   status.MonthlyLimit,
+		"monthlyPercent": status.MonthlyPercent,
+		"paused":         status.IsPaused,
+		"pauseReason":    status.PauseReason,
+		"blockedTasks":   status.BlockedTasks,
+		"updatedAt":      status.LastUpdated,
+	})
+}
+
+// handleAPIApprovalAudit lists who was asked to approve what, who answered
+// and the decisions, optionally for one task.
+func (p *Pilot) handleAPIApprovalAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := apiDefaultExecutionLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, apiMaxExecutionLimit)
+	}
+
+	result := []apiApprovalEvent{}
+	if p.approvalMgr != nil {
+		trail, err := p.approvalMgr.AuditTrail(r.URL.Query().Get("task"), limit)
+		if err != nil {
+			http.Error(w, "failed to fetch approval audit", http.StatusInternalServerError)
+			return
+		}
+		for _, e := range trail {
+			result = append(result, apiApprovalEvent{
+				RequestID: e.RequestID,
+				TaskID:    e.TaskID,
+				Stage:     string(e.Stage),
+				Channel:   e.Channel,
+				Event:     string(e.Event),
+				Actor:     e.Actor,
+				Decision:  string(e.Decision),
+				At:        e.At,
+			})
+		}
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{"events": result})
+}
+
+// taskState looks up an orchestrator task by ID.
+func (p *Pilot) taskState(taskID string) (*executor.TaskState, bool) {
+	for _, state := range p.orchestrator.GetTaskStates() {
+		if state.ID == taskID {
+			return state, true
+		}
+	}
+	return nil, false
+}
+
+func toAPITask(state *executor.TaskState) apiTask {
+	return apiTask{
+		ID:          state.ID,
+		Title:       state.Title,
+		Status:      string(state.Status),
+		Phase:       state.Phase,
+		Progress:    state.Progress,
+		Message:     state.Message,
+		Error:       state.Error,
+		PRURL:       state.PRUrl,
+		IssueURL:    state.IssueURL,
+		StartedAt:   state.StartedAt,
+		CompletedAt: state.CompletedAt,
+	}
+}
+
+func toAPIExecution(exec *memory.Execution) apiExecution {
+	return apiExecution{
+		ID:           exec.ID,
+		TaskID:       exec.TaskID,
+		ProjectPath:  exec.ProjectPath,
+		Status:       exec.Status,
+		Title:        exec.TaskTitle,
+		Error:        exec.Error,
+		PRURL:        exec.PRUrl,
+		Backend:      exec.TaskBackend,
+		Model:        exec.ModelName,
+		DurationMs:   exec.DurationMs,
+		TokensTotal:  exec.TokensTotal,
+		CostUSD:      exec.EstimatedCostUSD,
+		CreatedAt:    exec.CreatedAt,
+		CompletedAt:  exec.CompletedAt,
+		FilesChanged: exec.FilesChanged,
+	}
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,236 @@
+package pilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/config"
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/orchestrator"
+)
+
+type stubAutopilotProvider struct {
+	prs []*gateway.AutopilotPRState
+}
+
+func (s *stubAutopilotProvider) GetEnvironment() string                    { return "stage" }
+func (s *stubAutopilotProvider) GetActivePRs() []*gateway.AutopilotPRState { return s.prs }
+func (s *stubAutopilotProvider) GetFailureCount() int                      { return 0 }
+func (s *stubAutopilotProvider) IsAutoReleaseEnabled() bool                { return false }
+
+// newTestAPIPilot builds a Pilot with an orchestrator that is never started,
+// so queued tasks stay pending.
+func newTestAPIPilot(t *testing.T) *Pilot {
+	t.Helper()
+
+	orch, err := orchestrator.NewOrchestrator(&orchestrator.Config{}, nil)
+	if err != nil {
+		t.Skipf("orchestrator unavailable: %v", err)
+	}
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	return &Pilot{
+		ctx: context.Background(),
+		config: &config.Config{Projects: []*config.ProjectConfig{
+			{Name: "api", Path: "/src/api"},
+			{Name: "web", Path: "/src/web"},
+		}},
+		orchestrator: orch,
+		store:        store,
+	}
+}
+
+func serveAPI(handler http.HandlerFunc, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+func TestAPITasks(t *testing.T) {
+	p := newTestAPIPilot(t)
+
+	w := serveAPI(p.handleAPITasks, http.MethodPost, "/api/v1/tasks", `{"id":"API-1","title":"Add caching","project":"web"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("POST expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var created apiTask
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "API-1" || created.Status != "pending" {
+		t.Errorf("created = %+v, want pending API-1", created)
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"duplicate id", `{"id":"API-1","title":"Again"}`, http.StatusConflict},
+		{"missing title", `{"description":"no title"}`, http.StatusBadRequest},
+		{"unknown project", `{"title":"x","project":"/etc"}`, http.StatusBadRequest},
+		{"invalid json", `{`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if w := serveAPI(p.handleAPITasks, http.MethodPost, "/api/v1/tasks", tt.body); w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+
+	w = serveAPI(p.handleAPITasks, http.MethodGet, "/api/v1/tasks?status=pending", "")
+	var list struct {
+		Tasks []apiTask `json:"tasks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Tasks) != 1 || list.Tasks[0].Title != "Add caching" {
+		t.Errorf("tasks = %+v, want the queued task", list.Tasks)
+	}
+
+	if w := serveAPI(p.handleAPITask, http.MethodDelete, "/api/v1/tasks/API-1", ""); w.Code != http.StatusAccepted {
+		t.Fatalf("DELETE expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	w = serveAPI(p.handleAPITask, http.MethodGet, "/api/v1/tasks/API-1", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Status != "cancelled" {
+		t.Errorf("status after cancel = %s, want cancelled", created.Status)
+	}
+	if w := serveAPI(p.handleAPITask, http.MethodGet, "/api/v1/tasks/API-404", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown task, got %d", w.Code)
+	}
+}
+
+func TestAPIExecutions(t *testing.T) {
+	p := newTestAPIPilot(t)
+	now := time.Now()
+	for _, exec := range []*memory.Execution{
+		{ID: "exec-queued", TaskID: "GH-2", ProjectPath: "/src/api", Status: "queued", CreatedAt: now},
+		{ID: "exec-done", TaskID: "GH-1", ProjectPath: "/src/api", Status: "completed", PRUrl: "https://github.com/org/api/pull/1", CreatedAt: now.Add(-time.Hour)},
+		{ID: "exec-running", TaskID: "GH-3", ProjectPath: "/src/api", Status: "running", CreatedAt: now.Add(-time.Minute)},
+	} {
+		if err := p.store.SaveExecution(exec); err != nil {
+			t.Fatalf("SaveExecution: %v", err)
+		}
+	}
+
+	w := serveAPI(p.handleAPIExecutions, http.MethodGet, "/api/v1/executions?status=completed", "")
+	var list struct {
+		Executions []apiExecution `json:"executions"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Executions) != 1 || list.Executions[0].PRURL == "" {
+		t.Errorf("executions = %+v, want only exec-done", list.Executions)
+	}
+	if w := serveAPI(p.handleAPIExecutions, http.MethodGet, "/api/v1/executions?limit=abc", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid limit, got %d", w.Code)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{"get", http.MethodGet, "/api/v1/executions/exec-done", http.StatusOK},
+		{"get missing", http.MethodGet, "/api/v1/executions/nope", http.StatusNotFound},
+		{"cancel queued", http.MethodPost, "/api/v1/executions/exec-queued/cancel", http.StatusOK},
+		{"cancel finished", http.MethodPost, "/api/v1/executions/exec-done/cancel", http.StatusConflict},
+		{"cancel untracked running", http.MethodPost, "/api/v1/executions/exec-running/cancel", http.StatusConflict},
+		{"cancel via GET", http.MethodGet, "/api/v1/executions/exec-done/cancel", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		if w := serveAPI(p.handleAPIExecution, tt.method, tt.target, ""); w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	exec, err := p.store.GetExecution("exec-queued")
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if exec.Status != "cancelled" {
+		t.Errorf("queued execution status = %s, want cancelled", exec.Status)
+	}
+}
+
+func TestAPIAutopilotPRsAndBudget(t *testing.T) {
+	p := newTestAPIPilot(t)
+
+	w := serveAPI(p.handleAPIAutopilotPRs, http.MethodGet, "/api/v1/autopilot/prs", "")
+	if !strings.Contains(w.Body.String(), `"enabled":false`) {
+		t.Errorf("expected disabled autopilot, got %s", w.Body.String())
+	}
+
+	p.autopilotProvider = &stubAutopilotProvider{prs: []*gateway.AutopilotPRState{
+		{PRNumber: 9, Stage: "waiting_ci", Repo: "org/api"},
+		{PRNumber: 4, Stage: "merged", Repo: "org/api"},
+	}}
+	w = serveAPI(p.handleAPIAutopilotPRs, http.MethodGet, "/api/v1/autopilot/prs?stage=waiting_ci", "")
+	var resp struct {
+		Enabled bool `json:"enabled"`
+		PRs     []struct {
+			Number int    `json:"number"`
+			Repo   string `json:"repo"`
+		} `json:"prs"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Enabled || len(resp.PRs) != 1 || resp.PRs[0].Number != 9 || resp.PRs[0].Repo != "org/api" {
+		t.Errorf("response = %+v, want PR 9 only", resp)
+	}
+
+	w = serveAPI(p.handleAPIBudget, http.MethodGet, "/api/v1/budget", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"enabled":false`) {
+		t.Errorf("expected disabled budget, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPIApprovalAudit(t *testing.T) {
+	p := newTestAPIPilot(t)
+	p.approvalMgr = approval.NewManager(nil)
+	p.approvalMgr.SetAuditStore(p.store)
+	for _, e := range []*memory.ApprovalAudit{
+		{RequestID: "r1", TaskID: "GH-1", Stage: "pre_merge", Channel: "slack", Event: "requested"},
+		{RequestID: "r1", TaskID: "GH-1", Stage: "pre_merge", Channel: "slack", Event: "decided", Actor: "alice", Decision: "approved"},
+		{RequestID: "r2", TaskID: "GH-2", Stage: "pre_execution", Event: "timed_out", Actor: "system", Decision: "rejected"},
+	} {
+		if err := p.store.SaveApprovalAudit(e); err != nil {
+			t.Fatalf("SaveApprovalAudit: %v", err)
+		}
+	}
+
+	w := serveAPI(p.handleAPIApprovalAudit, http.MethodGet, "/api/v1/approvals/audit?task=GH-1", "")
+	var list struct {
+		Events []apiApprovalEvent `json:"events"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Events) != 2 || list.Events[1].Actor != "alice" || list.Events[1].Decision != "approved" {
+		t.Errorf("events = %+v, want GH-1 request and approval by alice", list.Events)
+	}
+	if w := serveAPI(p.handleAPIApprovalAudit, http.MethodGet, "/api/v1/approvals/audit?limit=0", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid limit, got %d", w.Code)
+	}
+	if w := serveAPI(p.handleAPIApprovalAudit, http.MethodPost, "/api/v1/approvals/audit", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
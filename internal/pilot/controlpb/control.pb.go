@@ -0,0 +1,721 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: internal/pilot/controlpb/control.proto
+
+package controlpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubmitTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID; generated when empty.
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Task description in markdown; defaults to the title.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Project name or path; defaults to the first configured project.
+	Project string `protobuf:"bytes,4,opt,name=project,proto3" json:"project,omitempty"`
+	// Branch to work on; defaults to pilot/<id>.
+	Branch        string `protobuf:"bytes,5,opt,name=branch,proto3" json:"branch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTaskRequest) Reset() {
+	*x = SubmitTaskRequest{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTaskRequest) ProtoMessage() {}
+
+func (x *SubmitTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTaskRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTaskRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SubmitTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SubmitTaskRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *SubmitTaskRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+type Task struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// One of pending, queued, running, completed, failed, cancelled.
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Phase         string                 `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	Progress      int32                  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	PrUrl         string                 `protobuf:"bytes,8,opt,name=pr_url,json=prUrl,proto3" json:"pr_url,omitempty"`
+	IssueUrl      string                 `protobuf:"bytes,9,opt,name=issue_url,json=issueUrl,proto3" json:"issue_url,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Task) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Task) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Task) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Task) GetPrUrl() string {
+	if x != nil {
+		return x.PrUrl
+	}
+	return ""
+}
+
+func (x *Task) GetIssueUrl() string {
+	if x != nil {
+		return x.IssueUrl
+	}
+	return ""
+}
+
+func (x *Task) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type StreamProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProgressRequest) Reset() {
+	*x = StreamProgressRequest{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgressRequest) ProtoMessage() {}
+
+func (x *StreamProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgressRequest.ProtoReflect.Descriptor instead.
+func (*StreamProgressRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamProgressRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ProgressEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Snapshot of the task when the event was sent.
+	Task *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// True on the last event, once the task reached a terminal status.
+	Done          bool `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressEvent) Reset() {
+	*x = ProgressEvent{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressEvent) ProtoMessage() {}
+
+func (x *ProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressEvent.ProtoReflect.Descriptor instead.
+func (*ProgressEvent) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProgressEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ProgressEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ProgressEvent) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type CancelTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type CancelTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskResponse) Reset() {
+	*x = CancelTaskResponse{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskResponse) ProtoMessage() {}
+
+func (x *CancelTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskResponse.ProtoReflect.Descriptor instead.
+func (*CancelTaskResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *CancelTaskResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetBudgetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBudgetStatusRequest) Reset() {
+	*x = GetBudgetStatusRequest{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBudgetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBudgetStatusRequest) ProtoMessage() {}
+
+func (x *GetBudgetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBudgetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBudgetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{6}
+}
+
+type BudgetStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when no budget enforcer is configured; other fields are unset.
+	Enabled        bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	DailySpent     float64                `protobuf:"fixed64,2,opt,name=daily_spent,json=dailySpent,proto3" json:"daily_spent,omitempty"`
+	DailyLimit     float64                `protobuf:"fixed64,3,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	DailyPercent   float64                `protobuf:"fixed64,4,opt,name=daily_percent,json=dailyPercent,proto3" json:"daily_percent,omitempty"`
+	MonthlySpent   float64                `protobuf:"fixed64,5,opt,name=monthly_spent,json=monthlySpent,proto3" json:"monthly_spent,omitempty"`
+	MonthlyLimit   float64                `protobuf:"fixed64,6,opt,name=monthly_limit,json=monthlyLimit,proto3" json:"monthly_limit,omitempty"`
+	MonthlyPercent float64                `protobuf:"fixed64,7,opt,name=monthly_percent,json=monthlyPercent,proto3" json:"monthly_percent,omitempty"`
+	Paused         bool                   `protobuf:"varint,8,opt,name=paused,proto3" json:"paused,omitempty"`
+	PauseReason    string                 `protobuf:"bytes,9,opt,name=pause_reason,json=pauseReason,proto3" json:"pause_reason,omitempty"`
+	BlockedTasks   int32                  `protobuf:"varint,10,opt,name=blocked_tasks,json=blockedTasks,proto3" json:"blocked_tasks,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BudgetStatus) Reset() {
+	*x = BudgetStatus{}
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetStatus) ProtoMessage() {}
+
+func (x *BudgetStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pilot_controlpb_control_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetStatus.ProtoReflect.Descriptor instead.
+func (*BudgetStatus) Descriptor() ([]byte, []int) {
+	return file_internal_pilot_controlpb_control_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BudgetStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *BudgetStatus) GetDailySpent() float64 {
+	if x != nil {
+		return x.DailySpent
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetDailyLimit() float64 {
+	if x != nil {
+		return x.DailyLimit
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetDailyPercent() float64 {
+	if x != nil {
+		return x.DailyPercent
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetMonthlySpent() float64 {
+	if x != nil {
+		return x.MonthlySpent
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetMonthlyLimit() float64 {
+	if x != nil {
+		return x.MonthlyLimit
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetMonthlyPercent() float64 {
+	if x != nil {
+		return x.MonthlyPercent
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *BudgetStatus) GetPauseReason() string {
+	if x != nil {
+		return x.PauseReason
+	}
+	return ""
+}
+
+func (x *BudgetStatus) GetBlockedTasks() int32 {
+	if x != nil {
+		return x.BlockedTasks
+	}
+	return 0
+}
+
+func (x *BudgetStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_internal_pilot_controlpb_control_proto protoreflect.FileDescriptor
+
+const file_internal_pilot_controlpb_control_proto_rawDesc = "" +
+	"\n" +
+	"&internal/pilot/controlpb/control.proto\x12\bpilot.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x01\n" +
+	"\x11SubmitTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aproject\x18\x04 \x01(\tR\aproject\x12\x16\n" +
+	"\x06branch\x18\x05 \x01(\tR\x06branch\"\xd4\x02\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x12\x1a\n" +
+	"\bprogress\x18\x05 \x01(\x05R\bprogress\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x15\n" +
+	"\x06pr_url\x18\b \x01(\tR\x05prUrl\x12\x1b\n" +
+	"\tissue_url\x18\t \x01(\tR\bissueUrl\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"0\n" +
+	"\x15StreamProgressRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"w\n" +
+	"\rProgressEvent\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.pilot.v1.TaskR\x04task\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\",\n" +
+	"\x11CancelTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"E\n" +
+	"\x12CancelTaskResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x18\n" +
+	"\x16GetBudgetStatusRequest\"\x9d\x03\n" +
+	"\fBudgetStatus\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1f\n" +
+	"\vdaily_spent\x18\x02 \x01(\x01R\n" +
+	"dailySpent\x12\x1f\n" +
+	"\vdaily_limit\x18\x03 \x01(\x01R\n" +
+	"dailyLimit\x12#\n" +
+	"\rdaily_percent\x18\x04 \x01(\x01R\fdailyPercent\x12#\n" +
+	"\rmonthly_spent\x18\x05 \x01(\x01R\fmonthlySpent\x12#\n" +
+	"\rmonthly_limit\x18\x06 \x01(\x01R\fmonthlyLimit\x12'\n" +
+	"\x0fmonthly_percent\x18\a \x01(\x01R\x0emonthlyPercent\x12\x16\n" +
+	"\x06paused\x18\b \x01(\bR\x06paused\x12!\n" +
+	"\fpause_reason\x18\t \x01(\tR\vpauseReason\x12#\n" +
+	"\rblocked_tasks\x18\n" +
+	" \x01(\x05R\fblockedTasks\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xad\x02\n" +
+	"\fControlPlane\x129\n" +
+	"\n" +
+	"SubmitTask\x12\x1b.pilot.v1.SubmitTaskRequest\x1a\x0e.pilot.v1.Task\x12L\n" +
+	"\x0eStreamProgress\x12\x1f.pilot.v1.StreamProgressRequest\x1a\x17.pilot.v1.ProgressEvent0\x01\x12G\n" +
+	"\n" +
+	"CancelTask\x12\x1b.pilot.v1.CancelTaskRequest\x1a\x1c.pilot.v1.CancelTaskResponse\x12K\n" +
+	"\x0fGetBudgetStatus\x12 .pilot.v1.GetBudgetStatusRequest\x1a\x16.pilot.v1.BudgetStatusB7Z5github.com/alekspetrov/pilot/internal/pilot/controlpbb\x06proto3"
+
+var (
+	file_internal_pilot_controlpb_control_proto_rawDescOnce sync.Once
+	file_internal_pilot_controlpb_control_proto_rawDescData []byte
+)
+
+func file_internal_pilot_controlpb_control_proto_rawDescGZIP() []byte {
+	file_internal_pilot_controlpb_control_proto_rawDescOnce.Do(func() {
+		file_internal_pilot_controlpb_control_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_pilot_controlpb_control_proto_rawDesc), len(file_internal_pilot_controlpb_control_proto_rawDesc)))
+	})
+	return file_internal_pilot_controlpb_control_proto_rawDescData
+}
+
+var file_internal_pilot_controlpb_control_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_internal_pilot_controlpb_control_proto_goTypes = []any{
+	(*SubmitTaskRequest)(nil),      // 0: pilot.v1.SubmitTaskRequest
+	(*Task)(nil),                   // 1: pilot.v1.Task
+	(*StreamProgressRequest)(nil),  // 2: pilot.v1.StreamProgressRequest
+	(*ProgressEvent)(nil),          // 3: pilot.v1.ProgressEvent
+	(*CancelTaskRequest)(nil),      // 4: pilot.v1.CancelTaskRequest
+	(*CancelTaskResponse)(nil),     // 5: pilot.v1.CancelTaskResponse
+	(*GetBudgetStatusRequest)(nil), // 6: pilot.v1.GetBudgetStatusRequest
+	(*BudgetStatus)(nil),           // 7: pilot.v1.BudgetStatus
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+}
+var file_internal_pilot_controlpb_control_proto_depIdxs = []int32{
+	8, // 0: pilot.v1.Task.started_at:type_name -> google.protobuf.Timestamp
+	8, // 1: pilot.v1.Task.completed_at:type_name -> google.protobuf.Timestamp
+	1, // 2: pilot.v1.ProgressEvent.task:type_name -> pilot.v1.Task
+	8, // 3: pilot.v1.ProgressEvent.time:type_name -> google.protobuf.Timestamp
+	8, // 4: pilot.v1.BudgetStatus.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 5: pilot.v1.ControlPlane.SubmitTask:input_type -> pilot.v1.SubmitTaskRequest
+	2, // 6: pilot.v1.ControlPlane.StreamProgress:input_type -> pilot.v1.StreamProgressRequest
+	4, // 7: pilot.v1.ControlPlane.CancelTask:input_type -> pilot.v1.CancelTaskRequest
+	6, // 8: pilot.v1.ControlPlane.GetBudgetStatus:input_type -> pilot.v1.GetBudgetStatusRequest
+	1, // 9: pilot.v1.ControlPlane.SubmitTask:output_type -> pilot.v1.Task
+	3, // 10: pilot.v1.ControlPlane.StreamProgress:output_type -> pilot.v1.ProgressEvent
+	5, // 11: pilot.v1.ControlPlane.CancelTask:output_type -> pilot.v1.CancelTaskResponse
+	7, // 12: pilot.v1.ControlPlane.GetBudgetStatus:output_type -> pilot.v1.BudgetStatus
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_internal_pilot_controlpb_control_proto_init() }
+func file_internal_pilot_controlpb_control_proto_init() {
+	if File_internal_pilot_controlpb_control_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_pilot_controlpb_control_proto_rawDesc), len(file_internal_pilot_controlpb_control_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_pilot_controlpb_control_proto_goTypes,
+		DependencyIndexes: file_internal_pilot_controlpb_control_proto_depIdxs,
+		MessageInfos:      file_internal_pilot_controlpb_control_proto_msgTypes,
+	}.Build()
+	File_internal_pilot_controlpb_control_proto = out.File
+	file_internal_pilot_controlpb_control_proto_goTypes = nil
+	file_internal_pilot_controlpb_control_proto_depIdxs = nil
+}
@@ -0,0 +1,33 @@
+package pilot
+
+import (
+	"github.com/alekspetrov/pilot/internal/gateway"
+)
+
+// registerEvents forwards progress and token usage from the orchestrator and
+// the execution runner to the gateway's /events stream, and exposes the
+// runner's execution slots on /metrics.
+func (p *Pilot) registerEvents() {
+	p.orchestrator.OnToken("events", p.publishTokens)
+	if p.executionRunner != nil {
+		p.executionRunner.AddProgressCallback("events", p.publishProgress)
+		p.executionRunner.AddTokenCallback("events", p.publishTokens)
+		p.gateway.SetConcurrencySource(p.executionRunner)
+	}
+}
+
+func (p *Pilot) publishProgress(taskID, phase string, progress int, message string) {
+	p.gateway.PublishEvent(gateway.Event{
+		Type:   gateway.EventProgress,
+		TaskID: taskID,
+		Data:   gateway.ProgressEventData{Phase: phase, Progress: progress, Message: message},
+	})
+}
+
+func (p *Pilot) publishTokens(taskID string, inputTokens, outputTokens int64) {
+	p.gateway.PublishEvent(gateway.Event{
+		Type:   gateway.EventTokens,
+		TaskID: taskID,
+		Data:   gateway.TokenEventData{InputTokens: inputTokens, OutputTokens: outputTokens},
+	})
+}
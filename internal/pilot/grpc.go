@@ -0,0 +1,231 @@
+package pilot
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/logging"
+	"github.com/alekspetrov/pilot/internal/pilot/controlpb"
+)
+
+// grpcProgressInterval is how often StreamProgress polls task state for changes.
+const grpcProgressInterval = 500 * time.Millisecond
+
+// controlServer implements the gRPC ControlPlane service on top of the same
+// orchestrator and budget state as the REST management API.
+type controlServer struct {
+	controlpb.UnimplementedControlPlaneServer
+	pilot *Pilot
+}
+
+// newGRPCServer builds the control-plane gRPC server. Every call must carry
+// "authorization: Bearer <token>" metadata matching the gateway's api-token.
+// Without api-token auth configured all calls are refused, matching the REST
+// management API.
+func (p *Pilot) newGRPCServer() *grpc.Server {
+	var token string
+	if p.config.Auth != nil && p.config.Auth.Type == gateway.AuthTypeAPIToken {
+		token = p.config.Auth.Token
+	}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := checkGRPCToken(ctx, token); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := checkGRPCToken(ss.Context(), token); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+
+	srv := grpc.NewServer(opts...)
+	controlpb.RegisterControlPlaneServer(srv, &controlServer{pilot: p})
+	return srv
+}
+
+// startGRPC listens on gateway.host:gateway.grpc_port and serves the
+// control-plane API until Stop is called.
+func (p *Pilot) startGRPC() error {
+	addr := net.JoinHostPort(p.config.Gateway.Host, fmt.Sprintf("%d", p.config.Gateway.GRPCPort))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC on %s: %w", addr, err)
+	}
+
+	if p.config.Auth == nil || p.config.Auth.Type != gateway.AuthTypeAPIToken || p.config.Auth.Token == "" {
+		logging.WithComponent("grpc").Warn("gRPC control plane has no API token configured; all calls will be refused")
+	}
+	p.grpcServer = p.newGRPCServer()
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if err := p.grpcServer.Serve(lis); err != nil {
+			logging.WithComponent("grpc").Error("gRPC server error", slog.Any("error", err))
+		}
+	}()
+	logging.WithComponent("grpc").Info("gRPC control plane started", slog.String("addr", addr))
+	return nil
+}
+
+func checkGRPCToken(ctx context.Context, token string) error {
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "configure auth.type api-token with a token to use the gRPC API")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		const prefix = "Bearer "
+		if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) &&
+			subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid authorization token")
+}
+
+// SubmitTask queues a task on the orchestrator.
+func (s *controlServer) SubmitTask(_ context.Context, req *controlpb.SubmitTaskRequest) (*controlpb.Task, error) {
+	task, err := s.pilot.queueAPITask(apiCreateTaskRequest{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Project:     req.GetProject(),
+		Branch:      req.GetBranch(),
+	})
+	switch {
+	case errors.Is(err, errTaskExists):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toProtoTask(task), nil
+}
+
+// StreamProgress sends the task's current state, then an event on every
+// change until the task completes, fails or is cancelled.
+func (s *controlServer) StreamProgress(req *controlpb.StreamProgressRequest, stream grpc.ServerStreamingServer[controlpb.ProgressEvent]) error {
+	if req.GetTaskId() == "" {
+		return status.Error(codes.InvalidArgument, "task_id is required")
+	}
+
+	ticker := time.NewTicker(grpcProgressInterval)
+	defer ticker.Stop()
+
+	var last apiTask
+	sent := false
+	for {
+		state, ok := s.pilot.taskState(req.GetTaskId())
+		if !ok {
+			return status.Errorf(codes.NotFound, "task %s not found", req.GetTaskId())
+		}
+
+		task := toAPITask(state)
+		done := isTerminalStatus(state.Status)
+		if !sent || progressChanged(last, task) || done {
+			event := &controlpb.ProgressEvent{
+				Task: toProtoTask(task),
+				Time: timestamppb.Now(),
+				Done: done,
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			last, sent = task, true
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-s.pilot.ctx.Done():
+			return status.Error(codes.Unavailable, "pilot is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+// CancelTask cancels a queued or running orchestrator task.
+func (s *controlServer) CancelTask(_ context.Context, req *controlpb.CancelTaskRequest) (*controlpb.CancelTaskResponse, error) {
+	taskID := req.GetTaskId()
+	if _, ok := s.pilot.taskState(taskID); !ok {
+		return nil, status.Errorf(codes.NotFound, "task %s not found", taskID)
+	}
+	if err := s.pilot.orchestrator.CancelTask(taskID); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &controlpb.CancelTaskResponse{TaskId: taskID, Status: "cancelling"}, nil
+}
+
+// GetBudgetStatus returns current spend against the configured budget limits.
+func (s *controlServer) GetBudgetStatus(ctx context.Context, _ *controlpb.GetBudgetStatusRequest) (*controlpb.BudgetStatus, error) {
+	if s.pilot.budgetEnforcer == nil {
+		return &controlpb.BudgetStatus{Enabled: false}, nil
+	}
+
+	st, err := s.pilot.budgetEnforcer.GetStatus(ctx, "", "")
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to fetch budget status")
+	}
+	return &controlpb.BudgetStatus{
+		Enabled:        true,
+		DailySpent:     st.DailySpent,
+		DailyLimit:     st.DailyLimit,
+		DailyPercent:   st.DailyPercent,
+		MonthlySpent:   st.MonthlySpent,
+		MonthlyLimit:   st.MonthlyLimit,
+		MonthlyPercent: st.MonthlyPercent,
+		Paused:         st.IsPaused,
+		PauseReason:    st.PauseReason,
+		BlockedTasks:   int32(st.BlockedTasks),
+		UpdatedAt:      timestamppb.New(st.LastUpdated),
+	}, nil
+}
+
+func isTerminalStatus(s executor.TaskStatus) bool {
+	return s == executor.StatusCompleted || s == executor.StatusFailed || s == executor.StatusCancelled || s == executor.StatusBlocked
+}
+
+func progressChanged(a, b apiTask) bool {
+	return a.Status != b.Status || a.Phase != b.Phase || a.Progress != b.Progress ||
+		a.Message != b.Message || a.Error != b.Error || a.PRURL != b.PRURL
+}
+
+func toProtoTask(t apiTask) *controlpb.Task {
+	task := &controlpb.Task{
+		Id:       t.ID,
+		Title:    t.Title,
+		Status:   t.Status,
+		Phase:    t.Phase,
+		Progress: int32(t.Progress),
+		Message:  t.Message,
+		Error:    t.Error,
+		PrUrl:    t.PRURL,
+		IssueUrl: t.IssueURL,
+	}
+	if t.StartedAt != nil {
+		task.StartedAt = timestamppb.New(*t.StartedAt)
+	}
+	if t.CompletedAt != nil {
+		task.CompletedAt = timestamppb.New(*t.CompletedAt)
+	}
+	return task
+}
@@ -0,0 +1,173 @@
+package pilot
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/alekspetrov/pilot/internal/gateway"
+	"github.com/alekspetrov/pilot/internal/pilot/controlpb"
+)
+
+// newTestControlClient serves p's control plane over an in-memory listener.
+func newTestControlClient(t *testing.T, p *Pilot) controlpb.ControlPlaneClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := p.newGRPCServer()
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return controlpb.NewControlPlaneClient(conn)
+}
+
+func TestGRPCSubmitStreamCancel(t *testing.T) {
+	p := newTestAPIPilot(t)
+	p.config.Auth = &gateway.AuthConfig{Type: gateway.AuthTypeAPIToken, Token: "secret"}
+	client := newTestControlClient(t, p)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+
+	task, err := client.SubmitTask(ctx, &controlpb.SubmitTaskRequest{Id: "RPC-1", Title: "Add caching", Project: "api"})
+	if err != nil {
+		t.Fatalf("SubmitTask: %v", err)
+	}
+	if task.GetId() != "RPC-1" || task.GetStatus() != "pending" {
+		t.Errorf("task = %v, want pending RPC-1", task)
+	}
+
+	tests := []struct {
+		name string
+		req  *controlpb.SubmitTaskRequest
+		want codes.Code
+	}{
+		{"duplicate id", &controlpb.SubmitTaskRequest{Id: "RPC-1", Title: "Again"}, codes.AlreadyExists},
+		{"missing title", &controlpb.SubmitTaskRequest{Description: "no title"}, codes.InvalidArgument},
+		{"unknown project", &controlpb.SubmitTaskRequest{Title: "x", Project: "/etc"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := client.SubmitTask(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	stream, err := client.StreamProgress(ctx, &controlpb.StreamProgressRequest{TaskId: "RPC-1"})
+	if err != nil {
+		t.Fatalf("StreamProgress: %v", err)
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if event.GetDone() || event.GetTask().GetStatus() != "pending" {
+		t.Errorf("first event = %v, want pending and not done", event)
+	}
+
+	resp, err := client.CancelTask(ctx, &controlpb.CancelTaskRequest{TaskId: "RPC-1"})
+	if err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	if resp.GetStatus() != "cancelling" {
+		t.Errorf("cancel status = %s, want cancelling", resp.GetStatus())
+	}
+
+	for !event.GetDone() {
+		if event, err = stream.Recv(); err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+	}
+	if event.GetTask().GetStatus() != "cancelled" || event.GetTask().GetCompletedAt() == nil {
+		t.Errorf("last event = %v, want cancelled with completion time", event)
+	}
+
+	if _, err := client.CancelTask(ctx, &controlpb.CancelTaskRequest{TaskId: "RPC-404"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown task, got %v", err)
+	}
+	missing, err := client.StreamProgress(ctx, &controlpb.StreamProgressRequest{TaskId: "RPC-404"})
+	if err != nil {
+		t.Fatalf("StreamProgress: %v", err)
+	}
+	if _, err := missing.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound stream for unknown task, got %v", err)
+	}
+}
+
+func TestGRPCBudgetAndAuth(t *testing.T) {
+	p := newTestAPIPilot(t)
+	p.config.Auth = &gateway.AuthConfig{Type: gateway.AuthTypeAPIToken, Token: "secret"}
+	client := newTestControlClient(t, p)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := client.GetBudgetStatus(ctx, &controlpb.GetBudgetStatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without token, got %v", err)
+	}
+	wrong := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer nope")
+	if _, err := client.GetBudgetStatus(wrong, &controlpb.GetBudgetStatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated with wrong token, got %v", err)
+	}
+
+	authed := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	budget, err := client.GetBudgetStatus(authed, &controlpb.GetBudgetStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetBudgetStatus: %v", err)
+	}
+	if budget.GetEnabled() {
+		t.Errorf("budget = %v, want disabled without enforcer", budget)
+	}
+
+	stream, err := client.StreamProgress(ctx, &controlpb.StreamProgressRequest{TaskId: "RPC-1"})
+	if err != nil {
+		t.Fatalf("StreamProgress: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated stream without token, got %v", err)
+	}
+}
+
+func TestGRPCRefusesWithoutToken(t *testing.T) {
+	tests := []struct {
+		name string
+		auth *gateway.AuthConfig
+	}{
+		{"no auth", nil},
+		{"claude-code auth", &gateway.AuthConfig{Type: gateway.AuthTypeClaudeCode}},
+		{"api-token without token", &gateway.AuthConfig{Type: gateway.AuthTypeAPIToken}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestAPIPilot(t)
+			p.config.Auth = tt.auth
+			client := newTestControlClient(t, p)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer anything")
+
+			if _, err := client.SubmitTask(ctx, &controlpb.SubmitTaskRequest{Id: "RPC-1", Title: "x"}); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("SubmitTask: expected Unauthenticated, got %v", err)
+			}
+			if _, err := client.CancelTask(ctx, &controlpb.CancelTaskRequest{TaskId: "RPC-1"}); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("CancelTask: expected Unauthenticated, got %v", err)
+			}
+			if _, ok := p.taskState("RPC-1"); ok {
+				t.Error("task should not have been queued")
+			}
+		})
+	}
+}
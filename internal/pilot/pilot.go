@@ -8,6 +8,8 @@ import (
 	"log/slog"
 	"sync"
 
+	"google.golang.org/grpc"
+
 	"github.com/alekspetrov/pilot/internal/adapters/asana"
 	"github.com/alekspetrov/pilot/internal/adapters/azuredevops"
 	"github.com/alekspetrov/pilot/internal/adapters/github"
@@ -20,12 +22,14 @@ import (
 	"github.com/alekspetrov/pilot/internal/alerts"
 	"github.com/alekspetrov/pilot/internal/comms"
 	"github.com/alekspetrov/pilot/internal/approval"
+	"github.com/alekspetrov/pilot/internal/budget"
 	"github.com/alekspetrov/pilot/internal/config"
 	"github.com/alekspetrov/pilot/internal/executor"
 	"github.com/alekspetrov/pilot/internal/gateway"
 	"github.com/alekspetrov/pilot/internal/logging"
 	"github.com/alekspetrov/pilot/internal/memory"
 	"github.com/alekspetrov/pilot/internal/orchestrator"
+	"github.com/alekspetrov/pilot/internal/quality"
 	"github.com/alekspetrov/pilot/internal/teams"
 	"github.com/alekspetrov/pilot/internal/webhooks"
 )
@@ -71,6 +75,10 @@ type Pilot struct {
 	webhookManager         *webhooks.Manager
 	approvalMgr            *approval.Manager
 	dashboardFS            fs.FS // Embedded React frontend (GH-1612)
+	autopilotProvider      gateway.AutopilotProvider
+	budgetEnforcer         *budget.Enforcer
+	executionRunner        *executor.Runner // Runner for tasks executed outside the orchestrator
+	grpcServer             *grpc.Server     // gRPC control-plane API, nil unless gateway.grpc_port is set
 
 	// linearTasks maps task IDs to Linear issue IDs for completion callbacks
 	linearTasks   map[string]linearTaskInfo
@@ -182,6 +190,30 @@ func WithDashboardFS(fsys fs.FS) Option {
 	}
 }
 
+// WithAutopilotProvider exposes autopilot PR state on /api/v1/autopilot and
+// the management API's /api/v1/autopilot/prs.
+func WithAutopilotProvider(provider gateway.AutopilotProvider) Option {
+	return func(p *Pilot) {
+		p.autopilotProvider = provider
+		p.gateway.SetAutopilotProvider(provider)
+	}
+}
+
+// WithBudgetEnforcer exposes budget status on the management API's /api/v1/budget.
+func WithBudgetEnforcer(enforcer *budget.Enforcer) Option {
+	return func(p *Pilot) {
+		p.budgetEnforcer = enforcer
+	}
+}
+
+// WithExecutionRunner lets the management API cancel executions run outside
+// the orchestrator, e.g. issues picked up by the GitHub poller.
+func WithExecutionRunner(runner *executor.Runner) Option {
+	return func(p *Pilot) {
+		p.executionRunner = runner
+	}
+}
+
 // New creates a new Pilot instance
 func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -211,6 +243,7 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 
 	// Initialize approval manager
 	p.approvalMgr = approval.NewManager(cfg.Approval)
+	p.approvalMgr.SetAuditStore(p.store)
 
 	// Initialize Slack notifier if enabled
 	if cfg.Adapters.Slack != nil && cfg.Adapters.Slack.Enabled {
@@ -265,12 +298,14 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
 	}
 	p.orchestrator = orch
+	p.orchestrator.SetLeakGuard(quality.NewLeakGuard(cfg.LeakGuard))
 
 	// Register completion callback for platform notifications + outbound webhooks
 	p.orchestrator.OnCompletion(p.handleTaskCompletion)
 
-	// Register progress callback for outbound webhooks
+	// Register progress callback for outbound webhooks and the /events stream
 	p.orchestrator.OnProgress(func(taskID, phase string, progress int, message string) {
+		p.publishProgress(taskID, phase, progress, message)
 		if p.webhookManager.IsEnabled() {
 			p.webhookManager.Dispatch(ctx, webhooks.NewEvent(webhooks.EventTaskProgress, &webhooks.TaskProgressData{
 				TaskID:   taskID,
@@ -318,6 +353,7 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 		)
 		p.githubWH.OnIssue(p.handleGithubIssue)
 		p.githubNotify = github.NewNotifier(p.githubClient, cfg.Adapters.GitHub.PilotLabel)
+		p.githubNotify.SetCommentManager(github.NewCommentManager(p.githubClient, cfg.Adapters.GitHub.Comments))
 	}
 
 	// Initialize GitLab adapter if enabled
@@ -392,7 +428,14 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 	if cfg.Adapters.GitHub != nil && cfg.Adapters.GitHub.WebhookSecret != "" {
 		gatewayCfg.GithubWebhookSecret = cfg.Adapters.GitHub.WebhookSecret
 	}
-	p.gateway = gateway.NewServer(gatewayCfg)
+	// API token auth protects /api/v1/ (including the management API). The default
+	// claude-code auth type is not enforced so remote dashboards keep working, but
+	// without a token the gateway refuses the API's mutating requests.
+	if cfg.Auth != nil && cfg.Auth.Type == gateway.AuthTypeAPIToken {
+		p.gateway = gateway.NewServerWithAuth(gatewayCfg, cfg.Auth)
+	} else {
+		p.gateway = gateway.NewServer(gatewayCfg)
+	}
 
 	// Register webhook handlers
 	if p.linearMultiWH != nil {
@@ -528,6 +571,15 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 		opt(p)
 	}
 
+	// Only team members whose role allows the stage may answer approvals
+	if auth, ok := p.slackMemberResolver.(approval.Authorizer); ok {
+		p.approvalMgr.SetAuthorizer(auth)
+	}
+
+	// Management REST API under the gateway's authenticated /api/v1/ routes
+	p.registerAPI()
+	p.registerEvents()
+
 	// Set embedded dashboard frontend on gateway if available (GH-1612)
 	if p.dashboardFS != nil {
 		p.gateway.SetDashboardFS(p.dashboardFS)
@@ -555,8 +607,10 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 
 		// Build comms.MemberResolver wrapper (GH-634)
 		var tgMemberResolver comms.MemberResolver
+		var tgCommands comms.CommandChecker
 		if p.telegramMemberResolver != nil {
 			tgMemberResolver = &telegram.MemberResolverAdapter{Inner: p.telegramMemberResolver}
+			tgCommands, _ = p.telegramMemberResolver.(comms.CommandChecker)
 		}
 
 		tgCommsHandler := comms.NewHandler(&comms.HandlerConfig{
@@ -566,8 +620,11 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 			ProjectPath:    projectPath,
 			RateLimit:      cfg.Adapters.Telegram.RateLimit,
 			MemberResolver: tgMemberResolver,
+			Commands:       tgCommands,
 			Store:          p.store,
 			TaskIDPrefix:   "TG",
+			Adapter:        "telegram",
+			Enforcer:       p.budgetEnforcer,
 		})
 
 		p.telegramHandler = telegram.NewHandler(&telegram.HandlerConfig{
@@ -600,8 +657,10 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 		slackMessenger := slack.NewMessenger(slackClient)
 
 		var slackMemberResolver comms.MemberResolver
+		var slackCommands comms.CommandChecker
 		if p.slackMemberResolver != nil {
 			slackMemberResolver = &slack.MemberResolverAdapter{Inner: p.slackMemberResolver}
+			slackCommands, _ = p.slackMemberResolver.(comms.CommandChecker)
 		}
 
 		slackCommsHandler := comms.NewHandler(&comms.HandlerConfig{
@@ -610,8 +669,11 @@ func New(cfg *config.Config, opts ...Option) (*Pilot, error) {
 			Projects:       config.NewSlackProjectSource(cfg),
 			ProjectPath:    projectPath,
 			MemberResolver: slackMemberResolver,
+			Commands:       slackCommands,
 			Store:          p.store,
 			TaskIDPrefix:   "SLACK",
+			Adapter:        "slack",
+			Enforcer:       p.budgetEnforcer,
 		})
 
 		p.slackHandler = slack.NewHandler(&slack.HandlerConfig{
@@ -662,6 +724,13 @@ func (p *Pilot) Start() error {
 		}
 	}()
 
+	// Start gRPC control-plane API if a port is configured
+	if p.config.Gateway.GRPCPort > 0 {
+		if err := p.startGRPC(); err != nil {
+			return err
+		}
+	}
+
 	// Start Telegram polling if handler is initialized (GH-349)
 	if p.telegramHandler != nil {
 		p.telegramHandler.StartPolling(p.ctx)
@@ -670,6 +739,7 @@ func (p *Pilot) Start() error {
 
 	// Start GitHub polling if poller is initialized (GH-350)
 	if p.githubPoller != nil {
+		p.gateway.RegisterStatusComponent(p.githubPoller)
 		go p.githubPoller.Start(p.ctx)
 		logging.WithComponent("pilot").Info("GitHub polling started in gateway mode")
 	}
@@ -716,6 +786,9 @@ func (p *Pilot) Stop() error {
 	}
 
 	p.orchestrator.Stop()
+	if p.grpcServer != nil {
+		p.grpcServer.Stop()
+	}
 	_ = p.gateway.Shutdown()
 	_ = p.store.Close()
 	p.wg.Wait()
@@ -991,6 +1064,11 @@ func (p *Pilot) Gateway() *gateway.Server {
 	return p.gateway
 }
 
+// AlertEngine returns the alerts engine, nil when alerts are not configured
+func (p *Pilot) AlertEngine() *alerts.Engine {
+	return p.alertEngine
+}
+
 // TeamsService returns the teams service for RBAC (GH-633)
 // Returns nil if --team was not provided.
 func (p *Pilot) TeamsService() *teams.Service {
@@ -1446,6 +1524,8 @@ func (p *Pilot) convertAlertsConfig(cfg *config.AlertsConfig) *alerts.AlertConfi
 			Type:       ch.Type,
 			Enabled:    ch.Enabled,
 			Severities: ch.Severities,
+			Member:     ch.Member,
+			QuietHours: ch.QuietHours,
 			Slack:      ch.Slack,     // Same type, direct pass-through
 			Telegram:   ch.Telegram,  // Same type, direct pass-through
 			Email:      ch.Email,     // Same type, direct pass-through
@@ -1474,6 +1554,8 @@ func (p *Pilot) convertAlertsConfig(cfg *config.AlertsConfig) *alerts.AlertConfi
 				Pattern:              r.Condition.Pattern,
 				FilePattern:          r.Condition.FilePattern,
 				Paths:                r.Condition.Paths,
+				RepeatedStalls:       r.Condition.RepeatedStalls,
+				StallWindow:          r.Condition.StallWindow,
 			},
 		}
 	}
@@ -1485,5 +1567,5 @@ func (p *Pilot) convertAlertsConfig(cfg *config.AlertsConfig) *alerts.AlertConfi
 		SuppressDuplicates: cfg.Defaults.SuppressDuplicates,
 	}
 
-	return alerts.FromConfigAlerts(cfg.Enabled, channels, rules, defaults)
+	return alerts.FromConfigAlerts(cfg.Enabled, channels, rules, defaults, cfg.Members...)
 }
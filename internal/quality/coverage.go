@@ -0,0 +1,257 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CoverageDeltaGateName is the gate name of the built-in coverage delta check
+const CoverageDeltaGateName = "coverage-delta"
+
+// coverageEpsilon absorbs float noise when comparing coverage percentages
+const coverageEpsilon = 0.005
+
+// CoverageDeltaConfig configures the built-in coverage delta check, which
+// measures test coverage on the base commit and on the change and fails
+// when coverage drops by more than MaxDrop percentage points.
+//
+// Example YAML configuration:
+//
+//	quality:
+//	  coverage_delta:
+//	    enabled: true
+//	    max_drop: 0.5
+type CoverageDeltaConfig struct {
+	Enabled    bool          `yaml:"enabled" json:"enabled"`
+	MaxDrop    float64       `yaml:"max_drop" json:"max_drop"`       // Allowed drop in percentage points (default: 0, any drop fails)
+	Command    string        `yaml:"command" json:"command"`         // Coverage command (default: per detected toolchain)
+	BaseBranch string        `yaml:"base_branch" json:"base_branch"` // Branch coverage is compared against (default: auto-detect)
+	Timeout    time.Duration `yaml:"timeout" json:"timeout"`         // Max time per coverage run (default: 10m)
+}
+
+// Validate checks the allowed drop
+func (c *CoverageDeltaConfig) Validate() error {
+	if c.MaxDrop < 0 || c.MaxDrop > 100 {
+		return fmt.Errorf("coverage_delta max_drop must be between 0 and 100, got %v", c.MaxDrop)
+	}
+	return nil
+}
+
+func (c *CoverageDeltaConfig) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return 10 * time.Minute
+}
+
+// CoverageDelta is the coverage change measured by the coverage delta check
+type CoverageDelta struct {
+	Base    float64 `json:"base"`     // Coverage on the base commit, in percent
+	Head    float64 `json:"head"`     // Coverage with the change, in percent
+	MaxDrop float64 `json:"max_drop"` // Allowed drop in percentage points
+}
+
+// Delta returns the change in percentage points, negative when coverage dropped
+func (d *CoverageDelta) Delta() float64 {
+	return d.Head - d.Base
+}
+
+// Exceeded returns true if coverage dropped by more than the allowed amount
+func (d *CoverageDelta) Exceeded() bool {
+	return -d.Delta() > d.MaxDrop+coverageEpsilon
+}
+
+// String formats the delta as "80.0% → 81.2% (+1.2)"
+func (d *CoverageDelta) String() string {
+	return fmt.Sprintf("%.1f%% → %.1f%% (%+.1f)", d.Base, d.Head, d.Delta())
+}
+
+// coverageCommands print total coverage in a format parseCoverageOutput
+// reads. "{profile}" is replaced with a temporary file path.
+var coverageCommands = map[Language]string{
+	LanguageGo:     "go test -coverprofile={profile} ./... >/dev/null && go tool cover -func={profile}",
+	LanguageNode:   "npx --no-install c8 --reporter=text-summary npm test",
+	LanguagePython: "python -m pytest -q --cov=. --cov-report=term",
+	LanguageRust:   "cargo llvm-cov --summary-only",
+}
+
+// CoverageCommand returns the command measuring coverage for the project at
+// projectPath, based on its first detected language. Returns "" when no
+// supported toolchain is found.
+func CoverageCommand(projectPath string) string {
+	for _, lang := range DetectLanguages(projectPath) {
+		if cmd, ok := coverageCommands[lang]; ok {
+			return cmd
+		}
+	}
+	return ""
+}
+
+// baseCoverage caches coverage measured on base commits, keyed by commit
+// and command. A base commit's coverage does not change, so retries of the
+// quality gates measure it once.
+var baseCoverage sync.Map
+
+// sharedDirs are dependency directories linked into the base checkout so the
+// coverage command can run without reinstalling dependencies
+var sharedDirs = []string{"node_modules", ".venv", "venv"}
+
+// runCoverageDelta measures coverage on the merge-base and on the working
+// tree and compares them.
+func (r *Runner) runCoverageDelta(ctx context.Context) *Result {
+	result := &Result{
+		GateName:  CoverageDeltaGateName,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+	}
+	r.reportProgress(CoverageDeltaGateName, StatusRunning, "Measuring coverage before and after the change...")
+
+	finish := func(status GateStatus, message string) *Result {
+		result.Status = status
+		result.CompletedAt = time.Now()
+		result.Duration = result.CompletedAt.Sub(result.StartedAt)
+		r.reportProgress(CoverageDeltaGateName, status, message)
+		return result
+	}
+
+	cfg := r.config.CoverageDelta
+	command := cfg.Command
+	if command == "" {
+		command = CoverageCommand(r.projectDir)
+	}
+	if command == "" {
+		result.Output = "note: no supported toolchain detected, set coverage_delta.command"
+		return finish(StatusSkipped, "coverage delta skipped: no coverage command")
+	}
+
+	base, err := mergeBase(ctx, r.projectDir, cfg.BaseBranch)
+	if err != nil {
+		result.Output = "note: " + err.Error()
+		return finish(StatusSkipped, "coverage delta skipped: no base commit")
+	}
+
+	before, err := r.measureBaseCoverage(ctx, base, command)
+	if err != nil {
+		// The change can't be blamed for a base that doesn't measure
+		result.Output = fmt.Sprintf("note: coverage of base %s could not be measured: %v", shortSHA(base), err)
+		return finish(StatusSkipped, "coverage delta skipped: base coverage unavailable")
+	}
+
+	after, output, err := r.measureCoverage(ctx, r.projectDir, command)
+	if err != nil {
+		result.ExitCode = 1
+		result.Error = fmt.Sprintf("coverage run failed: %v", err)
+		result.Output = output
+		return finish(StatusFailed, result.Error)
+	}
+
+	delta := &CoverageDelta{Base: before, Head: after, MaxDrop: cfg.MaxDrop}
+	result.Coverage = after
+	result.CoverageDelta = delta
+	if delta.Exceeded() {
+		result.ExitCode = 1
+		result.Error = fmt.Sprintf("coverage dropped %.1f points (%s), max drop is %.1f", -delta.Delta(), delta, cfg.MaxDrop)
+		result.Output = fmt.Sprintf("Coverage %s against base %s.\nAdd tests for the new and changed code so coverage does not drop by more than %.1f points.\n",
+			delta, shortSHA(base), cfg.MaxDrop)
+		return finish(StatusFailed, result.Error)
+	}
+	result.Output = fmt.Sprintf("Coverage %s against base %s.\n", delta, shortSHA(base))
+	return finish(StatusPassed, fmt.Sprintf("%s gate passed: %s", CoverageDeltaGateName, delta))
+}
+
+// measureBaseCoverage measures coverage of the base commit in a temporary
+// worktree, reusing earlier measurements of the same commit.
+func (r *Runner) measureBaseCoverage(ctx context.Context, base, command string) (float64, error) {
+	key := base + "\x00" + command
+	if v, ok := baseCoverage.Load(key); ok {
+		return v.(float64), nil
+	}
+
+	tmp, err := os.MkdirTemp("", "pilot-coverage-base-")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	dir := filepath.Join(tmp, "src")
+
+	if _, err := runTool(ctx, r.projectDir, "git", "worktree", "add", "--detach", dir, base); err != nil {
+		return 0, fmt.Errorf("git worktree add failed: %w", err)
+	}
+	defer func() {
+		_, _ = runTool(context.Background(), r.projectDir, "git", "worktree", "remove", "--force", dir)
+	}()
+	for _, name := range sharedDirs {
+		if src := filepath.Join(r.projectDir, name); fileExists(src) {
+			_ = os.Symlink(src, filepath.Join(dir, name))
+		}
+	}
+
+	coverage, _, err := r.measureCoverage(ctx, dir, command)
+	if err != nil {
+		return 0, err
+	}
+	baseCoverage.Store(key, coverage)
+	return coverage, nil
+}
+
+// measureCoverage runs the coverage command in dir and parses the total
+func (r *Runner) measureCoverage(ctx context.Context, dir, command string) (float64, string, error) {
+	profile, err := os.CreateTemp("", "pilot-coverage-*.out")
+	if err != nil {
+		return 0, "", err
+	}
+	_ = profile.Close()
+	defer func() { _ = os.Remove(profile.Name()) }()
+
+	gate := &Gate{
+		Name:    CoverageDeltaGateName,
+		Type:    GateCoverage,
+		Command: strings.ReplaceAll(command, "{profile}", profile.Name()),
+		Timeout: r.config.CoverageDelta.timeout(),
+	}
+	exitCode, output, err := r.executeCommandIn(ctx, dir, gate, gate.Command, nil)
+	if err != nil {
+		return 0, output, err
+	}
+	if exitCode != 0 {
+		return 0, output, fmt.Errorf("command exited with code %d", exitCode)
+	}
+	coverage, ok := parseCoverage(output)
+	if !ok {
+		return 0, output, fmt.Errorf("no coverage total found in output")
+	}
+	return coverage, output, nil
+}
+
+// mergeBase returns the commit where HEAD left its base branch
+func mergeBase(ctx context.Context, dir, baseBranch string) (string, error) {
+	for _, base := range baseCandidates(baseBranch) {
+		out, err := runTool(ctx, dir, "git", "merge-base", "HEAD", base)
+		if err == nil {
+			return strings.TrimSpace(string(out)), nil
+		}
+	}
+	return "", fmt.Errorf("no base branch found to compare against")
+}
+
+// baseCandidates lists the refs tried, in order, as the branch a change is
+// compared against
+func baseCandidates(baseBranch string) []string {
+	bases := []string{"origin/HEAD", "origin/main", "origin/master", "main", "master"}
+	if baseBranch != "" {
+		bases = append([]string{baseBranch, "origin/" + baseBranch}, bases...)
+	}
+	return bases
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
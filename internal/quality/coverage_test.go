@@ -0,0 +1,140 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCoverageDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		delta    CoverageDelta
+		exceeded bool
+		want     string
+	}{
+		{name: "increase", delta: CoverageDelta{Base: 80, Head: 81.25}, want: "80.0% → 81.2% (+1.2)"},
+		{name: "drop within limit", delta: CoverageDelta{Base: 80, Head: 79.5, MaxDrop: 0.5}, want: "80.0% → 79.5% (-0.5)"},
+		{name: "drop past limit", delta: CoverageDelta{Base: 80, Head: 79.4, MaxDrop: 0.5}, exceeded: true, want: "80.0% → 79.4% (-0.6)"},
+		{name: "any drop fails by default", delta: CoverageDelta{Base: 80, Head: 79.9}, exceeded: true, want: "80.0% → 79.9% (-0.1)"},
+		{name: "unchanged", delta: CoverageDelta{Base: 80, Head: 80}, want: "80.0% → 80.0% (+0.0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.delta.Exceeded(); got != tt.exceeded {
+				t.Errorf("Exceeded() = %v, want %v", got, tt.exceeded)
+			}
+			if got := tt.delta.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := (&CoverageDeltaConfig{MaxDrop: -1}).Validate(); err == nil {
+		t.Error("Validate should reject a negative max_drop")
+	}
+}
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   float64
+		found  bool
+	}{
+		{name: "go tool cover", output: "pkg/a.go:10:\tFoo\t100.0%\ntotal:\t\t\t(statements)\t72.4%\n", want: 72.4, found: true},
+		{name: "cargo llvm-cov", output: "Filename  Regions  Missed  Cover\nTOTAL     120      6       95.00%   40   2   95.00%\n", want: 95, found: true},
+		{name: "c8 text summary", output: "Statements   : 81.2% ( 100/123 )\nLines        : 80.5% ( 99/123 )\n", want: 80.5, found: true},
+		{name: "none", output: "PASS\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := parseCoverage(tt.output)
+			if got != tt.want || found != tt.found {
+				t.Errorf("parseCoverage() = %v, %v; want %v, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestRunner_RunAll_CoverageDelta(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeCoverage := func(pct string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "coverage.txt"), []byte("coverage: "+pct+"% of statements\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	writeCoverage("80.0")
+	run("add", "coverage.txt")
+	run("-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "-m", "init")
+	run("checkout", "-q", "-b", "pilot/GH-1")
+
+	check := func(pct string, maxDrop float64) *Result {
+		t.Helper()
+		writeCoverage(pct)
+		config := &Config{
+			Enabled:       true,
+			CoverageDelta: &CoverageDeltaConfig{Enabled: true, MaxDrop: maxDrop, Command: "cat coverage.txt"},
+		}
+		results, err := NewRunner(config, dir).RunAll(context.Background(), "test-task")
+		if err != nil {
+			t.Fatalf("RunAll failed: %v", err)
+		}
+		if len(results.Results) != 1 || results.Results[0].GateName != CoverageDeltaGateName {
+			t.Fatalf("expected only the coverage delta result, got %+v", results.Results)
+		}
+		result := results.Results[0]
+		if results.AllPassed != (result.Status == StatusPassed) {
+			t.Errorf("AllPassed = %v with coverage delta status %s", results.AllPassed, result.Status)
+		}
+		return result
+	}
+
+	result := check("78.0", 1)
+	if result.Status != StatusFailed || !strings.Contains(result.Error, "coverage dropped 2.0 points") {
+		t.Errorf("2 point drop with max_drop 1: status %s, error %q", result.Status, result.Error)
+	}
+	if result.CoverageDelta == nil || result.CoverageDelta.Base != 80 || result.CoverageDelta.Head != 78 {
+		t.Errorf("CoverageDelta = %+v, want 80 → 78", result.CoverageDelta)
+	}
+
+	result = check("82.5", 0)
+	if result.Status != StatusPassed || result.CoverageDelta.Delta() != 2.5 {
+		t.Errorf("coverage increase: status %s, delta %+v", result.Status, result.CoverageDelta)
+	}
+
+	// The base worktree is removed after measuring
+	out, _ := exec.Command("git", "-C", dir, "worktree", "list").Output()
+	if strings.Count(string(out), "\n") != 1 {
+		t.Errorf("leftover worktrees:\n%s", out)
+	}
+}
+
+func TestRunner_CoverageDelta_SkipsWithoutBase(t *testing.T) {
+	dir := t.TempDir()
+	config := &Config{
+		Enabled:       true,
+		CoverageDelta: &CoverageDeltaConfig{Enabled: true, Command: "echo 'coverage: 50%'"},
+	}
+	results, err := NewRunner(config, dir).RunAll(context.Background(), "test-task")
+	if err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+	if !results.AllPassed || results.Results[0].Status != StatusSkipped {
+		t.Errorf("outside a git repo the check should be skipped, got %+v", results.Results[0])
+	}
+}
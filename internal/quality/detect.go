@@ -0,0 +1,210 @@
+package quality
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Language is a project toolchain recognized by DetectGates
+type Language string
+
+const (
+	LanguageGo     Language = "go"
+	LanguageNode   Language = "node"
+	LanguagePython Language = "python"
+	LanguageRust   Language = "rust"
+)
+
+// npmDefaultTest is the test script npm init writes; it always fails
+const npmDefaultTest = `echo "Error: no test specified" && exit 1`
+
+// DetectLanguages returns the languages of the project at projectPath,
+// based on the manifest files in its root.
+func DetectLanguages(projectPath string) []Language {
+	var langs []Language
+	if fileExists(filepath.Join(projectPath, "go.mod")) {
+		langs = append(langs, LanguageGo)
+	}
+	if fileExists(filepath.Join(projectPath, "package.json")) {
+		langs = append(langs, LanguageNode)
+	}
+	if fileExists(filepath.Join(projectPath, "pyproject.toml")) || fileExists(filepath.Join(projectPath, "setup.py")) {
+		langs = append(langs, LanguagePython)
+	}
+	if fileExists(filepath.Join(projectPath, "Cargo.toml")) {
+		langs = append(langs, LanguageRust)
+	}
+	return langs
+}
+
+// DetectGates returns default build, test and lint gates for the languages
+// of the project at projectPath. Build and test gates are required, lint
+// gates warn only. In a project with several languages gate names are
+// prefixed with the language, e.g. "go-test".
+func DetectGates(projectPath string) []*Gate {
+	langs := DetectLanguages(projectPath)
+	var gates []*Gate
+	for _, lang := range langs {
+		for _, g := range languageGates(projectPath, lang) {
+			if len(langs) > 1 {
+				g.Name = string(lang) + "-" + g.Name
+			}
+			gates = append(gates, g)
+		}
+	}
+	return gates
+}
+
+// WithDetectedGates returns the config for the project at projectPath. When
+// AutoDetect is set, gates detected for the project are added for every gate
+// type the config does not define itself; otherwise c is returned unchanged.
+func (c *Config) WithDetectedGates(projectPath string) *Config {
+	if c == nil || !c.AutoDetect {
+		return c
+	}
+	configured := make(map[GateType]bool, len(c.Gates))
+	for _, g := range c.Gates {
+		configured[g.Type] = true
+	}
+
+	var added []*Gate
+	for _, g := range DetectGates(projectPath) {
+		if !configured[g.Type] {
+			added = append(added, g)
+		}
+	}
+	if len(added) == 0 {
+		return c
+	}
+
+	merged := *c
+	merged.Gates = append(append([]*Gate{}, c.Gates...), added...)
+	return &merged
+}
+
+func languageGates(projectPath string, lang Language) []*Gate {
+	switch lang {
+	case LanguageGo:
+		return []*Gate{
+			buildGate("go build ./..."),
+			testGate("go test ./..."),
+			lintGate("if command -v golangci-lint >/dev/null 2>&1; then golangci-lint run; else go vet ./...; fi"),
+		}
+	case LanguageNode:
+		return nodeGates(projectPath)
+	case LanguagePython:
+		return pythonGates(projectPath)
+	case LanguageRust:
+		return []*Gate{
+			buildGate("cargo check --all-targets"),
+			testGate("cargo test"),
+			lintGate("cargo clippy --all-targets -- -D warnings"),
+		}
+	}
+	return nil
+}
+
+// nodeGates runs the package.json scripts the project defines, with the
+// package manager its lock file points to.
+func nodeGates(projectPath string) []*Gate {
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if data, err := os.ReadFile(filepath.Join(projectPath, "package.json")); err == nil {
+		_ = json.Unmarshal(data, &pkg)
+	}
+
+	run := "npm run"
+	switch {
+	case fileExists(filepath.Join(projectPath, "pnpm-lock.yaml")):
+		run = "pnpm run"
+	case fileExists(filepath.Join(projectPath, "yarn.lock")):
+		run = "yarn run"
+	case fileExists(filepath.Join(projectPath, "bun.lockb")), fileExists(filepath.Join(projectPath, "bun.lock")):
+		run = "bun run"
+	}
+
+	var gates []*Gate
+	switch {
+	case pkg.Scripts["build"] != "":
+		gates = append(gates, buildGate(run+" build"))
+	case fileExists(filepath.Join(projectPath, "tsconfig.json")):
+		gate := buildGate("npx tsc --noEmit")
+		gate.Name, gate.Type = "typecheck", GateTypeCheck
+		gates = append(gates, gate)
+	}
+	if test := pkg.Scripts["test"]; test != "" && test != npmDefaultTest {
+		gates = append(gates, testGate(run+" test"))
+	}
+	if pkg.Scripts["lint"] != "" {
+		gates = append(gates, lintGate(run+" lint"))
+	}
+	return gates
+}
+
+// pythonGates compiles the sources and runs pytest and ruff when the
+// project uses them.
+func pythonGates(projectPath string) []*Gate {
+	pyproject, _ := os.ReadFile(filepath.Join(projectPath, "pyproject.toml"))
+	uses := func(tool string, files ...string) bool {
+		if strings.Contains(string(pyproject), "[tool."+tool) {
+			return true
+		}
+		for _, f := range files {
+			if fileExists(filepath.Join(projectPath, f)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	gates := []*Gate{
+		buildGate(`python -m compileall -q -x '(^|/)(\.?venv|node_modules)/' .`),
+	}
+	if uses("pytest", "pytest.ini", "conftest.py", "tests") {
+		gates = append(gates, testGate("python -m pytest -q"))
+	}
+	if uses("ruff", "ruff.toml", ".ruff.toml") {
+		gates = append(gates, lintGate("ruff check ."))
+	}
+	return gates
+}
+
+func buildGate(command string) *Gate {
+	return &Gate{
+		Name:        "build",
+		Type:        GateBuild,
+		Command:     command,
+		Required:    true,
+		MaxRetries:  2,
+		RetryDelay:  5 * time.Second,
+		FailureHint: "Fix compilation errors in the changed files",
+	}
+}
+
+func testGate(command string) *Gate {
+	return &Gate{
+		Name:        "test",
+		Type:        GateTest,
+		Command:     command,
+		Required:    true,
+		MaxRetries:  2,
+		RetryDelay:  5 * time.Second,
+		FailureHint: "Fix failing tests or update test expectations",
+	}
+}
+
+func lintGate(command string) *Gate {
+	return &Gate{
+		Name:        "lint",
+		Type:        GateLint,
+		Command:     command,
+		Required:    false,
+		MaxRetries:  1,
+		RetryDelay:  2 * time.Second,
+		FailureHint: "Fix linting errors: formatting, unused imports, etc.",
+	}
+}
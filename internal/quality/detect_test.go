@@ -0,0 +1,146 @@
+package quality
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// gateSummary renders gates as "name=command" lines for comparison
+func gateSummary(gates []*Gate) string {
+	var lines []string
+	for _, g := range gates {
+		lines = append(lines, g.Name+"="+g.Command)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDetectGates(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "go",
+			files: map[string]string{"go.mod": "module example.com/x\n"},
+			want: []string{
+				"build=go build ./...",
+				"test=go test ./...",
+				"lint=if command -v golangci-lint >/dev/null 2>&1; then golangci-lint run; else go vet ./...; fi",
+			},
+		},
+		{
+			name: "node with pnpm and default test script",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"build": "tsc", "lint": "eslint .", "test": "echo \"Error: no test specified\" && exit 1"}}`,
+				"pnpm-lock.yaml": "",
+			},
+			want: []string{"build=pnpm run build", "lint=pnpm run lint"},
+		},
+		{
+			name: "typescript without build script",
+			files: map[string]string{
+				"package.json":  `{"scripts": {"test": "vitest run"}}`,
+				"tsconfig.json": "{}",
+			},
+			want: []string{"typecheck=npx tsc --noEmit", "test=npm run test"},
+		},
+		{
+			name: "python with pytest and ruff",
+			files: map[string]string{
+				"pyproject.toml":  "[project]\nname = \"x\"\n\n[tool.ruff]\nline-length = 100\n",
+				"tests/test_x.py": "",
+			},
+			want: []string{
+				`build=python -m compileall -q -x '(^|/)(\.?venv|node_modules)/' .`,
+				"test=python -m pytest -q",
+				"lint=ruff check .",
+			},
+		},
+		{
+			name:  "rust",
+			files: map[string]string{"Cargo.toml": "[package]\nname = \"x\"\n"},
+			want: []string{
+				"build=cargo check --all-targets",
+				"test=cargo test",
+				"lint=cargo clippy --all-targets -- -D warnings",
+			},
+		},
+		{
+			name: "go with node frontend",
+			files: map[string]string{
+				"go.mod":       "module example.com/x\n",
+				"package.json": `{"scripts": {"build": "vite build"}}`,
+			},
+			want: []string{
+				"go-build=go build ./...",
+				"go-test=go test ./...",
+				"go-lint=if command -v golangci-lint >/dev/null 2>&1; then golangci-lint run; else go vet ./...; fi",
+				"node-build=npm run build",
+			},
+		},
+		{
+			name:  "unknown",
+			files: map[string]string{"README.md": "hi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			gates := DetectGates(dir)
+			if got, want := gateSummary(gates), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("DetectGates() =\n%s\nwant\n%s", got, want)
+			}
+			for _, g := range gates {
+				if g.Required != (g.Type != GateLint) {
+					t.Errorf("gate %s Required = %v", g.Name, g.Required)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_WithDetectedGates(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/x\n"})
+
+	cfg := &Config{
+		Enabled: true,
+		Gates:   []*Gate{{Name: "unit", Type: GateTest, Command: "make test"}},
+	}
+	if got := cfg.WithDetectedGates(dir); got != cfg {
+		t.Error("config without auto_detect should be returned unchanged")
+	}
+
+	cfg.AutoDetect = true
+	got := cfg.WithDetectedGates(dir)
+	want := "unit=make test\nbuild=go build ./...\nlint=if command -v golangci-lint >/dev/null 2>&1; then golangci-lint run; else go vet ./...; fi"
+	if gateSummary(got.Gates) != want {
+		t.Errorf("gates =\n%s\nwant\n%s", gateSummary(got.Gates), want)
+	}
+	if len(cfg.Gates) != 1 {
+		t.Errorf("original config modified: %d gates", len(cfg.Gates))
+	}
+
+	if got := cfg.WithDetectedGates(t.TempDir()); got != cfg {
+		t.Error("nothing detected should return the config unchanged")
+	}
+}
@@ -25,13 +25,24 @@ type Executor struct {
 	log    *slog.Logger
 }
 
-// NewExecutor creates a quality gate executor for a task
+// NewExecutor creates a quality gate executor for a task.
+// With local_ci, gates running the project's CI jobs are added; with
+// auto_detect, default gates for the project's languages are added for gate
+// types neither defines.
 func NewExecutor(cfg *ExecutorConfig) *Executor {
+	config := cfg.Config.WithLocalCIGates(cfg.ProjectPath).WithDetectedGates(cfg.ProjectPath)
+	log := logging.WithComponent("quality")
+	if added := len(config.Gates) - len(cfg.Config.Gates); added > 0 {
+		log.Debug("Added detected quality gates",
+			slog.String("project", cfg.ProjectPath),
+			slog.Int("gates", added),
+		)
+	}
 	return &Executor{
-		runner: NewRunner(cfg.Config, cfg.ProjectPath),
-		config: cfg.Config,
+		runner: NewRunner(config, cfg.ProjectPath),
+		config: config,
 		taskID: cfg.TaskID,
-		log:    logging.WithComponent("quality"),
+		log:    log,
 	}
 }
 
@@ -135,7 +146,7 @@ func GenerateReport(taskID string, results *CheckResults, attempt int) *GateRepo
 
 	for i, r := range results.Results {
 		report.Gates[i] = GateReportItem{
-			Name:     r.GateName,
+			Name:     r.DisplayName(),
 			Status:   string(r.Status),
 			Duration: r.Duration,
 			Error:    r.Error,
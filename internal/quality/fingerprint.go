@@ -0,0 +1,54 @@
+package quality
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// Patterns for output that changes between runs of the same failure.
+// They are applied in order, so longer tokens are replaced before the
+// numbers inside them.
+var fingerprintNoise = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`), ""},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`), "<time>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`), "<addr>"},
+	{regexp.MustCompile(`(/tmp|/var/folders|/private/var/folders)/[^\s:'"]+`), "<tmp>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|us|ms|s|m|h)\b`), "<dur>"},
+	{regexp.MustCompile(`[ \t]+`), " "},
+}
+
+// Fingerprint returns a short hash of a failure's output after removing
+// run-specific noise such as timestamps, durations, memory addresses and
+// temp paths. Two failures with the same fingerprint failed the same way;
+// differing fingerprints point at a flaky gate. Empty output has no
+// fingerprint.
+func Fingerprint(output string) string {
+	normalized := normalizeOutput(output)
+	if normalized == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+// normalizeOutput strips run-specific noise from command output
+func normalizeOutput(output string) string {
+	for _, n := range fingerprintNoise {
+		output = n.re.ReplaceAllString(output, n.repl)
+	}
+	lines := strings.Split(output, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
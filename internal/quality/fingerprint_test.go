@@ -0,0 +1,30 @@
+package quality
+
+import "testing"
+
+func TestFingerprint_IgnoresRunNoise(t *testing.T) {
+	a := "\x1b[31mFAIL\x1b[0m TestFoo (0.12s)\n2024-01-02T10:00:00Z panic at 0xc000123 in /tmp/go-build123/foo.test\n--- FAIL: pkg 1.234s"
+	b := "FAIL   TestFoo (3.4s)\n2025-06-07T11:22:33.456Z panic at 0xc000999 in /tmp/go-build987/foo.test\n\n--- FAIL: pkg 0.5s"
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Errorf("expected same fingerprint for output differing only in noise:\n%s\n%s", normalizeOutput(a), normalizeOutput(b))
+	}
+}
+
+func TestFingerprint_DistinguishesFailures(t *testing.T) {
+	a := Fingerprint("main_test.go:12: expected 2, got 3")
+	b := Fingerprint("main_test.go:40: timeout waiting for server")
+
+	if a == b {
+		t.Error("expected different fingerprints for different failures")
+	}
+	if len(a) != 16 {
+		t.Errorf("expected 16-character fingerprint, got %q", a)
+	}
+}
+
+func TestFingerprint_Empty(t *testing.T) {
+	if got := Fingerprint(" \n\t\n"); got != "" {
+		t.Errorf("expected no fingerprint for empty output, got %q", got)
+	}
+}
@@ -0,0 +1,254 @@
+package quality
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Local CI runners
+const (
+	LocalCIDirect = "direct" // Run the jobs' shell steps with the local toolchain
+	LocalCIAct    = "act"    // Run whole jobs in containers with nektos/act
+)
+
+// workflowDir holds the GitHub Actions workflows of a repository
+const workflowDir = ".github/workflows"
+
+// workflow is the part of a GitHub Actions workflow file local CI reads
+type workflow struct {
+	On   interface{}            `yaml:"on"`
+	Env  map[string]string      `yaml:"env"`
+	Jobs map[string]workflowJob `yaml:"jobs"`
+}
+
+type workflowJob struct {
+	Name           string                 `yaml:"name"`
+	Env            map[string]string      `yaml:"env"`
+	TimeoutMinutes int                    `yaml:"timeout-minutes"`
+	Services       map[string]interface{} `yaml:"services"`
+	Defaults       struct {
+		Run struct {
+			WorkingDirectory string `yaml:"working-directory"`
+		} `yaml:"run"`
+	} `yaml:"defaults"`
+	Steps []workflowStep `yaml:"steps"`
+}
+
+type workflowStep struct {
+	Run              string `yaml:"run"`
+	Shell            string `yaml:"shell"`
+	WorkingDirectory string `yaml:"working-directory"`
+}
+
+// skippedJobWords mark jobs that publish or need infrastructure CI provides
+var skippedJobWords = []string{"deploy", "release", "publish", "docker", "pages", "e2e"}
+
+// jobTypeWords map job names to gate types, checked in order
+var jobTypeWords = []struct {
+	gateType GateType
+	words    []string
+}{
+	{GateLint, []string{"lint", "vet", "fmt", "format", "style", "clippy"}},
+	{GateTypeCheck, []string{"typecheck", "type-check", "tsc", "mypy"}},
+	{GateTest, []string{"test", "spec"}},
+	{GateBuild, []string{"build", "compile"}},
+}
+
+// DetectWorkflowGates returns a gate for each build, test, lint and
+// typecheck job of the GitHub Actions workflows at projectPath that run on
+// push or pull_request. With the direct runner a gate runs the job's shell
+// steps, skipping action steps and steps using ${{ }} expressions; jobs with
+// service containers are left to CI. With act the whole job runs in a
+// container.
+func DetectWorkflowGates(projectPath, runner string) []*Gate {
+	files, _ := filepath.Glob(filepath.Join(projectPath, workflowDir, "*.y*ml"))
+	sort.Strings(files)
+
+	var gates []*Gate
+	names := make(map[string]bool)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var wf workflow
+		if err := yaml.Unmarshal(data, &wf); err != nil || !triggersOnPush(wf.On) {
+			continue
+		}
+		rel := filepath.ToSlash(filepath.Join(workflowDir, filepath.Base(file)))
+
+		jobIDs := make([]string, 0, len(wf.Jobs))
+		for id := range wf.Jobs {
+			jobIDs = append(jobIDs, id)
+		}
+		sort.Strings(jobIDs)
+
+		for _, id := range jobIDs {
+			job := wf.Jobs[id]
+			gateType, ok := workflowJobType(id, job.Name)
+			if !ok {
+				continue
+			}
+
+			var command string
+			if runner == LocalCIAct {
+				command = fmt.Sprintf("act -W %s -j %s", shellQuote(rel), shellQuote(id))
+			} else {
+				if len(job.Services) > 0 {
+					continue
+				}
+				command = directJobCommand(wf.Env, job)
+				if command == "" {
+					continue
+				}
+			}
+
+			name := "ci-" + id
+			if names[name] {
+				name = "ci-" + strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) + "-" + id
+			}
+			names[name] = true
+
+			gates = append(gates, &Gate{
+				Name:        name,
+				Type:        gateType,
+				Command:     command,
+				Required:    gateType != GateLint,
+				Timeout:     time.Duration(job.TimeoutMinutes) * time.Minute,
+				MaxRetries:  1,
+				RetryDelay:  5 * time.Second,
+				FailureHint: fmt.Sprintf("Fix the failure the CI job %q in %s would report", id, rel),
+			})
+		}
+	}
+	return gates
+}
+
+// WithLocalCIGates returns the config for the project at projectPath. When
+// LocalCI is set, gates for the project's CI jobs are added unless a gate of
+// the same name is configured; otherwise c is returned unchanged.
+func (c *Config) WithLocalCIGates(projectPath string) *Config {
+	if c == nil || !c.LocalCI {
+		return c
+	}
+	configured := make(map[string]bool, len(c.Gates))
+	for _, g := range c.Gates {
+		configured[g.Name] = true
+	}
+
+	var added []*Gate
+	for _, g := range DetectWorkflowGates(projectPath, c.LocalCIRunner) {
+		if !configured[g.Name] {
+			added = append(added, g)
+		}
+	}
+	if len(added) == 0 {
+		return c
+	}
+
+	merged := *c
+	merged.Gates = append(append([]*Gate{}, c.Gates...), added...)
+	return &merged
+}
+
+// triggersOnPush reports whether a workflow's on: runs it for pushes or
+// pull requests. It may be a string, a list or a map of events.
+func triggersOnPush(on interface{}) bool {
+	var events []string
+	switch v := on.(type) {
+	case string:
+		events = []string{v}
+	case []interface{}:
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				events = append(events, s)
+			}
+		}
+	case map[string]interface{}:
+		for e := range v {
+			events = append(events, e)
+		}
+	}
+	for _, e := range events {
+		if e == "push" || e == "pull_request" {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowJobType maps a job to a gate type by its ID and name.
+func workflowJobType(id, name string) (GateType, bool) {
+	label := strings.ToLower(id + " " + name)
+	for _, w := range skippedJobWords {
+		if strings.Contains(label, w) {
+			return "", false
+		}
+	}
+	for _, jt := range jobTypeWords {
+		for _, w := range jt.words {
+			if strings.Contains(label, w) {
+				return jt.gateType, true
+			}
+		}
+	}
+	return "", false
+}
+
+// directJobCommand builds a shell script from a job's run steps, or returns
+// "" when the job has none that can run locally.
+func directJobCommand(workflowEnv map[string]string, job workflowJob) string {
+	var steps []string
+	for _, step := range job.Steps {
+		if step.Run == "" || strings.Contains(step.Run, "${{") {
+			continue
+		}
+		if step.Shell != "" && step.Shell != "bash" && step.Shell != "sh" {
+			continue
+		}
+		script := strings.TrimRight(step.Run, "\n")
+		dir := step.WorkingDirectory
+		if dir == "" {
+			dir = job.Defaults.Run.WorkingDirectory
+		}
+		if dir != "" && !strings.Contains(dir, "${{") {
+			script = fmt.Sprintf("(cd %s\n%s\n)", shellQuote(dir), script)
+		}
+		steps = append(steps, script)
+	}
+	if len(steps) == 0 {
+		return ""
+	}
+
+	lines := []string{"set -e"}
+	lines = append(lines, envExports(workflowEnv)...)
+	lines = append(lines, envExports(job.Env)...)
+	return strings.Join(append(lines, steps...), "\n")
+}
+
+// envExports returns export statements for env values without expressions.
+func envExports(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k, v := range env {
+		if !strings.Contains(v, "${{") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	exports := make([]string, 0, len(keys))
+	for _, k := range keys {
+		exports = append(exports, fmt.Sprintf("export %s=%s", k, shellQuote(env[k])))
+	}
+	return exports
+}
+
+// shellQuote quotes s for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
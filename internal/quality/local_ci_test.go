@@ -0,0 +1,142 @@
+package quality
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const ciWorkflow = `name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+env:
+  CGO_ENABLED: "0"
+  TOKEN: ${{ secrets.TOKEN }}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+      - run: go build ./...
+  test:
+    runs-on: ubuntu-latest
+    timeout-minutes: 15
+    steps:
+      - uses: actions/checkout@v4
+      - name: Unit tests
+        run: |
+          go test ./...
+          go test -race ./internal/...
+      - run: go tool cover -func=${{ env.COVERAGE }}
+  web-lint:
+    name: Lint frontend
+    runs-on: ubuntu-latest
+    defaults:
+      run:
+        working-directory: web
+    steps:
+      - run: npm ci
+      - run: npm run lint
+  integration:
+    name: Integration tests
+    runs-on: ubuntu-latest
+    services:
+      postgres:
+        image: postgres:16
+    steps:
+      - run: go test -tags integration ./...
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: ./deploy.sh
+`
+
+func TestDetectWorkflowGates(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".github/workflows/ci.yml": ciWorkflow,
+		".github/workflows/release.yml": `on:
+  release:
+    types: [published]
+jobs:
+  build:
+    steps:
+      - run: make dist
+`,
+		".github/workflows/nightly.yaml": `on: [push]
+jobs:
+  build:
+    steps:
+      - run: make nightly
+`,
+	})
+
+	t.Run("direct", func(t *testing.T) {
+		gates := DetectWorkflowGates(dir, LocalCIDirect)
+		want := strings.Join([]string{
+			"ci-build=set -e\nexport CGO_ENABLED='0'\ngo build ./...",
+			"ci-test=set -e\nexport CGO_ENABLED='0'\ngo test ./...\ngo test -race ./internal/...",
+			"ci-web-lint=set -e\nexport CGO_ENABLED='0'\n(cd 'web'\nnpm ci\n)\n(cd 'web'\nnpm run lint\n)",
+			"ci-nightly-build=set -e\nmake nightly",
+		}, "\n")
+		if got := gateSummary(gates); got != want {
+			t.Errorf("gates =\n%s\nwant\n%s", got, want)
+		}
+		byName := make(map[string]*Gate)
+		for _, g := range gates {
+			byName[g.Name] = g
+		}
+		if g := byName["ci-test"]; g.Type != GateTest || !g.Required || g.Timeout != 15*time.Minute {
+			t.Errorf("ci-test = %+v", g)
+		}
+		if g := byName["ci-web-lint"]; g.Type != GateLint || g.Required {
+			t.Errorf("ci-web-lint = %+v", g)
+		}
+	})
+
+	t.Run("act", func(t *testing.T) {
+		want := strings.Join([]string{
+			"ci-build=act -W '.github/workflows/ci.yml' -j 'build'",
+			"ci-integration=act -W '.github/workflows/ci.yml' -j 'integration'",
+			"ci-test=act -W '.github/workflows/ci.yml' -j 'test'",
+			"ci-web-lint=act -W '.github/workflows/ci.yml' -j 'web-lint'",
+			"ci-nightly-build=act -W '.github/workflows/nightly.yaml' -j 'build'",
+		}, "\n")
+		if got := gateSummary(DetectWorkflowGates(dir, LocalCIAct)); got != want {
+			t.Errorf("gates =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("no workflows", func(t *testing.T) {
+		if gates := DetectWorkflowGates(t.TempDir(), LocalCIDirect); len(gates) != 0 {
+			t.Errorf("gates = %s", gateSummary(gates))
+		}
+	})
+}
+
+func TestConfig_WithLocalCIGates(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{".github/workflows/ci.yml": ciWorkflow})
+
+	cfg := &Config{Enabled: true, Gates: []*Gate{{Name: "ci-build", Type: GateBuild, Command: "make build"}}}
+	if got := cfg.WithLocalCIGates(dir); got != cfg {
+		t.Error("config without local_ci should be returned unchanged")
+	}
+
+	cfg.LocalCI = true
+	got := cfg.WithLocalCIGates(dir)
+	if want := "ci-build=make build\nci-test"; !strings.HasPrefix(gateSummary(got.Gates), want) || len(got.Gates) != 3 {
+		t.Errorf("gates =\n%s", gateSummary(got.Gates))
+	}
+	if len(cfg.Gates) != 1 {
+		t.Error("original config should not be modified")
+	}
+
+	cfg.LocalCIRunner = "docker"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject unknown local_ci_runner")
+	}
+}
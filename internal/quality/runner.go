@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -53,13 +54,17 @@ func (r *Runner) RunAll(ctx context.Context, taskID string) (*CheckResults, erro
 		}, nil
 	}
 
+	runs := expandMatrix(r.config.Gates)
 	results := &CheckResults{
 		TaskID:    taskID,
 		StartedAt: time.Now(),
-		Results:   make([]*Result, len(r.config.Gates)),
+		Results:   make([]*Result, len(runs)),
 	}
 
 	parallel := r.config.IsParallel()
+	scanSecurity := r.config.Security != nil && r.config.Security.Enabled
+	checkCoverage := r.config.CoverageDelta != nil && r.config.CoverageDelta.Enabled
+	var security, coverage *Result
 	mode := "parallel"
 	if !parallel {
 		mode = "sequential"
@@ -67,41 +72,82 @@ func (r *Runner) RunAll(ctx context.Context, taskID string) (*CheckResults, erro
 
 	r.log.Info("Starting quality gate checks",
 		slog.String("task_id", taskID),
-		slog.Int("gate_count", len(r.config.Gates)),
+		slog.Int("gate_count", len(runs)),
 		slog.String("mode", mode),
 	)
 
 	if parallel {
-		// Execute all gates in parallel
+		// Execute all gates (and matrix cells) in parallel
 		var wg sync.WaitGroup
-		for i, gate := range r.config.Gates {
+		for i, run := range runs {
 			wg.Add(1)
-			go func(idx int, g *Gate) {
+			go func(idx int, gr gateRun) {
 				defer wg.Done()
-				results.Results[idx] = r.runGate(ctx, g)
-			}(i, gate)
+				results.Results[idx] = r.runGate(ctx, gr.gate, gr.cell)
+			}(i, run)
+		}
+		if scanSecurity {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				security = r.runSecurity(ctx)
+			}()
+		}
+		if checkCoverage {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				coverage = r.runCoverageDelta(ctx)
+			}()
 		}
 		wg.Wait()
 	} else {
 		// Execute gates sequentially
-		for i, gate := range r.config.Gates {
-			results.Results[i] = r.runGate(ctx, gate)
+		for i, run := range runs {
+			results.Results[i] = r.runGate(ctx, run.gate, run.cell)
+		}
+		if scanSecurity {
+			security = r.runSecurity(ctx)
+		}
+		if checkCoverage {
+			coverage = r.runCoverageDelta(ctx)
 		}
 	}
 
-	// Evaluate results
+	// Evaluate results. A required matrix gate fails if any of its cells fails.
 	allPassed := true
 	for i, result := range results.Results {
-		gate := r.config.Gates[i]
-		if result.Status == StatusFailed && gate.Required {
+		if result.Status == StatusFailed && runs[i].gate.Required {
 			allPassed = false
 			r.log.Warn("Required quality gate failed",
-				slog.String("gate", gate.Name),
+				slog.String("gate", result.DisplayName()),
 				slog.String("error", result.Error),
 			)
 		}
 	}
 
+	// The security scan is always required: blocking findings stop the PR
+	if security != nil {
+		results.Results = append(results.Results, security)
+		if security.Status == StatusFailed {
+			allPassed = false
+			r.log.Warn("Security scan found blocking issues",
+				slog.String("error", security.Error),
+			)
+		}
+	}
+
+	// The coverage delta check is required too: a drop past max_drop fails
+	if coverage != nil {
+		results.Results = append(results.Results, coverage)
+		if coverage.Status == StatusFailed {
+			allPassed = false
+			r.log.Warn("Coverage dropped past the allowed delta",
+				slog.String("error", coverage.Error),
+			)
+		}
+	}
+
 	results.AllPassed = allPassed
 	results.CompletedAt = time.Now()
 	results.TotalTime = results.CompletedAt.Sub(results.StartedAt)
@@ -115,35 +161,88 @@ func (r *Runner) RunAll(ctx context.Context, taskID string) (*CheckResults, erro
 	return results, nil
 }
 
-// RunGate executes a single quality gate
+// gateRun is a single execution of a gate, for one matrix cell when the gate has a matrix.
+type gateRun struct {
+	gate *Gate
+	cell *MatrixCell
+}
+
+// expandMatrix returns one run per plain gate and one run per matrix cell.
+func expandMatrix(gates []*Gate) []gateRun {
+	runs := make([]gateRun, 0, len(gates))
+	for _, gate := range gates {
+		if len(gate.Matrix) == 0 {
+			runs = append(runs, gateRun{gate: gate})
+			continue
+		}
+		for i := range gate.Matrix {
+			runs = append(runs, gateRun{gate: gate, cell: &gate.Matrix[i]})
+		}
+	}
+	return runs
+}
+
+// RunGate executes a single quality gate. A matrix gate runs every cell
+// sequentially and returns the first failing cell's result, or the last
+// cell's result when all pass.
 func (r *Runner) RunGate(ctx context.Context, gateName string) (*Result, error) {
 	gate := r.config.GetGate(gateName)
 	if gate == nil {
 		return nil, ErrGateNotFound
 	}
-	return r.runGate(ctx, gate), nil
+	if len(gate.Matrix) == 0 {
+		return r.runGate(ctx, gate, nil), nil
+	}
+
+	var result *Result
+	for i := range gate.Matrix {
+		result = r.runGate(ctx, gate, &gate.Matrix[i])
+		if result.Status == StatusFailed {
+			break
+		}
+	}
+	return result, nil
 }
 
-// runGate executes a gate with retry logic
-func (r *Runner) runGate(ctx context.Context, gate *Gate) *Result {
+// runGate executes a gate with retry logic. cell is nil for non-matrix gates.
+func (r *Runner) runGate(ctx context.Context, gate *Gate, cell *MatrixCell) *Result {
 	result := &Result{
 		GateName:  gate.Name,
 		Status:    StatusRunning,
 		StartedAt: time.Now(),
 	}
+	if cell != nil {
+		result.Matrix = cell.Name
+	}
+	name := result.DisplayName()
+	command := gate.CommandFor(cell)
 
-	r.reportProgress(gate.Name, StatusRunning, fmt.Sprintf("Running %s gate...", gate.Name))
+	r.reportProgress(name, StatusRunning, fmt.Sprintf("Running %s gate...", name))
 
 	maxAttempts := gate.MaxRetries + 1
 	if maxAttempts < 1 {
 		maxAttempts = 1
 	}
 
+	// failed fingerprints the attempt's failure and reports whether retrying
+	// is pointless: the attempt failed exactly like the one before it.
+	failures := 0
+	failed := func() bool {
+		fingerprint := Fingerprint(result.Error + "\n" + result.Output)
+		deterministic := failures > 0 && fingerprint == result.Fingerprint
+		if failures > 0 && !deterministic {
+			result.Flaky = true
+		}
+		failures++
+		result.Fingerprint = fingerprint
+		return deterministic
+	}
+
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		if attempt > 0 {
 			result.RetryCount = attempt
 			result.Status = StatusRetrying
-			r.reportProgress(gate.Name, StatusRetrying, fmt.Sprintf("Retrying %s (attempt %d/%d)...", gate.Name, attempt+1, maxAttempts))
+			r.reportProgress(name, StatusRetrying, fmt.Sprintf("Retrying %s (attempt %d/%d)...", name, attempt+1, maxAttempts))
 
 			// Wait before retry
 			if gate.RetryDelay > 0 {
@@ -160,12 +259,12 @@ func (r *Runner) runGate(ctx context.Context, gate *Gate) *Result {
 		}
 
 		r.log.Debug("Executing gate command",
-			slog.String("gate", gate.Name),
-			slog.String("command", gate.Command),
+			slog.String("gate", name),
+			slog.String("command", command),
 			slog.Int("attempt", attempt+1),
 		)
 
-		exitCode, output, err := r.executeCommand(ctx, gate)
+		exitCode, output, err := r.executeCommand(ctx, gate, command, cell)
 
 		result.ExitCode = exitCode
 		result.Output = output
@@ -178,12 +277,16 @@ func (r *Runner) runGate(ctx context.Context, gate *Gate) *Result {
 			}
 			// Command execution error (not exit code error)
 			result.Error = err.Error()
+			if failed() {
+				r.stopRetrying(result, name)
+				break
+			}
 			continue
 		}
 
 		if exitCode == 0 {
 			result.Status = StatusPassed
-			r.reportProgress(gate.Name, StatusPassed, fmt.Sprintf("%s gate passed", gate.Name))
+			r.reportProgress(name, StatusPassed, fmt.Sprintf("%s gate passed", name))
 
 			// Parse coverage if this is a coverage gate
 			if gate.Type == GateCoverage {
@@ -191,21 +294,31 @@ func (r *Runner) runGate(ctx context.Context, gate *Gate) *Result {
 				if gate.Threshold > 0 && result.Coverage < gate.Threshold {
 					result.Status = StatusFailed
 					result.Error = fmt.Sprintf("coverage %.1f%% below threshold %.1f%%", result.Coverage, gate.Threshold)
-					r.reportProgress(gate.Name, StatusFailed, result.Error)
+					r.reportProgress(name, StatusFailed, result.Error)
+					if failed() {
+						r.stopRetrying(result, name)
+						break
+					}
 					continue
 				}
 			}
 
+			// Passing after a failure means the failure was not deterministic
+			result.Flaky = failures > 0
 			break
 		}
 
 		// Exit code != 0
 		result.Error = fmt.Sprintf("command exited with code %d", exitCode)
+		if failed() {
+			r.stopRetrying(result, name)
+			break
+		}
 
 		// Don't retry on last attempt
 		if attempt == maxAttempts-1 {
 			result.Status = StatusFailed
-			r.reportProgress(gate.Name, StatusFailed, fmt.Sprintf("%s gate failed: %s", gate.Name, result.Error))
+			r.reportProgress(name, StatusFailed, fmt.Sprintf("%s gate failed: %s", name, result.Error))
 		}
 	}
 
@@ -215,15 +328,38 @@ func (r *Runner) runGate(ctx context.Context, gate *Gate) *Result {
 	return result
 }
 
-// executeCommand runs the gate command
-func (r *Runner) executeCommand(ctx context.Context, gate *Gate) (int, string, error) {
+// stopRetrying fails a gate whose failure repeated with the same
+// fingerprint. Retrying a deterministic failure only burns time.
+func (r *Runner) stopRetrying(result *Result, name string) {
+	result.Status = StatusFailed
+	r.log.Info("Gate failure is deterministic, skipping remaining retries",
+		slog.String("gate", name),
+		slog.String("fingerprint", result.Fingerprint),
+		slog.Int("retries", result.RetryCount),
+	)
+	r.reportProgress(name, StatusFailed, fmt.Sprintf("%s gate failed the same way twice: %s", name, result.Error))
+}
+
+// executeCommand runs the gate command, with the matrix cell's environment if any
+func (r *Runner) executeCommand(ctx context.Context, gate *Gate, command string, cell *MatrixCell) (int, string, error) {
+	return r.executeCommandIn(ctx, r.projectDir, gate, command, cell)
+}
+
+// executeCommandIn runs the gate command in dir
+func (r *Runner) executeCommandIn(ctx context.Context, dir string, gate *Gate, command string, cell *MatrixCell) (int, string, error) {
 	timeout := gate.DefaultTimeout()
 	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Use shell to execute command (supports pipes, redirects, etc.)
-	cmd := exec.CommandContext(cmdCtx, "sh", "-c", gate.Command)
-	cmd.Dir = r.projectDir
+	cmd := exec.CommandContext(cmdCtx, "sh", "-c", command)
+	cmd.Dir = dir
+	if cell != nil && len(cell.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range cell.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -257,47 +393,57 @@ func (r *Runner) executeCommand(ctx context.Context, gate *Gate) (int, string, e
 
 // parseCoverageOutput extracts coverage percentage from command output
 func parseCoverageOutput(output string) float64 {
+	coverage, _ := parseCoverage(output)
+	return coverage
+}
+
+// parseCoverage extracts the last coverage percentage reported in command
+// output and whether one was found
+func parseCoverage(output string) (float64, bool) {
 	// Go coverage patterns
 	// "coverage: 85.3% of statements"
 	// "ok  	pkg	0.123s	coverage: 85.3% of statements"
-	goPattern := regexp.MustCompile(`coverage:\s*([\d.]+)%`)
+	// "total:	(statements)	85.3%" (go tool cover -func)
+	goPattern := regexp.MustCompile(`(?:coverage:|^total:\s+\(statements\))\s*([\d.]+)%`)
 
 	// Jest/NYC patterns
 	// "All files |   85.3 |   80.0 |   90.0 |   85.3 |"
 	// "Statements   : 85.3% ( 100/117 )"
 	jestPattern := regexp.MustCompile(`(?:Statements|Lines)\s*:\s*([\d.]+)%`)
 
-	// Python coverage patterns
+	// Python and cargo-llvm-cov coverage patterns
 	// "TOTAL                                              85%"
 	// "TOTAL         100      15      85%"
-	pyPattern := regexp.MustCompile(`TOTAL\s+.*?(\d+)%`)
+	// "TOTAL   1234   56   95.46%   ..."
+	pyPattern := regexp.MustCompile(`TOTAL\s+.*?([\d.]+)%`)
 
 	scanner := bufio.NewScanner(strings.NewReader(output))
 	var coverage float64
+	found := false
 
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		if matches := goPattern.FindStringSubmatch(line); len(matches) > 1 {
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
-				coverage = val
+				coverage, found = val, true
 			}
 		}
 
 		if matches := jestPattern.FindStringSubmatch(line); len(matches) > 1 {
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
-				coverage = val
+				coverage, found = val, true
 			}
 		}
 
 		if matches := pyPattern.FindStringSubmatch(line); len(matches) > 1 {
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
-				coverage = val
+				coverage, found = val, true
 			}
 		}
 	}
 
-	return coverage
+	return coverage, found
 }
 
 // reportProgress sends progress update via callback
@@ -325,7 +471,7 @@ func FormatErrorFeedback(results *CheckResults) string {
 			continue
 		}
 
-		sb.WriteString(fmt.Sprintf("### %s Gate (FAILED)\n\n", result.GateName))
+		sb.WriteString(fmt.Sprintf("### %s Gate (FAILED)\n\n", result.DisplayName()))
 		sb.WriteString("**Error Output:**\n```\n")
 
 		// Truncate output if too long
@@ -3,6 +3,7 @@ package quality
 import (
 	"context"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -101,6 +102,65 @@ func TestRunner_RunAll_FailingRequiredGate(t *testing.T) {
 	}
 }
 
+func TestRunner_RunAll_MatrixGate(t *testing.T) {
+	config := &Config{
+		Enabled: true,
+		Gates: []*Gate{
+			{Name: "build", Type: GateBuild, Command: "true", Required: true},
+			{
+				Name:     "test",
+				Type:     GateTest,
+				Command:  `test "$TOOLCHAIN" != old`,
+				Required: true,
+				Matrix: []MatrixCell{
+					{Name: "old", Env: map[string]string{"TOOLCHAIN": "old"}},
+					{Name: "new", Env: map[string]string{"TOOLCHAIN": "new"}},
+					{Name: "wrapped", Command: "echo wrapped && {command}"},
+				},
+			},
+		},
+	}
+
+	runner := NewRunner(config, "/tmp")
+	results, err := runner.RunAll(context.Background(), "test-task")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.AllPassed {
+		t.Error("expected AllPassed to be false when one matrix cell fails")
+	}
+	if len(results.Results) != 4 {
+		t.Fatalf("expected 4 results (build + 3 cells), got %d", len(results.Results))
+	}
+
+	want := map[string]GateStatus{
+		"build":          StatusPassed,
+		"test (old)":     StatusFailed,
+		"test (new)":     StatusPassed,
+		"test (wrapped)": StatusPassed,
+	}
+	for _, r := range results.Results {
+		if r.Status != want[r.DisplayName()] {
+			t.Errorf("%s: status = %s, want %s", r.DisplayName(), r.Status, want[r.DisplayName()])
+		}
+	}
+	if !strings.Contains(results.Results[3].Output, "wrapped") {
+		t.Errorf("wrapped cell output = %q, want cell command output", results.Results[3].Output)
+	}
+	if feedback := FormatErrorFeedback(results); !strings.Contains(feedback, "### test (old) Gate (FAILED)") {
+		t.Errorf("feedback should name the failing cell:\n%s", feedback)
+	}
+
+	// RunGate stops at the first failing cell
+	result, err := runner.RunGate(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("RunGate: %v", err)
+	}
+	if result.Matrix != "old" || result.Status != StatusFailed {
+		t.Errorf("RunGate = %s/%s, want failed old cell", result.Matrix, result.Status)
+	}
+}
+
 func TestRunner_RunAll_FailingOptionalGate(t *testing.T) {
 	config := &Config{
 		Enabled: true,
@@ -128,14 +188,15 @@ func TestRunner_RunAll_FailingOptionalGate(t *testing.T) {
 }
 
 func TestRunner_RunGate_WithRetry(t *testing.T) {
-	// Use a file to track attempts
+	// Use a file to track attempts, so every attempt fails differently
+	counter := filepath.Join(t.TempDir(), "attempts")
 	config := &Config{
 		Enabled: true,
 		Gates: []*Gate{
 			{
 				Name:       "retry-test",
 				Type:       GateCustom,
-				Command:    "exit 1",
+				Command:    "echo x >> " + counter + " && wc -l < " + counter + " && exit 1",
 				Required:   true,
 				Timeout:    5 * time.Second,
 				MaxRetries: 2,
@@ -156,6 +217,77 @@ func TestRunner_RunGate_WithRetry(t *testing.T) {
 	if result.RetryCount != 2 {
 		t.Errorf("expected 2 retries, got %d", result.RetryCount)
 	}
+	if !result.Flaky {
+		t.Error("expected gate failing with differing output to be flaky")
+	}
+}
+
+func TestRunner_RunGate_DeterministicFailureSkipsRetries(t *testing.T) {
+	config := &Config{
+		Enabled: true,
+		Gates: []*Gate{
+			{
+				Name:       "deterministic",
+				Type:       GateCustom,
+				Command:    "echo 'main_test.go:12: expected 2, got 3' && exit 1",
+				Required:   true,
+				Timeout:    5 * time.Second,
+				MaxRetries: 3,
+			},
+		},
+	}
+
+	runner := NewRunner(config, "/tmp")
+	result, err := runner.RunGate(context.Background(), "deterministic")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StatusFailed {
+		t.Errorf("expected status Failed, got %s", result.Status)
+	}
+	if result.RetryCount != 1 {
+		t.Errorf("expected to stop after 1 retry, got %d", result.RetryCount)
+	}
+	if result.Flaky {
+		t.Error("expected deterministic failure not to be flaky")
+	}
+	if result.Fingerprint == "" {
+		t.Error("expected failure fingerprint")
+	}
+}
+
+func TestRunner_RunGate_PassOnRetryIsFlaky(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	config := &Config{
+		Enabled: true,
+		Gates: []*Gate{
+			{
+				Name:       "flaky",
+				Type:       GateCustom,
+				Command:    "test -f " + marker + " || { touch " + marker + "; echo 'connection reset'; exit 1; }",
+				Required:   true,
+				Timeout:    5 * time.Second,
+				MaxRetries: 2,
+			},
+		},
+	}
+
+	runner := NewRunner(config, "/tmp")
+	result, err := runner.RunGate(context.Background(), "flaky")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StatusPassed {
+		t.Errorf("expected status Passed, got %s", result.Status)
+	}
+	if !result.Flaky {
+		t.Error("expected gate passing on retry to be flaky")
+	}
+	if result.Fingerprint != Fingerprint("command exited with code 1\nconnection reset\n") {
+		t.Errorf("expected fingerprint of the failed attempt, got %q", result.Fingerprint)
+	}
 }
 
 func TestRunner_RunGate_ContextCancellation(t *testing.T) {
@@ -0,0 +1,777 @@
+package quality
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// SecurityGateName is the name reported for the built-in security scan.
+const SecurityGateName = "security-scan"
+
+// Severity ranks a security finding
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// AtLeast returns true if s is as severe as min or more
+func (s Severity) AtLeast(min Severity) bool {
+	return s.rank() >= min.rank()
+}
+
+// FindingKind identifies what the security scan found
+type FindingKind string
+
+const (
+	FindingSecret     FindingKind = "secret"
+	FindingDependency FindingKind = "dependency"
+)
+
+// Finding is a single issue reported by the security scan
+type Finding struct {
+	Kind     FindingKind `json:"kind"`
+	Severity Severity    `json:"severity"`
+	Rule     string      `json:"rule"` // Secret rule name or advisory ID
+	File     string      `json:"file,omitempty"`
+	Line     int         `json:"line,omitempty"`
+	Package  string      `json:"package,omitempty"`
+	Version  string      `json:"version,omitempty"`
+	FixedIn  string      `json:"fixed_in,omitempty"`
+	Message  string      `json:"message"` // Never contains the full secret
+}
+
+// Location returns file:line for secrets and package@version for dependencies.
+func (f Finding) Location() string {
+	if f.Kind == FindingDependency {
+		if f.Version == "" {
+			return f.Package
+		}
+		return f.Package + "@" + f.Version
+	}
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return f.File
+}
+
+// SecurityConfig configures the built-in security scan, which runs alongside
+// the command gates when quality gates are enabled.
+//
+// Example YAML configuration:
+//
+//	quality:
+//	  security:
+//	    enabled: true
+//	    block_on: high
+//	    allowlist: ["^testdata/", "GO-2023-1840"]
+type SecurityConfig struct {
+	Enabled      bool          `yaml:"enabled" json:"enabled"`
+	Secrets      *bool         `yaml:"secrets" json:"secrets"`           // Scan the diff for secrets (default: true)
+	Dependencies *bool         `yaml:"dependencies" json:"dependencies"` // Audit dependencies (default: true)
+	BlockOn      Severity      `yaml:"block_on" json:"block_on"`         // Lowest severity that blocks the PR (default: high)
+	BaseBranch   string        `yaml:"base_branch" json:"base_branch"`   // Branch the diff is taken against (default: auto-detect)
+	Allowlist    []string      `yaml:"allowlist" json:"allowlist"`       // Regexes for files, rules or packages to ignore
+	Timeout      time.Duration `yaml:"timeout" json:"timeout"`           // Max time per dependency audit (default: 5m)
+}
+
+// ScansSecrets returns whether the diff is scanned for secrets.
+// Defaults to true if not explicitly set.
+func (c *SecurityConfig) ScansSecrets() bool {
+	return c.Secrets == nil || *c.Secrets
+}
+
+// ScansDependencies returns whether dependencies are audited.
+// Defaults to true if not explicitly set.
+func (c *SecurityConfig) ScansDependencies() bool {
+	return c.Dependencies == nil || *c.Dependencies
+}
+
+// BlockSeverity returns the lowest severity that fails the scan
+func (c *SecurityConfig) BlockSeverity() Severity {
+	if c.BlockOn == "" {
+		return SeverityHigh
+	}
+	return c.BlockOn
+}
+
+func (c *SecurityConfig) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return 5 * time.Minute
+}
+
+// Validate checks the block severity and allowlist patterns
+func (c *SecurityConfig) Validate() error {
+	if c.BlockOn != "" && c.BlockOn.rank() == 0 {
+		return fmt.Errorf("security block_on must be low, medium, high or critical, got %q", c.BlockOn)
+	}
+	for _, pattern := range c.Allowlist {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid security allowlist pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// BlockingFindings returns the findings at or above min severity
+func BlockingFindings(findings []Finding, min Severity) []Finding {
+	var blocking []Finding
+	for _, f := range findings {
+		if f.Severity.AtLeast(min) {
+			blocking = append(blocking, f)
+		}
+	}
+	return blocking
+}
+
+// SecurityScanner detects secrets added by the diff and known vulnerabilities
+// in the project's dependencies.
+type SecurityScanner struct {
+	config     *SecurityConfig
+	projectDir string
+	allowlist  []*regexp.Regexp
+	log        *slog.Logger
+
+	// lookPath and run are replaced in tests
+	lookPath func(file string) (string, error)
+	run      func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+}
+
+// NewSecurityScanner creates a scanner for projectDir
+func NewSecurityScanner(config *SecurityConfig, projectDir string) *SecurityScanner {
+	s := &SecurityScanner{
+		config:     config,
+		projectDir: projectDir,
+		log:        logging.WithComponent("quality"),
+		lookPath:   exec.LookPath,
+		run:        runTool,
+	}
+	for _, pattern := range config.Allowlist {
+		if re, err := regexp.Compile(pattern); err == nil {
+			s.allowlist = append(s.allowlist, re)
+		}
+	}
+	return s
+}
+
+// Scan runs the enabled scans and returns their findings, most severe first.
+// Notes describe scans that could not run, e.g. an audit tool that is not
+// installed; they never fail the scan.
+func (s *SecurityScanner) Scan(ctx context.Context) ([]Finding, []string) {
+	var findings []Finding
+	var notes []string
+
+	if s.config.ScansSecrets() {
+		diff, err := s.diff(ctx)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("secret scan skipped: %v", err))
+		} else {
+			findings = append(findings, ScanSecrets(diff)...)
+		}
+	}
+
+	if s.config.ScansDependencies() {
+		for _, a := range auditors {
+			if !fileExists(filepath.Join(s.projectDir, a.marker)) {
+				continue
+			}
+			if _, err := s.lookPath(a.binary); err != nil {
+				notes = append(notes, fmt.Sprintf("%s found but %s is not installed, dependency audit skipped", a.marker, a.binary))
+				continue
+			}
+			found, err := s.audit(ctx, a)
+			if err != nil {
+				s.log.Warn("Dependency audit failed", slog.String("tool", a.binary), slog.Any("error", err))
+				notes = append(notes, fmt.Sprintf("%s audit failed: %v", a.binary, err))
+				continue
+			}
+			findings = append(findings, found...)
+		}
+	}
+
+	kept := findings[:0]
+	for _, f := range findings {
+		if !s.allowed(f) {
+			kept = append(kept, f)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Severity.rank() > kept[j].Severity.rank() })
+	return kept, notes
+}
+
+func (s *SecurityScanner) allowed(f Finding) bool {
+	for _, re := range s.allowlist {
+		for _, field := range []string{f.File, f.Rule, f.Package} {
+			if field != "" && re.MatchString(field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// diff returns the changes since the branch left its base, including
+// uncommitted ones. Without a known base only uncommitted changes are scanned.
+func (s *SecurityScanner) diff(ctx context.Context) (string, error) {
+	from := "HEAD"
+	for _, base := range baseCandidates(s.config.BaseBranch) {
+		out, err := s.run(ctx, s.projectDir, "git", "merge-base", "HEAD", base)
+		if err == nil {
+			from = strings.TrimSpace(string(out))
+			break
+		}
+	}
+	out, err := s.run(ctx, s.projectDir, "git", "diff", "--no-color", "--no-ext-diff", "-U0", from)
+	if err != nil {
+		return "", fmt.Errorf("git diff failed: %w", err)
+	}
+	return string(out), nil
+}
+
+func (s *SecurityScanner) audit(ctx context.Context, a auditor) ([]Finding, error) {
+	auditCtx, cancel := context.WithTimeout(ctx, s.config.timeout())
+	defer cancel()
+
+	// Audit tools exit non-zero when they find vulnerabilities, so the
+	// output decides: a report that parses is a result
+	out, runErr := s.run(auditCtx, s.projectDir, a.command[0], a.command[1:]...)
+	if auditCtx.Err() == context.DeadlineExceeded {
+		return nil, ErrGateTimeout
+	}
+	findings, err := a.parse(out)
+	if err != nil {
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, fmt.Errorf("unreadable report: %w", err)
+	}
+	return findings, nil
+}
+
+// runTool runs a command in dir and returns its stdout
+func runTool(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.Bytes(), fmt.Errorf("%w: %s", err, firstLine(msg))
+		}
+		return stdout.Bytes(), err
+	}
+	return stdout.Bytes(), nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// secretRule detects one kind of secret on an added line
+type secretRule struct {
+	name     string
+	desc     string
+	severity Severity
+	pattern  *regexp.Regexp
+	// generic rules match assignments rather than a known token format. They
+	// only report lines no specific rule matched, and require the value to
+	// look random, which filters out placeholders like "changeme"
+	generic bool
+}
+
+var secretRules = []secretRule{
+	{name: "private-key", desc: "Private key", severity: SeverityCritical,
+		pattern: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY( BLOCK)?-----`)},
+	{name: "aws-access-key", desc: "AWS access key ID", severity: SeverityHigh,
+		pattern: regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{name: "github-token", desc: "GitHub token", severity: SeverityHigh,
+		pattern: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{name: "slack-token", desc: "Slack token", severity: SeverityHigh,
+		pattern: regexp.MustCompile(`\b(xox[abprs]-[A-Za-z0-9-]{10,})`)},
+	{name: "stripe-key", desc: "Stripe live key", severity: SeverityHigh,
+		pattern: regexp.MustCompile(`\b([rs]k_live_[A-Za-z0-9]{20,})\b`)},
+	{name: "google-api-key", desc: "Google API key", severity: SeverityHigh,
+		pattern: regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`)},
+	{name: "llm-api-key", desc: "Anthropic or OpenAI API key", severity: SeverityHigh,
+		pattern: regexp.MustCompile(`\b(sk-(?:ant-|proj-)?[A-Za-z0-9_-]{32,})`)},
+	{name: "generic-secret", desc: "Hardcoded credential", severity: SeverityMedium, generic: true,
+		pattern: regexp.MustCompile(`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d|credential)s?["']?\s*(?::=|[:=])\s*["']([^"'\s]{12,})["']`)},
+}
+
+// highEntropyString matches long quoted tokens checked by entropy alone
+var highEntropyString = regexp.MustCompile(`["'` + "`" + `]([A-Za-z0-9+/=_-]{32,})["'` + "`" + `]`)
+
+// minAssignmentEntropy is the Shannon entropy, in bits per character, a
+// value assigned to a credential-like name needs to be reported
+const minAssignmentEntropy = 3.5
+
+// minStringEntropy is the Shannon entropy, in bits per character, above
+// which a quoted token is reported as a possible secret. Hex digests stay
+// below it.
+const minStringEntropy = 4.5
+
+// allowSecretMarker on a line suppresses secret findings for it
+const allowSecretMarker = "pilot:allow-secret"
+
+// secretScanSkipped lists generated files full of checksums
+var secretScanSkipped = map[string]bool{
+	"go.sum":            true,
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"Cargo.lock":        true,
+	"poetry.lock":       true,
+	"composer.lock":     true,
+	"Gemfile.lock":      true,
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)`)
+
+// ScanSecrets reports secrets on the lines a unified diff adds
+func ScanSecrets(diff string) []Finding {
+	var findings []Finding
+	var file string
+	var line int
+	for _, text := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(text, "+++ "), "b/")
+			if file == "/dev/null" || secretScanSkipped[path.Base(file)] {
+				file = ""
+			}
+		case strings.HasPrefix(text, "@@"):
+			if m := hunkHeader.FindStringSubmatch(text); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+		case strings.HasPrefix(text, "+"):
+			if file != "" {
+				findings = append(findings, scanLine(file, line, text[1:])...)
+			}
+			line++
+		case strings.HasPrefix(text, " "):
+			line++
+		}
+	}
+	return findings
+}
+
+func scanLine(file string, line int, text string) []Finding {
+	if strings.Contains(text, allowSecretMarker) {
+		return nil
+	}
+	var findings []Finding
+	for _, rule := range secretRules {
+		if rule.generic && len(findings) > 0 {
+			continue
+		}
+		m := rule.pattern.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		value := m[len(m)-1]
+		if value == "" {
+			value = m[0]
+		}
+		if rule.generic && (shannonEntropy(value) < minAssignmentEntropy || isPlaceholder(value)) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Kind:     FindingSecret,
+			Severity: rule.severity,
+			Rule:     rule.name,
+			File:     file,
+			Line:     line,
+			Message:  fmt.Sprintf("%s %s", rule.desc, maskSecret(value)),
+		})
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	for _, m := range highEntropyString.FindAllStringSubmatch(text, -1) {
+		if shannonEntropy(m[1]) >= minStringEntropy {
+			findings = append(findings, Finding{
+				Kind:     FindingSecret,
+				Severity: SeverityLow,
+				Rule:     "high-entropy-string",
+				File:     file,
+				Line:     line,
+				Message:  "High-entropy string " + maskSecret(m[1]),
+			})
+		}
+	}
+	return findings
+}
+
+// isPlaceholder returns true for values that reference a secret rather than contain one
+func isPlaceholder(value string) bool {
+	lower := strings.ToLower(value)
+	for _, marker := range []string{"${", "{{", "<", "example", "placeholder", "changeme", "xxxx", "your_", "your-"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return strings.HasPrefix(value, "$") || strings.HasPrefix(value, "env:")
+}
+
+// shannonEntropy returns the entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// maskSecret keeps only the first characters of a secret for reports
+func maskSecret(s string) string {
+	if len(s) <= 8 {
+		return "****"
+	}
+	return s[:4] + "****"
+}
+
+// auditor runs one language's dependency audit tool
+type auditor struct {
+	marker  string   // File whose presence enables the audit
+	binary  string   // Executable that must be installed
+	command []string // Command producing a JSON report
+	parse   func([]byte) ([]Finding, error)
+}
+
+var auditors = []auditor{
+	{marker: "go.mod", binary: "govulncheck", command: []string{"govulncheck", "-json", "./..."}, parse: parseGovulncheck},
+	{marker: "package-lock.json", binary: "npm", command: []string{"npm", "audit", "--json"}, parse: parseNpmAudit},
+	{marker: "requirements.txt", binary: "pip-audit", command: []string{"pip-audit", "-r", "requirements.txt", "-f", "json"}, parse: parsePipAudit},
+	{marker: "Cargo.lock", binary: "cargo-audit", command: []string{"cargo", "audit", "--json"}, parse: parseCargoAudit},
+}
+
+// parseGovulncheck reads the govulncheck -json message stream. Vulnerable
+// code the project calls is high severity, an imported vulnerable package
+// medium and a vulnerable module that is only required low.
+func parseGovulncheck(out []byte) ([]Finding, error) {
+	type frame struct {
+		Module   string `json:"module"`
+		Version  string `json:"version"`
+		Package  string `json:"package"`
+		Function string `json:"function"`
+	}
+	type message struct {
+		OSV *struct {
+			ID      string `json:"id"`
+			Summary string `json:"summary"`
+		} `json:"osv"`
+		Finding *struct {
+			OSV          string  `json:"osv"`
+			FixedVersion string  `json:"fixed_version"`
+			Trace        []frame `json:"trace"`
+		} `json:"finding"`
+	}
+
+	summaries := make(map[string]string)
+	byID := make(map[string]*Finding)
+	var order []string
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if msg.OSV != nil {
+			summaries[msg.OSV.ID] = msg.OSV.Summary
+		}
+		if msg.Finding == nil || len(msg.Finding.Trace) == 0 {
+			continue
+		}
+		top := msg.Finding.Trace[0]
+		severity := SeverityLow
+		if top.Function != "" {
+			severity = SeverityHigh
+		} else if top.Package != "" {
+			severity = SeverityMedium
+		}
+		if existing, ok := byID[msg.Finding.OSV]; ok {
+			if severity.rank() > existing.Severity.rank() {
+				existing.Severity = severity
+			}
+			continue
+		}
+		byID[msg.Finding.OSV] = &Finding{
+			Kind:     FindingDependency,
+			Severity: severity,
+			Rule:     msg.Finding.OSV,
+			Package:  top.Module,
+			Version:  top.Version,
+			FixedIn:  msg.Finding.FixedVersion,
+		}
+		order = append(order, msg.Finding.OSV)
+	}
+
+	findings := make([]Finding, 0, len(order))
+	for _, id := range order {
+		f := byID[id]
+		f.Message = summaries[id]
+		findings = append(findings, *f)
+	}
+	return findings, nil
+}
+
+// parseNpmAudit reads the npm audit --json report (npm 7+)
+func parseNpmAudit(out []byte) ([]Finding, error) {
+	var report struct {
+		Vulnerabilities map[string]struct {
+			Name     string            `json:"name"`
+			Severity string            `json:"severity"`
+			Range    string            `json:"range"`
+			Via      []json.RawMessage `json:"via"`
+		} `json:"vulnerabilities"`
+		Error *struct {
+			Summary string `json:"summary"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, err
+	}
+	if report.Error != nil {
+		return nil, errors.New(report.Error.Summary)
+	}
+
+	names := make([]string, 0, len(report.Vulnerabilities))
+	for name := range report.Vulnerabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []Finding
+	for _, name := range names {
+		v := report.Vulnerabilities[name]
+		f := Finding{
+			Kind:     FindingDependency,
+			Severity: npmSeverity(v.Severity),
+			Package:  name,
+			Message:  "vulnerable versions " + v.Range,
+		}
+		var via []string
+		for _, raw := range v.Via {
+			var advisory struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			}
+			if json.Unmarshal(raw, &advisory) == nil && advisory.Title != "" {
+				if f.Rule == "" {
+					f.Rule = path.Base(advisory.URL)
+					f.Message = advisory.Title
+				}
+				continue
+			}
+			var dep string
+			if json.Unmarshal(raw, &dep) == nil {
+				via = append(via, dep)
+			}
+		}
+		if f.Rule == "" {
+			// Vulnerable only through its dependencies
+			f.Rule = "npm-audit"
+			f.Message = "depends on vulnerable " + strings.Join(via, ", ")
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+func npmSeverity(s string) Severity {
+	switch s {
+	case "critical":
+		return SeverityCritical
+	case "high":
+		return SeverityHigh
+	case "moderate":
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// parsePipAudit reads the pip-audit -f json report. pip-audit does not rate
+// vulnerabilities, so every known one is treated as high severity.
+func parsePipAudit(out []byte) ([]Finding, error) {
+	var report struct {
+		Dependencies []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Vulns   []struct {
+				ID          string   `json:"id"`
+				FixVersions []string `json:"fix_versions"`
+				Description string   `json:"description"`
+			} `json:"vulns"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, dep := range report.Dependencies {
+		for _, v := range dep.Vulns {
+			findings = append(findings, Finding{
+				Kind:     FindingDependency,
+				Severity: SeverityHigh,
+				Rule:     v.ID,
+				Package:  dep.Name,
+				Version:  dep.Version,
+				FixedIn:  strings.Join(v.FixVersions, ", "),
+				Message:  truncate(firstLine(v.Description), 160),
+			})
+		}
+	}
+	return findings, nil
+}
+
+// parseCargoAudit reads the cargo audit --json report. Advisories are
+// treated as high severity.
+func parseCargoAudit(out []byte) ([]Finding, error) {
+	var report struct {
+		Vulnerabilities struct {
+			List []struct {
+				Advisory struct {
+					ID    string `json:"id"`
+					Title string `json:"title"`
+				} `json:"advisory"`
+				Package struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+				} `json:"package"`
+				Versions struct {
+					Patched []string `json:"patched"`
+				} `json:"versions"`
+			} `json:"list"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, v := range report.Vulnerabilities.List {
+		findings = append(findings, Finding{
+			Kind:     FindingDependency,
+			Severity: SeverityHigh,
+			Rule:     v.Advisory.ID,
+			Package:  v.Package.Name,
+			Version:  v.Package.Version,
+			FixedIn:  strings.Join(v.Versions.Patched, ", "),
+			Message:  v.Advisory.Title,
+		})
+	}
+	return findings, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// FormatFindings lists findings one per line
+func FormatFindings(findings []Finding) string {
+	var sb strings.Builder
+	for _, f := range findings {
+		sb.WriteString(fmt.Sprintf("[%s] %s %s at %s", f.Severity, f.Kind, f.Rule, f.Location()))
+		if f.FixedIn != "" {
+			sb.WriteString(fmt.Sprintf(" (fixed in %s)", f.FixedIn))
+		}
+		if f.Message != "" {
+			sb.WriteString(": " + f.Message)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// runSecurity runs the built-in security scan as a gate. It fails when a
+// finding reaches the configured block severity.
+func (r *Runner) runSecurity(ctx context.Context) *Result {
+	result := &Result{
+		GateName:  SecurityGateName,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+	}
+	r.reportProgress(SecurityGateName, StatusRunning, "Scanning for secrets and vulnerable dependencies...")
+
+	cfg := r.config.Security
+	findings, notes := NewSecurityScanner(cfg, r.projectDir).Scan(ctx)
+	result.Findings = findings
+
+	var sb strings.Builder
+	sb.WriteString(FormatFindings(findings))
+	for _, note := range notes {
+		sb.WriteString("note: " + note + "\n")
+	}
+
+	blocking := BlockingFindings(findings, cfg.BlockSeverity())
+	if len(blocking) > 0 {
+		result.Status = StatusFailed
+		result.ExitCode = 1
+		result.Error = fmt.Sprintf("%d security finding(s) at or above %s severity", len(blocking), cfg.BlockSeverity())
+		sb.WriteString("\nRemove hardcoded secrets and read them from the environment or a secret store; upgrade vulnerable dependencies to a fixed version.\n")
+		r.reportProgress(SecurityGateName, StatusFailed, result.Error)
+	} else {
+		result.Status = StatusPassed
+		r.reportProgress(SecurityGateName, StatusPassed, fmt.Sprintf("%s gate passed with %d finding(s)", SecurityGateName, len(findings)))
+	}
+	result.Output = sb.String()
+	result.CompletedAt = time.Now()
+	result.Duration = result.CompletedAt.Sub(result.StartedAt)
+	return result
+}
@@ -4,8 +4,10 @@ package quality
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -53,6 +55,27 @@ type Gate struct {
 	MaxRetries  int           `yaml:"max_retries" json:"max_retries"`   // Retry count on failure
 	RetryDelay  time.Duration `yaml:"retry_delay" json:"retry_delay"`   // Delay between retries
 	FailureHint string        `yaml:"failure_hint" json:"failure_hint"` // Hint for Claude on failure
+	Matrix      []MatrixCell  `yaml:"matrix" json:"matrix"`             // Run once per toolchain version
+}
+
+// MatrixCell is one toolchain variant of a matrix gate, e.g. Go 1.22 or Node 18.
+// A matrix gate runs its command once per cell and fails if any cell fails.
+type MatrixCell struct {
+	Name    string            `yaml:"name" json:"name"`       // Label shown in reports, e.g. "go1.22"
+	Command string            `yaml:"command" json:"command"` // Toolchain command; "{command}" expands to the gate command
+	Env     map[string]string `yaml:"env" json:"env"`         // Extra environment, e.g. GOTOOLCHAIN: go1.22.0
+}
+
+// CommandFor returns the shell command the gate runs for a matrix cell.
+// An empty cell command runs the gate command unchanged.
+func (g *Gate) CommandFor(cell *MatrixCell) string {
+	if cell == nil || cell.Command == "" {
+		return g.Command
+	}
+	if strings.Contains(cell.Command, "{command}") {
+		return strings.ReplaceAll(cell.Command, "{command}", g.Command)
+	}
+	return cell.Command
 }
 
 // DefaultTimeout returns default timeout for a gate type
@@ -80,16 +103,21 @@ func (g *Gate) DefaultTimeout() time.Duration {
 
 // Result represents the outcome of running a gate
 type Result struct {
-	GateName    string        `json:"gate_name"`
-	Status      GateStatus    `json:"status"`
-	ExitCode    int           `json:"exit_code"`
-	Output      string        `json:"output"` // stdout + stderr
-	Error       string        `json:"error"`  // Error message if failed
-	Duration    time.Duration `json:"duration"`
-	RetryCount  int           `json:"retry_count"` // How many retries were attempted
-	Coverage    float64       `json:"coverage"`    // Parsed coverage percentage (for coverage gates)
-	StartedAt   time.Time     `json:"started_at"`
-	CompletedAt time.Time     `json:"completed_at"`
+	GateName      string         `json:"gate_name"`
+	Matrix        string         `json:"matrix,omitempty"` // Matrix cell name, empty for non-matrix gates
+	Status        GateStatus     `json:"status"`
+	ExitCode      int            `json:"exit_code"`
+	Output        string         `json:"output"` // stdout + stderr
+	Error         string         `json:"error"`  // Error message if failed
+	Duration      time.Duration  `json:"duration"`
+	RetryCount    int            `json:"retry_count"`              // How many retries were attempted
+	Coverage      float64        `json:"coverage"`                 // Parsed coverage percentage (for coverage gates)
+	Findings      []Finding      `json:"findings,omitempty"`       // Security scan findings (security scan only)
+	CoverageDelta *CoverageDelta `json:"coverage_delta,omitempty"` // Coverage before and after the change (coverage delta check only)
+	Fingerprint   string         `json:"fingerprint,omitempty"`    // Fingerprint of the last failure's normalized output
+	Flaky         bool           `json:"flaky,omitempty"`          // Failed with differing output, or passed on retry
+	StartedAt     time.Time      `json:"started_at"`
+	CompletedAt   time.Time      `json:"completed_at"`
 }
 
 // Passed returns true if the gate passed
@@ -97,6 +125,14 @@ func (r *Result) Passed() bool {
 	return r.Status == StatusPassed
 }
 
+// DisplayName returns the gate name qualified with its matrix cell, e.g. "test (go1.22)".
+func (r *Result) DisplayName() string {
+	if r.Matrix == "" {
+		return r.GateName
+	}
+	return fmt.Sprintf("%s (%s)", r.GateName, r.Matrix)
+}
+
 // CheckResults holds all gate check results for a task
 type CheckResults struct {
 	TaskID      string        `json:"task_id"`
@@ -120,10 +156,15 @@ func (cr *CheckResults) GetFailedGates() []*Result {
 
 // Config holds quality gates configuration
 type Config struct {
-	Enabled   bool          `yaml:"enabled" json:"enabled"`
-	Parallel  *bool         `yaml:"parallel" json:"parallel"` // Run gates in parallel (default: true)
-	Gates     []*Gate       `yaml:"gates" json:"gates"`
-	OnFailure FailureConfig `yaml:"on_failure" json:"on_failure"`
+	Enabled       bool                 `yaml:"enabled" json:"enabled"`
+	Parallel      *bool                `yaml:"parallel" json:"parallel"`               // Run gates in parallel (default: true)
+	AutoDetect    bool                 `yaml:"auto_detect" json:"auto_detect"`         // Add default gates for the project's languages
+	LocalCI       bool                 `yaml:"local_ci" json:"local_ci"`               // Add gates running the project's CI jobs before push
+	LocalCIRunner string               `yaml:"local_ci_runner" json:"local_ci_runner"` // "direct" (default) or "act"
+	Gates         []*Gate              `yaml:"gates" json:"gates"`
+	OnFailure     FailureConfig        `yaml:"on_failure" json:"on_failure"`
+	Security      *SecurityConfig      `yaml:"security" json:"security"`             // Built-in secret and dependency scan
+	CoverageDelta *CoverageDeltaConfig `yaml:"coverage_delta" json:"coverage_delta"` // Built-in coverage drop check
 }
 
 // IsParallel returns whether gates should run in parallel.
@@ -225,6 +266,37 @@ func (c *Config) Validate() error {
 		if g.Command == "" {
 			return errors.New("quality gate command is required for gate: " + g.Name)
 		}
+		if err := g.validateMatrix(); err != nil {
+			return err
+		}
+	}
+	switch c.LocalCIRunner {
+	case "", LocalCIDirect, LocalCIAct:
+	default:
+		return fmt.Errorf("quality local_ci_runner must be %q or %q, got %q", LocalCIDirect, LocalCIAct, c.LocalCIRunner)
+	}
+	if c.Security != nil {
+		if err := c.Security.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.CoverageDelta != nil {
+		return c.CoverageDelta.Validate()
+	}
+	return nil
+}
+
+// validateMatrix checks that every matrix cell has a unique name.
+func (g *Gate) validateMatrix() error {
+	seen := make(map[string]bool, len(g.Matrix))
+	for _, cell := range g.Matrix {
+		if cell.Name == "" {
+			return errors.New("quality gate matrix cell name is required for gate: " + g.Name)
+		}
+		if seen[cell.Name] {
+			return fmt.Errorf("duplicate matrix cell %q in gate: %s", cell.Name, g.Name)
+		}
+		seen[cell.Name] = true
 	}
 	return nil
 }
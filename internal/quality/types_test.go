@@ -191,6 +191,24 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "matrix cell without name",
+			config: &Config{
+				Gates: []*Gate{
+					{Name: "test", Command: "go test ./...", Matrix: []MatrixCell{{Env: map[string]string{"GOTOOLCHAIN": "go1.22.0"}}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate matrix cell",
+			config: &Config{
+				Gates: []*Gate{
+					{Name: "test", Command: "npm test", Matrix: []MatrixCell{{Name: "node18"}, {Name: "node18"}}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -374,3 +392,22 @@ func TestDetectBuildCommand_Priority(t *testing.T) {
 		t.Errorf("expected Go build command to take priority, got %q", got)
 	}
 }
+
+func TestGate_CommandFor(t *testing.T) {
+	gate := &Gate{Name: "test", Command: "npm test"}
+	tests := []struct {
+		name string
+		cell *MatrixCell
+		want string
+	}{
+		{"no cell", nil, "npm test"},
+		{"env only", &MatrixCell{Name: "go1.22"}, "npm test"},
+		{"wraps gate command", &MatrixCell{Name: "node18", Command: "npx -y -p node@18 -- {command}"}, "npx -y -p node@18 -- npm test"},
+		{"replaces gate command", &MatrixCell{Name: "go1.22", Command: "go1.22.0 test ./..."}, "go1.22.0 test ./..."},
+	}
+	for _, tt := range tests {
+		if got := gate.CommandFor(tt.cell); got != tt.want {
+			t.Errorf("%s: CommandFor() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -212,6 +212,9 @@ body {
 .phase-verifying { background: var(--accent-yellow); }
 .phase-completing { background: var(--accent-purple); }
 .phase-init { background: var(--text-muted); }
+.phase-planning { background: var(--accent-purple); }
+.phase-testing { background: var(--accent-yellow); }
+.phase-blocked { background: var(--accent-red); }
 
 .tool-grid {
   display: grid;
@@ -311,6 +314,8 @@ body {
 .timeline-event.text .timeline-content { color: var(--text-primary); }
 .timeline-event.error .timeline-content { color: var(--accent-red); }
 .timeline-event.result .timeline-content { color: var(--accent-green); }
+.timeline-event.step .timeline-content { color: var(--accent-purple); }
+.timeline-event.blocked .timeline-content { color: var(--accent-yellow); }
 
 .footer {
   text-align: center;
@@ -533,6 +538,13 @@ func renderHTMLTimeline(events []*StreamEvent) string {
 			} else if p.ToolName != "" {
 				class += " tool"
 				icon = getToolIcon(p.ToolName)
+			} else if len(p.Steps) > 0 {
+				if isBlocked(p.Steps) {
+					class += " blocked"
+				} else {
+					class += " step"
+				}
+				icon = stepsIcon(p.Steps)
 			} else if p.Text != "" {
 				class += " text"
 				icon = "💬"
@@ -584,6 +596,9 @@ func formatEventForTimeline(event *StreamEvent) string {
 			}
 			return p.ToolName
 		}
+		if len(p.Steps) > 0 {
+			return truncate(stepsText(p.Steps), 150)
+		}
 		if p.Text != "" {
 			return truncate(strings.ReplaceAll(p.Text, "\n", " "), 150)
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -130,6 +131,8 @@ func FormatEvent(event *StreamEvent, verbose bool) string {
 	case "assistant":
 		if parsed.ToolName != "" {
 			sb.WriteString(formatToolCall(parsed))
+		} else if len(parsed.Steps) > 0 {
+			sb.WriteString(formatSteps(parsed.Steps))
 		} else if parsed.Text != "" {
 			// Truncate long text
 			text := parsed.Text
@@ -340,6 +343,9 @@ func (a *Analyzer) Analyze() (*AnalysisReport, error) {
 		// Detect phase changes from text
 		if parsed.Text != "" {
 			newPhase := detectPhaseFromText(parsed.Text)
+			if n := len(parsed.Steps); n > 0 {
+				newPhase = parsed.Steps[n-1].Phase()
+			}
 			if newPhase != "" && newPhase != currentPhase {
 				// Record previous phase
 				if phaseEvents > 0 {
@@ -409,6 +415,11 @@ func (a *Analyzer) Analyze() (*AnalysisReport, error) {
 		}
 	}
 
+	// Prefer the executor's attribution over the estimate from events
+	if a.recording.TokenBreakdown != nil {
+		report.TokenBreakdown = *a.recording.TokenBreakdown
+	}
+
 	return report, nil
 }
 
@@ -442,6 +453,37 @@ func detectPhaseFromText(text string) string {
 	return ""
 }
 
+// writeTokenSection writes token usage by category, most tokens first
+func writeTokenSection(sb *strings.Builder, title string, usage map[string]TokenUsage) {
+	if len(usage) == 0 {
+		return
+	}
+	names := make([]string, 0, len(usage))
+	var total int64
+	for name, u := range usage {
+		names = append(names, name)
+		total += u.TotalTokens
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := usage[names[i]], usage[names[j]]
+		if a.TotalTokens != b.TotalTokens {
+			return a.TotalTokens > b.TotalTokens
+		}
+		return names[i] < names[j]
+	})
+
+	sb.WriteString(title + "\n")
+	sb.WriteString("───────────────────────────────────────\n")
+	for _, name := range names {
+		percent := 0.0
+		if total > 0 {
+			percent = float64(usage[name].TotalTokens) / float64(total) * 100
+		}
+		sb.WriteString(fmt.Sprintf("  %-12s %10d tokens (%5.1f%%)\n", name+":", usage[name].TotalTokens, percent))
+	}
+	sb.WriteString("\n")
+}
+
 // FormatReport formats an analysis report for terminal display
 func FormatReport(report *AnalysisReport) string {
 	var sb strings.Builder
@@ -471,6 +513,12 @@ func FormatReport(report *AnalysisReport) string {
 		sb.WriteString("\n")
 	}
 
+	// Token attribution, only when recorded by the executor
+	if rec.TokenBreakdown != nil {
+		writeTokenSection(&sb, "TOKENS BY PHASE", report.TokenBreakdown.ByPhase)
+		writeTokenSection(&sb, "TOKENS BY TOOL", report.TokenBreakdown.ByTool)
+	}
+
 	// Phase Analysis
 	if len(report.PhaseAnalysis) > 0 {
 		sb.WriteString("PHASE ANALYSIS\n")
@@ -3,6 +3,7 @@ package replay
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -497,6 +498,67 @@ func TestFormatReportWithTokenUsage(t *testing.T) {
 	}
 }
 
+func TestAnalyzeWithTokenBreakdown(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	recorder, _ := NewRecorder("TASK-BREAKDOWN", "/test/project", tmpDir)
+	recorder.SetTokenBreakdownSource(func() *TokenBreakdown {
+		return &TokenBreakdown{
+			ByPhase: map[string]TokenUsage{
+				"implement": {InputTokens: 700, OutputTokens: 50, TotalTokens: 750},
+				"verify":    {InputTokens: 200, OutputTokens: 50, TotalTokens: 250},
+			},
+			ByTool: map[string]TokenUsage{
+				"edit": {InputTokens: 900, OutputTokens: 100, TotalTokens: 1000},
+			},
+		}
+	})
+	_ = recorder.RecordEvent(`{"type":"result","result":"done","usage":{"input_tokens":900,"output_tokens":100}}`)
+	if err := recorder.Finish("completed"); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+
+	recording, err := LoadRecording(tmpDir, recorder.GetRecordingID())
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	if recording.TokenBreakdown == nil {
+		t.Fatal("recording should persist the token breakdown")
+	}
+	analyzer, _ := NewAnalyzer(recording)
+	report, _ := analyzer.Analyze()
+
+	if got := report.TokenBreakdown.ByPhase["implement"].TotalTokens; got != 750 {
+		t.Errorf("implement tokens = %d, want 750", got)
+	}
+
+	formatted := FormatReport(report)
+	for _, expected := range []string{"TOKENS BY PHASE", "TOKENS BY TOOL", "implement:", "75.0%", "edit:"} {
+		if !containsString(formatted, expected) {
+			t.Errorf("Expected report to contain %q", expected)
+		}
+	}
+	if strings.Index(formatted, "implement:") > strings.Index(formatted, "verify:") {
+		t.Error("phases should be listed most tokens first")
+	}
+}
+
+func TestFormatReportWithoutTokenBreakdown(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	recorder, _ := NewRecorder("TASK-NOBREAKDOWN", "/test/project", tmpDir)
+	_ = recorder.RecordEvent(`{"type":"result","result":"done","usage":{"input_tokens":10,"output_tokens":5}}`)
+	_ = recorder.Finish("completed")
+
+	recording, _ := LoadRecording(tmpDir, recorder.GetRecordingID())
+	analyzer, _ := NewAnalyzer(recording)
+	report, _ := analyzer.Analyze()
+
+	if containsString(FormatReport(report), "TOKENS BY PHASE") {
+		t.Error("recordings without attribution should not show token sections")
+	}
+}
+
 func TestFormatReportWithErrors(t *testing.T) {
 	tmpDir := t.TempDir()
 
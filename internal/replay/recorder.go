@@ -3,6 +3,7 @@ package replay
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/encryption"
 	"github.com/alekspetrov/pilot/internal/logging"
 	"log/slog"
 )
@@ -26,12 +28,21 @@ type Recorder struct {
 	sequence     int
 	currentPhase string
 	phaseStart   time.Time
+	key          *encryption.Key // Seals recording files, nil when encryption is off
+	tokenSource  func() *TokenBreakdown
 	mu           sync.Mutex
 	log          *slog.Logger
 }
 
 // NewRecorder creates a recorder for a task execution
 func NewRecorder(taskID, projectPath, basePath string) (*Recorder, error) {
+	// Refuse to record in plaintext when encryption is on but the key is
+	// unavailable
+	key, err := encryption.Default()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+
 	// Generate unique recording ID
 	id := fmt.Sprintf("TG-%d", time.Now().UnixNano()/int64(time.Millisecond))
 
@@ -61,6 +72,7 @@ func NewRecorder(taskID, projectPath, basePath string) (*Recorder, error) {
 		basePath:    basePath,
 		streamFile:  streamFile,
 		diffFiles:   make(map[string]*FileDiff),
+		key:         key,
 		log:         logging.WithComponent("recorder"),
 		recording: &Recording{
 			ID:          id,
@@ -131,7 +143,13 @@ func (r *Recorder) RecordEvent(rawJSON string) error {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	if _, err := r.streamFile.WriteString(string(eventJSON) + "\n"); err != nil {
+	line := string(eventJSON)
+	if r.key != nil {
+		if line, err = r.key.SealLine(eventJSON); err != nil {
+			return fmt.Errorf("failed to encrypt event: %w", err)
+		}
+	}
+	if _, err := r.streamFile.WriteString(line + "\n"); err != nil {
 		return fmt.Errorf("failed to write event: %w", err)
 	}
 
@@ -196,6 +214,7 @@ func (r *Recorder) parseEvent(rawJSON string) *ParsedEvent {
 						case "text":
 							if text, ok := b["text"].(string); ok {
 								parsed.Text = text
+								parsed.Steps = append(parsed.Steps, parseSignalSteps(text)...)
 							}
 						}
 					}
@@ -223,6 +242,11 @@ func (r *Recorder) detectFileOp(toolName string) string {
 
 // detectPhase determines execution phase from event
 func (r *Recorder) detectPhase(parsed *ParsedEvent) string {
+	// From progress step signals
+	if n := len(parsed.Steps); n > 0 {
+		return parsed.Steps[n-1].Phase()
+	}
+
 	// From tool usage
 	switch parsed.ToolName {
 	case "Read", "Glob", "Grep":
@@ -332,6 +356,14 @@ func (r *Recorder) SetModel(model string) {
 	r.recording.Metadata.ModelName = model
 }
 
+// SetTokenBreakdownSource sets the function that reports the token
+// breakdown when the recording finishes
+func (r *Recorder) SetTokenBreakdownSource(fn func() *TokenBreakdown) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokenSource = fn
+}
+
 // Finish completes the recording
 func (r *Recorder) Finish(status string) error {
 	r.mu.Lock()
@@ -348,6 +380,10 @@ func (r *Recorder) Finish(status string) error {
 	// Calculate estimated cost
 	r.recording.TokenUsage.EstimatedCostUSD = r.estimateCost()
 
+	if r.tokenSource != nil {
+		r.recording.TokenBreakdown = r.tokenSource()
+	}
+
 	// Close stream file
 	if err := r.streamFile.Close(); err != nil {
 		r.log.Error("Failed to close stream file", slog.Any("error", err))
@@ -385,7 +421,7 @@ func (r *Recorder) saveMetadata() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(metadataPath, data, 0644)
+	return r.writeFile(metadataPath, data)
 }
 
 // saveDiffs saves file diffs
@@ -404,7 +440,7 @@ func (r *Recorder) saveDiffs() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(diffsPath, data, 0644)
+	return r.writeFile(diffsPath, data)
 }
 
 // generateSummary creates a human-readable summary
@@ -467,7 +503,19 @@ func (r *Recorder) generateSummary() error {
 		sb.WriteString("\n")
 	}
 
-	return os.WriteFile(r.recording.SummaryPath, []byte(sb.String()), 0644)
+	return r.writeFile(r.recording.SummaryPath, []byte(sb.String()))
+}
+
+// writeFile writes a recording file, sealed when encryption is on
+func (r *Recorder) writeFile(path string, data []byte) error {
+	if r.key != nil {
+		sealed, err := r.key.Seal(data)
+		if err != nil {
+			return err
+		}
+		data = sealed
+	}
+	return os.WriteFile(path, data, 0644)
 }
 
 // estimateCost calculates estimated cost from token usage
@@ -550,7 +598,10 @@ func ListRecordings(basePath string, filter *RecordingFilter) ([]*RecordingSumma
 
 		// Load metadata
 		metadataPath := filepath.Join(basePath, entry.Name(), "metadata.json")
-		data, err := os.ReadFile(metadataPath)
+		data, err := encryption.ReadFile(metadataPath)
+		if errors.Is(err, encryption.ErrKeyUnavailable) {
+			return nil, fmt.Errorf("recording %s is encrypted: %w", entry.Name(), err)
+		}
 		if err != nil {
 			continue // Skip invalid recordings
 		}
@@ -595,7 +646,7 @@ func ListRecordings(basePath string, filter *RecordingFilter) ([]*RecordingSumma
 // LoadRecording loads a recording by ID
 func LoadRecording(basePath, id string) (*Recording, error) {
 	metadataPath := filepath.Join(basePath, id, "metadata.json")
-	data, err := os.ReadFile(metadataPath)
+	data, err := encryption.ReadFile(metadataPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read recording: %w", err)
 	}
@@ -619,11 +670,19 @@ func LoadStreamEvents(recording *Recording) ([]*StreamEvent, error) {
 	var events []*StreamEvent
 	scanner := bufio.NewScanner(file)
 	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
+	scanner.Buffer(buf, 2*1024*1024) // Sealed lines are base64, a third larger
 
 	for scanner.Scan() {
+		line, err := encryption.OpenLine(scanner.Bytes())
+		if errors.Is(err, encryption.ErrKeyUnavailable) {
+			return nil, fmt.Errorf("recording %s is encrypted: %w", recording.ID, err)
+		}
+		if err != nil {
+			continue // Skip corrupt events
+		}
+
 		var event StreamEvent
-		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+		if err := json.Unmarshal(line, &event); err != nil {
 			continue // Skip malformed events
 		}
 		events = append(events, &event)
@@ -641,3 +700,25 @@ func DeleteRecording(basePath, id string) error {
 	recordingDir := filepath.Join(basePath, id)
 	return os.RemoveAll(recordingDir)
 }
+
+// PurgeRecordings deletes the recordings for which match returns true and
+// returns their IDs. Recordings are deleted one at a time, so on error the
+// returned IDs are those already deleted.
+func PurgeRecordings(basePath string, match func(*RecordingSummary) bool) ([]string, error) {
+	recordings, err := ListRecordings(basePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, recording := range recordings {
+		if !match(recording) {
+			continue
+		}
+		if err := DeleteRecording(basePath, recording.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete recording %s: %w", recording.ID, err)
+		}
+		deleted = append(deleted, recording.ID)
+	}
+	return deleted, nil
+}
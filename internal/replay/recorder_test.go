@@ -1,10 +1,14 @@
 package replay
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
 )
 
 func TestNewRecorder(t *testing.T) {
@@ -232,6 +236,63 @@ func TestLoadStreamEvents(t *testing.T) {
 	}
 }
 
+func TestEncryptedRecording(t *testing.T) {
+	key, err := encryption.NewKey([]byte(strings.Repeat("k", encryption.KeySize)))
+	if err != nil {
+		t.Fatalf("NewKey failed: %v", err)
+	}
+	encryption.SetDefault(key)
+	defer encryption.SetDefault(nil)
+
+	tmpDir := t.TempDir()
+	recorder, err := NewRecorder("TASK-SECRET", "/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+	_ = recorder.RecordEvent(`{"type":"assistant","message":{"content":[{"type":"text","text":"proprietary algorithm"}]}}`)
+	recorder.SetBranch("pilot/TASK-SECRET")
+	if err := recorder.Finish("completed"); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+
+	recordingDir := filepath.Join(tmpDir, recorder.GetRecordingID())
+	for _, name := range []string{"stream.jsonl", "metadata.json", "summary.md"} {
+		data, err := os.ReadFile(filepath.Join(recordingDir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if strings.Contains(string(data), "proprietary") || strings.Contains(string(data), "TASK-SECRET") {
+			t.Errorf("%s is stored in plaintext", name)
+		}
+	}
+
+	// Replay decrypts transparently
+	summaries, err := ListRecordings(tmpDir, nil)
+	if err != nil || len(summaries) != 1 || summaries[0].TaskID != "TASK-SECRET" {
+		t.Fatalf("ListRecordings = %+v, %v", summaries, err)
+	}
+	recording, err := LoadRecording(tmpDir, recorder.GetRecordingID())
+	if err != nil || recording.Metadata.Branch != "pilot/TASK-SECRET" {
+		t.Fatalf("LoadRecording = %+v, %v", recording, err)
+	}
+	events, err := LoadStreamEvents(recording)
+	if err != nil || len(events) != 1 || !strings.Contains(events[0].Raw, "proprietary algorithm") {
+		t.Fatalf("LoadStreamEvents = %+v, %v", events, err)
+	}
+
+	// Without the key the recording cannot be read
+	encryption.SetDefault(nil)
+	if _, err := LoadRecording(tmpDir, recorder.GetRecordingID()); !errors.Is(err, encryption.ErrKeyUnavailable) {
+		t.Errorf("LoadRecording without key: err = %v, want ErrKeyUnavailable", err)
+	}
+	if _, err := LoadStreamEvents(recording); !errors.Is(err, encryption.ErrKeyUnavailable) {
+		t.Errorf("LoadStreamEvents without key: err = %v, want ErrKeyUnavailable", err)
+	}
+	if _, err := ListRecordings(tmpDir, nil); !errors.Is(err, encryption.ErrKeyUnavailable) {
+		t.Errorf("ListRecordings without key: err = %v, want ErrKeyUnavailable", err)
+	}
+}
+
 func TestDeleteRecording(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -261,6 +322,32 @@ func TestDeleteRecording(t *testing.T) {
 	}
 }
 
+func TestPurgeRecordings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, taskID := range []string{"TASK-KEEP", "TASK-PURGE", "TASK-PURGE"} {
+		recorder, err := NewRecorder(taskID, "/test/project", tmpDir)
+		if err != nil {
+			t.Fatalf("Failed to create recorder: %v", err)
+		}
+		_ = recorder.Finish("completed")
+		time.Sleep(2 * time.Millisecond) // IDs are millisecond timestamps
+	}
+
+	deleted, err := PurgeRecordings(tmpDir, func(r *RecordingSummary) bool { return r.TaskID == "TASK-PURGE" })
+	if err != nil {
+		t.Fatalf("PurgeRecordings failed: %v", err)
+	}
+	if len(deleted) != 2 {
+		t.Errorf("deleted %d recordings, want 2", len(deleted))
+	}
+
+	remaining, _ := ListRecordings(tmpDir, nil)
+	if len(remaining) != 1 || remaining[0].TaskID != "TASK-KEEP" {
+		t.Errorf("remaining recordings = %+v, want only TASK-KEEP", remaining)
+	}
+}
+
 func TestDefaultRecordingsPath(t *testing.T) {
 	path := DefaultRecordingsPath()
 	if path == "" {
@@ -286,6 +373,16 @@ func TestDetectPhase(t *testing.T) {
 			parsed:   &ParsedEvent{ToolName: "Read"},
 			expected: "Exploring",
 		},
+		{
+			name:     "Test step signal - Testing",
+			parsed:   &ParsedEvent{Steps: []SignalStep{{Type: "test", Detail: "./auth/..."}}},
+			expected: "Testing",
+		},
+		{
+			name:     "Last step signal wins - Blocked",
+			parsed:   &ParsedEvent{Steps: []SignalStep{{Type: "plan"}, {Type: "blocked", Detail: "no access"}}},
+			expected: "Blocked",
+		},
 		{
 			name:     "Glob tool - Exploring",
 			parsed:   &ParsedEvent{ToolName: "Glob"},
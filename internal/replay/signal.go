@@ -0,0 +1,107 @@
+package replay
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// signalBlockRegex matches ```pilot-signal\n{...}\n``` blocks. The executor
+// parses the same contract for live progress; it imports this package, so
+// the step subset is parsed here again for recordings.
+var signalBlockRegex = regexp.MustCompile("(?s)```pilot-signal\\s*\\n(.+?)\\n```")
+
+// parseSignalSteps extracts the progress steps (plan, edit, test, blocked)
+// from pilot-signal blocks in text. Other signals and edit steps without a
+// file are skipped.
+func parseSignalSteps(text string) []SignalStep {
+	var steps []SignalStep
+	for _, match := range signalBlockRegex.FindAllStringSubmatch(text, -1) {
+		var signal struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+			File    string `json:"file"`
+			Package string `json:"package"`
+			Reason  string `json:"reason"`
+		}
+		if err := json.Unmarshal([]byte(match[1]), &signal); err != nil {
+			continue
+		}
+
+		step := SignalStep{Type: signal.Type}
+		switch signal.Type {
+		case "plan":
+			step.Detail = signal.Message
+		case "edit":
+			if signal.File == "" {
+				continue
+			}
+			step.Detail = signal.File
+		case "test":
+			step.Detail = signal.Package
+		case "blocked":
+			step.Detail = signal.Reason
+			if step.Detail == "" {
+				step.Detail = signal.Message
+			}
+		default:
+			continue
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// String renders the step as in the signal contract, e.g. "EDIT main.go"
+func (s SignalStep) String() string {
+	if s.Detail == "" {
+		return strings.ToUpper(s.Type)
+	}
+	return strings.ToUpper(s.Type) + " " + s.Detail
+}
+
+// Phase returns the recording phase the step belongs to
+func (s SignalStep) Phase() string {
+	switch s.Type {
+	case "plan":
+		return "Planning"
+	case "edit":
+		return "Implementing"
+	case "test":
+		return "Testing"
+	default:
+		return "Blocked"
+	}
+}
+
+// formatSteps renders the steps of one event with their icon
+func formatSteps(steps []SignalStep) string {
+	return stepsIcon(steps) + " " + stepsText(steps)
+}
+
+// stepsText joins the steps of one event, e.g. "PLAN fix auth; EDIT auth.go"
+func stepsText(steps []SignalStep) string {
+	parts := make([]string, 0, len(steps))
+	for _, s := range steps {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// stepsIcon returns the timeline icon for the steps of one event
+func stepsIcon(steps []SignalStep) string {
+	if isBlocked(steps) {
+		return "⛔"
+	}
+	return "🧭"
+}
+
+// isBlocked reports whether any of steps is a blocked step
+func isBlocked(steps []SignalStep) bool {
+	for _, s := range steps {
+		if s.Type == "blocked" {
+			return true
+		}
+	}
+	return false
+}
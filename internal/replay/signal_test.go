@@ -0,0 +1,23 @@
+package replay
+
+import "testing"
+
+func TestParseSignalSteps(t *testing.T) {
+	text := "Starting.\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"plan\",\"message\":\"fix token refresh\"}\n```\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"status\",\"progress\":20}\n```\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"edit\"}\n```\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"edit\",\"file\":\"auth.go\"}\n```\n" +
+		"```pilot-signal\n{\"v\":2,\"type\":\"blocked\",\"message\":\"need prod creds\"}\n```"
+
+	steps := parseSignalSteps(text)
+	if got := stepsText(steps); got != "PLAN fix token refresh; EDIT auth.go; BLOCKED need prod creds" {
+		t.Errorf("stepsText() = %q", got)
+	}
+	if stepsIcon(steps) != "⛔" {
+		t.Errorf("stepsIcon() = %q, want blocked icon", stepsIcon(steps))
+	}
+	if stepsIcon(steps[:2]) != "🧭" {
+		t.Errorf("stepsIcon() = %q, want step icon", stepsIcon(steps[:2]))
+	}
+}
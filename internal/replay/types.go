@@ -20,6 +20,10 @@ type Recording struct {
 	Metadata     *Metadata     `json:"metadata"`
 	TokenUsage   *TokenUsage   `json:"token_usage,omitempty"`
 	PhaseTimings []PhaseTiming `json:"phase_timings,omitempty"`
+
+	// TokenBreakdown is the executor's attribution of tokens to phases and
+	// tool categories, nil for recordings made before attribution
+	TokenBreakdown *TokenBreakdown `json:"token_breakdown,omitempty"`
 }
 
 // Metadata holds task metadata for the recording
@@ -71,6 +75,13 @@ type ParsedEvent struct {
 	OutputTokens  int64          `json:"output_tokens,omitempty"`
 	FilePath      string         `json:"file_path,omitempty"`      // For file operations
 	FileOperation string         `json:"file_operation,omitempty"` // read, write, edit
+	Steps         []SignalStep   `json:"steps,omitempty"`          // Progress steps signalled in Text
+}
+
+// SignalStep is a progress step the agent reported with a pilot-signal block
+type SignalStep struct {
+	Type   string `json:"type"`             // plan, edit, test or blocked
+	Detail string `json:"detail,omitempty"` // Plan summary, file, package or reason
 }
 
 // FileDiff represents a file change during execution
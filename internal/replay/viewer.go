@@ -449,6 +449,9 @@ func (m *ViewerModel) formatEventContent(event *StreamEvent) string {
 			}
 			return fmt.Sprintf("%s %s", icon, p.ToolName)
 		}
+		if len(p.Steps) > 0 {
+			return formatSteps(p.Steps)
+		}
 		if p.Text != "" {
 			text := strings.ReplaceAll(p.Text, "\n", " ")
 			text = strings.TrimSpace(text)
@@ -0,0 +1,92 @@
+package retention
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config sets how long each kind of data is kept. Ages are written as "90d",
+// "12w" or a Go duration such as "720h"; an empty age keeps data forever.
+//
+// Example YAML configuration:
+//
+//	retention:
+//	  executions: 180d
+//	  recordings: 30d
+//	  transcripts: 30d
+//	  identity: 365d
+type Config struct {
+	// Executions removes execution history, with its logs, usage events and
+	// recordings.
+	Executions string `yaml:"executions"`
+	// Recordings removes execution recordings.
+	Recordings string `yaml:"recordings"`
+	// Transcripts clears the model conversation (execution output and log
+	// lines) but keeps the execution history.
+	Transcripts string `yaml:"transcripts"`
+	// Identity removes team audit log and approval audit entries, which
+	// record who did what.
+	Identity string `yaml:"identity"`
+}
+
+// Enabled reports whether any age is set.
+func (c *Config) Enabled() bool {
+	return c != nil && (c.Executions != "" || c.Recordings != "" || c.Transcripts != "" || c.Identity != "")
+}
+
+// Validate checks that every age parses.
+func (c *Config) Validate() error {
+	for _, field := range []struct{ name, value string }{
+		{"executions", c.Executions},
+		{"recordings", c.Recordings},
+		{"transcripts", c.Transcripts},
+		{"identity", c.Identity},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if _, err := ParseAge(field.value); err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// ParseAge parses an age such as "30d", "4w" or "720h".
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	var age time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(s, "d"):
+		var days int
+		days, err = strconv.Atoi(strings.TrimSuffix(s, "d"))
+		age = time.Duration(days) * 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		var weeks int
+		weeks, err = strconv.Atoi(strings.TrimSuffix(s, "w"))
+		age = time.Duration(weeks) * 7 * 24 * time.Hour
+	default:
+		age, err = time.ParseDuration(s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q, use e.g. 90d, 12w or 720h", s)
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("age %q must be positive", s)
+	}
+	return age, nil
+}
+
+// cutoff returns the time before which data older than age is removed, or
+// the zero time when age is empty.
+func cutoff(now time.Time, age string) time.Time {
+	d, err := ParseAge(age)
+	if age == "" || err != nil {
+		return time.Time{}
+	}
+	return now.Add(-d)
+}
@@ -0,0 +1,321 @@
+// Package retention removes old and personal data from every store Pilot
+// keeps: execution history in the memory store, execution recordings and
+// team identity data. It applies configured retention ages and handles
+// purge requests for one person, producing a report for compliance records.
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/replay"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+// Options selects the data removed by Purge.
+type Options struct {
+	// Member is the email of the person whose data is removed. Without
+	// OlderThan their team memberships are removed too.
+	Member string
+	// OlderThan removes data older than this age. Combined with Member,
+	// only that person's data older than it is removed.
+	OlderThan time.Duration
+}
+
+// Report records what a purge removed.
+type Report struct {
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	// Trigger is "manual" for pilot data purge and "retention" for the
+	// scheduled retention sweep.
+	Trigger   string   `json:"trigger"`
+	Member    string   `json:"member,omitempty"`
+	MemberIDs []string `json:"member_ids,omitempty"`
+	// Cutoffs maps each kind of data to the time before which it was removed.
+	Cutoffs map[string]time.Time `json:"cutoffs,omitempty"`
+	// Deleted counts removed rows per table, plus "recordings".
+	Deleted map[string]int64 `json:"deleted"`
+	// Redacted counts rows kept with personal data cleared, per table.
+	Redacted   map[string]int64 `json:"redacted,omitempty"`
+	Recordings []string         `json:"recordings,omitempty"`
+}
+
+func newReport(trigger string) *Report {
+	return &Report{
+		StartedAt: time.Now(),
+		Trigger:   trigger,
+		Cutoffs:   make(map[string]time.Time),
+		Deleted:   make(map[string]int64),
+		Redacted:  make(map[string]int64),
+	}
+}
+
+// Total returns the number of items deleted or redacted.
+func (r *Report) Total() int64 {
+	var total int64
+	for _, n := range r.Deleted {
+		total += n
+	}
+	for _, n := range r.Redacted {
+		total += n
+	}
+	return total
+}
+
+// Save writes the report as JSON into dir and returns its path. Reports may
+// name the purged person, so they are readable by the owner only.
+func (r *Report) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("purge-%s.json", r.StartedAt.UTC().Format("20060102T150405Z")))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	return path, nil
+}
+
+// Purger removes data across the memory store, recordings and teams.
+type Purger struct {
+	store          *memory.Store
+	teams          *teams.Store
+	recordingsPath string
+	log            *slog.Logger
+}
+
+// NewPurger creates a purger. teamsStore may be nil when teams are not used,
+// in which case member purges are unavailable and identity retention only
+// covers the approval audit trail.
+func NewPurger(store *memory.Store, teamsStore *teams.Store, recordingsPath string) *Purger {
+	return &Purger{
+		store:          store,
+		teams:          teamsStore,
+		recordingsPath: recordingsPath,
+		log:            slog.Default().With("component", "retention"),
+	}
+}
+
+// Purge removes the data selected by opts. On error the returned report
+// covers what was removed before the failure.
+func (p *Purger) Purge(opts Options) (*Report, error) {
+	if opts.Member == "" && opts.OlderThan <= 0 {
+		return nil, fmt.Errorf("a member or an age is required")
+	}
+	report := newReport("manual")
+	defer func() { report.CompletedAt = time.Now() }()
+
+	var before time.Time
+	if opts.OlderThan > 0 {
+		before = report.StartedAt.Add(-opts.OlderThan)
+	}
+
+	if opts.Member == "" {
+		report.Cutoffs["all"] = before
+		if err := p.purgeExecutions(report, memory.PurgeFilter{Before: before}); err != nil {
+			return report, err
+		}
+		if err := p.purgeRecordingsBefore(report, before); err != nil {
+			return report, err
+		}
+		if err := p.purgeTranscripts(report, before); err != nil {
+			return report, err
+		}
+		return report, p.pruneAuditLog(report, before)
+	}
+
+	if p.teams == nil {
+		return nil, fmt.Errorf("teams are not configured, cannot resolve member %s", opts.Member)
+	}
+	members, err := p.teams.GetMembersByEmail(opts.Member)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up member: %w", err)
+	}
+	report.Member = opts.Member
+	for _, m := range members {
+		report.MemberIDs = append(report.MemberIDs, m.ID)
+	}
+	if !before.IsZero() {
+		report.Cutoffs["member"] = before
+	}
+
+	if len(report.MemberIDs) > 0 {
+		if err := p.purgeExecutions(report, memory.PurgeFilter{Before: before, RequestedBy: report.MemberIDs}); err != nil {
+			return report, err
+		}
+	}
+	if !before.IsZero() {
+		// Identity and usage records are kept: only old activity goes
+		return report, nil
+	}
+
+	n, err := p.store.PurgeUsageEvents(append([]string{opts.Member}, report.MemberIDs...))
+	if err != nil {
+		return report, fmt.Errorf("failed to purge usage events: %w", err)
+	}
+	report.Deleted["usage_events"] += n
+
+	// Approvals are recorded under whichever identity the approver used
+	actors := append([]string{opts.Member}, report.MemberIDs...)
+	for _, m := range members {
+		actors = append(actors, m.GitHubUser, m.SlackUserID)
+		if m.TelegramID != 0 {
+			actors = append(actors, strconv.FormatInt(m.TelegramID, 10))
+		}
+	}
+	n, err = p.store.AnonymizeApprovalActors(actors, teams.PurgedEmail)
+	if err != nil {
+		return report, fmt.Errorf("failed to anonymize approval audit: %w", err)
+	}
+	report.Redacted["approval_audit"] += n
+
+	ids, anonymized, err := p.teams.PurgeMember(opts.Member)
+	if err != nil {
+		return report, fmt.Errorf("failed to purge team memberships: %w", err)
+	}
+	report.Deleted["team_members"] += int64(len(ids))
+	report.Redacted["team_audit_log"] += anonymized
+	return report, nil
+}
+
+// ApplyRetention removes data older than the ages in cfg.
+func (p *Purger) ApplyRetention(cfg *Config) (*Report, error) {
+	report := newReport("retention")
+	defer func() { report.CompletedAt = time.Now() }()
+	if !cfg.Enabled() {
+		return report, nil
+	}
+
+	if before := cutoff(report.StartedAt, cfg.Executions); !before.IsZero() {
+		report.Cutoffs["executions"] = before
+		if err := p.purgeExecutions(report, memory.PurgeFilter{Before: before}); err != nil {
+			return report, err
+		}
+	}
+	if before := cutoff(report.StartedAt, cfg.Recordings); !before.IsZero() {
+		report.Cutoffs["recordings"] = before
+		if err := p.purgeRecordingsBefore(report, before); err != nil {
+			return report, err
+		}
+	}
+	if before := cutoff(report.StartedAt, cfg.Transcripts); !before.IsZero() {
+		report.Cutoffs["transcripts"] = before
+		if err := p.purgeTranscripts(report, before); err != nil {
+			return report, err
+		}
+	}
+	if before := cutoff(report.StartedAt, cfg.Identity); !before.IsZero() {
+		report.Cutoffs["identity"] = before
+		if err := p.pruneAuditLog(report, before); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// Run applies cfg at start and then once a day until ctx is cancelled,
+// saving a report into reportDir whenever something was removed.
+func (p *Purger) Run(ctx context.Context, cfg *Config, reportDir string) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		report, err := p.ApplyRetention(cfg)
+		if err != nil {
+			p.log.Warn("retention sweep failed", slog.Any("error", err))
+		}
+		if report.Total() > 0 {
+			path, saveErr := report.Save(reportDir)
+			if saveErr != nil {
+				p.log.Warn("failed to save retention report", slog.Any("error", saveErr))
+			}
+			p.log.Info("retention sweep removed old data",
+				slog.Int64("items", report.Total()),
+				slog.String("report", path))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeExecutions removes executions and the recordings of their tasks.
+func (p *Purger) purgeExecutions(report *Report, filter memory.PurgeFilter) error {
+	result, err := p.store.PurgeExecutions(filter)
+	if err != nil {
+		return fmt.Errorf("failed to purge executions: %w", err)
+	}
+	for table, n := range result.Rows {
+		report.Deleted[table] += n
+	}
+	if len(result.TaskIDs) == 0 {
+		return nil
+	}
+
+	tasks := make(map[string]bool, len(result.TaskIDs))
+	for _, id := range result.TaskIDs {
+		tasks[id] = true
+	}
+	return p.purgeRecordings(report, func(r *replay.RecordingSummary) bool { return tasks[r.TaskID] })
+}
+
+func (p *Purger) purgeRecordingsBefore(report *Report, before time.Time) error {
+	return p.purgeRecordings(report, func(r *replay.RecordingSummary) bool { return r.StartTime.Before(before) })
+}
+
+func (p *Purger) purgeRecordings(report *Report, match func(*replay.RecordingSummary) bool) error {
+	if p.recordingsPath == "" {
+		return nil
+	}
+	deleted, err := replay.PurgeRecordings(p.recordingsPath, match)
+	report.Recordings = append(report.Recordings, deleted...)
+	report.Deleted["recordings"] += int64(len(deleted))
+	if err != nil {
+		return fmt.Errorf("failed to purge recordings: %w", err)
+	}
+	return nil
+}
+
+func (p *Purger) purgeTranscripts(report *Report, before time.Time) error {
+	counts, err := p.store.PurgeTranscripts(before)
+	if err != nil {
+		return fmt.Errorf("failed to purge transcripts: %w", err)
+	}
+	report.Redacted["executions"] += counts["executions"]
+	report.Deleted["execution_logs"] += counts["execution_logs"]
+	report.Deleted["pending_questions"] += counts["pending_questions"]
+	return nil
+}
+
+// pruneAuditLog removes approval and team audit entries recorded before
+// before.
+func (p *Purger) pruneAuditLog(report *Report, before time.Time) error {
+	n, err := p.store.PruneApprovalAudit(before)
+	if err != nil {
+		return fmt.Errorf("failed to prune approval audit: %w", err)
+	}
+	report.Deleted["approval_audit"] += n
+
+	if p.teams == nil {
+		return nil
+	}
+	n, err = p.teams.PruneAuditLog(before)
+	if err != nil {
+		return fmt.Errorf("failed to prune team audit log: %w", err)
+	}
+	report.Deleted["team_audit_log"] += n
+	return nil
+}
@@ -0,0 +1,191 @@
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/alekspetrov/pilot/internal/replay"
+	"github.com/alekspetrov/pilot/internal/teams"
+)
+
+func setupPurger(t *testing.T) (*Purger, *memory.Store, *teams.Store, string) {
+	t.Helper()
+	store, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	teamsStore, err := teams.NewStore(store.DB())
+	if err != nil {
+		t.Fatalf("teams.NewStore failed: %v", err)
+	}
+	recordings := t.TempDir()
+	return NewPurger(store, teamsStore, recordings), store, teamsStore, recordings
+}
+
+func record(t *testing.T, basePath, taskID string) {
+	t.Helper()
+	recorder, err := replay.NewRecorder(taskID, "/project", basePath)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	_ = recorder.Finish("completed")
+	time.Sleep(2 * time.Millisecond) // recording IDs are millisecond timestamps
+}
+
+func TestPurge_Member(t *testing.T) {
+	purger, store, teamsStore, recordings := setupPurger(t)
+
+	team, owner := teams.NewTeam("Team", "owner@example.com")
+	_ = teamsStore.CreateTeam(team)
+	_ = teamsStore.AddMember(owner)
+	leaver := teams.NewMember(team.ID, "leaver@example.com", teams.RoleDeveloper, owner.ID)
+	leaver.GitHubUser = "leaver-gh"
+	_ = teamsStore.AddMember(leaver)
+	for _, actor := range []string{"Leaver-GH", "owner@example.com", "leaver-gh, owner@example.com", ""} {
+		if err := store.SaveApprovalAudit(&memory.ApprovalAudit{RequestID: "r1", TaskID: "GH-1", Event: "decided", Actor: actor, Decision: "approved"}); err != nil {
+			t.Fatalf("SaveApprovalAudit failed: %v", err)
+		}
+	}
+
+	_ = store.SaveExecution(&memory.Execution{ID: "e1", TaskID: "GH-1", ProjectPath: "/project", Status: "completed", RequestedBy: leaver.ID})
+	_ = store.SaveExecution(&memory.Execution{ID: "e2", TaskID: "GH-2", ProjectPath: "/project", Status: "completed", RequestedBy: owner.ID})
+	_ = store.RecordTaskUsage("e1", leaver.ID, "/project", 1000, 10, 10)
+	record(t, recordings, "GH-1")
+	record(t, recordings, "GH-2")
+
+	if _, err := purger.Purge(Options{}); err == nil {
+		t.Error("Purge without member or age should fail")
+	}
+
+	report, err := purger.Purge(Options{Member: "leaver@example.com"})
+	if err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if report.Deleted["executions"] != 1 || report.Deleted["recordings"] != 1 || report.Deleted["team_members"] != 1 || report.Deleted["usage_events"] == 0 {
+		t.Errorf("report.Deleted = %v", report.Deleted)
+	}
+	if len(report.MemberIDs) != 1 || report.MemberIDs[0] != leaver.ID {
+		t.Errorf("report.MemberIDs = %v", report.MemberIDs)
+	}
+
+	if _, err := store.GetExecution("e2"); err != nil {
+		t.Errorf("another member's execution was purged: %v", err)
+	}
+	left, _ := replay.ListRecordings(recordings, nil)
+	if len(left) != 1 || left[0].TaskID != "GH-2" {
+		t.Errorf("recordings left = %+v, want only GH-2", left)
+	}
+	if members, _ := teamsStore.GetMembersByEmail("leaver@example.com"); len(members) != 0 {
+		t.Error("membership should be removed")
+	}
+	if report.Redacted["approval_audit"] != 2 {
+		t.Errorf("report.Redacted = %v, want the leaver's 2 approval audit events", report.Redacted)
+	}
+	audit, _ := store.GetApprovalAudit("", 0)
+	var actors []string
+	for _, e := range audit {
+		actors = append(actors, e.Actor)
+	}
+	if want := []string{teams.PurgedEmail, "owner@example.com", teams.PurgedEmail + ", owner@example.com", ""}; fmt.Sprint(actors) != fmt.Sprint(want) {
+		t.Errorf("approval audit actors = %q, want %q", actors, want)
+	}
+
+	dir := t.TempDir()
+	path, err := report.Save(dir)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("report mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	var saved Report
+	if err := json.Unmarshal(data, &saved); err != nil || saved.Member != "leaver@example.com" || saved.Trigger != "manual" {
+		t.Errorf("saved report = %+v, %v", saved, err)
+	}
+}
+
+func TestApplyRetention(t *testing.T) {
+	purger, store, _, recordings := setupPurger(t)
+
+	_ = store.SaveExecution(&memory.Execution{ID: "old", TaskID: "GH-1", ProjectPath: "/project", Status: "completed", Output: "transcript"})
+	_ = store.SaveExecution(&memory.Execution{ID: "new", TaskID: "GH-2", ProjectPath: "/project", Status: "completed", Output: "transcript"})
+	if _, err := store.DB().Exec(`UPDATE executions SET created_at = '2020-01-01 00:00:00' WHERE id = 'old'`); err != nil {
+		t.Fatal(err)
+	}
+	record(t, recordings, "GH-1")
+	record(t, recordings, "GH-2")
+	for _, q := range []*memory.PendingQuestion{
+		{TaskID: "GH-1", Adapter: "slack", ContextID: "C1", Question: "Which bucket?", Description: "Add uploads"},
+		{TaskID: "GH-9", Adapter: "slack", ContextID: "C9", Question: "Which region?", Description: "Deploy", CreatedAt: time.Now().Add(-2 * time.Hour)},
+	} {
+		if err := store.SavePendingQuestion(q); err != nil {
+			t.Fatalf("SavePendingQuestion failed: %v", err)
+		}
+		if q.TaskID == "GH-9" {
+			_, _ = store.ResolvePendingQuestion(q.ID, memory.QuestionAnswered, "eu-west-1")
+		}
+	}
+
+	for _, at := range []time.Time{time.Now().AddDate(-2, 0, 0), time.Now()} {
+		if err := store.SaveApprovalAudit(&memory.ApprovalAudit{RequestID: "r1", TaskID: "GH-1", Event: "decided", Actor: "alice", CreatedAt: at}); err != nil {
+			t.Fatalf("SaveApprovalAudit failed: %v", err)
+		}
+	}
+
+	report, err := purger.ApplyRetention(&Config{Executions: "30d", Transcripts: "1h", Identity: "365d"})
+	if err != nil {
+		t.Fatalf("ApplyRetention failed: %v", err)
+	}
+	if report.Deleted["executions"] != 1 || report.Deleted["recordings"] != 1 {
+		t.Errorf("report.Deleted = %v, want the old execution and its recording", report.Deleted)
+	}
+	if report.Deleted["pending_questions"] != 2 {
+		t.Errorf("report.Deleted = %v, want the old task's question and the old answered one", report.Deleted)
+	}
+	if report.Deleted["approval_audit"] != 1 {
+		t.Errorf("report.Deleted = %v, want the approval audit event past the identity age", report.Deleted)
+	}
+	if audit, _ := store.GetApprovalAudit("", 0); len(audit) != 1 {
+		t.Errorf("approval audit left = %d events, want 1", len(audit))
+	}
+	if exec, err := store.GetExecution("new"); err != nil || exec.Output != "transcript" {
+		t.Errorf("recent execution = %+v, %v; want it untouched", exec, err)
+	}
+	if _, ok := report.Cutoffs["recordings"]; ok {
+		t.Error("recordings age is not configured")
+	}
+
+	if report, _ := purger.ApplyRetention(&Config{}); report.Total() != 0 {
+		t.Errorf("empty config removed %d items", report.Total())
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "30d", want: 30 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "0d", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+	if err := (&Config{Recordings: "forever"}).Validate(); err == nil {
+		t.Error("Validate should reject an invalid age")
+	}
+}
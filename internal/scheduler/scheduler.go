@@ -0,0 +1,380 @@
+// Package scheduler runs recurring maintenance tasks on cron schedules.
+//
+// Jobs are defined in config (e.g. "update dependencies weekly") and queued
+// through the executor dispatcher like any other task, so their executions
+// show up in briefs and the dashboard. A job is skipped while its previous
+// run is still queued or running. Finished runs are reported to the alerts
+// engine as task_completed / task_failed events. A job also waits while a PR
+// opened by an earlier run is still open, so runs don't pile up unmerged PRs.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+	"github.com/robfig/cron/v3"
+)
+
+// ErrPreviousRunOpen is returned when a job's previous run is still queued or running
+var ErrPreviousRunOpen = errors.New("previous run is still open")
+
+// ErrPullRequestOpen is returned when a PR from a previous run is still open
+var ErrPullRequestOpen = errors.New("pull request from a previous run is still open")
+
+// jobNamePattern restricts job names to characters safe for task IDs and branches
+var jobNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Config configures scheduled recurring tasks
+type Config struct {
+	Enabled  bool        `yaml:"enabled"`
+	Timezone string      `yaml:"timezone"` // IANA name (default: local)
+	Jobs     []JobConfig `yaml:"jobs"`
+}
+
+// JobConfig defines a recurring task
+type JobConfig struct {
+	Name        string `yaml:"name"`        // Unique, lowercase letters, digits and dashes
+	Schedule    string `yaml:"schedule"`    // Cron syntax: "0 3 * * 1"
+	Project     string `yaml:"project"`     // Project name or path (default: the default project)
+	Title       string `yaml:"title"`       // Task title (default: the job name)
+	Description string `yaml:"description"` // What the agent should do
+	CreatePR    *bool  `yaml:"create_pr"`   // Open a PR with the changes (default: true)
+}
+
+// shouldCreatePR reports whether runs of the job open a PR
+func (j JobConfig) shouldCreatePR() bool {
+	return j.CreatePR == nil || *j.CreatePR
+}
+
+// taskPrefix is the task ID prefix shared by all runs of the job
+func (j JobConfig) taskPrefix() string {
+	return "SCHED-" + j.Name + "-"
+}
+
+// branchPrefix is the branch name prefix shared by all runs of the job
+func (j JobConfig) branchPrefix() string {
+	return "pilot/" + j.taskPrefix()
+}
+
+// Validate checks job names, schedules and the timezone
+func (c *Config) Validate() error {
+	if _, err := c.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	seen := make(map[string]bool)
+	for _, job := range c.Jobs {
+		if !jobNamePattern.MatchString(job.Name) {
+			return fmt.Errorf("invalid job name %q (use lowercase letters, digits and dashes)", job.Name)
+		}
+		if seen[job.Name] {
+			return fmt.Errorf("duplicate job name %q", job.Name)
+		}
+		seen[job.Name] = true
+		if _, err := cron.ParseStandard(job.Schedule); err != nil {
+			return fmt.Errorf("job %s: invalid schedule %q: %w", job.Name, job.Schedule, err)
+		}
+		if strings.TrimSpace(job.Description) == "" {
+			return fmt.Errorf("job %s: description is required", job.Name)
+		}
+	}
+	return nil
+}
+
+// NextRun returns the job's next scheduled run after t
+func (c *Config) NextRun(job JobConfig, after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := c.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after.In(loc)), nil
+}
+
+// location returns the configured timezone, defaulting to the local one
+func (c *Config) location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+// TaskQueue queues tasks and reports their executions (satisfied by *executor.Dispatcher)
+type TaskQueue interface {
+	QueueTask(ctx context.Context, task *executor.Task) (string, error)
+	WaitForExecution(ctx context.Context, execID string, pollInterval time.Duration) (*memory.Execution, error)
+}
+
+// ExecutionLister lists open executions (satisfied by *memory.Store)
+type ExecutionLister interface {
+	GetQueuedTasks(limit int) ([]*memory.Execution, error)
+	GetActiveExecutions() ([]*memory.Execution, error)
+}
+
+// OpenPRFinder looks up open pull requests (see cmd/pilot for the GitHub one)
+type OpenPRFinder interface {
+	// FindOpenPR returns the URL of an open PR in the project's repository
+	// whose head branch starts with branchPrefix, or "" if there is none.
+	FindOpenPR(ctx context.Context, projectPath, branchPrefix string) (string, error)
+}
+
+// Scheduler queues configured jobs on their cron schedules
+type Scheduler struct {
+	config   *Config
+	queue    TaskQueue
+	store    ExecutionLister
+	alerts   executor.AlertEventProcessor
+	prs      OpenPRFinder
+	resolver func(project string) (string, error)
+	logger   *slog.Logger
+	cron     *cron.Cron
+	now      func() time.Time
+
+	pollInterval time.Duration
+	mu           sync.Mutex
+	running      bool
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// Option configures a Scheduler
+type Option func(*Scheduler)
+
+// WithLogger sets the logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Scheduler) {
+		s.logger = logger
+	}
+}
+
+// WithAlertProcessor reports finished runs to the alerts engine
+func WithAlertProcessor(processor executor.AlertEventProcessor) Option {
+	return func(s *Scheduler) {
+		s.alerts = processor
+	}
+}
+
+// WithProjectResolver maps a job's project (name or path) to a project path.
+// The resolver returns an error for projects that are not configured.
+func WithProjectResolver(resolver func(project string) (string, error)) Option {
+	return func(s *Scheduler) {
+		s.resolver = resolver
+	}
+}
+
+// WithOpenPRFinder skips runs while a PR from a previous run is still open
+func WithOpenPRFinder(finder OpenPRFinder) Option {
+	return func(s *Scheduler) {
+		s.prs = finder
+	}
+}
+
+// New creates a scheduler. The store is used to skip jobs whose previous run
+// is still open.
+func New(config *Config, queue TaskQueue, store ExecutionLister, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		config:       config,
+		queue:        queue,
+		store:        store,
+		resolver:     func(project string) (string, error) { return project, nil },
+		logger:       slog.Default(),
+		now:          time.Now,
+		pollInterval: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	loc, err := config.location()
+	if err != nil {
+		s.logger.Warn("invalid scheduler timezone, using local", "timezone", config.Timezone, "error", err)
+		loc = time.Local
+	}
+	s.cron = cron.New(cron.WithLocation(loc))
+	return s
+}
+
+// Start schedules all jobs. Runs are queued in the background until Stop.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+	if !s.config.Enabled || len(s.config.Jobs) == 0 {
+		s.logger.Info("task scheduler disabled")
+		return nil
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.config.Jobs {
+		job := job
+		if _, err := s.cron.AddFunc(job.Schedule, func() {
+			_, err := s.RunJob(ctx, job.Name)
+			if err != nil && !errors.Is(err, ErrPreviousRunOpen) && !errors.Is(err, ErrPullRequestOpen) {
+				s.logger.Error("scheduled job failed to queue", "job", job.Name, "error", err)
+			}
+		}); err != nil {
+			s.cancel()
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+	}
+
+	s.cron.Start()
+	s.running = true
+	s.logger.Info("task scheduler started", "jobs", len(s.config.Jobs), "timezone", s.config.Timezone)
+	return nil
+}
+
+// Stop stops scheduling and abandons waiting on queued runs. Runs already
+// queued still execute.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+	<-s.cron.Stop().Done()
+	s.cancel()
+	s.wg.Wait()
+	s.running = false
+	s.logger.Info("task scheduler stopped")
+}
+
+// RunJob queues a run of the named job now and returns its execution ID.
+// Returns ErrPreviousRunOpen when the previous run is still queued or running,
+// and ErrPullRequestOpen while a PR from a previous run is still open.
+func (s *Scheduler) RunJob(ctx context.Context, name string) (string, error) {
+	job, ok := s.job(name)
+	if !ok {
+		return "", fmt.Errorf("unknown job %q", name)
+	}
+
+	open, err := s.openRun(job)
+	if err != nil {
+		return "", fmt.Errorf("failed to check previous run: %w", err)
+	}
+	if open != "" {
+		s.logger.Info("skipping scheduled job, previous run still open", "job", job.Name, "task_id", open)
+		return "", ErrPreviousRunOpen
+	}
+
+	projectPath, err := s.resolver(job.Project)
+	if err != nil {
+		return "", fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if s.prs != nil {
+		prURL, err := s.prs.FindOpenPR(ctx, projectPath, job.branchPrefix())
+		if err != nil {
+			return "", fmt.Errorf("failed to check open pull requests: %w", err)
+		}
+		if prURL != "" {
+			s.logger.Info("skipping scheduled job, pull request from a previous run still open", "job", job.Name, "pr_url", prURL)
+			return "", ErrPullRequestOpen
+		}
+	}
+
+	taskID := job.taskPrefix() + s.now().Format("20060102-1504")
+	title := job.Title
+	if title == "" {
+		title = job.Name
+	}
+	task := &executor.Task{
+		ID:          taskID,
+		Title:       title,
+		Description: job.Description,
+		ProjectPath: projectPath,
+		Branch:      "pilot/" + taskID,
+		CreatePR:    job.shouldCreatePR(),
+	}
+
+	execID, err := s.queue.QueueTask(ctx, task)
+	if err != nil {
+		return "", err
+	}
+	s.logger.Info("scheduled job queued", "job", job.Name, "task_id", taskID, "execution_id", execID)
+
+	if s.alerts != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.report(ctx, job, task, execID)
+		}()
+	}
+	return execID, nil
+}
+
+// job looks up a job by name
+func (s *Scheduler) job(name string) (JobConfig, bool) {
+	for _, job := range s.config.Jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return JobConfig{}, false
+}
+
+// openRun returns the task ID of the job's queued or running execution, if any
+func (s *Scheduler) openRun(job JobConfig) (string, error) {
+	if s.store == nil {
+		return "", nil
+	}
+	queued, err := s.store.GetQueuedTasks(1000)
+	if err != nil {
+		return "", err
+	}
+	active, err := s.store.GetActiveExecutions()
+	if err != nil {
+		return "", err
+	}
+	for _, exec := range append(queued, active...) {
+		if strings.HasPrefix(exec.TaskID, job.taskPrefix()) {
+			return exec.TaskID, nil
+		}
+	}
+	return "", nil
+}
+
+// report waits for a run to finish and emits the result to the alerts engine
+func (s *Scheduler) report(ctx context.Context, job JobConfig, task *executor.Task, execID string) {
+	exec, err := s.queue.WaitForExecution(ctx, execID, s.pollInterval)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Warn("failed to wait for scheduled job", "job", job.Name, "execution_id", execID, "error", err)
+		}
+		return
+	}
+
+	event := executor.AlertEvent{
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+		Project:   task.ProjectPath,
+		Metadata:  map[string]string{"scheduled_job": job.Name},
+		Timestamp: s.now(),
+	}
+	if exec.Status == "completed" {
+		event.Type = executor.AlertEventTypeTaskCompleted
+		if exec.PRUrl != "" {
+			event.Metadata["pr_url"] = exec.PRUrl
+		}
+	} else {
+		event.Type = executor.AlertEventTypeTaskFailed
+		event.Error = exec.Error
+		if event.Error == "" {
+			event.Error = "run " + exec.Status
+		}
+	}
+	s.alerts.ProcessEvent(event)
+}
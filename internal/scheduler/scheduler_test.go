@@ -0,0 +1,261 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/executor"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+type mockQueue struct {
+	mu     sync.Mutex
+	tasks  []*executor.Task
+	result *memory.Execution
+	err    error
+}
+
+func (q *mockQueue) QueueTask(_ context.Context, task *executor.Task) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return "", q.err
+	}
+	q.tasks = append(q.tasks, task)
+	return "exec-" + task.ID, nil
+}
+
+func (q *mockQueue) WaitForExecution(_ context.Context, execID string, _ time.Duration) (*memory.Execution, error) {
+	return q.result, nil
+}
+
+type mockLister struct {
+	queued []*memory.Execution
+	active []*memory.Execution
+}
+
+func (l *mockLister) GetQueuedTasks(int) ([]*memory.Execution, error) { return l.queued, nil }
+func (l *mockLister) GetActiveExecutions() ([]*memory.Execution, error) {
+	return l.active, nil
+}
+
+type mockPRFinder struct {
+	open map[string]string // branch prefix -> PR URL
+	got  []string          // project paths looked up
+}
+
+func (f *mockPRFinder) FindOpenPR(_ context.Context, projectPath, branchPrefix string) (string, error) {
+	f.got = append(f.got, projectPath)
+	return f.open[branchPrefix], nil
+}
+
+type mockAlerts struct {
+	mu     sync.Mutex
+	events []executor.AlertEvent
+}
+
+func (a *mockAlerts) ProcessEvent(event executor.AlertEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, event)
+}
+
+func testConfig() *Config {
+	noPR := false
+	return &Config{
+		Enabled:  true,
+		Timezone: "UTC",
+		Jobs: []JobConfig{
+			{Name: "deps", Schedule: "0 3 * * 1", Project: "api", Title: "Update dependencies", Description: "Bump go modules"},
+			{Name: "lint", Schedule: "@daily", Description: "Fix lint warnings", CreatePR: &noPR},
+		},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr bool
+	}{
+		{"valid", func(c *Config) {}, false},
+		{"bad timezone", func(c *Config) { c.Timezone = "Mars/Olympus" }, true},
+		{"bad name", func(c *Config) { c.Jobs[0].Name = "Deps Weekly" }, true},
+		{"duplicate name", func(c *Config) { c.Jobs[1].Name = "deps" }, true},
+		{"bad schedule", func(c *Config) { c.Jobs[0].Schedule = "every monday" }, true},
+		{"missing description", func(c *Config) { c.Jobs[1].Description = " " }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig()
+			tt.mutate(c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_NextRun(t *testing.T) {
+	c := testConfig()
+	// 2026-03-04 is a Wednesday; next Monday 03:00 UTC is 2026-03-09
+	next, err := c.NextRun(c.Jobs[0], time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NextRun() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 9, 3, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("NextRun() = %s, want %s", next, want)
+	}
+}
+
+func TestScheduler_RunJob(t *testing.T) {
+	queue := &mockQueue{}
+	s := New(testConfig(), queue, &mockLister{}, WithProjectResolver(func(p string) (string, error) {
+		if p == "api" {
+			return "/src/api", nil
+		}
+		return "/src/default", nil
+	}))
+	s.now = func() time.Time { return time.Date(2026, 3, 9, 3, 0, 0, 0, time.UTC) }
+
+	execID, err := s.RunJob(context.Background(), "deps")
+	if err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+	if execID != "exec-SCHED-deps-20260309-0300" {
+		t.Errorf("execID = %q", execID)
+	}
+	if _, err := s.RunJob(context.Background(), "lint"); err != nil {
+		t.Fatalf("RunJob(lint) error = %v", err)
+	}
+
+	deps, lint := queue.tasks[0], queue.tasks[1]
+	if deps.Title != "Update dependencies" || deps.ProjectPath != "/src/api" || deps.Branch != "pilot/SCHED-deps-20260309-0300" || !deps.CreatePR {
+		t.Errorf("deps task = %+v", deps)
+	}
+	if lint.Title != "lint" || lint.ProjectPath != "/src/default" || lint.CreatePR {
+		t.Errorf("lint task = %+v", lint)
+	}
+
+	if _, err := s.RunJob(context.Background(), "missing"); err == nil {
+		t.Error("RunJob(missing) error = nil, want unknown job")
+	}
+}
+
+func TestScheduler_RunJobSkipsOpenRun(t *testing.T) {
+	queue := &mockQueue{}
+	lister := &mockLister{
+		queued: []*memory.Execution{{TaskID: "GH-12"}},
+		active: []*memory.Execution{{TaskID: "SCHED-deps-20260302-0300", Status: "running"}},
+	}
+	s := New(testConfig(), queue, lister)
+
+	if _, err := s.RunJob(context.Background(), "deps"); !errors.Is(err, ErrPreviousRunOpen) {
+		t.Fatalf("RunJob(deps) error = %v, want ErrPreviousRunOpen", err)
+	}
+	if _, err := s.RunJob(context.Background(), "lint"); err != nil {
+		t.Fatalf("RunJob(lint) error = %v", err)
+	}
+	if len(queue.tasks) != 1 || queue.tasks[0].ID[:11] != "SCHED-lint-" {
+		t.Errorf("queued tasks = %+v, want only lint", queue.tasks)
+	}
+}
+
+func TestScheduler_RunJobSkipsOpenPR(t *testing.T) {
+	queue := &mockQueue{}
+	finder := &mockPRFinder{open: map[string]string{"pilot/SCHED-deps-": "https://github.com/o/r/pull/7"}}
+	s := New(testConfig(), queue, &mockLister{}, WithOpenPRFinder(finder), WithProjectResolver(func(p string) (string, error) {
+		return "/src/" + p, nil
+	}))
+
+	if _, err := s.RunJob(context.Background(), "deps"); !errors.Is(err, ErrPullRequestOpen) {
+		t.Fatalf("RunJob(deps) error = %v, want ErrPullRequestOpen", err)
+	}
+	if _, err := s.RunJob(context.Background(), "lint"); err != nil {
+		t.Fatalf("RunJob(lint) error = %v", err)
+	}
+	if len(queue.tasks) != 1 || queue.tasks[0].ID[:11] != "SCHED-lint-" {
+		t.Errorf("queued tasks = %+v, want only lint", queue.tasks)
+	}
+	if len(finder.got) != 2 || finder.got[0] != "/src/api" {
+		t.Errorf("looked up projects %v, want the resolved project path first", finder.got)
+	}
+}
+
+func TestScheduler_RunJobUnknownProject(t *testing.T) {
+	queue := &mockQueue{}
+	s := New(testConfig(), queue, &mockLister{}, WithProjectResolver(func(p string) (string, error) {
+		return "", errors.New("project not found")
+	}))
+
+	if _, err := s.RunJob(context.Background(), "deps"); err == nil {
+		t.Fatal("RunJob() error = nil, want unknown project")
+	}
+	if len(queue.tasks) != 0 {
+		t.Errorf("queued tasks = %+v, want none", queue.tasks)
+	}
+}
+
+func TestScheduler_ReportsResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *memory.Execution
+		wantType executor.AlertEventType
+		wantErr  string
+	}{
+		{"completed", &memory.Execution{Status: "completed", PRUrl: "https://github.com/o/r/pull/7"}, executor.AlertEventTypeTaskCompleted, ""},
+		{"failed", &memory.Execution{Status: "failed", Error: "tests failed"}, executor.AlertEventTypeTaskFailed, "tests failed"},
+		{"cancelled", &memory.Execution{Status: "cancelled"}, executor.AlertEventTypeTaskFailed, "run cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := &mockQueue{result: tt.result}
+			processor := &mockAlerts{}
+			s := New(testConfig(), queue, &mockLister{}, WithAlertProcessor(processor))
+
+			if _, err := s.RunJob(context.Background(), "deps"); err != nil {
+				t.Fatalf("RunJob() error = %v", err)
+			}
+			s.wg.Wait()
+
+			if len(processor.events) != 1 {
+				t.Fatalf("got %d events, want 1", len(processor.events))
+			}
+			event := processor.events[0]
+			if event.Type != tt.wantType || event.Error != tt.wantErr {
+				t.Errorf("event = %s/%q, want %s/%q", event.Type, event.Error, tt.wantType, tt.wantErr)
+			}
+			if event.Metadata["scheduled_job"] != "deps" {
+				t.Errorf("scheduled_job = %q, want deps", event.Metadata["scheduled_job"])
+			}
+			if event.Metadata["pr_url"] != tt.result.PRUrl {
+				t.Errorf("pr_url = %q, want %q", event.Metadata["pr_url"], tt.result.PRUrl)
+			}
+		})
+	}
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	disabled := testConfig()
+	disabled.Enabled = false
+	s := New(disabled, &mockQueue{}, &mockLister{})
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if s.running {
+		t.Error("disabled scheduler should not run")
+	}
+
+	s = New(testConfig(), &mockQueue{}, &mockLister{})
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if got := len(s.cron.Entries()); got != 2 {
+		t.Errorf("cron entries = %d, want 2", got)
+	}
+	s.Stop()
+	s.Stop() // idempotent
+}
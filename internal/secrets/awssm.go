@@ -0,0 +1,262 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWSSecretsConfig configures AWS Secrets Manager. Unset fields fall back
+// to AWS_REGION (or AWS_DEFAULT_REGION), AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN, then to the AWS_PROFILE (or
+// "default") profile in ~/.aws/credentials.
+type AWSSecretsConfig struct {
+	Region          string `yaml:"region"`
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	SessionToken    string `yaml:"session_token"`
+	Profile         string `yaml:"profile"`  // Profile in the shared credentials file
+	Endpoint        string `yaml:"endpoint"` // Override the regional endpoint, e.g. for a VPC endpoint
+}
+
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// AWSSecretsManager reads secrets with GetSecretValue.
+// aws-sm://pilot/github-token reads the secret named pilot/github-token;
+// aws-sm://pilot/github#token reads the token key of a JSON secret.
+type AWSSecretsManager struct {
+	region   string
+	endpoint string
+	creds    awsCredentials
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewAWSSecretsManager returns a Secrets Manager client; cfg may be nil
+func NewAWSSecretsManager(cfg *AWSSecretsConfig) *AWSSecretsManager {
+	if cfg == nil {
+		cfg = &AWSSecretsConfig{}
+	}
+	a := &AWSSecretsManager{
+		region:   firstNonEmpty(cfg.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+		endpoint: strings.TrimRight(cfg.Endpoint, "/"),
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+	}
+	switch {
+	case cfg.AccessKeyID != "":
+		a.creds = awsCredentials{cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken}
+	case os.Getenv("AWS_ACCESS_KEY_ID") != "":
+		a.creds = awsCredentials{os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")}
+	default:
+		a.creds = sharedCredentials(firstNonEmpty(cfg.Profile, os.Getenv("AWS_PROFILE"), "default"))
+	}
+	if a.endpoint == "" && a.region != "" {
+		a.endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", a.region)
+	}
+	return a
+}
+
+// sharedCredentials reads a profile from the AWS shared credentials file
+func sharedCredentials(profile string) awsCredentials {
+	path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return awsCredentials{}
+		}
+		path = filepath.Join(home, ".aws", "credentials")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return awsCredentials{}
+	}
+	defer func() { _ = f.Close() }()
+
+	var creds awsCredentials
+	inProfile := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inProfile = strings.TrimSpace(line[1:len(line)-1]) == profile
+			continue
+		}
+		if !inProfile {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			creds.accessKeyID = value
+		case "aws_secret_access_key":
+			creds.secretAccessKey = value
+		case "aws_session_token":
+			creds.sessionToken = value
+		}
+	}
+	return creds
+}
+
+// Resolve reads the secret named path, or one key of it when field is set
+func (a *AWSSecretsManager) Resolve(ctx context.Context, path, field string) (string, error) {
+	if a.endpoint == "" {
+		return "", fmt.Errorf("aws region is not set: configure secrets.aws_secrets_manager.region or AWS_REGION")
+	}
+	if a.creds.accessKeyID == "" || a.creds.secretAccessKey == "" {
+		return "", fmt.Errorf("aws credentials are not set: configure AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or ~/.aws/credentials")
+	}
+
+	body, _ := json.Marshal(map[string]string{"SecretId": path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signV4(req, body, "secretsmanager", a.region, a.creds, a.now())
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("secrets manager request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secrets manager response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		if strings.HasSuffix(apiErr.Type, "ResourceNotFoundException") {
+			return "", fmt.Errorf("%w in secrets manager: %s", ErrNotFound, path)
+		}
+		if apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(respBody))
+		}
+		return "", fmt.Errorf("secrets manager returned %d for %s: %s", resp.StatusCode, path, apiErr.Message)
+	}
+
+	var result struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary string `json:"SecretBinary"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse secrets manager response: %w", err)
+	}
+	value := result.SecretString
+	if value == "" && result.SecretBinary != "" {
+		raw, err := base64.StdEncoding.DecodeString(result.SecretBinary)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode binary secret %s: %w", path, err)
+		}
+		value = string(raw)
+	}
+	if field == "" {
+		return value, nil
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object, so #%s cannot be read from it", path, field)
+	}
+	return pickField(data, path, field)
+}
+
+// signV4 signs a request with AWS Signature Version 4, covering the host
+// and every header already set
+func signV4(req *http.Request, body []byte, service, region string, creds awsCredentials, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hexSHA256([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
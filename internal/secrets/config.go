@@ -0,0 +1,103 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsSecretKey reports config keys that hold credentials
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "key", "dsn", "webhook_url", "credentials":
+		return true
+	}
+	for _, suffix := range []string{"token", "secret", "password", "_key", "apikey"} {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPlaintext reports a secret value written into the config itself rather
+// than referenced, e.g. "ghp_abc" but not "${GITHUB_TOKEN}" or
+// "vault://secret/pilot/github#token"
+func IsPlaintext(value string) bool {
+	if _, _, _, ok := ParseProviderRef(value); ok {
+		return false
+	}
+	return value != "" && !strings.HasPrefix(value, "${")
+}
+
+// RewriteConfig calls fn with the dotted path and value of every plaintext
+// secret in a YAML config and replaces the value with what fn returns.
+// Comments and layout are kept. It returns the paths it rewrote; the data
+// is returned unchanged when there were none.
+func RewriteConfig(data []byte, fn func(path, value string) (string, error)) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return data, nil, nil
+	}
+
+	var paths []string
+	if err := rewriteNode(doc.Content[0], "", fn, &paths); err != nil {
+		return nil, nil, err
+	}
+	if len(paths) == 0 {
+		return data, nil, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), paths, nil
+}
+
+func rewriteNode(node *yaml.Node, prefix string, fn func(path, value string) (string, error), paths *[]string) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := key.Value
+			if prefix != "" {
+				keyPath = prefix + "." + key.Value
+			}
+			if value.Kind == yaml.ScalarNode && IsSecretKey(key.Value) {
+				if IsPlaintext(value.Value) {
+					replacement, err := fn(keyPath, value.Value)
+					if err != nil {
+						return fmt.Errorf("%s: %w", keyPath, err)
+					}
+					value.Value = replacement
+					value.Tag = "!!str"
+					value.Style = yaml.DoubleQuotedStyle
+					*paths = append(*paths, keyPath)
+				}
+				continue
+			}
+			if err := rewriteNode(value, keyPath, fn, paths); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if err := rewriteNode(item, fmt.Sprintf("%s[%d]", prefix, i), fn, paths); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/charmbracelet/x/term"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
+)
+
+// Environment variables configuring the default secrets file
+const (
+	PassphraseEnv = "PILOT_SECRETS_PASSPHRASE"
+	FileEnv       = "PILOT_SECRETS_FILE"
+)
+
+// scrypt parameters for deriving the file key from the passphrase
+const (
+	scryptN    = 1 << 15
+	scryptR    = 8
+	scryptP    = 1
+	saltLength = 16
+)
+
+// fileFormatVersion is the secrets file layout version
+const fileFormatVersion = 1
+
+// fileEnvelope is the secrets file on disk. Data holds the JSON map of
+// secrets sealed with AES-256-GCM under a key derived from the passphrase.
+type fileEnvelope struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Data    []byte `json:"data"`
+}
+
+// File stores secrets in one encrypted file. The passphrase is asked for
+// once, on first use.
+type File struct {
+	path       string
+	passphrase func() ([]byte, error)
+
+	mu      sync.Mutex
+	key     *encryption.Key
+	salt    []byte
+	secrets map[string]string // nil until unlocked
+}
+
+// NewFile returns the secrets file at path, unlocked with the passphrase
+// the func returns
+func NewFile(path string, passphrase func() ([]byte, error)) *File {
+	return &File{path: path, passphrase: passphrase}
+}
+
+var (
+	defaultFile   *File
+	defaultFileMu sync.Mutex
+)
+
+// DefaultFile returns the shared secrets file: PILOT_SECRETS_FILE or
+// ~/.pilot/secrets.enc, unlocked with PILOT_SECRETS_PASSPHRASE or a
+// passphrase typed at the terminal. It is shared so the passphrase is
+// asked for once per process.
+func DefaultFile() *File {
+	defaultFileMu.Lock()
+	defer defaultFileMu.Unlock()
+	if path := DefaultFilePath(); defaultFile == nil || defaultFile.path != path {
+		defaultFile = NewFile(path, passphraseFromEnvOrTerminal)
+	}
+	return defaultFile
+}
+
+// DefaultFilePath is where the secrets file is kept
+func DefaultFilePath() string {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".pilot", "secrets.enc")
+}
+
+func passphraseFromEnvOrTerminal() ([]byte, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return []byte(p), nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return nil, fmt.Errorf("secrets file is locked: set %s", PassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, "🔑 Secrets passphrase: ")
+	p, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(p) == 0 {
+		return nil, fmt.Errorf("passphrase must not be empty")
+	}
+	return p, nil
+}
+
+// Path returns the file's location
+func (f *File) Path() string {
+	return f.path
+}
+
+// Get reads a secret
+func (f *File) Get(name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.unlock(); err != nil {
+		return "", err
+	}
+	value, ok := f.secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%w in %s", ErrNotFound, f.path)
+	}
+	return value, nil
+}
+
+// Set creates or replaces a secret, creating the file on first use
+func (f *File) Set(name, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.unlock(); err != nil {
+		return err
+	}
+	f.secrets[name] = value
+	return f.save()
+}
+
+// Delete removes a secret
+func (f *File) Delete(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.unlock(); err != nil {
+		return err
+	}
+	if _, ok := f.secrets[name]; !ok {
+		return fmt.Errorf("%w in %s", ErrNotFound, f.path)
+	}
+	delete(f.secrets, name)
+	return f.save()
+}
+
+// List returns the names of the stored secrets, sorted. A missing file has
+// none and does not ask for the passphrase.
+func (f *File) List() ([]string, error) {
+	if _, err := os.Stat(f.path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.unlock(); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(f.secrets))
+	for name := range f.secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// unlock reads and decrypts the file, or prepares a new one. Must be
+// called with mu held.
+func (f *File) unlock() error {
+	if f.secrets != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		salt := make([]byte, saltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		key, err := f.deriveKey(salt, scryptN, scryptR, scryptP)
+		if err != nil {
+			return err
+		}
+		f.key, f.salt, f.secrets = key, salt, make(map[string]string)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	var env fileEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("secrets file %s is corrupt: %w", f.path, err)
+	}
+	if env.Version != fileFormatVersion {
+		return fmt.Errorf("secrets file %s has unsupported version %d", f.path, env.Version)
+	}
+	key, err := f.deriveKey(env.Salt, env.N, env.R, env.P)
+	if err != nil {
+		return err
+	}
+	plaintext, err := key.Open(env.Data)
+	if err != nil {
+		return fmt.Errorf("failed to unlock %s: wrong passphrase or corrupt file", f.path)
+	}
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return fmt.Errorf("secrets file %s is corrupt: %w", f.path, err)
+	}
+	f.key, f.salt, f.secrets = key, env.Salt, secrets
+	return nil
+}
+
+func (f *File) deriveKey(salt []byte, n, r, p int) (*encryption.Key, error) {
+	passphrase, err := f.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := scrypt.Key(passphrase, salt, n, r, p, encryption.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive secrets key: %w", err)
+	}
+	return encryption.NewKey(raw)
+}
+
+// save seals the secrets and replaces the file atomically. Must be called
+// with mu held after unlock.
+func (f *File) save() error {
+	plaintext, err := json.Marshal(f.secrets)
+	if err != nil {
+		return err
+	}
+	sealed, err := f.key.Seal(plaintext)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(fileEnvelope{
+		Version: fileFormatVersion,
+		Salt:    f.salt,
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+		Data:    sealed,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), ".secrets-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeychainService groups Pilot's secrets in the keychain: the service name
+// on macOS and the "service" attribute on Linux. Each secret's name is its
+// account.
+const KeychainService = "pilot-secrets"
+
+// Keychain stores secrets in the OS keychain through its CLI: security on
+// macOS, secret-tool (libsecret) on Linux
+type Keychain struct {
+	goos string
+	run  func(stdin string, name string, args ...string) (string, error)
+}
+
+// NewKeychain returns the keychain of this OS
+func NewKeychain() *Keychain {
+	return &Keychain{goos: runtime.GOOS, run: runCommand}
+}
+
+func runCommand(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return string(out), nil
+}
+
+// Get reads a secret
+func (k *Keychain) Get(name string) (string, error) {
+	var out string
+	var err error
+	switch k.goos {
+	case "darwin":
+		out, err = k.run("", "security", "find-generic-password", "-s", KeychainService, "-a", name, "-w")
+	case "linux":
+		out, err = k.run("", "secret-tool", "lookup", "service", KeychainService, "account", name)
+	default:
+		return "", k.unsupported()
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w in keychain: %v", ErrNotFound, err)
+	}
+	value := strings.TrimRight(out, "\r\n")
+	if value == "" {
+		// secret-tool exits 0 with no output for a missing entry
+		return "", fmt.Errorf("%w in keychain", ErrNotFound)
+	}
+	return value, nil
+}
+
+// Set creates or replaces a secret
+func (k *Keychain) Set(name, value string) error {
+	var err error
+	switch k.goos {
+	case "darwin":
+		_, err = k.run("", "security", "add-generic-password", "-U", "-s", KeychainService, "-a", name, "-w", value)
+	case "linux":
+		_, err = k.run(value, "secret-tool", "store", "--label", "Pilot: "+name, "service", KeychainService, "account", name)
+	default:
+		return k.unsupported()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write keychain: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a secret
+func (k *Keychain) Delete(name string) error {
+	var err error
+	switch k.goos {
+	case "darwin":
+		_, err = k.run("", "security", "delete-generic-password", "-s", KeychainService, "-a", name)
+	case "linux":
+		_, err = k.run("", "secret-tool", "clear", "service", KeychainService, "account", name)
+	default:
+		return k.unsupported()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete from keychain: %w", err)
+	}
+	return nil
+}
+
+func (k *Keychain) unsupported() error {
+	return fmt.Errorf("keychain is not supported on %s, use the %s backend", k.goos, BackendFile)
+}
@@ -0,0 +1,333 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alekspetrov/pilot/internal/logging"
+)
+
+// URL schemes of the secret managers a config value can point at:
+//
+//	token: vault://secret/pilot/github#token
+//	token: aws-sm://pilot/github-token
+//	token: aws-sm://pilot/github#token
+//
+// The path names the secret and the optional #field picks one key out of
+// a secret holding several.
+const (
+	SchemeVault = "vault"
+	SchemeAWSSM = "aws-sm"
+)
+
+const (
+	defaultCacheTTL        = 5 * time.Minute
+	defaultRefreshInterval = 5 * time.Minute
+	defaultResolveTimeout  = 30 * time.Second
+)
+
+// Provider reads secrets from a secret manager
+type Provider interface {
+	// Resolve returns the secret at path, or its field when field is set
+	Resolve(ctx context.Context, path, field string) (string, error)
+}
+
+// Config configures the secret managers behind vault:// and aws-sm://
+// config values. Both work without it when the usual VAULT_* and AWS_*
+// environment variables are set.
+type Config struct {
+	CacheTTL        time.Duration     `yaml:"cache_ttl"`           // How long a resolved value is reused before it is fetched again (default: 5m)
+	RefreshInterval time.Duration     `yaml:"refresh_interval"`    // How often the daemon checks resolved values for rotation (default: 5m)
+	Vault           *VaultConfig      `yaml:"vault"`               // HashiCorp Vault
+	AWS             *AWSSecretsConfig `yaml:"aws_secrets_manager"` // AWS Secrets Manager
+}
+
+// Validate rejects negative durations and unknown KV versions
+func (c *Config) Validate() error {
+	if c.CacheTTL < 0 || c.RefreshInterval < 0 {
+		return fmt.Errorf("cache_ttl and refresh_interval must not be negative")
+	}
+	if c.Vault != nil && c.Vault.KVVersion != 0 && c.Vault.KVVersion != 1 && c.Vault.KVVersion != 2 {
+		return fmt.Errorf("vault.kv_version must be 1 or 2, got %d", c.Vault.KVVersion)
+	}
+	return nil
+}
+
+// RefreshEvery returns the rotation check interval
+func (c *Config) RefreshEvery() time.Duration {
+	if c == nil || c.RefreshInterval <= 0 {
+		return defaultRefreshInterval
+	}
+	return c.RefreshInterval
+}
+
+func (c *Config) cacheTTL() time.Duration {
+	if c == nil || c.CacheTTL <= 0 {
+		return defaultCacheTTL
+	}
+	return c.CacheTTL
+}
+
+// ParseProviderRef splits a vault:// or aws-sm:// value into its scheme,
+// path and field. ok is false for any other value.
+func ParseProviderRef(value string) (scheme, path, field string, ok bool) {
+	scheme, rest, found := strings.Cut(value, "://")
+	if !found || (scheme != SchemeVault && scheme != SchemeAWSSM) {
+		return "", "", "", false
+	}
+	path, field, _ = strings.Cut(rest, "#")
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "", "", "", false
+	}
+	return scheme, path, field, true
+}
+
+type cachedSecret struct {
+	value   string
+	fetched time.Time
+}
+
+// Providers resolves vault:// and aws-sm:// values and caches them, so a
+// config loaded several times fetches each secret once per cache TTL and
+// keeps working on the last value while a secret manager is unreachable
+type Providers struct {
+	mu        sync.Mutex
+	config    *Config
+	providers map[string]Provider
+	ttl       time.Duration
+	cache     map[string]cachedSecret
+	now       func() time.Time
+	log       *slog.Logger
+}
+
+// NewProviders returns providers configured from environment variables
+// only
+func NewProviders() *Providers {
+	p := &Providers{
+		cache: make(map[string]cachedSecret),
+		now:   time.Now,
+		log:   logging.WithComponent("secrets"),
+	}
+	p.Configure(nil)
+	return p
+}
+
+var defaultProviders = NewProviders()
+
+// DefaultProviders returns the providers config.Load resolves through
+func DefaultProviders() *Providers {
+	return defaultProviders
+}
+
+// Configure applies the secrets config section. The cache is
+// kept unless the settings changed.
+func (p *Providers) Configure(cfg *Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.providers != nil && reflect.DeepEqual(p.config, cfg) {
+		return
+	}
+	var vault *VaultConfig
+	var aws *AWSSecretsConfig
+	if cfg != nil {
+		vault, aws = cfg.Vault, cfg.AWS
+	}
+	p.config = cfg
+	p.ttl = cfg.cacheTTL()
+	p.providers = map[string]Provider{
+		SchemeVault: NewVault(vault),
+		SchemeAWSSM: NewAWSSecretsManager(aws),
+	}
+	p.cache = make(map[string]cachedSecret)
+}
+
+// Register sets the provider behind a scheme
+func (p *Providers) Register(scheme string, provider Provider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.providers == nil {
+		p.providers = make(map[string]Provider)
+	}
+	p.providers[scheme] = provider
+	p.cache = make(map[string]cachedSecret)
+}
+
+// Resolve returns the value behind a vault:// or aws-sm:// reference,
+// from the cache while it is fresh
+func (p *Providers) Resolve(ctx context.Context, ref string) (string, error) {
+	p.mu.Lock()
+	cached, ok := p.cache[ref]
+	fresh := ok && p.now().Sub(cached.fetched) < p.ttl
+	p.mu.Unlock()
+	if fresh {
+		return cached.value, nil
+	}
+
+	value, err := p.fetch(ctx, ref)
+	if err != nil {
+		if ok {
+			p.log.Warn("secret manager unreachable, using cached value", "ref", ref, "error", err)
+			return cached.value, nil
+		}
+		return "", err
+	}
+	p.store(ref, value)
+	return value, nil
+}
+
+func (p *Providers) fetch(ctx context.Context, ref string) (string, error) {
+	scheme, path, field, ok := ParseProviderRef(ref)
+	if !ok {
+		return "", fmt.Errorf("%q is not a secret manager reference", ref)
+	}
+	p.mu.Lock()
+	provider := p.providers[scheme]
+	p.mu.Unlock()
+	if provider == nil {
+		return "", fmt.Errorf("no provider for %s://", scheme)
+	}
+	return provider.Resolve(ctx, path, field)
+}
+
+func (p *Providers) store(ref, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache[ref] = cachedSecret{value: value, fetched: p.now()}
+}
+
+// ResolveConfig replaces every vault:// and aws-sm:// value in a YAML
+// config with the secret it points at. The data is returned unchanged
+// when it holds no such value.
+func (p *Providers) ResolveConfig(ctx context.Context, data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte(SchemeVault+"://")) && !bytes.Contains(data, []byte(SchemeAWSSM+"://")) {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultResolveTimeout)
+	defer cancel()
+
+	var errs []string
+	resolved := 0
+	walkScalars(&doc, "", func(path string, node *yaml.Node) {
+		if _, _, _, ok := ParseProviderRef(node.Value); !ok {
+			return
+		}
+		value, err := p.Resolve(ctx, node.Value)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s: %v", path, node.Value, err))
+			return
+		}
+		node.Value = value
+		node.Tag = "!!str"
+		node.Style = yaml.DoubleQuotedStyle
+		resolved++
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	if resolved == 0 {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// walkScalars calls fn with the dotted path of every scalar value
+func walkScalars(node *yaml.Node, prefix string, fn func(path string, node *yaml.Node)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkScalars(child, prefix, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := node.Content[i].Value
+			if prefix != "" {
+				keyPath = prefix + "." + keyPath
+			}
+			walkScalars(node.Content[i+1], keyPath, fn)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			walkScalars(item, fmt.Sprintf("%s[%d]", prefix, i), fn)
+		}
+	case yaml.ScalarNode:
+		fn(prefix, node)
+	}
+}
+
+// Refresh fetches every cached reference again and returns the ones whose
+// value changed. References that fail keep their cached value.
+func (p *Providers) Refresh(ctx context.Context) []string {
+	p.mu.Lock()
+	refs := make([]string, 0, len(p.cache))
+	for ref := range p.cache {
+		refs = append(refs, ref)
+	}
+	p.mu.Unlock()
+	sort.Strings(refs)
+
+	var changed []string
+	for _, ref := range refs {
+		value, err := p.fetch(ctx, ref)
+		if err != nil {
+			p.log.Warn("failed to refresh secret", "ref", ref, "error", err)
+			continue
+		}
+		p.mu.Lock()
+		previous, ok := p.cache[ref]
+		p.mu.Unlock()
+		p.store(ref, value)
+		if ok && previous.value != value {
+			changed = append(changed, ref)
+		}
+	}
+	return changed
+}
+
+// Watch refreshes the cached references every interval until ctx is done
+// and calls onRotate with the ones that changed
+func (p *Providers) Watch(ctx context.Context, interval time.Duration, onRotate func(refs []string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if changed := p.Refresh(ctx); len(changed) > 0 {
+				onRotate(changed)
+			}
+		}
+	}
+}
+
+// Cached returns the number of references resolved so far
+func (p *Providers) Cached() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.cache)
+}
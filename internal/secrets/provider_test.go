@@ -0,0 +1,188 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeProvider serves secrets from a map and counts lookups
+type fakeProvider struct {
+	values map[string]string
+	err    error
+	calls  int
+}
+
+func (f *fakeProvider) Resolve(_ context.Context, path, field string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	value, ok := f.values[path+"#"+field]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func TestParseProviderRef(t *testing.T) {
+	tests := []struct {
+		value, scheme, path, field string
+		ok                         bool
+	}{
+		{"vault://secret/pilot/github#token", SchemeVault, "secret/pilot/github", "token", true},
+		{"aws-sm://pilot/github-token", SchemeAWSSM, "pilot/github-token", "", true},
+		{"https://example.com", "", "", "", false},
+		{"vault://", "", "", "", false},
+		{"ghp_abc", "", "", "", false},
+	}
+	for _, tt := range tests {
+		scheme, path, field, ok := ParseProviderRef(tt.value)
+		if scheme != tt.scheme || path != tt.path || field != tt.field || ok != tt.ok {
+			t.Errorf("ParseProviderRef(%q) = %q, %q, %q, %v", tt.value, scheme, path, field, ok)
+		}
+	}
+}
+
+func TestProviders_ResolveConfigCachesAndRefreshes(t *testing.T) {
+	vault := &fakeProvider{values: map[string]string{"secret/pilot/github#token": "ghp_v1"}}
+	p := NewProviders()
+	p.Register(SchemeVault, vault)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	config := []byte("adapters:\n  github:\n    token: vault://secret/pilot/github#token\n    repo: acme/app\n")
+	out, err := p.ResolveConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ResolveConfig failed: %v", err)
+	}
+	if !strings.Contains(string(out), `token: "ghp_v1"`) || !strings.Contains(string(out), "repo: acme/app") {
+		t.Errorf("resolved config:\n%s", out)
+	}
+
+	// Within the TTL the cached value is used
+	if _, err := p.ResolveConfig(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+	if vault.calls != 1 {
+		t.Errorf("provider called %d times, want 1", vault.calls)
+	}
+
+	// Rotation is picked up by Refresh
+	vault.values["secret/pilot/github#token"] = "ghp_v2"
+	if changed := p.Refresh(context.Background()); strings.Join(changed, ",") != "vault://secret/pilot/github#token" {
+		t.Errorf("Refresh() changed = %v", changed)
+	}
+
+	// An unreachable provider serves the last value after the TTL
+	vault.err = fmt.Errorf("connection refused")
+	now = now.Add(time.Hour)
+	out, err = p.ResolveConfig(context.Background(), config)
+	if err != nil || !strings.Contains(string(out), "ghp_v2") {
+		t.Errorf("ResolveConfig with provider down = %s, %v", out, err)
+	}
+
+	// Without a cached value the error surfaces with the config path
+	_, err = p.ResolveConfig(context.Background(), []byte("token: vault://secret/other#token\n"))
+	if err == nil || !strings.Contains(err.Error(), "token: vault://secret/other#token") {
+		t.Errorf("ResolveConfig() missing secret = %v", err)
+	}
+
+	plain := []byte("token: ghp_abc\n")
+	if out, err := p.ResolveConfig(context.Background(), plain); err != nil || string(out) != string(plain) {
+		t.Errorf("ResolveConfig() without references = %q, %v", out, err)
+	}
+}
+
+func TestVault_Resolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.test" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/pilot/github":
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"ghp_vault","user":"pilot"},"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	v := NewVault(&VaultConfig{Address: srv.URL + "/", Token: "s.test"})
+	if got, err := v.Resolve(context.Background(), "secret/pilot/github", "token"); err != nil || got != "ghp_vault" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+	if _, err := v.Resolve(context.Background(), "secret/pilot/github", ""); err == nil {
+		t.Error("Resolve() without a field should fail for a secret with several fields")
+	}
+	if _, err := v.Resolve(context.Background(), "secret/pilot/missing", "token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve() missing = %v, want ErrNotFound", err)
+	}
+
+	denied := NewVault(&VaultConfig{Address: srv.URL, Token: "wrong"})
+	if _, err := denied.Resolve(context.Background(), "secret/pilot/github", "token"); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Resolve() with a bad token = %v", err)
+	}
+}
+
+func TestAWSSecretsManager_Resolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260101/eu-west-1/secretsmanager/aws4_request") ||
+			r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req struct{ SecretId string }
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch req.SecretId {
+		case "pilot/github-token":
+			_, _ = w.Write([]byte(`{"Name":"pilot/github-token","SecretString":"ghp_aws"}`))
+		case "pilot/github":
+			_, _ = w.Write([]byte(`{"Name":"pilot/github","SecretString":"{\"token\":\"ghp_json\"}"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+	defer srv.Close()
+
+	a := NewAWSSecretsManager(&AWSSecretsConfig{
+		Region:          "eu-west-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		Endpoint:        srv.URL,
+	})
+	a.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	if got, err := a.Resolve(context.Background(), "pilot/github-token", ""); err != nil || got != "ghp_aws" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+	if got, err := a.Resolve(context.Background(), "pilot/github", "token"); err != nil || got != "ghp_json" {
+		t.Errorf("Resolve() JSON field = %q, %v", got, err)
+	}
+	if _, err := a.Resolve(context.Background(), "pilot/missing", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve() missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSignV4(t *testing.T) {
+	// get-vanilla from the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	creds := awsCredentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signV4(req, nil, "service", "us-east-1", creds, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+// Package secrets keeps adapter tokens and other credentials out of the
+// config file. A config value can reference a secret instead of holding it:
+//
+//	${env:GITHUB_TOKEN}        environment variable
+//	${keychain:github-token}   OS keychain (macOS Keychain, Linux Secret Service)
+//	${secret:github-token}     encrypted secrets file, unlocked with a passphrase
+//
+// Plain ${VAR} and $VAR keep expanding environment variables.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Backends a reference can name
+const (
+	BackendEnv      = "env"
+	BackendKeychain = "keychain"
+	BackendFile     = "secret"
+)
+
+// ErrNotFound is returned when a secret does not exist
+var ErrNotFound = errors.New("secret not found")
+
+// Store reads and writes named secrets
+type Store interface {
+	Get(name string) (string, error)
+	Set(name, value string) error
+	Delete(name string) error
+}
+
+// Reference returns the config value that resolves to the named secret
+func Reference(backend, name string) string {
+	return fmt.Sprintf("${%s:%s}", backend, name)
+}
+
+// ValidateName rejects names that cannot appear in a reference
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("secret name is required")
+	}
+	if strings.ContainsAny(name, "{}$: \t\n") {
+		return fmt.Errorf("secret name %q must not contain spaces, '$', ':', '{' or '}'", name)
+	}
+	return nil
+}
+
+// Resolver expands secret references in config text
+type Resolver struct {
+	Keychain Store
+	File     Store
+	Getenv   func(string) string
+}
+
+// NewResolver resolves the keychain and the default secrets file
+func NewResolver() *Resolver {
+	return &Resolver{
+		Keychain: NewKeychain(),
+		File:     DefaultFile(),
+		Getenv:   os.Getenv,
+	}
+}
+
+// Store returns the store behind a backend
+func (r *Resolver) Store(backend string) (Store, error) {
+	switch backend {
+	case BackendKeychain:
+		return r.Keychain, nil
+	case BackendFile:
+		return r.File, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets backend %q (want %s or %s)", backend, BackendKeychain, BackendFile)
+	}
+}
+
+// Expand replaces references and environment variables in s. Stores are
+// only consulted for references that name them, so a config without
+// ${keychain:…} or ${secret:…} never prompts for a passphrase.
+func (r *Resolver) Expand(s string) (string, error) {
+	var errs []error
+	expanded := os.Expand(s, func(key string) string {
+		backend, name, ok := strings.Cut(key, ":")
+		if !ok {
+			return r.Getenv(key)
+		}
+		var value string
+		var err error
+		switch backend {
+		case BackendEnv:
+			value = r.Getenv(name)
+		case BackendKeychain, BackendFile:
+			store, _ := r.Store(backend)
+			value, err = store.Get(name)
+		default:
+			// Not a reference, e.g. ${a:b} in a shell snippet; keep os.ExpandEnv behavior
+			return r.Getenv(key)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", Reference(backend, name), err))
+		}
+		return value
+	})
+	return expanded, errors.Join(errs...)
+}
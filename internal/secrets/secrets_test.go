@@ -0,0 +1,211 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// memStore is an in-memory Store
+type memStore map[string]string
+
+func (m memStore) Get(name string) (string, error) {
+	value, ok := m[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (m memStore) Set(name, value string) error {
+	m[name] = value
+	return nil
+}
+
+func (m memStore) Delete(name string) error {
+	delete(m, name)
+	return nil
+}
+
+func passphrase(p string) func() ([]byte, error) {
+	return func() ([]byte, error) { return []byte(p), nil }
+}
+
+func TestResolver_Expand(t *testing.T) {
+	env := map[string]string{"GITHUB_TOKEN": "ghp_env", "HOME": "/home/pilot"}
+	r := &Resolver{
+		Keychain: memStore{"slack": "xoxb-keychain"},
+		File:     memStore{"linear": "lin_file"},
+		Getenv:   func(k string) string { return env[k] },
+	}
+
+	got, err := r.Expand("a: ${env:GITHUB_TOKEN}\nb: ${keychain:slack}\nc: ${secret:linear}\nd: ${GITHUB_TOKEN}\ne: $HOME\n")
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	want := "a: ghp_env\nb: xoxb-keychain\nc: lin_file\nd: ghp_env\ne: /home/pilot\n"
+	if got != want {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+
+	_, err = r.Expand("token: ${secret:missing}")
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "${secret:missing}") {
+		t.Errorf("Expand() missing secret error = %v", err)
+	}
+}
+
+func TestResolver_ExpandSkipsUnusedStores(t *testing.T) {
+	locked := NewFile(filepath.Join(t.TempDir(), "secrets.enc"), func() ([]byte, error) {
+		return nil, fmt.Errorf("should not be asked for")
+	})
+	r := &Resolver{Keychain: memStore{}, File: locked, Getenv: func(string) string { return "v" }}
+	if _, err := r.Expand("token: ${TOKEN}"); err != nil {
+		t.Errorf("Expand() without references = %v", err)
+	}
+}
+
+func TestFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pilot", "secrets.enc")
+	f := NewFile(path, passphrase("correct horse"))
+	if names, err := f.List(); err != nil || len(names) != 0 {
+		t.Fatalf("List() on missing file = %v, %v", names, err)
+	}
+	if err := f.Set("github-token", "ghp_abc"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := f.Set("slack-token", "xoxb-abc"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("secrets file mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "ghp_abc") {
+		t.Error("secrets file contains the plaintext secret")
+	}
+
+	reopened := NewFile(path, passphrase("correct horse"))
+	if got, err := reopened.Get("github-token"); err != nil || got != "ghp_abc" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+	if err := reopened.Delete("slack-token"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if names, _ := reopened.List(); strings.Join(names, ",") != "github-token" {
+		t.Errorf("List() = %v", names)
+	}
+	if _, err := reopened.Get("slack-token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() deleted secret = %v, want ErrNotFound", err)
+	}
+
+	wrong := NewFile(path, passphrase("wrong"))
+	if _, err := wrong.Get("github-token"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Get() with wrong passphrase = %v", err)
+	}
+}
+
+func TestRewriteConfig(t *testing.T) {
+	config := `adapters:
+  github:
+    token: ghp_secret # personal token
+    webhook_secret: "${GITHUB_WEBHOOK_SECRET}"
+  slack:
+    bot_token: ${keychain:slack}
+    channel: "#dev"
+alerts:
+  channels:
+    - pagerduty:
+        routing_key: abc123
+`
+	out, paths, err := RewriteConfig([]byte(config), func(path, value string) (string, error) {
+		return Reference(BackendFile, path), nil
+	})
+	if err != nil {
+		t.Fatalf("RewriteConfig failed: %v", err)
+	}
+	want := []string{"adapters.github.token", "alerts.channels[0].pagerduty.routing_key"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	got := string(out)
+	for _, s := range []string{
+		`"${secret:adapters.github.token}"`,
+		`"${secret:alerts.channels[0].pagerduty.routing_key}"`,
+		"# personal token",
+		"${keychain:slack}",
+		"${GITHUB_WEBHOOK_SECRET}",
+	} {
+		if !strings.Contains(got, s) {
+			t.Errorf("rewritten config missing %q:\n%s", s, got)
+		}
+	}
+
+	// The rewritten config resolves back to the original values
+	r := &Resolver{
+		Keychain: memStore{"slack": "x"},
+		File:     memStore{"adapters.github.token": "ghp_secret", "alerts.channels[0].pagerduty.routing_key": "abc123"},
+		Getenv:   func(string) string { return "" },
+	}
+	expanded, err := r.Expand(got)
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	if !strings.Contains(expanded, `"ghp_secret"`) || !strings.Contains(expanded, `"abc123"`) {
+		t.Errorf("expanded config:\n%s", expanded)
+	}
+
+	unchanged, paths, err := RewriteConfig([]byte("memory:\n  path: ~/.pilot\n"), nil)
+	if err != nil || len(paths) != 0 || string(unchanged) != "memory:\n  path: ~/.pilot\n" {
+		t.Errorf("RewriteConfig() without secrets = %q, %v, %v", unchanged, paths, err)
+	}
+}
+
+func TestKeychain_Commands(t *testing.T) {
+	var calls []string
+	fake := func(stdin, name string, args ...string) (string, error) {
+		calls = append(calls, fmt.Sprintf("%s %s <%s>", name, strings.Join(args, " "), stdin))
+		return "s3cret\n", nil
+	}
+
+	mac := &Keychain{goos: "darwin", run: fake}
+	if got, err := mac.Get("github-token"); err != nil || got != "s3cret" {
+		t.Errorf("darwin Get() = %q, %v", got, err)
+	}
+	linux := &Keychain{goos: "linux", run: fake}
+	if err := linux.Set("github-token", "ghp_abc"); err != nil {
+		t.Errorf("linux Set() = %v", err)
+	}
+	want := []string{
+		"security find-generic-password -s pilot-secrets -a github-token -w <>",
+		"secret-tool store --label Pilot: github-token service pilot-secrets account github-token <ghp_abc>",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+
+	windows := &Keychain{goos: "windows", run: fake}
+	if _, err := windows.Get("x"); err == nil {
+		t.Error("Get() on an unsupported OS should fail")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"github-token", "adapters.github.token", "alerts.channels[0].key"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "a:b", "a}b", "a b"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// VaultConfig configures HashiCorp Vault. Unset fields fall back to
+// VAULT_ADDR, VAULT_TOKEN (then ~/.vault-token) and VAULT_NAMESPACE.
+type VaultConfig struct {
+	Address   string `yaml:"address"`    // e.g. https://vault.internal:8200
+	Token     string `yaml:"token"`      // Token with read access to the referenced paths
+	Namespace string `yaml:"namespace"`  // Vault Enterprise namespace
+	KVVersion int    `yaml:"kv_version"` // KV secrets engine version, 1 or 2 (default: 2)
+}
+
+// Vault reads secrets from a KV secrets engine. The first element of a
+// path is the mount: vault://secret/pilot/github#token reads the token
+// field of pilot/github on the secret/ mount.
+type Vault struct {
+	address   string
+	token     string
+	namespace string
+	kvVersion int
+	client    *http.Client
+}
+
+// NewVault returns a Vault client; cfg may be nil
+func NewVault(cfg *VaultConfig) *Vault {
+	v := &Vault{
+		address:   os.Getenv("VAULT_ADDR"),
+		token:     os.Getenv("VAULT_TOKEN"),
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		kvVersion: 2,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg != nil {
+		if cfg.Address != "" {
+			v.address = cfg.Address
+		}
+		if cfg.Token != "" {
+			v.token = cfg.Token
+		}
+		if cfg.Namespace != "" {
+			v.namespace = cfg.Namespace
+		}
+		if cfg.KVVersion != 0 {
+			v.kvVersion = cfg.KVVersion
+		}
+	}
+	if v.token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				v.token = strings.TrimSpace(string(data))
+			}
+		}
+	}
+	v.address = strings.TrimRight(v.address, "/")
+	return v
+}
+
+// Resolve reads a field of the secret at path. The field may be left out
+// when the secret has only one.
+func (v *Vault) Resolve(ctx context.Context, path, field string) (string, error) {
+	if v.address == "" {
+		return "", fmt.Errorf("vault address is not set: configure secrets.vault.address or VAULT_ADDR")
+	}
+	if v.token == "" {
+		return "", fmt.Errorf("vault token is not set: configure secrets.vault.token or VAULT_TOKEN")
+	}
+
+	apiPath := path
+	if v.kvVersion == 2 {
+		mount, rest, ok := strings.Cut(path, "/")
+		if !ok {
+			return "", fmt.Errorf("vault path %q must include the mount, e.g. secret/pilot/github", path)
+		}
+		apiPath = mount + "/data/" + rest
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.address+"/v1/"+apiPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w in vault at %s", ErrNotFound, path)
+	case http.StatusForbidden:
+		return "", fmt.Errorf("vault denied access to %s: check the token's policy", path)
+	default:
+		return "", fmt.Errorf("vault returned %d for %s: %s", resp.StatusCode, path, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse vault response: %w", err)
+	}
+	data := result.Data
+	if v.kvVersion == 2 {
+		inner, _ := data["data"].(map[string]any)
+		data = inner
+	}
+	return pickField(data, path, field)
+}
+
+// pickField returns one field of a secret holding several
+func pickField(data map[string]any, path, field string) (string, error) {
+	if field == "" {
+		if len(data) != 1 {
+			return "", fmt.Errorf("secret %s has %d fields, pick one with #field", path, len(data))
+		}
+		for _, value := range data {
+			return stringValue(value), nil
+		}
+	}
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("%w: field %q of %s", ErrNotFound, field, path)
+	}
+	return stringValue(value), nil
+}
+
+func stringValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
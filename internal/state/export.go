@@ -0,0 +1,166 @@
+package state
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+// Sources locates the state Export bundles
+type Sources struct {
+	ConfigPath     string        // Config file; skipped when it does not exist
+	MemoryPath     string        // memory.path
+	RecordingsPath string        // Recordings directory; empty skips recordings
+	Store          *memory.Store // Open store of MemoryPath, snapshotted while in use
+	PilotVersion   string
+}
+
+// Export writes a tar.gz archive of the state in src to w
+func Export(w io.Writer, src Sources) (*Manifest, error) {
+	host, _ := os.Hostname()
+	m := &Manifest{
+		Version:      FormatVersion,
+		CreatedAt:    time.Now().UTC(),
+		Hostname:     host,
+		PilotVersion: src.PilotVersion,
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	a := &archiveWriter{tw: tw}
+
+	// Contents are gathered first so the manifest leads the archive
+	var configData []byte
+	if data, err := os.ReadFile(src.ConfigPath); err == nil {
+		redacted, keys, err := RedactConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		configData = redacted
+		m.Config = true
+		m.Redacted = keys
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var snapshot string
+	if src.Store != nil {
+		if src.Store.Driver() == memory.DriverPostgres {
+			m.Notes = append(m.Notes, "Memory store is on PostgreSQL and was not exported; back it up with pg_dump")
+		} else {
+			dir, err := os.MkdirTemp("", "pilot-state-")
+			if err != nil {
+				return nil, err
+			}
+			defer func() { _ = os.RemoveAll(dir) }()
+			snapshot = filepath.Join(dir, databaseName)
+			if err := src.Store.Snapshot(snapshot); err != nil {
+				return nil, err
+			}
+			m.Database = true
+
+			// The snapshot is plaintext; keep it sealed in the archive when
+			// the store is encrypted at rest
+			key, err := encryption.Default()
+			if err != nil {
+				return nil, fmt.Errorf("memory store: %w", err)
+			}
+			if key != nil {
+				sealed := snapshot + sealedSuffix
+				if err := key.EncryptFile(snapshot, sealed); err != nil {
+					return nil, fmt.Errorf("failed to encrypt memory store: %w", err)
+				}
+				snapshot = sealed
+				m.Encrypted = true
+			}
+		}
+	}
+
+	// Counted up front for the manifest, then written below
+	var err error
+	if m.Files, err = countFiles(src.MemoryPath, skipMemoryFile); err != nil {
+		return nil, fmt.Errorf("failed to read memory directory: %w", err)
+	}
+	if src.RecordingsPath != "" {
+		if m.Recordings, err = countFiles(src.RecordingsPath, nil); err != nil {
+			return nil, fmt.Errorf("failed to read recordings: %w", err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.writeBytes(manifestName, manifest, 0644); err != nil {
+		return nil, err
+	}
+	if configData != nil {
+		if err := a.writeBytes(configName, configData, 0600); err != nil {
+			return nil, err
+		}
+	}
+	if snapshot != "" {
+		name := databaseName
+		if m.Encrypted {
+			name += sealedSuffix
+		}
+		if err := a.writeFile(path.Join(memoryDir, name), snapshot); err != nil {
+			return nil, fmt.Errorf("failed to add memory store: %w", err)
+		}
+	}
+	if err := a.writeTree(memoryDir, src.MemoryPath, skipMemoryFile); err != nil {
+		return nil, err
+	}
+	if src.RecordingsPath != "" {
+		if err := a.writeTree(recordingsDir, src.RecordingsPath, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// skipMemoryFile leaves the live database files out of the memory tree
+func skipMemoryFile(rel string) bool {
+	return path.Dir(rel) == "." && isSkippedMemoryFile(rel)
+}
+
+// countFiles counts the regular files under dir that skip does not exclude
+func countFiles(dir string, skip func(rel string) bool) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if skip == nil || !skip(filepath.ToSlash(rel)) {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,267 @@
+package state
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
+)
+
+// ErrStateExists is returned when Import would overwrite a memory store
+// without Targets.Force
+var ErrStateExists = errors.New("memory store already exists")
+
+// Targets locates where Import unpacks an archive
+type Targets struct {
+	ConfigPath     string
+	RecordingsPath string
+	// MemoryPath resolves memory.path once the config is in place, so a
+	// fresh machine uses the imported config's path
+	MemoryPath func() (string, error)
+	// SkipDatabase leaves the memory store out, e.g. when the target runs
+	// on PostgreSQL
+	SkipDatabase bool
+	// Force replaces an existing memory store and files instead of
+	// stopping or skipping them
+	Force bool
+}
+
+// Result reports what Import did
+type Result struct {
+	Manifest     *Manifest
+	MemoryPath   string
+	ConfigPath   string // Where the archive's config was written, "" if absent
+	ConfigKept   bool   // An existing config was kept; the archive's is next to it
+	Database     bool
+	Files        int
+	Recordings   int
+	Skipped      int // Existing files left alone
+	SkippedStore bool
+}
+
+// Import unpacks the archive read from r into dst
+func Import(r io.Reader, dst Targets) (*Result, error) {
+	tr, closeArchive, err := openArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = closeArchive() }()
+
+	res := &Result{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return res, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if res.Manifest == nil {
+			if hdr.Name != manifestName {
+				return res, fmt.Errorf("not a pilot state archive: missing %s", manifestName)
+			}
+			if res.Manifest, err = readManifest(tr); err != nil {
+				return res, err
+			}
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == configName:
+			if err := res.importConfig(tr, dst.ConfigPath); err != nil {
+				return res, err
+			}
+		case strings.HasPrefix(name, memoryDir+"/"):
+			if err := res.resolveMemory(dst); err != nil {
+				return res, err
+			}
+			rel := strings.TrimPrefix(name, memoryDir+"/")
+			if rel == databaseName || rel == databaseName+sealedSuffix {
+				if dst.SkipDatabase {
+					res.SkippedStore = true
+					continue
+				}
+				var data io.Reader = tr
+				if rel != databaseName {
+					opened, err := unseal(tr)
+					if err != nil {
+						return res, err
+					}
+					defer func() { _ = opened.Close() }()
+					data = opened
+				}
+				if err := writeEntry(data, res.MemoryPath, databaseName, hdr.FileInfo().Mode()); err != nil {
+					return res, err
+				}
+				res.Database = true
+				continue
+			}
+			written, err := writeEntryUnlessExists(tr, res.MemoryPath, rel, hdr.FileInfo().Mode(), dst.Force)
+			if err != nil {
+				return res, err
+			}
+			if written {
+				res.Files++
+			} else {
+				res.Skipped++
+			}
+		case strings.HasPrefix(name, recordingsDir+"/"):
+			if dst.RecordingsPath == "" {
+				continue
+			}
+			rel := strings.TrimPrefix(name, recordingsDir+"/")
+			written, err := writeEntryUnlessExists(tr, dst.RecordingsPath, rel, hdr.FileInfo().Mode(), dst.Force)
+			if err != nil {
+				return res, err
+			}
+			if written {
+				res.Recordings++
+			} else {
+				res.Skipped++
+			}
+		}
+	}
+	if res.Manifest == nil {
+		return res, fmt.Errorf("not a pilot state archive: empty")
+	}
+	return res, nil
+}
+
+// unseal decrypts a sealed database entry with the configured key. The
+// store seals it again on close when encryption at rest is on here.
+func unseal(r io.Reader) (io.ReadCloser, error) {
+	key, err := encryption.Default()
+	if err != nil {
+		return nil, fmt.Errorf("memory store: %w", err)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("memory store in the archive is encrypted: %w; configure the key it was exported with", encryption.ErrKeyUnavailable)
+	}
+	pr, pw := io.Pipe()
+	go func() { _ = pw.CloseWithError(key.Decrypt(pw, r)) }()
+	return pr, nil
+}
+
+// importConfig writes the archive's config, next to an existing one so
+// its secrets are not replaced by redacted values
+func (res *Result) importConfig(r io.Reader, configPath string) error {
+	target := configPath
+	if _, err := os.Stat(configPath); err == nil {
+		target = configPath + importedSuffix
+		res.ConfigKept = true
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if err := writeFile(target, r, 0600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	res.ConfigPath = target
+	return nil
+}
+
+// resolveMemory finds the memory path on first use and checks that the
+// store there may be replaced
+func (res *Result) resolveMemory(dst Targets) error {
+	if res.MemoryPath != "" {
+		return nil
+	}
+	dir, err := dst.MemoryPath()
+	if err != nil {
+		return err
+	}
+	if !dst.SkipDatabase && res.Manifest.Database {
+		existing, err := existingStore(dir)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 && !dst.Force {
+			return fmt.Errorf("%w at %s", ErrStateExists, dir)
+		}
+		for _, name := range existing {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return fmt.Errorf("failed to replace memory store: %w", err)
+			}
+		}
+	}
+	res.MemoryPath = dir
+	return nil
+}
+
+// existingStore lists the database files of a memory store in dir
+func existingStore(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && isSkippedMemoryFile(e.Name()) && !strings.HasSuffix(e.Name(), ".lock") {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// writeEntryUnlessExists writes an entry below dir, leaving an existing
+// file alone unless force is set. Reports whether it wrote.
+func writeEntryUnlessExists(r io.Reader, dir, rel string, mode os.FileMode, force bool) (bool, error) {
+	target, err := entryPath(dir, rel)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(target); err == nil && !force {
+		return false, nil
+	}
+	return true, writeEntry(r, dir, rel, mode)
+}
+
+// writeEntry writes an entry below dir
+func writeEntry(r io.Reader, dir, rel string, mode os.FileMode) error {
+	target, err := entryPath(dir, rel)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if err := writeFile(target, r, mode.Perm()|0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return nil
+}
+
+// writeFile copies r into path through a temp file, so an interrupted
+// import leaves no partial file behind
+func writeFile(path string, r io.Reader, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
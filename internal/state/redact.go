@@ -0,0 +1,19 @@
+package state
+
+import (
+	"github.com/alekspetrov/pilot/internal/secrets"
+)
+
+// redactedValue replaces secrets in the exported config
+const redactedValue = "REDACTED"
+
+// RedactConfig replaces the values of secret keys in a YAML config with
+// REDACTED and returns the dotted paths of the keys it changed. Values
+// that reference environment variables or stored secrets, such as
+// "${GITHUB_TOKEN}" or "${keychain:github-token}", hold no secret and are
+// kept.
+func RedactConfig(data []byte) ([]byte, []string, error) {
+	return secrets.RewriteConfig(data, func(string, string) (string, error) {
+		return redactedValue, nil
+	})
+}
@@ -0,0 +1,156 @@
+// Package state moves a Pilot setup between machines. Export bundles the
+// memory store (execution history, autopilot state, teams, budgets), the
+// pattern and knowledge files next to it, recordings and the config into
+// one tar.gz archive; Import unpacks it on the new machine. Secrets in the
+// config are left out of the archive, and a memory store encrypted at
+// rest stays sealed with the same key.
+package state
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the archive layout version Export writes
+const FormatVersion = 1
+
+// Archive entries
+const (
+	manifestName   = "manifest.json"
+	configName     = "config.yaml"
+	memoryDir      = "memory"
+	recordingsDir  = "recordings"
+	databaseName   = "pilot.db"
+	sealedSuffix   = ".enc"
+	importedSuffix = ".imported"
+)
+
+// Manifest describes an archive
+type Manifest struct {
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	Hostname     string    `json:"hostname"`
+	PilotVersion string    `json:"pilot_version"`
+	Database     bool      `json:"database"`            // memory/pilot.db is included
+	Encrypted    bool      `json:"encrypted,omitempty"` // The database is sealed as memory/pilot.db.enc
+	Files        int       `json:"files"`               // Memory files besides the database
+	Recordings   int       `json:"recordings"`          // Files under recordings/
+	Config       bool      `json:"config"`
+	Redacted     []string  `json:"redacted,omitempty"` // Config keys whose secrets were left out
+	Notes        []string  `json:"notes,omitempty"`
+}
+
+// isSkippedMemoryFile reports files in the memory directory that are not
+// exported: the live database, which is snapshotted instead, its
+// journals, locks and sealed copy.
+func isSkippedMemoryFile(name string) bool {
+	return name == databaseName || strings.HasPrefix(name, databaseName+"-") || strings.HasPrefix(name, databaseName+".")
+}
+
+// archiveWriter writes regular files into a tar stream
+type archiveWriter struct {
+	tw *tar.Writer
+}
+
+func (a *archiveWriter) writeBytes(name string, data []byte, mode fs.FileMode) error {
+	if err := a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(mode.Perm()),
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := a.tw.Write(data)
+	return err
+}
+
+func (a *archiveWriter) writeFile(name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(a.tw, f)
+	return err
+}
+
+// writeTree adds every regular file under dir below prefix, skipping those
+// skip reports
+func (a *archiveWriter) writeTree(prefix, dir string, skip func(rel string) bool) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if skip != nil && skip(rel) {
+			return nil
+		}
+		if err := a.writeFile(path.Join(prefix, rel), p); err != nil {
+			return fmt.Errorf("failed to add %s: %w", p, err)
+		}
+		return nil
+	})
+}
+
+// openArchive opens a tar.gz archive for reading
+func openArchive(r io.Reader) (*tar.Reader, func() error, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a pilot state archive: %w", err)
+	}
+	return tar.NewReader(gz), gz.Close, nil
+}
+
+// entryPath maps an archive entry name to a path below dir, rejecting names
+// that would escape it
+func entryPath(dir, name string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("archive entry %q escapes its directory", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+// readManifest decodes the manifest entry and checks its version
+func readManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if m.Version < 1 || m.Version > FormatVersion {
+		return nil, fmt.Errorf("archive format version %d is not supported (want %d or older), upgrade pilot", m.Version, FormatVersion)
+	}
+	return &m, nil
+}
@@ -0,0 +1,250 @@
+package state
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alekspetrov/pilot/internal/encryption"
+	"github.com/alekspetrov/pilot/internal/memory"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	config := `# Pilot config
+adapters:
+  github:
+    enabled: true
+    token: ghp_secret # personal token
+    webhook_secret: "${GITHUB_WEBHOOK_SECRET}"
+  slack:
+    bot_token: xoxb-secret
+alerts:
+  channels:
+    - name: pd
+      pagerduty:
+        routing_key: abc123
+memory:
+  path: ~/.pilot/data
+`
+	out, redacted, err := RedactConfig([]byte(config))
+	if err != nil {
+		t.Fatalf("RedactConfig failed: %v", err)
+	}
+	got := string(out)
+	for _, secret := range []string{"ghp_secret", "xoxb-secret", "abc123"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("redacted config still contains %q:\n%s", secret, got)
+		}
+	}
+	for _, kept := range []string{"${GITHUB_WEBHOOK_SECRET}", "~/.pilot/data", "# Pilot config"} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("redacted config lost %q:\n%s", kept, got)
+		}
+	}
+	want := []string{"adapters.github.token", "adapters.slack.bot_token", "alerts.channels[0].pagerduty.routing_key"}
+	if strings.Join(redacted, ",") != strings.Join(want, ",") {
+		t.Errorf("redacted = %v, want %v", redacted, want)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	src := t.TempDir()
+	memPath := filepath.Join(src, "data")
+	recPath := filepath.Join(src, "recordings")
+	configPath := filepath.Join(src, "config.yaml")
+	writeTestFile(t, configPath, "adapters:\n  github:\n    token: ghp_secret\n")
+	writeTestFile(t, filepath.Join(memPath, "global_patterns.json"), `{"patterns":[]}`)
+	writeTestFile(t, filepath.Join(recPath, "TG-1", "metadata.json"), `{"id":"TG-1"}`)
+
+	store, err := memory.NewStore(memPath)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if err := store.SaveExecution(&memory.Execution{ID: "exec-1", TaskID: "GH-1", ProjectPath: "/app", Status: "completed"}); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+
+	var archive bytes.Buffer
+	m, err := Export(&archive, Sources{
+		ConfigPath:     configPath,
+		MemoryPath:     memPath,
+		RecordingsPath: recPath,
+		Store:          store,
+		PilotVersion:   "1.2.3",
+	})
+	_ = store.Close()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !m.Database || m.Files != 1 || m.Recordings != 1 || !m.Config || len(m.Redacted) != 1 {
+		t.Errorf("manifest = %+v", m)
+	}
+
+	dst := t.TempDir()
+	dstMem := filepath.Join(dst, "data")
+	targets := Targets{
+		ConfigPath:     filepath.Join(dst, "config.yaml"),
+		RecordingsPath: filepath.Join(dst, "recordings"),
+		MemoryPath:     func() (string, error) { return dstMem, nil },
+	}
+	res, err := Import(bytes.NewReader(archive.Bytes()), targets)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !res.Database || res.Files != 1 || res.Recordings != 1 || res.ConfigKept {
+		t.Errorf("result = %+v", res)
+	}
+
+	config, err := os.ReadFile(targets.ConfigPath)
+	if err != nil {
+		t.Fatalf("imported config: %v", err)
+	}
+	if strings.Contains(string(config), "ghp_secret") {
+		t.Errorf("imported config contains the secret:\n%s", config)
+	}
+	if _, err := os.Stat(filepath.Join(targets.RecordingsPath, "TG-1", "metadata.json")); err != nil {
+		t.Errorf("recording not imported: %v", err)
+	}
+
+	imported, err := memory.NewStore(dstMem)
+	if err != nil {
+		t.Fatalf("NewStore on imported data failed: %v", err)
+	}
+	exec, err := imported.GetExecution("exec-1")
+	_ = imported.Close()
+	if err != nil || exec.TaskID != "GH-1" {
+		t.Errorf("imported execution = %+v, %v", exec, err)
+	}
+
+	// A second import keeps the config and refuses to replace the store
+	_, err = Import(bytes.NewReader(archive.Bytes()), targets)
+	if !errors.Is(err, ErrStateExists) {
+		t.Errorf("second Import() = %v, want ErrStateExists", err)
+	}
+	targets.Force = true
+	res, err = Import(bytes.NewReader(archive.Bytes()), targets)
+	if err != nil {
+		t.Fatalf("forced Import failed: %v", err)
+	}
+	if !res.ConfigKept || res.ConfigPath != targets.ConfigPath+importedSuffix || !res.Database {
+		t.Errorf("forced result = %+v", res)
+	}
+}
+
+func TestExportImport_Encrypted(t *testing.T) {
+	encoded, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encryption.ParseKey(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryption.SetDefault(key)
+	defer encryption.SetDefault(nil)
+
+	memPath := filepath.Join(t.TempDir(), "data")
+	store, err := memory.NewStore(memPath)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if err := store.SaveExecution(&memory.Execution{ID: "exec-1", TaskID: "GH-1", ProjectPath: "/app", Status: "completed"}); err != nil {
+		t.Fatalf("SaveExecution failed: %v", err)
+	}
+
+	var archive bytes.Buffer
+	m, err := Export(&archive, Sources{MemoryPath: memPath, Store: store})
+	_ = store.Close()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !m.Database || !m.Encrypted {
+		t.Errorf("manifest = %+v", m)
+	}
+
+	// The database must not be in the archive as plain SQLite
+	tr, closeArchive, err := openArchive(bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = closeArchive() }()
+	found := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name == memoryDir+"/"+databaseName {
+			t.Errorf("archive contains plaintext %s", hdr.Name)
+		}
+		if hdr.Name != memoryDir+"/"+databaseName+sealedSuffix || hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		found = true
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.HasPrefix(data, []byte("SQLite format 3")) || !encryption.IsEncrypted(data) {
+			t.Errorf("archived database is not sealed")
+		}
+	}
+	if !found {
+		t.Fatal("archive has no sealed database")
+	}
+
+	dstMem := filepath.Join(t.TempDir(), "data")
+	targets := Targets{
+		ConfigPath: filepath.Join(t.TempDir(), "config.yaml"),
+		MemoryPath: func() (string, error) { return dstMem, nil },
+	}
+	if _, err := Import(bytes.NewReader(archive.Bytes()), targets); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	imported, err := memory.NewStore(dstMem)
+	if err != nil {
+		t.Fatalf("NewStore on imported data failed: %v", err)
+	}
+	exec, err := imported.GetExecution("exec-1")
+	_ = imported.Close()
+	if err != nil || exec.TaskID != "GH-1" {
+		t.Errorf("imported execution = %+v, %v", exec, err)
+	}
+
+	// Without the key the sealed database cannot be imported
+	encryption.SetDefault(nil)
+	targets.MemoryPath = func() (string, error) { return filepath.Join(t.TempDir(), "data"), nil }
+	if _, err := Import(bytes.NewReader(archive.Bytes()), targets); !errors.Is(err, encryption.ErrKeyUnavailable) {
+		t.Errorf("Import() without key = %v, want ErrKeyUnavailable", err)
+	}
+}
+
+func TestImportRejectsBadArchives(t *testing.T) {
+	if _, err := Import(strings.NewReader("not gzip"), Targets{}); err == nil {
+		t.Error("Import() should reject a file that is not an archive")
+	}
+	if _, err := entryPath("/data", "../etc/passwd"); err == nil {
+		t.Error("entryPath() should reject entries escaping the directory")
+	}
+	if _, err := entryPath("/data", "TG-1/metadata.json"); err != nil {
+		t.Errorf("entryPath() = %v", err)
+	}
+}
@@ -1,10 +1,22 @@
 package teams
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // ServiceAdapter wraps teams.Service to satisfy executor.TeamChecker interface (GH-634).
 // It converts string-typed permissions to teams.Permission, decoupling the executor
 // package from direct dependency on the teams package.
 type ServiceAdapter struct {
-	service *Service
+	service    *Service
+	mattermost IdentityResolver
+}
+
+// IdentityResolver maps a chat user ID to a team member ID, or "" when the
+// user doesn't match a member
+type IdentityResolver interface {
+	ResolveIdentity(userID string) (string, error)
 }
 
 // NewServiceAdapter creates a ServiceAdapter wrapping the given teams service.
@@ -41,3 +53,61 @@ func (a *ServiceAdapter) ResolveTelegramIdentity(telegramID int64, email string)
 func (a *ServiceAdapter) ResolveSlackIdentity(slackUserID, email string) (string, error) {
 	return a.service.ResolveSlackIdentity(slackUserID, email)
 }
+
+// ResolveMattermostIdentity resolves a Mattermost user ID and/or email to a team member ID.
+// Returns ("", nil) when no matching member is found.
+func (a *ServiceAdapter) ResolveMattermostIdentity(mattermostUserID, email string) (string, error) {
+	return a.service.ResolveMattermostIdentity(mattermostUserID, email)
+}
+
+// SetMattermostResolver sets how AuthorizeApproval resolves Mattermost users.
+// Mattermost members are matched by email, which the resolver looks up; without
+// one Mattermost users never resolve.
+func (a *ServiceAdapter) SetMattermostResolver(r IdentityResolver) {
+	a.mattermost = r
+}
+
+// CheckCommand verifies a member's role allows a chat command (see DefaultCommandRoles).
+func (a *ServiceAdapter) CheckCommand(memberID, command string) error {
+	return a.service.CheckCommand(memberID, command)
+}
+
+// AuthorizeApproval checks that the chat user answering an approval request at
+// stage may do so. Telegram, Slack and Mattermost users are resolved to team
+// members, who need the role of the stage's approval command and, when the
+// stage sets minRole, at least that role. Users who don't map to a member, and
+// other channels, are denied.
+func (a *ServiceAdapter) AuthorizeApproval(channel, userID, stage, minRole string) error {
+	var memberID string
+	var err error
+	switch channel {
+	case "telegram":
+		if telegramID, parseErr := strconv.ParseInt(userID, 10, 64); parseErr == nil {
+			memberID, err = a.service.ResolveTelegramIdentity(telegramID, "")
+		}
+	case "slack":
+		memberID, err = a.service.ResolveSlackIdentity(userID, "")
+	case "mattermost":
+		if a.mattermost != nil {
+			memberID, err = a.mattermost.ResolveIdentity(userID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	command := ApprovalCommand(stage)
+	if memberID == "" {
+		role := Role(minRole)
+		if role == "" {
+			role = a.service.CommandRole(command)
+		}
+		return fmt.Errorf("%w: approval requires a team member with the %s role", ErrPermissionDenied, role)
+	}
+	if err := a.service.CheckCommand(memberID, command); err != nil {
+		return err
+	}
+	if minRole != "" {
+		return a.service.CheckMinRole(memberID, Role(minRole))
+	}
+	return nil
+}
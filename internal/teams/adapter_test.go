@@ -222,3 +222,39 @@ func TestServiceAdapter_ResolveSlackIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAdapter_ResolveMattermostIdentity(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store, _ := NewStore(db)
+	service := NewService(store)
+	adapter := NewServiceAdapter(service)
+
+	team, owner, _ := service.CreateTeam("Test Team", "owner@example.com")
+	dev, _ := service.AddMember(team.ID, owner.ID, "dev@example.com", RoleDeveloper, nil)
+
+	tests := []struct {
+		name   string
+		userID string
+		email  string
+		wantID string
+	}{
+		{"by email", "", "dev@example.com", dev.ID},
+		{"userID with email", "mm-user-1", "dev@example.com", dev.ID},
+		{"userID alone", "mm-user-1", "", ""},
+		{"no match", "", "unknown@example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adapter.ResolveMattermostIdentity(tt.userID, tt.email)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.wantID {
+				t.Errorf("got %q, want %q", got, tt.wantID)
+			}
+		})
+	}
+}
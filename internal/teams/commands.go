@@ -0,0 +1,98 @@
+package teams
+
+import "fmt"
+
+// Chat commands and actions subject to the command permission matrix. Chat
+// adapters map their slash commands and buttons onto these names.
+const (
+	CommandAsk           = "ask"            // Questions, research, planning and chat
+	CommandStatus        = "status"         // Status, queue, tasks, history, projects, briefs
+	CommandBudget        = "budget"         // View spend and budget
+	CommandRun           = "run"            // Create or start tasks
+	CommandCancel        = "cancel"         // Cancel or stop running tasks
+	CommandApprove       = "approve"        // Answer pre-execution and post-failure approvals
+	CommandApproveMerge  = "approve_merge"  // Answer pre-merge and promotion approvals
+	CommandApproveConfig = "approve_config" // Answer config change approvals, including budgets
+)
+
+// DefaultCommandRoles is the minimum role needed for each command
+var DefaultCommandRoles = map[string]Role{
+	CommandAsk:           RoleViewer,
+	CommandStatus:        RoleViewer,
+	CommandBudget:        RoleViewer,
+	CommandRun:           RoleDeveloper,
+	CommandCancel:        RoleDeveloper,
+	CommandApprove:       RoleDeveloper,
+	CommandApproveMerge:  RoleAdmin,
+	CommandApproveConfig: RoleAdmin,
+}
+
+// ApprovalCommand returns the command needed to answer an approval request
+// at stage
+func ApprovalCommand(stage string) string {
+	switch stage {
+	case "pre_merge", "pre_promotion":
+		return CommandApproveMerge
+	case "config_change":
+		return CommandApproveConfig
+	default:
+		return CommandApprove
+	}
+}
+
+// ValidateCommandRoles checks overrides of the command permission matrix
+func ValidateCommandRoles(roles map[string]Role) error {
+	for command, role := range roles {
+		if _, ok := DefaultCommandRoles[command]; !ok {
+			return fmt.Errorf("unknown command %q", command)
+		}
+		if !role.IsValid() {
+			return fmt.Errorf("command %s: %w %q", command, ErrInvalidRole, role)
+		}
+	}
+	return nil
+}
+
+// SetCommandRoles overrides the minimum role of individual commands
+func (s *Service) SetCommandRoles(roles map[string]Role) {
+	s.commandRoles = roles
+}
+
+// CommandRole returns the minimum role needed for command, or "" when the
+// command is not restricted
+func (s *Service) CommandRole(command string) Role {
+	if role, ok := s.commandRoles[command]; ok {
+		return role
+	}
+	return DefaultCommandRoles[command]
+}
+
+// CheckCommand checks that a member's role allows a chat command
+func (s *Service) CheckCommand(memberID, command string) error {
+	member, err := s.store.GetMember(memberID)
+	if err != nil || member == nil {
+		return ErrMemberNotFound
+	}
+
+	if err := s.verifyIdentity(member); err != nil {
+		return err
+	}
+
+	required := s.CommandRole(command)
+	if required == "" || member.Role.Level() >= required.Level() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s requires the %s role", ErrPermissionDenied, command, required)
+}
+
+// CheckMinRole checks that a member holds at least role
+func (s *Service) CheckMinRole(memberID string, role Role) error {
+	member, err := s.store.GetMember(memberID)
+	if err != nil || member == nil {
+		return ErrMemberNotFound
+	}
+	if member.Role.Level() >= role.Level() {
+		return nil
+	}
+	return fmt.Errorf("%w: requires the %s role", ErrPermissionDenied, role)
+}
@@ -0,0 +1,146 @@
+package teams
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestService_CheckCommand(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store, err := NewStore(db)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	service := NewService(store)
+
+	team, owner, err := service.CreateTeam("acme", "owner@example.com")
+	if err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	viewer, _ := service.AddMember(team.ID, owner.ID, "viewer@example.com", RoleViewer, nil)
+	dev, _ := service.AddMember(team.ID, owner.ID, "dev@example.com", RoleDeveloper, nil)
+	admin, _ := service.AddMember(team.ID, owner.ID, "admin@example.com", RoleAdmin, nil)
+
+	tests := []struct {
+		member  *Member
+		command string
+		allowed bool
+	}{
+		{viewer, CommandStatus, true},
+		{viewer, CommandAsk, true},
+		{viewer, CommandRun, false},
+		{dev, CommandRun, true},
+		{dev, CommandApprove, true},
+		{dev, CommandApproveMerge, false},
+		{dev, CommandApproveConfig, false},
+		{admin, CommandApproveMerge, true},
+		{viewer, "unrestricted", true},
+	}
+	for _, tt := range tests {
+		err := service.CheckCommand(tt.member.ID, tt.command)
+		if tt.allowed && err != nil {
+			t.Errorf("%s %s: unexpected error %v", tt.member.Role, tt.command, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrPermissionDenied) {
+			t.Errorf("%s %s: expected permission denied, got %v", tt.member.Role, tt.command, err)
+		}
+	}
+
+	// Overrides tighten or relax individual commands
+	service.SetCommandRoles(map[string]Role{CommandRun: RoleAdmin, CommandApproveMerge: RoleDeveloper})
+	if err := service.CheckCommand(dev.ID, CommandRun); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected run denied for developer after override, got %v", err)
+	}
+	if err := service.CheckCommand(dev.ID, CommandApproveMerge); err != nil {
+		t.Errorf("expected approve_merge allowed after override, got %v", err)
+	}
+
+	if err := service.CheckCommand("missing", CommandStatus); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound, got %v", err)
+	}
+}
+
+func TestServiceAdapter_AuthorizeApproval(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store, err := NewStore(db)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	service := NewService(store)
+	adapter := NewServiceAdapter(service)
+
+	team, owner, _ := service.CreateTeam("acme", "owner@example.com")
+	dev, _ := service.AddMember(team.ID, owner.ID, "dev@example.com", RoleDeveloper, nil)
+	if err := service.UpdateMemberIdentity(team.ID, owner.ID, dev.ID, "", 42, "U42"); err != nil {
+		t.Fatalf("UpdateMemberIdentity: %v", err)
+	}
+
+	if err := adapter.AuthorizeApproval("telegram", "42", "pre_execution", ""); err != nil {
+		t.Errorf("developer should answer pre_execution approvals: %v", err)
+	}
+	if err := adapter.AuthorizeApproval("telegram", "42", "pre_merge", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected pre_merge denied for developer, got %v", err)
+	}
+	if err := adapter.AuthorizeApproval("slack", "U42", "config_change", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected config_change denied for developer, got %v", err)
+	}
+	if err := adapter.AuthorizeApproval("slack", "U-unknown", "pre_merge", ""); !errors.Is(err, ErrPermissionDenied) || !strings.Contains(err.Error(), "admin") {
+		t.Errorf("expected unmapped user denied pre_merge with the admin role, got %v", err)
+	}
+	if err := adapter.AuthorizeApproval("telegram", "not-a-number", "pre_execution", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected unparseable Telegram ID denied, got %v", err)
+	}
+	if err := adapter.AuthorizeApproval("discord", "42", "pre_execution", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected unknown channel denied, got %v", err)
+	}
+
+	// Mattermost users resolve through the email lookup
+	if err := adapter.AuthorizeApproval("mattermost", "mm-dev", "pre_execution", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected Mattermost user denied without a resolver, got %v", err)
+	}
+	adapter.SetMattermostResolver(mattermostResolver{"mm-dev": dev.ID, "mm-owner": owner.ID})
+	if err := adapter.AuthorizeApproval("mattermost", "mm-dev", "pre_execution", ""); err != nil {
+		t.Errorf("developer should answer pre_execution approvals on Mattermost: %v", err)
+	}
+	if err := adapter.AuthorizeApproval("mattermost", "mm-dev", "pre_merge", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected pre_merge denied for Mattermost developer, got %v", err)
+	}
+	if err := adapter.AuthorizeApproval("mattermost", "mm-owner", "pre_merge", ""); err != nil {
+		t.Errorf("owner should answer pre_merge approvals on Mattermost: %v", err)
+	}
+	if err := adapter.AuthorizeApproval("mattermost", "mm-unknown", "pre_execution", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected unmapped Mattermost user denied, got %v", err)
+	}
+
+	// min_role restricts answers to identified members at that role or above
+	if err := adapter.AuthorizeApproval("telegram", "42", "pre_execution", "admin"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected developer denied by min_role admin, got %v", err)
+	}
+	if err := adapter.AuthorizeApproval("telegram", "42", "pre_execution", "developer"); err != nil {
+		t.Errorf("developer should satisfy min_role developer: %v", err)
+	}
+	if err := adapter.AuthorizeApproval("slack", "U-unknown", "pre_execution", "developer"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected unmapped user denied when min_role is set, got %v", err)
+	}
+}
+
+type mattermostResolver map[string]string
+
+func (r mattermostResolver) ResolveIdentity(userID string) (string, error) {
+	return r[userID], nil
+}
+
+func TestValidateCommandRoles(t *testing.T) {
+	if err := ValidateCommandRoles(map[string]Role{CommandRun: RoleAdmin}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateCommandRoles(map[string]Role{"deploy": RoleAdmin}); err == nil {
+		t.Error("expected error for unknown command")
+	}
+	if err := ValidateCommandRoles(map[string]Role{CommandRun: "lead"}); err == nil {
+		t.Error("expected error for invalid role")
+	}
+}
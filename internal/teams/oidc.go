@@ -0,0 +1,530 @@
+package teams
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OIDCConfig maps team members to identities at an OpenID Connect identity
+// provider. When set, members must log in with `pilot team login` before
+// they can run tasks, and group claims decide their roles.
+//
+// Example YAML configuration:
+//
+//	team:
+//	  oidc:
+//	    issuer: https://acme.okta.com
+//	    client_id: pilot-cli
+//	    role_mappings:
+//	      pilot-admins: admin
+//	      engineering: developer
+type OIDCConfig struct {
+	Issuer       string          `yaml:"issuer"`
+	ClientID     string          `yaml:"client_id"`
+	ClientSecret string          `yaml:"client_secret"` // Only for confidential clients
+	Scopes       []string        `yaml:"scopes"`        // Extra scopes; openid, email, profile and offline_access are always requested
+	GroupsClaim  string          `yaml:"groups_claim"`  // ID token claim listing the user's groups (default: groups)
+	RoleMappings map[string]Role `yaml:"role_mappings"` // IdP group -> team role; the highest matching role wins
+	CacheTTL     time.Duration   `yaml:"cache_ttl"`     // How long a verified identity is trusted before it is refreshed (default: 1h)
+}
+
+// Validate checks the issuer, client and role mappings
+func (c *OIDCConfig) Validate() error {
+	if c.Issuer == "" {
+		return fmt.Errorf("oidc issuer is required")
+	}
+	if u, err := url.Parse(c.Issuer); err != nil || u.Scheme != "https" && u.Hostname() != "localhost" && u.Hostname() != "127.0.0.1" {
+		return fmt.Errorf("oidc issuer must be an https URL, got %q", c.Issuer)
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("oidc client_id is required")
+	}
+	for group, role := range c.RoleMappings {
+		if !role.IsValid() || role == RoleOwner {
+			return fmt.Errorf("oidc role_mappings.%s must be admin, developer or viewer, got %q", group, role)
+		}
+	}
+	return nil
+}
+
+func (c *OIDCConfig) groupsClaim() string {
+	if c.GroupsClaim != "" {
+		return c.GroupsClaim
+	}
+	return "groups"
+}
+
+func (c *OIDCConfig) cacheTTL() time.Duration {
+	if c.CacheTTL > 0 {
+		return c.CacheTTL
+	}
+	return time.Hour
+}
+
+// OIDCClaims are the ID token claims Pilot uses
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+	ExpiresAt     time.Time
+}
+
+// OIDCTokens is a token endpoint response
+type OIDCTokens struct {
+	IDToken      string `json:"id_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// DeviceAuth is a pending device authorization: the user opens
+// VerificationURI and enters UserCode while Pilot polls for tokens.
+type DeviceAuth struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// ErrDeviceAuthExpired is returned when the user did not approve a device
+// authorization in time
+var ErrDeviceAuthExpired = errors.New("device authorization expired")
+
+type oidcDiscovery struct {
+	Issuer                      string `json:"issuer"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	JWKSURI                     string `json:"jwks_uri"`
+}
+
+// OIDCProvider verifies ID tokens and runs the device authorization and
+// refresh token grants against an identity provider.
+type OIDCProvider struct {
+	config *OIDCConfig
+	client *http.Client
+	now    func() time.Time
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// jwksRefetchInterval is how often tokens with an unknown key ID may fetch
+// the key set again
+const jwksRefetchInterval = time.Minute
+
+// NewOIDCProvider returns a provider for cfg, or nil when cfg is nil
+func NewOIDCProvider(cfg *OIDCConfig) *OIDCProvider {
+	if cfg == nil {
+		return nil
+	}
+	return &OIDCProvider{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// RoleFor returns the highest role mapped from groups, or "" when none of
+// them is mapped
+func (p *OIDCProvider) RoleFor(groups []string) Role {
+	var role Role
+	for _, g := range groups {
+		if mapped, ok := p.config.RoleMappings[g]; ok && mapped.Level() > role.Level() {
+			role = mapped
+		}
+	}
+	return role
+}
+
+// StartDeviceAuth begins a device authorization grant
+func (p *OIDCProvider) StartDeviceAuth(ctx context.Context) (*DeviceAuth, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if d.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("identity provider %s does not support device authorization", p.config.Issuer)
+	}
+	scopes := append([]string{"openid", "email", "profile", "offline_access"}, p.config.Scopes...)
+	form := url.Values{
+		"client_id": {p.config.ClientID},
+		"scope":     {strings.Join(scopes, " ")},
+	}
+	var auth DeviceAuth
+	if err := p.postForm(ctx, d.DeviceAuthorizationEndpoint, form, &auth); err != nil {
+		return nil, fmt.Errorf("device authorization failed: %w", err)
+	}
+	if auth.Interval <= 0 {
+		auth.Interval = 5
+	}
+	return &auth, nil
+}
+
+// PollDeviceToken polls until the user approves the device authorization,
+// it expires or ctx is done
+func (p *OIDCProvider) PollDeviceToken(ctx context.Context, auth *DeviceAuth) (*OIDCTokens, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	interval := time.Duration(auth.Interval) * time.Second
+	deadline := p.now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	form := url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {auth.DeviceCode},
+		"client_id":   {p.config.ClientID},
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		var tokens OIDCTokens
+		err := p.postForm(ctx, d.TokenEndpoint, form, &tokens)
+		var tokenErr *oauthError
+		switch {
+		case err == nil:
+			return &tokens, nil
+		case errors.As(err, &tokenErr) && tokenErr.Code == "authorization_pending":
+		case errors.As(err, &tokenErr) && tokenErr.Code == "slow_down":
+			interval += 5 * time.Second
+		case errors.As(err, &tokenErr) && tokenErr.Code == "expired_token":
+			return nil, ErrDeviceAuthExpired
+		default:
+			return nil, fmt.Errorf("token request failed: %w", err)
+		}
+		if auth.ExpiresIn > 0 && p.now().After(deadline) {
+			return nil, ErrDeviceAuthExpired
+		}
+	}
+}
+
+// Refresh exchanges a refresh token for new tokens
+func (p *OIDCProvider) Refresh(ctx context.Context, refreshToken string) (*OIDCTokens, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {p.config.ClientID},
+	}
+	var tokens OIDCTokens
+	if err := p.postForm(ctx, d.TokenEndpoint, form, &tokens); err != nil {
+		return nil, fmt.Errorf("token refresh failed: %w", err)
+	}
+	return &tokens, nil
+}
+
+// VerifyIDToken checks an ID token's signature, issuer, audience and expiry
+// and returns its claims
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, raw string) (*OIDCClaims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed id token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed id token header: %w", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id token signature: %w", err)
+	}
+	key, err := p.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var payload map[string]any
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, fmt.Errorf("malformed id token payload: %w", err)
+	}
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if iss, _ := payload["iss"].(string); iss != d.Issuer {
+		return nil, fmt.Errorf("id token issuer %q does not match %q", iss, d.Issuer)
+	}
+	if !audienceContains(payload["aud"], p.config.ClientID) {
+		return nil, fmt.Errorf("id token was not issued for client %q", p.config.ClientID)
+	}
+	exp, _ := payload["exp"].(float64)
+	expiresAt := time.Unix(int64(exp), 0)
+	if exp == 0 || !p.now().Before(expiresAt) {
+		return nil, fmt.Errorf("id token expired")
+	}
+
+	claims := &OIDCClaims{ExpiresAt: expiresAt}
+	claims.Subject, _ = payload["sub"].(string)
+	claims.Email, _ = payload["email"].(string)
+	claims.Name, _ = payload["name"].(string)
+	// Emails count as verified only when the IdP says so
+	switch v := payload["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string: // Some providers send "true"
+		claims.EmailVerified = v == "true"
+	}
+	switch groups := payload[p.config.groupsClaim()].(type) {
+	case []any:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				claims.Groups = append(claims.Groups, s)
+			}
+		}
+	case string:
+		claims.Groups = []string{groups}
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("id token has no subject")
+	}
+	return claims, nil
+}
+
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	var d oidcDiscovery
+	endpoint := strings.TrimRight(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, endpoint, &d); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery document at %s has no token endpoint or jwks_uri", endpoint)
+	}
+	// The document must be the configured issuer's, or tokens from another
+	// issuer would verify
+	if strings.TrimRight(d.Issuer, "/") != strings.TrimRight(p.config.Issuer, "/") {
+		return nil, fmt.Errorf("oidc discovery document at %s is for issuer %q, not %q", endpoint, d.Issuer, p.config.Issuer)
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// key returns the signing key with kid, fetching the key set again when it
+// is unknown so rotated keys are picked up. Refetches are at most one per
+// jwksRefetchInterval, so tokens with made-up key IDs can't flood the IdP.
+func (p *OIDCProvider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	recent := p.keys != nil && p.now().Sub(p.keysFetchedAt) < jwksRefetchInterval
+	if !ok && !recent {
+		p.keysFetchedAt = p.now()
+	}
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if recent {
+		return nil, fmt.Errorf("no signing key %q at %s", kid, d.JWKSURI)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no signing key %q at %s", kid, d.JWKSURI)
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, endpoint string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	return p.do(req, out)
+}
+
+func (p *OIDCProvider) postForm(ctx context.Context, endpoint string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+	return p.do(req, out)
+}
+
+// oauthError is an OAuth 2.0 error response
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *oauthError) Error() string {
+	if e.Description != "" {
+		return e.Code + ": " + e.Description
+	}
+	return e.Code
+}
+
+func (p *OIDCProvider) do(req *http.Request, out any) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oe oauthError
+		if json.Unmarshal(body, &oe) == nil && oe.Code != "" {
+			return &oe
+		}
+		return fmt.Errorf("%s returned %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// jwk is one key of a JSON Web Key Set
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// verifySignature checks an RS256 or ES256 signature over signed
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("id token signing key is not an RSA key")
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("invalid id token signature")
+		}
+		return nil
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(sig) != 64 {
+			return fmt.Errorf("id token signing key is not a P-256 key")
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return fmt.Errorf("invalid id token signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported id token algorithm %q", alg)
+	}
+}
+
+func decodeSegment(seg string, out any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func audienceContains(aud any, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []any:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
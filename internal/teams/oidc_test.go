@@ -0,0 +1,391 @@
+package teams
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeIdP is a minimal OIDC provider signing ID tokens with an RSA key
+type fakeIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any // Claims of the next issued ID token
+	denied bool           // Reject refresh token grants
+	issuer string         // Issuer in the discovery document (default: the server URL)
+
+	jwksFetches atomic.Int32
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	idp := &fakeIdP{t: t, key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := idp.issuer
+		if issuer == "" {
+			issuer = idp.server.URL
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        issuer,
+			"token_endpoint":                idp.server.URL + "/token",
+			"device_authorization_endpoint": idp.server.URL + "/device",
+			"jwks_uri":                      idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(DeviceAuth{
+			DeviceCode:      "dev-1",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: idp.server.URL + "/activate",
+			ExpiresIn:       60,
+			Interval:        1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") == "refresh_token" && idp.denied {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(OIDCTokens{
+			IDToken:      idp.sign(idp.claims),
+			RefreshToken: "refresh-1",
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	idp.claims = idp.baseClaims()
+	return idp
+}
+
+func (idp *fakeIdP) baseClaims() map[string]any {
+	return map[string]any{
+		"iss":            idp.server.URL,
+		"aud":            "pilot-cli",
+		"sub":            "user-123",
+		"email":          "dev@example.com",
+		"email_verified": true,
+		"groups":         []string{"engineering", "pilot-admins"},
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (idp *fakeIdP) sign(claims map[string]any) string {
+	return idp.signKid("k1", claims)
+}
+
+func (idp *fakeIdP) signKid(kid string, claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		idp.t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (idp *fakeIdP) config() *OIDCConfig {
+	return &OIDCConfig{
+		Issuer:   idp.server.URL,
+		ClientID: "pilot-cli",
+		RoleMappings: map[string]Role{
+			"engineering":  RoleDeveloper,
+			"pilot-admins": RoleAdmin,
+		},
+	}
+}
+
+func TestOIDCProvider_VerifyIDToken(t *testing.T) {
+	idp := newFakeIdP(t)
+	p := NewOIDCProvider(idp.config())
+	ctx := context.Background()
+
+	claims, err := p.VerifyIDToken(ctx, idp.sign(idp.baseClaims()))
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if claims.Subject != "user-123" || claims.Email != "dev@example.com" || !claims.EmailVerified {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+	if strings.Join(claims.Groups, ",") != "engineering,pilot-admins" {
+		t.Errorf("groups = %v", claims.Groups)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(c map[string]any)
+	}{
+		{"wrong audience", func(c map[string]any) { c["aud"] = "other-app" }},
+		{"wrong issuer", func(c map[string]any) { c["iss"] = "https://evil.example.com" }},
+		{"expired", func(c map[string]any) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := idp.baseClaims()
+			tt.mutate(c)
+			if _, err := p.VerifyIDToken(ctx, idp.sign(c)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	t.Run("tampered payload", func(t *testing.T) {
+		parts := strings.Split(idp.sign(idp.baseClaims()), ".")
+		c := idp.baseClaims()
+		c["email"] = "ceo@example.com"
+		payload, _ := json.Marshal(c)
+		parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+		if _, err := p.VerifyIDToken(ctx, strings.Join(parts, ".")); err == nil {
+			t.Error("expected signature error")
+		}
+	})
+
+	t.Run("email_verified", func(t *testing.T) {
+		for _, tt := range []struct {
+			value any
+			want  bool
+		}{{nil, false}, {"true", true}, {"false", false}, {false, false}} {
+			c := idp.baseClaims()
+			if tt.value == nil {
+				delete(c, "email_verified")
+			} else {
+				c["email_verified"] = tt.value
+			}
+			claims, err := p.VerifyIDToken(ctx, idp.sign(c))
+			if err != nil {
+				t.Fatalf("VerifyIDToken: %v", err)
+			}
+			if claims.EmailVerified != tt.want {
+				t.Errorf("email_verified %v: EmailVerified = %v, want %v", tt.value, claims.EmailVerified, tt.want)
+			}
+		}
+	})
+}
+
+func TestOIDCProvider_UnknownKeyRefetchLimited(t *testing.T) {
+	idp := newFakeIdP(t)
+	p := NewOIDCProvider(idp.config())
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := p.VerifyIDToken(ctx, idp.sign(idp.baseClaims())); err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := p.VerifyIDToken(ctx, idp.signKid("bogus", idp.baseClaims())); err == nil {
+			t.Fatal("expected error for unknown key")
+		}
+	}
+	if got := idp.jwksFetches.Load(); got != 1 {
+		t.Errorf("key set fetched %d times, want 1", got)
+	}
+
+	// Rotated keys are picked up once the interval passed
+	now = now.Add(jwksRefetchInterval)
+	_, _ = p.VerifyIDToken(ctx, idp.signKid("bogus", idp.baseClaims()))
+	if got := idp.jwksFetches.Load(); got != 2 {
+		t.Errorf("key set fetched %d times, want 2", got)
+	}
+}
+
+func TestOIDCProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.issuer = "https://evil.example.com"
+	p := NewOIDCProvider(idp.config())
+
+	c := idp.baseClaims()
+	c["iss"] = idp.issuer
+	if _, err := p.VerifyIDToken(context.Background(), idp.sign(c)); err == nil || !strings.Contains(err.Error(), "issuer") {
+		t.Errorf("VerifyIDToken() error = %v, want issuer mismatch", err)
+	}
+}
+
+func TestOIDCProvider_RoleFor(t *testing.T) {
+	p := NewOIDCProvider(&OIDCConfig{RoleMappings: map[string]Role{
+		"eng":    RoleDeveloper,
+		"admins": RoleAdmin,
+	}})
+	if got := p.RoleFor([]string{"eng", "admins"}); got != RoleAdmin {
+		t.Errorf("RoleFor = %q, want admin", got)
+	}
+	if got := p.RoleFor([]string{"sales"}); got != "" {
+		t.Errorf("RoleFor unmapped = %q, want empty", got)
+	}
+}
+
+func TestOIDCProvider_DeviceFlow(t *testing.T) {
+	idp := newFakeIdP(t)
+	p := NewOIDCProvider(idp.config())
+	ctx := context.Background()
+
+	auth, err := p.StartDeviceAuth(ctx)
+	if err != nil {
+		t.Fatalf("StartDeviceAuth: %v", err)
+	}
+	if auth.UserCode != "ABCD-EFGH" {
+		t.Errorf("UserCode = %q", auth.UserCode)
+	}
+	tokens, err := p.PollDeviceToken(ctx, auth)
+	if err != nil {
+		t.Fatalf("PollDeviceToken: %v", err)
+	}
+	if tokens.IDToken == "" || tokens.RefreshToken != "refresh-1" {
+		t.Errorf("unexpected tokens: %+v", tokens)
+	}
+}
+
+func TestOIDCConfig_Validate(t *testing.T) {
+	valid := &OIDCConfig{Issuer: "https://acme.okta.com", ClientID: "pilot"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	tests := []*OIDCConfig{
+		{ClientID: "pilot"},
+		{Issuer: "http://acme.okta.com", ClientID: "pilot"},
+		{Issuer: "https://acme.okta.com"},
+		{Issuer: "https://acme.okta.com", ClientID: "pilot", RoleMappings: map[string]Role{"root": RoleOwner}},
+		{Issuer: "https://acme.okta.com", ClientID: "pilot", RoleMappings: map[string]Role{"x": "superuser"}},
+	}
+	for i, cfg := range tests {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestService_OIDCVerification(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store, err := NewStore(db)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	service := NewService(store)
+	idp := newFakeIdP(t)
+
+	team, owner, err := service.CreateTeam("acme", "owner@example.com")
+	if err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	member, err := service.AddMember(team.ID, owner.ID, "dev@example.com", RoleViewer, nil)
+	if err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	member.TelegramID = 42
+	if err := store.UpdateMember(member); err != nil {
+		t.Fatalf("UpdateMember: %v", err)
+	}
+
+	// Without OIDC, permission checks use the stored role only
+	if err := service.CheckPermission(member.ID, PermViewTasks); err != nil {
+		t.Fatalf("CheckPermission without oidc: %v", err)
+	}
+
+	service.SetOIDC(NewOIDCProvider(idp.config()))
+	if err := service.CheckPermission(member.ID, PermViewTasks); !errors.Is(err, ErrNotVerified) {
+		t.Fatalf("expected ErrNotVerified before login, got %v", err)
+	}
+	// The resolver still returns the member so the check above applies
+	if id, err := service.ResolveTelegramIdentity(42, ""); err != nil || id != member.ID {
+		t.Fatalf("ResolveTelegramIdentity = %q, %v", id, err)
+	}
+
+	tokens := &OIDCTokens{IDToken: idp.sign(idp.baseClaims()), RefreshToken: "refresh-0"}
+	linked, err := service.LoginOIDC(context.Background(), tokens)
+	if err != nil {
+		t.Fatalf("LoginOIDC: %v", err)
+	}
+	if len(linked) != 1 || linked[0].ID != member.ID {
+		t.Fatalf("linked = %+v", linked)
+	}
+	if m, _ := store.GetMember(member.ID); m.Role != RoleAdmin {
+		t.Errorf("role = %q, want admin from pilot-admins group", m.Role)
+	}
+	if err := service.CheckPermission(member.ID, PermManageMembers); err != nil {
+		t.Errorf("CheckPermission after login: %v", err)
+	}
+
+	// A stale identity is refreshed at the IdP, picking up group changes
+	identity, _ := store.GetIdentity(member.ID)
+	identity.VerifiedAt = time.Now().Add(-2 * time.Hour)
+	_ = store.SaveIdentity(identity)
+	idp.claims["groups"] = []string{"engineering"}
+	if err := service.CheckProjectAccess(member.ID, "/repo", PermExecuteTasks); err != nil {
+		t.Errorf("CheckProjectAccess after refresh: %v", err)
+	}
+	if m, _ := store.GetMember(member.ID); m.Role != RoleDeveloper {
+		t.Errorf("role = %q, want developer after refresh", m.Role)
+	}
+	if identity, _ = store.GetIdentity(member.ID); identity.RefreshToken != "refresh-1" {
+		t.Errorf("refresh token not rotated: %q", identity.RefreshToken)
+	}
+
+	// When the IdP rejects the refresh the member must log in again
+	identity.VerifiedAt = time.Now().Add(-2 * time.Hour)
+	_ = store.SaveIdentity(identity)
+	idp.denied = true
+	if err := service.CheckPermission(member.ID, PermViewTasks); !errors.Is(err, ErrNotVerified) {
+		t.Errorf("expected ErrNotVerified after rejected refresh, got %v", err)
+	}
+
+	// Owners keep their role whatever their groups say
+	ownerClaims := idp.baseClaims()
+	ownerClaims["sub"] = "owner-1"
+	ownerClaims["email"] = "owner@example.com"
+	if _, err := service.LoginOIDC(context.Background(), &OIDCTokens{IDToken: idp.sign(ownerClaims)}); err != nil {
+		t.Fatalf("LoginOIDC owner: %v", err)
+	}
+	if m, _ := store.GetMember(owner.ID); m.Role != RoleOwner {
+		t.Errorf("owner role changed to %q", m.Role)
+	}
+
+	// Unverified emails don't link accounts
+	stranger := idp.baseClaims()
+	stranger["sub"] = "user-999"
+	stranger["email_verified"] = false
+	if _, err := service.LoginOIDC(context.Background(), &OIDCTokens{IDToken: idp.sign(stranger)}); err == nil {
+		t.Error("expected error for unverified email")
+	}
+
+	// Removing the member forgets the identity
+	if err := store.RemoveMember(member.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if identity, _ := store.GetIdentity(member.ID); identity != nil {
+		t.Error("identity should be deleted with the member")
+	}
+}
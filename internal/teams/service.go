@@ -1,6 +1,7 @@
 package teams
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,7 +10,9 @@ import (
 
 // Service provides team management operations with permission checking
 type Service struct {
-	store *Store
+	store        *Store
+	oidc         *OIDCProvider
+	commandRoles map[string]Role
 }
 
 // NewService creates a new team service
@@ -27,8 +30,159 @@ var (
 	ErrLastOwner         = fmt.Errorf("cannot remove last owner")
 	ErrAlreadyMember     = fmt.Errorf("already a member")
 	ErrSelfRoleChange    = fmt.Errorf("cannot change own role")
+	ErrNotVerified       = fmt.Errorf("identity not verified: run 'pilot team login'")
 )
 
+// SetOIDC enables identity verification against an OIDC provider. Once set,
+// members must have logged in with `pilot team login` to pass permission
+// checks, and their IdP groups decide their roles.
+func (s *Service) SetOIDC(provider *OIDCProvider) {
+	s.oidc = provider
+}
+
+// LoginOIDC links the holder of tokens to every team member with the same
+// IdP subject or verified email, syncs their roles from the group claims and
+// caches the identity. Returns the linked members.
+func (s *Service) LoginOIDC(ctx context.Context, tokens *OIDCTokens) ([]*Member, error) {
+	if s.oidc == nil {
+		return nil, fmt.Errorf("oidc is not configured")
+	}
+	claims, err := s.oidc.VerifyIDToken(ctx, tokens.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.store.GetMembersBySubject(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Email != "" && claims.EmailVerified {
+		byEmail, err := s.store.GetMembersByEmail(claims.Email)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range byEmail {
+			if !containsMember(members, m.ID) {
+				members = append(members, m)
+			}
+		}
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%s is not a member of any team", claims.Email)
+	}
+
+	for _, m := range members {
+		identity := &Identity{
+			MemberID:     m.ID,
+			Subject:      claims.Subject,
+			Email:        claims.Email,
+			Groups:       claims.Groups,
+			RefreshToken: tokens.RefreshToken,
+			VerifiedAt:   time.Now(),
+		}
+		if err := s.store.SaveIdentity(identity); err != nil {
+			return nil, fmt.Errorf("failed to save identity: %w", err)
+		}
+		if err := s.syncRole(m, claims.Groups); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// verifyIdentity checks that member has a verified IdP identity. Identities
+// older than the cache TTL are refreshed at the IdP; when that fails the
+// refresh token is dropped and the member must log in again.
+func (s *Service) verifyIdentity(member *Member) error {
+	if s.oidc == nil {
+		return nil
+	}
+	identity, err := s.store.GetIdentity(member.ID)
+	if err != nil || identity == nil {
+		return ErrNotVerified
+	}
+	if time.Since(identity.VerifiedAt) < s.oidc.config.cacheTTL() {
+		return nil
+	}
+	if identity.RefreshToken == "" {
+		return ErrNotVerified
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	claims, tokens, err := s.refreshIdentity(ctx, identity)
+	if err != nil || claims.Subject != identity.Subject {
+		identity.RefreshToken = ""
+		_ = s.store.SaveIdentity(identity)
+		return ErrNotVerified
+	}
+
+	identity.Email = claims.Email
+	identity.Groups = claims.Groups
+	identity.VerifiedAt = time.Now()
+	if tokens.RefreshToken != "" {
+		identity.RefreshToken = tokens.RefreshToken
+	}
+	if err := s.store.SaveIdentity(identity); err != nil {
+		return fmt.Errorf("failed to save identity: %w", err)
+	}
+	return s.syncRole(member, claims.Groups)
+}
+
+func (s *Service) refreshIdentity(ctx context.Context, identity *Identity) (*OIDCClaims, *OIDCTokens, error) {
+	tokens, err := s.oidc.Refresh(ctx, identity.RefreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	claims, err := s.oidc.VerifyIDToken(ctx, tokens.IDToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return claims, tokens, nil
+}
+
+// syncRole sets member's role from their IdP groups. Owners keep their role,
+// and members whose groups map to no role keep the one they were given.
+func (s *Service) syncRole(member *Member, groups []string) error {
+	role := s.oidc.RoleFor(groups)
+	if role == "" || member.Role == RoleOwner || member.Role == role {
+		return nil
+	}
+	oldRole := member.Role
+	member.Role = role
+	if err := s.store.UpdateMember(member); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	_ = s.logAudit(member.TeamID, member.ID, member.Email, AuditRoleChanged, "member", member.ID, map[string]interface{}{
+		"email":    member.Email,
+		"old_role": oldRole,
+		"new_role": role,
+		"source":   "oidc",
+	})
+	return nil
+}
+
+// firstVerified returns the first of members whose identity verifies, or
+// the first member so permission checks still apply and deny them
+func (s *Service) firstVerified(members []*Member) string {
+	for _, m := range members {
+		if s.verifyIdentity(m) == nil {
+			return m.ID
+		}
+	}
+	return members[0].ID
+}
+
+func containsMember(members []*Member, id string) bool {
+	for _, m := range members {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateTeam creates a new team with the given owner
 func (s *Service) CreateTeam(name, ownerEmail string) (*Team, *Member, error) {
 	team, owner := NewTeam(name, ownerEmail)
@@ -288,6 +442,47 @@ func (s *Service) UpdateMemberProjects(teamID, actorID, memberID string, project
 	return nil
 }
 
+// UpdateMemberIdentity links a member to their GitHub, Telegram and Slack
+// accounts. Empty or zero values leave the existing mapping unchanged.
+// Members may link their own accounts; linking others requires manage_members.
+func (s *Service) UpdateMemberIdentity(teamID, actorID, memberID, githubUser string, telegramID int64, slackUserID string) error {
+	actor, err := s.store.GetMember(actorID)
+	if err != nil || actor == nil {
+		return ErrMemberNotFound
+	}
+
+	if actorID != memberID && !actor.Role.HasPermission(PermManageMembers) {
+		return ErrPermissionDenied
+	}
+
+	member, err := s.store.GetMember(memberID)
+	if err != nil || member == nil {
+		return ErrMemberNotFound
+	}
+
+	if githubUser != "" {
+		member.GitHubUser = githubUser
+	}
+	if telegramID != 0 {
+		member.TelegramID = telegramID
+	}
+	if slackUserID != "" {
+		member.SlackUserID = slackUserID
+	}
+	if err := s.store.UpdateMember(member); err != nil {
+		return fmt.Errorf("failed to update member: %w", err)
+	}
+
+	_ = s.logAudit(teamID, actorID, actor.Email, AuditMemberUpdated, "member", memberID, map[string]interface{}{
+		"email":         member.Email,
+		"github_user":   member.GitHubUser,
+		"telegram_id":   member.TelegramID,
+		"slack_user_id": member.SlackUserID,
+	})
+
+	return nil
+}
+
 // GetMember retrieves a member by ID
 func (s *Service) GetMember(memberID string) (*Member, error) {
 	return s.store.GetMember(memberID)
@@ -335,6 +530,7 @@ func (s *Service) ResolveGitHubIdentity(ghUser, email string) (string, error) {
 
 // ResolveTelegramIdentity resolves a Telegram user ID (and optional email) to a member ID
 // across all teams. It tries Telegram ID first, then falls back to email (GH-634).
+// With OIDC configured, members with a verified IdP identity are preferred.
 // Returns ("", nil) when no matching member is found — callers should treat this as
 // "no RBAC enforcement" rather than an error.
 func (s *Service) ResolveTelegramIdentity(telegramID int64, email string) (string, error) {
@@ -345,7 +541,7 @@ func (s *Service) ResolveTelegramIdentity(telegramID int64, email string) (strin
 			return "", fmt.Errorf("lookup by telegram_id %d: %w", telegramID, err)
 		}
 		if len(members) > 0 {
-			return members[0].ID, nil
+			return s.firstVerified(members), nil
 		}
 	}
 
@@ -356,7 +552,7 @@ func (s *Service) ResolveTelegramIdentity(telegramID int64, email string) (strin
 			return "", fmt.Errorf("lookup by email %q: %w", email, err)
 		}
 		if len(members) > 0 {
-			return members[0].ID, nil
+			return s.firstVerified(members), nil
 		}
 	}
 
@@ -364,16 +560,43 @@ func (s *Service) ResolveTelegramIdentity(telegramID int64, email string) (strin
 }
 
 // ResolveSlackIdentity resolves a Slack user ID (and optional email) to a member ID
-// across all teams. Currently uses email-only resolution; slackUserID is reserved for
-// future store-level mapping when slack_user_id column is added (GH-784).
+// across all teams. It tries the linked Slack user ID first, then falls back to email.
+// With OIDC configured, members with a verified IdP identity are preferred.
 // Returns ("", nil) when no matching member is found — callers should treat this as
 // "no RBAC enforcement" rather than an error.
 func (s *Service) ResolveSlackIdentity(slackUserID, email string) (string, error) {
-	// TODO(GH-784): When slack_user_id column is added, lookup by slackUserID first
-	// For now, slackUserID is accepted but not used for store lookup
-	_ = slackUserID
+	// Try Slack user ID first (set with `pilot team member update --slack`)
+	if slackUserID != "" {
+		members, err := s.store.GetMembersBySlackUserID(slackUserID)
+		if err != nil {
+			return "", fmt.Errorf("lookup by slack_user_id %q: %w", slackUserID, err)
+		}
+		if len(members) > 0 {
+			return s.firstVerified(members), nil
+		}
+	}
 
 	// Resolve by email (Slack provides email via users.info API)
+	if email != "" {
+		members, err := s.store.GetMembersByEmail(email)
+		if err != nil {
+			return "", fmt.Errorf("lookup by email %q: %w", email, err)
+		}
+		if len(members) > 0 {
+			return s.firstVerified(members), nil
+		}
+	}
+
+	return "", nil
+}
+
+// ResolveMattermostIdentity resolves a Mattermost user ID (and optional email) to a
+// member ID across all teams. Like Slack, resolution is email-based; the Mattermost
+// adapter looks up the sender's email via the users API before calling this.
+// Returns ("", nil) when no matching member is found.
+func (s *Service) ResolveMattermostIdentity(mattermostUserID, email string) (string, error) {
+	_ = mattermostUserID
+
 	if email != "" {
 		members, err := s.store.GetMembersByEmail(email)
 		if err != nil {
@@ -427,6 +650,10 @@ func (s *Service) CheckPermission(memberID string, perm Permission) error {
 		return ErrMemberNotFound
 	}
 
+	if err := s.verifyIdentity(member); err != nil {
+		return err
+	}
+
 	if !member.Role.HasPermission(perm) {
 		return ErrPermissionDenied
 	}
@@ -441,6 +668,10 @@ func (s *Service) CheckProjectAccess(memberID, projectPath string, requiredPerm
 		return ErrMemberNotFound
 	}
 
+	if err := s.verifyIdentity(member); err != nil {
+		return err
+	}
+
 	// Check base permission
 	if !member.Role.HasPermission(requiredPerm) {
 		return ErrPermissionDenied
@@ -4,20 +4,21 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/alekspetrov/pilot/internal/memory"
 	_ "modernc.org/sqlite"
 )
 
 // Store provides persistent storage for teams
 type Store struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect memory.Dialect
 }
 
 // NewStore creates a new team store using an existing database connection
 func NewStore(db *sql.DB) (*Store, error) {
-	store := &Store{db: db}
+	store := &Store{db: db, dialect: memory.DialectFor(db)}
 	if err := store.migrate(); err != nil {
 		return nil, fmt.Errorf("failed to migrate team tables: %w", err)
 	}
@@ -80,20 +81,82 @@ func (s *Store) migrate() error {
 		`CREATE INDEX IF NOT EXISTS idx_team_audit_log_created ON team_audit_log(created_at)`,
 		`CREATE INDEX IF NOT EXISTS idx_team_audit_log_actor ON team_audit_log(actor_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_project_access_project ON project_access(project_path)`,
+		// OIDC identities verified at the team's identity provider
+		`CREATE TABLE IF NOT EXISTS team_identities (
+			member_id TEXT PRIMARY KEY,
+			subject TEXT NOT NULL,
+			email TEXT,
+			groups TEXT,
+			refresh_token TEXT,
+			verified_at DATETIME NOT NULL,
+			FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_team_identities_subject ON team_identities(subject)`,
 	}
 
-	for _, migration := range migrations {
-		_, err := s.db.Exec(migration)
-		if err != nil {
-			errStr := err.Error()
-			if strings.Contains(errStr, "duplicate column") {
-				continue
-			}
-			return fmt.Errorf("migration failed: %w", err)
-		}
-	}
+	return s.dialect.Migrate(s.db, memory.Schema{SQLite: migrations, Postgres: postgresMigrations})
+}
 
-	return nil
+// postgresMigrations creates the team tables on PostgreSQL with the
+// columns the SQLite migrations add, and without foreign keys, which
+// SQLite never enforced
+var postgresMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS teams (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		settings TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS team_members (
+		id TEXT PRIMARY KEY,
+		team_id TEXT NOT NULL,
+		email TEXT NOT NULL,
+		name TEXT,
+		role TEXT NOT NULL,
+		projects TEXT,
+		joined_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		invited_by TEXT,
+		github_user TEXT,
+		telegram_id BIGINT DEFAULT 0,
+		slack_user_id TEXT,
+		UNIQUE(team_id, email)
+	)`,
+	`CREATE TABLE IF NOT EXISTS team_audit_log (
+		id TEXT PRIMARY KEY,
+		team_id TEXT NOT NULL,
+		actor_id TEXT NOT NULL,
+		actor_email TEXT NOT NULL,
+		action TEXT NOT NULL,
+		resource TEXT NOT NULL,
+		resource_id TEXT,
+		details TEXT,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS project_access (
+		team_id TEXT NOT NULL,
+		project_path TEXT NOT NULL,
+		default_role TEXT NOT NULL DEFAULT 'developer',
+		PRIMARY KEY (team_id, project_path)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_members_team ON team_members(team_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_members_email ON team_members(email)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_members_github_user ON team_members(github_user)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_members_telegram_id ON team_members(telegram_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_members_slack_user_id ON team_members(slack_user_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_audit_log_team ON team_audit_log(team_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_audit_log_created ON team_audit_log(created_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_audit_log_actor ON team_audit_log(actor_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_project_access_project ON project_access(project_path)`,
+	`CREATE TABLE IF NOT EXISTS team_identities (
+		member_id TEXT PRIMARY KEY,
+		subject TEXT NOT NULL,
+		email TEXT,
+		groups TEXT,
+		refresh_token TEXT,
+		verified_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_identities_subject ON team_identities(subject)`,
 }
 
 // CreateTeam creates a new team
@@ -306,10 +369,122 @@ func (s *Store) UpdateMember(member *Member) error {
 
 // RemoveMember removes a member from a team
 func (s *Store) RemoveMember(id string) error {
+	if _, err := s.db.Exec(`DELETE FROM team_identities WHERE member_id = ?`, id); err != nil {
+		return err
+	}
 	_, err := s.db.Exec(`DELETE FROM team_members WHERE id = ?`, id)
 	return err
 }
 
+// PurgedEmail replaces the email of a purged member in the audit log.
+const PurgedEmail = "[purged]"
+
+// PurgeMember erases a person from every team: their memberships are deleted
+// and audit log entries keep the action but lose the email. Returns the
+// removed member IDs, so other stores can purge data keyed by them, and the
+// number of audit log entries anonymized.
+func (s *Store) PurgeMember(email string) ([]string, int64, error) {
+	members, err := s.GetMembersByEmail(email)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`DELETE FROM team_identities WHERE member_id IN (SELECT id FROM team_members WHERE email = ?)`, email); err != nil {
+		return nil, 0, fmt.Errorf("failed to delete identities: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM team_members WHERE email = ?`, email); err != nil {
+		return nil, 0, fmt.Errorf("failed to delete memberships: %w", err)
+	}
+	result, err := tx.Exec(`
+		UPDATE team_audit_log SET
+			actor_email = CASE WHEN actor_email = ? THEN ? ELSE actor_email END,
+			details = CASE WHEN `+s.dialect.Position("COALESCE(details, '')", "?")+` > 0 THEN NULL ELSE details END
+		WHERE actor_email = ? OR `+s.dialect.Position("COALESCE(details, '')", "?")+` > 0
+	`, email, PurgedEmail, email, email, email)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to anonymize audit log: %w", err)
+	}
+	anonymized, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+	return ids, anonymized, nil
+}
+
+// SaveIdentity creates or replaces a member's verified identity
+func (s *Store) SaveIdentity(identity *Identity) error {
+	groups, _ := json.Marshal(identity.Groups)
+	_, err := s.db.Exec(`
+		INSERT INTO team_identities (member_id, subject, email, groups, refresh_token, verified_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(member_id) DO UPDATE SET
+			subject = excluded.subject,
+			email = excluded.email,
+			groups = excluded.groups,
+			refresh_token = excluded.refresh_token,
+			verified_at = excluded.verified_at
+	`, identity.MemberID, identity.Subject, identity.Email, string(groups), identity.RefreshToken, identity.VerifiedAt)
+	return err
+}
+
+// GetIdentity retrieves a member's identity, or nil if they never logged in
+func (s *Store) GetIdentity(memberID string) (*Identity, error) {
+	var identity Identity
+	var email, groups, refreshToken sql.NullString
+	err := s.db.QueryRow(`
+		SELECT member_id, subject, email, groups, refresh_token, verified_at
+		FROM team_identities WHERE member_id = ?
+	`, memberID).Scan(&identity.MemberID, &identity.Subject, &email, &groups, &refreshToken, &identity.VerifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	identity.Email = email.String
+	identity.RefreshToken = refreshToken.String
+	if groups.Valid && groups.String != "" {
+		_ = json.Unmarshal([]byte(groups.String), &identity.Groups)
+	}
+	return &identity, nil
+}
+
+// GetMembersBySubject retrieves the members linked to an IdP subject
+func (s *Store) GetMembersBySubject(subject string) ([]*Member, error) {
+	rows, err := s.db.Query(`SELECT `+memberColumns+` FROM team_members WHERE id IN (SELECT member_id FROM team_identities WHERE subject = ?)`, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return s.scanMembers(rows)
+}
+
+// DeleteIdentity forgets a member's identity, so they must log in again
+func (s *Store) DeleteIdentity(memberID string) error {
+	_, err := s.db.Exec(`DELETE FROM team_identities WHERE member_id = ?`, memberID)
+	return err
+}
+
+// PruneAuditLog deletes audit log entries created before the cutoff.
+func (s *Store) PruneAuditLog(before time.Time) (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM team_audit_log WHERE created_at < ?`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // CountMembersByRole counts members by role in a team
 func (s *Store) CountMembersByRole(teamID string, role Role) (int, error) {
 	var count int
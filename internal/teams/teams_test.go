@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/alekspetrov/pilot/internal/memory"
 	_ "modernc.org/sqlite"
 )
 
@@ -807,6 +809,66 @@ func TestStore_RemoveMember(t *testing.T) {
 	}
 }
 
+func TestStore_PurgeMember(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store, err := NewStore(db)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	team1, owner := NewTeam("Team 1", "owner@example.com")
+	team2, owner2 := NewTeam("Team 2", "owner2@example.com")
+	_ = store.CreateTeam(team1)
+	_ = store.CreateTeam(team2)
+	_ = store.AddMember(owner)
+	_ = store.AddMember(owner2)
+	member1 := NewMember(team1.ID, "leaver@example.com", RoleDeveloper, owner.ID)
+	member2 := NewMember(team2.ID, "leaver@example.com", RoleViewer, owner2.ID)
+	_ = store.AddMember(member1)
+	_ = store.AddMember(member2)
+
+	entries := []*AuditEntry{
+		{ID: "audit-1", TeamID: team1.ID, ActorID: owner.ID, ActorEmail: owner.Email, Action: AuditMemberAdded, Resource: "member", Details: map[string]interface{}{"email": "leaver@example.com"}},
+		{ID: "audit-2", TeamID: team1.ID, ActorID: member1.ID, ActorEmail: member1.Email, Action: AuditTaskCreated, Resource: "task"},
+		{ID: "audit-3", TeamID: team1.ID, ActorID: owner.ID, ActorEmail: owner.Email, Action: AuditTeamUpdated, Resource: "team", Details: map[string]interface{}{"name": "Team 1"}},
+	}
+	for _, e := range entries {
+		e.CreatedAt = owner.JoinedAt
+		_ = store.AddAuditEntry(e)
+	}
+
+	ids, anonymized, err := store.PurgeMember("leaver@example.com")
+	if err != nil {
+		t.Fatalf("PurgeMember failed: %v", err)
+	}
+	if len(ids) != 2 || anonymized != 2 {
+		t.Errorf("PurgeMember = %v, %d anonymized; want 2 IDs and 2 entries", ids, anonymized)
+	}
+	if members, _ := store.GetMembersByEmail("leaver@example.com"); len(members) != 0 {
+		t.Errorf("got %d memberships after purge, want 0", len(members))
+	}
+
+	log, _ := store.GetAuditLog(team1.ID, 10)
+	for _, e := range log {
+		switch e.ID {
+		case "audit-1":
+			if e.ActorEmail != owner.Email || e.Details != nil {
+				t.Errorf("audit-1 = %q %v, want owner kept and details dropped", e.ActorEmail, e.Details)
+			}
+		case "audit-2":
+			if e.ActorEmail != PurgedEmail {
+				t.Errorf("audit-2 actor = %q, want %q", e.ActorEmail, PurgedEmail)
+			}
+		case "audit-3":
+			if e.ActorEmail != owner.Email || e.Details["name"] != "Team 1" {
+				t.Errorf("audit-3 changed: %q %v", e.ActorEmail, e.Details)
+			}
+		}
+	}
+}
+
 func TestStore_CountMembersByRole(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -2646,3 +2708,97 @@ func TestService_GetMemberByGitHubUser(t *testing.T) {
 		t.Errorf("expected nil for unknown user, got %q", got.ID)
 	}
 }
+
+func TestService_UpdateMemberIdentity(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store, _ := NewStore(db)
+	service := NewService(store)
+
+	team, owner, _ := service.CreateTeam("Test Team", "owner@example.com")
+	dev, _ := service.AddMember(team.ID, owner.ID, "dev@example.com", RoleDeveloper, nil)
+	other, _ := service.AddMember(team.ID, owner.ID, "other@example.com", RoleDeveloper, nil)
+
+	// Members can link their own accounts
+	if err := service.UpdateMemberIdentity(team.ID, dev.ID, dev.ID, "dev-gh", 12345, ""); err != nil {
+		t.Fatalf("self link failed: %v", err)
+	}
+	// Owners can link others; empty values keep the existing mapping
+	if err := service.UpdateMemberIdentity(team.ID, owner.ID, dev.ID, "", 0, "U123"); err != nil {
+		t.Fatalf("owner link failed: %v", err)
+	}
+	// Developers cannot link others
+	if err := service.UpdateMemberIdentity(team.ID, other.ID, dev.ID, "hijack", 0, ""); err != ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	got, _ := service.GetMember(dev.ID)
+	if got.GitHubUser != "dev-gh" || got.TelegramID != 12345 || got.SlackUserID != "U123" {
+		t.Errorf("identity = %q/%d/%q, want dev-gh/12345/U123", got.GitHubUser, got.TelegramID, got.SlackUserID)
+	}
+}
+
+// TestStore_Postgres runs the store against the PostgreSQL server in
+// PILOT_TEST_POSTGRES_DSN
+func TestStore_Postgres(t *testing.T) {
+	dsn := os.Getenv("PILOT_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("PILOT_TEST_POSTGRES_DSN not set")
+	}
+	if err := memory.InitBackend(memory.BackendConfig{Driver: memory.DriverPostgres, DSN: dsn}); err != nil {
+		t.Fatalf("InitBackend failed: %v", err)
+	}
+	t.Cleanup(func() { _ = memory.InitBackend(memory.BackendConfig{}) })
+
+	mem, err := memory.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("memory.NewStore failed: %v", err)
+	}
+	defer func() { _ = mem.Close() }()
+
+	// A second store runs the same migrations against the migrated schema
+	if _, err := NewStore(mem.DB()); err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	store, err := NewStore(mem.DB())
+	if err != nil {
+		t.Fatalf("second NewStore failed: %v", err)
+	}
+
+	suffix := time.Now().Format("20060102150405.000000000")
+	leaver := "leaver-" + suffix + "@example.com"
+	team, owner := NewTeam("Team "+suffix, "owner-"+suffix+"@example.com")
+	if err := store.CreateTeam(team); err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+	_ = store.AddMember(owner)
+	member := NewMember(team.ID, leaver, RoleDeveloper, owner.ID)
+	if err := store.AddMember(member); err != nil {
+		t.Fatalf("AddMember failed: %v", err)
+	}
+	entry := &AuditEntry{ID: "audit-" + suffix, TeamID: team.ID, ActorID: owner.ID, ActorEmail: owner.Email,
+		Action: AuditMemberAdded, Resource: "member", Details: map[string]interface{}{"email": leaver}, CreatedAt: time.Now()}
+	if err := store.AddAuditEntry(entry); err != nil {
+		t.Fatalf("AddAuditEntry failed: %v", err)
+	}
+
+	// A second login replaces the identity
+	for _, token := range []string{"first-token", "second-token"} {
+		identity := &Identity{MemberID: owner.ID, Subject: "sub-" + suffix, Email: owner.Email, RefreshToken: token, VerifiedAt: time.Now()}
+		if err := store.SaveIdentity(identity); err != nil {
+			t.Fatalf("SaveIdentity failed: %v", err)
+		}
+	}
+	if identity, err := store.GetIdentity(owner.ID); err != nil || identity == nil || identity.RefreshToken != "second-token" {
+		t.Errorf("GetIdentity = %+v, %v; want the second login", identity, err)
+	}
+
+	ids, anonymized, err := store.PurgeMember(leaver)
+	if err != nil {
+		t.Fatalf("PurgeMember failed: %v", err)
+	}
+	if len(ids) != 1 || anonymized != 1 {
+		t.Errorf("PurgeMember = %v, %d anonymized; want 1 ID and 1 entry", ids, anonymized)
+	}
+}
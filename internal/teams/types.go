@@ -37,6 +37,18 @@ type Member struct {
 	InvitedBy   string    `json:"invited_by,omitempty" yaml:"invited_by,omitempty"`
 }
 
+// Identity is a member's verified identity at the OIDC identity provider.
+// It is cached so chat and GitHub requests don't need a fresh login; once
+// it is older than the cache TTL it is refreshed with RefreshToken.
+type Identity struct {
+	MemberID     string    `json:"member_id"`
+	Subject      string    `json:"subject"` // IdP subject (sub claim)
+	Email        string    `json:"email"`
+	Groups       []string  `json:"groups,omitempty"`
+	RefreshToken string    `json:"-"`
+	VerifiedAt   time.Time `json:"verified_at"`
+}
+
 // Role defines permission levels within a team
 type Role string
 
@@ -90,4 +90,22 @@ const (
 
 	// FakePlaneWebhookSecret is a safe test webhook secret for Plane.so.
 	FakePlaneWebhookSecret = "test-plane-webhook-secret"
+
+	// FakeMattermostToken is a safe test bot access token for Mattermost.
+	FakeMattermostToken = "test-mattermost-token"
+
+	// FakeNotionAPIKey is a safe test integration secret for Notion.
+	FakeNotionAPIKey = "test-notion-api-key"
+
+	// FakeTrelloAPIKey is a safe test API key for Trello.
+	FakeTrelloAPIKey = "test-trello-api-key"
+
+	// FakeTrelloToken is a safe test user token for Trello.
+	FakeTrelloToken = "test-trello-token"
+
+	// FakeClickUpToken is a safe test personal API token for ClickUp.
+	FakeClickUpToken = "pk_test_clickup_token"
+
+	// FakeGiteaToken is a safe test access token for Gitea/Forgejo.
+	FakeGiteaToken = "gitea_test_token_abc123"
 )